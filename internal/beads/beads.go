@@ -636,6 +636,13 @@ func (b *Beads) AddDependency(issue, dependsOn string) error {
 	return err
 }
 
+// AddRelated links issue to related with a non-blocking "related" dependency,
+// so the link never affects whether either issue is ready.
+func (b *Beads) AddRelated(issue, related string) error {
+	_, err := b.run("dep", "add", issue, related, "--type=related")
+	return err
+}
+
 // RemoveDependency removes a dependency.
 func (b *Beads) RemoveDependency(issue, dependsOn string) error {
 	_, err := b.run("dep", "remove", issue, dependsOn)
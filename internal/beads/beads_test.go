@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 )
 
 // TestNew verifies the constructor.
@@ -620,6 +621,57 @@ rig: gastown`
 	}
 }
 
+func TestMRFieldsSkippedAt(t *testing.T) {
+	now := time.Date(2026, 3, 7, 12, 0, 0, 0, time.UTC)
+	fields := ParseMRFields(&Issue{Description: "branch: polecat/Nux/gt-xyz\nskip_until: 2026-03-07T13:00:00Z"})
+	if fields == nil || fields.SkipUntil != "2026-03-07T13:00:00Z" {
+		t.Fatalf("skip_until not parsed: %+v", fields)
+	}
+	if !strings.Contains(FormatMRFields(fields), "skip_until: 2026-03-07T13:00:00Z") {
+		t.Error("skip_until not formatted")
+	}
+
+	if !fields.SkippedAt(now) {
+		t.Error("expected MR to be skipped before skip_until")
+	}
+	if fields.SkippedAt(now.Add(2 * time.Hour)) {
+		t.Error("expected skip to expire after skip_until")
+	}
+
+	fields.SkipUntil = "tomorrow"
+	if fields.SkippedAt(now) {
+		t.Error("unparseable skip_until should be ignored")
+	}
+	var none *MRFields
+	if none.SkippedAt(now) {
+		t.Error("nil fields should not be skipped")
+	}
+}
+
+func TestMRFieldsReverts(t *testing.T) {
+	issue := &Issue{Description: "branch: revert/abc1234\nreverts: abc1234def"}
+	fields := ParseMRFields(issue)
+	if fields == nil || fields.Reverts != "abc1234def" {
+		t.Fatalf("reverts not parsed: %+v", fields)
+	}
+	fields.Reverts = "fedcba9"
+	if desc := SetMRFields(issue, fields); !strings.Contains(desc, "reverts: fedcba9") || strings.Contains(desc, "abc1234def") {
+		t.Errorf("SetMRFields() = %q, want reverts replaced", desc)
+	}
+}
+
+func TestMRFieldsStackedOn(t *testing.T) {
+	issue := &Issue{Description: "branch: polecat/nux/b\nstacked_on: gt-mr-a"}
+	fields := ParseMRFields(issue)
+	if fields == nil || fields.StackedOn != "gt-mr-a" {
+		t.Fatalf("stacked_on not parsed: %+v", fields)
+	}
+	fields.StackedOn = "gt-mr-z"
+	if desc := SetMRFields(issue, fields); !strings.Contains(desc, "stacked_on: gt-mr-z") || strings.Contains(desc, "gt-mr-a") {
+		t.Errorf("SetMRFields() = %q, want stacked_on replaced", desc)
+	}
+}
+
 // TestSetMRFieldsPreservesURL tests that URLs in prose are preserved.
 func TestSetMRFieldsPreservesURL(t *testing.T) {
 	// URLs contain colons which could be confused with key: value
@@ -649,6 +701,33 @@ Also see http://localhost:8080/api`,
 }
 
 // TestParseAttachmentFields tests parsing attachment fields from issue descriptions.
+func TestParseWatchers(t *testing.T) {
+	tests := []struct {
+		name  string
+		issue *Issue
+		want  string
+	}{
+		{"nil issue", nil, ""},
+		{"no watchers", &Issue{Description: "Just text\nassignee: gastown/nux"}, ""},
+		{"comma separated", &Issue{Description: "watchers: mayor/, gastown/crew/max"}, "mayor/|gastown/crew/max"},
+		{"space separated", &Issue{Description: "Watchers: overseer gastown/witness"}, "overseer|gastown/witness"},
+		{
+			name:  "cc lines merged and deduped",
+			issue: &Issue{Description: "Epic for auth.\n\nwatchers: overseer\ncc: mayor/, overseer"},
+			want:  "overseer|mayor/",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := strings.Join(ParseWatchers(tt.issue), "|")
+			if got != tt.want {
+				t.Errorf("ParseWatchers() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestParseAttachmentFields(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -727,6 +806,17 @@ ATTACHED_AT: 2025-12-21T14:00:00Z`,
 				AttachedAt:       "2025-12-21T14:00:00Z",
 			},
 		},
+		{
+			name: "work log link",
+			issue: &Issue{
+				Description: `dispatched_by: mayor/
+work_log: .runtime/worklogs/gt-abc.json`,
+			},
+			wantFields: &AttachmentFields{
+				DispatchedBy: "mayor/",
+				WorkLog:      ".runtime/worklogs/gt-abc.json",
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -750,6 +840,9 @@ ATTACHED_AT: 2025-12-21T14:00:00Z`,
 			if fields.AttachedAt != tt.wantFields.AttachedAt {
 				t.Errorf("AttachedAt = %q, want %q", fields.AttachedAt, tt.wantFields.AttachedAt)
 			}
+			if fields.WorkLog != tt.wantFields.WorkLog {
+				t.Errorf("WorkLog = %q, want %q", fields.WorkLog, tt.wantFields.WorkLog)
+			}
 		})
 	}
 }
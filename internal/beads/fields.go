@@ -4,6 +4,7 @@ package beads
 import (
 	"fmt"
 	"strings"
+	"time"
 )
 
 // Note: AgentFields, ParseAgentFields, FormatAgentDescription, and CreateAgentBead are in beads.go
@@ -21,6 +22,7 @@ type AttachmentFields struct {
 	AttachedAt       string // ISO 8601 timestamp when attached
 	AttachedArgs     string // Natural language args passed via gt sling --args (no-tmux mode)
 	DispatchedBy     string // Agent ID that dispatched this work (for completion notification)
+	WorkLog          string // Town-relative path to the polecat work log for this bead
 }
 
 // ParseAttachmentFields extracts attachment fields from an issue's description.
@@ -65,6 +67,9 @@ func ParseAttachmentFields(issue *Issue) *AttachmentFields {
 		case "dispatched_by", "dispatched-by", "dispatchedby":
 			fields.DispatchedBy = value
 			hasFields = true
+		case "work_log", "work-log", "worklog":
+			fields.WorkLog = value
+			hasFields = true
 		}
 	}
 
@@ -95,6 +100,9 @@ func FormatAttachmentFields(fields *AttachmentFields) string {
 	if fields.DispatchedBy != "" {
 		lines = append(lines, "dispatched_by: "+fields.DispatchedBy)
 	}
+	if fields.WorkLog != "" {
+		lines = append(lines, "work_log: "+fields.WorkLog)
+	}
 
 	return strings.Join(lines, "\n")
 }
@@ -117,6 +125,9 @@ func SetAttachmentFields(issue *Issue, fields *AttachmentFields) string {
 		"dispatched_by":     true,
 		"dispatched-by":     true,
 		"dispatchedby":      true,
+		"work_log":          true,
+		"work-log":          true,
+		"worklog":           true,
 	}
 
 	// Collect non-attachment lines from existing description
@@ -167,6 +178,41 @@ func SetAttachmentFields(issue *Issue, fields *AttachmentFields) string {
 	return formatted + "\n\n" + strings.Join(otherLines, "\n")
 }
 
+// ParseWatchers extracts the mail addresses watching an issue, listed on a
+// "watchers:" (or "cc:") line as comma- or space-separated addresses.
+// Returns nil if no watchers are listed.
+func ParseWatchers(issue *Issue) []string {
+	if issue == nil || issue.Description == "" {
+		return nil
+	}
+
+	var watchers []string
+	seen := make(map[string]bool)
+	for _, line := range strings.Split(issue.Description, "\n") {
+		line = strings.TrimSpace(line)
+		colonIdx := strings.Index(line, ":")
+		if colonIdx == -1 {
+			continue
+		}
+
+		switch strings.ToLower(strings.TrimSpace(line[:colonIdx])) {
+		case "watchers", "watcher", "cc":
+		default:
+			continue
+		}
+
+		for _, addr := range strings.FieldsFunc(line[colonIdx+1:], func(r rune) bool {
+			return r == ',' || r == ' ' || r == '\t'
+		}) {
+			if !seen[addr] {
+				seen[addr] = true
+				watchers = append(watchers, addr)
+			}
+		}
+	}
+	return watchers
+}
+
 // MRFields holds the structured fields for a merge-request issue.
 // These fields are stored as key: value lines in the issue description.
 type MRFields struct {
@@ -187,6 +233,21 @@ type MRFields struct {
 	// Convoy tracking (for priority scoring - convoy starvation prevention)
 	ConvoyID        string // Parent convoy ID if part of a convoy
 	ConvoyCreatedAt string // Convoy creation time (ISO 8601) for starvation prevention
+
+	// Risk gate (see refinery risk_approval_threshold)
+	RiskApprovedBy string // Who approved merging despite a high risk score
+
+	// Operator pause (gt mq skip)
+	SkipUntil string // Leave the MR out of the ready queue until this time (RFC 3339)
+
+	// Revert (gt mq revert)
+	Reverts string // Merge commit this MR's branch reverts
+
+	// Conflict report (refinery)
+	ConflictReport string // Path of the conflict-report.md for the last conflict
+
+	// Stacks (gt mq submit --stacked-on)
+	StackedOn string // MR whose branch this MR's branch builds on
 }
 
 // ParseMRFields extracts structured merge-request fields from an issue's description.
@@ -261,6 +322,21 @@ func ParseMRFields(issue *Issue) *MRFields {
 		case "convoy_created_at", "convoy-created-at", "convoycreatedat":
 			fields.ConvoyCreatedAt = value
 			hasFields = true
+		case "risk_approved_by", "risk-approved-by", "riskapprovedby":
+			fields.RiskApprovedBy = value
+			hasFields = true
+		case "skip_until", "skip-until", "skipuntil":
+			fields.SkipUntil = value
+			hasFields = true
+		case "reverts":
+			fields.Reverts = value
+			hasFields = true
+		case "conflict_report", "conflict-report", "conflictreport":
+			fields.ConflictReport = value
+			hasFields = true
+		case "stacked_on", "stacked-on", "stackedon":
+			fields.StackedOn = value
+			hasFields = true
 		}
 	}
 
@@ -270,6 +346,16 @@ func ParseMRFields(issue *Issue) *MRFields {
 	return fields
 }
 
+// SkippedAt reports whether an operator skip (gt mq skip) is still in
+// effect at now. An unparseable skip_until is ignored.
+func (f *MRFields) SkippedAt(now time.Time) bool {
+	if f == nil || f.SkipUntil == "" {
+		return false
+	}
+	until, err := time.Parse(time.RFC3339, f.SkipUntil)
+	return err == nil && now.Before(until)
+}
+
 // parseIntField parses an integer from a string, returning 0 on error.
 func parseIntField(s string) (int, error) {
 	var n int
@@ -325,6 +411,21 @@ func FormatMRFields(fields *MRFields) string {
 	if fields.ConvoyCreatedAt != "" {
 		lines = append(lines, "convoy_created_at: "+fields.ConvoyCreatedAt)
 	}
+	if fields.RiskApprovedBy != "" {
+		lines = append(lines, "risk_approved_by: "+fields.RiskApprovedBy)
+	}
+	if fields.SkipUntil != "" {
+		lines = append(lines, "skip_until: "+fields.SkipUntil)
+	}
+	if fields.Reverts != "" {
+		lines = append(lines, "reverts: "+fields.Reverts)
+	}
+	if fields.ConflictReport != "" {
+		lines = append(lines, "conflict_report: "+fields.ConflictReport)
+	}
+	if fields.StackedOn != "" {
+		lines = append(lines, "stacked_on: "+fields.StackedOn)
+	}
 
 	return strings.Join(lines, "\n")
 }
@@ -371,6 +472,19 @@ func SetMRFields(issue *Issue, fields *MRFields) string {
 		"convoy_created_at":  true,
 		"convoy-created-at":  true,
 		"convoycreatedat":    true,
+		"risk_approved_by":   true,
+		"risk-approved-by":   true,
+		"riskapprovedby":     true,
+		"skip_until":         true,
+		"skip-until":         true,
+		"skipuntil":          true,
+		"reverts":            true,
+		"conflict_report":    true,
+		"conflict-report":    true,
+		"conflictreport":     true,
+		"stacked_on":         true,
+		"stacked-on":         true,
+		"stackedon":          true,
 	}
 
 	// Collect non-MR lines from existing description
@@ -6,9 +6,12 @@ import (
 	"os"
 	"os/exec"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/polecat"
 	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
 )
 
 var beadCmd = &cobra.Command{
@@ -57,10 +60,31 @@ Examples:
 	},
 }
 
+var beadWorklogCmd = &cobra.Command{
+	Use:   "worklog <bead-id>",
+	Short: "Show the polecat work sessions recorded for a bead",
+	Long: `Shows the audit trail of polecat sessions that worked on a bead.
+
+A session is opened when the bead is slung to a polecat and closed when the
+polecat runs 'gt done'. Each session records the agent, start/end times,
+branch, exit status, MR, and the files touched on the branch, so reviewers
+can reconstruct how a change was produced.
+
+Examples:
+  gt bead worklog gt-abc123          # Show sessions for gt-abc123
+  gt bead worklog gt-abc123 --json   # Output as JSON`,
+	Args: cobra.ExactArgs(1),
+	RunE: runBeadWorklog,
+}
+
+var beadWorklogJSON bool
+
 func init() {
 	beadMoveCmd.Flags().BoolVarP(&beadMoveDryRun, "dry-run", "n", false, "Show what would be done")
+	beadWorklogCmd.Flags().BoolVar(&beadWorklogJSON, "json", false, "Output as JSON")
 	beadCmd.AddCommand(beadMoveCmd)
 	beadCmd.AddCommand(beadShowCmd)
+	beadCmd.AddCommand(beadWorklogCmd)
 	rootCmd.AddCommand(beadCmd)
 }
 
@@ -165,3 +189,67 @@ func runBeadMove(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+func runBeadWorklog(cmd *cobra.Command, args []string) error {
+	beadID := args[0]
+
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	log, err := polecat.LoadWorkLog(townRoot, beadID)
+	if err != nil {
+		return err
+	}
+
+	if beadWorklogJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(log)
+	}
+
+	if len(log.Sessions) == 0 {
+		fmt.Printf("%s No work sessions recorded for %s\n", style.Dim.Render("○"), beadID)
+		return nil
+	}
+
+	fmt.Printf("%s Work log for %s (%d sessions)\n", style.Bold.Render("📜"), beadID, len(log.Sessions))
+	for i, s := range log.Sessions {
+		fmt.Printf("\n%d. %s\n", i+1, style.Bold.Render(s.Agent))
+		if s.StartedAt.IsZero() {
+			fmt.Printf("   Started:  %s\n", style.Dim.Render("(unknown)"))
+		} else {
+			fmt.Printf("   Started:  %s\n", s.StartedAt.Local().Format("2006-01-02 15:04:05"))
+		}
+		if s.EndedAt == nil {
+			fmt.Printf("   Ended:    %s\n", style.Dim.Render("(in progress)"))
+		} else {
+			fmt.Printf("   Ended:    %s", s.EndedAt.Local().Format("2006-01-02 15:04:05"))
+			if d := s.Duration(); d > 0 {
+				fmt.Printf(" (%s)", d.Round(time.Second))
+			}
+			fmt.Println()
+		}
+		if s.SessionID != "" {
+			fmt.Printf("   Session:  %s\n", s.SessionID)
+		}
+		if s.Branch != "" {
+			fmt.Printf("   Branch:   %s\n", s.Branch)
+		}
+		if s.ExitType != "" {
+			fmt.Printf("   Exit:     %s\n", s.ExitType)
+		}
+		if s.MRID != "" {
+			fmt.Printf("   MR:       %s\n", s.MRID)
+		}
+		if len(s.FilesTouched) > 0 {
+			fmt.Printf("   Files touched (%d):\n", len(s.FilesTouched))
+			for _, f := range s.FilesTouched {
+				fmt.Printf("     %s\n", f)
+			}
+		}
+	}
+
+	return nil
+}
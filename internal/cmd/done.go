@@ -13,6 +13,7 @@ import (
 	"github.com/steveyegge/gastown/internal/mail"
 	"github.com/steveyegge/gastown/internal/polecat"
 	"github.com/steveyegge/gastown/internal/rig"
+	"github.com/steveyegge/gastown/internal/runtime"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/tmux"
 	"github.com/steveyegge/gastown/internal/townlog"
@@ -410,6 +411,24 @@ func runDone(cmd *cobra.Command, args []string) error {
 		fmt.Printf("  Branch: %s\n", branch)
 	}
 
+	// Close the work-log session for this bead (audit trail, non-fatal)
+	if issueID != "" {
+		end := polecat.WorkSessionEnd{
+			SessionID: runtime.SessionIDFromEnv(),
+			Branch:    branch,
+			ExitType:  exitType,
+			MRID:      mrID,
+		}
+		if cwdAvailable {
+			if files, err := g.ChangedFiles("origin/"+defaultBranch, "HEAD"); err == nil {
+				end.FilesTouched = files
+			}
+		}
+		if err := polecat.FinishWorkSession(townRoot, issueID, sender, end); err != nil {
+			style.PrintWarning("could not update work log: %v", err)
+		}
+	}
+
 	// Notify Witness about completion
 	// Use town-level beads for cross-agent mail
 	townRouter := mail.NewRouter(townRoot)
@@ -10,7 +10,12 @@ import (
 
 // Inbox command flags
 var (
-	inboxOnce bool // Exit after displaying (no interactive mode)
+	inboxOnce    bool   // Exit after displaying (no interactive mode)
+	inboxRefresh string // Auto-refresh interval override ("off" disables)
+	inboxTime    string // Time display override (relative or absolute)
+	inboxTZ      string // Display timezone override
+	inboxTheme   string // Color theme override
+	inboxNoColor bool   // Render with text attributes only
 )
 
 var inboxCmd = &cobra.Command{
@@ -35,19 +40,76 @@ NAVIGATION:
   g, G         Go to top/bottom
   pgup, pgdn   Page up/down
   L            Learn message type (classification override)
+  S            Summarize message (whole thread in thread view)
+  B            Create a bead from the message (subject, quoted body, references)
+  E            Export the message as markdown to mail-<id>.md (whole thread
+               in thread view, to mail-<thread>.md)
+  R, W         Reply to the sender, or to all recipients (others are CC'd)
+  :            Command palette
   q, Esc       Quit
 
+THREAD VIEW (tab):
+  ↑/k, ↓/j     Select the previous/next message
+  enter        Expand or collapse the selected message's full body
+  pgup, pgdn   Scroll the thread
+  g, G         Jump to the oldest/newest message
+
+The command palette runs any action by name, with fuzzy matching ("mar"
+finds mark-all-read): archive-old, mark-all-read, compose <address>
+[subject], open-bead [bead-id], switch-account <address> (view another
+inbox) and every keyed action. Tab completes the selected name.
+
+The S action runs an agent non-interactively (claude by default). Configure
+it in <town>/config/inbox.json: {"summarizer": {"agent": "gemini"}} or
+{"summarizer": {"command": "my-llm --stdin"}} to read the prompt on stdin.
+
+The inbox refetches messages every 30s. Change this with --refresh or
+{"refresh_interval": "2m"} in the same file; "off" disables auto-refresh
+(useful with slow beads backends) and r reloads manually. The header shows
+when messages were last refreshed.
+
+Message times show as relative ages ("3h") by default. Set {"time":
+{"display": "absolute"}} to show send times instead, "timezone" to an IANA
+name (e.g. "Europe/Berlin", "UTC") for a display zone other than the local
+one, "clock" to "12h" or "24h", and "locale" (e.g. "en-US", "de-DE") for the
+date format; the locale defaults to $LC_TIME/$LANG. The preview and thread
+view always show full timestamps with the zone. --time and --tz override
+the config.
+
+New messages arriving on refresh are counted in the header's NEW badge, and
+alerts also raise a desktop notification (notify-send). Set the level per
+type with {"notify": {"alert": "sound", "proposal": "desktop", "info":
+"none"}}: none, badge, desktop (badge + notification) or sound (desktop +
+terminal bell). "quiet_hours": "22:00-07:00" drops desktop and sound to the
+badge during those hours, in the display timezone.
+
+Colors follow {"theme": "dark"} (the default), "light" for light terminal
+backgrounds or "high-contrast". {"no_color": true}, --no-color, NO_COLOR or
+CLICOLOR=0 turn color off: selection, badges, unread messages and message
+ages are then set apart with bold, faint and reverse video instead. --theme
+overrides the config.
+
 Examples:
   gt inbox                    # Your inbox (auto-detected identity)
   gt inbox mayor/             # Mayor's inbox
   gt inbox gastown/Toast      # Polecat's inbox
-  gt inbox --once             # Show and exit (non-interactive)`,
+  gt inbox --once             # Show and exit (non-interactive)
+  gt inbox --refresh off      # Manual refresh only (r)
+  gt inbox --time absolute --tz UTC
+  gt inbox --theme light`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runInbox,
 }
 
 func init() {
 	inboxCmd.Flags().BoolVar(&inboxOnce, "once", false, "Show inbox and exit (non-interactive)")
+	inboxCmd.Flags().StringVar(&inboxRefresh, "refresh", "", "Auto-refresh interval (e.g. 30s, 2m) or off (default from config/inbox.json, else 30s)")
+
+	inboxCmd.Flags().StringVar(&inboxTime, "time", "", "Time display: relative or absolute (default from config/inbox.json, else relative)")
+	inboxCmd.Flags().StringVar(&inboxTZ, "tz", "", "Display timezone, e.g. Europe/Berlin, UTC, local (default from config/inbox.json)")
+
+	inboxCmd.Flags().StringVar(&inboxTheme, "theme", "", "Color theme: dark, light or high-contrast (default from config/inbox.json, else dark)")
+	inboxCmd.Flags().BoolVar(&inboxNoColor, "no-color", false, "Disable colors (also NO_COLOR)")
 
 	rootCmd.AddCommand(inboxCmd)
 }
@@ -74,6 +136,41 @@ func runInbox(cmd *cobra.Command, args []string) error {
 
 	// Interactive TUI mode
 	m := inbox.New(address, workDir)
+	if inboxRefresh != "" {
+		interval, err := inbox.ParseRefreshInterval(inboxRefresh)
+		if err != nil {
+			return err
+		}
+		m.SetRefreshInterval(interval)
+	}
+	if inboxTime != "" || inboxTZ != "" {
+		f := m.TimeFormat()
+		if inboxTime != "" {
+			if f.Mode, err = inbox.ParseTimeMode(inboxTime); err != nil {
+				return err
+			}
+		}
+		if inboxTZ != "" {
+			if f.Location, err = inbox.ParseTimezone(inboxTZ); err != nil {
+				return err
+			}
+		}
+		m.SetTimeFormat(f)
+	}
+	if inboxTheme != "" || inboxNoColor {
+		theme := m.Theme()
+		if inboxTheme != "" {
+			if theme, err = inbox.ParseTheme(inboxTheme); err != nil {
+				return err
+			}
+			// An explicit theme keeps NO_COLOR and no_color in effect
+			theme.NoColor = m.Theme().NoColor
+		}
+		if inboxNoColor {
+			theme = theme.WithoutColor()
+		}
+		m.SetTheme(theme)
+	}
 	p := tea.NewProgram(m, tea.WithAltScreen())
 	_, err = p.Run()
 	return err
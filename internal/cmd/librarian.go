@@ -1,10 +1,16 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"log"
 	"os"
+	"os/signal"
 	"path/filepath"
 	"strings"
+	"syscall"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/gastown/internal/librarian"
@@ -95,6 +101,19 @@ Skills are YAML files in <town>/librarian/skills/ that define:
 - Triggers: conditions that activate the skill (labels, keywords, patterns)
 - Content: files, patterns, docs, and notes to inject
 
+A rig can add its own skills in <rig>/librarian/skills/. A rig skill with
+the same id as a town skill overrides it; with "extend: true" its triggers
+and content are added to the town skill instead. Each skill is listed with
+its scope (town or rig). When several skills match a bead they are injected
+by priority, then rig before town, then by id.
+
+Skills that have been injected also show how often, and how useful they
+were rated via 'gt librarian feedback', to guide curation.
+
+Triggers miss beads that describe the same work in other words. Build an
+embedding index with 'gt librarian skills index' and skills also match on
+similarity to the bead; see 'gt librarian skills index --help'.
+
 Example skill file (librarian/skills/go-testing.yaml):
   id: go-testing
   name: Go Testing
@@ -111,6 +130,34 @@ Example skill file (librarian/skills/go-testing.yaml):
 	RunE: runLibrarianSkills,
 }
 
+var librarianSkillsIndexCmd = &cobra.Command{
+	Use:   "index",
+	Short: "Build the skill embedding index for similarity matching",
+	Long: `Embed every skill and save the index used for similarity matching.
+
+With an index, a skill matches a bead when one of its triggers fires OR
+when the bead's title and description are similar enough to the skill
+(name, description, keywords, labels, patterns, and notes). Each match is
+scored: half for a trigger match, half for similarity. 'gt librarian
+match' shows the scores.
+
+By default skills are embedded with a built-in hashed bag of words and
+trigrams, which needs no model but only catches shared words and stems.
+For real semantic matching, pass --embed-command: a shell command that
+reads text on stdin and prints its embedding as a JSON array of numbers.
+Beads are embedded with the same command when matched.
+
+The index is saved next to the skills directory (the rig's, inside a rig)
+and picked up by the daemon without a restart. Skills edited after
+indexing match on triggers only until the index is rebuilt.
+
+Examples:
+  gt librarian skills index
+  gt librarian skills index --threshold 0.4
+  gt librarian skills index --embed-command 'embed-text --model nomic-embed-text'`,
+	RunE: runLibrarianSkillsIndex,
+}
+
 var librarianInjectCmd = &cobra.Command{
 	Use:   "inject <bead-id>",
 	Short: "Inject skills into a bead's enrichment",
@@ -119,9 +166,27 @@ var librarianInjectCmd = &cobra.Command{
 This command:
 1. Analyzes the bead's context (labels, title, description)
 2. Matches applicable skills from the skills directory
-3. Generates enrichment content with relevant files, patterns, and docs
-
-Use --preview to see which skills would match without generating enrichment.`,
+3. Searches closed beads and merged MRs for similar prior work
+4. Generates enrichment content with relevant files, patterns, docs, and
+   prior work
+
+Prior work is found by TF-IDF similarity of titles and descriptions, and
+listed with a one-line summary of each match (3 at standard depth, 5 at
+deep; quick skips the search). A merged MR is listed with its work bead
+and merge commit, so earlier partial fixes are easy to find.
+
+Use --preview to see which skills would match without generating enrichment.
+
+Use --snapshot-docs to fetch the documentation pages referenced by matched
+skills and embed them (converted to markdown, size-capped) in the enrichment.
+Snapshots are cached in <town>/librarian/snapshots/ so later enrichments are
+reproducible and work offline; --refresh-snapshots re-fetches them.
+
+Use --target-context to keep the enrichment within a polecat's context
+budget (in tokens, estimated at 4 bytes per token). The least relevant
+content is dropped first: doc snapshots, context notes, docs, prior work,
+then patterns and files from the lowest-priority skills. What was omitted
+is noted in the enrichment and reported.`,
 	Args: cobra.ExactArgs(1),
 	RunE: runLibrarianInject,
 }
@@ -131,16 +196,82 @@ var librarianMatchCmd = &cobra.Command{
 	Short: "Preview which skills match a bead",
 	Long: `Preview skills that would be injected for a bead without generating enrichment.
 
-Shows the bead context and lists all matching skills with their triggers.`,
+Shows the bead context and lists all matching skills with their triggers.
+With a skill embedding index (gt librarian skills index), each match also
+shows its score and similarity, and skills matched on similarity alone are
+marked as such.`,
 	Args: cobra.ExactArgs(1),
 	RunE: runLibrarianMatch,
 }
 
+var librarianFeedbackCmd = &cobra.Command{
+	Use:   "feedback <bead-id>",
+	Short: "Rate a bead's enrichment",
+	Long: `Rate how helpful a bead's enrichment was.
+
+The rating is credited to the skills that were injected into the bead's last
+enrichment (recorded by 'gt librarian inject'), and shows up as per-skill
+usefulness in 'gt librarian skills'. Use --skill to credit specific skills
+instead, e.g. for enrichments written by hand.
+
+Examples:
+  gt librarian feedback gt-abc12 --useful
+  gt librarian feedback gt-abc12 --noisy --note "docs were for the v1 API"
+  gt librarian feedback gt-abc12 --noisy --skill go-testing`,
+	Args: cobra.ExactArgs(1),
+	RunE: runLibrarianFeedback,
+}
+
+var (
+	feedbackUseful bool
+	feedbackNoisy  bool
+	feedbackNote   string
+	feedbackSkills []string
+)
+
+var (
+	daemonDepth         string
+	daemonInterval      time.Duration
+	daemonSkillInterval time.Duration
+	daemonTargetContext int
+)
+
+var (
+	skillsIndexEmbedCommand string
+	skillsIndexThreshold    float64
+)
+
 var (
-	injectDepth   string
-	injectPreview bool
+	injectDepth            string
+	injectPreview          bool
+	injectSnapshotDocs     bool
+	injectRefreshSnapshots bool
+	injectTargetContext    int
 )
 
+var librarianDaemonCmd = &cobra.Command{
+	Use:   "daemon",
+	Short: "Enrich new beads with skills, hot-reloading skills as they change",
+	Long: `Run the Librarian's skill injection as a foreground daemon.
+
+The daemon injects matching skills into open, unassigned beads that have not
+been enriched yet, writing each enrichment to <town>/librarian/.enrichments/
+and recording it for 'gt librarian feedback'. Each enrichment is logged with
+the skill versions it used (a short hash of the skill file).
+
+The town and rig skills directories are watched while the daemon runs: added and changed
+skills are validated and activated without a restart, and removed skills
+are deactivated. A skill that fails validation is logged and rejected; if
+it replaced a valid version, that version stays active. Beads that matched
+no skill and have no prior work are retried when a skill is added or
+changed.
+
+Examples:
+  gt librarian daemon
+  gt librarian daemon --depth deep --interval 5m`,
+	RunE: runLibrarianDaemon,
+}
+
 // Enrich/Review/Summarize commands (from polecat branch)
 var librarianEnrichCmd = &cobra.Command{
 	Use:   "enrich <bead-id>",
@@ -183,8 +314,11 @@ func init() {
 	librarianCmd.AddCommand(librarianRestartCmd)
 	// Skills commands
 	librarianCmd.AddCommand(librarianSkillsCmd)
+	librarianSkillsCmd.AddCommand(librarianSkillsIndexCmd)
 	librarianCmd.AddCommand(librarianInjectCmd)
 	librarianCmd.AddCommand(librarianMatchCmd)
+	librarianCmd.AddCommand(librarianFeedbackCmd)
+	librarianCmd.AddCommand(librarianDaemonCmd)
 	// Enrich/Review/Summarize commands
 	librarianCmd.AddCommand(librarianEnrichCmd)
 	librarianCmd.AddCommand(librarianReviewCmd)
@@ -194,8 +328,26 @@ func init() {
 	librarianAttachCmd.Flags().StringVar(&librarianAgentOverride, "agent", "", "Agent alias to use (default: gemini)")
 	librarianRestartCmd.Flags().StringVar(&librarianAgentOverride, "agent", "", "Agent alias to use (default: gemini)")
 
+	librarianSkillsIndexCmd.Flags().StringVar(&skillsIndexEmbedCommand, "embed-command", "", "Shell command embedding stdin text as a JSON array (default: built-in hashed embeddings)")
+	librarianSkillsIndexCmd.Flags().Float64Var(&skillsIndexThreshold, "threshold", 0, "Similarity a skill needs to match without a trigger (default: 0.3 built-in, 0.5 with --embed-command)")
+
 	librarianInjectCmd.Flags().StringVar(&injectDepth, "depth", "standard", "Enrichment depth: quick, standard, or deep")
 	librarianInjectCmd.Flags().BoolVar(&injectPreview, "preview", false, "Preview matches without generating enrichment")
+	librarianInjectCmd.Flags().BoolVar(&injectSnapshotDocs, "snapshot-docs", false, "Fetch and embed referenced documentation as markdown")
+	librarianInjectCmd.Flags().BoolVar(&injectRefreshSnapshots, "refresh-snapshots", false, "Re-fetch cached documentation snapshots (implies --snapshot-docs)")
+	librarianInjectCmd.Flags().IntVar(&injectTargetContext, "target-context", 0, "Fit the enrichment to this many tokens of model context (0 = no limit)")
+
+	librarianDaemonCmd.Flags().StringVar(&daemonDepth, "depth", "standard", "Enrichment depth: quick, standard, or deep")
+	librarianDaemonCmd.Flags().DurationVar(&daemonInterval, "interval", time.Minute, "How often to check for beads to enrich")
+	librarianDaemonCmd.Flags().DurationVar(&daemonSkillInterval, "skill-interval", 5*time.Second, "How often to check the skills directory for changes")
+	librarianDaemonCmd.Flags().IntVar(&daemonTargetContext, "target-context", 0, "Fit enrichments to this many tokens of model context (0 = no limit)")
+
+	librarianFeedbackCmd.Flags().BoolVar(&feedbackUseful, "useful", false, "The enrichment helped")
+	librarianFeedbackCmd.Flags().BoolVar(&feedbackNoisy, "noisy", false, "The enrichment was noise")
+	librarianFeedbackCmd.Flags().StringVar(&feedbackNote, "note", "", "What helped or what was noise")
+	librarianFeedbackCmd.Flags().StringSliceVar(&feedbackSkills, "skill", nil, "Skill ID to credit (repeatable; default: skills from the last injection)")
+	librarianFeedbackCmd.MarkFlagsMutuallyExclusive("useful", "noisy")
+	librarianFeedbackCmd.MarkFlagsOneRequired("useful", "noisy")
 
 	rootCmd.AddCommand(librarianCmd)
 }
@@ -347,6 +499,7 @@ func runLibrarianSkills(cmd *cobra.Command, args []string) error {
 	}
 
 	injector := librarian.NewInjector(townRoot, rigRoot)
+	injector.SetRigPath(librarianRigPath(townRoot))
 	skills, err := injector.ListSkills()
 	if err != nil {
 		return err
@@ -370,10 +523,16 @@ func runLibrarianSkills(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	stats, err := librarian.NewFeedbackStore(townRoot).SkillStats()
+	if err != nil {
+		// Non-fatal: the listing is still useful without feedback stats
+		fmt.Fprintf(os.Stderr, "Warning: reading skill feedback: %v\n", err)
+	}
+
 	fmt.Printf("%s %d skills available\n\n", style.Bold.Render("●"), len(skills))
 
 	for _, skill := range skills {
-		fmt.Printf("  %s %s\n", style.Bold.Render(skill.ID), style.Dim.Render(fmt.Sprintf("(%s)", skill.Name)))
+		fmt.Printf("  %s %s %s\n", style.Bold.Render(skill.ID), style.Dim.Render(fmt.Sprintf("(%s)", skill.Name)), formatSkillScope(skill))
 		if skill.Description != "" {
 			fmt.Printf("    %s\n", skill.Description)
 		}
@@ -389,13 +548,107 @@ func runLibrarianSkills(cmd *cobra.Command, args []string) error {
 		if contentSummary != "" {
 			fmt.Printf("    %s %s\n", style.Dim.Render("Content:"), contentSummary)
 		}
+
+		// Show feedback
+		if st := stats[skill.ID]; st != nil {
+			fmt.Printf("    %s %s\n", style.Dim.Render("Feedback:"), formatSkillFeedback(st))
+			for _, note := range st.Notes {
+				fmt.Printf("      %s\n", style.Dim.Render("“"+note+"”"))
+			}
+		}
 		fmt.Println()
 	}
 
 	fmt.Printf("Skills directory: %s\n", style.Dim.Render(skillsDir))
+	if rigSkillsDir := injector.GetRigSkillsDir(); rigSkillsDir != "" {
+		fmt.Printf("Rig skills directory: %s\n", style.Dim.Render(rigSkillsDir))
+	}
+	printStaleSkills(injector.StaleSkills())
+	return nil
+}
+
+// printStaleSkills warns about skills added or edited since the embedding
+// index was built; they match on triggers only until it is rebuilt.
+func printStaleSkills(stale []string) {
+	if len(stale) == 0 {
+		return
+	}
+	fmt.Printf("\n%s %d skills changed since indexing (%s); rebuild with 'gt librarian skills index'\n",
+		style.WarningPrefix, len(stale), strings.Join(stale, ", "))
+}
+
+func runLibrarianSkillsIndex(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return err
+	}
+	rigRoot, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("getting current directory: %w", err)
+	}
+	if skillsIndexThreshold < 0 || skillsIndexThreshold > 1 {
+		return fmt.Errorf("invalid --threshold %v: must be between 0 and 1", skillsIndexThreshold)
+	}
+
+	injector := librarian.NewInjector(townRoot, rigRoot)
+	injector.SetRigPath(librarianRigPath(townRoot))
+	skills, err := injector.ListSkills()
+	if err != nil {
+		return err
+	}
+	if len(skills) == 0 {
+		fmt.Printf("%s No skills to index\n", style.Dim.Render("○"))
+		return nil
+	}
+
+	var embedder librarian.Embedder = librarian.HashEmbedder{}
+	if skillsIndexEmbedCommand != "" {
+		embedder = librarian.CommandEmbedder{Command: skillsIndexEmbedCommand}
+	}
+	idx, err := librarian.BuildSkillIndex(skills, embedder, skillsIndexThreshold)
+	if err != nil {
+		return err
+	}
+	path := injector.SkillIndexPath()
+	if err := idx.Save(path); err != nil {
+		return fmt.Errorf("saving skill index: %w", err)
+	}
+
+	fmt.Printf("%s Indexed %d skills (%s, similarity threshold %.2f)\n",
+		style.Bold.Render("✓"), len(idx.Entries), idx.Model, idx.Threshold)
+	fmt.Printf("  %s\n", style.Dim.Render(path))
 	return nil
 }
 
+// librarianRigPath returns the rig containing the current directory, whose
+// librarian/skills override town skills, or "" outside a rig.
+func librarianRigPath(townRoot string) string {
+	rigName, err := inferRigFromCwd(townRoot)
+	if err != nil {
+		return ""
+	}
+	_, r, err := getRig(rigName)
+	if err != nil {
+		return "" // e.g. <town>/librarian itself
+	}
+	return r.Path
+}
+
+// formatSkillScope renders a skill's scope, noting rig overrides.
+func formatSkillScope(skill *librarian.Skill) string {
+	if skill.Scope != librarian.ScopeRig {
+		return style.Dim.Render("[town]")
+	}
+	switch {
+	case skill.Shadows == "":
+		return style.Info.Render("[rig]")
+	case skill.Extend:
+		return style.Info.Render("[rig, extends town]")
+	default:
+		return style.Info.Render("[rig, overrides town]")
+	}
+}
+
 func runLibrarianInject(cmd *cobra.Command, args []string) error {
 	beadID := args[0]
 
@@ -409,28 +662,27 @@ func runLibrarianInject(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("getting current directory: %w", err)
 	}
 
-	// Parse depth
-	var depth librarian.EnrichmentDepth
-	switch strings.ToLower(injectDepth) {
-	case "quick":
-		depth = librarian.DepthQuick
-	case "standard":
-		depth = librarian.DepthStandard
-	case "deep":
-		depth = librarian.DepthDeep
-	default:
-		return fmt.Errorf("invalid depth: %s (use quick, standard, or deep)", injectDepth)
+	depth, err := parseEnrichmentDepth(injectDepth)
+	if err != nil {
+		return err
 	}
 
 	injector := librarian.NewInjector(townRoot, rigRoot)
+	injector.SetRigPath(librarianRigPath(townRoot))
+	injector.SetTargetContext(injectTargetContext)
+	if injectSnapshotDocs || injectRefreshSnapshots {
+		snapshotter := librarian.NewDocSnapshotter(townRoot)
+		snapshotter.Refresh = injectRefreshSnapshots
+		injector.SetDocSnapshotter(snapshotter)
+	}
 
 	// Preview mode
 	if injectPreview {
-		skills, ctx, err := injector.PreviewMatches(beadID)
+		matches, ctx, err := injector.PreviewMatches(beadID)
 		if err != nil {
 			return err
 		}
-		printMatchPreview(ctx, skills)
+		printMatchPreview(ctx, matches)
 		return nil
 	}
 
@@ -440,15 +692,36 @@ func runLibrarianInject(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	// Remember which skills contributed so feedback can be credited to them
+	if len(result.MatchedSkills) > 0 {
+		if err := librarian.NewFeedbackStore(townRoot).RecordInjection(beadID, depth, result.MatchedSkills); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: recording injection: %v\n", err)
+		}
+	}
+
 	// Print result
 	fmt.Printf("%s Skill injection complete\n\n", style.Bold.Render("✓"))
 	fmt.Printf("  Bead: %s\n", style.Bold.Render(beadID))
 	fmt.Printf("  Depth: %s\n", depth)
 	fmt.Printf("  Skills matched: %d\n", len(result.MatchedSkills))
-	fmt.Printf("  Files: %d | Patterns: %d | Docs: %d\n",
+	fmt.Printf("  Files: %d | Patterns: %d | Docs: %d | Prior work: %d\n",
 		result.Stats.FilesCount,
 		result.Stats.PatternsCount,
-		result.Stats.DocsCount)
+		result.Stats.DocsCount,
+		result.Stats.PriorBeadsCount)
+	if result.Stats.SnapshotsCount > 0 {
+		fmt.Printf("  Doc snapshots: %d\n", result.Stats.SnapshotsCount)
+	}
+	if len(result.Stats.Omitted) > 0 {
+		fmt.Printf("  %s %s\n", style.Dim.Render(fmt.Sprintf("⚠ omitted to fit %d tokens:", injectTargetContext)),
+			strings.Join(result.Stats.Omitted, ", "))
+	}
+	if result.PriorArtErr != nil {
+		fmt.Printf("  %s %v\n", style.Dim.Render("⚠ prior work search skipped:"), result.PriorArtErr)
+	}
+	for _, err := range result.SnapshotErrors {
+		fmt.Printf("  %s %v\n", style.Dim.Render("⚠ snapshot skipped:"), err)
+	}
 
 	if len(result.MatchedSkills) > 0 {
 		skillNames := make([]string, len(result.MatchedSkills))
@@ -466,6 +739,59 @@ func runLibrarianInject(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// parseEnrichmentDepth parses a --depth flag value.
+func parseEnrichmentDepth(s string) (librarian.EnrichmentDepth, error) {
+	switch strings.ToLower(s) {
+	case "quick":
+		return librarian.DepthQuick, nil
+	case "standard":
+		return librarian.DepthStandard, nil
+	case "deep":
+		return librarian.DepthDeep, nil
+	default:
+		return "", fmt.Errorf("invalid depth: %s (use quick, standard, or deep)", s)
+	}
+}
+
+func runLibrarianDaemon(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return err
+	}
+
+	rigRoot, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("getting current directory: %w", err)
+	}
+
+	depth, err := parseEnrichmentDepth(daemonDepth)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	logger := log.New(os.Stdout, "", log.LstdFlags)
+	d := librarian.NewDaemon(librarian.DaemonConfig{
+		TownRoot:       townRoot,
+		RigRoot:        rigRoot,
+		RigPath:        librarianRigPath(townRoot),
+		Depth:          depth,
+		SkillInterval:  daemonSkillInterval,
+		TargetContext:  daemonTargetContext,
+		EnrichInterval: daemonInterval,
+		Logger:         logger.Printf,
+	})
+
+	logger.Printf("Librarian daemon started (skills every %s, beads every %s, Ctrl-C to stop)", daemonSkillInterval, daemonInterval)
+	if err := d.Run(ctx); err != nil {
+		return err
+	}
+	logger.Printf("Librarian daemon stopped")
+	return nil
+}
+
 func runLibrarianMatch(cmd *cobra.Command, args []string) error {
 	beadID := args[0]
 
@@ -480,17 +806,19 @@ func runLibrarianMatch(cmd *cobra.Command, args []string) error {
 	}
 
 	injector := librarian.NewInjector(townRoot, rigRoot)
+	injector.SetRigPath(librarianRigPath(townRoot))
 
-	skills, ctx, err := injector.PreviewMatches(beadID)
+	matches, ctx, err := injector.PreviewMatches(beadID)
 	if err != nil {
 		return err
 	}
 
-	printMatchPreview(ctx, skills)
+	printMatchPreview(ctx, matches)
+	printStaleSkills(injector.StaleSkills())
 	return nil
 }
 
-func printMatchPreview(ctx *librarian.BeadContext, skills []*librarian.Skill) {
+func printMatchPreview(ctx *librarian.BeadContext, matches []librarian.SkillMatch) {
 	fmt.Printf("%s Bead Context\n\n", style.Bold.Render("●"))
 	fmt.Printf("  ID: %s\n", style.Bold.Render(ctx.ID))
 	fmt.Printf("  Title: %s\n", ctx.Title)
@@ -513,26 +841,42 @@ func printMatchPreview(ctx *librarian.BeadContext, skills []*librarian.Skill) {
 
 	fmt.Println()
 
-	if len(skills) == 0 {
+	if len(matches) == 0 {
 		fmt.Printf("%s No skills matched\n", style.Dim.Render("○"))
 		fmt.Println("\nTip: Create skills in <town>/librarian/skills/ to enable automatic injection.")
 		return
 	}
 
-	fmt.Printf("%s %d skills matched\n\n", style.Bold.Render("●"), len(skills))
+	fmt.Printf("%s %d skills matched\n\n", style.Bold.Render("●"), len(matches))
 
-	for _, skill := range skills {
-		fmt.Printf("  %s %s\n", style.Bold.Render("→"), skill.Name)
+	for _, m := range matches {
+		skill := m.Skill
+		fmt.Printf("  %s %s %s\n", style.Bold.Render("→"), skill.Name, style.Dim.Render(formatMatchScore(m)))
 		if skill.Description != "" {
 			fmt.Printf("    %s\n", style.Dim.Render(skill.Description))
 		}
-		triggers := formatTriggers(skill.Triggers)
-		if triggers != "" {
+		if !m.Triggered {
+			fmt.Printf("    %s similarity %.2f\n", style.Dim.Render("Matched by:"), m.Similarity)
+		} else if triggers := formatTriggers(skill.Triggers); triggers != "" {
 			fmt.Printf("    %s %s\n", style.Dim.Render("Matched by:"), triggers)
 		}
 	}
 }
 
+// formatMatchScore renders a skill match's score, e.g. "score 0.82
+// (trigger + similarity 0.64)". Matches without an index entry only have
+// their trigger.
+func formatMatchScore(m librarian.SkillMatch) string {
+	switch {
+	case m.Similarity < 0:
+		return "(trigger)"
+	case m.Triggered:
+		return fmt.Sprintf("score %.2f (trigger + similarity %.2f)", m.Score, m.Similarity)
+	default:
+		return fmt.Sprintf("score %.2f (similarity %.2f)", m.Score, m.Similarity)
+	}
+}
+
 func formatTriggers(t librarian.SkillTriggers) string {
 	var parts []string
 	if len(t.Labels) > 0 {
@@ -553,6 +897,15 @@ func formatTriggers(t librarian.SkillTriggers) string {
 	return strings.Join(parts, ", ")
 }
 
+func formatSkillFeedback(st *librarian.SkillFeedbackStats) string {
+	summary := fmt.Sprintf("injected %d×", st.Injections)
+	if st.Rated() == 0 {
+		return summary + ", not rated"
+	}
+	return fmt.Sprintf("%s, %d%% useful (%d useful, %d noisy)",
+		summary, int(st.Usefulness()*100+0.5), st.Useful, st.Noisy)
+}
+
 func formatContentSummary(c librarian.SkillContent) string {
 	var parts []string
 	if len(c.Files) > 0 {
@@ -570,6 +923,38 @@ func formatContentSummary(c librarian.SkillContent) string {
 	return strings.Join(parts, ", ")
 }
 
+func runLibrarianFeedback(cmd *cobra.Command, args []string) error {
+	beadID := args[0]
+
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return err
+	}
+
+	rating := librarian.RatingUseful
+	if feedbackNoisy {
+		rating = librarian.RatingNoisy
+	}
+
+	fb, err := librarian.NewFeedbackStore(townRoot).AddFeedback(librarian.Feedback{
+		BeadID: beadID,
+		Rating: rating,
+		Note:   feedbackNote,
+		Skills: feedbackSkills,
+		By:     detectSender(),
+	})
+	if err != nil {
+		if errors.Is(err, librarian.ErrNoInjectionRecord) {
+			return fmt.Errorf("%w (name the skills with --skill)", err)
+		}
+		return err
+	}
+
+	fmt.Printf("%s Recorded %s feedback for %s\n", style.Bold.Render("✓"), fb.Rating, style.Bold.Render(beadID))
+	fmt.Printf("  Skills: %s\n", strings.Join(fb.Skills, ", "))
+	return nil
+}
+
 // getSkillsPath returns the path to the skills directory for the current workspace.
 func getSkillsPath() (string, error) {
 	townRoot, err := workspace.FindFromCwdOrError()
@@ -18,6 +18,7 @@ var (
 	mailNotify        bool
 	mailSendSelf      bool
 	mailCC            []string // CC recipients
+	mailBCC           []string // BCC recipients
 	mailInboxJSON     bool
 	mailReadJSON      bool
 	mailInboxUnread   bool
@@ -28,6 +29,7 @@ var (
 	mailThreadJSON    bool
 	mailReplySubject  string
 	mailReplyMessage  string
+	mailReplyAll      bool
 
 	// Search flags
 	mailSearchFrom    string
@@ -82,13 +84,18 @@ COMMANDS:
   inbox     View your inbox
   send      Send a message
   read      Read a specific message
-  mark      Mark messages read/unread`,
+  mark      Mark messages read/unread
+  deadletter  Show mail that could not be delivered
+  export    Export a mailbox to a .tar.gz archive
+  import    Import a mailbox archive
+  api       JSON-lines mail API over stdio (for bots and plugins)
+  unread    Unread-mail badge for shell prompts and tmux`,
 }
 
 var mailSendCmd = &cobra.Command{
-	Use:   "send <address>",
+	Use:   "send <address>...",
 	Short: "Send a message",
-	Long: `Send a message to an agent.
+	Long: `Send a message to one or more agents.
 
 Addresses:
   mayor/           - Send to Mayor
@@ -96,6 +103,13 @@ Addresses:
   <rig>/<polecat>  - Send to a specific polecat
   <rig>/           - Broadcast to a rig
   list:<name>      - Send to a mailing list (fans out to all members)
+  @polecats/<rig>  - Send to all polecats in a rig (also @crew/<rig>,
+                     @rig/<rig>, @witnesses, @town, @overseer)
+
+Several addresses can be given; each recipient gets its own copy, and
+'gt mail reply --all' reaches all of them. --cc and --bcc accept the same
+address forms. BCC recipients get a private copy that doesn't list anyone
+else.
 
 Mailing lists are defined in ~/gt/config/messaging.json and allow
 sending to multiple recipients at once. Each recipient gets their
@@ -125,8 +139,10 @@ Examples:
   gt mail send mayor/ -s "Re: Status" -m "Done" --reply-to msg-abc123
   gt mail send --self -s "Handoff" -m "Context for next session"
   gt mail send greenplace/Toast -s "Update" -m "Progress report" --cc overseer
+  gt mail send greenplace/Toast greenplace/Nux -s "Pair up" -m "Split gt-abc"
+  gt mail send @polecats/greenplace -s "Freeze" -m "Hold merges" --bcc mayor/
   gt mail send list:oncall -s "Alert" -m "System down"`,
-	Args: cobra.MaximumNArgs(1),
+	Args: cobra.ArbitraryArgs,
 	RunE: runMailSend,
 }
 
@@ -269,10 +285,12 @@ var mailReplyCmd = &cobra.Command{
 This is a convenience command that automatically:
 - Sets the reply-to field to the original message
 - Prefixes the subject with "Re: " (if not already present)
-- Sends to the original sender
+- Sends to the original sender (and, with --all, CCs everyone else
+  the original went to)
 
 Examples:
   gt mail reply msg-abc123 -m "Thanks, working on it now"
+  gt mail reply msg-abc123 --all -m "Agreed, merging now"
   gt mail reply msg-abc123 -s "Custom subject" -m "Reply body"`,
 	Args: cobra.ExactArgs(1),
 	RunE: runMailReply,
@@ -428,6 +446,7 @@ func init() {
 	mailSendCmd.Flags().BoolVar(&mailPermanent, "permanent", false, "Send as permanent (not ephemeral, synced to remote)")
 	mailSendCmd.Flags().BoolVar(&mailSendSelf, "self", false, "Send to self (auto-detect from cwd)")
 	mailSendCmd.Flags().StringArrayVar(&mailCC, "cc", nil, "CC recipients (can be used multiple times)")
+	mailSendCmd.Flags().StringArrayVar(&mailBCC, "bcc", nil, "BCC recipients (can be used multiple times)")
 	_ = mailSendCmd.MarkFlagRequired("subject") // cobra flags: error only at runtime if missing
 
 	// Inbox flags
@@ -451,6 +470,7 @@ func init() {
 	// Reply flags
 	mailReplyCmd.Flags().StringVarP(&mailReplySubject, "subject", "s", "", "Override reply subject (default: Re: <original>)")
 	mailReplyCmd.Flags().StringVarP(&mailReplyMessage, "message", "m", "", "Reply message body (required)")
+	mailReplyCmd.Flags().BoolVarP(&mailReplyAll, "all", "a", false, "Reply to all recipients (others are CC'd)")
 	_ = mailReplyCmd.MarkFlagRequired("message")
 
 	// Search flags
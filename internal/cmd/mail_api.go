@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	mailapi "github.com/steveyegge/gastown/pkg/mail"
+)
+
+// API command flags
+var mailAPIIdentity string
+
+var mailAPICmd = &cobra.Command{
+	Use:   "api",
+	Short: "Serve mail operations as JSON lines over stdio",
+	Long: `Read mail requests as JSON lines on stdin and answer each with a JSON
+line on stdout, for bots and editor plugins that integrate with agent mail.
+
+Requests act on the current context's mailbox (or --identity):
+
+  {"id":"1","op":"list","unread":true}
+  {"id":"2","op":"read","message_id":"hq-abc123"}
+  {"id":"3","op":"ack","message_id":"hq-abc123"}
+  {"id":"4","op":"send","message":{"to":"mayor/","subject":"Done","body":"gt-42 merged"}}
+
+Each response echoes the request's id, with "ok" and either the result
+("messages" for list, "message" for read and send) or an "error":
+
+  {"id":"1","ok":true,"messages":[{"id":"hq-abc123","from":"gastown/Toast",...}]}
+  {"id":"2","ok":false,"error":"message not found"}
+
+Messages use the same JSON as 'gt mail inbox --json'. Reading doesn't mark
+a message read; ack does. Sent mail is from the mailbox's address. The
+stream ends at end of input. Go programs can use the same operations
+directly through the github.com/steveyegge/gastown/pkg/mail package.
+
+Examples:
+  echo '{"op":"list"}' | gt mail api
+  gt mail api --identity mayor/ < requests.jsonl`,
+	Args: cobra.NoArgs,
+	RunE: runMailAPI,
+}
+
+func init() {
+	mailAPICmd.Flags().StringVar(&mailAPIIdentity, "identity", "", "Mailbox address to serve (default: current context)")
+
+	mailCmd.AddCommand(mailAPICmd)
+}
+
+func runMailAPI(cmd *cobra.Command, args []string) error {
+	townRoot, err := findMailWorkDir()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	address := mailAPIIdentity
+	if address == "" {
+		address = detectSender()
+	}
+
+	client, err := mailapi.Open(townRoot, address)
+	if err != nil {
+		return err
+	}
+	return mailapi.Serve(client, os.Stdin, os.Stdout)
+}
@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/mail"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// Auth command flags
+var mailAuthInitForce bool
+
+var mailAuthCmd = &cobra.Command{
+	Use:   "auth",
+	Short: "Manage mail message signing",
+	Long: `Manage message authentication between agents.
+
+A rig with a signing key signs all mail its agents send with an HMAC under
+that shared secret, so readers can tell a message claiming to come from
+e.g. gastown/refinery really came from the gastown rig. Town-level agents
+(mayor, deacon, overseer) share the town key.
+
+Mail is signed with the key of the workspace it is sent from, so sending as
+another rig's agent (e.g. a beads polecat claiming to be gastown/refinery)
+fails once the sending workspace has a key. The signature covers the
+sender, subject, body, thread, reply-to, type and priority.
+
+Once a rig has a key, its unsigned or mis-signed messages are flagged in
+'gt mail read' and the inbox TUI. Mail from rigs without a key is not
+flagged.
+
+Keys live at <rig>/.runtime/mail-signing.key (town: .runtime/mail-signing.key).`,
+	RunE: requireSubcommand,
+}
+
+var mailAuthInitCmd = &cobra.Command{
+	Use:   "init <rig|town>",
+	Short: "Create a rig's mail signing key",
+	Long: `Create the signing key for a rig, or for town-level agents ("town").
+
+From then on mail sent by the rig's agents is signed. Replacing an existing
+key (--force) makes every message signed with the old key fail
+verification.
+
+Examples:
+  gt mail auth init gastown
+  gt mail auth init town`,
+	Args: cobra.ExactArgs(1),
+	RunE: runMailAuthInit,
+}
+
+var mailAuthVerifyCmd = &cobra.Command{
+	Use:   "verify <message-id>",
+	Short: "Check a message's signature",
+	Long: `Check that a message came from its sender's rig.
+
+Prints the message's authentication status:
+  verified  signed with the sender's rig key
+  unsigned  the sender's rig signs its mail, but this message isn't signed
+  invalid   the signature doesn't match (altered, or signed by another rig)
+  disabled  the sender's rig has no signing key
+
+Exit codes:
+  0  verified
+  1  not verified
+
+Examples:
+  gt mail auth verify hq-abc123`,
+	Args: cobra.ExactArgs(1),
+	RunE: runMailAuthVerify,
+}
+
+func init() {
+	mailAuthInitCmd.Flags().BoolVar(&mailAuthInitForce, "force", false, "Replace an existing key")
+
+	mailAuthCmd.AddCommand(mailAuthInitCmd)
+	mailAuthCmd.AddCommand(mailAuthVerifyCmd)
+	mailCmd.AddCommand(mailAuthCmd)
+}
+
+func runMailAuthInit(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	rig := args[0]
+	if rig == "town" {
+		rig = ""
+	}
+	path := mail.MailKeyPath(townRoot, rig)
+	if err := mail.GenerateMailKey(path, mailAuthInitForce); err != nil {
+		return err
+	}
+
+	fmt.Printf("%s Created mail signing key %s\n", style.SuccessPrefix, path)
+	if mailAuthInitForce {
+		fmt.Printf("  %s\n", style.Dim.Render("Messages signed with the previous key will no longer verify"))
+	}
+	return nil
+}
+
+func runMailAuthVerify(cmd *cobra.Command, args []string) error {
+	mailbox, err := getMailbox(detectSender())
+	if err != nil {
+		return err
+	}
+	msg, err := mailbox.Get(args[0])
+	if err != nil {
+		return fmt.Errorf("getting message: %w", err)
+	}
+
+	status := mailAuthStatus(msg)
+	fmt.Printf("%s %s from %s\n", renderMailAuth(status), msg.ID, msg.From)
+	if !status.Trusted() {
+		return NewSilentExit(1)
+	}
+	return nil
+}
+
+// mailAuthStatus checks a message's signature against the current town's
+// keys. Outside a workspace no keys are known.
+func mailAuthStatus(msg *mail.Message) mail.AuthStatus {
+	townRoot, _ := workspace.FindFromCwd()
+	status, err := mail.VerifyMessage(townRoot, msg)
+	if err != nil {
+		return mail.AuthInvalid
+	}
+	return status
+}
+
+// renderMailAuth renders an authentication status for display.
+func renderMailAuth(status mail.AuthStatus) string {
+	switch status {
+	case mail.AuthVerified:
+		return style.Success.Render("verified")
+	case mail.AuthUnsigned:
+		return style.Warning.Render("UNSIGNED")
+	case mail.AuthInvalid:
+		return style.Error.Render("INVALID SIGNATURE")
+	default:
+		return style.Dim.Render("not signed (rig has no key)")
+	}
+}
@@ -0,0 +1,182 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/mail"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// Dead-letter command flags
+var (
+	deadLetterJSON    bool
+	deadLetterPending bool
+	deadLetterAll     bool
+)
+
+var mailDeadLetterCmd = &cobra.Command{
+	Use:   "deadletter",
+	Short: "Show mail that could not be delivered",
+	Long: `Show messages that could not be delivered.
+
+Automated notifications (merge failures, watcher updates, swarm reports) that
+fail to send are queued in <town>/mail/retry.json and retried with backoff by
+the daemon heartbeat and by later successful sends. After several failed
+attempts, or immediately if retrying can't help (e.g. an unknown mailing
+list), a message is moved to the dead-letter box so it is never silently
+lost.
+
+Examples:
+  gt mail deadletter                  # List dead letters
+  gt mail deadletter --pending        # List messages waiting for retry
+  gt mail deadletter retry hq-abc123  # Requeue one and retry now
+  gt mail deadletter retry --all      # Requeue everything
+  gt mail deadletter discard --all    # Empty the dead-letter box`,
+	Args: cobra.NoArgs,
+	RunE: runMailDeadLetter,
+}
+
+var mailDeadLetterRetryCmd = &cobra.Command{
+	Use:   "retry [id]",
+	Short: "Requeue dead letters and retry delivery now",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  runMailDeadLetterRetry,
+}
+
+var mailDeadLetterDiscardCmd = &cobra.Command{
+	Use:   "discard [id]",
+	Short: "Delete dead letters",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  runMailDeadLetterDiscard,
+}
+
+func init() {
+	mailDeadLetterCmd.Flags().BoolVar(&deadLetterJSON, "json", false, "Output as JSON")
+	mailDeadLetterCmd.Flags().BoolVar(&deadLetterPending, "pending", false, "Show the retry queue instead of the dead-letter box")
+	mailDeadLetterRetryCmd.Flags().BoolVar(&deadLetterAll, "all", false, "Requeue every dead letter")
+	mailDeadLetterDiscardCmd.Flags().BoolVar(&deadLetterAll, "all", false, "Discard every dead letter")
+
+	mailDeadLetterCmd.AddCommand(mailDeadLetterRetryCmd)
+	mailDeadLetterCmd.AddCommand(mailDeadLetterDiscardCmd)
+	mailCmd.AddCommand(mailDeadLetterCmd)
+}
+
+// townMailRouter returns a router for the current town.
+func townMailRouter() (*mail.Router, error) {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return nil, fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	return mail.NewRouterWithTownRoot(townRoot, townRoot), nil
+}
+
+func runMailDeadLetter(cmd *cobra.Command, args []string) error {
+	router, err := townMailRouter()
+	if err != nil {
+		return err
+	}
+
+	var entries []mail.DeliveryFailure
+	if deadLetterPending {
+		entries, err = router.PendingRetries()
+	} else {
+		entries, err = router.DeadLetters()
+	}
+	if err != nil {
+		return err
+	}
+
+	if deadLetterJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(entries)
+	}
+
+	if len(entries) == 0 {
+		if deadLetterPending {
+			fmt.Println("No messages waiting for retry.")
+		} else {
+			fmt.Println("No dead letters.")
+		}
+		return nil
+	}
+
+	for _, f := range entries {
+		fmt.Printf("%s %s → %s: %s\n", style.Bold.Render(f.ID), f.Message.From, f.Message.To, f.Message.Subject)
+		fmt.Printf("    %d attempt(s) since %s, last error: %s\n",
+			f.Attempts, f.FirstFailedAt.Format("2006-01-02 15:04"), f.LastError)
+		if deadLetterPending {
+			fmt.Printf("    %s\n", style.Dim.Render("next attempt in "+time.Until(f.NextAttemptAt).Round(time.Second).String()))
+		}
+	}
+	if !deadLetterPending {
+		fmt.Printf("\n%s\n", style.Dim.Render("Requeue with: gt mail deadletter retry <id> (or --all)"))
+	}
+	return nil
+}
+
+// deadLetterTargets resolves the dead-letter IDs named by args or --all.
+func deadLetterTargets(router *mail.Router, args []string) ([]string, error) {
+	if len(args) == 1 {
+		return args, nil
+	}
+	if !deadLetterAll {
+		return nil, fmt.Errorf("specify a dead-letter ID or --all")
+	}
+	entries, err := router.DeadLetters()
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, len(entries))
+	for i, f := range entries {
+		ids[i] = f.ID
+	}
+	return ids, nil
+}
+
+func runMailDeadLetterRetry(cmd *cobra.Command, args []string) error {
+	router, err := townMailRouter()
+	if err != nil {
+		return err
+	}
+	ids, err := deadLetterTargets(router, args)
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		if err := router.RequeueDeadLetter(id); err != nil {
+			return err
+		}
+	}
+
+	stats, err := router.RetryPending()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%s Requeued %d message(s): %d delivered, %d rescheduled, %d dead-lettered\n",
+		style.Bold.Render("✓"), len(ids), stats.Delivered, stats.Rescheduled, stats.DeadLettered)
+	return nil
+}
+
+func runMailDeadLetterDiscard(cmd *cobra.Command, args []string) error {
+	router, err := townMailRouter()
+	if err != nil {
+		return err
+	}
+	ids, err := deadLetterTargets(router, args)
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		if err := router.DiscardDeadLetter(id); err != nil {
+			return err
+		}
+	}
+	fmt.Printf("%s Discarded %d dead letter(s)\n", style.Bold.Render("✓"), len(ids))
+	return nil
+}
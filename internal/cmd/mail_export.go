@@ -0,0 +1,246 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/mail"
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+// Export/import command flags
+var (
+	mailExportSince   string
+	mailExportOutput  string
+	mailImportAddress string
+
+	mailExportThreadMD      bool
+	mailExportThreadJSON    bool
+	mailExportThreadMessage bool
+	mailExportThreadOutput  string
+)
+
+var mailExportCmd = &cobra.Command{
+	Use:   "export <address>",
+	Short: "Export a mailbox to an archive file",
+	Long: `Export a mailbox's inbox and archive to a .tar.gz file.
+
+The archive holds a manifest and the inbox and archived messages as JSONL,
+with read state, threads and pinning preserved. Use it to move a town's
+mail to another machine or to back up overseer correspondence; restore it
+with 'gt mail import'.
+
+Examples:
+  gt mail export mayor/                                 # Everything, to mayor.mail.tar.gz
+  gt mail export overseer --since 90d --output mail.tar.gz
+  gt mail export gastown/witness --since 720h`,
+	Args: cobra.ExactArgs(1),
+	RunE: runMailExport,
+}
+
+var mailImportCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Import a mailbox archive from 'gt mail export'",
+	Long: `Import messages from an archive written by 'gt mail export'.
+
+Messages go to the mailbox they were exported from, or to --address.
+Inbox messages keep their read state, thread and original send time, and
+archived messages are restored to the archive. Messages the mailbox
+already holds are skipped, so importing the same archive twice is safe.
+
+In beads-backed mailboxes imported messages get new IDs (replies are
+relinked) and are stored as permanent messages.
+
+Examples:
+  gt mail import mail.tar.gz
+  gt mail import mayor.mail.tar.gz --address mayor/`,
+	Args: cobra.ExactArgs(1),
+	RunE: runMailImport,
+}
+
+var mailExportThreadCmd = &cobra.Command{
+	Use:   "export-thread <id>",
+	Short: "Render a message or thread as markdown",
+	Long: `Render a message or a whole thread as markdown, for pasting into
+beads, specs or incident docs.
+
+<id> is a thread ID or a message ID; a message exports its whole thread
+unless --message is given. The markdown starts with YAML front-matter
+(subject, thread, participants, dates) followed by each message, oldest
+first. It is written to stdout unless --output is given.
+
+The inbox TUI exports the same way with E.
+
+Examples:
+  gt mail export-thread thread-abc123 --md
+  gt mail export-thread hq-msg-xyz --message --md -o incident-notes.md
+  gt mail export-thread thread-abc123 --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runMailExportThread,
+}
+
+func init() {
+	mailExportCmd.Flags().StringVar(&mailExportSince, "since", "", "Only export messages newer than this (e.g. 90d, 24h)")
+	mailExportCmd.Flags().StringVarP(&mailExportOutput, "output", "o", "", "Archive file to write (default: <address>.mail.tar.gz)")
+	mailImportCmd.Flags().StringVar(&mailImportAddress, "address", "", "Mailbox to import into (default: the exported address)")
+	mailExportThreadCmd.Flags().BoolVar(&mailExportThreadMD, "md", false, "Render as markdown (the default)")
+	mailExportThreadCmd.Flags().BoolVar(&mailExportThreadJSON, "json", false, "Output the messages as JSON")
+	mailExportThreadCmd.Flags().BoolVar(&mailExportThreadMessage, "message", false, "Export only the given message, not its thread")
+	mailExportThreadCmd.Flags().StringVarP(&mailExportThreadOutput, "output", "o", "", "File to write (default: stdout)")
+	mailExportThreadCmd.MarkFlagsMutuallyExclusive("md", "json")
+
+	mailCmd.AddCommand(mailExportCmd)
+	mailCmd.AddCommand(mailImportCmd)
+	mailCmd.AddCommand(mailExportThreadCmd)
+}
+
+func runMailExport(cmd *cobra.Command, args []string) error {
+	address := args[0]
+	var since time.Time
+	if mailExportSince != "" {
+		d, err := parseDuration(mailExportSince)
+		if err != nil || d <= 0 {
+			return fmt.Errorf("invalid --since %q (want a duration like 90d or 24h)", mailExportSince)
+		}
+		since = time.Now().Add(-d)
+	}
+
+	mailbox, err := getMailbox(address)
+	if err != nil {
+		return err
+	}
+
+	output := mailExportOutput
+	if output == "" {
+		output = mailExportFileName(address)
+	}
+	f, err := os.Create(output)
+	if err != nil {
+		return fmt.Errorf("creating %s: %w", output, err)
+	}
+	manifest, err := mailbox.Export(f, address, since)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(output)
+		return fmt.Errorf("exporting %s: %w", address, err)
+	}
+
+	fmt.Printf("%s Exported %s: %d inbox, %d archived → %s\n",
+		style.Bold.Render("✓"), address, manifest.Inbox, manifest.Archived, output)
+	return nil
+}
+
+// mailExportFileName is the default archive name for an address,
+// e.g. "gastown-witness.mail.tar.gz".
+func mailExportFileName(address string) string {
+	name := strings.Trim(strings.ReplaceAll(address, "/", "-"), "-")
+	if name == "" {
+		return "mail.tar.gz"
+	}
+	return name + ".mail.tar.gz"
+}
+
+func runMailImport(cmd *cobra.Command, args []string) error {
+	f, err := os.Open(args[0])
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+
+	exp, err := mail.ReadExport(f)
+	if err != nil {
+		return fmt.Errorf("%s: %w", args[0], err)
+	}
+	address := mailImportAddress
+	if address == "" {
+		address = exp.Manifest.Address
+	}
+	if address == "" {
+		return fmt.Errorf("%s does not record its address; pass --address", args[0])
+	}
+
+	mailbox, err := getMailbox(address)
+	if err != nil {
+		return err
+	}
+	result, err := mailbox.Import(exp)
+	if err != nil {
+		if result != nil && result.Inbox+result.Archived > 0 {
+			fmt.Printf("%s Imported %d inbox, %d archived before failing\n",
+				style.Warning.Render("⚠"), result.Inbox, result.Archived)
+		}
+		return fmt.Errorf("importing into %s: %w", address, err)
+	}
+
+	fmt.Printf("%s Imported into %s: %d inbox, %d archived\n",
+		style.Bold.Render("✓"), address, result.Inbox, result.Archived)
+	if result.Skipped > 0 {
+		fmt.Printf("  %s\n", style.Dim.Render(fmt.Sprintf("Skipped %d already present", result.Skipped)))
+	}
+	return nil
+}
+
+func runMailExportThread(cmd *cobra.Command, args []string) error {
+	mailbox, err := getMailbox(detectSender())
+	if err != nil {
+		return err
+	}
+	messages, err := exportThreadMessages(mailbox, args[0], mailExportThreadMessage)
+	if err != nil {
+		return err
+	}
+
+	var out []byte
+	if mailExportThreadJSON {
+		out, err = json.MarshalIndent(messages, "", "  ")
+		out = append(out, '\n')
+	} else {
+		var md string
+		md, err = mail.RenderMarkdown(messages, time.Now())
+		out = []byte(md)
+	}
+	if err != nil {
+		return err
+	}
+
+	if mailExportThreadOutput == "" {
+		_, err = os.Stdout.Write(out)
+		return err
+	}
+	if err := os.WriteFile(mailExportThreadOutput, out, 0644); err != nil {
+		return fmt.Errorf("writing %s: %w", mailExportThreadOutput, err)
+	}
+	fmt.Printf("%s Exported %d message(s) → %s\n",
+		style.Bold.Render("✓"), len(messages), mailExportThreadOutput)
+	return nil
+}
+
+// exportThreadMessages resolves id, a message or thread ID, to the
+// messages to export: the message's whole thread unless messageOnly.
+func exportThreadMessages(mailbox *mail.Mailbox, id string, messageOnly bool) ([]*mail.Message, error) {
+	msg, getErr := mailbox.Get(id)
+	if getErr == nil && (messageOnly || msg.ThreadID == "") {
+		return []*mail.Message{msg}, nil
+	}
+	threadID := id
+	if getErr == nil {
+		threadID = msg.ThreadID
+	}
+	messages, err := mailbox.ListByThread(threadID)
+	if err != nil {
+		return nil, fmt.Errorf("getting thread: %w", err)
+	}
+	if len(messages) == 0 {
+		if getErr == nil {
+			return []*mail.Message{msg}, nil
+		}
+		return nil, fmt.Errorf("no message or thread %q", id)
+	}
+	return messages, nil
+}
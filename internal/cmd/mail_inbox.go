@@ -125,11 +125,16 @@ func runMailRead(cmd *cobra.Command, args []string) error {
 	// User must explicitly delete/ack the message.
 	// This preserves handoff messages for reference.
 
+	auth := mailAuthStatus(msg)
+
 	// JSON output
 	if mailReadJSON {
 		enc := json.NewEncoder(os.Stdout)
 		enc.SetIndent("", "  ")
-		return enc.Encode(msg)
+		return enc.Encode(struct {
+			*mail.Message
+			Auth mail.AuthStatus `json:"auth"`
+		}{msg, auth})
 	}
 
 	// Human-readable output
@@ -146,7 +151,11 @@ func runMailRead(cmd *cobra.Command, args []string) error {
 	}
 
 	fmt.Printf("%s %s%s%s\n\n", style.Bold.Render("Subject:"), msg.Subject, typeStr, priorityStr)
-	fmt.Printf("From: %s\n", msg.From)
+	fmt.Printf("From: %s", msg.From)
+	if auth != mail.AuthDisabled {
+		fmt.Printf(" (%s)", renderMailAuth(auth))
+	}
+	fmt.Println()
 	fmt.Printf("To: %s\n", msg.To)
 	fmt.Printf("Date: %s\n", msg.Timestamp.Format("2006-01-02 15:04:05"))
 	fmt.Printf("ID: %s\n", style.Dim.Render(msg.ID))
@@ -16,7 +16,7 @@ import (
 )
 
 func runMailSend(cmd *cobra.Command, args []string) error {
-	var to string
+	var targets []string
 
 	if mailSendSelf {
 		// Auto-detect identity from cwd
@@ -39,12 +39,13 @@ func runMailSend(cmd *cobra.Command, args []string) error {
 			TownRoot: townRoot,
 			WorkDir:  cwd,
 		}
-		to = buildAgentIdentity(ctx)
-		if to == "" {
+		self := buildAgentIdentity(ctx)
+		if self == "" {
 			return fmt.Errorf("cannot determine identity (role: %s)", ctx.Role)
 		}
+		targets = []string{self}
 	} else if len(args) > 0 {
-		to = args[0]
+		targets = args
 	} else {
 		return fmt.Errorf("address required (or use --self)")
 	}
@@ -59,12 +60,16 @@ func runMailSend(cmd *cobra.Command, args []string) error {
 	from := detectSender()
 
 	// Create message
+	to := strings.Join(targets, ", ")
 	msg := &mail.Message{
 		From:    from,
-		To:      to,
+		To:      targets[0],
 		Subject: mailSubject,
 		Body:    mailBody,
 	}
+	if len(targets) > 1 {
+		msg.Recipients = targets
+	}
 
 	// Set priority (--urgent overrides --priority)
 	if mailUrgent {
@@ -85,8 +90,9 @@ func runMailSend(cmd *cobra.Command, args []string) error {
 	// Set wisp flag (ephemeral message) - default true, --permanent overrides
 	msg.Wisp = mailWisp && !mailPermanent
 
-	// Set CC recipients
+	// Set CC and BCC recipients
 	msg.CC = mailCC
+	msg.BCC = mailBCC
 
 	// Handle reply-to: auto-set type to reply and look up thread
 	if mailReplyTo != "" {
@@ -114,51 +120,15 @@ func runMailSend(cmd *cobra.Command, args []string) error {
 	townRoot, _ := workspace.FindFromCwd()
 	b := beads.New(townRoot)
 	resolver := mail.NewResolver(b, townRoot)
-
-	recipients, err := resolver.Resolve(to)
-	if err != nil {
-		// Fall back to legacy routing if resolver fails
-		router := mail.NewRouter(workDir)
-		if err := router.Send(msg); err != nil {
-			return fmt.Errorf("sending message: %w", err)
-		}
-		_ = events.LogFeed(events.TypeMail, from, events.MailPayload(to, mailSubject))
-		fmt.Printf("%s Message sent to %s\n", style.Bold.Render("✓"), to)
-		fmt.Printf("  Subject: %s\n", mailSubject)
-		return nil
-	}
-
-	// Route based on recipient type
 	router := mail.NewRouter(workDir)
-	var recipientAddrs []string
-
-	for _, rec := range recipients {
-		switch rec.Type {
-		case mail.RecipientQueue:
-			// Queue messages: single message, workers claim
-			msg.To = rec.Address
-			if err := router.Send(msg); err != nil {
-				return fmt.Errorf("sending to queue: %w", err)
-			}
-			recipientAddrs = append(recipientAddrs, rec.Address)
-
-		case mail.RecipientChannel:
-			// Channel messages: single message, broadcast
-			msg.To = rec.Address
-			if err := router.Send(msg); err != nil {
-				return fmt.Errorf("sending to channel: %w", err)
-			}
-			recipientAddrs = append(recipientAddrs, rec.Address)
 
-		default:
-			// Direct/agent messages: fan out to each recipient
-			msgCopy := *msg
-			msgCopy.To = rec.Address
-			if err := router.Send(&msgCopy); err != nil {
-				return fmt.Errorf("sending to %s: %w", rec.Address, err)
-			}
-			recipientAddrs = append(recipientAddrs, rec.Address)
+	var recipientAddrs []string
+	for _, target := range targets {
+		addrs, err := deliverMail(router, resolver, msg, target)
+		if err != nil {
+			return err
 		}
+		recipientAddrs = append(recipientAddrs, addrs...)
 	}
 
 	// Log mail event to activity feed
@@ -175,6 +145,9 @@ func runMailSend(cmd *cobra.Command, args []string) error {
 	if len(msg.CC) > 0 {
 		fmt.Printf("  CC: %s\n", strings.Join(msg.CC, ", "))
 	}
+	if len(mailBCC) > 0 {
+		fmt.Printf("  BCC: %s\n", strings.Join(mailBCC, ", "))
+	}
 	if msg.Type != mail.TypeNotification {
 		fmt.Printf("  Type: %s\n", msg.Type)
 	}
@@ -182,6 +155,56 @@ func runMailSend(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// deliverMail sends msg to one target address, resolving it to queues,
+// channels, or agents. It returns the addresses the message went to. BCC
+// copies go out with the first delivery only.
+func deliverMail(router *mail.Router, resolver *mail.Resolver, msg *mail.Message, to string) ([]string, error) {
+	send := func(m *mail.Message) error {
+		if err := router.Send(m); err != nil {
+			return err
+		}
+		msg.BCC = nil
+		return nil
+	}
+
+	recipients, err := resolver.Resolve(to)
+	if err != nil {
+		// Fall back to legacy routing if resolver fails
+		msgCopy := *msg
+		msgCopy.To = to
+		if err := send(&msgCopy); err != nil {
+			return nil, fmt.Errorf("sending message: %w", err)
+		}
+		return []string{to}, nil
+	}
+
+	// Route based on recipient type
+	var recipientAddrs []string
+	for _, rec := range recipients {
+		msgCopy := *msg
+		msgCopy.To = rec.Address
+		switch rec.Type {
+		case mail.RecipientQueue:
+			// Queue messages: single message, workers claim
+			if err := send(&msgCopy); err != nil {
+				return nil, fmt.Errorf("sending to queue: %w", err)
+			}
+		case mail.RecipientChannel:
+			// Channel messages: single message, broadcast
+			if err := send(&msgCopy); err != nil {
+				return nil, fmt.Errorf("sending to channel: %w", err)
+			}
+		default:
+			// Direct/agent messages: fan out to each recipient
+			if err := send(&msgCopy); err != nil {
+				return nil, fmt.Errorf("sending to %s: %w", rec.Address, err)
+			}
+		}
+		recipientAddrs = append(recipientAddrs, rec.Address)
+	}
+	return recipientAddrs, nil
+}
+
 // generateThreadID creates a random thread ID for new message threads.
 func generateThreadID() string {
 	b := make([]byte, 6)
@@ -296,3 +296,17 @@ func TestAnnounceMessageParsing(t *testing.T) {
 		})
 	}
 }
+
+func TestMailExportFileName(t *testing.T) {
+	tests := map[string]string{
+		"mayor/":           "mayor.mail.tar.gz",
+		"gastown/witness":  "gastown-witness.mail.tar.gz",
+		"gastown/crew/max": "gastown-crew-max.mail.tar.gz",
+		"/":                "mail.tar.gz",
+	}
+	for address, want := range tests {
+		if got := mailExportFileName(address); got != want {
+			t.Errorf("mailExportFileName(%q) = %q, want %q", address, got, want)
+		}
+	}
+}
@@ -125,6 +125,10 @@ func runMailReply(cmd *cobra.Command, args []string) error {
 		ThreadID: original.ThreadID,
 	}
 
+	if mailReplyAll {
+		_, reply.CC = original.ReplyAllRecipients(from)
+	}
+
 	// If original has no thread ID, create one
 	if reply.ThreadID == "" {
 		reply.ThreadID = generateThreadID()
@@ -137,6 +141,9 @@ func runMailReply(cmd *cobra.Command, args []string) error {
 
 	fmt.Printf("%s Reply sent to %s\n", style.Bold.Render("✓"), original.From)
 	fmt.Printf("  Subject: %s\n", subject)
+	if len(reply.CC) > 0 {
+		fmt.Printf("  CC: %s\n", strings.Join(reply.CC, ", "))
+	}
 	if original.ThreadID != "" {
 		fmt.Printf("  Thread: %s\n", style.Dim.Render(original.ThreadID))
 	}
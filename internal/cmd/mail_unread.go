@@ -0,0 +1,230 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/mail"
+	"github.com/steveyegge/gastown/internal/tui/inbox"
+)
+
+// Unread command flags
+var (
+	mailUnreadAddress string
+	mailUnreadFormat  string
+	mailUnreadMaxAge  time.Duration
+	mailUnreadRefresh bool
+)
+
+// unreadRefreshTimeout is how long a background refresh may hold its lock
+// before another prompt is allowed to start one.
+const unreadRefreshTimeout = 30 * time.Second
+
+var mailUnreadCmd = &cobra.Command{
+	Use:   "unread",
+	Short: "Print an unread-mail badge for shell prompts and tmux",
+	Long: `Print a compact badge of unread mail, for tmux status bars and shell
+prompts. Nothing is printed when there is no unread mail.
+
+  ✉3 ?1 !1    3 unread, 1 asks a question, 1 is urgent
+
+Crew mailboxes kept as a Maildir are counted from the Maildir index.
+Beads-backed mailboxes are counted from a cache under <town>/.runtime/mail,
+so the command answers in milliseconds either way. A cache older than
+--max-age is printed as is and refreshed in the background; delivering new
+mail expires it at once. Errors print nothing, so a broken town never
+garbles a prompt.
+
+Formats:
+  plain   Text badge (default)
+  tmux    Badge with tmux #[fg=...] color codes
+
+Examples:
+  gt mail unread --address overseer
+  gt mail unread --address overseer --format tmux
+
+  # ~/.tmux.conf
+  set -g status-right '#(cd ~/gt && gt mail unread --address overseer --format tmux)'
+  set -g status-interval 5
+
+  # ~/.bashrc
+  PS1='$(cd ~/gt && gt mail unread --address overseer) '"$PS1"`,
+	Args:          cobra.NoArgs,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE:          runMailUnread,
+}
+
+func init() {
+	mailUnreadCmd.Flags().StringVar(&mailUnreadAddress, "address", "", "Mailbox address (default: current context)")
+	mailUnreadCmd.Flags().StringVar(&mailUnreadFormat, "format", "plain", "Output format: plain or tmux")
+	mailUnreadCmd.Flags().DurationVar(&mailUnreadMaxAge, "max-age", 15*time.Second, "Refresh counts cached longer than this")
+	mailUnreadCmd.Flags().BoolVar(&mailUnreadRefresh, "refresh", false, "Recount unread mail now")
+	_ = mailUnreadCmd.Flags().MarkHidden("refresh")
+
+	mailCmd.AddCommand(mailUnreadCmd)
+}
+
+func runMailUnread(cmd *cobra.Command, args []string) error {
+	if mailUnreadFormat != "plain" && mailUnreadFormat != "tmux" {
+		return fmt.Errorf("invalid --format %q: must be plain or tmux", mailUnreadFormat)
+	}
+
+	townRoot, err := findMailWorkDir()
+	if err != nil {
+		return nil
+	}
+	address := mailUnreadAddress
+	if address == "" {
+		address = detectSender()
+	}
+
+	if mailUnreadRefresh {
+		defer func() { _ = os.Remove(unreadRefreshLock(townRoot, address)) }()
+		_, err := refreshUnreadSummary(townRoot, address)
+		return err
+	}
+
+	// A Maildir's index makes listing cheap, so it needs no cache
+	if dir := crewMaildir(townRoot, address); dir != "" {
+		messages, err := mail.NewMailbox(dir).ListUnread()
+		if err != nil {
+			return nil
+		}
+		if badge := formatUnreadBadge(summarizeUnread(address, messages), mailUnreadFormat); badge != "" {
+			fmt.Println(badge)
+		}
+		return nil
+	}
+
+	summary := mail.LoadUnreadSummary(townRoot, address)
+	if summary == nil {
+		// First run for this mailbox: count now so the badge isn't blank
+		if summary, err = refreshUnreadSummary(townRoot, address); err != nil {
+			return nil
+		}
+	} else if age, ok := mail.UnreadSummaryAge(townRoot, address); !ok || age > mailUnreadMaxAge {
+		startUnreadRefresh(townRoot, address)
+	}
+
+	if badge := formatUnreadBadge(summary, mailUnreadFormat); badge != "" {
+		fmt.Println(badge)
+	}
+	return nil
+}
+
+// crewMaildir returns the Maildir of a crew address (<rig>/crew/<name>), or
+// "" if the address has none.
+func crewMaildir(townRoot, address string) string {
+	parts := strings.Split(strings.TrimSuffix(address, "/"), "/")
+	if len(parts) != 3 || parts[1] != "crew" {
+		return ""
+	}
+	dir := filepath.Join(townRoot, parts[0], "crew", parts[2], "mail")
+	if !mail.IsMaildir(dir) {
+		return ""
+	}
+	return dir
+}
+
+// refreshUnreadSummary counts the mailbox's unread mail and caches it.
+func refreshUnreadSummary(townRoot, address string) (*mail.UnreadSummary, error) {
+	mailbox, err := mail.NewRouter(townRoot).GetMailbox(address)
+	if err != nil {
+		return nil, err
+	}
+	messages, err := mailbox.ListUnread()
+	if err != nil {
+		return nil, err
+	}
+	summary := summarizeUnread(address, messages)
+	if err := mail.SaveUnreadSummary(townRoot, summary); err != nil {
+		return nil, err
+	}
+	return summary, nil
+}
+
+// summarizeUnread counts unread messages, questions and urgent messages.
+func summarizeUnread(address string, messages []*mail.Message) *mail.UnreadSummary {
+	summary := &mail.UnreadSummary{Address: address, UpdatedAt: time.Now()}
+	for _, msg := range messages {
+		if msg.Read {
+			continue
+		}
+		summary.Unread++
+		if inbox.InferMessageType(msg) == inbox.TypeQuestion {
+			summary.Questions++
+		}
+		if msg.Priority == mail.PriorityUrgent {
+			summary.Urgent++
+		}
+	}
+	return summary
+}
+
+// unreadRefreshLock is held while a background refresh for address runs.
+func unreadRefreshLock(townRoot, address string) string {
+	return mail.UnreadSummaryPath(townRoot, address) + ".lock"
+}
+
+// startUnreadRefresh recounts the mailbox in a detached 'gt mail unread
+// --refresh', unless one is already running for it. The refresh removes
+// the lock when it exits.
+func startUnreadRefresh(townRoot, address string) {
+	lock := unreadRefreshLock(townRoot, address)
+	if info, err := os.Stat(lock); err == nil && time.Since(info.ModTime()) < unreadRefreshTimeout {
+		return
+	}
+	_ = os.Remove(lock)
+	f, err := os.OpenFile(lock, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644) //nolint:gosec // G304: path is built from the town root
+	if err != nil {
+		return
+	}
+	_ = f.Close()
+
+	gtPath, err := os.Executable()
+	if err != nil {
+		_ = os.Remove(lock)
+		return
+	}
+	refresh := exec.Command(gtPath, "mail", "unread", "--refresh", "--address", address) //nolint:gosec // G204: gtPath is this executable
+	refresh.Dir = townRoot
+	refresh.Stdin = nil
+	refresh.Stdout = nil
+	refresh.Stderr = nil
+	if err := refresh.Start(); err != nil {
+		_ = os.Remove(lock)
+		return
+	}
+	_ = refresh.Process.Release()
+}
+
+// formatUnreadBadge renders the badge, or "" when nothing is unread.
+func formatUnreadBadge(s *mail.UnreadSummary, format string) string {
+	if s == nil || s.Unread == 0 {
+		return ""
+	}
+	type part struct{ text, color string }
+	parts := []part{{fmt.Sprintf("✉%d", s.Unread), "yellow"}}
+	if s.Questions > 0 {
+		parts = append(parts, part{fmt.Sprintf("?%d", s.Questions), "cyan,bold"})
+	}
+	if s.Urgent > 0 {
+		parts = append(parts, part{fmt.Sprintf("!%d", s.Urgent), "red,bold"})
+	}
+
+	out := make([]string, len(parts))
+	for i, p := range parts {
+		if format == "tmux" {
+			out[i] = "#[fg=" + p.color + "]" + p.text + "#[default]"
+		} else {
+			out[i] = p.text
+		}
+	}
+	return strings.Join(out, " ")
+}
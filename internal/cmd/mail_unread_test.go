@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/mail"
+)
+
+func TestSummarizeUnread(t *testing.T) {
+	messages := []*mail.Message{
+		{Subject: "Should I rebase gt-42?"},
+		{Subject: "[question] which branch"},
+		{Subject: "Build broken", Priority: mail.PriorityUrgent},
+		{Subject: "FYI: merged"},
+		{Subject: "Already seen?", Read: true},
+	}
+	s := summarizeUnread("overseer", messages)
+	if s.Unread != 4 || s.Questions != 2 || s.Urgent != 1 {
+		t.Errorf("summarizeUnread() = %d unread, %d questions, %d urgent; want 4, 2, 1", s.Unread, s.Questions, s.Urgent)
+	}
+}
+
+func TestFormatUnreadBadge(t *testing.T) {
+	tests := []struct {
+		name    string
+		summary *mail.UnreadSummary
+		format  string
+		want    string
+	}{
+		{"nil", nil, "plain", ""},
+		{"nothing unread", &mail.UnreadSummary{}, "tmux", ""},
+		{"unread only", &mail.UnreadSummary{Unread: 2}, "plain", "✉2"},
+		{"plain", &mail.UnreadSummary{Unread: 3, Questions: 1, Urgent: 1}, "plain", "✉3 ?1 !1"},
+		{"tmux", &mail.UnreadSummary{Unread: 3, Questions: 1}, "tmux", "#[fg=yellow]✉3#[default] #[fg=cyan,bold]?1#[default]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatUnreadBadge(tt.summary, tt.format); got != tt.want {
+				t.Errorf("formatUnreadBadge() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCrewMaildir(t *testing.T) {
+	townRoot := t.TempDir()
+	dir := filepath.Join(townRoot, "gastown", "crew", "max", "mail")
+	if err := mail.NewMaildirStore(dir).Append(&mail.Message{ID: "m1", Subject: "hello"}); err != nil {
+		t.Fatalf("Append: %v", err)
+	}
+
+	if got := crewMaildir(townRoot, "gastown/crew/max"); got != dir {
+		t.Errorf("crewMaildir(gastown/crew/max) = %q, want %q", got, dir)
+	}
+	for _, address := range []string{"gastown/crew/joe", "overseer", "gastown/polecats/max"} {
+		if got := crewMaildir(townRoot, address); got != "" {
+			t.Errorf("crewMaildir(%s) = %q, want \"\"", address, got)
+		}
+	}
+}
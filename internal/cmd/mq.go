@@ -22,6 +22,7 @@ var (
 	mqSubmitEpic      string
 	mqSubmitPriority  int
 	mqSubmitNoCleanup bool
+	mqSubmitStackedOn string
 
 	// Retry flags
 	mqRetryNow bool
@@ -88,6 +89,13 @@ Target branch auto-detection:
 
 This ensures batch work on epics automatically flows to integration branches.
 
+Stacked MRs:
+  A branch built on top of another of the worker's open MRs is submitted
+  stacked on it (or name the MR with --stacked-on). The stacked MR takes
+  its parent's target and merges only after the parent does; the Refinery
+  then rebases it onto the target. If the parent fails to merge, the MRs
+  stacked on it fail with it.
+
 Polecat auto-cleanup:
   When run from a polecat work branch (polecat/<worker>/<issue>), this command
   automatically triggers polecat shutdown after submitting the MR. The polecat
@@ -101,7 +109,8 @@ Examples:
   gt mq submit --issue gp-abc            # Explicit issue
   gt mq submit --epic gt-xyz             # Target integration branch explicitly
   gt mq submit --priority 0              # Override priority (P0)
-  gt mq submit --no-cleanup              # Submit without auto-cleanup`,
+  gt mq submit --no-cleanup              # Submit without auto-cleanup
+  gt mq submit --stacked-on gt-mr-abc    # Stack on an open MR's branch`,
 	RunE: runMqSubmit,
 }
 
@@ -166,12 +175,39 @@ var mqStatusCmd = &cobra.Command{
 Shows all MR fields, current status with timestamps, dependencies,
 blockers, and processing history.
 
+Open MRs also show a risk score (0-100) from diff size, files touched,
+how much of the change is tests, and how often merges touching the same
+areas have failed tests. If the rig sets merge_queue.risk_approval_threshold,
+MRs at or above it are assigned to the overseer until approved with
+'gt mq approve'.
+
+Rigs that throttle merges show the MR's standing: merges of its epic in the
+last 24 hours against merge_queue.max_merges_per_epic_per_day, its worker's
+last merge against merge_queue.worker_cooldown, and until when it is held.
+
 Example:
   gt mq status gp-mr-abc123`,
 	Args: cobra.ExactArgs(1),
 	RunE: runMqStatus,
 }
 
+var mqApproveCmd = &cobra.Command{
+	Use:   "approve <rig> <mr-id>",
+	Short: "Approve a high-risk merge request",
+	Long: `Approve a merge request held by the risk gate.
+
+When merge_queue.risk_approval_threshold is set, the refinery assigns MRs
+whose risk score reaches it to the overseer instead of merging them.
+Approving records who signed off and returns the MR to the ready queue.
+
+See 'gt mq status <id>' for the risk breakdown.
+
+Examples:
+  gt mq approve greenplace gp-mr-abc123`,
+	Args: cobra.ExactArgs(2),
+	RunE: runMQApprove,
+}
+
 var mqIntegrationCmd = &cobra.Command{
 	Use:   "integration",
 	Short: "Manage integration branches for epics",
@@ -276,6 +312,7 @@ func init() {
 	mqSubmitCmd.Flags().StringVar(&mqSubmitEpic, "epic", "", "Target epic's integration branch instead of main")
 	mqSubmitCmd.Flags().IntVarP(&mqSubmitPriority, "priority", "p", -1, "Override priority (0-4, default: inherit from issue)")
 	mqSubmitCmd.Flags().BoolVar(&mqSubmitNoCleanup, "no-cleanup", false, "Don't auto-cleanup after submit (for polecats)")
+	mqSubmitCmd.Flags().StringVar(&mqSubmitStackedOn, "stacked-on", "", "Open MR this branch builds on (default: detect from the worker's open MRs)")
 
 	// Retry flags
 	mqRetryCmd.Flags().BoolVar(&mqRetryNow, "now", false, "Immediately process instead of waiting for refinery loop")
@@ -301,6 +338,7 @@ func init() {
 	mqCmd.AddCommand(mqListCmd)
 	mqCmd.AddCommand(mqRejectCmd)
 	mqCmd.AddCommand(mqStatusCmd)
+	mqCmd.AddCommand(mqApproveCmd)
 
 	// Integration branch subcommands
 	mqIntegrationCreateCmd.Flags().StringVar(&mqIntegrationCreateBranch, "branch", "", "Override branch name template (supports {epic}, {prefix}, {user})")
@@ -432,3 +470,23 @@ func runMQReject(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+func runMQApprove(cmd *cobra.Command, args []string) error {
+	rigName := args[0]
+	mrID := args[1]
+
+	_, r, _, err := getRefineryManager(rigName)
+	if err != nil {
+		return err
+	}
+
+	approver := detectSender()
+	if err := refinery.NewEngineer(r).ApproveMR(mrID, approver); err != nil {
+		return fmt.Errorf("approving MR: %w", err)
+	}
+
+	fmt.Printf("%s Approved: %s\n", style.Bold.Render("✓"), mrID)
+	fmt.Printf("  Approved by: %s\n", approver)
+	fmt.Printf("  %s\n", style.Dim.Render("Will be merged on next refinery cycle"))
+	return nil
+}
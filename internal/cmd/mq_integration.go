@@ -6,129 +6,40 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
-	"regexp"
 	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/gastown/internal/beads"
 	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/refinery"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/workspace"
 )
 
-// Integration branch template constants
-const defaultIntegrationBranchTemplate = "integration/{epic}"
+// defaultIntegrationBranchTemplate is refinery.DefaultIntegrationBranchTemplate.
+const defaultIntegrationBranchTemplate = refinery.DefaultIntegrationBranchTemplate
 
-// invalidBranchCharsRegex matches characters that are invalid in git branch names.
-// Git branch names cannot contain: ~ ^ : \ space, .., @{, or end with .lock
-var invalidBranchCharsRegex = regexp.MustCompile(`[~^:\s\\]|\.\.|\.\.|@\{`)
-
-// buildIntegrationBranchName expands an integration branch template with variables.
-// Variables supported:
-//   - {epic}: Full epic ID (e.g., "RA-123")
-//   - {prefix}: Epic prefix before first hyphen (e.g., "RA")
-//   - {user}: Git user.name (e.g., "klauern")
-//
-// If template is empty, uses defaultIntegrationBranchTemplate.
+// buildIntegrationBranchName expands an integration branch template with
+// variables (see refinery.IntegrationBranchName).
 func buildIntegrationBranchName(template, epicID string) string {
-	if template == "" {
-		template = defaultIntegrationBranchTemplate
-	}
-
-	result := template
-	result = strings.ReplaceAll(result, "{epic}", epicID)
-	result = strings.ReplaceAll(result, "{prefix}", extractEpicPrefix(epicID))
-
-	// Git user (optional - leaves placeholder if not available)
-	if user := getGitUserName(); user != "" {
-		result = strings.ReplaceAll(result, "{user}", user)
-	}
-
-	return result
+	return refinery.IntegrationBranchName(template, epicID)
 }
 
 // extractEpicPrefix extracts the prefix from an epic ID (before the first hyphen).
-// Examples: "RA-123" -> "RA", "PROJ-456" -> "PROJ", "abc" -> "abc"
 func extractEpicPrefix(epicID string) string {
-	if idx := strings.Index(epicID, "-"); idx > 0 {
-		return epicID[:idx]
-	}
-	return epicID
-}
-
-// getGitUserName returns the git user.name config value, or empty if not set.
-func getGitUserName() string {
-	cmd := exec.Command("git", "config", "user.name")
-	out, err := cmd.Output()
-	if err != nil {
-		return ""
-	}
-	return strings.TrimSpace(string(out))
+	return refinery.EpicPrefix(epicID)
 }
 
 // validateBranchName checks if a branch name is valid for git.
-// Returns an error if the branch name contains invalid characters.
 func validateBranchName(branchName string) error {
-	if branchName == "" {
-		return fmt.Errorf("branch name cannot be empty")
-	}
-
-	// Check for invalid characters
-	if invalidBranchCharsRegex.MatchString(branchName) {
-		return fmt.Errorf("branch name %q contains invalid characters (~ ^ : \\ space, .., or @{)", branchName)
-	}
-
-	// Check for .lock suffix
-	if strings.HasSuffix(branchName, ".lock") {
-		return fmt.Errorf("branch name %q cannot end with .lock", branchName)
-	}
-
-	// Check for leading/trailing slashes or dots
-	if strings.HasPrefix(branchName, "/") || strings.HasSuffix(branchName, "/") {
-		return fmt.Errorf("branch name %q cannot start or end with /", branchName)
-	}
-	if strings.HasPrefix(branchName, ".") || strings.HasSuffix(branchName, ".") {
-		return fmt.Errorf("branch name %q cannot start or end with .", branchName)
-	}
-
-	// Check for consecutive slashes
-	if strings.Contains(branchName, "//") {
-		return fmt.Errorf("branch name %q cannot contain consecutive slashes", branchName)
-	}
-
-	return nil
+	return refinery.ValidateBranchName(branchName)
 }
 
 // getIntegrationBranchField extracts the integration_branch field from an epic's description.
 // Returns empty string if the field is not found.
 func getIntegrationBranchField(description string) string {
-	if description == "" {
-		return ""
-	}
-
-	lines := strings.Split(description, "\n")
-	for _, line := range lines {
-		trimmed := strings.TrimSpace(line)
-		if strings.HasPrefix(strings.ToLower(trimmed), "integration_branch:") {
-			value := strings.TrimPrefix(trimmed, "integration_branch:")
-			value = strings.TrimPrefix(value, "Integration_branch:")
-			value = strings.TrimPrefix(value, "INTEGRATION_BRANCH:")
-			// Handle case variations
-			for _, prefix := range []string{"integration_branch:", "Integration_branch:", "INTEGRATION_BRANCH:"} {
-				if strings.HasPrefix(trimmed, prefix) {
-					value = strings.TrimPrefix(trimmed, prefix)
-					break
-				}
-			}
-			// Re-parse properly - the prefix removal above is messy
-			parts := strings.SplitN(trimmed, ":", 2)
-			if len(parts) == 2 {
-				return strings.TrimSpace(parts[1])
-			}
-		}
-	}
-	return ""
+	return refinery.IntegrationBranchField(description)
 }
 
 // getIntegrationBranchTemplate returns the integration branch template to use.
@@ -137,19 +48,7 @@ func getIntegrationBranchTemplate(rigPath, cliOverride string) string {
 	if cliOverride != "" {
 		return cliOverride
 	}
-
-	// Try to load rig settings
-	settingsPath := filepath.Join(rigPath, "settings", "config.json")
-	settings, err := config.LoadRigSettings(settingsPath)
-	if err != nil {
-		return defaultIntegrationBranchTemplate
-	}
-
-	if settings.MergeQueue != nil && settings.MergeQueue.IntegrationBranchTemplate != "" {
-		return settings.MergeQueue.IntegrationBranchTemplate
-	}
-
-	return defaultIntegrationBranchTemplate
+	return refinery.IntegrationBranchTemplate(rigPath)
 }
 
 // IntegrationStatusOutput is the JSON output structure for integration status.
@@ -276,35 +175,7 @@ func runMqIntegrationCreate(cmd *cobra.Command, args []string) error {
 
 // addIntegrationBranchField adds or updates the integration_branch field in a description.
 func addIntegrationBranchField(description, branchName string) string {
-	fieldLine := "integration_branch: " + branchName
-
-	// If description is empty, just return the field
-	if description == "" {
-		return fieldLine
-	}
-
-	// Check if integration_branch field already exists
-	lines := strings.Split(description, "\n")
-	var newLines []string
-	found := false
-
-	for _, line := range lines {
-		trimmed := strings.TrimSpace(line)
-		if strings.HasPrefix(strings.ToLower(trimmed), "integration_branch:") {
-			// Replace existing field
-			newLines = append(newLines, fieldLine)
-			found = true
-		} else {
-			newLines = append(newLines, line)
-		}
-	}
-
-	if !found {
-		// Add field at the beginning
-		newLines = append([]string{fieldLine}, newLines...)
-	}
-
-	return strings.Join(newLines, "\n")
+	return refinery.SetIntegrationBranchField(description, branchName)
 }
 
 // runMqIntegrationLand merges an integration branch to main.
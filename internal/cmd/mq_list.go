@@ -90,6 +90,11 @@ func runMQList(cmd *cobra.Command, args []string) error {
 		// Parse MR fields
 		fields := beads.ParseMRFields(issue)
 
+		// Skipped MRs (gt mq skip) aren't ready until the skip expires
+		if mqListReady && fields.SkippedAt(now) {
+			continue
+		}
+
 		// Filter by worker
 		if mqListWorker != "" {
 			worker := ""
@@ -136,6 +141,7 @@ func runMQList(cmd *cobra.Command, args []string) error {
 
 	// Human-readable output
 	fmt.Printf("%s Merge queue for '%s':\n\n", style.Bold.Render("📋"), rigName)
+	printQueuePause(r.Path, rigName)
 
 	if len(filtered) == 0 {
 		fmt.Printf("  %s\n", style.Dim.Render("(empty)"))
@@ -163,6 +169,8 @@ func runMQList(cmd *cobra.Command, args []string) error {
 		if issue.Status == "open" {
 			if len(issue.BlockedBy) > 0 || issue.BlockedByCount > 0 {
 				displayStatus = "blocked"
+			} else if fields.SkippedAt(now) {
+				displayStatus = "skipped"
 			} else {
 				displayStatus = "ready"
 			}
@@ -177,6 +185,8 @@ func runMQList(cmd *cobra.Command, args []string) error {
 			styledStatus = style.Warning.Render("active")
 		case "blocked":
 			styledStatus = style.Dim.Render("blocked")
+		case "skipped":
+			styledStatus = style.Dim.Render("skipped")
 		case "closed":
 			styledStatus = style.Dim.Render("closed")
 		}
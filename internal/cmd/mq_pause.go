@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/refinery"
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+var mqPauseCmd = &cobra.Command{
+	Use:   "pause <rig> [reason]",
+	Short: "Stop the refinery from merging",
+	Long: `Pause a rig's merge queue.
+
+While paused, the refinery's ready queue is empty and nothing merges.
+Submitted MRs keep queueing and merge in order once the queue resumes.
+
+The refinery pauses the queue itself when the target branch fails its
+pre-merge checks: the branch doesn't exist, it is behind its remote, or
+the refinery checkout has local changes. It also pauses when the
+changelog entry can't be written or the merge commit isn't signed, since
+no MR can fix the refinery's own setup. The overseer is mailed the
+reason. Fix the problem, then resume.
+
+Examples:
+  gt mq pause greenplace "release freeze"
+  gt mq resume greenplace`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runMQPause,
+}
+
+var mqResumeCmd = &cobra.Command{
+	Use:   "resume <rig>",
+	Short: "Resume a paused merge queue",
+	Long: `Resume a rig's paused merge queue.
+
+The refinery picks up the ready queue on its next cycle. If the target
+branch still fails its checks, the queue pauses again.
+
+Examples:
+  gt mq resume greenplace`,
+	Args: cobra.ExactArgs(1),
+	RunE: runMQResume,
+}
+
+func init() {
+	mqCmd.AddCommand(mqPauseCmd)
+	mqCmd.AddCommand(mqResumeCmd)
+}
+
+func runMQPause(cmd *cobra.Command, args []string) error {
+	_, r, rigName, err := getRefineryManager(args[0])
+	if err != nil {
+		return err
+	}
+	reason := strings.Join(args[1:], " ")
+	if reason == "" {
+		reason = "paused by operator"
+	}
+
+	if err := refinery.PauseQueue(r.Path, refinery.QueuePause{Reason: reason, PausedBy: detectSender()}); err != nil {
+		return fmt.Errorf("pausing merge queue: %w", err)
+	}
+	fmt.Printf("%s Merge queue paused: %s\n", style.Bold.Render("⏸"), rigName)
+	fmt.Printf("  %s\n", style.Dim.Render("Resume with: gt mq resume "+rigName))
+	return nil
+}
+
+func runMQResume(cmd *cobra.Command, args []string) error {
+	_, r, rigName, err := getRefineryManager(args[0])
+	if err != nil {
+		return err
+	}
+
+	pause, err := refinery.LoadQueuePause(r.Path)
+	if err != nil {
+		return err
+	}
+	if pause == nil {
+		fmt.Printf("%s Merge queue for %s is not paused\n", style.Dim.Render("○"), rigName)
+		return nil
+	}
+	if err := refinery.ResumeQueue(r.Path); err != nil {
+		return fmt.Errorf("resuming merge queue: %w", err)
+	}
+	fmt.Printf("%s Merge queue resumed: %s\n", style.Bold.Render("✓"), rigName)
+	fmt.Printf("  Was paused %s: %s\n", pause.PausedAt.Format("2006-01-02 15:04"), pause.Reason)
+	return nil
+}
+
+// printQueuePause warns that a rig's merge queue is paused, or that its
+// refinery is skipping MRs because its environment is unhealthy.
+func printQueuePause(rigPath, rigName string) {
+	if issue, err := refinery.LoadHealthIssue(rigPath); err == nil && issue != nil {
+		fmt.Printf("%s\n", style.Warning.Render(fmt.Sprintf("⚠ Refinery unhealthy since %s, skipping MRs:",
+			issue.Since.Format("2006-01-02 15:04"))))
+		for _, p := range issue.Problems {
+			fmt.Printf("  - %s\n", p)
+		}
+		fmt.Println()
+	}
+
+	pause, err := refinery.LoadQueuePause(rigPath)
+	if err != nil || pause == nil {
+		return
+	}
+	fmt.Printf("%s\n", style.Warning.Render(fmt.Sprintf("⏸ Merge queue paused since %s: %s",
+		pause.PausedAt.Format("2006-01-02 15:04"), pause.Reason)))
+	fmt.Printf("  %s\n\n", style.Dim.Render("Resume with: gt mq resume "+rigName))
+}
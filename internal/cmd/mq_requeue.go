@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/refinery"
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+// Skip command flags
+var mqSkipFor string
+
+var mqRequeueCmd = &cobra.Command{
+	Use:   "requeue <rig> <mr-id>",
+	Short: "Put a failed, closed or skipped MR back in the ready queue",
+	Long: `Put a merge request back in the refinery's ready queue.
+
+Reopens a closed MR (e.g. rejected or closed on conflict) and clears a
+stale refinery claim or a skip from 'gt mq skip'. The refinery picks it up
+on its next queue scan. MRs held for risk approval stay held until
+'gt mq approve', and open blockers such as a conflict-resolution task
+still apply.
+
+Examples:
+  gt mq requeue greenplace gp-mr-abc123`,
+	Args: cobra.ExactArgs(2),
+	RunE: runMQRequeue,
+}
+
+var mqReprioritizeCmd = &cobra.Command{
+	Use:   "reprioritize <rig> <mr-id> <priority>",
+	Short: "Change a merge request's priority",
+	Long: `Change a merge request's priority in the queue.
+
+Priority is 0-4 (or P0-P4, P0 highest), or "up"/"down" to bump it one
+level. The refinery orders the queue by priority on its next scan.
+
+Examples:
+  gt mq reprioritize greenplace gp-mr-abc123 P0
+  gt mq reprioritize greenplace gp-mr-abc123 up
+  gt mq reprioritize greenplace gp-mr-abc123 down`,
+	Args: cobra.ExactArgs(3),
+	RunE: runMQReprioritize,
+}
+
+var mqSkipCmd = &cobra.Command{
+	Use:   "skip <rig> <mr-id>",
+	Short: "Leave a merge request out of the queue for a while",
+	Long: `Temporarily leave a merge request out of the refinery's ready queue.
+
+The MR stays open and returns to the queue by itself once the skip
+expires; 'gt mq requeue' lifts it early. 'gt mq list' shows skipped MRs
+as skipped.
+
+Examples:
+  gt mq skip greenplace gp-mr-abc123            # For an hour
+  gt mq skip greenplace gp-mr-abc123 --for 1d`,
+	Args: cobra.ExactArgs(2),
+	RunE: runMQSkip,
+}
+
+func init() {
+	mqSkipCmd.Flags().StringVar(&mqSkipFor, "for", "1h", "How long to skip the MR (e.g. 30m, 4h, 2d)")
+
+	mqCmd.AddCommand(mqRequeueCmd)
+	mqCmd.AddCommand(mqReprioritizeCmd)
+	mqCmd.AddCommand(mqSkipCmd)
+}
+
+func runMQRequeue(cmd *cobra.Command, args []string) error {
+	_, r, _, err := getRefineryManager(args[0])
+	if err != nil {
+		return err
+	}
+	mrID := args[1]
+
+	prev, err := refinery.NewEngineer(r).RequeueMR(mrID)
+	if err != nil {
+		return fmt.Errorf("requeueing MR: %w", err)
+	}
+
+	fmt.Printf("%s Requeued: %s\n", style.Bold.Render("✓"), mrID)
+	if prev.Status != "open" {
+		fmt.Printf("  Reopened (was %s)\n", prev.Status)
+	}
+	if prev.Assignee == refinery.RiskApprover {
+		fmt.Printf("  %s\n", style.Warning.Render("Still held for risk approval: gt mq approve "+args[0]+" "+mrID))
+	}
+	if len(prev.BlockedBy) > 0 {
+		fmt.Printf("  %s\n", style.Dim.Render("Blocked by: "+strings.Join(prev.BlockedBy, ", ")))
+	}
+	fmt.Printf("  %s\n", style.Dim.Render("Will be processed on next refinery cycle"))
+	return nil
+}
+
+func runMQReprioritize(cmd *cobra.Command, args []string) error {
+	_, r, _, err := getRefineryManager(args[0])
+	if err != nil {
+		return err
+	}
+	mrID := args[1]
+
+	issue, err := beads.New(r.BeadsPath()).Show(mrID)
+	if err != nil {
+		return fmt.Errorf("fetching merge request: %w", err)
+	}
+	priority, err := parseMRPriority(args[2], issue.Priority)
+	if err != nil {
+		return err
+	}
+	if priority == issue.Priority {
+		fmt.Printf("%s %s is already P%d\n", style.Dim.Render("○"), mrID, priority)
+		return nil
+	}
+
+	if err := refinery.NewEngineer(r).SetMRPriority(mrID, priority); err != nil {
+		return fmt.Errorf("reprioritizing MR: %w", err)
+	}
+	fmt.Printf("%s %s: P%d → P%d\n", style.Bold.Render("✓"), mrID, issue.Priority, priority)
+	return nil
+}
+
+func runMQSkip(cmd *cobra.Command, args []string) error {
+	d, err := parseDuration(mqSkipFor)
+	if err != nil || d <= 0 {
+		return fmt.Errorf("invalid --for %q (want a duration like 30m, 4h or 2d)", mqSkipFor)
+	}
+	_, r, _, err := getRefineryManager(args[0])
+	if err != nil {
+		return err
+	}
+	mrID := args[1]
+
+	until := time.Now().Add(d)
+	if err := refinery.NewEngineer(r).SkipMR(mrID, until); err != nil {
+		return fmt.Errorf("skipping MR: %w", err)
+	}
+	fmt.Printf("%s Skipped: %s until %s\n", style.Bold.Render("✓"), mrID, until.Format("2006-01-02 15:04"))
+	fmt.Printf("  %s\n", style.Dim.Render("Requeue early with: gt mq requeue "+args[0]+" "+mrID))
+	return nil
+}
+
+// parseMRPriority parses a priority argument: 0-4, P0-P4, or "up"/"down"
+// relative to current. Bumps stop at P0 and P4.
+func parseMRPriority(arg string, current int) (int, error) {
+	switch strings.ToLower(arg) {
+	case "up":
+		return max(current-1, 0), nil
+	case "down":
+		return min(current+1, refinery.MaxMRPriority), nil
+	}
+	n, err := strconv.Atoi(strings.TrimPrefix(strings.ToUpper(arg), "P"))
+	if err != nil || n < 0 || n > refinery.MaxMRPriority {
+		return 0, fmt.Errorf("invalid priority %q (want 0-%d, P0-P%d, up or down)", arg, refinery.MaxMRPriority, refinery.MaxMRPriority)
+	}
+	return n, nil
+}
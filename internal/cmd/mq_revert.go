@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/refinery"
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+// Revert command flags
+var mqRevertReason string
+
+var mqRevertCmd = &cobra.Command{
+	Use:   "revert <rig> <merge-commit>",
+	Short: "Revert a merge through the merge queue",
+	Long: `Revert a merge that broke the target branch.
+
+Commits a revert of the merge on a new revert/<sha> branch and queues it
+as a P0 merge request, so the revert goes through the usual conflict
+check, tests and push. Merge commits are reverted against their first
+parent.
+
+The source issue of the reverted merge is reopened with the "reverted"
+label, and its worker and watchers are mailed the reason.
+
+Examples:
+  gt mq revert greenplace 3f2a9c1
+  gt mq revert greenplace 3f2a9c1 --reason "breaks login on staging"`,
+	Args: cobra.ExactArgs(2),
+	RunE: runMQRevert,
+}
+
+var mqHealthCmd = &cobra.Command{
+	Use:   "health <rig>",
+	Short: "Run the post-merge health check on the target branch",
+	Long: `Run the rig's post-merge health check against the target branch HEAD.
+
+The check is the merge_queue.post_merge_check command in the rig's
+config.json, run in the refinery worktree. If it fails and auto_revert is
+set, the merge at HEAD is reverted as with 'gt mq revert' (a revert is
+never reverted automatically).
+
+The refinery runs this after each merge; run it by hand to check the
+target branch now.
+
+Config:
+  "merge_queue": {
+    "post_merge_check": "make smoke",
+    "post_merge_check_timeout": "10m",
+    "auto_revert": true
+  }
+
+Exits non-zero if the check fails.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runMQHealth,
+}
+
+func init() {
+	mqRevertCmd.Flags().StringVar(&mqRevertReason, "reason", "", "Why the merge is reverted (sent to the worker)")
+
+	mqCmd.AddCommand(mqRevertCmd)
+	mqCmd.AddCommand(mqHealthCmd)
+}
+
+func runMQRevert(cmd *cobra.Command, args []string) error {
+	eng, err := loadRefineryEngineer(args[0])
+	if err != nil {
+		return err
+	}
+
+	res, err := eng.RevertMerge(args[1], mqRevertReason)
+	if err != nil {
+		return fmt.Errorf("reverting %s: %w", args[1], err)
+	}
+	printRevert(res)
+	return nil
+}
+
+func runMQHealth(cmd *cobra.Command, args []string) error {
+	eng, err := loadRefineryEngineer(args[0])
+	if err != nil {
+		return err
+	}
+
+	result, err := eng.CheckPostMerge(context.Background())
+	if errors.Is(err, refinery.ErrNoPostMergeCheck) {
+		fmt.Printf("%s No post_merge_check configured for %s\n", style.Dim.Render("○"), args[0])
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	commit := result.Commit
+	if len(commit) > 8 {
+		commit = commit[:8]
+	}
+	if result.Passed {
+		fmt.Printf("%s Post-merge check passed at %s\n", style.Bold.Render("✓"), commit)
+		return nil
+	}
+
+	fmt.Printf("%s Post-merge check failed at %s: %s\n", style.Bold.Render("✗"), commit, result.Error)
+	if result.Output != "" {
+		fmt.Println(style.Dim.Render(result.Output))
+	}
+	if result.Revert != nil {
+		printRevert(result.Revert)
+	} else {
+		fmt.Printf("  %s\n", style.Warning.Render("Not reverted: "+result.Skipped))
+	}
+	return NewSilentExit(1)
+}
+
+// loadRefineryEngineer returns the rig's engineer with its merge queue
+// config loaded, logging to stderr.
+func loadRefineryEngineer(rigName string) (*refinery.Engineer, error) {
+	_, r, _, err := getRefineryManager(rigName)
+	if err != nil {
+		return nil, err
+	}
+	eng := refinery.NewEngineer(r)
+	eng.SetOutput(os.Stderr)
+	if err := eng.LoadConfig(); err != nil {
+		return nil, fmt.Errorf("loading merge queue config: %w", err)
+	}
+	return eng, nil
+}
+
+func printRevert(res *refinery.RevertResult) {
+	fmt.Printf("%s Revert queued: %s\n", style.Bold.Render("✓"), res.MRID)
+	fmt.Printf("  Branch: %s → %s\n", res.Branch, res.Target)
+	if res.RevertedMR != "" {
+		fmt.Printf("  Reverts: %s\n", res.RevertedMR)
+	}
+	if res.SourceIssue != "" {
+		fmt.Printf("  Reopened: %s (labeled %s)\n", res.SourceIssue, refinery.RevertLabel)
+	} else {
+		fmt.Printf("  %s\n", style.Warning.Render("No source issue found for the commit; nothing reopened"))
+	}
+	if res.Worker != "" {
+		fmt.Printf("  Notified: %s\n", res.Worker)
+	}
+	fmt.Printf("  %s\n", style.Dim.Render("Will be processed on next refinery cycle"))
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/refinery"
 	"github.com/steveyegge/gastown/internal/style"
 )
 
@@ -34,6 +35,14 @@ type MRStatusOutput struct {
 	MergeCommit string `json:"merge_commit,omitempty"`
 	CloseReason string `json:"close_reason,omitempty"`
 
+	// Risk scoring (open MRs only)
+	Risk           *refinery.RiskAssessment `json:"risk,omitempty"`
+	RiskThreshold  int                      `json:"risk_threshold,omitempty"`
+	RiskApprovedBy string                   `json:"risk_approved_by,omitempty"`
+
+	// Merge throttles (open MRs in rigs that configure them)
+	Throttle *refinery.ThrottleStatus `json:"throttle,omitempty"`
+
 	// Dependencies
 	DependsOn []DependencyInfo `json:"depends_on,omitempty"`
 	Blocks    []DependencyInfo `json:"blocks,omitempty"`
@@ -95,6 +104,19 @@ func runMqStatus(cmd *cobra.Command, args []string) error {
 		output.Rig = mrFields.Rig
 		output.MergeCommit = mrFields.MergeCommit
 		output.CloseReason = mrFields.CloseReason
+		output.RiskApprovedBy = mrFields.RiskApprovedBy
+
+		if issue.Status != "closed" {
+			if eng := mrEngineer(mrFields); eng != nil {
+				output.Risk, output.RiskThreshold = assessMRRisk(eng, mrFields)
+				output.Throttle, _ = eng.ThrottleStatus(&refinery.MRInfo{
+					ID:          issue.ID,
+					Target:      mrFields.Target,
+					SourceIssue: mrFields.SourceIssue,
+					Worker:      mrFields.Worker,
+				})
+			}
+		}
 	}
 
 	// Add dependency info from the issue's Dependencies field
@@ -127,11 +149,45 @@ func runMqStatus(cmd *cobra.Command, args []string) error {
 	}
 
 	// Human-readable output
-	return printMqStatus(issue, mrFields)
+	return printMqStatus(issue, mrFields, &output)
+}
+
+// mrEngineer returns the refinery engineer of an MR's rig with its config
+// loaded, or nil if the rig can't be loaded.
+func mrEngineer(mrFields *beads.MRFields) *refinery.Engineer {
+	if mrFields.Rig == "" {
+		return nil
+	}
+	_, r, _, err := getRefineryManager(mrFields.Rig)
+	if err != nil {
+		return nil
+	}
+	eng := refinery.NewEngineer(r)
+	if err := eng.LoadConfig(); err != nil {
+		return nil
+	}
+	return eng
+}
+
+// assessMRRisk scores an open MR's branch with its rig's refinery config.
+// Returns nil if the MR can't be scored (e.g., the branch isn't local).
+func assessMRRisk(eng *refinery.Engineer, mrFields *beads.MRFields) (*refinery.RiskAssessment, int) {
+	if mrFields.Branch == "" {
+		return nil, 0
+	}
+	target := mrFields.Target
+	if target == "" {
+		target = eng.Config().TargetBranch
+	}
+	risk, err := eng.AssessMRRisk(mrFields.Branch, target)
+	if err != nil {
+		return nil, 0
+	}
+	return risk, eng.Config().RiskApprovalThreshold
 }
 
 // printMqStatus prints detailed MR status in human-readable format.
-func printMqStatus(issue *beads.Issue, mrFields *beads.MRFields) error {
+func printMqStatus(issue *beads.Issue, mrFields *beads.MRFields, output *MRStatusOutput) error {
 	// Header
 	fmt.Printf("%s %s\n", style.Bold.Render("📋 Merge Request:"), issue.ID)
 	fmt.Printf("   %s\n\n", issue.Title)
@@ -186,6 +242,14 @@ func printMqStatus(issue *beads.Issue, mrFields *beads.MRFields) error {
 		}
 	}
 
+	if output.Risk != nil {
+		printMqRisk(output)
+	}
+
+	if output.Throttle != nil {
+		printMqThrottle(output.Throttle)
+	}
+
 	// Dependencies (what this MR is waiting on)
 	if len(issue.Dependencies) > 0 {
 		fmt.Printf("\n%s\n", style.Bold.Render("Waiting On"))
@@ -225,6 +289,68 @@ func printMqStatus(issue *beads.Issue, mrFields *beads.MRFields) error {
 	return nil
 }
 
+// printMqRisk prints the risk score, its factors, and the approval gate.
+func printMqRisk(output *MRStatusOutput) {
+	risk := output.Risk
+	fmt.Printf("\n%s\n", style.Bold.Render("Risk"))
+	fmt.Printf("   Score:    %s\n", formatRiskScore(risk))
+	for _, f := range risk.Factors {
+		fmt.Printf("   %-9s %3.0f%% %s\n", f.Name+":", 100*f.Score, style.Dim.Render("("+f.Detail+")"))
+	}
+	if len(risk.Areas) > 0 {
+		fmt.Printf("   Areas:    %s\n", strings.Join(risk.Areas, ", "))
+	}
+
+	switch {
+	case output.RiskThreshold == 0:
+	case output.RiskApprovedBy != "":
+		fmt.Printf("   Approval: %s\n", style.Success.Render("approved by "+output.RiskApprovedBy))
+	case risk.Score >= output.RiskThreshold:
+		fmt.Printf("   Approval: %s\n", style.Warning.Render(
+			fmt.Sprintf("required (threshold %d) - gt mq approve %s %s", output.RiskThreshold, output.Rig, output.ID)))
+	default:
+		fmt.Printf("   Approval: %s\n", style.Dim.Render(fmt.Sprintf("not required (threshold %d)", output.RiskThreshold)))
+	}
+}
+
+// printMqThrottle prints the MR's epic quota and worker cooldown.
+func printMqThrottle(t *refinery.ThrottleStatus) {
+	fmt.Printf("\n%s\n", style.Bold.Render("Throttle"))
+	if t.EpicLimit > 0 {
+		if t.Epic == "" {
+			fmt.Printf("   Epic quota: %s\n", style.Dim.Render("n/a (no epic)"))
+		} else {
+			fmt.Printf("   Epic quota: %d/%d merges in the last 24h (%s)\n", t.EpicMerges, t.EpicLimit, t.Epic)
+		}
+	}
+	if t.Cooldown > 0 {
+		last := style.Dim.Render("no recent merges")
+		if t.WorkerLastMerge != nil {
+			last = "last merge " + formatTimeAgo(t.WorkerLastMerge.Format(time.RFC3339))
+		}
+		fmt.Printf("   Cooldown:   %s per worker, %s\n", t.Cooldown, last)
+	}
+	if t.Throttled {
+		fmt.Printf("   Held until: %s\n", style.Warning.Render(
+			fmt.Sprintf("%s (%s)", t.Until.Local().Format("2006-01-02 15:04"), t.Reason)))
+	} else {
+		fmt.Printf("   Held until: %s\n", style.Success.Render("not held"))
+	}
+}
+
+// formatRiskScore renders a risk score colored by level.
+func formatRiskScore(risk *refinery.RiskAssessment) string {
+	text := fmt.Sprintf("%d/100 (%s)", risk.Score, risk.Level)
+	switch risk.Level {
+	case refinery.RiskHigh:
+		return style.Error.Render(text)
+	case refinery.RiskMedium:
+		return style.Warning.Render(text)
+	default:
+		return style.Success.Render(text)
+	}
+}
+
 // formatStatus formats the status with appropriate styling.
 func formatStatus(status string) string {
 	switch status {
@@ -314,20 +440,23 @@ func getDescriptionWithoutMRFields(description string) string {
 
 	// Known MR field keys (lowercase)
 	mrKeys := map[string]bool{
-		"branch":       true,
-		"target":       true,
-		"source_issue": true,
-		"source-issue": true,
-		"sourceissue":  true,
-		"worker":       true,
-		"rig":          true,
-		"merge_commit": true,
-		"merge-commit": true,
-		"mergecommit":  true,
-		"close_reason": true,
-		"close-reason": true,
-		"closereason":  true,
-		"type":         true,
+		"branch":           true,
+		"target":           true,
+		"source_issue":     true,
+		"source-issue":     true,
+		"sourceissue":      true,
+		"worker":           true,
+		"rig":              true,
+		"merge_commit":     true,
+		"merge-commit":     true,
+		"mergecommit":      true,
+		"close_reason":     true,
+		"close-reason":     true,
+		"closereason":      true,
+		"type":             true,
+		"risk_approved_by": true,
+		"risk-approved-by": true,
+		"riskapprovedby":   true,
 	}
 
 	var lines []string
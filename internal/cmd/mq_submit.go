@@ -13,6 +13,7 @@ import (
 	"github.com/steveyegge/gastown/internal/beads"
 	"github.com/steveyegge/gastown/internal/constants"
 	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/refinery"
 	"github.com/steveyegge/gastown/internal/rig"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/workspace"
@@ -146,6 +147,15 @@ func runMqSubmit(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// A branch built on another open MR is stacked on it and shares its target
+	parent, parentFields, err := stackParent(bd, g, branch, worker, mqSubmitStackedOn)
+	if err != nil {
+		return err
+	}
+	if parent != nil {
+		target = parentFields.Target
+	}
+
 	// Get source issue for priority inheritance
 	var priority int
 	if mqSubmitPriority >= 0 {
@@ -168,6 +178,9 @@ func runMqSubmit(cmd *cobra.Command, args []string) error {
 	if worker != "" {
 		description += fmt.Sprintf("\nworker: %s", worker)
 	}
+	if parent != nil {
+		description += fmt.Sprintf("\nstacked_on: %s", parent.ID)
+	}
 
 	// Check if MR bead already exists for this branch (idempotency)
 	var mrIssue *beads.Issue
@@ -190,6 +203,13 @@ func runMqSubmit(cmd *cobra.Command, args []string) error {
 		if err != nil {
 			return fmt.Errorf("creating merge request bead: %w", err)
 		}
+
+		// The stack merges in order: this MR waits for its parent
+		if parent != nil {
+			if err := bd.AddDependency(mrIssue.ID, parent.ID); err != nil {
+				style.PrintWarning("could not block MR on %s: %v", parent.ID, err)
+			}
+		}
 	}
 
 	// Success output
@@ -202,6 +222,11 @@ func runMqSubmit(cmd *cobra.Command, args []string) error {
 		fmt.Printf("  Worker: %s\n", worker)
 	}
 	fmt.Printf("  Priority: P%d\n", priority)
+	if parent != nil {
+		fmt.Printf("  Stacked on: %s (%s)\n", parent.ID, parentFields.Branch)
+	}
+
+	notifyRefinery(rigName, refinery.ReadyEvent{MRID: mrIssue.ID, Branch: branch, Target: target})
 
 	// Auto-cleanup for polecats: if this is a polecat branch and cleanup not disabled,
 	// send lifecycle request and wait for termination
@@ -220,6 +245,53 @@ func runMqSubmit(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// stackParent returns the open MR a submission is stacked on: the
+// explicit --stacked-on MR, or else the worker's open MR that branch
+// builds on. Detection failures only leave the MR unstacked.
+func stackParent(bd *beads.Beads, g *git.Git, branch, worker, explicit string) (*beads.Issue, *beads.MRFields, error) {
+	if explicit == "" {
+		parent, fields, err := refinery.FindStackParent(bd, g, branch, worker)
+		if err != nil {
+			fmt.Printf("  %s\n", style.Dim.Render(fmt.Sprintf("(note: could not check for a stack: %v)", err)))
+			return nil, nil, nil
+		}
+		return parent, fields, nil
+	}
+
+	parent, err := bd.Show(explicit)
+	if err != nil {
+		return nil, nil, fmt.Errorf("looking up --stacked-on MR %s: %w", explicit, err)
+	}
+	fields := beads.ParseMRFields(parent)
+	if fields == nil {
+		return nil, nil, fmt.Errorf("--stacked-on %s is not a merge request", explicit)
+	}
+	if parent.Status == "closed" {
+		return nil, nil, fmt.Errorf("--stacked-on %s is already closed; submit without --stacked-on", explicit)
+	}
+	if fields.Branch == branch {
+		return nil, nil, fmt.Errorf("cannot stack %s on its own MR %s", branch, explicit)
+	}
+	return parent, fields, nil
+}
+
+// notifyRefinery announces a ready MR to the rig's refinery when it uses an
+// event trigger mode, so it is processed without waiting for the next poll.
+// Failures only delay processing until the refinery's fallback poll.
+func notifyRefinery(rigName string, ev refinery.ReadyEvent) {
+	_, r, err := getRig(rigName)
+	if err != nil {
+		return
+	}
+	eng := refinery.NewEngineer(r)
+	if err := eng.LoadConfig(); err != nil {
+		return
+	}
+	if err := eng.NotifyMRReady(ev); err != nil {
+		style.PrintWarning("could not notify refinery: %v", err)
+	}
+}
+
 // detectIntegrationBranch checks if an issue is a descendant of an epic that has an integration branch.
 // Traverses up the parent chain until it finds an epic or runs out of parents.
 // Returns the integration branch target (e.g., "integration/gt-epic") if found, or "" if not.
@@ -238,8 +310,12 @@ func detectIntegrationBranch(bd *beads.Beads, g *git.Git, issueID string) (strin
 
 		// Check if this issue is an epic
 		if issue.Type == "epic" {
-			// Found an epic - check if it has an integration branch
-			integrationBranch := "integration/" + issue.ID
+			// Found an epic - check if it has an integration branch, preferring
+			// the one recorded on the epic (custom templates, planner handoff)
+			integrationBranch := refinery.IntegrationBranchField(issue.Description)
+			if integrationBranch == "" {
+				integrationBranch = refinery.IntegrationBranchName(refinery.DefaultIntegrationBranchTemplate, issue.ID)
+			}
 
 			// Check local first (faster)
 			exists, err := g.BranchExists(integrationBranch)
@@ -740,3 +740,34 @@ func TestPolecatCleanupTimeoutConstant(t *testing.T) {
 		t.Errorf("expectedMaxCleanupWait = %v, want 5m", expectedMaxCleanupWait)
 	}
 }
+
+func TestParseMRPriority(t *testing.T) {
+	tests := []struct {
+		arg     string
+		current int
+		want    int
+		wantErr bool
+	}{
+		{"0", 2, 0, false},
+		{"P3", 2, 3, false},
+		{"p1", 2, 1, false},
+		{"up", 2, 1, false},
+		{"up", 0, 0, false},
+		{"down", 2, 3, false},
+		{"DOWN", 4, 4, false},
+		{"5", 2, 0, true},
+		{"P-1", 2, 0, true},
+		{"high", 2, 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseMRPriority(tt.arg, tt.current)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseMRPriority(%q, %d) error = %v, wantErr %v", tt.arg, tt.current, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && got != tt.want {
+			t.Errorf("parseMRPriority(%q, %d) = %d, want %d", tt.arg, tt.current, got, tt.want)
+		}
+	}
+}
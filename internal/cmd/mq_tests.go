@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/refinery"
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+// MQ tests command flags
+var (
+	mqTestsTarget string
+	mqTestsRun    bool
+	mqTestsJSON   bool
+)
+
+var mqTestsCmd = &cobra.Command{
+	Use:   "tests <rig> <branch>",
+	Short: "Show or run the tests an MR branch needs",
+	Long: `Show or run the test commands for an MR branch, for the refinery patrol.
+
+Without merge_queue.test_rules this is just merge_queue.test_command. With
+test_rules set to a rules file in the repo, the branch's changed paths
+(git diff --name-only <target>...<branch>) select the commands to run, so a
+monorepo MR only runs the tests for what it touches. If no rule matches, the
+full test_command runs instead.
+
+Rules file example (e.g. .gastown/test-rules.yaml):
+  rules:
+    - paths: ["services/api/**", "libs/auth/**"]
+      command: cd services/api && go test ./...
+    - paths: ["web/**"]
+      command: cd web && npm test
+
+The rules are read from the refinery worktree, so run this with the target
+branch checked out: an MR can't change its own rules.
+
+Examples:
+  gt mq tests gastown polecat/nux/gt-abc
+  gt mq tests gastown polecat/nux/gt-abc --run`,
+	Args: cobra.ExactArgs(2),
+	RunE: runMQTests,
+}
+
+func init() {
+	mqTestsCmd.Flags().StringVar(&mqTestsTarget, "target", "", "Target branch (default: merge_queue.target_branch)")
+	mqTestsCmd.Flags().BoolVar(&mqTestsRun, "run", false, "Run the commands in the refinery worktree, stopping at the first failure")
+	mqTestsCmd.Flags().BoolVar(&mqTestsJSON, "json", false, "Output the test plan as JSON")
+
+	mqCmd.AddCommand(mqTestsCmd)
+}
+
+func runMQTests(cmd *cobra.Command, args []string) error {
+	_, r, _, err := getRefineryManager(args[0])
+	if err != nil {
+		return err
+	}
+	branch := args[1]
+
+	eng := refinery.NewEngineer(r)
+	eng.SetOutput(os.Stderr)
+	if err := eng.LoadConfig(); err != nil {
+		return fmt.Errorf("loading merge queue config: %w", err)
+	}
+	target := mqTestsTarget
+	if target == "" {
+		target = eng.Config().TargetBranch
+	}
+
+	commands, selected := eng.TestPlan(branch, target)
+
+	if mqTestsJSON && !mqTestsRun {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(struct {
+			Branch   string   `json:"branch"`
+			Target   string   `json:"target"`
+			Selected bool     `json:"selected"`
+			Commands []string `json:"commands"`
+		}{branch, target, selected, commands})
+	}
+
+	if len(commands) == 0 {
+		fmt.Printf("%s No test command configured\n", style.Dim.Render("○"))
+		return nil
+	}
+
+	if !mqTestsRun {
+		for _, c := range commands {
+			fmt.Println(c)
+		}
+		return nil
+	}
+
+	scope := "full suite"
+	if selected {
+		scope = "selected by changed paths"
+	}
+	fmt.Printf("%s Running %d test command(s) (%s)\n", style.Bold.Render("●"), len(commands), scope)
+	for _, c := range commands {
+		fmt.Printf("\n%s %s\n", style.Bold.Render("$"), c)
+		// Commands come from the rig's trusted merge_queue config or the
+		// target branch's rules file, as in the engineer
+		run := exec.Command("sh", "-c", c) //nolint:gosec // G204: trusted rig config
+		run.Dir = eng.WorkDir()
+		run.Stdout = os.Stdout
+		run.Stderr = os.Stderr
+		if err := run.Run(); err != nil {
+			fmt.Printf("\n%s Tests failed: %s: %v\n", style.Bold.Render("✗"), c, err)
+			return NewSilentExit(1)
+		}
+	}
+	fmt.Printf("\n%s Tests passed\n", style.Bold.Render("✓"))
+	return nil
+}
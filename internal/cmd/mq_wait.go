@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/refinery"
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+// MQ wait command flags
+var (
+	mqWaitJSON  bool
+	mqWaitQuiet bool
+)
+
+var mqWaitCmd = &cobra.Command{
+	Use:   "wait <rig>",
+	Short: "Wait until a merge request is ready",
+	Long: `Block until a merge request is ready to process, for the refinery patrol.
+
+How the wait ends depends on the rig's merge_queue.trigger_mode:
+  poll   Returns after poll_interval (default)
+  file   Returns as soon as 'gt mq submit' writes an MR ready event, or
+         after fallback_poll_interval
+  http   Listens on trigger_addr for events posted by 'gt mq submit', or
+         returns after fallback_poll_interval
+
+The event modes cut merge latency from the poll interval to seconds. Either
+way, scan the queue with 'gt mq list' after waking: the fallback poll exists
+in case an event was missed.
+
+On startup the merge_queue remote and push_remotes are checked against the
+refinery's repo, so a missing remote is reported before any merge.
+
+Examples:
+  gt mq wait gastown
+  gt mq wait gastown --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runMQWait,
+}
+
+func init() {
+	mqWaitCmd.Flags().BoolVar(&mqWaitJSON, "json", false, "Output as JSON")
+	mqWaitCmd.Flags().BoolVarP(&mqWaitQuiet, "quiet", "q", false, "Suppress output")
+
+	mqCmd.AddCommand(mqWaitCmd)
+}
+
+func runMQWait(cmd *cobra.Command, args []string) error {
+	_, r, _, err := getRefineryManager(args[0])
+	if err != nil {
+		return err
+	}
+
+	eng := refinery.NewEngineer(r)
+	if err := eng.LoadConfig(); err != nil {
+		return fmt.Errorf("loading merge queue config: %w", err)
+	}
+	if err := eng.ValidateRemotes(); err != nil {
+		return err
+	}
+
+	trigger, err := eng.NewTrigger()
+	if err != nil {
+		return err
+	}
+	defer trigger.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if !mqWaitQuiet && !mqWaitJSON {
+		mode := eng.Config().TriggerMode
+		if addr := trigger.Addr(); addr != "" {
+			mode += " on " + addr
+		}
+		fmt.Printf("%s Waiting for merge requests (%s)...\n", style.Dim.Render("⏳"), mode)
+	}
+
+	result, err := trigger.Wait(ctx)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil
+		}
+		return err
+	}
+
+	if mqWaitJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(result)
+	}
+	if mqWaitQuiet {
+		return nil
+	}
+
+	if result.Reason == refinery.WakePoll {
+		fmt.Printf("%s Poll interval elapsed (%v)\n", style.Dim.Render("○"), result.Elapsed.Round(time.Second))
+		return nil
+	}
+	for _, ev := range result.Events {
+		fmt.Printf("%s MR ready: %s", style.Bold.Render("✓"), ev.MRID)
+		if ev.Branch != "" {
+			fmt.Printf(" %s", style.Dim.Render(fmt.Sprintf("(%s → %s)", ev.Branch, ev.Target)))
+		}
+		fmt.Println()
+	}
+	return nil
+}
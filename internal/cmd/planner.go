@@ -2,12 +2,15 @@ package cmd
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/planner"
 	"github.com/steveyegge/gastown/internal/planneragent"
 	"github.com/steveyegge/gastown/internal/rig"
@@ -17,13 +20,17 @@ import (
 
 // Planner command flags
 var (
-	plannerStatusJSON bool
-	plannerShowJSON   bool
-	plannerRig        string
+	plannerStatusJSON      bool
+	plannerShowJSON        bool
+	plannerRig             string
+	plannerAnswerForce     bool
+	plannerAnswerDecision  bool
+	plannerAnswerRationale string
 )
 
 var plannerCmd = &cobra.Command{
 	Use:     "planner",
+	Aliases: []string{"shape"},
 	GroupID: GroupWork,
 	Short:   "Plan specs through structured planning",
 	RunE:    runPlannerDefault,
@@ -38,6 +45,16 @@ Subcommands are available for specific operations:
   gt planner attach  - Attach to running session
   gt planner new     - Create a new planning session record
   gt planner status  - Check session status
+  gt planner ask     - Mail clarifying questions to the overseer
+  gt planner risk    - Manage the risk register
+  gt planner depend  - Declare prerequisite specs
+  gt planner graph   - Show the dependency graph between specs
+  gt planner handoff - Hand off a session (deferred questions become beads)
+  gt planner publish - Publish an approved spec to the rig's docs
+  gt planner gc      - Archive cancelled and abandoned sessions
+
+'gt shape' is an alias: every subcommand and flag, including --rig and the
+agent session commands, works under either name.
 
 This implements the "Plan before you build" discipline for AI-driven development.`,
 }
@@ -62,7 +79,9 @@ var plannerStatusCmd = &cobra.Command{
 	Short: "Show planning session status",
 	Long: `Show the status of a planning session.
 
-If no session ID is provided, shows the active session.
+If no session ID is provided, shows the active session. Status only reads
+the session: answers the overseer mailed back appear once they have been
+recorded with 'gt planner ingest'.
 
 Examples:
   gt planner status
@@ -91,7 +110,10 @@ var plannerListCmd = &cobra.Command{
 	Short: "List all planning sessions",
 	Long: `List all planning sessions in the .specs/ directory.
 
-Shows session ID, title, status, and creation date.
+Shows session ID, title, status, and creation date. Sessions still in
+questioning or reviewing past the rig's planner.max_session_age (default 7d)
+are marked stale; 'gt planner ingest' mails the overseer a reminder about
+each once.
 
 Examples:
   gt planner list
@@ -120,16 +142,235 @@ var plannerAnswerCmd = &cobra.Command{
 The planner asks questions to clarify requirements. Use this command
 to provide answers that will be incorporated into the spec.
 
+If the session was saved by someone else since it was loaded (for example,
+another terminal answering at the same time), the answer is rejected with a
+conflict error. Re-run the command to apply it to the latest revision, or use
+--force to overwrite the other changes.
+
+With --decision, a significant answer is also recorded in the decision log
+(planning/decisions.md), with --rationale explaining why.
+
 Examples:
   gt planner answer q1 "JWT tokens with refresh"
-  gt planner answer q2 "Support Google and GitHub OAuth"`,
+  gt planner answer q3 "No offline mode" --decision --rationale "Kiosks are always online"
+  gt planner answer q2 "Support Google and GitHub OAuth"
+  gt planner answer q2 "Google only" --force`,
 	Args: cobra.MinimumNArgs(2),
 	RunE: runPlannerAnswer,
 }
 
+var plannerAskCmd = &cobra.Command{
+	Use:   "ask <question>...",
+	Short: "Ask the overseer clarifying questions",
+	Long: `Add clarifying questions to the active planning session and mail them
+to the overseer as a single QUESTION message.
+
+The overseer can answer from the inbox (gt inbox, R to reply) with one
+answer per line, e.g. "q1: use JWT". Replies are recorded by
+'gt planner ingest'.
+
+Examples:
+  gt planner ask "Which auth provider?" "Do we need SSO at launch?"`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runPlannerAsk,
+}
+
+var plannerIngestCmd = &cobra.Command{
+	Use:   "ingest",
+	Short: "Record answers from overseer mail replies",
+	Long: `Read replies to planner questions from the planner's mailbox and record
+their answers in the matching planning sessions.
+
+Each line of the form "<question-id>: <answer>" is recorded; quoted lines
+are ignored. Replies with no such lines are left unread.
+
+Ingest also mails the overseer a reminder, once per session, about sessions
+still open past the rig's planner.max_session_age. Run it from a patrol or
+schedule to keep answers and reminders flowing; 'gt planner status' and
+'gt planner list' never send or consume mail.
+
+Examples:
+  gt planner ingest`,
+	Args: cobra.NoArgs,
+	RunE: runPlannerIngest,
+}
+
+var plannerRiskCmd = &cobra.Command{
+	Use:   "risk",
+	Short: "Manage the session risk register",
+	Long: `Manage the risk register of the active planning session.
+
+Risks are stored on the session, survive handoff, and are rendered to
+planning/risks.md. Open risks are shown by 'gt planner status'.
+
+Examples:
+  gt planner risk add "OAuth provider rate limits" --severity high
+  gt planner risk resolve r1 --mitigation "Cache tokens for 1h"
+  gt planner risk resolve r2 --status accepted`,
+	RunE: requireSubcommand,
+}
+
+var plannerRiskAddCmd = &cobra.Command{
+	Use:   "add <text>",
+	Short: "Record a risk",
+	Args:  cobra.MinimumNArgs(1),
+	RunE:  runPlannerRiskAdd,
+}
+
+var plannerRiskResolveCmd = &cobra.Command{
+	Use:   "resolve <risk-id>",
+	Short: "Mark a risk mitigated or accepted",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runPlannerRiskResolve,
+}
+
+var plannerDeferCmd = &cobra.Command{
+	Use:   "defer <question-id>",
+	Short: "Defer a question past handoff",
+	Long: `Defer an unanswered question in the active planning session.
+
+Deferred questions no longer block review. At handoff each one becomes a
+follow-up bead so it is answered later instead of being lost.
+
+Examples:
+  gt planner defer q3 --reason "Needs input from legal"`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPlannerDefer,
+}
+
+var plannerDependCmd = &cobra.Command{
+	Use:   "depend <spec-id>...",
+	Short: "Declare prerequisite specs",
+	Long: `Declare that the active planning session depends on other specs.
+
+Dependencies are stored on the session as depends_on. The session cannot be
+handed off until every prerequisite spec is approved. Dependencies that
+would create a cycle are rejected.
+
+Examples:
+  gt planner depend gt-plan-xyz
+  gt planner depend gt-plan-xyz --remove`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runPlannerDepend,
+}
+
+var plannerGraphCmd = &cobra.Command{
+	Use:   "graph",
+	Short: "Show the dependency graph between specs",
+	Long: `Show the dependency graph between the rig's planning sessions.
+
+By default, lists each session with its prerequisites and whether they are
+approved. Use --dot to emit Graphviz DOT, with edges from each spec to its
+prerequisites.
+
+Examples:
+  gt planner graph
+  gt planner graph --dot | dot -Tsvg > specs.svg`,
+	Args: cobra.NoArgs,
+	RunE: runPlannerGraph,
+}
+
+var plannerHandoffCmd = &cobra.Command{
+	Use:   "handoff [session-id]",
+	Short: "Hand off a planning session",
+	Long: `Hand off a planning session for execution.
+
+Creates a follow-up bead for each deferred question, regenerates
+planning/risks.md with the open risks and follow-up beads, and marks the
+session handed off. If no session ID is provided, hands off the active session.
+Sessions whose slices were accepted with --epics (see 'gt planner slice')
+get a separate epic per slice.
+
+With --integration-branches, each of the session's epics (slice epics and
+the epic the spec was published with) gets an integration branch, named
+from the rig's merge_queue.integration_branch_template, pushed to origin
+and recorded in the epic bead. Polecats working on the epic's children
+branch from it and their MRs merge into it; land it with
+'gt mq integration land'. The setting sticks, so a retried handoff
+finishes the branches.
+
+Handoff is blocked while any spec the session depends on (see
+'gt planner depend') is not yet approved, or while any task in tasks.md
+lacks acceptance criteria or a size estimate and is not waived (see
+'gt planner tasks').
+
+Examples:
+  gt planner handoff
+  gt planner handoff gt-plan-abc123
+  gt planner handoff --integration-branches`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runPlannerHandoff,
+}
+
+var plannerPublishCmd = &cobra.Command{
+	Use:   "publish <session-id>",
+	Short: "Publish an approved spec to the rig's docs",
+	Long: `Publish an approved spec into the rig's docs tree via the merge queue.
+
+Copies SPEC.md with front-matter (title, status, owner, date, linked epic)
+to <dest>/<title-slug>.md on a docs/spec-<session-id> branch and submits the
+branch to the merge queue. Publishing again refreshes the same file, branch
+and MR. The session must be approved or handed off.
+
+Examples:
+  gt planner publish gt-plan-abc123
+  gt planner publish gt-plan-abc123 --dest docs/specs/ --epic gt-epic-42
+  gt planner publish gt-plan-abc123 --no-mr`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPlannerPublish,
+}
+
 // Flags for planner new
 var plannerNewIdea string
 
+// Flags for planner publish
+var (
+	plannerPublishDest  string
+	plannerPublishOwner string
+	plannerPublishEpic  string
+	plannerPublishNoMR  bool
+)
+
+// Flags for planner risk and defer
+var (
+	plannerRiskSeverity   string
+	plannerRiskMitigation string
+	plannerRiskStatus     string
+	plannerDeferReason    string
+)
+
+// Flags for planner depend and graph
+var (
+	plannerDependRemove bool
+	plannerGraphDOT     bool
+)
+
+// Flags for planner handoff
+var plannerHandoffIntegration bool
+
+// Flags for planner gc
+var (
+	plannerGCOlderThan string
+	plannerGCDryRun    bool
+)
+
+var plannerGCCmd = &cobra.Command{
+	Use:   "gc",
+	Short: "Archive cancelled and abandoned planning sessions",
+	Long: `Archive old planning sessions out of the active .specs/ listing.
+
+Cancelled sessions, and sessions abandoned in questioning or reviewing (not
+updated within --older-than), are moved to .specs/.archive/. Abandoned
+sessions are cancelled first so their planning bead is closed.
+
+Examples:
+  gt planner gc                     # Archive sessions idle for 30 days
+  gt planner gc --older-than 14d
+  gt planner gc --dry-run`,
+	Args: cobra.NoArgs,
+	RunE: runPlannerGC,
+}
+
 // Flags for planner session management
 var plannerAgentOverride string
 
@@ -188,6 +429,33 @@ func init() {
 	// New command flags
 	plannerNewCmd.Flags().StringVar(&plannerNewIdea, "idea", "", "Initial idea/description for the feature")
 
+	// Answer command flags
+	plannerAnswerCmd.Flags().BoolVar(&plannerAnswerForce, "force", false, "Overwrite concurrent changes to the session")
+	plannerAnswerCmd.Flags().BoolVar(&plannerAnswerDecision, "decision", false, "Also record the answer in the decision log")
+	plannerAnswerCmd.Flags().StringVar(&plannerAnswerRationale, "rationale", "", "Rationale for the decision (with --decision)")
+
+	// Risk and defer command flags
+	plannerRiskAddCmd.Flags().StringVar(&plannerRiskSeverity, "severity", planner.SeverityMedium, "Severity (low, medium, high)")
+	plannerRiskAddCmd.Flags().StringVar(&plannerRiskMitigation, "mitigation", "", "Planned mitigation")
+	plannerRiskResolveCmd.Flags().StringVar(&plannerRiskStatus, "status", string(planner.RiskMitigated), "New status (mitigated, accepted, open)")
+	plannerRiskResolveCmd.Flags().StringVar(&plannerRiskMitigation, "mitigation", "", "Mitigation applied")
+	plannerDeferCmd.Flags().StringVar(&plannerDeferReason, "reason", "", "Why the question is deferred")
+
+	// Depend and graph command flags
+	plannerDependCmd.Flags().BoolVar(&plannerDependRemove, "remove", false, "Remove the dependencies instead of adding them")
+	plannerGraphCmd.Flags().BoolVar(&plannerGraphDOT, "dot", false, "Output Graphviz DOT")
+	plannerHandoffCmd.Flags().BoolVar(&plannerHandoffIntegration, "integration-branches", false, "Create an integration branch for each of the session's epics")
+
+	// GC flags
+	plannerGCCmd.Flags().StringVar(&plannerGCOlderThan, "older-than", "30d", "Archive sessions not updated for this long (e.g., 30d, 72h)")
+	plannerGCCmd.Flags().BoolVar(&plannerGCDryRun, "dry-run", false, "Show what would be archived")
+
+	// Publish command flags
+	plannerPublishCmd.Flags().StringVar(&plannerPublishDest, "dest", "", "Docs directory in the repo (default "+planner.DefaultPublishDest+", or the previous one)")
+	plannerPublishCmd.Flags().StringVar(&plannerPublishOwner, "owner", "", "Spec owner for the front-matter")
+	plannerPublishCmd.Flags().StringVar(&plannerPublishEpic, "epic", "", "Linked epic for the front-matter")
+	plannerPublishCmd.Flags().BoolVar(&plannerPublishNoMR, "no-mr", false, "Commit to the publish branch without submitting to the merge queue")
+
 	// Status command flags
 	plannerStatusCmd.Flags().BoolVar(&plannerStatusJSON, "json", false, "Output as JSON")
 
@@ -209,6 +477,17 @@ func init() {
 	plannerCmd.AddCommand(plannerListCmd)
 	plannerCmd.AddCommand(plannerCancelCmd)
 	plannerCmd.AddCommand(plannerAnswerCmd)
+	plannerCmd.AddCommand(plannerAskCmd)
+	plannerCmd.AddCommand(plannerIngestCmd)
+	plannerCmd.AddCommand(plannerDeferCmd)
+	plannerCmd.AddCommand(plannerDependCmd)
+	plannerCmd.AddCommand(plannerGraphCmd)
+	plannerCmd.AddCommand(plannerHandoffCmd)
+	plannerCmd.AddCommand(plannerPublishCmd)
+	plannerCmd.AddCommand(plannerGCCmd)
+	plannerRiskCmd.AddCommand(plannerRiskAddCmd)
+	plannerRiskCmd.AddCommand(plannerRiskResolveCmd)
+	plannerCmd.AddCommand(plannerRiskCmd)
 
 	// Add session management subcommands
 	plannerCmd.AddCommand(plannerAgentStartCmd)
@@ -284,10 +563,11 @@ func runPlannerNew(cmd *cobra.Command, args []string) error {
 }
 
 func runPlannerStatus(cmd *cobra.Command, args []string) error {
-	mgr, _, err := getPlannerManager()
+	mgr, r, err := getPlannerManager()
 	if err != nil {
 		return err
 	}
+	maxAge := plannerMaxSessionAge(r)
 
 	var session *planner.PlanningSession
 
@@ -333,23 +613,60 @@ func runPlannerStatus(cmd *cobra.Command, args []string) error {
 	case planner.StatusCancelled:
 		statusStr = style.Dim.Render("✗ cancelled")
 	}
+	if session.IsStale(maxAge, time.Now()) {
+		statusStr += " " + style.Warning.Render("[stale]")
+	}
 	fmt.Printf("  Status: %s\n", statusStr)
 	fmt.Printf("  Created: %s\n", session.CreatedAt.Format("2006-01-02 15:04"))
 
+	// Show revision and whether a save is in progress
+	lockStr := "🔓 unlocked"
+	if mgr.IsSessionLocked(session.ID) {
+		lockStr = style.Bold.Render("🔒 locked (save in progress)")
+	}
+	revStr := fmt.Sprintf("%d", session.Revision)
+	if session.UpdatedBy != "" {
+		revStr += fmt.Sprintf(" (saved by %s, %s)", session.UpdatedBy, formatAge(session.UpdatedAt))
+	}
+	fmt.Printf("  Revision: %s\n", revStr)
+	fmt.Printf("  Lock: %s\n", lockStr)
+
 	// Show unanswered questions
 	unanswered := 0
 	for _, q := range session.Questions {
-		if q.Answer == "" {
+		if q.Answer == "" && !q.Deferred {
 			unanswered++
 		}
 	}
 	if unanswered > 0 {
 		fmt.Printf("\n  %s\n", style.Bold.Render("Pending Questions:"))
 		for _, q := range session.Questions {
-			if q.Answer == "" {
+			if q.Answer == "" && !q.Deferred {
 				fmt.Printf("    • [%s] %s\n", q.ID, q.Text)
 			}
 		}
+		fmt.Printf("  %s\n", style.Dim.Render("Run 'gt planner ingest' to record answers mailed back"))
+	}
+
+	if deferred := session.DeferredQuestions(); len(deferred) > 0 {
+		fmt.Printf("\n  %s\n", style.Bold.Render("Deferred Questions:"))
+		for _, q := range deferred {
+			line := fmt.Sprintf("    ◌ [%s] %s", q.ID, q.Text)
+			if q.FollowUpBeadID != "" {
+				line += style.Dim.Render(" → " + q.FollowUpBeadID)
+			}
+			fmt.Println(line)
+		}
+	}
+
+	if open := session.OpenRisks(); len(open) > 0 {
+		fmt.Printf("\n  %s\n", style.Bold.Render("Open Risks:"))
+		for _, risk := range open {
+			fmt.Printf("    ⚠ [%s] %s %s\n", risk.ID, risk.Text, style.Dim.Render("("+risk.Severity+")"))
+			if risk.Mitigation != "" {
+				fmt.Printf("      → %s\n", style.Dim.Render(risk.Mitigation))
+			}
+		}
 	}
 
 	return nil
@@ -409,6 +726,8 @@ func runPlannerShow(cmd *cobra.Command, args []string) error {
 			status := "○"
 			if q.Answer != "" {
 				status = "✓"
+			} else if q.Deferred {
+				status = "◌"
 			}
 			fmt.Printf("    %s [%s] %s\n", status, q.ID, q.Text)
 			if q.Answer != "" {
@@ -417,6 +736,26 @@ func runPlannerShow(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Show prerequisite specs
+	if len(session.DependsOn) > 0 {
+		fmt.Printf("\n  %s\n", style.Bold.Render("Depends on:"))
+		for _, id := range session.DependsOn {
+			fmt.Printf("    %s %s\n", id, style.Dim.Render(plannerDependencyStatus(mgr, id)))
+		}
+	}
+
+	// Show risk register
+	if len(session.Risks) > 0 {
+		fmt.Printf("\n  %s\n", style.Bold.Render("Risks:"))
+		for _, risk := range session.Risks {
+			fmt.Printf("    [%s] %s %s\n", risk.ID, risk.Text,
+				style.Dim.Render(fmt.Sprintf("(%s, %s)", risk.Severity, risk.Status)))
+			if risk.Mitigation != "" {
+				fmt.Printf("      → %s\n", style.Dim.Render(risk.Mitigation))
+			}
+		}
+	}
+
 	// Show artifacts
 	fmt.Printf("\n  %s\n", style.Bold.Render("Artifacts:"))
 	if artifacts.RawIdeaPath != "" {
@@ -425,6 +764,15 @@ func runPlannerShow(cmd *cobra.Command, args []string) error {
 	if artifacts.RequirementsPath != "" {
 		fmt.Printf("    • requirements.md: %s\n", style.Dim.Render(artifacts.RequirementsPath))
 	}
+	if artifacts.RisksPath != "" {
+		fmt.Printf("    • risks.md: %s\n", style.Dim.Render(artifacts.RisksPath))
+	}
+	if artifacts.DecisionsPath != "" {
+		fmt.Printf("    • decisions.md: %s\n", style.Dim.Render(artifacts.DecisionsPath))
+	}
+	if artifacts.GlossaryPath != "" {
+		fmt.Printf("    • glossary.md: %s\n", style.Dim.Render(artifacts.GlossaryPath))
+	}
 	if artifacts.ProposalPath != "" {
 		fmt.Printf("    • proposal.md: %s\n", style.Dim.Render(artifacts.ProposalPath))
 	}
@@ -438,6 +786,15 @@ func runPlannerShow(cmd *cobra.Command, args []string) error {
 		fmt.Printf("    • %s-review.md: %s\n", agent, style.Dim.Render(path))
 	}
 
+	if pub := session.Publication; pub != nil {
+		fmt.Printf("\n  %s\n", style.Bold.Render("Published:"))
+		fmt.Printf("    %s on %s %s\n", pub.Path, pub.Branch,
+			style.Dim.Render(pub.PublishedAt.Format("2006-01-02 15:04")))
+		if pub.MRID != "" {
+			fmt.Printf("    MR: %s\n", pub.MRID)
+		}
+	}
+
 	return nil
 }
 
@@ -446,6 +803,7 @@ func runPlannerList(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	maxAge := plannerMaxSessionAge(r)
 
 	sessions, err := mgr.ListSessions()
 	if err != nil {
@@ -466,6 +824,7 @@ func runPlannerList(cmd *cobra.Command, args []string) error {
 
 	fmt.Printf("%s Planning Sessions: %s\n\n", style.Bold.Render("📋"), r.Name)
 
+	now := time.Now()
 	for _, s := range sessions {
 		statusIcon := "○"
 		switch s.Status {
@@ -481,7 +840,11 @@ func runPlannerList(cmd *cobra.Command, args []string) error {
 
 		ageStr := formatAge(s.CreatedAt)
 
-		fmt.Printf("  %s %s - %s\n", statusIcon, s.ID, s.Title)
+		badge := ""
+		if s.IsStale(maxAge, now) {
+			badge = " " + style.Warning.Render("[stale]")
+		}
+		fmt.Printf("  %s %s - %s%s\n", statusIcon, s.ID, s.Title, badge)
 		fmt.Printf("    %s | %s\n", style.Dim.Render(string(s.Status)), style.Dim.Render(ageStr))
 	}
 
@@ -504,6 +867,83 @@ func runPlannerCancel(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func runPlannerGC(cmd *cobra.Command, args []string) error {
+	olderThan, err := parseDuration(plannerGCOlderThan)
+	if err != nil {
+		return fmt.Errorf("invalid --older-than: %w", err)
+	}
+
+	mgr, r, err := getPlannerManager()
+	if err != nil {
+		return err
+	}
+
+	candidates, err := mgr.ArchiveCandidates(olderThan)
+	if err != nil {
+		return fmt.Errorf("listing sessions: %w", err)
+	}
+	if len(candidates) == 0 {
+		fmt.Printf("%s No sessions to archive in %s\n", style.Dim.Render("○"), r.Name)
+		return nil
+	}
+
+	archived := 0
+	for _, s := range candidates {
+		reason := "cancelled"
+		if s.IsOpen() {
+			reason = "abandoned in " + string(s.Status)
+		}
+		if plannerGCDryRun {
+			fmt.Printf("  Would archive %s - %s (%s, updated %s)\n", s.ID, s.Title, reason, formatAge(s.UpdatedAt))
+			continue
+		}
+		if err := mgr.ArchiveSession(s); err != nil {
+			fmt.Printf("%s %v\n", style.Bold.Render("✗"), err)
+			continue
+		}
+		archived++
+		fmt.Printf("%s Archived %s - %s (%s)\n", style.Bold.Render("✓"), s.ID, s.Title, reason)
+	}
+
+	if plannerGCDryRun {
+		fmt.Printf("\n%s\n", style.Dim.Render(fmt.Sprintf("%d session(s) would be archived to .specs/%s/", len(candidates), planner.ArchiveDirName)))
+		return nil
+	}
+	if archived < len(candidates) {
+		return NewSilentExit(1)
+	}
+	return nil
+}
+
+// plannerMaxSessionAge returns the rig's planner session timebox from
+// settings/config.json, or planner.DefaultMaxSessionAge.
+func plannerMaxSessionAge(r *rig.Rig) time.Duration {
+	settings, err := config.LoadRigSettings(filepath.Join(r.Path, "settings", "config.json"))
+	if err != nil || settings.Planner == nil || settings.Planner.MaxSessionAge == "" {
+		return planner.DefaultMaxSessionAge
+	}
+	maxAge, err := parseDuration(settings.Planner.MaxSessionAge)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s invalid planner.max_session_age %q, using default: %v\n",
+			style.Bold.Render("⚠"), settings.Planner.MaxSessionAge, err)
+		return planner.DefaultMaxSessionAge
+	}
+	return maxAge
+}
+
+// remindStalePlannerSessions mails reminders about stale sessions and
+// reports them. Failures are reported but not fatal, so listing still works
+// without mail.
+func remindStalePlannerSessions(mgr *planner.Manager, maxAge time.Duration) {
+	reminded, err := mgr.RemindStaleSessions(maxAge)
+	for _, s := range reminded {
+		fmt.Printf("%s Reminded %s that %s is stale\n", style.Bold.Render("⚠"), planner.OverseerAddress, s.ID)
+	}
+	if err != nil {
+		fmt.Printf("%s Reminding about stale sessions: %v\n", style.Bold.Render("⚠"), err)
+	}
+}
+
 func runPlannerAnswer(cmd *cobra.Command, args []string) error {
 	questionID := args[0]
 	answer := strings.Join(args[1:], " ")
@@ -521,32 +961,36 @@ func runPlannerAnswer(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("getting active session: %w", err)
 	}
 
-	// Find and update the question
-	found := false
-	for i := range session.Questions {
-		if session.Questions[i].ID == questionID {
-			session.Questions[i].Answer = answer
-			now := time.Now()
-			session.Questions[i].AnsweredAt = &now
-			found = true
-			break
+	if err := session.AnswerQuestion(questionID, answer); err != nil {
+		return fmt.Errorf("question %s not found in session %s", questionID, session.ID)
+	}
+	var decision planner.Decision
+	if plannerAnswerDecision {
+		if decision, err = session.AddDecision("", plannerAnswerRationale, questionID); err != nil {
+			return err
 		}
 	}
 
-	if !found {
-		return fmt.Errorf("question %s not found in session %s", questionID, session.ID)
+	save := mgr.SaveSession
+	if plannerAnswerForce {
+		save = mgr.ForceSaveSession
 	}
-
-	if err := mgr.SaveSession(session); err != nil {
+	if err := save(session); err != nil {
+		if errors.Is(err, planner.ErrSessionConflict) {
+			return fmt.Errorf("saving session: %w, or use --force to overwrite", err)
+		}
 		return fmt.Errorf("saving session: %w", err)
 	}
 
 	fmt.Printf("%s Answer recorded for question %s\n", style.Bold.Render("✓"), questionID)
+	if decision.ID != "" {
+		fmt.Printf("  %s\n", style.Dim.Render(fmt.Sprintf("Recorded as decision %s", decision.ID)))
+	}
 
 	// Check if all questions are answered
 	unanswered := 0
 	for _, q := range session.Questions {
-		if q.Answer == "" {
+		if q.Answer == "" && !q.Deferred {
 			unanswered++
 		}
 	}
@@ -559,6 +1003,328 @@ func runPlannerAnswer(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func runPlannerAsk(cmd *cobra.Command, args []string) error {
+	mgr, _, err := getPlannerManager()
+	if err != nil {
+		return err
+	}
+	session, err := loadActivePlanningSession(mgr)
+	if err != nil {
+		return err
+	}
+
+	questions, err := mgr.AskQuestions(session, args)
+	if err != nil {
+		if len(questions) == 0 {
+			return fmt.Errorf("saving session: %w", err)
+		}
+		// Questions are recorded even if the mail didn't go out
+		fmt.Printf("%s Recorded %d question(s), but %v\n", style.Bold.Render("⚠"), len(questions), err)
+		return NewSilentExit(1)
+	}
+
+	fmt.Printf("%s Asked %s %d question(s)\n", style.Bold.Render("✓"), planner.OverseerAddress, len(questions))
+	for _, q := range questions {
+		fmt.Printf("    • [%s] %s\n", q.ID, q.Text)
+	}
+	fmt.Printf("  %s\n", style.Dim.Render("Replies like \"q1: use JWT\" are recorded by 'gt planner ingest'"))
+	return nil
+}
+
+func runPlannerIngest(cmd *cobra.Command, args []string) error {
+	mgr, r, err := getPlannerManager()
+	if err != nil {
+		return err
+	}
+	result, err := mgr.IngestAnswers()
+	if err != nil {
+		return fmt.Errorf("ingesting answers: %w", err)
+	}
+
+	for _, a := range result.Answered {
+		fmt.Printf("%s Answer from %s recorded for %s %s: %s\n",
+			style.Bold.Render("✓"), a.From, a.SessionID, a.QuestionID, a.Answer)
+	}
+	for _, u := range result.Unknown {
+		fmt.Printf("%s Ignored answer for unknown question %s\n", style.Bold.Render("⚠"), u)
+	}
+	if len(result.Unparsed) > 0 {
+		fmt.Printf("%s %d reply(s) had no \"q<N>: answer\" lines and were left unread\n",
+			style.Dim.Render("○"), len(result.Unparsed))
+	}
+	if len(result.Answered) == 0 && len(result.Unknown) == 0 && len(result.Unparsed) == 0 {
+		fmt.Printf("%s No new answers\n", style.Dim.Render("○"))
+	}
+
+	// Nudge the overseer about sessions that have run past their timebox
+	remindStalePlannerSessions(mgr, plannerMaxSessionAge(r))
+	return nil
+}
+
+// loadActivePlanningSession returns the active session with a user-facing error.
+func loadActivePlanningSession(mgr *planner.Manager) (*planner.PlanningSession, error) {
+	session, err := mgr.GetActiveSession()
+	if err != nil {
+		if err == planner.ErrNoActiveSession {
+			return nil, fmt.Errorf("no active planning session - use 'gt planner new' to start one")
+		}
+		return nil, fmt.Errorf("getting active session: %w", err)
+	}
+	return session, nil
+}
+
+// savePlanningRisks saves the session and regenerates its risks.md.
+func savePlanningRisks(mgr *planner.Manager, session *planner.PlanningSession) error {
+	if err := mgr.SaveSession(session); err != nil {
+		return fmt.Errorf("saving session: %w", err)
+	}
+	if _, err := mgr.WriteRisks(session); err != nil {
+		return err
+	}
+	return nil
+}
+
+func runPlannerRiskAdd(cmd *cobra.Command, args []string) error {
+	mgr, _, err := getPlannerManager()
+	if err != nil {
+		return err
+	}
+	session, err := loadActivePlanningSession(mgr)
+	if err != nil {
+		return err
+	}
+
+	risk, err := session.AddRisk(strings.Join(args, " "), plannerRiskSeverity, plannerRiskMitigation)
+	if err != nil {
+		return err
+	}
+	if err := savePlanningRisks(mgr, session); err != nil {
+		return err
+	}
+
+	fmt.Printf("%s Risk %s recorded (%s)\n", style.Bold.Render("✓"), risk.ID, risk.Severity)
+	return nil
+}
+
+func runPlannerRiskResolve(cmd *cobra.Command, args []string) error {
+	status := planner.RiskStatus(plannerRiskStatus)
+	switch status {
+	case planner.RiskOpen, planner.RiskMitigated, planner.RiskAccepted:
+	default:
+		return fmt.Errorf("invalid status %q (use mitigated, accepted, or open)", plannerRiskStatus)
+	}
+
+	mgr, _, err := getPlannerManager()
+	if err != nil {
+		return err
+	}
+	session, err := loadActivePlanningSession(mgr)
+	if err != nil {
+		return err
+	}
+
+	if err := session.SetRiskStatus(args[0], status, plannerRiskMitigation); err != nil {
+		return err
+	}
+	if err := savePlanningRisks(mgr, session); err != nil {
+		return err
+	}
+
+	fmt.Printf("%s Risk %s marked %s\n", style.Bold.Render("✓"), args[0], status)
+	return nil
+}
+
+func runPlannerDefer(cmd *cobra.Command, args []string) error {
+	mgr, _, err := getPlannerManager()
+	if err != nil {
+		return err
+	}
+	session, err := loadActivePlanningSession(mgr)
+	if err != nil {
+		return err
+	}
+
+	if err := session.DeferQuestion(args[0], plannerDeferReason); err != nil {
+		return err
+	}
+	if err := savePlanningRisks(mgr, session); err != nil {
+		return err
+	}
+
+	fmt.Printf("%s Question %s deferred\n", style.Bold.Render("✓"), args[0])
+	fmt.Printf("  %s\n", style.Dim.Render("A follow-up bead will be created at handoff"))
+	return nil
+}
+
+func runPlannerDepend(cmd *cobra.Command, args []string) error {
+	mgr, _, err := getPlannerManager()
+	if err != nil {
+		return err
+	}
+	session, err := loadActivePlanningSession(mgr)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range args {
+		if plannerDependRemove {
+			if !session.RemoveDependency(id) {
+				return fmt.Errorf("%s does not depend on %s", session.ID, id)
+			}
+			continue
+		}
+		if err := mgr.AddDependency(session, id); err != nil {
+			return err
+		}
+	}
+	if err := mgr.SaveSession(session); err != nil {
+		return fmt.Errorf("saving session: %w", err)
+	}
+
+	verb := "now depends on"
+	if plannerDependRemove {
+		verb = "no longer depends on"
+	}
+	fmt.Printf("%s %s %s %s\n", style.Bold.Render("✓"), session.ID, verb, strings.Join(args, ", "))
+
+	if unmet, err := mgr.UnmetDependencies(session); err == nil && len(unmet) > 0 {
+		fmt.Printf("  %s\n", style.Dim.Render("Handoff blocked until approved: "+strings.Join(unmet, ", ")))
+	}
+	return nil
+}
+
+func runPlannerGraph(cmd *cobra.Command, args []string) error {
+	mgr, _, err := getPlannerManager()
+	if err != nil {
+		return err
+	}
+	graph, err := mgr.DependencyGraph()
+	if err != nil {
+		return err
+	}
+
+	if plannerGraphDOT {
+		return graph.WriteDOT(os.Stdout)
+	}
+
+	if len(graph.Sessions) == 0 {
+		fmt.Println("No planning sessions")
+		return nil
+	}
+
+	fmt.Printf("%s Spec Dependencies\n\n", style.Bold.Render("📋"))
+	for _, id := range graph.IDs() {
+		session := graph.Sessions[id]
+		fmt.Printf("  %s %s %s\n", id, session.Title, style.Dim.Render("("+string(session.Status)+")"))
+		for _, dep := range session.DependsOn {
+			fmt.Printf("    → %s %s\n", dep, style.Dim.Render(plannerDependencyStatus(mgr, dep)))
+		}
+	}
+	return nil
+}
+
+// plannerDependencyStatus describes a prerequisite spec for display.
+func plannerDependencyStatus(mgr *planner.Manager, specID string) string {
+	dep, err := mgr.LoadSession(specID)
+	if err != nil {
+		return "(missing)"
+	}
+	if dep.IsApproved() {
+		return fmt.Sprintf("(%s ✓)", dep.Status)
+	}
+	return fmt.Sprintf("(%s, blocks handoff)", dep.Status)
+}
+
+func runPlannerHandoff(cmd *cobra.Command, args []string) error {
+	mgr, _, err := getPlannerManager()
+	if err != nil {
+		return err
+	}
+
+	var session *planner.PlanningSession
+	if len(args) > 0 {
+		session, err = mgr.LoadSession(args[0])
+		if err != nil {
+			return fmt.Errorf("loading session: %w", err)
+		}
+	} else {
+		session, err = loadActivePlanningSession(mgr)
+		if err != nil {
+			return err
+		}
+	}
+
+	if session.Status == planner.StatusCancelled {
+		return fmt.Errorf("session %s is cancelled", session.ID)
+	}
+
+	if plannerHandoffIntegration {
+		session.IntegrationBranches = true
+	}
+	created, err := mgr.Handoff(session)
+	for _, id := range created {
+		fmt.Printf("  Created follow-up bead %s\n", id)
+	}
+	if errors.Is(err, planner.ErrTasksIncomplete) {
+		fmt.Printf("  %s\n", style.Dim.Render("See: gt planner tasks lint "+session.ID))
+	}
+	if err != nil {
+		return fmt.Errorf("handing off session: %w", err)
+	}
+
+	fmt.Printf("%s Planning session %s handed off\n", style.Bold.Render("✓"), session.ID)
+	for _, s := range session.Slices {
+		if s.EpicID != "" {
+			fmt.Printf("  Slice %d epic: %s\n", s.Number, s.EpicID)
+		}
+		if s.IntegrationBranch != "" {
+			fmt.Printf("    Integration branch: %s\n", s.IntegrationBranch)
+		}
+	}
+	if pub := session.Publication; pub != nil && pub.IntegrationBranch != "" {
+		fmt.Printf("  Epic %s integration branch: %s\n", pub.Epic, pub.IntegrationBranch)
+	}
+	if open := session.OpenRisks(); len(open) > 0 {
+		fmt.Printf("  %s\n", style.Dim.Render(fmt.Sprintf("%d open risk(s) carried over in risks.md", len(open))))
+	}
+	return nil
+}
+
+func runPlannerPublish(cmd *cobra.Command, args []string) error {
+	mgr, _, err := getPlannerManager()
+	if err != nil {
+		return err
+	}
+
+	session, err := mgr.LoadSession(args[0])
+	if err != nil {
+		return fmt.Errorf("loading session: %w", err)
+	}
+
+	pub, err := mgr.Publish(session, planner.PublishOptions{
+		Dest:  plannerPublishDest,
+		Owner: plannerPublishOwner,
+		Epic:  plannerPublishEpic,
+		NoMR:  plannerPublishNoMR,
+	})
+	if err != nil {
+		return fmt.Errorf("publishing spec: %w", err)
+	}
+
+	fmt.Printf("%s Published spec for %s\n", style.Bold.Render("✓"), session.ID)
+	fmt.Printf("  Path: %s\n", pub.Path)
+	for _, r := range pub.Records {
+		fmt.Printf("  Record: %s\n", r)
+	}
+	fmt.Printf("  Branch: %s → %s\n", pub.Branch, pub.Target)
+	if pub.MRID != "" {
+		fmt.Printf("  MR: %s\n", style.Bold.Render(pub.MRID))
+	} else {
+		fmt.Printf("  %s\n", style.Dim.Render("Not submitted (--no-mr); run 'gt mq submit --branch "+pub.Branch+" --issue "+session.ID+"' when ready"))
+	}
+	return nil
+}
+
 // getPlannerAgentManager returns a planner agent manager for the current rig.
 func getPlannerAgentManager() (*planneragent.Manager, *rig.Rig, error) {
 	// Find town root
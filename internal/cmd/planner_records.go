@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+// Decision log and glossary command flags
+var (
+	plannerDecideRationale string
+	plannerDecideQuestion  string
+	plannerDefineQuestion  string
+)
+
+var plannerDecideCmd = &cobra.Command{
+	Use:   "decide [decision]",
+	Short: "Record a decision in the decision log",
+	Long: `Record a significant decision in the active planning session's decision
+log, with its rationale and date.
+
+The log is regenerated in planning/decisions.md on every save and linked
+from SPEC.md. With --question and no decision text, the question's answer
+is recorded as the decision.
+
+Examples:
+  gt planner decide "Use JWT with refresh tokens" --rationale "Stateless API servers"
+  gt planner decide --question q2 --rationale "Only Google accounts at launch"`,
+	RunE: runPlannerDecide,
+}
+
+var plannerDefineCmd = &cobra.Command{
+	Use:   "define <term> <definition>",
+	Short: "Add a domain term to the glossary",
+	Long: `Add a domain term to the active planning session's glossary.
+
+Terms collected while questioning are regenerated in planning/glossary.md
+on every save and linked from SPEC.md. Defining a term again replaces its
+definition.
+
+Examples:
+  gt planner define "Household" "The guardians and children sharing one account"
+  gt planner define "Roster" "A class's enrolled students" --question q4`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: runPlannerDefine,
+}
+
+func init() {
+	plannerDecideCmd.Flags().StringVar(&plannerDecideRationale, "rationale", "", "Why the decision was made")
+	plannerDecideCmd.Flags().StringVar(&plannerDecideQuestion, "question", "", "Question whose answer settled the decision")
+	plannerDefineCmd.Flags().StringVar(&plannerDefineQuestion, "question", "", "Question the term came up in")
+
+	plannerCmd.AddCommand(plannerDecideCmd)
+	plannerCmd.AddCommand(plannerDefineCmd)
+}
+
+func runPlannerDecide(cmd *cobra.Command, args []string) error {
+	text := strings.Join(args, " ")
+	if text == "" && plannerDecideQuestion == "" {
+		return fmt.Errorf("give the decision, or --question to record a question's answer")
+	}
+
+	mgr, _, err := getPlannerManager()
+	if err != nil {
+		return err
+	}
+	session, err := loadActivePlanningSession(mgr)
+	if err != nil {
+		return err
+	}
+
+	decision, err := session.AddDecision(text, plannerDecideRationale, plannerDecideQuestion)
+	if err != nil {
+		return err
+	}
+	if err := mgr.SaveSession(session); err != nil {
+		return fmt.Errorf("saving session: %w", err)
+	}
+
+	fmt.Printf("%s Decision %s recorded: %s\n", style.Bold.Render("✓"), decision.ID, decision.Text)
+	return nil
+}
+
+func runPlannerDefine(cmd *cobra.Command, args []string) error {
+	mgr, _, err := getPlannerManager()
+	if err != nil {
+		return err
+	}
+	session, err := loadActivePlanningSession(mgr)
+	if err != nil {
+		return err
+	}
+
+	term, err := session.DefineTerm(args[0], strings.Join(args[1:], " "), plannerDefineQuestion)
+	if err != nil {
+		return err
+	}
+	if err := mgr.SaveSession(session); err != nil {
+		return fmt.Errorf("saving session: %w", err)
+	}
+
+	fmt.Printf("%s Defined %q in the glossary\n", style.Bold.Render("✓"), term.Term)
+	return nil
+}
@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/planner"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/ui"
+)
+
+// Slice command flags
+var (
+	plannerSliceInto   int
+	plannerSliceAccept bool
+	plannerSliceEpics  bool
+	plannerSliceJSON   bool
+)
+
+var plannerSliceCmd = &cobra.Command{
+	Use:   "slice <session-id>",
+	Short: "Split an approved spec into shippable slices",
+	Long: `Explore splitting an approved spec into independently shippable slices.
+
+With --into N, mails the rig's planner agent a SLICE request to propose N
+slices, each a group of tasks from spec/tasks.md that ships on its own. The
+agent writes its proposal to planning/slices.md:
+
+  ## Slice 1: Token store
+  Tasks: T1, T2
+  Ships storage and login without SSO.
+
+Without --into, shows the proposal and checks that every task is in exactly
+one slice. Nothing changes until the proposal is accepted with --accept,
+which writes each slice's tasks to spec/slices/slice-<n>.md as its own task
+group and records the slices on the session. With --epics, handoff then
+creates a separate epic for each slice.
+
+Examples:
+  gt planner slice gt-plan-abc123 --into 2
+  gt planner slice gt-plan-abc123
+  gt planner slice gt-plan-abc123 --accept --epics`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPlannerSlice,
+}
+
+func init() {
+	plannerSliceCmd.Flags().IntVar(&plannerSliceInto, "into", 0, "Ask the planner agent to propose this many slices")
+	plannerSliceCmd.Flags().BoolVar(&plannerSliceAccept, "accept", false, "Accept the proposal and generate per-slice task groups")
+	plannerSliceCmd.Flags().BoolVar(&plannerSliceEpics, "epics", false, "With --accept, create an epic per slice at handoff")
+	plannerSliceCmd.Flags().BoolVar(&plannerSliceJSON, "json", false, "Output as JSON")
+
+	plannerCmd.AddCommand(plannerSliceCmd)
+}
+
+func runPlannerSlice(cmd *cobra.Command, args []string) error {
+	if plannerSliceInto != 0 && plannerSliceAccept {
+		return errors.New("--into and --accept can't be combined: accept once the agent has proposed slices")
+	}
+	if plannerSliceEpics && !plannerSliceAccept {
+		return errors.New("--epics requires --accept")
+	}
+
+	mgr, _, err := getPlannerManager()
+	if err != nil {
+		return err
+	}
+	session, err := mgr.LoadSession(args[0])
+	if err != nil {
+		return fmt.Errorf("loading session: %w", err)
+	}
+
+	if plannerSliceInto != 0 {
+		if err := mgr.RequestSlices(session, plannerSliceInto); err != nil {
+			return err
+		}
+		fmt.Printf("%s Asked the planner for %d slices of %s\n", style.Bold.Render("✓"), plannerSliceInto, session.ID)
+		fmt.Printf("  %s\n", style.Dim.Render("Review the proposal with: gt planner slice "+session.ID))
+		return nil
+	}
+
+	slices, err := mgr.LoadSliceProposal(session)
+	if errors.Is(err, planner.ErrNoSliceProposal) && len(session.Slices) > 0 && !plannerSliceAccept {
+		// No new proposal; show the accepted slices
+		return printPlannerSlices(session.Slices, nil, "Accepted slices")
+	}
+	if errors.Is(err, planner.ErrNoSliceProposal) {
+		if session.SliceRequest != nil {
+			return fmt.Errorf("%w; the planner was asked for %d slices at %s",
+				err, session.SliceRequest.Into, session.SliceRequest.RequestedAt.Format("2006-01-02 15:04"))
+		}
+		return fmt.Errorf("%w; ask for one with: gt planner slice %s --into 2", err, session.ID)
+	}
+	invalid := errors.Is(err, planner.ErrInvalidSlices)
+	if err != nil && !invalid {
+		return err
+	}
+
+	if !plannerSliceAccept {
+		if err := printPlannerSlices(slices, err, "Proposed slices"); err != nil {
+			return err
+		}
+		if invalid {
+			return NewSilentExit(1)
+		}
+		if !plannerSliceJSON {
+			fmt.Printf("\n  %s\n", style.Dim.Render("Accept with: gt planner slice "+session.ID+" --accept [--epics]"))
+		}
+		return nil
+	}
+
+	if invalid {
+		return err
+	}
+	paths, err := mgr.AcceptSlices(session, slices, plannerSliceEpics)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%s Accepted %d slices for %s\n", style.Bold.Render("✓"), len(slices), session.ID)
+	for _, p := range paths {
+		fmt.Printf("  %s\n", p)
+	}
+	if plannerSliceEpics {
+		fmt.Printf("  %s\n", style.Dim.Render("Handoff will create an epic per slice"))
+	}
+	return nil
+}
+
+func printPlannerSlices(slices []planner.Slice, problem error, heading string) error {
+	if plannerSliceJSON {
+		out := struct {
+			Slices  []planner.Slice `json:"slices"`
+			Problem string          `json:"problem,omitempty"`
+		}{Slices: slices}
+		if problem != nil {
+			out.Problem = problem.Error()
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(out)
+	}
+
+	fmt.Printf("%s\n\n", style.Bold.Render(heading))
+	for _, s := range slices {
+		fmt.Printf("  Slice %d: %s\n", s.Number, s.Title)
+		fmt.Printf("    Tasks: %s\n", strings.Join(s.Tasks, ", "))
+		if s.Rationale != "" {
+			fmt.Printf("    %s\n", style.Dim.Render(strings.ReplaceAll(s.Rationale, "\n", "\n    ")))
+		}
+		if s.EpicID != "" {
+			fmt.Printf("    Epic: %s\n", s.EpicID)
+		}
+	}
+	if problem != nil {
+		fmt.Printf("\n%s %v\n", ui.RenderWarnIcon(), problem)
+	}
+	return nil
+}
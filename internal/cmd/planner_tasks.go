@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/planner"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/ui"
+)
+
+// Tasks command flags
+var (
+	plannerTasksJSON   bool
+	plannerTasksReason string
+)
+
+var plannerTasksCmd = &cobra.Command{
+	Use:   "tasks",
+	Short: "Check the task breakdown in tasks.md",
+	Long: `Check the task breakdown of a planning session's spec/tasks.md.
+
+Every task needs acceptance criteria and a size estimate (XS, S, M, L, XL)
+before the session can be handed off. Tasks are headings like "## T1: Title":
+
+  ## T1: Add token store
+  Size: M
+  Acceptance criteria:
+  - Tokens survive a restart
+  - Expired tokens are rejected
+
+Handoff is blocked while any task is missing either, unless the task is
+waived with 'gt planner tasks waive'.
+
+Examples:
+  gt planner tasks lint gt-plan-abc123
+  gt planner tasks waive gt-plan-abc123 T4 --reason "Spike, sized after research"`,
+	RunE: requireSubcommand,
+}
+
+var plannerTasksLintCmd = &cobra.Command{
+	Use:   "lint <session-id>",
+	Short: "Report tasks missing acceptance criteria or a size",
+	Long: `Report tasks in a session's tasks.md that are missing acceptance
+criteria or a size estimate. Waived tasks are listed but don't fail the lint.
+
+Exits non-zero if any task blocks handoff.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPlannerTasksLint,
+}
+
+var plannerTasksWaiveCmd = &cobra.Command{
+	Use:   "waive <session-id> <task-id>...",
+	Short: "Allow tasks to be handed off incomplete",
+	Args:  cobra.MinimumNArgs(2),
+	RunE:  runPlannerTasksWaive,
+}
+
+func init() {
+	plannerTasksLintCmd.Flags().BoolVar(&plannerTasksJSON, "json", false, "Output as JSON")
+	plannerTasksWaiveCmd.Flags().StringVar(&plannerTasksReason, "reason", "", "Why the tasks may be handed off as they are (required)")
+	_ = plannerTasksWaiveCmd.MarkFlagRequired("reason")
+
+	plannerTasksCmd.AddCommand(plannerTasksLintCmd)
+	plannerTasksCmd.AddCommand(plannerTasksWaiveCmd)
+	plannerCmd.AddCommand(plannerTasksCmd)
+}
+
+func runPlannerTasksLint(cmd *cobra.Command, args []string) error {
+	mgr, _, err := getPlannerManager()
+	if err != nil {
+		return err
+	}
+	session, err := mgr.LoadSession(args[0])
+	if err != nil {
+		return fmt.Errorf("loading session: %w", err)
+	}
+	lint, err := mgr.LintSessionTasks(session)
+	if err != nil {
+		return err
+	}
+	if lint == nil {
+		return fmt.Errorf("session %s has no spec/tasks.md yet", session.ID)
+	}
+	blocking := lint.Blocking()
+
+	if plannerTasksJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(lint); err != nil {
+			return err
+		}
+	} else {
+		printTaskLint(lint, len(blocking))
+	}
+
+	if len(blocking) > 0 {
+		return NewSilentExit(1)
+	}
+	return nil
+}
+
+func printTaskLint(lint *planner.TaskLint, blocking int) {
+	if lint.Tasks == 0 {
+		fmt.Printf("%s No tasks found in %s\n", ui.RenderWarnIcon(), lint.Path)
+		fmt.Printf("  %s\n", style.Dim.Render(`Tasks are headings like "## T1: Title"`))
+		return
+	}
+
+	for _, p := range lint.Problems {
+		line := fmt.Sprintf("%s %s: missing %s", p.ID, p.Title, strings.Join(p.Missing, " and "))
+		if p.Waiver != "" {
+			fmt.Printf("  %s %s\n", style.Dim.Render("○"), style.Dim.Render(line+" (waived: "+p.Waiver+")"))
+		} else {
+			fmt.Printf("  %s %s\n", ui.RenderWarnIcon(), line)
+		}
+	}
+
+	if blocking == 0 {
+		fmt.Printf("%s %d task(s) have acceptance criteria and a size\n", style.Bold.Render("✓"), lint.Tasks-len(lint.Problems))
+		return
+	}
+	fmt.Printf("\n%d of %d task(s) block handoff. Fix tasks.md or waive with:\n", blocking, lint.Tasks)
+	fmt.Printf("  %s\n", style.Dim.Render("gt planner tasks waive <session-id> <task-id> --reason \"...\""))
+}
+
+func runPlannerTasksWaive(cmd *cobra.Command, args []string) error {
+	mgr, _, err := getPlannerManager()
+	if err != nil {
+		return err
+	}
+	session, err := mgr.LoadSession(args[0])
+	if err != nil {
+		return fmt.Errorf("loading session: %w", err)
+	}
+
+	taskIDs := args[1:]
+	if err := mgr.WaiveTasks(session, taskIDs, plannerTasksReason); err != nil {
+		return err
+	}
+	fmt.Printf("%s Waived %s\n", style.Bold.Render("✓"), strings.Join(taskIDs, ", "))
+	return nil
+}
@@ -6,11 +6,13 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/steveyegge/gastown/internal/beads"
 	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/constants"
 	"github.com/steveyegge/gastown/internal/events"
 	"github.com/steveyegge/gastown/internal/git"
 	"github.com/steveyegge/gastown/internal/polecat"
+	"github.com/steveyegge/gastown/internal/refinery"
 	"github.com/steveyegge/gastown/internal/rig"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/tmux"
@@ -83,14 +85,25 @@ func SpawnPolecatForSling(rigName string, opts SlingSpawnOptions) (*SpawnedPolec
 	}
 	fmt.Printf("Allocated polecat: %s\n", polecatName)
 
-	// Check if polecat already exists (shouldn't happen - indicates stale state needing repair)
-	existingPolecat, err := polecatMgr.Get(polecatName)
-
 	// Build add options with hook_bead set atomically at spawn time
 	addOpts := polecat.AddOptions{
 		HookBead: opts.HookBead,
 	}
 
+	// Work under an epic with an integration branch starts from that branch,
+	// so the polecat's MR merges into it cleanly
+	if opts.HookBead != "" {
+		base, err := refinery.FindIntegrationBranch(beads.New(r.Path), opts.HookBead)
+		if err != nil {
+			style.PrintWarning("could not check %s for an integration branch: %v", opts.HookBead, err)
+		} else if base != "" {
+			addOpts.BaseBranch = base
+			fmt.Printf("Branching from integration branch %s\n", base)
+		}
+	}
+
+	// Check if polecat already exists (shouldn't happen - indicates stale state needing repair)
+	existingPolecat, err := polecatMgr.Get(polecatName)
 	if err == nil {
 		// Stale state: polecat exists despite fresh name allocation - repair it
 		// Check for uncommitted work first
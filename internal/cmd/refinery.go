@@ -188,6 +188,13 @@ var refineryReadyCmd = &cobra.Command{
 Shows MRs that are:
 - Not currently claimed by any worker (or claim is stale)
 - Not blocked by an open task (e.g., conflict resolution in progress)
+- Not held by a merge throttle (merge_queue.max_merges_per_epic_per_day
+  or merge_queue.worker_cooldown)
+
+If the refinery's last preflight found its environment unhealthy (less
+than merge_queue.min_free_disk_mb free, an unfinished merge, or a failing
+git fsck), the problems are listed first: MRs are skipped until they are
+fixed.
 
 This is the preferred command for finding work to process.
 
@@ -687,10 +694,14 @@ func runRefineryReady(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	// Create engineer for the rig (it has beads access for status checking)
+	// Create engineer for the rig (it has beads access for status checking).
+	// The config supplies the merge throttles.
 	eng := refinery.NewEngineer(r)
+	if err := eng.LoadConfig(); err != nil {
+		return fmt.Errorf("loading refinery config: %w", err)
+	}
 
-	// Get ready MRs (unclaimed AND unblocked)
+	// Get ready MRs (unclaimed, unblocked, and not throttled)
 	ready, err := eng.ListReadyMRs()
 	if err != nil {
 		return fmt.Errorf("listing ready MRs: %w", err)
@@ -705,6 +716,7 @@ func runRefineryReady(cmd *cobra.Command, args []string) error {
 
 	// Human-readable output
 	fmt.Printf("%s Ready MRs for '%s':\n\n", style.Bold.Render("🚀"), rigName)
+	printQueuePause(r.Path, rigName)
 
 	if len(ready) == 0 {
 		fmt.Printf("  %s\n", style.Dim.Render("(none ready)"))
@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/web"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var (
+	servePort    int
+	serveResults string
+	serveOpen    bool
+)
+
+var serveCmd = &cobra.Command{
+	Use:     "serve",
+	GroupID: GroupDiag,
+	Short:   "Serve read-only town dashboards over HTTP",
+	Long: `Start a small read-only web server with town-wide dashboards.
+
+The HTML page at / is meant for a wall monitor and refreshes every 30
+seconds. It shows:
+- Merge queue state for each rig
+- Recent tester batches with a pass-rate sparkline
+- Quarantined test scenarios
+- Unread mail counts
+- Running agent sessions
+
+The same data is available as JSON:
+  /api/status       Everything (per-section failures under "errors")
+  /api/mq           Merge queues
+  /api/batches      Recent batches, newest first
+  /api/quarantine   Quarantined scenarios
+  /api/inbox        Mail counts
+  /api/sessions     Agent sessions
+
+Example:
+  gt serve                          # Start on default port 8081
+  gt serve --port 3000              # Start on port 3000
+  gt serve --results ./test-results # Read batches from another directory`,
+	RunE: runServe,
+}
+
+func init() {
+	serveCmd.Flags().IntVar(&servePort, "port", 8081, "HTTP port to listen on")
+	serveCmd.Flags().StringVar(&serveResults, "results", "test-results", "Tester output directory for batches and quarantine")
+	serveCmd.Flags().BoolVar(&serveOpen, "open", false, "Open browser automatically")
+	rootCmd.AddCommand(serveCmd)
+}
+
+func runServe(cmd *cobra.Command, args []string) error {
+	// Verify we're in a workspace
+	if _, err := workspace.FindFromCwdOrError(); err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	fetcher, err := web.NewLiveTownFetcher(serveResults)
+	if err != nil {
+		return fmt.Errorf("creating town fetcher: %w", err)
+	}
+
+	handler, err := web.NewTownHandler(fetcher)
+	if err != nil {
+		return fmt.Errorf("creating town handler: %w", err)
+	}
+
+	url := fmt.Sprintf("http://localhost:%d", servePort)
+
+	if serveOpen {
+		go openBrowser(url)
+	}
+
+	fmt.Printf("🏙  Gas Town dashboards at %s (JSON under %s/api/)\n", url, url)
+	fmt.Printf("   Press Ctrl+C to stop\n")
+
+	server := &http.Server{
+		Addr:              fmt.Sprintf(":%d", servePort),
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+		ReadTimeout:       30 * time.Second,
+		WriteTimeout:      60 * time.Second,
+		IdleTimeout:       120 * time.Second,
+	}
+	return server.ListenAndServe()
+}
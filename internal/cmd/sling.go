@@ -478,6 +478,13 @@ func runSling(cmd *cobra.Command, args []string) error {
 		fmt.Printf("%s Could not store dispatcher in bead: %v\n", style.Dim.Render("Warning:"), err)
 	}
 
+	// Open a work-log session so reviewers can reconstruct how the change was produced
+	if strings.Contains(targetAgent, "/polecats/") {
+		if err := startPolecatWorkLog(townRoot, beadID, targetAgent, hookWorkDir); err != nil {
+			fmt.Printf("%s Could not start work log: %v\n", style.Dim.Render("Warning:"), err)
+		}
+	}
+
 	// Store args in bead description (no-tmux mode: beads as data plane)
 	if slingArgs != "" {
 		if err := storeArgsInBead(beadID, slingArgs); err != nil {
@@ -116,6 +116,11 @@ func runBatchSling(beadIDs []string, rigName string, townBeadsDir string) error
 			fmt.Printf("  %s Could not attach work molecule: %v\n", style.Dim.Render("Warning:"), err)
 		}
 
+		// Open a work-log session for the polecat
+		if err := startPolecatWorkLog(townRoot, beadID, targetAgent, hookWorkDir); err != nil {
+			fmt.Printf("  %s Could not start work log: %v\n", style.Dim.Render("Warning:"), err)
+		}
+
 		// Store args if provided
 		if slingArgs != "" {
 			if err := storeArgsInBead(beadID, slingArgs); err != nil {
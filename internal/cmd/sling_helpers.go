@@ -5,12 +5,15 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
 	"time"
 
 	"github.com/steveyegge/gastown/internal/beads"
 	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/polecat"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/tmux"
 	"github.com/steveyegge/gastown/internal/workspace"
@@ -170,6 +173,61 @@ func storeDispatcherInBead(beadID, dispatcher string) error {
 	return nil
 }
 
+// startPolecatWorkLog opens a work-log session for a polecat that was just
+// hooked to beadID and links the log from the bead's description.
+// The branch is read from the polecat's worktree when available.
+func startPolecatWorkLog(townRoot, beadID, agent, workDir string) error {
+	branch := ""
+	if workDir != "" {
+		if b, err := git.NewGit(workDir).CurrentBranch(); err == nil {
+			branch = b
+		}
+	}
+	if err := polecat.StartWorkSession(townRoot, beadID, agent, branch); err != nil {
+		return fmt.Errorf("recording work session: %w", err)
+	}
+
+	// Get the bead to preserve existing description content
+	showCmd := exec.Command("bd", "show", beadID, "--json")
+	out, err := showCmd.Output()
+	if err != nil {
+		return fmt.Errorf("fetching bead: %w", err)
+	}
+
+	// Parse the bead
+	var issues []beads.Issue
+	if err := json.Unmarshal(out, &issues); err != nil {
+		return fmt.Errorf("parsing bead: %w", err)
+	}
+	if len(issues) == 0 {
+		return fmt.Errorf("bead not found")
+	}
+	issue := &issues[0]
+
+	fields := beads.ParseAttachmentFields(issue)
+	if fields == nil {
+		fields = &beads.AttachmentFields{}
+	}
+	relPath, err := filepath.Rel(townRoot, polecat.WorkLogPath(townRoot, beadID))
+	if err != nil {
+		return fmt.Errorf("computing work log path: %w", err)
+	}
+	if fields.WorkLog == relPath {
+		return nil // Already linked
+	}
+	fields.WorkLog = relPath
+
+	// Update the bead
+	newDesc := beads.SetAttachmentFields(issue, fields)
+	updateCmd := exec.Command("bd", "update", beadID, "--description="+newDesc)
+	updateCmd.Stderr = os.Stderr
+	if err := updateCmd.Run(); err != nil {
+		return fmt.Errorf("updating bead description: %w", err)
+	}
+
+	return nil
+}
+
 // storeAttachedMoleculeInBead sets the attached_molecule field in a bead's description.
 // This is required for gt hook to recognize that a molecule is attached to the bead.
 // Called after bonding a formula wisp to a bead via "gt sling <formula> --on <bead>".
@@ -24,19 +24,31 @@ application, observing UX issues through the eyes of specific personas.
 
 RUNNING TESTS:
   gt tester run <scenario.yaml>      Run a single test scenario
+  gt tester rerun <run-dir>          Re-run a scenario exactly as recorded
   gt tester preflight                Check environment before testing
+  gt tester doctor                   Diagnose the Playwright/browser stack
 
 MANAGING SCENARIOS:
   gt tester list                     List available scenarios
   gt tester validate <pattern>       Validate scenario files
+  gt tester probe <scenario.yaml>    Time wait strategies against the target
+  gt tester migrate-scenarios        Upgrade scenarios to the current schema
 
 VIEWING RESULTS:
   gt tester results [date]           View test results
   gt tester review                   Review and validate observations
+  gt tester review stats             False-positive rates from reviews
   gt tester artifacts <run-path>     Open test artifacts
+  gt tester observations export      Export observations (CSV/SARIF)
+  gt tester verify <path>            Check signed results for tampering
+  gt tester personas                 Compare scenario results by persona
 
 BATCH EXECUTION:
   gt tester batch <pattern>          Run multiple scenarios
+  gt tester batch status             Show running batches, detect hung ones
+  gt tester baseline show            Show pinned baselines per environment
+  gt tester schedule add <cron>      Run batches on a cron schedule
+  gt tester triage <batch-id>        Triage a batch's new issues
 
 STABILITY:
   gt tester flaky                    View flaky test metrics
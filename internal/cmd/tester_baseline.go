@@ -0,0 +1,207 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/tester/batch"
+	"github.com/steveyegge/gastown/internal/ui"
+)
+
+var (
+	baselineOutputDir string
+	baselineEnv       string
+)
+
+var testerBaselineCmd = &cobra.Command{
+	Use:   "baseline",
+	Short: "Manage pinned batch baselines",
+	Long: `Pin a "golden" batch run per environment for regression tracking.
+
+Once a baseline is pinned, 'gt tester batch --compare-to baseline' compares
+against the pinned batch for the batch's environment instead of a raw ID.
+
+SUBCOMMANDS:
+  set      Pin a batch as the baseline (no gates)
+  show     Show pinned baselines
+  promote  Pin a batch only if it passes promotion gates
+
+Promotion gates: the batch completed, ran at least one scenario, had no
+failures or errors, and introduced no new issues vs the current baseline.
+
+Examples:
+  gt tester baseline set a1b2c3d4
+  gt tester baseline set a1b2c3d4 --env production
+  gt tester baseline show
+  gt tester baseline promote e5f6a7b8`,
+	RunE: requireSubcommand,
+}
+
+var baselineSetCmd = &cobra.Command{
+	Use:   "set <batch-id>",
+	Short: "Pin a batch as the baseline",
+	Long: `Pin a batch run as the baseline for its environment.
+
+The environment defaults to the one the batch ran against; use --env to
+override. Replaces any existing pin without checking gates.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runBaselineSet,
+}
+
+var baselineShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Show pinned baselines",
+	Long:  `Show the pinned baseline for each environment (or only --env).`,
+	Args:  cobra.NoArgs,
+	RunE:  runBaselineShow,
+}
+
+var baselinePromoteCmd = &cobra.Command{
+	Use:   "promote <batch-id>",
+	Short: "Promote a batch to baseline if gates pass",
+	Long: `Promote a batch run to baseline for its environment.
+
+The pin is only updated when the batch passes the promotion gates; otherwise
+the failing gates are listed and the command exits non-zero.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runBaselinePromote,
+}
+
+func init() {
+	testerBaselineCmd.PersistentFlags().StringVar(&baselineOutputDir, "output", "test-results", "Output directory containing batch results")
+	testerBaselineCmd.PersistentFlags().StringVar(&baselineEnv, "env", "", "Environment (default: the batch's environment)")
+
+	testerBaselineCmd.AddCommand(baselineSetCmd)
+	testerBaselineCmd.AddCommand(baselineShowCmd)
+	testerBaselineCmd.AddCommand(baselinePromoteCmd)
+
+	testerCmd.AddCommand(testerBaselineCmd)
+}
+
+func runBaselineSet(cmd *cobra.Command, args []string) error {
+	result, err := batch.LoadBatchResult(baselineOutputDir, args[0])
+	if err != nil {
+		return err
+	}
+
+	return pinBaseline(result, false)
+}
+
+func runBaselineShow(cmd *cobra.Command, args []string) error {
+	store, err := batch.LoadBaselineStore(baselineOutputDir)
+	if err != nil {
+		return err
+	}
+
+	pins := store.List()
+	if baselineEnv != "" {
+		pins = nil
+		if pin, ok := store.Get(baselineEnv); ok {
+			pins = append(pins, pin)
+		}
+	}
+
+	if testerJSON {
+		data, _ := json.MarshalIndent(pins, "", "  ")
+		fmt.Println(string(data))
+		return nil
+	}
+
+	if len(pins) == 0 {
+		fmt.Println("No baselines pinned")
+		return nil
+	}
+
+	fmt.Printf("Baselines (%d)\n", len(pins))
+	fmt.Println(strings.Repeat("─", 60))
+	for _, pin := range pins {
+		how := "Pinned"
+		if pin.Promoted {
+			how = "Promoted"
+		}
+		fmt.Printf("  %s: %s\n", pin.Environment, pin.BatchID)
+		fmt.Printf("    %s %s", how, pin.PinnedAt.Format("2006-01-02 15:04"))
+		if pin.PinnedBy != "" {
+			fmt.Printf(" by %s", pin.PinnedBy)
+		}
+		fmt.Println()
+		if pin.Previous != "" {
+			fmt.Printf("    Previous: %s\n", pin.Previous)
+		}
+	}
+
+	return nil
+}
+
+func runBaselinePromote(cmd *cobra.Command, args []string) error {
+	candidate, err := batch.LoadBatchResult(baselineOutputDir, args[0])
+	if err != nil {
+		return err
+	}
+
+	store, err := batch.LoadBaselineStore(baselineOutputDir)
+	if err != nil {
+		return err
+	}
+
+	var current *batch.BatchResult
+	if pin, ok := store.Get(baselineEnvFor(candidate)); ok {
+		current, err = batch.LoadBatchResult(baselineOutputDir, pin.BatchID)
+		if err != nil {
+			fmt.Printf("%s Current baseline %s not found, promoting without comparison: %v\n",
+				ui.RenderWarnIcon(), pin.BatchID, err)
+		}
+	}
+
+	if failures := batch.CheckPromotion(candidate, current); len(failures) > 0 {
+		fmt.Printf("%s Batch %s not promoted:\n", ui.RenderFailIcon(), candidate.ID)
+		for _, f := range failures {
+			fmt.Printf("  - %s\n", f)
+		}
+		return NewSilentExit(1)
+	}
+
+	return pinBaseline(candidate, true)
+}
+
+// pinBaseline records result as the baseline for its environment.
+func pinBaseline(result *batch.BatchResult, promoted bool) error {
+	store, err := batch.LoadBaselineStore(baselineOutputDir)
+	if err != nil {
+		return err
+	}
+
+	pin := batch.BaselinePin{
+		Environment: baselineEnvFor(result),
+		BatchID:     result.ID,
+		PinnedBy:    os.Getenv("BD_ACTOR"),
+		Promoted:    promoted,
+	}
+	if pin.PinnedBy == "" {
+		pin.PinnedBy = os.Getenv("USER")
+	}
+	if err := store.Set(pin); err != nil {
+		return fmt.Errorf("saving baseline: %w", err)
+	}
+
+	pin, _ = store.Get(pin.Environment)
+	fmt.Printf("%s Baseline for %s: %s\n", ui.RenderPassIcon(), pin.Environment, pin.BatchID)
+	if pin.Previous != "" {
+		fmt.Printf("  Replaced: %s\n", pin.Previous)
+	}
+	return nil
+}
+
+// baselineEnvFor returns the environment a batch's baseline is pinned under.
+func baselineEnvFor(result *batch.BatchResult) string {
+	if baselineEnv != "" {
+		return baselineEnv
+	}
+	if result.Config.Environment != "" {
+		return result.Config.Environment
+	}
+	return "staging"
+}
@@ -4,10 +4,18 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/tester"
+	"github.com/steveyegge/gastown/internal/tester/artifacts"
 	"github.com/steveyegge/gastown/internal/tester/batch"
 )
 
@@ -16,20 +24,155 @@ var (
 	batchStopOnFail         bool
 	batchConvoy             string
 	batchModel              string
+	batchAB                 []string
+	batchRotatePersonas     bool
 	batchFilter             []string
 	batchExclude            []string
 	batchIncludeQuarantined bool
 	batchCompareTo          string
+	batchCompareWindow      int
 	batchOutputDir          string
+	batchManifest           string
+	batchSuiteURL           string
+	batchUpload             string
+	batchKeepLocal          bool
+	batchOnlyChanged        string
+	batchChangeMap          string
+	batchJourneys           string
+	batchResume             string
+	batchA11y               bool
+	batchBuildSHA           string
+	batchAppVersion         string
+	batchWarmUp             bool
+	batchWarmUpDeferrals    int
+	batchSign               string
+	batchSuite              string
+	batchSuiteDir           string
+	batchSignKey            string
+	batchMailTo             string
+	batchNoMail             bool
+	batchFormat             string
+	batchNoRetriesReport    bool
 )
 
 var testerBatchCmd = &cobra.Command{
-	Use:   "batch <pattern>",
+	Use:   "batch [pattern]",
 	Short: "Run multiple test scenarios",
 	Long: `Run multiple test scenarios matching a glob pattern.
 
+Scenarios can also come from a suite manifest (--manifest) listing explicit
+scenarios with per-scenario model and tag overrides, or from an HTTP endpoint
+serving the same suite definition (--suite-url) for central suite management
+across towns. Remote scenarios are cached under <output>/.suite-cache.
+
+Named suites (--suite smoke) are suite files (suite.yaml, or <name>.suite.yaml)
+found under --suite-dir, matched by their name field or, without one, by
+their directory or file name. A suite can include other suites by name or
+path; included suites inherit the includer's defaults (model, environment,
+retry, tags) wherever their own defaults don't set them.
+
+Suite definition format:
+  name: parent-portal
+  include: [auth]                # other suites (local suites only)
+  defaults:
+    model: sonnet
+    environment: staging
+    retry:
+      max_attempts: 2
+    tags: [smoke]
+  scenarios:
+    - path: registration/signup.yaml
+      tags: [critical-path]
+    - glob: checkout/*.yaml      # manifest files only
+
+Artifacts (video, trace, screenshots) can be uploaded to object storage after
+each run with --upload s3://bucket/prefix or gs://bucket/prefix, or by setting
+GT_TESTER_UPLOAD in CI. Artifact manifests are rewritten to the uploaded URLs
+and local copies are removed unless --keep-local is set.
+
+--a11y adds an axe-core accessibility scan at key steps of every scenario.
+Violations are merged into each scenario's observations (with WCAG
+references) and totalled by rule in the batch summary.
+
+--warm-up probes each scenario's target URL just before it runs. If the app
+is momentarily unavailable (connection refused or a 5xx such as 502), the
+scenario is deferred to later in the batch instead of burning an agent run.
+After --warm-up-deferrals attempts it is skipped as unavailable, so outages
+don't show up as failures in flake data.
+
+--ab runs every scenario under two models (e.g. --ab haiku,sonnet) and adds
+a model comparison to the summary: pass rates, success criteria met and
+observation counts per model, and the scenarios where the models disagree,
+so model spend can be justified with data. A/B runs are not recorded with
+the flake detector, since their outcomes differ by model on purpose.
+
+--rotate-personas runs each scenario that lists a persona_pool as the next
+persona in its pool, moving on one persona per batch (tracked in
+<output>/.persona-rotation.json). Run from a schedule, successive batches
+cycle through every persona; 'gt tester personas' then compares each
+scenario's pass rate and observations by persona, to find UX issues that
+only affect some kinds of user.
+
+--compare-window N compares the batch against the last N completed batches
+in the same environment rather than a single baseline. Each scenario is
+judged against its recent history: it is a new failure only if it failed in
+a majority of recent runs after passing at the start of the window, so one
+flaky run is reported as intermittent rather than as a regression. The
+summary also shows the pass-rate delta against the window's average.
+
+--sign signs the batch manifest and each run's observations.json and
+manifest.json, by default with an HMAC under the workspace key
+(.runtime/tester-signing.key, created on first use) or $GT_TESTER_SIGNING_KEY.
+--sign=minisign signs with the minisign secret key given by --signing-key.
+'gt tester verify' checks the signatures, so results cited in compliance
+reports can be shown to be unmodified since the batch generated them.
+
 By default, quarantined tests are skipped. Use --include-quarantined to run them.
 
+--only-changed runs just the scenarios affected by a git diff, for fast
+pre-merge smoke tests. Without a value it uses uncommitted changes (against
+HEAD); pass a revision or range (main...HEAD) to diff commits. A scenario is
+affected when its own file changed, when a rule in .tester-changes.yaml
+(or --change-map) matches a changed file, or when a changed file under
+pages/, routes/ or app/ maps to the scenario's start URL:
+
+  rules:
+    - paths: ["src/checkout/**"]   # globs relative to the repo root
+      tags: [checkout]
+    - paths: ["src/auth/**"]
+      scenarios: ["scenarios/registration/*.yaml"]
+  routes: true                     # URL-route convention (default on)
+
+Ctrl-C (or SIGTERM) stops the batch gracefully: running scenarios are
+cancelled, unfinished ones are marked aborted, and a partial manifest is
+written with "interrupted": true. Re-run just the aborted scenarios, with the
+original settings, using --resume <batch-id>.
+
+A batch that passes only because quarantined scenarios were skipped, with
+at least one of them failing its last run, reports their impact: the
+journeys (from journeys.yaml or --journeys) and tags they cover and how
+long each has been excluded. The report is also saved in the manifest as
+"quarantine_impact", so hidden risk stays visible in CI.
+
+After each batch, a summary (pass/fail counts, new issues against the
+baseline or recent batches, auto-quarantine changes and the most severe
+observations) is mailed to the overseer, so the result shows up in
+'gt inbox' without checking the terminal that ran it. Send it elsewhere
+with --mail-to, or not at all with --no-mail.
+
+The summary counts the failures retries rescued (runs that passed only
+after retrying), by error type and scenario. --no-retries-report also
+shows what the pass rate would have been without retries, to quantify how
+much instability they are masking.
+
+--format junit also writes junit.xml next to the batch's manifest.json:
+one test case per scenario run with its duration, failure or error
+message, and skip reason, for Jenkins and GitLab CI test summary views.
+
+While it runs, the batch keeps a heartbeat file in <output>/.heartbeats/ for
+external supervisors. gt tester batch status reads it to spot hung or dead
+batches, and can kill and resume them.
+
 The batch runner:
 1. Runs preflight checks (once for the batch)
 2. Finds all matching scenario files
@@ -43,8 +186,22 @@ Examples:
   gt tester batch "scenarios/registration/*.yaml" --parallel 3
   gt tester batch "**/*.yaml" --filter critical-path
   gt tester batch "**/*.yaml" --exclude slow --stop-on-fail
-  gt tester batch "**/*.yaml" --convoy parent-portal-tests`,
-	Args: cobra.ExactArgs(1),
+  gt tester batch "**/*.yaml" --convoy parent-portal-tests
+  gt tester batch "**/*.yaml" --compare-to baseline
+  gt tester batch "**/*.yaml" --compare-window 5
+  gt tester batch "**/*.yaml" --ab haiku,sonnet
+  gt tester batch "**/*.yaml" --rotate-personas
+  gt tester batch --manifest suites/nightly.yaml
+  gt tester batch --suite smoke
+  gt tester batch --suite-url https://qa.example.com/suites/smoke.yaml
+  gt tester batch "**/*.yaml" --upload gs://qa-artifacts/nightly
+  gt tester batch "**/*.yaml" --only-changed
+  gt tester batch "**/*.yaml" --only-changed=origin/main...HEAD
+  gt tester batch --resume 3f9a1c2e
+  gt tester batch "**/*.yaml" --build-sha $(git rev-parse HEAD) --app-version 1.4.2
+  gt tester batch --suite nightly --format junit
+  gt tester batch --suite nightly --no-retries-report`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: runTesterBatch,
 }
 
@@ -53,52 +210,211 @@ func init() {
 	testerBatchCmd.Flags().BoolVar(&batchStopOnFail, "stop-on-fail", false, "Stop batch on first failure")
 	testerBatchCmd.Flags().StringVar(&batchConvoy, "convoy", "", "Create convoy bead with this name")
 	testerBatchCmd.Flags().StringVar(&batchModel, "model", "", "Override model for all scenarios (haiku, sonnet, gemini)")
+	testerBatchCmd.Flags().StringSliceVar(&batchAB, "ab", nil, "Run every scenario under two models and compare them (e.g. haiku,sonnet)")
+	testerBatchCmd.Flags().BoolVar(&batchRotatePersonas, "rotate-personas", false, "Run scenarios with a persona_pool as the next persona in their pool")
 	testerBatchCmd.Flags().StringSliceVar(&batchFilter, "filter", nil, "Only run scenarios with these tags")
 	testerBatchCmd.Flags().StringSliceVar(&batchExclude, "exclude", nil, "Skip scenarios with these tags")
 	testerBatchCmd.Flags().BoolVar(&batchIncludeQuarantined, "include-quarantined", false, "Include quarantined tests")
-	testerBatchCmd.Flags().StringVar(&batchCompareTo, "compare-to", "", "Compare to previous batch run")
+	testerBatchCmd.Flags().StringVar(&batchCompareTo, "compare-to", "", "Compare to previous batch run (\"baseline\" for the pinned baseline)")
+	testerBatchCmd.Flags().IntVar(&batchCompareWindow, "compare-window", 0, "Compare to the last N batches in this environment")
 	testerBatchCmd.Flags().BoolVar(&testerSkipPreflight, "skip-preflight", false, "Skip preflight checks (not recommended)")
 	testerBatchCmd.Flags().StringVar(&batchOutputDir, "output", "test-results", "Output directory for results")
+	testerBatchCmd.Flags().StringVar(&batchManifest, "manifest", "", "Suite manifest file listing scenarios")
+	testerBatchCmd.Flags().StringVar(&batchSuiteURL, "suite-url", "", "HTTP URL serving a suite definition")
+	testerBatchCmd.Flags().StringVar(&batchSuite, "suite", "", "Named suite (or suite file) to run")
+	testerBatchCmd.Flags().StringVar(&batchSuiteDir, "suite-dir", ".", "Directory searched for suite files")
+	testerBatchCmd.Flags().StringVar(&batchUpload, "upload", "", "Upload artifacts to object storage (s3://bucket/prefix, gs://bucket/prefix)")
+	testerBatchCmd.Flags().BoolVar(&batchKeepLocal, "keep-local", false, "Keep local artifact files after upload")
+	testerBatchCmd.Flags().StringVar(&batchOnlyChanged, "only-changed", "", "Only run scenarios affected by changes in this git revision or range")
+	testerBatchCmd.Flags().Lookup("only-changed").NoOptDefVal = "HEAD"
+	testerBatchCmd.Flags().StringVar(&batchChangeMap, "change-map", "", "Change mapping file for --only-changed (default "+batch.ChangeMapFileName+")")
+	testerBatchCmd.Flags().StringVar(&batchJourneys, "journeys", "", "Journey map for the quarantine impact report (default "+batch.JourneysFileName+" if present)")
+	testerBatchCmd.Flags().BoolVar(&batchA11y, "a11y", false, "Also run axe-core accessibility scans at key steps")
+	testerBatchCmd.Flags().StringVar(&batchResume, "resume", "", "Re-run the aborted scenarios of an interrupted batch")
+	testerBatchCmd.Flags().StringVar(&batchBuildSHA, "build-sha", "", "Commit of the app build under test (recorded for flake trends)")
+	testerBatchCmd.Flags().StringVar(&batchAppVersion, "app-version", "", "Version of the app build under test (recorded for flake trends)")
+	testerBatchCmd.Flags().BoolVar(&batchWarmUp, "warm-up", false, "Probe each scenario's target URL first and defer it while the app is unavailable")
+	testerBatchCmd.Flags().IntVar(&batchWarmUpDeferrals, "warm-up-deferrals", batch.DefaultWarmUpDeferrals, "Times a scenario may be deferred before it is skipped")
+	testerBatchCmd.Flags().StringVar(&batchSign, "sign", "", "Sign the batch manifest and run results (hmac, minisign)")
+	testerBatchCmd.Flags().Lookup("sign").NoOptDefVal = tester.SignHMAC
+	testerBatchCmd.Flags().StringVar(&batchMailTo, "mail-to", defaultBatchMailTo, "Mail the batch summary to this address")
+	testerBatchCmd.Flags().BoolVar(&batchNoMail, "no-mail", false, "Don't mail the batch summary")
+	testerBatchCmd.Flags().BoolVar(&batchNoRetriesReport, "no-retries-report", false, "Compare the pass rate with what it would have been without retries")
+	testerBatchCmd.Flags().StringVar(&batchFormat, "format", "json", "Report format: json (manifest only) or junit (also junit.xml)")
+	testerBatchCmd.Flags().StringVar(&batchSignKey, "signing-key", "", "Signing key file (default: $"+tester.SigningKeyEnvVar+" or the workspace key)")
 
 	testerCmd.AddCommand(testerBatchCmd)
 }
 
 func runTesterBatch(cmd *cobra.Command, args []string) error {
-	pattern := args[0]
+	if batchResume != "" {
+		if len(args) > 0 || batchManifest != "" || batchSuiteURL != "" || batchSuite != "" {
+			return fmt.Errorf("--resume cannot be combined with a pattern, --manifest, --suite, or --suite-url")
+		}
+		return resumeTesterBatch(batchResume)
+	}
+
+	var pattern string
+	if len(args) > 0 {
+		pattern = args[0]
+	}
+
+	sources := 0
+	for _, v := range []string{pattern, batchManifest, batchSuite, batchSuiteURL} {
+		if v != "" {
+			sources++
+		}
+	}
+	if sources == 0 {
+		return fmt.Errorf("specify a scenario pattern, --manifest, --suite, or --suite-url")
+	}
+	if sources > 1 {
+		return fmt.Errorf("pattern, --manifest, --suite, and --suite-url are mutually exclusive")
+	}
+	if batchCompareWindow < 0 {
+		return fmt.Errorf("--compare-window must be non-negative")
+	}
+	if batchFormat != "json" && batchFormat != batch.FormatJUnit {
+		return fmt.Errorf("invalid --format %q: must be json or junit", batchFormat)
+	}
+	if len(batchAB) > 0 {
+		if len(batchAB) != 2 || batchAB[0] == "" || batchAB[1] == "" || batchAB[0] == batchAB[1] {
+			return fmt.Errorf("--ab takes two different models, e.g. --ab haiku,sonnet")
+		}
+		if batchModel != "" {
+			return fmt.Errorf("--ab and --model are mutually exclusive")
+		}
+	}
 
 	config := batch.Config{
 		Pattern:            pattern,
+		Manifest:           batchManifest,
+		SuiteURL:           batchSuiteURL,
+		Suite:              batchSuite,
+		SuiteDir:           batchSuiteDir,
 		Parallel:           batchParallel,
 		StopOnFail:         batchStopOnFail,
 		ConvoyName:         batchConvoy,
 		Model:              batchModel,
+		ABModels:           batchAB,
+		RotatePersonas:     batchRotatePersonas,
 		Environment:        testerEnv,
 		FilterTags:         batchFilter,
 		ExcludeTags:        batchExclude,
 		IncludeQuarantined: batchIncludeQuarantined,
 		CompareTo:          batchCompareTo,
+		CompareWindow:      batchCompareWindow,
 		SkipPreflight:      testerSkipPreflight,
 		OutputDir:          batchOutputDir,
+		Upload:             batchUpload,
+		KeepLocalArtifacts: batchKeepLocal,
+		OnlyChanged:        batchOnlyChanged,
+		ChangeMap:          batchChangeMap,
+		Journeys:           batchJourneys,
+		A11y:               batchA11y,
+		BuildSHA:           batchBuildSHA,
+		AppVersion:         batchAppVersion,
+		WarmUp:             batchWarmUp,
+		WarmUpDeferrals:    batchWarmUpDeferrals,
+		Sign:               batchSign,
+		NoRetriesReport:    batchNoRetriesReport,
+	}
+	if batchFormat == batch.FormatJUnit {
+		config.Format = batch.FormatJUnit
+	}
+	if config.Sign != "" {
+		config.SigningKey = resolveSigningKey(config.Sign, batchSignKey)
 	}
 
 	if config.Environment == "" {
 		config.Environment = "staging"
 	}
+	if config.Upload == "" {
+		config.Upload = os.Getenv(artifacts.UploadEnvVar)
+	}
 
 	runner, err := batch.NewRunner(config)
 	if err != nil {
 		return fmt.Errorf("failed to create batch runner: %w", err)
 	}
+	return executeTesterBatch(runner, config)
+}
+
+// resumeTesterBatch re-runs the aborted scenarios of an interrupted batch
+// with the batch's original settings.
+func resumeTesterBatch(batchID string) error {
+	prev, err := batch.LoadBatchResult(batchOutputDir, batchID)
+	if err != nil {
+		return err
+	}
+	config, err := batch.ResumeConfig(prev)
+	if err != nil {
+		return err
+	}
+
+	runner, err := batch.NewRunner(config)
+	if err != nil {
+		return fmt.Errorf("failed to create batch runner: %w", err)
+	}
+	runner.SetSource(&batch.ResumeSource{Batch: prev})
+	return executeTesterBatch(runner, config)
+}
+
+// setFlakeBeadStore files tracking beads for flaky scenarios in the working
+// directory's beads database, when the flake config enables file_beads.
+func setFlakeBeadStore(runner *batch.Runner) {
+	if workDir, err := os.Getwd(); err == nil {
+		runner.SetBeadStore(beads.New(workDir))
+	}
+}
+
+// executeTesterBatch runs a batch until it finishes, times out, or is
+// interrupted, and prints the (possibly partial) result.
+func executeTesterBatch(runner *batch.Runner, config batch.Config) error {
+	setFlakeBeadStore(runner)
 
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+	sigCtx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	ctx, cancel := context.WithTimeout(sigCtx, 30*time.Minute)
 	defer cancel()
 
-	fmt.Printf("Batch: %s\n", pattern)
+	go func() {
+		<-sigCtx.Done()
+		// Restore default handling so a second Ctrl-C kills the process
+		stop()
+		if ctx.Err() == context.Canceled {
+			fmt.Fprintln(os.Stderr, "\nInterrupted: cancelling scenarios and writing partial manifest (Ctrl-C again to force quit)")
+		}
+	}()
+
+	fmt.Printf("Batch: %s\n", runner.Source().Name())
+	if config.Upload != "" {
+		fmt.Printf("Upload: %s\n", config.Upload)
+	}
 
 	result, err := runner.Run(ctx)
 	if err != nil {
 		return fmt.Errorf("batch run failed: %w", err)
 	}
+	if result.Changes == nil || len(result.Changes.Affected) > 0 {
+		defer mailBatchSummary(result)
+	}
+
+	if result.Interrupted {
+		if testerJSON {
+			data, _ := json.MarshalIndent(result, "", "  ")
+			fmt.Println(string(data))
+		} else {
+			printBatchResult(result)
+			fmt.Printf("\nBatch interrupted: %d scenario(s) aborted, partial manifest saved.\n", result.Summary.Aborted)
+			fmt.Printf("Resume with: gt tester batch --resume %s", result.ID)
+			if config.OutputDir != "test-results" {
+				fmt.Printf(" --output %s", config.OutputDir)
+			}
+			fmt.Println()
+		}
+		return fmt.Errorf("batch interrupted")
+	}
 
 	if testerJSON {
 		data, _ := json.MarshalIndent(result, "", "  ")
@@ -106,6 +422,12 @@ func runTesterBatch(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	if result.Changes != nil && len(result.Changes.Affected) == 0 {
+		fmt.Printf("No scenarios affected by %d changed file(s) in %s\n",
+			len(result.Changes.Files), result.Changes.Range)
+		return nil
+	}
+
 	printBatchResult(result)
 
 	// Return error if any tests failed
@@ -123,6 +445,15 @@ func printBatchResult(result *batch.BatchResult) {
 		fmt.Printf(" (%d quarantined, skipped)", result.ScenariosSkipped)
 	}
 	fmt.Println()
+	if result.Changes != nil {
+		fmt.Printf("  Changed: %d files in %s, %d scenarios affected\n",
+			len(result.Changes.Files), result.Changes.Range, len(result.Changes.Affected))
+		for _, r := range result.Results {
+			if reason, ok := result.Changes.Affected[r.Path]; ok {
+				fmt.Printf("    %s: %s\n", r.Scenario, reason)
+			}
+		}
+	}
 	fmt.Printf("  Running: %d scenarios\n", result.ScenariosRun)
 	fmt.Printf("  Parallel: %d\n", result.Config.Parallel)
 	if result.ConvoyID != "" {
@@ -139,17 +470,30 @@ func printBatchResult(result *batch.BatchResult) {
 	// Print individual results
 	fmt.Println("Running...")
 	for _, r := range result.Results {
+		if result.AB != nil && r.Model != "" {
+			r.Scenario += " [" + r.Model + "]"
+		}
+		if result.Config.RotatePersonas && r.Persona != "" {
+			r.Scenario += " as " + r.Persona
+		}
 		printScenarioResult(r)
 	}
 	fmt.Println()
 
 	// Print summary
-	fmt.Println("Batch Complete")
+	if result.Interrupted {
+		fmt.Println("Batch Interrupted")
+	} else {
+		fmt.Println("Batch Complete")
+	}
 	fmt.Printf("  Passed: %d/%d\n", result.Summary.Passed, result.ScenariosRun)
 	fmt.Printf("  Failed: %d/%d\n", result.Summary.Failed, result.ScenariosRun)
 	if result.Summary.Skipped > 0 {
 		fmt.Printf("  Skipped: %d (quarantined)\n", result.Summary.Skipped)
 	}
+	if result.Summary.Aborted > 0 {
+		fmt.Printf("  Aborted: %d (interrupted or abort_if)\n", result.Summary.Aborted)
+	}
 	fmt.Printf("  Total time: %s", formatDuration(result.TotalDuration))
 	if result.Config.Parallel > 1 {
 		fmt.Printf(" (parallel)")
@@ -167,8 +511,24 @@ func printBatchResult(result *batch.BatchResult) {
 		fmt.Printf("  Total observations: %d issues (%s)\n", total, strings.Join(obsStr, ", "))
 	}
 
+	if result.Config.A11y {
+		fmt.Printf("  Accessibility: %d violations", result.Summary.A11yViolations)
+		if top := topA11yRules(result.Summary.A11yRules, 3); top != "" {
+			fmt.Printf(" (%s)", top)
+		}
+		fmt.Println()
+	}
+
 	if result.Summary.TotalRetries > 0 {
-		fmt.Printf("  Retries: %d\n", result.Summary.TotalRetries)
+		fmt.Printf("  Retries: %d", result.Summary.TotalRetries)
+		if rr := result.Summary.Retries; rr != nil {
+			fmt.Printf(" (%d rescued, %d still failing)", rr.Rescued, rr.StillFailing)
+		}
+		fmt.Println()
+		printRetryReport(result.Summary.Retries, result.Config.NoRetriesReport)
+	}
+	if result.Summary.WarmUpDeferrals > 0 {
+		fmt.Printf("  Warm-up deferrals: %d\n", result.Summary.WarmUpDeferrals)
 	}
 	fmt.Println()
 
@@ -188,6 +548,12 @@ func printBatchResult(result *batch.BatchResult) {
 				failCount,
 				result.ScenariosRun)
 		}
+		if result.Summary.InfraErrors > 0 {
+			fmt.Printf("  Infra error rate this batch: %.0f%% (%d/%d)\n",
+				result.Summary.InfraErrorRate*100,
+				result.Summary.InfraErrors,
+				result.ScenariosRun)
+		}
 
 		if len(result.Summary.AutoQuarantined) > 0 {
 			fmt.Printf("  Auto-quarantined: %s\n",
@@ -204,6 +570,12 @@ func printBatchResult(result *batch.BatchResult) {
 				strings.Join(result.Summary.FlakyScenarios, ", "))
 		}
 
+		for _, scenario := range append(result.Summary.AutoQuarantined, result.Summary.FlakyScenarios...) {
+			if runbook := result.Summary.Runbooks[scenario]; runbook != "" {
+				fmt.Printf("    %s runbook: %s\n", scenario, runbook)
+			}
+		}
+
 		if len(result.Summary.NewQuarantineCandidates) > 0 {
 			fmt.Printf("  Quarantine candidates: %s (investigate)\n",
 				strings.Join(result.Summary.NewQuarantineCandidates, ", "))
@@ -213,6 +585,22 @@ func printBatchResult(result *batch.BatchResult) {
 	// Print comparison results if available
 	if result.Comparison != nil {
 		printComparison(result.Comparison)
+		if len(result.Comparison.NewIssues) > 0 {
+			fmt.Printf("  Triage with: gt tester triage %s\n", result.ID)
+		}
+	}
+	if result.Trend != nil {
+		printTrend(result.Trend)
+	}
+	if result.AB != nil {
+		printModelComparison(result.AB)
+	}
+	if result.QuarantineImpact != nil {
+		fmt.Println("\nQuarantine Impact:")
+		fmt.Println("  Passed only because quarantined scenarios were skipped")
+		for _, line := range quarantineImpactLines(result.QuarantineImpact) {
+			fmt.Println("  " + line)
+		}
 	}
 
 	// Print output location
@@ -220,6 +608,143 @@ func printBatchResult(result *batch.BatchResult) {
 		fmt.Printf("\nConvoy: %s\n", result.ConvoyID)
 	}
 	fmt.Printf("Results: %s\n", result.OutputDir)
+	if result.Config.Format == batch.FormatJUnit {
+		fmt.Printf("JUnit:   %s\n", filepath.Join(result.OutputDir, batch.JUnitFileName))
+	}
+}
+
+// printRetryReport lists the failures retries rescued, by error type and
+// scenario, and with compare the pass rate without them.
+func printRetryReport(rr *batch.RetryReport, compare bool) {
+	if rr == nil {
+		return
+	}
+	if rr.Rescued > 0 {
+		fmt.Printf("    Rescued by error type: %s\n", formatRetryErrorTypes(rr.ByErrorType))
+		for _, s := range rr.Scenarios {
+			name := s.Scenario
+			if s.Model != "" {
+				name += " [" + s.Model + "]"
+			}
+			retries := "retries"
+			if s.Retries == 1 {
+				retries = "retry"
+			}
+			fmt.Printf("    ↻ %s: passed after %d %s (%s)\n", name, s.Retries, retries, s.ErrorType)
+		}
+	}
+	if compare {
+		fmt.Printf("    Pass rate: %.0f%% with retries, %.0f%% without (%.0f pts masked by retries)\n",
+			rr.PassRate*100, rr.PassRateWithoutRetries*100, rr.Masked()*100)
+	}
+}
+
+// formatRetryErrorTypes lists rescue counts by error type, most first:
+// "timeout 2, browser_crash 1".
+func formatRetryErrorTypes(byType map[string]int) string {
+	types := make([]string, 0, len(byType))
+	for t := range byType {
+		types = append(types, t)
+	}
+	sort.Slice(types, func(i, j int) bool {
+		if byType[types[i]] != byType[types[j]] {
+			return byType[types[i]] > byType[types[j]]
+		}
+		return types[i] < types[j]
+	})
+	parts := make([]string, len(types))
+	for i, t := range types {
+		parts[i] = fmt.Sprintf("%s %d", t, byType[t])
+	}
+	return strings.Join(parts, ", ")
+}
+
+// quarantineImpactLines describes the skipped quarantined scenarios behind
+// a batch's pass: what each covers and how long it has been excluded.
+func quarantineImpactLines(impact *batch.QuarantineImpact) []string {
+	var lines []string
+	for _, q := range impact.Scenarios {
+		mark := "○"
+		if q.Failing() {
+			mark = "✗"
+		}
+		line := fmt.Sprintf("%s %s", mark, q.Scenario)
+		if q.LastOutcome != "" {
+			line += fmt.Sprintf(" (last run: %s)", q.LastOutcome)
+		}
+		if q.Excluded > 0 {
+			line += " - excluded " + formatDurationAgo(q.Excluded)
+		}
+		if q.Reason != "" {
+			line += ": " + q.Reason
+		}
+		lines = append(lines, line)
+		if len(q.Journeys) > 0 {
+			lines = append(lines, "    Journeys: "+strings.Join(q.Journeys, ", "))
+		}
+		if len(q.Tags) > 0 {
+			lines = append(lines, "    Tags: "+strings.Join(q.Tags, ", "))
+		}
+		if q.Owner != "" {
+			lines = append(lines, "    Owner: "+q.Owner)
+		}
+	}
+	if len(impact.Journeys) > 0 {
+		lines = append(lines, "Journeys at risk: "+strings.Join(impact.Journeys, ", "))
+	}
+	return lines
+}
+
+// topA11yRules formats the most frequent accessibility rules, most frequent
+// first (ties by rule ID).
+func topA11yRules(rules map[string]int, n int) string {
+	ids := make([]string, 0, len(rules))
+	for id := range rules {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if rules[ids[i]] != rules[ids[j]] {
+			return rules[ids[i]] > rules[ids[j]]
+		}
+		return ids[i] < ids[j]
+	})
+	if len(ids) > n {
+		ids = ids[:n]
+	}
+	parts := make([]string, len(ids))
+	for i, id := range ids {
+		parts[i] = fmt.Sprintf("%s ×%d", id, rules[id])
+	}
+	return strings.Join(parts, ", ")
+}
+
+// printModelComparison prints an A/B batch's per-model results and the
+// scenarios where the models disagree.
+func printModelComparison(c *batch.ModelComparison) {
+	fmt.Println()
+	fmt.Printf("Model Comparison (%s):\n", strings.Join(c.Models, " vs "))
+	for _, s := range c.Stats {
+		fmt.Printf("  %-10s passed %d/%d (%.0f%%), criteria %d/%d (%.0f%%), %d observations, avg %s\n",
+			s.Model, s.Passed, s.Runs, s.PassRate*100,
+			s.CriteriaMet, s.CriteriaTotal, s.CriteriaPassRate*100,
+			s.TotalObservations, formatDuration(s.AvgDuration))
+	}
+
+	if c.Disagreements == 0 {
+		fmt.Println("  Models agree on every scenario")
+		return
+	}
+	fmt.Printf("  Disagreements (%d):\n", c.Disagreements)
+	for _, p := range c.Pairs {
+		if !p.Disagree {
+			continue
+		}
+		var runs []string
+		for _, run := range p.Runs {
+			runs = append(runs, fmt.Sprintf("%s %s (criteria %s, %d obs)", run.Model, run.Status, run.Criteria(), run.Observations))
+		}
+		fmt.Printf("    %s: %s\n", p.Scenario, strings.Join(runs, ", "))
+	}
 }
 
 // printComparison prints the regression comparison results.
@@ -279,6 +804,41 @@ func printComparison(c *batch.Comparison) {
 	}
 }
 
+// printTrend prints the comparison against recent batches.
+func printTrend(t *batch.TrendComparison) {
+	fmt.Println()
+	fmt.Printf("Trend (vs last %d of %d requested batches):\n", len(t.BatchIDs), t.Window)
+	fmt.Printf("  Pass rate: %.0f%% (window avg %.0f%%, %+.0f pts)\n",
+		t.PassRate*100, t.WindowPassRate*100, t.PassRateDelta*100)
+
+	sections := []struct {
+		icon, title string
+		items       []batch.TrendItem
+	}{
+		{"✗", "New Failures", t.NewFailures},
+		{"↺", "Recurring", t.Recurring},
+		{"~", "Intermittent", t.Intermittent},
+		{"✓", "Fixed", t.Fixed},
+	}
+	for _, sec := range sections {
+		if len(sec.items) == 0 {
+			continue
+		}
+		fmt.Printf("  %s (%d):\n", sec.title, len(sec.items))
+		for _, item := range sec.items {
+			line := fmt.Sprintf("    %s %s - %s", sec.icon, item.Scenario, item.Description())
+			if item.WindowPassRate >= 0 {
+				line += fmt.Sprintf(" (window pass rate %.0f%%)", item.WindowPassRate*100)
+			}
+			fmt.Println(line)
+		}
+	}
+
+	if len(t.NewFailures) == 0 && len(t.Recurring) == 0 && len(t.Intermittent) == 0 && len(t.Fixed) == 0 {
+		fmt.Println("  No significant changes detected")
+	}
+}
+
 func printScenarioResult(r batch.ScenarioResult) {
 	var status string
 	switch r.Status {
@@ -288,7 +848,7 @@ func printScenarioResult(r batch.ScenarioResult) {
 		status = "✗"
 	case batch.StatusError:
 		status = "✗"
-	case batch.StatusSkipped:
+	case batch.StatusSkipped, batch.StatusAborted:
 		status = "○"
 	case batch.StatusRetrying:
 		status = "↻"
@@ -302,7 +862,7 @@ func printScenarioResult(r batch.ScenarioResult) {
 		line += fmt.Sprintf(" (%s)", formatDuration(r.Duration))
 	}
 
-	if r.Status == batch.StatusSkipped {
+	if r.Status == batch.StatusSkipped || r.Status == batch.StatusAborted {
 		line += fmt.Sprintf(" - %s", r.SkipReason)
 	} else if r.Status == batch.StatusFailed || r.Status == batch.StatusError {
 		if r.Error != "" {
@@ -323,4 +883,7 @@ func printScenarioResult(r batch.ScenarioResult) {
 	}
 
 	fmt.Println(line)
+	if r.ArtifactURL != "" {
+		fmt.Printf("      artifacts: %s\n", r.ArtifactURL)
+	}
 }
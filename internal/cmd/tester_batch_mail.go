@@ -0,0 +1,218 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/mail"
+	"github.com/steveyegge/gastown/internal/planner"
+	"github.com/steveyegge/gastown/internal/tester/batch"
+	"github.com/steveyegge/gastown/internal/ui"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+const (
+	// defaultBatchMailTo is where batch summaries go unless --mail-to
+	// says otherwise.
+	defaultBatchMailTo = planner.OverseerAddress
+
+	// batchMailTopObservations is how many observations the summary lists.
+	batchMailTopObservations = 5
+)
+
+// batchMailObservation is an observation from one of the batch's runs.
+type batchMailObservation struct {
+	Scenario string
+	Observation
+}
+
+// mailBatchSummary sends the batch's summary to batchMailTo through the
+// mail router, so the result shows up in the recipient's inbox. Outside a
+// Gas Town workspace there is no mail to send to, and the batch goes
+// unreported.
+func mailBatchSummary(result *batch.BatchResult) {
+	if batchNoMail || batchMailTo == "" {
+		return
+	}
+	townRoot, err := workspace.FindFromCwd()
+	if err != nil || townRoot == "" {
+		return
+	}
+
+	msg := batchSummaryMail(result, topBatchObservations(result, batchMailTopObservations))
+	msg.To = batchMailTo
+	if err := mail.NewRouterWithTownRoot(townRoot, townRoot).Send(msg); err != nil {
+		fmt.Fprintf(os.Stderr, "%s Failed to mail batch summary to %s: %v\n", ui.RenderWarnIcon(), batchMailTo, err)
+		return
+	}
+	if !testerJSON {
+		fmt.Printf("Summary mailed to %s\n", batchMailTo)
+	}
+}
+
+// batchSummaryMail formats a batch's summary mail: pass/fail counts, new
+// issues against the baseline or recent batches, quarantine changes and
+// the top observations. The caller sets the recipient.
+func batchSummaryMail(result *batch.BatchResult, top []batchMailObservation) *mail.Message {
+	s := result.Summary
+	failed := s.Failed + s.Errors
+
+	status := "passed"
+	switch {
+	case result.Interrupted:
+		status = "interrupted"
+	case failed > 0:
+		status = "failed"
+	}
+	subject := fmt.Sprintf("Batch %s %s: %d/%d passed", result.ID, status, s.Passed, result.ScenariosRun)
+	if env := result.Config.Environment; env != "" {
+		subject += " (" + env + ")"
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Batch: %s\n", result.ID)
+	if env := result.Config.Environment; env != "" {
+		fmt.Fprintf(&b, "Environment: %s\n", env)
+	}
+	if result.Config.BuildSHA != "" || result.Config.AppVersion != "" {
+		fmt.Fprintf(&b, "Build: %s\n", strings.TrimSpace(result.Config.AppVersion+" "+result.Config.BuildSHA))
+	}
+	fmt.Fprintf(&b, "Duration: %s\n", formatDuration(result.TotalDuration))
+
+	b.WriteString("\nResults:\n")
+	fmt.Fprintf(&b, "  Passed: %d/%d\n", s.Passed, result.ScenariosRun)
+	fmt.Fprintf(&b, "  Failed: %d/%d", failed, result.ScenariosRun)
+	if s.Errors > 0 {
+		fmt.Fprintf(&b, " (%d errors", s.Errors)
+		if s.InfraErrors > 0 {
+			fmt.Fprintf(&b, ", %d infrastructure", s.InfraErrors)
+		}
+		b.WriteString(")")
+	}
+	b.WriteString("\n")
+	if s.Skipped > 0 {
+		fmt.Fprintf(&b, "  Skipped: %d (quarantined)\n", s.Skipped)
+	}
+	if s.Aborted > 0 {
+		fmt.Fprintf(&b, "  Aborted: %d\n", s.Aborted)
+	}
+	if rr := s.Retries; rr != nil && rr.Rescued > 0 {
+		fmt.Fprintf(&b, "  Rescued by retries: %d (%s)\n", rr.Rescued, formatRetryErrorTypes(rr.ByErrorType))
+		if result.Config.NoRetriesReport {
+			fmt.Fprintf(&b, "  Pass rate without retries: %.0f%% (%.0f pts masked)\n",
+				rr.PassRateWithoutRetries*100, rr.Masked()*100)
+		}
+	}
+	for _, r := range result.Results {
+		if r.Status == batch.StatusFailed || r.Status == batch.StatusError {
+			line := "  ✗ " + r.Scenario
+			if r.Error != "" {
+				line += " - " + r.Error
+			}
+			b.WriteString(line + "\n")
+		}
+	}
+
+	if result.Comparison != nil {
+		fmt.Fprintf(&b, "\nNew issues vs %s: %d\n", result.Comparison.BaselineID, len(result.Comparison.NewIssues))
+		for _, item := range result.Comparison.NewIssues {
+			fmt.Fprintf(&b, "  ✗ [%s] %s - %s\n", item.Severity, item.Scenario, item.Description)
+		}
+		if len(result.Comparison.Fixed) > 0 {
+			fmt.Fprintf(&b, "  Fixed: %d\n", len(result.Comparison.Fixed))
+		}
+		if len(result.Comparison.NewIssues) > 0 {
+			fmt.Fprintf(&b, "  Triage with: gt tester triage %s\n", result.ID)
+		}
+	}
+	if result.Trend != nil {
+		fmt.Fprintf(&b, "\nNew failures vs last %d batches: %d\n", len(result.Trend.BatchIDs), len(result.Trend.NewFailures))
+		for _, item := range result.Trend.NewFailures {
+			fmt.Fprintf(&b, "  ✗ %s - %s\n", item.Scenario, item.Description())
+		}
+		fmt.Fprintf(&b, "  Pass rate: %.0f%% (%+.0f pts vs window)\n", result.Trend.PassRate*100, result.Trend.PassRateDelta*100)
+	}
+
+	if len(s.AutoQuarantined) > 0 || len(s.AutoUnquarantined) > 0 || len(s.NewQuarantineCandidates) > 0 {
+		b.WriteString("\nQuarantine:\n")
+		if len(s.AutoQuarantined) > 0 {
+			fmt.Fprintf(&b, "  Auto-quarantined: %s\n", strings.Join(s.AutoQuarantined, ", "))
+		}
+		if len(s.AutoUnquarantined) > 0 {
+			fmt.Fprintf(&b, "  Auto-unquarantined: %s\n", strings.Join(s.AutoUnquarantined, ", "))
+		}
+		if len(s.NewQuarantineCandidates) > 0 {
+			fmt.Fprintf(&b, "  Candidates: %s\n", strings.Join(s.NewQuarantineCandidates, ", "))
+		}
+	}
+
+	if result.QuarantineImpact != nil {
+		b.WriteString("\nQuarantine impact (passed only because these were skipped):\n")
+		for _, line := range quarantineImpactLines(result.QuarantineImpact) {
+			b.WriteString("  " + line + "\n")
+		}
+	}
+
+	if len(top) > 0 {
+		fmt.Fprintf(&b, "\nTop observations (%d total):\n", countBatchObservations(s.TotalObservations))
+		for _, o := range top {
+			line := fmt.Sprintf("  [%s] %s: %s", o.Severity, o.Scenario, o.Description)
+			if o.Location != "" {
+				line += " (" + o.Location + ")"
+			}
+			b.WriteString(line + "\n")
+		}
+	}
+
+	fmt.Fprintf(&b, "\nResults: %s\n", result.OutputDir)
+
+	msg := &mail.Message{
+		From:      "tester/batch",
+		Subject:   subject,
+		Body:      b.String(),
+		Timestamp: time.Now(),
+	}
+	if status != "passed" {
+		msg.Priority = mail.PriorityHigh
+	}
+	return msg
+}
+
+// topBatchObservations returns the batch's n most severe observations,
+// read from each run's observations.json. Runs in scenario order break
+// ties, keeping each run's own order.
+func topBatchObservations(result *batch.BatchResult, n int) []batchMailObservation {
+	var all []batchMailObservation
+	for _, sr := range result.Results {
+		if sr.ArtifactDir == "" {
+			continue
+		}
+		obs, err := LoadObservationResult(filepath.Join(sr.ArtifactDir, "observations.json"))
+		if err != nil {
+			continue
+		}
+		for _, o := range obs.Observations {
+			all = append(all, batchMailObservation{Scenario: sr.Scenario, Observation: o})
+		}
+	}
+	sort.SliceStable(all, func(i, j int) bool {
+		return all[i].Severity < all[j].Severity
+	})
+	if len(all) > n {
+		all = all[:n]
+	}
+	return all
+}
+
+// countBatchObservations totals observations across severities.
+func countBatchObservations(bySeverity map[string]int) int {
+	total := 0
+	for _, count := range bySeverity {
+		total += count
+	}
+	return total
+}
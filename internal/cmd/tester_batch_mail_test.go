@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/mail"
+	"github.com/steveyegge/gastown/internal/tester/batch"
+)
+
+func writeRunObservations(t *testing.T, observations ...Observation) string {
+	t.Helper()
+	dir := t.TempDir()
+	data, err := json.Marshal(ObservationResult{Observations: observations})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "observations.json"), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestBatchSummaryMail(t *testing.T) {
+	result := &batch.BatchResult{
+		ID:            "3f9a1c2e",
+		Config:        batch.Config{Environment: "staging"},
+		TotalDuration: 90 * time.Second,
+		ScenariosRun:  3,
+		OutputDir:     "test-results/3f9a1c2e",
+		Results: []batch.ScenarioResult{
+			{Scenario: "signup", Status: batch.StatusPassed, ArtifactDir: writeRunObservations(t,
+				Observation{Severity: SeverityP2, Description: "Slow submit button", Location: "/signup"},
+				Observation{Severity: SeverityP3, Description: "Typo in footer"})},
+			{Scenario: "checkout", Status: batch.StatusFailed, Error: "payment form never loaded", ArtifactDir: writeRunObservations(t,
+				Observation{Severity: SeverityP0, Description: "Cannot pay"})},
+			{Scenario: "login", Status: batch.StatusPassed},
+		},
+		Summary: batch.BatchSummary{
+			Passed:            2,
+			Failed:            1,
+			TotalObservations: map[string]int{"P0": 1, "P2": 1, "P3": 1},
+			AutoQuarantined:   []string{"search"},
+		},
+		Comparison: &batch.Comparison{
+			BaselineID: "baseline-1",
+			NewIssues: []batch.ComparisonItem{
+				{Scenario: "checkout", Description: "Regression: payment form never loaded", Severity: "P0"},
+			},
+		},
+	}
+
+	top := topBatchObservations(result, 2)
+	if len(top) != 2 || top[0].Scenario != "checkout" || top[1].Description != "Slow submit button" {
+		t.Fatalf("topBatchObservations = %+v, want the P0 then the P2", top)
+	}
+
+	msg := batchSummaryMail(result, top)
+	if msg.Subject != "Batch 3f9a1c2e failed: 2/3 passed (staging)" {
+		t.Errorf("Subject = %q", msg.Subject)
+	}
+	if msg.Priority != mail.PriorityHigh {
+		t.Errorf("Priority = %v, want high for a failed batch", msg.Priority)
+	}
+	for _, want := range []string{
+		"Passed: 2/3",
+		"Failed: 1/3",
+		"✗ checkout - payment form never loaded",
+		"New issues vs baseline-1: 1",
+		"✗ [P0] checkout - Regression: payment form never loaded",
+		"Triage with: gt tester triage 3f9a1c2e",
+		"Auto-quarantined: search",
+		"Top observations (3 total):",
+		"[P0] checkout: Cannot pay",
+		"[P2] signup: Slow submit button (/signup)",
+		"Results: test-results/3f9a1c2e",
+	} {
+		if !strings.Contains(msg.Body, want) {
+			t.Errorf("body missing %q:\n%s", want, msg.Body)
+		}
+	}
+	if strings.Contains(msg.Body, "Typo in footer") {
+		t.Error("body lists more than the top observations")
+	}
+}
+
+func TestBatchSummaryMailPassed(t *testing.T) {
+	msg := batchSummaryMail(&batch.BatchResult{
+		ID:           "a1",
+		ScenariosRun: 1,
+		Summary:      batch.BatchSummary{Passed: 1},
+	}, nil)
+	if msg.Subject != "Batch a1 passed: 1/1 passed" {
+		t.Errorf("Subject = %q", msg.Subject)
+	}
+	if msg.Priority == mail.PriorityHigh {
+		t.Error("a passing batch should not be high priority")
+	}
+	for _, absent := range []string{"New issues", "Quarantine:", "Top observations"} {
+		if strings.Contains(msg.Body, absent) {
+			t.Errorf("body has %q without anything to report:\n%s", absent, msg.Body)
+		}
+	}
+}
+
+func TestBatchSummaryMail_RetriesRescued(t *testing.T) {
+	result := &batch.BatchResult{
+		ID:           "3f9a1c2e",
+		Config:       batch.Config{NoRetriesReport: true},
+		ScenariosRun: 2,
+		Results: []batch.ScenarioResult{
+			{Scenario: "signup", Status: batch.StatusPassed},
+			{Scenario: "checkout", Status: batch.StatusPassed, RetryCount: 1, RetryErrors: []string{"timeout"}},
+		},
+		Summary: batch.BatchSummary{Passed: 2, TotalRetries: 1},
+	}
+	result.Summary.Retries = batch.BuildRetryReport(result.Results, false)
+
+	body := batchSummaryMail(result, nil).Body
+	for _, want := range []string{
+		"Rescued by retries: 1 (timeout 1)",
+		"Pass rate without retries: 50% (50 pts masked)",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("mail body missing %q:\n%s", want, body)
+		}
+	}
+
+	result.Config.NoRetriesReport = false
+	if body := batchSummaryMail(result, nil).Body; strings.Contains(body, "without retries") {
+		t.Errorf("pass rate comparison shown without --no-retries-report:\n%s", body)
+	}
+}
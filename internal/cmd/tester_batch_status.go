@@ -0,0 +1,173 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/tester/batch"
+)
+
+var (
+	batchStatusHungAfter time.Duration
+	batchStatusKill      bool
+	batchStatusResume    bool
+	batchStatusGrace     time.Duration
+)
+
+var testerBatchStatusCmd = &cobra.Command{
+	Use:   "status [batch-id]",
+	Short: "Show running batches and detect hung ones",
+	Long: `Show the batches running in a results directory, read from their heartbeats.
+
+A running batch writes <output>/.heartbeats/<batch-id>.json with its PID,
+worker count, progress and the scenarios in flight, refreshed every 10s and
+removed once the batch saves its manifest. External supervisors can watch
+these files directly.
+
+Each batch is reported as:
+  alive   running and heartbeating
+  hung    a scenario has run longer than --hung-after
+  stale   the heartbeat stopped being refreshed
+  dead    the process exited without finishing the batch
+
+Exits 1 if any batch is hung, stale or dead. With --kill, unhealthy batches
+are stopped (SIGTERM, then SIGKILL after --grace) and an interrupted
+manifest is saved, so they can be resumed with gt tester batch --resume.
+--resume kills and then resumes a single unhealthy batch.
+
+Examples:
+  gt tester batch status
+  gt tester batch status --hung-after 30m --json
+  gt tester batch status --kill
+  gt tester batch status 3f9a1c2e --resume`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runTesterBatchStatus,
+}
+
+func init() {
+	testerBatchStatusCmd.Flags().DurationVar(&batchStatusHungAfter, "hung-after", batch.DefaultHungAfter, "Consider a batch hung when a scenario runs this long")
+	testerBatchStatusCmd.Flags().BoolVar(&batchStatusKill, "kill", false, "Kill hung, stale or dead batches and save their partial manifests")
+	testerBatchStatusCmd.Flags().BoolVar(&batchStatusResume, "resume", false, "Kill an unhealthy batch and resume its unfinished scenarios")
+	testerBatchStatusCmd.Flags().DurationVar(&batchStatusGrace, "grace", 30*time.Second, "Time to wait after SIGTERM before SIGKILL")
+	testerBatchStatusCmd.Flags().StringVar(&batchOutputDir, "output", "test-results", "Output directory for results")
+	testerBatchStatusCmd.Flags().BoolVar(&testerJSON, "json", false, "Output as JSON")
+
+	testerBatchCmd.AddCommand(testerBatchStatusCmd)
+}
+
+// batchStatus is a batch's heartbeat with its checked state.
+type batchStatus struct {
+	*batch.Heartbeat
+	State  batch.BatchState `json:"state"`
+	Reason string           `json:"reason,omitempty"`
+}
+
+func runTesterBatchStatus(cmd *cobra.Command, args []string) error {
+	var heartbeats []*batch.Heartbeat
+	if len(args) > 0 {
+		hb, err := batch.LoadHeartbeat(batchOutputDir, args[0])
+		if errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("batch %s is not running (no heartbeat in %s)", args[0], batchOutputDir)
+		}
+		if err != nil {
+			return err
+		}
+		heartbeats = append(heartbeats, hb)
+	} else {
+		var err error
+		heartbeats, err = batch.ListHeartbeats(batchOutputDir)
+		if err != nil {
+			return fmt.Errorf("listing heartbeats: %w", err)
+		}
+	}
+
+	now := time.Now()
+	var statuses []batchStatus
+	var unhealthy []batchStatus
+	for _, hb := range heartbeats {
+		s := batchStatus{Heartbeat: hb}
+		s.State, s.Reason = hb.Check(now, batchStatusHungAfter)
+		statuses = append(statuses, s)
+		if s.State != batch.BatchAlive {
+			unhealthy = append(unhealthy, s)
+		}
+	}
+
+	if batchStatusResume && len(unhealthy) > 1 {
+		return fmt.Errorf("%d batches are unhealthy; pass the batch ID to resume one", len(unhealthy))
+	}
+
+	if testerJSON {
+		data, _ := json.MarshalIndent(statuses, "", "  ")
+		fmt.Println(string(data))
+	} else {
+		printBatchStatuses(statuses, now)
+	}
+
+	if len(unhealthy) == 0 {
+		return nil
+	}
+	if !batchStatusKill && !batchStatusResume {
+		return NewSilentExit(1)
+	}
+
+	for _, s := range unhealthy {
+		if err := s.Kill(batchStatusGrace); err != nil {
+			return fmt.Errorf("killing batch %s: %w", s.BatchID, err)
+		}
+		result, err := s.Recover(s.Reason)
+		if err != nil {
+			return fmt.Errorf("recovering batch %s: %w", s.BatchID, err)
+		}
+		fmt.Fprintf(os.Stderr, "%s Stopped batch %s: %d scenario(s) left to resume\n",
+			style.SuccessPrefix, s.BatchID, result.Summary.Aborted)
+	}
+
+	if batchStatusResume {
+		return resumeTesterBatch(unhealthy[0].BatchID)
+	}
+	fmt.Fprintf(os.Stderr, "Resume with: gt tester batch --resume <batch-id>")
+	if batchOutputDir != "test-results" {
+		fmt.Fprintf(os.Stderr, " --output %s", batchOutputDir)
+	}
+	fmt.Fprintln(os.Stderr)
+	return nil
+}
+
+func printBatchStatuses(statuses []batchStatus, now time.Time) {
+	if len(statuses) == 0 {
+		fmt.Printf("No running batches in %s\n", batchOutputDir)
+		return
+	}
+
+	for i, s := range statuses {
+		if i > 0 {
+			fmt.Println()
+		}
+		state := style.Success.Render(string(s.State))
+		if s.State != batch.BatchAlive {
+			state = style.Error.Render(string(s.State))
+		}
+		fmt.Printf("%s %s (pid %d, %d workers)\n", style.Bold.Render("Batch "+s.BatchID), state, s.PID, s.Workers)
+		if s.Reason != "" {
+			fmt.Printf("  %s\n", s.Reason)
+		}
+		fmt.Printf("  Started: %s (%s ago), last heartbeat %s ago\n",
+			s.StartedAt.Format("2006-01-02 15:04:05"),
+			now.Sub(s.StartedAt).Round(time.Second),
+			now.Sub(s.UpdatedAt).Round(time.Second))
+		fmt.Printf("  Progress: %d/%d runs\n", s.Completed, s.Total)
+		for _, r := range s.Running {
+			name := r.Scenario
+			if r.Model != "" {
+				name += " (" + r.Model + ")"
+			}
+			fmt.Printf("  Running: %s for %s\n", name, now.Sub(r.StartedAt).Round(time.Second))
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/tester/batch"
+	"github.com/steveyegge/gastown/internal/ui"
+)
+
+// Coverage command flags
+var (
+	coverageJourneys  string
+	coverageOutputDir string
+	coverageManifest  string
+	coverageBatches   int
+)
+
+var testerCoverageCmd = &cobra.Command{
+	Use:   "coverage [pattern]",
+	Short: "Map scenarios onto user journeys and report the gaps",
+	Long: `Report which user journeys are exercised by a scenario suite.
+
+Journeys are declared in a journey map (journeys.yaml by default). A
+scenario covers a journey when its start URL path matches one of the
+journey's routes, it carries one of the journey's tags, or its file
+matches one of the journey's scenario globs:
+
+  journeys:
+    - id: checkout
+      name: Checkout
+      area: commerce
+      routes: ["/cart", "/checkout/**"]
+    - id: signup
+      area: accounts
+      tags: [registration]
+
+Each journey is reported as covered, quarantined-only (every scenario
+covering it is quarantined, so batches skip it), or uncovered. The trend
+shows how many journeys the last --batches batches in the environment
+exercised and passed.
+
+Examples:
+  gt tester coverage "scenarios/**/*.yaml"
+  gt tester coverage --manifest suite.yaml --journeys docs/journeys.yaml
+  gt tester coverage "scenarios/*.yaml" --env production --batches 20 --json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runTesterCoverage,
+}
+
+func init() {
+	testerCoverageCmd.Flags().StringVar(&coverageJourneys, "journeys", batch.JourneysFileName, "Journey map file")
+	testerCoverageCmd.Flags().StringVar(&coverageManifest, "manifest", "", "Suite manifest file listing scenarios")
+	testerCoverageCmd.Flags().StringVar(&coverageOutputDir, "output", "test-results", "Results directory (batches and quarantine)")
+	testerCoverageCmd.Flags().IntVar(&coverageBatches, "batches", 10, "Number of recent batches in the coverage trend")
+	testerCoverageCmd.Flags().StringVar(&testerEnv, "env", "staging", "Environment of the batches in the trend")
+	testerCoverageCmd.Flags().BoolVar(&testerJSON, "json", false, "Output as JSON")
+
+	testerCmd.AddCommand(testerCoverageCmd)
+}
+
+func runTesterCoverage(cmd *cobra.Command, args []string) error {
+	var pattern string
+	if len(args) > 0 {
+		pattern = args[0]
+	}
+	if (pattern == "") == (coverageManifest == "") {
+		return fmt.Errorf("specify either a scenario pattern or --manifest")
+	}
+	if coverageBatches < 0 {
+		return fmt.Errorf("--batches must be non-negative")
+	}
+
+	journeys, err := batch.LoadJourneyMap(coverageJourneys)
+	if err != nil {
+		return err
+	}
+
+	source := batch.NewScenarioSource(batch.Config{
+		Pattern:   pattern,
+		Manifest:  coverageManifest,
+		OutputDir: coverageOutputDir,
+	})
+	scenarios, err := batch.LoadCoverageScenarios(source)
+	if err != nil {
+		return fmt.Errorf("loading scenarios: %w", err)
+	}
+
+	detector, err := detectorFor(coverageOutputDir)
+	if err != nil {
+		return fmt.Errorf("failed to initialize flake detector: %w", err)
+	}
+
+	var batches []*batch.BatchResult
+	if coverageBatches > 0 {
+		batches, err = batch.LoadRecentBatches(coverageOutputDir, testerEnv, coverageBatches)
+		if err != nil {
+			return err
+		}
+	}
+
+	report := batch.BuildCoverage(journeys, scenarios, detector.IsQuarantined, batches)
+	report.SortByStatus()
+
+	if testerJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	}
+
+	fmt.Printf("\n%s %d of %d journeys covered (%.0f%%) by %d scenarios\n",
+		style.Bold.Render("Journey Coverage:"), report.Covered, report.Total, report.Percent(), len(scenarios))
+
+	for _, j := range report.Journeys {
+		label := j.Name
+		if j.Area != "" {
+			label = j.Area + " / " + j.Name
+		}
+		switch j.Status {
+		case batch.CoverageUncovered:
+			fmt.Printf("  %s %s %s\n", ui.RenderFailIcon(), label, style.Dim.Render("no scenarios"))
+		case batch.CoverageQuarantinedOnly:
+			fmt.Printf("  %s %s %s\n", ui.RenderWarnIcon(), label,
+				style.Dim.Render("only quarantined: "+strings.Join(j.Quarantined, ", ")))
+		default:
+			fmt.Printf("  %s %s %s\n", ui.RenderPassIcon(), label, style.Dim.Render(strings.Join(j.Scenarios, ", ")))
+		}
+	}
+
+	if len(report.Trend) > 0 {
+		fmt.Printf("\n%s (%s, oldest first)\n", style.Bold.Render("Trend:"), testerEnv)
+		for _, p := range report.Trend {
+			fmt.Printf("  %s  %s  exercised %d/%d, passing %d/%d\n",
+				p.StartedAt.Format("2006-01-02 15:04"), p.BatchID, p.Exercised, p.Total, p.Passing, p.Total)
+		}
+	}
+
+	if report.Uncovered > 0 || report.QuarantinedOnly > 0 {
+		fmt.Printf("\n%s %d journeys uncovered, %d covered only by quarantined scenarios.\n",
+			ui.RenderWarnIcon(), report.Uncovered, report.QuarantinedOnly)
+	}
+	return nil
+}
@@ -0,0 +1,487 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/ui"
+)
+
+var (
+	doctorBrowser       string
+	doctorHeaded        bool
+	doctorNoLaunch      bool
+	doctorLaunchTimeout time.Duration
+)
+
+var testerDoctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Diagnose the Playwright and browser stack",
+	Long: `Diagnose the Playwright and browser stack tester runs depend on.
+
+Goes deeper than 'gt tester preflight', for when runs fail with errors
+that don't explain themselves:
+  - Node.js version
+  - Playwright package: which one resolves from this directory, and its version
+  - Browsers: whether the builds this Playwright version expects are installed
+  - Playwright MCP server availability
+  - Display: whether headed runs are possible, or only headless
+  - Sample launch: starts the browser, opens a page and closes it
+
+Each problem is printed with the command that fixes it. Launch failures
+are matched against known causes (missing browser build, missing system
+libraries, no X server, startup hangs).
+
+Exits with code 4 if any check fails.
+
+Examples:
+  gt tester doctor                      # Check everything, launch chromium headless
+  gt tester doctor --headed             # Check headed launches work
+  gt tester doctor --browser firefox
+  gt tester doctor --no-launch --json`,
+	RunE: runTesterDoctor,
+}
+
+func init() {
+	testerDoctorCmd.Flags().StringVar(&doctorBrowser, "browser", "chromium", "Browser to check and launch (chromium, firefox, webkit)")
+	testerDoctorCmd.Flags().BoolVar(&doctorHeaded, "headed", false, "Launch the sample browser headed")
+	testerDoctorCmd.Flags().BoolVar(&doctorNoLaunch, "no-launch", false, "Skip the sample browser launch")
+	testerDoctorCmd.Flags().DurationVar(&doctorLaunchTimeout, "launch-timeout", 60*time.Second, "Time allowed for the sample launch")
+	testerDoctorCmd.Flags().BoolVar(&testerJSON, "json", false, "Output as JSON")
+
+	testerCmd.AddCommand(testerDoctorCmd)
+}
+
+// DoctorResult contains the tester doctor's check results.
+type DoctorResult struct {
+	Browser  string           `json:"browser"`
+	Checks   []PreflightCheck `json:"checks"`
+	Healthy  bool             `json:"healthy"`
+	Warnings int              `json:"warnings"`
+	Failures int              `json:"failures"`
+}
+
+// playwrightPackage is the Playwright package resolved from the project.
+type playwrightPackage struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Path    string `json:"path"`
+}
+
+// playwrightBrowser is a browser build Playwright expects, from
+// 'playwright install --dry-run'.
+type playwrightBrowser struct {
+	Name     string
+	Version  string
+	Location string
+}
+
+func runTesterDoctor(cmd *cobra.Command, args []string) error {
+	switch doctorBrowser {
+	case "chromium", "firefox", "webkit":
+	default:
+		return fmt.Errorf("invalid --browser %q: must be chromium, firefox, or webkit", doctorBrowser)
+	}
+
+	result := DoctorResult{Browser: doctorBrowser, Healthy: true}
+	add := func(c PreflightCheck) {
+		result.Checks = append(result.Checks, c)
+		switch c.Status {
+		case "fail":
+			result.Healthy = false
+			result.Failures++
+		case "warn":
+			result.Warnings++
+		}
+	}
+
+	node := checkNodeJS()
+	add(node)
+	var pkg *playwrightPackage
+	if node.Status == "fail" {
+		add(skippedCheck("Playwright package", "needs Node.js"))
+		add(skippedCheck("Browsers", "needs Node.js"))
+	} else {
+		var check PreflightCheck
+		pkg, check = checkPlaywrightPackage()
+		add(check)
+		if pkg == nil {
+			add(skippedCheck("Browsers", "needs the Playwright package"))
+		} else {
+			add(checkPlaywrightBrowsers(doctorBrowser))
+		}
+	}
+	add(checkMCPServer())
+	add(checkDisplay(doctorHeaded))
+
+	switch {
+	case doctorNoLaunch:
+		add(skippedCheck("Sample launch", "--no-launch"))
+	case pkg == nil:
+		add(skippedCheck("Sample launch", "needs the Playwright package"))
+	default:
+		add(checkBrowserLaunch(doctorBrowser, doctorHeaded, doctorLaunchTimeout))
+	}
+
+	if testerJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(result); err != nil {
+			return err
+		}
+	} else {
+		fmt.Printf("\n%s (%s)\n\n", style.Bold.Render("Tester Doctor"), doctorBrowser)
+		printPreflightChecks(result.Checks)
+		fmt.Println()
+
+		switch {
+		case result.Healthy && result.Warnings == 0:
+			fmt.Printf("%s Browser stack looks healthy.\n", ui.RenderPassIcon())
+		case result.Healthy:
+			fmt.Printf("%s %d warning(s). Runs should work; see the fixes above if they don't.\n",
+				ui.RenderWarnIcon(), result.Warnings)
+		default:
+			fmt.Printf("%s %d check(s) failed, %d warning(s). Apply the fixes above and rerun 'gt tester doctor'.\n",
+				ui.RenderFailIcon(), result.Failures, result.Warnings)
+		}
+	}
+
+	if !result.Healthy {
+		return NewSilentExit(4)
+	}
+	return nil
+}
+
+// skippedCheck is a check that couldn't run because of an earlier one.
+func skippedCheck(name, reason string) PreflightCheck {
+	return PreflightCheck{Name: name, Status: "skip", Message: "skipped: " + reason}
+}
+
+// playwrightResolveScript prints the first Playwright package that
+// resolves from the working directory as JSON.
+const playwrightResolveScript = `
+for (const name of ['playwright', '@playwright/test', 'playwright-core']) {
+  try {
+    const path = require.resolve(name + '/package.json');
+    console.log(JSON.stringify({ name, version: require(path).version, path }));
+    process.exit(0);
+  } catch (e) {}
+}
+process.exit(1);
+`
+
+// nodeCommand runs node with the project's node_modules resolvable, as
+// tester runs and probes do.
+func nodeCommand(ctx context.Context, args ...string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, "node", args...)
+	cmd.Env = os.Environ()
+	if wd, err := os.Getwd(); err == nil {
+		cmd.Env = append(cmd.Env, "NODE_PATH="+filepath.Join(wd, "node_modules"))
+	}
+	return cmd
+}
+
+// checkPlaywrightPackage finds the Playwright package tester runs load.
+func checkPlaywrightPackage() (*playwrightPackage, PreflightCheck) {
+	check := PreflightCheck{Name: "Playwright package"}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	out, err := nodeCommand(ctx, "-e", playwrightResolveScript).Output()
+	var pkg playwrightPackage
+	if err != nil || json.Unmarshal(bytes.TrimSpace(out), &pkg) != nil {
+		check.Status = "fail"
+		check.Details = "No playwright, @playwright/test or playwright-core package resolves from this directory"
+		check.Fix = "npm install -D playwright (in the directory you run gt tester from)"
+		return nil, check
+	}
+
+	check.Status = "pass"
+	check.Message = fmt.Sprintf("%s %s", pkg.Name, pkg.Version)
+	check.Details = filepath.Dir(pkg.Path)
+	if pkg.Name == "playwright-core" {
+		check.Status = "warn"
+		check.Details = "Only playwright-core resolves; it doesn't download browsers on install"
+		check.Fix = "npm install -D playwright"
+	}
+	return &pkg, check
+}
+
+// checkPlaywrightBrowsers checks that the browser builds the installed
+// Playwright expects are present. Upgrading Playwright without reinstalling
+// browsers is the usual cause of "Executable doesn't exist" launch errors.
+func checkPlaywrightBrowsers(browser string) PreflightCheck {
+	check := PreflightCheck{Name: "Browsers"}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, "npx", "--no", "playwright", "install", "--dry-run", browser).Output()
+	expected := parseInstallDryRun(string(out))
+	if err != nil || len(expected) == 0 {
+		// Older Playwright has no --dry-run: list what is installed
+		installed := installedBrowserBuilds(playwrightBrowsersDir())
+		if len(installed) == 0 {
+			check.Status = "fail"
+			check.Details = fmt.Sprintf("No browsers in %s", playwrightBrowsersDir())
+			check.Fix = "npx playwright install " + browser
+			return check
+		}
+		check.Status = "warn"
+		check.Message = strings.Join(installed, ", ")
+		check.Details = "Could not ask Playwright which builds it expects; these are installed"
+		check.Fix = "npx playwright install " + browser + " (if launches report a missing executable)"
+		return check
+	}
+
+	var present, missing []string
+	for _, b := range expected {
+		name := b.Name
+		if b.Version != "" {
+			name += " " + b.Version
+		}
+		if _, err := os.Stat(b.Location); err == nil {
+			present = append(present, name)
+		} else {
+			missing = append(missing, fmt.Sprintf("%s (%s)", name, b.Location))
+		}
+	}
+	if len(missing) > 0 {
+		check.Status = "fail"
+		check.Message = fmt.Sprintf("%d of %d builds missing", len(missing), len(expected))
+		check.Details = "Missing: " + strings.Join(missing, ", ")
+		check.Fix = "npx playwright install " + browser
+		return check
+	}
+	check.Status = "pass"
+	check.Message = strings.Join(present, ", ")
+	return check
+}
+
+// parseInstallDryRun parses 'playwright install --dry-run' output:
+//
+//	browser: chromium version 131.0.6778.33
+//	  Install location:    /home/u/.cache/ms-playwright/chromium-1148
+//	  Download url:        https://...
+func parseInstallDryRun(out string) []playwrightBrowser {
+	var browsers []playwrightBrowser
+	for _, line := range strings.Split(out, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, "browser:"):
+			fields := strings.Fields(strings.TrimPrefix(line, "browser:"))
+			if len(fields) == 0 {
+				continue
+			}
+			b := playwrightBrowser{Name: fields[0]}
+			if len(fields) >= 3 && fields[1] == "version" {
+				b.Version = fields[2]
+			}
+			browsers = append(browsers, b)
+		case strings.HasPrefix(line, "Install location:") && len(browsers) > 0:
+			browsers[len(browsers)-1].Location = strings.TrimSpace(strings.TrimPrefix(line, "Install location:"))
+		}
+	}
+
+	// Entries without a location can't be checked
+	located := browsers[:0]
+	for _, b := range browsers {
+		if b.Location != "" {
+			located = append(located, b)
+		}
+	}
+	return located
+}
+
+// playwrightBrowsersDir is where Playwright keeps downloaded browsers.
+func playwrightBrowsersDir() string {
+	if dir := os.Getenv("PLAYWRIGHT_BROWSERS_PATH"); dir != "" && dir != "0" {
+		return dir
+	}
+	home, _ := os.UserHomeDir()
+	switch runtime.GOOS {
+	case "darwin":
+		return filepath.Join(home, "Library", "Caches", "ms-playwright")
+	case "windows":
+		if local := os.Getenv("LOCALAPPDATA"); local != "" {
+			return filepath.Join(local, "ms-playwright")
+		}
+		return filepath.Join(home, "AppData", "Local", "ms-playwright")
+	default:
+		if cache := os.Getenv("XDG_CACHE_HOME"); cache != "" {
+			return filepath.Join(cache, "ms-playwright")
+		}
+		return filepath.Join(home, ".cache", "ms-playwright")
+	}
+}
+
+// installedBrowserBuilds lists the browser builds in a Playwright browsers
+// directory, e.g. "chromium-1148".
+func installedBrowserBuilds(dir string) []string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	var builds []string
+	for _, e := range entries {
+		if e.IsDir() && !strings.HasPrefix(e.Name(), ".") {
+			builds = append(builds, e.Name())
+		}
+	}
+	sort.Strings(builds)
+	return builds
+}
+
+// checkDisplay reports whether browsers can run headed. Headless runs need
+// no display; headed runs and headed verification do.
+func checkDisplay(headed bool) PreflightCheck {
+	check := PreflightCheck{Name: "Display"}
+	if runtime.GOOS != "linux" {
+		check.Status = "pass"
+		check.Message = "headed and headless"
+		return check
+	}
+
+	for _, env := range []string{"WAYLAND_DISPLAY", "DISPLAY"} {
+		if v := os.Getenv(env); v != "" {
+			check.Status = "pass"
+			check.Message = fmt.Sprintf("headed and headless (%s=%s)", env, v)
+			return check
+		}
+	}
+
+	check.Message = "headless only"
+	check.Details = "No DISPLAY or WAYLAND_DISPLAY; --headed runs and headed verification need a display"
+	if _, err := exec.LookPath("xvfb-run"); err == nil {
+		check.Status = "pass"
+		check.Message = "headless; headed through xvfb-run"
+		check.Details = ""
+		return check
+	}
+	check.Status = "pass"
+	if headed {
+		check.Status = "fail"
+	}
+	check.Fix = "Install Xvfb (e.g. apt install xvfb) and run headed tests under xvfb-run"
+	return check
+}
+
+// browserLaunchScript launches a browser, loads a page and prints the
+// browser version and launch time as JSON.
+const browserLaunchScript = `
+let pw;
+try { pw = require('playwright'); } catch (e) { pw = require('@playwright/test'); }
+const type = pw[process.env.GT_DOCTOR_BROWSER];
+const timeout = Number(process.env.GT_DOCTOR_TIMEOUT_MS);
+
+(async () => {
+  const start = Date.now();
+  const browser = await type.launch({ headless: process.env.GT_DOCTOR_HEADED !== '1', timeout });
+  const page = await browser.newPage();
+  await page.setContent('<title>gt tester doctor</title><p>ok</p>', { timeout });
+  const title = await page.title();
+  const out = { version: browser.version(), launch_ms: Date.now() - start, title };
+  await browser.close();
+  console.log(JSON.stringify(out));
+})().catch((e) => { console.error(String(e && e.message || e)); process.exit(1); });
+`
+
+// checkBrowserLaunch starts the browser, loads a page and closes it.
+func checkBrowserLaunch(browser string, headed bool, timeout time.Duration) PreflightCheck {
+	mode := "headless"
+	if headed {
+		mode = "headed"
+	}
+	check := PreflightCheck{Name: fmt.Sprintf("Sample launch (%s %s)", browser, mode)}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout+10*time.Second)
+	defer cancel()
+	cmd := nodeCommand(ctx, "-e", browserLaunchScript)
+	cmd.Env = append(cmd.Env,
+		"GT_DOCTOR_BROWSER="+browser,
+		fmt.Sprintf("GT_DOCTOR_TIMEOUT_MS=%d", timeout.Milliseconds()))
+	if headed {
+		cmd.Env = append(cmd.Env, "GT_DOCTOR_HEADED=1")
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+
+	var out struct {
+		Version  string `json:"version"`
+		LaunchMs int64  `json:"launch_ms"`
+		Title    string `json:"title"`
+	}
+	if err == nil && json.Unmarshal(bytes.TrimSpace(stdout.Bytes()), &out) == nil {
+		check.Status = "pass"
+		check.Message = fmt.Sprintf("%s %s in %s", browser, out.Version, time.Duration(out.LaunchMs)*time.Millisecond)
+		if out.Title != "gt tester doctor" {
+			check.Status = "warn"
+			check.Details = fmt.Sprintf("Page loaded but its title read back as %q", out.Title)
+		}
+		return check
+	}
+
+	output := stderr.String()
+	if ctx.Err() != nil {
+		output += "\nTimeout exceeded"
+	}
+	check.Status = "fail"
+	check.Details, check.Fix = diagnoseLaunchError(output, browser)
+	return check
+}
+
+// launchDiagnoses map launch error text to a fix, most specific first.
+var launchDiagnoses = []struct {
+	markers []string
+	fix     func(browser string) string
+}{
+	{[]string{"Executable doesn't exist", "Please run the following command to download new browsers"},
+		func(b string) string { return "npx playwright install " + b }},
+	{[]string{"Host system is missing dependencies", "error while loading shared libraries"},
+		func(b string) string { return "sudo npx playwright install-deps " + b }},
+	{[]string{"Cannot find module"},
+		func(string) string { return "npm install -D playwright" }},
+	{[]string{"Missing X server", "without having a XServer", "cannot open display"},
+		func(string) string {
+			return "Run headless, or under xvfb-run (apt install xvfb), or set DISPLAY"
+		}},
+	{[]string{"ENOSPC", "No space left on device"},
+		func(string) string { return "Free disk space (see 'gt tester preflight')" }},
+	{[]string{"Timeout", "timed out"},
+		func(string) string {
+			return "The browser hung while starting: in containers give it shared memory (docker run --shm-size=1g); rerun with DEBUG=pw:browser for its log"
+		}},
+}
+
+// diagnoseLaunchError extracts the first meaningful line of a launch error
+// and suggests a fix for known causes.
+func diagnoseLaunchError(output, browser string) (details, fix string) {
+	details = "Launch failed"
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.Trim(strings.TrimSpace(line), "║╔╗╚╝═ ")
+		if line != "" {
+			details = line
+			break
+		}
+	}
+	for _, d := range launchDiagnoses {
+		for _, marker := range d.markers {
+			if strings.Contains(output, marker) {
+				return details, d.fix(browser)
+			}
+		}
+	}
+	return details, "Rerun with DEBUG=pw:browser gt tester doctor to see the browser's own log"
+}
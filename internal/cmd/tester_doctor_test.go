@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseInstallDryRun(t *testing.T) {
+	out := `browser: chromium version 131.0.6778.33
+  Install location:    /home/u/.cache/ms-playwright/chromium-1148
+  Download url:        https://playwright.azureedge.net/builds/chromium/1148/chromium-linux.zip
+
+browser: ffmpeg
+  Install location:    /home/u/.cache/ms-playwright/ffmpeg-1010
+  Download url:        https://playwright.azureedge.net/builds/ffmpeg/1010/ffmpeg-linux.zip
+
+browser: chromium-headless-shell version 131.0.6778.33
+`
+	got := parseInstallDryRun(out)
+	if len(got) != 2 {
+		t.Fatalf("parseInstallDryRun() = %+v, want 2 located browsers", got)
+	}
+	if got[0].Name != "chromium" || got[0].Version != "131.0.6778.33" ||
+		got[0].Location != "/home/u/.cache/ms-playwright/chromium-1148" {
+		t.Errorf("chromium = %+v", got[0])
+	}
+	if got[1].Name != "ffmpeg" || got[1].Version != "" || got[1].Location != "/home/u/.cache/ms-playwright/ffmpeg-1010" {
+		t.Errorf("ffmpeg = %+v", got[1])
+	}
+
+	if got := parseInstallDryRun("error: unknown option '--dry-run'"); len(got) != 0 {
+		t.Errorf("unsupported --dry-run: got %+v, want none", got)
+	}
+}
+
+func TestDiagnoseLaunchError(t *testing.T) {
+	tests := []struct {
+		name        string
+		output      string
+		wantDetails string
+		wantFix     string
+	}{
+		{
+			name: "missing executable",
+			output: `browserType.launch: Executable doesn't exist at /root/.cache/ms-playwright/chromium-1148/chrome-linux/chrome
+╔═════════════════════════════════════════════════════════════════════════╗
+║ Looks like Playwright was just installed or updated.                    ║
+╚═════════════════════════════════════════════════════════════════════════╝`,
+			wantDetails: "browserType.launch: Executable doesn't exist",
+			wantFix:     "npx playwright install chromium",
+		},
+		{
+			name:    "missing libraries",
+			output:  "chrome: error while loading shared libraries: libnss3.so: cannot open shared object file",
+			wantFix: "sudo npx playwright install-deps chromium",
+		},
+		{
+			name:    "no display",
+			output:  "browserType.launch: Target page, context or browser has been closed\nMissing X server or $DISPLAY",
+			wantFix: "xvfb-run",
+		},
+		{
+			name:    "timeout",
+			output:  "browserType.launch: Timeout 60000ms exceeded.",
+			wantFix: "--shm-size",
+		},
+		{
+			name:        "unknown",
+			output:      "\n\nsomething odd\n",
+			wantDetails: "something odd",
+			wantFix:     "DEBUG=pw:browser",
+		},
+	}
+	for _, tt := range tests {
+		details, fix := diagnoseLaunchError(tt.output, "chromium")
+		if tt.wantDetails != "" && !strings.HasPrefix(details, tt.wantDetails) {
+			t.Errorf("%s: details = %q, want prefix %q", tt.name, details, tt.wantDetails)
+		}
+		if !strings.Contains(fix, tt.wantFix) {
+			t.Errorf("%s: fix = %q, want it to contain %q", tt.name, fix, tt.wantFix)
+		}
+	}
+}
@@ -0,0 +1,192 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/tester/flake"
+)
+
+// Flake command flags
+var (
+	flakeOutputDir       string
+	flakeSimWindows      []int
+	flakeSimDurations    []string
+	flakeSimThresholds   []float64
+	flakeSimMinRuns      []int
+	flakeSimConsecutive  int
+	flakeSimShowScenario bool
+)
+
+var testerFlakeCmd = &cobra.Command{
+	Use:   "flake",
+	Short: "Flake detection tools",
+	Long: `Tools for tuning flake detection.
+
+SUBCOMMANDS:
+  simulate  Replay run history through alternative flake configs`,
+	RunE: requireSubcommand,
+}
+
+var flakeSimulateCmd = &cobra.Command{
+	Use:   "simulate",
+	Short: "Replay run history through alternative flake configs",
+	Long: `Replay recorded scenario runs through alternative flake detection settings
+and report how many auto-quarantines each would have fired, so
+flake_threshold and window_size can be tuned with data instead of guesses.
+
+Each combination of window (--window run counts and --window-duration
+periods), --threshold and --min-runs is simulated, with
+the remaining settings taken from <output>/.flake-config.yaml. The current
+config is always shown first for reference. Auto-quarantine is on in every
+simulation; manual quarantines and per-scenario policies are ignored.
+
+Time-based windows are measured back from each replayed run.
+
+Only retained history is replayed: the detector keeps twice the window it
+records under, so simulating a larger window sees no more runs than that.
+
+Examples:
+  gt tester flake simulate --threshold 0.2,0.3,0.4
+  gt tester flake simulate --window 5,10,20 --threshold 0.3
+  gt tester flake simulate --window 10 --window-duration 7d,14d
+  gt tester flake simulate --window 10 --min-runs 3,5 --scenarios
+  gt tester flake simulate --threshold 0.25 --json`,
+	Args: cobra.NoArgs,
+	RunE: runFlakeSimulate,
+}
+
+func init() {
+	flakeSimulateCmd.Flags().IntSliceVar(&flakeSimWindows, "window", nil, "Window sizes to simulate (default: current)")
+	flakeSimulateCmd.Flags().StringSliceVar(&flakeSimDurations, "window-duration", nil, "Time-based windows to simulate, e.g. 7d,14d")
+	flakeSimulateCmd.Flags().Float64SliceVar(&flakeSimThresholds, "threshold", nil, "Flake thresholds to simulate, 0-1 (default: current)")
+	flakeSimulateCmd.Flags().IntSliceVar(&flakeSimMinRuns, "min-runs", nil, "Minimum run counts to simulate (default: current)")
+	flakeSimulateCmd.Flags().IntVar(&flakeSimConsecutive, "consecutive-failures", -1, "Consecutive failures threshold to simulate (0 disables; default: current)")
+	flakeSimulateCmd.Flags().BoolVar(&flakeSimShowScenario, "scenarios", false, "List the scenarios each config would quarantine")
+	flakeSimulateCmd.Flags().BoolVar(&testerJSON, "json", false, "Output as JSON")
+
+	testerFlakeCmd.PersistentFlags().StringVar(&flakeOutputDir, "output", "test-results", "Output directory for flake data")
+	testerFlakeCmd.AddCommand(flakeSimulateCmd)
+
+	testerCmd.AddCommand(testerFlakeCmd)
+}
+
+func runFlakeSimulate(cmd *cobra.Command, args []string) error {
+	current, err := flake.LoadConfig(filepath.Join(flakeOutputDir, flake.ConfigFileName))
+	if err != nil {
+		return err
+	}
+	detector, err := flake.NewDetector(filepath.Join(flakeOutputDir, flake.DataFileName), current)
+	if err != nil {
+		return fmt.Errorf("failed to initialize flake detector: %w", err)
+	}
+
+	for _, t := range flakeSimThresholds {
+		if t <= 0 || t > 1 {
+			return fmt.Errorf("invalid --threshold %v: must be in (0, 1]", t)
+		}
+	}
+	for _, w := range flakeSimWindows {
+		if w <= 0 {
+			return fmt.Errorf("invalid --window %d: must be positive", w)
+		}
+	}
+	for _, m := range flakeSimMinRuns {
+		if m <= 0 {
+			return fmt.Errorf("invalid --min-runs %d: must be positive", m)
+		}
+	}
+
+	// Each window is a run count or a duration (which takes precedence)
+	type window struct {
+		size     int
+		duration flake.Duration
+	}
+	var windows []window
+	for _, w := range flakeSimWindows {
+		windows = append(windows, window{size: w})
+	}
+	for _, s := range flakeSimDurations {
+		d, err := flake.ParseDuration(s)
+		if err != nil || d <= 0 {
+			return fmt.Errorf("invalid --window-duration %q: must be a positive duration like 7d or 36h", s)
+		}
+		windows = append(windows, window{size: current.WindowSize, duration: d})
+	}
+	if len(windows) == 0 {
+		windows = []window{{size: current.WindowSize, duration: current.WindowDuration}}
+	}
+	thresholds := flakeSimThresholds
+	if len(thresholds) == 0 {
+		thresholds = []float64{current.FlakeThreshold}
+	}
+	minRuns := orDefault(flakeSimMinRuns, current.MinRuns)
+
+	configs := []flake.Config{current}
+	for _, w := range windows {
+		for _, t := range thresholds {
+			for _, m := range minRuns {
+				c := current
+				c.WindowSize, c.WindowDuration = w.size, w.duration
+				c.FlakeThreshold, c.MinRuns = t, m
+				if flakeSimConsecutive >= 0 {
+					c.ConsecutiveFailuresThreshold = flakeSimConsecutive
+				}
+				if flake.SimulationLabel(c) == flake.SimulationLabel(current) {
+					continue // already simulated as the current config
+				}
+				configs = append(configs, c)
+			}
+		}
+	}
+	results := detector.Simulate(configs)
+
+	if testerJSON {
+		data, _ := json.MarshalIndent(results, "", "  ")
+		fmt.Println(string(data))
+		return nil
+	}
+
+	metrics := detector.GetAllMetrics()
+	fmt.Printf("Flake Policy Simulation (%d scenarios)\n", len(metrics))
+	fmt.Println(strings.Repeat("─", 60))
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "CONFIG\tQUARANTINES\tSCENARIOS\tQUARANTINED NOW\tUNQUARANTINES")
+	for i, r := range results {
+		label := r.Label
+		if i == 0 {
+			label += " (current)"
+		}
+		fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%d\n", label, r.Quarantines, len(r.Scenarios), len(r.QuarantinedAtEnd), r.Unquarantines)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if flakeSimShowScenario {
+		for _, r := range results {
+			if len(r.Scenarios) == 0 {
+				continue
+			}
+			fmt.Printf("\n%s:\n", r.Label)
+			for _, s := range r.Scenarios {
+				fmt.Printf("  %s\n", s)
+			}
+		}
+	}
+	return nil
+}
+
+// orDefault returns values, or a single def if values is empty.
+func orDefault(values []int, def int) []int {
+	if len(values) == 0 {
+		return []int{def}
+	}
+	return values
+}
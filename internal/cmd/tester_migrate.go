@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/tester"
+	"github.com/steveyegge/gastown/internal/ui"
+)
+
+var migrateScenariosDryRun bool
+
+var testerMigrateScenariosCmd = &cobra.Command{
+	Use:   "migrate-scenarios [path...]",
+	Short: "Upgrade scenario files to the current schema version",
+	Long: fmt.Sprintf(`Rewrite scenario YAML files to the current schema version (%d).
+
+Unversioned scenarios in the draft format are upgraded:
+  user_story            -> persona, goal (context appended to goal)
+  target.url            -> environment.url
+  steps                 -> appended to goal as suggested steps
+  timeout: 600          -> timeout: 600s
+  recording.screenshots -> true (per-event map no longer supported)
+
+Paths may be files or directories (searched for .yaml/.yml files) and
+default to ./scenarios. Current scenarios are left untouched. Scenarios
+from a newer version than this gt supports are reported and skipped.
+Include fragments (*.fragment.yaml) are skipped, and scenarios that
+include fragments are only checked: deprecated fields in them are
+reported for upgrading by hand.
+
+Examples:
+  gt tester migrate-scenarios
+  gt tester migrate-scenarios scenarios/auth --dry-run`, tester.CurrentScenarioVersion),
+	RunE: runTesterMigrateScenarios,
+}
+
+func init() {
+	testerMigrateScenariosCmd.Flags().BoolVar(&migrateScenariosDryRun, "dry-run", false, "Show what would change without writing files")
+
+	testerCmd.AddCommand(testerMigrateScenariosCmd)
+}
+
+func runTesterMigrateScenarios(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		args = []string{"scenarios"}
+	}
+
+	files, err := findScenarioFiles(args)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		fmt.Println("No scenario files found")
+		return nil
+	}
+
+	var migrated, current, failed int
+	for _, path := range files {
+		if tester.IsScenarioFragment(path) {
+			fmt.Printf("  %s %s: include fragment, skipped\n", ui.RenderWarnIcon(), path)
+			continue
+		}
+
+		data, err := os.ReadFile(path) //nolint:gosec // G304: path is a user-specified scenario file
+		if err != nil {
+			return err
+		}
+
+		if v, err := tester.ScenarioVersion(data); err == nil && v > tester.CurrentScenarioVersion {
+			fmt.Printf("  %s %s: version %d is newer than supported version %d, skipped\n",
+				ui.RenderWarnIcon(), path, v, tester.CurrentScenarioVersion)
+			continue
+		}
+
+		out, changes, err := tester.MigrateScenarioFile(path)
+		if err != nil {
+			fmt.Printf("  %s %s: %v\n", ui.RenderFailIcon(), path, err)
+			failed++
+			continue
+		}
+		if len(changes) == 0 {
+			current++
+			continue
+		}
+
+		if !migrateScenariosDryRun {
+			info, err := os.Stat(path)
+			if err != nil {
+				return err
+			}
+			if err := os.WriteFile(path, out, info.Mode().Perm()); err != nil {
+				return fmt.Errorf("writing %s: %w", path, err)
+			}
+		}
+		migrated++
+		fmt.Printf("  %s %s\n", ui.RenderPassIcon(), path)
+		for _, c := range changes {
+			fmt.Printf("      %s\n", ui.RenderMuted(c))
+		}
+	}
+
+	verb := "Migrated"
+	if migrateScenariosDryRun {
+		verb = "Would migrate"
+	}
+	fmt.Printf("\n%s %d, %d already current", style.Bold.Render(verb+":"), migrated, current)
+	if failed > 0 {
+		fmt.Printf(", %d failed", failed)
+	}
+	fmt.Println()
+
+	if failed > 0 {
+		return NewSilentExit(1)
+	}
+	return nil
+}
+
+// findScenarioFiles expands paths into scenario YAML files, walking
+// directories. Include fragments found in directories are left out.
+func findScenarioFiles(paths []string) ([]string, error) {
+	var files []string
+	for _, p := range paths {
+		info, err := os.Stat(p)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			files = append(files, p)
+			continue
+		}
+		err = filepath.WalkDir(p, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			ext := strings.ToLower(filepath.Ext(path))
+			if !d.IsDir() && (ext == ".yaml" || ext == ".yml") && !tester.IsScenarioFragment(path) {
+				files = append(files, path)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
@@ -7,6 +7,8 @@ import (
 	"path/filepath"
 	"strings"
 	"time"
+
+	"github.com/steveyegge/gastown/internal/tester"
 )
 
 // ObservationType represents the type of UX observation
@@ -21,6 +23,8 @@ const (
 	ObservationBlocked ObservationType = "blocked"
 	// ObservationBug indicates a functional bug was encountered
 	ObservationBug ObservationType = "bug"
+	// ObservationAccessibility indicates an axe-core violation (--a11y runs)
+	ObservationAccessibility = ObservationType(tester.ObservationAccessibility)
 )
 
 // ValidObservationTypes returns all valid observation types
@@ -30,6 +34,7 @@ func ValidObservationTypes() []ObservationType {
 		ObservationFriction,
 		ObservationBlocked,
 		ObservationBug,
+		ObservationAccessibility,
 	}
 }
 
@@ -144,7 +149,7 @@ func (c Confidence) RequiresReview() bool {
 // Observation represents a UX observation made during AI user testing.
 // This is the full observation format matching the spec.
 type Observation struct {
-	// Type of observation (confusion, friction, blocked, bug)
+	// Type of observation (confusion, friction, blocked, bug, accessibility)
 	Type ObservationType `json:"type"`
 
 	// Severity level (P0-P3)
@@ -165,6 +170,12 @@ type Observation struct {
 	// Screenshot filename if one was captured
 	Screenshot string `json:"screenshot,omitempty"`
 
+	// Rule is the axe-core rule ID (accessibility observations only)
+	Rule string `json:"rule,omitempty"`
+
+	// WCAG lists the WCAG success criteria violated (accessibility observations only)
+	WCAG []string `json:"wcag,omitempty"`
+
 	// Validated is set to true/false after human review (nil = not reviewed)
 	Validated *bool `json:"validated"`
 
@@ -268,11 +279,23 @@ type ObservationResult struct {
 	// Infrastructure errors encountered
 	InfrastructureErrors []InfraError `json:"infrastructure_errors"`
 
+	// Abort condition that stopped the run, if any
+	AbortReason string `json:"abort_reason,omitempty"`
+
+	// Severity budgets the observations exceeded, failing the run
+	BudgetExceeded []string `json:"budget_exceeded,omitempty"`
+
+	// Emulated network profile the run used, if any
+	Network string `json:"network,omitempty"`
+
 	// Run metadata
 	RunID     string    `json:"run_id,omitempty"`
 	StartTime time.Time `json:"start_time"`
 	EndTime   time.Time `json:"end_time"`
 	Model     string    `json:"model,omitempty"`
+
+	// Provenance records how to reproduce the run (gt tester rerun)
+	Provenance *tester.Provenance `json:"provenance,omitempty"`
 }
 
 // NewObservationResult creates a new observation result
@@ -293,6 +316,18 @@ func (r *ObservationResult) AddObservation(obs Observation) {
 	r.Observations = append(r.Observations, obs)
 }
 
+// AddA11yFindings records axe-core violations as accessibility observations.
+// Scans are automated, so the observations carry high confidence.
+func (r *ObservationResult) AddA11yFindings(findings []tester.A11yFinding) {
+	for _, f := range findings {
+		obs := NewObservation(ObservationAccessibility, NormalizeSeverity(string(f.Severity)), ConfidenceHigh, f.Description)
+		obs.WithLocation(f.Location).WithTimestamp(f.Step)
+		obs.Rule = f.Rule
+		obs.WCAG = f.WCAG
+		r.AddObservation(*obs)
+	}
+}
+
 // AddInfraError records an infrastructure error
 func (r *ObservationResult) AddInfraError(errType, message string, attempt int) {
 	r.InfrastructureErrors = append(r.InfrastructureErrors, InfraError{
@@ -450,5 +485,9 @@ func FormatObservationForOutput(obs Observation, withTimestamp bool) string {
 
 	parts = append(parts, "-", obs.Description)
 
+	if len(obs.WCAG) > 0 {
+		parts = append(parts, fmt.Sprintf("(WCAG %s)", strings.Join(obs.WCAG, ", ")))
+	}
+
 	return strings.Join(parts, " ")
 }
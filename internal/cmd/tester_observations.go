@@ -0,0 +1,374 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// Observations command flags
+var (
+	obsExportFormat     string
+	obsExportSince      string
+	obsExportOutput     string
+	obsExportResultsDir string
+)
+
+var testerObservationsCmd = &cobra.Command{
+	Use:   "observations",
+	Short: "Analyze observations across test runs",
+	RunE:  requireSubcommand,
+	Long: `Analyze observations recorded across AI user testing runs.
+
+Commands:
+  gt tester observations export      Export observations as CSV or SARIF
+  gt tester observations hotspots    Show where in the app observations cluster`,
+}
+
+var testerObservationsExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export observations as CSV or SARIF",
+	Long: `Export all observations from test results as a flat table.
+
+Each row carries the observation plus its run context (scenario, persona,
+run ID) and review status (pending, validated, false_positive), so it can
+be analyzed in a spreadsheet or uploaded to a code-scanning dashboard.
+
+Formats:
+  csv     One row per observation with a header line
+  sarif   SARIF 2.1.0 log; severity P0/P1 → error, P2 → warning, P3 → note
+
+Examples:
+  gt tester observations export                          # CSV to stdout
+  gt tester observations export --format sarif -o ux.sarif
+  gt tester observations export --since 30d --format csv -o last-month.csv`,
+	RunE: runTesterObservationsExport,
+}
+
+func init() {
+	testerObservationsExportCmd.Flags().StringVar(&obsExportFormat, "format", "csv", "Output format: csv or sarif")
+	testerObservationsExportCmd.Flags().StringVar(&obsExportSince, "since", "", "Only include runs started within this window (e.g., 30d, 12h)")
+	testerObservationsExportCmd.Flags().StringVarP(&obsExportOutput, "output", "o", "", "Write to file instead of stdout")
+	testerObservationsCmd.PersistentFlags().StringVar(&obsExportResultsDir, "results-dir", "test-results", "Test results directory")
+
+	testerObservationsCmd.AddCommand(testerObservationsExportCmd)
+	testerCmd.AddCommand(testerObservationsCmd)
+}
+
+// Observation review statuses used in exports.
+const (
+	ReviewStatusPending       = "pending"
+	ReviewStatusValidated     = "validated"
+	ReviewStatusFalsePositive = "false_positive"
+)
+
+// ExportedObservation is an observation flattened with its run context.
+type ExportedObservation struct {
+	Date        string      `json:"date"`
+	Scenario    string      `json:"scenario"`
+	Persona     string      `json:"persona"`
+	Model       string      `json:"model,omitempty"`
+	RunID       string      `json:"run_id"`
+	ResultFile  string      `json:"result_file"`
+	StartTime   time.Time   `json:"start_time"`
+	Status      string      `json:"review_status"`
+	Observation Observation `json:"observation"`
+}
+
+// ReviewStatus returns the human review state of an observation.
+func (o *Observation) ReviewStatus() string {
+	if o.FalsePositive != nil && *o.FalsePositive {
+		return ReviewStatusFalsePositive
+	}
+	if o.Validated != nil && *o.Validated {
+		return ReviewStatusValidated
+	}
+	return ReviewStatusPending
+}
+
+func runTesterObservationsExport(cmd *cobra.Command, args []string) error {
+	format := strings.ToLower(obsExportFormat)
+	if format != "csv" && format != "sarif" {
+		return fmt.Errorf("unsupported format %q (valid: csv, sarif)", obsExportFormat)
+	}
+
+	var since time.Time
+	if obsExportSince != "" {
+		d, err := parseDuration(obsExportSince)
+		if err != nil {
+			return fmt.Errorf("invalid --since: %w", err)
+		}
+		since = time.Now().Add(-d)
+	}
+
+	rows, err := collectExportedObservations(obsExportResultsDir, since)
+	if err != nil {
+		return fmt.Errorf("collecting observations: %w", err)
+	}
+
+	var w io.Writer = os.Stdout
+	var f *os.File
+	if obsExportOutput != "" {
+		f, err = os.Create(obsExportOutput)
+		if err != nil {
+			return fmt.Errorf("creating output file: %w", err)
+		}
+		w = f
+	}
+
+	if format == "sarif" {
+		err = writeObservationsSARIF(w, rows)
+	} else {
+		err = writeObservationsCSV(w, rows)
+	}
+	// A failed close can mean the export was truncated
+	if f != nil {
+		if cerr := f.Close(); err == nil && cerr != nil {
+			err = fmt.Errorf("writing output file: %w", cerr)
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	if obsExportOutput != "" {
+		fmt.Fprintf(os.Stderr, "Exported %d observations to %s\n", len(rows), obsExportOutput)
+	}
+	return nil
+}
+
+// collectExportedObservations walks resultsDir for observations.json files and
+// flattens every observation from runs started at or after since.
+// A zero since includes all runs.
+func collectExportedObservations(resultsDir string, since time.Time) ([]ExportedObservation, error) {
+	var rows []ExportedObservation
+
+	if _, err := os.Stat(resultsDir); os.IsNotExist(err) {
+		return rows, nil // No results yet
+	}
+
+	err := filepath.Walk(resultsDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil // Skip errors
+		}
+		if info.IsDir() || info.Name() != "observations.json" {
+			return nil
+		}
+
+		result, err := LoadObservationResult(path)
+		if err != nil {
+			return nil // Skip invalid files
+		}
+
+		date := extractDateFromPath(path)
+		started := result.StartTime
+		if started.IsZero() && date != "" {
+			started, _ = time.Parse("2006-01-02", date)
+		}
+		if !since.IsZero() && started.Before(since) {
+			return nil
+		}
+		if date == "" && !started.IsZero() {
+			date = started.Format("2006-01-02")
+		}
+
+		for _, obs := range result.Observations {
+			rows = append(rows, ExportedObservation{
+				Date:        date,
+				Scenario:    result.Scenario,
+				Persona:     result.Persona,
+				Model:       result.Model,
+				RunID:       result.RunID,
+				ResultFile:  path,
+				StartTime:   started,
+				Status:      obs.ReviewStatus(),
+				Observation: obs,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		if !rows[i].StartTime.Equal(rows[j].StartTime) {
+			return rows[i].StartTime.Before(rows[j].StartTime)
+		}
+		return rows[i].Scenario < rows[j].Scenario
+	})
+
+	return rows, nil
+}
+
+// writeObservationsCSV writes one CSV row per observation with a header line.
+func writeObservationsCSV(w io.Writer, rows []ExportedObservation) error {
+	cw := csv.NewWriter(w)
+	header := []string{
+		"date", "scenario", "persona", "run_id", "type", "severity", "confidence",
+		"timestamp", "location", "description", "screenshot", "review_status", "result_file",
+	}
+	if err := cw.Write(header); err != nil {
+		return fmt.Errorf("writing CSV: %w", err)
+	}
+	for _, r := range rows {
+		record := []string{
+			r.Date,
+			r.Scenario,
+			r.Persona,
+			r.RunID,
+			string(r.Observation.Type),
+			string(r.Observation.Severity),
+			string(r.Observation.Confidence),
+			r.Observation.Timestamp,
+			r.Observation.Location,
+			r.Observation.Description,
+			r.Observation.Screenshot,
+			r.Status,
+			r.ResultFile,
+		}
+		if err := cw.Write(record); err != nil {
+			return fmt.Errorf("writing CSV: %w", err)
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// SARIF 2.1.0 subset used for observation exports.
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name  string      `json:"name"`
+	Rules []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID     string                 `json:"ruleId"`
+	Level      string                 `json:"level"`
+	Message    sarifMessage           `json:"message"`
+	Locations  []sarifLocation        `json:"locations,omitempty"`
+	Properties map[string]interface{} `json:"properties,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation *sarifPhysicalLocation `json:"physicalLocation,omitempty"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifLogicalLocation struct {
+	Name string `json:"name"`
+	Kind string `json:"kind,omitempty"`
+}
+
+// sarifLevel maps observation severity to a SARIF result level.
+func sarifLevel(s Severity) string {
+	switch NormalizeSeverity(string(s)) {
+	case SeverityP0, SeverityP1:
+		return "error"
+	case SeverityP2:
+		return "warning"
+	default:
+		return "note"
+	}
+}
+
+// writeObservationsSARIF writes observations as a SARIF 2.1.0 log.
+// Each observation type becomes a rule; false positives are omitted since
+// code-scanning dashboards treat every result as an open finding.
+func writeObservationsSARIF(w io.Writer, rows []ExportedObservation) error {
+	var rules []sarifRule
+	for _, t := range ValidObservationTypes() {
+		rules = append(rules, sarifRule{
+			ID:               "ux/" + string(t),
+			ShortDescription: sarifMessage{Text: fmt.Sprintf("UX %s observed by AI user testing", t)},
+		})
+	}
+
+	results := make([]sarifResult, 0, len(rows))
+	for _, r := range rows {
+		if r.Status == ReviewStatusFalsePositive {
+			continue
+		}
+		res := sarifResult{
+			RuleID:  "ux/" + strings.ToLower(string(r.Observation.Type)),
+			Level:   sarifLevel(r.Observation.Severity),
+			Message: sarifMessage{Text: r.Observation.Description},
+			Locations: []sarifLocation{{
+				PhysicalLocation: &sarifPhysicalLocation{
+					ArtifactLocation: sarifArtifactLocation{URI: filepath.ToSlash(r.ResultFile)},
+				},
+			}},
+			Properties: map[string]interface{}{
+				"scenario":      r.Scenario,
+				"persona":       r.Persona,
+				"run_id":        r.RunID,
+				"severity":      string(r.Observation.Severity),
+				"confidence":    string(r.Observation.Confidence),
+				"timestamp":     r.Observation.Timestamp,
+				"review_status": r.Status,
+			},
+		}
+		if r.Observation.Location != "" {
+			res.Locations[0].LogicalLocations = []sarifLogicalLocation{{
+				Name: r.Observation.Location,
+				Kind: "page",
+			}}
+		}
+		results = append(results, res)
+	}
+
+	log := sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs: []sarifRun{{
+			Tool:    sarifTool{Driver: sarifDriver{Name: "gt-tester", Rules: rules}},
+			Results: results,
+		}},
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(log); err != nil {
+		return fmt.Errorf("writing SARIF: %w", err)
+	}
+	return nil
+}
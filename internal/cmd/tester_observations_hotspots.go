@@ -0,0 +1,365 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/ui"
+)
+
+// Hotspots command flags
+var (
+	obsHotspotsSince  string
+	obsHotspotsTop    int
+	obsHotspotsFormat string
+	obsHotspotsOutput string
+)
+
+var testerObservationsHotspotsCmd = &cobra.Command{
+	Use:   "hotspots",
+	Short: "Show where in the app observations cluster",
+	Long: `Aggregate observations by location across runs and batches and report
+the hot spots: the pages generating the largest share of observations.
+
+Locations are compared without query strings, fragments, or trailing
+slashes, so /checkout?step=2 and /checkout/ count as one page. False
+positives are excluded.
+
+Each hot spot shows its share of all observations and of each severity
+("checkout generated 40% of all P1s"), and a trend: the observation count
+in the second half of the window against the first half (rising, falling,
+steady, or new).
+
+Formats:
+  text    Ranked table for the terminal
+  json    The full report
+  html    Standalone HTML report
+
+Examples:
+  gt tester observations hotspots
+  gt tester observations hotspots --since 30d
+  gt tester observations hotspots --since 7d --top 5 --format json
+  gt tester observations hotspots --since 30d --format html -o hotspots.html`,
+	RunE: runTesterObservationsHotspots,
+}
+
+func init() {
+	testerObservationsHotspotsCmd.Flags().StringVar(&obsHotspotsSince, "since", "", "Only include runs started within this window (e.g., 30d, 12h)")
+	testerObservationsHotspotsCmd.Flags().IntVar(&obsHotspotsTop, "top", 10, "Number of hot spots to show (0 for all)")
+	testerObservationsHotspotsCmd.Flags().StringVar(&obsHotspotsFormat, "format", "text", "Output format: text, json, or html")
+	testerObservationsHotspotsCmd.Flags().StringVarP(&obsHotspotsOutput, "output", "o", "", "Write to file instead of stdout")
+
+	testerObservationsCmd.AddCommand(testerObservationsHotspotsCmd)
+}
+
+// Hot spot trend directions.
+const (
+	TrendRising  = "rising"
+	TrendFalling = "falling"
+	TrendSteady  = "steady"
+	TrendNew     = "new"
+)
+
+// unspecifiedLocation groups observations recorded without a location.
+const unspecifiedLocation = "(unspecified)"
+
+// Hotspot is the observation tally for one location.
+type Hotspot struct {
+	Location     string `json:"location"`
+	Observations int    `json:"observations"`
+
+	// Share is the location's share of all observations (0-1).
+	Share float64 `json:"share"`
+
+	// BySeverity counts the location's observations per severity, and
+	// SeverityShare is its share of all observations of that severity.
+	BySeverity    map[Severity]int     `json:"by_severity"`
+	SeverityShare map[Severity]float64 `json:"severity_share"`
+
+	Scenarios []string `json:"scenarios"`
+	Runs      int      `json:"runs"`
+
+	// Earlier and Recent count observations in the first and second half
+	// of the window; Trend compares them.
+	Earlier int    `json:"earlier"`
+	Recent  int    `json:"recent"`
+	Trend   string `json:"trend"`
+}
+
+// HotspotReport ranks locations by the observations they generated.
+type HotspotReport struct {
+	Since      time.Time        `json:"since"`
+	Until      time.Time        `json:"until"`
+	Total      int              `json:"total"`
+	BySeverity map[Severity]int `json:"by_severity"`
+
+	// Headlines name the location generating the most observations of each
+	// actionable severity (P0, P1).
+	Headlines []string  `json:"headlines,omitempty"`
+	Hotspots  []Hotspot `json:"hotspots"`
+}
+
+func runTesterObservationsHotspots(cmd *cobra.Command, args []string) error {
+	format := strings.ToLower(obsHotspotsFormat)
+	if format != "text" && format != "json" && format != "html" {
+		return fmt.Errorf("unsupported format %q (valid: text, json, html)", obsHotspotsFormat)
+	}
+
+	now := time.Now()
+	var since time.Time
+	if obsHotspotsSince != "" {
+		d, err := parseDuration(obsHotspotsSince)
+		if err != nil {
+			return fmt.Errorf("invalid --since: %w", err)
+		}
+		since = now.Add(-d)
+	}
+
+	rows, err := collectExportedObservations(obsExportResultsDir, since)
+	if err != nil {
+		return fmt.Errorf("collecting observations: %w", err)
+	}
+	report := buildHotspotReport(rows, since, now)
+	if obsHotspotsTop > 0 && len(report.Hotspots) > obsHotspotsTop {
+		report.Hotspots = report.Hotspots[:obsHotspotsTop]
+	}
+
+	var w io.Writer = os.Stdout
+	if obsHotspotsOutput != "" {
+		f, err := os.Create(obsHotspotsOutput)
+		if err != nil {
+			return fmt.Errorf("creating output file: %w", err)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	switch format {
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(report)
+	case "html":
+		err = writeHotspotsHTML(w, report)
+	default:
+		printHotspots(w, report)
+	}
+	if err != nil {
+		return err
+	}
+
+	if obsHotspotsOutput != "" {
+		fmt.Fprintf(os.Stderr, "Wrote %d hot spots to %s\n", len(report.Hotspots), obsHotspotsOutput)
+	}
+	return nil
+}
+
+// buildHotspotReport tallies observations by location over [since, until],
+// ranked by observation count. A zero since starts the window at the
+// earliest run.
+func buildHotspotReport(rows []ExportedObservation, since, until time.Time) HotspotReport {
+	report := HotspotReport{Since: since, Until: until, BySeverity: make(map[Severity]int)}
+	if report.Since.IsZero() {
+		for _, row := range rows {
+			if !row.StartTime.IsZero() && (report.Since.IsZero() || row.StartTime.Before(report.Since)) {
+				report.Since = row.StartTime
+			}
+		}
+	}
+	mid := report.Since.Add(until.Sub(report.Since) / 2)
+
+	hotspots := make(map[string]*Hotspot)
+	scenarios := make(map[string]map[string]bool)
+	runs := make(map[string]map[string]bool)
+	for _, row := range rows {
+		if row.Status == ReviewStatusFalsePositive {
+			continue
+		}
+		loc := normalizeLocation(row.Observation.Location)
+		h, ok := hotspots[loc]
+		if !ok {
+			h = &Hotspot{Location: loc, BySeverity: make(map[Severity]int), SeverityShare: make(map[Severity]float64)}
+			hotspots[loc] = h
+			scenarios[loc] = make(map[string]bool)
+			runs[loc] = make(map[string]bool)
+		}
+
+		severity := NormalizeSeverity(string(row.Observation.Severity))
+		report.Total++
+		report.BySeverity[severity]++
+		h.Observations++
+		h.BySeverity[severity]++
+		if row.StartTime.Before(mid) {
+			h.Earlier++
+		} else {
+			h.Recent++
+		}
+		scenarios[loc][row.Scenario] = true
+		runs[loc][row.ResultFile] = true
+	}
+
+	for loc, h := range hotspots {
+		h.Share = float64(h.Observations) / float64(report.Total)
+		for severity, n := range h.BySeverity {
+			h.SeverityShare[severity] = float64(n) / float64(report.BySeverity[severity])
+		}
+		for scenario := range scenarios[loc] {
+			h.Scenarios = append(h.Scenarios, scenario)
+		}
+		sort.Strings(h.Scenarios)
+		h.Runs = len(runs[loc])
+		switch {
+		case h.Earlier == 0:
+			h.Trend = TrendNew
+		case h.Recent > h.Earlier:
+			h.Trend = TrendRising
+		case h.Recent < h.Earlier:
+			h.Trend = TrendFalling
+		default:
+			h.Trend = TrendSteady
+		}
+		report.Hotspots = append(report.Hotspots, *h)
+	}
+	sort.Slice(report.Hotspots, func(i, j int) bool {
+		a, b := report.Hotspots[i], report.Hotspots[j]
+		if a.Observations != b.Observations {
+			return a.Observations > b.Observations
+		}
+		if a.BySeverity[SeverityP0] != b.BySeverity[SeverityP0] {
+			return a.BySeverity[SeverityP0] > b.BySeverity[SeverityP0]
+		}
+		return a.Location < b.Location
+	})
+
+	for _, severity := range ValidSeverities() {
+		if !severity.RequiresAction() || report.BySeverity[severity] == 0 {
+			continue
+		}
+		var top *Hotspot
+		for i := range report.Hotspots {
+			h := &report.Hotspots[i]
+			if top == nil || h.BySeverity[severity] > top.BySeverity[severity] {
+				top = h
+			}
+		}
+		report.Headlines = append(report.Headlines, fmt.Sprintf("%s generated %s of all %ss (%d/%d)",
+			top.Location, formatRate(top.SeverityShare[severity]), severity,
+			top.BySeverity[severity], report.BySeverity[severity]))
+	}
+
+	return report
+}
+
+// normalizeLocation reduces a location to the page it names: URLs lose
+// their query string, fragment, and trailing slash.
+func normalizeLocation(location string) string {
+	location = strings.TrimSpace(location)
+	if location == "" {
+		return unspecifiedLocation
+	}
+	if u, err := url.Parse(location); err == nil && (u.Host != "" || strings.HasPrefix(location, "/")) {
+		u.RawQuery, u.Fragment, u.RawFragment = "", "", ""
+		u.ForceQuery = false
+		location = u.String()
+	} else if i := strings.IndexAny(location, "?#"); i > 0 {
+		location = location[:i]
+	}
+	if len(location) > 1 {
+		location = strings.TrimSuffix(location, "/")
+	}
+	return location
+}
+
+// trendArrow renders a hot spot trend for the terminal and HTML report.
+func trendArrow(trend string) string {
+	switch trend {
+	case TrendRising:
+		return "↑ rising"
+	case TrendFalling:
+		return "↓ falling"
+	case TrendNew:
+		return "★ new"
+	default:
+		return "→ steady"
+	}
+}
+
+// hotspotSeverities formats a hot spot's severity counts, e.g. "P0:1 P1:4".
+func hotspotSeverities(h Hotspot) string {
+	var parts []string
+	for _, severity := range ValidSeverities() {
+		if n := h.BySeverity[severity]; n > 0 {
+			parts = append(parts, fmt.Sprintf("%s:%d", severity, n))
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// printHotspots prints the report as a ranked table.
+func printHotspots(w io.Writer, report HotspotReport) {
+	if report.Total == 0 {
+		fmt.Fprintln(w, "\nNo observations in this window.")
+		return
+	}
+
+	fmt.Fprintf(w, "\n%s %d observations, %s to %s\n", style.Bold.Render("Observation Hot Spots:"),
+		report.Total, report.Since.Format("2006-01-02"), report.Until.Format("2006-01-02"))
+	for _, headline := range report.Headlines {
+		fmt.Fprintf(w, "  %s %s\n", ui.RenderWarnIcon(), headline)
+	}
+	fmt.Fprintln(w)
+	for i, h := range report.Hotspots {
+		fmt.Fprintf(w, "  %2d. %-40s %4d  %5s  %-10s  %s\n", i+1, h.Location, h.Observations,
+			formatRate(h.Share), trendArrow(h.Trend), hotspotSeverities(h))
+		fmt.Fprintf(w, "      %s\n", style.Dim.Render(fmt.Sprintf("%d runs: %s", h.Runs, strings.Join(h.Scenarios, ", "))))
+	}
+}
+
+var hotspotsHTMLTemplate = template.Must(template.New("hotspots").Funcs(template.FuncMap{
+	"rate":       formatRate,
+	"trend":      trendArrow,
+	"severities": hotspotSeverities,
+	"date":       func(t time.Time) string { return t.Format("2006-01-02") },
+	"join":       strings.Join,
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Observation Hot Spots</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; }
+th, td { padding: 0.3em 0.8em; border-bottom: 1px solid #ddd; text-align: left; }
+.rising { color: #b00; } .falling { color: #070; }
+</style>
+</head>
+<body>
+<h1>Observation Hot Spots</h1>
+<p>{{.Total}} observations, {{date .Since}} to {{date .Until}}</p>
+{{if .Headlines}}<ul>
+{{range .Headlines}}<li>{{.}}</li>
+{{end}}</ul>
+{{end}}<table>
+<tr><th>Location</th><th>Observations</th><th>Share</th><th>Severity</th><th>Trend</th><th>Runs</th><th>Scenarios</th></tr>
+{{range .Hotspots}}<tr><td>{{.Location}}</td><td>{{.Observations}}</td><td>{{rate .Share}}</td><td>{{severities .}}</td><td class="{{.Trend}}">{{trend .Trend}}</td><td>{{.Runs}}</td><td>{{join .Scenarios ", "}}</td></tr>
+{{end}}</table>
+</body>
+</html>
+`))
+
+// writeHotspotsHTML writes the report as a standalone HTML page.
+func writeHotspotsHTML(w io.Writer, report HotspotReport) error {
+	if err := hotspotsHTMLTemplate.Execute(w, report); err != nil {
+		return fmt.Errorf("writing HTML: %w", err)
+	}
+	return nil
+}
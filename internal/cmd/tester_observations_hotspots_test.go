@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func hotspotRow(location string, severity Severity, started time.Time, run string) ExportedObservation {
+	obs := Observation{Type: ObservationConfusion, Severity: severity, Location: location, Description: "x"}
+	return ExportedObservation{
+		Scenario:    "checkout",
+		ResultFile:  run,
+		StartTime:   started,
+		Status:      obs.ReviewStatus(),
+		Observation: obs,
+	}
+}
+
+func TestBuildHotspotReport(t *testing.T) {
+	now := time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)
+	since := now.Add(-30 * 24 * time.Hour)
+	early, late := since.Add(24*time.Hour), now.Add(-24*time.Hour)
+
+	rows := []ExportedObservation{
+		hotspotRow("https://shop.example.com/checkout?step=2", SeverityP1, early, "a"),
+		hotspotRow("https://shop.example.com/checkout/", SeverityP1, late, "b"),
+		hotspotRow("https://shop.example.com/checkout#pay", SeverityP2, late, "b"),
+		hotspotRow("https://shop.example.com/cart", SeverityP1, early, "a"),
+		hotspotRow("https://shop.example.com/cart", SeverityP3, early, "a"),
+		hotspotRow("", SeverityP2, late, "c"),
+	}
+	yes := true
+	fp := hotspotRow("https://shop.example.com/cart", SeverityP0, late, "c")
+	fp.Observation.FalsePositive = &yes
+	fp.Status = fp.Observation.ReviewStatus()
+	rows = append(rows, fp)
+
+	report := buildHotspotReport(rows, since, now)
+
+	if report.Total != 6 || report.BySeverity[SeverityP1] != 3 || report.BySeverity[SeverityP0] != 0 {
+		t.Fatalf("totals = %d %v, want 6 without the false positive", report.Total, report.BySeverity)
+	}
+	if len(report.Hotspots) != 3 {
+		t.Fatalf("expected 3 locations, got %+v", report.Hotspots)
+	}
+
+	checkout := report.Hotspots[0]
+	if checkout.Location != "https://shop.example.com/checkout" || checkout.Observations != 3 || checkout.Runs != 2 {
+		t.Errorf("top hot spot = %+v", checkout)
+	}
+	if checkout.Trend != TrendRising || checkout.Earlier != 1 || checkout.Recent != 2 {
+		t.Errorf("checkout trend = %s (%d → %d), want rising", checkout.Trend, checkout.Earlier, checkout.Recent)
+	}
+	if share := checkout.SeverityShare[SeverityP1]; share < 0.66 || share > 0.67 {
+		t.Errorf("checkout P1 share = %v, want 2/3", share)
+	}
+
+	if cart := report.Hotspots[1]; cart.Location != "https://shop.example.com/cart" || cart.Trend != TrendFalling {
+		t.Errorf("second hot spot = %+v, want falling cart", cart)
+	}
+	if unspecified := report.Hotspots[2]; unspecified.Location != unspecifiedLocation || unspecified.Trend != TrendNew {
+		t.Errorf("third hot spot = %+v, want new unspecified", unspecified)
+	}
+
+	want := "https://shop.example.com/checkout generated 67% of all P1s (2/3)"
+	if len(report.Headlines) != 1 || report.Headlines[0] != want {
+		t.Errorf("headlines = %q, want [%q]", report.Headlines, want)
+	}
+
+	var buf bytes.Buffer
+	if err := writeHotspotsHTML(&buf, report); err != nil {
+		t.Fatalf("writeHotspotsHTML() error = %v", err)
+	}
+	for _, s := range []string{"<table>", "generated 67% of all P1s", "↑ rising"} {
+		if !strings.Contains(buf.String(), s) {
+			t.Errorf("HTML report missing %q", s)
+		}
+	}
+}
+
+func TestNormalizeLocation(t *testing.T) {
+	tests := map[string]string{
+		"":                            unspecifiedLocation,
+		"  /checkout/?step=2 ":        "/checkout",
+		"https://a.example.com/x#top": "https://a.example.com/x",
+		"/":                           "/",
+		"checkout page":               "checkout page",
+		"settings modal?tab=billing":  "settings modal",
+	}
+	for in, want := range tests {
+		if got := normalizeLocation(in); got != want {
+			t.Errorf("normalizeLocation(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func exportRow(obsType ObservationType, severity Severity, description string) ExportedObservation {
+	obs := Observation{
+		Type:        obsType,
+		Severity:    severity,
+		Confidence:  ConfidenceHigh,
+		Timestamp:   "00:42",
+		Location:    "https://shop.example.com/checkout",
+		Description: description,
+	}
+	return ExportedObservation{
+		Date:        "2026-03-30",
+		Scenario:    "checkout",
+		Persona:     "first-time buyer",
+		RunID:       "run-001",
+		ResultFile:  filepath.Join("test-results", "2026-03-30", "checkout", "run-001", "observations.json"),
+		Status:      obs.ReviewStatus(),
+		Observation: obs,
+	}
+}
+
+func TestWriteObservationsCSV(t *testing.T) {
+	tricky := "Button says \"Pay\", but\nnothing happens"
+	rows := []ExportedObservation{exportRow(ObservationConfusion, SeverityP1, tricky)}
+
+	var buf bytes.Buffer
+	if err := writeObservationsCSV(&buf, rows); err != nil {
+		t.Fatalf("writeObservationsCSV: %v", err)
+	}
+
+	// Commas, quotes and newlines must survive a round trip through a CSV reader
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("output is not valid CSV: %v\n%s", err, buf.String())
+	}
+	if len(records) != 2 {
+		t.Fatalf("expected header and 1 row, got %d records", len(records))
+	}
+	header, row := records[0], records[1]
+	if len(row) != len(header) {
+		t.Fatalf("row has %d fields, header has %d", len(row), len(header))
+	}
+	fields := make(map[string]string, len(header))
+	for i, name := range header {
+		fields[name] = row[i]
+	}
+	if fields["description"] != tricky {
+		t.Errorf("description = %q, want %q", fields["description"], tricky)
+	}
+	if fields["persona"] != "first-time buyer" || fields["severity"] != "P1" || fields["review_status"] != ReviewStatusPending {
+		t.Errorf("unexpected fields: %v", fields)
+	}
+}
+
+func TestWriteObservationsSARIF(t *testing.T) {
+	fp := exportRow(ObservationConfusion, SeverityP0, "not a real issue")
+	yes := true
+	fp.Observation.FalsePositive = &yes
+	fp.Status = fp.Observation.ReviewStatus()
+	rows := []ExportedObservation{
+		exportRow(ObservationConfusion, SeverityP1, "confusing checkout"),
+		exportRow(ObservationConfusion, SeverityP3, "minor nit"),
+		fp,
+	}
+
+	var buf bytes.Buffer
+	if err := writeObservationsSARIF(&buf, rows); err != nil {
+		t.Fatalf("writeObservationsSARIF: %v", err)
+	}
+
+	var log struct {
+		Schema  string `json:"$schema"`
+		Version string `json:"version"`
+		Runs    []struct {
+			Tool struct {
+				Driver struct {
+					Name  string `json:"name"`
+					Rules []struct {
+						ID string `json:"id"`
+					} `json:"rules"`
+				} `json:"driver"`
+			} `json:"tool"`
+			Results []struct {
+				RuleID  string `json:"ruleId"`
+				Level   string `json:"level"`
+				Message struct {
+					Text string `json:"text"`
+				} `json:"message"`
+				Locations []struct {
+					PhysicalLocation struct {
+						ArtifactLocation struct {
+							URI string `json:"uri"`
+						} `json:"artifactLocation"`
+					} `json:"physicalLocation"`
+					LogicalLocations []struct {
+						Name string `json:"name"`
+					} `json:"logicalLocations"`
+				} `json:"locations"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatalf("output is not valid JSON: %v", err)
+	}
+
+	if log.Version != "2.1.0" || log.Schema == "" {
+		t.Errorf("version/schema = %q/%q, want SARIF 2.1.0", log.Version, log.Schema)
+	}
+	if len(log.Runs) != 1 {
+		t.Fatalf("expected 1 run, got %d", len(log.Runs))
+	}
+	run := log.Runs[0]
+	if run.Tool.Driver.Name != "gt-tester" || len(run.Tool.Driver.Rules) != len(ValidObservationTypes()) {
+		t.Errorf("driver = %+v, want gt-tester with one rule per observation type", run.Tool.Driver)
+	}
+
+	// The false positive is left out
+	if len(run.Results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(run.Results))
+	}
+	res := run.Results[0]
+	if res.RuleID != "ux/confusion" || res.Level != "error" || res.Message.Text != "confusing checkout" {
+		t.Errorf("first result = %+v", res)
+	}
+	if run.Results[1].Level != "note" {
+		t.Errorf("P3 level = %q, want note", run.Results[1].Level)
+	}
+	if len(res.Locations) != 1 ||
+		res.Locations[0].PhysicalLocation.ArtifactLocation.URI != "test-results/2026-03-30/checkout/run-001/observations.json" ||
+		len(res.Locations[0].LogicalLocations) != 1 || res.Locations[0].LogicalLocations[0].Name != "https://shop.example.com/checkout" {
+		t.Errorf("locations = %+v", res.Locations)
+	}
+}
+
+func TestCollectExportedObservations(t *testing.T) {
+	resultsDir := t.TempDir()
+	if rows, err := collectExportedObservations(filepath.Join(resultsDir, "missing"), time.Time{}); err != nil || len(rows) != 0 {
+		t.Fatalf("missing dir = %v, %v; want no rows", rows, err)
+	}
+
+	writeRun := func(date, scenario string, started time.Time, n int) {
+		t.Helper()
+		dir := filepath.Join(resultsDir, date, scenario, "run-001")
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		result := NewObservationResult(scenario, "tester")
+		result.StartTime = started
+		for i := 0; i < n; i++ {
+			result.AddObservation(*NewObservation(ObservationConfusion, SeverityP2, ConfidenceMedium, "obs"))
+		}
+		if err := result.WriteToFile(dir); err != nil {
+			t.Fatal(err)
+		}
+	}
+	now := time.Now()
+	writeRun(now.AddDate(0, 0, -10).Format("2006-01-02"), "old", now.AddDate(0, 0, -10), 1)
+	writeRun(now.Format("2006-01-02"), "signup", now.Add(-time.Hour), 2)
+	writeRun(now.Format("2006-01-02"), "checkout", now.Add(-time.Hour), 1)
+
+	rows, err := collectExportedObservations(resultsDir, time.Time{})
+	if err != nil {
+		t.Fatalf("collectExportedObservations: %v", err)
+	}
+	if len(rows) != 4 || rows[0].Scenario != "old" {
+		t.Fatalf("expected 4 rows, oldest first, got %d (%+v)", len(rows), rows)
+	}
+	// Runs started together are ordered by scenario
+	if rows[1].Scenario != "checkout" || rows[1].Date != now.Format("2006-01-02") {
+		t.Errorf("second row = %+v, want today's checkout", rows[1])
+	}
+
+	rows, err = collectExportedObservations(resultsDir, now.AddDate(0, 0, -1))
+	if err != nil {
+		t.Fatalf("collectExportedObservations: %v", err)
+	}
+	if len(rows) != 3 {
+		t.Errorf("expected 3 rows since yesterday, got %d", len(rows))
+	}
+}
@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/tester/batch"
+	"github.com/steveyegge/gastown/internal/ui"
+)
+
+// Persona comparison command flags
+var (
+	personasOutputDir string
+	personasBatches   int
+	personasScenario  string
+)
+
+var testerPersonasCmd = &cobra.Command{
+	Use:   "personas",
+	Short: "Compare scenario results by persona across recent batches",
+	Long: `Compare how each scenario fares under the personas it ran as.
+
+Scenarios list alternative personas in a persona_pool:
+
+  persona: sarah
+  persona_pool: [sarah, miguel, grandma-jo]
+
+Batches run with --rotate-personas (typically from a schedule) run each
+such scenario as the next persona in its pool, one persona per batch. Over
+time every persona gets runs, and this report totals the last --batches
+batches in the environment by scenario and persona: pass rate and
+observations per run.
+
+A persona is flagged when, with at least two runs, its pass rate trails the
+best persona's by 25 points or more, or it averages at least one more
+observation per run than the quietest persona, and at least twice as many.
+Those are UX issues that only affect some kinds of user.
+
+Examples:
+  gt tester personas
+  gt tester personas --batches 50 --env production
+  gt tester personas --scenario checkout --json`,
+	Args: cobra.NoArgs,
+	RunE: runTesterPersonas,
+}
+
+func init() {
+	testerPersonasCmd.Flags().StringVar(&personasOutputDir, "output", "test-results", "Results directory")
+	testerPersonasCmd.Flags().IntVar(&personasBatches, "batches", 30, "Number of recent batches to compare")
+	testerPersonasCmd.Flags().StringVar(&personasScenario, "scenario", "", "Only report this scenario")
+	testerPersonasCmd.Flags().StringVar(&testerEnv, "env", "staging", "Environment of the compared batches")
+	testerPersonasCmd.Flags().BoolVar(&testerJSON, "json", false, "Output as JSON")
+
+	testerCmd.AddCommand(testerPersonasCmd)
+}
+
+func runTesterPersonas(cmd *cobra.Command, args []string) error {
+	if personasBatches < 1 {
+		return fmt.Errorf("--batches must be positive")
+	}
+
+	batches, err := batch.LoadRecentBatches(personasOutputDir, testerEnv, personasBatches)
+	if err != nil {
+		return err
+	}
+	report := batch.ComparePersonas(batches)
+	if personasScenario != "" {
+		var kept []batch.PersonaScenario
+		report.Affected = 0
+		for _, s := range report.Scenarios {
+			if s.Scenario == personasScenario {
+				kept = append(kept, s)
+				if len(s.Affected) > 0 {
+					report.Affected++
+				}
+			}
+		}
+		report.Scenarios = kept
+	}
+
+	if testerJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	}
+
+	if len(report.Scenarios) == 0 {
+		fmt.Printf("No scenarios ran as more than one persona in the last %d batches (%s).\n", len(batches), testerEnv)
+		fmt.Printf("  %s\n", style.Dim.Render("Run batches with --rotate-personas over scenarios with a persona_pool"))
+		return nil
+	}
+
+	fmt.Printf("\n%s %d batches in %s, %s to %s\n", style.Bold.Render("Persona Comparison:"),
+		len(report.BatchIDs), testerEnv, report.Since.Format("2006-01-02"), report.Until.Format("2006-01-02"))
+
+	for _, s := range report.Scenarios {
+		fmt.Printf("\n  %s\n", s.Scenario)
+		for _, p := range s.Personas {
+			icon := ui.RenderPassIcon()
+			if slices.Contains(s.Affected, p.Persona) {
+				icon = ui.RenderWarnIcon()
+			}
+			fmt.Printf("    %s %-16s passed %d/%d (%.0f%%)  %.1f obs/run %s\n", icon, p.Persona,
+				p.Passed, p.Runs, p.PassRate*100, p.AvgObservations,
+				style.Dim.Render("last "+p.LastRun.Format("2006-01-02")))
+		}
+	}
+
+	if report.Affected > 0 {
+		var flagged []string
+		for _, s := range report.Scenarios {
+			if len(s.Affected) > 0 {
+				flagged = append(flagged, fmt.Sprintf("%s (%s)", s.Scenario, strings.Join(s.Affected, ", ")))
+			}
+		}
+		fmt.Printf("\n%s Persona-specific issues in %d scenarios: %s\n",
+			ui.RenderWarnIcon(), report.Affected, strings.Join(flagged, "; "))
+	}
+	return nil
+}
@@ -32,7 +32,9 @@ This command verifies that the testing environment is properly configured:
   - API health endpoints
   - Disk space for artifacts
 
-Use --fix to attempt automatic fixes for common issues.
+Use --fix to attempt automatic fixes for common issues. When runs fail
+with errors the preflight doesn't explain, 'gt tester doctor' digs deeper
+into the Playwright and browser stack.
 
 Examples:
   gt tester preflight                # Run all checks
@@ -45,7 +47,7 @@ Examples:
 // PreflightCheck represents a single preflight check result
 type PreflightCheck struct {
 	Name    string `json:"name"`
-	Status  string `json:"status"` // "pass", "warn", "fail"
+	Status  string `json:"status"` // "pass", "warn", "fail", "skip"
 	Message string `json:"message,omitempty"`
 	Details string `json:"details,omitempty"`
 	Fix     string `json:"fix,omitempty"`
@@ -102,14 +104,39 @@ func runTesterPreflight(cmd *cobra.Command, args []string) error {
 
 	// Human-readable output
 	fmt.Printf("\n%s (%s)\n\n", style.Bold.Render("Preflight Checks"), testerEnv)
+	printPreflightChecks(result.Checks)
 
-	for _, c := range result.Checks {
+	fmt.Println()
+
+	if result.AllPassed && result.Warnings == 0 {
+		fmt.Printf("%s All checks passed. Ready to run tests.\n", ui.RenderPassIcon())
+	} else if result.AllPassed {
+		fmt.Printf("%s %d warning(s). Tests can run but may have issues.\n",
+			ui.RenderWarnIcon(), result.Warnings)
+	} else {
+		fmt.Printf("%s %d check(s) failed, %d warning(s). Fix issues before running tests.\n",
+			ui.RenderFailIcon(), result.Failures, result.Warnings)
+	}
+
+	if !result.AllPassed {
+		return NewSilentExit(4) // Exit code 4 for preflight failure
+	}
+
+	return nil
+}
+
+// printPreflightChecks prints check results with their details and, for
+// checks that didn't pass, how to fix them.
+func printPreflightChecks(checks []PreflightCheck) {
+	for _, c := range checks {
 		icon := ui.RenderPassIcon()
 		switch c.Status {
 		case "warn":
 			icon = ui.RenderWarnIcon()
 		case "fail":
 			icon = ui.RenderFailIcon()
+		case "skip":
+			icon = ui.RenderSkipIcon()
 		}
 
 		fmt.Printf("  %s %s", icon, c.Name)
@@ -125,24 +152,6 @@ func runTesterPreflight(cmd *cobra.Command, args []string) error {
 			fmt.Printf("    Fix: %s\n", c.Fix)
 		}
 	}
-
-	fmt.Println()
-
-	if result.AllPassed && result.Warnings == 0 {
-		fmt.Printf("%s All checks passed. Ready to run tests.\n", ui.RenderPassIcon())
-	} else if result.AllPassed {
-		fmt.Printf("%s %d warning(s). Tests can run but may have issues.\n",
-			ui.RenderWarnIcon(), result.Warnings)
-	} else {
-		fmt.Printf("%s %d check(s) failed, %d warning(s). Fix issues before running tests.\n",
-			ui.RenderFailIcon(), result.Failures, result.Warnings)
-	}
-
-	if !result.AllPassed {
-		return NewSilentExit(4) // Exit code 4 for preflight failure
-	}
-
-	return nil
 }
 
 // checkPlaywright verifies Playwright is installed
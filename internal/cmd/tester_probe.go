@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/tester"
+	"github.com/steveyegge/gastown/internal/ui"
+)
+
+var probeTimeout int
+
+var testerProbeCmd = &cobra.Command{
+	Use:   "probe <scenario.yaml>",
+	Short: "Check a scenario's wait strategies against the live target",
+	Long: `Load a scenario's target URL and report whether each configured wait
+strategy resolves, and how long it takes.
+
+Each strategy is timed from navigation start in a headless browser:
+  network_idle        No network requests for 500ms
+  animation_complete  No running CSS animations or transitions
+  custom_selectors    Each selector appears on the page
+  min_load_time       Shown for comparison with the other strategies
+
+Use this to tune wait_strategies before full runs: strategies that never
+resolve cause timeouts, and an oversized min_load_time slows every run.
+Requires Node.js with the playwright package installed.
+
+Examples:
+  gt tester probe scenarios/signup.yaml
+  gt tester probe scenarios/signup.yaml --timeout 60
+  gt tester probe scenarios/signup.yaml --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTesterProbe,
+}
+
+func init() {
+	testerProbeCmd.Flags().IntVar(&probeTimeout, "timeout", 30, "Per-strategy timeout (seconds)")
+	testerProbeCmd.Flags().BoolVar(&testerJSON, "json", false, "Output as JSON")
+
+	testerCmd.AddCommand(testerProbeCmd)
+}
+
+func runTesterProbe(cmd *cobra.Command, args []string) error {
+	scenario, err := loadScenario(args[0])
+	if err != nil {
+		return fmt.Errorf("loading scenario: %w", err)
+	}
+
+	if !testerJSON {
+		fmt.Printf("\n%s %s\n", style.Bold.Render("Probing:"), scenario.Scenario)
+		fmt.Printf("  URL: %s\n", scenario.Environment.URL)
+		if scenario.Network != nil {
+			fmt.Printf("  Network: %s\n", scenario.Network.Describe())
+		}
+		fmt.Println()
+	}
+
+	result, err := tester.ProbeWaitStrategies(context.Background(), scenario, time.Duration(probeTimeout)*time.Second)
+	if err != nil {
+		return err
+	}
+
+	if testerJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(result)
+	}
+
+	fmt.Printf("  Response: HTTP %d, load event at %dms\n\n", result.Status, result.LoadMs)
+
+	unresolved := 0
+	for _, p := range result.Strategies {
+		icon := ui.RenderPassIcon()
+		status := fmt.Sprintf("%dms", p.DurationMs)
+		if !p.Resolved {
+			icon = ui.RenderFailIcon()
+			status = fmt.Sprintf("not resolved after %dms", p.DurationMs)
+			unresolved++
+		}
+		name := p.Strategy
+		if p.Target != "" {
+			name += " " + p.Target
+		}
+		fmt.Printf("  %s %-40s %s\n", icon, name, status)
+		if p.Error != "" {
+			fmt.Printf("    %s\n", ui.RenderMuted(p.Error))
+		}
+	}
+
+	if len(result.Advice) > 0 {
+		fmt.Printf("\n%s\n", style.Bold.Render("Suggestions"))
+		for _, a := range result.Advice {
+			fmt.Printf("  %s %s\n", ui.RenderWarnIcon(), a)
+		}
+	}
+	fmt.Println()
+
+	if unresolved > 0 {
+		return NewSilentExit(1)
+	}
+	return nil
+}
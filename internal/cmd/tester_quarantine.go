@@ -5,16 +5,28 @@ import (
 	"fmt"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/gastown/internal/tester/flake"
 )
 
 var (
-	quarantineReason     string
-	quarantineOutputDir  string
-	quarantineShowAll    bool
-	quarantineClearHist  bool
+	quarantineReason      string
+	quarantineOutputDir   string
+	quarantineShowAll     bool
+	quarantineClearHist   bool
+	quarantineTrendsBuild string
+	quarantineApplyDryRun bool
+	quarantineApplyPrune  bool
+
+	// Quarantine review flags
+	quarantineReviewApprove  bool
+	quarantineReviewReject   bool
+	quarantineReviewOwner    string
+	quarantineReviewExpires  string
+	quarantineReviewNote     string
+	quarantineReviewReviewer string
 )
 
 var testerQuarantineCmd = &cobra.Command{
@@ -32,13 +44,41 @@ SUBCOMMANDS:
   remove   Remove a test from quarantine
   status   Show flake metrics for a test
   flaky    List all flaky tests (not yet quarantined)
+  trends   Show failure rates by app build
+  apply    Apply quarantine changes from a file
+  review   Approve or reject quarantines awaiting review
 
 Examples:
   gt tester quarantine list
   gt tester quarantine add registration-flow --reason "Flaky login button"
   gt tester quarantine remove registration-flow
   gt tester quarantine status registration-flow
-  gt tester quarantine flaky`,
+  gt tester quarantine flaky
+  gt tester quarantine trends --build 1.4.2
+  gt tester quarantine apply quarantine.yaml --dry-run
+  gt tester quarantine review checkout-flow --approve --owner alice --expires 2026-11-01
+
+Flake detection is configured by <output>/.flake-config.yaml (defaults apply
+when absent). Runbook rules attach a triage link to auto-quarantines, and a
+webhook can be notified when they fire:
+
+  flake_threshold: 0.3
+  webhook_url: https://hooks.slack.com/services/...
+  default_runbook: https://wiki.example.com/qa/flaky-tests
+  runbooks:
+    - tag: checkout
+      url: https://wiki.example.com/qa/checkout#{scenario}
+    - scenario_prefix: registration-
+      url: https://wiki.example.com/qa/registration
+
+Set exclude_infra_errors: true to keep infrastructure errors (timeouts,
+browser crashes, network errors) out of quarantine decisions. They are still
+recorded and shown as a separate infra error rate.
+
+Set file_beads: true to file a tracking bead (labeled gt:flaky-test) for each
+quarantined or flagged test instead of relying on batch summaries. The bead
+holds the test's metrics and recent failure excerpts, is updated rather than
+duplicated when the test flakes again, and is closed on auto-unquarantine.`,
 	RunE: requireSubcommand,
 }
 
@@ -128,6 +168,88 @@ Examples:
 	RunE: runQuarantineClear,
 }
 
+var quarantineTrendsCmd = &cobra.Command{
+	Use:   "trends [scenario]",
+	Short: "Show failure rates by app build",
+	Long: `Show how each test's failure rate changed across app builds.
+
+Builds come from the --build-sha and --app-version flags of
+'gt tester batch'; runs recorded without them are grouped as "unknown".
+
+With --build, answers "did flakiness start with build X": each test's
+failure rate on that build is compared with its rate before the build was
+first tested. Tests that were under the flake threshold before and at or
+over it on the build are reported as starting with it - likely an app
+regression rather than test rot. The build may be an app version or a
+commit SHA (prefix).
+
+Examples:
+  gt tester quarantine trends                      # All tests, by build
+  gt tester quarantine trends checkout-flow        # One test
+  gt tester quarantine trends --build 1.4.2        # Did flakiness start with 1.4.2?
+  gt tester quarantine trends --build abc1234 --json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runQuarantineTrends,
+}
+
+var quarantineApplyCmd = &cobra.Command{
+	Use:   "apply <file.yaml>",
+	Short: "Apply quarantine changes from a file",
+	Long: `Declaratively add, update and remove quarantine entries from a file,
+so quarantine state can live in version control and be reviewed.
+
+All changes are validated first and saved together; if any entry is invalid,
+nothing is changed. The changes made are printed as a diff.
+
+  quarantine:
+    - scenario: checkout-flow
+      reason: Payment sandbox times out (PAY-123)
+      owner: alice
+      expires: 2026-11-01        # YYYY-MM-DD or RFC 3339; optional
+  remove:
+    - registration-flow
+
+Listed scenarios are quarantined, or updated if already quarantined (an
+auto-quarantined entry becomes a manual one). Expired entries stay listed
+but no longer cause the scenario to be skipped.
+
+With --prune, manual entries not listed in the file are removed as well, so
+the file is the complete set of manual quarantines. Auto-quarantined entries
+are only removed when listed under remove.
+
+Examples:
+  gt tester quarantine apply quarantine.yaml --dry-run
+  gt tester quarantine apply quarantine.yaml
+  gt tester quarantine apply quarantine.yaml --prune --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runQuarantineApply,
+}
+
+var quarantineReviewCmd = &cobra.Command{
+	Use:   "review [scenario]",
+	Short: "Approve or reject quarantines awaiting review",
+	Long: `Review quarantine entries that need a human decision.
+
+Auto-quarantined tests are flagged for review. With no arguments, lists the
+entries awaiting review, oldest first. With a scenario, records a decision:
+
+  --approve   Keep the test quarantined. Requires --owner and --expires, so
+              every approved quarantine has someone fixing it and lapses if
+              they don't.
+  --reject    Unquarantine the test so it runs in batches again. If it keeps
+              failing it may be auto-quarantined (and flagged) again.
+
+The reviewer (your mail identity, or --reviewer) and the time are recorded
+on the entry along with the decision.
+
+Examples:
+  gt tester quarantine review
+  gt tester quarantine review checkout-flow --approve --owner alice --expires 2026-11-01
+  gt tester quarantine review search --reject --note "Fixed by PAY-123"`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runQuarantineReview,
+}
+
 func init() {
 	// Quarantine add flags
 	quarantineAddCmd.Flags().StringVarP(&quarantineReason, "reason", "r", "", "Reason for quarantining (required)")
@@ -136,6 +258,22 @@ func init() {
 	// Quarantine status flags
 	quarantineStatusCmd.Flags().BoolVar(&quarantineShowAll, "all", false, "Show all tracked scenarios (including stable)")
 
+	// Quarantine trends flags
+	quarantineTrendsCmd.Flags().StringVar(&quarantineTrendsBuild, "build", "", "Check whether flakiness started with this build (app version or SHA)")
+
+	// Quarantine apply flags
+	quarantineApplyCmd.Flags().BoolVar(&quarantineApplyDryRun, "dry-run", false, "Show the changes without saving them")
+	quarantineApplyCmd.Flags().BoolVar(&quarantineApplyPrune, "prune", false, "Remove manual entries not listed in the file")
+
+	// Quarantine review flags
+	quarantineReviewCmd.Flags().BoolVar(&quarantineReviewApprove, "approve", false, "Keep the test quarantined (requires --owner and --expires)")
+	quarantineReviewCmd.Flags().BoolVar(&quarantineReviewReject, "reject", false, "Unquarantine the test")
+	quarantineReviewCmd.Flags().StringVar(&quarantineReviewOwner, "owner", "", "Who is responsible for fixing the test")
+	quarantineReviewCmd.Flags().StringVar(&quarantineReviewExpires, "expires", "", "When the approved quarantine lapses (YYYY-MM-DD or RFC 3339)")
+	quarantineReviewCmd.Flags().StringVar(&quarantineReviewNote, "note", "", "Note to add to the entry")
+	quarantineReviewCmd.Flags().StringVar(&quarantineReviewReviewer, "reviewer", "", "Reviewer identity (default: your mail identity)")
+	quarantineReviewCmd.MarkFlagsMutuallyExclusive("approve", "reject")
+
 	// Global flags
 	testerQuarantineCmd.PersistentFlags().StringVar(&quarantineOutputDir, "output", "test-results", "Output directory for flake data")
 
@@ -146,13 +284,25 @@ func init() {
 	testerQuarantineCmd.AddCommand(quarantineStatusCmd)
 	testerQuarantineCmd.AddCommand(quarantineFlakyCmd)
 	testerQuarantineCmd.AddCommand(quarantineClearCmd)
+	testerQuarantineCmd.AddCommand(quarantineTrendsCmd)
+	testerQuarantineCmd.AddCommand(quarantineApplyCmd)
+	testerQuarantineCmd.AddCommand(quarantineReviewCmd)
 
 	testerCmd.AddCommand(testerQuarantineCmd)
 }
 
 func getDetector() (*flake.Detector, error) {
-	storagePath := filepath.Join(quarantineOutputDir, ".flake-data.json")
-	return flake.NewDetector(storagePath, flake.DefaultConfig())
+	return detectorFor(quarantineOutputDir)
+}
+
+// detectorFor opens the flake detector for a results directory.
+func detectorFor(outputDir string) (*flake.Detector, error) {
+	storagePath := filepath.Join(outputDir, flake.DataFileName)
+	config, err := flake.LoadConfig(filepath.Join(outputDir, flake.ConfigFileName))
+	if err != nil {
+		return nil, err
+	}
+	return flake.NewDetector(storagePath, config)
 }
 
 func runQuarantineList(cmd *cobra.Command, args []string) error {
@@ -187,16 +337,31 @@ func runQuarantineList(cmd *cobra.Command, args []string) error {
 		if entry.ReviewRequired {
 			reviewTag = " (needs review)"
 		}
+		if entry.Expired(time.Now()) {
+			reviewTag += " (expired)"
+		}
 
 		fmt.Printf("  %s%s%s\n", entry.Scenario, autoTag, reviewTag)
 		fmt.Printf("    Quarantined: %s\n", entry.QuarantinedAt.Format("2006-01-02 15:04"))
 		fmt.Printf("    Reason: %s\n", entry.Reason)
+		if entry.Owner != "" {
+			fmt.Printf("    Owner: %s\n", entry.Owner)
+		}
+		if entry.ExpiresAt != nil {
+			fmt.Printf("    Expires: %s\n", entry.ExpiresAt.Format("2006-01-02 15:04"))
+		}
 		if entry.FlakeRate > 0 {
 			fmt.Printf("    Flake rate: %.0f%%\n", entry.FlakeRate*100)
 		}
 		if entry.Notes != "" {
 			fmt.Printf("    Notes: %s\n", entry.Notes)
 		}
+		if entry.Runbook != "" {
+			fmt.Printf("    Runbook: %s\n", entry.Runbook)
+		}
+		if entry.ReviewedAt != nil {
+			fmt.Printf("    Reviewed: %s by %s (%s)\n", entry.ReviewedAt.Format("2006-01-02 15:04"), entry.ReviewedBy, entry.ReviewDecision)
+		}
 		fmt.Println()
 	}
 
@@ -248,6 +413,157 @@ func runQuarantineRemove(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func runQuarantineApply(cmd *cobra.Command, args []string) error {
+	file, err := flake.LoadQuarantineFile(args[0])
+	if err != nil {
+		return err
+	}
+
+	detector, err := getDetector()
+	if err != nil {
+		return fmt.Errorf("failed to initialize flake detector: %w", err)
+	}
+
+	changes, err := detector.ApplyQuarantine(file, quarantineApplyPrune, quarantineApplyDryRun)
+	if err != nil {
+		return fmt.Errorf("failed to apply quarantine file: %w", err)
+	}
+
+	if testerJSON {
+		data, _ := json.MarshalIndent(changes, "", "  ")
+		fmt.Println(string(data))
+		return nil
+	}
+
+	if len(changes) == 0 {
+		fmt.Println("Quarantine is up to date")
+		return nil
+	}
+
+	var added, updated, removed int
+	for _, c := range changes {
+		switch c.Action {
+		case flake.ChangeAdd:
+			added++
+			fmt.Printf("+ %s\n", c.Scenario)
+			fmt.Printf("    reason: %s\n", c.Entry.Reason)
+			if c.Entry.Owner != "" {
+				fmt.Printf("    owner: %s\n", c.Entry.Owner)
+			}
+			if c.Entry.ExpiresAt != nil {
+				fmt.Printf("    expires: %s\n", c.Entry.ExpiresAt.Format("2006-01-02 15:04"))
+			}
+		case flake.ChangeUpdate:
+			updated++
+			fmt.Printf("~ %s\n", c.Scenario)
+			for _, field := range c.Fields {
+				fmt.Printf("    %s\n", field)
+			}
+		case flake.ChangeRemove:
+			removed++
+			fmt.Printf("- %s\n", c.Scenario)
+		}
+	}
+
+	verb := "Applied"
+	if quarantineApplyDryRun {
+		verb = "Would apply"
+	}
+	fmt.Printf("\n%s: %d added, %d updated, %d removed\n", verb, added, updated, removed)
+	return nil
+}
+
+func runQuarantineReview(cmd *cobra.Command, args []string) error {
+	detector, err := getDetector()
+	if err != nil {
+		return fmt.Errorf("failed to initialize flake detector: %w", err)
+	}
+
+	if len(args) == 0 {
+		if quarantineReviewApprove || quarantineReviewReject {
+			return fmt.Errorf("specify the scenario to review")
+		}
+		return listPendingReview(detector)
+	}
+	if !quarantineReviewApprove && !quarantineReviewReject {
+		return fmt.Errorf("specify --approve or --reject")
+	}
+
+	expiresAt, err := flake.ParseExpiry(quarantineReviewExpires)
+	if err != nil {
+		return err
+	}
+	reviewer := quarantineReviewReviewer
+	if reviewer == "" {
+		reviewer = detectSender()
+	}
+
+	scenario := args[0]
+	entry, err := detector.ReviewQuarantine(scenario, flake.QuarantineReview{
+		Reviewer:  reviewer,
+		Approve:   quarantineReviewApprove,
+		Owner:     quarantineReviewOwner,
+		ExpiresAt: expiresAt,
+		Notes:     quarantineReviewNote,
+	})
+	if err != nil {
+		return err
+	}
+
+	if testerJSON {
+		data, _ := json.MarshalIndent(entry, "", "  ")
+		fmt.Println(string(data))
+		return nil
+	}
+
+	if entry.ReviewDecision == flake.ReviewApproved {
+		fmt.Printf("Approved quarantine: %s\n", scenario)
+		fmt.Printf("  Owner: %s\n", entry.Owner)
+		fmt.Printf("  Expires: %s\n", entry.ExpiresAt.Format("2006-01-02 15:04"))
+	} else {
+		fmt.Printf("Rejected quarantine: %s\n", scenario)
+		fmt.Println("  This test will now run in batch executions.")
+	}
+	fmt.Printf("  Reviewed by: %s\n", entry.ReviewedBy)
+	return nil
+}
+
+// listPendingReview prints the quarantine entries awaiting review.
+func listPendingReview(detector *flake.Detector) error {
+	entries := detector.PendingReview()
+
+	if testerJSON {
+		data, _ := json.MarshalIndent(entries, "", "  ")
+		fmt.Println(string(data))
+		return nil
+	}
+
+	if len(entries) == 0 {
+		fmt.Println("No quarantines awaiting review")
+		return nil
+	}
+
+	fmt.Printf("Awaiting Review (%d)\n", len(entries))
+	fmt.Println(strings.Repeat("─", 60))
+	for _, entry := range entries {
+		fmt.Printf("  %s\n", entry.Scenario)
+		fmt.Printf("    Quarantined: %s\n", entry.QuarantinedAt.Format("2006-01-02 15:04"))
+		fmt.Printf("    Reason: %s\n", entry.Reason)
+		if entry.FlakeRate > 0 {
+			fmt.Printf("    Flake rate: %.0f%%\n", entry.FlakeRate*100)
+		}
+		if entry.Runbook != "" {
+			fmt.Printf("    Runbook: %s\n", entry.Runbook)
+		}
+		fmt.Println()
+	}
+
+	fmt.Println("To review a quarantine:")
+	fmt.Println("  gt tester quarantine review <scenario> --approve --owner <who> --expires <YYYY-MM-DD>")
+	fmt.Println("  gt tester quarantine review <scenario> --reject")
+	return nil
+}
+
 func runQuarantineStatus(cmd *cobra.Command, args []string) error {
 	detector, err := getDetector()
 	if err != nil {
@@ -315,6 +631,12 @@ func showScenarioStatus(detector *flake.Detector, scenario string) error {
 				"first_run":     history.FirstRun,
 				"last_run":      history.LastRun,
 			}
+			if history.TrackingBead != "" {
+				data["tracking_bead"] = history.TrackingBead
+			}
+			if len(history.Reviews) > 0 {
+				data["reviews"] = history.Reviews
+			}
 		}
 		output, _ := json.MarshalIndent(data, "", "  ")
 		fmt.Println(string(output))
@@ -341,6 +663,11 @@ func showScenarioStatus(detector *flake.Detector, scenario string) error {
 		}
 		if entry.ReviewRequired {
 			fmt.Println("  Review: Required")
+		} else if entry.ReviewedAt != nil {
+			fmt.Printf("  Review: %s by %s on %s\n", entry.ReviewDecision, entry.ReviewedBy, entry.ReviewedAt.Format("2006-01-02 15:04"))
+		}
+		if entry.Runbook != "" {
+			fmt.Printf("  Runbook: %s\n", entry.Runbook)
 		}
 		fmt.Println()
 	} else if metrics.IsFlaky {
@@ -350,13 +677,31 @@ func showScenarioStatus(detector *flake.Detector, scenario string) error {
 		fmt.Println("Status: Stable")
 		fmt.Println()
 	}
+	if history.TrackingBead != "" {
+		fmt.Printf("Tracking bead: %s\n\n", history.TrackingBead)
+	}
+	if len(history.Reviews) > 0 {
+		fmt.Println("Reviews:")
+		for _, r := range history.Reviews {
+			fmt.Printf("  %s  %s by %s\n", r.ReviewedAt.Format("2006-01-02 15:04"), r.Decision, r.Reviewer)
+		}
+		fmt.Println()
+	}
 
 	// Metrics
-	fmt.Println("Window Metrics:")
+	fmt.Printf("Window Metrics (%s):\n", metrics.Window)
+	failed := metrics.WindowFailures + metrics.WindowErrors
+	if metrics.ScoredRuns < metrics.WindowRuns {
+		failed = metrics.WindowFailures
+	}
 	fmt.Printf("  Flake rate: %.0f%% (%d/%d failed)\n",
-		metrics.FlakeRate*100, metrics.WindowFailures+metrics.WindowErrors, metrics.WindowRuns)
+		metrics.FlakeRate*100, failed, metrics.ScoredRuns)
 	fmt.Printf("  Success rate: %.0f%% (%d/%d passed)\n",
-		metrics.SuccessRate*100, metrics.WindowPasses, metrics.WindowRuns)
+		metrics.SuccessRate*100, metrics.WindowPasses, metrics.ScoredRuns)
+	if metrics.WindowErrors > 0 {
+		fmt.Printf("  Infra error rate: %.0f%% (%d/%d runs)\n",
+			metrics.InfraErrorRate*100, metrics.WindowErrors, metrics.WindowRuns)
+	}
 	fmt.Printf("  Average retries: %.1f\n", metrics.AverageRetries)
 	if metrics.AverageDuration > 0 {
 		fmt.Printf("  Average duration: %s\n", formatDuration(metrics.AverageDuration))
@@ -410,6 +755,9 @@ func showScenarioStatus(detector *flake.Detector, scenario string) error {
 		if run.Duration > 0 {
 			line += fmt.Sprintf(" [%s]", formatDuration(run.Duration))
 		}
+		if label := run.BuildLabel(); label != flake.UnknownBuild {
+			line += " build " + label
+		}
 		fmt.Println(line)
 	}
 
@@ -482,6 +830,104 @@ func runQuarantineClear(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func runQuarantineTrends(cmd *cobra.Command, args []string) error {
+	detector, err := getDetector()
+	if err != nil {
+		return fmt.Errorf("failed to initialize flake detector: %w", err)
+	}
+
+	var scenarios []string
+	if len(args) == 1 {
+		if detector.GetHistory(args[0]) == nil {
+			return fmt.Errorf("no history found for scenario %q", args[0])
+		}
+		scenarios = args
+	} else {
+		for _, m := range detector.GetAllMetrics() {
+			scenarios = append(scenarios, m.Scenario)
+		}
+	}
+
+	if quarantineTrendsBuild != "" {
+		return showBuildOnsets(detector, scenarios, quarantineTrendsBuild)
+	}
+
+	trends := make(map[string][]flake.BuildStats, len(scenarios))
+	for _, scenario := range scenarios {
+		trends[scenario] = detector.BuildTrend(scenario)
+	}
+
+	if testerJSON {
+		data, _ := json.MarshalIndent(trends, "", "  ")
+		fmt.Println(string(data))
+		return nil
+	}
+
+	if len(scenarios) == 0 {
+		fmt.Println("No test history tracked yet")
+		return nil
+	}
+
+	fmt.Printf("Failure Rate by Build (%d scenarios)\n", len(scenarios))
+	fmt.Println(strings.Repeat("─", 60))
+	for _, scenario := range scenarios {
+		fmt.Printf("  %s\n", scenario)
+		for _, b := range trends[scenario] {
+			fmt.Printf("    %-24s %3.0f%% failed  (%d runs, first %s)\n",
+				b.Build, b.FailureRate*100, b.Runs, b.FirstRun.Format("01-02 15:04"))
+		}
+		fmt.Println()
+	}
+	return nil
+}
+
+// showBuildOnsets reports which scenarios became flaky with build.
+func showBuildOnsets(detector *flake.Detector, scenarios []string, build string) error {
+	var onsets []*flake.BuildOnset
+	for _, scenario := range scenarios {
+		o, err := detector.BuildOnset(scenario, build)
+		if err != nil {
+			continue // not run against this build
+		}
+		onsets = append(onsets, o)
+	}
+
+	if testerJSON {
+		data, _ := json.MarshalIndent(onsets, "", "  ")
+		fmt.Println(string(data))
+		return nil
+	}
+
+	if len(onsets) == 0 {
+		return fmt.Errorf("no runs recorded against build %s (record builds with gt tester batch --build-sha/--app-version)", build)
+	}
+
+	started := 0
+	fmt.Printf("Flakiness vs Build %s (%d scenarios ran on it)\n", build, len(onsets))
+	fmt.Println(strings.Repeat("─", 60))
+	for _, o := range onsets {
+		verdict := ""
+		switch {
+		case o.Started:
+			verdict = " [STARTED WITH THIS BUILD]"
+			started++
+		case o.Before.Runs == 0:
+			verdict = " (no earlier runs)"
+		}
+		fmt.Printf("  %s%s\n", o.Scenario, verdict)
+		fmt.Printf("    Before: %.0f%% failed (%d runs) | On %s: %.0f%% failed (%d runs)\n",
+			o.Before.FailureRate*100, o.Before.Runs, build, o.On.FailureRate*100, o.On.Runs)
+	}
+	fmt.Println()
+
+	if started == 0 {
+		fmt.Printf("No tests became flaky with build %s.\n", build)
+	} else {
+		fmt.Printf("%d test(s) became flaky with build %s - likely an app regression.\n", started, build)
+	}
+	return nil
+}
+
 func printMetricsSummary(m *flake.FlakeMetrics, isQuarantined bool) {
 	status := ""
 	if isQuarantined {
@@ -491,8 +937,11 @@ func printMetricsSummary(m *flake.FlakeMetrics, isQuarantined bool) {
 	}
 
 	fmt.Printf("  %s%s\n", m.Scenario, status)
-	fmt.Printf("    Flake rate: %.0f%% | Success: %.0f%% | Runs: %d\n",
-		m.FlakeRate*100, m.SuccessRate*100, m.WindowRuns)
+	fmt.Printf("    Flake rate: %.0f%% | Success: %.0f%% | Runs: %d (%s)\n",
+		m.FlakeRate*100, m.SuccessRate*100, m.WindowRuns, m.Window)
+	if m.WindowErrors > 0 {
+		fmt.Printf("    Infra error rate: %.0f%%\n", m.InfraErrorRate*100)
+	}
 
 	if m.ConsecutiveFailures > 0 {
 		fmt.Printf("    Consecutive failures: %d\n", m.ConsecutiveFailures)
@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/tester"
+	"github.com/steveyegge/gastown/internal/ui"
+)
+
+// Rerun command flags
+var rerunOutput string
+
+var testerRerunCmd = &cobra.Command{
+	Use:   "rerun <run-dir>",
+	Short: "Re-run a scenario exactly as a previous run",
+	Long: `Re-run a scenario from the provenance recorded in a run's observations.json.
+
+The scenario is replayed from the content saved with the run, not the file
+on disk, with the same model, environment, timeout, retries, recording and
+accessibility settings. A warning is printed if the scenario file has since
+changed or the run was made by a different gt version.
+
+The recorded scenario is written to scenario.yaml in the new run directory,
+which defaults to <run-dir>-rerun.
+
+Examples:
+  gt tester rerun test-results/2026-03-07/signup/run-001
+  gt tester rerun test-results/2026-03-07/signup/run-001 --output /tmp/signup-rerun`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTesterRerun,
+}
+
+func init() {
+	testerRerunCmd.Flags().StringVar(&rerunOutput, "output", "", "Output directory for the new run (default: <run-dir>-rerun)")
+	testerRerunCmd.Flags().BoolVar(&testerSkipPreflight, "skip-preflight", false, "Skip environment preflight checks")
+	testerRerunCmd.Flags().BoolVar(&testerVerbose, "verbose", false, "Show agent output in real-time")
+	testerRerunCmd.Flags().BoolVar(&testerJSON, "json", false, "Output result as JSON")
+
+	testerCmd.AddCommand(testerRerunCmd)
+}
+
+func runTesterRerun(cmd *cobra.Command, args []string) error {
+	runDir := filepath.Clean(args[0])
+	prev, err := LoadObservationResult(filepath.Join(runDir, "observations.json"))
+	if err != nil {
+		return fmt.Errorf("loading run %s: %w", runDir, err)
+	}
+	p := prev.Provenance
+	if p == nil {
+		return fmt.Errorf("run %s has no provenance (recorded by an older gt)", runDir)
+	}
+
+	if tester.HashScenario([]byte(p.ScenarioContent)) != p.ScenarioHash {
+		return fmt.Errorf("run %s: recorded scenario does not match its hash", runDir)
+	}
+
+	if p.ScenarioChanged() {
+		fmt.Printf("%s %s changed since the run; re-running the recorded version\n", ui.RenderWarnIcon(), p.ScenarioFile)
+	}
+	if current := versionString(); p.GastownVersion != current {
+		fmt.Printf("%s Run was made with gt %s, re-running with %s\n", ui.RenderWarnIcon(), p.GastownVersion, current)
+	}
+
+	outputDir := rerunOutput
+	if outputDir == "" {
+		outputDir = strings.TrimSuffix(runDir, string(filepath.Separator)) + "-rerun"
+	}
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return fmt.Errorf("creating output directory: %w", err)
+	}
+	scenarioPath := filepath.Join(outputDir, "scenario.yaml")
+	if err := os.WriteFile(scenarioPath, []byte(p.ScenarioContent), 0644); err != nil {
+		return fmt.Errorf("writing recorded scenario: %w", err)
+	}
+
+	// Replay the resolved settings through the run flags
+	s := p.Settings
+	runModel = s.Model
+	testerEnv = s.Environment
+	runRetry, runNoRetry = s.MaxAttempts, false
+	runTimeout = s.TimeoutSeconds
+	runHeaded = s.Headed
+	runNoVideo, runNoTrace = !s.Video, !s.Trace
+	runA11y = s.A11y
+	runOutput = outputDir
+
+	return runScenarioFile(scenarioPath, p.Overrides, runDir)
+}
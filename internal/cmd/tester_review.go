@@ -44,7 +44,8 @@ Examples:
   gt tester review --scenario signup    # Filter by scenario
   gt tester review --date 2026-01-15    # Filter by date
   gt tester review --validate 1         # Validate observation #1
-  gt tester review --false-positive 2   # Mark #2 as false positive`,
+  gt tester review --false-positive 2   # Mark #2 as false positive
+  gt tester review stats                # False-positive rates by persona/model`,
 	RunE: runTesterReview,
 }
 
@@ -0,0 +1,232 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/ui"
+)
+
+// Review stats command flags
+var (
+	reviewStatsSince       string
+	reviewStatsMinReviewed int
+	reviewStatsThreshold   float64
+)
+
+var testerReviewStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show false-positive rates from review decisions",
+	Long: `Show how often reviewed observations turned out to be false positives.
+
+Review decisions (gt tester review --validate / --false-positive) are
+aggregated into false-positive rates per scenario, observation type,
+confidence level, and persona/model combination. Combinations whose rate
+is at or above --threshold are flagged as over-reporting: their
+observations are good candidates for lower confidence or severity.
+
+Rates only count reviewed observations, and groups with fewer than
+--min-reviewed reviews are not flagged.
+
+Examples:
+  gt tester review stats
+  gt tester review stats --since 30d
+  gt tester review stats --threshold 0.25 --min-reviewed 10 --json`,
+	RunE: runTesterReviewStats,
+}
+
+func init() {
+	testerReviewStatsCmd.Flags().StringVar(&reviewStatsSince, "since", "", "Only include runs started within this window (e.g., 30d, 12h)")
+	testerReviewStatsCmd.Flags().IntVar(&reviewStatsMinReviewed, "min-reviewed", 5, "Minimum reviewed observations before a group is flagged")
+	testerReviewStatsCmd.Flags().Float64Var(&reviewStatsThreshold, "threshold", 0.3, "False-positive rate at which a group is flagged as over-reporting")
+	testerReviewStatsCmd.Flags().StringVar(&reviewResultsDir, "results-dir", "test-results", "Test results directory")
+	testerReviewStatsCmd.Flags().BoolVar(&testerJSON, "json", false, "Output as JSON")
+
+	testerReviewCmd.AddCommand(testerReviewStatsCmd)
+}
+
+// ReviewStat is the review outcome tally for one group of observations.
+type ReviewStat struct {
+	Key               string  `json:"key"`
+	Total             int     `json:"total"`
+	Reviewed          int     `json:"reviewed"`
+	Validated         int     `json:"validated"`
+	FalsePositives    int     `json:"false_positives"`
+	FalsePositiveRate float64 `json:"false_positive_rate"`
+
+	// OverReports is set when the group has enough reviews and its
+	// false-positive rate is at or above the threshold.
+	OverReports bool `json:"over_reports"`
+}
+
+// ReviewCalibration is the calibration report built from review decisions.
+type ReviewCalibration struct {
+	Overall      ReviewStat   `json:"overall"`
+	ByScenario   []ReviewStat `json:"by_scenario"`
+	ByType       []ReviewStat `json:"by_type"`
+	ByConfidence []ReviewStat `json:"by_confidence"`
+	ByAgent      []ReviewStat `json:"by_agent"`
+}
+
+func runTesterReviewStats(cmd *cobra.Command, args []string) error {
+	var since time.Time
+	if reviewStatsSince != "" {
+		d, err := parseDuration(reviewStatsSince)
+		if err != nil {
+			return fmt.Errorf("invalid --since: %w", err)
+		}
+		since = time.Now().Add(-d)
+	}
+
+	rows, err := collectExportedObservations(reviewResultsDir, since)
+	if err != nil {
+		return fmt.Errorf("collecting observations: %w", err)
+	}
+
+	report := buildReviewCalibration(rows, reviewStatsMinReviewed, reviewStatsThreshold)
+
+	if testerJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	}
+
+	if report.Overall.Reviewed == 0 {
+		fmt.Println("\nNo reviewed observations yet.")
+		fmt.Printf("   Review with: %s\n", ui.RenderCommand("gt tester review --interactive"))
+		return nil
+	}
+
+	fmt.Printf("\n%s %d of %d observations reviewed, %d false positives (%s)\n",
+		style.Bold.Render("Review Calibration:"), report.Overall.Reviewed, report.Overall.Total,
+		report.Overall.FalsePositives, formatRate(report.Overall.FalsePositiveRate))
+
+	printReviewStats("By persona/model", report.ByAgent)
+	printReviewStats("By confidence", report.ByConfidence)
+	printReviewStats("By type", report.ByType)
+	printReviewStats("By scenario", report.ByScenario)
+
+	var flagged []string
+	for _, s := range report.ByAgent {
+		if s.OverReports {
+			flagged = append(flagged, s.Key)
+		}
+	}
+	if len(flagged) > 0 {
+		fmt.Printf("\n%s %d persona/model combinations over-report (≥%s false positives).\n",
+			ui.RenderWarnIcon(), len(flagged), formatRate(reviewStatsThreshold))
+		fmt.Println("   Consider lowering the confidence or severity of their observations.")
+	}
+
+	return nil
+}
+
+// buildReviewCalibration tallies review outcomes by scenario, observation
+// type, confidence, and persona/model. Groups with at least minReviewed
+// reviews and a false-positive rate at or above threshold are flagged.
+func buildReviewCalibration(rows []ExportedObservation, minReviewed int, threshold float64) ReviewCalibration {
+	overall := &ReviewStat{Key: "all"}
+	byScenario := make(map[string]*ReviewStat)
+	byType := make(map[string]*ReviewStat)
+	byConfidence := make(map[string]*ReviewStat)
+	byAgent := make(map[string]*ReviewStat)
+
+	for _, row := range rows {
+		status := row.Observation.ReviewStatus()
+		model := row.Model
+		if model == "" {
+			model = "default"
+		}
+		for _, stat := range []*ReviewStat{
+			overall,
+			reviewStatFor(byScenario, row.Scenario),
+			reviewStatFor(byType, string(row.Observation.Type)),
+			reviewStatFor(byConfidence, string(row.Observation.Confidence)),
+			reviewStatFor(byAgent, row.Persona+"/"+model),
+		} {
+			stat.Total++
+			switch status {
+			case ReviewStatusValidated:
+				stat.Reviewed++
+				stat.Validated++
+			case ReviewStatusFalsePositive:
+				stat.Reviewed++
+				stat.FalsePositives++
+			}
+		}
+	}
+
+	finish := func(stat *ReviewStat) {
+		if stat.Reviewed > 0 {
+			stat.FalsePositiveRate = float64(stat.FalsePositives) / float64(stat.Reviewed)
+		}
+		stat.OverReports = stat.Reviewed >= minReviewed && stat.FalsePositiveRate >= threshold
+	}
+	finish(overall)
+
+	return ReviewCalibration{
+		Overall:      *overall,
+		ByScenario:   sortedReviewStats(byScenario, finish),
+		ByType:       sortedReviewStats(byType, finish),
+		ByConfidence: sortedReviewStats(byConfidence, finish),
+		ByAgent:      sortedReviewStats(byAgent, finish),
+	}
+}
+
+// reviewStatFor returns the group's stat, creating it on first use.
+func reviewStatFor(groups map[string]*ReviewStat, key string) *ReviewStat {
+	stat, ok := groups[key]
+	if !ok {
+		stat = &ReviewStat{Key: key}
+		groups[key] = stat
+	}
+	return stat
+}
+
+// sortedReviewStats finishes each group and orders them by false-positive
+// rate (highest first), then by number of reviews.
+func sortedReviewStats(groups map[string]*ReviewStat, finish func(*ReviewStat)) []ReviewStat {
+	stats := make([]ReviewStat, 0, len(groups))
+	for _, stat := range groups {
+		finish(stat)
+		stats = append(stats, *stat)
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].FalsePositiveRate != stats[j].FalsePositiveRate {
+			return stats[i].FalsePositiveRate > stats[j].FalsePositiveRate
+		}
+		if stats[i].Reviewed != stats[j].Reviewed {
+			return stats[i].Reviewed > stats[j].Reviewed
+		}
+		return stats[i].Key < stats[j].Key
+	})
+	return stats
+}
+
+// printReviewStats prints one section of the calibration report, skipping
+// groups with no reviews.
+func printReviewStats(title string, stats []ReviewStat) {
+	fmt.Printf("\n%s\n", style.Bold.Render(title+":"))
+	for _, s := range stats {
+		if s.Reviewed == 0 {
+			continue
+		}
+		line := fmt.Sprintf("  %-32s %5s false positive  (%d/%d reviewed, %d observations)",
+			s.Key, formatRate(s.FalsePositiveRate), s.FalsePositives, s.Reviewed, s.Total)
+		if s.OverReports {
+			fmt.Printf("%s %s\n", line, ui.RenderWarn("over-reports"))
+		} else {
+			fmt.Println(line)
+		}
+	}
+}
+
+// formatRate formats a 0-1 rate as a percentage.
+func formatRate(rate float64) string {
+	return fmt.Sprintf("%.0f%%", rate*100)
+}
@@ -0,0 +1,55 @@
+package cmd
+
+import "testing"
+
+func reviewedRow(scenario, persona, model string, confidence Confidence, falsePositive *bool) ExportedObservation {
+	obs := Observation{Type: ObservationConfusion, Severity: SeverityP2, Confidence: confidence, Description: "x"}
+	if falsePositive != nil {
+		valid := !*falsePositive
+		obs.Validated = &valid
+		obs.FalsePositive = falsePositive
+	}
+	return ExportedObservation{Scenario: scenario, Persona: persona, Model: model, Observation: obs}
+}
+
+func TestBuildReviewCalibration(t *testing.T) {
+	yes, no := true, false
+	rows := []ExportedObservation{
+		reviewedRow("signup", "sarah", "haiku", ConfidenceLow, &yes),
+		reviewedRow("signup", "sarah", "haiku", ConfidenceLow, &yes),
+		reviewedRow("signup", "sarah", "haiku", ConfidenceHigh, &no),
+		reviewedRow("checkout", "sarah", "sonnet", ConfidenceHigh, &no),
+		reviewedRow("checkout", "sarah", "sonnet", ConfidenceHigh, &no),
+		reviewedRow("checkout", "sarah", "sonnet", ConfidenceHigh, nil),
+	}
+
+	report := buildReviewCalibration(rows, 2, 0.5)
+
+	if report.Overall.Total != 6 || report.Overall.Reviewed != 5 || report.Overall.FalsePositives != 2 {
+		t.Errorf("overall = %+v", report.Overall)
+	}
+	if len(report.ByAgent) != 2 {
+		t.Fatalf("expected 2 persona/model groups, got %d", len(report.ByAgent))
+	}
+
+	// Sorted by false-positive rate, highest first
+	haiku := report.ByAgent[0]
+	if haiku.Key != "sarah/haiku" || !haiku.OverReports {
+		t.Errorf("expected sarah/haiku to over-report, got %+v", haiku)
+	}
+	if haiku.FalsePositiveRate < 0.66 || haiku.FalsePositiveRate > 0.67 {
+		t.Errorf("sarah/haiku rate = %v, want 2/3", haiku.FalsePositiveRate)
+	}
+	if sonnet := report.ByAgent[1]; sonnet.OverReports || sonnet.Reviewed != 2 || sonnet.Total != 3 {
+		t.Errorf("unexpected sarah/sonnet stat %+v", sonnet)
+	}
+
+	if low := report.ByConfidence[0]; low.Key != string(ConfidenceLow) || low.FalsePositiveRate != 1 {
+		t.Errorf("expected low confidence to lead with rate 1, got %+v", low)
+	}
+
+	// Too few reviews to flag
+	if report := buildReviewCalibration(rows, 10, 0.5); report.ByAgent[0].OverReports {
+		t.Error("expected no flag below --min-reviewed")
+	}
+}
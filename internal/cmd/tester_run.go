@@ -1,9 +1,11 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
 	"strings"
 	"time"
@@ -11,20 +13,26 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/tester"
+	"github.com/steveyegge/gastown/internal/tester/artifacts"
 	"github.com/steveyegge/gastown/internal/ui"
 )
 
 // Run command flags
 var (
-	runModel        string
-	runHeaded       bool
-	runNoVideo      bool
-	runNoTrace      bool
-	runTimeout      int
-	runRetry        int
-	runNoRetry      bool
-	runCompareTo    string
-	runOutput       string
+	runModel     string
+	runHeaded    bool
+	runNoVideo   bool
+	runNoTrace   bool
+	runTimeout   int
+	runRetry     int
+	runNoRetry   bool
+	runCompareTo string
+	runOutput    string
+	runUpload    string
+	runKeepLocal bool
+	runA11y      bool
+	runSign      string
+	runSignKey   string
 )
 
 var testerRunCmd = &cobra.Command{
@@ -48,7 +56,50 @@ Examples:
   gt tester run scenarios/signup.yaml --headed  # Show browser window
   gt tester run scenarios/signup.yaml --model sonnet  # Use Sonnet model
   gt tester run scenarios/signup.yaml --retry 5       # Set max retries
-  gt tester run scenarios/signup.yaml --no-retry      # Disable retry`,
+  gt tester run scenarios/signup.yaml --no-retry      # Disable retry
+  gt tester run scenarios/signup.yaml --upload s3://qa-artifacts/runs
+  gt tester run scenarios/signup.yaml --a11y        # Also audit accessibility
+
+With --a11y the agent additionally runs an axe-core scan at key steps (page
+loads, dialogs, form submissions). Violations become "accessibility"
+observations with WCAG references, merged into observations.json; axe impact
+maps to severity (critical P0, serious P1, moderate P2, minor P3).
+
+Scenarios can declare abort_if conditions for environment problems that
+make a run meaningless:
+
+  abort_if:
+    selectors: [".maintenance-banner"]
+    http_status: [503]
+    text: ["rate limited"]
+
+If the agent sees any of them it stops immediately and the run is reported
+as aborted (exit code 3). Aborted runs are not retried and never count
+toward the flake rate.
+
+A severity_budget caps observations per severity. A run that meets its
+success criteria still fails (exit code 1) when a count exceeds its budget,
+so new friction can gate CI:
+
+  severity_budget:
+    P0: 0
+    P1: 2
+
+A network block runs the scenario on a degraded connection (slow-3g,
+fast-3g, offline-intervals, or custom latency_ms/download_kbps/upload_kbps),
+emulated through Playwright. The profile is recorded in the results.
+
+observations.json carries a provenance block: the scenario file's content
+and hash, the settings resolved from the scenario, defaults and flags, the
+flags given, and the gt version. 'gt tester rerun <run-dir>' replays it.
+
+Artifacts can be uploaded to object storage with --upload (or by setting
+GT_TESTER_UPLOAD). Uploads use the aws or gcloud CLI and their configured
+credentials; local copies are removed afterwards unless --keep-local is set.
+
+With --sign, observations.json and manifest.json are signed (HMAC with the
+workspace key, or --sign=minisign with --signing-key pointing at a minisign
+secret key) so 'gt tester verify' can show they are unmodified.`,
 	Args: cobra.ExactArgs(1),
 	RunE: runTesterRun,
 }
@@ -60,7 +111,7 @@ type TestRunResult struct {
 	StartTime     time.Time     `json:"start_time"`
 	EndTime       time.Time     `json:"end_time"`
 	Duration      string        `json:"duration"`
-	Status        string        `json:"status"` // "pass", "fail", "error"
+	Status        string        `json:"status"` // "pass", "fail", "error", "aborted"
 	ExitCode      int           `json:"exit_code"`
 	Observations  []Observation `json:"observations"`
 	CriteriaMet   int           `json:"criteria_met"`
@@ -68,6 +119,14 @@ type TestRunResult struct {
 	RetryAttempts int           `json:"retry_attempts"`
 	Artifacts     TestArtifacts `json:"artifacts"`
 	Error         string        `json:"error,omitempty"`
+	AbortReason   string        `json:"abort_reason,omitempty"`
+	Network       string        `json:"network,omitempty"` // emulated network profile
+
+	// BudgetExceeded lists the severity budgets the observations exceeded
+	BudgetExceeded []string `json:"budget_exceeded,omitempty"`
+
+	// Provenance records how to reproduce the run (gt tester rerun)
+	Provenance *tester.Provenance `json:"provenance,omitempty"`
 
 	// Full observation result for detailed output
 	ObservationResult *ObservationResult `json:"-"`
@@ -103,13 +162,34 @@ func init() {
 	testerRunCmd.Flags().BoolVar(&runNoRetry, "no-retry", false, "Disable retry logic")
 	testerRunCmd.Flags().StringVar(&runCompareTo, "compare-to", "", "Compare results to previous run")
 	testerRunCmd.Flags().StringVar(&runOutput, "output", "", "Custom output directory")
+	testerRunCmd.Flags().StringVar(&runUpload, "upload", "", "Upload artifacts to object storage (s3://bucket/prefix, gs://bucket/prefix)")
+	testerRunCmd.Flags().BoolVar(&runKeepLocal, "keep-local", false, "Keep local artifact files after upload")
+	testerRunCmd.Flags().BoolVar(&runA11y, "a11y", false, "Also run axe-core accessibility scans at key steps")
+	testerRunCmd.Flags().StringVar(&runSign, "sign", "", "Sign the results (hmac, minisign)")
+	testerRunCmd.Flags().Lookup("sign").NoOptDefVal = tester.SignHMAC
+	testerRunCmd.Flags().StringVar(&runSignKey, "signing-key", "", "Signing key file (default: $"+tester.SigningKeyEnvVar+" or the workspace key)")
+	testerRunCmd.Flags().StringVar(&testerEnv, "env", "staging", "Target environment (staging, production)")
 	testerRunCmd.Flags().BoolVar(&testerSkipPreflight, "skip-preflight", false, "Skip environment preflight checks")
 	testerRunCmd.Flags().BoolVar(&testerVerbose, "verbose", false, "Show agent output in real-time")
 }
 
+// runProvenanceFlags are the run flags that affect reproducibility,
+// recorded as overrides in the run's provenance when set.
+var runProvenanceFlags = []string{"model", "headed", "no-video", "no-trace", "timeout", "retry", "no-retry", "a11y", "env"}
+
 func runTesterRun(cmd *cobra.Command, args []string) error {
-	scenarioPath := args[0]
+	overrides := make(map[string]string)
+	for _, name := range runProvenanceFlags {
+		if f := cmd.Flags().Lookup(name); f != nil && f.Changed {
+			overrides[name] = f.Value.String()
+		}
+	}
+	return runScenarioFile(args[0], overrides, "")
+}
 
+// runScenarioFile runs a scenario with the current run flags. overrides
+// and rerunOf are recorded in the run's provenance.
+func runScenarioFile(scenarioPath string, overrides map[string]string, rerunOf string) error {
 	// Load and validate scenario
 	scenario, err := loadScenario(scenarioPath)
 	if err != nil {
@@ -127,6 +207,12 @@ func runTesterRun(cmd *cobra.Command, args []string) error {
 		model = "haiku"
 	}
 	fmt.Printf("  Model: %s\n", model)
+	if scenario.Network != nil {
+		fmt.Printf("  Network: %s\n", scenario.Network.Describe())
+	}
+	if runA11y {
+		fmt.Println("  Accessibility audit: on (axe-core)")
+	}
 	fmt.Println()
 
 	// Run preflight checks unless skipped
@@ -163,6 +249,18 @@ func runTesterRun(cmd *cobra.Command, args []string) error {
 		timeout = runTimeout
 	}
 
+	settings := resolveRunSettings(scenario, model, maxAttempts, timeout)
+	provenance, err := tester.NewProvenance(scenarioPath, settings, overrides, versionString())
+	if err != nil {
+		return err
+	}
+	provenance.RerunOf = rerunOf
+
+	signer, err := newResultSigner(runSign, runSignKey)
+	if err != nil {
+		return err
+	}
+
 	// Create output directory
 	outputDir := runOutput
 	if outputDir == "" {
@@ -182,11 +280,13 @@ func runTesterRun(cmd *cobra.Command, args []string) error {
 		Artifacts: TestArtifacts{
 			OutputDir: outputDir,
 		},
+		Provenance: provenance,
 	}
 
 	// Run test with retry logic
 	fmt.Println("Starting browser...")
 	var lastErr error
+	var retried []tester.RetryAttempt
 
 	for attempt := 1; attempt <= maxAttempts; attempt++ {
 		result.RetryAttempts = attempt
@@ -211,6 +311,7 @@ func runTesterRun(cmd *cobra.Command, args []string) error {
 			}
 
 			if attempt < maxAttempts {
+				retried = append(retried, tester.RetryAttempt{Attempt: attempt, Error: infraErr.Error()})
 				backoff := calculateBackoff(attempt, scenario.Retry)
 				fmt.Printf("  %s %s (attempt %d/%d)\n", ui.RenderFailIcon(), infraErr.Type, attempt, maxAttempts)
 				fmt.Printf("  Retrying in %v...\n", backoff)
@@ -224,6 +325,13 @@ func runTesterRun(cmd *cobra.Command, args []string) error {
 		break
 	}
 
+	// Record the retried attempts so batch reports can tell what retries rescued
+	if len(retried) > 0 {
+		if err := tester.WriteRetryLog(outputDir, retried); err != nil {
+			fmt.Printf("  %s Could not write retry log: %v\n", ui.RenderWarnIcon(), err)
+		}
+	}
+
 	// Record end time and duration
 	result.EndTime = time.Now()
 	result.Duration = result.EndTime.Sub(result.StartTime).Round(time.Second).String()
@@ -262,6 +370,24 @@ func runTesterRun(cmd *cobra.Command, args []string) error {
 		fmt.Printf("  Retries: %d\n", result.RetryAttempts-1)
 	}
 
+	// Upload artifacts if configured
+	uploadTarget := runUpload
+	if uploadTarget == "" {
+		uploadTarget = os.Getenv(artifacts.UploadEnvVar)
+	}
+	if uploadTarget != "" {
+		if err := uploadTestArtifacts(&result, uploadTarget, !runKeepLocal); err != nil {
+			fmt.Printf("  %s Artifact upload failed: %v\n", ui.RenderWarnIcon(), err)
+		}
+	}
+
+	// Sign the results as they stand after the upload
+	if signer != nil {
+		if _, err := signer.SignResults(result.Artifacts.OutputDir); err != nil {
+			fmt.Printf("  %s Signing results failed: %v\n", ui.RenderWarnIcon(), err)
+		}
+	}
+
 	// Artifacts
 	fmt.Println()
 	fmt.Println("Artifacts:")
@@ -285,9 +411,15 @@ func runTesterRun(cmd *cobra.Command, args []string) error {
 			fmt.Printf("Result: %s - %d P0/P1 issues require attention\n", ui.RenderWarn("PASS with issues"), p0p1Count)
 		}
 	case "fail":
-		fmt.Printf("Result: %s - success criteria not met\n", ui.RenderFail("FAIL"))
+		if len(result.BudgetExceeded) > 0 {
+			fmt.Printf("Result: %s - severity budget exceeded: %s\n", ui.RenderFail("FAIL"), strings.Join(result.BudgetExceeded, ", "))
+		} else {
+			fmt.Printf("Result: %s - success criteria not met\n", ui.RenderFail("FAIL"))
+		}
 	case "error":
 		fmt.Printf("Result: %s - %s\n", ui.RenderFail("ERROR"), result.Error)
+	case tester.StatusAborted:
+		fmt.Printf("Result: %s - %s\n", ui.RenderWarn("ABORTED"), result.AbortReason)
 	}
 
 	// JSON output if requested
@@ -306,14 +438,136 @@ func runTesterRun(cmd *cobra.Command, args []string) error {
 		return NewSilentExit(1)
 	case "error":
 		return NewSilentExit(result.ExitCode)
+	case tester.StatusAborted:
+		return NewSilentExit(3)
+	}
+
+	return nil
+}
+
+// writeTesterContext renders the tester CLAUDE.md for scenario into
+// outputDir, the context the run's agent is spawned with.
+func writeTesterContext(scenario *tester.ScenarioConfig, outputDir string) error {
+	data := &tester.TesterTemplateData{
+		PersonaName:    scenario.Persona,
+		Goal:           scenario.Goal,
+		ScenarioName:   scenario.Scenario,
+		AbortIf:        scenario.AbortIf,
+		AbortOutputDir: outputDir,
+		Network:        scenario.Network,
 	}
+	if len(scenario.SuccessCriteria) > 0 {
+		data.SuccessCriteria = "- " + strings.Join(scenario.SuccessCriteria, "\n- ")
+	}
+	if runA11y {
+		data.A11yOutputDir = outputDir
+	}
+	rendered, err := tester.RenderTesterTemplate(data)
+	if err != nil {
+		return fmt.Errorf("rendering tester instructions: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(outputDir, "CLAUDE.md"), []byte(rendered), 0644); err != nil {
+		return fmt.Errorf("writing tester instructions: %w", err)
+	}
+	return nil
+}
 
+// writeTesterMCPConfig writes the run's .mcp.json: Playwright MCP recording
+// into the run directory, in the browser profile given, headed if the run
+// settings ask for it.
+func writeTesterMCPConfig(result *TestRunResult, profile *tester.BrowserProfile) error {
+	outputDir := result.Artifacts.OutputDir
+	pw := *tester.DefaultConfig().Playwright
+	if result.Provenance != nil && result.Provenance.Settings.Headed {
+		pw.Headless = false
+		pw.Headed = true
+	}
+	pw.Profile = profile
+	if _, err := tester.EnsureMCPConfig(outputDir, outputDir, &pw); err != nil {
+		return fmt.Errorf("writing browser config: %w", err)
+	}
+	return nil
+}
+
+// resolveRunSettings combines the scenario's recording settings with the
+// run flags and the already resolved model, attempts and timeout.
+func resolveRunSettings(scenario *tester.ScenarioConfig, model string, maxAttempts, timeout int) tester.RunSettings {
+	settings := tester.RunSettings{
+		Model:          model,
+		Environment:    testerEnv,
+		MaxAttempts:    maxAttempts,
+		TimeoutSeconds: timeout,
+		Headed:         runHeaded,
+		Video:          !runNoVideo,
+		Trace:          !runNoTrace,
+		A11y:           runA11y,
+	}
+	if rec := scenario.Recording; rec != nil {
+		settings.Headed = settings.Headed || rec.Headed
+		if rec.Video != nil && !*rec.Video {
+			settings.Video = false
+		}
+		if rec.Trace != nil && !*rec.Trace {
+			settings.Trace = false
+		}
+	}
+	return settings
+}
+
+// uploadTestArtifacts uploads the run's artifact files and replaces their
+// paths in result with remote URLs. Files that were not produced are skipped.
+// deleteLocal removes only the uploaded video and trace; observations.json
+// and summary.md stay in the run directory for review, stats and export.
+func uploadTestArtifacts(result *TestRunResult, target string, deleteLocal bool) error {
+	uploader, err := artifacts.NewUploader(target)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	runKey := path.Join(result.StartTime.Format("2006-01-02"), result.Scenario, filepath.Base(result.Artifacts.OutputDir))
+	files := []struct {
+		path  *string
+		media bool
+	}{
+		{&result.Artifacts.Video, true},
+		{&result.Artifacts.Trace, true},
+		{&result.Artifacts.Summary, false},
+		{&result.Artifacts.Observations, false},
+	}
+	for _, f := range files {
+		p := f.path
+		if *p == "" {
+			continue
+		}
+		if _, err := os.Stat(*p); err != nil {
+			continue
+		}
+		url, err := uploader.Upload(ctx, *p, path.Join(runKey, filepath.Base(*p)))
+		if err != nil {
+			return err
+		}
+		if deleteLocal && f.media {
+			_ = os.Remove(*p)
+		}
+		*p = url
+	}
 	return nil
 }
 
 // loadScenario loads and parses a scenario YAML file using the tester package parser.
+// Parse warnings (e.g., a newer schema version) are printed to stderr.
 func loadScenario(path string) (*tester.ScenarioConfig, error) {
-	return tester.ParseScenarioFile(path)
+	scenario, err := tester.ParseScenarioFile(path)
+	if err != nil {
+		return nil, err
+	}
+	for _, w := range scenario.Warnings {
+		fmt.Fprintf(os.Stderr, "%s %s: %s\n", ui.RenderWarnIcon(), path, w)
+	}
+	return scenario, nil
 }
 
 // runPreflightQuick runs a quick subset of preflight checks
@@ -352,8 +606,36 @@ func executeTestScenario(scenario *tester.ScenarioConfig, result *TestRunResult,
 	obsResult := NewObservationResult(scenario.Scenario, scenario.Persona)
 	obsResult.Model = model
 	obsResult.RunID = fmt.Sprintf("run-%03d", attempt)
+	obsResult.Provenance = result.Provenance
+	obsResult.Network = scenario.Network.Describe()
+	result.Network = obsResult.Network
 	result.ObservationResult = obsResult
 
+	// A fresh browser profile per attempt, so a retry doesn't inherit the
+	// failed attempt's cookies or localStorage
+	profile, err := tester.NewBrowserProfile(scenario.Environment.ProfileFixture)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := profile.Remove(); err != nil {
+			fmt.Printf("  %s Could not remove browser profile: %v\n", ui.RenderWarnIcon(), err)
+		}
+	}()
+	if profile.Fixture != "" {
+		fmt.Printf("  Browser profile seeded from %s\n", profile.Fixture)
+	}
+
+	// The agent's CLAUDE.md, including the abort, network and --a11y
+	// instructions that point at this run's directory, and its .mcp.json,
+	// which starts the browser in the attempt's profile
+	if err := writeTesterContext(scenario, result.Artifacts.OutputDir); err != nil {
+		return err
+	}
+	if err := writeTesterMCPConfig(result, profile); err != nil {
+		return err
+	}
+
 	// For now, this is a placeholder for the actual test execution
 	// In a full implementation, this would:
 	// 1. Spawn a Task agent with the tester CLAUDE.md context
@@ -372,9 +654,43 @@ func executeTestScenario(scenario *tester.ScenarioConfig, result *TestRunResult,
 	obsResult.OverallExperience = "Test completed successfully (scaffold implementation)"
 	obsResult.RetryCount = attempt - 1
 
+	// A matched abort_if condition ends the run without a verdict
+	if signal, err := tester.LoadAbortSignal(result.Artifacts.OutputDir); err != nil {
+		fmt.Printf("  %s Could not read abort signal: %v\n", ui.RenderWarnIcon(), err)
+	} else if signal != nil {
+		result.Status = tester.StatusAborted
+		result.AbortReason = signal.Reason()
+		result.CriteriaMet = 0
+		obsResult.AbortReason = result.AbortReason
+		obsResult.SuccessCriteriaMet = nil
+		obsResult.OverallExperience = "Run aborted: " + result.AbortReason
+	}
+
+	// Merge the agent's axe-core scans into the observations
+	if runA11y {
+		findings, err := tester.LoadA11yFindings(result.Artifacts.OutputDir)
+		if err != nil {
+			fmt.Printf("  %s Could not read accessibility scans: %v\n", ui.RenderWarnIcon(), err)
+		}
+		obsResult.AddA11yFindings(findings)
+	}
+
 	// Copy observations to result
 	result.Observations = obsResult.Observations
 
+	// Observations over the scenario's severity budget fail a pass
+	if result.Status == "pass" {
+		counts := make(map[string]int)
+		for sev, n := range obsResult.CountBySeverity() {
+			counts[string(sev)] = n
+		}
+		if exceeded := scenario.CheckSeverityBudget(counts); len(exceeded) > 0 {
+			result.Status = "fail"
+			result.BudgetExceeded = exceeded
+			obsResult.BudgetExceeded = exceeded
+		}
+	}
+
 	// Create artifact paths
 	result.Artifacts.Video = filepath.Join(result.Artifacts.OutputDir, "video.webm")
 	result.Artifacts.Trace = filepath.Join(result.Artifacts.OutputDir, "trace.zip")
@@ -404,8 +720,18 @@ func generateSummaryMarkdown(scenario *tester.ScenarioConfig, obsResult *Observa
 	sb.WriteString(fmt.Sprintf("**Persona**: %s\n", scenario.Persona))
 	sb.WriteString(fmt.Sprintf("**URL**: %s\n", scenario.Environment.URL))
 	sb.WriteString(fmt.Sprintf("**Model**: %s\n", model))
+	if obsResult.Network != "" {
+		sb.WriteString(fmt.Sprintf("**Network**: %s\n", obsResult.Network))
+	}
 	sb.WriteString(fmt.Sprintf("**Duration**: %d seconds\n", obsResult.DurationSeconds))
-	sb.WriteString(fmt.Sprintf("**Completed**: %v\n\n", obsResult.Completed))
+	sb.WriteString(fmt.Sprintf("**Completed**: %v\n", obsResult.Completed))
+	if obsResult.AbortReason != "" {
+		sb.WriteString(fmt.Sprintf("**Aborted**: %s\n", obsResult.AbortReason))
+	}
+	if len(obsResult.BudgetExceeded) > 0 {
+		sb.WriteString(fmt.Sprintf("**Severity budget exceeded**: %s\n", strings.Join(obsResult.BudgetExceeded, ", ")))
+	}
+	sb.WriteString("\n")
 
 	// Observations section
 	sb.WriteString("## Observations\n\n")
@@ -429,6 +755,12 @@ func generateSummaryMarkdown(scenario *tester.ScenarioConfig, obsResult *Observa
 				sb.WriteString(fmt.Sprintf("- **Location**: %s\n", obs.Location))
 			}
 			sb.WriteString(fmt.Sprintf("- **Description**: %s\n", obs.Description))
+			if obs.Rule != "" {
+				sb.WriteString(fmt.Sprintf("- **Rule**: %s\n", obs.Rule))
+			}
+			if len(obs.WCAG) > 0 {
+				sb.WriteString(fmt.Sprintf("- **WCAG**: %s\n", strings.Join(obs.WCAG, ", ")))
+			}
 			if obs.Screenshot != "" {
 				sb.WriteString(fmt.Sprintf("- **Screenshot**: %s\n", obs.Screenshot))
 			}
@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/tester"
+)
+
+func TestWriteTesterContext_A11y(t *testing.T) {
+	scenario := &tester.ScenarioConfig{
+		Scenario:        "checkout",
+		Persona:         "Sarah",
+		Goal:            "Buy a gift card",
+		SuccessCriteria: []string{"Order confirmation shown"},
+	}
+
+	oldA11y := runA11y
+	defer func() { runA11y = oldA11y }()
+
+	for _, a11y := range []bool{false, true} {
+		runA11y = a11y
+		dir := t.TempDir()
+		if err := writeTesterContext(scenario, dir); err != nil {
+			t.Fatalf("writeTesterContext: %v", err)
+		}
+		data, err := os.ReadFile(filepath.Join(dir, "CLAUDE.md"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		hasAudit := strings.Contains(string(data), "## Accessibility Audit")
+		if hasAudit != a11y {
+			t.Errorf("--a11y=%v: accessibility instructions present = %v", a11y, hasAudit)
+		}
+		if a11y && !strings.Contains(string(data), filepath.Join(dir, tester.A11yDir)) {
+			t.Error("accessibility instructions don't point at the run directory")
+		}
+	}
+}
+
+func TestWriteTesterMCPConfig_Profile(t *testing.T) {
+	fixture := filepath.Join(t.TempDir(), "alice.json")
+	if err := os.WriteFile(fixture, []byte(`{"cookies":[]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, fixture := range []string{"", fixture} {
+		profile, err := tester.NewBrowserProfile(fixture)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer profile.Remove()
+
+		result := &TestRunResult{Artifacts: TestArtifacts{OutputDir: t.TempDir()}}
+		if err := writeTesterMCPConfig(result, profile); err != nil {
+			t.Fatalf("writeTesterMCPConfig: %v", err)
+		}
+		data, err := os.ReadFile(filepath.Join(result.Artifacts.OutputDir, ".mcp.json"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var mcp tester.MCPConfig
+		if err := json.Unmarshal(data, &mcp); err != nil {
+			t.Fatal(err)
+		}
+		args := strings.Join(mcp.MCPServers["playwright"].Args, " ")
+		want := "--user-data-dir " + profile.Dir
+		if fixture != "" {
+			want = "--storage-state " + profile.StorageState
+		}
+		if !strings.Contains(args, want) {
+			t.Errorf("fixture %q: args = %q, want %q", fixture, args, want)
+		}
+	}
+}
+
+func TestUploadTestArtifacts_KeepsObservations(t *testing.T) {
+	binDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(binDir, "aws"), []byte("#!/bin/sh\nexit 0\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	resultsDir := t.TempDir()
+	runDir := filepath.Join(resultsDir, "2026-03-30", "checkout", "run-001")
+	if err := os.MkdirAll(runDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	obs := NewObservationResult("checkout", "tester")
+	obs.AddObservation(*NewObservation(ObservationConfusion, SeverityP2, ConfidenceMedium, "unclear total"))
+	if err := obs.WriteToFile(runDir); err != nil {
+		t.Fatal(err)
+	}
+	video := filepath.Join(runDir, "video.webm")
+	summary := filepath.Join(runDir, "summary.md")
+	os.WriteFile(video, []byte("video"), 0644)
+	os.WriteFile(summary, []byte("# Summary"), 0644)
+
+	result := TestRunResult{
+		Scenario: "checkout",
+		Artifacts: TestArtifacts{
+			Video:        video,
+			Summary:      summary,
+			Observations: filepath.Join(runDir, "observations.json"),
+			OutputDir:    runDir,
+		},
+	}
+	if err := uploadTestArtifacts(&result, "s3://qa-artifacts", true); err != nil {
+		t.Fatalf("uploadTestArtifacts: %v", err)
+	}
+	if !strings.HasPrefix(result.Artifacts.Observations, "https://") {
+		t.Errorf("observations path = %q, want uploaded URL", result.Artifacts.Observations)
+	}
+	if _, err := os.Stat(video); !os.IsNotExist(err) {
+		t.Error("expected local video to be removed")
+	}
+	if _, err := os.Stat(summary); err != nil {
+		t.Errorf("expected summary.md to be kept: %v", err)
+	}
+
+	// Export still finds the run's observations after the upload
+	rows, err := collectExportedObservations(resultsDir, time.Time{})
+	if err != nil {
+		t.Fatalf("collectExportedObservations: %v", err)
+	}
+	if len(rows) != 1 || rows[0].Observation.Description != "unclear total" {
+		t.Errorf("exported rows after upload = %+v, want the run's observation", rows)
+	}
+}
@@ -0,0 +1,419 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/mail"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/tester/artifacts"
+	"github.com/steveyegge/gastown/internal/tester/batch"
+	"github.com/steveyegge/gastown/internal/ui"
+	"github.com/steveyegge/gastown/internal/util"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var (
+	scheduleOutputDir string
+	schedulePattern   string
+	scheduleManifest  string
+	scheduleParallel  int
+	scheduleFilter    []string
+	scheduleExclude   []string
+	scheduleModel     string
+	scheduleRotate    bool
+	scheduleCompareTo string
+	scheduleNotify    string
+	scheduleID        string
+	scheduleOnce      bool
+	scheduleInterval  time.Duration
+)
+
+var testerScheduleCmd = &cobra.Command{
+	Use:   "schedule",
+	Short: "Run batches on a cron schedule",
+	Long: `Schedule batch runs with cron expressions, without external cron glue.
+
+Schedules are stored in <output>/.schedules.json. 'gt tester schedule run'
+is a lightweight scheduler daemon that starts due batches, records each run
+under the schedule ID (also stored in the batch manifest as schedule_id),
+and notifies on completion.
+
+Cron expressions use five fields in local time:
+  minute hour day-of-month month day-of-week
+Shorthands @hourly, @daily, @weekly, @monthly and @yearly are accepted.
+
+Notifications (--notify) go to a mail address such as "mayor/" or to an
+http(s) webhook URL (Slack-compatible JSON payload).
+
+--rotate-personas cycles each scenario's persona_pool across the schedule's
+runs, one persona per batch; compare the results with 'gt tester personas'.
+
+SUBCOMMANDS:
+  add      Add a schedule
+  list     List schedules and their last run
+  remove   Remove a schedule
+  pause    Pause a schedule
+  resume   Resume a paused schedule
+  run      Run the scheduler
+
+Examples:
+  gt tester schedule add "0 2 * * *" --pattern "scenarios/*.yaml" --env staging
+  gt tester schedule add @hourly --manifest suites/smoke.yaml --notify mayor/
+  gt tester schedule add @daily --pattern "scenarios/*.yaml" --rotate-personas
+  gt tester schedule list
+  gt tester schedule run
+  gt tester schedule run --once`,
+	RunE: requireSubcommand,
+}
+
+var scheduleAddCmd = &cobra.Command{
+	Use:   "add <cron>",
+	Short: "Add a batch schedule",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runScheduleAdd,
+}
+
+var scheduleListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List batch schedules",
+	Args:  cobra.NoArgs,
+	RunE:  runScheduleList,
+}
+
+var scheduleRemoveCmd = &cobra.Command{
+	Use:   "remove <schedule-id>",
+	Short: "Remove a batch schedule",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runScheduleRemove,
+}
+
+var schedulePauseCmd = &cobra.Command{
+	Use:   "pause <schedule-id>",
+	Short: "Pause a batch schedule",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setSchedulePaused(args[0], true)
+	},
+}
+
+var scheduleResumeCmd = &cobra.Command{
+	Use:   "resume <schedule-id>",
+	Short: "Resume a paused batch schedule",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setSchedulePaused(args[0], false)
+	},
+}
+
+var scheduleRunCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Run the batch scheduler",
+	Long: `Run the scheduler in the foreground, starting batches when due.
+
+Due schedules run one at a time. Runs missed while the scheduler was stopped
+are not replayed; each schedule resumes at its next matching time. Use
+--once to run whatever is due now and exit (e.g. from a systemd timer).
+Stop with Ctrl-C; a running batch is cancelled.`,
+	Args: cobra.NoArgs,
+	RunE: runScheduleRun,
+}
+
+func init() {
+	testerScheduleCmd.PersistentFlags().StringVar(&scheduleOutputDir, "output", "test-results", "Output directory for results and schedules")
+
+	scheduleAddCmd.Flags().StringVar(&schedulePattern, "pattern", "", "Scenario glob pattern")
+	scheduleAddCmd.Flags().StringVar(&scheduleManifest, "manifest", "", "Suite manifest file listing scenarios")
+	scheduleAddCmd.Flags().StringVar(&testerEnv, "env", "staging", "Target environment")
+	scheduleAddCmd.Flags().IntVarP(&scheduleParallel, "parallel", "p", 1, "Number of scenarios to run simultaneously")
+	scheduleAddCmd.Flags().StringSliceVar(&scheduleFilter, "filter", nil, "Only run scenarios with these tags")
+	scheduleAddCmd.Flags().StringSliceVar(&scheduleExclude, "exclude", nil, "Skip scenarios with these tags")
+	scheduleAddCmd.Flags().StringVar(&scheduleModel, "model", "", "Override model for all scenarios")
+	scheduleAddCmd.Flags().BoolVar(&scheduleRotate, "rotate-personas", false, "Cycle each scenario's persona_pool, one persona per run")
+	scheduleAddCmd.Flags().StringVar(&scheduleCompareTo, "compare-to", "", "Compare each run to a batch (\"baseline\" for the pinned baseline)")
+	scheduleAddCmd.Flags().StringVar(&scheduleNotify, "notify", "", "Notify on completion (mail address or webhook URL)")
+	scheduleAddCmd.Flags().StringVar(&scheduleID, "id", "", "Schedule ID (default: generated)")
+
+	scheduleListCmd.Flags().BoolVar(&testerJSON, "json", false, "Output as JSON")
+
+	scheduleRunCmd.Flags().BoolVar(&scheduleOnce, "once", false, "Run due schedules once and exit")
+	scheduleRunCmd.Flags().DurationVar(&scheduleInterval, "interval", 30*time.Second, "How often to check for due schedules")
+
+	testerScheduleCmd.AddCommand(scheduleAddCmd)
+	testerScheduleCmd.AddCommand(scheduleListCmd)
+	testerScheduleCmd.AddCommand(scheduleRemoveCmd)
+	testerScheduleCmd.AddCommand(schedulePauseCmd)
+	testerScheduleCmd.AddCommand(scheduleResumeCmd)
+	testerScheduleCmd.AddCommand(scheduleRunCmd)
+
+	testerCmd.AddCommand(testerScheduleCmd)
+}
+
+func runScheduleAdd(cmd *cobra.Command, args []string) error {
+	if (schedulePattern == "") == (scheduleManifest == "") {
+		return fmt.Errorf("specify exactly one of --pattern or --manifest")
+	}
+
+	sched := &batch.Schedule{
+		ID:   scheduleID,
+		Cron: args[0],
+		Config: batch.Config{
+			Pattern:        schedulePattern,
+			Manifest:       scheduleManifest,
+			Parallel:       scheduleParallel,
+			Model:          scheduleModel,
+			RotatePersonas: scheduleRotate,
+			Environment:    testerEnv,
+			FilterTags:     scheduleFilter,
+			ExcludeTags:    scheduleExclude,
+			CompareTo:      scheduleCompareTo,
+			OutputDir:      scheduleOutputDir,
+		},
+		Notify:    scheduleNotify,
+		CreatedBy: os.Getenv("BD_ACTOR"),
+	}
+	if sched.CreatedBy == "" {
+		sched.CreatedBy = os.Getenv("USER")
+	}
+
+	store, err := batch.LoadScheduleStore(scheduleOutputDir)
+	if err != nil {
+		return err
+	}
+	if err := store.Add(sched, time.Now()); err != nil {
+		return err
+	}
+
+	fmt.Printf("%s Schedule %s added\n", ui.RenderPassIcon(), sched.ID)
+	fmt.Printf("  Cron: %s\n", sched.Cron)
+	fmt.Printf("  Next run: %s\n", sched.NextRun.Format("2006-01-02 15:04 MST"))
+	fmt.Printf("  %s\n", style.Dim.Render("Start the scheduler with 'gt tester schedule run'"))
+	return nil
+}
+
+func runScheduleList(cmd *cobra.Command, args []string) error {
+	store, err := batch.LoadScheduleStore(scheduleOutputDir)
+	if err != nil {
+		return err
+	}
+	schedules := store.List()
+
+	if testerJSON {
+		data, _ := json.MarshalIndent(schedules, "", "  ")
+		fmt.Println(string(data))
+		return nil
+	}
+
+	if len(schedules) == 0 {
+		fmt.Println("No schedules")
+		return nil
+	}
+
+	fmt.Printf("Schedules (%d)\n", len(schedules))
+	fmt.Println(strings.Repeat("─", 60))
+	for _, s := range schedules {
+		source := s.Config.Pattern
+		if source == "" {
+			source = s.Config.Manifest
+		}
+		fmt.Printf("  %s  %s  %s (%s)\n", s.ID, s.Cron, source, s.Config.Environment)
+		if s.Paused {
+			fmt.Printf("    %s\n", style.Dim.Render("paused"))
+		} else {
+			fmt.Printf("    Next: %s\n", s.NextRun.Format("2006-01-02 15:04"))
+		}
+		if last, ok := s.LastRun(); ok {
+			icon := ui.RenderPassIcon()
+			if last.Error != "" || last.Failed > 0 || last.Errors > 0 {
+				icon = ui.RenderFailIcon()
+			}
+			fmt.Printf("    Last: %s %s %s\n", icon, last.StartedAt.Format("2006-01-02 15:04"), describeScheduleRun(last))
+		}
+		if s.Config.RotatePersonas {
+			fmt.Printf("    %s\n", style.Dim.Render("rotating personas"))
+		}
+		if s.Notify != "" {
+			fmt.Printf("    Notify: %s\n", s.Notify)
+		}
+	}
+	return nil
+}
+
+func runScheduleRemove(cmd *cobra.Command, args []string) error {
+	store, err := batch.LoadScheduleStore(scheduleOutputDir)
+	if err != nil {
+		return err
+	}
+	if err := store.Remove(args[0]); err != nil {
+		return err
+	}
+	fmt.Printf("%s Schedule %s removed\n", ui.RenderPassIcon(), args[0])
+	return nil
+}
+
+func setSchedulePaused(id string, paused bool) error {
+	store, err := batch.LoadScheduleStore(scheduleOutputDir)
+	if err != nil {
+		return err
+	}
+	if err := store.SetPaused(id, paused, time.Now()); err != nil {
+		return err
+	}
+	state := "resumed"
+	if paused {
+		state = "paused"
+	}
+	fmt.Printf("%s Schedule %s %s\n", ui.RenderPassIcon(), id, state)
+	return nil
+}
+
+func runScheduleRun(cmd *cobra.Command, args []string) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if !scheduleOnce {
+		fmt.Printf("Scheduler started (checking every %s, Ctrl-C to stop)\n", scheduleInterval)
+	}
+
+	ticker := time.NewTicker(scheduleInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := runDueSchedules(ctx); err != nil {
+			if scheduleOnce {
+				return err
+			}
+			fmt.Printf("%s %v\n", ui.RenderWarnIcon(), err)
+		}
+		if scheduleOnce {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			fmt.Println("Scheduler stopped")
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// runDueSchedules runs every schedule that is due, one at a time. The store
+// is reloaded each pass so schedules added while the daemon runs are seen.
+func runDueSchedules(ctx context.Context) error {
+	store, err := batch.LoadScheduleStore(scheduleOutputDir)
+	if err != nil {
+		return err
+	}
+
+	for _, sched := range store.Due(time.Now()) {
+		if ctx.Err() != nil {
+			return nil
+		}
+		run := runScheduledBatch(ctx, sched)
+
+		// Reload so concurrent edits (add/remove/pause) aren't clobbered
+		if store, err = batch.LoadScheduleStore(scheduleOutputDir); err != nil {
+			return err
+		}
+		if err := store.RecordRun(sched.ID, run, time.Now()); err != nil {
+			fmt.Printf("%s Recording run for %s: %v\n", ui.RenderWarnIcon(), sched.ID, err)
+		}
+
+		if sched.Notify != "" {
+			if err := notifyScheduleRun(sched, run); err != nil {
+				fmt.Printf("%s Notifying %s: %v\n", ui.RenderWarnIcon(), sched.Notify, err)
+			}
+		}
+	}
+	return nil
+}
+
+// runScheduledBatch runs one scheduled batch and summarizes the outcome.
+func runScheduledBatch(ctx context.Context, sched *batch.Schedule) batch.ScheduleRun {
+	started := time.Now()
+	fmt.Printf("[%s] Running schedule %s (%s)\n", started.Format("15:04:05"), sched.ID, sched.Cron)
+
+	config := sched.Config
+	config.ScheduleID = sched.ID
+	if config.Upload == "" {
+		config.Upload = os.Getenv(artifacts.UploadEnvVar)
+	}
+
+	runner, err := batch.NewRunner(config)
+	if err != nil {
+		return batch.NewScheduleRun(started, nil, fmt.Errorf("failed to create batch runner: %w", err))
+	}
+	setFlakeBeadStore(runner)
+
+	runCtx, cancel := context.WithTimeout(ctx, 30*time.Minute)
+	defer cancel()
+
+	result, err := runner.Run(runCtx)
+	run := batch.NewScheduleRun(started, result, err)
+	fmt.Printf("[%s] Schedule %s finished: %s\n", time.Now().Format("15:04:05"), sched.ID, describeScheduleRun(run))
+	return run
+}
+
+func describeScheduleRun(run batch.ScheduleRun) string {
+	if run.Error != "" && run.BatchID == "" {
+		return "error: " + run.Error
+	}
+	desc := fmt.Sprintf("batch %s: %d passed, %d failed", run.BatchID, run.Passed, run.Failed)
+	if run.Errors > 0 {
+		desc += fmt.Sprintf(", %d errors", run.Errors)
+	}
+	if run.Duration != "" {
+		desc += " in " + run.Duration
+	}
+	if run.Error != "" {
+		desc += " (" + run.Error + ")"
+	}
+	return desc
+}
+
+// notifyScheduleRun sends a completion notice to a mail address or webhook.
+func notifyScheduleRun(sched *batch.Schedule, run batch.ScheduleRun) error {
+	status := "passed"
+	if run.Error != "" || run.Failed > 0 || run.Errors > 0 {
+		status = "failed"
+	}
+	subject := fmt.Sprintf("Scheduled batch %s %s", sched.ID, status)
+	body := fmt.Sprintf("Schedule: %s (%s)\nEnvironment: %s\nResult: %s\n",
+		sched.ID, sched.Cron, sched.Config.Environment, describeScheduleRun(run))
+	if run.BatchID != "" {
+		body += fmt.Sprintf("\nResults: %s (batch %s)\n", sched.Config.OutputDir, run.BatchID)
+	}
+
+	if strings.HasPrefix(sched.Notify, "http://") || strings.HasPrefix(sched.Notify, "https://") {
+		return util.PostWebhook(sched.Notify, map[string]interface{}{
+			"text":     subject + "\n" + body,
+			"event":    "schedule_run",
+			"schedule": sched.ID,
+			"run":      run,
+		})
+	}
+
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("mail notification requires a Gas Town workspace: %w", err)
+	}
+	msg := &mail.Message{
+		From:      "tester/scheduler",
+		To:        sched.Notify,
+		Subject:   subject,
+		Body:      body,
+		Timestamp: time.Now(),
+	}
+	if status == "failed" {
+		msg.Priority = mail.PriorityHigh
+	}
+	return mail.NewRouterWithTownRoot(townRoot, townRoot).Send(msg)
+}
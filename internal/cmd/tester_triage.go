@@ -0,0 +1,363 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/tester/batch"
+	"github.com/steveyegge/gastown/internal/tester/flake"
+	"github.com/steveyegge/gastown/internal/ui"
+)
+
+// Triage command flags
+var (
+	triageResultsDir string
+	triageAll        bool
+	triageLastObs    int
+)
+
+var testerTriageCmd = &cobra.Command{
+	Use:   "triage <batch-id>",
+	Short: "Walk a batch's new issues and decide what to do with each",
+	Long: `Triage the new issues from a batch's comparison to its baseline.
+
+For each new issue, shows the failing scenario's error, its last
+observations and screenshots, then offers:
+
+  [f] File bead      Create a bug bead for the issue
+  [q] Quarantine     Quarantine the scenario
+  [k] Known flake    Record the failure as a known flake
+  [d] Dismiss        Record that no action is needed
+  [o] Open           Open the run's screenshots
+  [s] Skip           Decide later
+  [x] Exit
+
+Decisions are written back to the batch manifest as they are made, so
+triage can be stopped and resumed. Issues that already have a decision are
+skipped unless --all is given.
+
+The batch must have been run with --compare-to to have new issues.
+
+Examples:
+  gt tester triage a1b2c3d4
+  gt tester triage a1b2c3d4 --all
+  gt tester triage a1b2c3d4 --json   # List issues and decisions`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTesterTriage,
+}
+
+func init() {
+	testerTriageCmd.Flags().StringVar(&triageResultsDir, "results", "test-results", "Test results directory")
+	testerTriageCmd.Flags().BoolVar(&triageAll, "all", false, "Revisit issues that already have a decision")
+	testerTriageCmd.Flags().IntVar(&triageLastObs, "observations", 5, "Number of recent observations to show per issue")
+	testerTriageCmd.Flags().BoolVar(&testerJSON, "json", false, "List issues and decisions as JSON (no prompts)")
+
+	testerCmd.AddCommand(testerTriageCmd)
+}
+
+// triageIssue is a new issue with its recorded decision, for --json.
+type triageIssue struct {
+	batch.ComparisonItem
+	Decision *batch.TriageDecision `json:"decision,omitempty"`
+}
+
+func runTesterTriage(cmd *cobra.Command, args []string) error {
+	result, err := batch.LoadBatchResult(triageResultsDir, args[0])
+	if err != nil {
+		return err
+	}
+	if result.Comparison == nil {
+		return fmt.Errorf("batch %s has no comparison (run it with --compare-to)", result.ID)
+	}
+
+	if testerJSON {
+		issues := make([]triageIssue, 0, len(result.Comparison.NewIssues))
+		for _, item := range result.Comparison.NewIssues {
+			issues = append(issues, triageIssue{ComparisonItem: item, Decision: result.DecisionFor(item)})
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(issues)
+	}
+
+	items := result.UntriagedIssues()
+	if triageAll {
+		items = result.Comparison.NewIssues
+	}
+	if len(items) == 0 {
+		fmt.Printf("\n%s No new issues to triage in batch %s.\n", ui.RenderPassIcon(), result.ID)
+		return nil
+	}
+
+	fmt.Printf("\n%s Triage batch %s (vs %s)\n", style.Bold.Render("🔍"), result.ID, result.Comparison.BaselineID)
+	fmt.Printf("   %d new issue(s) to triage\n\n", len(items))
+
+	reader := bufio.NewReader(os.Stdin)
+	decided := make(map[batch.TriageAction]int)
+
+	for i, item := range items {
+		fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
+		fmt.Printf("%s (%d/%d)\n\n", style.Bold.Render(item.Scenario), i+1, len(items))
+		sr := result.ScenarioResult(item.Scenario)
+		showTriageIssue(result, item, sr)
+
+		decision, quit, err := promptTriageAction(reader, result, item, sr)
+		if err != nil {
+			return err
+		}
+		if quit {
+			fmt.Println("\n  Exiting triage.")
+			break
+		}
+		if decision == nil {
+			fmt.Printf("  %s Skipped.\n\n", ui.RenderMuted("→"))
+			continue
+		}
+
+		result.RecordTriage(*decision)
+		if err := batch.SaveBatchResult(triageResultsDir, result); err != nil {
+			return fmt.Errorf("saving triage decision: %w", err)
+		}
+		decided[decision.Action]++
+		fmt.Println()
+	}
+
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Printf("\n%s %d filed, %d quarantined, %d known flake, %d dismissed",
+		style.Bold.Render("Triage:"), decided[batch.TriageFiled], decided[batch.TriageQuarantined],
+		decided[batch.TriageKnownFlake], decided[batch.TriageDismissed])
+	if remaining := len(result.UntriagedIssues()); remaining > 0 {
+		fmt.Printf(", %d remaining", remaining)
+	}
+	fmt.Println()
+	return nil
+}
+
+// showTriageIssue prints an issue with the scenario's error and last
+// observations and screenshots.
+func showTriageIssue(result *batch.BatchResult, item batch.ComparisonItem, sr *batch.ScenarioResult) {
+	fmt.Printf("  %s  %s\n", triageSeverity(item.Severity), item.Description)
+	if d := result.DecisionFor(item); d != nil {
+		fmt.Printf("  %s\n", ui.RenderMuted(fmt.Sprintf("Previously: %s %s", d.Action, d.Note)))
+	}
+	if sr == nil {
+		fmt.Printf("  %s\n\n", ui.RenderMuted("(scenario not in this batch)"))
+		return
+	}
+
+	fmt.Printf("  Status: %s", sr.Status)
+	if sr.Error != "" {
+		fmt.Printf(" - %s", sr.Error)
+	}
+	fmt.Println()
+	if f := sr.Flake; f != nil {
+		line := fmt.Sprintf("Flake rate: %.0f%% over %d runs", f.FlakeRate*100, f.WindowRuns)
+		if f.WindowMode == flake.WindowModeTime {
+			line += " in the " + f.Window
+		}
+		if f.IsFlaky {
+			line += " (flaky)"
+		}
+		fmt.Printf("  %s\n", ui.RenderMuted(line))
+	}
+	if sr.ArtifactDir != "" {
+		fmt.Printf("  Artifacts: %s\n", sr.ArtifactDir)
+	}
+	if sr.ArtifactURL != "" {
+		fmt.Printf("  Artifacts: %s\n", sr.ArtifactURL)
+	}
+
+	obs := lastObservations(sr, triageLastObs)
+	if len(obs) > 0 {
+		fmt.Printf("\n  %s\n", style.Bold.Render("Last observations:"))
+		for _, o := range obs {
+			fmt.Printf("    %s\n", FormatObservationForOutput(o, true))
+			if o.Screenshot != "" {
+				fmt.Printf("      %s\n", ui.RenderMuted("screenshot: "+o.Screenshot))
+			}
+		}
+	}
+	if shots := runScreenshots(sr); len(shots) > 0 {
+		fmt.Printf("\n  Screenshots: %d in %s\n", len(shots), filepath.Dir(shots[0]))
+	}
+	fmt.Println()
+}
+
+// promptTriageAction asks what to do with an issue until it gets an answer.
+// It returns nil for skip.
+func promptTriageAction(reader *bufio.Reader, result *batch.BatchResult, item batch.ComparisonItem, sr *batch.ScenarioResult) (*batch.TriageDecision, bool, error) {
+	for {
+		fmt.Println("  [f] File bead  [q] Quarantine  [k] Known flake  [d] Dismiss  [o] Open  [s] Skip  [x] Exit")
+		fmt.Print("  > ")
+		input, err := reader.ReadString('\n')
+		if err != nil {
+			return nil, false, fmt.Errorf("reading input: %w", err)
+		}
+
+		decision := &batch.TriageDecision{
+			Scenario:    item.Scenario,
+			Description: item.Description,
+			DecidedBy:   detectSender(),
+		}
+
+		switch strings.TrimSpace(strings.ToLower(input)) {
+		case "f", "file", "bead":
+			id, err := fileTriageBead(result, item, sr)
+			if err != nil {
+				fmt.Printf("  %s %v\n", ui.RenderFailIcon(), err)
+				continue
+			}
+			decision.Action = batch.TriageFiled
+			decision.BeadID = id
+			fmt.Printf("  %s Filed %s\n", ui.RenderPassIcon(), id)
+			return decision, false, nil
+
+		case "q", "quarantine":
+			detector, err := detectorFor(triageResultsDir)
+			if err != nil {
+				return nil, false, fmt.Errorf("failed to initialize flake detector: %w", err)
+			}
+			if !detector.IsQuarantined(item.Scenario) {
+				reason := fmt.Sprintf("Triage of batch %s: %s", result.ID, item.Description)
+				if err := detector.Quarantine(item.Scenario, reason); err != nil {
+					fmt.Printf("  %s %v\n", ui.RenderFailIcon(), err)
+					continue
+				}
+			}
+			decision.Action = batch.TriageQuarantined
+			fmt.Printf("  %s Quarantined %s\n", ui.RenderPassIcon(), item.Scenario)
+			return decision, false, nil
+
+		case "k", "known", "flake":
+			decision.Action = batch.TriageKnownFlake
+			decision.Note = readTriageNote(reader)
+			fmt.Printf("  %s Marked as known flake\n", ui.RenderWarnIcon())
+			return decision, false, nil
+
+		case "d", "dismiss":
+			decision.Action = batch.TriageDismissed
+			decision.Note = readTriageNote(reader)
+			fmt.Printf("  %s Dismissed\n", ui.RenderMuted("✗"))
+			return decision, false, nil
+
+		case "o", "open":
+			shots := runScreenshots(sr)
+			if len(shots) == 0 {
+				fmt.Printf("  %s No screenshots for this run\n", ui.RenderWarnIcon())
+				continue
+			}
+			openFile(filepath.Dir(shots[0]))
+
+		case "s", "skip", "":
+			return nil, false, nil
+
+		case "x", "exit", "quit":
+			return nil, true, nil
+
+		default:
+			fmt.Printf("  %s Unknown command\n", ui.RenderWarnIcon())
+		}
+	}
+}
+
+func readTriageNote(reader *bufio.Reader) string {
+	fmt.Print("  Note (optional): ")
+	note, _ := reader.ReadString('\n')
+	return strings.TrimSpace(note)
+}
+
+// fileTriageBead files a bug bead for a new issue and returns its ID.
+func fileTriageBead(result *batch.BatchResult, item batch.ComparisonItem, sr *batch.ScenarioResult) (string, error) {
+	workDir, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+
+	var desc strings.Builder
+	fmt.Fprintf(&desc, "New issue in tester batch %s (vs %s).\n\n", result.ID, result.Comparison.BaselineID)
+	fmt.Fprintf(&desc, "scenario: %s\n", item.Scenario)
+	fmt.Fprintf(&desc, "severity: %s\n", item.Severity)
+	fmt.Fprintf(&desc, "issue: %s\n", item.Description)
+	if sr != nil {
+		if sr.ArtifactDir != "" {
+			fmt.Fprintf(&desc, "artifacts: %s\n", sr.ArtifactDir)
+		}
+		if sr.ArtifactURL != "" {
+			fmt.Fprintf(&desc, "artifact_url: %s\n", sr.ArtifactURL)
+		}
+		if obs := lastObservations(sr, triageLastObs); len(obs) > 0 {
+			desc.WriteString("\nLast observations:\n")
+			for _, o := range obs {
+				fmt.Fprintf(&desc, "- %s\n", FormatObservationForOutput(o, true))
+			}
+		}
+	}
+
+	issue, err := beads.New(workDir).Create(beads.CreateOptions{
+		Title:       fmt.Sprintf("[tester] %s: %s", item.Scenario, item.Description),
+		Type:        "bug",
+		Priority:    triagePriority(item.Severity),
+		Description: desc.String(),
+		Actor:       detectSender(),
+	})
+	if err != nil {
+		return "", fmt.Errorf("creating bead: %w", err)
+	}
+	return issue.ID, nil
+}
+
+// lastObservations returns up to n of the run's most recent observations.
+func lastObservations(sr *batch.ScenarioResult, n int) []Observation {
+	if sr == nil || sr.ArtifactDir == "" || n <= 0 {
+		return nil
+	}
+	obs, err := LoadObservationResult(filepath.Join(sr.ArtifactDir, "observations.json"))
+	if err != nil {
+		return nil
+	}
+	if len(obs.Observations) > n {
+		return obs.Observations[len(obs.Observations)-n:]
+	}
+	return obs.Observations
+}
+
+// runScreenshots lists the screenshots saved for a run.
+func runScreenshots(sr *batch.ScenarioResult) []string {
+	if sr == nil || sr.ArtifactDir == "" {
+		return nil
+	}
+	shots, _ := filepath.Glob(filepath.Join(sr.ArtifactDir, "screenshots", "*.png"))
+	return shots
+}
+
+// triagePriority maps an observation severity (P0-P3) to a bead priority.
+func triagePriority(severity string) int {
+	switch severity {
+	case "P0":
+		return 0
+	case "P1":
+		return 1
+	case "P3":
+		return 3
+	default:
+		return 2
+	}
+}
+
+func triageSeverity(severity string) string {
+	switch severity {
+	case "P0":
+		return ui.RenderFail(severity)
+	case "P1":
+		return ui.RenderWarn(severity)
+	default:
+		return severity
+	}
+}
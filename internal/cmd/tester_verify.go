@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/tester"
+	"github.com/steveyegge/gastown/internal/ui"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// Verify command flags
+var (
+	verifyKey       string
+	verifyPublicKey string
+)
+
+var testerVerifyCmd = &cobra.Command{
+	Use:   "verify <path>",
+	Short: "Check signed results for tampering",
+	Long: `Verify that signed test results are unmodified since they were generated.
+
+Runs and batches started with --sign write a .sig record next to each
+manifest.json and observations.json, holding the file's SHA-256 and an
+HMAC (with the workspace key) or a minisign signature. The signature also
+covers the file's name, its run directory and the signing time, so it
+can't be copied onto another file or run. verify recomputes the digest
+and checks the signature.
+
+<path> is a result file, or a run, batch or results directory, which is
+searched for manifest.json and observations.json files.
+
+HMAC signatures are checked against --key, $GT_TESTER_SIGNING_KEY, or the
+workspace key (.runtime/tester-signing.key in the town root). minisign
+signatures need the public key (--public-key).
+
+Exit codes:
+  0  every file verified
+  1  a file was modified, unsigned, or could not be verified
+
+Examples:
+  gt tester verify test-results/2026-03-07/batch-a1b2c3d4
+  gt tester verify test-results/2026-03-07/signup/run-001/observations.json
+  gt tester verify test-results --public-key tester.pub`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTesterVerify,
+}
+
+func init() {
+	testerVerifyCmd.Flags().StringVar(&verifyKey, "key", "", "HMAC key file (default: $"+tester.SigningKeyEnvVar+" or the workspace key)")
+	testerVerifyCmd.Flags().StringVar(&verifyPublicKey, "public-key", "", "minisign public key file")
+	testerVerifyCmd.Flags().BoolVar(&testerJSON, "json", false, "Output results as JSON")
+
+	testerCmd.AddCommand(testerVerifyCmd)
+}
+
+// resolveSigningKey returns the key file for signing or HMAC verification:
+// the flag value, $GT_TESTER_SIGNING_KEY, or (for HMAC) the workspace key.
+func resolveSigningKey(method, flagValue string) string {
+	if flagValue != "" {
+		return flagValue
+	}
+	if env := os.Getenv(tester.SigningKeyEnvVar); env != "" {
+		return env
+	}
+	if method == tester.SignHMAC {
+		if townRoot, err := workspace.FindFromCwd(); err == nil && townRoot != "" {
+			return tester.DefaultSigningKeyPath(townRoot)
+		}
+	}
+	return ""
+}
+
+// newResultSigner returns the signer for a --sign flag value, or nil when
+// signing is off.
+func newResultSigner(method, keyFlag string) (*tester.Signer, error) {
+	if method == "" {
+		return nil, nil
+	}
+	return tester.NewSigner(method, resolveSigningKey(method, keyFlag))
+}
+
+func runTesterVerify(cmd *cobra.Command, args []string) error {
+	keys := tester.VerifyKeys{
+		HMACKey:           resolveSigningKey(tester.SignHMAC, verifyKey),
+		MinisignPublicKey: verifyPublicKey,
+	}
+	results, err := tester.VerifyResults(args[0], keys)
+	if err != nil {
+		return err
+	}
+	if len(results) == 0 {
+		return fmt.Errorf("no manifest.json or observations.json found under %s", args[0])
+	}
+
+	failed := 0
+	for _, r := range results {
+		if r.Status != tester.VerifyOK {
+			failed++
+		}
+	}
+
+	if testerJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(results); err != nil {
+			return err
+		}
+	} else {
+		for _, r := range results {
+			switch r.Status {
+			case tester.VerifyOK:
+				fmt.Printf("%s %s (%s, signed %s)\n", ui.RenderPassIcon(), r.File, r.Method, r.SignedAt.Format("2006-01-02 15:04"))
+			case tester.VerifyTampered:
+				fmt.Printf("%s %s: %s\n", ui.RenderFailIcon(), r.File, ui.RenderFail("TAMPERED")+" - "+r.Detail)
+			default:
+				fmt.Printf("%s %s: %s\n", ui.RenderWarnIcon(), r.File, r.Detail)
+			}
+		}
+		fmt.Printf("\n%d of %d files verified\n", len(results)-failed, len(results))
+	}
+
+	if failed > 0 {
+		return NewSilentExit(1)
+	}
+	return nil
+}
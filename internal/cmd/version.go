@@ -47,6 +47,14 @@ func init() {
 	}
 }
 
+// versionString describes the running gt build, e.g. "0.4.0 (dev: abc1234)".
+func versionString() string {
+	if commit := resolveCommitHash(); commit != "" {
+		return fmt.Sprintf("%s (%s: %s)", Version, Build, version.ShortCommit(commit))
+	}
+	return fmt.Sprintf("%s (%s)", Version, Build)
+}
+
 func resolveCommitHash() string {
 	if Commit != "" {
 		return Commit
@@ -233,6 +233,31 @@ func validateMergeQueueConfig(c *MergeQueueConfig) error {
 		}
 	}
 
+	// Validate trigger settings if specified
+	switch c.TriggerMode {
+	case "", "poll", "file", "http":
+	default:
+		return fmt.Errorf("invalid trigger_mode %q: want poll, file, or http", c.TriggerMode)
+	}
+	if c.FallbackPollInterval != "" {
+		if _, err := time.ParseDuration(c.FallbackPollInterval); err != nil {
+			return fmt.Errorf("invalid fallback_poll_interval: %w", err)
+		}
+	}
+
+	// Validate push remotes if specified
+	remote := c.Remote
+	if remote == "" {
+		remote = "origin"
+	}
+	seen := map[string]bool{remote: true}
+	for _, name := range c.PushRemotes {
+		if name == "" || seen[name] {
+			return fmt.Errorf("invalid push_remotes: %q is empty, duplicated, or the primary remote", name)
+		}
+		seen[name] = true
+	}
+
 	// Validate non-negative values
 	if c.RetryFlakyTests < 0 {
 		return fmt.Errorf("%w: retry_flaky_tests must be non-negative", ErrMissingField)
@@ -240,6 +265,9 @@ func validateMergeQueueConfig(c *MergeQueueConfig) error {
 	if c.MaxConcurrent < 0 {
 		return fmt.Errorf("%w: max_concurrent must be non-negative", ErrMissingField)
 	}
+	if c.MaxCommitsBehind < 0 {
+		return fmt.Errorf("%w: max_commits_behind must be non-negative", ErrMissingField)
+	}
 
 	return nil
 }
@@ -268,6 +268,14 @@ type WorkflowConfig struct {
 	DefaultFormula string `json:"default_formula,omitempty"`
 }
 
+// PlannerConfig represents planner settings for a rig.
+type PlannerConfig struct {
+	// MaxSessionAge is how long a planning session may stay in questioning
+	// or reviewing before it is flagged stale and the overseer is reminded
+	// (e.g., "7d", "72h"). Default: 7d. "0" disables the timebox.
+	MaxSessionAge string `json:"max_session_age,omitempty"`
+}
+
 // RigSettings represents per-rig behavioral configuration (settings/config.json).
 type RigSettings struct {
 	Type       string            `json:"type"`                  // "rig-settings"
@@ -277,6 +285,7 @@ type RigSettings struct {
 	Namepool   *NamepoolConfig   `json:"namepool,omitempty"`    // polecat name pool settings
 	Crew       *CrewConfig       `json:"crew,omitempty"`        // crew startup settings
 	Workflow   *WorkflowConfig   `json:"workflow,omitempty"`    // workflow settings
+	Planner    *PlannerConfig    `json:"planner,omitempty"`     // planner settings
 	Runtime    *RuntimeConfig    `json:"runtime,omitempty"`     // LLM runtime settings (deprecated: use Agent)
 
 	// Agent selects which agent preset to use for this rig.
@@ -699,6 +708,17 @@ type MergeQueueConfig struct {
 	// TargetBranch is the default branch to merge into (usually "main").
 	TargetBranch string `json:"target_branch"`
 
+	// Remote is the remote fetched from and pushed to. Default: "origin".
+	Remote string `json:"remote,omitempty"`
+
+	// PushRemotes are mirrors the target branch is also pushed to after
+	// each merge (best-effort).
+	PushRemotes []string `json:"push_remotes,omitempty"`
+
+	// CredentialHelper is the git credential helper for fetch and push
+	// (e.g., "store"). Empty uses the repo's git config.
+	CredentialHelper string `json:"credential_helper,omitempty"`
+
 	// IntegrationBranches enables integration branch workflow for epics.
 	IntegrationBranches bool `json:"integration_branches"`
 
@@ -719,6 +739,12 @@ type MergeQueueConfig struct {
 	// TestCommand is the command to run for tests.
 	TestCommand string `json:"test_command,omitempty"`
 
+	// TestRules is a rules file, relative to the repo root, mapping changed
+	// path globs to test commands. The refinery runs only the commands whose
+	// rules match an MR's changed files, falling back to TestCommand when
+	// none match.
+	TestRules string `json:"test_rules,omitempty"`
+
 	// DeleteMergedBranches controls whether to delete branches after merging.
 	DeleteMergedBranches bool `json:"delete_merged_branches"`
 
@@ -728,8 +754,58 @@ type MergeQueueConfig struct {
 	// PollInterval is how often to poll for new merge requests (e.g., "30s").
 	PollInterval string `json:"poll_interval"`
 
+	// TriggerMode is "poll" (default), "file", or "http". The event modes
+	// wake the refinery as soon as gt mq submit announces an MR.
+	TriggerMode string `json:"trigger_mode,omitempty"`
+
+	// TriggerAddr is the local address listened on in "http" trigger mode.
+	// Default: "127.0.0.1:7733".
+	TriggerAddr string `json:"trigger_addr,omitempty"`
+
+	// FallbackPollInterval is how often the queue is polled anyway in the
+	// event trigger modes (e.g., "5m").
+	FallbackPollInterval string `json:"fallback_poll_interval,omitempty"`
+
 	// MaxConcurrent is the maximum number of concurrent merges.
 	MaxConcurrent int `json:"max_concurrent"`
+
+	// RequireUpToDate rejects branches that do not contain the target HEAD
+	// (within MaxCommitsBehind), assigning them back as stale.
+	RequireUpToDate bool `json:"require_up_to_date,omitempty"`
+
+	// MaxCommitsBehind is how many target commits a branch may be missing
+	// when RequireUpToDate is set. Default: 0 (must contain target HEAD).
+	MaxCommitsBehind int `json:"max_commits_behind,omitempty"`
+
+	// AutoBisect bisects test failures across an MR's commits to name the
+	// commit that broke the tests. It does not bisect across MRs: the
+	// refinery merges one MR at a time and has no batches yet.
+	AutoBisect bool `json:"auto_bisect,omitempty"`
+
+	// Changelog appends an entry for each merged MR to ChangelogPath as
+	// part of the merge commit.
+	Changelog bool `json:"changelog,omitempty"`
+
+	// ChangelogPath is the changelog file, or a fragment directory if it
+	// ends in "/". Default: "CHANGELOG.md".
+	ChangelogPath string `json:"changelog_path,omitempty"`
+
+	// ChangelogTemplate is a Go text/template for each entry.
+	// Fields: .Title, .SourceIssue, .Worker, .Branch, .Target, .MR, .Date
+	ChangelogTemplate string `json:"changelog_template,omitempty"`
+
+	// SignCommits signs merge commits and verifies the signature before push.
+	SignCommits bool `json:"sign_commits,omitempty"`
+
+	// SigningFormat is "gpg" (default) or "ssh".
+	SigningFormat string `json:"signing_format,omitempty"`
+
+	// SigningKey is a GPG key ID or SSH key path. Empty uses user.signingkey.
+	SigningKey string `json:"signing_key,omitempty"`
+
+	// RiskApprovalThreshold assigns MRs with a risk score (0-100) at or above
+	// it to the overseer for approval. 0 disables the gate.
+	RiskApprovalThreshold int `json:"risk_approval_threshold,omitempty"`
 }
 
 // OnConflict strategy constants.
@@ -23,6 +23,7 @@ import (
 	"github.com/steveyegge/gastown/internal/deacon"
 	"github.com/steveyegge/gastown/internal/events"
 	"github.com/steveyegge/gastown/internal/feed"
+	"github.com/steveyegge/gastown/internal/mail"
 	"github.com/steveyegge/gastown/internal/polecat"
 	"github.com/steveyegge/gastown/internal/refinery"
 	"github.com/steveyegge/gastown/internal/rig"
@@ -274,6 +275,10 @@ func (d *Daemon) heartbeat(state *State) {
 	// This is a safety net - Deacon patrol also does this more frequently.
 	d.cleanupOrphanedProcesses()
 
+	// 13. Retry mail that failed delivery (SendOrQueue), dead-lettering
+	// messages that keep failing
+	d.retryUndeliveredMail()
+
 	// Update state
 	state.LastHeartbeat = time.Now()
 	state.HeartbeatCount++
@@ -987,6 +992,20 @@ Manual intervention may be required.`,
 	}
 }
 
+// retryUndeliveredMail retries queued mail deliveries that are due.
+func (d *Daemon) retryUndeliveredMail() {
+	router := mail.NewRouterWithTownRoot(d.config.TownRoot, d.config.TownRoot)
+	stats, err := router.RetryPending()
+	if err != nil {
+		d.logger.Printf("Warning: mail retry failed: %v", err)
+		return
+	}
+	if stats.Delivered > 0 || stats.DeadLettered > 0 {
+		d.logger.Printf("Mail retry: %d delivered, %d dead-lettered, %d pending",
+			stats.Delivered, stats.DeadLettered, stats.Pending)
+	}
+}
+
 // cleanupOrphanedProcesses kills orphaned claude subagent processes.
 // These are Task tool subagents that didn't clean up after completion.
 // Detection uses TTY column: processes with TTY "?" have no controlling terminal.
@@ -3,10 +3,12 @@ package git
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
 )
 
@@ -36,7 +38,8 @@ func (e *GitError) Unwrap() error {
 // Git wraps git operations for a working directory.
 type Git struct {
 	workDir string
-	gitDir  string // Optional: explicit git directory (for bare repos)
+	gitDir  string   // Optional: explicit git directory (for bare repos)
+	config  []string // Optional: -c overrides applied to every command
 }
 
 // NewGit creates a new Git wrapper for the given directory.
@@ -56,6 +59,27 @@ func (g *Git) WorkDir() string {
 	return g.workDir
 }
 
+// SetSigning signs every commit this Git instance creates (commits, merges,
+// amends). format is "gpg" or "ssh"; an empty key uses the repo's
+// user.signingkey. Signing is applied via -c overrides so the repo's own
+// config is left untouched.
+func (g *Git) SetSigning(format, key string) {
+	g.config = append(g.config, "-c", "commit.gpgsign=true")
+	if format != "" {
+		g.config = append(g.config, "-c", "gpg.format="+format)
+	}
+	if key != "" {
+		g.config = append(g.config, "-c", "user.signingkey="+key)
+	}
+}
+
+// SetCredentialHelper makes fetches and pushes by this Git instance use
+// helper (e.g. "store", "cache --timeout=3600" or "!gh auth git-credential")
+// in place of any helpers in the repo's config.
+func (g *Git) SetCredentialHelper(helper string) {
+	g.config = append(g.config, "-c", "credential.helper=", "-c", "credential.helper="+helper)
+}
+
 // IsRepo returns true if the workDir is a git repository.
 func (g *Git) IsRepo() bool {
 	_, err := g.run("rev-parse", "--git-dir")
@@ -68,6 +92,9 @@ func (g *Git) run(args ...string) (string, error) {
 	if g.gitDir != "" {
 		args = append([]string{"--git-dir=" + g.gitDir}, args...)
 	}
+	if len(g.config) > 0 {
+		args = append(append([]string{}, g.config...), args...)
+	}
 
 	cmd := exec.Command("git", args...)
 	if g.workDir != "" {
@@ -95,9 +122,13 @@ func (g *Git) wrapError(err error, stdout, stderr string, args []string) error {
 
 	// Determine command name (first arg, or first non-flag arg)
 	command := ""
-	for _, arg := range args {
-		if !strings.HasPrefix(arg, "-") {
-			command = arg
+	for i := 0; i < len(args); i++ {
+		if args[i] == "-c" {
+			i++ // skip the config override value
+			continue
+		}
+		if !strings.HasPrefix(args[i], "-") {
+			command = args[i]
 			break
 		}
 	}
@@ -263,6 +294,12 @@ func (g *Git) Commit(message string) error {
 	return err
 }
 
+// CommitAmend folds staged changes into HEAD, keeping its message.
+func (g *Git) CommitAmend() error {
+	_, err := g.run("commit", "--amend", "--no-edit")
+	return err
+}
+
 // CommitAll stages all changes and commits.
 func (g *Git) CommitAll(message string) error {
 	_, err := g.run("commit", "-am", message)
@@ -411,12 +448,73 @@ func (g *Git) Rebase(onto string) error {
 	return err
 }
 
+// RebaseOnto replays the commits of branch after upstream onto newBase
+// (git rebase --onto newBase upstream branch), leaving branch checked out.
+func (g *Git) RebaseOnto(newBase, upstream, branch string) error {
+	_, err := g.run("rebase", "--onto", newBase, upstream, branch)
+	return err
+}
+
 // AbortMerge aborts a merge in progress.
 func (g *Git) AbortMerge() error {
 	_, err := g.run("merge", "--abort")
 	return err
 }
 
+// MergeInProgress reports whether a merge was left unfinished (MERGE_HEAD
+// exists), e.g. by a process killed mid-merge.
+func (g *Git) MergeInProgress() bool {
+	_, err := g.run("rev-parse", "-q", "--verify", "MERGE_HEAD")
+	return err == nil
+}
+
+// FsckQuick checks that every object reachable from the refs is present,
+// without reading blob contents (git fsck --connectivity-only). It catches
+// corruption such as missing objects in a fraction of a full fsck's time.
+func (g *Git) FsckQuick() error {
+	_, err := g.run("fsck", "--connectivity-only", "--no-dangling", "--no-progress")
+	return err
+}
+
+// Revert commits the inverse of commit onto the current branch. Merge
+// commits are reverted against their first parent (git revert -m 1).
+func (g *Git) Revert(commit string) error {
+	parents, err := g.Parents(commit)
+	if err != nil {
+		return err
+	}
+	args := []string{"revert", "--no-edit"}
+	if len(parents) > 1 {
+		args = append(args, "-m", "1")
+	}
+	_, err = g.run(append(args, commit)...)
+	return err
+}
+
+// AbortRevert aborts a revert in progress.
+func (g *Git) AbortRevert() error {
+	_, err := g.run("revert", "--abort")
+	return err
+}
+
+// Parents returns the parent commits of commit.
+func (g *Git) Parents(commit string) ([]string, error) {
+	out, err := g.run("rev-list", "--parents", "-n", "1", commit)
+	if err != nil {
+		return nil, err
+	}
+	fields := strings.Fields(out)
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("commit %s not found", commit)
+	}
+	return fields[1:], nil
+}
+
+// CommitSubject returns the subject line of commit's message.
+func (g *Git) CommitSubject(commit string) (string, error) {
+	return g.run("log", "-1", "--format=%s", commit)
+}
+
 // CheckConflicts performs a test merge to check if source can be merged into target
 // without conflicts. Returns a list of conflicting files, or empty slice if clean.
 // The merge is always aborted after checking - no actual changes are made.
@@ -578,11 +676,36 @@ func (g *Git) ResetBranch(name, ref string) error {
 	return err
 }
 
+// ResetHard resets the current branch, index and working tree to ref.
+func (g *Git) ResetHard(ref string) error {
+	_, err := g.run("reset", "--hard", ref)
+	return err
+}
+
 // Rev returns the commit hash for the given ref.
 func (g *Git) Rev(ref string) (string, error) {
 	return g.run("rev-parse", ref)
 }
 
+// IsSigned reports whether the commit at ref carries a signature (GPG,
+// SSH or X.509). It checks that a signature is present, not that it is
+// trusted, so it works without an allowed-signers file or keyring.
+func (g *Git) IsSigned(ref string) (bool, error) {
+	out, err := g.run("cat-file", "commit", ref)
+	if err != nil {
+		return false, err
+	}
+	for _, line := range strings.Split(out, "\n") {
+		if line == "" {
+			break // end of headers
+		}
+		if strings.HasPrefix(line, "gpgsig ") || strings.HasPrefix(line, "gpgsig-sha256 ") {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 // IsAncestor checks if ancestor is an ancestor of descendant.
 func (g *Git) IsAncestor(ancestor, descendant string) (bool, error) {
 	_, err := g.run("merge-base", "--is-ancestor", ancestor, descendant)
@@ -890,6 +1013,139 @@ func (g *Git) CommitsAhead(base, branch string) (int, error) {
 	return count, nil
 }
 
+// ChangedFiles returns the files changed on head since it diverged from base
+// (git diff --name-only base...head).
+func (g *Git) ChangedFiles(base, head string) ([]string, error) {
+	out, err := g.run("diff", "--name-only", base+"..."+head)
+	if err != nil {
+		return nil, err
+	}
+	if out == "" {
+		return nil, nil
+	}
+	return strings.Split(out, "\n"), nil
+}
+
+// FileDiffStat is one file's line counts from git diff --numstat.
+type FileDiffStat struct {
+	Path    string
+	Added   int
+	Deleted int
+	Binary  bool // numstat reports "-" counts for binary files
+}
+
+// DiffNumstat returns per-file line counts for head since it diverged from
+// base (git diff --numstat base...head). Rename detection is off, so a
+// renamed file is reported as a deletion of the old path and an addition of
+// the new one.
+func (g *Git) DiffNumstat(base, head string) ([]FileDiffStat, error) {
+	out, err := g.run("diff", "--numstat", "--no-renames", base+"..."+head)
+	if err != nil {
+		return nil, err
+	}
+
+	var stats []FileDiffStat
+	for _, line := range splitLines(out) {
+		parts := strings.SplitN(line, "\t", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		stat := FileDiffStat{Path: parts[2]}
+		if parts[0] == "-" && parts[1] == "-" {
+			stat.Binary = true
+		} else {
+			stat.Added, _ = strconv.Atoi(parts[0])
+			stat.Deleted, _ = strconv.Atoi(parts[1])
+		}
+		stats = append(stats, stat)
+	}
+	return stats, nil
+}
+
+// DiffNames returns the files changed by rev, relative to the repo root.
+// A range ("a..b" or "a...b") is diffed as given; a single revision is
+// diffed against the working tree, including untracked files.
+func (g *Git) DiffNames(rev string) ([]string, error) {
+	out, err := g.run("diff", "--name-only", rev)
+	if err != nil {
+		return nil, err
+	}
+	files := splitLines(out)
+
+	if !strings.Contains(rev, "..") {
+		// ls-files reports paths relative to the cwd; --full-name makes
+		// them root-relative like diff's
+		untracked, err := g.run("ls-files", "--others", "--exclude-standard", "--full-name", ":/")
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, splitLines(untracked)...)
+	}
+	return files, nil
+}
+
+// TopLevel returns the absolute path of the repository root.
+func (g *Git) TopLevel() (string, error) {
+	return g.run("rev-parse", "--show-toplevel")
+}
+
+func splitLines(out string) []string {
+	if out == "" {
+		return nil
+	}
+	return strings.Split(out, "\n")
+}
+
+// CommitRange returns the commits on head that are not on base, oldest first
+// (git rev-list --reverse --first-parent base..head).
+func (g *Git) CommitRange(base, head string) ([]string, error) {
+	out, err := g.run("rev-list", "--reverse", "--first-parent", base+".."+head)
+	if err != nil {
+		return nil, err
+	}
+	if out == "" {
+		return nil, nil
+	}
+	return strings.Split(out, "\n"), nil
+}
+
+// MergeBase returns the best common ancestor of a and b.
+func (g *Git) MergeBase(a, b string) (string, error) {
+	return g.run("merge-base", a, b)
+}
+
+// MergeTree performs an in-memory merge of theirs into ours without touching
+// the index or working tree (git merge-tree --write-tree), returning git's
+// raw output: the result tree, the conflicted paths and the conflict messages.
+// clean reports whether the merge had no conflicts.
+func (g *Git) MergeTree(ours, theirs string) (out string, clean bool, err error) {
+	out, err = g.run("merge-tree", "--write-tree", "--messages", ours, theirs)
+	if err == nil {
+		return out, true, nil
+	}
+	// Exit code 1 means the merge has conflicts, not an error
+	var gitErr *GitError
+	if errors.As(err, &gitErr) && strings.Contains(gitErr.Err.Error(), "exit status 1") {
+		return gitErr.Stdout, false, nil
+	}
+	return "", false, err
+}
+
+// LogOneline returns the commits on head that are not on base, newest first,
+// as one-line summaries (git log --oneline base..head). With paths, only
+// commits touching them are listed.
+func (g *Git) LogOneline(base, head string, paths ...string) ([]string, error) {
+	args := []string{"log", "--oneline", "--no-decorate", base + ".." + head}
+	if len(paths) > 0 {
+		args = append(append(args, "--"), paths...)
+	}
+	out, err := g.run(args...)
+	if err != nil {
+		return nil, err
+	}
+	return splitLines(out), nil
+}
+
 // CountCommitsBehind returns the number of commits that HEAD is behind the given ref.
 // For example, CountCommitsBehind("origin/main") returns how many commits
 // are on origin/main that are not on the current HEAD.
@@ -4,6 +4,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"reflect"
 	"testing"
 )
 
@@ -326,6 +327,127 @@ func TestCheckConflicts_NoConflict(t *testing.T) {
 	}
 }
 
+func TestChangedFiles(t *testing.T) {
+	dir := initTestRepo(t)
+	g := NewGit(dir)
+	mainBranch, _ := g.CurrentBranch()
+
+	if err := g.CreateBranch("feature"); err != nil {
+		t.Fatalf("CreateBranch: %v", err)
+	}
+	if err := g.Checkout("feature"); err != nil {
+		t.Fatalf("Checkout feature: %v", err)
+	}
+
+	// No changes yet
+	files, err := g.ChangedFiles(mainBranch, "HEAD")
+	if err != nil {
+		t.Fatalf("ChangedFiles: %v", err)
+	}
+	if len(files) != 0 {
+		t.Errorf("expected no changed files, got %v", files)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "feature.txt"), []byte("feature"), 0644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+	if err := g.Add("feature.txt"); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if err := g.Commit("add feature file"); err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+
+	files, err = g.ChangedFiles(mainBranch, "HEAD")
+	if err != nil {
+		t.Fatalf("ChangedFiles: %v", err)
+	}
+	if len(files) != 1 || files[0] != "feature.txt" {
+		t.Errorf("ChangedFiles = %v, want [feature.txt]", files)
+	}
+}
+
+func TestDiffNumstat(t *testing.T) {
+	dir := initTestRepo(t)
+	g := NewGit(dir)
+	mainBranch, _ := g.CurrentBranch()
+
+	if err := g.CreateBranch("feature"); err != nil {
+		t.Fatalf("CreateBranch: %v", err)
+	}
+	if err := g.Checkout("feature"); err != nil {
+		t.Fatalf("Checkout feature: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "feature.txt"), []byte("a\nb\nc\n"), 0644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "blob.bin"), []byte{0, 1, 2, 0}, 0644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+	if err := g.Add("feature.txt", "blob.bin"); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if err := g.Commit("add files"); err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+
+	stats, err := g.DiffNumstat(mainBranch, "HEAD")
+	if err != nil {
+		t.Fatalf("DiffNumstat: %v", err)
+	}
+	want := []FileDiffStat{
+		{Path: "blob.bin", Binary: true},
+		{Path: "feature.txt", Added: 3},
+	}
+	if !reflect.DeepEqual(stats, want) {
+		t.Errorf("DiffNumstat = %+v, want %+v", stats, want)
+	}
+}
+
+func TestCommitRange(t *testing.T) {
+	dir := initTestRepo(t)
+	g := NewGit(dir)
+	mainBranch, _ := g.CurrentBranch()
+
+	if err := g.CreateBranch("feature"); err != nil {
+		t.Fatalf("CreateBranch: %v", err)
+	}
+	if err := g.Checkout("feature"); err != nil {
+		t.Fatalf("Checkout feature: %v", err)
+	}
+
+	var want []string
+	for _, name := range []string{"a.txt", "b.txt"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(name), 0644); err != nil {
+			t.Fatalf("write file: %v", err)
+		}
+		if err := g.Add(name); err != nil {
+			t.Fatalf("Add: %v", err)
+		}
+		if err := g.Commit("add " + name); err != nil {
+			t.Fatalf("Commit: %v", err)
+		}
+		sha, _ := g.Rev("HEAD")
+		want = append(want, sha)
+	}
+
+	commits, err := g.CommitRange(mainBranch, "feature")
+	if err != nil {
+		t.Fatalf("CommitRange: %v", err)
+	}
+	if len(commits) != 2 || commits[0] != want[0] || commits[1] != want[1] {
+		t.Errorf("CommitRange = %v, want %v (oldest first)", commits, want)
+	}
+
+	commits, err = g.CommitRange("feature", mainBranch)
+	if err != nil {
+		t.Fatalf("CommitRange: %v", err)
+	}
+	if len(commits) != 0 {
+		t.Errorf("expected no commits, got %v", commits)
+	}
+}
+
 func TestCheckConflicts_WithConflict(t *testing.T) {
 	dir := initTestRepo(t)
 	g := NewGit(dir)
@@ -488,3 +610,109 @@ func stringContains(s, substr string) bool {
 	}
 	return false
 }
+
+func TestSetSigning_SSH(t *testing.T) {
+	if _, err := exec.LookPath("ssh-keygen"); err != nil {
+		t.Skip("ssh-keygen not available")
+	}
+	dir := initTestRepo(t)
+	key := filepath.Join(t.TempDir(), "id_ed25519")
+	if out, err := exec.Command("ssh-keygen", "-q", "-t", "ed25519", "-N", "", "-f", key).CombinedOutput(); err != nil {
+		t.Fatalf("ssh-keygen: %v: %s", err, out)
+	}
+
+	g := NewGit(dir)
+	signed, err := g.IsSigned("HEAD")
+	if err != nil {
+		t.Fatalf("IsSigned: %v", err)
+	}
+	if signed {
+		t.Error("expected initial commit to be unsigned")
+	}
+
+	g.SetSigning("ssh", key)
+	if err := os.WriteFile(filepath.Join(dir, "signed.txt"), []byte("x"), 0644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+	if err := g.Add("signed.txt"); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if err := g.Commit("signed commit"); err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+
+	signed, err = g.IsSigned("HEAD")
+	if err != nil {
+		t.Fatalf("IsSigned: %v", err)
+	}
+	if !signed {
+		t.Error("expected signed commit")
+	}
+}
+
+func TestSetSigning_MissingKeyReportsCommand(t *testing.T) {
+	dir := initTestRepo(t)
+	g := NewGit(dir)
+	g.SetSigning("ssh", filepath.Join(t.TempDir(), "missing"))
+
+	if err := os.WriteFile(filepath.Join(dir, "x.txt"), []byte("x"), 0644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+	_ = g.Add("x.txt")
+	err := g.Commit("should fail")
+	if err == nil {
+		t.Fatal("expected commit with a missing signing key to fail")
+	}
+	gitErr, ok := err.(*GitError)
+	if !ok || gitErr.Command != "commit" {
+		t.Errorf("expected GitError for commit, got %#v", err)
+	}
+}
+
+func TestDiffNames(t *testing.T) {
+	dir := initTestRepo(t)
+	g := NewGit(dir)
+
+	if err := os.MkdirAll(filepath.Join(dir, "src"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "src", "committed.go"), []byte("package src\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	_ = g.Add("src/committed.go")
+	if err := g.Commit("add src"); err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("# Changed\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "src", "new.go"), []byte("package src\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Range: only the committed change
+	files, err := g.DiffNames("HEAD~1..HEAD")
+	if err != nil {
+		t.Fatalf("DiffNames range: %v", err)
+	}
+	if len(files) != 1 || files[0] != "src/committed.go" {
+		t.Errorf("DiffNames(range) = %v, want [src/committed.go]", files)
+	}
+
+	// Single revision: working tree changes plus untracked files,
+	// root-relative even from a subdirectory
+	sub := NewGit(filepath.Join(dir, "src"))
+	files, err = sub.DiffNames("HEAD")
+	if err != nil {
+		t.Fatalf("DiffNames working tree: %v", err)
+	}
+	want := map[string]bool{"README.md": true, "src/new.go": true}
+	if len(files) != len(want) {
+		t.Fatalf("DiffNames(HEAD) = %v, want README.md and src/new.go", files)
+	}
+	for _, f := range files {
+		if !want[f] {
+			t.Errorf("unexpected changed file %q", f)
+		}
+	}
+}
@@ -0,0 +1,220 @@
+package librarian
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+)
+
+// DaemonConfig configures a librarian daemon.
+type DaemonConfig struct {
+	TownRoot string
+	RigRoot  string
+	Depth    EnrichmentDepth
+
+	// RigPath is the rig whose librarian/skills override or extend town
+	// skills. Empty means town skills only.
+	RigPath string
+
+	// SkillInterval is how often the skills directory is rescanned.
+	SkillInterval time.Duration
+
+	// EnrichInterval is how often open beads are checked for enrichment.
+	EnrichInterval time.Duration
+
+	// TargetContext fits enrichments to this many tokens (0 = no limit).
+	TargetContext int
+
+	Logger func(format string, args ...interface{})
+}
+
+// Daemon enriches new beads with matching skills, hot-reloading the skills
+// directory as it changes so skill edits take effect without a restart.
+type Daemon struct {
+	config   DaemonConfig
+	registry *SkillRegistry
+	watcher  *SkillWatcher
+	injector *Injector
+	beads    *beads.Beads
+	feedback *FeedbackStore
+
+	// enriched holds beads that already have an injection record.
+	enriched map[string]bool
+
+	// unmatched holds beads that matched no skill and no prior work. They
+	// are retried when a skill is added or updated.
+	unmatched map[string]bool
+
+	// indexModTime is the modification time of the embedding index last
+	// loaded (zero if there was none).
+	indexModTime time.Time
+}
+
+// NewDaemon creates a librarian daemon.
+func NewDaemon(config DaemonConfig) *Daemon {
+	registry := NewSkillRegistry(config.TownRoot)
+	registry.SetRigPath(config.RigPath)
+	injector := NewInjector(config.TownRoot, config.RigRoot)
+	injector.SetSkillRegistry(registry)
+	injector.SetTargetContext(config.TargetContext)
+	return &Daemon{
+		config:    config,
+		registry:  registry,
+		watcher:   NewSkillWatcher(registry),
+		injector:  injector,
+		beads:     beads.New(config.RigRoot),
+		feedback:  NewFeedbackStore(config.TownRoot),
+		unmatched: make(map[string]bool),
+	}
+}
+
+// EnrichmentsDir returns where the daemon writes enrichments, one
+// <bead-id>.md file per bead.
+func (d *Daemon) EnrichmentsDir() string {
+	return filepath.Join(d.config.TownRoot, "librarian", ".enrichments")
+}
+
+// Run loads skills and enriches beads until ctx is cancelled.
+func (d *Daemon) Run(ctx context.Context) error {
+	records, err := d.feedback.injections()
+	if err != nil {
+		return fmt.Errorf("reading injection records: %w", err)
+	}
+	d.enriched = make(map[string]bool, len(records))
+	for _, rec := range records {
+		d.enriched[rec.BeadID] = true
+	}
+
+	if err := d.reloadSkills(); err != nil {
+		return err
+	}
+	d.enrichPending()
+
+	skillTicker := time.NewTicker(d.config.SkillInterval)
+	defer skillTicker.Stop()
+	enrichTicker := time.NewTicker(d.config.EnrichInterval)
+	defer enrichTicker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-skillTicker.C:
+			if err := d.reloadSkills(); err != nil {
+				d.config.Logger("skills: %v", err)
+			}
+		case <-enrichTicker.C:
+			d.enrichPending()
+		}
+	}
+}
+
+// reloadSkills applies skill file changes and logs each one.
+func (d *Daemon) reloadSkills() error {
+	changes, err := d.watcher.Scan()
+	if err != nil {
+		return err
+	}
+	for _, c := range changes {
+		d.config.Logger("skills: %s", c)
+		if c.Kind == SkillAdded || c.Kind == SkillUpdated {
+			// A new or changed skill may now match beads that had none
+			d.unmatched = make(map[string]bool)
+		}
+	}
+	return d.reloadIndex()
+}
+
+// reloadIndex reloads the skill embedding index when 'gt librarian skills
+// index' has rebuilt (or removed) it.
+func (d *Daemon) reloadIndex() error {
+	var modTime time.Time
+	if info, err := os.Stat(d.registry.IndexPath()); err == nil {
+		modTime = info.ModTime()
+	}
+	if modTime.Equal(d.indexModTime) {
+		return nil
+	}
+	if err := d.registry.LoadIndex(); err != nil {
+		return err
+	}
+	d.indexModTime = modTime
+	if idx := d.registry.Index(); idx != nil {
+		d.config.Logger("skills: loaded embedding index (%d skills, %s)", len(idx.Entries), idx.Model)
+		// Beads may now match on similarity
+		d.unmatched = make(map[string]bool)
+	}
+	return nil
+}
+
+// enrichPending enriches open, unassigned beads that have not been enriched.
+func (d *Daemon) enrichPending() {
+	issues, err := d.beads.List(beads.ListOptions{
+		Status:     "open",
+		NoAssignee: true,
+		Priority:   -1,
+	})
+	if err != nil {
+		d.config.Logger("listing beads: %v", err)
+		return
+	}
+	for _, issue := range issues {
+		if d.enriched[issue.ID] || d.unmatched[issue.ID] {
+			continue
+		}
+		if err := d.enrich(issue.ID); err != nil {
+			d.config.Logger("enriching %s: %v", issue.ID, err)
+		}
+	}
+}
+
+// enrich injects skills into one bead, writes the enrichment, and records
+// which skill versions were used.
+func (d *Daemon) enrich(beadID string) error {
+	result, err := d.injector.InjectForBead(beadID, d.config.Depth)
+	if err != nil {
+		return err
+	}
+	if result.PriorArtErr != nil {
+		d.config.Logger("  prior work search for %s: %v", beadID, result.PriorArtErr)
+	}
+	if len(result.MatchedSkills) == 0 && len(result.PriorWork) == 0 {
+		d.unmatched[beadID] = true
+		return nil
+	}
+
+	if err := os.MkdirAll(d.EnrichmentsDir(), 0755); err != nil {
+		return fmt.Errorf("creating enrichments directory: %w", err)
+	}
+	path := filepath.Join(d.EnrichmentsDir(), beadID+".md")
+	if err := os.WriteFile(path, []byte(result.Enrichment), 0644); err != nil {
+		return fmt.Errorf("writing enrichment: %w", err)
+	}
+	if err := d.feedback.RecordInjection(beadID, d.config.Depth, result.MatchedSkills); err != nil {
+		return fmt.Errorf("recording injection: %w", err)
+	}
+	d.enriched[beadID] = true
+
+	d.config.Logger("enriched %s with %s", beadID, formatSkillVersions(result.MatchedSkills))
+	if len(result.Stats.Omitted) > 0 {
+		d.config.Logger("  omitted from %s to fit %d tokens: %s", beadID, d.config.TargetContext, strings.Join(result.Stats.Omitted, ", "))
+	}
+	return nil
+}
+
+// formatSkillVersions lists skills as "id@version".
+func formatSkillVersions(skills []*Skill) string {
+	parts := make([]string, len(skills))
+	for i, s := range skills {
+		parts[i] = s.ID
+		if s.Version != "" {
+			parts[i] += "@" + s.Version
+		}
+	}
+	return strings.Join(parts, ", ")
+}
@@ -25,6 +25,7 @@ var EnrichmentLimits = struct {
 	MaxPatterns       int
 	MaxContextNotes   int
 	MaxTotalSizeBytes int
+	MaxSnapshotBytes  int
 }{
 	MaxFiles:          10,
 	MaxPriorBeads:     5,
@@ -32,6 +33,16 @@ var EnrichmentLimits = struct {
 	MaxPatterns:       5,
 	MaxContextNotes:   1024, // bytes
 	MaxTotalSizeBytes: 10240,
+	MaxSnapshotBytes:  8192, // all doc snapshots combined, on top of the total
+}
+
+// BytesPerToken is the rough bytes-per-token ratio used to estimate how
+// much of a model's context an enrichment takes.
+const BytesPerToken = 4
+
+// EstimateTokens estimates the tokens a model needs for s.
+func EstimateTokens(s string) int {
+	return (len(s) + BytesPerToken - 1) / BytesPerToken
 }
 
 // EnrichmentBuilder builds enrichment content from matched skills.
@@ -44,6 +55,11 @@ type EnrichmentBuilder struct {
 	depth        EnrichmentDepth
 	startTime    time.Time
 	rigRoot      string
+
+	// targetTokens caps the built enrichment (0 = no cap); omitted counts
+	// what was dropped to meet it, by kind.
+	targetTokens int
+	omitted      map[string]int
 }
 
 type fileEntry struct {
@@ -62,6 +78,7 @@ type docEntry struct {
 	title       string
 	url         string
 	description string
+	snapshot    *DocSnapshot
 }
 
 type priorWorkEntry struct {
@@ -156,6 +173,28 @@ func (b *EnrichmentBuilder) AddDoc(title, url, description string) {
 	})
 }
 
+// SnapshotDocs fetches markdown snapshots for the documentation links that
+// will be rendered. Docs that cannot be fetched keep their plain link; the
+// errors are returned so callers can report them.
+func (b *EnrichmentBuilder) SnapshotDocs(s *DocSnapshotter) []error {
+	var errs []error
+	for i := range b.docs {
+		if i >= EnrichmentLimits.MaxDocs {
+			break
+		}
+		if b.docs[i].url == "" {
+			continue
+		}
+		snap, err := s.Snapshot(b.docs[i].url)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		b.docs[i].snapshot = snap
+	}
+	return errs
+}
+
 // AddPriorWork adds a prior work reference to the enrichment.
 func (b *EnrichmentBuilder) AddPriorWork(id, status, title, learning string) {
 	b.priorWork = append(b.priorWork, priorWorkEntry{
@@ -171,8 +210,99 @@ func (b *EnrichmentBuilder) AddContextNote(note string) {
 	b.contextNotes = append(b.contextNotes, note)
 }
 
+// SetTargetTokens fits the enrichment to a model context budget. Build
+// drops the least relevant content first (doc snapshots, context notes,
+// docs, prior work, then patterns and files from the last-injected skill
+// back) until the estimate fits, and notes what was omitted. Zero disables
+// the budget.
+func (b *EnrichmentBuilder) SetTargetTokens(tokens int) {
+	b.targetTokens = tokens
+}
+
 // Build generates the enrichment markdown content.
 func (b *EnrichmentBuilder) Build(summary string) string {
+	result := b.render(summary)
+	if b.targetTokens <= 0 {
+		return result
+	}
+	for EstimateTokens(result) > b.targetTokens && b.dropLeastRelevant() {
+		result = b.render(summary) + b.omittedNote()
+	}
+	return result
+}
+
+// dropLeastRelevant removes the lowest-priority entry that would be
+// rendered and records it as omitted. It returns false once only the
+// header and summary are left.
+func (b *EnrichmentBuilder) dropLeastRelevant() bool {
+	if b.omitted == nil {
+		b.omitted = make(map[string]int)
+	}
+	for i := min(len(b.docs), EnrichmentLimits.MaxDocs) - 1; i >= 0; i-- {
+		if b.docs[i].snapshot != nil {
+			b.docs[i].snapshot = nil
+			b.omitted["doc snapshots"]++
+			return true
+		}
+	}
+	switch {
+	case b.shownContextNotes() > 0:
+		b.contextNotes = b.contextNotes[:b.shownContextNotes()-1]
+		b.omitted["context notes"]++
+	case len(b.docs) > 0:
+		b.docs = b.docs[:min(len(b.docs), EnrichmentLimits.MaxDocs)-1]
+		b.omitted["docs"]++
+	case len(b.priorWork) > 0:
+		b.priorWork = b.priorWork[:min(len(b.priorWork), EnrichmentLimits.MaxPriorBeads)-1]
+		b.omitted["prior work"]++
+	case len(b.patterns) > 0:
+		b.patterns = b.patterns[:min(len(b.patterns), EnrichmentLimits.MaxPatterns)-1]
+		b.omitted["patterns"]++
+	case len(b.files) > 0:
+		b.files = b.files[:min(len(b.files), EnrichmentLimits.MaxFiles)-1]
+		b.omitted["files"]++
+	default:
+		return false
+	}
+	return true
+}
+
+// shownContextNotes returns how many context notes fit in
+// EnrichmentLimits.MaxContextNotes.
+func (b *EnrichmentBuilder) shownContextNotes() int {
+	totalSize := 0
+	for i, note := range b.contextNotes {
+		if totalSize+len(note) > EnrichmentLimits.MaxContextNotes {
+			return i
+		}
+		totalSize += len(note)
+	}
+	return len(b.contextNotes)
+}
+
+// omittedOrder lists omitted kinds in the order they are reported.
+var omittedOrder = []string{"files", "patterns", "prior work", "docs", "context notes", "doc snapshots"}
+
+// Omitted describes what was dropped to meet the token target, e.g.
+// ["2 patterns", "1 doc snapshots"]. It is empty until Build trims.
+func (b *EnrichmentBuilder) Omitted() []string {
+	var omitted []string
+	for _, kind := range omittedOrder {
+		if n := b.omitted[kind]; n > 0 {
+			omitted = append(omitted, fmt.Sprintf("%d %s", n, kind))
+		}
+	}
+	return omitted
+}
+
+// omittedNote tells the reader that content was left out.
+func (b *EnrichmentBuilder) omittedNote() string {
+	return fmt.Sprintf("\n> Omitted to fit a %d-token context: %s\n",
+		b.targetTokens, strings.Join(b.Omitted(), ", "))
+}
+
+// render generates the enrichment from the builder's current content.
+func (b *EnrichmentBuilder) render(summary string) string {
 	var sb strings.Builder
 	elapsed := time.Since(b.startTime)
 
@@ -214,8 +344,11 @@ func (b *EnrichmentBuilder) Build(summary string) string {
 			work = work[:EnrichmentLimits.MaxPriorBeads]
 		}
 		for _, w := range work {
-			sb.WriteString(fmt.Sprintf("- **%s** (%s): \"%s\" - %s\n",
-				w.id, w.status, w.title, w.learning))
+			entry := fmt.Sprintf("- **%s** (%s): \"%s\"", w.id, w.status, w.title)
+			if w.learning != "" {
+				entry += " - " + w.learning
+			}
+			sb.WriteString(entry + "\n")
 		}
 		sb.WriteString("\n")
 	}
@@ -275,18 +408,73 @@ func (b *EnrichmentBuilder) Build(summary string) string {
 		result += "\n[Truncated due to size limit]\n"
 	}
 
+	// Documentation snapshots have their own budget so they never crowd out
+	// the sections above.
+	result += b.buildSnapshots()
+
 	return result
 }
 
+// buildSnapshots renders fetched documentation snapshots, capped at
+// EnrichmentLimits.MaxSnapshotBytes.
+func (b *EnrichmentBuilder) buildSnapshots() string {
+	var sb strings.Builder
+	budget := EnrichmentLimits.MaxSnapshotBytes
+	for i, d := range b.docs {
+		if i >= EnrichmentLimits.MaxDocs {
+			break
+		}
+		if d.snapshot == nil {
+			continue
+		}
+		if sb.Len() == 0 {
+			sb.WriteString("\n### Documentation Snapshots\n")
+		}
+
+		header := fmt.Sprintf("\n#### %s\n> Snapshot of %s (fetched %s, sha256 %s)\n\n",
+			d.title, d.url, d.snapshot.FetchedAt.Format("2006-01-02"), shortHash(d.snapshot.SHA256))
+		remaining := budget - sb.Len() - len(header)
+		if remaining <= 0 {
+			sb.WriteString("\n[Further snapshots omitted due to size limit]\n")
+			break
+		}
+		content, _ := truncateAtLine(d.snapshot.Content, remaining)
+		sb.WriteString(header)
+		sb.WriteString(content + "\n")
+	}
+	return sb.String()
+}
+
+// shortHash abbreviates a hex digest for display.
+func shortHash(h string) string {
+	if len(h) > 12 {
+		return h[:12]
+	}
+	return h
+}
+
 // Stats returns statistics about the enrichment content.
 func (b *EnrichmentBuilder) Stats() EnrichmentStats {
 	return EnrichmentStats{
 		FilesCount:      len(b.files),
 		PriorBeadsCount: len(b.priorWork),
 		DocsCount:       len(b.docs),
+		SnapshotsCount:  b.snapshotCount(),
 		PatternsCount:   len(b.patterns),
 		Depth:           string(b.depth),
+		Omitted:         b.Omitted(),
+	}
+}
+
+// snapshotCount returns how many docs have snapshots attached.
+func (b *EnrichmentBuilder) snapshotCount() int {
+	n := 0
+	for _, d := range b.docs {
+		if d.snapshot != nil {
+			n++
+		}
 	}
+	return n
 }
 
 // EnrichmentStats contains statistics about enrichment content.
@@ -294,8 +482,12 @@ type EnrichmentStats struct {
 	FilesCount      int    `json:"files_count"`
 	PriorBeadsCount int    `json:"prior_beads_count"`
 	DocsCount       int    `json:"docs_count"`
+	SnapshotsCount  int    `json:"snapshots_count,omitempty"`
 	PatternsCount   int    `json:"patterns_count"`
 	Depth           string `json:"depth"`
+
+	// Omitted lists content dropped to fit the target context.
+	Omitted []string `json:"omitted,omitempty"`
 }
 
 // formatDuration formats a duration for display.
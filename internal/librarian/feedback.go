@@ -0,0 +1,270 @@
+package librarian
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// FeedbackRating is a verdict on how helpful an enrichment was.
+type FeedbackRating string
+
+const (
+	RatingUseful FeedbackRating = "useful"
+	RatingNoisy  FeedbackRating = "noisy"
+)
+
+// ErrNoInjectionRecord is returned when feedback is given for a bead whose
+// contributing skills are unknown and none were supplied.
+var ErrNoInjectionRecord = errors.New("no skill injection recorded for bead")
+
+// InjectionRecord remembers which skills contributed to a bead's enrichment,
+// so later feedback can be credited to them.
+type InjectionRecord struct {
+	BeadID     string          `json:"bead_id"`
+	Skills     []string        `json:"skills"`
+	Depth      EnrichmentDepth `json:"depth,omitempty"`
+	InjectedAt time.Time       `json:"injected_at"`
+
+	// Versions maps skill ID to the version of the skill that was injected.
+	Versions map[string]string `json:"versions,omitempty"`
+}
+
+// Feedback is a single rating of a bead's enrichment.
+type Feedback struct {
+	BeadID string         `json:"bead_id"`
+	Rating FeedbackRating `json:"rating"`
+	Note   string         `json:"note,omitempty"`
+
+	// Skills are the skill IDs the rating is credited to.
+	Skills []string `json:"skills"`
+
+	By string    `json:"by,omitempty"`
+	At time.Time `json:"at"`
+}
+
+// SkillFeedbackStats aggregates feedback for one skill.
+type SkillFeedbackStats struct {
+	SkillID    string
+	Injections int
+	Useful     int
+	Noisy      int
+	// Notes are the most recent feedback notes, newest first.
+	Notes []string
+}
+
+// maxStatsNotes bounds how many notes SkillFeedbackStats keeps per skill.
+const maxStatsNotes = 3
+
+// Rated returns how many ratings the skill has received.
+func (s *SkillFeedbackStats) Rated() int {
+	return s.Useful + s.Noisy
+}
+
+// Usefulness returns the fraction of ratings that were useful, or -1 if the
+// skill has not been rated.
+func (s *SkillFeedbackStats) Usefulness() float64 {
+	if s.Rated() == 0 {
+		return -1
+	}
+	return float64(s.Useful) / float64(s.Rated())
+}
+
+// FeedbackStore persists injection records and enrichment feedback under
+// <town>/librarian/feedback.
+type FeedbackStore struct {
+	dir string
+}
+
+// NewFeedbackStore creates a feedback store for a town.
+func NewFeedbackStore(townRoot string) *FeedbackStore {
+	return &FeedbackStore{dir: filepath.Join(townRoot, "librarian", "feedback")}
+}
+
+func (s *FeedbackStore) injectionsPath() string {
+	return filepath.Join(s.dir, "injections.jsonl")
+}
+
+func (s *FeedbackStore) feedbackPath() string {
+	return filepath.Join(s.dir, "feedback.jsonl")
+}
+
+// RecordInjection records the skills that contributed to a bead's enrichment.
+func (s *FeedbackStore) RecordInjection(beadID string, depth EnrichmentDepth, skills []*Skill) error {
+	ids := make([]string, len(skills))
+	var versions map[string]string
+	for i, sk := range skills {
+		ids[i] = sk.ID
+		if sk.Version != "" {
+			if versions == nil {
+				versions = make(map[string]string)
+			}
+			versions[sk.ID] = sk.Version
+		}
+	}
+	return s.append(s.injectionsPath(), InjectionRecord{
+		BeadID:     beadID,
+		Skills:     ids,
+		Depth:      depth,
+		InjectedAt: time.Now(),
+		Versions:   versions,
+	})
+}
+
+// LastInjection returns the most recent injection record for a bead, or nil
+// if the bead has never been enriched.
+func (s *FeedbackStore) LastInjection(beadID string) (*InjectionRecord, error) {
+	records, err := s.injections()
+	if err != nil {
+		return nil, err
+	}
+	for i := len(records) - 1; i >= 0; i-- {
+		if records[i].BeadID == beadID {
+			return &records[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// AddFeedback records a rating for a bead's enrichment. If fb.Skills is empty
+// the rating is credited to the skills from the bead's last injection.
+func (s *FeedbackStore) AddFeedback(fb Feedback) (*Feedback, error) {
+	if fb.Rating != RatingUseful && fb.Rating != RatingNoisy {
+		return nil, fmt.Errorf("invalid rating %q (use %s or %s)", fb.Rating, RatingUseful, RatingNoisy)
+	}
+	if len(fb.Skills) == 0 {
+		rec, err := s.LastInjection(fb.BeadID)
+		if err != nil {
+			return nil, err
+		}
+		if rec == nil || len(rec.Skills) == 0 {
+			return nil, fmt.Errorf("%w %s", ErrNoInjectionRecord, fb.BeadID)
+		}
+		fb.Skills = rec.Skills
+	}
+	if fb.At.IsZero() {
+		fb.At = time.Now()
+	}
+	if err := s.append(s.feedbackPath(), fb); err != nil {
+		return nil, err
+	}
+	return &fb, nil
+}
+
+// SkillStats aggregates injections and feedback per skill ID.
+func (s *FeedbackStore) SkillStats() (map[string]*SkillFeedbackStats, error) {
+	stats := make(map[string]*SkillFeedbackStats)
+	get := func(id string) *SkillFeedbackStats {
+		st, ok := stats[id]
+		if !ok {
+			st = &SkillFeedbackStats{SkillID: id}
+			stats[id] = st
+		}
+		return st
+	}
+
+	records, err := s.injections()
+	if err != nil {
+		return nil, err
+	}
+	for _, rec := range records {
+		for _, id := range rec.Skills {
+			get(id).Injections++
+		}
+	}
+
+	var feedback []Feedback
+	if err := readJSONL(s.feedbackPath(), func(line []byte) error {
+		var fb Feedback
+		if err := json.Unmarshal(line, &fb); err != nil {
+			return err
+		}
+		feedback = append(feedback, fb)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	sort.SliceStable(feedback, func(i, j int) bool { return feedback[i].At.After(feedback[j].At) })
+	for _, fb := range feedback {
+		for _, id := range fb.Skills {
+			st := get(id)
+			switch fb.Rating {
+			case RatingUseful:
+				st.Useful++
+			case RatingNoisy:
+				st.Noisy++
+			}
+			if fb.Note != "" && len(st.Notes) < maxStatsNotes {
+				st.Notes = append(st.Notes, fb.Note)
+			}
+		}
+	}
+	return stats, nil
+}
+
+func (s *FeedbackStore) injections() ([]InjectionRecord, error) {
+	var records []InjectionRecord
+	err := readJSONL(s.injectionsPath(), func(line []byte) error {
+		var rec InjectionRecord
+		if err := json.Unmarshal(line, &rec); err != nil {
+			return err
+		}
+		records = append(records, rec)
+		return nil
+	})
+	return records, err
+}
+
+// append writes v as one JSON line to path.
+func (s *FeedbackStore) append(path string, v interface{}) error {
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return fmt.Errorf("creating feedback directory: %w", err)
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// readJSONL calls fn for each non-empty line of path. A missing file is
+// treated as empty.
+func readJSONL(path string, fn func([]byte) error) error {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1<<20)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if err := fn([]byte(line)); err != nil {
+			return fmt.Errorf("%s:%d: %w", filepath.Base(path), lineNo, err)
+		}
+	}
+	return scanner.Err()
+}
@@ -0,0 +1,63 @@
+package librarian
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeedbackStore_CreditsInjectedSkills(t *testing.T) {
+	store := NewFeedbackStore(t.TempDir())
+
+	require.NoError(t, store.RecordInjection("gt-1", DepthStandard, []*Skill{{ID: "go-testing", Version: "1a2b3c4d"}, {ID: "auth"}}))
+	require.NoError(t, store.RecordInjection("gt-2", DepthQuick, []*Skill{{ID: "auth"}}))
+
+	rec, err := store.LastInjection("gt-1")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"go-testing": "1a2b3c4d"}, rec.Versions)
+
+	fb, err := store.AddFeedback(Feedback{BeadID: "gt-1", Rating: RatingUseful, Note: "saved a search"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"go-testing", "auth"}, fb.Skills)
+
+	_, err = store.AddFeedback(Feedback{BeadID: "gt-2", Rating: RatingNoisy, Note: "irrelevant docs"})
+	require.NoError(t, err)
+
+	stats, err := store.SkillStats()
+	require.NoError(t, err)
+
+	auth := stats["auth"]
+	require.NotNil(t, auth)
+	assert.Equal(t, 2, auth.Injections)
+	assert.Equal(t, 1, auth.Useful)
+	assert.Equal(t, 1, auth.Noisy)
+	assert.InDelta(t, 0.5, auth.Usefulness(), 0.001)
+	assert.Len(t, auth.Notes, 2)
+
+	goTesting := stats["go-testing"]
+	require.NotNil(t, goTesting)
+	assert.Equal(t, 1, goTesting.Injections)
+	assert.InDelta(t, 1.0, goTesting.Usefulness(), 0.001)
+}
+
+func TestFeedbackStore_Errors(t *testing.T) {
+	store := NewFeedbackStore(t.TempDir())
+
+	_, err := store.AddFeedback(Feedback{BeadID: "gt-1", Rating: "meh"})
+	assert.Error(t, err)
+
+	_, err = store.AddFeedback(Feedback{BeadID: "gt-1", Rating: RatingUseful})
+	assert.True(t, errors.Is(err, ErrNoInjectionRecord))
+
+	// Explicit skills don't need an injection record
+	_, err = store.AddFeedback(Feedback{BeadID: "gt-1", Rating: RatingUseful, Skills: []string{"auth"}})
+	assert.NoError(t, err)
+
+	stats, err := store.SkillStats()
+	require.NoError(t, err)
+	assert.Equal(t, 0, stats["auth"].Injections)
+	assert.Equal(t, 1, stats["auth"].Useful)
+	assert.Equal(t, -1.0, (&SkillFeedbackStats{}).Usefulness())
+}
@@ -3,6 +3,7 @@ package librarian
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/steveyegge/gastown/internal/beads"
 )
@@ -10,20 +11,91 @@ import (
 // Injector handles dynamic skill injection for beads.
 // It extracts context from beads, matches skills, and builds enrichment.
 type Injector struct {
-	registry *SkillRegistry
-	beads    *beads.Beads
-	rigRoot  string
+	registry    *SkillRegistry
+	beads       *beads.Beads
+	rigRoot     string
+	snapshotter *DocSnapshotter
+
+	// targetTokens is the context budget enrichments are fitted to
+	// (0 = unlimited).
+	targetTokens int
+
+	// sharedRegistry is set when the registry is managed elsewhere (e.g. by
+	// a SkillWatcher) and must not be reloaded per injection.
+	sharedRegistry bool
+
+	// priorArt lists beads for the prior work search (nil disables it).
+	// The index built from it is reused for priorArtTTL.
+	priorArt      PriorArtSource
+	priorArtIndex *PriorArtIndex
+	priorArtAt    time.Time
 }
 
+// priorArtTTL is how long a prior art index is reused before the beads are
+// listed again.
+const priorArtTTL = 10 * time.Minute
+
+// standardPriorWork is how much prior work a standard-depth enrichment
+// lists; deep enrichments list up to EnrichmentLimits.MaxPriorBeads.
+const standardPriorWork = 3
+
 // NewInjector creates a new skill injector.
 func NewInjector(townRoot, rigRoot string) *Injector {
+	b := beads.New(rigRoot)
 	return &Injector{
 		registry: NewSkillRegistry(townRoot),
-		beads:    beads.New(rigRoot),
+		beads:    b,
 		rigRoot:  rigRoot,
+		priorArt: b,
 	}
 }
 
+// SetDocSnapshotter enables fetching documentation snapshots into the
+// enrichment. Pass nil to only emit documentation links (the default).
+func (inj *Injector) SetDocSnapshotter(s *DocSnapshotter) {
+	inj.snapshotter = s
+}
+
+// SetTargetContext fits enrichments to a model context budget of tokens,
+// omitting the least relevant content first (see
+// EnrichmentBuilder.SetTargetTokens). Zero removes the budget.
+func (inj *Injector) SetTargetContext(tokens int) {
+	inj.targetTokens = tokens
+}
+
+// SetRigPath adds the rig's librarian/skills directory to the injector's
+// registry, so rig skills override or extend town skills (see
+// SkillRegistry.SetRigPath).
+func (inj *Injector) SetRigPath(rigPath string) {
+	inj.registry.SetRigPath(rigPath)
+}
+
+// SetSkillRegistry makes the injector match against a registry managed
+// elsewhere, such as one kept current by a SkillWatcher. The injector no
+// longer loads skills itself.
+func (inj *Injector) SetSkillRegistry(r *SkillRegistry) {
+	inj.registry = r
+	inj.sharedRegistry = true
+}
+
+// SetPriorArtSource sets where closed beads and merged MRs are listed from
+// for the prior work search. Pass nil to disable the search.
+func (inj *Injector) SetPriorArtSource(src PriorArtSource) {
+	inj.priorArt = src
+	inj.priorArtIndex = nil
+}
+
+// loadSkills loads skills into the injector's own registry.
+func (inj *Injector) loadSkills() error {
+	if inj.sharedRegistry {
+		return nil
+	}
+	if err := inj.registry.LoadSkills(); err != nil {
+		return fmt.Errorf("loading skills: %w", err)
+	}
+	return nil
+}
+
 // InjectionResult contains the result of skill injection.
 type InjectionResult struct {
 	// MatchedSkills is the list of skills that matched the bead context
@@ -37,14 +109,24 @@ type InjectionResult struct {
 
 	// Context is the extracted bead context used for matching
 	Context *BeadContext
+
+	// SnapshotErrors lists documentation pages that could not be snapshotted
+	SnapshotErrors []error
+
+	// PriorWork lists closed beads and merged MRs similar to the bead
+	PriorWork []PriorWork
+
+	// PriorArtErr is set if the prior work search failed; the enrichment
+	// is built without it
+	PriorArtErr error
 }
 
 // InjectForBead performs skill injection for a bead.
 // It loads skills, extracts bead context, matches skills, and builds enrichment.
 func (inj *Injector) InjectForBead(beadID string, depth EnrichmentDepth) (*InjectionResult, error) {
 	// Load skills
-	if err := inj.registry.LoadSkills(); err != nil {
-		return nil, fmt.Errorf("loading skills: %w", err)
+	if err := inj.loadSkills(); err != nil {
+		return nil, err
 	}
 
 	// Get bead information
@@ -69,6 +151,7 @@ func (inj *Injector) InjectForBead(beadID string, depth EnrichmentDepth) (*Injec
 
 	// Build enrichment
 	builder := NewEnrichmentBuilder(inj.rigRoot, depth)
+	builder.SetTargetTokens(inj.targetTokens)
 
 	// Inject all matched skills
 	for _, skill := range matchedSkills {
@@ -84,14 +167,20 @@ func (inj *Injector) InjectForBead(beadID string, depth EnrichmentDepth) (*Injec
 		builder.AddContextNote(fmt.Sprintf("Skills injected: %s", strings.Join(skillNames, ", ")))
 	}
 
+	priorWork, priorArtErr := inj.addPriorWork(builder, ctx, depth)
+	snapshotErrs := inj.snapshotDocs(builder)
+
 	// Generate summary
 	summary := generateSummary(issue, matchedSkills)
 
 	return &InjectionResult{
-		MatchedSkills: matchedSkills,
-		Enrichment:    builder.Build(summary),
-		Stats:         builder.Stats(),
-		Context:       ctx,
+		MatchedSkills:  matchedSkills,
+		Enrichment:     builder.Build(summary),
+		Stats:          builder.Stats(),
+		Context:        ctx,
+		SnapshotErrors: snapshotErrs,
+		PriorWork:      priorWork,
+		PriorArtErr:    priorArtErr,
 	}, nil
 }
 
@@ -99,8 +188,8 @@ func (inj *Injector) InjectForBead(beadID string, depth EnrichmentDepth) (*Injec
 // Useful when bead info is already available.
 func (inj *Injector) InjectForContext(ctx *BeadContext, depth EnrichmentDepth) (*InjectionResult, error) {
 	// Load skills
-	if err := inj.registry.LoadSkills(); err != nil {
-		return nil, fmt.Errorf("loading skills: %w", err)
+	if err := inj.loadSkills(); err != nil {
+		return nil, err
 	}
 
 	// Match skills
@@ -108,6 +197,7 @@ func (inj *Injector) InjectForContext(ctx *BeadContext, depth EnrichmentDepth) (
 
 	// Build enrichment
 	builder := NewEnrichmentBuilder(inj.rigRoot, depth)
+	builder.SetTargetTokens(inj.targetTokens)
 
 	// Inject all matched skills
 	for _, skill := range matchedSkills {
@@ -123,17 +213,57 @@ func (inj *Injector) InjectForContext(ctx *BeadContext, depth EnrichmentDepth) (
 		builder.AddContextNote(fmt.Sprintf("Skills injected: %s", strings.Join(skillNames, ", ")))
 	}
 
+	priorWork, priorArtErr := inj.addPriorWork(builder, ctx, depth)
+	snapshotErrs := inj.snapshotDocs(builder)
+
 	// Generate summary based on context
 	summary := fmt.Sprintf("Context prepared for: %s", ctx.Title)
 
 	return &InjectionResult{
-		MatchedSkills: matchedSkills,
-		Enrichment:    builder.Build(summary),
-		Stats:         builder.Stats(),
-		Context:       ctx,
+		MatchedSkills:  matchedSkills,
+		Enrichment:     builder.Build(summary),
+		Stats:          builder.Stats(),
+		Context:        ctx,
+		SnapshotErrors: snapshotErrs,
+		PriorWork:      priorWork,
+		PriorArtErr:    priorArtErr,
 	}, nil
 }
 
+// snapshotDocs attaches documentation snapshots if a snapshotter is set.
+func (inj *Injector) snapshotDocs(builder *EnrichmentBuilder) []error {
+	if inj.snapshotter == nil {
+		return nil
+	}
+	return builder.SnapshotDocs(inj.snapshotter)
+}
+
+// addPriorWork searches closed beads and merged MRs for work similar to
+// ctx and adds it to the enrichment. Quick enrichments skip the search.
+func (inj *Injector) addPriorWork(builder *EnrichmentBuilder, ctx *BeadContext, depth EnrichmentDepth) ([]PriorWork, error) {
+	if inj.priorArt == nil || depth == DepthQuick {
+		return nil, nil
+	}
+	if inj.priorArtIndex == nil || time.Since(inj.priorArtAt) > priorArtTTL {
+		issues, err := inj.priorArt.List(beads.ListOptions{Status: "all", Priority: -1})
+		if err != nil {
+			return nil, fmt.Errorf("listing beads for prior work: %w", err)
+		}
+		inj.priorArtIndex = BuildPriorArtIndex(issues)
+		inj.priorArtAt = time.Now()
+	}
+
+	limit := standardPriorWork
+	if depth == DepthDeep {
+		limit = EnrichmentLimits.MaxPriorBeads
+	}
+	work := inj.priorArtIndex.Search(ctx, limit)
+	for _, w := range work {
+		builder.AddPriorWork(w.ID, w.Status, w.Title, w.Summary)
+	}
+	return work, nil
+}
+
 // extractContext extracts BeadContext from a beads.Issue.
 func (inj *Injector) extractContext(issue *beads.Issue) *BeadContext {
 	return &BeadContext{
@@ -171,17 +301,18 @@ func generateSummary(issue *beads.Issue, skills []*Skill) string {
 
 // ListSkills returns all available skills.
 func (inj *Injector) ListSkills() ([]*Skill, error) {
-	if err := inj.registry.LoadSkills(); err != nil {
-		return nil, fmt.Errorf("loading skills: %w", err)
+	if err := inj.loadSkills(); err != nil {
+		return nil, err
 	}
 	return inj.registry.AllSkills(), nil
 }
 
-// PreviewMatches returns skills that would match a given bead without building enrichment.
-func (inj *Injector) PreviewMatches(beadID string) ([]*Skill, *BeadContext, error) {
+// PreviewMatches returns the skills that would match a given bead, with
+// their scores, without building enrichment.
+func (inj *Injector) PreviewMatches(beadID string) ([]SkillMatch, *BeadContext, error) {
 	// Load skills
-	if err := inj.registry.LoadSkills(); err != nil {
-		return nil, nil, fmt.Errorf("loading skills: %w", err)
+	if err := inj.loadSkills(); err != nil {
+		return nil, nil, err
 	}
 
 	// Get bead information
@@ -201,13 +332,33 @@ func (inj *Injector) PreviewMatches(beadID string) ([]*Skill, *BeadContext, erro
 		}
 	}
 
-	// Match skills
-	matchedSkills := inj.registry.MatchSkills(ctx)
+	return inj.registry.ScoreSkills(ctx), ctx, nil
+}
 
-	return matchedSkills, ctx, nil
+// StaleSkills returns the IDs of loaded skills the embedding index has no
+// entry for at their current version, or nil without an index. Call after
+// ListSkills or PreviewMatches has loaded skills.
+func (inj *Injector) StaleSkills() []string {
+	idx := inj.registry.Index()
+	if idx == nil {
+		return nil
+	}
+	return idx.Stale(inj.registry.AllSkills())
+}
+
+// SkillIndexPath returns where the skill embedding index is kept (see
+// SkillRegistry.IndexPath).
+func (inj *Injector) SkillIndexPath() string {
+	return inj.registry.IndexPath()
 }
 
-// GetSkillsDir returns the path to the skills directory.
+// GetSkillsDir returns the path to the town skills directory.
 func (inj *Injector) GetSkillsDir() string {
 	return inj.registry.SkillsDir()
 }
+
+// GetRigSkillsDir returns the path to the rig skills directory, or "" if
+// no rig path is set.
+func (inj *Injector) GetRigSkillsDir() string {
+	return inj.registry.RigSkillsDir()
+}
@@ -0,0 +1,267 @@
+package librarian
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/steveyegge/gastown/internal/beads"
+)
+
+// MinPriorArtScore is the cosine similarity a closed bead or merged MR
+// needs to be suggested as prior work.
+const MinPriorArtScore = 0.2
+
+// maxPriorArtSummary caps the length of a prior work summary.
+const maxPriorArtSummary = 120
+
+// PriorWork is a closed bead or merged MR similar to the bead being
+// enriched.
+type PriorWork struct {
+	// ID is the closed bead, or the MR when its work bead isn't closed.
+	ID string `json:"id"`
+
+	// Status is "merged" when the work landed through the merge queue,
+	// otherwise the bead's status.
+	Status string `json:"status"`
+
+	Title string `json:"title"`
+
+	// Summary is a one-line summary of the work.
+	Summary string `json:"summary"`
+
+	// MergeCommit is the commit the work was merged in, if known.
+	MergeCommit string `json:"merge_commit,omitempty"`
+
+	// Score is the similarity to the bead being enriched (0-1).
+	Score float64 `json:"score"`
+}
+
+// PriorArtSource lists beads for the prior art index. *beads.Beads
+// satisfies it.
+type PriorArtSource interface {
+	List(opts beads.ListOptions) ([]*beads.Issue, error)
+}
+
+// PriorArtIndex is a TF-IDF index over closed beads and merged MRs, used to
+// find work similar to a bead.
+type PriorArtIndex struct {
+	docs []priorArtDoc
+	idf  map[string]float64
+}
+
+type priorArtDoc struct {
+	work PriorWork
+	vec  map[string]float64
+}
+
+// BuildPriorArtIndex indexes closed beads by title and description. A
+// merged MR is folded into its work bead when that bead is closed;
+// otherwise it is indexed on its own with its work bead's text, since
+// part of the work already landed. Other open beads and MRs that didn't
+// merge are skipped.
+func BuildPriorArtIndex(issues []*beads.Issue) *PriorArtIndex {
+	byID := make(map[string]*beads.Issue, len(issues))
+	for _, issue := range issues {
+		byID[issue.ID] = issue
+	}
+
+	merges := make(map[string]*beads.MRFields)
+	var works []PriorWork
+	var texts []string
+	for _, issue := range issues {
+		if isMergeRequest(issue) {
+			fields := beads.ParseMRFields(issue)
+			if fields == nil || fields.CloseReason != "merged" {
+				continue
+			}
+			// MR titles only name the work bead; match on the bead instead
+			src := byID[fields.SourceIssue]
+			switch {
+			case src == nil:
+			case src.Status == "closed":
+				merges[src.ID] = fields
+			default:
+				works = append(works, PriorWork{
+					ID:          issue.ID,
+					Status:      "merged",
+					Title:       src.Title,
+					Summary:     mergeSummary(fields) + " (" + src.ID + " still " + src.Status + ")",
+					MergeCommit: fields.MergeCommit,
+				})
+				texts = append(texts, src.Title+"\n"+src.Title+"\n"+src.Description)
+			}
+			continue
+		}
+		if issue.Status != "closed" {
+			continue
+		}
+		works = append(works, PriorWork{
+			ID:      issue.ID,
+			Status:  issue.Status,
+			Title:   issue.Title,
+			Summary: firstSentence(issue.Description),
+		})
+		// Titles count twice: they say what the work was about
+		texts = append(texts, issue.Title+"\n"+issue.Title+"\n"+issue.Description)
+	}
+
+	for i := range works {
+		if fields, ok := merges[works[i].ID]; ok {
+			works[i].Status = "merged"
+			works[i].MergeCommit = fields.MergeCommit
+			if works[i].Summary == "" {
+				works[i].Summary = mergeSummary(fields)
+			} else {
+				works[i].Summary += " (" + mergeSummary(fields) + ")"
+			}
+		}
+	}
+
+	// Document frequencies, then IDF-weighted unit vectors
+	termFreqs := make([]map[string]int, len(texts))
+	df := make(map[string]int)
+	for i, text := range texts {
+		termFreqs[i] = termCounts(text)
+		for term := range termFreqs[i] {
+			df[term]++
+		}
+	}
+	idx := &PriorArtIndex{idf: make(map[string]float64, len(df))}
+	for term, n := range df {
+		idx.idf[term] = math.Log(1 + float64(len(texts))/float64(n))
+	}
+	for i, work := range works {
+		idx.docs = append(idx.docs, priorArtDoc{work: work, vec: idx.vector(termFreqs[i])})
+	}
+	return idx
+}
+
+// Len returns the number of indexed documents.
+func (idx *PriorArtIndex) Len() int {
+	return len(idx.docs)
+}
+
+// Search returns up to limit pieces of prior work similar to ctx, best
+// first, skipping ctx's own bead.
+func (idx *PriorArtIndex) Search(ctx *BeadContext, limit int) []PriorWork {
+	query := idx.vector(termCounts(ctx.Title + "\n" + ctx.Title + "\n" + ctx.Description))
+	if len(query) == 0 {
+		return nil
+	}
+
+	var matches []PriorWork
+	for _, doc := range idx.docs {
+		if doc.work.ID == ctx.ID {
+			continue
+		}
+		score := 0.0
+		for term, w := range query {
+			score += w * doc.vec[term]
+		}
+		if score >= MinPriorArtScore {
+			work := doc.work
+			work.Score = score
+			matches = append(matches, work)
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Score > matches[j].Score
+	})
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches
+}
+
+// vector converts term counts into a unit-length TF-IDF vector. Terms the
+// index has never seen carry no weight.
+func (idx *PriorArtIndex) vector(counts map[string]int) map[string]float64 {
+	vec := make(map[string]float64, len(counts))
+	norm := 0.0
+	for term, n := range counts {
+		idf, ok := idx.idf[term]
+		if !ok {
+			continue
+		}
+		w := (1 + math.Log(float64(n))) * idf
+		vec[term] = w
+		norm += w * w
+	}
+	if norm == 0 {
+		return nil
+	}
+	norm = math.Sqrt(norm)
+	for term := range vec {
+		vec[term] /= norm
+	}
+	return vec
+}
+
+// priorArtStopWords are common words that say nothing about the work.
+var priorArtStopWords = map[string]bool{
+	"the": true, "and": true, "for": true, "with": true, "from": true,
+	"this": true, "that": true, "into": true, "when": true, "should": true,
+	"are": true, "was": true, "not": true, "but": true, "can": true,
+	"add": true, "use": true, "make": true, "new": true, "all": true,
+	"bead": true, "beads": true, "task": true, "issue": true,
+}
+
+// termCounts tokenizes text into lowercase terms of three or more letters
+// or digits, without stop words and bare numbers.
+func termCounts(text string) map[string]int {
+	counts := make(map[string]int)
+	for _, term := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if len(term) < 3 || priorArtStopWords[term] || strings.Trim(term, "0123456789") == "" {
+			continue
+		}
+		counts[term]++
+	}
+	return counts
+}
+
+// isMergeRequest reports whether issue is a merge-request bead.
+func isMergeRequest(issue *beads.Issue) bool {
+	if issue.Type == "merge-request" {
+		return true
+	}
+	for _, l := range issue.Labels {
+		if l == "gt:merge-request" {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeSummary describes a merged MR, e.g. "merged in 1a2b3c4 from
+// polecat/Nux/gt-xyz".
+func mergeSummary(fields *beads.MRFields) string {
+	summary := "merged"
+	if fields.MergeCommit != "" {
+		summary += " in " + shortHash(fields.MergeCommit)
+	}
+	if fields.Branch != "" {
+		summary += " from " + fields.Branch
+	}
+	return summary
+}
+
+// firstSentence returns the first sentence of a description's first
+// paragraph, capped at maxPriorArtSummary.
+func firstSentence(description string) string {
+	text := strings.TrimSpace(description)
+	if i := strings.Index(text, "\n\n"); i >= 0 {
+		text = text[:i]
+	}
+	text = strings.Join(strings.Fields(text), " ")
+	if i := strings.Index(text, ". "); i >= 0 {
+		text = text[:i+1]
+	}
+	if len(text) > maxPriorArtSummary {
+		text = strings.TrimSpace(text[:maxPriorArtSummary-3]) + "..."
+	}
+	return text
+}
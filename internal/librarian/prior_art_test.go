@@ -0,0 +1,160 @@
+package librarian
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakePriorArtSource is an in-memory PriorArtSource.
+type fakePriorArtSource struct {
+	issues []*beads.Issue
+	err    error
+	lists  int
+}
+
+func (f *fakePriorArtSource) List(opts beads.ListOptions) ([]*beads.Issue, error) {
+	f.lists++
+	return f.issues, f.err
+}
+
+func priorArtIssues() []*beads.Issue {
+	return []*beads.Issue{
+		{
+			ID:          "gt-101",
+			Title:       "Retry webhook delivery with exponential backoff",
+			Description: "Webhook delivery gave up after one failure. Added backoff to the delivery worker.",
+			Status:      "closed",
+		},
+		{
+			ID:          "gt-102",
+			Title:       "Dark mode for the settings page",
+			Description: "Theme toggle in settings.",
+			Status:      "closed",
+		},
+		{
+			ID:          "gt-103",
+			Title:       "Webhook signature verification",
+			Description: "Verify HMAC signatures on inbound webhook requests.",
+			Status:      "in_progress",
+		},
+		{
+			ID:          "gt-104",
+			Title:       "Webhook delivery dashboard",
+			Description: "Show webhook delivery failures.",
+			Status:      "open",
+		},
+		{
+			ID:          "gt-mr1",
+			Title:       "Merge: gt-101",
+			Type:        "merge-request",
+			Status:      "closed",
+			Description: "branch: polecat/Nux/gt-101\nsource_issue: gt-101\nmerge_commit: 1a2b3c4d5e6f7a8b\nclose_reason: merged",
+		},
+		{
+			ID:          "gt-mr2",
+			Title:       "Merge: gt-103",
+			Labels:      []string{"gt:merge-request"},
+			Status:      "closed",
+			Description: "branch: polecat/Toast/gt-103\nsource_issue: gt-103\nmerge_commit: 9f8e7d6c\nclose_reason: merged",
+		},
+		{
+			ID:          "gt-mr3",
+			Title:       "Merge: gt-104",
+			Type:        "merge-request",
+			Status:      "closed",
+			Description: "branch: polecat/Nux/gt-104\nsource_issue: gt-104\nclose_reason: rejected",
+		},
+	}
+}
+
+func TestPriorArtSearch(t *testing.T) {
+	idx := BuildPriorArtIndex(priorArtIssues())
+	// Two closed beads plus the merged MR of a bead that's still open
+	assert.Equal(t, 3, idx.Len())
+
+	work := idx.Search(&BeadContext{
+		ID:          "gt-200",
+		Title:       "Webhook delivery retries are not resumed after restart",
+		Description: "Pending webhook retries are lost when the delivery worker restarts.",
+	}, 5)
+	require.NotEmpty(t, work)
+
+	top := work[0]
+	assert.Equal(t, "gt-101", top.ID)
+	assert.Equal(t, "merged", top.Status)
+	assert.Equal(t, "1a2b3c4d5e6f7a8b", top.MergeCommit)
+	assert.Equal(t, "Webhook delivery gave up after one failure. (merged in 1a2b3c4d5e6f from polecat/Nux/gt-101)", top.Summary)
+
+	for _, w := range work {
+		assert.NotEqual(t, "gt-102", w.ID, "unrelated work should not match")
+		assert.NotEqual(t, "gt-104", w.ID, "open beads are not prior work")
+		assert.NotEqual(t, "gt-mr3", w.ID, "rejected MRs are not prior work")
+		assert.GreaterOrEqual(t, w.Score, MinPriorArtScore)
+	}
+}
+
+func TestPriorArtSearchMergedMRForOpenBead(t *testing.T) {
+	idx := BuildPriorArtIndex(priorArtIssues())
+
+	work := idx.Search(&BeadContext{Title: "Rotate webhook signature secrets"}, 5)
+	require.NotEmpty(t, work)
+	assert.Equal(t, "gt-mr2", work[0].ID)
+	assert.Equal(t, "Webhook signature verification", work[0].Title)
+	assert.Contains(t, work[0].Summary, "gt-103 still in_progress")
+}
+
+func TestPriorArtSearchSkipsSelf(t *testing.T) {
+	idx := BuildPriorArtIndex(priorArtIssues())
+
+	work := idx.Search(&BeadContext{ID: "gt-102", Title: "Dark mode for the settings page"}, 5)
+	assert.Empty(t, work)
+}
+
+func TestFirstSentence(t *testing.T) {
+	assert.Equal(t, "Fixes the login loop.", firstSentence("Fixes the login\nloop. Also tidies up.\n\nMore detail."))
+	assert.Equal(t, "", firstSentence("  "))
+
+	long := firstSentence(strings.Repeat("word ", 50))
+	assert.LessOrEqual(t, len(long), maxPriorArtSummary)
+	assert.True(t, strings.HasSuffix(long, "..."))
+}
+
+func TestInjectorAddsPriorWork(t *testing.T) {
+	inj := NewInjector(t.TempDir(), t.TempDir())
+	src := &fakePriorArtSource{issues: priorArtIssues()}
+	inj.SetPriorArtSource(src)
+
+	ctx := &BeadContext{ID: "gt-200", Title: "Webhook delivery retries lost on restart"}
+	result, err := inj.InjectForContext(ctx, DepthStandard)
+	require.NoError(t, err)
+	require.NotEmpty(t, result.PriorWork)
+	assert.Contains(t, result.Enrichment, "### Prior Work")
+	assert.Contains(t, result.Enrichment, `**gt-101** (merged): "Retry webhook delivery with exponential backoff"`)
+	assert.Equal(t, len(result.PriorWork), result.Stats.PriorBeadsCount)
+
+	// The index is reused across injections
+	_, err = inj.InjectForContext(ctx, DepthDeep)
+	require.NoError(t, err)
+	assert.Equal(t, 1, src.lists)
+
+	// Quick enrichments skip the search
+	result, err = inj.InjectForContext(ctx, DepthQuick)
+	require.NoError(t, err)
+	assert.Empty(t, result.PriorWork)
+	assert.NotContains(t, result.Enrichment, "### Prior Work")
+}
+
+func TestInjectorPriorArtError(t *testing.T) {
+	inj := NewInjector(t.TempDir(), t.TempDir())
+	inj.SetPriorArtSource(&fakePriorArtSource{err: errors.New("bd not found")})
+
+	result, err := inj.InjectForContext(&BeadContext{Title: "Webhook retries"}, DepthStandard)
+	require.NoError(t, err)
+	assert.ErrorContains(t, result.PriorArtErr, "bd not found")
+	assert.Empty(t, result.PriorWork)
+}
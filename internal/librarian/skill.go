@@ -1,15 +1,32 @@
 package librarian
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"math"
 	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
+	"sync"
 
 	"gopkg.in/yaml.v3"
 )
 
+// SkillScope is where a skill is defined.
+type SkillScope string
+
+const (
+	// ScopeTown skills live in <town>/librarian/skills/ and apply to every rig.
+	ScopeTown SkillScope = "town"
+
+	// ScopeRig skills live in <rig>/librarian/skills/. A rig skill with the
+	// same ID as a town skill overrides it, or extends it if Extend is set.
+	ScopeRig SkillScope = "rig"
+)
+
 // Skill represents a reusable skill definition that can be injected into beads.
 // Skills contain domain knowledge, patterns, and file references that help
 // agents understand how to approach specific types of work.
@@ -34,6 +51,23 @@ type Skill struct {
 
 	// Exclusive means only one skill in this group can be injected
 	Exclusive string `yaml:"exclusive,omitempty" json:"exclusive,omitempty"`
+
+	// Extend makes a rig skill add its triggers and content to the town
+	// skill with the same ID instead of replacing it.
+	Extend bool `yaml:"extend,omitempty" json:"extend,omitempty"`
+
+	// Version identifies the skill file's content (a short hash), so
+	// enrichments can be traced to the definition that produced them.
+	Version string `yaml:"-" json:"version,omitempty"`
+
+	// Path is the file the skill was loaded from.
+	Path string `yaml:"-" json:"path,omitempty"`
+
+	// Scope is where the skill was loaded from. Empty means town.
+	Scope SkillScope `yaml:"-" json:"scope,omitempty"`
+
+	// Shadows is the town skill file a rig skill overrides or extends.
+	Shadows string `yaml:"-" json:"shadows,omitempty"`
 }
 
 // SkillTriggers defines conditions for skill injection.
@@ -126,10 +160,18 @@ type BeadContext struct {
 	ParentLabel []string // Labels from parent bead if available
 }
 
-// SkillRegistry manages skill definitions and matching.
+// SkillRegistry manages skill definitions and matching. It is safe for
+// concurrent use, so a SkillWatcher can reload skills while beads are
+// being enriched.
 type SkillRegistry struct {
-	skills   []*Skill
-	skillDir string
+	mu          sync.RWMutex
+	skills      []*Skill
+	skillDir    string
+	rigSkillDir string
+
+	// index holds skill embeddings for similarity matching (nil: triggers
+	// only).
+	index *SkillIndex
 }
 
 // NewSkillRegistry creates a new skill registry for a town.
@@ -140,16 +182,89 @@ func NewSkillRegistry(townRoot string) *SkillRegistry {
 	}
 }
 
-// LoadSkills loads all skill definitions from the skills directory.
+// SetRigPath adds the rig's librarian/skills directory as a rig scope whose
+// skills override or extend town skills. An empty path means town skills
+// only. Call it before loading skills.
+func (r *SkillRegistry) SetRigPath(rigPath string) {
+	r.rigSkillDir = ""
+	if rigPath != "" {
+		r.rigSkillDir = filepath.Join(rigPath, "librarian", "skills")
+	}
+}
+
+// LoadSkills loads all skill definitions from the town and rig skills
+// directories, replacing any loaded before, and resolves rig overrides.
 func (r *SkillRegistry) LoadSkills() error {
+	town, err := r.loadSkillDir(r.skillDir, ScopeTown)
+	if err != nil {
+		return err
+	}
+	var rig []*Skill
+	if r.rigSkillDir != "" {
+		if rig, err = r.loadSkillDir(r.rigSkillDir, ScopeRig); err != nil {
+			return err
+		}
+	}
+	r.setSkills(resolveSkills(town, rig))
+	if err := r.LoadIndex(); err != nil {
+		// Matching still works on triggers alone
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	return nil
+}
+
+// IndexPath returns the embedding index file: next to the rig skills
+// directory if the registry has a rig scope, else next to the town's.
+func (r *SkillRegistry) IndexPath() string {
+	dir := r.skillDir
+	if r.rigSkillDir != "" {
+		dir = r.rigSkillDir
+	}
+	return filepath.Join(filepath.Dir(dir), SkillIndexFile)
+}
+
+// LoadIndex (re)loads the embedding index from IndexPath. Without an index
+// file skills match on triggers only.
+func (r *SkillRegistry) LoadIndex() error {
+	idx, err := LoadSkillIndex(r.IndexPath())
+	if err != nil {
+		return err
+	}
+	r.SetIndex(idx)
+	return nil
+}
+
+// SetIndex sets the embedding index used for similarity matching. Pass nil
+// to match on triggers only.
+func (r *SkillRegistry) SetIndex(idx *SkillIndex) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.index = idx
+}
+
+// Index returns the registry's embedding index, or nil.
+func (r *SkillRegistry) Index() *SkillIndex {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.index
+}
+
+// loadSkillDir loads the skills in one directory, in path order. A second
+// file defining an ID already loaded from the directory is skipped.
+func (r *SkillRegistry) loadSkillDir(dir string, scope SkillScope) ([]*Skill, error) {
 	// Check if skills directory exists
-	if _, err := os.Stat(r.skillDir); os.IsNotExist(err) {
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
 		// No skills directory is fine - just return empty
-		return nil
+		return nil, nil
 	}
 
+	var skills []*Skill
+	seen := make(map[string]string)
+
 	// Walk the skills directory for YAML files
-	return filepath.Walk(r.skillDir, func(path string, info os.FileInfo, err error) error {
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
@@ -172,10 +287,109 @@ func (r *SkillRegistry) LoadSkills() error {
 			fmt.Fprintf(os.Stderr, "Warning: failed to load skill %s: %v\n", path, err)
 			return nil
 		}
+		if other, ok := seen[skill.ID]; ok {
+			fmt.Fprintf(os.Stderr, "Warning: skipping skill %s: id %s is already defined in %s\n", path, skill.ID, other)
+			return nil
+		}
+		seen[skill.ID] = path
 
-		r.skills = append(r.skills, skill)
+		skill.Scope = scope
+		skills = append(skills, skill)
 		return nil
 	})
+	return skills, err
+}
+
+// resolveSkills applies rig skills to town skills: a rig skill replaces the
+// town skill with the same ID, or is merged into it if it sets Extend; rig
+// skills with new IDs are added. The result is in ID order.
+func resolveSkills(town, rig []*Skill) []*Skill {
+	byID := make(map[string]*Skill, len(town)+len(rig))
+	for _, s := range town {
+		byID[s.ID] = s
+	}
+	for _, s := range rig {
+		base, ok := byID[s.ID]
+		switch {
+		case !ok:
+			byID[s.ID] = s
+		case s.Extend:
+			byID[s.ID] = mergeSkill(base, s)
+		default:
+			override := *s
+			override.Shadows = base.Path
+			byID[s.ID] = &override
+		}
+	}
+
+	skills := make([]*Skill, 0, len(byID))
+	for _, s := range byID {
+		skills = append(skills, s)
+	}
+	sort.Slice(skills, func(i, j int) bool { return skills[i].ID < skills[j].ID })
+	return skills
+}
+
+// mergeSkill returns base extended by a rig skill: triggers and content are
+// appended, and the rig skill's non-empty scalar fields win.
+func mergeSkill(base, ext *Skill) *Skill {
+	merged := *base
+	merged.Scope = ScopeRig
+	merged.Path = ext.Path
+	merged.Shadows = base.Path
+	merged.Extend = true
+	merged.Version = base.Version + "+" + ext.Version
+	if ext.Name != "" && ext.Name != ext.ID {
+		merged.Name = ext.Name
+	}
+	if ext.Description != "" {
+		merged.Description = ext.Description
+	}
+	if ext.Priority != 0 {
+		merged.Priority = ext.Priority
+	}
+	if ext.Exclusive != "" {
+		merged.Exclusive = ext.Exclusive
+	}
+
+	t, et := base.Triggers, ext.Triggers
+	merged.Triggers = SkillTriggers{
+		Labels:              appendStrings(t.Labels, et.Labels),
+		TitlePatterns:       appendStrings(t.TitlePatterns, et.TitlePatterns),
+		DescriptionPatterns: appendStrings(t.DescriptionPatterns, et.DescriptionPatterns),
+		Keywords:            appendStrings(t.Keywords, et.Keywords),
+		ParentLabels:        appendStrings(t.ParentLabels, et.ParentLabels),
+		BeadTypes:           appendStrings(t.BeadTypes, et.BeadTypes),
+	}
+
+	c, ec := base.Content, ext.Content
+	merged.Content = SkillContent{
+		Files:          append(append([]SkillFile(nil), c.Files...), ec.Files...),
+		Patterns:       append(append([]SkillPattern(nil), c.Patterns...), ec.Patterns...),
+		Documentation:  append(append([]SkillDoc(nil), c.Documentation...), ec.Documentation...),
+		ContextNotes:   appendStrings(c.ContextNotes, ec.ContextNotes),
+		PriorWorkQuery: c.PriorWorkQuery,
+	}
+	if ec.PriorWorkQuery != "" {
+		merged.Content.PriorWorkQuery = ec.PriorWorkQuery
+	}
+	return &merged
+}
+
+// appendStrings returns a new slice holding a followed by b.
+func appendStrings(a, b []string) []string {
+	if len(a)+len(b) == 0 {
+		return nil
+	}
+	return append(append(make([]string, 0, len(a)+len(b)), a...), b...)
+}
+
+// setSkills replaces the registry's skills.
+func (r *SkillRegistry) setSkills(skills []*Skill) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.skills = skills
 }
 
 // loadSkillFile loads a single skill from a YAML file.
@@ -197,31 +411,140 @@ func (r *SkillRegistry) loadSkillFile(path string) (*Skill, error) {
 	if skill.Name == "" {
 		skill.Name = skill.ID
 	}
+	skill.Version = skillVersion(data)
+	skill.Path = path
 
 	return &skill, nil
 }
 
-// MatchSkills returns all skills that match the given bead context.
+// skillVersion returns a short content hash of a skill file.
+func skillVersion(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])[:8]
+}
+
+// Validate checks that a skill can be activated: it needs an ID, at least
+// one trigger (unless it extends a town skill), trigger patterns that compile, and complete content entries.
+func (s *Skill) Validate() error {
+	if s.ID == "" {
+		return fmt.Errorf("missing id")
+	}
+
+	// A skill extending a town skill may add only content
+	t := s.Triggers
+	if !s.Extend && len(t.Labels)+len(t.TitlePatterns)+len(t.DescriptionPatterns)+
+		len(t.Keywords)+len(t.ParentLabels)+len(t.BeadTypes) == 0 {
+		return fmt.Errorf("skill %s has no triggers", s.ID)
+	}
+	for _, pattern := range append(append([]string{}, t.TitlePatterns...), t.DescriptionPatterns...) {
+		if _, err := regexp.Compile("(?i)" + pattern); err != nil {
+			return fmt.Errorf("skill %s: invalid pattern %q: %w", s.ID, pattern, err)
+		}
+	}
+
+	for _, f := range s.Content.Files {
+		if f.Path == "" {
+			return fmt.Errorf("skill %s: file entry missing path", s.ID)
+		}
+	}
+	for _, p := range s.Content.Patterns {
+		if p.Name == "" {
+			return fmt.Errorf("skill %s: pattern entry missing name", s.ID)
+		}
+	}
+	for _, d := range s.Content.Documentation {
+		if d.URL == "" {
+			return fmt.Errorf("skill %s: documentation entry missing url", s.ID)
+		}
+	}
+	return nil
+}
+
+// SkillMatch is a matched skill with how it matched.
+type SkillMatch struct {
+	Skill *Skill `json:"skill"`
+
+	// Triggered is set when one of the skill's triggers fired.
+	Triggered bool `json:"triggered"`
+
+	// Similarity is the cosine similarity of the bead's and the skill's
+	// embeddings, or -1 without an index entry for the skill.
+	Similarity float64 `json:"similarity"`
+
+	// Score blends the two: TriggerWeight for a trigger match plus the rest
+	// weighted by similarity. A trigger match alone, without an index,
+	// scores 1.
+	Score float64 `json:"score"`
+}
+
+// MatchSkills returns all skills that match the given bead context, in
+// injection order: higher priority first, then rig skills before town
+// skills, then by ID. Within an exclusive group the first in that order
+// wins.
 func (r *SkillRegistry) MatchSkills(ctx *BeadContext) []*Skill {
+	matches := r.ScoreSkills(ctx)
+	skills := make([]*Skill, len(matches))
+	for i, m := range matches {
+		skills[i] = m.Skill
+	}
+	return skills
+}
+
+// ScoreSkills matches skills like MatchSkills and scores each match. With
+// an embedding index, a skill also matches when its similarity to the bead
+// reaches the index threshold, so beads that use none of a skill's trigger
+// words can still get it. If the bead can't be embedded, skills match on
+// triggers alone.
+func (r *SkillRegistry) ScoreSkills(ctx *BeadContext) []SkillMatch {
+	// Embed before taking the lock: an embedding command can take a while
+	idx := r.Index()
+	var query []float64
+	if idx != nil && len(idx.Entries) > 0 {
+		var err error
+		if query, err = idx.Embedder().Embed(beadText(ctx)); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: skill similarity unavailable, matching on triggers only: %v\n", err)
+			query = nil
+		}
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
 	// First pass: collect all matching skills
-	var allMatched []*Skill
+	var allMatched []SkillMatch
 	for _, skill := range r.skills {
-		if r.skillMatches(skill, ctx) {
-			allMatched = append(allMatched, skill)
+		m := SkillMatch{Skill: skill, Triggered: r.skillMatches(skill, ctx), Similarity: -1}
+		if query != nil {
+			if vec := idx.vector(skill); vec != nil {
+				m.Similarity = math.Max(0, cosine(query, vec))
+			}
 		}
+		switch {
+		case m.Similarity < 0 && m.Triggered:
+			m.Score = 1
+		case m.Similarity >= 0 && (m.Triggered || m.Similarity >= idx.Threshold):
+			m.Score = (1 - TriggerWeight) * m.Similarity
+			if m.Triggered {
+				m.Score += TriggerWeight
+			}
+		default:
+			continue
+		}
+		allMatched = append(allMatched, m)
 	}
 
-	// Sort by priority (higher first) BEFORE applying exclusive filtering
-	sortSkillsByPriority(allMatched)
+	// Sort BEFORE applying exclusive filtering
+	sortMatchesByPriority(allMatched)
 
-	// Second pass: filter exclusive groups (highest priority wins)
-	var result []*Skill
+	// Second pass: filter exclusive groups (first in injection order wins)
+	var result []SkillMatch
 	exclusiveGroups := make(map[string]bool)
-	for _, skill := range allMatched {
+	for _, m := range allMatched {
+		skill := m.Skill
 		if skill.Exclusive != "" && exclusiveGroups[skill.Exclusive] {
 			continue
 		}
-		result = append(result, skill)
+		result = append(result, m)
 		if skill.Exclusive != "" {
 			exclusiveGroups[skill.Exclusive] = true
 		}
@@ -230,6 +553,11 @@ func (r *SkillRegistry) MatchSkills(ctx *BeadContext) []*Skill {
 	return result
 }
 
+// beadText is the text a bead is embedded from for skill matching.
+func beadText(ctx *BeadContext) string {
+	return ctx.Title + "\n" + ctx.Description
+}
+
 // skillMatches checks if a skill's triggers match the bead context.
 func (r *SkillRegistry) skillMatches(skill *Skill, ctx *BeadContext) bool {
 	triggers := skill.Triggers
@@ -303,19 +631,27 @@ func matchPattern(pattern, text string) bool {
 	return re.MatchString(text)
 }
 
-// sortSkillsByPriority sorts skills by priority (higher first).
-func sortSkillsByPriority(skills []*Skill) {
-	for i := 0; i < len(skills)-1; i++ {
-		for j := i + 1; j < len(skills); j++ {
-			if skills[j].Priority > skills[i].Priority {
-				skills[i], skills[j] = skills[j], skills[i]
-			}
+// sortMatchesByPriority sorts matches by skill priority (higher first),
+// breaking ties with rig skills before town skills and then by ID, so
+// injection order doesn't depend on load order.
+func sortMatchesByPriority(matches []SkillMatch) {
+	sort.SliceStable(matches, func(i, j int) bool {
+		a, b := matches[i].Skill, matches[j].Skill
+		if a.Priority != b.Priority {
+			return a.Priority > b.Priority
 		}
-	}
+		if (a.Scope == ScopeRig) != (b.Scope == ScopeRig) {
+			return a.Scope == ScopeRig
+		}
+		return a.ID < b.ID
+	})
 }
 
 // GetSkill returns a skill by ID.
 func (r *SkillRegistry) GetSkill(id string) *Skill {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
 	for _, skill := range r.skills {
 		if skill.ID == id {
 			return skill
@@ -326,15 +662,27 @@ func (r *SkillRegistry) GetSkill(id string) *Skill {
 
 // AllSkills returns all loaded skills.
 func (r *SkillRegistry) AllSkills() []*Skill {
-	return r.skills
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return append([]*Skill(nil), r.skills...)
 }
 
-// SkillsDir returns the skills directory path.
+// SkillsDir returns the town skills directory path.
 func (r *SkillRegistry) SkillsDir() string {
 	return r.skillDir
 }
 
+// RigSkillsDir returns the rig skills directory path, or "" if the registry
+// has no rig scope.
+func (r *SkillRegistry) RigSkillsDir() string {
+	return r.rigSkillDir
+}
+
 // AddSkill adds a skill to the registry (useful for testing).
 func (r *SkillRegistry) AddSkill(skill *Skill) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
 	r.skills = append(r.skills, skill)
 }
@@ -0,0 +1,271 @@
+package librarian
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// SkillIndexFile is the embedding index's file name, next to the skills
+// directory it indexes (<town>/librarian/ or <rig>/librarian/).
+const SkillIndexFile = "skill-index.json"
+
+// HashEmbedModel names the built-in embedder.
+const HashEmbedModel = "hash-512"
+
+// Default similarity a skill needs to match on embeddings alone. Hashed
+// embeddings only share terms, so their scores run lower than a model's.
+const (
+	DefaultHashSimilarity    = 0.3
+	DefaultCommandSimilarity = 0.5
+)
+
+// TriggerWeight is the weight of a trigger match in a skill's blended
+// score; the embedding similarity makes up the rest.
+const TriggerWeight = 0.5
+
+// hashEmbedDims is the dimension of the built-in embeddings.
+const hashEmbedDims = 512
+
+// embedCommandTimeout bounds one run of an embedding command.
+const embedCommandTimeout = 30 * time.Second
+
+// Embedder turns text into a vector. Similar texts get vectors with a high
+// cosine similarity.
+type Embedder interface {
+	Embed(text string) ([]float64, error)
+}
+
+// HashEmbedder is the built-in embedder: terms and their character
+// trigrams are hashed into a fixed-size vector. It needs no model and
+// catches shared words and word stems ("migrate", "migrations"), but not
+// synonyms; use a CommandEmbedder for those.
+type HashEmbedder struct{}
+
+// Embed returns the unit-length hashed embedding of text.
+func (HashEmbedder) Embed(text string) ([]float64, error) {
+	vec := make([]float64, hashEmbedDims)
+	for term, n := range termCounts(text) {
+		w := 1 + math.Log(float64(n))
+		addHashed(vec, "w:"+term, w)
+		padded := "^" + term + "$"
+		for i := 0; i+3 <= len(padded); i++ {
+			addHashed(vec, "t:"+padded[i:i+3], w/2)
+		}
+	}
+	return normalize(vec), nil
+}
+
+// addHashed adds w to the dimension feature hashes to, with a sign from the
+// hash so collisions tend to cancel out.
+func addHashed(vec []float64, feature string, w float64) {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(feature))
+	sum := h.Sum64()
+	if sum&(1<<63) != 0 {
+		w = -w
+	}
+	vec[sum%uint64(len(vec))] += w
+}
+
+// CommandEmbedder embeds text by running a shell command, e.g. a script
+// calling a local model server. The command reads the text on stdin and
+// writes the vector as a JSON array of numbers on stdout.
+type CommandEmbedder struct {
+	Command string
+}
+
+// Embed runs the command on text.
+func (c CommandEmbedder) Embed(text string) ([]float64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), embedCommandTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "sh", "-c", c.Command) //nolint:gosec // G204: command is configured by the town operator
+	cmd.Stdin = strings.NewReader(text)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("embedding command failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	var vec []float64
+	if err := json.Unmarshal(stdout.Bytes(), &vec); err != nil {
+		return nil, fmt.Errorf("embedding command output is not a JSON array of numbers: %w", err)
+	}
+	if len(vec) == 0 {
+		return nil, fmt.Errorf("embedding command returned an empty vector")
+	}
+	return normalize(vec), nil
+}
+
+// SkillIndex holds an embedding of each skill, built offline by 'gt
+// librarian skills index'. Beads are embedded the same way when matched
+// and compared to it.
+type SkillIndex struct {
+	// Model is the embedder used: HashEmbedModel or "command".
+	Model string `json:"model"`
+
+	// Command is the embedding command, for the "command" model.
+	Command string `json:"command,omitempty"`
+
+	// Threshold is the similarity a skill needs to match without a trigger.
+	Threshold float64 `json:"threshold"`
+
+	// BuiltAt is when the index was built.
+	BuiltAt time.Time `json:"built_at"`
+
+	// Entries are the indexed skills.
+	Entries []SkillEmbedding `json:"entries"`
+}
+
+// SkillEmbedding is one skill's embedding.
+type SkillEmbedding struct {
+	ID string `json:"id"`
+
+	// Version is the skill version embedded. An edited skill no longer
+	// matches on similarity until the index is rebuilt.
+	Version string `json:"version"`
+
+	Vector []float64 `json:"vector"`
+}
+
+// Embedder returns the embedder the index was built with.
+func (idx *SkillIndex) Embedder() Embedder {
+	if idx.Model == "command" {
+		return CommandEmbedder{Command: idx.Command}
+	}
+	return HashEmbedder{}
+}
+
+// BuildSkillIndex embeds each skill's text with e. Threshold 0 picks the
+// default for the embedder.
+func BuildSkillIndex(skills []*Skill, e Embedder, threshold float64) (*SkillIndex, error) {
+	idx := &SkillIndex{Model: HashEmbedModel, Threshold: threshold, BuiltAt: time.Now()}
+	if c, ok := e.(CommandEmbedder); ok {
+		idx.Model, idx.Command = "command", c.Command
+	}
+	if idx.Threshold == 0 {
+		idx.Threshold = DefaultHashSimilarity
+		if idx.Model == "command" {
+			idx.Threshold = DefaultCommandSimilarity
+		}
+	}
+
+	for _, skill := range skills {
+		vec, err := e.Embed(skillText(skill))
+		if err != nil {
+			return nil, fmt.Errorf("embedding skill %s: %w", skill.ID, err)
+		}
+		idx.Entries = append(idx.Entries, SkillEmbedding{ID: skill.ID, Version: skill.Version, Vector: vec})
+	}
+	return idx, nil
+}
+
+// LoadSkillIndex reads an index file. A missing file returns nil.
+func LoadSkillIndex(path string) (*SkillIndex, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // G304: path is the librarian's own index
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var idx SkillIndex
+	if err := json.Unmarshal(data, &idx); err != nil {
+		return nil, fmt.Errorf("parsing skill index %s: %w", path, err)
+	}
+	return &idx, nil
+}
+
+// Save writes the index to path.
+func (idx *SkillIndex) Save(path string) error {
+	data, err := json.Marshal(idx)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// Stale returns the IDs of skills the index has no embedding of for their
+// current version.
+func (idx *SkillIndex) Stale(skills []*Skill) []string {
+	var stale []string
+	for _, skill := range skills {
+		if idx.vector(skill) == nil {
+			stale = append(stale, skill.ID)
+		}
+	}
+	return stale
+}
+
+// vector returns the embedding of skill's current version, or nil.
+func (idx *SkillIndex) vector(skill *Skill) []float64 {
+	for _, e := range idx.Entries {
+		if e.ID == skill.ID && e.Version == skill.Version {
+			return e.Vector
+		}
+	}
+	return nil
+}
+
+// skillText is the text a skill is embedded from: what it is about and the
+// words its triggers look for.
+func skillText(skill *Skill) string {
+	parts := []string{skill.Name, skill.Description}
+	parts = append(parts, skill.Triggers.Keywords...)
+	for _, label := range append(append([]string{}, skill.Triggers.Labels...), skill.Triggers.ParentLabels...) {
+		// "domain:auth" is about auth
+		if i := strings.LastIndex(label, ":"); i >= 0 {
+			label = label[i+1:]
+		}
+		parts = append(parts, strings.TrimSuffix(label, "*"))
+	}
+	for _, p := range skill.Content.Patterns {
+		parts = append(parts, p.Name, p.Description)
+	}
+	for _, f := range skill.Content.Files {
+		parts = append(parts, f.Description)
+	}
+	parts = append(parts, skill.Content.ContextNotes...)
+	return strings.Join(parts, "\n")
+}
+
+// normalize scales vec to unit length in place.
+func normalize(vec []float64) []float64 {
+	norm := 0.0
+	for _, v := range vec {
+		norm += v * v
+	}
+	if norm == 0 {
+		return vec
+	}
+	norm = math.Sqrt(norm)
+	for i := range vec {
+		vec[i] /= norm
+	}
+	return vec
+}
+
+// cosine returns the cosine similarity of two unit vectors, or 0 if their
+// dimensions differ.
+func cosine(a, b []float64) float64 {
+	if len(a) != len(b) {
+		return 0
+	}
+	dot := 0.0
+	for i := range a {
+		dot += a[i] * b[i]
+	}
+	return dot
+}
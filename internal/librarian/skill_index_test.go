@@ -0,0 +1,131 @@
+package librarian
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func indexedRegistry(t *testing.T, e Embedder) (*SkillRegistry, *Skill, *Skill) {
+	t.Helper()
+	migrations := &Skill{
+		ID:          "db-migrations",
+		Name:        "Database Migrations",
+		Description: "Writing reversible schema migrations for the postgres database",
+		Triggers:    SkillTriggers{Labels: []string{"domain:db"}},
+		Version:     "v1",
+	}
+	goTesting := &Skill{
+		ID:          "go-testing",
+		Name:        "Go Testing",
+		Description: "Table-driven unit tests",
+		Triggers:    SkillTriggers{Keywords: []string{"flaky test"}},
+		Version:     "v1",
+	}
+	r := NewSkillRegistry(t.TempDir())
+	r.AddSkill(migrations)
+	r.AddSkill(goTesting)
+
+	idx, err := BuildSkillIndex(r.AllSkills(), e, 0)
+	require.NoError(t, err)
+	r.SetIndex(idx)
+	return r, migrations, goTesting
+}
+
+func TestScoreSkills_Similarity(t *testing.T) {
+	r, migrations, goTesting := indexedRegistry(t, HashEmbedder{})
+
+	// No trigger fires, but the bead is about migrating the database schema
+	ctx := &BeadContext{
+		ID:          "gt-1",
+		Title:       "Migrate user table schema",
+		Description: "Add a reversible migration for the postgres users table",
+	}
+	matches := r.ScoreSkills(ctx)
+	require.Len(t, matches, 1)
+	assert.Equal(t, migrations, matches[0].Skill)
+	assert.False(t, matches[0].Triggered)
+	assert.GreaterOrEqual(t, matches[0].Similarity, DefaultHashSimilarity)
+	assert.InDelta(t, (1-TriggerWeight)*matches[0].Similarity, matches[0].Score, 1e-9)
+
+	// A trigger match is blended with its similarity
+	matches = r.ScoreSkills(&BeadContext{ID: "gt-2", Title: "Fix flaky test in CI"})
+	require.Len(t, matches, 1)
+	assert.Equal(t, goTesting, matches[0].Skill)
+	assert.True(t, matches[0].Triggered)
+	assert.GreaterOrEqual(t, matches[0].Score, TriggerWeight)
+
+	// Unrelated beads match nothing
+	assert.Empty(t, r.ScoreSkills(&BeadContext{ID: "gt-3", Title: "Update the README badges"}))
+
+	// An edited skill matches on triggers only until the index is rebuilt
+	migrations.Version = "v2"
+	assert.Equal(t, []string{"db-migrations"}, r.Index().Stale(r.AllSkills()))
+	assert.Empty(t, r.ScoreSkills(ctx))
+	matches = r.ScoreSkills(&BeadContext{ID: "gt-4", Labels: []string{"domain:db"}})
+	require.Len(t, matches, 1)
+	assert.Equal(t, -1.0, matches[0].Similarity)
+	assert.Equal(t, 1.0, matches[0].Score)
+}
+
+func TestScoreSkills_CommandEmbedder(t *testing.T) {
+	dir := t.TempDir()
+	script := filepath.Join(dir, "embed.sh")
+	// Everything about databases points one way, everything else another
+	require.NoError(t, os.WriteFile(script, []byte(`#!/bin/sh
+if grep -qiE 'database|sql'; then echo '[1, 0]'; else echo '[0, 1]'; fi
+`), 0755))
+
+	r, migrations, _ := indexedRegistry(t, CommandEmbedder{Command: script})
+	assert.Equal(t, "command", r.Index().Model)
+	assert.Equal(t, DefaultCommandSimilarity, r.Index().Threshold)
+
+	matches := r.ScoreSkills(&BeadContext{ID: "gt-1", Title: "Slow SQL query on orders"})
+	require.Len(t, matches, 1)
+	assert.Equal(t, migrations, matches[0].Skill)
+	assert.InDelta(t, 1.0, matches[0].Similarity, 1e-9)
+
+	// A failing embedder falls back to triggers
+	r.Index().Command = "exit 1"
+	matches = r.ScoreSkills(&BeadContext{ID: "gt-2", Title: "Fix flaky test", Description: "database"})
+	require.Len(t, matches, 1)
+	assert.Equal(t, "go-testing", matches[0].Skill.ID)
+	assert.Equal(t, -1.0, matches[0].Similarity)
+}
+
+func TestSkillIndex_SaveLoad(t *testing.T) {
+	townRoot := t.TempDir()
+	skillsDir := filepath.Join(townRoot, "librarian", "skills")
+	require.NoError(t, os.MkdirAll(skillsDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(skillsDir, "auth.yaml"), []byte(`id: auth
+name: Authentication
+description: Login sessions and tokens
+triggers:
+  labels: ["domain:auth"]
+`), 0644))
+
+	r := NewSkillRegistry(townRoot)
+	require.NoError(t, r.LoadSkills())
+	assert.Nil(t, r.Index())
+	assert.Equal(t, filepath.Join(townRoot, "librarian", SkillIndexFile), r.IndexPath())
+
+	idx, err := BuildSkillIndex(r.AllSkills(), HashEmbedder{}, 0.4)
+	require.NoError(t, err)
+	require.NoError(t, idx.Save(r.IndexPath()))
+
+	require.NoError(t, r.LoadSkills())
+	require.NotNil(t, r.Index())
+	assert.Equal(t, 0.4, r.Index().Threshold)
+	assert.Empty(t, r.Index().Stale(r.AllSkills()))
+
+	matches := r.ScoreSkills(&BeadContext{ID: "gt-1", Title: "Session tokens expire after login"})
+	require.Len(t, matches, 1)
+	assert.Equal(t, "auth", matches[0].Skill.ID)
+
+	// Rig registries keep their own index
+	r.SetRigPath(filepath.Join(townRoot, "gastown"))
+	assert.Equal(t, filepath.Join(townRoot, "gastown", "librarian", SkillIndexFile), r.IndexPath())
+}
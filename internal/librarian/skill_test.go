@@ -1,6 +1,10 @@
 package librarian
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -185,6 +189,72 @@ func TestSkillExclusiveGroups(t *testing.T) {
 	assert.Equal(t, "other", matched[1].ID)
 }
 
+func TestSkillTieBreakRigFirst(t *testing.T) {
+	registry := &SkillRegistry{
+		skills: []*Skill{
+			{ID: "b-town", Scope: ScopeTown, Exclusive: "style", Triggers: SkillTriggers{Keywords: []string{"lint"}}},
+			{ID: "a-town", Scope: ScopeTown, Triggers: SkillTriggers{Keywords: []string{"lint"}}},
+			{ID: "z-rig", Scope: ScopeRig, Exclusive: "style", Triggers: SkillTriggers{Keywords: []string{"lint"}}},
+		},
+	}
+
+	matched := registry.MatchSkills(&BeadContext{Title: "fix lint"})
+
+	// Equal priority: rig before town, then by ID; the rig skill wins the group
+	require.Len(t, matched, 2)
+	assert.Equal(t, "z-rig", matched[0].ID)
+	assert.Equal(t, "a-town", matched[1].ID)
+}
+
+func TestLoadSkills_RigScope(t *testing.T) {
+	townRoot := t.TempDir()
+	rigPath := t.TempDir()
+	registry := NewSkillRegistry(townRoot)
+	registry.SetRigPath(rigPath)
+
+	townDir := registry.SkillsDir()
+	rigDir := registry.RigSkillsDir()
+	require.NoError(t, os.MkdirAll(townDir, 0755))
+	require.NoError(t, os.MkdirAll(rigDir, 0755))
+
+	files := map[string]string{
+		filepath.Join(townDir, "go.yaml"):   "id: go\nname: Go\ntriggers:\n  keywords: [golang]\ncontent:\n  context_notes: [town note]\n",
+		filepath.Join(townDir, "docs.yaml"): "id: docs\ntriggers:\n  keywords: [docs]\n",
+		filepath.Join(townDir, "sql.yaml"):  "id: sql\ntriggers:\n  keywords: [sql]\n",
+		filepath.Join(rigDir, "go.yaml"):    "id: go\nextend: true\ntriggers:\n  keywords: [gofmt]\ncontent:\n  context_notes: [rig note]\n",
+		filepath.Join(rigDir, "docs.yaml"):  "id: docs\ntriggers:\n  keywords: [manual]\n",
+		filepath.Join(rigDir, "ui.yaml"):    "id: ui\ntriggers:\n  keywords: [css]\n",
+	}
+	for path, content := range files {
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+
+	require.NoError(t, registry.LoadSkills())
+	require.Len(t, registry.AllSkills(), 4)
+
+	// Extended: town triggers and content plus the rig's
+	goSkill := registry.GetSkill("go")
+	require.NotNil(t, goSkill)
+	assert.Equal(t, ScopeRig, goSkill.Scope)
+	assert.Equal(t, "Go", goSkill.Name)
+	assert.Equal(t, []string{"golang", "gofmt"}, goSkill.Triggers.Keywords)
+	assert.Equal(t, []string{"town note", "rig note"}, goSkill.Content.ContextNotes)
+	assert.Equal(t, filepath.Join(townDir, "go.yaml"), goSkill.Shadows)
+
+	// Overridden: only the rig definition applies
+	docs := registry.GetSkill("docs")
+	require.NotNil(t, docs)
+	assert.Equal(t, []string{"manual"}, docs.Triggers.Keywords)
+	assert.Empty(t, registry.MatchSkills(&BeadContext{Title: "update docs"}))
+
+	assert.Equal(t, ScopeTown, registry.GetSkill("sql").Scope)
+	assert.Equal(t, ScopeRig, registry.GetSkill("ui").Scope)
+
+	// Reloading replaces rather than duplicates
+	require.NoError(t, registry.LoadSkills())
+	assert.Len(t, registry.AllSkills(), 4)
+}
+
 func TestLabelMatchWildcard(t *testing.T) {
 	tests := []struct {
 		trigger string
@@ -293,3 +363,40 @@ func TestEnrichmentLimits(t *testing.T) {
 	// This is a simple check - the limit is enforced in Build()
 	assert.NotEmpty(t, output)
 }
+
+func TestEnrichmentTargetTokens(t *testing.T) {
+	newBuilder := func() *EnrichmentBuilder {
+		builder := NewEnrichmentBuilder("/tmp/rig", DepthStandard)
+		for i := 0; i < 5; i++ {
+			builder.AddFile(fmt.Sprintf("src/file%d.go", i), "", strings.Repeat("relevant code ", 10))
+			builder.AddPattern(fmt.Sprintf("Pattern %d", i), strings.Repeat("how to do it ", 10), "")
+		}
+		builder.AddDoc("Go Docs", "https://go.dev", "Official docs")
+		builder.AddContextNote("Remember to run tests")
+		return builder
+	}
+
+	full := newBuilder().Build("Summary")
+
+	builder := newBuilder()
+	builder.SetTargetTokens(EstimateTokens(full) / 2)
+	output := builder.Build("Summary")
+
+	assert.LessOrEqual(t, EstimateTokens(output), EstimateTokens(full)/2)
+	// Least relevant content goes first; the first file survives
+	assert.NotContains(t, output, "Remember to run tests")
+	assert.NotContains(t, output, "[Go Docs]")
+	assert.Contains(t, output, "`src/file0.go`")
+	assert.Contains(t, output, "Omitted to fit a")
+
+	omitted := builder.Stats().Omitted
+	require.NotEmpty(t, omitted)
+	assert.Contains(t, omitted, "1 docs")
+	assert.Contains(t, omitted, "1 context notes")
+
+	// A generous budget leaves the enrichment untouched
+	builder = newBuilder()
+	builder.SetTargetTokens(EstimateTokens(full) * 2)
+	assert.NotContains(t, builder.Build("Summary"), "Omitted to fit a")
+	assert.Empty(t, builder.Stats().Omitted)
+}
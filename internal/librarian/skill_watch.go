@@ -0,0 +1,213 @@
+package librarian
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// SkillChangeKind describes what happened to a skill file during a scan.
+type SkillChangeKind string
+
+const (
+	SkillAdded    SkillChangeKind = "added"
+	SkillUpdated  SkillChangeKind = "updated"
+	SkillRemoved  SkillChangeKind = "removed"
+	SkillRejected SkillChangeKind = "rejected"
+)
+
+// SkillChange is one skill file change applied (or rejected) by a scan.
+type SkillChange struct {
+	Kind    SkillChangeKind
+	Path    string
+	SkillID string
+	Version string
+
+	// Err explains why a rejected skill was not activated. The previous
+	// version of the skill, if any, stays active.
+	Err error
+}
+
+func (c SkillChange) String() string {
+	switch c.Kind {
+	case SkillRejected:
+		return fmt.Sprintf("rejected %s: %v", c.Path, c.Err)
+	case SkillRemoved:
+		return fmt.Sprintf("removed %s@%s", c.SkillID, c.Version)
+	default:
+		return fmt.Sprintf("%s %s@%s", c.Kind, c.SkillID, c.Version)
+	}
+}
+
+// fileStamp is what a scan compares to detect a changed skill file.
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// SkillWatcher hot-reloads a registry's town and rig skills directories:
+// each Scan picks up skills that were added, changed, or removed. Changed
+// skills are validated before they replace the active version; invalid ones
+// are rejected and the previous version keeps serving.
+type SkillWatcher struct {
+	registry *SkillRegistry
+	stamps   map[string]fileStamp // path -> last seen stamp
+	active   map[string]*Skill    // path -> skill activated from it
+	scopes   map[string]SkillScope
+}
+
+// NewSkillWatcher creates a watcher for a registry. The registry should be
+// empty; the first Scan loads every valid skill.
+func NewSkillWatcher(registry *SkillRegistry) *SkillWatcher {
+	return &SkillWatcher{
+		registry: registry,
+		stamps:   make(map[string]fileStamp),
+		active:   make(map[string]*Skill),
+		scopes:   make(map[string]SkillScope),
+	}
+}
+
+// Scan checks the skills directories once and applies any changes to the
+// registry, returning them in path order. Files whose modification time and
+// size are unchanged since the last scan are not re-read.
+func (w *SkillWatcher) Scan() ([]SkillChange, error) {
+	current, err := w.skillFiles(w.registry.skillDir, ScopeTown)
+	if err != nil {
+		return nil, err
+	}
+	if w.registry.rigSkillDir != "" {
+		rig, err := w.skillFiles(w.registry.rigSkillDir, ScopeRig)
+		if err != nil {
+			return nil, err
+		}
+		for path, stamp := range rig {
+			current[path] = stamp
+		}
+	}
+
+	var changes []SkillChange
+	paths := make([]string, 0, len(current))
+	for path := range current {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		stamp := current[path]
+		if prev, ok := w.stamps[path]; ok && prev == stamp {
+			continue
+		}
+		w.stamps[path] = stamp
+
+		skill, err := w.registry.loadSkillFile(path)
+		if err == nil {
+			skill.Scope = w.scopes[path]
+			err = skill.Validate()
+		}
+		if err == nil {
+			err = w.checkDuplicate(path, skill)
+		}
+		if err != nil {
+			changes = append(changes, SkillChange{Kind: SkillRejected, Path: path, Err: err})
+			continue
+		}
+
+		kind := SkillAdded
+		if prev, ok := w.active[path]; ok {
+			if prev.Version == skill.Version {
+				continue // touched but not changed
+			}
+			kind = SkillUpdated
+		}
+		w.active[path] = skill
+		changes = append(changes, SkillChange{Kind: kind, Path: path, SkillID: skill.ID, Version: skill.Version})
+	}
+
+	var removed []string
+	for path := range w.stamps {
+		if _, ok := current[path]; !ok {
+			removed = append(removed, path)
+		}
+	}
+	sort.Strings(removed)
+	for _, path := range removed {
+		delete(w.stamps, path)
+		delete(w.scopes, path)
+		skill, ok := w.active[path]
+		if !ok {
+			continue
+		}
+		delete(w.active, path)
+		changes = append(changes, SkillChange{Kind: SkillRemoved, Path: path, SkillID: skill.ID, Version: skill.Version})
+	}
+
+	for _, c := range changes {
+		if c.Kind != SkillRejected {
+			w.registry.setSkills(w.resolve())
+			break
+		}
+	}
+	return changes, nil
+}
+
+// resolve applies the active rig skills to the active town skills.
+func (w *SkillWatcher) resolve() []*Skill {
+	paths := make([]string, 0, len(w.active))
+	for path := range w.active {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var town, rig []*Skill
+	for _, path := range paths {
+		if skill := w.active[path]; skill.Scope == ScopeRig {
+			rig = append(rig, skill)
+		} else {
+			town = append(town, skill)
+		}
+	}
+	return resolveSkills(town, rig)
+}
+
+// checkDuplicate rejects a skill whose ID is already served from another
+// file in the same scope. A rig skill may share a town skill's ID to
+// override or extend it.
+func (w *SkillWatcher) checkDuplicate(path string, skill *Skill) error {
+	for other, active := range w.active {
+		if other != path && active.ID == skill.ID && active.Scope == skill.Scope {
+			return fmt.Errorf("skill id %s is already defined in %s", skill.ID, other)
+		}
+	}
+	return nil
+}
+
+// skillFiles stamps every YAML file under a skills directory and records
+// its scope. A missing directory has no skills.
+func (w *SkillWatcher) skillFiles(dir string, scope SkillScope) (map[string]fileStamp, error) {
+	files := make(map[string]fileStamp)
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && path == dir {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		ext := strings.ToLower(filepath.Ext(path))
+		if ext != ".yaml" && ext != ".yml" {
+			return nil
+		}
+		files[path] = fileStamp{modTime: info.ModTime(), size: info.Size()}
+		w.scopes[path] = scope
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scanning skills directory: %w", err)
+	}
+	return files, nil
+}
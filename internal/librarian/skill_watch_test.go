@@ -0,0 +1,132 @@
+package librarian
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeSkill(t *testing.T, path, content string, modTime time.Time) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	// Scans compare modification times, which may not tick between writes
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+}
+
+func TestSkillWatcherScan(t *testing.T) {
+	townRoot := t.TempDir()
+	registry := NewSkillRegistry(townRoot)
+	watcher := NewSkillWatcher(registry)
+
+	// A missing skills directory is just empty
+	changes, err := watcher.Scan()
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+
+	require.NoError(t, os.MkdirAll(registry.SkillsDir(), 0755))
+	path := filepath.Join(registry.SkillsDir(), "go-testing.yaml")
+	base := time.Now().Add(-time.Hour)
+
+	writeSkill(t, path, "id: go-testing\ntriggers:\n  keywords: [test]\n", base)
+	changes, err = watcher.Scan()
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	assert.Equal(t, SkillAdded, changes[0].Kind)
+	v1 := changes[0].Version
+	require.NotEmpty(t, v1)
+	assert.Len(t, registry.MatchSkills(&BeadContext{Title: "Add test"}), 1)
+
+	// Unchanged files produce no changes
+	changes, err = watcher.Scan()
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+
+	writeSkill(t, path, "id: go-testing\ntriggers:\n  keywords: [testing]\n", base.Add(time.Minute))
+	changes, err = watcher.Scan()
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	assert.Equal(t, SkillUpdated, changes[0].Kind)
+	assert.NotEqual(t, v1, changes[0].Version)
+	assert.Len(t, registry.AllSkills(), 1)
+	v2 := changes[0].Version
+
+	// An invalid edit is rejected and the previous version stays active
+	writeSkill(t, path, "id: go-testing\ntriggers:\n  title_patterns: [\"(\"]\n", base.Add(2*time.Minute))
+	changes, err = watcher.Scan()
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	assert.Equal(t, SkillRejected, changes[0].Kind)
+	assert.Error(t, changes[0].Err)
+	require.NotNil(t, registry.GetSkill("go-testing"))
+	assert.Equal(t, v2, registry.GetSkill("go-testing").Version)
+
+	// A second file may not redefine an active skill ID
+	dup := filepath.Join(registry.SkillsDir(), "dup.yml")
+	writeSkill(t, dup, "id: go-testing\ntriggers:\n  keywords: [go]\n", base)
+	changes, err = watcher.Scan()
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	assert.Equal(t, SkillRejected, changes[0].Kind)
+
+	require.NoError(t, os.Remove(path))
+	require.NoError(t, os.Remove(dup))
+	changes, err = watcher.Scan()
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	assert.Equal(t, SkillRemoved, changes[0].Kind)
+	assert.Empty(t, registry.AllSkills())
+}
+
+func TestSkillWatcherRigOverride(t *testing.T) {
+	registry := NewSkillRegistry(t.TempDir())
+	registry.SetRigPath(t.TempDir())
+	watcher := NewSkillWatcher(registry)
+	require.NoError(t, os.MkdirAll(registry.SkillsDir(), 0755))
+	require.NoError(t, os.MkdirAll(registry.RigSkillsDir(), 0755))
+	base := time.Now().Add(-time.Hour)
+
+	writeSkill(t, filepath.Join(registry.SkillsDir(), "go.yaml"), "id: go\ntriggers:\n  keywords: [golang]\n", base)
+	rigPath := filepath.Join(registry.RigSkillsDir(), "go.yaml")
+	writeSkill(t, rigPath, "id: go\ntriggers:\n  keywords: [gofmt]\n", base)
+
+	// The same ID in the rig scope overrides instead of being a duplicate
+	changes, err := watcher.Scan()
+	require.NoError(t, err)
+	require.Len(t, changes, 2)
+	require.Len(t, registry.AllSkills(), 1)
+	assert.Equal(t, ScopeRig, registry.GetSkill("go").Scope)
+	assert.Empty(t, registry.MatchSkills(&BeadContext{Title: "golang service"}))
+
+	// Removing the rig skill restores the town skill
+	require.NoError(t, os.Remove(rigPath))
+	changes, err = watcher.Scan()
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	assert.Equal(t, SkillRemoved, changes[0].Kind)
+	assert.Equal(t, ScopeTown, registry.GetSkill("go").Scope)
+	assert.Len(t, registry.MatchSkills(&BeadContext{Title: "golang service"}), 1)
+}
+
+func TestSkillValidate(t *testing.T) {
+	valid := Skill{ID: "s", Triggers: SkillTriggers{Keywords: []string{"x"}}}
+	assert.NoError(t, valid.Validate())
+
+	noTriggers := Skill{ID: "s"}
+	assert.Error(t, noTriggers.Validate())
+
+	badPattern := valid
+	badPattern.Triggers.DescriptionPatterns = []string{"[unclosed"}
+	assert.Error(t, badPattern.Validate())
+
+	noURL := valid
+	noURL.Content.Documentation = []SkillDoc{{Title: "Docs"}}
+	assert.Error(t, noURL.Validate())
+
+	// An extending rig skill may add content without triggers
+	extend := Skill{ID: "s", Extend: true, Content: SkillContent{ContextNotes: []string{"note"}}}
+	assert.NoError(t, extend.Validate())
+}
@@ -0,0 +1,316 @@
+package librarian
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html"
+)
+
+// DefaultSnapshotMaxBytes caps the size of a single documentation snapshot.
+const DefaultSnapshotMaxBytes = 4096
+
+// maxFetchBytes bounds how much of a page is downloaded before conversion.
+const maxFetchBytes = 2 << 20
+
+// DocSnapshot is a markdown copy of an external documentation page.
+type DocSnapshot struct {
+	// URL is the documentation page that was fetched.
+	URL string `json:"url"`
+
+	// FetchedAt is when the page was fetched.
+	FetchedAt time.Time `json:"fetched_at"`
+
+	// SHA256 is the hash of the converted markdown, for reproducibility checks.
+	SHA256 string `json:"sha256"`
+
+	// Truncated is true if the content was cut to the size cap.
+	Truncated bool `json:"truncated,omitempty"`
+
+	// Content is the page converted to markdown.
+	Content string `json:"content"`
+}
+
+// DocSnapshotter fetches documentation URLs and stores markdown snapshots.
+// Snapshots are cached on disk so repeated enrichments are reproducible and
+// work offline or when the docs host is rate-limiting.
+type DocSnapshotter struct {
+	// CacheDir is where snapshots are stored (<town>/librarian/snapshots).
+	CacheDir string
+
+	// MaxBytes caps snapshot content (DefaultSnapshotMaxBytes if zero).
+	MaxBytes int
+
+	// Refresh re-fetches pages even when a cached snapshot exists.
+	Refresh bool
+
+	// Client is the HTTP client (defaults to a 15s timeout client).
+	Client *http.Client
+}
+
+// NewDocSnapshotter creates a snapshotter that caches under the town's
+// librarian directory.
+func NewDocSnapshotter(townRoot string) *DocSnapshotter {
+	return &DocSnapshotter{
+		CacheDir: filepath.Join(townRoot, "librarian", "snapshots"),
+		MaxBytes: DefaultSnapshotMaxBytes,
+	}
+}
+
+// Snapshot returns the snapshot for url, fetching it if it is not cached.
+// If fetching fails but a cached snapshot exists, the cached copy is used.
+func (s *DocSnapshotter) Snapshot(url string) (*DocSnapshot, error) {
+	cached, cacheErr := s.load(url)
+	if cached != nil && !s.Refresh {
+		return cached, nil
+	}
+
+	snap, err := s.fetch(url)
+	if err != nil {
+		if cached != nil {
+			return cached, nil
+		}
+		if cacheErr != nil && !os.IsNotExist(cacheErr) {
+			return nil, fmt.Errorf("fetching %s: %w (cache: %v)", url, err, cacheErr)
+		}
+		return nil, fmt.Errorf("fetching %s: %w", url, err)
+	}
+
+	if err := s.save(snap); err != nil {
+		return nil, err
+	}
+	return snap, nil
+}
+
+// snapshotPath returns the cache file for a URL.
+func (s *DocSnapshotter) snapshotPath(url string) string {
+	sum := sha256.Sum256([]byte(url))
+	return filepath.Join(s.CacheDir, hex.EncodeToString(sum[:8])+".json")
+}
+
+func (s *DocSnapshotter) load(url string) (*DocSnapshot, error) {
+	data, err := os.ReadFile(s.snapshotPath(url))
+	if err != nil {
+		return nil, err
+	}
+	var snap DocSnapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return nil, fmt.Errorf("parsing snapshot: %w", err)
+	}
+	return &snap, nil
+}
+
+func (s *DocSnapshotter) save(snap *DocSnapshot) error {
+	if err := os.MkdirAll(s.CacheDir, 0755); err != nil {
+		return fmt.Errorf("creating snapshots directory: %w", err)
+	}
+	data, err := json.MarshalIndent(snap, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.snapshotPath(snap.URL), data, 0644)
+}
+
+func (s *DocSnapshotter) fetch(url string) (*DocSnapshot, error) {
+	client := s.Client
+	if client == nil {
+		client = &http.Client{Timeout: 15 * time.Second}
+	}
+
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP %s", resp.Status)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxFetchBytes))
+	if err != nil {
+		return nil, fmt.Errorf("reading response: %w", err)
+	}
+
+	var content string
+	if strings.Contains(resp.Header.Get("Content-Type"), "html") || looksLikeHTML(body) {
+		content = htmlToMarkdown(body)
+	} else {
+		content = strings.TrimSpace(string(body))
+	}
+
+	maxBytes := s.MaxBytes
+	if maxBytes <= 0 {
+		maxBytes = DefaultSnapshotMaxBytes
+	}
+	content, truncated := truncateAtLine(content, maxBytes)
+
+	sum := sha256.Sum256([]byte(content))
+	return &DocSnapshot{
+		URL:       url,
+		FetchedAt: time.Now().UTC(),
+		SHA256:    hex.EncodeToString(sum[:]),
+		Truncated: truncated,
+		Content:   content,
+	}, nil
+}
+
+// looksLikeHTML sniffs for an HTML document when no content type is sent.
+func looksLikeHTML(body []byte) bool {
+	head := bytes.ToLower(bytes.TrimSpace(body))
+	if len(head) > 512 {
+		head = head[:512]
+	}
+	return bytes.HasPrefix(head, []byte("<!doctype html")) || bytes.Contains(head, []byte("<html"))
+}
+
+const truncatedMarker = "\n\n[Snapshot truncated]"
+
+// truncateAtLine cuts s to at most max bytes, marker included, preferring a
+// line boundary.
+func truncateAtLine(s string, max int) (string, bool) {
+	if len(s) <= max {
+		return s, false
+	}
+	limit := max - len(truncatedMarker)
+	if limit <= 0 {
+		return s[:max], true
+	}
+	cut := s[:limit]
+	if idx := strings.LastIndex(cut, "\n"); idx > limit/2 {
+		cut = cut[:idx]
+	}
+	return strings.TrimRight(cut, "\n ") + truncatedMarker, true
+}
+
+var blankLines = regexp.MustCompile(`\n{3,}`)
+
+// skippedTags are dropped from snapshots along with everything inside them.
+var skippedTags = map[string]bool{
+	"script": true, "style": true, "nav": true, "header": true,
+	"footer": true, "noscript": true, "svg": true,
+}
+
+// htmlToMarkdown converts an HTML page to simplified markdown.
+// Only structure useful to an agent is kept: headings, paragraphs, lists,
+// links, and code. Navigation chrome, scripts, and styles are dropped.
+func htmlToMarkdown(body []byte) string {
+	z := html.NewTokenizer(bytes.NewReader(body))
+
+	var sb strings.Builder
+	skipDepth := 0
+	inPre := false
+	var href string
+
+	for {
+		tt := z.Next()
+		switch tt {
+		case html.ErrorToken:
+			out := blankLines.ReplaceAllString(sb.String(), "\n\n")
+			return strings.TrimSpace(out)
+
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, hasAttr := z.TagName()
+			tag := string(name)
+			if skippedTags[tag] {
+				if tt == html.StartTagToken {
+					skipDepth++
+				}
+				continue
+			}
+			if skipDepth > 0 {
+				continue
+			}
+			switch tag {
+			case "h1", "h2", "h3", "h4", "h5", "h6":
+				sb.WriteString("\n\n" + strings.Repeat("#", int(tag[1]-'0')) + " ")
+			case "p", "div", "section", "article", "table", "tr":
+				sb.WriteString("\n\n")
+			case "br":
+				sb.WriteString("\n")
+			case "li":
+				sb.WriteString("\n- ")
+			case "pre":
+				inPre = true
+				sb.WriteString("\n\n```\n")
+			case "code":
+				if !inPre {
+					sb.WriteString("`")
+				}
+			case "a":
+				href = ""
+				for hasAttr {
+					var k, v []byte
+					k, v, hasAttr = z.TagAttr()
+					if string(k) == "href" {
+						href = string(v)
+					}
+				}
+				if href != "" {
+					sb.WriteString("[")
+				}
+			}
+
+		case html.EndTagToken:
+			name, _ := z.TagName()
+			tag := string(name)
+			if skippedTags[tag] {
+				if skipDepth > 0 {
+					skipDepth--
+				}
+				continue
+			}
+			if skipDepth > 0 {
+				continue
+			}
+			switch tag {
+			case "h1", "h2", "h3", "h4", "h5", "h6", "p", "ul", "ol":
+				sb.WriteString("\n")
+			case "pre":
+				inPre = false
+				sb.WriteString("\n```\n")
+			case "code":
+				if !inPre {
+					sb.WriteString("`")
+				}
+			case "a":
+				if href != "" {
+					sb.WriteString("](" + href + ")")
+				}
+				href = ""
+			}
+
+		case html.TextToken:
+			if skipDepth > 0 {
+				continue
+			}
+			text := string(z.Text())
+			if inPre {
+				sb.WriteString(text)
+				continue
+			}
+			text = strings.Join(strings.Fields(text), " ")
+			if text == "" {
+				continue
+			}
+			// Preserve a single space between inline runs
+			if sb.Len() > 0 {
+				last := sb.String()[sb.Len()-1]
+				if last != ' ' && last != '\n' && last != '[' && last != '`' {
+					sb.WriteString(" ")
+				}
+			}
+			sb.WriteString(text)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package librarian
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTMLToMarkdown(t *testing.T) {
+	page := `<!DOCTYPE html><html><head><style>body{}</style><script>var x=1;</script></head>
+<body><nav><ul><li><a href="/">Home</a></li></ul><h2>Menu</h2></nav>
+<h1>Retry Policy</h1>
+<p>Use <code>Backoff</code> for <a href="https://example.com/retries">retries</a>.</p>
+<ul><li>First item</li><li>Second item</li></ul>
+<pre>func main() {}</pre>
+<footer>Copyright</footer></body></html>`
+
+	md := htmlToMarkdown([]byte(page))
+
+	assert.Contains(t, md, "# Retry Policy")
+	assert.Contains(t, md, "`Backoff`")
+	assert.Contains(t, md, "[retries](https://example.com/retries)")
+	assert.Contains(t, md, "- First item")
+	assert.Contains(t, md, "```\nfunc main() {}\n```")
+	assert.NotContains(t, md, "var x")
+	assert.NotContains(t, md, "Home")
+	assert.NotContains(t, md, "Copyright")
+	assert.NotContains(t, md, "Menu")
+	assert.NotContains(t, md, "- \n")
+	assert.True(t, strings.HasPrefix(md, "# Retry Policy"), "skipped nav left markup behind:\n%s", md)
+}
+
+func TestDocSnapshotter_CachesAndCaps(t *testing.T) {
+	var hits int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write([]byte(strings.Repeat("line of documentation\n", 100)))
+	}))
+
+	s := &DocSnapshotter{CacheDir: t.TempDir(), MaxBytes: 200}
+
+	snap, err := s.Snapshot(srv.URL + "/doc")
+	require.NoError(t, err)
+	assert.True(t, snap.Truncated)
+	assert.LessOrEqual(t, len(snap.Content), 200)
+	assert.True(t, strings.HasSuffix(snap.Content, "[Snapshot truncated]"))
+	assert.NotEmpty(t, snap.SHA256)
+
+	// Second call is served from cache
+	again, err := s.Snapshot(srv.URL + "/doc")
+	require.NoError(t, err)
+	assert.Equal(t, snap.SHA256, again.SHA256)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&hits))
+
+	// Refresh falls back to the cached copy when the host is unreachable
+	srv.Close()
+	s.Refresh = true
+	offline, err := s.Snapshot(srv.URL + "/doc")
+	require.NoError(t, err)
+	assert.Equal(t, snap.Content, offline.Content)
+}
+
+func TestEnrichmentBuilder_SnapshotDocs(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte("<html><body><h2>Auth Guide</h2><p>Use tokens.</p></body></html>"))
+	}))
+	defer srv.Close()
+
+	builder := NewEnrichmentBuilder(t.TempDir(), DepthStandard)
+	builder.AddDoc("Auth", srv.URL+"/auth", "Auth docs")
+	builder.AddDoc("Missing", srv.URL+"/missing", "Gone")
+
+	errs := builder.SnapshotDocs(&DocSnapshotter{CacheDir: t.TempDir()})
+	assert.Len(t, errs, 1)
+	assert.Equal(t, 1, builder.Stats().SnapshotsCount)
+
+	out := builder.Build("")
+	assert.Contains(t, out, "### Documentation Snapshots")
+	assert.Contains(t, out, "## Auth Guide")
+	assert.Contains(t, out, "Snapshot of "+srv.URL+"/auth")
+	assert.NotContains(t, out, "#### Missing")
+}
@@ -0,0 +1,242 @@
+package mail
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Message authentication.
+//
+// Each rig can have a shared HMAC secret in <rig>/.runtime/mail-signing.key;
+// town-level senders (mayor/, deacon/, overseer) use the key in the town's
+// .runtime directory. Mail is signed with the key of the workspace it is
+// sent from (the rig the sending process runs in), and a workspace may only
+// sign mail from its own senders, so an agent can't vouch for another
+// rig's mail. Readers check the signature against the sender's rig key. A
+// valid signature shows the message came from an agent with access to the
+// sender's rig key — it authenticates the rig, not the individual agent.
+//
+// The signature covers the sender, subject, body, thread, reply-to, type
+// and priority. The recipient is left out so every copy of a fan-out send
+// carries the same signature.
+
+// MailKeyFile is the name of a rig's (or the town's) mail signing key.
+const MailKeyFile = "mail-signing.key"
+
+// sigLabelPrefix is the beads label holding a message signature.
+const sigLabelPrefix = "sig:"
+
+// ErrForeignSender is returned when signing mail whose sender belongs to
+// another rig than the workspace sending it.
+var ErrForeignSender = errors.New("sender does not belong to this workspace")
+
+// AuthStatus is the outcome of checking a message's signature.
+type AuthStatus string
+
+// Authentication statuses.
+const (
+	// AuthVerified means the signature matches the sender's rig key.
+	AuthVerified AuthStatus = "verified"
+
+	// AuthUnsigned means the sender's rig signs its mail but this message
+	// carries no signature.
+	AuthUnsigned AuthStatus = "unsigned"
+
+	// AuthInvalid means the signature doesn't match: the message was
+	// altered, or signed with another rig's key.
+	AuthInvalid AuthStatus = "invalid"
+
+	// AuthDisabled means the sender's rig has no key, so its mail can't be
+	// authenticated.
+	AuthDisabled AuthStatus = "disabled"
+)
+
+// Trusted reports whether a message with this status can be relied on to
+// come from its sender's rig.
+func (s AuthStatus) Trusted() bool {
+	return s == AuthVerified
+}
+
+// Flagged reports whether the status should be called out to the reader:
+// the sender's rig signs its mail, but this message didn't check out.
+func (s AuthStatus) Flagged() bool {
+	return s == AuthUnsigned || s == AuthInvalid
+}
+
+// senderRig returns the rig a sender address belongs to, or "" for
+// town-level senders.
+func senderRig(from string) string {
+	identity := addressToIdentity(from)
+	if isTownLevelAddress(identity) {
+		return ""
+	}
+	rig, _, _ := strings.Cut(identity, "/")
+	return rig
+}
+
+// MailKeyPath returns the signing key for rig, or for town-level senders
+// when rig is "".
+func MailKeyPath(townRoot, rig string) string {
+	if rig == "" {
+		return filepath.Join(townRoot, ".runtime", MailKeyFile)
+	}
+	return filepath.Join(townRoot, rig, ".runtime", MailKeyFile)
+}
+
+// SenderKeyPath returns the signing key for the rig of a sender address.
+func SenderKeyPath(townRoot, from string) string {
+	return MailKeyPath(townRoot, senderRig(from))
+}
+
+// workspaceRig returns the rig whose workspace holds dir, or "" for the
+// town-level workspaces (the town root, mayor/ and deacon/). ok is false
+// when dir is outside the town.
+func workspaceRig(townRoot, dir string) (rig string, ok bool) {
+	rel, err := filepath.Rel(townRoot, dir)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+	first, _, _ := strings.Cut(filepath.ToSlash(rel), "/")
+	switch {
+	case first == ".", first == "mayor", first == "deacon", strings.HasPrefix(first, "."):
+		return "", true
+	}
+	return first, true
+}
+
+// GenerateMailKey writes a new random signing key to path, readable only by
+// its owner. An existing key is kept unless force is set, since replacing it
+// invalidates every message already signed with it.
+func GenerateMailKey(path string, force bool) error {
+	if _, err := os.Stat(path); err == nil && !force {
+		return fmt.Errorf("mail signing key %s already exists", path)
+	}
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return fmt.Errorf("generating mail signing key: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("creating key directory: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(hex.EncodeToString(key)+"\n"), 0600); err != nil {
+		return fmt.Errorf("writing mail signing key: %w", err)
+	}
+	return nil
+}
+
+// loadMailKey reads a hex-encoded signing key. It returns nil, nil when the
+// key doesn't exist.
+func loadMailKey(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading mail signing key: %w", err)
+	}
+	key, err := hex.DecodeString(strings.TrimSpace(string(data)))
+	if err != nil || len(key) == 0 {
+		return nil, fmt.Errorf("mail signing key %s is not a hex key", path)
+	}
+	return key, nil
+}
+
+// computeSignature returns the hex HMAC-SHA256 of a message's signed fields.
+// Fields are normalized the way they round-trip through beads, so a
+// delivered copy verifies against the signature made at send time.
+func computeSignature(key []byte, msg *Message) string {
+	mac := hmac.New(sha256.New, key)
+	for _, field := range []string{
+		identityToAddress(addressToIdentity(msg.From)),
+		strings.TrimSpace(msg.Subject),
+		strings.TrimSpace(msg.Body),
+		msg.ThreadID,
+		msg.ReplyTo,
+		string(ParseMessageType(string(msg.Type))),
+		string(PriorityFromInt(PriorityToBeads(msg.Priority))),
+	} {
+		// Length-prefix each field so they can't be shifted into each other
+		fmt.Fprintf(mac, "%d:%s\n", len(field), field)
+	}
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// SignMessage sets msg.Signature with the key of the workspace holding
+// workDir, the directory the message is sent from. It reports whether the
+// message was signed: mail sent from outside the town, or from a rig
+// without a key, is sent unsigned. A sender from another rig than the
+// workspace's is rejected with ErrForeignSender.
+func SignMessage(townRoot, workDir string, msg *Message) (bool, error) {
+	if townRoot == "" {
+		return false, nil
+	}
+	rig, ok := workspaceRig(townRoot, workDir)
+	if !ok {
+		return false, nil
+	}
+	key, err := loadMailKey(MailKeyPath(townRoot, rig))
+	if err != nil || key == nil {
+		return false, err
+	}
+	if senderRig(msg.From) != rig {
+		workspace := rig
+		if workspace == "" {
+			workspace = "town"
+		}
+		return false, fmt.Errorf("%w: %s sent from the %s workspace", ErrForeignSender, msg.From, workspace)
+	}
+	msg.Signature = computeSignature(key, msg)
+	return true, nil
+}
+
+// VerifyMessage checks msg's signature against its sender's rig key.
+func VerifyMessage(townRoot string, msg *Message) (AuthStatus, error) {
+	if townRoot == "" {
+		return AuthDisabled, nil
+	}
+	key, err := loadMailKey(SenderKeyPath(townRoot, msg.From))
+	if err != nil {
+		return AuthDisabled, err
+	}
+	if key == nil {
+		return AuthDisabled, nil
+	}
+	if msg.Signature == "" {
+		return AuthUnsigned, nil
+	}
+	if !hmac.Equal([]byte(computeSignature(key, msg)), []byte(msg.Signature)) {
+		return AuthInvalid, nil
+	}
+	return AuthVerified, nil
+}
+
+// signatureLabel signs msg with the key of the workspace this process runs
+// in and returns its signature label, or "" when that workspace has no key.
+func (r *Router) signatureLabel(msg *Message) (string, error) {
+	workDir, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	signed, err := SignMessage(r.townRoot, workDir, msg)
+	if err != nil || !signed {
+		return "", err
+	}
+	return sigLabelPrefix + msg.Signature, nil
+}
+
+// Authenticate checks a message's signature against its sender's rig key
+// in the router's town. Keys that can't be read count as invalid.
+func (r *Router) Authenticate(msg *Message) AuthStatus {
+	status, err := VerifyMessage(r.townRoot, msg)
+	if err != nil {
+		return AuthInvalid
+	}
+	return status
+}
@@ -0,0 +1,331 @@
+package mail
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ExportVersion is the format version written to export manifests.
+const ExportVersion = 1
+
+// Files in an export archive.
+const (
+	exportManifestFile = "manifest.json"
+	exportInboxFile    = "inbox.jsonl"
+	exportArchiveFile  = "archive.jsonl"
+)
+
+// ExportManifest describes a mailbox export.
+type ExportManifest struct {
+	// Version is the export format version.
+	Version int `json:"version"`
+
+	// Address is the mailbox the messages were exported from.
+	Address string `json:"address"`
+
+	// ExportedAt is when the export was made.
+	ExportedAt time.Time `json:"exported_at"`
+
+	// Since is the cutoff for exported messages, if any.
+	Since *time.Time `json:"since,omitempty"`
+
+	// Inbox is the number of inbox messages.
+	Inbox int `json:"inbox"`
+
+	// Archived is the number of archived messages.
+	Archived int `json:"archived"`
+}
+
+// Export is a mailbox's inbox and archive, as written by Mailbox.Export.
+type Export struct {
+	Manifest ExportManifest
+	Inbox    []*Message
+	Archived []*Message
+}
+
+// ImportResult counts the messages an import added.
+type ImportResult struct {
+	Inbox    int
+	Archived int
+
+	// Skipped is the number of messages already in the mailbox.
+	Skipped int
+}
+
+// Export writes the mailbox's inbox and archive as a gzipped tar holding a
+// manifest and one JSONL file each, oldest message first. Read state,
+// threads and pinning are kept on the messages. Messages older than since
+// are left out; a zero since exports everything.
+func (m *Mailbox) Export(w io.Writer, address string, since time.Time) (*ExportManifest, error) {
+	inbox, err := m.List()
+	if err != nil {
+		return nil, fmt.Errorf("listing inbox: %w", err)
+	}
+	archived, err := m.ListArchived()
+	if err != nil {
+		return nil, fmt.Errorf("listing archive: %w", err)
+	}
+	inbox, archived = exportable(inbox, since), exportable(archived, since)
+
+	manifest := &ExportManifest{
+		Version:    ExportVersion,
+		Address:    address,
+		ExportedAt: timeNow().UTC(),
+		Inbox:      len(inbox),
+		Archived:   len(archived),
+	}
+	if !since.IsZero() {
+		manifest.Since = &since
+	}
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	manifestData, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	files := []struct {
+		name string
+		data []byte
+	}{
+		{exportManifestFile, manifestData},
+		{exportInboxFile, marshalJSONL(inbox)},
+		{exportArchiveFile, marshalJSONL(archived)},
+	}
+	for _, f := range files {
+		hdr := &tar.Header{
+			Name:    f.name,
+			Mode:    0644,
+			Size:    int64(len(f.data)),
+			ModTime: manifest.ExportedAt,
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return nil, err
+		}
+		if _, err := tw.Write(f.data); err != nil {
+			return nil, err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// exportable returns the messages at or after since, oldest first.
+func exportable(messages []*Message, since time.Time) []*Message {
+	var out []*Message
+	for _, msg := range messages {
+		if since.IsZero() || !msg.Timestamp.Before(since) {
+			out = append(out, msg)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].Timestamp.Before(out[j].Timestamp)
+	})
+	return out
+}
+
+func marshalJSONL(messages []*Message) []byte {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, msg := range messages {
+		_ = enc.Encode(msg) // Message always marshals
+	}
+	return buf.Bytes()
+}
+
+// ReadExport reads an archive written by Mailbox.Export.
+func ReadExport(r io.Reader) (*Export, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("not a mail export: %w", err)
+	}
+	defer func() { _ = gz.Close() }()
+
+	exp := &Export{}
+	sawManifest := false
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading mail export: %w", err)
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", hdr.Name, err)
+		}
+		switch hdr.Name {
+		case exportManifestFile:
+			if err := json.Unmarshal(data, &exp.Manifest); err != nil {
+				return nil, fmt.Errorf("parsing %s: %w", exportManifestFile, err)
+			}
+			sawManifest = true
+		case exportInboxFile:
+			if exp.Inbox, err = unmarshalJSONL(data); err != nil {
+				return nil, fmt.Errorf("parsing %s: %w", exportInboxFile, err)
+			}
+		case exportArchiveFile:
+			if exp.Archived, err = unmarshalJSONL(data); err != nil {
+				return nil, fmt.Errorf("parsing %s: %w", exportArchiveFile, err)
+			}
+		}
+	}
+	if !sawManifest {
+		return nil, fmt.Errorf("not a mail export: missing %s", exportManifestFile)
+	}
+	if exp.Manifest.Version > ExportVersion {
+		return nil, fmt.Errorf("mail export version %d is newer than supported (%d)", exp.Manifest.Version, ExportVersion)
+	}
+	return exp, nil
+}
+
+func unmarshalJSONL(data []byte) ([]*Message, error) {
+	var messages []*Message
+	dec := json.NewDecoder(bytes.NewReader(data))
+	for dec.More() {
+		var msg Message
+		if err := dec.Decode(&msg); err != nil {
+			return nil, err
+		}
+		messages = append(messages, &msg)
+	}
+	return messages, nil
+}
+
+// Import adds an export's messages to the mailbox, skipping messages it
+// already holds, so re-importing the same archive is harmless. Inbox
+// messages keep their read state, thread and original send time; archived
+// messages go to the archive.
+//
+// Beads mailboxes assign new IDs to imported inbox messages, so reply-to
+// references are rewritten to the new IDs, and messages are imported as
+// permanent (not wisps) addressed to this mailbox.
+func (m *Mailbox) Import(exp *Export) (*ImportResult, error) {
+	inbox, err := m.List()
+	if err != nil {
+		return nil, fmt.Errorf("listing inbox: %w", err)
+	}
+	archived, err := m.ListArchived()
+	if err != nil {
+		return nil, fmt.Errorf("listing archive: %w", err)
+	}
+	have := make(map[string]bool)
+	for _, msg := range append(inbox, archived...) {
+		have[msg.ID] = true
+		have[importKey(msg)] = true
+	}
+
+	result := &ImportResult{}
+	ids := make(map[string]string) // exported ID -> ID in this mailbox
+	for _, msg := range exportable(exp.Inbox, time.Time{}) {
+		if have[msg.ID] || have[importKey(msg)] {
+			result.Skipped++
+			continue
+		}
+		imported := *msg
+		if newID, ok := ids[imported.ReplyTo]; ok {
+			imported.ReplyTo = newID
+		}
+		if m.legacy {
+			err = m.store.Append(&imported)
+		} else {
+			imported.ID, err = m.createBeads(&imported)
+		}
+		if err != nil {
+			return result, fmt.Errorf("importing %s: %w", msg.ID, err)
+		}
+		ids[msg.ID] = imported.ID
+		have[msg.ID] = true
+		have[importKey(msg)] = true
+		result.Inbox++
+	}
+
+	for _, msg := range exportable(exp.Archived, time.Time{}) {
+		if have[msg.ID] || have[importKey(msg)] {
+			result.Skipped++
+			continue
+		}
+		imported := *msg
+		if newID, ok := ids[imported.ReplyTo]; ok {
+			imported.ReplyTo = newID
+		}
+		if err := m.appendToArchive(&imported); err != nil {
+			return result, fmt.Errorf("archiving %s: %w", msg.ID, err)
+		}
+		have[msg.ID] = true
+		have[importKey(msg)] = true
+		result.Archived++
+	}
+	return result, nil
+}
+
+// importKey identifies a message independently of its ID, which beads
+// mailboxes reassign on import.
+func importKey(msg *Message) string {
+	return strings.Join([]string{msg.From, msg.Subject, msg.Timestamp.UTC().Format(time.RFC3339)}, "\x00")
+}
+
+// createBeads creates an imported message in a beads mailbox and returns
+// its new ID. The original send time is kept in a sent-at label.
+func (m *Mailbox) createBeads(msg *Message) (string, error) {
+	labels := []string{
+		"from:" + msg.From,
+		"sent-at:" + msg.Timestamp.UTC().Format(time.RFC3339),
+	}
+	if msg.ThreadID != "" {
+		labels = append(labels, "thread:"+msg.ThreadID)
+	}
+	if msg.ReplyTo != "" {
+		labels = append(labels, "reply-to:"+msg.ReplyTo)
+	}
+	if msg.Type != "" && msg.Type != TypeNotification {
+		labels = append(labels, "msg-type:"+string(msg.Type))
+	}
+	if len(msg.Recipients) > 1 {
+		for _, to := range msg.Recipients {
+			labels = append(labels, "to:"+addressToIdentity(to))
+		}
+	}
+	if msg.Signature != "" {
+		labels = append(labels, sigLabelPrefix+msg.Signature)
+	}
+	if msg.Read {
+		labels = append(labels, "read")
+	}
+
+	args := []string{"create", msg.Subject,
+		"--type", "message",
+		"--assignee", m.identity,
+		"-d", msg.Body,
+		"--priority", fmt.Sprintf("%d", PriorityToBeads(msg.Priority)),
+		"--labels", strings.Join(labels, ","),
+		"--actor", msg.From,
+		"--json",
+	}
+	stdout, err := runBdCommand(args, m.workDir, m.beadsDir)
+	if err != nil {
+		return "", err
+	}
+	var created struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal(stdout, &created); err != nil || created.ID == "" {
+		return "", fmt.Errorf("parsing bd create output: %q", strings.TrimSpace(string(stdout)))
+	}
+	return created.ID, nil
+}
@@ -0,0 +1,127 @@
+package mail
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestMailboxExportImport(t *testing.T) {
+	for _, store := range []string{StoreJSONL, StoreMaildir} {
+		t.Run(store, func(t *testing.T) {
+			var src *Mailbox
+			if store == StoreMaildir {
+				src = NewMailboxWithStore(NewMaildirStore(t.TempDir()))
+			} else {
+				src = NewMailbox(t.TempDir())
+			}
+
+			now := time.Now().UTC().Truncate(time.Second)
+			old := &Message{ID: "msg-old", From: "mayor/", To: "overseer", Subject: "Old", Timestamp: now.Add(-100 * 24 * time.Hour), ThreadID: "t-1"}
+			first := &Message{ID: "msg-1", From: "mayor/", To: "overseer", Subject: "Status", Body: "All green", Timestamp: now.Add(-2 * time.Hour), ThreadID: "t-2", Read: true}
+			reply := &Message{ID: "msg-2", From: "overseer", To: "overseer", Subject: "Re: Status", Timestamp: now.Add(-time.Hour), ThreadID: "t-2", ReplyTo: "msg-1", Pinned: true}
+			filed := &Message{ID: "msg-3", From: "gastown/witness", To: "overseer", Subject: "Report", Timestamp: now.Add(-3 * time.Hour)}
+			for _, msg := range []*Message{old, first, reply} {
+				if err := src.Append(msg); err != nil {
+					t.Fatalf("Append() error = %v", err)
+				}
+			}
+			if err := src.appendToArchive(filed); err != nil {
+				t.Fatalf("appendToArchive() error = %v", err)
+			}
+
+			var buf bytes.Buffer
+			manifest, err := src.Export(&buf, "overseer", now.Add(-90*24*time.Hour))
+			if err != nil {
+				t.Fatalf("Export() error = %v", err)
+			}
+			if manifest.Inbox != 2 || manifest.Archived != 1 {
+				t.Errorf("manifest counts = %d inbox, %d archived; want 2, 1", manifest.Inbox, manifest.Archived)
+			}
+
+			exp, err := ReadExport(bytes.NewReader(buf.Bytes()))
+			if err != nil {
+				t.Fatalf("ReadExport() error = %v", err)
+			}
+			if exp.Manifest.Address != "overseer" || exp.Manifest.Since == nil {
+				t.Errorf("manifest = %+v", exp.Manifest)
+			}
+
+			dst := NewMailbox(t.TempDir())
+			result, err := dst.Import(exp)
+			if err != nil {
+				t.Fatalf("Import() error = %v", err)
+			}
+			if result.Inbox != 2 || result.Archived != 1 || result.Skipped != 0 {
+				t.Errorf("Import() = %+v, want 2 inbox, 1 archived", result)
+			}
+
+			got, err := dst.Get("msg-1")
+			if err != nil {
+				t.Fatalf("Get(msg-1) error = %v", err)
+			}
+			if !got.Read || got.ThreadID != "t-2" || got.Body != "All green" || !got.Timestamp.Equal(first.Timestamp) {
+				t.Errorf("imported msg-1 = %+v", got)
+			}
+			got, err = dst.Get("msg-2")
+			if err != nil {
+				t.Fatalf("Get(msg-2) error = %v", err)
+			}
+			if got.Read || !got.Pinned || got.ReplyTo != "msg-1" {
+				t.Errorf("imported msg-2 = %+v", got)
+			}
+			if _, err := dst.Get("msg-old"); err != ErrMessageNotFound {
+				t.Errorf("message before --since should not be exported, Get() error = %v", err)
+			}
+			archived, _ := dst.ListArchived()
+			if len(archived) != 1 || archived[0].ID != "msg-3" {
+				t.Errorf("archive = %v, want msg-3", archived)
+			}
+
+			// Importing again adds nothing
+			result, err = dst.Import(exp)
+			if err != nil {
+				t.Fatalf("second Import() error = %v", err)
+			}
+			if result.Inbox != 0 || result.Archived != 0 || result.Skipped != 3 {
+				t.Errorf("second Import() = %+v, want 3 skipped", result)
+			}
+		})
+	}
+}
+
+func TestReadExportInvalid(t *testing.T) {
+	if _, err := ReadExport(bytes.NewReader([]byte("not gzip"))); err == nil {
+		t.Error("ReadExport() of garbage should fail")
+	}
+
+	// A valid archive of an empty mailbox round-trips
+	var buf bytes.Buffer
+	if _, err := NewMailbox(t.TempDir()).Export(&buf, "mayor/", time.Time{}); err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	exp, err := ReadExport(&buf)
+	if err != nil {
+		t.Fatalf("ReadExport() error = %v", err)
+	}
+	if len(exp.Inbox) != 0 || len(exp.Archived) != 0 || exp.Manifest.Since != nil {
+		t.Errorf("empty export = %+v", exp)
+	}
+}
+
+func TestBeadsMessageSentAt(t *testing.T) {
+	created := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	bm := BeadsMessage{
+		ID:        "hq-1",
+		CreatedAt: created,
+		Labels:    []string{"from:mayor/", "sent-at:2025-11-02T08:30:00Z"},
+	}
+	if got := bm.ToMessage().Timestamp; !got.Equal(time.Date(2025, 11, 2, 8, 30, 0, 0, time.UTC)) {
+		t.Errorf("Timestamp = %v, want sent-at time", got)
+	}
+
+	bm = BeadsMessage{ID: "hq-2", CreatedAt: created, Labels: []string{"sent-at:garbage"}}
+	if got := bm.ToMessage().Timestamp; !got.Equal(created) {
+		t.Errorf("Timestamp = %v, want created time for unparseable sent-at", got)
+	}
+}
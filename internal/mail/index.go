@@ -0,0 +1,183 @@
+package mail
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// maildirIndexFile caches parsed messages in a Maildir folder, like
+// Dovecot's dovecot.index, so listing a large inbox doesn't re-read and
+// parse every message file. It is one JSON entry per line: delivery
+// appends, and scans compact it when messages have been removed.
+const maildirIndexFile = ".gt-index.jsonl"
+
+// indexEntry is a message in the index. Message files only change when
+// Replace rewrites them, which appends a fresh entry, so an entry stays
+// valid for as long as its file exists; read and pinned state always come
+// from the filename flags.
+type indexEntry struct {
+	// File is the message's Maildir unique name (without flags).
+	File string `json:"file"`
+
+	Message *Message `json:"msg"`
+
+	// Tokens are the distinct lowercased words of the subject and body,
+	// used to skip messages that can't match a search.
+	Tokens []string `json:"tokens,omitempty"`
+}
+
+// maildirIndex is a folder's index, keyed by unique name.
+type maildirIndex struct {
+	path    string
+	entries map[string]*indexEntry
+}
+
+// loadMaildirIndex reads a folder's index. A missing or damaged index is
+// empty; it is rebuilt by the next scan.
+func loadMaildirIndex(dir string) *maildirIndex {
+	idx := &maildirIndex{
+		path:    filepath.Join(dir, maildirIndexFile),
+		entries: make(map[string]*indexEntry),
+	}
+	file, err := os.Open(idx.path)
+	if err != nil {
+		return idx
+	}
+	defer func() { _ = file.Close() }() // non-fatal: OS will close on exit
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var e indexEntry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil || e.File == "" || e.Message == nil {
+			continue // Skip malformed lines
+		}
+		idx.entries[e.File] = &e
+	}
+	return idx
+}
+
+// newIndexEntry indexes a delivered message.
+func newIndexEntry(unique string, msg *Message) *indexEntry {
+	stored := *msg
+	stored.Read, stored.Pinned = false, false
+	return &indexEntry{
+		File:    unique,
+		Message: &stored,
+		Tokens:  tokenize(msg.Subject + " " + msg.Body),
+	}
+}
+
+// appendMaildirIndex records a delivery in the folder's index.
+func appendMaildirIndex(dir string, e *indexEntry) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	file, err := os.OpenFile(filepath.Join(dir, maildirIndexFile), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	_, err = file.Write(append(data, '\n'))
+	if cerr := file.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// save atomically rewrites the index with its current entries.
+func (idx *maildirIndex) save() error {
+	files := make([]string, 0, len(idx.entries))
+	for f := range idx.entries {
+		files = append(files, f)
+	}
+	sort.Strings(files)
+
+	var buf strings.Builder
+	for _, f := range files {
+		data, err := json.Marshal(idx.entries[f])
+		if err != nil {
+			return err
+		}
+		buf.Write(data)
+		buf.WriteByte('\n')
+	}
+
+	tmpPath := idx.path + ".tmp"
+	if err := os.WriteFile(tmpPath, []byte(buf.String()), 0600); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpPath, idx.path); err != nil {
+		_ = os.Remove(tmpPath) // best-effort cleanup
+		return err
+	}
+	return nil
+}
+
+// tokenize returns the distinct lowercased words (letter and digit runs)
+// of s, sorted.
+func tokenize(s string) []string {
+	seen := make(map[string]bool)
+	for _, word := range strings.FieldsFunc(strings.ToLower(s), isTokenSeparator) {
+		seen[word] = true
+	}
+	tokens := make([]string, 0, len(seen))
+	for t := range seen {
+		tokens = append(tokens, t)
+	}
+	sort.Strings(tokens)
+	return tokens
+}
+
+func isTokenSeparator(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+}
+
+// tokenSet holds the indexed tokens of the messages a store last listed,
+// keyed by message ID.
+type tokenSet struct {
+	mu     sync.RWMutex
+	tokens map[string][]string
+}
+
+func (ts *tokenSet) set(files []maildirFile) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	if ts.tokens == nil {
+		ts.tokens = make(map[string][]string)
+	}
+	for _, f := range files {
+		ts.tokens[f.msg.ID] = f.tokens
+	}
+}
+
+// mayContain reports whether the subject or body of message id may contain
+// query (case-insensitively). Every word of the query must occur within
+// one of the message's words; messages that weren't indexed may match.
+func (ts *tokenSet) mayContain(id, query string) bool {
+	ts.mu.RLock()
+	tokens, ok := ts.tokens[id]
+	ts.mu.RUnlock()
+	if !ok {
+		return true
+	}
+	for _, word := range strings.FieldsFunc(strings.ToLower(query), isTokenSeparator) {
+		found := false
+		for _, t := range tokens {
+			if strings.Contains(t, word) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
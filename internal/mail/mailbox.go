@@ -1,7 +1,6 @@
 package mail
 
 import (
-	"bufio"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -29,15 +28,23 @@ type Mailbox struct {
 	identity string // beads identity (e.g., "gastown/polecats/Toast")
 	workDir  string // directory to run bd commands in
 	beadsDir string // explicit .beads directory path (set via BEADS_DIR)
-	path     string // for legacy JSONL mode (crew workers)
-	legacy   bool   // true = use JSONL files, false = use beads
+	path     string // for legacy file mode (crew workers)
+	store    Store  // file store for legacy mode (JSONL or Maildir)
+	legacy   bool   // true = use file store, false = use beads
 }
 
-// NewMailbox creates a mailbox for the given JSONL path (legacy mode).
-// Used by crew workers that have local JSONL inboxes.
+// NewMailbox creates a file-based mailbox in the given directory (legacy mode).
+// Used by crew workers that have local inboxes. The directory holds a JSONL
+// inbox by default, or a Maildir (see OpenStore).
 func NewMailbox(path string) *Mailbox {
+	return NewMailboxWithStore(OpenStore(path))
+}
+
+// NewMailboxWithStore creates a file-based mailbox on an explicit store.
+func NewMailboxWithStore(store Store) *Mailbox {
 	return &Mailbox{
-		path:   filepath.Join(path, "inbox.jsonl"),
+		path:   store.Path(),
+		store:  store,
 		legacy: true,
 	}
 }
@@ -78,7 +85,8 @@ func (m *Mailbox) Identity() string {
 	return m.identity
 }
 
-// Path returns the JSONL path for legacy mailboxes.
+// Path returns the inbox location for legacy mailboxes
+// (the JSONL file or the Maildir root).
 func (m *Mailbox) Path() string {
 	return m.path
 }
@@ -253,31 +261,8 @@ func (m *Mailbox) queryMessages(beadsDir, filterFlag, filterValue, status string
 }
 
 func (m *Mailbox) listLegacy() ([]*Message, error) {
-	file, err := os.Open(m.path)
+	messages, err := m.store.List()
 	if err != nil {
-		if os.IsNotExist(err) {
-			return nil, nil
-		}
-		return nil, err
-	}
-	defer func() { _ = file.Close() }() // non-fatal: OS will close on exit
-
-	var messages []*Message
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		line := scanner.Text()
-		if line == "" {
-			continue
-		}
-
-		var msg Message
-		if err := json.Unmarshal([]byte(line), &msg); err != nil {
-			continue // Skip malformed lines
-		}
-		messages = append(messages, &msg)
-	}
-
-	if err := scanner.Err(); err != nil {
 		return nil, err
 	}
 
@@ -557,70 +542,25 @@ func (m *Mailbox) Archive(id string) error {
 // ArchivePath returns the path to the archive file.
 func (m *Mailbox) ArchivePath() string {
 	if m.legacy {
-		return m.path + ".archive"
+		return m.store.ArchivePath()
 	}
 	// For beads, use archive.jsonl in the same directory as beads
 	return filepath.Join(m.beadsDir, "archive.jsonl")
 }
 
 func (m *Mailbox) appendToArchive(msg *Message) error {
-	archivePath := m.ArchivePath()
-
-	// Ensure directory exists
-	dir := filepath.Dir(archivePath)
-	if err := os.MkdirAll(dir, 0755); err != nil {
-		return err
-	}
-
-	// Open for append
-	file, err := os.OpenFile(archivePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644) //nolint:gosec // G302: archive is non-sensitive operational data
-	if err != nil {
-		return err
-	}
-	defer func() { _ = file.Close() }()
-
-	data, err := json.Marshal(msg)
-	if err != nil {
-		return err
+	if m.legacy {
+		return m.store.AppendArchived(msg)
 	}
-
-	_, err = file.WriteString(string(data) + "\n")
-	return err
+	return appendJSONL(m.ArchivePath(), msg, 0644)
 }
 
-// ListArchived returns all messages in the archive file.
+// ListArchived returns all messages in the archive.
 func (m *Mailbox) ListArchived() ([]*Message, error) {
-	archivePath := m.ArchivePath()
-
-	file, err := os.Open(archivePath)
-	if err != nil {
-		if os.IsNotExist(err) {
-			return nil, nil
-		}
-		return nil, err
-	}
-	defer func() { _ = file.Close() }()
-
-	var messages []*Message
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		line := scanner.Text()
-		if line == "" {
-			continue
-		}
-
-		var msg Message
-		if err := json.Unmarshal([]byte(line), &msg); err != nil {
-			continue // Skip malformed lines
-		}
-		messages = append(messages, &msg)
-	}
-
-	if err := scanner.Err(); err != nil {
-		return nil, err
+	if m.legacy {
+		return m.store.ListArchived()
 	}
-
-	return messages, nil
+	return readJSONL(m.ArchivePath())
 }
 
 // PurgeArchive removes messages from the archive, optionally filtering by age.
@@ -637,7 +577,7 @@ func (m *Mailbox) PurgeArchive(olderThanDays int) (int, error) {
 
 	// If no age filter, remove all
 	if olderThanDays <= 0 {
-		if err := os.Remove(m.ArchivePath()); err != nil && !os.IsNotExist(err) {
+		if err := m.rewriteArchive(nil); err != nil {
 			return 0, err
 		}
 		return len(messages), nil
@@ -657,44 +597,19 @@ func (m *Mailbox) PurgeArchive(olderThanDays int) (int, error) {
 	}
 
 	// Rewrite archive with remaining messages
-	if len(keep) == 0 {
-		if err := os.Remove(m.ArchivePath()); err != nil && !os.IsNotExist(err) {
-			return 0, err
-		}
-	} else {
-		if err := m.rewriteArchive(keep); err != nil {
-			return 0, err
-		}
+	if err := m.rewriteArchive(keep); err != nil {
+		return 0, err
 	}
 
 	return purged, nil
 }
 
+// rewriteArchive replaces the archive contents; an empty list removes it.
 func (m *Mailbox) rewriteArchive(messages []*Message) error {
-	archivePath := m.ArchivePath()
-	tmpPath := archivePath + ".tmp"
-
-	file, err := os.Create(tmpPath)
-	if err != nil {
-		return err
-	}
-
-	for _, msg := range messages {
-		data, err := json.Marshal(msg)
-		if err != nil {
-			_ = file.Close()
-			_ = os.Remove(tmpPath)
-			return err
-		}
-		_, _ = file.WriteString(string(data) + "\n")
-	}
-
-	if err := file.Close(); err != nil {
-		_ = os.Remove(tmpPath)
-		return err
+	if m.legacy {
+		return m.store.ReplaceArchived(messages)
 	}
-
-	return os.Rename(tmpPath, archivePath)
+	return replaceJSONLArchive(m.ArchivePath(), messages)
 }
 
 // SearchOptions specifies search parameters.
@@ -739,6 +654,7 @@ func (m *Mailbox) Search(opts SearchOptions) ([]*Message, error) {
 	// Combine and search
 	all := append(inbox, archived...)
 	var matches []*Message
+	index, _ := m.store.(SearchIndex)
 
 	for _, msg := range all {
 		// Apply from filter
@@ -746,6 +662,11 @@ func (m *Mailbox) Search(opts SearchOptions) ([]*Message, error) {
 			continue
 		}
 
+		// Skip messages the store's word index rules out
+		if index != nil && !index.MayContain(msg.ID, opts.Query) {
+			continue
+		}
+
 		// Search in specified fields
 		matched := false
 		if opts.SubjectOnly {
@@ -798,59 +719,12 @@ func (m *Mailbox) Append(msg *Message) error {
 }
 
 func (m *Mailbox) appendLegacy(msg *Message) error {
-	// Ensure directory exists
-	dir := filepath.Dir(m.path)
-	if err := os.MkdirAll(dir, 0755); err != nil {
-		return err
-	}
-
-	// Open for append
-	file, err := os.OpenFile(m.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
-	if err != nil {
-		return err
-	}
-	defer func() { _ = file.Close() }() // non-fatal: OS will close on exit
-
-	data, err := json.Marshal(msg)
-	if err != nil {
-		return err
-	}
-
-	_, err = file.WriteString(string(data) + "\n")
-	return err
+	return m.store.Append(msg)
 }
 
 // rewriteLegacy rewrites the mailbox with the given messages.
 func (m *Mailbox) rewriteLegacy(messages []*Message) error {
-	// Sort by timestamp (oldest first for JSONL)
-	sort.Slice(messages, func(i, j int) bool {
-		return messages[i].Timestamp.Before(messages[j].Timestamp)
-	})
-
-	// Write to temp file
-	tmpPath := m.path + ".tmp"
-	file, err := os.Create(tmpPath)
-	if err != nil {
-		return err
-	}
-
-	for _, msg := range messages {
-		data, err := json.Marshal(msg)
-		if err != nil {
-			_ = file.Close()         // best-effort cleanup
-			_ = os.Remove(tmpPath)   // best-effort cleanup
-			return err
-		}
-		_, _ = file.WriteString(string(data) + "\n") // non-fatal: partial write is acceptable
-	}
-
-	if err := file.Close(); err != nil {
-		_ = os.Remove(tmpPath) // best-effort cleanup
-		return err
-	}
-
-	// Atomic rename
-	return os.Rename(tmpPath, m.path)
+	return m.store.Replace(messages)
 }
 
 // ListByThread returns all messages in a given thread.
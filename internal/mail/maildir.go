@@ -0,0 +1,458 @@
+package mail
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// maildirArchive is the Maildir++ subfolder holding archived messages.
+const maildirArchive = ".Archive"
+
+// maildirDomain qualifies message IDs in Message-ID headers.
+const maildirDomain = "gastown"
+
+// MaildirStore keeps each message as an RFC 5322 file in a Maildir
+// (tmp/new/cur), so standard mail tools like mutt and notmuch can read and
+// search agent mail. Read state maps to the Seen flag and pinning to the
+// Flagged flag; archived messages live in the .Archive Maildir++ folder.
+// Gastown-specific fields are kept in X-Gastown-* headers.
+type MaildirStore struct {
+	dir string
+
+	// tokens are the indexed words of the messages last listed, for Search.
+	tokens tokenSet
+}
+
+// NewMaildirStore creates a Maildir store rooted at dir.
+func NewMaildirStore(dir string) *MaildirStore {
+	return &MaildirStore{dir: dir}
+}
+
+// IsMaildir reports whether dir already contains a Maildir.
+func IsMaildir(dir string) bool {
+	for _, sub := range []string{"cur", "new"} {
+		info, err := os.Stat(filepath.Join(dir, sub))
+		if err != nil || !info.IsDir() {
+			return false
+		}
+	}
+	return true
+}
+
+// Path returns the Maildir root.
+func (s *MaildirStore) Path() string {
+	return s.dir
+}
+
+// List returns all inbox messages.
+func (s *MaildirStore) List() ([]*Message, error) {
+	return s.list(s.dir)
+}
+
+// Append delivers a message into the inbox.
+func (s *MaildirStore) Append(msg *Message) error {
+	return deliverMaildir(s.dir, msg)
+}
+
+// Replace rewrites the inbox to contain exactly messages. Read/pinned
+// changes only rename files; messages whose other fields changed (e.g., a
+// queue claim) are rewritten in place. Missing messages are removed and new
+// ones delivered.
+func (s *MaildirStore) Replace(messages []*Message) error {
+	return replaceMaildir(s.dir, messages)
+}
+
+// ArchivePath returns the archive Maildir folder.
+func (s *MaildirStore) ArchivePath() string {
+	return filepath.Join(s.dir, maildirArchive)
+}
+
+// ListArchived returns all archived messages.
+func (s *MaildirStore) ListArchived() ([]*Message, error) {
+	return s.list(s.ArchivePath())
+}
+
+// list scans a folder, remembering its messages' tokens for Search.
+func (s *MaildirStore) list(dir string) ([]*Message, error) {
+	files, err := scanMaildir(dir)
+	if err != nil {
+		return nil, err
+	}
+	s.tokens.set(files)
+	return maildirMessages(files), nil
+}
+
+// MayContain reports whether message id's subject or body may contain
+// query, using the words indexed when it was last listed. A false result
+// means the message certainly doesn't match; a true one must be verified.
+func (s *MaildirStore) MayContain(id, query string) bool {
+	return s.tokens.mayContain(id, query)
+}
+
+// AppendArchived delivers a message into the archive folder.
+func (s *MaildirStore) AppendArchived(msg *Message) error {
+	return deliverMaildir(s.ArchivePath(), msg)
+}
+
+// ReplaceArchived rewrites the archive folder to hold exactly messages.
+func (s *MaildirStore) ReplaceArchived(messages []*Message) error {
+	return replaceMaildir(s.ArchivePath(), messages)
+}
+
+// maildirFile is a message file found in a Maildir.
+type maildirFile struct {
+	path   string
+	msg    *Message
+	tokens []string
+}
+
+// scanMaildir reads all messages in new/ and cur/. A missing Maildir is
+// empty. Messages come from the folder's index where possible; files it
+// doesn't cover are parsed and added, and entries for removed files are
+// dropped. The index is a cache, so failing to update it is not an error.
+func scanMaildir(dir string) ([]maildirFile, error) {
+	idx := loadMaildirIndex(dir)
+	seen := make(map[string]bool, len(idx.entries))
+	dirty := false
+
+	var files []maildirFile
+	for _, sub := range []string{"new", "cur"} {
+		entries, err := os.ReadDir(filepath.Join(dir, sub))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		for _, e := range entries {
+			if e.IsDir() || strings.HasPrefix(e.Name(), ".") {
+				continue
+			}
+			path := filepath.Join(dir, sub, e.Name())
+			unique := maildirUnique(e.Name())
+			seen[unique] = true
+
+			entry, ok := idx.entries[unique]
+			if !ok {
+				data, err := os.ReadFile(path)
+				if err != nil {
+					return nil, err
+				}
+				msg, err := parseMaildirMessage(data)
+				if err != nil {
+					continue // Skip malformed messages
+				}
+				if msg.ID == "" {
+					msg.ID = unique
+				}
+				entry = newIndexEntry(unique, msg)
+				idx.entries[unique] = entry
+				dirty = true
+			}
+
+			msg := *entry.Message
+			flags := maildirFlags(e.Name())
+			msg.Read = strings.ContainsRune(flags, 'S')
+			msg.Pinned = strings.ContainsRune(flags, 'F')
+			files = append(files, maildirFile{path: path, msg: &msg, tokens: entry.Tokens})
+		}
+	}
+
+	for unique := range idx.entries {
+		if !seen[unique] {
+			delete(idx.entries, unique)
+			dirty = true
+		}
+	}
+	if dirty {
+		_ = idx.save() // best-effort: rebuilt by the next scan
+	}
+	return files, nil
+}
+
+// maildirMessages returns the messages of scanned files.
+func maildirMessages(files []maildirFile) []*Message {
+	messages := make([]*Message, 0, len(files))
+	for _, f := range files {
+		messages = append(messages, f.msg)
+	}
+	return messages
+}
+
+// deliverMaildir writes msg to tmp/ and moves it into new/ (or cur/ with
+// flags if it is already read or pinned), per the Maildir protocol.
+func deliverMaildir(dir string, msg *Message) error {
+	for _, sub := range []string{"tmp", "new", "cur"} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0700); err != nil {
+			return err
+		}
+	}
+	if msg.ID == "" {
+		msg.ID = generateID()
+	}
+	if msg.Timestamp.IsZero() {
+		msg.Timestamp = timeNow()
+	}
+
+	unique := fmt.Sprintf("%d.%s.%s", msg.Timestamp.UnixNano(), sanitizeMaildirName(msg.ID), maildirHost())
+	tmpPath := filepath.Join(dir, "tmp", unique)
+	if err := os.WriteFile(tmpPath, renderMaildirMessage(msg), 0600); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpPath, maildirTarget(dir, unique, msg, false)); err != nil {
+		_ = os.Remove(tmpPath) // best-effort cleanup
+		return err
+	}
+	_ = appendMaildirIndex(dir, newIndexEntry(unique, msg)) // best-effort: the next scan indexes it anyway
+	return nil
+}
+
+func replaceMaildir(dir string, messages []*Message) error {
+	files, err := scanMaildir(dir)
+	if err != nil {
+		return err
+	}
+
+	existing := make(map[string]maildirFile, len(files))
+	for _, f := range files {
+		existing[f.msg.ID] = f
+	}
+
+	keep := make(map[string]bool, len(messages))
+	for _, msg := range messages {
+		keep[msg.ID] = true
+		f, ok := existing[msg.ID]
+		if !ok {
+			if err := deliverMaildir(dir, msg); err != nil {
+				return err
+			}
+			continue
+		}
+		inNew := filepath.Base(filepath.Dir(f.path)) == "new"
+		target := maildirTarget(dir, maildirUnique(filepath.Base(f.path)), msg, !inNew)
+		if !bytes.Equal(renderMaildirMessage(f.msg), renderMaildirMessage(msg)) {
+			if err := rewriteMaildirMessage(dir, f.path, target, msg); err != nil {
+				return err
+			}
+			continue
+		}
+		if target != f.path {
+			if err := os.Rename(f.path, target); err != nil {
+				return err
+			}
+		}
+	}
+
+	for id, f := range existing {
+		if !keep[id] {
+			if err := os.Remove(f.path); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// rewriteMaildirMessage replaces the message file at path with msg, saved at
+// target, and re-indexes it. The new content goes through tmp/ so readers
+// never see a partial file.
+func rewriteMaildirMessage(dir, path, target string, msg *Message) error {
+	unique := maildirUnique(filepath.Base(path))
+	if err := os.MkdirAll(filepath.Join(dir, "tmp"), 0700); err != nil {
+		return err
+	}
+	tmpPath := filepath.Join(dir, "tmp", unique)
+	if err := os.WriteFile(tmpPath, renderMaildirMessage(msg), 0600); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpPath, target); err != nil {
+		_ = os.Remove(tmpPath) // best-effort cleanup
+		return err
+	}
+	if target != path {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	// A stale index entry would hide the change, so drop the index if it
+	// can't be updated; the next scan rebuilds it
+	if err := appendMaildirIndex(dir, newIndexEntry(unique, msg)); err != nil {
+		_ = os.Remove(filepath.Join(dir, maildirIndexFile))
+	}
+	return nil
+}
+
+// maildirTarget returns where a message file belongs given its flags.
+// Unseen, unflagged messages stay in new/ unless already moved to cur/.
+func maildirTarget(dir, unique string, msg *Message, inCur bool) string {
+	var flags string
+	if msg.Pinned {
+		flags += "F"
+	}
+	if msg.Read {
+		flags += "S"
+	}
+	if flags == "" && !inCur {
+		return filepath.Join(dir, "new", unique)
+	}
+	return filepath.Join(dir, "cur", unique+":2,"+flags)
+}
+
+// maildirUnique strips the info suffix (":2,FLAGS") from a Maildir filename.
+func maildirUnique(name string) string {
+	if i := strings.Index(name, ":"); i >= 0 {
+		return name[:i]
+	}
+	return name
+}
+
+// maildirFlags returns the flags from a Maildir filename.
+func maildirFlags(name string) string {
+	if i := strings.Index(name, ":2,"); i >= 0 {
+		return name[i+3:]
+	}
+	return ""
+}
+
+func sanitizeMaildirName(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '/' || r == ':' || r == '.' || r <= ' ' {
+			return '_'
+		}
+		return r
+	}, s)
+}
+
+func maildirHost() string {
+	host, err := os.Hostname()
+	if err != nil || host == "" {
+		return "localhost"
+	}
+	return sanitizeMaildirName(host)
+}
+
+// headerValue makes a value safe for a single header line.
+func headerValue(s string) string {
+	return strings.NewReplacer("\r", " ", "\n", " ").Replace(s)
+}
+
+// renderMaildirMessage formats msg as an RFC 5322 message.
+func renderMaildirMessage(msg *Message) []byte {
+	var b bytes.Buffer
+	header := func(name, value string) {
+		if value != "" {
+			fmt.Fprintf(&b, "%s: %s\r\n", name, headerValue(value))
+		}
+	}
+
+	header("Message-ID", "<"+msg.ID+"@"+maildirDomain+">")
+	header("Date", msg.Timestamp.Format(time.RFC1123Z))
+	header("From", msg.From)
+	header("To", msg.To)
+	header("Cc", strings.Join(msg.CC, ", "))
+	header("Subject", mime.QEncoding.Encode("utf-8", headerValue(msg.Subject)))
+	if msg.ReplyTo != "" {
+		header("In-Reply-To", "<"+msg.ReplyTo+"@"+maildirDomain+">")
+	}
+	header("X-Gastown-ID", msg.ID)
+	header("X-Gastown-Timestamp", msg.Timestamp.Format(time.RFC3339Nano))
+	header("X-Gastown-Recipients", strings.Join(msg.Recipients, ", "))
+	header("X-Gastown-Thread", msg.ThreadID)
+	header("X-Gastown-Reply-To", msg.ReplyTo)
+	header("X-Gastown-Priority", string(msg.Priority))
+	header("X-Gastown-Type", string(msg.Type))
+	header("X-Gastown-Delivery", string(msg.Delivery))
+	header("X-Gastown-Queue", msg.Queue)
+	header("X-Gastown-Channel", msg.Channel)
+	header("X-Gastown-Claimed-By", msg.ClaimedBy)
+	if msg.ClaimedAt != nil {
+		header("X-Gastown-Claimed-At", msg.ClaimedAt.Format(time.RFC3339))
+	}
+	if msg.Wisp {
+		header("X-Gastown-Wisp", "true")
+	}
+	header("X-Gastown-Signature", msg.Signature)
+	header("MIME-Version", "1.0")
+	header("Content-Type", "text/plain; charset=utf-8")
+	header("Content-Transfer-Encoding", "8bit")
+	b.WriteString("\r\n")
+	b.WriteString(msg.Body)
+	return b.Bytes()
+}
+
+// splitAddressList splits a comma-separated address header.
+func splitAddressList(value string) []string {
+	var addrs []string
+	for _, addr := range strings.Split(value, ",") {
+		if addr = strings.TrimSpace(addr); addr != "" {
+			addrs = append(addrs, addr)
+		}
+	}
+	return addrs
+}
+
+// parseMaildirMessage parses a Maildir message file. Read and pinned state
+// come from the filename flags, not the content.
+func parseMaildirMessage(data []byte) (*Message, error) {
+	m, err := mail.ReadMessage(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(m.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	h := m.Header
+	dec := new(mime.WordDecoder)
+	subject, err := dec.DecodeHeader(h.Get("Subject"))
+	if err != nil {
+		subject = h.Get("Subject")
+	}
+
+	msg := &Message{
+		ID:        h.Get("X-Gastown-ID"),
+		From:      h.Get("From"),
+		To:        h.Get("To"),
+		Subject:   subject,
+		Body:      strings.ReplaceAll(string(body), "\r\n", "\n"),
+		Priority:  Priority(h.Get("X-Gastown-Priority")),
+		Type:      MessageType(h.Get("X-Gastown-Type")),
+		Delivery:  Delivery(h.Get("X-Gastown-Delivery")),
+		ThreadID:  h.Get("X-Gastown-Thread"),
+		ReplyTo:   h.Get("X-Gastown-Reply-To"),
+		Queue:     h.Get("X-Gastown-Queue"),
+		Channel:   h.Get("X-Gastown-Channel"),
+		ClaimedBy: h.Get("X-Gastown-Claimed-By"),
+		Wisp:      h.Get("X-Gastown-Wisp") == "true",
+		Signature: h.Get("X-Gastown-Signature"),
+	}
+	if msg.ID == "" {
+		msg.ID = strings.TrimSuffix(strings.TrimPrefix(h.Get("Message-ID"), "<"), ">")
+	}
+	if msg.Priority == "" {
+		msg.Priority = PriorityNormal
+	}
+	if msg.Type == "" {
+		msg.Type = TypeNotification
+	}
+	msg.CC = splitAddressList(h.Get("Cc"))
+	msg.Recipients = splitAddressList(h.Get("X-Gastown-Recipients"))
+	if ts, err := time.Parse(time.RFC3339Nano, h.Get("X-Gastown-Timestamp")); err == nil {
+		msg.Timestamp = ts
+	} else if date, err := h.Date(); err == nil {
+		msg.Timestamp = date
+	}
+	if at, err := time.Parse(time.RFC3339, h.Get("X-Gastown-Claimed-At")); err == nil {
+		msg.ClaimedAt = &at
+	}
+
+	return msg, nil
+}
@@ -0,0 +1,266 @@
+package mail
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestOpenStore(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	if _, ok := OpenStore(tmpDir).(*JSONLStore); !ok {
+		t.Error("OpenStore should default to JSONL")
+	}
+
+	t.Setenv(StoreEnvVar, StoreMaildir)
+	if _, ok := OpenStore(tmpDir).(*MaildirStore); !ok {
+		t.Errorf("OpenStore with %s=maildir should use Maildir", StoreEnvVar)
+	}
+
+	// An existing Maildir is detected regardless of the env var
+	t.Setenv(StoreEnvVar, "")
+	maildir := t.TempDir()
+	for _, sub := range []string{"cur", "new", "tmp"} {
+		os.MkdirAll(filepath.Join(maildir, sub), 0700)
+	}
+	if _, ok := OpenStore(maildir).(*MaildirStore); !ok {
+		t.Error("OpenStore should detect an existing Maildir")
+	}
+}
+
+func TestMailboxMaildir(t *testing.T) {
+	tmpDir := t.TempDir()
+	m := NewMailboxWithStore(NewMaildirStore(tmpDir))
+
+	msgs := []*Message{
+		{ID: "msg-001", From: "mayor/", To: "gastown/Toast", Subject: "First", Body: "line one\nline two", Timestamp: time.Now().Add(-time.Hour), ThreadID: "thread-a", Priority: PriorityHigh},
+		{ID: "msg-002", From: "gastown/refinery", To: "gastown/Toast", Subject: "Überprüfung ✓", Body: "Second", Timestamp: time.Now(), CC: []string{"mayor/"}, Recipients: []string{"gastown/Toast", "gastown/Nux"}, ThreadID: "thread-a"},
+	}
+	for _, msg := range msgs {
+		if err := m.Append(msg); err != nil {
+			t.Fatalf("Append error: %v", err)
+		}
+	}
+
+	// Unread messages are delivered to new/
+	entries, _ := os.ReadDir(filepath.Join(tmpDir, "new"))
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 files in new/, got %d", len(entries))
+	}
+
+	listed, err := m.List()
+	if err != nil {
+		t.Fatalf("List error: %v", err)
+	}
+	if len(listed) != 2 || listed[0].ID != "msg-002" {
+		t.Fatalf("List = %v, want msg-002 first", listed)
+	}
+	if listed[0].Subject != "Überprüfung ✓" || len(listed[0].CC) != 1 || len(listed[0].Recipients) != 2 {
+		t.Errorf("Round trip lost fields: %+v", listed[0])
+	}
+	if listed[1].Body != "line one\nline two" || listed[1].Priority != PriorityHigh {
+		t.Errorf("Round trip lost fields: %+v", listed[1])
+	}
+
+	// Marking read sets the Seen flag
+	if err := m.MarkRead("msg-001"); err != nil {
+		t.Fatalf("MarkRead error: %v", err)
+	}
+	seen, _ := filepath.Glob(filepath.Join(tmpDir, "cur", "*:2,S"))
+	if len(seen) != 1 {
+		t.Errorf("Expected 1 seen message in cur/, got %v", seen)
+	}
+	_, unread, _ := m.Count()
+	if unread != 1 {
+		t.Errorf("unread = %d, want 1", unread)
+	}
+
+	thread, err := m.ListByThread("thread-a")
+	if err != nil || len(thread) != 2 {
+		t.Errorf("ListByThread = %v, %v", thread, err)
+	}
+
+	// Archive moves the message into the .Archive folder
+	if err := m.Archive("msg-002"); err != nil {
+		t.Fatalf("Archive error: %v", err)
+	}
+	if !strings.HasSuffix(m.ArchivePath(), ".Archive") {
+		t.Errorf("ArchivePath = %q", m.ArchivePath())
+	}
+	archived, err := m.ListArchived()
+	if err != nil || len(archived) != 1 || archived[0].ID != "msg-002" {
+		t.Errorf("ListArchived = %v, %v", archived, err)
+	}
+	if _, err := m.Get("msg-002"); err != ErrMessageNotFound {
+		t.Errorf("Expected archived message removed from inbox, got %v", err)
+	}
+
+	purged, err := m.PurgeArchive(0)
+	if err != nil || purged != 1 {
+		t.Errorf("PurgeArchive = %d, %v", purged, err)
+	}
+}
+
+func TestMaildirExternalMessage(t *testing.T) {
+	tmpDir := t.TempDir()
+	for _, sub := range []string{"cur", "new", "tmp"} {
+		os.MkdirAll(filepath.Join(tmpDir, sub), 0700)
+	}
+
+	// A message delivered by another tool, already seen and flagged in mutt
+	raw := "From: overseer\r\nTo: gastown/Toast\r\nSubject: Hello\r\nDate: Mon, 02 Jan 2006 15:04:05 -0700\r\nMessage-ID: <abc@example.com>\r\n\r\nBody text"
+	os.WriteFile(filepath.Join(tmpDir, "cur", "1136239445.abc.host:2,FS"), []byte(raw), 0600)
+
+	m := NewMailbox(tmpDir)
+	listed, err := m.List()
+	if err != nil || len(listed) != 1 {
+		t.Fatalf("List = %v, %v", listed, err)
+	}
+	msg := listed[0]
+	if msg.ID != "abc@example.com" || !msg.Read || !msg.Pinned || msg.Body != "Body text" {
+		t.Errorf("Unexpected parsed message: %+v", msg)
+	}
+	if msg.Timestamp.Year() != 2006 {
+		t.Errorf("Timestamp = %v, want from Date header", msg.Timestamp)
+	}
+}
+
+func TestMaildirIndex(t *testing.T) {
+	tmpDir := t.TempDir()
+	store := NewMaildirStore(tmpDir)
+	m := NewMailboxWithStore(store)
+
+	for i, body := range []string{"deploy the refinery", "merge queue stalled", "lunch?"} {
+		msg := &Message{ID: fmt.Sprintf("msg-%d", i), From: "mayor/", To: "gastown/Toast", Subject: "Note", Body: body, Timestamp: time.Now().Add(time.Duration(i) * time.Minute)}
+		if err := m.Append(msg); err != nil {
+			t.Fatalf("Append: %v", err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, maildirIndexFile)); err != nil {
+		t.Fatalf("delivery should write the index: %v", err)
+	}
+
+	// Indexed messages are listed without re-parsing their files
+	newFiles, _ := filepath.Glob(filepath.Join(tmpDir, "new", "*"))
+	if len(newFiles) != 3 {
+		t.Fatalf("new/ holds %d files, want 3", len(newFiles))
+	}
+	original, _ := os.ReadFile(newFiles[0])
+	os.WriteFile(newFiles[0], []byte("garbage"), 0600)
+	if err := m.MarkReadOnly("msg-1"); err != nil {
+		t.Fatalf("MarkReadOnly: %v", err)
+	}
+	listed, err := m.List()
+	if err != nil || len(listed) != 3 {
+		t.Fatalf("List = %d messages, %v; want 3", len(listed), err)
+	}
+	for _, msg := range listed {
+		if msg.Read != (msg.ID == "msg-1") {
+			t.Errorf("%s Read = %v; read state should come from the filename", msg.ID, msg.Read)
+		}
+	}
+
+	// MarkReadOnly moved msg-1, so newFiles[0] is still in place
+	os.WriteFile(newFiles[0], original, 0600)
+
+	// Removed files drop out of the index
+	if err := m.Delete("msg-2"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := m.List(); err != nil {
+		t.Fatal(err)
+	}
+	if idx := loadMaildirIndex(tmpDir); len(idx.entries) != 2 {
+		t.Errorf("index has %d entries after delete, want 2", len(idx.entries))
+	}
+
+	// A lost index is rebuilt from the files
+	os.Remove(filepath.Join(tmpDir, maildirIndexFile))
+	if listed, _ := m.List(); len(listed) != 2 {
+		t.Errorf("List without index = %d messages, want 2", len(listed))
+	}
+	if idx := loadMaildirIndex(tmpDir); len(idx.entries) != 2 {
+		t.Errorf("rebuilt index has %d entries, want 2", len(idx.entries))
+	}
+}
+
+func TestMaildirSearchIndex(t *testing.T) {
+	store := NewMaildirStore(t.TempDir())
+	m := NewMailboxWithStore(store)
+	m.Append(&Message{ID: "a", From: "mayor/", Subject: "Refinery", Body: "Merge queue stalled on gt-42"})
+	m.Append(&Message{ID: "b", From: "mayor/", Subject: "Lunch", Body: "Tacos at noon"})
+	if _, err := m.List(); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		query string
+		want  bool
+	}{
+		{"queue stall", true},
+		{"GT-4", true},
+		{"ueue", true},
+		{"tacos", false},
+		{"", true},
+	}
+	for _, tt := range tests {
+		if got := store.MayContain("a", tt.query); got != tt.want {
+			t.Errorf("MayContain(a, %q) = %v, want %v", tt.query, got, tt.want)
+		}
+	}
+	if !store.MayContain("unknown", "anything") {
+		t.Error("unindexed messages should not be ruled out")
+	}
+
+	results, err := m.Search(SearchOptions{Query: "queue stalled"})
+	if err != nil || len(results) != 1 || results[0].ID != "a" {
+		t.Errorf("Search = %v, %v; want message a", results, err)
+	}
+}
+
+func TestMaildirReplaceRewritesChangedMessages(t *testing.T) {
+	tmpDir := t.TempDir()
+	store := NewMaildirStore(tmpDir)
+
+	msg := &Message{ID: "msg-1", From: "mayor/", To: "work/queue", Subject: "Task", Body: "do it", Queue: "work", Timestamp: time.Now()}
+	if err := store.Append(msg); err != nil {
+		t.Fatalf("Append: %v", err)
+	}
+
+	listed, err := store.List()
+	if err != nil || len(listed) != 1 {
+		t.Fatalf("List = %d messages, %v; want 1", len(listed), err)
+	}
+	claimed := *listed[0]
+	claimedAt := time.Now().Truncate(time.Second)
+	claimed.ClaimedBy = "gastown/Toast"
+	claimed.ClaimedAt = &claimedAt
+	claimed.Read = true
+	if err := store.Replace([]*Message{&claimed}); err != nil {
+		t.Fatalf("Replace: %v", err)
+	}
+
+	// The claim survives both the index and a rebuild from the files
+	for _, rebuild := range []bool{false, true} {
+		if rebuild {
+			os.Remove(filepath.Join(tmpDir, maildirIndexFile))
+		}
+		listed, err = store.List()
+		if err != nil || len(listed) != 1 {
+			t.Fatalf("List = %d messages, %v; want 1", len(listed), err)
+		}
+		got := listed[0]
+		if got.ClaimedBy != "gastown/Toast" || got.ClaimedAt == nil || !got.Read {
+			t.Errorf("rebuild=%v: message = %+v, want claimed and read", rebuild, got)
+		}
+	}
+
+	files, _ := filepath.Glob(filepath.Join(tmpDir, "*", "*"))
+	if len(files) != 1 {
+		t.Errorf("expected one message file after rewrite, got %v", files)
+	}
+}
@@ -0,0 +1,125 @@
+package mail
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// markdownFrontMatter is the YAML front-matter on an exported message or
+// thread.
+type markdownFrontMatter struct {
+	Subject      string   `yaml:"subject"`
+	Thread       string   `yaml:"thread,omitempty"`
+	Message      string   `yaml:"message,omitempty"`
+	Messages     int      `yaml:"messages"`
+	Participants []string `yaml:"participants"`
+	Started      string   `yaml:"started,omitempty"`
+	LastReply    string   `yaml:"last_reply,omitempty"`
+	Exported     string   `yaml:"exported"`
+}
+
+// RenderMarkdown renders a message, or the messages of a thread, as
+// markdown with YAML front-matter describing them, for pasting into beads,
+// specs or incident docs. Messages are rendered oldest first.
+func RenderMarkdown(msgs []*Message, exportedAt time.Time) (string, error) {
+	if len(msgs) == 0 {
+		return "", fmt.Errorf("no messages to render")
+	}
+	sorted := make([]*Message, len(msgs))
+	copy(sorted, msgs)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Timestamp.Before(sorted[j].Timestamp)
+	})
+	first, last := sorted[0], sorted[len(sorted)-1]
+
+	fm := markdownFrontMatter{
+		Subject:      first.Subject,
+		Thread:       first.ThreadID,
+		Messages:     len(sorted),
+		Participants: markdownParticipants(sorted),
+		Exported:     exportedAt.UTC().Format(time.RFC3339),
+	}
+	if len(sorted) == 1 {
+		fm.Message = first.ID
+	}
+	if !first.Timestamp.IsZero() {
+		fm.Started = first.Timestamp.UTC().Format(time.RFC3339)
+	}
+	if len(sorted) > 1 && !last.Timestamp.IsZero() {
+		fm.LastReply = last.Timestamp.UTC().Format(time.RFC3339)
+	}
+	header, err := yaml.Marshal(fm)
+	if err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	sb.WriteString("---\n")
+	sb.Write(header)
+	sb.WriteString("---\n\n")
+	fmt.Fprintf(&sb, "# %s\n", markdownSubject(first.Subject))
+
+	for _, msg := range sorted {
+		sb.WriteString("\n")
+		heading := msg.From
+		if !msg.Timestamp.IsZero() {
+			heading += " · " + msg.Timestamp.UTC().Format("2006-01-02 15:04 UTC")
+		}
+		fmt.Fprintf(&sb, "## %s\n\n", heading)
+
+		to := msg.To
+		if len(msg.Recipients) > 1 {
+			to = strings.Join(msg.Recipients, ", ")
+		}
+		fmt.Fprintf(&sb, "- **To:** %s\n", to)
+		if len(msg.CC) > 0 {
+			fmt.Fprintf(&sb, "- **CC:** %s\n", strings.Join(msg.CC, ", "))
+		}
+		if msg.Subject != first.Subject {
+			fmt.Fprintf(&sb, "- **Subject:** %s\n", msg.Subject)
+		}
+		if msg.ID != "" {
+			fmt.Fprintf(&sb, "- **Message:** `%s`\n", msg.ID)
+		}
+
+		if body := strings.TrimSpace(msg.Body); body != "" {
+			sb.WriteString("\n" + body + "\n")
+		}
+	}
+	return sb.String(), nil
+}
+
+// markdownParticipants lists the senders and recipients of msgs in order
+// of first appearance.
+func markdownParticipants(msgs []*Message) []string {
+	seen := make(map[string]bool)
+	var participants []string
+	add := func(addrs ...string) {
+		for _, addr := range addrs {
+			if addr != "" && !seen[addr] {
+				seen[addr] = true
+				participants = append(participants, addr)
+			}
+		}
+	}
+	for _, msg := range msgs {
+		add(msg.From, msg.To)
+		add(msg.Recipients...)
+		add(msg.CC...)
+	}
+	return participants
+}
+
+// markdownSubject returns the subject for the document title, or a
+// placeholder when it is empty.
+func markdownSubject(subject string) string {
+	subject = strings.TrimSpace(subject)
+	if subject == "" {
+		return "(no subject)"
+	}
+	return subject
+}
@@ -0,0 +1,72 @@
+package mail
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRenderMarkdownThread(t *testing.T) {
+	start := time.Date(2026, 3, 4, 9, 30, 0, 0, time.UTC)
+	msgs := []*Message{
+		{
+			ID:        "hq-2",
+			From:      "gastown/witness",
+			To:        "mayor/",
+			CC:        []string{"overseer"},
+			Subject:   "Re: Refinery stuck",
+			Body:      "Restarted it.\n",
+			Timestamp: start.Add(10 * time.Minute),
+			ThreadID:  "thread-abc",
+		},
+		{
+			ID:        "hq-1",
+			From:      "mayor/",
+			To:        "gastown/witness",
+			Subject:   "Refinery stuck",
+			Body:      "The merge queue hasn't moved in an hour.\n\nCan you check?",
+			Timestamp: start,
+			ThreadID:  "thread-abc",
+		},
+	}
+
+	md, err := RenderMarkdown(msgs, start.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("RenderMarkdown: %v", err)
+	}
+
+	for _, want := range []string{
+		"---\nsubject: Refinery stuck\nthread: thread-abc\nmessages: 2\n",
+		"participants:\n    - mayor/\n    - gastown/witness\n    - overseer\n",
+		"started: \"2026-03-04T09:30:00Z\"\nlast_reply: \"2026-03-04T09:40:00Z\"\nexported: \"2026-03-04T10:30:00Z\"\n---\n\n# Refinery stuck\n",
+		"## mayor/ · 2026-03-04 09:30 UTC\n\n- **To:** gastown/witness\n- **Message:** `hq-1`\n\nThe merge queue hasn't moved in an hour.\n\nCan you check?\n",
+		"- **CC:** overseer\n- **Subject:** Re: Refinery stuck\n",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("markdown missing %q:\n%s", want, md)
+		}
+	}
+	if strings.Index(md, "hq-1") > strings.Index(md, "hq-2") {
+		t.Error("messages should render oldest first")
+	}
+	if strings.Contains(md, "message: hq-") {
+		t.Error("thread export should not name a single message")
+	}
+}
+
+func TestRenderMarkdownSingleMessage(t *testing.T) {
+	md, err := RenderMarkdown([]*Message{{ID: "hq-1", From: "mayor/", To: "overseer"}}, time.Now())
+	if err != nil {
+		t.Fatalf("RenderMarkdown: %v", err)
+	}
+	if !strings.Contains(md, "message: hq-1\nmessages: 1\n") {
+		t.Errorf("front-matter should name the message:\n%s", md)
+	}
+	if !strings.Contains(md, "# (no subject)\n") || strings.Contains(md, "last_reply") {
+		t.Errorf("unexpected markdown:\n%s", md)
+	}
+
+	if _, err := RenderMarkdown(nil, time.Now()); err == nil {
+		t.Error("expected error for no messages")
+	}
+}
@@ -0,0 +1,290 @@
+package mail
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/gofrs/flock"
+	"github.com/steveyegge/gastown/internal/util"
+)
+
+// Delivery retry settings. Undeliverable messages are retried with
+// exponential backoff (30s, 1m, 2m, ... capped at an hour) and moved to the
+// dead-letter box after MaxDeliveryAttempts.
+const (
+	MaxDeliveryAttempts = 6
+	retryBaseDelay      = 30 * time.Second
+	retryMaxDelay       = time.Hour
+)
+
+// Files under <town>/mail/ holding undelivered messages.
+const (
+	retryQueueFile = "retry.json"
+	deadLetterFile = "deadletter.json"
+	deliveryLock   = ".delivery.lock"
+)
+
+// ErrDeadLetterNotFound indicates no dead letter has the given ID.
+var ErrDeadLetterNotFound = errors.New("dead letter not found")
+
+// DeliveryFailure is a message that could not be delivered, waiting in the
+// retry queue or parked in the dead-letter box.
+type DeliveryFailure struct {
+	// ID identifies the entry for gt mail deadletter.
+	ID string `json:"id"`
+
+	// Message is the undelivered message.
+	Message *Message `json:"message"`
+
+	// Attempts is how many deliveries have failed.
+	Attempts int `json:"attempts"`
+
+	// LastError is the most recent delivery error.
+	LastError string `json:"last_error"`
+
+	// FirstFailedAt is when delivery first failed.
+	FirstFailedAt time.Time `json:"first_failed_at"`
+
+	// LastAttemptAt is when delivery was last attempted.
+	LastAttemptAt time.Time `json:"last_attempt_at"`
+
+	// NextAttemptAt is when the retry queue next tries delivery. Unset in
+	// the dead-letter box.
+	NextAttemptAt time.Time `json:"next_attempt_at,omitempty"`
+}
+
+// RetryStats summarizes a RetryPending pass.
+type RetryStats struct {
+	Delivered    int `json:"delivered"`
+	Rescheduled  int `json:"rescheduled"`
+	DeadLettered int `json:"dead_lettered"`
+	Pending      int `json:"pending"`
+}
+
+// SendOrQueue delivers msg like Send, but a failed delivery is not lost: the
+// message is queued for retry (or dead-lettered, if retrying can't help)
+// and the delivery error is returned with "(queued for retry)" appended.
+// Use it for automated notifications that nobody is watching to resend.
+// After a successful send, due retries are flushed.
+func (r *Router) SendOrQueue(msg *Message) error {
+	err := r.Send(msg)
+	if r.townRoot == "" {
+		return err
+	}
+	if err == nil {
+		_, _ = r.RetryPending() // best-effort: delivery works, catch up on retries
+		return nil
+	}
+
+	now := time.Now()
+	failure := DeliveryFailure{
+		ID:            generateID(),
+		Message:       msg,
+		Attempts:      1,
+		LastError:     err.Error(),
+		FirstFailedAt: now,
+		LastAttemptAt: now,
+	}
+	if isPermanentDeliveryError(err) {
+		if qerr := r.updateDelivery(func(queue, dead []DeliveryFailure) ([]DeliveryFailure, []DeliveryFailure, error) {
+			return queue, append(dead, failure), nil
+		}); qerr != nil {
+			return fmt.Errorf("%w (dead-lettering failed: %v)", err, qerr)
+		}
+		return fmt.Errorf("%w (dead-lettered)", err)
+	}
+
+	failure.NextAttemptAt = now.Add(retryDelay(1))
+	if qerr := r.updateDelivery(func(queue, dead []DeliveryFailure) ([]DeliveryFailure, []DeliveryFailure, error) {
+		return append(queue, failure), dead, nil
+	}); qerr != nil {
+		return fmt.Errorf("%w (queueing for retry failed: %v)", err, qerr)
+	}
+	return fmt.Errorf("%w (queued for retry)", err)
+}
+
+// RetryPending retries every queued message that is due. Messages that
+// fail MaxDeliveryAttempts times move to the dead-letter box.
+func (r *Router) RetryPending() (RetryStats, error) {
+	var stats RetryStats
+	if r.townRoot == "" {
+		return stats, nil
+	}
+	if _, err := os.Stat(r.deliveryPath(retryQueueFile)); os.IsNotExist(err) {
+		return stats, nil // nothing queued
+	}
+	err := r.updateDelivery(func(queue, dead []DeliveryFailure) ([]DeliveryFailure, []DeliveryFailure, error) {
+		var remaining []DeliveryFailure
+		now := time.Now()
+		for _, f := range queue {
+			if now.Before(f.NextAttemptAt) {
+				remaining = append(remaining, f)
+				continue
+			}
+			f.LastAttemptAt = now
+			err := r.Send(f.Message)
+			if err == nil {
+				stats.Delivered++
+				continue
+			}
+			f.Attempts++
+			f.LastError = err.Error()
+			if f.Attempts >= MaxDeliveryAttempts || isPermanentDeliveryError(err) {
+				f.NextAttemptAt = time.Time{}
+				dead = append(dead, f)
+				stats.DeadLettered++
+				continue
+			}
+			f.NextAttemptAt = now.Add(retryDelay(f.Attempts))
+			remaining = append(remaining, f)
+			stats.Rescheduled++
+		}
+		stats.Pending = len(remaining)
+		return remaining, dead, nil
+	})
+	return stats, err
+}
+
+// PendingRetries returns the messages waiting in the retry queue, soonest
+// first.
+func (r *Router) PendingRetries() ([]DeliveryFailure, error) {
+	if r.townRoot == "" {
+		return nil, nil
+	}
+	queue, err := readDeliveryFile(r.deliveryPath(retryQueueFile))
+	sort.Slice(queue, func(i, j int) bool {
+		return queue[i].NextAttemptAt.Before(queue[j].NextAttemptAt)
+	})
+	return queue, err
+}
+
+// DeadLetters returns the messages in the dead-letter box, oldest first.
+func (r *Router) DeadLetters() ([]DeliveryFailure, error) {
+	if r.townRoot == "" {
+		return nil, nil
+	}
+	return readDeliveryFile(r.deliveryPath(deadLetterFile))
+}
+
+// RequeueDeadLetter moves a dead letter back to the retry queue, due now
+// with a fresh attempt count.
+func (r *Router) RequeueDeadLetter(id string) error {
+	return r.updateDelivery(func(queue, dead []DeliveryFailure) ([]DeliveryFailure, []DeliveryFailure, error) {
+		for i, f := range dead {
+			if f.ID == id {
+				f.Attempts = 0
+				f.NextAttemptAt = time.Now()
+				return append(queue, f), append(dead[:i], dead[i+1:]...), nil
+			}
+		}
+		return nil, nil, fmt.Errorf("%w: %s", ErrDeadLetterNotFound, id)
+	})
+}
+
+// DiscardDeadLetter deletes a dead letter.
+func (r *Router) DiscardDeadLetter(id string) error {
+	return r.updateDelivery(func(queue, dead []DeliveryFailure) ([]DeliveryFailure, []DeliveryFailure, error) {
+		for i, f := range dead {
+			if f.ID == id {
+				return queue, append(dead[:i], dead[i+1:]...), nil
+			}
+		}
+		return nil, nil, fmt.Errorf("%w: %s", ErrDeadLetterNotFound, id)
+	})
+}
+
+// updateDelivery applies fn to the retry queue and dead-letter box under
+// the delivery lock, so concurrent senders and retry passes don't lose
+// entries, and saves what fn returns.
+func (r *Router) updateDelivery(fn func(queue, dead []DeliveryFailure) ([]DeliveryFailure, []DeliveryFailure, error)) error {
+	if r.townRoot == "" {
+		return fmt.Errorf("no town root: cannot persist undelivered mail")
+	}
+	dir := filepath.Join(r.townRoot, "mail")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	lock := flock.New(filepath.Join(dir, deliveryLock))
+	if err := lock.Lock(); err != nil {
+		return fmt.Errorf("locking mail retry queue: %w", err)
+	}
+	defer func() { _ = lock.Unlock() }()
+
+	queue, err := readDeliveryFile(r.deliveryPath(retryQueueFile))
+	if err != nil {
+		return err
+	}
+	dead, err := readDeliveryFile(r.deliveryPath(deadLetterFile))
+	if err != nil {
+		return err
+	}
+
+	queue, dead, err = fn(queue, dead)
+	if err != nil {
+		return err
+	}
+	if err := writeDeliveryFile(r.deliveryPath(retryQueueFile), queue); err != nil {
+		return err
+	}
+	return writeDeliveryFile(r.deliveryPath(deadLetterFile), dead)
+}
+
+// deliveryPath returns the path of a delivery file under <town>/mail/.
+func (r *Router) deliveryPath(name string) string {
+	return filepath.Join(r.townRoot, "mail", name)
+}
+
+// readDeliveryFile reads a delivery file. A missing file is empty.
+func readDeliveryFile(path string) ([]DeliveryFailure, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var failures []DeliveryFailure
+	if err := json.Unmarshal(data, &failures); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return failures, nil
+}
+
+// writeDeliveryFile writes a delivery file, removing it when empty.
+func writeDeliveryFile(path string, failures []DeliveryFailure) error {
+	if len(failures) == 0 {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	data, err := json.MarshalIndent(failures, "", "  ")
+	if err != nil {
+		return err
+	}
+	return util.AtomicWriteFile(path, data, 0644)
+}
+
+// retryDelay is the backoff after the given number of failed attempts.
+func retryDelay(attempts int) time.Duration {
+	delay := retryBaseDelay
+	for i := 1; i < attempts && delay < retryMaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > retryMaxDelay {
+		delay = retryMaxDelay
+	}
+	return delay
+}
+
+// isPermanentDeliveryError reports whether retrying cannot fix err, such
+// as an address naming a list, queue or channel that isn't configured.
+func isPermanentDeliveryError(err error) bool {
+	return errors.Is(err, ErrUnknownList) || errors.Is(err, ErrUnknownQueue) || errors.Is(err, ErrUnknownAnnounce)
+}
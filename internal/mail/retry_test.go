@@ -0,0 +1,116 @@
+package mail
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+func TestSendOrQueue_RetryThenDeadLetter(t *testing.T) {
+	townRoot := t.TempDir()
+	r := NewRouterWithTownRoot(townRoot, townRoot)
+
+	// Without a messaging config the list can't be expanded yet: transient
+	msg := NewMessage("gastown/refinery", "list:oncall", "Merge failed", "details")
+	err := r.SendOrQueue(msg)
+	if err == nil || !strings.Contains(err.Error(), "queued for retry") {
+		t.Fatalf("SendOrQueue error = %v, want queued for retry", err)
+	}
+
+	pending, err := r.PendingRetries()
+	if err != nil || len(pending) != 1 {
+		t.Fatalf("PendingRetries = %v, %v", pending, err)
+	}
+	if until := time.Until(pending[0].NextAttemptAt); until <= 0 || until > retryBaseDelay {
+		t.Errorf("NextAttemptAt in %v, want within %v", until, retryBaseDelay)
+	}
+
+	// Not due yet: nothing happens
+	stats, err := r.RetryPending()
+	if err != nil || stats.Pending != 1 || stats.Rescheduled != 0 {
+		t.Fatalf("RetryPending (not due) = %+v, %v", stats, err)
+	}
+
+	// Make the last attempt due
+	if err := r.updateDelivery(func(queue, dead []DeliveryFailure) ([]DeliveryFailure, []DeliveryFailure, error) {
+		queue[0].NextAttemptAt = time.Now().Add(-time.Second)
+		queue[0].Attempts = MaxDeliveryAttempts - 1
+		return queue, dead, nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	stats, err = r.RetryPending()
+	if err != nil || stats.DeadLettered != 1 || stats.Pending != 0 {
+		t.Fatalf("RetryPending (due) = %+v, %v", stats, err)
+	}
+
+	dead, err := r.DeadLetters()
+	if err != nil || len(dead) != 1 {
+		t.Fatalf("DeadLetters = %v, %v", dead, err)
+	}
+	if dead[0].Message.Subject != "Merge failed" || dead[0].Attempts != MaxDeliveryAttempts {
+		t.Errorf("Unexpected dead letter: %+v", dead[0])
+	}
+
+	// Requeueing makes it due immediately with a fresh count
+	if err := r.RequeueDeadLetter(dead[0].ID); err != nil {
+		t.Fatalf("RequeueDeadLetter: %v", err)
+	}
+	pending, _ = r.PendingRetries()
+	if len(pending) != 1 || pending[0].Attempts != 0 || time.Now().Before(pending[0].NextAttemptAt) {
+		t.Errorf("Unexpected requeued entry: %+v", pending)
+	}
+	if dead, _ := r.DeadLetters(); len(dead) != 0 {
+		t.Errorf("Dead letter should be gone after requeue, got %d", len(dead))
+	}
+}
+
+func TestSendOrQueue_PermanentErrorDeadLetters(t *testing.T) {
+	townRoot := t.TempDir()
+	if err := config.SaveMessagingConfig(config.MessagingConfigPath(townRoot), config.NewMessagingConfig()); err != nil {
+		t.Fatal(err)
+	}
+	r := NewRouterWithTownRoot(townRoot, townRoot)
+
+	err := r.SendOrQueue(NewMessage("mayor/", "list:nope", "Hello", "body"))
+	if !errors.Is(err, ErrUnknownList) || !strings.Contains(err.Error(), "dead-lettered") {
+		t.Fatalf("SendOrQueue error = %v", err)
+	}
+	if pending, _ := r.PendingRetries(); len(pending) != 0 {
+		t.Errorf("Permanent failure should not be retried, got %d pending", len(pending))
+	}
+
+	dead, _ := r.DeadLetters()
+	if len(dead) != 1 {
+		t.Fatalf("Expected 1 dead letter, got %d", len(dead))
+	}
+	if err := r.DiscardDeadLetter("missing"); !errors.Is(err, ErrDeadLetterNotFound) {
+		t.Errorf("DiscardDeadLetter(missing) = %v", err)
+	}
+	if err := r.DiscardDeadLetter(dead[0].ID); err != nil {
+		t.Fatalf("DiscardDeadLetter: %v", err)
+	}
+	if dead, _ := r.DeadLetters(); len(dead) != 0 {
+		t.Errorf("Expected empty dead-letter box, got %d", len(dead))
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{1, 30 * time.Second},
+		{2, time.Minute},
+		{3, 2 * time.Minute},
+		{20, time.Hour},
+	}
+	for _, tt := range tests {
+		if got := retryDelay(tt.attempts); got != tt.want {
+			t.Errorf("retryDelay(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
@@ -501,7 +501,17 @@ func (r *Router) shouldBeWisp(msg *Message) bool {
 // Supports single-copy delivery for:
 // - Queues (queue:name) - stores single message for worker claiming
 // - Announces (announce:name) - bulletin board, no claiming, retention-limited
+// CC and BCC may also use @group and list: addresses. BCC recipients get
+// their own copy that doesn't list the other recipients.
 func (r *Router) Send(msg *Message) error {
+	if len(msg.CC) > 0 || len(msg.BCC) > 0 {
+		return r.sendWithCopies(msg)
+	}
+	return r.route(msg)
+}
+
+// route delivers msg according to its To address.
+func (r *Router) route(msg *Message) error {
 	// Check for mailing list address
 	if isListAddress(msg.To) {
 		return r.sendToList(msg)
@@ -531,6 +541,77 @@ func (r *Router) Send(msg *Message) error {
 	return r.sendToSingle(msg)
 }
 
+// sendWithCopies expands @group and list: addresses in CC and BCC, delivers
+// msg with the expanded CC, then sends each BCC recipient a private copy.
+func (r *Router) sendWithCopies(msg *Message) error {
+	cc, err := r.expandAddresses(msg.CC)
+	if err != nil {
+		return fmt.Errorf("expanding CC: %w", err)
+	}
+	bcc, err := r.expandAddresses(msg.BCC)
+	if err != nil {
+		return fmt.Errorf("expanding BCC: %w", err)
+	}
+
+	primary := *msg
+	primary.CC = cc
+	primary.BCC = nil
+	if err := r.route(&primary); err != nil {
+		return err
+	}
+
+	// BCC copies name no one else, so replies can't reveal the BCC
+	var errs []string
+	for _, addr := range bcc {
+		private := primary
+		private.To = addr
+		private.CC = nil
+		private.Recipients = nil
+		if err := r.sendToSingle(&private); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", addr, err))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("some BCC sends failed: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// expandAddresses resolves @group and list: addresses to their members,
+// keeping other addresses as given. Duplicates are dropped.
+func (r *Router) expandAddresses(addresses []string) ([]string, error) {
+	var expanded []string
+	seen := make(map[string]bool)
+	for _, addr := range addresses {
+		members := []string{addr}
+		switch {
+		case isListAddress(addr):
+			list, err := r.expandList(parseListName(addr))
+			if err != nil {
+				return nil, err
+			}
+			members = list
+		case isGroupAddress(addr):
+			group := parseGroupAddress(addr)
+			if group == nil {
+				return nil, fmt.Errorf("invalid group address: %s", addr)
+			}
+			resolved, err := r.resolveGroup(group)
+			if err != nil {
+				return nil, fmt.Errorf("resolving group %s: %w", addr, err)
+			}
+			members = resolved
+		}
+		for _, m := range members {
+			if id := addressToIdentity(m); !seen[id] {
+				seen[id] = true
+				expanded = append(expanded, m)
+			}
+		}
+	}
+	return expanded, nil
+}
+
 // sendToGroup resolves a @group address and sends individual messages to each member.
 func (r *Router) sendToGroup(msg *Message) error {
 	group := parseGroupAddress(msg.To)
@@ -574,6 +655,16 @@ func (r *Router) sendToSingle(msg *Message) error {
 	// Build labels for from/thread/reply-to/cc
 	var labels []string
 	labels = append(labels, "from:"+msg.From)
+	sigLabel, err := r.signatureLabel(msg)
+	if err != nil {
+		return fmt.Errorf("signing message: %w", err)
+	}
+	if sigLabel != "" {
+		labels = append(labels, sigLabel)
+	}
+	if msg.Type != "" {
+		labels = append(labels, "msg-type:"+string(msg.Type))
+	}
 	if msg.ThreadID != "" {
 		labels = append(labels, "thread:"+msg.ThreadID)
 	}
@@ -585,6 +676,12 @@ func (r *Router) sendToSingle(msg *Message) error {
 		ccIdentity := addressToIdentity(cc)
 		labels = append(labels, "cc:"+ccIdentity)
 	}
+	// Record all primary recipients of a multi-recipient send for reply-all
+	if len(msg.Recipients) > 1 {
+		for _, to := range msg.Recipients {
+			labels = append(labels, "to:"+addressToIdentity(to))
+		}
+	}
 
 	// Build command: bd create <subject> --type=message --assignee=<recipient> -d <body>
 	args := []string{"create", msg.Subject,
@@ -611,11 +708,19 @@ func (r *Router) sendToSingle(msg *Message) error {
 	}
 
 	beadsDir := r.resolveBeadsDir(msg.To)
-	_, err := runBdCommand(args, filepath.Dir(beadsDir), beadsDir)
+	_, err = runBdCommand(args, filepath.Dir(beadsDir), beadsDir)
 	if err != nil {
 		return fmt.Errorf("sending message: %w", err)
 	}
 
+	// Refresh the recipients' unread badges on their next prompt
+	if r.townRoot != "" {
+		InvalidateUnreadSummary(r.townRoot, msg.To)
+		for _, cc := range msg.CC {
+			InvalidateUnreadSummary(r.townRoot, cc)
+		}
+	}
+
 	// Notify recipient if they have an active session (best-effort notification)
 	// Skip notification for self-mail (handoffs to future-self don't need present-self notified)
 	if !isSelfMail(msg.From, msg.To) {
@@ -685,6 +790,16 @@ func (r *Router) sendToQueue(msg *Message) error {
 	var labels []string
 	labels = append(labels, "from:"+msg.From)
 	labels = append(labels, "queue:"+queueName)
+	sigLabel, err := r.signatureLabel(msg)
+	if err != nil {
+		return fmt.Errorf("signing message: %w", err)
+	}
+	if sigLabel != "" {
+		labels = append(labels, sigLabel)
+	}
+	if msg.Type != "" {
+		labels = append(labels, "msg-type:"+string(msg.Type))
+	}
 	if msg.ThreadID != "" {
 		labels = append(labels, "thread:"+msg.ThreadID)
 	}
@@ -756,6 +871,16 @@ func (r *Router) sendToAnnounce(msg *Message) error {
 	var labels []string
 	labels = append(labels, "from:"+msg.From)
 	labels = append(labels, "announce:"+announceName)
+	sigLabel, err := r.signatureLabel(msg)
+	if err != nil {
+		return fmt.Errorf("signing message: %w", err)
+	}
+	if sigLabel != "" {
+		labels = append(labels, sigLabel)
+	}
+	if msg.Type != "" {
+		labels = append(labels, "msg-type:"+string(msg.Type))
+	}
 	if msg.ThreadID != "" {
 		labels = append(labels, "thread:"+msg.ThreadID)
 	}
@@ -829,6 +954,16 @@ func (r *Router) sendToChannel(msg *Message) error {
 	var labels []string
 	labels = append(labels, "from:"+msg.From)
 	labels = append(labels, "channel:"+channelName)
+	sigLabel, err := r.signatureLabel(msg)
+	if err != nil {
+		return fmt.Errorf("signing message: %w", err)
+	}
+	if sigLabel != "" {
+		labels = append(labels, sigLabel)
+	}
+	if msg.Type != "" {
+		labels = append(labels, "msg-type:"+string(msg.Type))
+	}
 	if msg.ThreadID != "" {
 		labels = append(labels, "thread:"+msg.ThreadID)
 	}
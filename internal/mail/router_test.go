@@ -813,3 +813,40 @@ func TestExpandAnnounceNoTownRoot(t *testing.T) {
 		t.Errorf("expandAnnounce error = %v, want containing 'no town root'", err)
 	}
 }
+
+func TestExpandAddresses(t *testing.T) {
+	tmpDir := t.TempDir()
+	configDir := filepath.Join(tmpDir, "config")
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	configContent := `{
+  "type": "messaging",
+  "version": 1,
+  "lists": {
+    "oncall": ["mayor/", "gastown/witness"]
+  }
+}`
+	if err := os.WriteFile(filepath.Join(configDir, "messaging.json"), []byte(configContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+	r := NewRouterWithTownRoot(tmpDir, tmpDir)
+
+	got, err := r.expandAddresses([]string{"mayor", "list:oncall", "gastown/Toast", "gastown/polecats/Toast"})
+	if err != nil {
+		t.Fatalf("expandAddresses: %v", err)
+	}
+	want := []string{"mayor", "gastown/witness", "gastown/Toast"}
+	if len(got) != len(want) {
+		t.Fatalf("expandAddresses = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("expandAddresses[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+
+	if _, err := r.expandAddresses([]string{"@bogus/"}); err == nil {
+		t.Error("expected error for invalid group address")
+	}
+}
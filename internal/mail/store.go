@@ -0,0 +1,216 @@
+package mail
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// StoreEnvVar selects the backend for new file-based mailboxes
+// ("jsonl" or "maildir"). Existing mailboxes keep their detected format.
+const StoreEnvVar = "GT_MAIL_STORE"
+
+// Store backend names.
+const (
+	StoreJSONL   = "jsonl"
+	StoreMaildir = "maildir"
+)
+
+// Store persists messages for a file-based mailbox (crew workers).
+// Beads-backed mailboxes don't use a Store.
+type Store interface {
+	// Path returns the on-disk location of the inbox.
+	Path() string
+
+	// List returns all inbox messages (any order).
+	List() ([]*Message, error)
+
+	// Append adds a message to the inbox.
+	Append(msg *Message) error
+
+	// Replace rewrites the inbox to contain exactly messages.
+	Replace(messages []*Message) error
+
+	// ArchivePath returns the on-disk location of the archive.
+	ArchivePath() string
+
+	// ListArchived returns all archived messages.
+	ListArchived() ([]*Message, error)
+
+	// AppendArchived adds a message to the archive.
+	AppendArchived(msg *Message) error
+
+	// ReplaceArchived rewrites the archive to contain exactly messages.
+	// An empty list removes the archive.
+	ReplaceArchived(messages []*Message) error
+}
+
+// SearchIndex is implemented by stores that index the words of their
+// messages, so Search can skip messages that can't match without scanning
+// their text.
+type SearchIndex interface {
+	// MayContain reports whether message id's subject or body may contain
+	// query. False means it certainly doesn't.
+	MayContain(id, query string) bool
+}
+
+// OpenStore returns the store for a mailbox directory. A directory that
+// already holds a Maildir (cur/new/tmp) opens as Maildir; otherwise the
+// backend named by GT_MAIL_STORE is used, defaulting to JSONL.
+func OpenStore(dir string) Store {
+	if IsMaildir(dir) || os.Getenv(StoreEnvVar) == StoreMaildir {
+		return NewMaildirStore(dir)
+	}
+	return NewJSONLStore(dir)
+}
+
+// JSONLStore keeps the inbox as one JSON message per line in inbox.jsonl,
+// with archived messages in inbox.jsonl.archive.
+type JSONLStore struct {
+	path string
+}
+
+// NewJSONLStore creates a JSONL store in dir.
+func NewJSONLStore(dir string) *JSONLStore {
+	return &JSONLStore{path: filepath.Join(dir, "inbox.jsonl")}
+}
+
+// Path returns the inbox file path.
+func (s *JSONLStore) Path() string {
+	return s.path
+}
+
+// List returns all inbox messages.
+func (s *JSONLStore) List() ([]*Message, error) {
+	return readJSONL(s.path)
+}
+
+// Append adds a message to the inbox file.
+func (s *JSONLStore) Append(msg *Message) error {
+	return appendJSONL(s.path, msg, 0600)
+}
+
+// Replace rewrites the inbox file (oldest first).
+func (s *JSONLStore) Replace(messages []*Message) error {
+	sort.Slice(messages, func(i, j int) bool {
+		return messages[i].Timestamp.Before(messages[j].Timestamp)
+	})
+	return writeJSONL(s.path, messages)
+}
+
+// ArchivePath returns the archive file path.
+func (s *JSONLStore) ArchivePath() string {
+	return s.path + ".archive"
+}
+
+// ListArchived returns all archived messages.
+func (s *JSONLStore) ListArchived() ([]*Message, error) {
+	return readJSONL(s.ArchivePath())
+}
+
+// AppendArchived adds a message to the archive file.
+func (s *JSONLStore) AppendArchived(msg *Message) error {
+	return appendJSONL(s.ArchivePath(), msg, 0644)
+}
+
+// ReplaceArchived rewrites the archive file.
+func (s *JSONLStore) ReplaceArchived(messages []*Message) error {
+	return replaceJSONLArchive(s.ArchivePath(), messages)
+}
+
+// readJSONL reads messages from a JSONL file. A missing file is empty.
+func readJSONL(path string) ([]*Message, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer func() { _ = file.Close() }() // non-fatal: OS will close on exit
+
+	var messages []*Message
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+
+		var msg Message
+		if err := json.Unmarshal([]byte(line), &msg); err != nil {
+			continue // Skip malformed lines
+		}
+		messages = append(messages, &msg)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return messages, nil
+}
+
+// appendJSONL appends one message to a JSONL file, creating it if needed.
+func appendJSONL(path string, msg *Message, perm os.FileMode) error {
+	// Ensure directory exists
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	// Open for append
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, perm) //nolint:gosec // G302: archive is non-sensitive operational data
+	if err != nil {
+		return err
+	}
+	defer func() { _ = file.Close() }() // non-fatal: OS will close on exit
+
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	_, err = file.WriteString(string(data) + "\n")
+	return err
+}
+
+// writeJSONL atomically rewrites a JSONL file with messages.
+func writeJSONL(path string, messages []*Message) error {
+	// Write to temp file
+	tmpPath := path + ".tmp"
+	file, err := os.Create(tmpPath)
+	if err != nil {
+		return err
+	}
+
+	for _, msg := range messages {
+		data, err := json.Marshal(msg)
+		if err != nil {
+			_ = file.Close()       // best-effort cleanup
+			_ = os.Remove(tmpPath) // best-effort cleanup
+			return err
+		}
+		_, _ = file.WriteString(string(data) + "\n") // non-fatal: partial write is acceptable
+	}
+
+	if err := file.Close(); err != nil {
+		_ = os.Remove(tmpPath) // best-effort cleanup
+		return err
+	}
+
+	// Atomic rename
+	return os.Rename(tmpPath, path)
+}
+
+// replaceJSONLArchive rewrites an archive file, removing it when empty.
+func replaceJSONLArchive(path string, messages []*Message) error {
+	if len(messages) == 0 {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	return writeJSONL(path, messages)
+}
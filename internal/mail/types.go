@@ -108,6 +108,15 @@ type Message struct {
 	// CC'd recipients see the message in their inbox but are not the primary recipient.
 	CC []string `json:"cc,omitempty"`
 
+	// Recipients lists every primary recipient when a message is sent to
+	// several addresses at once. Each recipient still gets its own copy with
+	// To set to them; Recipients lets reply-all reach the others.
+	Recipients []string `json:"recipients,omitempty"`
+
+	// BCC contains addresses that get a private copy of this message.
+	// It is only set when sending; delivered copies never carry it.
+	BCC []string `json:"bcc,omitempty"`
+
 	// Queue is the queue name for queue-routed messages.
 	// Mutually exclusive with To and Channel - a message is either direct, queued, or broadcast.
 	Queue string `json:"queue,omitempty"`
@@ -123,6 +132,10 @@ type Message struct {
 	// ClaimedAt is when the queue message was claimed.
 	// Only set for queue messages after claiming.
 	ClaimedAt *time.Time `json:"claimed_at,omitempty"`
+
+	// Signature is the HMAC of the message under its sender's rig key,
+	// set when the rig signs its mail (see VerifyMessage).
+	Signature string `json:"signature,omitempty"`
 }
 
 // NewMessage creates a new message with a generated ID and thread ID.
@@ -141,6 +154,28 @@ func NewMessage(from, to, subject, body string) *Message {
 	}
 }
 
+// ReplyAllRecipients returns the addresses a reply-all from self goes to:
+// the original sender, and every other primary and CC recipient as CC.
+func (m *Message) ReplyAllRecipients(self string) (to string, cc []string) {
+	seen := map[string]bool{
+		addressToIdentity(self):   true,
+		addressToIdentity(m.From): true,
+	}
+	var others []string
+	others = append(others, m.To)
+	others = append(others, m.Recipients...)
+	others = append(others, m.CC...)
+	for _, addr := range others {
+		id := addressToIdentity(addr)
+		if addr == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		cc = append(cc, addr)
+	}
+	return m.From, cc
+}
+
 // NewReplyMessage creates a reply message that inherits the thread from the original.
 func NewReplyMessage(from, to, subject, body string, original *Message) *Message {
 	return &Message{
@@ -255,6 +290,11 @@ func generateID() string {
 	return "msg-" + hex.EncodeToString(b)
 }
 
+// NewThreadID returns a thread ID for a message starting a conversation.
+func NewThreadID() string {
+	return generateThreadID()
+}
+
 // generateThreadID creates a random thread ID.
 // Falls back to time-based ID if crypto/rand fails (extremely rare).
 func generateThreadID() string {
@@ -276,7 +316,7 @@ type BeadsMessage struct {
 	Priority    int       `json:"priority"`    // 0=urgent, 1=high, 2=normal, 3=low
 	Status      string    `json:"status"`      // open=unread, closed=read
 	CreatedAt   time.Time `json:"created_at"`
-	Labels      []string  `json:"labels"` // Metadata labels (from:X, thread:X, reply-to:X, msg-type:X, cc:X, queue:X, channel:X, claimed-by:X, claimed-at:X)
+	Labels      []string  `json:"labels"` // Metadata labels (from:X, thread:X, reply-to:X, msg-type:X, cc:X, queue:X, channel:X, claimed-by:X, claimed-at:X, sent-at:X, sig:X)
 	Pinned      bool      `json:"pinned,omitempty"`
 	Wisp        bool      `json:"wisp,omitempty"` // Ephemeral message (filtered from JSONL export)
 
@@ -286,10 +326,13 @@ type BeadsMessage struct {
 	replyTo   string
 	msgType   string
 	cc        []string   // CC recipients
+	to        []string   // All primary recipients (multi-recipient sends)
 	queue     string     // Queue name (for queue messages)
 	channel   string     // Channel name (for broadcast messages)
 	claimedBy string     // Who claimed the queue message
 	claimedAt *time.Time // When the queue message was claimed
+	sentAt    *time.Time // Original send time (imported messages)
+	signature string     // Sender's rig signature
 }
 
 // ParseLabels extracts metadata from the labels array.
@@ -305,6 +348,8 @@ func (bm *BeadsMessage) ParseLabels() {
 			bm.msgType = strings.TrimPrefix(label, "msg-type:")
 		} else if strings.HasPrefix(label, "cc:") {
 			bm.cc = append(bm.cc, strings.TrimPrefix(label, "cc:"))
+		} else if strings.HasPrefix(label, "to:") {
+			bm.to = append(bm.to, strings.TrimPrefix(label, "to:"))
 		} else if strings.HasPrefix(label, "queue:") {
 			bm.queue = strings.TrimPrefix(label, "queue:")
 		} else if strings.HasPrefix(label, "channel:") {
@@ -316,6 +361,13 @@ func (bm *BeadsMessage) ParseLabels() {
 			if t, err := time.Parse(time.RFC3339, ts); err == nil {
 				bm.claimedAt = &t
 			}
+		} else if strings.HasPrefix(label, sigLabelPrefix) {
+			bm.signature = strings.TrimPrefix(label, sigLabelPrefix)
+		} else if strings.HasPrefix(label, "sent-at:") {
+			ts := strings.TrimPrefix(label, "sent-at:")
+			if t, err := time.Parse(time.RFC3339, ts); err == nil {
+				bm.sentAt = &t
+			}
 		}
 	}
 }
@@ -366,24 +418,37 @@ func (bm *BeadsMessage) ToMessage() *Message {
 		ccAddrs = append(ccAddrs, identityToAddress(cc))
 	}
 
+	var toAddrs []string
+	for _, to := range bm.to {
+		toAddrs = append(toAddrs, identityToAddress(to))
+	}
+
+	// Imported messages keep the time they were originally sent
+	timestamp := bm.CreatedAt
+	if bm.sentAt != nil {
+		timestamp = *bm.sentAt
+	}
+
 	return &Message{
-		ID:        bm.ID,
-		From:      identityToAddress(bm.sender),
-		To:        identityToAddress(bm.Assignee),
-		Subject:   bm.Title,
-		Body:      bm.Description,
-		Timestamp: bm.CreatedAt,
-		Read:      bm.Status == "closed" || bm.HasLabel("read"),
-		Priority:  priority,
-		Type:      msgType,
-		ThreadID:  bm.threadID,
-		ReplyTo:   bm.replyTo,
-		Wisp:      bm.Wisp,
-		CC:        ccAddrs,
-		Queue:     bm.queue,
-		Channel:   bm.channel,
-		ClaimedBy: bm.claimedBy,
-		ClaimedAt: bm.claimedAt,
+		ID:         bm.ID,
+		From:       identityToAddress(bm.sender),
+		To:         identityToAddress(bm.Assignee),
+		Subject:    bm.Title,
+		Body:       bm.Description,
+		Timestamp:  timestamp,
+		Read:       bm.Status == "closed" || bm.HasLabel("read"),
+		Priority:   priority,
+		Type:       msgType,
+		ThreadID:   bm.threadID,
+		ReplyTo:    bm.replyTo,
+		Wisp:       bm.Wisp,
+		CC:         ccAddrs,
+		Recipients: toAddrs,
+		Queue:      bm.queue,
+		Channel:    bm.channel,
+		ClaimedBy:  bm.claimedBy,
+		ClaimedAt:  bm.claimedAt,
+		Signature:  bm.signature,
 	}
 }
 
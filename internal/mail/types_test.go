@@ -712,3 +712,43 @@ func TestMessageIsClaimed(t *testing.T) {
 		t.Error("Claimed message should be claimed")
 	}
 }
+
+func TestBeadsMessageToMessageRecipients(t *testing.T) {
+	bm := BeadsMessage{
+		ID:       "hq-multi",
+		Assignee: "gastown/Toast",
+		Labels:   []string{"from:mayor/", "to:gastown/Toast", "to:gastown/polecats/Nux", "cc:overseer"},
+	}
+
+	msg := bm.ToMessage()
+	if len(msg.Recipients) != 2 || msg.Recipients[1] != "gastown/Nux" {
+		t.Errorf("Recipients = %v, want [gastown/Toast gastown/Nux]", msg.Recipients)
+	}
+	if len(msg.CC) != 1 || msg.CC[0] != "overseer" {
+		t.Errorf("CC = %v, want [overseer]", msg.CC)
+	}
+}
+
+func TestReplyAllRecipients(t *testing.T) {
+	msg := &Message{
+		From:       "mayor/",
+		To:         "gastown/Toast",
+		Recipients: []string{"gastown/Toast", "gastown/Nux"},
+		CC:         []string{"overseer", "mayor", "gastown/crew/Nux"},
+	}
+
+	to, cc := msg.ReplyAllRecipients("gastown/polecats/Toast")
+	if to != "mayor/" {
+		t.Errorf("to = %q, want mayor/", to)
+	}
+	// Self, the sender, and duplicates in another form are dropped
+	want := []string{"gastown/Nux", "overseer"}
+	if len(cc) != len(want) {
+		t.Fatalf("cc = %v, want %v", cc, want)
+	}
+	for i := range want {
+		if cc[i] != want[i] {
+			t.Errorf("cc[%d] = %q, want %q", i, cc[i], want[i])
+		}
+	}
+}
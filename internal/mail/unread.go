@@ -0,0 +1,85 @@
+package mail
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// UnreadSummary is a mailbox's unread counts, cached for 'gt mail unread' so
+// shell prompts and tmux status bars can show them without querying beads on
+// every redraw.
+type UnreadSummary struct {
+	Address string `json:"address"`
+
+	// Unread is the number of unread messages.
+	Unread int `json:"unread"`
+
+	// Questions is how many of the unread messages ask the reader something.
+	Questions int `json:"questions"`
+
+	// Urgent is how many of the unread messages are urgent.
+	Urgent int `json:"urgent"`
+
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// UnreadSummaryPath returns where the summary for address is cached.
+func UnreadSummaryPath(townRoot, address string) string {
+	name := sanitizeMaildirName(addressToIdentity(address))
+	return filepath.Join(townRoot, ".runtime", "mail", "unread-"+name+".json")
+}
+
+// LoadUnreadSummary reads the cached summary for address. A missing or
+// damaged cache returns nil.
+func LoadUnreadSummary(townRoot, address string) *UnreadSummary {
+	data, err := os.ReadFile(UnreadSummaryPath(townRoot, address)) //nolint:gosec // G304: path is built from the town root
+	if err != nil {
+		return nil
+	}
+	var s UnreadSummary
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil
+	}
+	return &s
+}
+
+// SaveUnreadSummary caches s for its address. The file is replaced
+// atomically so a prompt never reads a partial write.
+func SaveUnreadSummary(townRoot string, s *UnreadSummary) error {
+	path := UnreadSummaryPath(townRoot, s.Address)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// InvalidateUnreadSummary marks the cached summary for address as stale, so
+// the next 'gt mail unread' refreshes it. Delivery calls this; reads only
+// age out, which is fine for a badge.
+func InvalidateUnreadSummary(townRoot, address string) {
+	path := UnreadSummaryPath(townRoot, address)
+	if _, err := os.Stat(path); err != nil {
+		return
+	}
+	_ = os.Chtimes(path, time.Time{}, time.Unix(0, 0))
+}
+
+// UnreadSummaryAge returns how long ago the cached summary for address was
+// written, or false if there is none.
+func UnreadSummaryAge(townRoot, address string) (time.Duration, bool) {
+	info, err := os.Stat(UnreadSummaryPath(townRoot, address))
+	if err != nil {
+		return 0, false
+	}
+	return time.Since(info.ModTime()), true
+}
@@ -0,0 +1,45 @@
+package mail
+
+import (
+	"testing"
+	"time"
+)
+
+func TestUnreadSummaryRoundTrip(t *testing.T) {
+	townRoot := t.TempDir()
+
+	if s := LoadUnreadSummary(townRoot, "overseer"); s != nil {
+		t.Fatalf("LoadUnreadSummary() with no cache = %+v, want nil", s)
+	}
+	if _, ok := UnreadSummaryAge(townRoot, "overseer"); ok {
+		t.Fatal("UnreadSummaryAge() with no cache reported a cache")
+	}
+
+	want := &UnreadSummary{Address: "overseer", Unread: 3, Questions: 1, Urgent: 1, UpdatedAt: time.Now()}
+	if err := SaveUnreadSummary(townRoot, want); err != nil {
+		t.Fatalf("SaveUnreadSummary() error = %v", err)
+	}
+	got := LoadUnreadSummary(townRoot, "overseer")
+	if got == nil || got.Unread != 3 || got.Questions != 1 || got.Urgent != 1 {
+		t.Fatalf("LoadUnreadSummary() = %+v, want %+v", got, want)
+	}
+	if age, ok := UnreadSummaryAge(townRoot, "overseer"); !ok || age > time.Minute {
+		t.Errorf("UnreadSummaryAge() = %v, %v; want fresh", age, ok)
+	}
+
+	InvalidateUnreadSummary(townRoot, "overseer")
+	if age, ok := UnreadSummaryAge(townRoot, "overseer"); !ok || age < time.Hour {
+		t.Errorf("UnreadSummaryAge() after invalidate = %v, %v; want stale", age, ok)
+	}
+	if LoadUnreadSummary(townRoot, "overseer") == nil {
+		t.Error("invalidated summary should still load until refreshed")
+	}
+}
+
+func TestUnreadSummaryPath_NormalizesAddress(t *testing.T) {
+	a := UnreadSummaryPath("/town", "gastown/crew/max")
+	b := UnreadSummaryPath("/town", "gastown/max")
+	if a != b {
+		t.Errorf("UnreadSummaryPath() = %q and %q, want the same file for one mailbox", a, b)
+	}
+}
@@ -0,0 +1,206 @@
+package planner
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Dependency errors
+var (
+	ErrDependencyCycle     = errors.New("dependency would create a cycle")
+	ErrPrerequisitePending = errors.New("prerequisite specs are not approved")
+)
+
+// IsApproved reports whether the session's spec has been approved, either
+// still awaiting handoff or already handed off.
+func (s *PlanningSession) IsApproved() bool {
+	return s.Status == StatusApproved || s.Status == StatusHandedOff
+}
+
+// RemoveDependency drops a prerequisite. It reports whether it was present.
+func (s *PlanningSession) RemoveDependency(specID string) bool {
+	for i, id := range s.DependsOn {
+		if id == specID {
+			s.DependsOn = append(s.DependsOn[:i], s.DependsOn[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// AddDependency records that session depends on the spec specID. The spec
+// must be an existing planning session, and the dependency may not create a
+// cycle. Adding an existing dependency is a no-op. The session is not saved.
+func (m *Manager) AddDependency(session *PlanningSession, specID string) error {
+	for _, id := range session.DependsOn {
+		if id == specID {
+			return nil
+		}
+	}
+	if specID == session.ID {
+		return fmt.Errorf("%w: %s cannot depend on itself", ErrDependencyCycle, specID)
+	}
+	if _, err := m.LoadSession(specID); err != nil {
+		return fmt.Errorf("loading %s: %w", specID, err)
+	}
+
+	graph, err := m.DependencyGraph()
+	if err != nil {
+		return err
+	}
+	graph.Sessions[session.ID] = session
+	if path := graph.path(specID, session.ID); path != nil {
+		return fmt.Errorf("%w: %s", ErrDependencyCycle, strings.Join(append([]string{session.ID}, path...), " → "))
+	}
+
+	session.DependsOn = append(session.DependsOn, specID)
+	return nil
+}
+
+// UnmetDependencies returns the prerequisites of session that are not yet
+// approved, in declaration order. Prerequisites that no longer exist count
+// as unmet.
+func (m *Manager) UnmetDependencies(session *PlanningSession) ([]string, error) {
+	var unmet []string
+	for _, id := range session.DependsOn {
+		dep, err := m.LoadSession(id)
+		if err == ErrSessionNotFound {
+			unmet = append(unmet, id+" (missing)")
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("loading %s: %w", id, err)
+		}
+		if !dep.IsApproved() {
+			unmet = append(unmet, fmt.Sprintf("%s (%s)", id, dep.Status))
+		}
+	}
+	return unmet, nil
+}
+
+// checkDependencies returns ErrPrerequisitePending if any prerequisite of
+// session is not yet approved.
+func (m *Manager) checkDependencies(session *PlanningSession) error {
+	unmet, err := m.UnmetDependencies(session)
+	if err != nil {
+		return err
+	}
+	if len(unmet) > 0 {
+		return fmt.Errorf("%w: %s", ErrPrerequisitePending, strings.Join(unmet, ", "))
+	}
+	return nil
+}
+
+// DependencyGraph is the dependency graph across a rig's planning sessions.
+type DependencyGraph struct {
+	// Sessions maps session IDs to sessions.
+	Sessions map[string]*PlanningSession
+}
+
+// DependencyGraph loads every planning session in the rig.
+func (m *Manager) DependencyGraph() (*DependencyGraph, error) {
+	sessions, err := m.ListSessions()
+	if err != nil {
+		return nil, err
+	}
+	graph := &DependencyGraph{Sessions: make(map[string]*PlanningSession, len(sessions))}
+	for _, s := range sessions {
+		graph.Sessions[s.ID] = s
+	}
+	return graph, nil
+}
+
+// IDs returns the session IDs in sorted order.
+func (g *DependencyGraph) IDs() []string {
+	ids := make([]string, 0, len(g.Sessions))
+	for id := range g.Sessions {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// path returns a dependency path from one session to another (inclusive),
+// or nil if to is not reachable from from.
+func (g *DependencyGraph) path(from, to string) []string {
+	visited := make(map[string]bool)
+	var walk func(id string) []string
+	walk = func(id string) []string {
+		if id == to {
+			return []string{id}
+		}
+		if visited[id] {
+			return nil
+		}
+		visited[id] = true
+		s, ok := g.Sessions[id]
+		if !ok {
+			return nil
+		}
+		for _, dep := range s.DependsOn {
+			if rest := walk(dep); rest != nil {
+				return append([]string{id}, rest...)
+			}
+		}
+		return nil
+	}
+	return walk(from)
+}
+
+// WriteDOT renders the graph in Graphviz DOT format. Edges point from a
+// spec to its prerequisites; approved specs are filled green, cancelled
+// specs are dashed, and prerequisites missing from the rig are drawn red.
+func (g *DependencyGraph) WriteDOT(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString("digraph planner {\n")
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=box, style=rounded];\n")
+
+	missing := make(map[string]bool)
+	for _, id := range g.IDs() {
+		s := g.Sessions[id]
+		attrs := fmt.Sprintf("label=%s", dotQuote(fmt.Sprintf("%s\n%s\n[%s]", s.ID, s.Title, s.Status)))
+		switch {
+		case s.IsApproved():
+			attrs += `, style="rounded,filled", fillcolor=palegreen`
+		case s.Status == StatusCancelled:
+			attrs += `, style="rounded,dashed", fontcolor=gray`
+		}
+		fmt.Fprintf(&b, "  %s [%s];\n", dotQuote(id), attrs)
+		for _, dep := range s.DependsOn {
+			if _, ok := g.Sessions[dep]; !ok {
+				missing[dep] = true
+			}
+		}
+	}
+
+	missingIDs := make([]string, 0, len(missing))
+	for id := range missing {
+		missingIDs = append(missingIDs, id)
+	}
+	sort.Strings(missingIDs)
+	for _, id := range missingIDs {
+		fmt.Fprintf(&b, "  %s [label=%s, color=red, fontcolor=red];\n", dotQuote(id), dotQuote(id+"\n[missing]"))
+	}
+
+	for _, id := range g.IDs() {
+		for _, dep := range g.Sessions[id].DependsOn {
+			fmt.Fprintf(&b, "  %s -> %s;\n", dotQuote(id), dotQuote(dep))
+		}
+	}
+	b.WriteString("}\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// dotQuote quotes s as a DOT string, escaping quotes and newlines.
+func dotQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	s = strings.ReplaceAll(s, "\n", `\n`)
+	return `"` + s + `"`
+}
@@ -0,0 +1,100 @@
+package planner
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestAddDependency(t *testing.T) {
+	mgr := newTestManager(t)
+	a := &PlanningSession{ID: "gt-plan-a", Title: "Auth", Status: StatusQuestioning}
+	b := &PlanningSession{ID: "gt-plan-b", Title: "Billing", Status: StatusQuestioning}
+	for _, s := range []*PlanningSession{a, b} {
+		if err := mgr.SaveSession(s); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := mgr.AddDependency(b, "gt-plan-a"); err != nil {
+		t.Fatalf("AddDependency: %v", err)
+	}
+	if err := mgr.AddDependency(b, "gt-plan-a"); err != nil || len(b.DependsOn) != 1 {
+		t.Errorf("re-adding should be a no-op, got %v %v", err, b.DependsOn)
+	}
+	if err := mgr.SaveSession(b); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := mgr.AddDependency(a, "gt-plan-b"); !errors.Is(err, ErrDependencyCycle) {
+		t.Errorf("expected ErrDependencyCycle, got %v", err)
+	}
+	if err := mgr.AddDependency(a, "gt-plan-a"); !errors.Is(err, ErrDependencyCycle) {
+		t.Errorf("expected ErrDependencyCycle for self-dependency, got %v", err)
+	}
+	if err := mgr.AddDependency(a, "gt-plan-zzz"); !errors.Is(err, ErrSessionNotFound) {
+		t.Errorf("expected ErrSessionNotFound, got %v", err)
+	}
+
+	if !b.RemoveDependency("gt-plan-a") || len(b.DependsOn) != 0 {
+		t.Errorf("RemoveDependency failed: %v", b.DependsOn)
+	}
+}
+
+func TestHandoff_BlockedByUnapprovedPrerequisite(t *testing.T) {
+	mgr := newTestManager(t)
+	prereq := &PlanningSession{ID: "gt-plan-a", Title: "Auth", Status: StatusReviewing}
+	session := &PlanningSession{ID: "gt-plan-b", Title: "Billing", Status: StatusApproved, DependsOn: []string{"gt-plan-a", "gt-plan-gone"}}
+	for _, s := range []*PlanningSession{prereq, session} {
+		if err := mgr.SaveSession(s); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	_, err := mgr.Handoff(session)
+	if !errors.Is(err, ErrPrerequisitePending) {
+		t.Fatalf("expected ErrPrerequisitePending, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "gt-plan-a (reviewing)") || !strings.Contains(err.Error(), "gt-plan-gone (missing)") {
+		t.Errorf("error should name unmet prerequisites: %v", err)
+	}
+	if session.Status != StatusApproved {
+		t.Errorf("blocked handoff changed status to %s", session.Status)
+	}
+
+	prereq.Status = StatusApproved
+	if err := mgr.SaveSession(prereq); err != nil {
+		t.Fatal(err)
+	}
+	session.RemoveDependency("gt-plan-gone")
+	if _, err := mgr.Handoff(session); err != nil {
+		t.Fatalf("Handoff: %v", err)
+	}
+	if session.Status != StatusHandedOff {
+		t.Errorf("Status = %s, want handed_off", session.Status)
+	}
+}
+
+func TestDependencyGraph_WriteDOT(t *testing.T) {
+	graph := &DependencyGraph{Sessions: map[string]*PlanningSession{
+		"gt-plan-a": {ID: "gt-plan-a", Title: `Auth "v2"`, Status: StatusApproved},
+		"gt-plan-b": {ID: "gt-plan-b", Title: "Billing", Status: StatusQuestioning, DependsOn: []string{"gt-plan-a", "gt-plan-x"}},
+	}}
+
+	var b strings.Builder
+	if err := graph.WriteDOT(&b); err != nil {
+		t.Fatal(err)
+	}
+	dot := b.String()
+	for _, want := range []string{
+		"digraph planner {",
+		`"gt-plan-a" [label="gt-plan-a\nAuth \"v2\"\n[approved]", style="rounded,filled", fillcolor=palegreen];`,
+		`"gt-plan-x" [label="gt-plan-x\n[missing]", color=red, fontcolor=red];`,
+		`"gt-plan-b" -> "gt-plan-a";`,
+		`"gt-plan-b" -> "gt-plan-x";`,
+	} {
+		if !strings.Contains(dot, want) {
+			t.Errorf("DOT missing %q:\n%s", want, dot)
+		}
+	}
+}
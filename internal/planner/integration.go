@@ -0,0 +1,73 @@
+package planner
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/steveyegge/gastown/internal/refinery"
+)
+
+// ErrIntegrationBranchesDisabled is returned by Handoff when the session
+// asks for integration branches but the rig's merge queue doesn't merge
+// into them.
+var ErrIntegrationBranchesDisabled = errors.New("integration branches are disabled for this rig (merge_queue.integration_branches)")
+
+// createIntegrationBranches gives each of the session's epics an
+// integration branch, named from the rig's template and cut from its
+// default branch, and records it in the epic bead. Polecats working on the
+// epic's children then branch from it and the refinery merges into it.
+// Branches already created by an earlier, interrupted handoff are kept.
+func (m *Manager) createIntegrationBranches(session *PlanningSession) error {
+	if !session.IntegrationBranches {
+		return nil
+	}
+	pub := session.Publication
+	hasEpic := pub != nil && pub.Epic != ""
+	for _, s := range session.Slices {
+		hasEpic = hasEpic || s.EpicID != ""
+	}
+	if !hasEpic {
+		return nil
+	}
+	if !refinery.IntegrationBranchesEnabled(m.rig.Path) {
+		return ErrIntegrationBranchesDisabled
+	}
+
+	eng := refinery.NewEngineer(m.rig)
+	if err := eng.LoadConfig(); err != nil {
+		return fmt.Errorf("loading merge queue config: %w", err)
+	}
+	template := refinery.IntegrationBranchTemplate(m.rig.Path)
+	for i := range session.Slices {
+		s := &session.Slices[i]
+		if s.EpicID == "" || s.IntegrationBranch != "" {
+			continue
+		}
+		branch, err := m.createIntegrationBranch(eng, template, s.EpicID)
+		if err != nil {
+			return err
+		}
+		s.IntegrationBranch = branch
+	}
+	if pub != nil && pub.Epic != "" && pub.IntegrationBranch == "" {
+		branch, err := m.createIntegrationBranch(eng, template, pub.Epic)
+		if err != nil {
+			return err
+		}
+		pub.IntegrationBranch = branch
+	}
+	return nil
+}
+
+// createIntegrationBranch creates, pushes and records one epic's
+// integration branch.
+func (m *Manager) createIntegrationBranch(eng *refinery.Engineer, template, epicID string) (string, error) {
+	branch := refinery.IntegrationBranchName(template, epicID)
+	if err := eng.EnsureIntegrationBranch(branch, m.rig.DefaultBranch()); err != nil {
+		return "", fmt.Errorf("creating integration branch for %s: %w", epicID, err)
+	}
+	if err := refinery.RecordIntegrationBranch(m.beads, epicID, branch); err != nil {
+		return "", err
+	}
+	return branch, nil
+}
@@ -0,0 +1,45 @@
+package planner
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestHandoff_IntegrationBranchesDisabled(t *testing.T) {
+	mgr := newTestManager(t)
+	settings := filepath.Join(mgr.rig.Path, "settings", "config.json")
+	if err := os.MkdirAll(filepath.Dir(settings), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(settings, []byte(`{"merge_queue": {"integration_branches": false}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Without epics there is nothing to branch
+	session := &PlanningSession{ID: "gt-plan-a", Title: "Auth", Status: StatusApproved, IntegrationBranches: true}
+	if err := mgr.SaveSession(session); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := mgr.Handoff(session); err != nil {
+		t.Fatalf("Handoff: %v", err)
+	}
+
+	session = &PlanningSession{
+		ID:                  "gt-plan-b",
+		Title:               "Billing",
+		Status:              StatusApproved,
+		Slices:              []Slice{{Number: 1, Title: "Invoices", EpicID: "gt-epic1"}},
+		IntegrationBranches: true,
+	}
+	if err := mgr.SaveSession(session); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := mgr.Handoff(session); !errors.Is(err, ErrIntegrationBranchesDisabled) {
+		t.Fatalf("expected ErrIntegrationBranchesDisabled, got %v", err)
+	}
+	if session.Status != StatusApproved {
+		t.Errorf("failed handoff changed status to %s", session.Status)
+	}
+}
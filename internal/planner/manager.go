@@ -8,8 +8,10 @@ import (
 	"path/filepath"
 	"time"
 
+	"github.com/gofrs/flock"
 	"github.com/steveyegge/gastown/internal/agent"
 	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/mail"
 	"github.com/steveyegge/gastown/internal/rig"
 	"github.com/steveyegge/gastown/internal/util"
 )
@@ -19,6 +21,7 @@ var (
 	ErrNoActiveSession = errors.New("no active planning session")
 	ErrSessionExists   = errors.New("planning session already exists")
 	ErrSessionNotFound = errors.New("planning session not found")
+	ErrSessionConflict = errors.New("planning session was modified concurrently")
 )
 
 // Manager handles planner lifecycle and planning session operations.
@@ -27,6 +30,7 @@ type Manager struct {
 	workDir      string
 	stateManager *agent.StateManager[Planner]
 	beads        *beads.Beads
+	router       *mail.Router
 }
 
 // NewManager creates a new planner manager for a rig.
@@ -40,7 +44,8 @@ func NewManager(r *rig.Rig) *Manager {
 				State:   StateStopped,
 			}
 		}),
-		beads: beads.New(r.Path),
+		beads:  beads.New(r.Path),
+		router: mail.NewRouter(r.Path),
 	}
 }
 
@@ -64,7 +69,8 @@ func (m *Manager) Status() (*Planner, error) {
 	return m.stateManager.Load()
 }
 
-// ListSessions returns all planning sessions in the .specs directory.
+// ListSessions returns all planning sessions in the .specs directory,
+// excluding archived ones.
 func (m *Manager) ListSessions() ([]*PlanningSession, error) {
 	specsDir := m.specsDir()
 
@@ -80,7 +86,7 @@ func (m *Manager) ListSessions() ([]*PlanningSession, error) {
 
 	var sessions []*PlanningSession
 	for _, entry := range entries {
-		if !entry.IsDir() {
+		if !entry.IsDir() || entry.Name() == ArchiveDirName {
 			continue
 		}
 
@@ -115,17 +121,108 @@ func (m *Manager) LoadSession(sessionID string) (*PlanningSession, error) {
 	return &session, nil
 }
 
-// SaveSession saves a planning session to disk.
+// SaveSession saves a planning session to disk, and regenerates its
+// decisions.md and glossary.md and their links from SPEC.md.
+//
+// Saves use optimistic concurrency: if the session on disk has moved past the
+// revision that was loaded (e.g., another terminal answered a question), the
+// save is rejected with ErrSessionConflict instead of silently overwriting.
 func (m *Manager) SaveSession(session *PlanningSession) error {
+	return m.saveSession(session, false, true)
+}
+
+// ForceSaveSession saves a planning session, overwriting any concurrent changes.
+func (m *Manager) ForceSaveSession(session *PlanningSession) error {
+	return m.saveSession(session, true, true)
+}
+
+// saveSession writes a session. Bookkeeping saves pass touch=false to keep
+// UpdatedAt and UpdatedBy, so they don't count as activity for gc.
+func (m *Manager) saveSession(session *PlanningSession, force, touch bool) error {
 	sessionDir := m.sessionDir(session.ID)
 	if err := os.MkdirAll(sessionDir, 0755); err != nil {
 		return fmt.Errorf("creating session directory: %w", err)
 	}
 
+	// Hold the lock across read-compare-write so two saves can't both pass
+	// the revision check.
+	fileLock := flock.New(m.sessionLockPath(session.ID))
+	if err := fileLock.Lock(); err != nil {
+		return fmt.Errorf("locking session: %w", err)
+	}
+	defer func() { _ = fileLock.Unlock() }()
+
+	current, err := m.LoadSession(session.ID)
+	if err != nil && err != ErrSessionNotFound {
+		return err
+	}
+
+	revision := session.Revision
+	if current != nil && current.Revision != session.Revision {
+		if !force {
+			by := current.UpdatedBy
+			if by == "" {
+				by = "unknown"
+			}
+			return fmt.Errorf("%w: %s is at revision %d but was loaded at revision %d (last saved by %s at %s); reload and retry",
+				ErrSessionConflict, session.ID, current.Revision, session.Revision,
+				by, current.UpdatedAt.Format("15:04:05"))
+		}
+		if current.Revision > revision {
+			revision = current.Revision
+		}
+	}
+
 	sessionFile := filepath.Join(sessionDir, "session.json")
-	session.UpdatedAt = time.Now()
+	session.Revision = revision + 1
+	if touch {
+		session.UpdatedAt = time.Now()
+		session.UpdatedBy = sessionWriter()
+	}
 
-	return util.AtomicWriteJSON(sessionFile, session)
+	if err := util.AtomicWriteJSON(sessionFile, session); err != nil {
+		return err
+	}
+	return m.writeRecords(session)
+}
+
+// sessionLockPath returns the path of the lock file guarding session saves.
+func (m *Manager) sessionLockPath(sessionID string) string {
+	return filepath.Join(m.sessionDir(sessionID), ".session.lock")
+}
+
+// IsSessionLocked reports whether a save is currently in progress for a session.
+func (m *Manager) IsSessionLocked(sessionID string) bool {
+	lockPath := m.sessionLockPath(sessionID)
+	if !fileExists(lockPath) {
+		return false
+	}
+	fileLock := flock.New(lockPath)
+	locked, err := fileLock.TryLock()
+	if err != nil {
+		return false
+	}
+	if locked {
+		_ = fileLock.Unlock()
+		return false
+	}
+	return true
+}
+
+// sessionWriter identifies who is saving a session (BD_ACTOR, else user@host).
+func sessionWriter() string {
+	if actor := os.Getenv("BD_ACTOR"); actor != "" {
+		return actor
+	}
+	user := os.Getenv("USER")
+	host, _ := os.Hostname()
+	if user == "" {
+		return host
+	}
+	if host == "" {
+		return user
+	}
+	return user + "@" + host
 }
 
 // CreateSession creates a new planning session.
@@ -266,6 +363,15 @@ func (m *Manager) GetSessionArtifacts(sessionID string) (*SpecArtifacts, error)
 	if requirements := filepath.Join(planningDir, "requirements.md"); fileExists(requirements) {
 		artifacts.RequirementsPath = requirements
 	}
+	if risks := filepath.Join(planningDir, "risks.md"); fileExists(risks) {
+		artifacts.RisksPath = risks
+	}
+	if decisions := filepath.Join(planningDir, "decisions.md"); fileExists(decisions) {
+		artifacts.DecisionsPath = decisions
+	}
+	if glossary := filepath.Join(planningDir, "glossary.md"); fileExists(glossary) {
+		artifacts.GlossaryPath = glossary
+	}
 
 	// Check for proposal artifacts
 	proposalDir := filepath.Join(sessionDir, "proposal")
@@ -0,0 +1,96 @@
+package planner
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/rig"
+)
+
+func newTestManager(t *testing.T) *Manager {
+	t.Helper()
+	return NewManager(&rig.Rig{Name: "testrig", Path: t.TempDir()})
+}
+
+func TestSaveSession_IncrementsRevision(t *testing.T) {
+	mgr := newTestManager(t)
+	session := &PlanningSession{ID: "gt-plan1", Title: "Test", Status: StatusQuestioning}
+
+	if err := mgr.SaveSession(session); err != nil {
+		t.Fatalf("SaveSession: %v", err)
+	}
+	if err := mgr.SaveSession(session); err != nil {
+		t.Fatalf("SaveSession (second): %v", err)
+	}
+
+	loaded, err := mgr.LoadSession("gt-plan1")
+	if err != nil {
+		t.Fatalf("LoadSession: %v", err)
+	}
+	if loaded.Revision != 2 {
+		t.Errorf("Revision = %d, want 2", loaded.Revision)
+	}
+	if loaded.UpdatedBy == "" {
+		t.Error("expected UpdatedBy to be set")
+	}
+}
+
+func TestSaveSession_Conflict(t *testing.T) {
+	mgr := newTestManager(t)
+	session := &PlanningSession{
+		ID:        "gt-plan2",
+		Status:    StatusQuestioning,
+		Questions: []Question{{ID: "q1", Text: "Which auth?"}, {ID: "q2", Text: "Which DB?"}},
+	}
+	if err := mgr.SaveSession(session); err != nil {
+		t.Fatalf("SaveSession: %v", err)
+	}
+
+	// Two terminals load the same revision
+	first, _ := mgr.LoadSession("gt-plan2")
+	second, _ := mgr.LoadSession("gt-plan2")
+
+	first.Questions[0].Answer = "JWT"
+	if err := mgr.SaveSession(first); err != nil {
+		t.Fatalf("first save: %v", err)
+	}
+
+	second.Questions[1].Answer = "Postgres"
+	err := mgr.SaveSession(second)
+	if !errors.Is(err, ErrSessionConflict) {
+		t.Fatalf("expected ErrSessionConflict, got %v", err)
+	}
+
+	// The first writer's answer must survive the rejected save
+	loaded, _ := mgr.LoadSession("gt-plan2")
+	if loaded.Questions[0].Answer != "JWT" || loaded.Questions[1].Answer != "" {
+		t.Errorf("unexpected answers after conflict: %+v", loaded.Questions)
+	}
+
+	// Force overwrites and moves past the on-disk revision
+	if err := mgr.ForceSaveSession(second); err != nil {
+		t.Fatalf("ForceSaveSession: %v", err)
+	}
+	loaded, _ = mgr.LoadSession("gt-plan2")
+	if loaded.Revision != 3 {
+		t.Errorf("Revision = %d, want 3", loaded.Revision)
+	}
+	if loaded.Questions[1].Answer != "Postgres" {
+		t.Errorf("expected forced answer to be saved, got %+v", loaded.Questions)
+	}
+}
+
+func TestIsSessionLocked(t *testing.T) {
+	mgr := newTestManager(t)
+	if mgr.IsSessionLocked("gt-none") {
+		t.Error("expected no lock for unknown session")
+	}
+
+	session := &PlanningSession{ID: "gt-plan3"}
+	if err := mgr.SaveSession(session); err != nil {
+		t.Fatalf("SaveSession: %v", err)
+	}
+	if mgr.IsSessionLocked("gt-plan3") {
+		t.Error("expected lock to be released after save")
+	}
+}
@@ -0,0 +1,300 @@
+package planner
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/git"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultPublishDest is the docs directory specs are published to,
+// relative to the rig's repo root.
+const DefaultPublishDest = "docs/specs/"
+
+// Publish errors
+var (
+	ErrNotApproved = errors.New("spec is not approved")
+	ErrNoSpec      = errors.New("session has no SPEC.md")
+)
+
+// PublishOptions configures Manager.Publish.
+type PublishOptions struct {
+	// Dest is the docs directory relative to the repo root.
+	// Default: the previous publication's directory, else DefaultPublishDest.
+	Dest string
+
+	// Owner is recorded in the front-matter. Default: the previous
+	// owner, else whoever publishes.
+	Owner string
+
+	// Epic is the linked epic recorded in the front-matter.
+	Epic string
+
+	// NoMR skips submitting the branch to the merge queue.
+	NoMR bool
+}
+
+// specFrontMatter is the YAML front-matter on a published spec.
+type specFrontMatter struct {
+	Title   string `yaml:"title"`
+	Status  string `yaml:"status"`
+	Owner   string `yaml:"owner,omitempty"`
+	Date    string `yaml:"date"`
+	Epic    string `yaml:"epic,omitempty"`
+	Session string `yaml:"session"`
+}
+
+// RenderPublishedSpec prefixes a spec with front-matter describing the
+// session. Any front-matter already on the spec is replaced, and its links
+// to the session's planning records point at the copies published next to
+// it (publishedRecords).
+func RenderPublishedSpec(session *PlanningSession, spec string, pub *Publication) (string, error) {
+	fm, err := yaml.Marshal(specFrontMatter{
+		Title:   session.Title,
+		Status:  string(session.Status),
+		Owner:   pub.Owner,
+		Date:    pub.PublishedAt.Format("2006-01-02"),
+		Epic:    pub.Epic,
+		Session: session.ID,
+	})
+	if err != nil {
+		return "", err
+	}
+	var links []string
+	for _, f := range publishedRecords(session, pub) {
+		links = append(links, fmt.Sprintf("- [%s](%s)", f.title, path.Base(f.path)))
+	}
+	body := linkRecords(stripFrontMatter(spec), links)
+	return "---\n" + string(fm) + "---\n\n" + strings.TrimLeft(body, "\n"), nil
+}
+
+// publishedFile is a file committed on the publish branch.
+type publishedFile struct {
+	path    string // relative to the repo root, slash-separated
+	title   string
+	content string
+}
+
+// publishedRecords returns the session's planning records as published
+// next to the spec: <spec>-decisions.md and <spec>-glossary.md.
+func publishedRecords(session *PlanningSession, pub *Publication) []publishedFile {
+	base := strings.TrimSuffix(pub.Path, path.Ext(pub.Path))
+	var files []publishedFile
+	for _, r := range sessionRecords(session) {
+		files = append(files, publishedFile{
+			path:    base + "-" + r.name,
+			title:   r.title,
+			content: r.content,
+		})
+	}
+	return files
+}
+
+func stripFrontMatter(doc string) string {
+	if !strings.HasPrefix(doc, "---\n") {
+		return doc
+	}
+	end := strings.Index(doc[4:], "\n---\n")
+	if end < 0 {
+		return doc
+	}
+	return doc[4+end+len("\n---\n"):]
+}
+
+var slugUnsafe = regexp.MustCompile(`[^a-z0-9]+`)
+
+// specSlug derives the published file name from the session title.
+func specSlug(session *PlanningSession) string {
+	slug := strings.Trim(slugUnsafe.ReplaceAllString(strings.ToLower(session.Title), "-"), "-")
+	if slug == "" {
+		slug = session.ID
+	}
+	return slug + ".md"
+}
+
+// publishBranch is the branch a session's spec is published on.
+func publishBranch(sessionID string) string {
+	return "docs/spec-" + sessionID
+}
+
+// repoDir returns the rig's working clone, preferring refinery/rig so the
+// publish branch lands in the repo the refinery merges from.
+func (m *Manager) repoDir() string {
+	dir := filepath.Join(m.rig.Path, "refinery", "rig")
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		dir = filepath.Join(m.rig.Path, "mayor", "rig")
+	}
+	return dir
+}
+
+// Publish copies an approved session's SPEC.md, with front-matter, into the
+// rig's docs tree on a docs/spec-<session> branch and submits the branch to
+// the merge queue. Publishing again refreshes the same file, branch and MR.
+// The user's checkouts are untouched: the commit is made in a temporary
+// worktree.
+func (m *Manager) Publish(session *PlanningSession, opts PublishOptions) (*Publication, error) {
+	if session.Status != StatusApproved && session.Status != StatusHandedOff {
+		return nil, fmt.Errorf("%w: session %s is %s", ErrNotApproved, session.ID, session.Status)
+	}
+	artifacts, err := m.GetSessionArtifacts(session.ID)
+	if err != nil {
+		return nil, err
+	}
+	if artifacts.SpecPath == "" {
+		return nil, ErrNoSpec
+	}
+	spec, err := os.ReadFile(artifacts.SpecPath)
+	if err != nil {
+		return nil, fmt.Errorf("reading SPEC.md: %w", err)
+	}
+
+	pub := &Publication{
+		Branch:      publishBranch(session.ID),
+		Target:      m.rig.DefaultBranch(),
+		Owner:       opts.Owner,
+		Epic:        opts.Epic,
+		PublishedAt: time.Now(),
+	}
+	prev := session.Publication
+	if prev != nil {
+		pub.MRID = prev.MRID
+		if pub.Owner == "" {
+			pub.Owner = prev.Owner
+		}
+		if pub.Epic == "" {
+			pub.Epic = prev.Epic
+		}
+	}
+	if pub.Owner == "" {
+		pub.Owner = sessionWriter()
+	}
+	switch {
+	case opts.Dest != "":
+		pub.Path = filepath.ToSlash(filepath.Join(opts.Dest, specSlug(session)))
+	case prev != nil:
+		pub.Path = prev.Path
+	default:
+		pub.Path = DefaultPublishDest + specSlug(session)
+	}
+
+	content, err := RenderPublishedSpec(session, string(spec), pub)
+	if err != nil {
+		return nil, fmt.Errorf("rendering front-matter: %w", err)
+	}
+	files := []publishedFile{{path: pub.Path, content: content}}
+	pub.Records = nil
+	for _, r := range publishedRecords(session, pub) {
+		files = append(files, r)
+		pub.Records = append(pub.Records, r.path)
+	}
+	if pub.Commit, err = m.commitPublication(session, pub, files); err != nil {
+		return nil, err
+	}
+
+	if !opts.NoMR {
+		if err := m.submitPublication(session, pub); err != nil {
+			return nil, err
+		}
+	}
+
+	session.Publication = pub
+	if err := m.SaveSession(session); err != nil {
+		return nil, err
+	}
+	return pub, nil
+}
+
+// commitPublication writes the spec and its records on the publish branch
+// in a temporary worktree and returns the branch head.
+func (m *Manager) commitPublication(session *PlanningSession, pub *Publication, files []publishedFile) (string, error) {
+	g := git.NewGit(m.repoDir())
+	if !g.IsRepo() {
+		return "", fmt.Errorf("no rig repo at %s", m.repoDir())
+	}
+
+	tmp, err := os.MkdirTemp("", "gt-publish-*")
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = os.RemoveAll(tmp) }()
+	wtPath := filepath.Join(tmp, "wt")
+
+	exists, err := g.BranchExists(pub.Branch)
+	if err != nil {
+		return "", err
+	}
+	if exists {
+		err = g.WorktreeAddExisting(wtPath, pub.Branch)
+	} else {
+		err = g.WorktreeAddFromRef(wtPath, pub.Branch, pub.Target)
+	}
+	if err != nil {
+		return "", fmt.Errorf("creating publish worktree: %w", err)
+	}
+	defer func() { _ = g.WorktreeRemove(wtPath, true) }()
+
+	wt := git.NewGit(wtPath)
+	for _, f := range files {
+		target := filepath.Join(wtPath, filepath.FromSlash(f.path))
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return "", err
+		}
+		if err := os.WriteFile(target, []byte(f.content), 0644); err != nil {
+			return "", fmt.Errorf("writing %s: %w", f.path, err)
+		}
+		if err := wt.Add(f.path); err != nil {
+			return "", err
+		}
+	}
+	status, err := wt.Status()
+	if err != nil {
+		return "", err
+	}
+	if !status.Clean {
+		msg := fmt.Sprintf("docs: publish spec %q (%s)", session.Title, session.ID)
+		if err := wt.Commit(msg); err != nil {
+			return "", fmt.Errorf("committing spec: %w", err)
+		}
+	}
+	return wt.Rev("HEAD")
+}
+
+// submitPublication submits the publish branch to the merge queue, reusing
+// an open MR for the branch if there is one.
+func (m *Manager) submitPublication(session *PlanningSession, pub *Publication) error {
+	existing, err := m.beads.FindMRForBranch(pub.Branch)
+	if err != nil {
+		return fmt.Errorf("checking for existing MR: %w", err)
+	}
+	if existing != nil {
+		pub.MRID = existing.ID
+		return nil
+	}
+
+	sourceIssue := session.SpecBeadID
+	if sourceIssue == "" {
+		sourceIssue = session.ID
+	}
+	mr, err := m.beads.Create(beads.CreateOptions{
+		Title:    fmt.Sprintf("Merge: %s", sourceIssue),
+		Type:     "merge-request",
+		Priority: 2,
+		Description: fmt.Sprintf("branch: %s\ntarget: %s\nsource_issue: %s\nrig: %s",
+			pub.Branch, pub.Target, sourceIssue, m.rig.Name),
+		Actor:     sessionWriter(),
+		Ephemeral: true,
+	})
+	if err != nil {
+		return fmt.Errorf("creating merge request bead: %w", err)
+	}
+	pub.MRID = mr.ID
+	return nil
+}
@@ -0,0 +1,156 @@
+package planner
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRenderPublishedSpec(t *testing.T) {
+	session := &PlanningSession{ID: "gt-plan1", Title: "Auth: SSO", Status: StatusApproved}
+	pub := &Publication{
+		Owner:       "mayor",
+		Epic:        "gt-epic1",
+		PublishedAt: time.Date(2026, 3, 4, 12, 0, 0, 0, time.UTC),
+	}
+
+	spec := "---\nstale: true\n---\n\n# Spec: Auth\n\nBody\n"
+	got, err := RenderPublishedSpec(session, spec, pub)
+	if err != nil {
+		t.Fatalf("RenderPublishedSpec: %v", err)
+	}
+
+	want := "---\ntitle: 'Auth: SSO'\nstatus: approved\nowner: mayor\ndate: \"2026-03-04\"\nepic: gt-epic1\nsession: gt-plan1\n---\n\n# Spec: Auth\n\nBody\n"
+	if got != want {
+		t.Errorf("RenderPublishedSpec =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestPublish_IncludesRecords(t *testing.T) {
+	mgr := newTestManager(t)
+	repo := filepath.Join(mgr.rig.Path, "mayor", "rig")
+	runGit(t, "", "init", "-b", "main", repo)
+	runGit(t, repo, "config", "user.email", "test@test.com")
+	runGit(t, repo, "config", "user.name", "Test User")
+	runGit(t, repo, "commit", "--allow-empty", "-m", "initial")
+
+	session := &PlanningSession{
+		ID:        "gt-plan4",
+		Title:     "Dark Mode",
+		Status:    StatusApproved,
+		Decisions: []Decision{{ID: "d1", Text: "Follow the OS setting", DecidedAt: time.Now()}},
+	}
+	if err := mgr.SaveSession(session); err != nil {
+		t.Fatal(err)
+	}
+	specDir := filepath.Join(mgr.sessionDir(session.ID), "spec")
+	if err := os.MkdirAll(specDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(specDir, "SPEC.md"), []byte("# Spec: Dark Mode\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := mgr.writeRecords(session); err != nil {
+		t.Fatal(err)
+	}
+
+	pub, err := mgr.Publish(session, PublishOptions{NoMR: true})
+	if err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+	if len(pub.Records) != 1 || pub.Records[0] != "docs/specs/dark-mode-decisions.md" {
+		t.Fatalf("Records = %v, want [docs/specs/dark-mode-decisions.md]", pub.Records)
+	}
+
+	content := runGit(t, repo, "show", pub.Branch+":"+pub.Path)
+	if !strings.Contains(content, "- [Decision log](dark-mode-decisions.md)") || strings.Contains(content, "../planning/") {
+		t.Errorf("published spec should link the published decision log:\n%s", content)
+	}
+	if record := runGit(t, repo, "show", pub.Branch+":"+pub.Records[0]); !strings.Contains(record, "Follow the OS setting") {
+		t.Errorf("published decision log missing decision:\n%s", record)
+	}
+}
+
+func TestPublish_RequiresApproval(t *testing.T) {
+	mgr := newTestManager(t)
+	session := &PlanningSession{ID: "gt-plan2", Title: "Draft", Status: StatusReviewing}
+
+	if _, err := mgr.Publish(session, PublishOptions{NoMR: true}); !errors.Is(err, ErrNotApproved) {
+		t.Errorf("expected ErrNotApproved, got %v", err)
+	}
+
+	session.Status = StatusApproved
+	if _, err := mgr.Publish(session, PublishOptions{NoMR: true}); !errors.Is(err, ErrNoSpec) {
+		t.Errorf("expected ErrNoSpec, got %v", err)
+	}
+}
+
+func TestPublish_CommitsToBranch(t *testing.T) {
+	mgr := newTestManager(t)
+	repo := filepath.Join(mgr.rig.Path, "mayor", "rig")
+	runGit(t, "", "init", "-b", "main", repo)
+	runGit(t, repo, "config", "user.email", "test@test.com")
+	runGit(t, repo, "config", "user.name", "Test User")
+	runGit(t, repo, "commit", "--allow-empty", "-m", "initial")
+
+	session := &PlanningSession{ID: "gt-plan3", Title: "Dark Mode", Status: StatusApproved}
+	if err := mgr.SaveSession(session); err != nil {
+		t.Fatal(err)
+	}
+	specDir := filepath.Join(mgr.sessionDir(session.ID), "spec")
+	if err := os.MkdirAll(specDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(specDir, "SPEC.md"), []byte("# Spec: Dark Mode\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	pub, err := mgr.Publish(session, PublishOptions{Owner: "crew/max", NoMR: true})
+	if err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+	if pub.Path != "docs/specs/dark-mode.md" || pub.Branch != "docs/spec-gt-plan3" || pub.Target != "main" {
+		t.Errorf("unexpected publication: %+v", pub)
+	}
+
+	content := runGit(t, repo, "show", pub.Branch+":"+pub.Path)
+	if !strings.Contains(content, "owner: crew/max") || !strings.Contains(content, "# Spec: Dark Mode") {
+		t.Errorf("published spec missing front-matter or body:\n%s", content)
+	}
+	if out := runGit(t, repo, "status", "--porcelain"); out != "" {
+		t.Errorf("expected rig checkout untouched, got status:\n%s", out)
+	}
+
+	// Republishing an unchanged spec on the same day is a no-op commit-wise
+	// and keeps the owner and path
+	again, err := mgr.Publish(session, PublishOptions{NoMR: true})
+	if err != nil {
+		t.Fatalf("Publish (again): %v", err)
+	}
+	if again.Commit != pub.Commit || again.Owner != "crew/max" || again.Path != pub.Path {
+		t.Errorf("republish changed publication: %+v -> %+v", pub, again)
+	}
+
+	loaded, err := mgr.LoadSession(session.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.Publication == nil || loaded.Publication.Commit != pub.Commit {
+		t.Errorf("publication not saved on session: %+v", loaded.Publication)
+	}
+}
+
+func runGit(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %v: %v\n%s", args, err, out)
+	}
+	return strings.TrimSpace(string(out))
+}
@@ -0,0 +1,215 @@
+package planner
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/mail"
+)
+
+// OverseerAddress is where the planner sends its questions.
+const OverseerAddress = "overseer"
+
+// QuestionSubjectPrefix marks question mail from the planner. The inbox TUI
+// files subjects with this prefix under QUESTION.
+const QuestionSubjectPrefix = "[QUESTION]"
+
+// questionSubjectRe extracts the session ID from a question subject or a
+// reply to one ("Re: [QUESTION] Planning gt-abc: ...").
+var questionSubjectRe = regexp.MustCompile(`\[QUESTION\] Planning (\S+):`)
+
+// answerLineRe matches a structured answer line such as "q1: use JWT".
+var answerLineRe = regexp.MustCompile(`(?i)^\s*(q\d+)\s*[:=)-]\s*(.+?)\s*$`)
+
+// PlannerAddress returns the mail address of a rig's planner.
+func PlannerAddress(rigName string) string {
+	return rigName + "/planner"
+}
+
+// AddQuestion records a new clarifying question and returns it.
+func (s *PlanningSession) AddQuestion(text string) Question {
+	q := Question{
+		ID:      fmt.Sprintf("q%d", len(s.Questions)+1),
+		Text:    text,
+		AskedAt: time.Now(),
+	}
+	s.Questions = append(s.Questions, q)
+	return q
+}
+
+// AnswerQuestion records the answer to a question, replacing any earlier one.
+func (s *PlanningSession) AnswerQuestion(questionID, answer string) error {
+	for i := range s.Questions {
+		if s.Questions[i].ID != questionID {
+			continue
+		}
+		now := time.Now()
+		s.Questions[i].Answer = answer
+		s.Questions[i].AnsweredAt = &now
+		return nil
+	}
+	return fmt.Errorf("%w: %s", ErrQuestionNotFound, questionID)
+}
+
+// QuestionMail renders questions as a QUESTION message to the overseer.
+func QuestionMail(s *PlanningSession, questions []Question) (subject, body string) {
+	subject = fmt.Sprintf("%s Planning %s: %s", QuestionSubjectPrefix, s.ID, s.Title)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "The planner has %d question(s) about %q (session %s):\n\n", len(questions), s.Title, s.ID)
+	for _, q := range questions {
+		fmt.Fprintf(&b, "%s: %s\n", q.ID, q.Text)
+	}
+	b.WriteString("\nReply with one answer per line, for example:\n\n")
+	fmt.Fprintf(&b, "%s: use JWT\n", questions[0].ID)
+	return subject, b.String()
+}
+
+// ParseAnswers extracts structured answers ("q1: use JWT") from a reply
+// body, keyed by question ID. Quoted lines are ignored; if a question is
+// answered twice the last answer wins.
+func ParseAnswers(body string) map[string]string {
+	answers := make(map[string]string)
+	for _, line := range strings.Split(body, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), ">") {
+			continue
+		}
+		if m := answerLineRe.FindStringSubmatch(line); m != nil {
+			answers[strings.ToLower(m[1])] = m[2]
+		}
+	}
+	return answers
+}
+
+// IngestedAnswer is an answer applied to a session from mail.
+type IngestedAnswer struct {
+	SessionID  string `json:"session_id"`
+	QuestionID string `json:"question_id"`
+	Answer     string `json:"answer"`
+	From       string `json:"from"`
+}
+
+// IngestResult summarizes an IngestAnswers pass.
+type IngestResult struct {
+	// Answered are the answers recorded in sessions.
+	Answered []IngestedAnswer `json:"answered,omitempty"`
+
+	// Unknown lists answers to questions that aren't in their session, as
+	// "session/question", or to sessions that don't exist.
+	Unknown []string `json:"unknown,omitempty"`
+
+	// Unparsed lists replies with no structured answers. They are left
+	// unread for a human to handle.
+	Unparsed []string `json:"unparsed,omitempty"`
+}
+
+// AskQuestions records questions in the session, saves it, and mails them to
+// the overseer as a single QUESTION message.
+func (m *Manager) AskQuestions(session *PlanningSession, texts []string) ([]Question, error) {
+	if len(texts) == 0 {
+		return nil, nil
+	}
+	questions := make([]Question, 0, len(texts))
+	for _, text := range texts {
+		questions = append(questions, session.AddQuestion(text))
+	}
+	if err := m.SaveSession(session); err != nil {
+		return nil, err
+	}
+
+	subject, body := QuestionMail(session, questions)
+	msg := &mail.Message{
+		From:     PlannerAddress(m.rig.Name),
+		To:       OverseerAddress,
+		Subject:  subject,
+		Body:     body,
+		Priority: mail.PriorityNormal,
+		Type:     mail.TypeTask,
+	}
+	if err := m.router.Send(msg); err != nil {
+		return questions, fmt.Errorf("mailing questions: %w", err)
+	}
+	return questions, nil
+}
+
+// IngestAnswers reads unread replies to planner questions from the planner's
+// mailbox and records their structured answers in the matching sessions.
+// Replies are marked read once their answers are saved.
+func (m *Manager) IngestAnswers() (*IngestResult, error) {
+	mailbox, err := m.router.GetMailbox(PlannerAddress(m.rig.Name))
+	if err != nil {
+		return nil, fmt.Errorf("getting planner mailbox: %w", err)
+	}
+	return m.ingestAnswers(mailbox)
+}
+
+func (m *Manager) ingestAnswers(mailbox *mail.Mailbox) (*IngestResult, error) {
+	messages, err := mailbox.ListUnread()
+	if err != nil {
+		return nil, fmt.Errorf("listing planner mail: %w", err)
+	}
+
+	// Messages are newest first; apply oldest first so a later reply to the
+	// same question wins.
+	result := &IngestResult{}
+	for i := len(messages) - 1; i >= 0; i-- {
+		msg := messages[i]
+		match := questionSubjectRe.FindStringSubmatch(msg.Subject)
+		if match == nil {
+			continue // not a reply to a planner question
+		}
+		sessionID := match[1]
+
+		answers := ParseAnswers(msg.Body)
+		if len(answers) == 0 {
+			result.Unparsed = append(result.Unparsed, msg.ID)
+			continue
+		}
+
+		// Load fresh so answers from another terminal aren't clobbered
+		session, err := m.LoadSession(sessionID)
+		if err == ErrSessionNotFound {
+			result.Unknown = append(result.Unknown, sessionID)
+			continue
+		}
+		if err != nil {
+			return result, fmt.Errorf("loading session %s: %w", sessionID, err)
+		}
+
+		var applied []IngestedAnswer
+		for _, q := range session.Questions {
+			answer, ok := answers[q.ID]
+			if !ok {
+				continue
+			}
+			delete(answers, q.ID)
+			_ = session.AnswerQuestion(q.ID, answer)
+			applied = append(applied, IngestedAnswer{
+				SessionID:  sessionID,
+				QuestionID: q.ID,
+				Answer:     answer,
+				From:       msg.From,
+			})
+		}
+		var unknown []string
+		for id := range answers {
+			unknown = append(unknown, sessionID+"/"+id)
+		}
+		sort.Strings(unknown)
+		result.Unknown = append(result.Unknown, unknown...)
+		if len(applied) > 0 {
+			if err := m.SaveSession(session); err != nil {
+				return result, fmt.Errorf("saving session %s: %w", sessionID, err)
+			}
+			result.Answered = append(result.Answered, applied...)
+		}
+
+		if err := mailbox.MarkRead(msg.ID); err != nil {
+			return result, fmt.Errorf("marking %s read: %w", msg.ID, err)
+		}
+	}
+	return result, nil
+}
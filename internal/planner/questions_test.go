@@ -0,0 +1,106 @@
+package planner
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/mail"
+)
+
+func TestParseAnswers(t *testing.T) {
+	body := `Thanks!
+
+q1: use JWT
+Q2 - Postgres, we already run it
+> q3: quoted from the original, ignored
+q4:
+q1: JWT with refresh tokens
+`
+	got := ParseAnswers(body)
+	want := map[string]string{"q1": "JWT with refresh tokens", "q2": "Postgres, we already run it"}
+	if len(got) != len(want) {
+		t.Fatalf("ParseAnswers() = %v, want %v", got, want)
+	}
+	for id, answer := range want {
+		if got[id] != answer {
+			t.Errorf("answer %s = %q, want %q", id, got[id], answer)
+		}
+	}
+}
+
+func TestQuestionMail(t *testing.T) {
+	s := &PlanningSession{ID: "gt-plan1", Title: "Login"}
+	q1 := s.AddQuestion("Which auth?")
+	q2 := s.AddQuestion("Which DB?")
+	if q1.ID != "q1" || q2.ID != "q2" {
+		t.Fatalf("question IDs = %s, %s", q1.ID, q2.ID)
+	}
+
+	subject, body := QuestionMail(s, []Question{q1, q2})
+	if !strings.HasPrefix(subject, QuestionSubjectPrefix) || !strings.Contains(subject, "gt-plan1") {
+		t.Errorf("subject = %q", subject)
+	}
+	// The reply subject must still identify the session
+	if m := questionSubjectRe.FindStringSubmatch("Re: " + subject); m == nil || m[1] != "gt-plan1" {
+		t.Errorf("session not found in reply subject %q", "Re: "+subject)
+	}
+	if !strings.Contains(body, "q2: Which DB?") {
+		t.Errorf("body missing question:\n%s", body)
+	}
+}
+
+func TestIngestAnswers(t *testing.T) {
+	mgr := newTestManager(t)
+	session := &PlanningSession{ID: "gt-plan1", Title: "Login", Status: StatusQuestioning}
+	session.AddQuestion("Which auth?")
+	session.AddQuestion("Which DB?")
+	if err := mgr.SaveSession(session); err != nil {
+		t.Fatalf("SaveSession: %v", err)
+	}
+
+	mailbox := mail.NewMailbox(t.TempDir())
+	now := time.Now()
+	for _, msg := range []*mail.Message{
+		{ID: "m1", From: "overseer", Subject: "Re: [QUESTION] Planning gt-plan1: Login", Body: "q1: sessions\nq9: huh", Timestamp: now.Add(-2 * time.Minute)},
+		{ID: "m2", From: "overseer", Subject: "Re: [QUESTION] Planning gt-plan1: Login", Body: "q1: use JWT", Timestamp: now.Add(-time.Minute)},
+		{ID: "m3", From: "overseer", Subject: "Re: [QUESTION] Planning gt-plan1: Login", Body: "let's talk", Timestamp: now},
+		{ID: "m4", From: "mayor/", Subject: "Unrelated", Body: "q2: not an answer", Timestamp: now},
+	} {
+		if err := mailbox.Append(msg); err != nil {
+			t.Fatalf("Append: %v", err)
+		}
+	}
+
+	result, err := mgr.ingestAnswers(mailbox)
+	if err != nil {
+		t.Fatalf("ingestAnswers: %v", err)
+	}
+	if len(result.Answered) != 2 || len(result.Unknown) != 1 || result.Unknown[0] != "gt-plan1/q9" {
+		t.Errorf("result = %+v", result)
+	}
+	if len(result.Unparsed) != 1 || result.Unparsed[0] != "m3" {
+		t.Errorf("Unparsed = %v, want [m3]", result.Unparsed)
+	}
+
+	loaded, err := mgr.LoadSession("gt-plan1")
+	if err != nil {
+		t.Fatalf("LoadSession: %v", err)
+	}
+	if loaded.Questions[0].Answer != "use JWT" || loaded.Questions[0].AnsweredAt == nil {
+		t.Errorf("q1 = %+v, want the later answer", loaded.Questions[0])
+	}
+	if loaded.Questions[1].Answer != "" {
+		t.Errorf("q2 answered from unrelated mail: %q", loaded.Questions[1].Answer)
+	}
+
+	// Ingested replies are consumed; the rest are left for a human
+	unread, _ := mailbox.ListUnread()
+	var ids []string
+	for _, msg := range unread {
+		ids = append(ids, msg.ID)
+	}
+	if got := strings.Join(ids, ","); strings.Contains(got, "m1") || strings.Contains(got, "m2") || !strings.Contains(got, "m3") {
+		t.Errorf("unread after ingest = %v", ids)
+	}
+}
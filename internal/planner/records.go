@@ -0,0 +1,204 @@
+package planner
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// The decision log and glossary are regenerated on every save, so they
+// always match the session. SPEC.md links to them from a marked section
+// that saves keep up to date and publishing strips, since the links only
+// resolve inside the session directory.
+const (
+	recordsBegin = "<!-- gt:planning-records -->"
+	recordsEnd   = "<!-- /gt:planning-records -->"
+)
+
+// AddDecision records a decision and returns it. With a question ID and
+// no text, the decision is the question's answer.
+func (s *PlanningSession) AddDecision(text, rationale, questionID string) (Decision, error) {
+	if questionID != "" {
+		q := s.question(questionID)
+		if q == nil {
+			return Decision{}, fmt.Errorf("%w: %s", ErrQuestionNotFound, questionID)
+		}
+		if text == "" {
+			text = q.Answer
+		}
+	}
+	if text == "" {
+		return Decision{}, fmt.Errorf("decision text is required")
+	}
+
+	d := Decision{
+		ID:         fmt.Sprintf("d%d", len(s.Decisions)+1),
+		Text:       text,
+		Rationale:  rationale,
+		QuestionID: questionID,
+		DecidedAt:  time.Now(),
+	}
+	s.Decisions = append(s.Decisions, d)
+	return d, nil
+}
+
+// DefineTerm adds a term to the glossary, replacing any earlier definition
+// of the same term (ignoring case).
+func (s *PlanningSession) DefineTerm(term, definition, questionID string) (GlossaryTerm, error) {
+	term = strings.TrimSpace(term)
+	if term == "" || strings.TrimSpace(definition) == "" {
+		return GlossaryTerm{}, fmt.Errorf("term and definition are required")
+	}
+	if questionID != "" && s.question(questionID) == nil {
+		return GlossaryTerm{}, fmt.Errorf("%w: %s", ErrQuestionNotFound, questionID)
+	}
+
+	entry := GlossaryTerm{
+		Term:       term,
+		Definition: definition,
+		QuestionID: questionID,
+		DefinedAt:  time.Now(),
+	}
+	for i := range s.Glossary {
+		if strings.EqualFold(s.Glossary[i].Term, term) {
+			s.Glossary[i] = entry
+			return entry, nil
+		}
+	}
+	s.Glossary = append(s.Glossary, entry)
+	return entry, nil
+}
+
+// question returns the session's question with the given ID, or nil.
+func (s *PlanningSession) question(id string) *Question {
+	for i := range s.Questions {
+		if s.Questions[i].ID == id {
+			return &s.Questions[i]
+		}
+	}
+	return nil
+}
+
+// RenderDecisions renders the decision log as markdown, oldest first.
+func RenderDecisions(s *PlanningSession) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Decisions: %s\n\n", s.Title)
+	fmt.Fprintf(&b, "**Session**: %s\n\n", s.ID)
+
+	if len(s.Decisions) == 0 {
+		b.WriteString("No decisions recorded.\n")
+	}
+	for _, d := range s.Decisions {
+		fmt.Fprintf(&b, "## %s: %s\n\n", d.ID, strings.Join(strings.Fields(d.Text), " "))
+		fmt.Fprintf(&b, "**Date**: %s\n", d.DecidedAt.Format("2006-01-02"))
+		if q := s.question(d.QuestionID); q != nil {
+			fmt.Fprintf(&b, "**Question**: [%s] %s\n", q.ID, q.Text)
+		}
+		if d.Rationale != "" {
+			fmt.Fprintf(&b, "\n%s\n", d.Rationale)
+		}
+		b.WriteString("\n")
+	}
+	return strings.TrimRight(b.String(), "\n") + "\n"
+}
+
+// RenderGlossary renders the glossary as markdown, sorted by term.
+func RenderGlossary(s *PlanningSession) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Glossary: %s\n\n", s.Title)
+	fmt.Fprintf(&b, "**Session**: %s\n\n", s.ID)
+
+	if len(s.Glossary) == 0 {
+		b.WriteString("No terms defined.\n")
+		return b.String()
+	}
+	terms := append([]GlossaryTerm(nil), s.Glossary...)
+	sort.Slice(terms, func(i, j int) bool {
+		return strings.ToLower(terms[i].Term) < strings.ToLower(terms[j].Term)
+	})
+	b.WriteString("| Term | Definition |\n")
+	b.WriteString("|------|------------|\n")
+	for _, t := range terms {
+		fmt.Fprintf(&b, "| %s | %s |\n", markdownCell(t.Term), markdownCell(t.Definition))
+	}
+	return b.String()
+}
+
+// planningRecord is a rendered planning record: the decision log or the
+// glossary.
+type planningRecord struct {
+	name, title, content string
+}
+
+// sessionRecords renders the planning records a session has entries for.
+func sessionRecords(session *PlanningSession) []planningRecord {
+	var records []planningRecord
+	if len(session.Decisions) > 0 {
+		records = append(records, planningRecord{"decisions.md", "Decision log", RenderDecisions(session)})
+	}
+	if len(session.Glossary) > 0 {
+		records = append(records, planningRecord{"glossary.md", "Glossary", RenderGlossary(session)})
+	}
+	return records
+}
+
+// writeRecords regenerates decisions.md and glossary.md for a session
+// that has any, and links them from SPEC.md if the spec has been written.
+func (m *Manager) writeRecords(session *PlanningSession) error {
+	planningDir := filepath.Join(m.sessionDir(session.ID), "planning")
+
+	var links []string
+	for _, r := range sessionRecords(session) {
+		if err := os.MkdirAll(planningDir, 0755); err != nil {
+			return fmt.Errorf("creating planning directory: %w", err)
+		}
+		if err := os.WriteFile(filepath.Join(planningDir, r.name), []byte(r.content), 0644); err != nil {
+			return fmt.Errorf("writing %s: %w", r.name, err)
+		}
+		links = append(links, fmt.Sprintf("- [%s](../planning/%s)", r.title, r.name))
+	}
+
+	specPath := filepath.Join(m.sessionDir(session.ID), "spec", "SPEC.md")
+	spec, err := os.ReadFile(specPath)
+	if err != nil {
+		return nil // No spec yet
+	}
+	if linked := linkRecords(string(spec), links); linked != string(spec) {
+		if err := os.WriteFile(specPath, []byte(linked), 0644); err != nil {
+			return fmt.Errorf("writing SPEC.md: %w", err)
+		}
+	}
+	return nil
+}
+
+// linkRecords replaces the spec's planning records section with one
+// listing links, appending it if the spec has none.
+func linkRecords(spec string, links []string) string {
+	spec = stripRecords(spec)
+	if len(links) == 0 {
+		return spec
+	}
+	return strings.TrimRight(spec, "\n") + "\n\n" + recordsBegin + "\n## Planning Records\n\n" +
+		strings.Join(links, "\n") + "\n" + recordsEnd + "\n"
+}
+
+// stripRecords removes the planning records section from a spec.
+func stripRecords(spec string) string {
+	start := strings.Index(spec, recordsBegin)
+	if start < 0 {
+		return spec
+	}
+	end := strings.Index(spec[start:], recordsEnd)
+	if end < 0 {
+		return spec
+	}
+	rest := strings.TrimLeft(spec[start+end+len(recordsEnd):], "\n")
+	head := strings.TrimRight(spec[:start], "\n") + "\n"
+	if rest == "" {
+		return head
+	}
+	return head + "\n" + rest
+}
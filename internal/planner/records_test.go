@@ -0,0 +1,123 @@
+package planner
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAddDecision(t *testing.T) {
+	s := &PlanningSession{Questions: []Question{{ID: "q1", Text: "Which auth?", Answer: "JWT"}}}
+
+	d1, err := s.AddDecision("", "Stateless servers", "q1")
+	if err != nil {
+		t.Fatalf("AddDecision: %v", err)
+	}
+	if d1.ID != "d1" || d1.Text != "JWT" || d1.DecidedAt.IsZero() {
+		t.Errorf("decision from answer = %+v, want d1 JWT", d1)
+	}
+
+	if _, err := s.AddDecision("x", "", "q9"); !errors.Is(err, ErrQuestionNotFound) {
+		t.Errorf("expected ErrQuestionNotFound, got %v", err)
+	}
+	if _, err := s.AddDecision("", "", ""); err == nil {
+		t.Error("expected error for a decision without text")
+	}
+
+	if d2, _ := s.AddDecision("Postgres", "", ""); d2.ID != "d2" {
+		t.Errorf("second decision ID = %s, want d2", d2.ID)
+	}
+}
+
+func TestDefineTerm_Replaces(t *testing.T) {
+	s := &PlanningSession{}
+	_, _ = s.DefineTerm("Household", "A family", "")
+	_, _ = s.DefineTerm("household", "Guardians and children sharing an account", "")
+
+	if len(s.Glossary) != 1 || s.Glossary[0].Definition != "Guardians and children sharing an account" {
+		t.Errorf("Glossary = %+v, want one replaced definition", s.Glossary)
+	}
+	if _, err := s.DefineTerm("Roster", "", ""); err == nil {
+		t.Error("expected error for an empty definition")
+	}
+}
+
+func TestSaveSession_WritesRecords(t *testing.T) {
+	mgr := newTestManager(t)
+	session := &PlanningSession{
+		ID:        "gt-plan4",
+		Title:     "Auth",
+		Questions: []Question{{ID: "q1", Text: "Which auth?", Answer: "JWT"}},
+	}
+	if err := mgr.SaveSession(session); err != nil {
+		t.Fatalf("SaveSession: %v", err)
+	}
+	planningDir := filepath.Join(mgr.sessionDir(session.ID), "planning")
+	if _, err := os.Stat(filepath.Join(planningDir, "decisions.md")); !os.IsNotExist(err) {
+		t.Error("decisions.md written without any decisions")
+	}
+
+	specDir := filepath.Join(mgr.sessionDir(session.ID), "spec")
+	if err := os.MkdirAll(specDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	specPath := filepath.Join(specDir, "SPEC.md")
+	if err := os.WriteFile(specPath, []byte("# Spec: Auth\n\nBody\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, _ = session.AddDecision("", "Stateless | servers", "q1")
+	_, _ = session.DefineTerm("Token", "A signed JWT", "q1")
+	if err := mgr.SaveSession(session); err != nil {
+		t.Fatalf("SaveSession: %v", err)
+	}
+
+	artifacts, err := mgr.GetSessionArtifacts(session.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for path, wants := range map[string][]string{
+		artifacts.DecisionsPath: {"# Decisions: Auth", "## d1: JWT", "**Question**: [q1] Which auth?", "Stateless | servers"},
+		artifacts.GlossaryPath:  {"# Glossary: Auth", "| Token | A signed JWT |"},
+	} {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("reading record %q: %v", path, err)
+		}
+		for _, want := range wants {
+			if !strings.Contains(string(data), want) {
+				t.Errorf("%s missing %q:\n%s", filepath.Base(path), want, data)
+			}
+		}
+	}
+
+	// Saving again keeps a single, current link section
+	_, _ = session.AddDecision("Refresh tokens", "", "")
+	if err := mgr.SaveSession(session); err != nil {
+		t.Fatalf("SaveSession: %v", err)
+	}
+	data, err := os.ReadFile(specPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	spec := string(data)
+	if strings.Count(spec, "## Planning Records") != 1 ||
+		!strings.Contains(spec, "- [Decision log](../planning/decisions.md)") ||
+		!strings.Contains(spec, "- [Glossary](../planning/glossary.md)") {
+		t.Errorf("SPEC.md links =\n%s", spec)
+	}
+
+	// Publishing points the links at the records published next to the spec
+	published, err := RenderPublishedSpec(session, spec, &Publication{Path: "docs/specs/auth.md"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Count(published, "## Planning Records") != 1 ||
+		strings.Contains(published, "../planning/") ||
+		!strings.Contains(published, "- [Decision log](auth-decisions.md)") ||
+		!strings.Contains(published, "- [Glossary](auth-glossary.md)") {
+		t.Errorf("published spec =\n%s", published)
+	}
+}
@@ -0,0 +1,251 @@
+package planner
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+)
+
+// Risk register errors
+var (
+	ErrRiskNotFound     = errors.New("risk not found")
+	ErrQuestionNotFound = errors.New("question not found")
+	ErrInvalidSeverity  = errors.New("invalid severity (use low, medium, or high)")
+)
+
+// AddRisk records a new open risk and returns it. Severity defaults to medium.
+func (s *PlanningSession) AddRisk(text, severity, mitigation string) (Risk, error) {
+	if severity == "" {
+		severity = SeverityMedium
+	}
+	switch severity {
+	case SeverityLow, SeverityMedium, SeverityHigh:
+	default:
+		return Risk{}, fmt.Errorf("%w: %q", ErrInvalidSeverity, severity)
+	}
+
+	risk := Risk{
+		ID:         fmt.Sprintf("r%d", len(s.Risks)+1),
+		Text:       text,
+		Severity:   severity,
+		Mitigation: mitigation,
+		Status:     RiskOpen,
+		RaisedAt:   time.Now(),
+	}
+	s.Risks = append(s.Risks, risk)
+	return risk, nil
+}
+
+// SetRiskStatus moves a risk to status, recording the mitigation if given.
+func (s *PlanningSession) SetRiskStatus(riskID string, status RiskStatus, mitigation string) error {
+	for i := range s.Risks {
+		if s.Risks[i].ID != riskID {
+			continue
+		}
+		s.Risks[i].Status = status
+		if mitigation != "" {
+			s.Risks[i].Mitigation = mitigation
+		}
+		if status == RiskOpen {
+			s.Risks[i].ResolvedAt = nil
+		} else {
+			now := time.Now()
+			s.Risks[i].ResolvedAt = &now
+		}
+		return nil
+	}
+	return fmt.Errorf("%w: %s", ErrRiskNotFound, riskID)
+}
+
+// OpenRisks returns risks that are still open, highest severity first.
+func (s *PlanningSession) OpenRisks() []Risk {
+	var open []Risk
+	for _, sev := range []string{SeverityHigh, SeverityMedium, SeverityLow} {
+		for _, r := range s.Risks {
+			if r.Status == RiskOpen && r.Severity == sev {
+				open = append(open, r)
+			}
+		}
+	}
+	return open
+}
+
+// DeferQuestion marks an unanswered question as deferred past handoff.
+func (s *PlanningSession) DeferQuestion(questionID, reason string) error {
+	for i := range s.Questions {
+		if s.Questions[i].ID != questionID {
+			continue
+		}
+		if s.Questions[i].Answer != "" {
+			return fmt.Errorf("question %s is already answered", questionID)
+		}
+		s.Questions[i].Deferred = true
+		s.Questions[i].DeferReason = reason
+		return nil
+	}
+	return fmt.Errorf("%w: %s", ErrQuestionNotFound, questionID)
+}
+
+// DeferredQuestions returns questions deferred past handoff.
+func (s *PlanningSession) DeferredQuestions() []Question {
+	var deferred []Question
+	for _, q := range s.Questions {
+		if q.Deferred && q.Answer == "" {
+			deferred = append(deferred, q)
+		}
+	}
+	return deferred
+}
+
+// RenderRisks renders the risk register and deferred questions as markdown.
+func RenderRisks(s *PlanningSession) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Risks: %s\n\n", s.Title)
+	fmt.Fprintf(&b, "**Session**: %s\n", s.ID)
+	fmt.Fprintf(&b, "**Updated**: %s\n\n", time.Now().Format("2006-01-02"))
+
+	b.WriteString("## Risk Register\n\n")
+	if len(s.Risks) == 0 {
+		b.WriteString("No risks recorded.\n")
+	} else {
+		b.WriteString("| ID | Severity | Status | Risk | Mitigation |\n")
+		b.WriteString("|----|----------|--------|------|------------|\n")
+		for _, r := range s.Risks {
+			fmt.Fprintf(&b, "| %s | %s | %s | %s | %s |\n",
+				r.ID, r.Severity, r.Status, markdownCell(r.Text), markdownCell(r.Mitigation))
+		}
+	}
+
+	b.WriteString("\n## Open Questions\n\n")
+	deferred := s.DeferredQuestions()
+	if len(deferred) == 0 {
+		b.WriteString("No deferred questions.\n")
+	}
+	for _, q := range deferred {
+		fmt.Fprintf(&b, "- [%s] %s", q.ID, q.Text)
+		if q.FollowUpBeadID != "" {
+			fmt.Fprintf(&b, " (follow-up: %s)", q.FollowUpBeadID)
+		}
+		b.WriteString("\n")
+		if q.DeferReason != "" {
+			fmt.Fprintf(&b, "  - Deferred: %s\n", q.DeferReason)
+		}
+	}
+
+	return b.String()
+}
+
+// markdownCell makes text safe for a single markdown table cell.
+func markdownCell(s string) string {
+	s = strings.ReplaceAll(s, "|", "\\|")
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// WriteRisks writes the session's risks.md artifact and returns its path.
+func (m *Manager) WriteRisks(session *PlanningSession) (string, error) {
+	planningDir := filepath.Join(m.sessionDir(session.ID), "planning")
+	if err := os.MkdirAll(planningDir, 0755); err != nil {
+		return "", fmt.Errorf("creating planning directory: %w", err)
+	}
+	path := filepath.Join(planningDir, "risks.md")
+	if err := os.WriteFile(path, []byte(RenderRisks(session)), 0644); err != nil {
+		return "", fmt.Errorf("writing risks.md: %w", err)
+	}
+	return path, nil
+}
+
+// Handoff marks a session handed off. Each deferred question gets a
+// follow-up bead so it isn't lost with the session, and risks.md is
+// regenerated with the bead IDs. With SliceEpics, each accepted slice gets
+// its own epic; with IntegrationBranches, each epic gets an integration
+// branch. Beads and branches already created on an earlier, interrupted
+// handoff are not duplicated. Returns the new follow-up bead IDs.
+//
+// Handoff is blocked with ErrPrerequisitePending while any spec the session
+// depends on is not yet approved, and with ErrTasksIncomplete while tasks.md
+// has tasks missing acceptance criteria or a size that are not waived.
+func (m *Manager) Handoff(session *PlanningSession) ([]string, error) {
+	if err := m.checkDependencies(session); err != nil {
+		return nil, err
+	}
+	if err := m.checkTasks(session); err != nil {
+		return nil, err
+	}
+
+	var created []string
+	var createErr error
+	for i := range session.Questions {
+		q := &session.Questions[i]
+		if !q.Deferred || q.Answer != "" || q.FollowUpBeadID != "" {
+			continue
+		}
+		bead, err := m.beads.Create(beads.CreateOptions{
+			Title:       fmt.Sprintf("Follow-up: %s", q.Text),
+			Type:        "task",
+			Priority:    2,
+			Description: followUpDescription(session, q),
+			Actor:       sessionWriter(),
+		})
+		if err != nil {
+			createErr = fmt.Errorf("creating follow-up bead for %s: %w", q.ID, err)
+			break
+		}
+		q.FollowUpBeadID = bead.ID
+		created = append(created, bead.ID)
+	}
+
+	if createErr == nil {
+		_, createErr = m.createSliceEpics(session)
+	}
+	if createErr == nil {
+		createErr = m.createIntegrationBranches(session)
+	}
+	if createErr == nil {
+		session.Status = StatusHandedOff
+	}
+	// Save even on failure so created beads are recorded and not recreated
+	if err := m.SaveSession(session); err != nil {
+		return created, err
+	}
+	if createErr != nil {
+		return created, createErr
+	}
+
+	if _, err := m.WriteRisks(session); err != nil {
+		return created, err
+	}
+
+	planner, err := m.stateManager.Load()
+	if err != nil {
+		return created, err
+	}
+	if planner.ActiveSessionID == session.ID {
+		planner.ActiveSessionID = ""
+		if err := m.stateManager.Save(planner); err != nil {
+			return created, err
+		}
+	}
+
+	return created, nil
+}
+
+func followUpDescription(session *PlanningSession, q *Question) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Deferred question from planning session %s (%s):\n\n", session.ID, session.Title)
+	fmt.Fprintf(&b, "%s\n", q.Text)
+	if q.DeferReason != "" {
+		fmt.Fprintf(&b, "\nDeferred because: %s\n", q.DeferReason)
+	}
+	if open := session.OpenRisks(); len(open) > 0 {
+		b.WriteString("\nOpen risks at handoff:\n")
+		for _, r := range open {
+			fmt.Fprintf(&b, "- [%s] %s (%s)\n", r.ID, r.Text, r.Severity)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,115 @@
+package planner
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestAddRisk(t *testing.T) {
+	s := &PlanningSession{ID: "gt-plan1"}
+
+	r1, err := s.AddRisk("Provider rate limits", "", "")
+	if err != nil {
+		t.Fatalf("AddRisk: %v", err)
+	}
+	if r1.ID != "r1" || r1.Severity != SeverityMedium || r1.Status != RiskOpen {
+		t.Errorf("unexpected risk: %+v", r1)
+	}
+
+	if _, err := s.AddRisk("Data loss", "critical", ""); !errors.Is(err, ErrInvalidSeverity) {
+		t.Errorf("expected ErrInvalidSeverity, got %v", err)
+	}
+
+	r2, _ := s.AddRisk("Data loss", SeverityHigh, "Backups")
+	if r2.ID != "r2" {
+		t.Errorf("second risk ID = %s, want r2", r2.ID)
+	}
+}
+
+func TestOpenRisks_SortedBySeverity(t *testing.T) {
+	s := &PlanningSession{}
+	_, _ = s.AddRisk("low", SeverityLow, "")
+	_, _ = s.AddRisk("high", SeverityHigh, "")
+	_, _ = s.AddRisk("medium", SeverityMedium, "")
+	_, _ = s.AddRisk("resolved", SeverityHigh, "")
+
+	if err := s.SetRiskStatus("r4", RiskMitigated, "fixed"); err != nil {
+		t.Fatalf("SetRiskStatus: %v", err)
+	}
+	if s.Risks[3].ResolvedAt == nil || s.Risks[3].Mitigation != "fixed" {
+		t.Errorf("resolved risk not updated: %+v", s.Risks[3])
+	}
+
+	var got []string
+	for _, r := range s.OpenRisks() {
+		got = append(got, r.Text)
+	}
+	if strings.Join(got, ",") != "high,medium,low" {
+		t.Errorf("OpenRisks = %v, want high,medium,low", got)
+	}
+
+	if err := s.SetRiskStatus("r9", RiskAccepted, ""); !errors.Is(err, ErrRiskNotFound) {
+		t.Errorf("expected ErrRiskNotFound, got %v", err)
+	}
+}
+
+func TestDeferQuestion(t *testing.T) {
+	s := &PlanningSession{Questions: []Question{
+		{ID: "q1", Text: "Which auth?", Answer: "JWT"},
+		{ID: "q2", Text: "Retention period?"},
+	}}
+
+	if err := s.DeferQuestion("q1", ""); err == nil {
+		t.Error("expected error deferring an answered question")
+	}
+	if err := s.DeferQuestion("q3", ""); !errors.Is(err, ErrQuestionNotFound) {
+		t.Errorf("expected ErrQuestionNotFound, got %v", err)
+	}
+	if err := s.DeferQuestion("q2", "Needs legal"); err != nil {
+		t.Fatalf("DeferQuestion: %v", err)
+	}
+
+	deferred := s.DeferredQuestions()
+	if len(deferred) != 1 || deferred[0].ID != "q2" || deferred[0].DeferReason != "Needs legal" {
+		t.Errorf("DeferredQuestions = %+v", deferred)
+	}
+}
+
+func TestWriteRisks(t *testing.T) {
+	mgr := newTestManager(t)
+	session := &PlanningSession{
+		ID:        "gt-plan3",
+		Title:     "Auth",
+		Questions: []Question{{ID: "q1", Text: "SSO later?", Deferred: true, FollowUpBeadID: "gt-fu1"}},
+	}
+	_, _ = session.AddRisk("Token | leakage", SeverityHigh, "Short TTL")
+
+	path, err := mgr.WriteRisks(session)
+	if err != nil {
+		t.Fatalf("WriteRisks: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	content := string(data)
+	for _, want := range []string{
+		"# Risks: Auth",
+		"| r1 | high | open | Token \\| leakage | Short TTL |",
+		"- [q1] SSO later? (follow-up: gt-fu1)",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("risks.md missing %q:\n%s", want, content)
+		}
+	}
+
+	artifacts, err := mgr.GetSessionArtifacts(session.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if artifacts.RisksPath != path {
+		t.Errorf("RisksPath = %q, want %q", artifacts.RisksPath, path)
+	}
+}
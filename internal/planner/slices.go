@@ -0,0 +1,382 @@
+package planner
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/mail"
+)
+
+// Slicing errors
+var (
+	ErrNoSliceProposal = errors.New("no slice proposal yet (planning/slices.md)")
+	ErrInvalidSlices   = errors.New("slice proposal is invalid")
+	ErrNoTasks         = errors.New("session has no tasks in spec/tasks.md")
+)
+
+// SliceSubjectPrefix marks slice requests mailed to the planner agent.
+const SliceSubjectPrefix = "[SLICE]"
+
+var (
+	// sliceHeadingRe matches a slice heading: "## Slice 1: Token store".
+	sliceHeadingRe = regexp.MustCompile(`^#{2,4}\s+Slice\s*(\d+)\b[\s:.)-]*(.*?)\s*$`)
+
+	// sliceTasksRe matches a slice's task list: "Tasks: T1, T3".
+	sliceTasksRe = regexp.MustCompile(`(?i)^[-*\s]*\**tasks\**\s*:\**\s*(.+)$`)
+
+	// taskIDRe matches a task ID in a slice's task list.
+	taskIDRe = regexp.MustCompile(`(?i)\bT(\d+)\b`)
+)
+
+// Slice is an independently shippable part of a spec: a group of its tasks.
+//
+// The planner agent proposes slices in planning/slices.md, one heading per
+// slice with the tasks it takes and why it ships on its own:
+//
+//	## Slice 1: Token store
+//	Tasks: T1, T2
+//	Ships storage and login without SSO.
+type Slice struct {
+	// Number is the slice's position, from 1.
+	Number int `json:"number"`
+
+	// Title is the slice heading text.
+	Title string `json:"title"`
+
+	// Tasks are the tasks.md task IDs in the slice.
+	Tasks []string `json:"tasks"`
+
+	// Rationale explains why the slice ships independently.
+	Rationale string `json:"rationale,omitempty"`
+
+	// EpicID is the epic created for the slice at handoff (SliceEpics).
+	EpicID string `json:"epic_id,omitempty"`
+
+	// IntegrationBranch is the epic's integration branch, if handoff created
+	// one (IntegrationBranches).
+	IntegrationBranch string `json:"integration_branch,omitempty"`
+}
+
+// SliceRequest records a request for the planner agent to propose slices.
+type SliceRequest struct {
+	// Into is the number of slices asked for.
+	Into int `json:"into"`
+
+	// RequestedAt is when the request was mailed.
+	RequestedAt time.Time `json:"requested_at"`
+}
+
+// ParseSlices parses the slices in a slices.md document.
+func ParseSlices(doc string) []Slice {
+	var slices []Slice
+	var cur *Slice
+	var rationale []string
+
+	flush := func() {
+		if cur != nil {
+			cur.Rationale = strings.TrimSpace(strings.Join(rationale, "\n"))
+		}
+		rationale = nil
+	}
+
+	for _, line := range strings.Split(doc, "\n") {
+		if m := sliceHeadingRe.FindStringSubmatch(line); m != nil {
+			flush()
+			n, _ := strconv.Atoi(m[1])
+			slices = append(slices, Slice{Number: n, Title: m[2]})
+			cur = &slices[len(slices)-1]
+			continue
+		}
+		if cur == nil {
+			continue
+		}
+		if headingRe.MatchString(line) {
+			// Any other heading ends the slice
+			flush()
+			cur = nil
+			continue
+		}
+		if m := sliceTasksRe.FindStringSubmatch(line); m != nil && len(cur.Tasks) == 0 {
+			for _, id := range taskIDRe.FindAllStringSubmatch(m[1], -1) {
+				cur.Tasks = append(cur.Tasks, "T"+id[1])
+			}
+			continue
+		}
+		rationale = append(rationale, line)
+	}
+	flush()
+	return slices
+}
+
+// ValidateSlices checks that slices partition tasks: slice numbers are
+// unique, every slice has tasks, every task is in exactly one slice, and no
+// slice names an unknown task.
+func ValidateSlices(slices []Slice, tasks []Task) error {
+	if len(slices) < 2 {
+		return fmt.Errorf("%w: need at least 2 slices, got %d", ErrInvalidSlices, len(slices))
+	}
+	known := make(map[string]bool, len(tasks))
+	for _, t := range tasks {
+		known[t.ID] = true
+	}
+
+	var problems []string
+	owner := make(map[string]int)
+	numbered := make(map[int]bool, len(slices))
+	for _, s := range slices {
+		if numbered[s.Number] {
+			problems = append(problems, fmt.Sprintf("slice %d is defined more than once", s.Number))
+		}
+		numbered[s.Number] = true
+		if len(s.Tasks) == 0 {
+			problems = append(problems, fmt.Sprintf("slice %d has no tasks", s.Number))
+		}
+		for _, id := range s.Tasks {
+			switch {
+			case !known[id]:
+				problems = append(problems, fmt.Sprintf("slice %d names unknown task %s", s.Number, id))
+			case owner[id] != 0:
+				problems = append(problems, fmt.Sprintf("%s is in slices %d and %d", id, owner[id], s.Number))
+			default:
+				owner[id] = s.Number
+			}
+		}
+	}
+	var unassigned []string
+	for _, t := range tasks {
+		if owner[t.ID] == 0 {
+			unassigned = append(unassigned, t.ID)
+		}
+	}
+	if len(unassigned) > 0 {
+		problems = append(problems, "not in any slice: "+strings.Join(unassigned, ", "))
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: %s", ErrInvalidSlices, strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// slicesPath returns the session's slice proposal path.
+func (m *Manager) slicesPath(sessionID string) string {
+	return filepath.Join(m.sessionDir(sessionID), "planning", "slices.md")
+}
+
+// sessionTasks reads and parses the session's tasks.md.
+func (m *Manager) sessionTasks(sessionID string) (string, []Task, error) {
+	data, err := os.ReadFile(m.tasksPath(sessionID))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil, ErrNoTasks
+		}
+		return "", nil, fmt.Errorf("reading tasks.md: %w", err)
+	}
+	tasks := ParseTasks(string(data))
+	if len(tasks) == 0 {
+		return "", nil, ErrNoTasks
+	}
+	return string(data), tasks, nil
+}
+
+// SliceMail renders a request for the planner agent to propose slices.
+func SliceMail(s *PlanningSession, into int, slicesPath string) (subject, body string) {
+	subject = fmt.Sprintf("%s Planning %s: %s", SliceSubjectPrefix, s.ID, s.Title)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Propose how to split the approved spec %q (session %s) into %d\n", s.Title, s.ID, into)
+	b.WriteString("independently shippable slices. Each slice should deliver user-visible\n")
+	b.WriteString("value on its own and must not depend on tasks in a later slice.\n\n")
+	b.WriteString("Assign every task in spec/tasks.md to exactly one slice and write the\n")
+	fmt.Fprintf(&b, "proposal to %s:\n\n", slicesPath)
+	b.WriteString("## Slice 1: <title>\n")
+	b.WriteString("Tasks: T1, T2\n")
+	b.WriteString("<why this slice ships on its own>\n\n")
+	fmt.Fprintf(&b, "The overseer reviews it with: gt planner slice %s\n", s.ID)
+	return subject, b.String()
+}
+
+// RequestSlices asks the planner agent to propose splitting an approved
+// spec into into slices, recording the request on the session.
+func (m *Manager) RequestSlices(session *PlanningSession, into int) error {
+	if into < 2 {
+		return fmt.Errorf("--into must be at least 2, got %d", into)
+	}
+	if session.Status != StatusApproved {
+		return fmt.Errorf("%w: session %s is %s", ErrNotApproved, session.ID, session.Status)
+	}
+	_, tasks, err := m.sessionTasks(session.ID)
+	if err != nil {
+		return err
+	}
+	if len(tasks) < into {
+		return fmt.Errorf("can't split %d task(s) into %d slices", len(tasks), into)
+	}
+
+	subject, body := SliceMail(session, into, m.slicesPath(session.ID))
+	msg := &mail.Message{
+		From:     OverseerAddress,
+		To:       PlannerAddress(m.rig.Name),
+		Subject:  subject,
+		Body:     body,
+		Priority: mail.PriorityNormal,
+		Type:     mail.TypeTask,
+	}
+	if err := m.router.Send(msg); err != nil {
+		return fmt.Errorf("mailing slice request: %w", err)
+	}
+
+	session.SliceRequest = &SliceRequest{Into: into, RequestedAt: time.Now()}
+	return m.SaveSession(session)
+}
+
+// LoadSliceProposal reads the planner agent's slice proposal and checks it
+// against the session's tasks.
+func (m *Manager) LoadSliceProposal(session *PlanningSession) ([]Slice, error) {
+	data, err := os.ReadFile(m.slicesPath(session.ID))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrNoSliceProposal
+		}
+		return nil, fmt.Errorf("reading slices.md: %w", err)
+	}
+	_, tasks, err := m.sessionTasks(session.ID)
+	if err != nil {
+		return nil, err
+	}
+	slices := ParseSlices(string(data))
+	return slices, ValidateSlices(slices, tasks)
+}
+
+// AcceptSlices adopts a slice proposal: each slice's tasks are written to
+// spec/slices/slice-<n>.md as its own task group, and the slices are saved
+// on the session. With epics, handoff creates an epic per slice. Returns
+// the task group files written.
+func (m *Manager) AcceptSlices(session *PlanningSession, slices []Slice, epics bool) ([]string, error) {
+	doc, tasks, err := m.sessionTasks(session.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := ValidateSlices(slices, tasks); err != nil {
+		return nil, err
+	}
+
+	dir := filepath.Join(m.sessionDir(session.ID), "spec", "slices")
+	if err := os.RemoveAll(dir); err != nil {
+		return nil, fmt.Errorf("clearing old task groups: %w", err)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("creating slices directory: %w", err)
+	}
+
+	sections := taskSections(doc, tasks)
+	var paths []string
+	for _, s := range slices {
+		path := filepath.Join(dir, fmt.Sprintf("slice-%d.md", s.Number))
+		if err := os.WriteFile(path, []byte(renderSliceTasks(session, s, sections)), 0644); err != nil {
+			return paths, fmt.Errorf("writing task group for slice %d: %w", s.Number, err)
+		}
+		paths = append(paths, path)
+	}
+
+	// Keep epics already created for slices that survive unchanged
+	existing := make(map[string]string)
+	for _, s := range session.Slices {
+		existing[sliceKey(s)] = s.EpicID
+	}
+	for i := range slices {
+		slices[i].EpicID = existing[sliceKey(slices[i])]
+	}
+
+	session.Slices = slices
+	session.SliceEpics = epics
+	return paths, m.SaveSession(session)
+}
+
+// sliceKey identifies a slice by its task set.
+func sliceKey(s Slice) string {
+	tasks := append([]string(nil), s.Tasks...)
+	sort.Strings(tasks)
+	return strings.Join(tasks, ",")
+}
+
+// taskSections returns each task's markdown from tasks.md: its heading and
+// everything up to the next heading at its level or above, as ParseTasks
+// reads it.
+func taskSections(doc string, tasks []Task) map[string]string {
+	lines := strings.Split(doc, "\n")
+	sections := make(map[string]string, len(tasks))
+	for _, t := range tasks {
+		level := len(headingRe.FindStringSubmatch(lines[t.Line-1])[1])
+		end := t.Line
+		for ; end < len(lines); end++ {
+			if h := headingRe.FindStringSubmatch(lines[end]); h != nil && len(h[1]) <= level {
+				break
+			}
+		}
+		sections[t.ID] = strings.TrimRight(strings.Join(lines[t.Line-1:end], "\n"), "\n")
+	}
+	return sections
+}
+
+// renderSliceTasks renders a slice's task group.
+func renderSliceTasks(session *PlanningSession, s Slice, sections map[string]string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Slice %d: %s\n\n", s.Number, s.Title)
+	fmt.Fprintf(&b, "Part of %s (%s).\n", session.Title, session.ID)
+	if s.Rationale != "" {
+		fmt.Fprintf(&b, "\n%s\n", s.Rationale)
+	}
+	for _, id := range s.Tasks {
+		fmt.Fprintf(&b, "\n%s\n", sections[id])
+	}
+	return b.String()
+}
+
+// createSliceEpics creates an epic for each accepted slice that doesn't
+// have one yet, returning the new epic IDs. Epics created before a failure
+// are kept on the session so a retried handoff doesn't duplicate them.
+func (m *Manager) createSliceEpics(session *PlanningSession) ([]string, error) {
+	if !session.SliceEpics {
+		return nil, nil
+	}
+	var created []string
+	for i := range session.Slices {
+		s := &session.Slices[i]
+		if s.EpicID != "" {
+			continue
+		}
+		epic, err := m.beads.Create(beads.CreateOptions{
+			Title:       fmt.Sprintf("%s: Slice %d - %s", session.Title, s.Number, s.Title),
+			Type:        "epic",
+			Priority:    2,
+			Description: sliceEpicDescription(session, s),
+			Actor:       sessionWriter(),
+		})
+		if err != nil {
+			return created, fmt.Errorf("creating epic for slice %d: %w", s.Number, err)
+		}
+		s.EpicID = epic.ID
+		created = append(created, epic.ID)
+	}
+	return created, nil
+}
+
+func sliceEpicDescription(session *PlanningSession, s *Slice) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Slice %d of planning session %s (%s).\n", s.Number, session.ID, session.Title)
+	if s.Rationale != "" {
+		fmt.Fprintf(&b, "\n%s\n", s.Rationale)
+	}
+	fmt.Fprintf(&b, "\nTasks: %s\n", strings.Join(s.Tasks, ", "))
+	fmt.Fprintf(&b, "Task group: spec/slices/slice-%d.md\n", s.Number)
+	return b.String()
+}
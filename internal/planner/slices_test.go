@@ -0,0 +1,128 @@
+package planner
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testSlicesMD = `# Slices
+
+## Slice 1: Login
+Tasks: T1, T2
+Ships password login on its own.
+
+## Slice 2 - Providers and docs
+**Tasks**: T3 and T4
+SSO follows once login is live.
+
+## Notes
+Not a slice.
+`
+
+func TestParseSlices(t *testing.T) {
+	got := ParseSlices(testSlicesMD)
+	want := []Slice{
+		{Number: 1, Title: "Login", Tasks: []string{"T1", "T2"}, Rationale: "Ships password login on its own."},
+		{Number: 2, Title: "Providers and docs", Tasks: []string{"T3", "T4"}, Rationale: "SSO follows once login is live."},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseSlices() =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestValidateSlices(t *testing.T) {
+	tasks := ParseTasks(testTasksMD)
+
+	if err := ValidateSlices(ParseSlices(testSlicesMD), tasks); err != nil {
+		t.Errorf("valid proposal: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		slices []Slice
+		want   string
+	}{
+		{"one slice", []Slice{{Number: 1, Tasks: []string{"T1", "T2", "T3", "T4"}}}, "at least 2"},
+		{"unassigned", []Slice{{Number: 1, Tasks: []string{"T1"}}, {Number: 2, Tasks: []string{"T2"}}}, "not in any slice: T3, T4"},
+		{"duplicate", []Slice{{Number: 1, Tasks: []string{"T1", "T2"}}, {Number: 2, Tasks: []string{"T2", "T3", "T4"}}}, "T2 is in slices 1 and 2"},
+		{"unknown", []Slice{{Number: 1, Tasks: []string{"T1", "T2", "T9"}}, {Number: 2, Tasks: []string{"T3", "T4"}}}, "unknown task T9"},
+		{"empty", []Slice{{Number: 1, Tasks: []string{"T1", "T2", "T3", "T4"}}, {Number: 2}}, "slice 2 has no tasks"},
+		{"repeated number", []Slice{{Number: 1, Tasks: []string{"T1", "T2"}}, {Number: 1, Tasks: []string{"T3", "T4"}}}, "slice 1 is defined more than once"},
+	}
+	for _, tt := range tests {
+		err := ValidateSlices(tt.slices, tasks)
+		if !errors.Is(err, ErrInvalidSlices) || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: error = %v, want ErrInvalidSlices mentioning %q", tt.name, err, tt.want)
+		}
+	}
+}
+
+func TestAcceptSlices(t *testing.T) {
+	mgr := newTestManager(t)
+	session := &PlanningSession{ID: "gt-plan-s", Title: "Auth", Status: StatusApproved}
+	if err := mgr.SaveSession(session); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := mgr.LoadSliceProposal(session); !errors.Is(err, ErrNoSliceProposal) {
+		t.Fatalf("LoadSliceProposal() without slices.md = %v, want ErrNoSliceProposal", err)
+	}
+	if err := mgr.RequestSlices(session, 1); err == nil {
+		t.Error("RequestSlices(1) should fail")
+	}
+	if err := mgr.RequestSlices(session, 2); !errors.Is(err, ErrNoTasks) {
+		t.Errorf("RequestSlices() without tasks.md = %v, want ErrNoTasks", err)
+	}
+
+	sessionDir := mgr.sessionDir(session.ID)
+	for path, content := range map[string]string{
+		filepath.Join(sessionDir, "spec", "tasks.md"):      testTasksMD,
+		filepath.Join(sessionDir, "planning", "slices.md"): testSlicesMD,
+	} {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	slices, err := mgr.LoadSliceProposal(session)
+	if err != nil {
+		t.Fatalf("LoadSliceProposal: %v", err)
+	}
+	paths, err := mgr.AcceptSlices(session, slices, true)
+	if err != nil {
+		t.Fatalf("AcceptSlices: %v", err)
+	}
+	if len(paths) != 2 {
+		t.Fatalf("AcceptSlices wrote %d task groups, want 2", len(paths))
+	}
+
+	group, err := os.ReadFile(paths[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"# Slice 2: Providers and docs", "### Task 3 - Spike on providers", "## T4: Docs", "README explains login"} {
+		if !strings.Contains(string(group), want) {
+			t.Errorf("slice 2 task group missing %q:\n%s", want, group)
+		}
+	}
+	for _, unwanted := range []string{"Add token store", "## Appendix"} {
+		if strings.Contains(string(group), unwanted) {
+			t.Errorf("slice 2 task group should not contain %q:\n%s", unwanted, group)
+		}
+	}
+
+	loaded, err := mgr.LoadSession(session.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loaded.Slices) != 2 || !loaded.SliceEpics {
+		t.Errorf("slices not saved: %+v (epics %v)", loaded.Slices, loaded.SliceEpics)
+	}
+}
@@ -0,0 +1,146 @@
+package planner
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/mail"
+)
+
+// DefaultMaxSessionAge is how long a session may stay in questioning or
+// reviewing before it is considered stale.
+const DefaultMaxSessionAge = 7 * 24 * time.Hour
+
+// ArchiveDirName is the directory under .specs that archived sessions are
+// moved to, out of the active listing.
+const ArchiveDirName = ".archive"
+
+// StaleSubjectPrefix marks stale-session reminder mail from the planner.
+const StaleSubjectPrefix = "[STALE]"
+
+// IsOpen reports whether the session is still being shaped (questioning or
+// reviewing).
+func (s *PlanningSession) IsOpen() bool {
+	return s.Status == StatusQuestioning || s.Status == StatusReviewing
+}
+
+// IsStale reports whether an open session has run past maxAge since it was
+// created. A zero maxAge disables the timebox.
+func (s *PlanningSession) IsStale(maxAge time.Duration, now time.Time) bool {
+	return maxAge > 0 && s.IsOpen() && now.Sub(s.CreatedAt) > maxAge
+}
+
+// StaleReminderMail renders the reminder sent to the overseer for a stale
+// session.
+func StaleReminderMail(s *PlanningSession, maxAge time.Duration) (subject, body string) {
+	subject = fmt.Sprintf("%s Planning %s: %s", StaleSubjectPrefix, s.ID, s.Title)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Planning session %s (%q) has been %s since %s, past its %s timebox.\n\n",
+		s.ID, s.Title, s.Status, s.CreatedAt.Format("2006-01-02"), formatDays(maxAge))
+	open := 0
+	for _, q := range s.Questions {
+		if q.Answer == "" && !q.Deferred {
+			open++
+		}
+	}
+	if open > 0 {
+		fmt.Fprintf(&b, "%d question(s) are still unanswered.\n\n", open)
+	}
+	b.WriteString("Answer the open questions, defer them, or cancel the session:\n\n")
+	fmt.Fprintf(&b, "  gt planner cancel %s\n", s.ID)
+	return subject, b.String()
+}
+
+// RemindStaleSessions mails the overseer once about each session that has
+// gone stale and records the reminder in the session. Recording it leaves
+// UpdatedAt alone, so a reminder doesn't reset gc's idle clock. It returns
+// the sessions reminded; on error, those reminded before it.
+func (m *Manager) RemindStaleSessions(maxAge time.Duration) ([]*PlanningSession, error) {
+	sessions, err := m.ListSessions()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var reminded []*PlanningSession
+	for _, s := range sessions {
+		if !s.IsStale(maxAge, now) || s.StaleRemindedAt != nil {
+			continue
+		}
+		subject, body := StaleReminderMail(s, maxAge)
+		msg := &mail.Message{
+			From:     PlannerAddress(m.rig.Name),
+			To:       OverseerAddress,
+			Subject:  subject,
+			Body:     body,
+			Priority: mail.PriorityNormal,
+			Type:     mail.TypeTask,
+		}
+		if err := m.router.Send(msg); err != nil {
+			return reminded, fmt.Errorf("mailing reminder for %s: %w", s.ID, err)
+		}
+		s.StaleRemindedAt = &now
+		if err := m.saveSession(s, false, false); err != nil {
+			return reminded, fmt.Errorf("saving session %s: %w", s.ID, err)
+		}
+		reminded = append(reminded, s)
+	}
+	return reminded, nil
+}
+
+// ArchiveCandidates returns the sessions GC would archive: cancelled
+// sessions, and open sessions abandoned (not updated) for at least
+// olderThan.
+func (m *Manager) ArchiveCandidates(olderThan time.Duration) ([]*PlanningSession, error) {
+	sessions, err := m.ListSessions()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var candidates []*PlanningSession
+	for _, s := range sessions {
+		if now.Sub(s.UpdatedAt) < olderThan {
+			continue
+		}
+		if s.Status == StatusCancelled || s.IsOpen() {
+			candidates = append(candidates, s)
+		}
+	}
+	return candidates, nil
+}
+
+// ArchiveSession moves a session's directory to .specs/.archive. Abandoned
+// open sessions are cancelled first so their bead is closed.
+func (m *Manager) ArchiveSession(session *PlanningSession) error {
+	if session.IsOpen() {
+		if err := m.CancelSession(session.ID); err != nil {
+			return fmt.Errorf("cancelling %s: %w", session.ID, err)
+		}
+	}
+
+	archiveDir := filepath.Join(m.specsDir(), ArchiveDirName)
+	if err := os.MkdirAll(archiveDir, 0755); err != nil {
+		return fmt.Errorf("creating archive directory: %w", err)
+	}
+	dest := filepath.Join(archiveDir, session.ID)
+	if fileExists(dest) {
+		return fmt.Errorf("archiving %s: %s already exists", session.ID, dest)
+	}
+	if err := os.Rename(m.sessionDir(session.ID), dest); err != nil {
+		return fmt.Errorf("archiving %s: %w", session.ID, err)
+	}
+	return nil
+}
+
+// formatDays renders a duration in whole days when it is one, e.g. "7d".
+func formatDays(d time.Duration) string {
+	if d > 0 && d%(24*time.Hour) == 0 {
+		return fmt.Sprintf("%dd", d/(24*time.Hour))
+	}
+	return d.String()
+}
@@ -0,0 +1,102 @@
+package planner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/util"
+)
+
+func TestIsStale(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name   string
+		status PlanningStatus
+		age    time.Duration
+		maxAge time.Duration
+		want   bool
+	}{
+		{"questioning past timebox", StatusQuestioning, 8 * 24 * time.Hour, DefaultMaxSessionAge, true},
+		{"reviewing past timebox", StatusReviewing, 8 * 24 * time.Hour, DefaultMaxSessionAge, true},
+		{"within timebox", StatusQuestioning, time.Hour, DefaultMaxSessionAge, false},
+		{"approved", StatusApproved, 30 * 24 * time.Hour, DefaultMaxSessionAge, false},
+		{"timebox disabled", StatusQuestioning, 30 * 24 * time.Hour, 0, false},
+	}
+	for _, tt := range tests {
+		s := &PlanningSession{Status: tt.status, CreatedAt: now.Add(-tt.age)}
+		if got := s.IsStale(tt.maxAge, now); got != tt.want {
+			t.Errorf("%s: IsStale = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestArchiveSessions(t *testing.T) {
+	mgr := newTestManager(t)
+	old := time.Now().Add(-40 * 24 * time.Hour)
+
+	// Write sessions directly so UpdatedAt isn't bumped by SaveSession
+	for _, s := range []*PlanningSession{
+		{ID: "gt-old-cancelled", Status: StatusCancelled, CreatedAt: old, UpdatedAt: old},
+		{ID: "gt-old-approved", Status: StatusApproved, CreatedAt: old, UpdatedAt: old},
+		{ID: "gt-new-cancelled", Status: StatusCancelled, CreatedAt: old, UpdatedAt: time.Now()},
+	} {
+		if err := os.MkdirAll(mgr.sessionDir(s.ID), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := util.AtomicWriteJSON(filepath.Join(mgr.sessionDir(s.ID), "session.json"), s); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	candidates, err := mgr.ArchiveCandidates(30 * 24 * time.Hour)
+	if err != nil {
+		t.Fatalf("ArchiveCandidates: %v", err)
+	}
+	if len(candidates) != 1 || candidates[0].ID != "gt-old-cancelled" {
+		t.Fatalf("ArchiveCandidates = %v, want [gt-old-cancelled]", candidates)
+	}
+
+	if err := mgr.ArchiveSession(candidates[0]); err != nil {
+		t.Fatalf("ArchiveSession: %v", err)
+	}
+	if !fileExists(filepath.Join(mgr.specsDir(), ArchiveDirName, "gt-old-cancelled", "session.json")) {
+		t.Error("expected session moved to the archive")
+	}
+
+	sessions, err := mgr.ListSessions()
+	if err != nil {
+		t.Fatalf("ListSessions: %v", err)
+	}
+	if len(sessions) != 2 {
+		t.Errorf("ListSessions returned %d sessions, want 2 (archived excluded)", len(sessions))
+	}
+}
+
+func TestSaveSession_UntouchedKeepsIdleClock(t *testing.T) {
+	mgr := newTestManager(t)
+	old := time.Now().Add(-40 * 24 * time.Hour)
+	s := &PlanningSession{ID: "gt-idle", Status: StatusQuestioning, CreatedAt: old, UpdatedAt: old}
+	if err := os.MkdirAll(mgr.sessionDir(s.ID), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := util.AtomicWriteJSON(filepath.Join(mgr.sessionDir(s.ID), "session.json"), s); err != nil {
+		t.Fatal(err)
+	}
+
+	// Recording a stale reminder isn't activity
+	now := time.Now()
+	s.StaleRemindedAt = &now
+	if err := mgr.saveSession(s, false, false); err != nil {
+		t.Fatalf("saveSession: %v", err)
+	}
+
+	candidates, err := mgr.ArchiveCandidates(30 * 24 * time.Hour)
+	if err != nil {
+		t.Fatalf("ArchiveCandidates: %v", err)
+	}
+	if len(candidates) != 1 || candidates[0].StaleRemindedAt == nil {
+		t.Fatalf("ArchiveCandidates = %v, want the reminded idle session", candidates)
+	}
+}
@@ -0,0 +1,267 @@
+package planner
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Task lint errors
+var (
+	ErrTasksIncomplete = errors.New("tasks.md has tasks missing acceptance criteria or size")
+	ErrTaskNotFound    = errors.New("task not found in tasks.md")
+)
+
+// TaskSizes are the accepted task size estimates, smallest first.
+var TaskSizes = []string{"XS", "S", "M", "L", "XL"}
+
+// Task lint problems.
+const (
+	MissingAcceptance = "acceptance criteria"
+	MissingSize       = "size"
+)
+
+var (
+	// taskHeadingRe matches a task heading: "## T1: Title", "### Task 3 - Title".
+	taskHeadingRe = regexp.MustCompile(`^#{2,4}\s+(?:T|Task\s*)(\d+)\b[\s:.)-]*(.*?)\s*$`)
+
+	// headingRe matches any markdown heading, with its level.
+	headingRe = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*$`)
+
+	// sizeRe matches a size line: "Size: M", "**Estimate**: XL", "- size: s".
+	sizeRe = regexp.MustCompile(`(?i)^[-*\s]*\**(?:size|estimate)\**\s*:\**\s*(\S+)`)
+
+	// acceptanceRe matches the start of an acceptance criteria block, as a
+	// label ("**Acceptance criteria**: ...") or a heading.
+	acceptanceRe = regexp.MustCompile(`(?i)^(?:#+\s+|[-*\s]*)\**acceptance(?:\s+criteria)?\**\s*(?::\**\s*(.*)|$)`)
+
+	// listItemRe matches a list item or checkbox, capturing its text.
+	listItemRe = regexp.MustCompile(`^\s*(?:[-*+]|\d+[.)])\s+(?:\[[ xX]\]\s*)?(.+)$`)
+)
+
+// Task is a task parsed from tasks.md.
+//
+// tasks.md lists each task under its own heading with a size estimate and
+// acceptance criteria:
+//
+//	## T1: Add token store
+//	Size: M
+//	Acceptance criteria:
+//	- Tokens survive a restart
+//	- Expired tokens are rejected
+type Task struct {
+	// ID is the task ID, e.g. T1.
+	ID string `json:"id"`
+
+	// Title is the task heading text.
+	Title string `json:"title"`
+
+	// Line is the 1-based line of the task heading.
+	Line int `json:"line"`
+
+	// Size is the size estimate (one of TaskSizes), or empty if missing.
+	Size string `json:"size,omitempty"`
+
+	// Acceptance lists the acceptance criteria.
+	Acceptance []string `json:"acceptance,omitempty"`
+}
+
+// ParseTasks parses the tasks in a tasks.md document. Size estimates that
+// aren't one of TaskSizes are ignored.
+func ParseTasks(doc string) []Task {
+	var tasks []Task
+	var cur *Task
+	level := 0
+	inAcceptance := false
+
+	for i, line := range strings.Split(doc, "\n") {
+		if m := taskHeadingRe.FindStringSubmatch(line); m != nil {
+			tasks = append(tasks, Task{ID: "T" + m[1], Title: m[2], Line: i + 1})
+			cur = &tasks[len(tasks)-1]
+			level = len(headingRe.FindStringSubmatch(line)[1])
+			inAcceptance = false
+			continue
+		}
+		if cur == nil {
+			continue
+		}
+
+		// A heading at the task's level or above ends the task; a deeper
+		// one ends its acceptance block unless it starts one.
+		if h := headingRe.FindStringSubmatch(line); h != nil {
+			if len(h[1]) <= level {
+				cur = nil
+				continue
+			}
+			inAcceptance = false
+		}
+
+		if m := sizeRe.FindStringSubmatch(line); m != nil {
+			cur.Size = normalizeTaskSize(m[1])
+			inAcceptance = false
+			continue
+		}
+		if m := acceptanceRe.FindStringSubmatch(line); m != nil {
+			inAcceptance = true
+			if text := strings.TrimSpace(m[1]); text != "" {
+				cur.Acceptance = append(cur.Acceptance, text)
+			}
+			continue
+		}
+		if inAcceptance {
+			if m := listItemRe.FindStringSubmatch(line); m != nil {
+				cur.Acceptance = append(cur.Acceptance, strings.TrimSpace(m[1]))
+			} else if strings.TrimSpace(line) != "" {
+				inAcceptance = false
+			}
+		}
+	}
+	return tasks
+}
+
+// normalizeTaskSize returns the size in TaskSizes matching s (or spelled
+// out, e.g. "medium"), or "".
+func normalizeTaskSize(s string) string {
+	s = strings.ToUpper(strings.Trim(s, "*_`.,()"))
+	switch s {
+	case "SMALL":
+		return "S"
+	case "MEDIUM":
+		return "M"
+	case "LARGE":
+		return "L"
+	}
+	for _, size := range TaskSizes {
+		if s == size {
+			return size
+		}
+	}
+	return ""
+}
+
+// TaskProblem is a task missing acceptance criteria or a size estimate.
+type TaskProblem struct {
+	Task
+
+	// Missing names what the task lacks (MissingAcceptance, MissingSize).
+	Missing []string `json:"missing"`
+
+	// Waiver is the reason the problem was waived, if it was.
+	Waiver string `json:"waiver,omitempty"`
+}
+
+// TaskLint is the result of linting a session's tasks.md.
+type TaskLint struct {
+	// Path is the tasks.md that was linted.
+	Path string `json:"path"`
+
+	// Tasks is the number of tasks found.
+	Tasks int `json:"tasks"`
+
+	// Problems lists the tasks with problems, waived or not.
+	Problems []TaskProblem `json:"problems,omitempty"`
+}
+
+// Blocking returns the problems that are not waived.
+func (l *TaskLint) Blocking() []TaskProblem {
+	var blocking []TaskProblem
+	for _, p := range l.Problems {
+		if p.Waiver == "" {
+			blocking = append(blocking, p)
+		}
+	}
+	return blocking
+}
+
+// LintTasks checks that every task has acceptance criteria and a size.
+// Waived tasks are reported with their waiver.
+func LintTasks(tasks []Task, waivers map[string]string) []TaskProblem {
+	var problems []TaskProblem
+	for _, t := range tasks {
+		var missing []string
+		if len(t.Acceptance) == 0 {
+			missing = append(missing, MissingAcceptance)
+		}
+		if t.Size == "" {
+			missing = append(missing, MissingSize)
+		}
+		if len(missing) > 0 {
+			problems = append(problems, TaskProblem{Task: t, Missing: missing, Waiver: waivers[t.ID]})
+		}
+	}
+	return problems
+}
+
+// tasksPath returns the session's tasks.md path.
+func (m *Manager) tasksPath(sessionID string) string {
+	return filepath.Join(m.sessionDir(sessionID), "spec", "tasks.md")
+}
+
+// LintSessionTasks lints the session's tasks.md. It returns nil if the
+// session has no tasks.md yet.
+func (m *Manager) LintSessionTasks(session *PlanningSession) (*TaskLint, error) {
+	path := m.tasksPath(session.ID)
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading tasks.md: %w", err)
+	}
+	tasks := ParseTasks(string(data))
+	return &TaskLint{
+		Path:     path,
+		Tasks:    len(tasks),
+		Problems: LintTasks(tasks, session.TaskWaivers),
+	}, nil
+}
+
+// WaiveTasks records that the given tasks may be handed off without
+// acceptance criteria or a size estimate. Each task must exist in tasks.md.
+func (m *Manager) WaiveTasks(session *PlanningSession, taskIDs []string, reason string) error {
+	if strings.TrimSpace(reason) == "" {
+		return errors.New("a waiver needs a reason")
+	}
+	data, err := os.ReadFile(m.tasksPath(session.ID))
+	if err != nil {
+		return fmt.Errorf("reading tasks.md: %w", err)
+	}
+	known := make(map[string]bool)
+	for _, t := range ParseTasks(string(data)) {
+		known[t.ID] = true
+	}
+	for _, id := range taskIDs {
+		if !known[strings.ToUpper(id)] {
+			return fmt.Errorf("%w: %s", ErrTaskNotFound, id)
+		}
+	}
+
+	if session.TaskWaivers == nil {
+		session.TaskWaivers = make(map[string]string)
+	}
+	for _, id := range taskIDs {
+		session.TaskWaivers[strings.ToUpper(id)] = reason
+	}
+	return m.SaveSession(session)
+}
+
+// checkTasks returns ErrTasksIncomplete if tasks.md has tasks missing
+// acceptance criteria or a size that are not waived.
+func (m *Manager) checkTasks(session *PlanningSession) error {
+	lint, err := m.LintSessionTasks(session)
+	if err != nil || lint == nil {
+		return err
+	}
+	blocking := lint.Blocking()
+	if len(blocking) == 0 {
+		return nil
+	}
+	ids := make([]string, 0, len(blocking))
+	for _, p := range blocking {
+		ids = append(ids, p.ID)
+	}
+	return fmt.Errorf("%w: %s", ErrTasksIncomplete, strings.Join(ids, ", "))
+}
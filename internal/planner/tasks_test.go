@@ -0,0 +1,124 @@
+package planner
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testTasksMD = `# Tasks: Auth
+
+## T1: Add token store
+Size: M
+Acceptance criteria:
+- Tokens survive a restart
+- [ ] Expired tokens are rejected
+
+Notes that are not criteria.
+
+## T2: Wire login
+**Estimate**: large
+**Acceptance**: Login returns a token
+
+### Task 3 - Spike on providers
+Some research.
+
+## T4: Docs
+- Size: XXL
+### Acceptance Criteria
+1. README explains login
+
+## Appendix
+Size: S
+`
+
+func TestParseTasks(t *testing.T) {
+	tasks := ParseTasks(testTasksMD)
+	if len(tasks) != 4 {
+		t.Fatalf("ParseTasks() found %d tasks, want 4: %+v", len(tasks), tasks)
+	}
+
+	want := []Task{
+		{ID: "T1", Title: "Add token store", Line: 3, Size: "M", Acceptance: []string{"Tokens survive a restart", "Expired tokens are rejected"}},
+		{ID: "T2", Title: "Wire login", Line: 11, Size: "L", Acceptance: []string{"Login returns a token"}},
+		{ID: "T3", Title: "Spike on providers", Line: 15},
+		{ID: "T4", Title: "Docs", Line: 18, Acceptance: []string{"README explains login"}},
+	}
+	if !reflect.DeepEqual(tasks, want) {
+		t.Errorf("ParseTasks() =\n%+v\nwant\n%+v", tasks, want)
+	}
+}
+
+func TestLintTasks(t *testing.T) {
+	problems := LintTasks(ParseTasks(testTasksMD), map[string]string{"T3": "spike"})
+	if len(problems) != 2 {
+		t.Fatalf("LintTasks() = %+v, want 2 problems", problems)
+	}
+	if problems[0].ID != "T3" || problems[0].Waiver != "spike" ||
+		!reflect.DeepEqual(problems[0].Missing, []string{MissingAcceptance, MissingSize}) {
+		t.Errorf("T3 problem = %+v", problems[0])
+	}
+	if problems[1].ID != "T4" || problems[1].Waiver != "" ||
+		!reflect.DeepEqual(problems[1].Missing, []string{MissingSize}) {
+		t.Errorf("T4 problem = %+v", problems[1])
+	}
+}
+
+func TestHandoff_BlockedByIncompleteTasks(t *testing.T) {
+	mgr := newTestManager(t)
+	session := &PlanningSession{ID: "gt-plan-t", Title: "Auth", Status: StatusApproved}
+	if err := mgr.SaveSession(session); err != nil {
+		t.Fatal(err)
+	}
+
+	lint, err := mgr.LintSessionTasks(session)
+	if err != nil || lint != nil {
+		t.Fatalf("LintSessionTasks() without tasks.md = %+v, %v; want nil, nil", lint, err)
+	}
+
+	specDir := filepath.Join(mgr.sessionDir(session.ID), "spec")
+	if err := os.MkdirAll(specDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(specDir, "tasks.md"), []byte(testTasksMD), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = mgr.Handoff(session)
+	if !errors.Is(err, ErrTasksIncomplete) {
+		t.Fatalf("expected ErrTasksIncomplete, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "T3, T4") {
+		t.Errorf("error should name incomplete tasks: %v", err)
+	}
+	if session.Status != StatusApproved {
+		t.Errorf("blocked handoff changed status to %s", session.Status)
+	}
+
+	if err := mgr.WaiveTasks(session, []string{"t9"}, "typo"); !errors.Is(err, ErrTaskNotFound) {
+		t.Errorf("WaiveTasks(unknown) error = %v, want ErrTaskNotFound", err)
+	}
+	if err := mgr.WaiveTasks(session, []string{"T3"}, ""); err == nil {
+		t.Error("WaiveTasks() without a reason should fail")
+	}
+	if err := mgr.WaiveTasks(session, []string{"t3", "T4"}, "sized during research"); err != nil {
+		t.Fatalf("WaiveTasks: %v", err)
+	}
+
+	loaded, err := mgr.LoadSession(session.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.TaskWaivers["T3"] != "sized during research" {
+		t.Errorf("waiver not saved: %v", loaded.TaskWaivers)
+	}
+	if _, err := mgr.Handoff(session); err != nil {
+		t.Fatalf("Handoff after waiver: %v", err)
+	}
+	if session.Status != StatusHandedOff {
+		t.Errorf("Status = %s, want handed_off", session.Status)
+	}
+}
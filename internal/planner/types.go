@@ -74,6 +74,13 @@ type PlanningSession struct {
 	// UpdatedAt is when the planning session was last updated.
 	UpdatedAt time.Time `json:"updated_at"`
 
+	// UpdatedBy identifies who last saved the session.
+	UpdatedBy string `json:"updated_by,omitempty"`
+
+	// Revision increments on every save and is used to detect concurrent
+	// edits (see Manager.SaveSession).
+	Revision int64 `json:"revision"`
+
 	// RawIdea is the original feature idea/request.
 	RawIdea string `json:"raw_idea,omitempty"`
 
@@ -88,6 +95,82 @@ type PlanningSession struct {
 
 	// ReviewStatus tracks the status of each review agent.
 	ReviewStatus map[string]ReviewResult `json:"review_status,omitempty"`
+
+	// Risks is the session's risk register. It is carried through handoff
+	// and rendered to planning/risks.md.
+	Risks []Risk `json:"risks,omitempty"`
+
+	// Decisions is the session's decision log, rendered to
+	// planning/decisions.md.
+	Decisions []Decision `json:"decisions,omitempty"`
+
+	// Glossary holds the domain terms collected during questioning,
+	// rendered to planning/glossary.md.
+	Glossary []GlossaryTerm `json:"glossary,omitempty"`
+
+	// Publication records where the approved spec was published, if it was.
+	Publication *Publication `json:"publication,omitempty"`
+
+	// DependsOn lists the planning sessions (specs) that must be approved
+	// before this one can be handed off.
+	DependsOn []string `json:"depends_on,omitempty"`
+
+	// TaskWaivers maps tasks.md task IDs to the reason they may be handed
+	// off without acceptance criteria or a size estimate.
+	TaskWaivers map[string]string `json:"task_waivers,omitempty"`
+
+	// StaleRemindedAt is when the overseer was reminded that the session
+	// ran past its timebox (see RemindStaleSessions).
+	StaleRemindedAt *time.Time `json:"stale_reminded_at,omitempty"`
+
+	// SliceRequest records the last request for the planner agent to
+	// propose slices (see RequestSlices).
+	SliceRequest *SliceRequest `json:"slice_request,omitempty"`
+
+	// Slices are the accepted independently shippable slices of the spec.
+	Slices []Slice `json:"slices,omitempty"`
+
+	// SliceEpics creates an epic per slice at handoff.
+	SliceEpics bool `json:"slice_epics,omitempty"`
+
+	// IntegrationBranches creates an integration branch at handoff for each
+	// of the session's epics (slice epics and the published spec's epic).
+	IntegrationBranches bool `json:"integration_branches,omitempty"`
+}
+
+// Publication records a spec published into the rig's docs tree.
+type Publication struct {
+	// Path is the published file, relative to the repo root.
+	Path string `json:"path"`
+
+	// Records are the planning records (decision log, glossary) published
+	// next to the spec and linked from it, relative to the repo root.
+	Records []string `json:"records,omitempty"`
+
+	// Branch is the branch carrying the published spec.
+	Branch string `json:"branch"`
+
+	// Target is the branch the spec is merged into.
+	Target string `json:"target"`
+
+	// Commit is the branch head after the last publish.
+	Commit string `json:"commit,omitempty"`
+
+	// MRID is the merge-request bead for the branch (if submitted).
+	MRID string `json:"mr_id,omitempty"`
+
+	// Owner is the spec owner recorded in the front-matter.
+	Owner string `json:"owner,omitempty"`
+
+	// Epic is the linked epic recorded in the front-matter.
+	Epic string `json:"epic,omitempty"`
+
+	// IntegrationBranch is Epic's integration branch, if handoff created one
+	// (IntegrationBranches).
+	IntegrationBranch string `json:"integration_branch,omitempty"`
+
+	// PublishedAt is when the spec was last published.
+	PublishedAt time.Time `json:"published_at"`
 }
 
 // Question represents a clarifying question from the planner.
@@ -106,6 +189,94 @@ type Question struct {
 
 	// AnsweredAt is when the question was answered (zero if not answered).
 	AnsweredAt *time.Time `json:"answered_at,omitempty"`
+
+	// Deferred marks a question intentionally left open until after
+	// handoff. Deferred questions become follow-up beads at handoff.
+	Deferred bool `json:"deferred,omitempty"`
+
+	// DeferReason explains why the question was deferred.
+	DeferReason string `json:"defer_reason,omitempty"`
+
+	// FollowUpBeadID is the bead created for a deferred question at handoff.
+	FollowUpBeadID string `json:"follow_up_bead_id,omitempty"`
+}
+
+// RiskStatus is the state of a risk in the risk register.
+type RiskStatus string
+
+const (
+	// RiskOpen is a risk that still needs attention.
+	RiskOpen RiskStatus = "open"
+
+	// RiskMitigated is a risk with an agreed mitigation in place.
+	RiskMitigated RiskStatus = "mitigated"
+
+	// RiskAccepted is a risk the team chose to live with.
+	RiskAccepted RiskStatus = "accepted"
+)
+
+// Risk severity levels.
+const (
+	SeverityLow    = "low"
+	SeverityMedium = "medium"
+	SeverityHigh   = "high"
+)
+
+// Risk is an entry in a planning session's risk register.
+type Risk struct {
+	// ID is the unique identifier for this risk (e.g., r1).
+	ID string `json:"id"`
+
+	// Text describes the risk.
+	Text string `json:"text"`
+
+	// Severity is low, medium, or high.
+	Severity string `json:"severity"`
+
+	// Mitigation describes how the risk is being addressed.
+	Mitigation string `json:"mitigation,omitempty"`
+
+	// Status is open, mitigated, or accepted.
+	Status RiskStatus `json:"status"`
+
+	// RaisedAt is when the risk was recorded.
+	RaisedAt time.Time `json:"raised_at"`
+
+	// ResolvedAt is when the risk left the open state.
+	ResolvedAt *time.Time `json:"resolved_at,omitempty"`
+}
+
+// Decision is an entry in a planning session's decision log.
+type Decision struct {
+	// ID is the unique identifier for this decision (e.g., d1).
+	ID string `json:"id"`
+
+	// Text states what was decided.
+	Text string `json:"text"`
+
+	// Rationale explains why.
+	Rationale string `json:"rationale,omitempty"`
+
+	// QuestionID is the question whose answer settled it, if any.
+	QuestionID string `json:"question_id,omitempty"`
+
+	// DecidedAt is when the decision was recorded.
+	DecidedAt time.Time `json:"decided_at"`
+}
+
+// GlossaryTerm is a domain term defined during a planning session.
+type GlossaryTerm struct {
+	// Term is the term as written in the spec.
+	Term string `json:"term"`
+
+	// Definition is what the term means in this domain.
+	Definition string `json:"definition"`
+
+	// QuestionID is the question it came up in, if any.
+	QuestionID string `json:"question_id,omitempty"`
+
+	// DefinedAt is when the term was last defined.
+	DefinedAt time.Time `json:"defined_at"`
 }
 
 // ReviewResult represents the result of a review agent's evaluation.
@@ -146,6 +317,15 @@ type SpecArtifacts struct {
 	// RequirementsPath is the path to requirements.md
 	RequirementsPath string `json:"requirements_path,omitempty"`
 
+	// RisksPath is the path to risks.md
+	RisksPath string `json:"risks_path,omitempty"`
+
+	// DecisionsPath is the path to decisions.md
+	DecisionsPath string `json:"decisions_path,omitempty"`
+
+	// GlossaryPath is the path to glossary.md
+	GlossaryPath string `json:"glossary_path,omitempty"`
+
 	// ProposalPath is the path to proposal.md
 	ProposalPath string `json:"proposal_path,omitempty"`
 
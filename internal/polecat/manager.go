@@ -221,7 +221,8 @@ func (m *Manager) exists(name string) bool {
 
 // AddOptions configures polecat creation.
 type AddOptions struct {
-	HookBead string // Bead ID to set as hook_bead at spawn time (atomic assignment)
+	HookBead   string // Bead ID to set as hook_bead at spawn time (atomic assignment)
+	BaseBranch string // Branch to start from instead of the rig's default (e.g. an epic's integration branch)
 }
 
 // Add creates a new polecat as a git worktree from the repo base.
@@ -285,6 +286,9 @@ func (m *Manager) AddWithOptions(name string, opts AddOptions) (*Polecat, error)
 	if rigCfg, err := rig.LoadRigConfig(m.rig.Path); err == nil && rigCfg.DefaultBranch != "" {
 		defaultBranch = rigCfg.DefaultBranch
 	}
+	if opts.BaseBranch != "" {
+		defaultBranch = opts.BaseBranch
+	}
 	startPoint := fmt.Sprintf("origin/%s", defaultBranch)
 
 	// Always create fresh branch - unique name guarantees no collision
@@ -597,6 +601,9 @@ func (m *Manager) RepairWorktreeWithOptions(name string, force bool, opts AddOpt
 	if rigCfg, err := rig.LoadRigConfig(m.rig.Path); err == nil && rigCfg.DefaultBranch != "" {
 		defaultBranch = rigCfg.DefaultBranch
 	}
+	if opts.BaseBranch != "" {
+		defaultBranch = opts.BaseBranch
+	}
 	startPoint := fmt.Sprintf("origin/%s", defaultBranch)
 
 	// Create fresh worktree with unique branch name, starting from origin's default branch
@@ -0,0 +1,185 @@
+// Package polecat provides polecat lifecycle management.
+package polecat
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/util"
+)
+
+// WorkSession records one agent session spent on a hooked bead.
+// Sessions are opened when work is slung to a polecat and closed by gt done,
+// so reviewers can reconstruct how a change was produced.
+type WorkSession struct {
+	// Agent is the agent address (e.g., "gastown/polecats/Toast").
+	Agent string `json:"agent"`
+
+	// SessionID is the runtime session ID, if known.
+	SessionID string `json:"session_id,omitempty"`
+
+	// StartedAt is when the bead was hooked to the agent.
+	StartedAt time.Time `json:"started_at"`
+
+	// EndedAt is when the agent ran gt done (nil while in progress).
+	EndedAt *time.Time `json:"ended_at,omitempty"`
+
+	// Branch is the git branch the work was done on.
+	Branch string `json:"branch,omitempty"`
+
+	// ExitType is the gt done exit status (COMPLETED, ESCALATED, etc.).
+	ExitType string `json:"exit_type,omitempty"`
+
+	// MRID is the merge request bead created on completion.
+	MRID string `json:"mr_id,omitempty"`
+
+	// FilesTouched lists files changed on the branch relative to its base.
+	FilesTouched []string `json:"files_touched,omitempty"`
+}
+
+// Duration returns how long the session ran, or 0 if it has not ended.
+func (s *WorkSession) Duration() time.Duration {
+	if s.EndedAt == nil || s.StartedAt.IsZero() {
+		return 0
+	}
+	return s.EndedAt.Sub(s.StartedAt)
+}
+
+// WorkLog is the audit trail of all sessions spent on a single bead.
+type WorkLog struct {
+	BeadID   string        `json:"bead_id"`
+	Sessions []WorkSession `json:"sessions"`
+}
+
+// WorkSessionEnd holds the details recorded when a session finishes.
+type WorkSessionEnd struct {
+	SessionID    string
+	Branch       string
+	ExitType     string
+	MRID         string
+	FilesTouched []string
+}
+
+// validateWorkLogBeadID rejects bead IDs that cannot be used as a work log
+// filename, so a crafted ID cannot escape the worklogs directory.
+func validateWorkLogBeadID(beadID string) error {
+	if beadID == "" || beadID == "." || beadID == ".." || strings.ContainsAny(beadID, `/\`) {
+		return fmt.Errorf("invalid bead ID for work log: %q", beadID)
+	}
+	return nil
+}
+
+// sameWorkAgent reports whether two agent addresses name the same agent.
+// Sling records polecats as "rig/polecats/name" while gt done sends from the
+// mail address "rig/name"; both forms refer to the same polecat.
+func sameWorkAgent(a, b string) bool {
+	return strings.Replace(a, "/polecats/", "/", 1) == strings.Replace(b, "/polecats/", "/", 1)
+}
+
+// WorkLogPath returns the path of the work log for a bead.
+// Work logs live at <townRoot>/.runtime/worklogs/<bead-id>.json.
+func WorkLogPath(townRoot, beadID string) string {
+	return filepath.Join(townRoot, constants.DirRuntime, "worklogs", beadID+".json")
+}
+
+// LoadWorkLog loads the work log for a bead.
+// Returns an empty log if none has been recorded yet.
+func LoadWorkLog(townRoot, beadID string) (*WorkLog, error) {
+	if err := validateWorkLogBeadID(beadID); err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(WorkLogPath(townRoot, beadID))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &WorkLog{BeadID: beadID}, nil
+		}
+		return nil, fmt.Errorf("reading work log: %w", err)
+	}
+
+	var log WorkLog
+	if err := json.Unmarshal(data, &log); err != nil {
+		return nil, fmt.Errorf("parsing work log: %w", err)
+	}
+	if log.BeadID == "" {
+		log.BeadID = beadID
+	}
+	return &log, nil
+}
+
+// Save writes the work log to disk.
+func (l *WorkLog) Save(townRoot string) error {
+	if err := validateWorkLogBeadID(l.BeadID); err != nil {
+		return err
+	}
+	path := WorkLogPath(townRoot, l.BeadID)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("creating worklogs directory: %w", err)
+	}
+	return util.AtomicWriteJSON(path, l)
+}
+
+// openSession returns the most recent unfinished session for agent, or nil.
+// Agent addresses are matched with or without the "polecats/" segment.
+func (l *WorkLog) openSession(agent string) *WorkSession {
+	for i := len(l.Sessions) - 1; i >= 0; i-- {
+		if sameWorkAgent(l.Sessions[i].Agent, agent) && l.Sessions[i].EndedAt == nil {
+			return &l.Sessions[i]
+		}
+	}
+	return nil
+}
+
+// StartWorkSession records that agent began working on beadID.
+// If the agent already has an open session on the bead it is left untouched,
+// so re-slinging the same work does not fragment the log.
+func StartWorkSession(townRoot, beadID, agent, branch string) error {
+	log, err := LoadWorkLog(townRoot, beadID)
+	if err != nil {
+		return err
+	}
+	if log.openSession(agent) != nil {
+		return nil
+	}
+
+	log.Sessions = append(log.Sessions, WorkSession{
+		Agent:     agent,
+		StartedAt: time.Now().UTC(),
+		Branch:    branch,
+	})
+	return log.Save(townRoot)
+}
+
+// FinishWorkSession closes the agent's open session on beadID.
+// If no session was opened (e.g., work was hooked before logging existed),
+// a session with an unknown start time is recorded instead.
+func FinishWorkSession(townRoot, beadID, agent string, end WorkSessionEnd) error {
+	log, err := LoadWorkLog(townRoot, beadID)
+	if err != nil {
+		return err
+	}
+
+	session := log.openSession(agent)
+	if session == nil {
+		log.Sessions = append(log.Sessions, WorkSession{Agent: agent})
+		session = &log.Sessions[len(log.Sessions)-1]
+	}
+
+	now := time.Now().UTC()
+	session.EndedAt = &now
+	session.ExitType = end.ExitType
+	session.MRID = end.MRID
+	session.FilesTouched = end.FilesTouched
+	if end.SessionID != "" {
+		session.SessionID = end.SessionID
+	}
+	if end.Branch != "" {
+		session.Branch = end.Branch
+	}
+
+	return log.Save(townRoot)
+}
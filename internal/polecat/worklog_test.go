@@ -0,0 +1,135 @@
+package polecat
+
+import (
+	"testing"
+)
+
+func TestWorkLog_StartAndFinish(t *testing.T) {
+	townRoot := t.TempDir()
+
+	if err := StartWorkSession(townRoot, "gt-abc", "gastown/polecats/Toast", "polecat/Toast"); err != nil {
+		t.Fatalf("StartWorkSession: %v", err)
+	}
+	// Re-slinging to the same agent must not open a second session
+	if err := StartWorkSession(townRoot, "gt-abc", "gastown/polecats/Toast", "polecat/Toast"); err != nil {
+		t.Fatalf("StartWorkSession (repeat): %v", err)
+	}
+
+	log, err := LoadWorkLog(townRoot, "gt-abc")
+	if err != nil {
+		t.Fatalf("LoadWorkLog: %v", err)
+	}
+	if len(log.Sessions) != 1 {
+		t.Fatalf("expected 1 session, got %d", len(log.Sessions))
+	}
+	if log.Sessions[0].EndedAt != nil {
+		t.Error("expected session to be open")
+	}
+
+	end := WorkSessionEnd{
+		SessionID:    "sess-1",
+		ExitType:     "COMPLETED",
+		MRID:         "gt-mr1",
+		FilesTouched: []string{"main.go", "README.md"},
+	}
+	if err := FinishWorkSession(townRoot, "gt-abc", "gastown/polecats/Toast", end); err != nil {
+		t.Fatalf("FinishWorkSession: %v", err)
+	}
+
+	log, err = LoadWorkLog(townRoot, "gt-abc")
+	if err != nil {
+		t.Fatalf("LoadWorkLog: %v", err)
+	}
+	s := log.Sessions[0]
+	if s.EndedAt == nil {
+		t.Fatal("expected session to be closed")
+	}
+	if s.Branch != "polecat/Toast" {
+		t.Errorf("Branch = %q, want polecat/Toast (preserved from start)", s.Branch)
+	}
+	if s.MRID != "gt-mr1" || s.ExitType != "COMPLETED" || s.SessionID != "sess-1" {
+		t.Errorf("unexpected session fields: %+v", s)
+	}
+	if len(s.FilesTouched) != 2 {
+		t.Errorf("FilesTouched = %v, want 2 files", s.FilesTouched)
+	}
+
+	// A new sling after completion opens a fresh session
+	if err := StartWorkSession(townRoot, "gt-abc", "gastown/polecats/Toast", ""); err != nil {
+		t.Fatalf("StartWorkSession: %v", err)
+	}
+	log, _ = LoadWorkLog(townRoot, "gt-abc")
+	if len(log.Sessions) != 2 {
+		t.Errorf("expected 2 sessions, got %d", len(log.Sessions))
+	}
+}
+
+func TestWorkLog_FinishWithoutStart(t *testing.T) {
+	townRoot := t.TempDir()
+
+	if err := FinishWorkSession(townRoot, "gt-xyz", "gastown/polecats/nux", WorkSessionEnd{Branch: "polecat/nux"}); err != nil {
+		t.Fatalf("FinishWorkSession: %v", err)
+	}
+
+	log, err := LoadWorkLog(townRoot, "gt-xyz")
+	if err != nil {
+		t.Fatalf("LoadWorkLog: %v", err)
+	}
+	if len(log.Sessions) != 1 {
+		t.Fatalf("expected 1 session, got %d", len(log.Sessions))
+	}
+	if !log.Sessions[0].StartedAt.IsZero() {
+		t.Error("expected unknown start time")
+	}
+	if log.Sessions[0].Duration() != 0 {
+		t.Error("expected zero duration for unknown start")
+	}
+}
+
+func TestLoadWorkLog_Missing(t *testing.T) {
+	log, err := LoadWorkLog(t.TempDir(), "gt-none")
+	if err != nil {
+		t.Fatalf("LoadWorkLog: %v", err)
+	}
+	if log.BeadID != "gt-none" || len(log.Sessions) != 0 {
+		t.Errorf("expected empty log, got %+v", log)
+	}
+}
+
+func TestWorkLog_FinishMatchesMailAddress(t *testing.T) {
+	townRoot := t.TempDir()
+
+	// Sling opens the session under the full polecat address...
+	if err := StartWorkSession(townRoot, "gt-abc", "gastown/polecats/Toast", "polecat/Toast"); err != nil {
+		t.Fatalf("StartWorkSession: %v", err)
+	}
+	// ...while gt done closes it using the mail address.
+	if err := FinishWorkSession(townRoot, "gt-abc", "gastown/Toast", WorkSessionEnd{ExitType: "COMPLETED"}); err != nil {
+		t.Fatalf("FinishWorkSession: %v", err)
+	}
+
+	log, err := LoadWorkLog(townRoot, "gt-abc")
+	if err != nil {
+		t.Fatalf("LoadWorkLog: %v", err)
+	}
+	if len(log.Sessions) != 1 {
+		t.Fatalf("expected 1 session, got %d", len(log.Sessions))
+	}
+	s := log.Sessions[0]
+	if s.EndedAt == nil || s.StartedAt.IsZero() {
+		t.Errorf("expected the opened session to be closed, got %+v", s)
+	}
+}
+
+func TestWorkLog_RejectsPathBeadIDs(t *testing.T) {
+	townRoot := t.TempDir()
+
+	for _, id := range []string{"", "..", "../escape", "a/b", `a\b`} {
+		if err := StartWorkSession(townRoot, id, "gastown/polecats/Toast", ""); err == nil {
+			t.Errorf("StartWorkSession(%q): expected error", id)
+		}
+		if _, err := LoadWorkLog(townRoot, id); err == nil {
+			t.Errorf("LoadWorkLog(%q): expected error", id)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package refinery
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+)
+
+// BisectResult identifies the commit that introduced a test failure.
+type BisectResult struct {
+	// Commit is the first commit on the branch whose tests fail.
+	Commit string
+
+	// Position is the 1-based index of Commit among the branch's commits.
+	Position int
+
+	// Total is the number of commits on the branch.
+	Total int
+
+	// Probes is how many test runs the bisect needed.
+	Probes int
+}
+
+// bisectFirstBad binary-searches candidates [0, n) for the first index for
+// which isBad reports true. The last index is probed first: if it passes,
+// the failure does not reproduce on the candidates and -1 is returned.
+// Everything before the range is assumed good, so it needs at most
+// 1+ceil(log2(n)) probes. Returns the index (or -1) and the probe count.
+func bisectFirstBad(n int, isBad func(i int) (bool, error)) (int, int, error) {
+	if n == 0 {
+		return -1, 0, nil
+	}
+	probes := 1
+	bad, err := isBad(n - 1)
+	if err != nil {
+		return -1, probes, err
+	}
+	if !bad {
+		return -1, probes, nil
+	}
+
+	lo, hi := 0, n-1 // hi is always known bad
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		bad, err := isBad(mid)
+		probes++
+		if err != nil {
+			return -1, probes, err
+		}
+		if bad {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+	return hi, probes, nil
+}
+
+// bisectTestFailure finds the first commit on branch (relative to target)
+// that fails the test commands. Each probe checks out the commit
+// detached in the refinery worktree; the target branch is restored afterwards.
+// Returns nil if the branch has fewer than two commits (nothing to narrow) or
+// if the branch tip passes on its own, in which case no commit is to blame.
+func (e *Engineer) bisectTestFailure(ctx context.Context, log *slog.Logger, branch, target string, testCommands []string) (*BisectResult, error) {
+	commits, err := e.git.CommitRange(target, branch)
+	if err != nil {
+		return nil, fmt.Errorf("listing branch commits: %w", err)
+	}
+	if len(commits) < 2 {
+		return nil, nil
+	}
+
+	log.Info("bisecting", "commits", len(commits), "branch", branch)
+	defer func() {
+		if err := e.git.Checkout(target); err != nil {
+			log.Warn("failed to restore target after bisect", "target", target, "err", err)
+		}
+	}()
+
+	idx, probes, err := bisectFirstBad(len(commits), func(i int) (bool, error) {
+		if err := e.git.Checkout(commits[i]); err != nil {
+			return false, fmt.Errorf("checking out %s: %w", shortSHA(commits[i]), err)
+		}
+		result := e.runTests(ctx, log, testCommands)
+		if ctx.Err() != nil {
+			return false, ctx.Err()
+		}
+		log.Debug("bisect probe", "commit", shortSHA(commits[i]),
+			"position", i+1, "total", len(commits), "result", passFail(result.Success))
+		return !result.Success, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if idx < 0 {
+		log.Info("branch tip passes tests, no commit to blame", "branch", branch, "test_runs", probes)
+		return nil, nil
+	}
+
+	return &BisectResult{
+		Commit:   commits[idx],
+		Position: idx + 1,
+		Total:    len(commits),
+		Probes:   probes,
+	}, nil
+}
+
+// shortSHA abbreviates a commit SHA for messages.
+func shortSHA(sha string) string {
+	if len(sha) > 8 {
+		return sha[:8]
+	}
+	return sha
+}
+
+func passFail(ok bool) string {
+	if ok {
+		return "pass"
+	}
+	return "fail"
+}
@@ -0,0 +1,167 @@
+package refinery
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/rig"
+)
+
+func TestBisectFirstBad(t *testing.T) {
+	tests := []struct {
+		name     string
+		n        int
+		firstBad int
+	}{
+		{"single", 1, 0},
+		{"first of many", 8, 0},
+		{"middle", 8, 3},
+		{"last", 8, 7},
+		{"odd count", 5, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, probes, err := bisectFirstBad(tt.n, func(i int) (bool, error) {
+				return i >= tt.firstBad, nil
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.firstBad {
+				t.Errorf("bisectFirstBad = %d, want %d", got, tt.firstBad)
+			}
+			if probes > 4 {
+				t.Errorf("expected at most 4 probes for n=%d, got %d", tt.n, probes)
+			}
+		})
+	}
+}
+
+func TestBisectFirstBad_TipPasses(t *testing.T) {
+	got, probes, err := bisectFirstBad(8, func(i int) (bool, error) {
+		return false, nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != -1 {
+		t.Errorf("bisectFirstBad = %d, want -1 when the tip passes", got)
+	}
+	if probes != 1 {
+		t.Errorf("expected only the tip to be probed, got %d probes", probes)
+	}
+}
+
+func runGit(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v: %v\n%s", args, err, out)
+	}
+}
+
+func TestEngineer_BisectTestFailure(t *testing.T) {
+	rigPath := t.TempDir()
+	repo := filepath.Join(rigPath, "mayor", "rig")
+	if err := os.MkdirAll(repo, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	runGit(t, repo, "init", "-b", "main")
+	runGit(t, repo, "config", "user.email", "test@test.com")
+	runGit(t, repo, "config", "user.name", "Test")
+	os.WriteFile(filepath.Join(repo, "README.md"), []byte("# test\n"), 0644)
+	runGit(t, repo, "add", ".")
+	runGit(t, repo, "commit", "-m", "initial")
+	runGit(t, repo, "checkout", "-b", "polecat/nux")
+
+	// Second of three commits breaks the "tests"
+	for _, name := range []string{"a.txt", "bad.txt", "c.txt"} {
+		os.WriteFile(filepath.Join(repo, name), []byte(name), 0644)
+		runGit(t, repo, "add", name)
+		runGit(t, repo, "commit", "-m", "add "+name)
+	}
+	runGit(t, repo, "checkout", "main")
+
+	e := NewEngineer(&rig.Rig{Name: "test-rig", Path: rigPath})
+	var out bytes.Buffer
+	e.SetOutput(&out)
+	result, err := e.bisectTestFailure(context.Background(), e.log, "polecat/nux", "main", []string{"test ! -f bad.txt"})
+	if err != nil {
+		t.Fatalf("bisectTestFailure: %v", err)
+	}
+	if result == nil {
+		t.Fatal("expected a bisect result")
+	}
+	if result.Position != 2 || result.Total != 3 {
+		t.Errorf("expected commit 2 of 3, got %d of %d\n%s", result.Position, result.Total, out.String())
+	}
+
+	// Target branch is restored after bisecting
+	branch, err := e.git.CurrentBranch()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if branch != "main" {
+		t.Errorf("expected to be back on main, got %q", branch)
+	}
+}
+
+func TestEngineer_BisectTestFailure_TipPasses(t *testing.T) {
+	rigPath := t.TempDir()
+	repo := filepath.Join(rigPath, "mayor", "rig")
+	if err := os.MkdirAll(repo, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	runGit(t, repo, "init", "-b", "main")
+	runGit(t, repo, "config", "user.email", "test@test.com")
+	runGit(t, repo, "config", "user.name", "Test")
+	os.WriteFile(filepath.Join(repo, "README.md"), []byte("# test\n"), 0644)
+	runGit(t, repo, "add", ".")
+	runGit(t, repo, "commit", "-m", "initial")
+	runGit(t, repo, "checkout", "-b", "polecat/nux")
+	for _, name := range []string{"a.txt", "b.txt"} {
+		os.WriteFile(filepath.Join(repo, name), []byte(name), 0644)
+		runGit(t, repo, "add", name)
+		runGit(t, repo, "commit", "-m", "add "+name)
+	}
+	runGit(t, repo, "checkout", "main")
+
+	// Every branch commit passes, so the failure is not the branch's fault
+	e := NewEngineer(&rig.Rig{Name: "test-rig", Path: rigPath})
+	var out bytes.Buffer
+	e.SetOutput(&out)
+	result, err := e.bisectTestFailure(context.Background(), e.log, "polecat/nux", "main", []string{"test ! -f bad.txt"})
+	if err != nil {
+		t.Fatalf("bisectTestFailure: %v", err)
+	}
+	if result != nil {
+		t.Errorf("expected no culprit when the branch tip passes, got %+v", result)
+	}
+}
+
+func TestEngineer_LoadConfig_AutoBisect(t *testing.T) {
+	tmpDir := t.TempDir()
+	config := `{"merge_queue": {"auto_bisect": true}}`
+	if err := os.WriteFile(filepath.Join(tmpDir, "config.json"), []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	e := NewEngineer(&rig.Rig{Name: "test-rig", Path: tmpDir})
+	if e.config.AutoBisect {
+		t.Error("expected AutoBisect to be false by default")
+	}
+	if err := e.LoadConfig(); err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	if !e.config.AutoBisect {
+		t.Error("expected AutoBisect to be true")
+	}
+}
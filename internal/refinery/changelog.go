@@ -0,0 +1,183 @@
+package refinery
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// DefaultChangelogPath is the changelog updated when ChangelogPath is unset.
+const DefaultChangelogPath = "CHANGELOG.md"
+
+// DefaultChangelogTemplate renders one bullet per merged MR.
+const DefaultChangelogTemplate = "- {{.Title}}{{if .SourceIssue}} ({{.SourceIssue}}){{end}}{{if .Worker}} by {{.Worker}}{{end}}"
+
+// changelogUnreleased is the heading new entries are inserted under when the
+// changelog has one; otherwise entries are appended.
+const changelogUnreleased = "## Unreleased"
+
+// ChangelogEntry is the data available to changelog templates.
+type ChangelogEntry struct {
+	MR          string // MR bead ID
+	Title       string // MR title (falls back to the branch)
+	SourceIssue string // Work item being merged
+	Worker      string // Who did the work
+	Branch      string // Source branch
+	Target      string // Target branch
+	Date        string // Merge date (YYYY-MM-DD)
+}
+
+// newChangelogEntry builds the template data for an MR.
+func newChangelogEntry(mr *MRInfo, now time.Time) ChangelogEntry {
+	title := mr.Title
+	if title == "" {
+		title = mr.Branch
+	}
+	return ChangelogEntry{
+		MR:          mr.ID,
+		Title:       title,
+		SourceIssue: mr.SourceIssue,
+		Worker:      mr.Worker,
+		Branch:      mr.Branch,
+		Target:      mr.Target,
+		Date:        now.Format("2006-01-02"),
+	}
+}
+
+// RenderChangelogEntry executes a changelog template (DefaultChangelogTemplate
+// if empty) for entry.
+func RenderChangelogEntry(tmpl string, entry ChangelogEntry) (string, error) {
+	if tmpl == "" {
+		tmpl = DefaultChangelogTemplate
+	}
+	t, err := template.New("changelog").Option("missingkey=error").Parse(tmpl)
+	if err != nil {
+		return "", fmt.Errorf("parsing changelog template: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, entry); err != nil {
+		return "", fmt.Errorf("rendering changelog template: %w", err)
+	}
+	return strings.TrimRight(buf.String(), "\n"), nil
+}
+
+// writeChangelog records text in the changelog at path (relative to
+// workDir) and returns the file it wrote, relative to workDir. A path ending
+// in "/" or naming an existing directory is a fragment directory: each entry
+// becomes its own file named after the source issue (or MR/branch), which
+// avoids merge conflicts between concurrent MRs. Otherwise the entry is
+// inserted under the "## Unreleased" heading, or appended if there is none.
+func writeChangelog(workDir, path, text string, entry ChangelogEntry) (string, error) {
+	if path == "" {
+		path = DefaultChangelogPath
+	}
+	full := filepath.Join(workDir, path)
+
+	if strings.HasSuffix(path, "/") || isDir(full) {
+		if err := os.MkdirAll(full, 0755); err != nil {
+			return "", fmt.Errorf("creating changelog directory: %w", err)
+		}
+		name := entry.SourceIssue
+		if name == "" {
+			name = entry.MR
+		}
+		if name == "" {
+			name = entry.Branch
+		}
+		rel := filepath.Join(path, changelogFragmentName(name)+".md")
+		if err := os.WriteFile(filepath.Join(workDir, rel), []byte(text+"\n"), 0644); err != nil {
+			return "", fmt.Errorf("writing changelog fragment: %w", err)
+		}
+		return rel, nil
+	}
+
+	existing, err := os.ReadFile(full)
+	if err != nil && !os.IsNotExist(err) {
+		return "", fmt.Errorf("reading changelog: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+		return "", fmt.Errorf("creating changelog directory: %w", err)
+	}
+	if err := os.WriteFile(full, []byte(insertChangelogEntry(string(existing), text)), 0644); err != nil {
+		return "", fmt.Errorf("writing changelog: %w", err)
+	}
+	return path, nil
+}
+
+// insertChangelogEntry adds text below the Unreleased heading's existing
+// entries, or at the end of content if there is no such heading.
+func insertChangelogEntry(content, text string) string {
+	lines := strings.Split(strings.TrimRight(content, "\n"), "\n")
+	if content == "" {
+		lines = nil
+	}
+
+	for i, line := range lines {
+		if !strings.EqualFold(strings.TrimSpace(line), changelogUnreleased) {
+			continue
+		}
+		// Insert after the last non-blank line before the next heading
+		at := i + 1
+		for j := i + 1; j < len(lines) && !strings.HasPrefix(lines[j], "#"); j++ {
+			if strings.TrimSpace(lines[j]) != "" {
+				at = j + 1
+			}
+		}
+		if at == i+1 {
+			// Keep a blank line between the heading and its first entry
+			text = "\n" + text
+		}
+		out := append([]string{}, lines[:at]...)
+		out = append(out, text)
+		out = append(out, lines[at:]...)
+		return strings.Join(out, "\n") + "\n"
+	}
+
+	if len(lines) == 0 {
+		return text + "\n"
+	}
+	return strings.Join(lines, "\n") + "\n" + text + "\n"
+}
+
+func changelogFragmentName(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+			return r
+		default:
+			return '-'
+		}
+	}, s)
+}
+
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
+
+// updateChangelog writes the changelog entry for mr and folds it into the
+// merge commit just created, so the entry lands atomically with the merge.
+func (e *Engineer) updateChangelog(log *slog.Logger, mr *MRInfo) error {
+	entry := newChangelogEntry(mr, time.Now())
+	text, err := RenderChangelogEntry(e.config.ChangelogTemplate, entry)
+	if err != nil {
+		return err
+	}
+	rel, err := writeChangelog(e.workDir, e.config.ChangelogPath, text, entry)
+	if err != nil {
+		return err
+	}
+	if err := e.git.Add(rel); err != nil {
+		return fmt.Errorf("staging changelog: %w", err)
+	}
+	if err := e.git.CommitAmend(); err != nil {
+		return fmt.Errorf("amending merge commit: %w", err)
+	}
+	log.Info("updated changelog", "path", rel)
+	return nil
+}
@@ -0,0 +1,157 @@
+package refinery
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/rig"
+)
+
+func TestRenderChangelogEntry(t *testing.T) {
+	entry := newChangelogEntry(&MRInfo{
+		ID:          "gt-mr1",
+		Title:       "Add widgets",
+		SourceIssue: "gt-abc",
+		Worker:      "polecat/nux",
+		Branch:      "polecat/nux/gt-abc",
+	}, time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC))
+
+	got, err := RenderChangelogEntry("", entry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "- Add widgets (gt-abc) by polecat/nux"; got != want {
+		t.Errorf("default template = %q, want %q", got, want)
+	}
+
+	got, err = RenderChangelogEntry("* {{.Date}} {{.Title}} [{{.MR}}]", entry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "* 2026-03-04 Add widgets [gt-mr1]"; got != want {
+		t.Errorf("custom template = %q, want %q", got, want)
+	}
+
+	if _, err := RenderChangelogEntry("{{.Nope}}", entry); err == nil {
+		t.Error("expected error for unknown field")
+	}
+}
+
+func TestNewChangelogEntry_TitleFallback(t *testing.T) {
+	entry := newChangelogEntry(&MRInfo{Branch: "polecat/nux/gt-abc"}, time.Now())
+	if entry.Title != "polecat/nux/gt-abc" {
+		t.Errorf("Title = %q, want branch fallback", entry.Title)
+	}
+}
+
+func TestInsertChangelogEntry(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name:    "empty",
+			content: "",
+			want:    "- new\n",
+		},
+		{
+			name:    "no unreleased heading",
+			content: "# Changelog\n\n- old\n",
+			want:    "# Changelog\n\n- old\n- new\n",
+		},
+		{
+			name:    "empty unreleased section",
+			content: "# Changelog\n\n## Unreleased\n\n## 1.0.0\n\n- old\n",
+			want:    "# Changelog\n\n## Unreleased\n\n- new\n\n## 1.0.0\n\n- old\n",
+		},
+		{
+			name:    "unreleased with entries",
+			content: "## Unreleased\n\n- first\n\n## 1.0.0\n- old\n",
+			want:    "## Unreleased\n\n- first\n- new\n\n## 1.0.0\n- old\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := insertChangelogEntry(tt.content, "- new"); got != tt.want {
+				t.Errorf("got:\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteChangelog_Fragments(t *testing.T) {
+	dir := t.TempDir()
+	entry := ChangelogEntry{SourceIssue: "gt-abc", Branch: "polecat/nux"}
+
+	rel, err := writeChangelog(dir, "changelog.d/", "- entry", entry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rel != filepath.Join("changelog.d", "gt-abc.md") {
+		t.Errorf("fragment path = %q", rel)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, rel))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "- entry\n" {
+		t.Errorf("fragment content = %q", data)
+	}
+
+	// An existing directory is a fragment dir even without the trailing slash
+	rel, err = writeChangelog(dir, "changelog.d", "- other", ChangelogEntry{Branch: "polecat/furiosa"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rel != filepath.Join("changelog.d", "polecat-furiosa.md") {
+		t.Errorf("fragment path = %q", rel)
+	}
+}
+
+func TestWriteChangelog_File(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "CHANGELOG.md"), []byte("## Unreleased\n\n## 1.0.0\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	rel, err := writeChangelog(dir, "", "- entry", ChangelogEntry{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rel != DefaultChangelogPath {
+		t.Errorf("path = %q, want %q", rel, DefaultChangelogPath)
+	}
+	data, _ := os.ReadFile(filepath.Join(dir, rel))
+	if !strings.Contains(string(data), "## Unreleased\n\n- entry\n") {
+		t.Errorf("entry not under Unreleased:\n%s", data)
+	}
+}
+
+func TestEngineer_LoadConfig_Changelog(t *testing.T) {
+	tmpDir := t.TempDir()
+	config := `{"merge_queue": {"changelog": true, "changelog_path": "changes/", "changelog_template": "- {{.Title}}"}}`
+	if err := os.WriteFile(filepath.Join(tmpDir, "config.json"), []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	e := NewEngineer(&rig.Rig{Name: "test-rig", Path: tmpDir})
+	if err := e.LoadConfig(); err != nil {
+		t.Fatal(err)
+	}
+	if !e.config.Changelog || e.config.ChangelogPath != "changes/" || e.config.ChangelogTemplate != "- {{.Title}}" {
+		t.Errorf("changelog config not loaded: %+v", e.config)
+	}
+
+	bad := `{"merge_queue": {"changelog_template": "{{.Title"}}`
+	if err := os.WriteFile(filepath.Join(tmpDir, "config.json"), []byte(bad), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := e.LoadConfig(); err == nil {
+		t.Error("expected error for invalid changelog_template")
+	}
+}
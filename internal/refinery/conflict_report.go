@@ -0,0 +1,161 @@
+package refinery
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+)
+
+// ConflictReportFileName is the name of the report written for a conflicted MR.
+const ConflictReportFileName = "conflict-report.md"
+
+// maxMergeTreeLines caps the git merge-tree output kept in a report, so a
+// conflict across many files doesn't flood the failure mail.
+const maxMergeTreeLines = 200
+
+// ConflictReport explains a merge conflict: what collided and which commits
+// on each side touched the conflicting files. It is attached to the failure
+// mail, the MR bead and the conflict-resolution task so the worker resolving
+// it doesn't have to re-derive the collision.
+type ConflictReport struct {
+	MR     string
+	Branch string
+	Target string
+
+	// MergeBase is the common ancestor of Branch and Target.
+	MergeBase string
+
+	// Files are the conflicting files.
+	Files []string
+
+	// BranchCommits and TargetCommits are the commits on each side since
+	// MergeBase that touch Files, newest first, as one-line summaries.
+	BranchCommits []string
+	TargetCommits []string
+
+	// MergeTree is the raw output of git merge-tree --write-tree for the
+	// merge, possibly truncated to maxMergeTreeLines.
+	MergeTree string
+
+	GeneratedAt time.Time
+}
+
+// ConflictReportPath returns where the conflict report for an MR is kept in
+// the rig's .runtime directory.
+func ConflictReportPath(rigPath, mrID string) string {
+	return filepath.Join(rigPath, ".runtime", "conflicts", mrID, ConflictReportFileName)
+}
+
+// buildConflictReport collects the conflict report for merging branch into
+// target. files are the conflicting files found by the test merge; git
+// failures leave the affected sections empty rather than failing the report.
+func (e *Engineer) buildConflictReport(log *slog.Logger, mr *MRInfo, branch, target string, files []string) *ConflictReport {
+	report := &ConflictReport{
+		MR:          mr.ID,
+		Branch:      branch,
+		Target:      target,
+		Files:       files,
+		GeneratedAt: time.Now().UTC(),
+	}
+
+	base, err := e.git.MergeBase(target, branch)
+	if err != nil {
+		log.Warn("conflict report: merge-base failed", "err", err)
+	} else {
+		report.MergeBase = base
+		if report.BranchCommits, err = e.git.LogOneline(base, branch, files...); err != nil {
+			log.Warn("conflict report: listing branch commits failed", "err", err)
+		}
+		if report.TargetCommits, err = e.git.LogOneline(base, target, files...); err != nil {
+			log.Warn("conflict report: listing target commits failed", "err", err)
+		}
+	}
+
+	out, _, err := e.git.MergeTree(target, branch)
+	if err != nil {
+		log.Warn("conflict report: merge-tree failed", "err", err)
+	} else {
+		report.MergeTree = truncateLines(out, maxMergeTreeLines)
+	}
+	return report
+}
+
+// truncateLines keeps the first n lines of s, noting how many were dropped.
+func truncateLines(s string, n int) string {
+	lines := strings.Split(s, "\n")
+	if len(lines) <= n {
+		return s
+	}
+	return strings.Join(lines[:n], "\n") + fmt.Sprintf("\n... (%d more lines)", len(lines)-n)
+}
+
+// Markdown renders the report.
+func (r *ConflictReport) Markdown() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# Conflict report: %s into %s\n\n", r.Branch, r.Target)
+	if r.MR != "" {
+		fmt.Fprintf(&sb, "- MR: %s\n", r.MR)
+	}
+	if r.MergeBase != "" {
+		fmt.Fprintf(&sb, "- Merge base: %s\n", r.MergeBase)
+	}
+	fmt.Fprintf(&sb, "- Generated: %s\n", r.GeneratedAt.Format(time.RFC3339))
+
+	sb.WriteString("\n## Conflicting files\n\n")
+	writeMarkdownList(&sb, r.Files, "`%s`", "(none reported)")
+
+	fmt.Fprintf(&sb, "\n## Commits on %s touching these files\n\n", r.Branch)
+	writeMarkdownList(&sb, r.BranchCommits, "%s", "(none)")
+
+	fmt.Fprintf(&sb, "\n## Commits on %s touching these files\n\n", r.Target)
+	writeMarkdownList(&sb, r.TargetCommits, "%s", "(none)")
+
+	if r.MergeTree != "" {
+		fmt.Fprintf(&sb, "\n## git merge-tree --write-tree %s %s\n\n```\n%s\n```\n", r.Target, r.Branch, r.MergeTree)
+	}
+	return sb.String()
+}
+
+func writeMarkdownList(sb *strings.Builder, items []string, format, empty string) {
+	if len(items) == 0 {
+		sb.WriteString(empty + "\n")
+		return
+	}
+	for _, item := range items {
+		fmt.Fprintf(sb, "- "+format+"\n", item)
+	}
+}
+
+// attachConflictReport writes a conflicted MR's report to the rig's .runtime
+// directory and records its path on the MR bead. Returns the report's path,
+// or "" if it couldn't be written.
+func (e *Engineer) attachConflictReport(log *slog.Logger, report *ConflictReport) string {
+	path := ConflictReportPath(e.rig.Path, report.MR)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		log.Warn("failed to write conflict report", "err", err)
+		return ""
+	}
+	if err := os.WriteFile(path, []byte(report.Markdown()), 0644); err != nil { //nolint:gosec // G306: report is not sensitive
+		log.Warn("failed to write conflict report", "err", err)
+		return ""
+	}
+
+	issue, err := e.beads.Show(report.MR)
+	if err == nil {
+		if fields := beads.ParseMRFields(issue); fields != nil {
+			fields.ConflictReport = path
+			desc := beads.SetMRFields(issue, fields)
+			err = e.beads.Update(report.MR, beads.UpdateOptions{Description: &desc})
+		}
+	}
+	if err != nil {
+		log.Warn("failed to record conflict report on MR", "err", err)
+	}
+	log.Info("wrote conflict report", "path", path, "files", len(report.Files))
+	return path
+}
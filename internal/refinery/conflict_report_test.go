@@ -0,0 +1,78 @@
+package refinery
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/rig"
+)
+
+func TestEngineer_BuildConflictReport(t *testing.T) {
+	rigPath := t.TempDir()
+	repo := filepath.Join(rigPath, "mayor", "rig")
+	if err := os.MkdirAll(repo, 0755); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, repo, "init", "-b", "main")
+	runGit(t, repo, "config", "user.email", "test@test.com")
+	runGit(t, repo, "config", "user.name", "Test")
+	os.WriteFile(filepath.Join(repo, "shared.txt"), []byte("base\n"), 0644)
+	os.WriteFile(filepath.Join(repo, "other.txt"), []byte("base\n"), 0644)
+	runGit(t, repo, "add", ".")
+	runGit(t, repo, "commit", "-m", "initial")
+
+	runGit(t, repo, "checkout", "-b", "polecat/nux")
+	os.WriteFile(filepath.Join(repo, "shared.txt"), []byte("branch\n"), 0644)
+	runGit(t, repo, "commit", "-am", "branch edits shared")
+	os.WriteFile(filepath.Join(repo, "other.txt"), []byte("branch\n"), 0644)
+	runGit(t, repo, "commit", "-am", "branch edits other")
+
+	runGit(t, repo, "checkout", "main")
+	os.WriteFile(filepath.Join(repo, "shared.txt"), []byte("main\n"), 0644)
+	runGit(t, repo, "commit", "-am", "main edits shared")
+
+	e := NewEngineer(&rig.Rig{Name: "test-rig", Path: rigPath})
+	e.SetOutput(&bytes.Buffer{})
+
+	conflicts, err := e.git.CheckConflicts("polecat/nux", "main")
+	if err != nil {
+		t.Fatalf("CheckConflicts: %v", err)
+	}
+	report := e.buildConflictReport(e.log, &MRInfo{ID: "gt-mr1"}, "polecat/nux", "main", conflicts)
+
+	if len(report.Files) != 1 || report.Files[0] != "shared.txt" {
+		t.Errorf("Files = %v, want [shared.txt]", report.Files)
+	}
+	if report.MergeBase == "" {
+		t.Error("expected a merge base")
+	}
+	// Only commits touching the conflicting files are listed
+	if len(report.BranchCommits) != 1 || !strings.Contains(report.BranchCommits[0], "branch edits shared") {
+		t.Errorf("BranchCommits = %v", report.BranchCommits)
+	}
+	if len(report.TargetCommits) != 1 || !strings.Contains(report.TargetCommits[0], "main edits shared") {
+		t.Errorf("TargetCommits = %v", report.TargetCommits)
+	}
+	if !strings.Contains(report.MergeTree, "CONFLICT") {
+		t.Errorf("expected merge-tree conflict messages, got:\n%s", report.MergeTree)
+	}
+
+	md := report.Markdown()
+	for _, want := range []string{"# Conflict report: polecat/nux into main", "- `shared.txt`", "main edits shared", "```"} {
+		if !strings.Contains(md, want) {
+			t.Errorf("Markdown missing %q:\n%s", want, md)
+		}
+	}
+}
+
+func TestTruncateLines(t *testing.T) {
+	if got := truncateLines("a\nb", 2); got != "a\nb" {
+		t.Errorf("truncateLines under limit = %q", got)
+	}
+	if got := truncateLines("a\nb\nc\nd", 2); got != "a\nb\n... (2 more lines)" {
+		t.Errorf("truncateLines over limit = %q", got)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"log/slog"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -28,6 +29,20 @@ type MergeQueueConfig struct {
 	// TargetBranch is the default branch to merge to (e.g., "main").
 	TargetBranch string `json:"target_branch"`
 
+	// Remote is the remote the target branch is pulled from and pushed to,
+	// and where MR branches live. Default: DefaultRemote.
+	Remote string `json:"remote"`
+
+	// PushRemotes are secondary remotes (e.g., mirrors) the target branch is
+	// also pushed to after each merge. A failed mirror push is logged but
+	// does not fail the merge.
+	PushRemotes []string `json:"push_remotes"`
+
+	// CredentialHelper is the git credential helper used for fetches and
+	// pushes (e.g., "store" or "!gh auth git-credential"), overriding the
+	// repo's own. Empty uses the repo's git config.
+	CredentialHelper string `json:"credential_helper"`
+
 	// IntegrationBranches enables per-epic integration branches.
 	IntegrationBranches bool `json:"integration_branches"`
 
@@ -40,6 +55,12 @@ type MergeQueueConfig struct {
 	// TestCommand is the command to run for testing.
 	TestCommand string `json:"test_command"`
 
+	// TestRules is a rules file (see TestRules), relative to the repo root,
+	// mapping changed paths to test commands. When set, only the commands
+	// matching an MR's changed files run; TestCommand is the fallback when
+	// none match. Empty always runs TestCommand.
+	TestRules string `json:"test_rules"`
+
 	// DeleteMergedBranches controls whether to delete branches after merge.
 	DeleteMergedBranches bool `json:"delete_merged_branches"`
 
@@ -49,23 +70,124 @@ type MergeQueueConfig struct {
 	// PollInterval is how often to check for new MRs.
 	PollInterval time.Duration `json:"poll_interval"`
 
+	// TriggerMode is TriggerPoll, TriggerFile, or TriggerHTTP. The event
+	// modes wake the engineer as soon as gt mq submit announces an MR.
+	// Default: poll.
+	TriggerMode string `json:"trigger_mode"`
+
+	// TriggerAddr is the local address listened on in TriggerHTTP mode.
+	// Default: DefaultTriggerAddr.
+	TriggerAddr string `json:"trigger_addr"`
+
+	// FallbackPollInterval is how often the queue is checked anyway in the
+	// event trigger modes, in case an event was missed.
+	FallbackPollInterval time.Duration `json:"fallback_poll_interval"`
+
 	// MaxConcurrent is the maximum number of MRs to process concurrently.
 	MaxConcurrent int `json:"max_concurrent"`
+
+	// RequireUpToDate rejects MR branches that do not contain the current
+	// target HEAD (within MaxCommitsBehind). Stale branches are assigned back
+	// to the worker instead of being merged.
+	RequireUpToDate bool `json:"require_up_to_date"`
+
+	// MaxCommitsBehind is how many target commits a branch may be missing
+	// when RequireUpToDate is set. 0 means the branch must contain target HEAD.
+	MaxCommitsBehind int `json:"max_commits_behind"`
+
+	// AutoBisect bisects a test failure across the MR's commits so the
+	// worker is told which commit broke the tests. Only the MR's own
+	// commits are searched: MRs merge one at a time, so there is no batch
+	// of MRs to narrow. Bisecting across MRs needs speculative batching
+	// first and is tracked as follow-up work.
+	AutoBisect bool `json:"auto_bisect"`
+
+	// Changelog appends an entry for each merged MR to ChangelogPath,
+	// committed as part of the merge.
+	Changelog bool `json:"changelog"`
+
+	// ChangelogPath is the changelog file relative to the repo root, or a
+	// fragment directory (one file per MR) if it ends in "/" or is an
+	// existing directory. Default: CHANGELOG.md.
+	ChangelogPath string `json:"changelog_path"`
+
+	// ChangelogTemplate is a text/template for the entry, with fields
+	// .Title, .SourceIssue, .Worker, .Branch, .Target, .MR and .Date.
+	// Default: DefaultChangelogTemplate.
+	ChangelogTemplate string `json:"changelog_template"`
+
+	// SignCommits signs merge commits and verifies the signature before
+	// pushing, for targets with a signed-commit policy.
+	SignCommits bool `json:"sign_commits"`
+
+	// SigningFormat is "gpg" or "ssh". Default: gpg.
+	SigningFormat string `json:"signing_format"`
+
+	// SigningKey selects the key: a GPG key ID or an SSH key path (or
+	// "key::" literal). Empty uses the repo's user.signingkey.
+	SigningKey string `json:"signing_key"`
+
+	// RiskApprovalThreshold holds MRs whose risk score (0-100) reaches it
+	// for manual approval: they are assigned to the overseer until approved
+	// with gt mq approve. 0 disables the gate.
+	RiskApprovalThreshold int `json:"risk_approval_threshold"`
+
+	// PostMergeCheck is a health check command run against the target
+	// branch after each merge (see CheckPostMerge). Empty disables it.
+	PostMergeCheck string `json:"post_merge_check"`
+
+	// PostMergeCheckTimeout bounds PostMergeCheck. Default: 10m.
+	PostMergeCheckTimeout time.Duration `json:"post_merge_check_timeout"`
+
+	// AutoRevert reverts a merge through the queue (gt mq revert) when
+	// PostMergeCheck fails on it.
+	AutoRevert bool `json:"auto_revert"`
+
+	// MaxMergesPerEpicPerDay caps how many MRs of one epic (its integration
+	// branch, or the source issue's parent) merge in any EpicQuotaWindow.
+	// MRs over the quota wait in the queue. 0 disables the quota.
+	MaxMergesPerEpicPerDay int `json:"max_merges_per_epic_per_day"`
+
+	// WorkerCooldown is the minimum time between two merges from the same
+	// worker. 0 disables the cooldown.
+	WorkerCooldown time.Duration `json:"worker_cooldown"`
+
+	// MinFreeDiskMB is the free space the refinery checkout's filesystem
+	// needs before an MR is processed (see checkHealth). 0 disables the
+	// disk check. Default: DefaultMinFreeDiskMB.
+	MinFreeDiskMB int `json:"min_free_disk_mb"`
+
+	// FailureAssignment picks who gets the rework for a failed MR, per
+	// failure kind (FailureKindConflict, FailureKindTests, FailureKindMerge).
+	// Kinds not listed go back to the worker.
+	FailureAssignment map[string]FailureAssignment `json:"failure_assignment"`
+
+	// LogFormat is LogFormatText or LogFormatJSON. Default: text.
+	LogFormat string `json:"log_format"`
+
+	// LogLevel is debug, info, warn, or error. Default: info.
+	LogLevel string `json:"log_level"`
 }
 
 // DefaultMergeQueueConfig returns sensible defaults for merge queue configuration.
 func DefaultMergeQueueConfig() *MergeQueueConfig {
 	return &MergeQueueConfig{
-		Enabled:              true,
-		TargetBranch:         "main",
-		IntegrationBranches:  true,
-		OnConflict:           "assign_back",
-		RunTests:             true,
-		TestCommand:          "",
-		DeleteMergedBranches: true,
-		RetryFlakyTests:      1,
-		PollInterval:         30 * time.Second,
-		MaxConcurrent:        1,
+		Enabled:               true,
+		TargetBranch:          "main",
+		Remote:                DefaultRemote,
+		IntegrationBranches:   true,
+		OnConflict:            "assign_back",
+		RunTests:              true,
+		TestCommand:           "",
+		DeleteMergedBranches:  true,
+		RetryFlakyTests:       1,
+		PollInterval:          30 * time.Second,
+		TriggerMode:           TriggerPoll,
+		TriggerAddr:           DefaultTriggerAddr,
+		FallbackPollInterval:  5 * time.Minute,
+		MaxConcurrent:         1,
+		PostMergeCheckTimeout: 10 * time.Minute,
+		MinFreeDiskMB:         DefaultMinFreeDiskMB,
 	}
 }
 
@@ -86,17 +208,22 @@ type MRInfo struct {
 	ConvoyCreatedAt *time.Time // Convoy creation time
 	CreatedAt       time.Time  // MR creation time
 	BlockedBy       string     // Task ID blocking this MR
+	RiskApprovedBy  string     // Who approved merging despite a high risk score
+	Reverts         string     // Merge commit this MR reverts (gt mq revert)
+	StackedOn       string     // MR this MR's branch builds on (see stack.go)
+	CorrelationID   string     // Ties together log lines from one processing attempt
 }
 
-// Engineer is the merge queue processor that polls for ready merge-requests
-// and processes them according to the merge queue design.
+// Engineer is the merge queue processor that polls (or is triggered) for
+// ready merge-requests and processes them according to the merge queue design.
 type Engineer struct {
 	rig     *rig.Rig
 	beads   *beads.Beads
 	git     *git.Git
 	config  *MergeQueueConfig
 	workDir string
-	output  io.Writer    // Output destination for user-facing messages
+	output  io.Writer    // Output destination for log lines
+	log     *slog.Logger // Structured logger writing to output
 	router  *mail.Router // Mail router for sending protocol messages
 
 	// stopCh is used for graceful shutdown
@@ -124,15 +251,25 @@ func NewEngineer(r *rig.Rig) *Engineer {
 		config:  cfg,
 		workDir: gitDir,
 		output:  os.Stdout,
+		log:     slog.New(slog.NewTextHandler(os.Stdout, nil)),
 		router:  mail.NewRouter(r.Path),
 		stopCh:  make(chan struct{}),
 	}
 }
 
-// SetOutput sets the output writer for user-facing messages.
-// This is useful for testing or redirecting output.
+// SetOutput sets the output writer for log lines, keeping the configured
+// log format and level. This is useful for testing or redirecting output.
 func (e *Engineer) SetOutput(w io.Writer) {
 	e.output = w
+	if log, err := NewLogger(w, e.config.LogFormat, e.config.LogLevel); err == nil {
+		e.log = log
+	}
+}
+
+// SetLogger replaces the engineer's logger, e.g. to add attributes or send
+// logs to a custom handler.
+func (e *Engineer) SetLogger(log *slog.Logger) {
+	e.log = log
 }
 
 // LoadConfig loads merge queue configuration from the rig's config.json.
@@ -163,16 +300,42 @@ func (e *Engineer) LoadConfig() error {
 	// Parse merge_queue section into our config struct
 	// We need special handling for poll_interval (string -> Duration)
 	var mqRaw struct {
-		Enabled              *bool   `json:"enabled"`
-		TargetBranch         *string `json:"target_branch"`
-		IntegrationBranches  *bool   `json:"integration_branches"`
-		OnConflict           *string `json:"on_conflict"`
-		RunTests             *bool   `json:"run_tests"`
-		TestCommand          *string `json:"test_command"`
-		DeleteMergedBranches *bool   `json:"delete_merged_branches"`
-		RetryFlakyTests      *int    `json:"retry_flaky_tests"`
-		PollInterval         *string `json:"poll_interval"`
-		MaxConcurrent        *int    `json:"max_concurrent"`
+		Enabled                *bool                        `json:"enabled"`
+		TargetBranch           *string                      `json:"target_branch"`
+		Remote                 *string                      `json:"remote"`
+		PushRemotes            []string                     `json:"push_remotes"`
+		CredentialHelper       *string                      `json:"credential_helper"`
+		IntegrationBranches    *bool                        `json:"integration_branches"`
+		OnConflict             *string                      `json:"on_conflict"`
+		RunTests               *bool                        `json:"run_tests"`
+		TestCommand            *string                      `json:"test_command"`
+		TestRules              *string                      `json:"test_rules"`
+		DeleteMergedBranches   *bool                        `json:"delete_merged_branches"`
+		RetryFlakyTests        *int                         `json:"retry_flaky_tests"`
+		PollInterval           *string                      `json:"poll_interval"`
+		TriggerMode            *string                      `json:"trigger_mode"`
+		TriggerAddr            *string                      `json:"trigger_addr"`
+		FallbackPollInterval   *string                      `json:"fallback_poll_interval"`
+		MaxConcurrent          *int                         `json:"max_concurrent"`
+		RequireUpToDate        *bool                        `json:"require_up_to_date"`
+		MaxCommitsBehind       *int                         `json:"max_commits_behind"`
+		AutoBisect             *bool                        `json:"auto_bisect"`
+		Changelog              *bool                        `json:"changelog"`
+		ChangelogPath          *string                      `json:"changelog_path"`
+		ChangelogTemplate      *string                      `json:"changelog_template"`
+		SignCommits            *bool                        `json:"sign_commits"`
+		SigningFormat          *string                      `json:"signing_format"`
+		SigningKey             *string                      `json:"signing_key"`
+		RiskApprovalThreshold  *int                         `json:"risk_approval_threshold"`
+		PostMergeCheck         *string                      `json:"post_merge_check"`
+		PostMergeCheckTimeout  *string                      `json:"post_merge_check_timeout"`
+		AutoRevert             *bool                        `json:"auto_revert"`
+		MaxMergesPerEpicPerDay *int                         `json:"max_merges_per_epic_per_day"`
+		WorkerCooldown         *string                      `json:"worker_cooldown"`
+		MinFreeDiskMB          *int                         `json:"min_free_disk_mb"`
+		FailureAssignment      map[string]FailureAssignment `json:"failure_assignment"`
+		LogFormat              *string                      `json:"log_format"`
+		LogLevel               *string                      `json:"log_level"`
 	}
 
 	if err := json.Unmarshal(rawConfig.MergeQueue, &mqRaw); err != nil {
@@ -186,6 +349,18 @@ func (e *Engineer) LoadConfig() error {
 	if mqRaw.TargetBranch != nil {
 		e.config.TargetBranch = *mqRaw.TargetBranch
 	}
+	if mqRaw.Remote != nil && *mqRaw.Remote != "" {
+		e.config.Remote = *mqRaw.Remote
+	}
+	if mqRaw.PushRemotes != nil {
+		if err := checkPushRemotes(e.config.Remote, mqRaw.PushRemotes); err != nil {
+			return err
+		}
+		e.config.PushRemotes = mqRaw.PushRemotes
+	}
+	if mqRaw.CredentialHelper != nil {
+		e.config.CredentialHelper = strings.TrimSpace(*mqRaw.CredentialHelper)
+	}
 	if mqRaw.IntegrationBranches != nil {
 		e.config.IntegrationBranches = *mqRaw.IntegrationBranches
 	}
@@ -198,6 +373,9 @@ func (e *Engineer) LoadConfig() error {
 	if mqRaw.TestCommand != nil {
 		e.config.TestCommand = *mqRaw.TestCommand
 	}
+	if mqRaw.TestRules != nil {
+		e.config.TestRules = strings.TrimSpace(*mqRaw.TestRules)
+	}
 	if mqRaw.DeleteMergedBranches != nil {
 		e.config.DeleteMergedBranches = *mqRaw.DeleteMergedBranches
 	}
@@ -207,6 +385,94 @@ func (e *Engineer) LoadConfig() error {
 	if mqRaw.MaxConcurrent != nil {
 		e.config.MaxConcurrent = *mqRaw.MaxConcurrent
 	}
+	if mqRaw.RequireUpToDate != nil {
+		e.config.RequireUpToDate = *mqRaw.RequireUpToDate
+	}
+	if mqRaw.MaxCommitsBehind != nil {
+		if *mqRaw.MaxCommitsBehind < 0 {
+			return fmt.Errorf("invalid max_commits_behind %d: must be >= 0", *mqRaw.MaxCommitsBehind)
+		}
+		e.config.MaxCommitsBehind = *mqRaw.MaxCommitsBehind
+	}
+	if mqRaw.AutoBisect != nil {
+		e.config.AutoBisect = *mqRaw.AutoBisect
+	}
+	if mqRaw.Changelog != nil {
+		e.config.Changelog = *mqRaw.Changelog
+	}
+	if mqRaw.ChangelogPath != nil {
+		e.config.ChangelogPath = *mqRaw.ChangelogPath
+	}
+	if mqRaw.ChangelogTemplate != nil {
+		if _, err := RenderChangelogEntry(*mqRaw.ChangelogTemplate, ChangelogEntry{}); err != nil {
+			return fmt.Errorf("invalid changelog_template: %w", err)
+		}
+		e.config.ChangelogTemplate = *mqRaw.ChangelogTemplate
+	}
+	if mqRaw.SignCommits != nil {
+		e.config.SignCommits = *mqRaw.SignCommits
+	}
+	if mqRaw.SigningFormat != nil {
+		switch *mqRaw.SigningFormat {
+		case "", "gpg", "ssh":
+		default:
+			return fmt.Errorf("invalid signing_format %q: must be gpg or ssh", *mqRaw.SigningFormat)
+		}
+		e.config.SigningFormat = *mqRaw.SigningFormat
+	}
+	if mqRaw.SigningKey != nil {
+		e.config.SigningKey = *mqRaw.SigningKey
+	}
+	if mqRaw.RiskApprovalThreshold != nil {
+		if *mqRaw.RiskApprovalThreshold < 0 || *mqRaw.RiskApprovalThreshold > 100 {
+			return fmt.Errorf("invalid risk_approval_threshold %d: must be 0-100", *mqRaw.RiskApprovalThreshold)
+		}
+		e.config.RiskApprovalThreshold = *mqRaw.RiskApprovalThreshold
+	}
+	if mqRaw.PostMergeCheck != nil {
+		e.config.PostMergeCheck = strings.TrimSpace(*mqRaw.PostMergeCheck)
+	}
+	if mqRaw.PostMergeCheckTimeout != nil {
+		dur, err := time.ParseDuration(*mqRaw.PostMergeCheckTimeout)
+		if err != nil || dur <= 0 {
+			return fmt.Errorf("invalid post_merge_check_timeout %q: must be a positive duration", *mqRaw.PostMergeCheckTimeout)
+		}
+		e.config.PostMergeCheckTimeout = dur
+	}
+	if mqRaw.AutoRevert != nil {
+		e.config.AutoRevert = *mqRaw.AutoRevert
+	}
+	if mqRaw.MaxMergesPerEpicPerDay != nil {
+		if *mqRaw.MaxMergesPerEpicPerDay < 0 {
+			return fmt.Errorf("invalid max_merges_per_epic_per_day %d: must be >= 0", *mqRaw.MaxMergesPerEpicPerDay)
+		}
+		e.config.MaxMergesPerEpicPerDay = *mqRaw.MaxMergesPerEpicPerDay
+	}
+	if mqRaw.WorkerCooldown != nil {
+		dur, err := time.ParseDuration(*mqRaw.WorkerCooldown)
+		if err != nil || dur < 0 {
+			return fmt.Errorf("invalid worker_cooldown %q: must be a non-negative duration", *mqRaw.WorkerCooldown)
+		}
+		e.config.WorkerCooldown = dur
+	}
+	if mqRaw.MinFreeDiskMB != nil {
+		if *mqRaw.MinFreeDiskMB < 0 {
+			return fmt.Errorf("invalid min_free_disk_mb %d: must be >= 0", *mqRaw.MinFreeDiskMB)
+		}
+		e.config.MinFreeDiskMB = *mqRaw.MinFreeDiskMB
+	}
+	if mqRaw.FailureAssignment != nil {
+		if err := validateFailureAssignments(mqRaw.FailureAssignment); err != nil {
+			return err
+		}
+		e.config.FailureAssignment = mqRaw.FailureAssignment
+	}
+	if e.config.SignCommits {
+		e.git.SetSigning(e.config.SigningFormat, e.config.SigningKey)
+	}
+	if e.config.CredentialHelper != "" {
+		e.git.SetCredentialHelper(e.config.CredentialHelper)
+	}
 	if mqRaw.PollInterval != nil {
 		dur, err := time.ParseDuration(*mqRaw.PollInterval)
 		if err != nil {
@@ -214,6 +480,39 @@ func (e *Engineer) LoadConfig() error {
 		}
 		e.config.PollInterval = dur
 	}
+	if mqRaw.TriggerMode != nil {
+		switch *mqRaw.TriggerMode {
+		case "", TriggerPoll, TriggerFile, TriggerHTTP:
+		default:
+			return fmt.Errorf("invalid trigger_mode %q: must be %s, %s, or %s", *mqRaw.TriggerMode, TriggerPoll, TriggerFile, TriggerHTTP)
+		}
+		e.config.TriggerMode = *mqRaw.TriggerMode
+	}
+	if mqRaw.TriggerAddr != nil && *mqRaw.TriggerAddr != "" {
+		e.config.TriggerAddr = *mqRaw.TriggerAddr
+	}
+	if mqRaw.FallbackPollInterval != nil {
+		dur, err := time.ParseDuration(*mqRaw.FallbackPollInterval)
+		if err != nil {
+			return fmt.Errorf("invalid fallback_poll_interval %q: %w", *mqRaw.FallbackPollInterval, err)
+		}
+		e.config.FallbackPollInterval = dur
+	}
+	if mqRaw.LogFormat != nil || mqRaw.LogLevel != nil {
+		format, level := e.config.LogFormat, e.config.LogLevel
+		if mqRaw.LogFormat != nil {
+			format = *mqRaw.LogFormat
+		}
+		if mqRaw.LogLevel != nil {
+			level = *mqRaw.LogLevel
+		}
+		log, err := NewLogger(e.output, format, level)
+		if err != nil {
+			return err
+		}
+		e.config.LogFormat, e.config.LogLevel = format, level
+		e.log = log
+	}
 
 	return nil
 }
@@ -223,6 +522,11 @@ func (e *Engineer) Config() *MergeQueueConfig {
 	return e.config
 }
 
+// WorkDir returns the refinery worktree that merges and tests run in.
+func (e *Engineer) WorkDir() string {
+	return e.workDir
+}
+
 // ProcessResult contains the result of processing a merge request.
 type ProcessResult struct {
 	Success     bool
@@ -230,6 +534,36 @@ type ProcessResult struct {
 	Error       string
 	Conflict    bool
 	TestsFailed bool
+	Stale       bool
+
+	// QueuePaused is set when a target branch check (see checkTargetReady),
+	// the changelog or signing failed and paused the queue. The MR itself
+	// is not at fault.
+	QueuePaused bool
+
+	// Unhealthy is set when the preflight found the refinery's environment
+	// unfit to merge in (see checkHealth). The MR was not touched.
+	Unhealthy bool
+
+	// Failure names failures that aren't the worker's to fix, such as
+	// FailureChangelog and FailureUnsigned. These pause the queue for the
+	// QueueOperator instead of sending the MR back.
+	Failure FailureType
+
+	// CorrelationID is the ID the attempt was logged under.
+	CorrelationID string
+
+	// OffendingCommit is the first failing commit found by auto-bisect.
+	OffendingCommit string
+
+	// NeedsApproval is set when the risk gate held the MR for the overseer.
+	NeedsApproval bool
+
+	// Risk is the MR's risk assessment, if the diff could be scored.
+	Risk *RiskAssessment
+
+	// ConflictReport explains what collided, for conflicts.
+	ConflictReport *ConflictReport
 }
 
 // ProcessMR processes a single merge request from a beads issue.
@@ -243,20 +577,26 @@ func (e *Engineer) ProcessMR(ctx context.Context, mr *beads.Issue) ProcessResult
 		}
 	}
 
-	// Log what we're processing
-	_, _ = fmt.Fprintln(e.output, "[Engineer] Processing MR:")
-	_, _ = fmt.Fprintf(e.output, "  Branch: %s\n", mrFields.Branch)
-	_, _ = fmt.Fprintf(e.output, "  Target: %s\n", mrFields.Target)
-	_, _ = fmt.Fprintf(e.output, "  Worker: %s\n", mrFields.Worker)
-
-	return e.doMerge(ctx, mrFields.Branch, mrFields.Target, mrFields.SourceIssue)
+	return e.ProcessMRInfo(ctx, &MRInfo{
+		ID:          mr.ID,
+		Branch:      mrFields.Branch,
+		Target:      mrFields.Target,
+		SourceIssue: mrFields.SourceIssue,
+		Worker:      mrFields.Worker,
+		Rig:         mrFields.Rig,
+		Title:       mr.Title,
+		Reverts:     mrFields.Reverts,
+		StackedOn:   mrFields.StackedOn,
+	})
 }
 
 // doMerge performs the actual git merge operation.
-// This is the core merge logic shared by ProcessMR and ProcessMRFromQueue.
-func (e *Engineer) doMerge(ctx context.Context, branch, target, sourceIssue string) ProcessResult {
+// This is the core merge logic shared by ProcessMR and ProcessMRInfo.
+func (e *Engineer) doMerge(ctx context.Context, log *slog.Logger, mr *MRInfo) ProcessResult {
+	branch, target, sourceIssue := mr.Branch, mr.Target, mr.SourceIssue
+
 	// Step 1: Verify source branch exists locally (shared .repo.git with polecats)
-	_, _ = fmt.Fprintf(e.output, "[Engineer] Checking local branch %s...\n", branch)
+	log.Debug("checking local branch", "branch", branch)
 	exists, err := e.git.BranchExists(branch)
 	if err != nil {
 		return ProcessResult{
@@ -271,8 +611,13 @@ func (e *Engineer) doMerge(ctx context.Context, branch, target, sourceIssue stri
 		}
 	}
 
+	// Step 1.5: Make sure the checkout is clean and the target exists
+	if result := e.checkTargetReady(log, target); !result.Success {
+		return result
+	}
+
 	// Step 2: Checkout the target branch
-	_, _ = fmt.Fprintf(e.output, "[Engineer] Checking out target branch %s...\n", target)
+	log.Debug("checking out target branch", "target", target)
 	if err := e.git.Checkout(target); err != nil {
 		return ProcessResult{
 			Success: false,
@@ -280,14 +625,25 @@ func (e *Engineer) doMerge(ctx context.Context, branch, target, sourceIssue stri
 		}
 	}
 
-	// Make sure target is up to date with origin
-	if err := e.git.Pull("origin", target); err != nil {
+	// Make sure target is up to date with the remote
+	remote := e.config.Remote
+	if err := e.git.Pull(remote, target); err != nil {
 		// Pull might fail if nothing to pull, that's ok
-		_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: pull from origin/%s: %v (continuing)\n", target, err)
+		log.Warn("pull failed, continuing", "remote", remote, "target", target, "err", err)
+	}
+	if result := e.checkTargetSynced(log, target); !result.Success {
+		return result
+	}
+
+	// Step 2.5: Enforce branch freshness if configured
+	if e.config.RequireUpToDate {
+		if result := e.checkFreshness(log, branch, target); !result.Success {
+			return result
+		}
 	}
 
 	// Step 3: Check for merge conflicts (using local branch)
-	_, _ = fmt.Fprintf(e.output, "[Engineer] Checking for conflicts...\n")
+	log.Debug("checking for conflicts", "branch", branch, "target", target)
 	conflicts, err := e.git.CheckConflicts(branch, target)
 	if err != nil {
 		return ProcessResult{
@@ -298,24 +654,51 @@ func (e *Engineer) doMerge(ctx context.Context, branch, target, sourceIssue stri
 	}
 	if len(conflicts) > 0 {
 		return ProcessResult{
-			Success:  false,
-			Conflict: true,
-			Error:    fmt.Sprintf("merge conflicts in: %v", conflicts),
+			Success:        false,
+			Conflict:       true,
+			Error:          fmt.Sprintf("merge conflicts in: %v", conflicts),
+			ConflictReport: e.buildConflictReport(log, mr, branch, target, conflicts),
 		}
 	}
 
-	// Step 4: Run tests if configured
-	if e.config.RunTests && e.config.TestCommand != "" {
-		_, _ = fmt.Fprintf(e.output, "[Engineer] Running tests: %s\n", e.config.TestCommand)
-		result := e.runTests(ctx)
-		if !result.Success {
+	// Step 3.5: Score the MR's risk and hold risky MRs for approval
+	risk, err := e.AssessMRRisk(branch, target)
+	if err != nil {
+		log.Warn("risk scoring failed, continuing", "err", err)
+	} else {
+		log.Info("risk scored", "score", risk.Score, "level", risk.Level)
+		if threshold := e.config.RiskApprovalThreshold; threshold > 0 && risk.Score >= threshold && mr.RiskApprovedBy == "" {
 			return ProcessResult{
+				Success:       false,
+				NeedsApproval: true,
+				Risk:          risk,
+				Error:         fmt.Sprintf("risk score %d reaches approval threshold %d", risk.Score, threshold),
+			}
+		}
+	}
+
+	// Step 4: Run tests if configured, narrowed to the MR's changed paths
+	// when test rules are set
+	var testCommands []string
+	if e.config.RunTests {
+		testCommands, _ = e.testCommands(log, branch, target)
+	}
+	if len(testCommands) > 0 {
+		log.Info("running tests", "commands", testCommands)
+		result := e.runTests(ctx, log, testCommands)
+		if !result.Success {
+			failed := ProcessResult{
 				Success:     false,
 				TestsFailed: true,
 				Error:       result.Error,
+				Risk:        risk,
 			}
+			if e.config.AutoBisect {
+				e.applyBisect(ctx, log, branch, target, testCommands, &failed)
+			}
+			return failed
 		}
-		_, _ = fmt.Fprintln(e.output, "[Engineer] Tests passed")
+		log.Info("tests passed")
 	}
 
 	// Step 5: Perform the actual merge
@@ -323,7 +706,7 @@ func (e *Engineer) doMerge(ctx context.Context, branch, target, sourceIssue stri
 	if sourceIssue != "" {
 		mergeMsg = fmt.Sprintf("Merge %s into %s (%s)", branch, target, sourceIssue)
 	}
-	_, _ = fmt.Fprintf(e.output, "[Engineer] Merging with message: %s\n", mergeMsg)
+	log.Info("merging", "branch", branch, "target", target, "message", mergeMsg)
 	if err := e.git.MergeNoFF(branch, mergeMsg); err != nil {
 		// ZFC: Use git's porcelain output to detect conflicts instead of parsing stderr.
 		// GetConflictingFiles() uses `git diff --diff-filter=U` which is proper.
@@ -331,9 +714,10 @@ func (e *Engineer) doMerge(ctx context.Context, branch, target, sourceIssue stri
 		if conflictErr == nil && len(conflicts) > 0 {
 			_ = e.git.AbortMerge()
 			return ProcessResult{
-				Success:  false,
-				Conflict: true,
-				Error:    "merge conflict during actual merge",
+				Success:        false,
+				Conflict:       true,
+				Error:          "merge conflict during actual merge",
+				ConflictReport: e.buildConflictReport(log, mr, branch, target, conflicts),
 			}
 		}
 		return ProcessResult{
@@ -342,6 +726,21 @@ func (e *Engineer) doMerge(ctx context.Context, branch, target, sourceIssue stri
 		}
 	}
 
+	// Step 5.5: Record the merge in the changelog (amends the merge commit).
+	// A failure is the refinery's, not the MR's, and would fail every MR,
+	// so it pauses the queue (FailureChangelog).
+	if e.config.Changelog {
+		if err := e.updateChangelog(log, mr); err != nil {
+			_ = e.git.ResetHard("HEAD~1") // best-effort: undo the unpushed merge
+			return ProcessResult{
+				Success:     false,
+				QueuePaused: true,
+				Failure:     FailureChangelog,
+				Error:       fmt.Sprintf("changelog update failed: %v", err),
+			}
+		}
+	}
+
 	// Step 6: Get the merge commit SHA
 	mergeCommit, err := e.git.Rev("HEAD")
 	if err != nil {
@@ -351,68 +750,217 @@ func (e *Engineer) doMerge(ctx context.Context, branch, target, sourceIssue stri
 		}
 	}
 
-	// Step 7: Push to origin
-	_, _ = fmt.Fprintf(e.output, "[Engineer] Pushing to origin/%s...\n", target)
-	if err := e.git.Push("origin", target, false); err != nil {
+	// Step 6.5: Verify the merge commit is signed so a signed-commit policy
+	// rejects nothing at push time
+	if e.config.SignCommits {
+		if result := e.verifySignature(log, mergeCommit); !result.Success {
+			_ = e.git.ResetHard("HEAD~1") // best-effort: undo the unpushed merge
+			return result
+		}
+	}
+
+	// Step 7: Push to the remote, then to any mirrors
+	log.Info("pushing", "remote", remote, "target", target)
+	if err := e.git.Push(remote, target, false); err != nil {
 		return ProcessResult{
 			Success: false,
-			Error:   fmt.Sprintf("failed to push to origin: %v", err),
+			Error:   fmt.Sprintf("failed to push to %s: %v%s", remote, err, e.credentialHint(remote)),
 		}
 	}
+	e.pushMirrors(log, target)
 
-	_, _ = fmt.Fprintf(e.output, "[Engineer] Successfully merged: %s\n", mergeCommit[:8])
+	log.Info("merge pushed", "commit", shortSHA(mergeCommit))
 	return ProcessResult{
 		Success:     true,
 		MergeCommit: mergeCommit,
+		Risk:        risk,
+	}
+}
+
+// AssessMRRisk scores branch's diff against target using the rig's merge
+// history.
+func (e *Engineer) AssessMRRisk(branch, target string) (*RiskAssessment, error) {
+	files, err := e.git.DiffNumstat(target, branch)
+	if err != nil {
+		return nil, err
+	}
+	history, err := LoadMergeHistory(MergeHistoryPath(e.rig.Path))
+	if err != nil {
+		return nil, fmt.Errorf("loading merge history: %w", err)
+	}
+	return AssessRisk(files, history, DefaultRiskConfig()), nil
+}
+
+// recordMergeOutcome adds a tested MR to the merge history that feeds the
+// area failure rates. Only merges and test failures say anything about the
+// touched areas; conflicts and other failures are not recorded.
+func (e *Engineer) recordMergeOutcome(log *slog.Logger, mrID string, result ProcessResult) {
+	if result.Risk == nil || (!result.Success && !result.TestsFailed) {
+		return
+	}
+	history, err := LoadMergeHistory(MergeHistoryPath(e.rig.Path))
+	if err == nil {
+		err = history.Record(MergeOutcome{
+			MR:     mrID,
+			Areas:  result.Risk.Areas,
+			Failed: !result.Success,
+			At:     time.Now().UTC(),
+		})
+	}
+	if err != nil {
+		log.Warn("failed to record merge history", "err", err)
+	}
+}
+
+// requestRiskApproval assigns a held MR to the overseer. Assigned MRs drop
+// out of the ready queue until gt mq approve clears the assignment.
+func (e *Engineer) requestRiskApproval(log *slog.Logger, mr *MRInfo, result ProcessResult) {
+	approver := RiskApprover
+	if err := e.beads.Update(mr.ID, beads.UpdateOptions{Assignee: &approver}); err != nil {
+		log.Warn("failed to assign MR for approval", "approver", approver, "err", err)
+		return
 	}
+	log.Warn("held for approval", "reason", result.Error, "approver", approver,
+		"approve_with", fmt.Sprintf("gt mq approve %s %s", e.rig.Name, mr.ID))
 }
 
-// runTests runs the configured test command and returns the result.
-func (e *Engineer) runTests(ctx context.Context) ProcessResult {
-	if e.config.TestCommand == "" {
-		return ProcessResult{Success: true}
+// ApproveMR records approver's sign-off on a high-risk MR and returns it to
+// the ready queue.
+func (e *Engineer) ApproveMR(mrID, approver string) error {
+	issue, err := e.beads.Show(mrID)
+	if err != nil {
+		return err
+	}
+	fields := beads.ParseMRFields(issue)
+	if fields == nil {
+		return fmt.Errorf("%s is not a merge request", mrID)
+	}
+	fields.RiskApprovedBy = approver
+	desc := beads.SetMRFields(issue, fields)
+	empty := ""
+	return e.beads.Update(mrID, beads.UpdateOptions{Description: &desc, Assignee: &empty})
+}
+
+// verifySignature checks that the merge commit carries a signature.
+// Failures pause the queue (FailureUnsigned): signing is set up for the
+// refinery, so no MR can fix it.
+func (e *Engineer) verifySignature(log *slog.Logger, commit string) ProcessResult {
+	log.Debug("verifying signature", "commit", shortSHA(commit))
+	signed, err := e.git.IsSigned(commit)
+	if err != nil {
+		return ProcessResult{
+			Success:     false,
+			QueuePaused: true,
+			Failure:     FailureUnsigned,
+			Error:       fmt.Sprintf("signature check failed: %v", err),
+		}
+	}
+	if !signed {
+		return ProcessResult{
+			Success:     false,
+			QueuePaused: true,
+			Failure:     FailureUnsigned,
+			Error:       fmt.Sprintf("merge commit %s is not signed (check signing_key and signing_format)", commit[:8]),
+		}
+	}
+	return ProcessResult{Success: true}
+}
+
+// checkFreshness verifies that branch contains the target HEAD, allowing at
+// most MaxCommitsBehind missing target commits. Branches that fall further
+// behind were tested against an old target and must be rebased by the worker.
+func (e *Engineer) checkFreshness(log *slog.Logger, branch, target string) ProcessResult {
+	log.Debug("checking branch freshness", "branch", branch, "target", target)
+	behind, err := e.git.CommitsAhead(branch, target)
+	if err != nil {
+		return ProcessResult{
+			Success: false,
+			Error:   fmt.Sprintf("freshness check failed: %v", err),
+		}
+	}
+	if behind > e.config.MaxCommitsBehind {
+		return ProcessResult{
+			Success: false,
+			Stale:   true,
+			Error: fmt.Sprintf("stale branch: %s is %d commits behind %s (max %d)",
+				branch, behind, target, e.config.MaxCommitsBehind),
+		}
 	}
+	return ProcessResult{Success: true}
+}
 
-	// Run the test command with retries for flaky tests
+// applyBisect narrows a test failure to a single commit and records it on
+// the result. Bisect errors are logged and leave the result unchanged.
+func (e *Engineer) applyBisect(ctx context.Context, log *slog.Logger, branch, target string, testCommands []string, result *ProcessResult) {
+	bisect, err := e.bisectTestFailure(ctx, log, branch, target, testCommands)
+	if err != nil {
+		log.Warn("bisect failed", "err", err)
+		return
+	}
+	if bisect == nil {
+		return
+	}
+	log.Info("bisect found first failing commit", "commit", shortSHA(bisect.Commit),
+		"position", bisect.Position, "total", bisect.Total, "test_runs", bisect.Probes)
+	result.OffendingCommit = bisect.Commit
+	result.Error = fmt.Sprintf("%s; bisect: first failing commit %s (%d of %d on %s)",
+		result.Error, shortSHA(bisect.Commit), bisect.Position, bisect.Total, branch)
+}
+
+// runTests runs the test commands in order and returns the result. Each
+// command is retried for flaky tests; the first that keeps failing fails
+// the run.
+func (e *Engineer) runTests(ctx context.Context, log *slog.Logger, commands []string) ProcessResult {
+	// Run each test command with retries for flaky tests
 	maxRetries := e.config.RetryFlakyTests
 	if maxRetries < 1 {
 		maxRetries = 1
 	}
 
-	var lastErr error
-	for attempt := 1; attempt <= maxRetries; attempt++ {
-		if attempt > 1 {
-			_, _ = fmt.Fprintf(e.output, "[Engineer] Retrying tests (attempt %d/%d)...\n", attempt, maxRetries)
-		}
-
-		// Note: TestCommand comes from rig's config.json (trusted infrastructure config),
-		// not from PR branches. Shell execution is intentional for flexibility (pipes, etc).
-		cmd := exec.CommandContext(ctx, "sh", "-c", e.config.TestCommand) //nolint:gosec // G204: TestCommand is from trusted rig config
-		cmd.Dir = e.workDir
-		var stdout, stderr bytes.Buffer
-		cmd.Stdout = &stdout
-		cmd.Stderr = &stderr
+	for _, command := range commands {
+		var lastErr error
+		for attempt := 1; attempt <= maxRetries; attempt++ {
+			if attempt > 1 {
+				log.Info("retrying tests", "command", command, "attempt", attempt, "max_attempts", maxRetries)
+			}
 
-		err := cmd.Run()
-		if err == nil {
-			return ProcessResult{Success: true}
+			// Note: test commands come from rig's config.json or the target branch's
+			// test rules (trusted infrastructure config), not from PR branches. Shell
+			// execution is intentional for flexibility (pipes, etc).
+			cmd := exec.CommandContext(ctx, "sh", "-c", command) //nolint:gosec // G204: command is from trusted rig config
+			cmd.Dir = e.workDir
+			var stdout, stderr bytes.Buffer
+			cmd.Stdout = &stdout
+			cmd.Stderr = &stderr
+
+			err := cmd.Run()
+			if err == nil {
+				lastErr = nil
+				break
+			}
+			lastErr = err
+
+			// Check if context was canceled
+			if ctx.Err() != nil {
+				return ProcessResult{
+					Success: false,
+					Error:   "test run canceled",
+				}
+			}
 		}
-		lastErr = err
-
-		// Check if context was canceled
-		if ctx.Err() != nil {
+		if lastErr != nil {
+			msg := fmt.Sprintf("tests failed after %d attempts: %v", maxRetries, lastErr)
+			if len(commands) > 1 {
+				msg = fmt.Sprintf("%s: %s", command, msg)
+			}
 			return ProcessResult{
-				Success: false,
-				Error:   "test run canceled",
+				Success:     false,
+				TestsFailed: true,
+				Error:       msg,
 			}
 		}
 	}
-
-	return ProcessResult{
-		Success:     false,
-		TestsFailed: true,
-		Error:       fmt.Sprintf("tests failed after %d attempts: %v", maxRetries, lastErr),
-	}
+	return ProcessResult{Success: true}
 }
 
 // handleSuccess handles a successful merge completion.
@@ -423,6 +971,11 @@ func (e *Engineer) runTests(ctx context.Context) ProcessResult {
 // 4. Delete source branch if configured
 // 5. Log success
 func (e *Engineer) handleSuccess(mr *beads.Issue, result ProcessResult) {
+	log := e.log.With("mr", mr.ID)
+	if result.CorrelationID != "" {
+		log = log.With("correlation_id", result.CorrelationID)
+	}
+
 	// Parse MR fields from description
 	mrFields := beads.ParseMRFields(mr)
 	if mrFields == nil {
@@ -434,28 +987,28 @@ func (e *Engineer) handleSuccess(mr *beads.Issue, result ProcessResult) {
 	mrFields.CloseReason = "merged"
 	newDesc := beads.SetMRFields(mr, mrFields)
 	if err := e.beads.Update(mr.ID, beads.UpdateOptions{Description: &newDesc}); err != nil {
-		_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to update MR %s with merge commit: %v\n", mr.ID, err)
+		log.Warn("failed to record merge commit on MR", "err", err)
 	}
 
 	// 2. Close MR with reason 'merged'
 	if err := e.beads.CloseWithReason("merged", mr.ID); err != nil {
-		_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to close MR %s: %v\n", mr.ID, err)
+		log.Warn("failed to close MR", "err", err)
 	}
 
 	// 3. Close source issue with reference to MR
 	if mrFields.SourceIssue != "" {
 		closeReason := fmt.Sprintf("Merged in %s", mr.ID)
 		if err := e.beads.CloseWithReason(closeReason, mrFields.SourceIssue); err != nil {
-			_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to close source issue %s: %v\n", mrFields.SourceIssue, err)
+			log.Warn("failed to close source issue", "source_issue", mrFields.SourceIssue, "err", err)
 		} else {
-			_, _ = fmt.Fprintf(e.output, "[Engineer] Closed source issue: %s\n", mrFields.SourceIssue)
+			log.Info("closed source issue", "source_issue", mrFields.SourceIssue)
 		}
 	}
 
 	// 3.5. Clear agent bead's active_mr reference (traceability cleanup)
 	if mrFields.AgentBead != "" {
 		if err := e.beads.UpdateAgentActiveMR(mrFields.AgentBead, ""); err != nil {
-			_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to clear agent bead %s active_mr: %v\n", mrFields.AgentBead, err)
+			log.Warn("failed to clear agent bead active_mr", "agent_bead", mrFields.AgentBead, "err", err)
 		}
 	}
 
@@ -464,50 +1017,82 @@ func (e *Engineer) handleSuccess(mr *beads.Issue, result ProcessResult) {
 	// so we need to clean up both local and remote branches after merge.
 	if e.config.DeleteMergedBranches && mrFields.Branch != "" {
 		if err := e.git.DeleteBranch(mrFields.Branch, true); err != nil {
-			_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to delete local branch %s: %v\n", mrFields.Branch, err)
+			log.Warn("failed to delete local branch", "branch", mrFields.Branch, "err", err)
 		} else {
-			_, _ = fmt.Fprintf(e.output, "[Engineer] Deleted local branch: %s\n", mrFields.Branch)
+			log.Info("deleted local branch", "branch", mrFields.Branch)
 		}
 		// Also delete the remote branch (non-fatal if it doesn't exist)
-		if err := e.git.DeleteRemoteBranch("origin", mrFields.Branch); err != nil {
-			_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to delete remote branch %s: %v\n", mrFields.Branch, err)
+		if err := e.git.DeleteRemoteBranch(e.config.Remote, mrFields.Branch); err != nil {
+			log.Warn("failed to delete remote branch", "branch", e.config.Remote+"/"+mrFields.Branch, "err", err)
 		} else {
-			_, _ = fmt.Fprintf(e.output, "[Engineer] Deleted remote branch: origin/%s\n", mrFields.Branch)
+			log.Info("deleted remote branch", "branch", e.config.Remote+"/"+mrFields.Branch)
 		}
 	}
 
 	// 5. Log success
-	_, _ = fmt.Fprintf(e.output, "[Engineer] ✓ Merged: %s (commit: %s)\n", mr.ID, result.MergeCommit)
+	log.Info("merged", "commit", result.MergeCommit)
 }
 
 // handleFailure handles a failed merge request.
 // Reopens the MR for rework and logs the failure.
 func (e *Engineer) handleFailure(mr *beads.Issue, result ProcessResult) {
+	log := e.log.With("mr", mr.ID)
+	if result.CorrelationID != "" {
+		log = log.With("correlation_id", result.CorrelationID)
+	}
+
 	// Reopen the MR (back to open status for rework)
 	open := "open"
 	if err := e.beads.Update(mr.ID, beads.UpdateOptions{Status: &open}); err != nil {
-		_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to reopen MR %s: %v\n", mr.ID, err)
+		log.Warn("failed to reopen MR", "err", err)
 	}
 
 	// Log the failure
-	_, _ = fmt.Fprintf(e.output, "[Engineer] ✗ Failed: %s - %s\n", mr.ID, result.Error)
+	log.Error("merge failed", "reason", result.Error)
 }
 
 // ProcessMRInfo processes a merge request from MRInfo.
+// Each call starts a new attempt with a fresh correlation ID, which is kept
+// on mr and the result so HandleMRInfoSuccess and HandleMRInfoFailure log
+// under the same ID.
 func (e *Engineer) ProcessMRInfo(ctx context.Context, mr *MRInfo) ProcessResult {
-	// MR fields are directly on the struct
-	_, _ = fmt.Fprintln(e.output, "[Engineer] Processing MR:")
-	_, _ = fmt.Fprintf(e.output, "  Branch: %s\n", mr.Branch)
-	_, _ = fmt.Fprintf(e.output, "  Target: %s\n", mr.Target)
-	_, _ = fmt.Fprintf(e.output, "  Worker: %s\n", mr.Worker)
-	_, _ = fmt.Fprintf(e.output, "  Source: %s\n", mr.SourceIssue)
+	mr.CorrelationID = ""
+	log := e.mrLog(mr)
+	log.Info("processing MR", "branch", mr.Branch, "target", mr.Target,
+		"worker", mr.Worker, "source_issue", mr.SourceIssue)
+
+	// A paused queue merges nothing until an operator resumes it
+	if pause, err := LoadQueuePause(e.rig.Path); err != nil {
+		log.Warn("failed to read queue pause", "err", err)
+	} else if pause != nil {
+		return ProcessResult{
+			QueuePaused:   true,
+			Error:         "merge queue paused: " + pause.Reason,
+			CorrelationID: mr.CorrelationID,
+		}
+	}
+
+	// Don't start a merge that low disk or a damaged repo would leave half done
+	if result := e.checkHealth(log); !result.Success {
+		result.CorrelationID = mr.CorrelationID
+		return result
+	}
 
 	// Use the shared merge logic
-	return e.doMerge(ctx, mr.Branch, mr.Target, mr.SourceIssue)
+	result := e.doMerge(ctx, log, mr)
+	result.CorrelationID = mr.CorrelationID
+	if result.QueuePaused {
+		e.pauseQueue(log, mr, result.Error)
+	}
+	return result
 }
 
 // HandleMRInfoSuccess handles a successful merge from MRInfo.
 func (e *Engineer) HandleMRInfoSuccess(mr *MRInfo, result ProcessResult) {
+	log := e.resultLog(mr, result)
+	e.recordMergeOutcome(log, mr.ID, result)
+	e.recordThrottledMerge(log, mr)
+
 	// Release merge slot if this was a conflict resolution
 	// The slot is held while conflict resolution is in progress
 	holder := e.rig.Name + "/refinery"
@@ -516,10 +1101,10 @@ func (e *Engineer) HandleMRInfoSuccess(mr *MRInfo, result ProcessResult) {
 		// Only log if it seems like an actual issue
 		errStr := err.Error()
 		if !strings.Contains(errStr, "not held") && !strings.Contains(errStr, "not found") {
-			_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to release merge slot: %v\n", err)
+			log.Warn("failed to release merge slot", "err", err)
 		}
 	} else {
-		_, _ = fmt.Fprintf(e.output, "[Engineer] Released merge slot\n")
+		log.Info("released merge slot")
 	}
 
 	// Update and close the MR bead
@@ -527,7 +1112,7 @@ func (e *Engineer) HandleMRInfoSuccess(mr *MRInfo, result ProcessResult) {
 		// Fetch the MR bead to update its fields
 		mrBead, err := e.beads.Show(mr.ID)
 		if err != nil {
-			_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to fetch MR bead %s: %v\n", mr.ID, err)
+			log.Warn("failed to fetch MR bead", "err", err)
 		} else {
 			// Update MR with merge_commit SHA and close_reason
 			mrFields := beads.ParseMRFields(mrBead)
@@ -538,91 +1123,234 @@ func (e *Engineer) HandleMRInfoSuccess(mr *MRInfo, result ProcessResult) {
 			mrFields.CloseReason = "merged"
 			newDesc := beads.SetMRFields(mrBead, mrFields)
 			if err := e.beads.Update(mr.ID, beads.UpdateOptions{Description: &newDesc}); err != nil {
-				_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to update MR %s with merge commit: %v\n", mr.ID, err)
+				log.Warn("failed to record merge commit on MR", "err", err)
 			}
 		}
 
 		// Close MR bead with reason 'merged'
 		if err := e.beads.CloseWithReason("merged", mr.ID); err != nil {
-			_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to close MR %s: %v\n", mr.ID, err)
+			log.Warn("failed to close MR", "err", err)
 		} else {
-			_, _ = fmt.Fprintf(e.output, "[Engineer] Closed MR bead: %s\n", mr.ID)
+			log.Info("closed MR bead")
 		}
 	}
 
-	// 1. Close source issue with reference to MR
-	if mr.SourceIssue != "" {
+	// 1. Close source issue with reference to MR. A revert leaves the
+	// source issue open: it was reopened when the revert was queued.
+	if mr.SourceIssue != "" && mr.Reverts == "" {
 		closeReason := fmt.Sprintf("Merged in %s", mr.ID)
 		if err := e.beads.CloseWithReason(closeReason, mr.SourceIssue); err != nil {
-			_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to close source issue %s: %v\n", mr.SourceIssue, err)
+			log.Warn("failed to close source issue", "source_issue", mr.SourceIssue, "err", err)
 		} else {
-			_, _ = fmt.Fprintf(e.output, "[Engineer] Closed source issue: %s\n", mr.SourceIssue)
+			log.Info("closed source issue", "source_issue", mr.SourceIssue)
 		}
 	}
 
 	// 1.5. Clear agent bead's active_mr reference (traceability cleanup)
 	if mr.AgentBead != "" {
 		if err := e.beads.UpdateAgentActiveMR(mr.AgentBead, ""); err != nil {
-			_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to clear agent bead %s active_mr: %v\n", mr.AgentBead, err)
+			log.Warn("failed to clear agent bead active_mr", "agent_bead", mr.AgentBead, "err", err)
+		}
+	}
+
+	// 1.75. Rebase MRs stacked on this one onto the target (before the
+	// branch they build on is deleted)
+	if mr.Branch != "" {
+		if tip, err := e.git.Rev(mr.Branch); err == nil {
+			e.rebaseStack(log, mr, tip)
 		}
 	}
 
 	// 2. Delete source branch if configured (local only)
 	if e.config.DeleteMergedBranches && mr.Branch != "" {
 		if err := e.git.DeleteBranch(mr.Branch, true); err != nil {
-			_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to delete branch %s: %v\n", mr.Branch, err)
+			log.Warn("failed to delete local branch", "branch", mr.Branch, "err", err)
 		} else {
-			_, _ = fmt.Fprintf(e.output, "[Engineer] Deleted local branch: %s\n", mr.Branch)
+			log.Info("deleted local branch", "branch", mr.Branch)
 		}
 	}
 
-	// 3. Log success
-	_, _ = fmt.Fprintf(e.output, "[Engineer] ✓ Merged: %s (commit: %s)\n", mr.ID, result.MergeCommit)
+	// 3. Let anyone watching the source issue (or its epic) know
+	e.notifyWatchers(log, mr, "Merged",
+		fmt.Sprintf("Branch %s has been merged to %s.\n\nIssue: %s\nMR: %s\nCommit: %s",
+			mr.Branch, mr.Target, mr.SourceIssue, mr.ID, result.MergeCommit))
+
+	// 4. Log success
+	log.Info("merged", "commit", result.MergeCommit)
+
+	// 5. Run the post-merge health check, reverting the merge on failure
+	// if auto_revert is set
+	if e.config.PostMergeCheck != "" && mr.Reverts == "" {
+		if _, err := e.CheckPostMerge(context.Background()); err != nil {
+			log.Warn("post-merge check could not run", "err", err)
+		}
+	}
 }
 
 // HandleMRInfoFailure handles a failed merge from MRInfo.
 // For conflicts, creates a resolution task and blocks the MR until resolved.
 // This enables non-blocking delegation: the queue continues to the next MR.
 func (e *Engineer) HandleMRInfoFailure(mr *MRInfo, result ProcessResult) {
-	// Notify Witness of the failure so polecat can be alerted
+	// Held MRs haven't failed; they wait for the overseer
+	log := e.resultLog(mr, result)
+	if result.NeedsApproval {
+		e.requestRiskApproval(log, mr, result)
+		return
+	}
+	// MRs stopped by a paused queue haven't failed either; they wait for it
+	// to resume
+	if result.QueuePaused {
+		log.Warn("MR waiting for paused queue", "reason", result.Error, "failure_type", string(result.Failure))
+		return
+	}
+	if result.Unhealthy {
+		log.Warn("MR waiting for refinery environment to recover", "reason", result.Error)
+		return
+	}
+	e.recordMergeOutcome(log, mr.ID, result)
+
 	// Determine failure type from result
 	failureType := "build"
 	if result.Conflict {
 		failureType = "conflict"
+	} else if result.Stale {
+		failureType = string(FailureStale)
 	} else if result.TestsFailed {
 		failureType = "tests"
 	}
-	msg := protocol.NewMergeFailedMessage(e.rig.Name, mr.Worker, mr.Branch, mr.SourceIssue, mr.Target, failureType, result.Error)
-	if err := e.router.Send(msg); err != nil {
-		fmt.Fprintf(e.output, "[Engineer] Warning: failed to send MERGE_FAILED to witness: %v\n", err)
-	} else {
-		fmt.Fprintf(e.output, "[Engineer] Notified witness of merge failure for %s\n", mr.Worker)
+	// Conflicts carry a report of what collided, kept with the MR and
+	// appended to the failure mail
+	var report string
+	if result.ConflictReport != nil {
+		report = result.ConflictReport.Markdown()
+		if result.ConflictReport.MR != "" {
+			if path := e.attachConflictReport(log, result.ConflictReport); path != "" {
+				report = fmt.Sprintf("Conflict report: %s\n\n%s", path, report)
+			}
+		}
+	}
+
+	body := fmt.Sprintf("Merging branch %s to %s failed (%s).\n\nIssue: %s\nMR: %s\nError: %s\n\nThe MR stays in the queue for retry.",
+		mr.Branch, mr.Target, failureType, mr.SourceIssue, mr.ID, result.Error)
+	if report != "" {
+		body += "\n\n" + report
+	}
+
+	// MRs stacked on this one fail with it
+	stackNote := e.failStack(log, mr, failureType)
+	if stackNote != "" {
+		body += "\n\n" + stackNote
 	}
 
 	// If this was a conflict, create a conflict-resolution task for dispatch
 	// and block the MR until the task is resolved (non-blocking delegation)
+	reworkID := mr.SourceIssue
 	if result.Conflict {
-		taskID, err := e.createConflictResolutionTaskForMR(mr, result)
+		taskID, err := e.createConflictResolutionTaskForMR(log, mr, result)
 		if err != nil {
-			_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to create conflict resolution task: %v\n", err)
+			log.Warn("failed to create conflict resolution task", "err", err)
 		} else if taskID != "" {
+			reworkID = taskID
 			// Block the MR on the conflict resolution task using beads dependency
 			// When the task closes, the MR unblocks and re-enters the ready queue
 			if err := e.beads.AddDependency(mr.ID, taskID); err != nil {
-				_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to block MR on task: %v\n", err)
+				log.Warn("failed to block MR on conflict task", "task", taskID, "err", err)
 			} else {
-				_, _ = fmt.Fprintf(e.output, "[Engineer] MR %s blocked on conflict task %s (non-blocking delegation)\n", mr.ID, taskID)
+				log.Info("MR blocked on conflict task", "task", taskID)
 			}
 		}
 	}
 
+	// Hand the rework to whoever the failure assignment policy names, and
+	// notify the Witness so that polecat can be alerted
+	if polecat := e.assignFailure(log, mr, failureKind(result), reworkID, body); polecat != "" {
+		msg := protocol.NewMergeFailedMessage(e.rig.Name, polecat, mr.Branch, mr.SourceIssue, mr.Target, failureType, result.Error)
+		if report != "" {
+			msg.Body += "\n" + report
+		}
+		if stackNote != "" {
+			msg.Body += "\n" + stackNote
+		}
+		if err := e.router.SendOrQueue(msg); err != nil {
+			log.Warn("failed to send MERGE_FAILED to witness", "err", err)
+		} else {
+			log.Info("notified witness of merge failure", "worker", polecat)
+		}
+	}
+	e.notifyWatchers(log, mr, "Merge failed", body)
+
 	// Log the failure - MR stays in queue but may be blocked
-	_, _ = fmt.Fprintf(e.output, "[Engineer] ✗ Failed: %s - %s\n", mr.ID, result.Error)
+	next := "retry"
 	if mr.BlockedBy != "" {
-		_, _ = fmt.Fprintln(e.output, "[Engineer] MR blocked pending conflict resolution - queue continues to next MR")
-	} else {
-		_, _ = fmt.Fprintln(e.output, "[Engineer] MR remains in queue for retry")
+		next = "blocked on conflict resolution"
 	}
+	log.Error("merge failed", "reason", result.Error, "failure_type", failureType, "next", next)
+}
+
+// resultLog returns the logger for handling a processed MR, under the
+// correlation ID of the attempt that produced result.
+func (e *Engineer) resultLog(mr *MRInfo, result ProcessResult) *slog.Logger {
+	if result.CorrelationID != "" {
+		mr.CorrelationID = result.CorrelationID
+	}
+	return e.mrLog(mr)
+}
+
+// notifyWatchers mails everyone listed as a watcher on the MR's source issue
+// and on its parent (e.g., the epic) about the MR's outcome. The worker is
+// skipped; the witness already tells them. Best-effort.
+func (e *Engineer) notifyWatchers(log *slog.Logger, mr *MRInfo, status, body string) {
+	if mr.SourceIssue == "" {
+		return
+	}
+	source, err := e.beads.Show(mr.SourceIssue)
+	if err != nil {
+		return
+	}
+	var parent *beads.Issue
+	if source.Parent != "" {
+		parent, _ = e.beads.Show(source.Parent)
+	}
+
+	watchers := sourceWatchers(source, parent, e.rig.Name+"/"+mr.Worker)
+	if len(watchers) == 0 {
+		return
+	}
+
+	subject := fmt.Sprintf("%s: %s", status, mr.SourceIssue)
+	if source.Title != "" {
+		subject = fmt.Sprintf("%s: %s (%s)", status, source.Title, mr.SourceIssue)
+	}
+	for _, w := range watchers {
+		msg := &mail.Message{
+			From:     e.rig.Name + "/refinery",
+			To:       w,
+			Subject:  subject,
+			Body:     body,
+			Priority: mail.PriorityNormal,
+		}
+		if err := e.router.SendOrQueue(msg); err != nil {
+			log.Warn("failed to notify watcher", "watcher", w, "err", err)
+		}
+	}
+	log.Info("notified watchers", "count", len(watchers), "source_issue", mr.SourceIssue)
+}
+
+// sourceWatchers combines the watchers of a source issue and its parent,
+// deduplicated, excluding the given addresses.
+func sourceWatchers(source, parent *beads.Issue, exclude ...string) []string {
+	seen := make(map[string]bool)
+	for _, addr := range exclude {
+		seen[addr] = true
+	}
+	var watchers []string
+	for _, addr := range append(beads.ParseWatchers(source), beads.ParseWatchers(parent)...) {
+		if !seen[addr] {
+			seen[addr] = true
+			watchers = append(watchers, addr)
+		}
+	}
+	return watchers
 }
 
 // createConflictResolutionTaskForMR creates a dispatchable task for resolving merge conflicts.
@@ -635,40 +1363,39 @@ func (e *Engineer) HandleMRInfoFailure(mr *MRInfo, result ProcessResult) {
 //	Type: task
 //	Priority: inherit from original + boost (P2 -> P1)
 //	Parent: original MR bead
-//	Description: metadata including branch, conflict SHA, etc.
+//	Description: metadata including branch, conflict SHA, etc., plus the conflict report
 //
 // Merge Slot Integration:
 // Before creating a conflict resolution task, we acquire the merge-slot for this rig.
 // This serializes conflict resolution - only one polecat can resolve conflicts at a time.
 // If the slot is already held, we skip creating the task and let the MR stay in queue.
 // When the current resolution completes and merges, the slot is released.
-func (e *Engineer) createConflictResolutionTaskForMR(mr *MRInfo, _ ProcessResult) (string, error) { // result unused but kept for future merge diagnostics
+func (e *Engineer) createConflictResolutionTaskForMR(log *slog.Logger, mr *MRInfo, result ProcessResult) (string, error) {
 	// === MERGE SLOT GATE: Serialize conflict resolution ===
 	// Ensure merge slot exists (idempotent)
 	slotID, err := e.beads.MergeSlotEnsureExists()
 	if err != nil {
-		_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: could not ensure merge slot: %v\n", err)
+		log.Warn("could not ensure merge slot", "err", err)
 		// Continue anyway - slot is optional for now
 	} else {
 		// Try to acquire the merge slot
 		holder := e.rig.Name + "/refinery"
 		status, err := e.beads.MergeSlotAcquire(holder, false)
 		if err != nil {
-			_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: could not acquire merge slot: %v\n", err)
+			log.Warn("could not acquire merge slot", "err", err)
 			// Continue anyway - slot is optional
 		} else if !status.Available && status.Holder != "" && status.Holder != holder {
 			// Slot is held by someone else - skip creating the task
 			// The MR stays in queue and will retry when slot is released
-			_, _ = fmt.Fprintf(e.output, "[Engineer] Merge slot held by %s - deferring conflict resolution\n", status.Holder)
-			_, _ = fmt.Fprintf(e.output, "[Engineer] MR %s will retry after current resolution completes\n", mr.ID)
+			log.Info("merge slot held, deferring conflict resolution until it is released", "holder", status.Holder)
 			return "", nil // Not an error - just deferred
 		}
 		// Either we acquired the slot, or status indicates we already hold it
-		_, _ = fmt.Fprintf(e.output, "[Engineer] Acquired merge slot: %s\n", slotID)
+		log.Info("acquired merge slot", "slot", slotID)
 	}
 
 	// Get the current main SHA for conflict tracking
-	mainSHA, err := e.git.Rev("origin/" + mr.Target)
+	mainSHA, err := e.git.Rev(e.config.Remote + "/" + mr.Target)
 	if err != nil {
 		mainSHA = "unknown-sha"
 	}
@@ -703,7 +1430,7 @@ func (e *Engineer) createConflictResolutionTaskForMR(mr *MRInfo, _ ProcessResult
 
 ## Instructions
 1. Check out the branch: git checkout %s
-2. Rebase onto target: git rebase origin/%s
+2. Rebase onto target: git rebase %s/%s
 3. Resolve conflicts in your editor
 4. Complete the rebase: git add . && git rebase --continue
 5. Force-push the resolved branch: git push -f
@@ -717,8 +1444,11 @@ The Refinery will automatically retry the merge after you force-push.`,
 		mr.SourceIssue,
 		retryCount,
 		mr.Branch,
-		mr.Target,
+		e.config.Remote, mr.Target,
 	)
+	if result.ConflictReport != nil {
+		description += "\n\n" + result.ConflictReport.Markdown()
+	}
 
 	// Create the conflict resolution task
 	taskTitle := fmt.Sprintf("Resolve merge conflicts: %s", originalTitle)
@@ -736,7 +1466,7 @@ The Refinery will automatically retry the merge after you force-push.`,
 	// The conflict task's ID is returned so the MR can be blocked on it.
 	// When the task closes, the MR unblocks and re-enters the ready queue.
 
-	_, _ = fmt.Fprintf(e.output, "[Engineer] Created conflict resolution task: %s (P%d)\n", task.ID, task.Priority)
+	log.Info("created conflict resolution task", "task", task.ID, "priority", task.Priority)
 
 	return task.ID, nil
 }
@@ -760,6 +1490,11 @@ func (e *Engineer) IsBeadOpen(beadID string) (bool, error) {
 //
 // This queries beads for merge-request wisps.
 func (e *Engineer) ListReadyMRs() ([]*MRInfo, error) {
+	// Nothing is ready while the queue is paused
+	if pause, err := LoadQueuePause(e.rig.Path); err == nil && pause != nil {
+		return nil, nil
+	}
+
 	// Query beads for ready merge-request issues
 	issues, err := e.beads.ReadyWithType("merge-request")
 	if err != nil {
@@ -785,6 +1520,18 @@ func (e *Engineer) ListReadyMRs() ([]*MRInfo, error) {
 			continue
 		}
 
+		// Skip if an operator paused it (gt mq skip)
+		if fields.SkippedAt(time.Now()) {
+			continue
+		}
+
+		// Skip stacked MRs until the MR they build on has merged
+		if fields.StackedOn != "" {
+			if open, err := e.IsBeadOpen(fields.StackedOn); err == nil && open {
+				continue
+			}
+		}
+
 		// Parse convoy created_at if present
 		var convoyCreatedAt *time.Time
 		if fields.ConvoyCreatedAt != "" {
@@ -815,11 +1562,15 @@ func (e *Engineer) ListReadyMRs() ([]*MRInfo, error) {
 			ConvoyID:        fields.ConvoyID,
 			ConvoyCreatedAt: convoyCreatedAt,
 			CreatedAt:       createdAt,
+			RiskApprovedBy:  fields.RiskApprovedBy,
+			Reverts:         fields.Reverts,
+			StackedOn:       fields.StackedOn,
 		}
 		mrs = append(mrs, mr)
 	}
 
-	return mrs, nil
+	// Hold back MRs over their epic's quota or whose worker is cooling off
+	return e.filterThrottled(mrs), nil
 }
 
 // ListBlockedMRs returns MRs that are blocked by open tasks.
@@ -904,6 +1655,7 @@ func (e *Engineer) ListBlockedMRs() ([]*MRInfo, error) {
 			ConvoyCreatedAt: convoyCreatedAt,
 			CreatedAt:       createdAt,
 			BlockedBy:       blockedBy,
+			StackedOn:       fields.StackedOn,
 		}
 		mrs = append(mrs, mr)
 	}
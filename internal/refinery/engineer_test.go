@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/steveyegge/gastown/internal/beads"
 	"github.com/steveyegge/gastown/internal/rig"
 )
 
@@ -186,6 +187,145 @@ func TestEngineer_LoadConfig_InvalidPollInterval(t *testing.T) {
 	}
 }
 
+func TestEngineer_LoadConfig_Freshness(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "engineer-test-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	config := map[string]interface{}{
+		"merge_queue": map[string]interface{}{
+			"require_up_to_date": true,
+			"max_commits_behind": 5,
+		},
+	}
+
+	data, _ := json.MarshalIndent(config, "", "  ")
+	if err := os.WriteFile(filepath.Join(tmpDir, "config.json"), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	r := &rig.Rig{
+		Name: "test-rig",
+		Path: tmpDir,
+	}
+
+	e := NewEngineer(r)
+
+	if err := e.LoadConfig(); err != nil {
+		t.Fatalf("unexpected error loading config: %v", err)
+	}
+	if !e.config.RequireUpToDate {
+		t.Error("expected RequireUpToDate true")
+	}
+	if e.config.MaxCommitsBehind != 5 {
+		t.Errorf("expected MaxCommitsBehind 5, got %d", e.config.MaxCommitsBehind)
+	}
+}
+
+func TestEngineer_LoadConfig_NegativeMaxCommitsBehind(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "engineer-test-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	config := map[string]interface{}{
+		"merge_queue": map[string]interface{}{
+			"max_commits_behind": -1,
+		},
+	}
+
+	data, _ := json.MarshalIndent(config, "", "  ")
+	if err := os.WriteFile(filepath.Join(tmpDir, "config.json"), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	r := &rig.Rig{
+		Name: "test-rig",
+		Path: tmpDir,
+	}
+
+	e := NewEngineer(r)
+
+	if err := e.LoadConfig(); err == nil {
+		t.Error("expected error for negative max_commits_behind")
+	}
+}
+
+func TestEngineer_LoadConfig_Signing(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "engineer-test-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	config := map[string]interface{}{
+		"merge_queue": map[string]interface{}{
+			"sign_commits":   true,
+			"signing_format": "ssh",
+			"signing_key":    "~/.ssh/refinery_ed25519.pub",
+		},
+	}
+
+	data, _ := json.MarshalIndent(config, "", "  ")
+	if err := os.WriteFile(filepath.Join(tmpDir, "config.json"), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	r := &rig.Rig{
+		Name: "test-rig",
+		Path: tmpDir,
+	}
+
+	e := NewEngineer(r)
+
+	if err := e.LoadConfig(); err != nil {
+		t.Fatalf("unexpected error loading config: %v", err)
+	}
+	if !e.config.SignCommits {
+		t.Error("expected SignCommits true")
+	}
+	if e.config.SigningFormat != "ssh" {
+		t.Errorf("expected SigningFormat ssh, got %q", e.config.SigningFormat)
+	}
+	if e.config.SigningKey != "~/.ssh/refinery_ed25519.pub" {
+		t.Errorf("unexpected SigningKey %q", e.config.SigningKey)
+	}
+}
+
+func TestEngineer_LoadConfig_InvalidSigningFormat(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "engineer-test-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	config := map[string]interface{}{
+		"merge_queue": map[string]interface{}{
+			"sign_commits":   true,
+			"signing_format": "pgp",
+		},
+	}
+
+	data, _ := json.MarshalIndent(config, "", "  ")
+	if err := os.WriteFile(filepath.Join(tmpDir, "config.json"), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	r := &rig.Rig{
+		Name: "test-rig",
+		Path: tmpDir,
+	}
+
+	e := NewEngineer(r)
+
+	if err := e.LoadConfig(); err == nil {
+		t.Error("expected error for invalid signing_format")
+	}
+}
+
 func TestNewEngineer(t *testing.T) {
 	r := &rig.Rig{
 		Name: "test-rig",
@@ -215,3 +355,34 @@ func TestEngineer_DeleteMergedBranchesConfig(t *testing.T) {
 		t.Error("expected DeleteMergedBranches to be true by default")
 	}
 }
+
+func TestEngineer_RequireUpToDateDefault(t *testing.T) {
+	// Freshness enforcement is opt-in
+	cfg := DefaultMergeQueueConfig()
+	if cfg.RequireUpToDate {
+		t.Error("expected RequireUpToDate to be false by default")
+	}
+	if cfg.MaxCommitsBehind != 0 {
+		t.Errorf("expected MaxCommitsBehind 0 by default, got %d", cfg.MaxCommitsBehind)
+	}
+}
+
+func TestSourceWatchers(t *testing.T) {
+	source := &beads.Issue{Description: "watchers: gastown/crew/max, overseer, gastown/nux"}
+	epic := &beads.Issue{Description: "cc: overseer mayor/"}
+
+	got := sourceWatchers(source, epic, "gastown/nux")
+	want := []string{"gastown/crew/max", "overseer", "mayor/"}
+	if len(got) != len(want) {
+		t.Fatalf("sourceWatchers() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("sourceWatchers()[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+
+	if got := sourceWatchers(&beads.Issue{Description: "no watchers here"}, nil); got != nil {
+		t.Errorf("sourceWatchers() with no watchers = %v, want nil", got)
+	}
+}
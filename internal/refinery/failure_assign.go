@@ -0,0 +1,238 @@
+package refinery
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/mail"
+)
+
+// Failure assignment policies (merge_queue failure_assignment).
+const (
+	// AssignWorker hands the failure back to the MR's worker through the
+	// witness. This is the default.
+	AssignWorker = "worker"
+
+	// AssignQueue assigns the rework to a triage queue address and mails
+	// the failure there.
+	AssignQueue = "queue"
+
+	// AssignRoundRobin assigns the rework to the rig's polecats in turn,
+	// skipping the MR's worker, and alerts that polecat through the
+	// witness.
+	AssignRoundRobin = "round_robin"
+
+	// AssignUnassigned leaves the rework unassigned with a label for
+	// someone to pick up.
+	AssignUnassigned = "unassigned"
+)
+
+// Failure kinds a failure assignment is configured for.
+const (
+	// FailureKindConflict covers merge conflicts and stale branches.
+	FailureKindConflict = "conflict"
+
+	// FailureKindTests covers test failures.
+	FailureKindTests = "tests"
+
+	// FailureKindMerge covers build, push and other merge errors.
+	FailureKindMerge = "merge"
+)
+
+// DefaultTriageLabel is the label AssignUnassigned adds when none is set.
+const DefaultTriageLabel = "needs-triage"
+
+// FailureAssignment says who gets the rework for a failed MR: the
+// conflict-resolution task for conflicts, otherwise the source issue.
+type FailureAssignment struct {
+	// Policy is AssignWorker, AssignQueue, AssignRoundRobin or
+	// AssignUnassigned. Default: AssignWorker.
+	Policy string `json:"policy"`
+
+	// Queue is the triage queue address for AssignQueue (e.g.
+	// "queue:merge-triage").
+	Queue string `json:"queue,omitempty"`
+
+	// Label is added to the rework for AssignUnassigned.
+	// Default: DefaultTriageLabel.
+	Label string `json:"label,omitempty"`
+}
+
+// validateFailureAssignments checks a failure_assignment config section.
+func validateFailureAssignments(assignments map[string]FailureAssignment) error {
+	for kind, a := range assignments {
+		switch kind {
+		case FailureKindConflict, FailureKindTests, FailureKindMerge:
+		default:
+			return fmt.Errorf("invalid failure_assignment kind %q: must be %s, %s, or %s",
+				kind, FailureKindConflict, FailureKindTests, FailureKindMerge)
+		}
+		switch a.Policy {
+		case "", AssignWorker, AssignRoundRobin, AssignUnassigned:
+		case AssignQueue:
+			if !strings.HasPrefix(a.Queue, "queue:") {
+				return fmt.Errorf("failure_assignment %s: queue policy needs a queue:<name> address, got %q", kind, a.Queue)
+			}
+		default:
+			return fmt.Errorf("invalid failure_assignment %s policy %q: must be %s, %s, %s, or %s",
+				kind, a.Policy, AssignWorker, AssignQueue, AssignRoundRobin, AssignUnassigned)
+		}
+	}
+	return nil
+}
+
+// failureKind classifies a failed result for failure assignment.
+func failureKind(result ProcessResult) string {
+	switch {
+	case result.Conflict || result.Stale:
+		return FailureKindConflict
+	case result.TestsFailed:
+		return FailureKindTests
+	default:
+		return FailureKindMerge
+	}
+}
+
+// failureAssignment returns the configured assignment for a failure kind.
+func (e *Engineer) failureAssignment(kind string) FailureAssignment {
+	a := e.config.FailureAssignment[kind]
+	if a.Policy == "" {
+		a.Policy = AssignWorker
+	}
+	if a.Policy == AssignUnassigned && a.Label == "" {
+		a.Label = DefaultTriageLabel
+	}
+	return a
+}
+
+// assignFailure applies the failure assignment policy for a failed MR to
+// its rework bead (reworkID), mailing body to a triage queue if that is
+// the policy. It returns the polecat the witness should alert, or "" if
+// the witness has nothing to do.
+func (e *Engineer) assignFailure(log *slog.Logger, mr *MRInfo, kind, reworkID, body string) string {
+	a := e.failureAssignment(kind)
+	log = log.With("failure_assignment", a.Policy)
+
+	switch a.Policy {
+	case AssignQueue:
+		if reworkID != "" {
+			if err := e.beads.Update(reworkID, beads.UpdateOptions{Assignee: &a.Queue}); err != nil {
+				log.Warn("failed to assign rework to triage queue", "rework", reworkID, "err", err)
+			}
+		}
+		msg := &mail.Message{
+			From:     e.rig.Name + "/refinery",
+			To:       a.Queue,
+			Subject:  fmt.Sprintf("Merge failed (%s): %s", kind, mr.SourceIssue),
+			Body:     body,
+			Priority: mail.PriorityHigh,
+			Type:     mail.TypeTask,
+		}
+		if err := e.router.SendOrQueue(msg); err != nil {
+			log.Warn("failed to mail triage queue", "queue", a.Queue, "err", err)
+		} else {
+			log.Info("assigned failure to triage queue", "queue", a.Queue, "rework", reworkID)
+		}
+		return ""
+
+	case AssignRoundRobin:
+		polecat, err := e.nextFailurePolecat(mr.Worker)
+		if err != nil || polecat == "" {
+			log.Warn("no polecat to round-robin to, assigning back to worker", "err", err)
+			return mr.Worker
+		}
+		if reworkID != "" {
+			assignee := e.rig.Name + "/" + polecat
+			if err := e.beads.Update(reworkID, beads.UpdateOptions{Assignee: &assignee}); err != nil {
+				log.Warn("failed to assign rework", "rework", reworkID, "polecat", polecat, "err", err)
+				return mr.Worker
+			}
+		}
+		log.Info("assigned failure round-robin", "polecat", polecat, "rework", reworkID)
+		return polecat
+
+	case AssignUnassigned:
+		if reworkID != "" {
+			empty := ""
+			opts := beads.UpdateOptions{Assignee: &empty, AddLabels: []string{a.Label}}
+			if err := e.beads.Update(reworkID, opts); err != nil {
+				log.Warn("failed to label rework for triage", "rework", reworkID, "err", err)
+			} else {
+				log.Info("left failure unassigned", "label", a.Label, "rework", reworkID)
+			}
+		}
+		return ""
+
+	default:
+		return mr.Worker
+	}
+}
+
+// failureRoundRobinPath is where the last round-robin assignee is kept.
+func failureRoundRobinPath(rigPath string) string {
+	return filepath.Join(rigPath, ".runtime", "failure-round-robin")
+}
+
+// nextFailurePolecat picks the polecat after the last round-robin
+// assignee, in name order, skipping exclude (the MR's worker), and records
+// the pick.
+func (e *Engineer) nextFailurePolecat(exclude string) (string, error) {
+	names, err := rigPolecats(e.rig.Path)
+	if err != nil {
+		return "", err
+	}
+	path := failureRoundRobinPath(e.rig.Path)
+	last := ""
+	if data, err := os.ReadFile(path); err == nil {
+		last = strings.TrimSpace(string(data))
+	}
+
+	next := pickRoundRobin(names, last, exclude)
+	if next == "" {
+		return "", nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", err
+	}
+	return next, os.WriteFile(path, []byte(next+"\n"), 0644)
+}
+
+// pickRoundRobin returns the first name after last, wrapping around,
+// that isn't exclude. names must be sorted.
+func pickRoundRobin(names []string, last, exclude string) string {
+	start := sort.SearchStrings(names, last)
+	if start < len(names) && names[start] == last {
+		start++
+	}
+	for i := range names {
+		name := names[(start+i)%len(names)]
+		if name != exclude {
+			return name
+		}
+	}
+	return ""
+}
+
+// rigPolecats lists the polecats in a rig, sorted by name.
+func rigPolecats(rigPath string) ([]string, error) {
+	entries, err := os.ReadDir(filepath.Join(rigPath, "polecats"))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() && !strings.HasPrefix(entry.Name(), ".") {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
@@ -0,0 +1,139 @@
+package refinery
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/rig"
+)
+
+func TestFailureKind(t *testing.T) {
+	tests := []struct {
+		name   string
+		result ProcessResult
+		want   string
+	}{
+		{"conflict", ProcessResult{Conflict: true}, FailureKindConflict},
+		{"stale", ProcessResult{Stale: true}, FailureKindConflict},
+		{"tests", ProcessResult{TestsFailed: true}, FailureKindTests},
+		{"push", ProcessResult{Error: "push failed"}, FailureKindMerge},
+	}
+	for _, tt := range tests {
+		if got := failureKind(tt.result); got != tt.want {
+			t.Errorf("%s: failureKind() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func loadFailureAssignmentConfig(t *testing.T, assignments map[string]interface{}) (*Engineer, error) {
+	t.Helper()
+	tmpDir := t.TempDir()
+	config := map[string]interface{}{
+		"merge_queue": map[string]interface{}{
+			"failure_assignment": assignments,
+		},
+	}
+	data, _ := json.MarshalIndent(config, "", "  ")
+	if err := os.WriteFile(filepath.Join(tmpDir, "config.json"), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	e := NewEngineer(&rig.Rig{Name: "test-rig", Path: tmpDir})
+	return e, e.LoadConfig()
+}
+
+func TestEngineer_LoadConfig_FailureAssignment(t *testing.T) {
+	e, err := loadFailureAssignmentConfig(t, map[string]interface{}{
+		"conflict": map[string]interface{}{"policy": "round_robin"},
+		"tests":    map[string]interface{}{"policy": "queue", "queue": "queue:merge-triage"},
+		"merge":    map[string]interface{}{"policy": "unassigned"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error loading config: %v", err)
+	}
+
+	if a := e.failureAssignment(FailureKindConflict); a.Policy != AssignRoundRobin {
+		t.Errorf("conflict policy = %q, want %q", a.Policy, AssignRoundRobin)
+	}
+	if a := e.failureAssignment(FailureKindTests); a.Policy != AssignQueue || a.Queue != "queue:merge-triage" {
+		t.Errorf("tests assignment = %+v", a)
+	}
+	if a := e.failureAssignment(FailureKindMerge); a.Policy != AssignUnassigned || a.Label != DefaultTriageLabel {
+		t.Errorf("merge assignment = %+v, want unassigned with %q", a, DefaultTriageLabel)
+	}
+
+	// Unconfigured kinds go back to the worker
+	e.config.FailureAssignment = nil
+	if a := e.failureAssignment(FailureKindTests); a.Policy != AssignWorker {
+		t.Errorf("default policy = %q, want %q", a.Policy, AssignWorker)
+	}
+}
+
+func TestEngineer_LoadConfig_InvalidFailureAssignment(t *testing.T) {
+	tests := []struct {
+		name        string
+		assignments map[string]interface{}
+	}{
+		{"unknown kind", map[string]interface{}{"lint": map[string]interface{}{"policy": "worker"}}},
+		{"unknown policy", map[string]interface{}{"tests": map[string]interface{}{"policy": "mayor"}}},
+		{"queue without address", map[string]interface{}{"tests": map[string]interface{}{"policy": "queue"}}},
+		{"queue not a queue address", map[string]interface{}{"tests": map[string]interface{}{"policy": "queue", "queue": "gastown/witness"}}},
+	}
+	for _, tt := range tests {
+		if _, err := loadFailureAssignmentConfig(t, tt.assignments); err == nil {
+			t.Errorf("%s: expected error", tt.name)
+		}
+	}
+}
+
+func TestPickRoundRobin(t *testing.T) {
+	names := []string{"furiosa", "nux", "slit", "toast"}
+	tests := []struct {
+		last, exclude, want string
+	}{
+		{"", "", "furiosa"},
+		{"nux", "", "slit"},
+		{"toast", "", "furiosa"},
+		{"nux", "slit", "toast"},
+		{"gone", "", "nux"},
+	}
+	for _, tt := range tests {
+		if got := pickRoundRobin(names, tt.last, tt.exclude); got != tt.want {
+			t.Errorf("pickRoundRobin(last=%q, exclude=%q) = %q, want %q", tt.last, tt.exclude, got, tt.want)
+		}
+	}
+	if got := pickRoundRobin([]string{"nux"}, "", "nux"); got != "" {
+		t.Errorf("only the worker available: got %q, want none", got)
+	}
+}
+
+func TestNextFailurePolecat(t *testing.T) {
+	tmpDir := t.TempDir()
+	for _, name := range []string{"nux", "toast", "furiosa", ".pending"} {
+		if err := os.MkdirAll(filepath.Join(tmpDir, "polecats", name), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	e := NewEngineer(&rig.Rig{Name: "test-rig", Path: tmpDir})
+
+	var got []string
+	for i := 0; i < 4; i++ {
+		name, err := e.nextFailurePolecat("nux")
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, name)
+	}
+	want := []string{"furiosa", "toast", "furiosa", "toast"}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("round robin = %v, want %v", got, want)
+		}
+	}
+
+	empty := NewEngineer(&rig.Rig{Name: "test-rig", Path: t.TempDir()})
+	if name, err := empty.nextFailurePolecat("nux"); err != nil || name != "" {
+		t.Errorf("rig without polecats: got %q, %v", name, err)
+	}
+}
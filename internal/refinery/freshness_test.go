@@ -0,0 +1,83 @@
+package refinery
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newStaleBranchEngineer returns an engineer whose repo has a polecat/nux
+// branch that is two commits behind origin/main.
+func newStaleBranchEngineer(t *testing.T) *Engineer {
+	t.Helper()
+	e, repo := newGuardEngineer(t)
+
+	runGit(t, repo, "checkout", "-b", "polecat/nux")
+	os.WriteFile(filepath.Join(repo, "feature.txt"), []byte("feature"), 0644)
+	runGit(t, repo, "add", ".")
+	runGit(t, repo, "commit", "-m", "add feature")
+
+	runGit(t, repo, "checkout", "main")
+	for _, name := range []string{"a.txt", "b.txt"} {
+		os.WriteFile(filepath.Join(repo, name), []byte(name), 0644)
+		runGit(t, repo, "add", name)
+		runGit(t, repo, "commit", "-m", "add "+name)
+	}
+	runGit(t, repo, "push", "origin", "main")
+
+	e.config.RunTests = false
+	e.config.RequireUpToDate = true
+	return e
+}
+
+func TestDoMerge_FreshnessWithinLimit(t *testing.T) {
+	e := newStaleBranchEngineer(t)
+	e.config.MaxCommitsBehind = 2
+
+	if result := e.checkFreshness(e.log, "polecat/nux", "main"); !result.Success || result.Stale {
+		t.Fatalf("checkFreshness() = %+v, want success within max_commits_behind", result)
+	}
+
+	result := e.doMerge(context.Background(), e.log, &MRInfo{ID: "gt-mr1", Branch: "polecat/nux", Target: "main"})
+	if result.Stale {
+		t.Fatalf("doMerge() rejected a branch within max_commits_behind: %s", result.Error)
+	}
+	if !result.Success {
+		t.Errorf("doMerge() = %+v, want the merge to proceed", result)
+	}
+}
+
+func TestDoMerge_FreshnessBeyondLimit(t *testing.T) {
+	e := newStaleBranchEngineer(t)
+	e.config.MaxCommitsBehind = 1
+
+	result := e.doMerge(context.Background(), e.log, &MRInfo{ID: "gt-mr1", Branch: "polecat/nux", Target: "main"})
+	if result.Success || !result.Stale {
+		t.Fatalf("doMerge() = %+v, want a stale rejection", result)
+	}
+	if !strings.Contains(result.Error, "2 commits behind main (max 1)") {
+		t.Errorf("error = %q, want the commit count and limit", result.Error)
+	}
+	if result.MergeCommit != "" {
+		t.Errorf("stale branch was merged as %s", result.MergeCommit)
+	}
+}
+
+func TestProcessMRInfo_UnsignedMergePausesQueue(t *testing.T) {
+	e := newStaleBranchEngineer(t)
+	e.config.RequireUpToDate = false
+	e.config.SignCommits = true // but no signing key set up
+
+	result := e.ProcessMRInfo(context.Background(), &MRInfo{ID: "gt-mr1", Branch: "polecat/nux", Target: "main"})
+	if result.Success || !result.QueuePaused || result.Failure != FailureUnsigned {
+		t.Fatalf("ProcessMRInfo() = %+v, want a queue pause for the unsigned merge", result)
+	}
+	if result.Failure.ShouldAssignToWorker() {
+		t.Error("signing failures should not go back to the worker")
+	}
+	if pause, err := LoadQueuePause(e.rig.Path); err != nil || pause == nil || pause.MR != "gt-mr1" {
+		t.Errorf("queue pause = %+v, %v; want one naming gt-mr1", pause, err)
+	}
+}
@@ -0,0 +1,180 @@
+package refinery
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/mail"
+)
+
+// QueueOperator is alerted when a target branch check pauses the queue.
+const QueueOperator = "overseer"
+
+// QueuePause records why the merge queue stopped taking MRs. While the
+// pause file exists, ListReadyMRs returns nothing and ProcessMRInfo
+// refuses to merge; 'gt mq resume' removes it.
+type QueuePause struct {
+	// Reason explains what is wrong, e.g. a dirty refinery checkout.
+	Reason string `json:"reason"`
+
+	// Target is the target branch that failed its checks, if any.
+	Target string `json:"target,omitempty"`
+
+	// MR is the merge request being processed when the queue paused.
+	MR string `json:"mr,omitempty"`
+
+	// PausedAt is when the queue paused.
+	PausedAt time.Time `json:"paused_at"`
+
+	// PausedBy is who paused the queue: the refinery or an operator.
+	PausedBy string `json:"paused_by,omitempty"`
+}
+
+// QueuePausePath returns the merge queue pause file for a rig.
+func QueuePausePath(rigPath string) string {
+	return filepath.Join(rigPath, ".runtime", "merge-queue-paused.json")
+}
+
+// LoadQueuePause returns the rig's queue pause, or nil if the queue is
+// running.
+func LoadQueuePause(rigPath string) (*QueuePause, error) {
+	data, err := os.ReadFile(QueuePausePath(rigPath))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var p QueuePause
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("parsing queue pause: %w", err)
+	}
+	return &p, nil
+}
+
+// PauseQueue stops the rig's merge queue until ResumeQueue.
+func PauseQueue(rigPath string, p QueuePause) error {
+	if p.PausedAt.IsZero() {
+		p.PausedAt = time.Now()
+	}
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return err
+	}
+	path := QueuePausePath(rigPath)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// ResumeQueue lifts a queue pause. Resuming a running queue is a no-op.
+func ResumeQueue(rigPath string) error {
+	if err := os.Remove(QueuePausePath(rigPath)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// checkTargetReady verifies, before the target is checked out, that the
+// refinery checkout has no changes to tracked files and that target exists
+// locally or on the remote. Either problem would otherwise surface as a
+// confusing git error partway through the merge. Untracked files don't
+// get in the way of a merge and are ignored.
+func (e *Engineer) checkTargetReady(log *slog.Logger, target string) ProcessResult {
+	log.Debug("checking target branch", "target", target)
+	status, err := e.git.Status()
+	if err != nil {
+		return queuePausedResult(fmt.Sprintf("checking refinery checkout: %v", err))
+	}
+	var changed []string
+	for _, files := range [][]string{status.Modified, status.Added, status.Deleted} {
+		changed = append(changed, files...)
+	}
+	if len(changed) > 0 {
+		return queuePausedResult(fmt.Sprintf("refinery checkout %s has local changes: %s",
+			e.workDir, strings.Join(changed, ", ")))
+	}
+
+	remote := e.config.Remote
+	if err := e.git.Fetch(remote); err != nil {
+		log.Warn("fetch failed, checking target against last fetch", "remote", remote, "err", err)
+	}
+	local, err := e.git.BranchExists(target)
+	if err != nil {
+		return queuePausedResult(fmt.Sprintf("checking target branch %s: %v", target, err))
+	}
+	if !local && !e.remoteTargetExists(target) {
+		return queuePausedResult(fmt.Sprintf("target branch %s does not exist locally or on %s", target, remote))
+	}
+	return ProcessResult{Success: true}
+}
+
+// checkTargetSynced verifies, after the target is pulled, that it has every
+// commit on the remote's copy. A target still behind (e.g. it diverged and
+// the pull failed) would be pushed over or rejected after merging.
+func (e *Engineer) checkTargetSynced(log *slog.Logger, target string) ProcessResult {
+	if !e.remoteTargetExists(target) {
+		return ProcessResult{Success: true} // Not pushed yet
+	}
+	remoteRef := e.config.Remote + "/" + target
+	behind, err := e.git.CommitsAhead(target, remoteRef)
+	if err != nil {
+		return queuePausedResult(fmt.Sprintf("comparing %s with %s: %v", target, remoteRef, err))
+	}
+	if behind > 0 {
+		return queuePausedResult(fmt.Sprintf("target branch %s is %d commits behind %s", target, behind, remoteRef))
+	}
+	log.Debug("target branch in sync", "target", target, "remote", e.config.Remote)
+	return ProcessResult{Success: true}
+}
+
+// remoteTargetExists reports whether the last fetch saw target on the remote.
+func (e *Engineer) remoteTargetExists(target string) bool {
+	_, err := e.git.Rev("refs/remotes/" + e.config.Remote + "/" + target)
+	return err == nil
+}
+
+// queuePausedResult is the result for an MR stopped by a target branch check.
+func queuePausedResult(reason string) ProcessResult {
+	return ProcessResult{
+		Success:     false,
+		QueuePaused: true,
+		Error:       reason,
+	}
+}
+
+// pauseQueue pauses the queue after a failed target branch check and alerts
+// the QueueOperator, so the problem is fixed once rather than failing every
+// MR in turn.
+func (e *Engineer) pauseQueue(log *slog.Logger, mr *MRInfo, reason string) {
+	pause := QueuePause{
+		Reason:   reason,
+		Target:   mr.Target,
+		MR:       mr.ID,
+		PausedBy: e.rig.Name + "/refinery",
+	}
+	if err := PauseQueue(e.rig.Path, pause); err != nil {
+		log.Warn("failed to pause merge queue", "err", err)
+	}
+	log.Error("merge queue paused", "reason", reason,
+		"resume_with", fmt.Sprintf("gt mq resume %s", e.rig.Name))
+
+	msg := &mail.Message{
+		From:    e.rig.Name + "/refinery",
+		To:      QueueOperator,
+		Subject: fmt.Sprintf("Merge queue paused: %s", e.rig.Name),
+		Body: fmt.Sprintf("The %s merge queue stopped before merging %s (%s into %s).\n\nReason: %s\n\n"+
+			"No MRs will merge until the problem is fixed and the queue is resumed:\n  gt mq resume %s",
+			e.rig.Name, mr.ID, mr.Branch, mr.Target, reason, e.rig.Name),
+		Priority: mail.PriorityHigh,
+	}
+	if err := e.router.SendOrQueue(msg); err != nil {
+		log.Warn("failed to alert operator", "to", QueueOperator, "err", err)
+	}
+}
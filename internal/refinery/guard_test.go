@@ -0,0 +1,122 @@
+package refinery
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/rig"
+)
+
+// newGuardEngineer returns an engineer whose refinery checkout is a clone
+// of a bare origin with a main branch.
+func newGuardEngineer(t *testing.T) (*Engineer, string) {
+	t.Helper()
+	rigPath := t.TempDir()
+	origin := filepath.Join(rigPath, "origin.git")
+	repo := filepath.Join(rigPath, "refinery", "rig")
+	runGit(t, rigPath, "init", "--bare", "-b", "main", origin)
+	runGit(t, rigPath, "clone", origin, repo)
+	runGit(t, repo, "config", "user.email", "test@test.com")
+	runGit(t, repo, "config", "user.name", "Test")
+	os.WriteFile(filepath.Join(repo, "README.md"), []byte("readme"), 0644)
+	runGit(t, repo, "add", ".")
+	runGit(t, repo, "commit", "-m", "initial")
+	runGit(t, repo, "push", "origin", "main")
+
+	e := NewEngineer(&rig.Rig{Name: "test-rig", Path: rigPath})
+	e.SetOutput(&bytes.Buffer{})
+	return e, repo
+}
+
+func TestCheckTargetReady(t *testing.T) {
+	e, repo := newGuardEngineer(t)
+
+	if result := e.checkTargetReady(e.log, "main"); !result.Success {
+		t.Fatalf("clean checkout failed: %s", result.Error)
+	}
+
+	// Untracked files don't block merges
+	os.WriteFile(filepath.Join(repo, "notes.txt"), []byte("x"), 0644)
+	if result := e.checkTargetReady(e.log, "main"); !result.Success {
+		t.Errorf("untracked file failed the check: %s", result.Error)
+	}
+
+	if result := e.checkTargetReady(e.log, "release"); result.Success || !result.QueuePaused ||
+		!strings.Contains(result.Error, "does not exist") {
+		t.Errorf("missing target: %+v", result)
+	}
+
+	os.WriteFile(filepath.Join(repo, "README.md"), []byte("edited"), 0644)
+	if result := e.checkTargetReady(e.log, "main"); result.Success || !result.QueuePaused ||
+		!strings.Contains(result.Error, "local changes") {
+		t.Errorf("dirty checkout: %+v", result)
+	}
+}
+
+func TestCheckTargetSynced(t *testing.T) {
+	e, repo := newGuardEngineer(t)
+
+	// Someone else pushes to main; the fetch sees it but main hasn't moved
+	other := filepath.Join(t.TempDir(), "other")
+	runGit(t, repo, "clone", filepath.Join(e.rig.Path, "origin.git"), other)
+	runGit(t, other, "config", "user.email", "test@test.com")
+	runGit(t, other, "config", "user.name", "Test")
+	os.WriteFile(filepath.Join(other, "a.txt"), []byte("a"), 0644)
+	runGit(t, other, "add", ".")
+	runGit(t, other, "commit", "-m", "add a")
+	runGit(t, other, "push", "origin", "main")
+	runGit(t, repo, "fetch", "origin")
+
+	result := e.checkTargetSynced(e.log, "main")
+	if result.Success || !result.QueuePaused || !strings.Contains(result.Error, "1 commits behind origin/main") {
+		t.Errorf("behind target: %+v", result)
+	}
+
+	runGit(t, repo, "merge", "--ff-only", "origin/main")
+	if result := e.checkTargetSynced(e.log, "main"); !result.Success {
+		t.Errorf("synced target failed: %s", result.Error)
+	}
+}
+
+func TestProcessMRInfo_PausesQueue(t *testing.T) {
+	e, repo := newGuardEngineer(t)
+	runGit(t, repo, "branch", "polecat/nux/a")
+	os.WriteFile(filepath.Join(repo, "README.md"), []byte("edited"), 0644)
+
+	mr := &MRInfo{ID: "mr-1", Branch: "polecat/nux/a", Target: "main"}
+	result := e.ProcessMRInfo(context.Background(), mr)
+	if result.Success || !result.QueuePaused {
+		t.Fatalf("dirty checkout: %+v", result)
+	}
+	pause, err := LoadQueuePause(e.rig.Path)
+	if err != nil || pause == nil {
+		t.Fatalf("queue not paused: %v", err)
+	}
+	if pause.MR != "mr-1" || pause.Target != "main" || !strings.Contains(pause.Reason, "local changes") {
+		t.Errorf("pause = %+v", pause)
+	}
+
+	// Fixed, but still paused until resumed
+	runGit(t, repo, "checkout", "README.md")
+	if result := e.ProcessMRInfo(context.Background(), mr); !result.QueuePaused ||
+		!strings.HasPrefix(result.Error, "merge queue paused: ") {
+		t.Errorf("paused queue merged: %+v", result)
+	}
+	if ready, err := e.ListReadyMRs(); err != nil || len(ready) != 0 {
+		t.Errorf("ListReadyMRs on a paused queue = %v, %v", ready, err)
+	}
+
+	if err := ResumeQueue(e.rig.Path); err != nil {
+		t.Fatal(err)
+	}
+	if pause, _ := LoadQueuePause(e.rig.Path); pause != nil {
+		t.Errorf("queue still paused after resume: %+v", pause)
+	}
+	if err := ResumeQueue(e.rig.Path); err != nil {
+		t.Errorf("resuming a running queue: %v", err)
+	}
+}
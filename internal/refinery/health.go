@@ -0,0 +1,194 @@
+package refinery
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/mail"
+)
+
+// DefaultMinFreeDiskMB is the default free space required to process an MR.
+const DefaultMinFreeDiskMB = 1024
+
+// fsckInterval is how long a passing git fsck is trusted. fsck walks the
+// whole shared repository, so it runs at most this often rather than before
+// every MR; a failure is never cached, so recovery is seen at once.
+const fsckInterval = 10 * time.Minute
+
+// errDiskSpaceUnsupported is returned by freeDiskMB where free space can't
+// be measured; the disk check is then skipped.
+var errDiskSpaceUnsupported = errors.New("free disk space not supported on this platform")
+
+// HealthIssue records why the refinery last skipped processing. It is kept
+// while the problem lasts so the operator is alerted once per problem
+// rather than on every iteration.
+type HealthIssue struct {
+	// Problems lists what is wrong, e.g. low disk space.
+	Problems []string `json:"problems"`
+
+	// Checks names the failing check behind each problem ("disk",
+	// "merge_head", "fsck"). The operator is alerted again only when these
+	// change, not when a problem's details (e.g. the free MB) do.
+	Checks []string `json:"checks,omitempty"`
+
+	// Since is when the problems were first seen.
+	Since time.Time `json:"since"`
+}
+
+// HealthIssuePath returns the file recording a rig's refinery health issue.
+func HealthIssuePath(rigPath string) string {
+	return filepath.Join(rigPath, ".runtime", "refinery-unhealthy.json")
+}
+
+// fsckPassedPath returns the file recording when git fsck last passed in a
+// rig's refinery checkout. It outlives the Engineer, which each gt
+// invocation builds afresh.
+func fsckPassedPath(rigPath string) string {
+	return filepath.Join(rigPath, ".runtime", "refinery-fsck-passed")
+}
+
+// healthProblem is one failed preflight check.
+type healthProblem struct {
+	check   string // stable name of the check, e.g. "disk"
+	message string // what is wrong, with details
+}
+
+// LoadHealthIssue returns the rig's current refinery health issue, or nil
+// if the last check passed.
+func LoadHealthIssue(rigPath string) (*HealthIssue, error) {
+	data, err := os.ReadFile(HealthIssuePath(rigPath))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var h HealthIssue
+	if err := json.Unmarshal(data, &h); err != nil {
+		return nil, fmt.Errorf("parsing health issue: %w", err)
+	}
+	return &h, nil
+}
+
+// checkHealth is the preflight run before each MR: the checkout's
+// filesystem has MinFreeDiskMB free, no merge was left unfinished
+// (MERGE_HEAD), and a quick git fsck passes (at most every fsckInterval).
+// Any of these would otherwise fail the merge partway and leave the tree
+// dirty. An unhealthy
+// environment skips the MR, which stays queued, and alerts the
+// QueueOperator; processing resumes by itself once the check passes.
+func (e *Engineer) checkHealth(log *slog.Logger) ProcessResult {
+	problems := e.healthProblems(log)
+	if len(problems) == 0 {
+		if prev, _ := LoadHealthIssue(e.rig.Path); prev != nil {
+			log.Info("refinery environment recovered", "unhealthy_since", prev.Since)
+			_ = os.Remove(HealthIssuePath(e.rig.Path))
+		}
+		return ProcessResult{Success: true}
+	}
+
+	messages := make([]string, len(problems))
+	for i, p := range problems {
+		messages[i] = p.message
+	}
+	reason := strings.Join(messages, "; ")
+	log.Error("refinery environment unhealthy, skipping MR", "reason", reason)
+	e.reportHealthIssue(log, problems)
+	return ProcessResult{
+		Success:   false,
+		Unhealthy: true,
+		Error:     "refinery environment unhealthy: " + reason,
+	}
+}
+
+// healthProblems runs the preflight checks and describes each failure.
+func (e *Engineer) healthProblems(log *slog.Logger) []healthProblem {
+	var problems []healthProblem
+	if need := e.config.MinFreeDiskMB; need > 0 {
+		free, err := freeDiskMB(e.workDir)
+		switch {
+		case errors.Is(err, errDiskSpaceUnsupported):
+		case err != nil:
+			log.Warn("failed to check free disk space", "dir", e.workDir, "err", err)
+		case free < uint64(need):
+			problems = append(problems, healthProblem{"disk",
+				fmt.Sprintf("only %d MB free on %s (need %d MB)", free, e.workDir, need)})
+		}
+	}
+	if e.git.MergeInProgress() {
+		problems = append(problems, healthProblem{"merge_head",
+			fmt.Sprintf("unfinished merge in %s (MERGE_HEAD exists; run 'git merge --abort' there)", e.workDir)})
+	}
+	passed := fsckPassedPath(e.rig.Path)
+	if info, err := os.Stat(passed); err != nil || time.Since(info.ModTime()) >= fsckInterval {
+		if err := e.git.FsckQuick(); err != nil {
+			problems = append(problems, healthProblem{"fsck", fmt.Sprintf("git fsck failed in %s: %v", e.workDir, err)})
+			_ = os.Remove(passed)
+		} else if err := touchFile(passed); err != nil {
+			log.Warn("failed to record git fsck pass", "err", err)
+		}
+	}
+	return problems
+}
+
+// touchFile creates path (and its directory) if needed and sets its
+// modification time to now.
+func touchFile(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	now := time.Now()
+	return os.Chtimes(path, now, now)
+}
+
+// reportHealthIssue records the problems and alerts the QueueOperator,
+// unless the same checks were already reported as failing.
+func (e *Engineer) reportHealthIssue(log *slog.Logger, problems []healthProblem) {
+	issue := HealthIssue{Since: time.Now()}
+	for _, p := range problems {
+		issue.Problems = append(issue.Problems, p.message)
+		issue.Checks = append(issue.Checks, p.check)
+	}
+	if prev, _ := LoadHealthIssue(e.rig.Path); prev != nil {
+		if strings.Join(prev.Checks, ",") == strings.Join(issue.Checks, ",") {
+			return // Already alerted
+		}
+		issue.Since = prev.Since
+	}
+
+	if data, err := json.MarshalIndent(issue, "", "  "); err == nil {
+		path := HealthIssuePath(e.rig.Path)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err == nil {
+			err = os.WriteFile(path, data, 0644)
+		}
+		if err != nil {
+			log.Warn("failed to record health issue", "err", err)
+		}
+	}
+
+	msg := &mail.Message{
+		From:    e.rig.Name + "/refinery",
+		To:      QueueOperator,
+		Subject: fmt.Sprintf("Refinery unhealthy: %s", e.rig.Name),
+		Body: fmt.Sprintf("The %s refinery is skipping MRs until its environment is fixed:\n\n  - %s\n\n"+
+			"MRs stay queued and processing resumes on its own once the checks pass.",
+			e.rig.Name, strings.Join(issue.Problems, "\n  - ")),
+		Priority: mail.PriorityHigh,
+	}
+	if err := e.router.SendOrQueue(msg); err != nil {
+		log.Warn("failed to alert operator", "to", QueueOperator, "err", err)
+	}
+}
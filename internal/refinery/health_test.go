@@ -0,0 +1,109 @@
+package refinery
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCheckHealth(t *testing.T) {
+	e, repo := newGuardEngineer(t)
+
+	if result := e.checkHealth(e.log); !result.Success {
+		t.Fatalf("healthy checkout failed: %s", result.Error)
+	}
+
+	// A merge interrupted by a conflict leaves MERGE_HEAD behind
+	runGit(t, repo, "checkout", "-b", "feature")
+	os.WriteFile(filepath.Join(repo, "README.md"), []byte("feature"), 0644)
+	runGit(t, repo, "commit", "-am", "feature")
+	runGit(t, repo, "checkout", "main")
+	os.WriteFile(filepath.Join(repo, "README.md"), []byte("main"), 0644)
+	runGit(t, repo, "commit", "-am", "main")
+	_ = e.git.Merge("feature")
+
+	result := e.checkHealth(e.log)
+	if result.Success || !result.Unhealthy || !strings.Contains(result.Error, "MERGE_HEAD") {
+		t.Fatalf("unfinished merge: %+v", result)
+	}
+	issue, err := LoadHealthIssue(e.rig.Path)
+	if err != nil || issue == nil || len(issue.Problems) != 1 {
+		t.Fatalf("health issue not recorded: %+v, %v", issue, err)
+	}
+
+	// Not enough disk adds a problem but keeps the original start time
+	e.config.MinFreeDiskMB = 1 << 40
+	if result := e.checkHealth(e.log); !strings.Contains(result.Error, "MB free") {
+		t.Errorf("low disk: %+v", result)
+	}
+	again, _ := LoadHealthIssue(e.rig.Path)
+	if len(again.Problems) != 2 || !again.Since.Equal(issue.Since) {
+		t.Errorf("health issue = %+v, want 2 problems since %s", again, issue.Since)
+	}
+
+	// A changed free-space figure is the same problem: no new alert
+	again.Problems[1] = "only 1 MB free (need 2 MB)"
+	data, _ := json.Marshal(again)
+	if err := os.WriteFile(HealthIssuePath(e.rig.Path), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	e.checkHealth(e.log)
+	if kept, _ := LoadHealthIssue(e.rig.Path); kept.Problems[1] != again.Problems[1] {
+		t.Errorf("health issue rewritten for the same checks: %+v", kept)
+	}
+
+	e.config.MinFreeDiskMB = 0
+	if err := e.git.AbortMerge(); err != nil {
+		t.Fatal(err)
+	}
+	if result := e.checkHealth(e.log); !result.Success {
+		t.Fatalf("recovered checkout failed: %s", result.Error)
+	}
+	if issue, _ := LoadHealthIssue(e.rig.Path); issue != nil {
+		t.Error("health issue kept after recovery")
+	}
+}
+
+func TestCheckHealth_FsckInterval(t *testing.T) {
+	e, repo := newGuardEngineer(t)
+
+	if result := e.checkHealth(e.log); !result.Success {
+		t.Fatalf("healthy checkout failed: %s", result.Error)
+	}
+
+	// A ref to a missing object fails fsck, but a recent pass is trusted
+	broken := filepath.Join(repo, ".git", "refs", "heads", "broken")
+	if err := os.WriteFile(broken, []byte(strings.Repeat("ab", 20)+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if result := e.checkHealth(e.log); !result.Success {
+		t.Fatalf("fsck re-run within interval: %s", result.Error)
+	}
+
+	// The pass is recorded on disk, so a fresh Engineer trusts it too
+	fresh := NewEngineer(e.rig)
+	fresh.SetOutput(&bytes.Buffer{})
+	if result := fresh.checkHealth(fresh.log); !result.Success {
+		t.Fatalf("fsck re-run by a new engineer within interval: %s", result.Error)
+	}
+
+	old := time.Now().Add(-fsckInterval)
+	if err := os.Chtimes(fsckPassedPath(e.rig.Path), old, old); err != nil {
+		t.Fatal(err)
+	}
+	if result := e.checkHealth(e.log); result.Success || !strings.Contains(result.Error, "git fsck failed") {
+		t.Fatalf("corrupt repo after interval: %+v", result)
+	}
+
+	// Failures are not cached
+	if err := os.Remove(broken); err != nil {
+		t.Fatal(err)
+	}
+	if result := e.checkHealth(e.log); !result.Success {
+		t.Fatalf("repaired repo failed: %s", result.Error)
+	}
+}
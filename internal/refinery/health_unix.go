@@ -0,0 +1,15 @@
+//go:build !windows
+
+package refinery
+
+import "syscall"
+
+// freeDiskMB returns the space available to unprivileged users on the
+// filesystem holding path, in MB.
+func freeDiskMB(path string) (uint64, error) {
+	var st syscall.Statfs_t
+	if err := syscall.Statfs(path, &st); err != nil {
+		return 0, err
+	}
+	return st.Bavail * uint64(st.Bsize) / (1024 * 1024), nil //nolint:gosec // G115: Bsize is positive
+}
@@ -0,0 +1,7 @@
+//go:build windows
+
+package refinery
+
+func freeDiskMB(path string) (uint64, error) {
+	return 0, errDiskSpaceUnsupported
+}
@@ -0,0 +1,219 @@
+package refinery
+
+import (
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+// DefaultIntegrationBranchTemplate names an epic's integration branch when
+// the rig sets no merge_queue.integration_branch_template.
+const DefaultIntegrationBranchTemplate = "integration/{epic}"
+
+// integrationBranchField is the epic description field recording the
+// epic's integration branch.
+const integrationBranchField = "integration_branch:"
+
+// invalidBranchCharsRegex matches characters that are invalid in git branch names.
+// Git branch names cannot contain: ~ ^ : \ space, .., or @{
+var invalidBranchCharsRegex = regexp.MustCompile(`[~^:\s\\]|\.\.|@\{`)
+
+// IntegrationBranchName expands an integration branch template with variables.
+// Variables supported:
+//   - {epic}: Full epic ID (e.g., "RA-123")
+//   - {prefix}: Epic prefix before first hyphen (e.g., "RA")
+//   - {user}: Git user.name (e.g., "klauern")
+//
+// If template is empty, uses DefaultIntegrationBranchTemplate.
+func IntegrationBranchName(template, epicID string) string {
+	if template == "" {
+		template = DefaultIntegrationBranchTemplate
+	}
+
+	result := template
+	result = strings.ReplaceAll(result, "{epic}", epicID)
+	result = strings.ReplaceAll(result, "{prefix}", EpicPrefix(epicID))
+
+	// Git user (optional - leaves placeholder if not available)
+	if user := gitUserName(); user != "" {
+		result = strings.ReplaceAll(result, "{user}", user)
+	}
+
+	return result
+}
+
+// EpicPrefix extracts the prefix from an epic ID (before the first hyphen).
+// Examples: "RA-123" -> "RA", "PROJ-456" -> "PROJ", "abc" -> "abc"
+func EpicPrefix(epicID string) string {
+	if idx := strings.Index(epicID, "-"); idx > 0 {
+		return epicID[:idx]
+	}
+	return epicID
+}
+
+// gitUserName returns the git user.name config value, or empty if not set.
+func gitUserName() string {
+	out, err := exec.Command("git", "config", "user.name").Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// ValidateBranchName checks if a branch name is valid for git.
+func ValidateBranchName(branchName string) error {
+	if branchName == "" {
+		return fmt.Errorf("branch name cannot be empty")
+	}
+	if invalidBranchCharsRegex.MatchString(branchName) {
+		return fmt.Errorf("branch name %q contains invalid characters (~ ^ : \\ space, .., or @{)", branchName)
+	}
+	if strings.HasSuffix(branchName, ".lock") {
+		return fmt.Errorf("branch name %q cannot end with .lock", branchName)
+	}
+	if strings.HasPrefix(branchName, "/") || strings.HasSuffix(branchName, "/") {
+		return fmt.Errorf("branch name %q cannot start or end with /", branchName)
+	}
+	if strings.HasPrefix(branchName, ".") || strings.HasSuffix(branchName, ".") {
+		return fmt.Errorf("branch name %q cannot start or end with .", branchName)
+	}
+	if strings.Contains(branchName, "//") {
+		return fmt.Errorf("branch name %q cannot contain consecutive slashes", branchName)
+	}
+	return nil
+}
+
+// IntegrationBranchTemplate returns the rig's integration branch template
+// (merge_queue.integration_branch_template), or the default.
+func IntegrationBranchTemplate(rigPath string) string {
+	settings, err := config.LoadRigSettings(filepath.Join(rigPath, "settings", "config.json"))
+	if err == nil && settings.MergeQueue != nil && settings.MergeQueue.IntegrationBranchTemplate != "" {
+		return settings.MergeQueue.IntegrationBranchTemplate
+	}
+	return DefaultIntegrationBranchTemplate
+}
+
+// IntegrationBranchesEnabled reports whether the rig's merge queue merges
+// into epic integration branches (merge_queue.integration_branches, on by
+// default).
+func IntegrationBranchesEnabled(rigPath string) bool {
+	settings, err := config.LoadRigSettings(filepath.Join(rigPath, "settings", "config.json"))
+	if err != nil || settings.MergeQueue == nil {
+		return config.DefaultMergeQueueConfig().IntegrationBranches
+	}
+	return settings.MergeQueue.IntegrationBranches
+}
+
+// IntegrationBranchField extracts the integration_branch field from an
+// epic's description. Returns empty string if the field is not found.
+func IntegrationBranchField(description string) string {
+	for _, line := range strings.Split(description, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(strings.ToLower(trimmed), integrationBranchField) {
+			return strings.TrimSpace(trimmed[len(integrationBranchField):])
+		}
+	}
+	return ""
+}
+
+// SetIntegrationBranchField adds or updates the integration_branch field
+// in a description. A new field goes first.
+func SetIntegrationBranchField(description, branchName string) string {
+	fieldLine := "integration_branch: " + branchName
+	if description == "" {
+		return fieldLine
+	}
+
+	lines := strings.Split(description, "\n")
+	found := false
+	for i, line := range lines {
+		if strings.HasPrefix(strings.ToLower(strings.TrimSpace(line)), integrationBranchField) {
+			lines[i] = fieldLine
+			found = true
+		}
+	}
+	if !found {
+		lines = append([]string{fieldLine}, lines...)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// EnsureIntegrationBranch creates branch from <remote>/base and pushes it to
+// the merge queue's remote and push mirrors, unless the remote already has
+// it. Safe to retry after a partial failure.
+func (e *Engineer) EnsureIntegrationBranch(branch, base string) error {
+	if err := ValidateBranchName(branch); err != nil {
+		return fmt.Errorf("invalid branch name: %w", err)
+	}
+	remote := e.config.Remote
+	if err := e.git.Fetch(remote); err != nil {
+		return fmt.Errorf("fetching from %s: %w", remote, err)
+	}
+	exists, err := e.git.RemoteBranchExists(remote, branch)
+	if err != nil {
+		return fmt.Errorf("checking %s for %s: %w", remote, branch, err)
+	}
+	if exists {
+		return nil
+	}
+
+	local, err := e.git.BranchExists(branch)
+	if err != nil {
+		return fmt.Errorf("checking branch existence: %w", err)
+	}
+	if !local {
+		if err := e.git.CreateBranchFrom(branch, remote+"/"+base); err != nil {
+			return fmt.Errorf("creating branch %s from %s: %w", branch, base, err)
+		}
+	}
+	if err := e.git.Push(remote, branch, false); err != nil {
+		return fmt.Errorf("pushing %s to %s: %w%s", branch, remote, err, e.credentialHint(remote))
+	}
+	e.pushMirrors(e.log, branch)
+	return nil
+}
+
+// RecordIntegrationBranch records branch as the epic's integration branch,
+// where 'gt mq submit' and polecat spawning look for it.
+func RecordIntegrationBranch(bd *beads.Beads, epicID, branch string) error {
+	epic, err := bd.Show(epicID)
+	if err != nil {
+		return fmt.Errorf("fetching epic %s: %w", epicID, err)
+	}
+	desc := SetIntegrationBranchField(epic.Description, branch)
+	if desc == epic.Description {
+		return nil
+	}
+	if err := bd.Update(epicID, beads.UpdateOptions{Description: &desc}); err != nil {
+		return fmt.Errorf("recording integration branch on %s: %w", epicID, err)
+	}
+	return nil
+}
+
+// FindIntegrationBranch returns the recorded integration branch of the
+// nearest open epic above (or at) issueID, or "" if none has one. Epics
+// without the field, and closed (landed) epics whose branch is gone, are
+// skipped in favour of higher-level epics.
+func FindIntegrationBranch(bd *beads.Beads, issueID string) (string, error) {
+	// Limit depth to prevent infinite loops in case of circular references
+	const maxDepth = 10
+	currentID := issueID
+	for depth := 0; depth < maxDepth && currentID != ""; depth++ {
+		issue, err := bd.Show(currentID)
+		if err != nil {
+			return "", fmt.Errorf("looking up issue %s: %w", currentID, err)
+		}
+		if issue.Type == "epic" && issue.Status != "closed" {
+			if branch := IntegrationBranchField(issue.Description); branch != "" {
+				return branch, nil
+			}
+		}
+		currentID = issue.Parent
+	}
+	return "", nil
+}
@@ -0,0 +1,82 @@
+package refinery
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/git"
+)
+
+func TestEnsureIntegrationBranch(t *testing.T) {
+	e, repo := newGuardEngineer(t)
+	g := git.NewGit(repo)
+
+	if err := e.EnsureIntegrationBranch("integration/gt-epic1", "main"); err != nil {
+		t.Fatalf("EnsureIntegrationBranch() error = %v", err)
+	}
+	if exists, err := g.RemoteBranchExists("origin", "integration/gt-epic1"); err != nil || !exists {
+		t.Fatalf("branch not pushed to origin: %v", err)
+	}
+
+	// A retried handoff finds the branch already on origin
+	if err := e.EnsureIntegrationBranch("integration/gt-epic1", "main"); err != nil {
+		t.Errorf("retry error = %v", err)
+	}
+
+	if err := e.EnsureIntegrationBranch("integration/bad..name", "main"); err == nil ||
+		!strings.Contains(err.Error(), "invalid branch name") {
+		t.Errorf("expected invalid branch name error, got %v", err)
+	}
+}
+
+func TestEnsureIntegrationBranch_ConfiguredRemotes(t *testing.T) {
+	e, repo := newGuardEngineer(t)
+	g := git.NewGit(repo)
+
+	// The merge queue uses "upstream" and mirrors to "backup"; origin is unused
+	rigPath := e.rig.Path
+	for _, name := range []string{"upstream", "backup"} {
+		bare := filepath.Join(rigPath, name+".git")
+		runGit(t, rigPath, "clone", "--bare", filepath.Join(rigPath, "origin.git"), bare)
+		runGit(t, repo, "remote", "add", name, bare)
+	}
+	e.config.Remote = "upstream"
+	e.config.PushRemotes = []string{"backup"}
+
+	if err := e.EnsureIntegrationBranch("integration/gt-epic2", "main"); err != nil {
+		t.Fatalf("EnsureIntegrationBranch() error = %v", err)
+	}
+	for _, remote := range []string{"upstream", "backup"} {
+		runGit(t, repo, "fetch", remote)
+		if exists, err := g.RemoteBranchExists(remote, "integration/gt-epic2"); err != nil || !exists {
+			t.Errorf("branch not pushed to %s: %v", remote, err)
+		}
+	}
+	if exists, _ := g.RemoteBranchExists("origin", "integration/gt-epic2"); exists {
+		t.Error("branch was pushed to origin instead of the configured remote")
+	}
+}
+
+func TestIntegrationBranchSettings(t *testing.T) {
+	rigPath := t.TempDir()
+	if !IntegrationBranchesEnabled(rigPath) || IntegrationBranchTemplate(rigPath) != DefaultIntegrationBranchTemplate {
+		t.Fatal("expected defaults without rig settings")
+	}
+
+	settings := filepath.Join(rigPath, "settings", "config.json")
+	if err := os.MkdirAll(filepath.Dir(settings), 0755); err != nil {
+		t.Fatal(err)
+	}
+	data := `{"type": "rig-settings", "merge_queue": {"integration_branches": false, "integration_branch_template": "epic/{prefix}/{epic}"}}`
+	if err := os.WriteFile(settings, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if IntegrationBranchesEnabled(rigPath) {
+		t.Error("expected integration branches disabled")
+	}
+	if got := IntegrationBranchName(IntegrationBranchTemplate(rigPath), "RA-12"); got != "epic/RA/RA-12" {
+		t.Errorf("IntegrationBranchName() = %q, want epic/RA/RA-12", got)
+	}
+}
@@ -0,0 +1,72 @@
+package refinery
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+)
+
+// Engineer log formats.
+const (
+	LogFormatText = "text"
+	LogFormatJSON = "json"
+)
+
+// NewLogger returns a structured logger writing to w. format is
+// LogFormatText (key=value lines) or LogFormatJSON (one object per line, for
+// log pipelines); level is a level name accepted by ParseLogLevel.
+func NewLogger(w io.Writer, format, level string) (*slog.Logger, error) {
+	lvl, err := ParseLogLevel(level)
+	if err != nil {
+		return nil, err
+	}
+	opts := &slog.HandlerOptions{Level: lvl}
+	switch format {
+	case "", LogFormatText:
+		return slog.New(slog.NewTextHandler(w, opts)), nil
+	case LogFormatJSON:
+		return slog.New(slog.NewJSONHandler(w, opts)), nil
+	default:
+		return nil, fmt.Errorf("invalid log format %q: must be %s or %s", format, LogFormatText, LogFormatJSON)
+	}
+}
+
+// ParseLogLevel parses debug, info, warn, or error. Empty means info.
+func ParseLogLevel(s string) (slog.Level, error) {
+	switch strings.ToLower(s) {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "", "info":
+		return slog.LevelInfo, nil
+	case "warn", "warning":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	default:
+		return 0, fmt.Errorf("invalid log level %q: must be debug, info, warn, or error", s)
+	}
+}
+
+// newCorrelationID returns an ID for one attempt at processing an MR, so
+// every log line from the attempt - merge, tests, and the success or failure
+// handling that follows - can be filtered together. Retries of the same MR
+// get new IDs.
+func newCorrelationID(mrID string) string {
+	b := make([]byte, 4)
+	if _, err := rand.Read(b); err != nil {
+		return mrID
+	}
+	return mrID + "." + hex.EncodeToString(b)
+}
+
+// mrLog returns the engineer's logger scoped to an MR, assigning the MR a
+// correlation ID if it doesn't have one yet.
+func (e *Engineer) mrLog(mr *MRInfo) *slog.Logger {
+	if mr.CorrelationID == "" {
+		mr.CorrelationID = newCorrelationID(mr.ID)
+	}
+	return e.log.With("mr", mr.ID, "correlation_id", mr.CorrelationID)
+}
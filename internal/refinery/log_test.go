@@ -0,0 +1,115 @@
+package refinery
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/rig"
+)
+
+func TestNewLogger(t *testing.T) {
+	var buf bytes.Buffer
+	log, err := NewLogger(&buf, LogFormatJSON, "warn")
+	if err != nil {
+		t.Fatalf("NewLogger: %v", err)
+	}
+	log.Info("hidden")
+	log.Warn("shown", "mr", "gt-abc")
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("expected a single JSON line, got %q: %v", buf.String(), err)
+	}
+	if entry["msg"] != "shown" || entry["level"] != "WARN" || entry["mr"] != "gt-abc" {
+		t.Errorf("unexpected entry: %v", entry)
+	}
+
+	if _, err := NewLogger(&buf, "xml", ""); err == nil {
+		t.Error("expected error for unknown format")
+	}
+	if _, err := ParseLogLevel("verbose"); err == nil {
+		t.Error("expected error for unknown level")
+	}
+	if lvl, _ := ParseLogLevel(""); lvl != slog.LevelInfo {
+		t.Errorf("default level = %v, want info", lvl)
+	}
+}
+
+func TestEngineer_LoadConfig_Logging(t *testing.T) {
+	tmpDir := t.TempDir()
+	config := `{"merge_queue": {"log_format": "json", "log_level": "debug"}}`
+	if err := os.WriteFile(filepath.Join(tmpDir, "config.json"), []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	e := NewEngineer(&rig.Rig{Name: "test-rig", Path: tmpDir})
+	var buf bytes.Buffer
+	e.SetOutput(&buf)
+	if err := e.LoadConfig(); err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	e.log.Debug("probe")
+	if !strings.HasPrefix(buf.String(), "{") || !strings.Contains(buf.String(), `"level":"DEBUG"`) {
+		t.Errorf("expected a JSON debug line, got %q", buf.String())
+	}
+
+	bad := `{"merge_queue": {"log_format": "xml"}}`
+	if err := os.WriteFile(filepath.Join(tmpDir, "config.json"), []byte(bad), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := NewEngineer(&rig.Rig{Name: "test-rig", Path: tmpDir}).LoadConfig(); err == nil {
+		t.Error("expected error for invalid log_format")
+	}
+}
+
+func TestEngineer_ProcessMRInfo_CorrelationID(t *testing.T) {
+	e := NewEngineer(&rig.Rig{Name: "test-rig", Path: t.TempDir()})
+	var buf bytes.Buffer
+	log, err := NewLogger(&buf, LogFormatJSON, "debug")
+	if err != nil {
+		t.Fatal(err)
+	}
+	e.SetLogger(log)
+
+	// No git repo, so the attempt fails at the first step
+	mr := &MRInfo{ID: "gt-mr1", Branch: "polecat/nux", Target: "main"}
+	result := e.ProcessMRInfo(context.Background(), mr)
+	if result.Success {
+		t.Fatal("expected failure without a repo")
+	}
+	if result.CorrelationID == "" || !strings.HasPrefix(result.CorrelationID, "gt-mr1.") {
+		t.Fatalf("CorrelationID = %q", result.CorrelationID)
+	}
+	if mr.CorrelationID != result.CorrelationID {
+		t.Errorf("mr.CorrelationID = %q, want %q", mr.CorrelationID, result.CorrelationID)
+	}
+
+	scanner := bufio.NewScanner(&buf)
+	lines := 0
+	for scanner.Scan() {
+		var entry map[string]interface{}
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			t.Fatalf("bad log line %q: %v", scanner.Text(), err)
+		}
+		if entry["correlation_id"] != result.CorrelationID || entry["mr"] != "gt-mr1" {
+			t.Errorf("log line missing MR correlation: %v", entry)
+		}
+		lines++
+	}
+	if lines == 0 {
+		t.Error("expected log lines for the attempt")
+	}
+
+	// A retry is a new attempt with a new ID
+	again := e.ProcessMRInfo(context.Background(), mr)
+	if again.CorrelationID == result.CorrelationID {
+		t.Error("expected a new correlation ID for a retry")
+	}
+}
@@ -110,6 +110,16 @@ func (m *Manager) Start(foreground bool, agentOverride string) error {
 		return err
 	}
 
+	// A misconfigured merge_queue.remote or push_remotes should stop the
+	// refinery here rather than on its first merge
+	eng := NewEngineer(m.rig)
+	if err := eng.LoadConfig(); err != nil {
+		return fmt.Errorf("loading merge queue config: %w", err)
+	}
+	if err := eng.ValidateRemotes(); err != nil {
+		return err
+	}
+
 	t := tmux.NewTmux()
 	sessionID := m.SessionName()
 
@@ -552,9 +562,16 @@ func (m *Manager) getMergeConfig() MergeConfig {
 	return mergeConfig
 }
 
-// pushWithRetry pushes to the target branch with exponential backoff retry.
+// pushWithRetry pushes to the target branch on the merge queue's remote with
+// exponential backoff retry, then to its push mirrors.
 // Deprecated: The Refinery agent decides retry strategy (ZFC #5).
 func (m *Manager) pushWithRetry(targetBranch string, config MergeConfig) error {
+	eng := NewEngineer(m.rig)
+	if err := eng.LoadConfig(); err != nil {
+		return fmt.Errorf("loading merge queue config: %w", err)
+	}
+	eng.SetOutput(m.output)
+
 	var lastErr error
 	delay := time.Duration(config.PushRetryDelayMs) * time.Millisecond
 
@@ -565,8 +582,9 @@ func (m *Manager) pushWithRetry(targetBranch string, config MergeConfig) error {
 			delay *= 2 // Exponential backoff
 		}
 
-		err := util.ExecRun(m.workDir, "git", "push", "origin", targetBranch)
+		err := util.ExecRun(m.workDir, "git", "push", eng.config.Remote, targetBranch)
 		if err == nil {
+			eng.pushMirrors(eng.log, targetBranch)
 			return nil // Success
 		}
 		lastErr = err
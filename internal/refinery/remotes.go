@@ -0,0 +1,90 @@
+package refinery
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+// DefaultRemote is the remote the refinery fetches from and pushes to
+// unless merge_queue.remote says otherwise.
+const DefaultRemote = "origin"
+
+// checkPushRemotes rejects empty, duplicate, or primary-remote entries in
+// push_remotes.
+func checkPushRemotes(remote string, pushRemotes []string) error {
+	seen := map[string]bool{remote: true}
+	for _, name := range pushRemotes {
+		if name == "" {
+			return fmt.Errorf("invalid push_remotes: empty remote name")
+		}
+		if name == remote {
+			return fmt.Errorf("invalid push_remotes: %q is already the primary remote", name)
+		}
+		if seen[name] {
+			return fmt.Errorf("invalid push_remotes: %q is listed more than once", name)
+		}
+		seen[name] = true
+	}
+	return nil
+}
+
+// ValidateRemotes checks that the configured remote and push remotes exist
+// in the refinery's repo, so a misconfigured rig fails at startup rather
+// than on its first merge.
+func (e *Engineer) ValidateRemotes() error {
+	names, err := e.git.Remotes()
+	if err != nil {
+		return fmt.Errorf("listing git remotes in %s: %w", e.workDir, err)
+	}
+	known := make(map[string]bool, len(names))
+	for _, name := range names {
+		known[name] = true
+	}
+
+	check := func(field, name string) error {
+		if known[name] {
+			return nil
+		}
+		have := "none"
+		if len(names) > 0 {
+			have = strings.Join(names, ", ")
+		}
+		return fmt.Errorf("merge_queue.%s: remote %q is not configured in %s (have: %s); add it with: git -C %s remote add %s <url>",
+			field, name, e.workDir, have, e.workDir, name)
+	}
+	if err := check("remote", e.config.Remote); err != nil {
+		return err
+	}
+	for _, name := range e.config.PushRemotes {
+		if err := check("push_remotes", name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pushMirrors pushes target to each secondary push remote. Mirrors are
+// best-effort: the merge already landed on the primary remote.
+func (e *Engineer) pushMirrors(log *slog.Logger, target string) {
+	for _, remote := range e.config.PushRemotes {
+		if err := e.git.Push(remote, target, false); err != nil {
+			log.Warn("mirror push failed", "remote", remote, "target", target, "err", fmt.Sprintf("%v%s", err, e.credentialHint(remote)))
+			continue
+		}
+		log.Info("pushed to mirror", "remote", remote, "target", target)
+	}
+}
+
+// credentialHint returns a suffix for push errors suggesting a credential
+// helper when remote uses HTTPS and none is configured, or "" otherwise.
+func (e *Engineer) credentialHint(remote string) string {
+	if e.config.CredentialHelper != "" {
+		return ""
+	}
+	url, err := e.git.RemoteURL(remote)
+	if err != nil || !strings.HasPrefix(url, "https://") {
+		return ""
+	}
+	return " (if the remote needs authentication, set merge_queue.credential_helper, e.g. \"store\" or \"!gh auth git-credential\")"
+}
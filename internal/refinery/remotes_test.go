@@ -0,0 +1,96 @@
+package refinery
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/rig"
+)
+
+func TestEngineer_LoadConfig_Remotes(t *testing.T) {
+	tmpDir := t.TempDir()
+	write := func(mq map[string]interface{}) {
+		data, _ := json.Marshal(map[string]interface{}{"merge_queue": mq})
+		if err := os.WriteFile(filepath.Join(tmpDir, "config.json"), data, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	r := &rig.Rig{Name: "test-rig", Path: tmpDir}
+
+	e := NewEngineer(r)
+	if e.config.Remote != DefaultRemote {
+		t.Errorf("default Remote = %q, want %q", e.config.Remote, DefaultRemote)
+	}
+
+	write(map[string]interface{}{
+		"remote":            "upstream",
+		"push_remotes":      []string{"mirror"},
+		"credential_helper": " store ",
+	})
+	e = NewEngineer(r)
+	if err := e.LoadConfig(); err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	if e.config.Remote != "upstream" || len(e.config.PushRemotes) != 1 || e.config.CredentialHelper != "store" {
+		t.Errorf("unexpected config: %+v", e.config)
+	}
+
+	for _, bad := range [][]string{{""}, {"upstream"}, {"mirror", "mirror"}} {
+		write(map[string]interface{}{"remote": "upstream", "push_remotes": bad})
+		if err := NewEngineer(r).LoadConfig(); err == nil {
+			t.Errorf("expected error for push_remotes %q", bad)
+		}
+	}
+}
+
+func TestEngineer_ValidateRemotesAndMirrors(t *testing.T) {
+	rigPath := t.TempDir()
+	repo := filepath.Join(rigPath, "mayor", "rig")
+	if err := os.MkdirAll(repo, 0755); err != nil {
+		t.Fatal(err)
+	}
+	primary := filepath.Join(t.TempDir(), "primary.git")
+	mirror := filepath.Join(t.TempDir(), "mirror.git")
+	runGit(t, rigPath, "init", "--bare", "-b", "main", primary)
+	runGit(t, rigPath, "init", "--bare", "-b", "main", mirror)
+
+	runGit(t, repo, "init", "-b", "main")
+	runGit(t, repo, "config", "user.email", "test@test.com")
+	runGit(t, repo, "config", "user.name", "Test")
+	os.WriteFile(filepath.Join(repo, "README.md"), []byte("# test\n"), 0644)
+	runGit(t, repo, "add", ".")
+	runGit(t, repo, "commit", "-m", "initial")
+	runGit(t, repo, "remote", "add", "upstream", primary)
+
+	e := NewEngineer(&rig.Rig{Name: "test-rig", Path: rigPath})
+	var out bytes.Buffer
+	e.SetOutput(&out)
+
+	// "origin" is not configured in this repo
+	err := e.ValidateRemotes()
+	if err == nil || !strings.Contains(err.Error(), `remote "origin" is not configured`) {
+		t.Fatalf("expected missing origin error, got %v", err)
+	}
+
+	e.config.Remote = "upstream"
+	e.config.PushRemotes = []string{"mirror"}
+	if err := e.ValidateRemotes(); err == nil || !strings.Contains(err.Error(), "merge_queue.push_remotes") {
+		t.Fatalf("expected missing mirror error, got %v", err)
+	}
+
+	runGit(t, repo, "remote", "add", "mirror", mirror)
+	if err := e.ValidateRemotes(); err != nil {
+		t.Fatalf("ValidateRemotes: %v", err)
+	}
+
+	e.pushMirrors(e.log, "main")
+	cmd := exec.Command("git", "--git-dir", mirror, "rev-parse", "main")
+	if outb, err := cmd.CombinedOutput(); err != nil {
+		t.Errorf("mirror was not pushed: %v\n%s", err, outb)
+	}
+}
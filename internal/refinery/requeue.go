@@ -0,0 +1,74 @@
+package refinery
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+)
+
+// MaxMRPriority is the lowest MR priority (P4); P0 is the highest.
+const MaxMRPriority = 4
+
+// showMR loads an MR bead and its fields.
+func (e *Engineer) showMR(mrID string) (*beads.Issue, *beads.MRFields, error) {
+	issue, err := e.beads.Show(mrID)
+	if err != nil {
+		return nil, nil, err
+	}
+	fields := beads.ParseMRFields(issue)
+	if fields == nil {
+		return nil, nil, fmt.Errorf("%s is not a merge request", mrID)
+	}
+	return issue, fields, nil
+}
+
+// RequeueMR puts an MR back in the ready queue: a closed MR is reopened,
+// and a stale claim or skip is cleared. MRs held for risk approval stay
+// assigned to the approver (see ApproveMR), and open blockers such as a
+// conflict-resolution task still apply. It returns the MR as it was.
+func (e *Engineer) RequeueMR(mrID string) (*beads.Issue, error) {
+	issue, fields, err := e.showMR(mrID)
+	if err != nil {
+		return nil, err
+	}
+	fields.SkipUntil = ""
+	fields.CloseReason = ""
+	desc := beads.SetMRFields(issue, fields)
+	open, empty := "open", ""
+	opts := beads.UpdateOptions{Description: &desc}
+	if issue.Assignee != "" && issue.Assignee != RiskApprover {
+		opts.Assignee = &empty
+	}
+	if issue.Status != open {
+		opts.Status = &open
+	}
+	return issue, e.beads.Update(mrID, opts)
+}
+
+// SetMRPriority changes an MR's priority (0-4, P0 highest). The next
+// queue scan orders it accordingly.
+func (e *Engineer) SetMRPriority(mrID string, priority int) error {
+	if priority < 0 || priority > MaxMRPriority {
+		return fmt.Errorf("invalid priority %d (want 0-%d)", priority, MaxMRPriority)
+	}
+	if _, _, err := e.showMR(mrID); err != nil {
+		return err
+	}
+	return e.beads.Update(mrID, beads.UpdateOptions{Priority: &priority})
+}
+
+// SkipMR leaves an open MR out of the ready queue until the given time.
+// RequeueMR lifts the skip early.
+func (e *Engineer) SkipMR(mrID string, until time.Time) error {
+	issue, fields, err := e.showMR(mrID)
+	if err != nil {
+		return err
+	}
+	if issue.Status == "closed" {
+		return fmt.Errorf("%s is closed; requeue it instead", mrID)
+	}
+	fields.SkipUntil = until.UTC().Format(time.RFC3339)
+	desc := beads.SetMRFields(issue, fields)
+	return e.beads.Update(mrID, beads.UpdateOptions{Description: &desc})
+}
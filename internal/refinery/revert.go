@@ -0,0 +1,313 @@
+package refinery
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/mail"
+)
+
+// RevertLabel is added to a source issue when its merge is reverted.
+const RevertLabel = "reverted"
+
+// RevertBranchPrefix prefixes the branches gt mq revert queues.
+const RevertBranchPrefix = "revert/"
+
+// ErrNoPostMergeCheck is returned by CheckPostMerge when the rig has no
+// post_merge_check configured.
+var ErrNoPostMergeCheck = errors.New("no post_merge_check configured")
+
+// issueSuffixRe matches the source issue the refinery appends to merge
+// commit subjects: "Merge polecat/nux into main (gt-abc)".
+var issueSuffixRe = regexp.MustCompile(`\(([a-z0-9]+-[a-z0-9.]+)\)\s*$`)
+
+// RevertResult describes a revert queued by RevertMerge.
+type RevertResult struct {
+	MRID        string // The revert MR
+	Branch      string // Branch holding the revert commit
+	Target      string // Branch the revert merges into
+	Commit      string // Full SHA of the reverted commit
+	RevertedMR  string // MR that merged the commit, if known
+	SourceIssue string // Reopened source issue, if known
+	Worker      string // Worker that was notified, if known
+}
+
+// RevertMerge queues a revert of commit, a merge on the target branch.
+// The revert commit is made on a new revert/<sha> branch and submitted as
+// a P0 MR, so it goes through the usual conflict check, tests and push.
+// The source issue of the reverted merge is reopened with RevertLabel,
+// and its worker and watchers are told why.
+func (e *Engineer) RevertMerge(commit, reason string) (*RevertResult, error) {
+	full, err := e.git.Rev(commit + "^{commit}")
+	if err != nil {
+		return nil, fmt.Errorf("unknown commit %s", commit)
+	}
+	subject, _ := e.git.CommitSubject(full)
+
+	res := &RevertResult{Commit: full, Target: e.config.TargetBranch}
+	if m := issueSuffixRe.FindStringSubmatch(subject); m != nil {
+		res.SourceIssue = m[1]
+	}
+	merged, fields := e.findMergedMR(full, res.SourceIssue)
+	if fields != nil {
+		res.RevertedMR = merged.ID
+		res.Worker = fields.Worker
+		if fields.SourceIssue != "" {
+			res.SourceIssue = fields.SourceIssue
+		}
+		if fields.Target != "" {
+			res.Target = fields.Target
+		}
+	}
+	if reason == "" {
+		reason = "post-merge breakage"
+	}
+
+	res.Branch, err = e.createRevertBranch(full, res.Target)
+	if err != nil {
+		return nil, err
+	}
+
+	desc := beads.FormatMRFields(&beads.MRFields{
+		Branch:      res.Branch,
+		Target:      res.Target,
+		SourceIssue: res.SourceIssue,
+		Worker:      res.Worker,
+		Rig:         e.rig.Name,
+		Reverts:     full,
+	}) + fmt.Sprintf("\n\nReverting %s: %s", shortSHA(full), reason)
+	title := "Revert: " + shortSHA(full)
+	if res.SourceIssue != "" {
+		title = "Revert: " + res.SourceIssue
+	}
+	mr, err := e.beads.Create(beads.CreateOptions{
+		Title:       title,
+		Type:        "merge-request",
+		Priority:    0,
+		Description: desc,
+		Ephemeral:   true,
+	})
+	if err != nil {
+		_ = e.git.DeleteBranch(res.Branch, true) // best-effort: nothing queued it
+		return nil, fmt.Errorf("creating revert MR: %w", err)
+	}
+	res.MRID = mr.ID
+
+	log := e.log.With("mr", mr.ID, "reverts", shortSHA(full))
+	log.Info("queued revert", "branch", res.Branch, "target", res.Target, "reason", reason)
+	e.reopenReverted(log, res, reason)
+	if err := e.NotifyMRReady(ReadyEvent{MRID: mr.ID, Branch: res.Branch, Target: res.Target}); err != nil {
+		log.Warn("failed to notify refinery", "err", err)
+	}
+	return res, nil
+}
+
+// findMergedMR finds the closed MR that merged commit, by its merge_commit
+// field or, failing that, by source issue. It returns nils if none matches.
+func (e *Engineer) findMergedMR(commit, sourceIssue string) (*beads.Issue, *beads.MRFields) {
+	issues, err := e.beads.List(beads.ListOptions{
+		Status:   "closed",
+		Type:     "merge-request",
+		Priority: -1,
+	})
+	if err != nil {
+		return nil, nil
+	}
+	var bySource *beads.Issue
+	var bySourceFields *beads.MRFields
+	for _, issue := range issues {
+		fields := beads.ParseMRFields(issue)
+		if fields == nil || fields.Reverts != "" {
+			continue
+		}
+		if len(fields.MergeCommit) >= 7 && strings.HasPrefix(commit, fields.MergeCommit) {
+			return issue, fields
+		}
+		if sourceIssue != "" && fields.SourceIssue == sourceIssue &&
+			(bySource == nil || issue.ClosedAt > bySource.ClosedAt) {
+			bySource, bySourceFields = issue, fields
+		}
+	}
+	return bySource, bySourceFields
+}
+
+// createRevertBranch commits a revert of commit onto a new revert/<sha>
+// branch off target. The revert is made in a temporary worktree so the
+// refinery's own checkout is left alone.
+func (e *Engineer) createRevertBranch(commit, target string) (string, error) {
+	branch := RevertBranchPrefix + shortSHA(commit)
+	if exists, _ := e.git.BranchExists(branch); exists {
+		return "", fmt.Errorf("branch %s already exists; is a revert of %s already queued?", branch, shortSHA(commit))
+	}
+
+	// Prefer the remote target: it is what the revert will merge into
+	start := target
+	if err := e.git.FetchBranch(e.config.Remote, target); err == nil {
+		start = e.config.Remote + "/" + target
+	}
+	if onTarget, err := e.git.IsAncestor(commit, start); err != nil || !onTarget {
+		return "", fmt.Errorf("commit %s is not on %s", shortSHA(commit), target)
+	}
+
+	dir, err := os.MkdirTemp("", "gt-revert-")
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		_ = e.git.WorktreeRemove(dir, true)
+		_ = os.RemoveAll(dir)
+	}()
+	if err := e.git.WorktreeAddFromRef(dir, branch, start); err != nil {
+		return "", fmt.Errorf("creating revert worktree: %w", err)
+	}
+
+	wt := git.NewGit(dir)
+	if err := wt.Revert(commit); err != nil {
+		conflicts, _ := wt.GetConflictingFiles()
+		_ = wt.AbortRevert()
+		_ = e.git.WorktreeRemove(dir, true)
+		_ = e.git.DeleteBranch(branch, true)
+		if len(conflicts) > 0 {
+			return "", fmt.Errorf("reverting %s conflicts with %s in: %v", shortSHA(commit), target, conflicts)
+		}
+		return "", fmt.Errorf("reverting %s: %w", shortSHA(commit), err)
+	}
+	return branch, nil
+}
+
+// reopenReverted reopens the reverted merge's source issue with RevertLabel
+// and mails its worker and watchers. Best-effort.
+func (e *Engineer) reopenReverted(log *slog.Logger, res *RevertResult, reason string) {
+	if res.SourceIssue == "" {
+		log.Warn("reverted commit has no known source issue; nothing reopened")
+		return
+	}
+	open := "open"
+	if err := e.beads.Update(res.SourceIssue, beads.UpdateOptions{
+		Status:    &open,
+		AddLabels: []string{RevertLabel},
+	}); err != nil {
+		log.Warn("failed to reopen source issue", "source_issue", res.SourceIssue, "err", err)
+	} else {
+		log.Info("reopened source issue", "source_issue", res.SourceIssue)
+	}
+
+	body := fmt.Sprintf("Commit %s on %s is being reverted: %s\n\nIssue: %s (reopened, labeled %q)\nReverted MR: %s\nRevert MR: %s\n\nFix the breakage and resubmit with gt mq submit.",
+		shortSHA(res.Commit), res.Target, reason, res.SourceIssue, RevertLabel, res.RevertedMR, res.MRID)
+	mr := &MRInfo{ID: res.MRID, SourceIssue: res.SourceIssue, Worker: res.Worker}
+	if res.Worker != "" {
+		msg := &mail.Message{
+			From:     e.rig.Name + "/refinery",
+			To:       e.rig.Name + "/" + res.Worker,
+			Subject:  "Reverted: " + res.SourceIssue,
+			Body:     body,
+			Priority: mail.PriorityHigh,
+		}
+		if err := e.router.SendOrQueue(msg); err != nil {
+			log.Warn("failed to notify worker", "worker", res.Worker, "err", err)
+		}
+	}
+	e.notifyWatchers(log, mr, "Reverted", body)
+}
+
+// HealthCheckResult is the outcome of CheckPostMerge.
+type HealthCheckResult struct {
+	Commit  string        // Target HEAD that was checked
+	Passed  bool          // Whether the check passed
+	Output  string        // Tail of the check's output (on failure)
+	Error   string        // Why the check failed
+	Revert  *RevertResult // Revert queued for the failure, if any
+	Skipped string        // Why a failure was not reverted, if it wasn't
+}
+
+// CheckPostMerge runs the post_merge_check command against the target
+// branch HEAD in the refinery worktree. If it fails and auto_revert is set,
+// HEAD is reverted through the queue with RevertMerge. Reverts themselves
+// are never auto-reverted.
+func (e *Engineer) CheckPostMerge(ctx context.Context) (*HealthCheckResult, error) {
+	if e.config.PostMergeCheck == "" {
+		return nil, ErrNoPostMergeCheck
+	}
+	target := e.config.TargetBranch
+	if err := e.git.Checkout(target); err != nil {
+		return nil, fmt.Errorf("checking out %s: %w", target, err)
+	}
+	if err := e.git.Pull(e.config.Remote, target); err != nil {
+		e.log.Warn("pull failed, continuing", "remote", e.config.Remote, "target", target, "err", err)
+	}
+	head, err := e.git.Rev("HEAD")
+	if err != nil {
+		return nil, err
+	}
+	log := e.log.With("commit", shortSHA(head))
+
+	timeout := e.config.PostMergeCheckTimeout
+	if timeout <= 0 {
+		timeout = DefaultMergeQueueConfig().PostMergeCheckTimeout
+	}
+	checkCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	log.Info("running post-merge check", "command", e.config.PostMergeCheck)
+	// Like test_command, the check comes from the rig's config.json
+	cmd := exec.CommandContext(checkCtx, "sh", "-c", e.config.PostMergeCheck) //nolint:gosec // G204: command is from trusted rig config
+	cmd.Dir = e.workDir
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	runErr := cmd.Run()
+
+	result := &HealthCheckResult{Commit: head, Passed: runErr == nil}
+	if result.Passed {
+		log.Info("post-merge check passed")
+		return result, nil
+	}
+	result.Error = runErr.Error()
+	if checkCtx.Err() == context.DeadlineExceeded {
+		result.Error = fmt.Sprintf("timed out after %s", timeout)
+	}
+	result.Output = tailLines(out.String(), 20)
+	log.Error("post-merge check failed", "err", result.Error)
+
+	switch subject, _ := e.git.CommitSubject(head); {
+	case !e.config.AutoRevert:
+		result.Skipped = "auto_revert is off"
+	case isRevertSubject(subject):
+		result.Skipped = "HEAD is itself a revert"
+	default:
+		rev, err := e.RevertMerge(head, "post-merge check failed: "+result.Error)
+		if err != nil {
+			result.Skipped = err.Error()
+		}
+		result.Revert = rev
+	}
+	if result.Skipped != "" {
+		log.Warn("not reverting", "reason", result.Skipped)
+	}
+	return result, nil
+}
+
+// isRevertSubject reports whether a commit subject is a revert or the merge
+// of a revert branch.
+func isRevertSubject(subject string) bool {
+	return strings.HasPrefix(subject, `Revert "`) ||
+		strings.HasPrefix(subject, "Merge "+RevertBranchPrefix)
+}
+
+// tailLines returns the last n lines of s.
+func tailLines(s string, n int) string {
+	lines := strings.Split(strings.TrimRight(s, "\n"), "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return strings.Join(lines, "\n")
+}
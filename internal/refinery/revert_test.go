@@ -0,0 +1,142 @@
+package refinery
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/rig"
+)
+
+// newRevertTestEngineer returns an engineer on a repo whose main has a
+// merge of polecat/nux (adding bad.txt) as HEAD.
+func newRevertTestEngineer(t *testing.T) *Engineer {
+	t.Helper()
+	rigPath := t.TempDir()
+	repo := filepath.Join(rigPath, "mayor", "rig")
+	if err := os.MkdirAll(repo, 0755); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, repo, "init", "-b", "main")
+	runGit(t, repo, "config", "user.email", "test@test.com")
+	runGit(t, repo, "config", "user.name", "Test")
+	os.WriteFile(filepath.Join(repo, "README.md"), []byte("# test\n"), 0644)
+	runGit(t, repo, "add", ".")
+	runGit(t, repo, "commit", "-m", "initial")
+	runGit(t, repo, "checkout", "-b", "polecat/nux")
+	os.WriteFile(filepath.Join(repo, "bad.txt"), []byte("bad"), 0644)
+	runGit(t, repo, "add", ".")
+	runGit(t, repo, "commit", "-m", "add bad.txt")
+	runGit(t, repo, "checkout", "main")
+	runGit(t, repo, "merge", "--no-ff", "-m", "Merge polecat/nux into main (gt-abc)", "polecat/nux")
+
+	e := NewEngineer(&rig.Rig{Name: "test-rig", Path: rigPath})
+	e.SetOutput(&bytes.Buffer{})
+	return e
+}
+
+func TestEngineer_CreateRevertBranch(t *testing.T) {
+	e := newRevertTestEngineer(t)
+	merge, err := e.git.Rev("HEAD")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	branch, err := e.createRevertBranch(merge, "main")
+	if err != nil {
+		t.Fatalf("createRevertBranch: %v", err)
+	}
+	if branch != "revert/"+merge[:8] {
+		t.Errorf("branch = %q", branch)
+	}
+
+	// The revert branch drops bad.txt; the refinery checkout is untouched
+	files, err := e.git.ChangedFiles("main", branch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 || files[0] != "bad.txt" {
+		t.Errorf("revert changes %v, want [bad.txt]", files)
+	}
+	if current, _ := e.git.CurrentBranch(); current != "main" {
+		t.Errorf("refinery checkout moved to %q", current)
+	}
+	if _, err := os.Stat(filepath.Join(e.workDir, "bad.txt")); err != nil {
+		t.Errorf("refinery worktree changed: %v", err)
+	}
+	if subject, _ := e.git.CommitSubject(branch); !isRevertSubject(subject) {
+		t.Errorf("revert subject %q not recognized as a revert", subject)
+	}
+
+	if _, err := e.createRevertBranch(merge, "main"); err == nil {
+		t.Error("expected an error reverting the same commit twice")
+	}
+	runGit(t, e.workDir, "branch", "other", "main~1")
+	if _, err := e.createRevertBranch(merge, "other"); err == nil {
+		t.Error("expected an error reverting a commit not on the target")
+	}
+}
+
+func TestEngineer_CheckPostMerge(t *testing.T) {
+	e := newRevertTestEngineer(t)
+	if _, err := e.CheckPostMerge(context.Background()); err != ErrNoPostMergeCheck {
+		t.Fatalf("CheckPostMerge() without a check = %v, want ErrNoPostMergeCheck", err)
+	}
+
+	e.config.PostMergeCheck = "test ! -f bad.txt || { echo bad.txt is back; exit 1; }"
+	result, err := e.CheckPostMerge(context.Background())
+	if err != nil {
+		t.Fatalf("CheckPostMerge: %v", err)
+	}
+	if result.Passed || !strings.Contains(result.Output, "bad.txt is back") {
+		t.Errorf("result = %+v, want a failure with the check's output", result)
+	}
+	if result.Revert != nil || result.Skipped != "auto_revert is off" {
+		t.Errorf("failed check reverted without auto_revert: %+v", result)
+	}
+
+	e.config.PostMergeCheck = "true"
+	if result, err := e.CheckPostMerge(context.Background()); err != nil || !result.Passed {
+		t.Errorf("CheckPostMerge() = %+v, %v; want passed", result, err)
+	}
+}
+
+func TestIsRevertSubject(t *testing.T) {
+	tests := map[string]bool{
+		`Revert "Merge polecat/nux into main (gt-abc)"`: true,
+		"Merge revert/3f2a9c10 into main (gt-abc)":      true,
+		"Merge polecat/nux into main (gt-abc)":          false,
+		"Reverting the frobnicator config":              false,
+	}
+	for subject, want := range tests {
+		if got := isRevertSubject(subject); got != want {
+			t.Errorf("isRevertSubject(%q) = %v, want %v", subject, got, want)
+		}
+	}
+}
+
+func TestEngineer_LoadConfig_PostMergeCheck(t *testing.T) {
+	tmpDir := t.TempDir()
+	config := `{"merge_queue": {"post_merge_check": " make smoke ", "post_merge_check_timeout": "2m", "auto_revert": true}}`
+	if err := os.WriteFile(filepath.Join(tmpDir, "config.json"), []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+	e := NewEngineer(&rig.Rig{Name: "test-rig", Path: tmpDir})
+	if err := e.LoadConfig(); err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	if e.config.PostMergeCheck != "make smoke" || e.config.PostMergeCheckTimeout.Minutes() != 2 || !e.config.AutoRevert {
+		t.Errorf("config = %q, %s, %v", e.config.PostMergeCheck, e.config.PostMergeCheckTimeout, e.config.AutoRevert)
+	}
+
+	config = `{"merge_queue": {"post_merge_check_timeout": "soon"}}`
+	if err := os.WriteFile(filepath.Join(tmpDir, "config.json"), []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := NewEngineer(&rig.Rig{Name: "test-rig", Path: tmpDir}).LoadConfig(); err == nil {
+		t.Error("expected an error for an invalid post_merge_check_timeout")
+	}
+}
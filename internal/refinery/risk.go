@@ -0,0 +1,344 @@
+// Package refinery provides the merge queue processing agent.
+// This file contains risk scoring for merge requests.
+
+package refinery
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/git"
+)
+
+// RiskApprover is who high-risk MRs are assigned to for manual approval.
+const RiskApprover = "overseer"
+
+// Risk levels, by score.
+const (
+	RiskLow    = "low"
+	RiskMedium = "medium"
+	RiskHigh   = "high"
+)
+
+// RiskConfig contains tunable weights for MR risk scoring.
+// Each factor is normalized to 0..1 and the weighted average is scaled to 0..100.
+type RiskConfig struct {
+	// SizeWeight weights lines changed, saturating at SizeSaturation.
+	// Default: 30
+	SizeWeight float64
+
+	// FilesWeight weights files touched, saturating at FilesSaturation.
+	// Default: 20
+	FilesWeight float64
+
+	// TestWeight weights how little of the change is tests.
+	// Default: 20
+	TestWeight float64
+
+	// HistoryWeight weights the historical failure rate of the touched areas.
+	// Default: 30
+	HistoryWeight float64
+
+	// SizeSaturation is the lines-changed count that scores as maximum size risk.
+	// Default: 800
+	SizeSaturation int
+
+	// FilesSaturation is the file count that scores as maximum breadth risk.
+	// Default: 25
+	FilesSaturation int
+
+	// MinHistory is how many past merges an area needs before its failure
+	// rate counts. Default: 3
+	MinHistory int
+}
+
+// DefaultRiskConfig returns sensible defaults for MR risk scoring.
+func DefaultRiskConfig() RiskConfig {
+	return RiskConfig{
+		SizeWeight:      30,
+		FilesWeight:     20,
+		TestWeight:      20,
+		HistoryWeight:   30,
+		SizeSaturation:  800,
+		FilesSaturation: 25,
+		MinHistory:      3,
+	}
+}
+
+// RiskFactor is one component of a risk score.
+type RiskFactor struct {
+	Name   string  `json:"name"`
+	Detail string  `json:"detail"`
+	Score  float64 `json:"score"` // 0..1
+	Weight float64 `json:"weight"`
+}
+
+// RiskAssessment is the risk score for an MR's diff.
+type RiskAssessment struct {
+	// Score is 0 (trivial) to 100 (riskiest).
+	Score int    `json:"score"`
+	Level string `json:"level"`
+
+	LinesChanged int `json:"lines_changed"`
+	FilesTouched int `json:"files_touched"`
+
+	// TestLines is how many of LinesChanged are in test files.
+	TestLines int `json:"test_lines"`
+
+	// Areas are the directories the MR touches (see ChangeArea).
+	Areas []string `json:"areas,omitempty"`
+
+	// AreaFailureRate is the worst historical failure rate among Areas.
+	AreaFailureRate float64 `json:"area_failure_rate"`
+
+	Factors []RiskFactor `json:"factors"`
+}
+
+// AssessRisk scores a diff against the merge history of the areas it touches.
+// history may be nil.
+func AssessRisk(files []git.FileDiffStat, history *MergeHistory, cfg RiskConfig) *RiskAssessment {
+	a := &RiskAssessment{FilesTouched: len(files)}
+
+	codeLines := 0
+	areaSet := make(map[string]bool)
+	for _, f := range files {
+		lines := f.Added + f.Deleted
+		a.LinesChanged += lines
+		if IsTestFile(f.Path) {
+			a.TestLines += lines
+		} else {
+			codeLines += lines
+		}
+		areaSet[ChangeArea(f.Path)] = true
+	}
+	for area := range areaSet {
+		a.Areas = append(a.Areas, area)
+	}
+	sort.Strings(a.Areas)
+
+	// Test factor: code changes with no accompanying test changes are
+	// riskiest. Test lines at half the code lines or more count as covered.
+	testScore := 0.0
+	testDetail := "no code changes"
+	if codeLines > 0 {
+		testScore = 1 - math.Min(float64(a.TestLines)/(0.5*float64(codeLines)), 1)
+		testDetail = fmt.Sprintf("%d test / %d code lines", a.TestLines, codeLines)
+	}
+
+	historyDetail := "no history"
+	if history != nil {
+		var worst string
+		worst, a.AreaFailureRate = history.WorstFailureRate(a.Areas, cfg.MinHistory)
+		if worst != "" {
+			historyDetail = fmt.Sprintf("%.0f%% failed in %s", 100*a.AreaFailureRate, worst)
+		}
+	}
+
+	a.Factors = []RiskFactor{
+		{
+			Name:   "size",
+			Detail: fmt.Sprintf("%d lines", a.LinesChanged),
+			Score:  saturate(a.LinesChanged, cfg.SizeSaturation),
+			Weight: cfg.SizeWeight,
+		},
+		{
+			Name:   "files",
+			Detail: fmt.Sprintf("%d files", a.FilesTouched),
+			Score:  saturate(a.FilesTouched, cfg.FilesSaturation),
+			Weight: cfg.FilesWeight,
+		},
+		{Name: "tests", Detail: testDetail, Score: testScore, Weight: cfg.TestWeight},
+		{Name: "history", Detail: historyDetail, Score: a.AreaFailureRate, Weight: cfg.HistoryWeight},
+	}
+
+	var total, weights float64
+	for _, f := range a.Factors {
+		total += f.Score * f.Weight
+		weights += f.Weight
+	}
+	if weights > 0 {
+		a.Score = int(math.Round(100 * total / weights))
+	}
+	a.Level = RiskLevel(a.Score)
+	return a
+}
+
+// RiskLevel buckets a risk score.
+func RiskLevel(score int) string {
+	switch {
+	case score >= 67:
+		return RiskHigh
+	case score >= 34:
+		return RiskMedium
+	default:
+		return RiskLow
+	}
+}
+
+func saturate(n, max int) float64 {
+	if max <= 0 {
+		return 0
+	}
+	return math.Min(float64(n)/float64(max), 1)
+}
+
+// ChangeArea returns the area a file belongs to: its directory, truncated
+// to two levels (e.g., "internal/refinery/risk.go" -> "internal/refinery").
+// Top-level files belong to ".".
+func ChangeArea(file string) string {
+	dir := path.Dir(filepath.ToSlash(file))
+	parts := strings.Split(dir, "/")
+	if len(parts) > 2 {
+		parts = parts[:2]
+	}
+	return strings.Join(parts, "/")
+}
+
+// IsTestFile reports whether a path looks like a test file.
+func IsTestFile(file string) bool {
+	base := path.Base(filepath.ToSlash(file))
+	name := strings.TrimSuffix(base, path.Ext(base))
+	if strings.HasSuffix(name, "_test") || strings.HasSuffix(name, ".test") ||
+		strings.HasSuffix(name, ".spec") || strings.HasPrefix(name, "test_") {
+		return true
+	}
+	for _, dir := range strings.Split(path.Dir(filepath.ToSlash(file)), "/") {
+		if dir == "test" || dir == "tests" || dir == "testdata" || dir == "__tests__" {
+			return true
+		}
+	}
+	return false
+}
+
+// MergeOutcome records one processed MR for the area failure history.
+type MergeOutcome struct {
+	MR     string    `json:"mr"`
+	Areas  []string  `json:"areas"`
+	Failed bool      `json:"failed"`
+	At     time.Time `json:"at"`
+}
+
+// maxMergeHistory is how many outcomes are kept.
+const maxMergeHistory = 500
+
+// MergeHistory is the refinery's record of merge outcomes per area, stored
+// as JSONL in the rig's .runtime directory.
+type MergeHistory struct {
+	path     string
+	outcomes []MergeOutcome
+}
+
+// MergeHistoryPath returns the merge history file for a rig.
+func MergeHistoryPath(rigPath string) string {
+	return filepath.Join(rigPath, ".runtime", "merge-history.jsonl")
+}
+
+// LoadMergeHistory reads the merge history. A missing file is empty history.
+func LoadMergeHistory(path string) (*MergeHistory, error) {
+	h := &MergeHistory{path: path}
+
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return h, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var o MergeOutcome
+		if err := json.Unmarshal(scanner.Bytes(), &o); err != nil {
+			continue // skip corrupt lines
+		}
+		h.outcomes = append(h.outcomes, o)
+	}
+	return h, scanner.Err()
+}
+
+// Record appends an outcome, trimming the oldest beyond maxMergeHistory.
+func (h *MergeHistory) Record(o MergeOutcome) error {
+	h.outcomes = append(h.outcomes, o)
+	if len(h.outcomes) > maxMergeHistory {
+		h.outcomes = h.outcomes[len(h.outcomes)-maxMergeHistory:]
+		return h.rewrite()
+	}
+
+	if err := os.MkdirAll(filepath.Dir(h.path), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(h.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	data, err := json.Marshal(o)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(append(data, '\n'))
+	return err
+}
+
+func (h *MergeHistory) rewrite() error {
+	var buf strings.Builder
+	for _, o := range h.outcomes {
+		data, err := json.Marshal(o)
+		if err != nil {
+			return err
+		}
+		buf.Write(data)
+		buf.WriteByte('\n')
+	}
+	if err := os.MkdirAll(filepath.Dir(h.path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(h.path, []byte(buf.String()), 0644)
+}
+
+// FailureRate returns the fraction of recorded merges touching area that
+// failed, and how many merges that is based on.
+func (h *MergeHistory) FailureRate(area string) (float64, int) {
+	var total, failed int
+	for _, o := range h.outcomes {
+		for _, a := range o.Areas {
+			if a == area {
+				total++
+				if o.Failed {
+					failed++
+				}
+				break
+			}
+		}
+	}
+	if total == 0 {
+		return 0, 0
+	}
+	return float64(failed) / float64(total), total
+}
+
+// WorstFailureRate returns the area with the highest failure rate among
+// those with at least minSamples recorded merges.
+func (h *MergeHistory) WorstFailureRate(areas []string, minSamples int) (string, float64) {
+	var worst string
+	var worstRate float64
+	for _, area := range areas {
+		rate, n := h.FailureRate(area)
+		if n < minSamples || n == 0 {
+			continue
+		}
+		if worst == "" || rate > worstRate {
+			worst, worstRate = area, rate
+		}
+	}
+	return worst, worstRate
+}
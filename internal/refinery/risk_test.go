@@ -0,0 +1,104 @@
+package refinery
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/git"
+)
+
+func TestChangeAreaAndIsTestFile(t *testing.T) {
+	areas := map[string]string{
+		"README.md":                   ".",
+		"cmd/gt/main.go":              "cmd/gt",
+		"internal/refinery/risk.go":   "internal/refinery",
+		"internal/tui/feed/styles.go": "internal/tui",
+	}
+	for file, want := range areas {
+		if got := ChangeArea(file); got != want {
+			t.Errorf("ChangeArea(%q) = %q, want %q", file, got, want)
+		}
+	}
+
+	for file, want := range map[string]bool{
+		"internal/refinery/risk_test.go": true,
+		"web/app.spec.ts":                true,
+		"tests/test_api.py":              true,
+		"internal/git/testdata/x.txt":    true,
+		"internal/refinery/risk.go":      false,
+		"docs/testing.md":                false,
+	} {
+		if got := IsTestFile(file); got != want {
+			t.Errorf("IsTestFile(%q) = %v, want %v", file, got, want)
+		}
+	}
+}
+
+func TestAssessRisk(t *testing.T) {
+	cfg := DefaultRiskConfig()
+
+	small := AssessRisk([]git.FileDiffStat{
+		{Path: "internal/refinery/risk.go", Added: 20, Deleted: 4},
+		{Path: "internal/refinery/risk_test.go", Added: 30},
+	}, nil, cfg)
+	if small.Level != RiskLow || small.Score > 10 {
+		t.Errorf("small tested change scored %d (%s), want low", small.Score, small.Level)
+	}
+	if small.TestLines != 30 || small.LinesChanged != 54 {
+		t.Errorf("unexpected line counts: %+v", small)
+	}
+	if !reflect.DeepEqual(small.Areas, []string{"internal/refinery"}) {
+		t.Errorf("Areas = %v", small.Areas)
+	}
+
+	var files []git.FileDiffStat
+	for _, dir := range []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j"} {
+		for _, name := range []string{"x.go", "y.go", "z.go"} {
+			files = append(files, git.FileDiffStat{Path: "internal/" + dir + "/" + name, Added: 40})
+		}
+	}
+	big := AssessRisk(files, nil, cfg)
+	if big.Score != 70 || big.Level != RiskHigh {
+		t.Errorf("large untested change scored %d (%s), want 70 (high)", big.Score, big.Level)
+	}
+}
+
+func TestMergeHistory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".runtime", "merge-history.jsonl")
+	h, err := LoadMergeHistory(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	for i, failed := range []bool{true, true, false, true} {
+		o := MergeOutcome{MR: "gt-mr" + string(rune('a'+i)), Areas: []string{"internal/mail"}, Failed: failed, At: now}
+		if err := h.Record(o); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := h.Record(MergeOutcome{MR: "gt-mre", Areas: []string{"internal/git"}, Failed: true, At: now}); err != nil {
+		t.Fatal(err)
+	}
+
+	h, err = LoadMergeHistory(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rate, n := h.FailureRate("internal/mail"); rate != 0.75 || n != 4 {
+		t.Errorf("FailureRate = %v over %d, want 0.75 over 4", rate, n)
+	}
+
+	// internal/git has too little history to count
+	area, rate := h.WorstFailureRate([]string{"internal/git", "internal/mail"}, 3)
+	if area != "internal/mail" || rate != 0.75 {
+		t.Errorf("WorstFailureRate = %q %v", area, rate)
+	}
+
+	risk := AssessRisk([]git.FileDiffStat{{Path: "internal/mail/router.go", Added: 1}}, h, DefaultRiskConfig())
+	if risk.AreaFailureRate != 0.75 {
+		t.Errorf("AreaFailureRate = %v, want 0.75", risk.AreaFailureRate)
+	}
+}
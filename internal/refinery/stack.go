@@ -0,0 +1,188 @@
+package refinery
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/git"
+)
+
+// A worker can submit a stack of MRs: MR B's branch builds on MR A's
+// branch, and B records stacked_on: A (gt mq submit --stacked-on, or
+// detected from the branches). B depends on A, so the queue merges A
+// first. After A merges, B and the rest of the stack are rebased onto the
+// target so they merge with only their own commits. If A fails, the rest
+// of the stack fails with it: the worker and watchers hear about every
+// MR in the stack, and the stack waits until A is fixed and merged.
+
+// stackedMR is an open MR stacked on another MR.
+type stackedMR struct {
+	issue  *beads.Issue
+	fields *beads.MRFields
+}
+
+// info returns the MR as an MRInfo for logging and notifications.
+func (s stackedMR) info() *MRInfo {
+	return &MRInfo{
+		ID:          s.issue.ID,
+		Branch:      s.fields.Branch,
+		Target:      s.fields.Target,
+		SourceIssue: s.fields.SourceIssue,
+		Worker:      s.fields.Worker,
+		Rig:         s.fields.Rig,
+		Title:       s.issue.Title,
+		AgentBead:   s.fields.AgentBead,
+		StackedOn:   s.fields.StackedOn,
+	}
+}
+
+// stackChildren maps each MR ID to the open MRs stacked directly on it.
+func (e *Engineer) stackChildren() (map[string][]stackedMR, error) {
+	issues, err := e.beads.List(beads.ListOptions{
+		Status:   "open",
+		Label:    "gt:merge-request",
+		Priority: -1,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing merge requests: %w", err)
+	}
+	children := make(map[string][]stackedMR)
+	for _, issue := range issues {
+		fields := beads.ParseMRFields(issue)
+		if fields == nil || fields.StackedOn == "" {
+			continue
+		}
+		children[fields.StackedOn] = append(children[fields.StackedOn], stackedMR{issue: issue, fields: fields})
+	}
+	return children, nil
+}
+
+// stackDescendants returns the MRs stacked on mrID, directly or through
+// other stacked MRs, nearest first.
+func stackDescendants(children map[string][]stackedMR, mrID string) []stackedMR {
+	var out []stackedMR
+	seen := map[string]bool{mrID: true}
+	queue := []string{mrID}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		for _, child := range children[id] {
+			if seen[child.issue.ID] {
+				continue
+			}
+			seen[child.issue.ID] = true
+			out = append(out, child)
+			queue = append(queue, child.issue.ID)
+		}
+	}
+	return out
+}
+
+// rebaseStack rebases the MRs stacked on a just-merged MR onto its
+// target, and each MR further up the stack onto its rebased parent, so
+// every branch carries only its own commits. mergedTip is the merged
+// branch's commit before the merge. An MR that doesn't rebase cleanly is
+// left as it was: the merge will report the conflict when it is processed.
+func (e *Engineer) rebaseStack(log *slog.Logger, mr *MRInfo, mergedTip string) {
+	children, err := e.stackChildren()
+	if err != nil {
+		log.Warn("failed to find stacked MRs", "err", err)
+		return
+	}
+	if len(children[mr.ID]) == 0 {
+		return
+	}
+	defer func() {
+		if err := e.git.Checkout(mr.Target); err != nil {
+			log.Warn("failed to return to target after rebasing stack", "target", mr.Target, "err", err)
+		}
+	}()
+	e.rebaseChildren(log, children, mr.ID, mergedTip, mr.Target)
+}
+
+// rebaseChildren rebases the MRs stacked on parentID from oldBase onto
+// newBase, then their own stacked MRs onto them.
+func (e *Engineer) rebaseChildren(log *slog.Logger, children map[string][]stackedMR, parentID, oldBase, newBase string) {
+	for _, child := range children[parentID] {
+		branch := child.fields.Branch
+		oldTip, err := e.git.Rev(branch)
+		if err != nil {
+			log.Warn("stacked MR branch not found", "mr", child.issue.ID, "branch", branch, "err", err)
+			continue
+		}
+		if err := e.git.RebaseOnto(newBase, oldBase, branch); err != nil {
+			_ = e.git.AbortRebase()
+			log.Warn("failed to rebase stacked MR", "mr", child.issue.ID, "branch", branch, "onto", newBase, "err", err)
+			continue
+		}
+		log.Info("rebased stacked MR", "mr", child.issue.ID, "branch", branch, "onto", newBase)
+		e.rebaseChildren(log, children, child.issue.ID, oldTip, branch)
+	}
+}
+
+// failStack fails the MRs stacked on a failed MR along with it. They stay
+// blocked on it; the returned note lists them for the failure mail.
+func (e *Engineer) failStack(log *slog.Logger, mr *MRInfo, failureType string) string {
+	children, err := e.stackChildren()
+	if err != nil {
+		log.Warn("failed to find stacked MRs", "err", err)
+		return ""
+	}
+	stack := stackDescendants(children, mr.ID)
+	if len(stack) == 0 {
+		return ""
+	}
+
+	lines := make([]string, 0, len(stack))
+	for _, s := range stack {
+		child := s.info()
+		lines = append(lines, fmt.Sprintf("- %s (%s)", child.ID, child.Branch))
+		e.notifyWatchers(log, child, "Merge failed",
+			fmt.Sprintf("Branch %s is stacked on %s (%s), which failed to merge to %s (%s).\n\nIssue: %s\nMR: %s\n\nThe MR waits until %s merges.",
+				child.Branch, mr.ID, mr.Branch, mr.Target, failureType, child.SourceIssue, child.ID, mr.ID))
+		log.Error("stacked MR failed with its stack", "stacked_mr", child.ID, "branch", child.Branch)
+	}
+	return fmt.Sprintf("Stacked MRs failing with it (they merge after %s does):\n%s", mr.ID, strings.Join(lines, "\n"))
+}
+
+// FindStackParent returns the open MR that branch is stacked on: the
+// worker's open MR whose branch is the nearest ancestor of branch. It
+// returns nil if branch builds on none of them.
+func FindStackParent(bd *beads.Beads, g *git.Git, branch, worker string) (*beads.Issue, *beads.MRFields, error) {
+	if worker == "" {
+		return nil, nil, nil
+	}
+	issues, err := bd.List(beads.ListOptions{
+		Status:   "open",
+		Label:    "gt:merge-request",
+		Priority: -1,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("listing merge requests: %w", err)
+	}
+
+	var parent *beads.Issue
+	var parentFields *beads.MRFields
+	for _, issue := range issues {
+		fields := beads.ParseMRFields(issue)
+		if fields == nil || fields.Worker != worker || fields.Branch == "" || fields.Branch == branch {
+			continue
+		}
+		if ok, err := g.IsAncestor(fields.Branch, branch); err != nil || !ok {
+			continue
+		}
+		// Same commit: a duplicate submission, not a stack
+		if same, err := g.IsAncestor(branch, fields.Branch); err != nil || same {
+			continue
+		}
+		if parent != nil {
+			if nearer, err := g.IsAncestor(parentFields.Branch, fields.Branch); err != nil || !nearer {
+				continue
+			}
+		}
+		parent, parentFields = issue, fields
+	}
+	return parent, parentFields, nil
+}
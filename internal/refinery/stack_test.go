@@ -0,0 +1,102 @@
+package refinery
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/rig"
+)
+
+func stacked(id, branch, on string) stackedMR {
+	return stackedMR{
+		issue:  &beads.Issue{ID: id},
+		fields: &beads.MRFields{Branch: branch, Target: "main", StackedOn: on},
+	}
+}
+
+func TestStackDescendants(t *testing.T) {
+	children := map[string][]stackedMR{
+		"mr-a": {stacked("mr-b", "polecat/nux/b", "mr-a"), stacked("mr-x", "polecat/nux/x", "mr-a")},
+		"mr-b": {stacked("mr-c", "polecat/nux/c", "mr-b")},
+		"mr-c": {stacked("mr-a", "polecat/nux/a", "mr-c")}, // cycle back to the root
+	}
+
+	var got []string
+	for _, s := range stackDescendants(children, "mr-a") {
+		got = append(got, s.issue.ID)
+	}
+	want := []string{"mr-b", "mr-x", "mr-c"}
+	if len(got) != len(want) {
+		t.Fatalf("stackDescendants = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("stackDescendants = %v, want %v", got, want)
+		}
+	}
+
+	if got := stackDescendants(children, "mr-x"); len(got) != 0 {
+		t.Errorf("MR with nothing stacked on it: got %d descendants", len(got))
+	}
+}
+
+func TestEngineer_RebaseChildren(t *testing.T) {
+	rigPath := t.TempDir()
+	repo := filepath.Join(rigPath, "mayor", "rig")
+	if err := os.MkdirAll(repo, 0755); err != nil {
+		t.Fatal(err)
+	}
+	commit := func(file string) {
+		t.Helper()
+		os.WriteFile(filepath.Join(repo, file), []byte(file), 0644)
+		runGit(t, repo, "add", ".")
+		runGit(t, repo, "commit", "-m", "add "+file)
+	}
+	runGit(t, repo, "init", "-b", "main")
+	runGit(t, repo, "config", "user.email", "test@test.com")
+	runGit(t, repo, "config", "user.name", "Test")
+	commit("README.md")
+
+	// Stack: a <- b <- c, then a merges and main moves on
+	runGit(t, repo, "checkout", "-b", "polecat/nux/a")
+	commit("a.txt")
+	runGit(t, repo, "checkout", "-b", "polecat/nux/b")
+	commit("b.txt")
+	runGit(t, repo, "checkout", "-b", "polecat/nux/c")
+	commit("c.txt")
+	runGit(t, repo, "checkout", "main")
+	runGit(t, repo, "merge", "--no-ff", "-m", "Merge polecat/nux/a into main", "polecat/nux/a")
+	commit("other.txt")
+
+	e := NewEngineer(&rig.Rig{Name: "test-rig", Path: rigPath})
+	e.SetOutput(&bytes.Buffer{})
+	tipA, err := e.git.Rev("polecat/nux/a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	children := map[string][]stackedMR{
+		"mr-a": {stacked("mr-b", "polecat/nux/b", "mr-a")},
+		"mr-b": {stacked("mr-c", "polecat/nux/c", "mr-b")},
+	}
+	e.rebaseChildren(e.log, children, "mr-a", tipA, "main")
+
+	// b sits on main with only its own commit; c sits on the rebased b
+	for _, tt := range []struct {
+		base, branch string
+		ahead        int
+	}{
+		{"main", "polecat/nux/b", 1},
+		{"polecat/nux/b", "polecat/nux/c", 1},
+		{"main", "polecat/nux/c", 2},
+	} {
+		if ok, err := e.git.IsAncestor(tt.base, tt.branch); err != nil || !ok {
+			t.Errorf("%s is not based on %s", tt.branch, tt.base)
+		}
+		if ahead, err := e.git.CommitsAhead(tt.base, tt.branch); err != nil || ahead != tt.ahead {
+			t.Errorf("%s is %d commits ahead of %s, want %d", tt.branch, ahead, tt.base, tt.ahead)
+		}
+	}
+}
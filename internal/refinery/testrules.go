@@ -0,0 +1,157 @@
+package refinery
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// TestRules maps an MR's changed files to the test commands that cover
+// them, so a monorepo only runs the tests for the parts an MR touches.
+//
+// Example:
+//
+//	rules:
+//	  - paths: ["services/api/**", "libs/auth/**"]
+//	    command: cd services/api && go test ./...
+//	  - paths: ["web/**"]
+//	    command: cd web && npm test
+type TestRules struct {
+	// Rules select test commands for changed files matching their paths.
+	Rules []TestRule `json:"rules" yaml:"rules"`
+}
+
+// TestRule runs Command when any changed file matches Paths. Paths are
+// globs relative to the repo root; "**" matches any number of directories.
+type TestRule struct {
+	// Paths are the file globs this rule watches.
+	Paths []string `json:"paths" yaml:"paths"`
+
+	// Command is the test command to run, in the repo root.
+	Command string `json:"command" yaml:"command"`
+}
+
+// LoadTestRules reads and validates a test rules file (YAML or JSON).
+func LoadTestRules(file string) (*TestRules, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("reading test rules: %w", err)
+	}
+	var rules TestRules
+	if err := yaml.Unmarshal(data, &rules); err != nil {
+		return nil, fmt.Errorf("parsing test rules %s: %w", file, err)
+	}
+	for i, r := range rules.Rules {
+		if len(r.Paths) == 0 || strings.TrimSpace(r.Command) == "" {
+			return nil, fmt.Errorf("test rule %d in %s: paths and command are required", i+1, file)
+		}
+		for _, p := range r.Paths {
+			if _, err := path.Match(strings.ReplaceAll(p, "**", "*"), ""); err != nil {
+				return nil, fmt.Errorf("test rule %d in %s: invalid glob %q: %w", i+1, file, p, err)
+			}
+		}
+	}
+	return &rules, nil
+}
+
+// Select returns the commands of the rules matching any changed file, in
+// rule order without duplicates. An empty result means no rule matched.
+func (t *TestRules) Select(changed []string) []string {
+	var commands []string
+	seen := make(map[string]bool)
+	for _, r := range t.Rules {
+		if seen[r.Command] || !anyGlobMatches(r.Paths, changed) {
+			continue
+		}
+		seen[r.Command] = true
+		commands = append(commands, r.Command)
+	}
+	return commands
+}
+
+// TestPlan returns the test commands for an MR on branch into target, and
+// whether test rules narrowed them. With test_rules configured, only the
+// commands whose rules match the MR's changed files run; if no rule
+// matches, or the rules or diff can't be read, the full TestCommand runs
+// instead. The rules file is read from the refinery worktree, so check out
+// target first: an MR must not change its own rules.
+func (e *Engineer) TestPlan(branch, target string) (commands []string, selected bool) {
+	return e.testCommands(e.log, branch, target)
+}
+
+func (e *Engineer) testCommands(log *slog.Logger, branch, target string) ([]string, bool) {
+	var full []string
+	if e.config.TestCommand != "" {
+		full = []string{e.config.TestCommand}
+	}
+	if e.config.TestRules == "" {
+		return full, false
+	}
+
+	rulesPath := e.config.TestRules
+	if !filepath.IsAbs(rulesPath) {
+		rulesPath = filepath.Join(e.workDir, rulesPath)
+	}
+	rules, err := LoadTestRules(rulesPath)
+	if err != nil {
+		log.Warn("test rules unavailable, running full tests", "err", err)
+		return full, false
+	}
+	changed, err := e.git.DiffNames(target + "..." + branch)
+	if err != nil {
+		log.Warn("diffing MR failed, running full tests", "err", err)
+		return full, false
+	}
+
+	commands := rules.Select(changed)
+	if len(commands) == 0 {
+		log.Info("no test rules matched, running full tests", "changed_files", len(changed))
+		return full, false
+	}
+	log.Info("selected tests by changed paths", "changed_files", len(changed), "commands", len(commands))
+	return commands, true
+}
+
+// anyGlobMatches reports whether any name matches any of the globs.
+func anyGlobMatches(globs, names []string) bool {
+	for _, name := range names {
+		for _, g := range globs {
+			if matchPathGlob(g, name) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// matchPathGlob matches a slash-separated path against a glob where "**"
+// matches zero or more directories.
+func matchPathGlob(pattern, name string) bool {
+	return matchPathSegments(strings.Split(pattern, "/"), strings.Split(name, "/"))
+}
+
+func matchPathSegments(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(name); i++ {
+				if matchPathSegments(pattern[1:], name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], name[0]); !ok {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
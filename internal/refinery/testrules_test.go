@@ -0,0 +1,134 @@
+package refinery
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/rig"
+)
+
+func TestMatchPathGlob(t *testing.T) {
+	tests := []struct {
+		pattern string
+		name    string
+		want    bool
+	}{
+		{"services/api/**", "services/api/main.go", true},
+		{"services/api/**", "services/api/handlers/user.go", true},
+		{"services/api/**", "services/web/main.go", false},
+		{"**/*.go", "main.go", true},
+		{"**/*.go", "libs/auth/token.go", true},
+		{"**/*.go", "libs/auth/README.md", false},
+		{"libs/*/go.mod", "libs/auth/go.mod", true},
+		{"libs/*/go.mod", "libs/auth/sub/go.mod", false},
+		{"web/**/test/*.ts", "web/test/a.ts", true},
+		{"web/**/test/*.ts", "web/app/x/test/a.ts", true},
+		{"go.mod", "go.mod", true},
+		{"go.mod", "libs/go.mod", false},
+	}
+
+	for _, tt := range tests {
+		if got := matchPathGlob(tt.pattern, tt.name); got != tt.want {
+			t.Errorf("matchPathGlob(%q, %q) = %v, want %v", tt.pattern, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestTestRules_Select(t *testing.T) {
+	rules := &TestRules{Rules: []TestRule{
+		{Paths: []string{"services/api/**", "libs/auth/**"}, Command: "make test-api"},
+		{Paths: []string{"web/**"}, Command: "make test-web"},
+		{Paths: []string{"libs/**"}, Command: "make test-api"},
+	}}
+
+	tests := []struct {
+		name    string
+		changed []string
+		want    []string
+	}{
+		{"single rule", []string{"web/app.ts"}, []string{"make test-web"}},
+		{"deduplicated", []string{"libs/auth/token.go"}, []string{"make test-api"}},
+		{"rule order", []string{"web/app.ts", "services/api/main.go"}, []string{"make test-api", "make test-web"}},
+		{"no match", []string{"docs/README.md"}, nil},
+		{"no changes", nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rules.Select(tt.changed); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Select(%v) = %v, want %v", tt.changed, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadTestRules_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"missing command": "rules:\n  - paths: [\"web/**\"]\n",
+		"missing paths":   "rules:\n  - command: make test\n",
+		"bad glob":        "rules:\n  - paths: [\"web/[\"]\n    command: make test\n",
+		"bad yaml":        "rules: [\n",
+	}
+
+	for name, content := range tests {
+		t.Run(name, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), "rules.yaml")
+			if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := LoadTestRules(file); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestEngineer_TestPlan(t *testing.T) {
+	rigPath := t.TempDir()
+	repo := filepath.Join(rigPath, "mayor", "rig")
+	if err := os.MkdirAll(filepath.Join(repo, "web"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, repo, "init", "-b", "main")
+	runGit(t, repo, "config", "user.email", "test@test.com")
+	runGit(t, repo, "config", "user.name", "Test")
+	rules := "rules:\n  - paths: [\"web/**\"]\n    command: make test-web\n"
+	os.WriteFile(filepath.Join(repo, "test-rules.yaml"), []byte(rules), 0644)
+	os.WriteFile(filepath.Join(repo, "README.md"), []byte("# test\n"), 0644)
+	runGit(t, repo, "add", ".")
+	runGit(t, repo, "commit", "-m", "initial")
+
+	runGit(t, repo, "checkout", "-b", "polecat/web")
+	os.WriteFile(filepath.Join(repo, "web", "app.ts"), []byte("app"), 0644)
+	runGit(t, repo, "add", ".")
+	runGit(t, repo, "commit", "-m", "web change")
+
+	runGit(t, repo, "checkout", "-b", "polecat/docs", "main")
+	os.WriteFile(filepath.Join(repo, "README.md"), []byte("# docs\n"), 0644)
+	runGit(t, repo, "commit", "-am", "docs change")
+	runGit(t, repo, "checkout", "main")
+
+	e := NewEngineer(&rig.Rig{Name: "test-rig", Path: rigPath})
+	e.config.TestCommand = "make test"
+
+	// Without rules, the full suite runs
+	if got, selected := e.TestPlan("polecat/web", "main"); selected || !reflect.DeepEqual(got, []string{"make test"}) {
+		t.Errorf("without rules: got %v (selected=%v), want full suite", got, selected)
+	}
+
+	e.config.TestRules = "test-rules.yaml"
+	if got, selected := e.TestPlan("polecat/web", "main"); !selected || !reflect.DeepEqual(got, []string{"make test-web"}) {
+		t.Errorf("matching rule: got %v (selected=%v), want [make test-web]", got, selected)
+	}
+	if got, selected := e.TestPlan("polecat/docs", "main"); selected || !reflect.DeepEqual(got, []string{"make test"}) {
+		t.Errorf("no matching rule: got %v (selected=%v), want full suite", got, selected)
+	}
+
+	// A missing rules file falls back to the full suite
+	e.config.TestRules = "missing.yaml"
+	if got, selected := e.TestPlan("polecat/web", "main"); selected || !reflect.DeepEqual(got, []string{"make test"}) {
+		t.Errorf("missing rules: got %v (selected=%v), want full suite", got, selected)
+	}
+}
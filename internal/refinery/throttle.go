@@ -0,0 +1,251 @@
+package refinery
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// EpicQuotaWindow is the rolling period MaxMergesPerEpicPerDay counts
+// merges over.
+const EpicQuotaWindow = 24 * time.Hour
+
+// maxMergeLog is how many merges the throttle log keeps.
+const maxMergeLog = 1000
+
+// MergeRecord is a merge counted against the throttles.
+type MergeRecord struct {
+	MR     string    `json:"mr"`
+	Epic   string    `json:"epic,omitempty"`
+	Worker string    `json:"worker,omitempty"`
+	At     time.Time `json:"at"`
+}
+
+// MergeLog records recent merges by epic and worker for throttling, stored
+// as JSONL in the rig's .runtime directory.
+type MergeLog struct {
+	path    string
+	records []MergeRecord
+}
+
+// MergeLogPath returns the throttle merge log for a rig.
+func MergeLogPath(rigPath string) string {
+	return filepath.Join(rigPath, ".runtime", "merge-log.jsonl")
+}
+
+// LoadMergeLog reads the merge log. A missing file is an empty log.
+func LoadMergeLog(path string) (*MergeLog, error) {
+	l := &MergeLog{path: path}
+
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return l, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var r MergeRecord
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			continue // skip corrupt lines
+		}
+		l.records = append(l.records, r)
+	}
+	return l, scanner.Err()
+}
+
+// Record appends a merge, trimming the oldest beyond maxMergeLog.
+func (l *MergeLog) Record(r MergeRecord) error {
+	l.records = append(l.records, r)
+	if len(l.records) > maxMergeLog {
+		l.records = l.records[len(l.records)-maxMergeLog:]
+		return l.rewrite()
+	}
+
+	if err := os.MkdirAll(filepath.Dir(l.path), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	data, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(append(data, '\n'))
+	return err
+}
+
+func (l *MergeLog) rewrite() error {
+	var buf strings.Builder
+	for _, r := range l.records {
+		data, err := json.Marshal(r)
+		if err != nil {
+			return err
+		}
+		buf.Write(data)
+		buf.WriteByte('\n')
+	}
+	return os.WriteFile(l.path, []byte(buf.String()), 0644)
+}
+
+// ThrottleStatus is an MR's standing against the merge throttles.
+type ThrottleStatus struct {
+	// Epic is the MR's epic, if it has one.
+	Epic string `json:"epic,omitempty"`
+
+	// EpicMerges counts the epic's merges in the last EpicQuotaWindow.
+	EpicMerges int `json:"epic_merges"`
+
+	// EpicLimit is MaxMergesPerEpicPerDay (0 = unlimited).
+	EpicLimit int `json:"epic_limit"`
+
+	// Worker is the MR's worker.
+	Worker string `json:"worker,omitempty"`
+
+	// WorkerLastMerge is when the worker's last merge landed, if any.
+	WorkerLastMerge *time.Time `json:"worker_last_merge,omitempty"`
+
+	// Cooldown is WorkerCooldown (0 = none).
+	Cooldown time.Duration `json:"cooldown"`
+
+	// Throttled means the MR must wait until Until to merge.
+	Throttled bool      `json:"throttled"`
+	Reason    string    `json:"reason,omitempty"`
+	Until     time.Time `json:"until,omitempty"`
+}
+
+// Status reports where an MR for epic by worker stands against cfg's
+// throttles at now.
+func (l *MergeLog) Status(cfg *MergeQueueConfig, epic, worker string, now time.Time) *ThrottleStatus {
+	s := &ThrottleStatus{
+		Epic:      epic,
+		EpicLimit: cfg.MaxMergesPerEpicPerDay,
+		Worker:    worker,
+		Cooldown:  cfg.WorkerCooldown,
+	}
+
+	windowStart := now.Add(-EpicQuotaWindow)
+	var epicMerges []time.Time
+	for _, r := range l.records {
+		if epic != "" && r.Epic == epic && r.At.After(windowStart) {
+			epicMerges = append(epicMerges, r.At)
+		}
+		if worker != "" && r.Worker == worker && (s.WorkerLastMerge == nil || r.At.After(*s.WorkerLastMerge)) {
+			at := r.At
+			s.WorkerLastMerge = &at
+		}
+	}
+	s.EpicMerges = len(epicMerges)
+
+	if s.EpicLimit > 0 && epic != "" && s.EpicMerges >= s.EpicLimit {
+		// A slot frees up when the oldest merge still counted leaves the
+		// window (records are in merge order)
+		oldest := epicMerges[s.EpicMerges-s.EpicLimit]
+		s.throttle(oldest.Add(EpicQuotaWindow),
+			fmt.Sprintf("epic %s reached its quota of %d merges per day", epic, s.EpicLimit))
+	}
+	if s.Cooldown > 0 && s.WorkerLastMerge != nil {
+		if until := s.WorkerLastMerge.Add(s.Cooldown); until.After(now) {
+			s.throttle(until, fmt.Sprintf("worker %s is cooling off (%s between merges)", worker, s.Cooldown))
+		}
+	}
+	return s
+}
+
+// throttle holds the MR until the later of its current hold and until.
+func (s *ThrottleStatus) throttle(until time.Time, reason string) {
+	if s.Throttled && !until.After(s.Until) {
+		return
+	}
+	s.Throttled = true
+	s.Until = until
+	s.Reason = reason
+}
+
+// throttlingEnabled reports whether any merge throttle is configured.
+func (c *MergeQueueConfig) throttlingEnabled() bool {
+	return c.MaxMergesPerEpicPerDay > 0 || c.WorkerCooldown > 0
+}
+
+// mrEpic returns the epic an MR belongs to: the epic of its integration
+// branch, or else its source issue's parent. Returns "" if it has none.
+func (e *Engineer) mrEpic(mr *MRInfo) string {
+	if epic, ok := strings.CutPrefix(mr.Target, "integration/"); ok {
+		return epic
+	}
+	if mr.SourceIssue == "" {
+		return ""
+	}
+	source, err := e.beads.Show(mr.SourceIssue)
+	if err != nil {
+		return ""
+	}
+	return source.Parent
+}
+
+// ThrottleStatus reports where an MR stands against the merge throttles,
+// or nil if none are configured.
+func (e *Engineer) ThrottleStatus(mr *MRInfo) (*ThrottleStatus, error) {
+	if !e.config.throttlingEnabled() {
+		return nil, nil
+	}
+	mergeLog, err := LoadMergeLog(MergeLogPath(e.rig.Path))
+	if err != nil {
+		return nil, fmt.Errorf("loading merge log: %w", err)
+	}
+	return mergeLog.Status(e.config, e.mrEpic(mr), mr.Worker, time.Now()), nil
+}
+
+// filterThrottled drops MRs held by the merge throttles. They stay open
+// and return to the ready queue once their hold passes.
+func (e *Engineer) filterThrottled(mrs []*MRInfo) []*MRInfo {
+	if !e.config.throttlingEnabled() || len(mrs) == 0 {
+		return mrs
+	}
+	mergeLog, err := LoadMergeLog(MergeLogPath(e.rig.Path))
+	if err != nil {
+		e.log.Warn("failed to load merge log; not throttling", "err", err)
+		return mrs
+	}
+
+	now := time.Now()
+	var ready []*MRInfo
+	for _, mr := range mrs {
+		status := mergeLog.Status(e.config, e.mrEpic(mr), mr.Worker, now)
+		if status.Throttled {
+			e.mrLog(mr).Debug("throttled", "reason", status.Reason, "until", status.Until.Format(time.RFC3339))
+			continue
+		}
+		ready = append(ready, mr)
+	}
+	return ready
+}
+
+// recordThrottledMerge counts a merge against its epic's quota and its
+// worker's cooldown. Merges are recorded even with throttling off, so
+// enabling it takes recent merges into account.
+func (e *Engineer) recordThrottledMerge(log *slog.Logger, mr *MRInfo) {
+	mergeLog, err := LoadMergeLog(MergeLogPath(e.rig.Path))
+	if err == nil {
+		err = mergeLog.Record(MergeRecord{
+			MR:     mr.ID,
+			Epic:   e.mrEpic(mr),
+			Worker: mr.Worker,
+			At:     time.Now().UTC(),
+		})
+	}
+	if err != nil {
+		log.Warn("failed to record merge in merge log", "err", err)
+	}
+}
@@ -0,0 +1,59 @@
+package refinery
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestMergeLogStatus(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "merge-log.jsonl")
+	mergeLog, err := LoadMergeLog(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	for _, r := range []MergeRecord{
+		{MR: "mr-1", Epic: "gt-epic", Worker: "nux", At: now.Add(-30 * time.Hour)}, // outside the window
+		{MR: "mr-2", Epic: "gt-epic", Worker: "nux", At: now.Add(-20 * time.Hour)},
+		{MR: "mr-3", Epic: "gt-epic", Worker: "toast", At: now.Add(-10 * time.Minute)},
+	} {
+		if err := mergeLog.Record(r); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The log is persisted
+	mergeLog, err = LoadMergeLog(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := DefaultMergeQueueConfig()
+	if s := mergeLog.Status(cfg, "gt-epic", "toast", now); s.Throttled {
+		t.Errorf("no throttles configured, got %+v", s)
+	}
+
+	cfg.MaxMergesPerEpicPerDay = 2
+	s := mergeLog.Status(cfg, "gt-epic", "nux", now)
+	if !s.Throttled || s.EpicMerges != 2 {
+		t.Fatalf("expected epic quota hold with 2 merges, got %+v", s)
+	}
+	// The oldest counted merge (mr-2) leaves the window first
+	if want := now.Add(-20 * time.Hour).Add(EpicQuotaWindow); !s.Until.Equal(want) {
+		t.Errorf("Until = %v, want %v", s.Until, want)
+	}
+	if s := mergeLog.Status(cfg, "gt-other", "nux", now); s.Throttled {
+		t.Errorf("other epic should not be held: %+v", s)
+	}
+
+	cfg.MaxMergesPerEpicPerDay = 0
+	cfg.WorkerCooldown = time.Hour
+	s = mergeLog.Status(cfg, "", "toast", now)
+	if !s.Throttled || !s.Until.Equal(now.Add(50*time.Minute)) {
+		t.Errorf("expected toast cooling off for 50m, got %+v", s)
+	}
+	if s := mergeLog.Status(cfg, "", "nux", now); s.Throttled || s.WorkerLastMerge == nil {
+		t.Errorf("nux's cooldown has passed, got %+v", s)
+	}
+}
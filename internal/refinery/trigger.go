@@ -0,0 +1,287 @@
+package refinery
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/constants"
+)
+
+// Merge queue trigger modes.
+const (
+	// TriggerPoll checks the queue every PollInterval.
+	TriggerPoll = "poll"
+
+	// TriggerFile watches the rig's MR event directory for events written by
+	// gt mq submit, falling back to FallbackPollInterval.
+	TriggerFile = "file"
+
+	// TriggerHTTP listens on TriggerAddr for events posted by gt mq submit,
+	// falling back to FallbackPollInterval. Events that can't be delivered
+	// over HTTP are written to the event directory and picked up as well.
+	TriggerHTTP = "http"
+)
+
+// DefaultTriggerAddr is the local endpoint used by TriggerHTTP.
+const DefaultTriggerAddr = "127.0.0.1:7733"
+
+// TriggerPath is the HTTP path MR ready events are posted to.
+const TriggerPath = "/mr-ready"
+
+// triggerScanInterval is how often TriggerFile checks the event directory.
+const triggerScanInterval = time.Second
+
+// Trigger wake reasons.
+const (
+	WakeEvent = "event"
+	WakePoll  = "poll"
+)
+
+// ReadyEvent announces that a merge request is ready to process.
+type ReadyEvent struct {
+	MRID   string    `json:"mr_id"`
+	Branch string    `json:"branch,omitempty"`
+	Target string    `json:"target,omitempty"`
+	At     time.Time `json:"at"`
+}
+
+// WakeResult reports why a Trigger wait returned.
+type WakeResult struct {
+	// Reason is WakeEvent or WakePoll.
+	Reason string `json:"reason"`
+
+	// Events are the MR ready events received (WakeEvent only).
+	Events []ReadyEvent `json:"events,omitempty"`
+
+	// Elapsed is how long the wait took.
+	Elapsed time.Duration `json:"elapsed"`
+}
+
+// EventsDir returns the directory MR ready events are written to.
+func (e *Engineer) EventsDir() string {
+	return filepath.Join(e.rig.Path, constants.DirRuntime, "mq-events")
+}
+
+// NotifyMRReady tells a waiting engineer that an MR is ready. In TriggerHTTP
+// mode the event is posted to TriggerAddr; otherwise, or if no engineer is
+// listening, it is written to EventsDir. In TriggerPoll mode nothing is sent.
+func (e *Engineer) NotifyMRReady(ev ReadyEvent) error {
+	if ev.At.IsZero() {
+		ev.At = time.Now()
+	}
+	switch e.config.TriggerMode {
+	case TriggerHTTP:
+		if err := postReadyEvent(e.config.TriggerAddr, ev); err == nil {
+			return nil
+		}
+		return e.writeReadyEvent(ev)
+	case TriggerFile:
+		return e.writeReadyEvent(ev)
+	default:
+		return nil
+	}
+}
+
+// postReadyEvent delivers an event to an engineer listening on addr.
+func postReadyEvent(addr string, ev ReadyEvent) error {
+	data, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+	client := &http.Client{Timeout: 2 * time.Second}
+	resp, err := client.Post("http://"+addr+TriggerPath, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusAccepted {
+		return fmt.Errorf("trigger endpoint returned %s", resp.Status)
+	}
+	return nil
+}
+
+// writeReadyEvent writes an event file for the engineer to pick up.
+func (e *Engineer) writeReadyEvent(ev ReadyEvent) error {
+	if err := os.MkdirAll(e.EventsDir(), 0755); err != nil {
+		return fmt.Errorf("creating events directory: %w", err)
+	}
+	data, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+	// Write then rename so the watcher never reads a partial event
+	name := fmt.Sprintf("%d-%s.json", ev.At.UnixNano(), ev.MRID)
+	tmp := filepath.Join(e.EventsDir(), "."+name)
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("writing event: %w", err)
+	}
+	return os.Rename(tmp, filepath.Join(e.EventsDir(), name))
+}
+
+// Trigger wakes the engineer when an MR is ready, according to the
+// configured trigger mode.
+type Trigger struct {
+	engineer *Engineer
+	mode     string
+	interval time.Duration // fallback poll interval
+	events   chan ReadyEvent
+	listener net.Listener
+	server   *http.Server
+}
+
+// NewTrigger creates a trigger for the configured mode. In TriggerHTTP mode
+// it starts listening on TriggerAddr; call Close when done.
+func (e *Engineer) NewTrigger() (*Trigger, error) {
+	t := &Trigger{
+		engineer: e,
+		mode:     e.config.TriggerMode,
+		interval: e.config.PollInterval,
+	}
+	if t.mode == "" {
+		t.mode = TriggerPoll
+	}
+	if t.mode == TriggerPoll {
+		return t, nil
+	}
+	if e.config.FallbackPollInterval > 0 {
+		t.interval = e.config.FallbackPollInterval
+	}
+
+	if t.mode == TriggerHTTP {
+		listener, err := net.Listen("tcp", e.config.TriggerAddr)
+		if err != nil {
+			return nil, fmt.Errorf("listening on %s: %w", e.config.TriggerAddr, err)
+		}
+		t.listener = listener
+		t.events = make(chan ReadyEvent, 16)
+		mux := http.NewServeMux()
+		mux.HandleFunc(TriggerPath, t.handleReady)
+		t.server = &http.Server{Handler: mux, ReadHeaderTimeout: 5 * time.Second}
+		go func() { _ = t.server.Serve(listener) }()
+	}
+	return t, nil
+}
+
+// Addr returns the address the trigger listens on (TriggerHTTP only).
+func (t *Trigger) Addr() string {
+	if t.listener == nil {
+		return ""
+	}
+	return t.listener.Addr().String()
+}
+
+// Close stops the HTTP listener, if any.
+func (t *Trigger) Close() error {
+	if t.server == nil {
+		return nil
+	}
+	return t.server.Close()
+}
+
+// handleReady accepts an event posted by NotifyMRReady.
+func (t *Trigger) handleReady(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	var ev ReadyEvent
+	if err := json.NewDecoder(r.Body).Decode(&ev); err != nil || ev.MRID == "" {
+		http.Error(w, "invalid event", http.StatusBadRequest)
+		return
+	}
+	select {
+	case t.events <- ev:
+		w.WriteHeader(http.StatusAccepted)
+	default:
+		// The waiter is behind; have the sender fall back to an event file
+		http.Error(w, "busy", http.StatusServiceUnavailable)
+	}
+}
+
+// Wait blocks until an MR ready event arrives or the poll interval passes.
+// Events delivered while nobody was waiting are returned immediately.
+func (t *Trigger) Wait(ctx context.Context) (*WakeResult, error) {
+	start := time.Now()
+	wake := func(reason string, events []ReadyEvent) *WakeResult {
+		return &WakeResult{Reason: reason, Events: events, Elapsed: time.Since(start)}
+	}
+
+	poll := time.NewTimer(t.interval)
+	defer poll.Stop()
+
+	if t.mode == TriggerPoll {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-poll.C:
+			return wake(WakePoll, nil), nil
+		}
+	}
+
+	scan := time.NewTicker(triggerScanInterval)
+	defer scan.Stop()
+	for {
+		events, err := t.engineer.drainReadyEvents()
+		if err != nil {
+			return nil, err
+		}
+		if len(events) > 0 {
+			return wake(WakeEvent, events), nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-poll.C:
+			return wake(WakePoll, nil), nil
+		case ev := <-t.events:
+			return wake(WakeEvent, []ReadyEvent{ev}), nil
+		case <-scan.C:
+		}
+	}
+}
+
+// drainReadyEvents reads and removes all event files, oldest first.
+// Unreadable files are removed so they don't wake every wait.
+func (e *Engineer) drainReadyEvents() ([]ReadyEvent, error) {
+	entries, err := os.ReadDir(e.EventsDir())
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading events directory: %w", err)
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".json") && !strings.HasPrefix(entry.Name(), ".") {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+
+	var events []ReadyEvent
+	for _, name := range names {
+		path := filepath.Join(e.EventsDir(), name)
+		data, err := os.ReadFile(path)
+		_ = os.Remove(path)
+		if err != nil {
+			continue
+		}
+		var ev ReadyEvent
+		if json.Unmarshal(data, &ev) == nil && ev.MRID != "" {
+			events = append(events, ev)
+		}
+	}
+	return events, nil
+}
@@ -0,0 +1,152 @@
+package refinery
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/rig"
+)
+
+func newTriggerEngineer(t *testing.T, mode string) *Engineer {
+	t.Helper()
+	e := NewEngineer(&rig.Rig{Name: "test-rig", Path: t.TempDir()})
+	e.config.TriggerMode = mode
+	e.config.TriggerAddr = "127.0.0.1:0"
+	e.config.FallbackPollInterval = 5 * time.Second
+	return e
+}
+
+func TestTrigger_FileEvent(t *testing.T) {
+	e := newTriggerEngineer(t, TriggerFile)
+	trigger, err := e.NewTrigger()
+	if err != nil {
+		t.Fatalf("NewTrigger: %v", err)
+	}
+	defer trigger.Close()
+
+	// Events written before the wait are returned immediately
+	if err := e.NotifyMRReady(ReadyEvent{MRID: "gt-mr1", Branch: "polecat/nux", Target: "main"}); err != nil {
+		t.Fatalf("NotifyMRReady: %v", err)
+	}
+	result, err := trigger.Wait(context.Background())
+	if err != nil {
+		t.Fatalf("Wait: %v", err)
+	}
+	if result.Reason != WakeEvent || len(result.Events) != 1 || result.Events[0].MRID != "gt-mr1" {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+
+	// The event was consumed
+	entries, _ := os.ReadDir(e.EventsDir())
+	if len(entries) != 0 {
+		t.Errorf("expected event files to be consumed, found %d", len(entries))
+	}
+
+	// Events written during the wait wake it within the scan interval
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		_ = e.NotifyMRReady(ReadyEvent{MRID: "gt-mr2"})
+	}()
+	result, err = trigger.Wait(context.Background())
+	if err != nil {
+		t.Fatalf("Wait: %v", err)
+	}
+	if result.Reason != WakeEvent || result.Events[0].MRID != "gt-mr2" {
+		t.Errorf("unexpected result: %+v", result)
+	}
+}
+
+func TestTrigger_HTTPEvent(t *testing.T) {
+	e := newTriggerEngineer(t, TriggerHTTP)
+	trigger, err := e.NewTrigger()
+	if err != nil {
+		t.Fatalf("NewTrigger: %v", err)
+	}
+	defer trigger.Close()
+
+	// Submitters send to the address the trigger bound
+	sender := newTriggerEngineer(t, TriggerHTTP)
+	sender.config.TriggerAddr = trigger.Addr()
+	if err := sender.NotifyMRReady(ReadyEvent{MRID: "gt-mr1"}); err != nil {
+		t.Fatalf("NotifyMRReady: %v", err)
+	}
+	if _, err := os.Stat(sender.EventsDir()); !os.IsNotExist(err) {
+		t.Error("expected HTTP delivery, not an event file")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	result, err := trigger.Wait(ctx)
+	if err != nil {
+		t.Fatalf("Wait: %v", err)
+	}
+	if result.Reason != WakeEvent || result.Events[0].MRID != "gt-mr1" {
+		t.Errorf("unexpected result: %+v", result)
+	}
+}
+
+func TestTrigger_HTTPFallsBackToFile(t *testing.T) {
+	e := newTriggerEngineer(t, TriggerHTTP)
+	e.config.TriggerAddr = "127.0.0.1:1" // nothing listening
+	if err := e.NotifyMRReady(ReadyEvent{MRID: "gt-mr1"}); err != nil {
+		t.Fatalf("NotifyMRReady: %v", err)
+	}
+	events, err := e.drainReadyEvents()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 1 || events[0].MRID != "gt-mr1" {
+		t.Errorf("expected the event in a file, got %+v", events)
+	}
+}
+
+func TestTrigger_FallbackPoll(t *testing.T) {
+	e := newTriggerEngineer(t, TriggerFile)
+	e.config.FallbackPollInterval = 50 * time.Millisecond
+	trigger, err := e.NewTrigger()
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := trigger.Wait(context.Background())
+	if err != nil {
+		t.Fatalf("Wait: %v", err)
+	}
+	if result.Reason != WakePoll {
+		t.Errorf("Reason = %s, want poll", result.Reason)
+	}
+
+	// Poll mode sends nothing
+	poll := newTriggerEngineer(t, TriggerPoll)
+	if err := poll.NotifyMRReady(ReadyEvent{MRID: "gt-mr1"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(poll.EventsDir()); !os.IsNotExist(err) {
+		t.Error("poll mode should not write events")
+	}
+}
+
+func TestEngineer_LoadConfig_Trigger(t *testing.T) {
+	tmpDir := t.TempDir()
+	config := `{"merge_queue": {"trigger_mode": "http", "trigger_addr": "127.0.0.1:9999", "fallback_poll_interval": "2m"}}`
+	if err := os.WriteFile(filepath.Join(tmpDir, "config.json"), []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+	e := NewEngineer(&rig.Rig{Name: "test-rig", Path: tmpDir})
+	if err := e.LoadConfig(); err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	if e.config.TriggerMode != TriggerHTTP || e.config.TriggerAddr != "127.0.0.1:9999" || e.config.FallbackPollInterval != 2*time.Minute {
+		t.Errorf("unexpected trigger config: %+v", e.config)
+	}
+
+	bad := `{"merge_queue": {"trigger_mode": "webhook"}}`
+	if err := os.WriteFile(filepath.Join(tmpDir, "config.json"), []byte(bad), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := NewEngineer(&rig.Rig{Name: "test-rig", Path: tmpDir}).LoadConfig(); err == nil {
+		t.Error("expected error for invalid trigger_mode")
+	}
+}
@@ -282,12 +282,23 @@ const (
 
 	// FailureCheckout indicates checkout of target branch failed.
 	FailureCheckout FailureType = "checkout_fail"
+
+	// FailureStale indicates the branch is too far behind the target branch.
+	FailureStale FailureType = "stale_branch"
+
+	// FailureChangelog indicates the changelog entry for the merge could
+	// not be written. The refinery's setup is at fault, not the MR.
+	FailureChangelog FailureType = "changelog_fail"
+
+	// FailureUnsigned indicates the merge commit could not be verified as
+	// signed. The refinery's signing setup is at fault, not the MR.
+	FailureUnsigned FailureType = "unsigned_merge"
 )
 
 // FailureLabel returns the beads label for this failure type.
 func (f FailureType) FailureLabel() string {
 	switch f {
-	case FailureConflict:
+	case FailureConflict, FailureStale:
 		return "needs-rebase"
 	case FailureTestsFail, FailureBuildFail, FailureFlakyTest:
 		return "needs-fix"
@@ -301,7 +312,7 @@ func (f FailureType) FailureLabel() string {
 // ShouldAssignToWorker returns true if this failure should be assigned back to the worker.
 func (f FailureType) ShouldAssignToWorker() bool {
 	switch f {
-	case FailureConflict, FailureTestsFail, FailureBuildFail, FailureFlakyTest:
+	case FailureConflict, FailureStale, FailureTestsFail, FailureBuildFail, FailureFlakyTest:
 		return true
 	default:
 		return false
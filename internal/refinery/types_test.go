@@ -263,6 +263,7 @@ func TestFailureType_FailureLabel(t *testing.T) {
 	}{
 		{FailureNone, ""},
 		{FailureConflict, "needs-rebase"},
+		{FailureStale, "needs-rebase"},
 		{FailureTestsFail, "needs-fix"},
 		{FailureBuildFail, "needs-fix"},
 		{FailureFlakyTest, "needs-fix"},
@@ -288,6 +289,7 @@ func TestFailureType_ShouldAssignToWorker(t *testing.T) {
 	}{
 		{FailureNone, false},
 		{FailureConflict, true},
+		{FailureStale, true},
 		{FailureTestsFail, true},
 		{FailureBuildFail, true},
 		{FailureFlakyTest, true},
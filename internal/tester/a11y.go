@@ -0,0 +1,208 @@
+package tester
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// ObservationAccessibility indicates an accessibility violation found by an
+// axe-core scan in --a11y mode.
+const ObservationAccessibility ObservationType = "accessibility"
+
+// A11yDir is the run subdirectory the agent saves axe-core scan results to,
+// one <step>.json file per scan.
+const A11yDir = "a11y"
+
+// AxeCoreURL is the axe-core build injected into pages for scanning.
+const AxeCoreURL = "https://cdnjs.cloudflare.com/ajax/libs/axe-core/4.10.2/axe.min.js"
+
+// AxeScanScript loads axe-core into the current page (once) and resolves to
+// the scan results. The agent runs it with browser_evaluate at key steps.
+const AxeScanScript = `async () => {
+  if (!window.axe) {
+    await new Promise((resolve, reject) => {
+      const s = document.createElement('script');
+      s.src = '` + AxeCoreURL + `';
+      s.onload = resolve;
+      s.onerror = () => reject(new Error('failed to load axe-core'));
+      document.head.appendChild(s);
+    });
+  }
+  const r = await window.axe.run(document, { resultTypes: ['violations'] });
+  return { url: r.url, timestamp: r.timestamp, violations: r.violations };
+}`
+
+// AxeResult is the subset of an axe-core run result gt reads.
+type AxeResult struct {
+	URL        string         `json:"url"`
+	Timestamp  string         `json:"timestamp,omitempty"`
+	Violations []AxeViolation `json:"violations"`
+}
+
+// AxeViolation is a single failed axe-core rule.
+type AxeViolation struct {
+	// ID is the axe rule ID (e.g., "color-contrast").
+	ID string `json:"id"`
+
+	// Impact is minor, moderate, serious, or critical.
+	Impact string `json:"impact"`
+
+	// Help is the one-line rule summary.
+	Help string `json:"help"`
+
+	// HelpURL links to the rule documentation.
+	HelpURL string `json:"helpUrl"`
+
+	// Tags include WCAG references such as "wcag2aa" and "wcag143".
+	Tags []string `json:"tags"`
+
+	// Nodes are the offending elements.
+	Nodes []AxeNode `json:"nodes"`
+}
+
+// AxeNode is an element that failed a rule.
+type AxeNode struct {
+	Target []string `json:"target"`
+}
+
+// A11yFinding is an axe-core violation converted to observation form.
+type A11yFinding struct {
+	// Step is the scan the violation was found in (the result file name).
+	Step string `json:"step"`
+
+	// Rule is the axe rule ID.
+	Rule string `json:"rule"`
+
+	// Severity is derived from the axe impact.
+	Severity Severity `json:"severity"`
+
+	// Location is the page URL.
+	Location string `json:"location"`
+
+	// Description summarizes the rule and affected elements.
+	Description string `json:"description"`
+
+	// WCAG lists the WCAG success criteria the rule maps to (e.g., "1.4.3").
+	WCAG []string `json:"wcag,omitempty"`
+
+	// HelpURL links to the rule documentation.
+	HelpURL string `json:"help_url,omitempty"`
+}
+
+// A11yInstructions is appended to the tester CLAUDE.md in --a11y mode.
+func A11yInstructions(outputDir string) string {
+	return fmt.Sprintf(`
+## Accessibility Audit
+
+This run also audits accessibility. At each key step (after every page load,
+after opening a dialog or menu, and after submitting a form) run this script
+with browser_evaluate and save the JSON it returns to
+%s/<step>.json, naming the step (e.g. "01-homepage.json"):
+
+`+"```js\n%s\n```"+`
+
+Don't record axe violations as observations yourself and don't change how
+you navigate because of them; they are converted automatically.
+`, filepath.Join(outputDir, A11yDir), AxeScanScript)
+}
+
+// impactSeverity maps axe impact levels onto observation severities.
+var impactSeverity = map[string]Severity{
+	"critical": SeverityP0,
+	"serious":  SeverityP1,
+	"moderate": SeverityP2,
+	"minor":    SeverityP3,
+}
+
+// wcagCriterionTag matches success-criterion tags like "wcag143" or "wcag1410".
+var wcagCriterionTag = regexp.MustCompile(`^wcag(\d)(\d)(\d{1,2})$`)
+
+// WCAGReferences extracts WCAG success criteria from axe tags, e.g.
+// "wcag143" -> "1.4.3". Level tags such as "wcag2aa" are ignored.
+func WCAGReferences(tags []string) []string {
+	var refs []string
+	for _, tag := range tags {
+		if m := wcagCriterionTag.FindStringSubmatch(tag); m != nil {
+			refs = append(refs, fmt.Sprintf("%s.%s.%s", m[1], m[2], m[3]))
+		}
+	}
+	return refs
+}
+
+// A11yFindings converts one scan's violations into findings.
+func A11yFindings(step string, result *AxeResult) []A11yFinding {
+	findings := make([]A11yFinding, 0, len(result.Violations))
+	for _, v := range result.Violations {
+		severity, ok := impactSeverity[v.Impact]
+		if !ok {
+			severity = SeverityP2
+		}
+
+		var targets []string
+		for _, n := range v.Nodes {
+			targets = append(targets, strings.Join(n.Target, " "))
+		}
+		desc := v.Help
+		if len(targets) > 0 {
+			desc = fmt.Sprintf("%s (%d element(s): %s)", v.Help, len(targets), truncateTargets(targets, 3))
+		}
+
+		findings = append(findings, A11yFinding{
+			Step:        step,
+			Rule:        v.ID,
+			Severity:    severity,
+			Location:    result.URL,
+			Description: desc,
+			WCAG:        WCAGReferences(v.Tags),
+			HelpURL:     v.HelpURL,
+		})
+	}
+	return findings
+}
+
+func truncateTargets(targets []string, max int) string {
+	if len(targets) <= max {
+		return strings.Join(targets, ", ")
+	}
+	return fmt.Sprintf("%s, +%d more", strings.Join(targets[:max], ", "), len(targets)-max)
+}
+
+// LoadA11yFindings reads every scan saved under <runDir>/a11y and returns
+// their findings in step order. A missing directory yields no findings.
+func LoadA11yFindings(runDir string) ([]A11yFinding, error) {
+	dir := filepath.Join(runDir, A11yDir)
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, e := range entries {
+		if !e.IsDir() && filepath.Ext(e.Name()) == ".json" {
+			names = append(names, e.Name())
+		}
+	}
+	sort.Strings(names)
+
+	var findings []A11yFinding
+	for _, name := range names {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return nil, err
+		}
+		var result AxeResult
+		if err := json.Unmarshal(data, &result); err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", name, err)
+		}
+		findings = append(findings, A11yFindings(strings.TrimSuffix(name, ".json"), &result)...)
+	}
+	return findings, nil
+}
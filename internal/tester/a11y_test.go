@@ -0,0 +1,78 @@
+package tester
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestWCAGReferences(t *testing.T) {
+	got := WCAGReferences([]string{"cat.color", "wcag2aa", "wcag143", "wcag1410", "best-practice"})
+	want := []string{"1.4.3", "1.4.10"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("WCAGReferences = %v, want %v", got, want)
+	}
+}
+
+func TestLoadA11yFindings(t *testing.T) {
+	runDir := t.TempDir()
+
+	findings, err := LoadA11yFindings(runDir)
+	if err != nil || findings != nil {
+		t.Fatalf("missing a11y dir: got %v, %v", findings, err)
+	}
+
+	dir := filepath.Join(runDir, A11yDir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	scans := map[string]string{
+		"02-form.json": `{"url":"https://example.com/signup","violations":[
+			{"id":"label","impact":"critical","help":"Form elements must have labels","tags":["wcag2a","wcag412"],
+			 "nodes":[{"target":["#email"]}]}]}`,
+		"01-home.json": `{"url":"https://example.com/","violations":[
+			{"id":"color-contrast","impact":"serious","help":"Elements must have sufficient color contrast","tags":["wcag2aa","wcag143"],
+			 "nodes":[{"target":["a"]},{"target":["p"]},{"target":["h2"]},{"target":["li"]}]},
+			{"id":"region","impact":"unknown","help":"All content should be contained by landmarks","tags":["best-practice"]}]}`,
+		"notes.txt": "ignored",
+	}
+	for name, data := range scans {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	findings, err = LoadA11yFindings(runDir)
+	if err != nil {
+		t.Fatalf("LoadA11yFindings: %v", err)
+	}
+	if len(findings) != 3 {
+		t.Fatalf("got %d findings, want 3: %+v", len(findings), findings)
+	}
+
+	contrast := findings[0]
+	if contrast.Step != "01-home" || contrast.Rule != "color-contrast" || contrast.Severity != SeverityP1 {
+		t.Errorf("unexpected first finding: %+v", contrast)
+	}
+	if want := "Elements must have sufficient color contrast (4 element(s): a, p, h2, +1 more)"; contrast.Description != want {
+		t.Errorf("Description = %q, want %q", contrast.Description, want)
+	}
+	if findings[1].Severity != SeverityP2 {
+		t.Errorf("unknown impact severity = %s, want P2", findings[1].Severity)
+	}
+	label := findings[2]
+	if label.Step != "02-form" || label.Severity != SeverityP0 || label.Location != "https://example.com/signup" {
+		t.Errorf("unexpected label finding: %+v", label)
+	}
+	if !reflect.DeepEqual(label.WCAG, []string{"4.1.2"}) {
+		t.Errorf("WCAG = %v", label.WCAG)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "03-bad.json"), []byte("{"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadA11yFindings(runDir); err == nil {
+		t.Error("expected error for malformed scan")
+	}
+}
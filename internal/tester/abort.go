@@ -0,0 +1,137 @@
+package tester
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// StatusAborted is the TestResult status of a run stopped by one of the
+// scenario's abort_if conditions.
+const StatusAborted = "aborted"
+
+// AbortFile is the file in the run directory the agent writes when an
+// abort_if condition matches.
+const AbortFile = "abort.json"
+
+// Abort condition kinds, matching the abort_if fields.
+const (
+	AbortSelector   = "selector"
+	AbortHTTPStatus = "http_status"
+	AbortText       = "text"
+)
+
+// AbortSignal records which abort_if condition stopped a run.
+type AbortSignal struct {
+	// Condition is AbortSelector, AbortHTTPStatus or AbortText.
+	Condition string `json:"condition"`
+
+	// Value is the selector, status code or text that matched.
+	Value string `json:"value"`
+
+	// URL is the page the condition was seen on.
+	URL string `json:"url,omitempty"`
+}
+
+// Reason describes the signal for results and reports, e.g.
+// `abort_if matched: selector ".maintenance-banner" on https://app/login`.
+func (s *AbortSignal) Reason() string {
+	reason := "abort_if matched: "
+	if s.Condition == AbortHTTPStatus {
+		reason += "http_status " + s.Value
+	} else {
+		reason += strings.TrimSpace(s.Condition + " " + strconv.Quote(s.Value))
+	}
+	if s.URL != "" {
+		reason += " on " + s.URL
+	}
+	return reason
+}
+
+// AbortCheckScript returns a script for browser_evaluate that resolves to
+// the first matching selector or text condition, or null.
+func AbortCheckScript(abortIf *ScenarioAbortIf) string {
+	selectors, _ := json.Marshal(nonNil(abortIf.Selectors))
+	texts, _ := json.Marshal(nonNil(abortIf.Text))
+	return fmt.Sprintf(`() => {
+  for (const s of %s) {
+    if (document.querySelector(s)) return { condition: 'selector', value: s, url: location.href };
+  }
+  const body = ((document.body && document.body.innerText) || '').toLowerCase();
+  for (const t of %s) {
+    if (body.includes(t.toLowerCase())) return { condition: 'text', value: t, url: location.href };
+  }
+  return null;
+}`, selectors, texts)
+}
+
+func nonNil(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
+
+// AbortInstructions is appended to the tester CLAUDE.md for scenarios with
+// abort_if conditions.
+func AbortInstructions(abortIf *ScenarioAbortIf, outputDir string) string {
+	var b strings.Builder
+	b.WriteString(`
+## Abort Conditions
+
+Some environment problems make this test meaningless. If you see any of the
+following, STOP testing immediately: don't retry, don't record observations,
+and don't judge the success criteria.
+
+`)
+	for _, sel := range abortIf.Selectors {
+		fmt.Fprintf(&b, "- An element matching `%s` is on the page\n", sel)
+	}
+	for _, code := range abortIf.HTTPStatus {
+		fmt.Fprintf(&b, "- A page navigation returns HTTP status %d (check with browser_network_requests)\n", code)
+	}
+	for _, text := range abortIf.Text {
+		fmt.Fprintf(&b, "- The page shows the text %q\n", text)
+	}
+
+	if len(abortIf.Selectors) > 0 || len(abortIf.Text) > 0 {
+		b.WriteString("\nAfter every page load, run this script with browser_evaluate; a non-null\nresult means a condition matched:\n\n")
+		b.WriteString("```js\n" + AbortCheckScript(abortIf) + "\n```\n")
+	}
+
+	fmt.Fprintf(&b, `
+To abort, write the matched condition to %s as JSON, e.g.
+{"condition": "http_status", "value": "503", "url": "<page url>"}
+(condition is one of selector, http_status, text), then end the session.
+`, filepath.Join(outputDir, AbortFile))
+	return b.String()
+}
+
+// LoadAbortSignal reads <runDir>/abort.json. It returns nil if the run was
+// not aborted.
+func LoadAbortSignal(runDir string) (*AbortSignal, error) {
+	data, err := os.ReadFile(filepath.Join(runDir, AbortFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	// The agent may write a status code as a number
+	var raw struct {
+		Condition string      `json:"condition"`
+		Value     interface{} `json:"value"`
+		URL       string      `json:"url"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", AbortFile, err)
+	}
+	signal := &AbortSignal{Condition: raw.Condition, URL: raw.URL}
+	if raw.Value != nil {
+		signal.Value = fmt.Sprint(raw.Value)
+	}
+	return signal, nil
+}
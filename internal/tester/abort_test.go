@@ -0,0 +1,64 @@
+package tester
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadAbortSignal(t *testing.T) {
+	runDir := t.TempDir()
+
+	signal, err := LoadAbortSignal(runDir)
+	if err != nil || signal != nil {
+		t.Fatalf("no abort file: got %v, %v", signal, err)
+	}
+
+	// Agents may write status codes as numbers
+	data := `{"condition": "http_status", "value": 503, "url": "https://example.com/login"}`
+	if err := os.WriteFile(filepath.Join(runDir, AbortFile), []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+	signal, err = LoadAbortSignal(runDir)
+	if err != nil {
+		t.Fatalf("LoadAbortSignal: %v", err)
+	}
+	if got, want := signal.Reason(), "abort_if matched: http_status 503 on https://example.com/login"; got != want {
+		t.Errorf("Reason = %q, want %q", got, want)
+	}
+
+	text := &AbortSignal{Condition: AbortText, Value: "rate limited"}
+	if got, want := text.Reason(), `abort_if matched: text "rate limited"`; got != want {
+		t.Errorf("Reason = %q, want %q", got, want)
+	}
+}
+
+func TestAbortInstructions(t *testing.T) {
+	abortIf := &ScenarioAbortIf{Selectors: []string{".maintenance-banner"}, HTTPStatus: []int{503}}
+	rendered, err := RenderTesterTemplate(&TesterTemplateData{
+		PersonaName:    "Sarah",
+		AbortIf:        abortIf,
+		AbortOutputDir: "test-results/run-001",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"## Abort Conditions",
+		"`.maintenance-banner`",
+		"HTTP status 503",
+		`for (const s of [".maintenance-banner"])`,
+		`for (const t of [])`,
+		filepath.Join("test-results/run-001", AbortFile),
+	} {
+		if !strings.Contains(rendered, want) {
+			t.Errorf("instructions missing %q", want)
+		}
+	}
+
+	plain, _ := RenderTesterTemplate(&TesterTemplateData{PersonaName: "Sarah", AbortOutputDir: "x"})
+	if strings.Contains(plain, "Abort Conditions") {
+		t.Error("instructions rendered without abort_if")
+	}
+}
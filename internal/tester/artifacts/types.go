@@ -111,8 +111,12 @@ type Artifact struct {
 	Path string `json:"path"`
 
 	// AbsolutePath is the full absolute path to the artifact.
+	// Empty once the artifact has been uploaded and removed locally.
 	AbsolutePath string `json:"absolute_path"`
 
+	// URL is where the artifact was uploaded (empty if not uploaded).
+	URL string `json:"url,omitempty"`
+
 	// CreatedAt is when the artifact was created.
 	CreatedAt time.Time `json:"created_at"`
 
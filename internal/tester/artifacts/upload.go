@@ -0,0 +1,211 @@
+package artifacts
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// UploadEnvVar names the environment variable that enables artifact upload
+// when no --upload flag is given (e.g., set once in CI).
+const UploadEnvVar = "GT_TESTER_UPLOAD"
+
+// Uploader stores artifact files in remote object storage.
+type Uploader interface {
+	// Name returns the upload destination for display (e.g., "s3://bucket/prefix").
+	Name() string
+
+	// Upload copies the local file to key (relative to the destination
+	// prefix) and returns the URL where it can be fetched.
+	Upload(ctx context.Context, localPath, key string) (string, error)
+}
+
+// NewUploader creates an uploader for a destination URL:
+//
+//	s3://bucket/prefix   (uses the aws CLI; honors AWS_ENDPOINT_URL)
+//	gs://bucket/prefix   (uses the gcloud CLI)
+func NewUploader(target string) (Uploader, error) {
+	u, err := url.Parse(target)
+	if err != nil {
+		return nil, fmt.Errorf("invalid upload target %q: %w", target, err)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("invalid upload target %q: missing bucket", target)
+	}
+	prefix := strings.Trim(u.Path, "/")
+
+	switch u.Scheme {
+	case "s3":
+		return &S3Uploader{Bucket: u.Host, Prefix: prefix, Endpoint: os.Getenv("AWS_ENDPOINT_URL")}, nil
+	case "gs", "gcs":
+		return &GCSUploader{Bucket: u.Host, Prefix: prefix}, nil
+	default:
+		return nil, fmt.Errorf("unsupported upload target %q (use s3://bucket/prefix or gs://bucket/prefix)", target)
+	}
+}
+
+// S3Uploader uploads artifacts to S3 or an S3-compatible store via the aws CLI.
+type S3Uploader struct {
+	// Bucket is the destination bucket.
+	Bucket string
+
+	// Prefix is prepended to every object key.
+	Prefix string
+
+	// Endpoint is a custom S3-compatible endpoint (e.g., MinIO). Empty for AWS.
+	Endpoint string
+}
+
+// Name returns the destination URL.
+func (u *S3Uploader) Name() string {
+	return "s3://" + path.Join(u.Bucket, u.Prefix)
+}
+
+// Upload copies localPath to the bucket and returns its HTTPS URL.
+func (u *S3Uploader) Upload(ctx context.Context, localPath, key string) (string, error) {
+	objectKey := path.Join(u.Prefix, key)
+	args := []string{"s3", "cp", "--only-show-errors", localPath, "s3://" + u.Bucket + "/" + objectKey}
+	if u.Endpoint != "" {
+		args = append(args, "--endpoint-url", u.Endpoint)
+	}
+	if err := runUploadCLI(ctx, "aws", args...); err != nil {
+		return "", err
+	}
+
+	if u.Endpoint != "" {
+		return strings.TrimSuffix(u.Endpoint, "/") + "/" + u.Bucket + "/" + objectKey, nil
+	}
+	return "https://" + u.Bucket + ".s3.amazonaws.com/" + objectKey, nil
+}
+
+// GCSUploader uploads artifacts to Google Cloud Storage via the gcloud CLI.
+type GCSUploader struct {
+	// Bucket is the destination bucket.
+	Bucket string
+
+	// Prefix is prepended to every object key.
+	Prefix string
+}
+
+// Name returns the destination URL.
+func (u *GCSUploader) Name() string {
+	return "gs://" + path.Join(u.Bucket, u.Prefix)
+}
+
+// Upload copies localPath to the bucket and returns its HTTPS URL.
+func (u *GCSUploader) Upload(ctx context.Context, localPath, key string) (string, error) {
+	objectKey := path.Join(u.Prefix, key)
+	if err := runUploadCLI(ctx, "gcloud", "storage", "cp", localPath, "gs://"+u.Bucket+"/"+objectKey); err != nil {
+		return "", err
+	}
+	return "https://storage.googleapis.com/" + u.Bucket + "/" + objectKey, nil
+}
+
+// runUploadCLI runs a storage CLI, surfacing its stderr on failure.
+func runUploadCLI(ctx context.Context, name string, args ...string) error {
+	cmd := exec.CommandContext(ctx, name, args...) //nolint:gosec // G204: fixed CLI, args are artifact paths
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("%s upload failed: %s", name, msg)
+		}
+		return fmt.Errorf("%s upload failed: %w", name, err)
+	}
+	return nil
+}
+
+// UploadRun uploads all artifacts recorded in a run's manifest and rewrites
+// the manifest so each artifact carries its remote URL. Object keys mirror
+// the local layout (<date>/<scenario>/run-<id>/...). The updated manifest is
+// uploaded too. If deleteLocal is true, uploaded media (video, trace and
+// screenshots) is removed from disk afterwards; observations, the summary
+// and the manifest are always kept locally, since review, stats, export and
+// rerun read them from the run directory. Returns the URL of the uploaded
+// manifest.
+func (m *Manager) UploadRun(ctx context.Context, runDir string, up Uploader, deleteLocal bool) (string, error) {
+	manifest, err := m.LoadManifest(runDir)
+	if err != nil {
+		return "", err
+	}
+
+	runKey, err := filepath.Rel(m.baseDir, runDir)
+	if err != nil || strings.HasPrefix(runKey, "..") {
+		runKey = filepath.Base(runDir)
+	}
+	runKey = filepath.ToSlash(runKey)
+
+	for _, a := range manifest.allArtifacts() {
+		if a.URL != "" {
+			continue // Already uploaded
+		}
+		local := a.AbsolutePath
+		if local == "" {
+			local = filepath.Join(runDir, a.Path)
+		}
+		u, err := up.Upload(ctx, local, path.Join(runKey, filepath.ToSlash(a.Path)))
+		if err != nil {
+			return "", fmt.Errorf("uploading %s: %w", a.Path, err)
+		}
+		a.URL = u
+	}
+
+	if deleteLocal {
+		for _, a := range manifest.mediaArtifacts() {
+			local := a.AbsolutePath
+			if local == "" {
+				local = filepath.Join(runDir, a.Path)
+			}
+			_ = os.Remove(local)
+			a.AbsolutePath = ""
+		}
+	}
+
+	manifestPath := filepath.Join(runDir, "manifest.json")
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+	if err := os.WriteFile(manifestPath, data, 0644); err != nil {
+		return "", fmt.Errorf("failed to write manifest: %w", err)
+	}
+
+	manifestURL, err := up.Upload(ctx, manifestPath, path.Join(runKey, "manifest.json"))
+	if err != nil {
+		return "", fmt.Errorf("uploading manifest: %w", err)
+	}
+	return manifestURL, nil
+}
+
+// allArtifacts returns pointers to every artifact recorded in the manifest.
+func (a *ArtifactManifest) allArtifacts() []*Artifact {
+	all := a.mediaArtifacts()
+	for _, art := range []*Artifact{a.Observations, a.Summary} {
+		if art != nil {
+			all = append(all, art)
+		}
+	}
+	return all
+}
+
+// mediaArtifacts returns pointers to the recorded video, trace and
+// screenshots: the bulky files that may be dropped locally after upload.
+func (a *ArtifactManifest) mediaArtifacts() []*Artifact {
+	var media []*Artifact
+	for _, art := range []*Artifact{a.Video, a.Trace} {
+		if art != nil {
+			media = append(media, art)
+		}
+	}
+	for i := range a.Screenshots {
+		media = append(media, &a.Screenshots[i].Artifact)
+	}
+	return media
+}
@@ -0,0 +1,159 @@
+package artifacts
+
+import (
+	"context"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// fakeUploader records uploads and returns deterministic URLs.
+type fakeUploader struct {
+	keys []string
+}
+
+func (f *fakeUploader) Name() string { return "fake://bucket" }
+
+func (f *fakeUploader) Upload(_ context.Context, localPath, key string) (string, error) {
+	if _, err := os.Stat(localPath); err != nil {
+		return "", err
+	}
+	f.keys = append(f.keys, key)
+	return "https://example.com/" + key, nil
+}
+
+func TestNewUploader(t *testing.T) {
+	tests := []struct {
+		target  string
+		name    string
+		wantErr bool
+	}{
+		{"s3://qa-artifacts/runs/", "s3://qa-artifacts/runs", false},
+		{"gs://qa-artifacts", "gs://qa-artifacts", false},
+		{"s3:///runs", "", true},
+		{"ftp://host/path", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			up, err := NewUploader(tt.target)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected error for %q", tt.target)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewUploader(%q): %v", tt.target, err)
+			}
+			if up.Name() != tt.name {
+				t.Errorf("Name() = %q, want %q", up.Name(), tt.name)
+			}
+		})
+	}
+}
+
+func TestS3Uploader_UsesCLI(t *testing.T) {
+	binDir := t.TempDir()
+	logFile := filepath.Join(binDir, "args.log")
+	script := "#!/bin/sh\necho \"$@\" > " + logFile + "\n"
+	if err := os.WriteFile(filepath.Join(binDir, "aws"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	up := &S3Uploader{Bucket: "qa", Prefix: "runs"}
+	url, err := up.Upload(context.Background(), "/tmp/video.webm", "2026-01-14/login/run-1/video.webm")
+	if err != nil {
+		t.Fatalf("Upload: %v", err)
+	}
+	if url != "https://qa.s3.amazonaws.com/runs/2026-01-14/login/run-1/video.webm" {
+		t.Errorf("unexpected URL %q", url)
+	}
+
+	args, err := os.ReadFile(logFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(args), "s3 cp --only-show-errors /tmp/video.webm s3://qa/runs/2026-01-14/login/run-1/video.webm") {
+		t.Errorf("unexpected aws args: %s", args)
+	}
+}
+
+func TestManager_UploadRun(t *testing.T) {
+	m, err := NewManager(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	run, err := m.InitRun("login", "001", DefaultRecordingConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.WriteFile(m.VideoPath(run.RunDir), []byte("video"), 0644)
+	os.WriteFile(m.ScreenshotPath(run.RunDir, "error state"), []byte("png"), 0644)
+	if err := m.RecordVideo(run); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.RecordScreenshot(run, "error state", TriggerFailure, "00:12", "/login", ""); err != nil {
+		t.Fatal(err)
+	}
+	os.WriteFile(m.ObservationsPath(run.RunDir), []byte(`{"observations":[]}`), 0644)
+	if err := m.RecordObservations(run); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.FinalizeRun(run); err != nil {
+		t.Fatal(err)
+	}
+
+	up := &fakeUploader{}
+	manifestURL, err := m.UploadRun(context.Background(), run.RunDir, up, true)
+	if err != nil {
+		t.Fatalf("UploadRun: %v", err)
+	}
+
+	if len(up.keys) != 4 {
+		t.Fatalf("expected video, screenshot, observations, and manifest uploads, got %v", up.keys)
+	}
+	if !strings.HasSuffix(manifestURL, "/login/run-001/manifest.json") {
+		t.Errorf("unexpected manifest URL %q", manifestURL)
+	}
+
+	// Local media removed; observations and the rewritten manifest kept
+	if _, err := os.Stat(m.VideoPath(run.RunDir)); !os.IsNotExist(err) {
+		t.Error("expected local video to be removed")
+	}
+	if _, err := os.Stat(m.ScreenshotPath(run.RunDir, "error state")); !os.IsNotExist(err) {
+		t.Error("expected local screenshot to be removed")
+	}
+	if _, err := os.Stat(m.ObservationsPath(run.RunDir)); err != nil {
+		t.Errorf("expected observations.json to be kept: %v", err)
+	}
+	manifest, err := m.LoadManifest(run.RunDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if manifest.Video.URL != "https://example.com/"+path.Dir(up.keys[3])+"/video.webm" {
+		t.Errorf("unexpected video URL %q", manifest.Video.URL)
+	}
+	if manifest.Video.AbsolutePath != "" {
+		t.Errorf("expected absolute path cleared, got %q", manifest.Video.AbsolutePath)
+	}
+	if manifest.Screenshots[0].URL == "" {
+		t.Error("expected screenshot URL")
+	}
+	if manifest.Observations.URL == "" || manifest.Observations.AbsolutePath == "" {
+		t.Errorf("expected observations uploaded and still local, got %+v", manifest.Observations)
+	}
+
+	// Re-uploading skips artifacts that already have URLs
+	up.keys = nil
+	if _, err := m.UploadRun(context.Background(), run.RunDir, up, true); err != nil {
+		t.Fatalf("second UploadRun: %v", err)
+	}
+	if len(up.keys) != 1 {
+		t.Errorf("expected only the manifest to be re-uploaded, got %v", up.keys)
+	}
+}
@@ -0,0 +1,165 @@
+package batch
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// ModelComparison compares the models of an A/B batch, in which every
+// scenario ran once under each model.
+type ModelComparison struct {
+	// Models are the compared models, in --ab order.
+	Models []string `json:"models"`
+
+	// Stats are the per-model totals, in Models order.
+	Stats []ModelStats `json:"stats"`
+
+	// Pairs are the scenarios' paired results, by scenario name.
+	Pairs []ModelPair `json:"pairs"`
+
+	// Disagreements is how many pairs differ in outcome (one model passed,
+	// the other didn't).
+	Disagreements int `json:"disagreements"`
+}
+
+// ModelStats totals one model's runs in an A/B batch.
+type ModelStats struct {
+	Model string `json:"model"`
+
+	// Runs counts completed runs (passed, failed or errored).
+	Runs   int `json:"runs"`
+	Passed int `json:"passed"`
+
+	// PassRate is Passed/Runs (0-1).
+	PassRate float64 `json:"pass_rate"`
+
+	// Observations counts observations by severity.
+	Observations map[string]int `json:"observations"`
+
+	// TotalObservations is the sum of Observations.
+	TotalObservations int `json:"total_observations"`
+
+	// CriteriaMet and CriteriaTotal sum the success criteria over the runs.
+	CriteriaMet   int `json:"criteria_met"`
+	CriteriaTotal int `json:"criteria_total"`
+
+	// CriteriaPassRate is CriteriaMet/CriteriaTotal (0-1).
+	CriteriaPassRate float64 `json:"criteria_pass_rate"`
+
+	// AvgDuration is the mean duration of the runs.
+	AvgDuration time.Duration `json:"avg_duration"`
+}
+
+// ModelPair is one scenario's results under each model.
+type ModelPair struct {
+	Scenario string `json:"scenario"`
+
+	// Runs holds the scenario's run under each model, in Models order. A
+	// model whose run is missing has an empty Status.
+	Runs []ModelRun `json:"runs"`
+
+	// Disagree is set when one model passed and another didn't.
+	Disagree bool `json:"disagree,omitempty"`
+}
+
+// ModelRun is the summary of one scenario run in a pair.
+type ModelRun struct {
+	Model         string    `json:"model"`
+	Status        RunStatus `json:"status"`
+	Observations  int       `json:"observations"`
+	CriteriaMet   int       `json:"criteria_met"`
+	CriteriaTotal int       `json:"criteria_total"`
+}
+
+// Criteria formats the run's success criteria, e.g. "2/3".
+func (m ModelRun) Criteria() string {
+	return fmt.Sprintf("%d/%d", m.CriteriaMet, m.CriteriaTotal)
+}
+
+// CompareModels pairs the results of an A/B batch by scenario and totals
+// them per model. Skipped and aborted runs appear in the pairs but don't
+// count toward the stats.
+func CompareModels(results []ScenarioResult, models []string) *ModelComparison {
+	cmp := &ModelComparison{Models: models}
+
+	slot := make(map[string]int, len(models))
+	durations := make([]time.Duration, len(models))
+	for i, model := range models {
+		slot[model] = i
+		cmp.Stats = append(cmp.Stats, ModelStats{Model: model, Observations: make(map[string]int)})
+	}
+
+	pairs := make(map[string]*ModelPair)
+	for _, sr := range results {
+		i, ok := slot[sr.Model]
+		if !ok {
+			continue // quarantine skips run under no model
+		}
+
+		pair := pairs[sr.Scenario]
+		if pair == nil {
+			pair = &ModelPair{Scenario: sr.Scenario, Runs: make([]ModelRun, len(models))}
+			for j, model := range models {
+				pair.Runs[j].Model = model
+			}
+			pairs[sr.Scenario] = pair
+		}
+		run := &pair.Runs[i]
+		run.Status = sr.Status
+		run.CriteriaMet = sr.SuccessCriteriaMet
+		run.CriteriaTotal = sr.SuccessCriteriaTotal
+		for _, n := range sr.Observations {
+			run.Observations += n
+		}
+
+		if !ran(sr.Status) {
+			continue
+		}
+		stats := &cmp.Stats[i]
+		stats.Runs++
+		if sr.Status == StatusPassed {
+			stats.Passed++
+		}
+		for sev, n := range sr.Observations {
+			stats.Observations[sev] += n
+			stats.TotalObservations += n
+		}
+		stats.CriteriaMet += sr.SuccessCriteriaMet
+		stats.CriteriaTotal += sr.SuccessCriteriaTotal
+		durations[i] += sr.Duration
+	}
+
+	for i := range cmp.Stats {
+		stats := &cmp.Stats[i]
+		if stats.Runs > 0 {
+			stats.PassRate = float64(stats.Passed) / float64(stats.Runs)
+			stats.AvgDuration = durations[i] / time.Duration(stats.Runs)
+		}
+		if stats.CriteriaTotal > 0 {
+			stats.CriteriaPassRate = float64(stats.CriteriaMet) / float64(stats.CriteriaTotal)
+		}
+	}
+
+	for _, pair := range pairs {
+		passed, completed := 0, 0
+		for _, run := range pair.Runs {
+			if ran(run.Status) {
+				completed++
+			}
+			if run.Status == StatusPassed {
+				passed++
+			}
+		}
+		if completed == len(models) && passed > 0 && passed < completed {
+			pair.Disagree = true
+			cmp.Disagreements++
+		}
+		cmp.Pairs = append(cmp.Pairs, *pair)
+	}
+	sort.Slice(cmp.Pairs, func(i, j int) bool {
+		return cmp.Pairs[i].Scenario < cmp.Pairs[j].Scenario
+	})
+
+	return cmp
+}
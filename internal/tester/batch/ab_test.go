@@ -0,0 +1,90 @@
+package batch
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRunABBatch(t *testing.T) {
+	tmpDir := t.TempDir()
+	for _, name := range []string{"login", "checkout"} {
+		os.WriteFile(filepath.Join(tmpDir, name+".yaml"), []byte("scenario: "+name+"\n"), 0644)
+	}
+
+	config := DefaultConfig()
+	config.OutputDir = tmpDir
+	config.Pattern = filepath.Join(tmpDir, "*.yaml")
+	config.SkipPreflight = true
+	config.ABModels = []string{"haiku", "sonnet"}
+
+	runner, err := NewRunner(config)
+	if err != nil {
+		t.Fatalf("failed to create runner: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	result, err := runner.Run(ctx)
+	if err != nil {
+		t.Fatalf("batch run failed: %v", err)
+	}
+
+	if result.ScenariosRun != 4 || len(result.Results) != 4 {
+		t.Fatalf("expected 4 runs, got %d (%d results)", result.ScenariosRun, len(result.Results))
+	}
+	if result.AB == nil {
+		t.Fatal("expected a model comparison")
+	}
+	if len(result.AB.Pairs) != 2 {
+		t.Errorf("expected 2 pairs, got %d", len(result.AB.Pairs))
+	}
+	for _, stats := range result.AB.Stats {
+		if stats.Runs != 2 {
+			t.Errorf("%s: expected 2 runs, got %d", stats.Model, stats.Runs)
+		}
+	}
+
+	// A/B runs are not recorded with the flake detector
+	if m := runner.flakeMetrics("login"); m != nil {
+		t.Errorf("expected no flake history, got %+v", m)
+	}
+}
+
+func TestCompareModels(t *testing.T) {
+	results := []ScenarioResult{
+		{Scenario: "login", Model: "haiku", Status: StatusFailed, Observations: map[string]int{"P1": 2}, SuccessCriteriaMet: 1, SuccessCriteriaTotal: 3, Duration: 2 * time.Second},
+		{Scenario: "login", Model: "sonnet", Status: StatusPassed, Observations: map[string]int{"P2": 1}, SuccessCriteriaMet: 3, SuccessCriteriaTotal: 3, Duration: 4 * time.Second},
+		{Scenario: "checkout", Model: "haiku", Status: StatusPassed, SuccessCriteriaMet: 2, SuccessCriteriaTotal: 2, Duration: 2 * time.Second},
+		{Scenario: "checkout", Model: "sonnet", Status: StatusAborted},
+		{Scenario: "search", Status: StatusSkipped, Quarantined: true},
+	}
+
+	cmp := CompareModels(results, []string{"haiku", "sonnet"})
+
+	haiku, sonnet := cmp.Stats[0], cmp.Stats[1]
+	if haiku.Runs != 2 || haiku.Passed != 1 || haiku.PassRate != 0.5 {
+		t.Errorf("haiku runs/passed/rate = %d/%d/%v, want 2/1/0.5", haiku.Runs, haiku.Passed, haiku.PassRate)
+	}
+	if haiku.CriteriaMet != 3 || haiku.CriteriaTotal != 5 || haiku.TotalObservations != 2 {
+		t.Errorf("haiku criteria %d/%d, observations %d; want 3/5, 2", haiku.CriteriaMet, haiku.CriteriaTotal, haiku.TotalObservations)
+	}
+	if sonnet.Runs != 1 || sonnet.AvgDuration != 4*time.Second || sonnet.Observations["P2"] != 1 {
+		t.Errorf("sonnet stats = %+v", sonnet)
+	}
+
+	if len(cmp.Pairs) != 2 || cmp.Pairs[0].Scenario != "checkout" {
+		t.Fatalf("pairs = %+v, want checkout and login", cmp.Pairs)
+	}
+	if cmp.Pairs[0].Disagree {
+		t.Error("checkout: an aborted run is not a disagreement")
+	}
+	if !cmp.Pairs[1].Disagree || cmp.Disagreements != 1 {
+		t.Errorf("login should disagree (disagreements = %d)", cmp.Disagreements)
+	}
+	if got := cmp.Pairs[1].Runs[0].Criteria(); got != "1/3" {
+		t.Errorf("login haiku criteria = %s, want 1/3", got)
+	}
+}
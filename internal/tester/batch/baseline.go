@@ -0,0 +1,142 @@
+package batch
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// BaselineRef is the --compare-to value that resolves to the pinned
+// baseline for the batch's environment.
+const BaselineRef = "baseline"
+
+// BaselineFileName is the baseline pin file stored in the results directory.
+const BaselineFileName = ".baselines.json"
+
+// BaselinePin records the golden batch run for an environment.
+type BaselinePin struct {
+	// Environment is the target environment the baseline applies to.
+	Environment string `json:"environment"`
+
+	// BatchID is the pinned batch run.
+	BatchID string `json:"batch_id"`
+
+	// PinnedAt is when the baseline was pinned.
+	PinnedAt time.Time `json:"pinned_at"`
+
+	// PinnedBy identifies who pinned the baseline.
+	PinnedBy string `json:"pinned_by,omitempty"`
+
+	// Promoted is true if the pin was set by a gated promotion.
+	Promoted bool `json:"promoted,omitempty"`
+
+	// Previous is the batch ID this pin replaced.
+	Previous string `json:"previous,omitempty"`
+}
+
+// BaselineStore manages baseline pins per environment.
+type BaselineStore struct {
+	path string
+	pins map[string]BaselinePin
+}
+
+// LoadBaselineStore loads the baseline pins from a results directory.
+func LoadBaselineStore(outputDir string) (*BaselineStore, error) {
+	store := &BaselineStore{
+		path: filepath.Join(outputDir, BaselineFileName),
+		pins: make(map[string]BaselinePin),
+	}
+
+	data, err := os.ReadFile(store.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return store, nil
+		}
+		return nil, fmt.Errorf("failed to read baselines: %w", err)
+	}
+
+	var pins []BaselinePin
+	if err := json.Unmarshal(data, &pins); err != nil {
+		return nil, fmt.Errorf("failed to parse baselines: %w", err)
+	}
+	for _, p := range pins {
+		store.pins[p.Environment] = p
+	}
+
+	return store, nil
+}
+
+// Get returns the pinned baseline for an environment.
+func (s *BaselineStore) Get(environment string) (BaselinePin, bool) {
+	pin, ok := s.pins[environment]
+	return pin, ok
+}
+
+// List returns all pins sorted by environment.
+func (s *BaselineStore) List() []BaselinePin {
+	pins := make([]BaselinePin, 0, len(s.pins))
+	for _, p := range s.pins {
+		pins = append(pins, p)
+	}
+	sort.Slice(pins, func(i, j int) bool {
+		return pins[i].Environment < pins[j].Environment
+	})
+	return pins
+}
+
+// Set pins a baseline, recording the batch it replaces, and saves the store.
+func (s *BaselineStore) Set(pin BaselinePin) error {
+	if old, ok := s.pins[pin.Environment]; ok && old.BatchID != pin.BatchID {
+		pin.Previous = old.BatchID
+	}
+	if pin.PinnedAt.IsZero() {
+		pin.PinnedAt = time.Now()
+	}
+	s.pins[pin.Environment] = pin
+	return s.save()
+}
+
+func (s *BaselineStore) save() error {
+	data, err := json.MarshalIndent(s.List(), "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(s.path, data, 0644)
+}
+
+// CheckPromotion returns the gates a candidate batch fails for promotion
+// over the current baseline (nil baseline if none is pinned). An empty
+// result means the candidate may be promoted. The gates are: the batch
+// completed, had no failures or errors, ran at least one scenario, and
+// introduced no new issues relative to the baseline.
+func CheckPromotion(candidate, baseline *BatchResult) []string {
+	var failures []string
+
+	if candidate.CompletedAt == nil {
+		failures = append(failures, "batch did not complete")
+	}
+	if candidate.ScenariosRun == 0 {
+		failures = append(failures, "batch ran no scenarios")
+	}
+	if candidate.Summary.Failed > 0 || candidate.Summary.Errors > 0 {
+		failures = append(failures, fmt.Sprintf("%d failed, %d errors", candidate.Summary.Failed, candidate.Summary.Errors))
+	}
+
+	if baseline != nil {
+		if baseline.ID == candidate.ID {
+			failures = append(failures, "batch is already the baseline")
+		} else if cmp := new(Runner).Compare(candidate, baseline); len(cmp.NewIssues) > 0 {
+			failures = append(failures, fmt.Sprintf("%d new issues vs baseline %s", len(cmp.NewIssues), baseline.ID))
+		}
+	}
+
+	return failures
+}
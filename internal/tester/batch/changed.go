@@ -0,0 +1,343 @@
+package batch
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/tester"
+	"gopkg.in/yaml.v3"
+)
+
+// ChangeMapFileName is the default change mapping file, read from the
+// working directory when Config.ChangeMap is unset.
+const ChangeMapFileName = ".tester-changes.yaml"
+
+// ChangeMap maps changed source files to the scenarios they affect.
+//
+// Example:
+//
+//	rules:
+//	  - paths: ["src/checkout/**", "api/cart/*.go"]
+//	    tags: [checkout]
+//	  - paths: ["src/auth/**"]
+//	    scenarios: ["scenarios/registration/*.yaml"]
+//	routes: true
+type ChangeMap struct {
+	// Rules select scenarios for changed files matching their paths.
+	Rules []ChangeRule `json:"rules,omitempty" yaml:"rules,omitempty"`
+
+	// Routes enables the URL-route convention: a changed file under a
+	// pages/, routes/ or app/ directory affects scenarios whose start URL
+	// is that route or below it. Default: true.
+	Routes *bool `json:"routes,omitempty" yaml:"routes,omitempty"`
+}
+
+// ChangeRule selects scenarios when any changed file matches Paths.
+// Paths and Scenarios are globs relative to the repo root; "**" matches
+// any number of directories.
+type ChangeRule struct {
+	// Paths are the source file globs this rule watches.
+	Paths []string `json:"paths" yaml:"paths"`
+
+	// Tags select scenarios carrying any of these tags.
+	Tags []string `json:"tags,omitempty" yaml:"tags,omitempty"`
+
+	// Scenarios select scenario files matching any of these globs.
+	Scenarios []string `json:"scenarios,omitempty" yaml:"scenarios,omitempty"`
+}
+
+// ChangeSelection records how --only-changed picked scenarios.
+type ChangeSelection struct {
+	// Range is the revision or range that was diffed.
+	Range string `json:"range"`
+
+	// Files are the changed files, relative to the repo root.
+	Files []string `json:"files"`
+
+	// Affected maps each selected scenario path to why it was selected.
+	Affected map[string]string `json:"affected"`
+}
+
+// ChangedScenario describes a scenario for change matching.
+type ChangedScenario struct {
+	// Path is the scenario file relative to the repo root.
+	Path string
+
+	// Tags are the scenario's tags.
+	Tags []string
+
+	// URL is the scenario's start URL (optional).
+	URL string
+
+	// Includes are the fragments the scenario includes, relative to the
+	// repo root.
+	Includes []string
+}
+
+// LoadChangeMap reads a change mapping file. A missing file yields an
+// empty map, which still applies the scenario-file and route conventions.
+func LoadChangeMap(path string) (*ChangeMap, error) {
+	m := &ChangeMap{}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return m, nil
+		}
+		return nil, fmt.Errorf("failed to read change map: %w", err)
+	}
+	if err := yaml.Unmarshal(data, m); err != nil {
+		return nil, fmt.Errorf("failed to parse change map %s: %w", path, err)
+	}
+	return m, nil
+}
+
+// Affected returns the scenarios affected by the changed files, keyed by
+// scenario path with the reason each was selected. A scenario is affected
+// when its own file or a fragment it includes changed, when a rule matching a changed file selects
+// it, or when a changed route file covers its start URL.
+func (m *ChangeMap) Affected(changed []string, scenarios []ChangedScenario) map[string]string {
+	affected := make(map[string]string)
+	mark := func(s ChangedScenario, reason string) {
+		if _, ok := affected[s.Path]; !ok {
+			affected[s.Path] = reason
+		}
+	}
+
+	changedSet := make(map[string]bool, len(changed))
+	for _, f := range changed {
+		changedSet[f] = true
+	}
+	for _, s := range scenarios {
+		if changedSet[s.Path] {
+			mark(s, "scenario changed")
+		}
+		for _, inc := range s.Includes {
+			if changedSet[inc] {
+				mark(s, fmt.Sprintf("%s (included)", inc))
+			}
+		}
+	}
+
+	for _, rule := range m.Rules {
+		file, ok := firstMatch(rule.Paths, changed)
+		if !ok {
+			continue
+		}
+		for _, s := range scenarios {
+			if hasAnyTag(s.Tags, rule.Tags) {
+				mark(s, fmt.Sprintf("%s (tag rule)", file))
+			} else if _, ok := firstMatch(rule.Scenarios, []string{s.Path}); ok {
+				mark(s, fmt.Sprintf("%s (scenario rule)", file))
+			}
+		}
+	}
+
+	if m.Routes == nil || *m.Routes {
+		for _, f := range changed {
+			route, ok := routeForFile(f)
+			if !ok {
+				continue
+			}
+			for _, s := range scenarios {
+				if s.URL != "" && routeCovers(route, s.URL) {
+					mark(s, fmt.Sprintf("%s (route %s)", f, "/"+strings.Join(route, "/")))
+				}
+			}
+		}
+	}
+
+	return affected
+}
+
+// selectChanged narrows scenarios to those affected by Config.OnlyChanged.
+func (r *Runner) selectChanged(scenarios []string) ([]string, *ChangeSelection, error) {
+	mapPath := r.config.ChangeMap
+	if mapPath == "" {
+		mapPath = ChangeMapFileName
+	}
+	changeMap, err := LoadChangeMap(mapPath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	g := git.NewGit(".")
+	root, err := g.TopLevel()
+	if err != nil {
+		return nil, nil, fmt.Errorf("--only-changed requires a git repository: %w", err)
+	}
+	files, err := g.DiffNames(r.config.OnlyChanged)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to diff %s: %w", r.config.OnlyChanged, err)
+	}
+
+	infos := make([]ChangedScenario, 0, len(scenarios))
+	byRel := make(map[string]string, len(scenarios))
+	for _, s := range scenarios {
+		rel := repoRelative(root, s)
+		byRel[rel] = s
+
+		info := ChangedScenario{Path: rel, Tags: r.extractTags(s)}
+		if sc, err := tester.ParseScenarioFile(s); err == nil {
+			info.Tags = append(info.Tags, sc.Tags...)
+			info.URL = sc.Environment.URL
+			for _, inc := range sc.Includes {
+				info.Includes = append(info.Includes, repoRelative(root, inc))
+			}
+		}
+		infos = append(infos, info)
+	}
+
+	selection := &ChangeSelection{
+		Range:    r.config.OnlyChanged,
+		Files:    files,
+		Affected: make(map[string]string),
+	}
+	for rel, reason := range changeMap.Affected(files, infos) {
+		selection.Affected[byRel[rel]] = reason
+	}
+
+	var selected []string
+	for _, s := range scenarios {
+		if _, ok := selection.Affected[s]; ok {
+			selected = append(selected, s)
+		}
+	}
+	sort.Strings(selected)
+
+	return selected, selection, nil
+}
+
+// repoRelative returns path relative to the repo root, slash-separated,
+// or path itself if it is outside the repo.
+func repoRelative(root, path string) string {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return path
+	}
+	rel, err := filepath.Rel(root, abs)
+	if err != nil {
+		return path
+	}
+	return filepath.ToSlash(rel)
+}
+
+// firstMatch returns the first name matching any of the globs.
+func firstMatch(globs, names []string) (string, bool) {
+	for _, name := range names {
+		for _, g := range globs {
+			if matchGlob(g, name) {
+				return name, true
+			}
+		}
+	}
+	return "", false
+}
+
+// matchGlob matches a slash-separated path against a glob where "**"
+// matches zero or more directories.
+func matchGlob(pattern, name string) bool {
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(name, "/"))
+}
+
+func matchSegments(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(name); i++ {
+				if matchSegments(pattern[1:], name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], name[0]); !ok {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
+
+// routeDirs are the directories whose contents map to URL routes.
+var routeDirs = map[string]bool{"pages": true, "routes": true, "app": true}
+
+// routeLeaves are file names that stand for their directory's route.
+var routeLeaves = map[string]bool{"index": true, "page": true, "route": true, "layout": true, "_layout": true}
+
+// routeForFile derives the URL route for a file under a pages/, routes/
+// or app/ directory (Next.js, Remix, SvelteKit and similar layouts).
+// Dynamic segments ([id], :id, $id) become "*" and catch-alls ([...slug])
+// become "**". The root route is returned as an empty slice.
+func routeForFile(file string) ([]string, bool) {
+	segments := strings.Split(file, "/")
+	start := -1
+	for i, seg := range segments[:len(segments)-1] {
+		if routeDirs[seg] {
+			start = i + 1
+		}
+	}
+	if start < 0 {
+		return nil, false
+	}
+
+	rest := segments[start:]
+	leaf := rest[len(rest)-1]
+	if dot := strings.Index(leaf, "."); dot > 0 {
+		leaf = leaf[:dot]
+	}
+	rest[len(rest)-1] = leaf
+	if routeLeaves[leaf] || strings.HasPrefix(leaf, "+") {
+		rest = rest[:len(rest)-1]
+	}
+
+	route := []string{}
+	for _, seg := range rest {
+		switch {
+		case seg == "" || (strings.HasPrefix(seg, "(") && strings.HasSuffix(seg, ")")):
+			// Route groups don't appear in the URL
+		case strings.HasPrefix(seg, "[..."), strings.HasPrefix(seg, "[[..."):
+			route = append(route, "**")
+		case strings.HasPrefix(seg, "["), strings.HasPrefix(seg, ":"), strings.HasPrefix(seg, "$"):
+			route = append(route, "*")
+		default:
+			route = append(route, seg)
+		}
+	}
+	return route, true
+}
+
+// routeCovers reports whether a scenario start URL is the route or below it.
+// The root route only covers the root URL.
+func routeCovers(route []string, rawURL string) bool {
+	p := rawURL
+	if u, err := url.Parse(rawURL); err == nil {
+		p = u.Path
+	}
+	var segs []string
+	for _, s := range strings.Split(p, "/") {
+		if s != "" {
+			segs = append(segs, s)
+		}
+	}
+
+	if len(route) == 0 {
+		return len(segs) == 0
+	}
+	for i, r := range route {
+		if r == "**" {
+			return true
+		}
+		if i >= len(segs) || (r != "*" && r != segs[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,147 @@
+package batch
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMatchGlob(t *testing.T) {
+	tests := []struct {
+		pattern, name string
+		want          bool
+	}{
+		{"src/checkout/**", "src/checkout/cart.go", true},
+		{"src/checkout/**", "src/checkout/deep/nested/cart.go", true},
+		{"src/**/*.go", "src/cart.go", true},
+		{"src/*.go", "src/checkout/cart.go", false},
+		{"api/cart/*.go", "api/cart/handler.go", true},
+		{"api/cart/*.go", "api/orders/handler.go", false},
+		{"**", "anything/at/all", true},
+	}
+	for _, tt := range tests {
+		if got := matchGlob(tt.pattern, tt.name); got != tt.want {
+			t.Errorf("matchGlob(%q, %q) = %v, want %v", tt.pattern, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestRouteForFile(t *testing.T) {
+	tests := []struct {
+		file  string
+		route string
+		ok    bool
+	}{
+		{"web/pages/checkout.tsx", "/checkout", true},
+		{"web/pages/index.tsx", "/", true},
+		{"src/app/(shop)/checkout/payment/page.tsx", "/checkout/payment", true},
+		{"src/app/orders/[id]/page.tsx", "/orders/*", true},
+		{"src/routes/docs/[...slug]/+page.svelte", "/docs/**", true},
+		{"app/routes/account.settings.tsx", "/account", true},
+		{"src/lib/util.ts", "", false},
+	}
+	for _, tt := range tests {
+		route, ok := routeForFile(tt.file)
+		if ok != tt.ok {
+			t.Errorf("routeForFile(%q) ok = %v, want %v", tt.file, ok, tt.ok)
+			continue
+		}
+		if got := "/" + strings.Join(route, "/"); ok && got != tt.route {
+			t.Errorf("routeForFile(%q) = %s, want %s", tt.file, got, tt.route)
+		}
+	}
+}
+
+func TestRouteCovers(t *testing.T) {
+	tests := []struct {
+		route []string
+		url   string
+		want  bool
+	}{
+		{[]string{"checkout"}, "https://staging.example.com/checkout", true},
+		{[]string{"checkout"}, "https://staging.example.com/checkout/payment?step=2", true},
+		{[]string{"checkout"}, "https://staging.example.com/cart", false},
+		{[]string{"orders", "*"}, "/orders/42", true},
+		{[]string{"orders", "*"}, "/orders", false},
+		{[]string{"docs", "**"}, "/docs/a/b/c", true},
+		{[]string{}, "https://staging.example.com/", true},
+		{[]string{}, "https://staging.example.com/checkout", false},
+	}
+	for _, tt := range tests {
+		if got := routeCovers(tt.route, tt.url); got != tt.want {
+			t.Errorf("routeCovers(%v, %q) = %v, want %v", tt.route, tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestChangeMap_Affected(t *testing.T) {
+	m := &ChangeMap{Rules: []ChangeRule{
+		{Paths: []string{"src/checkout/**"}, Tags: []string{"checkout"}},
+		{Paths: []string{"src/auth/**"}, Scenarios: []string{"scenarios/registration/*.yaml"}},
+	}}
+	scenarios := []ChangedScenario{
+		{Path: "scenarios/checkout/guest.yaml", Tags: []string{"checkout"}},
+		{Path: "scenarios/registration/signup.yaml"},
+		{Path: "scenarios/orders/history.yaml", URL: "https://staging.example.com/orders/history"},
+		{Path: "scenarios/search/basic.yaml", URL: "https://staging.example.com/search"},
+		{Path: "scenarios/profile/edit.yaml"},
+		{Path: "scenarios/profile/avatar.yaml", Includes: []string{"scenarios/fragments/profile.fragment.yaml"}},
+	}
+	changed := []string{
+		"src/checkout/cart.go",
+		"web/pages/orders/history.tsx",
+		"scenarios/profile/edit.yaml",
+		"scenarios/fragments/profile.fragment.yaml",
+		"README.md",
+	}
+
+	affected := m.Affected(changed, scenarios)
+
+	want := map[string]string{
+		"scenarios/checkout/guest.yaml": "src/checkout/cart.go (tag rule)",
+		"scenarios/orders/history.yaml": "web/pages/orders/history.tsx (route /orders/history)",
+		"scenarios/profile/edit.yaml":   "scenario changed",
+		"scenarios/profile/avatar.yaml": "scenarios/fragments/profile.fragment.yaml (included)",
+	}
+	if len(affected) != len(want) {
+		t.Fatalf("Affected = %v, want %v", affected, want)
+	}
+	for path, reason := range want {
+		if affected[path] != reason {
+			t.Errorf("Affected[%s] = %q, want %q", path, affected[path], reason)
+		}
+	}
+
+	// Routes can be turned off
+	off := false
+	m.Routes = &off
+	if _, ok := m.Affected(changed, scenarios)["scenarios/orders/history.yaml"]; ok {
+		t.Error("expected route convention to be disabled")
+	}
+}
+
+func TestLoadChangeMap(t *testing.T) {
+	dir := t.TempDir()
+
+	m, err := LoadChangeMap(filepath.Join(dir, ChangeMapFileName))
+	if err != nil {
+		t.Fatalf("missing file: %v", err)
+	}
+	if len(m.Rules) != 0 || m.Routes != nil {
+		t.Errorf("expected empty map, got %+v", m)
+	}
+
+	path := filepath.Join(dir, "changes.yaml")
+	data := "rules:\n  - paths: [\"src/auth/**\"]\n    scenarios: [\"scenarios/registration/*.yaml\"]\nroutes: false\n"
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+	m, err = LoadChangeMap(path)
+	if err != nil {
+		t.Fatalf("LoadChangeMap: %v", err)
+	}
+	if len(m.Rules) != 1 || m.Rules[0].Paths[0] != "src/auth/**" || m.Routes == nil || *m.Routes {
+		t.Errorf("unexpected change map: %+v", m)
+	}
+}
@@ -0,0 +1,313 @@
+package batch
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/tester"
+	"gopkg.in/yaml.v3"
+)
+
+// JourneysFileName is the default journey map, read from the working
+// directory.
+const JourneysFileName = "journeys.yaml"
+
+// Journey coverage statuses.
+const (
+	CoverageCovered         = "covered"
+	CoverageQuarantinedOnly = "quarantined_only"
+	CoverageUncovered       = "uncovered"
+)
+
+// JourneyMap declares the app's user journeys and how scenarios map to
+// them.
+//
+// Example:
+//
+//	journeys:
+//	  - id: checkout
+//	    name: Checkout
+//	    area: commerce
+//	    routes: ["/cart", "/checkout/**"]
+//	  - id: signup
+//	    area: accounts
+//	    tags: [registration]
+//	    scenarios: ["scenarios/registration/*.yaml"]
+type JourneyMap struct {
+	Journeys []Journey `json:"journeys" yaml:"journeys"`
+}
+
+// Journey is a user journey. A scenario covers it when the scenario's
+// start URL path matches one of Routes, it carries one of Tags, or its
+// file matches one of Scenarios.
+type Journey struct {
+	// ID identifies the journey in reports.
+	ID string `json:"id" yaml:"id"`
+
+	// Name is a human-readable name (defaults to ID).
+	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+
+	// Area groups journeys by app area in reports.
+	Area string `json:"area,omitempty" yaml:"area,omitempty"`
+
+	// Routes are URL path globs; "*" matches one segment and "**" any
+	// number of them.
+	Routes []string `json:"routes,omitempty" yaml:"routes,omitempty"`
+
+	// Tags select scenarios carrying any of these tags.
+	Tags []string `json:"tags,omitempty" yaml:"tags,omitempty"`
+
+	// Scenarios are scenario file globs.
+	Scenarios []string `json:"scenarios,omitempty" yaml:"scenarios,omitempty"`
+}
+
+// LoadJourneyMap reads and validates a journey map file.
+func LoadJourneyMap(path string) (*JourneyMap, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // G304: path is from the command line
+	if err != nil {
+		return nil, fmt.Errorf("failed to read journey map: %w", err)
+	}
+	var m JourneyMap
+	if err := yaml.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("failed to parse journey map %s: %w", path, err)
+	}
+	if err := m.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid journey map %s: %w", path, err)
+	}
+	return &m, nil
+}
+
+// Validate checks that journeys have unique IDs and a way to match
+// scenarios.
+func (m *JourneyMap) Validate() error {
+	if len(m.Journeys) == 0 {
+		return fmt.Errorf("no journeys declared")
+	}
+	seen := make(map[string]bool)
+	for i, j := range m.Journeys {
+		if j.ID == "" {
+			return fmt.Errorf("journey %d has no id", i+1)
+		}
+		if seen[j.ID] {
+			return fmt.Errorf("duplicate journey id %q", j.ID)
+		}
+		seen[j.ID] = true
+		if len(j.Routes) == 0 && len(j.Tags) == 0 && len(j.Scenarios) == 0 {
+			return fmt.Errorf("journey %q needs routes, tags or scenarios", j.ID)
+		}
+	}
+	return nil
+}
+
+// CoverageScenario describes a scenario for journey matching.
+type CoverageScenario struct {
+	// Name is the scenario name used in batch results and quarantine.
+	Name string
+
+	// Path is the scenario file, slash-separated.
+	Path string
+
+	// Tags are the scenario's tags.
+	Tags []string
+
+	// URL is the scenario's start URL.
+	URL string
+}
+
+// LoadCoverageScenarios reads the scenarios from a source for journey
+// matching. Files that fail to parse are still matched by path.
+func LoadCoverageScenarios(source ScenarioSource) ([]CoverageScenario, error) {
+	refs, err := source.Scenarios()
+	if err != nil {
+		return nil, err
+	}
+	scenarios := make([]CoverageScenario, 0, len(refs))
+	for _, ref := range refs {
+		scenarios = append(scenarios, coverageScenario(ref))
+	}
+	return scenarios, nil
+}
+
+// coverageScenario describes one scenario file for journey matching.
+func coverageScenario(ref ScenarioRef) CoverageScenario {
+	s := CoverageScenario{
+		Name: strings.TrimSuffix(filepath.Base(ref.Path), filepath.Ext(ref.Path)),
+		Path: filepath.ToSlash(ref.Path),
+		Tags: append([]string(nil), ref.Tags...),
+	}
+	if sc, err := tester.ParseScenarioFile(ref.Path); err == nil {
+		s.Tags = append(s.Tags, sc.Tags...)
+		s.URL = sc.Environment.URL
+	}
+	return s
+}
+
+// covers reports whether scenario s exercises journey j.
+func (j Journey) covers(s CoverageScenario) bool {
+	if hasAnyTag(s.Tags, j.Tags) {
+		return true
+	}
+	if _, ok := firstMatch(j.Scenarios, []string{s.Path}); ok {
+		return true
+	}
+	if s.URL == "" || len(j.Routes) == 0 {
+		return false
+	}
+	p := s.URL
+	if u, err := url.Parse(s.URL); err == nil {
+		p = u.Path
+	}
+	p = strings.Trim(p, "/")
+	for _, route := range j.Routes {
+		if matchGlob(strings.Trim(route, "/"), p) {
+			return true
+		}
+	}
+	return false
+}
+
+// JourneyCoverage is one journey's coverage.
+type JourneyCoverage struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Area string `json:"area,omitempty"`
+
+	// Status is CoverageCovered, CoverageQuarantinedOnly or CoverageUncovered.
+	Status string `json:"status"`
+
+	// Scenarios are the active scenarios covering the journey.
+	Scenarios []string `json:"scenarios,omitempty"`
+
+	// Quarantined are the quarantined scenarios covering the journey.
+	Quarantined []string `json:"quarantined,omitempty"`
+}
+
+// CoveragePoint is journey coverage in one batch.
+type CoveragePoint struct {
+	BatchID   string    `json:"batch_id"`
+	StartedAt time.Time `json:"started_at"`
+
+	// Exercised is the number of journeys with a scenario that ran.
+	Exercised int `json:"exercised"`
+
+	// Passing is the number of journeys with a scenario that passed.
+	Passing int `json:"passing"`
+
+	// Total is the number of journeys.
+	Total int `json:"total"`
+}
+
+// CoverageReport maps scenarios onto journeys.
+type CoverageReport struct {
+	Journeys []JourneyCoverage `json:"journeys"`
+
+	Total           int `json:"total"`
+	Covered         int `json:"covered"`
+	QuarantinedOnly int `json:"quarantined_only"`
+	Uncovered       int `json:"uncovered"`
+
+	// Trend is coverage per batch, oldest first.
+	Trend []CoveragePoint `json:"trend,omitempty"`
+}
+
+// Percent returns the share of journeys covered by an active scenario.
+func (r *CoverageReport) Percent() float64 {
+	if r.Total == 0 {
+		return 0
+	}
+	return float64(r.Covered) * 100 / float64(r.Total)
+}
+
+// BuildCoverage maps scenarios onto the journeys. quarantined reports
+// whether a scenario (by name) is quarantined; batches (newest first, as
+// from LoadRecentBatches) give the trend.
+func BuildCoverage(m *JourneyMap, scenarios []CoverageScenario, quarantined func(string) bool, batches []*BatchResult) *CoverageReport {
+	report := &CoverageReport{Total: len(m.Journeys)}
+	byJourney := make(map[string][]string)
+
+	for _, j := range m.Journeys {
+		jc := JourneyCoverage{ID: j.ID, Name: j.Name, Area: j.Area}
+		if jc.Name == "" {
+			jc.Name = j.ID
+		}
+		for _, s := range scenarios {
+			if !j.covers(s) {
+				continue
+			}
+			byJourney[j.ID] = append(byJourney[j.ID], s.Name)
+			if quarantined != nil && quarantined(s.Name) {
+				jc.Quarantined = append(jc.Quarantined, s.Name)
+			} else {
+				jc.Scenarios = append(jc.Scenarios, s.Name)
+			}
+		}
+
+		switch {
+		case len(jc.Scenarios) > 0:
+			jc.Status = CoverageCovered
+			report.Covered++
+		case len(jc.Quarantined) > 0:
+			jc.Status = CoverageQuarantinedOnly
+			report.QuarantinedOnly++
+		default:
+			jc.Status = CoverageUncovered
+			report.Uncovered++
+		}
+		report.Journeys = append(report.Journeys, jc)
+	}
+
+	for i := len(batches) - 1; i >= 0; i-- {
+		report.Trend = append(report.Trend, coverageInBatch(m, byJourney, batches[i]))
+	}
+	return report
+}
+
+// coverageInBatch counts the journeys a batch exercised and passed, using
+// the current journey-to-scenario mapping.
+func coverageInBatch(m *JourneyMap, byJourney map[string][]string, b *BatchResult) CoveragePoint {
+	status := make(map[string]RunStatus, len(b.Results))
+	for _, r := range b.Results {
+		status[r.Scenario] = r.Status
+	}
+
+	point := CoveragePoint{BatchID: b.ID, StartedAt: b.StartedAt, Total: len(m.Journeys)}
+	for _, j := range m.Journeys {
+		exercised, passing := false, false
+		for _, name := range byJourney[j.ID] {
+			st, ok := status[name]
+			if !ok {
+				continue
+			}
+			exercised = exercised || ran(st)
+			passing = passing || st == StatusPassed
+		}
+		if exercised {
+			point.Exercised++
+		}
+		if passing {
+			point.Passing++
+		}
+	}
+	return point
+}
+
+// SortByStatus orders journeys uncovered first, then quarantined-only,
+// then covered, by area and ID within each.
+func (r *CoverageReport) SortByStatus() {
+	rank := map[string]int{CoverageUncovered: 0, CoverageQuarantinedOnly: 1, CoverageCovered: 2}
+	sort.SliceStable(r.Journeys, func(i, j int) bool {
+		a, b := r.Journeys[i], r.Journeys[j]
+		if rank[a.Status] != rank[b.Status] {
+			return rank[a.Status] < rank[b.Status]
+		}
+		if a.Area != b.Area {
+			return a.Area < b.Area
+		}
+		return a.ID < b.ID
+	})
+}
@@ -0,0 +1,92 @@
+package batch
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadJourneyMap_Validation(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr bool
+	}{
+		{"valid", "journeys:\n  - id: checkout\n    routes: [\"/checkout/**\"]\n", false},
+		{"empty", "journeys: []\n", true},
+		{"missing id", "journeys:\n  - routes: [\"/cart\"]\n", true},
+		{"duplicate id", "journeys:\n  - id: a\n    tags: [x]\n  - id: a\n    tags: [y]\n", true},
+		{"no matchers", "journeys:\n  - id: a\n", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), JourneysFileName)
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+			_, err := LoadJourneyMap(path)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LoadJourneyMap() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBuildCoverage(t *testing.T) {
+	m := &JourneyMap{Journeys: []Journey{
+		{ID: "checkout", Routes: []string{"/checkout/**"}},
+		{ID: "signup", Tags: []string{"registration"}},
+		{ID: "search", Scenarios: []string{"scenarios/search/*.yaml"}},
+		{ID: "admin", Routes: []string{"/admin/**"}},
+	}}
+	scenarios := []CoverageScenario{
+		{Name: "checkout-flow", Path: "scenarios/checkout-flow.yaml", URL: "https://shop.example.com/checkout/cart?x=1"},
+		{Name: "register", Path: "scenarios/register.yaml", Tags: []string{"registration"}},
+		{Name: "find", Path: "scenarios/search/find.yaml"},
+	}
+	quarantined := func(name string) bool { return name == "register" }
+
+	// Newest first, as from LoadRecentBatches
+	batches := []*BatchResult{
+		{ID: "b2", Results: []ScenarioResult{
+			{Scenario: "checkout-flow", Status: StatusPassed},
+			{Scenario: "find", Status: StatusFailed},
+		}},
+		{ID: "b1", Results: []ScenarioResult{
+			{Scenario: "checkout-flow", Status: StatusFailed},
+			{Scenario: "register", Status: StatusSkipped},
+		}},
+	}
+
+	report := BuildCoverage(m, scenarios, quarantined, batches)
+
+	want := map[string]string{
+		"checkout": CoverageCovered,
+		"signup":   CoverageQuarantinedOnly,
+		"search":   CoverageCovered,
+		"admin":    CoverageUncovered,
+	}
+	for _, j := range report.Journeys {
+		if j.Status != want[j.ID] {
+			t.Errorf("journey %s status = %s, want %s", j.ID, j.Status, want[j.ID])
+		}
+	}
+	if report.Covered != 2 || report.QuarantinedOnly != 1 || report.Uncovered != 1 {
+		t.Errorf("counts = %d/%d/%d, want 2/1/1", report.Covered, report.QuarantinedOnly, report.Uncovered)
+	}
+
+	if len(report.Trend) != 2 || report.Trend[0].BatchID != "b1" {
+		t.Fatalf("trend = %+v, want b1 then b2", report.Trend)
+	}
+	if p := report.Trend[0]; p.Exercised != 1 || p.Passing != 0 {
+		t.Errorf("b1 exercised/passing = %d/%d, want 1/0", p.Exercised, p.Passing)
+	}
+	if p := report.Trend[1]; p.Exercised != 2 || p.Passing != 1 {
+		t.Errorf("b2 exercised/passing = %d/%d, want 2/1", p.Exercised, p.Passing)
+	}
+
+	report.SortByStatus()
+	if report.Journeys[0].ID != "admin" || report.Journeys[1].ID != "signup" {
+		t.Errorf("sorted order starts %s, %s; want admin, signup", report.Journeys[0].ID, report.Journeys[1].ID)
+	}
+}
@@ -0,0 +1,413 @@
+package batch
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/util"
+)
+
+// HeartbeatDirName is the directory under the results dir holding the
+// heartbeat file of each running batch (<batch-id>.json).
+const HeartbeatDirName = ".heartbeats"
+
+// HeartbeatInterval is how often a running batch refreshes its heartbeat,
+// whether or not a scenario has started or finished.
+const HeartbeatInterval = 10 * time.Second
+
+// DefaultHungAfter is how long a scenario may run before its batch is
+// considered hung.
+const DefaultHungAfter = 15 * time.Minute
+
+// staleHeartbeats is how many missed heartbeats make a batch stale.
+const staleHeartbeats = 6
+
+// BatchState is the liveness of a batch as read from its heartbeat.
+type BatchState string
+
+const (
+	// BatchAlive means the batch process is running and making progress.
+	BatchAlive BatchState = "alive"
+
+	// BatchHung means a scenario has been running longer than the hung
+	// threshold.
+	BatchHung BatchState = "hung"
+
+	// BatchStale means the heartbeat stopped being refreshed while the
+	// process still exists (or can't be checked from this host).
+	BatchStale BatchState = "stale"
+
+	// BatchDead means the batch process is gone without having finished.
+	BatchDead BatchState = "dead"
+)
+
+// RunningScenario is a scenario run in progress.
+type RunningScenario struct {
+	Scenario  string    `json:"scenario"`
+	Path      string    `json:"path"`
+	Model     string    `json:"model,omitempty"`
+	StartedAt time.Time `json:"started_at"`
+}
+
+// Heartbeat is the liveness file a batch writes while it runs, for
+// external supervisors and gt tester batch status. It is removed once the
+// batch writes its manifest, so a heartbeat left behind means the batch is
+// still running or died without finishing.
+type Heartbeat struct {
+	// BatchID is the batch being run.
+	BatchID string `json:"batch_id"`
+
+	// PID and Host identify the batch process.
+	PID  int    `json:"pid"`
+	Host string `json:"host,omitempty"`
+
+	// Workers is the number of scenarios run in parallel.
+	Workers int `json:"workers"`
+
+	// StartedAt is when the batch started; UpdatedAt is the last beat.
+	StartedAt time.Time `json:"started_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// Total and Completed count the batch's scenario runs.
+	Total     int `json:"total"`
+	Completed int `json:"completed"`
+
+	// Running lists the scenarios currently executing, oldest first.
+	Running []RunningScenario `json:"running"`
+
+	// Remaining lists the runs that have not finished, so a batch that
+	// died can be recovered and resumed.
+	Remaining []ScenarioRef `json:"remaining,omitempty"`
+
+	// OutputDir is the batch's output directory.
+	OutputDir string `json:"output_dir"`
+
+	// Config is the batch configuration.
+	Config Config `json:"config"`
+
+	// path is the heartbeat file the heartbeat was loaded from.
+	path string
+}
+
+// HeartbeatPath returns the heartbeat file of a batch under baseDir.
+func HeartbeatPath(baseDir, batchID string) string {
+	return filepath.Join(baseDir, HeartbeatDirName, batchID+".json")
+}
+
+// LoadHeartbeat reads a batch's heartbeat. It returns an error wrapping
+// os.ErrNotExist if the batch has no heartbeat.
+func LoadHeartbeat(baseDir, batchID string) (*Heartbeat, error) {
+	return loadHeartbeatFile(HeartbeatPath(baseDir, batchID))
+}
+
+// ListHeartbeats returns the heartbeats under baseDir, oldest batch first.
+func ListHeartbeats(baseDir string) ([]*Heartbeat, error) {
+	entries, err := os.ReadDir(filepath.Join(baseDir, HeartbeatDirName))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var heartbeats []*Heartbeat
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != ".json" {
+			continue
+		}
+		hb, err := loadHeartbeatFile(filepath.Join(baseDir, HeartbeatDirName, e.Name()))
+		if errors.Is(err, os.ErrNotExist) {
+			continue // Batch finished while listing
+		}
+		if err != nil {
+			return nil, err
+		}
+		heartbeats = append(heartbeats, hb)
+	}
+	sort.Slice(heartbeats, func(i, j int) bool {
+		return heartbeats[i].StartedAt.Before(heartbeats[j].StartedAt)
+	})
+	return heartbeats, nil
+}
+
+func loadHeartbeatFile(path string) (*Heartbeat, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var hb Heartbeat
+	if err := json.Unmarshal(data, &hb); err != nil {
+		return nil, fmt.Errorf("parsing heartbeat %s: %w", path, err)
+	}
+	hb.path = path
+	return &hb, nil
+}
+
+// Check reports the batch's state at now, and why it is not alive.
+// Scenarios running longer than hungAfter make the batch hung.
+func (h *Heartbeat) Check(now time.Time, hungAfter time.Duration) (BatchState, string) {
+	if h.onThisHost() && !processAlive(h.PID) {
+		return BatchDead, fmt.Sprintf("process %d exited without finishing the batch", h.PID)
+	}
+	if age := now.Sub(h.UpdatedAt); age > staleHeartbeats*HeartbeatInterval {
+		return BatchStale, fmt.Sprintf("no heartbeat for %s", age.Round(time.Second))
+	}
+	for _, s := range h.Running {
+		if d := now.Sub(s.StartedAt); hungAfter > 0 && d > hungAfter {
+			return BatchHung, fmt.Sprintf("%s running for %s", s.Scenario, d.Round(time.Second))
+		}
+	}
+	return BatchAlive, ""
+}
+
+// Kill stops the batch process: SIGTERM first, so the batch can write its
+// partial manifest, then SIGKILL if it is still running after grace.
+// A process on another host can't be killed.
+func (h *Heartbeat) Kill(grace time.Duration) error {
+	if !h.onThisHost() {
+		return fmt.Errorf("batch %s is running on %s", h.BatchID, h.Host)
+	}
+	if !processAlive(h.PID) {
+		return nil
+	}
+
+	process, err := os.FindProcess(h.PID)
+	if err != nil {
+		return fmt.Errorf("finding process: %w", err)
+	}
+	if err := process.Signal(syscall.SIGTERM); err != nil {
+		return fmt.Errorf("sending SIGTERM: %w", err)
+	}
+	for deadline := time.Now().Add(grace); time.Now().Before(deadline); time.Sleep(250 * time.Millisecond) {
+		if !processAlive(h.PID) {
+			return nil
+		}
+	}
+	if err := process.Signal(syscall.SIGKILL); err != nil && processAlive(h.PID) {
+		return fmt.Errorf("sending SIGKILL: %w", err)
+	}
+	return nil
+}
+
+// Recover returns the manifest of a batch that stopped without finishing,
+// and removes its heartbeat. A batch that was stopped gracefully has
+// already written a partial manifest; for one that was killed outright, an
+// interrupted manifest listing its unfinished runs as aborted is written
+// from the heartbeat. Runs that finished before the kill keep their
+// artifacts but are not listed in it.
+func (h *Heartbeat) Recover(reason string) (*BatchResult, error) {
+	manifestPath := filepath.Join(h.OutputDir, "manifest.json")
+	var result *BatchResult
+	if _, err := os.Stat(manifestPath); err == nil {
+		if result, err = loadManifestFile(manifestPath); err != nil {
+			return nil, err
+		}
+	} else {
+		result = h.interruptedResult(reason)
+		if err := util.AtomicWriteJSON(manifestPath, result); err != nil {
+			return nil, fmt.Errorf("writing manifest: %w", err)
+		}
+	}
+
+	if err := os.Remove(h.path); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	return result, nil
+}
+
+// interruptedResult builds an interrupted batch result from the heartbeat.
+func (h *Heartbeat) interruptedResult(reason string) *BatchResult {
+	result := &BatchResult{
+		ID:            h.BatchID,
+		Config:        h.Config,
+		StartedAt:     h.StartedAt,
+		TotalDuration: h.UpdatedAt.Sub(h.StartedAt),
+		ScenariosRun:  h.Total,
+		OutputDir:     h.OutputDir,
+		Interrupted:   true,
+		Summary: BatchSummary{
+			TotalObservations: make(map[string]int),
+			Aborted:           len(h.Remaining),
+		},
+	}
+	for _, ref := range h.Remaining {
+		result.Results = append(result.Results, ScenarioResult{
+			Scenario:    strings.TrimSuffix(filepath.Base(ref.Path), filepath.Ext(ref.Path)),
+			Path:        ref.Path,
+			Status:      StatusAborted,
+			Model:       ref.Model,
+			Environment: ref.Environment,
+			MaxAttempts: ref.MaxAttempts,
+			SkipReason:  "batch killed: " + reason,
+		})
+	}
+	return result
+}
+
+// onThisHost reports whether the batch process runs on this host, so its
+// PID can be checked.
+func (h *Heartbeat) onThisHost() bool {
+	host, _ := os.Hostname()
+	return h.Host == "" || h.Host == host
+}
+
+// processAlive checks if a process with the given PID exists.
+func processAlive(pid int) bool {
+	if pid <= 0 {
+		return false
+	}
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	// On Unix, FindProcess always succeeds. Send signal 0 to check if alive.
+	return process.Signal(syscall.Signal(0)) == nil
+}
+
+// heartbeatWriter maintains a running batch's heartbeat file. Its methods
+// are safe on a nil writer, which writes nothing.
+type heartbeatWriter struct {
+	mu       sync.Mutex
+	hb       Heartbeat
+	runs     []ScenarioRef
+	running  map[int]RunningScenario
+	finished []bool
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// startHeartbeat writes the batch's heartbeat and refreshes it every
+// HeartbeatInterval until stopped.
+func (r *Runner) startHeartbeat(result *BatchResult) *heartbeatWriter {
+	host, _ := os.Hostname()
+	w := &heartbeatWriter{
+		hb: Heartbeat{
+			BatchID:   result.ID,
+			PID:       os.Getpid(),
+			Host:      host,
+			Workers:   max(r.config.Parallel, 1),
+			StartedAt: result.StartedAt,
+			OutputDir: result.OutputDir,
+			Config:    r.config,
+			path:      HeartbeatPath(r.baseDir, result.ID),
+		},
+		running: make(map[int]RunningScenario),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	if err := os.MkdirAll(filepath.Dir(w.hb.path), 0755); err != nil {
+		fmt.Printf("Warning: failed to create heartbeat directory: %v\n", err)
+	}
+	w.write()
+
+	go func() {
+		defer close(w.done)
+		ticker := time.NewTicker(HeartbeatInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-w.stop:
+				return
+			case <-ticker.C:
+				w.write()
+			}
+		}
+	}()
+	return w
+}
+
+// setRuns records the batch's scenario runs.
+func (w *heartbeatWriter) setRuns(paths, models []string, refs map[string]ScenarioRef) {
+	if w == nil {
+		return
+	}
+	w.mu.Lock()
+	w.runs = make([]ScenarioRef, len(paths))
+	for i, p := range paths {
+		ref := refs[p]
+		w.runs[i] = ScenarioRef{Path: p, Model: models[i], Environment: ref.Environment, MaxAttempts: ref.MaxAttempts}
+	}
+	w.finished = make([]bool, len(paths))
+	w.mu.Unlock()
+	w.write()
+}
+
+// begin marks run idx as started.
+func (w *heartbeatWriter) begin(idx int) {
+	if w == nil {
+		return
+	}
+	w.mu.Lock()
+	ref := w.runs[idx]
+	w.running[idx] = RunningScenario{
+		Scenario:  strings.TrimSuffix(filepath.Base(ref.Path), filepath.Ext(ref.Path)),
+		Path:      ref.Path,
+		Model:     ref.Model,
+		StartedAt: time.Now(),
+	}
+	w.mu.Unlock()
+	w.write()
+}
+
+// end marks run idx as finished.
+func (w *heartbeatWriter) end(idx int) {
+	if w == nil {
+		return
+	}
+	w.mu.Lock()
+	delete(w.running, idx)
+	w.finished[idx] = true
+	w.mu.Unlock()
+	w.write()
+}
+
+// close stops the heartbeat and removes its file.
+func (w *heartbeatWriter) close() {
+	if w == nil {
+		return
+	}
+	close(w.stop)
+	<-w.done
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if err := os.Remove(w.hb.path); err != nil && !os.IsNotExist(err) {
+		fmt.Printf("Warning: failed to remove heartbeat: %v\n", err)
+	}
+}
+
+// write refreshes the heartbeat file. Failures are logged but don't stop
+// the batch.
+func (w *heartbeatWriter) write() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	hb := w.hb
+	hb.UpdatedAt = time.Now()
+	hb.Total = len(w.runs)
+	hb.Running = []RunningScenario{}
+	for idx, ref := range w.runs {
+		if w.finished[idx] {
+			hb.Completed++
+			continue
+		}
+		hb.Remaining = append(hb.Remaining, ref)
+		if s, ok := w.running[idx]; ok {
+			hb.Running = append(hb.Running, s)
+		}
+	}
+	sort.Slice(hb.Running, func(i, j int) bool {
+		return hb.Running[i].StartedAt.Before(hb.Running[j].StartedAt)
+	})
+
+	if err := util.AtomicWriteJSON(w.hb.path, hb); err != nil {
+		fmt.Printf("Warning: failed to write heartbeat: %v\n", err)
+	}
+}
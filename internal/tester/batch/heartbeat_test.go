@@ -0,0 +1,175 @@
+package batch
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestHeartbeatWriter(t *testing.T) {
+	tmpDir := t.TempDir()
+	config := DefaultConfig()
+	config.OutputDir = tmpDir
+	config.Parallel = 2
+
+	runner, err := NewRunner(config)
+	if err != nil {
+		t.Fatalf("failed to create runner: %v", err)
+	}
+	result := &BatchResult{ID: "abc123", StartedAt: time.Now(), OutputDir: filepath.Join(tmpDir, "batch-abc123")}
+
+	w := runner.startHeartbeat(result)
+	w.setRuns([]string{"a.yaml", "b.yaml", "b.yaml"}, []string{"haiku", "haiku", "sonnet"}, nil)
+	w.begin(0)
+	w.end(0)
+	w.begin(2)
+
+	hb, err := LoadHeartbeat(tmpDir, "abc123")
+	if err != nil {
+		t.Fatalf("LoadHeartbeat: %v", err)
+	}
+	if hb.PID != os.Getpid() || hb.Workers != 2 {
+		t.Errorf("PID = %d, Workers = %d", hb.PID, hb.Workers)
+	}
+	if hb.Total != 3 || hb.Completed != 1 || len(hb.Remaining) != 2 {
+		t.Errorf("Total = %d, Completed = %d, Remaining = %v", hb.Total, hb.Completed, hb.Remaining)
+	}
+	if len(hb.Running) != 1 || hb.Running[0].Scenario != "b" || hb.Running[0].Model != "sonnet" {
+		t.Errorf("Running = %+v, want b (sonnet)", hb.Running)
+	}
+	if state, reason := hb.Check(time.Now(), DefaultHungAfter); state != BatchAlive {
+		t.Errorf("Check() = %s (%s), want alive", state, reason)
+	}
+
+	w.close()
+	if _, err := LoadHeartbeat(tmpDir, "abc123"); !os.IsNotExist(err) {
+		t.Errorf("heartbeat should be removed on close, got %v", err)
+	}
+}
+
+func TestRunRemovesHeartbeat(t *testing.T) {
+	tmpDir := t.TempDir()
+	os.WriteFile(filepath.Join(tmpDir, "test.yaml"), []byte("scenario: test\n"), 0644)
+
+	config := DefaultConfig()
+	config.OutputDir = tmpDir
+	config.Pattern = filepath.Join(tmpDir, "*.yaml")
+	config.SkipPreflight = true
+
+	runner, err := NewRunner(config)
+	if err != nil {
+		t.Fatalf("failed to create runner: %v", err)
+	}
+	if _, err := runner.Run(context.Background()); err != nil {
+		t.Fatalf("batch run failed: %v", err)
+	}
+
+	heartbeats, err := ListHeartbeats(tmpDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(heartbeats) != 0 {
+		t.Errorf("finished batch left %d heartbeat(s)", len(heartbeats))
+	}
+}
+
+func TestHeartbeatCheck(t *testing.T) {
+	now := time.Now()
+	alive := func() *Heartbeat {
+		return &Heartbeat{
+			BatchID:   "abc123",
+			PID:       os.Getpid(),
+			UpdatedAt: now.Add(-5 * time.Second),
+			Running:   []RunningScenario{{Scenario: "checkout", StartedAt: now.Add(-2 * time.Minute)}},
+		}
+	}
+
+	hung := alive()
+	hung.Running[0].StartedAt = now.Add(-20 * time.Minute)
+
+	stale := alive()
+	stale.UpdatedAt = now.Add(-5 * time.Minute)
+
+	dead := alive()
+	cmd := exec.Command("true")
+	if err := cmd.Run(); err != nil {
+		t.Skipf("can't run a short-lived process: %v", err)
+	}
+	dead.PID = cmd.Process.Pid
+
+	otherHost := alive()
+	otherHost.PID = cmd.Process.Pid
+	otherHost.Host = "some-other-host"
+
+	tests := []struct {
+		name string
+		hb   *Heartbeat
+		want BatchState
+	}{
+		{"alive", alive(), BatchAlive},
+		{"hung", hung, BatchHung},
+		{"stale", stale, BatchStale},
+		{"dead", dead, BatchDead},
+		{"other host", otherHost, BatchAlive},
+	}
+	for _, tt := range tests {
+		if got, reason := tt.hb.Check(now, DefaultHungAfter); got != tt.want {
+			t.Errorf("%s: Check() = %s (%s), want %s", tt.name, got, reason, tt.want)
+		}
+	}
+}
+
+func TestHeartbeatRecover(t *testing.T) {
+	tmpDir := t.TempDir()
+	batchDir := filepath.Join(tmpDir, "2026-01-02", "batch-abc123")
+	if err := os.MkdirAll(batchDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	config := DefaultConfig()
+	config.OutputDir = tmpDir
+	config.FilterTags = []string{"smoke"}
+	runner, err := NewRunner(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := runner.startHeartbeat(&BatchResult{ID: "abc123", StartedAt: time.Now(), OutputDir: batchDir})
+	w.setRuns([]string{"a.yaml", "b.yaml"}, []string{"haiku", "sonnet"}, nil)
+	w.begin(0)
+	w.end(0)
+	w.begin(1)
+	// Simulate a process killed outright: stop beating, leave the file
+	close(w.stop)
+	<-w.done
+
+	hb, err := LoadHeartbeat(tmpDir, "abc123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := hb.Recover("b running for 20m0s")
+	if err != nil {
+		t.Fatalf("Recover: %v", err)
+	}
+	if !result.Interrupted || result.Summary.Aborted != 1 {
+		t.Errorf("Interrupted = %v, Aborted = %d", result.Interrupted, result.Summary.Aborted)
+	}
+	if _, err := LoadHeartbeat(tmpDir, "abc123"); !os.IsNotExist(err) {
+		t.Errorf("heartbeat should be removed by Recover, got %v", err)
+	}
+
+	// The recovered manifest resumes the unfinished run
+	prev, err := LoadBatchResult(tmpDir, "abc123")
+	if err != nil {
+		t.Fatalf("LoadBatchResult: %v", err)
+	}
+	if _, err := ResumeConfig(prev); err != nil {
+		t.Fatalf("ResumeConfig: %v", err)
+	}
+	refs, _ := (&ResumeSource{Batch: prev}).Scenarios()
+	if len(refs) != 1 || refs[0].Path != "b.yaml" || refs[0].Model != "sonnet" {
+		t.Errorf("resume refs = %+v, want b.yaml (sonnet)", refs)
+	}
+}
@@ -0,0 +1,165 @@
+package batch
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/tester/flake"
+)
+
+// QuarantineImpact records the risk hidden by a batch that passed only
+// because quarantined scenarios were skipped: at least one of them failed
+// its last recorded run, so the batch would likely have failed with them.
+type QuarantineImpact struct {
+	// Scenarios are the quarantined scenarios the batch skipped, longest
+	// excluded first.
+	Scenarios []QuarantinedScenario `json:"scenarios"`
+
+	// Journeys are the journeys covered by any skipped scenario, sorted.
+	Journeys []string `json:"journeys,omitempty"`
+
+	// Tags are the tags carried by any skipped scenario, sorted.
+	Tags []string `json:"tags,omitempty"`
+}
+
+// QuarantinedScenario is one quarantined scenario skipped by a batch.
+type QuarantinedScenario struct {
+	// Scenario is the scenario name.
+	Scenario string `json:"scenario"`
+
+	// Path is the scenario file.
+	Path string `json:"path"`
+
+	// Journeys are the IDs of the journeys the scenario covers.
+	Journeys []string `json:"journeys,omitempty"`
+
+	// Tags are the scenario's tags.
+	Tags []string `json:"tags,omitempty"`
+
+	// QuarantinedAt is when the scenario was quarantined (zero if unknown).
+	QuarantinedAt time.Time `json:"quarantined_at,omitempty"`
+
+	// Excluded is how long the scenario has been quarantined.
+	Excluded time.Duration `json:"excluded,omitempty"`
+
+	// Reason is why the scenario was quarantined.
+	Reason string `json:"reason,omitempty"`
+
+	// Owner is who is responsible for fixing the scenario.
+	Owner string `json:"owner,omitempty"`
+
+	// LastOutcome is the outcome of the scenario's last recorded run.
+	LastOutcome flake.RunOutcome `json:"last_outcome,omitempty"`
+}
+
+// Failing reports whether the scenario failed its last recorded run.
+func (q QuarantinedScenario) Failing() bool {
+	return q.LastOutcome == flake.OutcomeFail || q.LastOutcome == flake.OutcomeError
+}
+
+// quarantineImpact builds the impact of the quarantined scenarios skipped
+// by a batch, or returns nil unless skipping them is all that kept the
+// batch from failing.
+func (r *Runner) quarantineImpact(result *BatchResult, now time.Time) *QuarantineImpact {
+	if result.Summary.Failed > 0 || result.Summary.Errors > 0 || result.Summary.Aborted > 0 {
+		return nil
+	}
+
+	var scenarios []QuarantinedScenario
+	hidden := false
+	for _, sr := range result.Results {
+		if !sr.Quarantined || sr.Status != StatusSkipped {
+			continue
+		}
+		q := QuarantinedScenario{Scenario: sr.Scenario, Path: sr.Path}
+		if sr.Flake != nil {
+			q.LastOutcome = sr.Flake.LastOutcome
+		}
+		if entry := r.flakeDetector.GetQuarantineEntry(sr.Scenario); entry != nil {
+			q.QuarantinedAt, q.Reason, q.Owner = entry.QuarantinedAt, entry.Reason, entry.Owner
+		} else if entry, ok := r.quarantineStore.Get(sr.Scenario); ok {
+			q.QuarantinedAt, q.Reason = entry.QuarantinedAt, entry.Reason
+		}
+		if !q.QuarantinedAt.IsZero() {
+			q.Excluded = now.Sub(q.QuarantinedAt)
+		}
+		hidden = hidden || q.Failing()
+		scenarios = append(scenarios, q)
+	}
+	if !hidden {
+		return nil
+	}
+
+	journeys := r.impactJourneys()
+	impact := &QuarantineImpact{}
+	journeySet := make(map[string]bool)
+	tagSet := make(map[string]bool)
+	for i := range scenarios {
+		q := &scenarios[i]
+		ref, ok := r.refs[q.Path]
+		if !ok {
+			ref = ScenarioRef{Path: q.Path}
+		}
+		cs := coverageScenario(ref)
+		q.Tags = uniqueSorted(append(r.extractTags(q.Path), cs.Tags...))
+		for _, j := range journeys {
+			if j.covers(cs) {
+				q.Journeys = append(q.Journeys, j.ID)
+				journeySet[j.ID] = true
+			}
+		}
+		for _, tag := range q.Tags {
+			tagSet[tag] = true
+		}
+	}
+	sort.SliceStable(scenarios, func(i, j int) bool {
+		return scenarios[i].Excluded > scenarios[j].Excluded
+	})
+	impact.Scenarios = scenarios
+	impact.Journeys = sortedKeys(journeySet)
+	impact.Tags = sortedKeys(tagSet)
+	return impact
+}
+
+// impactJourneys loads the journey map for the impact report: Config.Journeys
+// if set, otherwise JourneysFileName if it exists. Without one, the report
+// lists tags only.
+func (r *Runner) impactJourneys() []Journey {
+	path := r.config.Journeys
+	if path == "" {
+		if _, err := os.Stat(JourneysFileName); err != nil {
+			return nil
+		}
+		path = JourneysFileName
+	}
+	m, err := LoadJourneyMap(path)
+	if err != nil {
+		fmt.Printf("Warning: quarantine impact without journeys: %v\n", err)
+		return nil
+	}
+	return m.Journeys
+}
+
+// uniqueSorted returns the distinct values, sorted.
+func uniqueSorted(values []string) []string {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	return sortedKeys(set)
+}
+
+// sortedKeys returns a set's keys, sorted.
+func sortedKeys(set map[string]bool) []string {
+	if len(set) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,98 @@
+package batch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/tester/flake"
+)
+
+const impactScenario = `
+scenario: %s
+persona: sarah
+tags: [%s]
+goal: Buy a gift card
+success_criteria:
+  - Order confirmed
+environment:
+  url: https://staging.example.com/%s
+`
+
+func TestQuarantineImpact(t *testing.T) {
+	tmpDir := t.TempDir()
+	for name, tag := range map[string]string{"checkout": "payments", "login": "auth"} {
+		content := fmt.Sprintf(impactScenario, name, tag, name)
+		if err := os.WriteFile(filepath.Join(tmpDir, name+".yaml"), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	journeys := filepath.Join(tmpDir, "journeys.yaml")
+	if err := os.WriteFile(journeys, []byte("journeys:\n  - id: purchase\n    routes: [\"/checkout\"]\n  - id: signin\n    tags: [auth]\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	config := DefaultConfig()
+	config.OutputDir = tmpDir
+	config.Pattern = filepath.Join(tmpDir, "*.yaml")
+	config.SkipPreflight = true
+	config.Journeys = journeys
+
+	run := func(outcome flake.RunOutcome) *BatchResult {
+		t.Helper()
+		runner, err := NewRunner(config)
+		if err != nil {
+			t.Fatalf("failed to create runner: %v", err)
+		}
+		if _, err := runner.flakeDetector.RecordRun("checkout", flake.RunRecord{Timestamp: time.Now(), Outcome: outcome}); err != nil {
+			t.Fatalf("RecordRun() error = %v", err)
+		}
+		if err := runner.flakeDetector.Quarantine("checkout", "flaky payment iframe"); err != nil {
+			t.Fatalf("Quarantine() error = %v", err)
+		}
+		result, err := runner.Run(context.Background())
+		if err != nil {
+			t.Fatalf("batch run failed: %v", err)
+		}
+		return result
+	}
+
+	// Skipping a scenario that last passed hides nothing
+	if result := run(flake.OutcomePass); result.QuarantineImpact != nil {
+		t.Errorf("expected no impact for a passing quarantined scenario, got %+v", result.QuarantineImpact)
+	}
+
+	result := run(flake.OutcomeFail)
+	impact := result.QuarantineImpact
+	if impact == nil || len(impact.Scenarios) != 1 {
+		t.Fatalf("expected impact for checkout, got %+v", impact)
+	}
+	q := impact.Scenarios[0]
+	if q.Scenario != "checkout" || !q.Failing() || q.Reason != "flaky payment iframe" || q.QuarantinedAt.IsZero() {
+		t.Errorf("unexpected impact scenario: %+v", q)
+	}
+	if len(q.Journeys) != 1 || q.Journeys[0] != "purchase" {
+		t.Errorf("checkout journeys = %v, want [purchase]", q.Journeys)
+	}
+	if !slices.Contains(impact.Tags, "payments") {
+		t.Errorf("impact tags = %v, want payments", impact.Tags)
+	}
+
+	// The impact is saved in the manifest
+	data, err := os.ReadFile(filepath.Join(result.OutputDir, "manifest.json"))
+	if err != nil {
+		t.Fatalf("failed to read manifest: %v", err)
+	}
+	var saved BatchResult
+	if err := json.Unmarshal(data, &saved); err != nil {
+		t.Fatal(err)
+	}
+	if saved.QuarantineImpact == nil || len(saved.QuarantineImpact.Journeys) != 1 {
+		t.Errorf("manifest impact = %+v", saved.QuarantineImpact)
+	}
+}
@@ -0,0 +1,195 @@
+package batch
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// FormatJUnit is the Config.Format that also writes a JUnit XML report.
+const FormatJUnit = "junit"
+
+// JUnitFileName is the JUnit report's file name, next to manifest.json.
+const JUnitFileName = "junit.xml"
+
+// junitTestSuites is the root of a JUnit report, in the dialect Jenkins and
+// GitLab CI read.
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name       string          `xml:"name,attr"`
+	Tests      int             `xml:"tests,attr"`
+	Failures   int             `xml:"failures,attr"`
+	Errors     int             `xml:"errors,attr"`
+	Skipped    int             `xml:"skipped,attr"`
+	Time       string          `xml:"time,attr"`
+	Timestamp  string          `xml:"timestamp,attr"`
+	Properties []junitProperty `xml:"properties>property,omitempty"`
+	Cases      []junitTestCase `xml:"testcase"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Error     *junitMessage `xml:"error,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr,omitempty"`
+	Type    string `xml:"type,attr,omitempty"`
+	Text    string `xml:",chardata"`
+}
+
+// WriteJUnit writes the batch result as a JUnit XML report. Each scenario
+// run is a test case: failed runs are failures, errored and aborted runs
+// errors (type "aborted" for the latter, since they never finished), and
+// skipped runs, e.g. quarantined scenarios, skipped with the skip reason as
+// the message.
+func WriteJUnit(w io.Writer, result *BatchResult) error {
+	suite := junitTestSuite{
+		Name:      "batch-" + result.ID,
+		Time:      junitSeconds(result.TotalDuration),
+		Timestamp: result.StartedAt.UTC().Format("2006-01-02T15:04:05"),
+	}
+	for _, p := range []junitProperty{
+		{"environment", result.Config.Environment},
+		{"model", result.Config.Model},
+		{"build_sha", result.Config.BuildSHA},
+		{"app_version", result.Config.AppVersion},
+	} {
+		if p.Value != "" {
+			suite.Properties = append(suite.Properties, p)
+		}
+	}
+
+	ab := len(result.Config.ABModels) > 0
+	for _, sr := range result.Results {
+		tc := junitTestCase{
+			Name:      sr.Scenario,
+			ClassName: junitClassName(sr),
+			Time:      junitSeconds(sr.Duration),
+		}
+		if ab && sr.Model != "" {
+			// A/B batches run every scenario once per model
+			tc.Name += " [" + sr.Model + "]"
+		}
+		switch sr.Status {
+		case StatusFailed:
+			suite.Failures++
+			tc.Failure = &junitMessage{Message: failureMessage(sr), Type: "failed", Text: sr.Error}
+		case StatusError:
+			suite.Errors++
+			typ := categorizeError(sr.Error)
+			if typ == "" {
+				typ = "error"
+			}
+			tc.Error = &junitMessage{Message: sr.Error, Type: typ, Text: sr.Error}
+		case StatusAborted:
+			suite.Errors++
+			reason := sr.SkipReason
+			if reason == "" {
+				reason = "aborted"
+			}
+			tc.Error = &junitMessage{Message: reason, Type: "aborted", Text: sr.Error}
+		case StatusSkipped:
+			suite.Skipped++
+			reason := sr.SkipReason
+			if reason == "" {
+				reason = string(sr.Status)
+			}
+			tc.Skipped = &junitMessage{Message: reason}
+		}
+		if sr.ArtifactDir != "" {
+			tc.SystemOut = "artifacts: " + sr.ArtifactDir
+			if sr.ArtifactURL != "" {
+				tc.SystemOut += "\nartifact manifest: " + sr.ArtifactURL
+			}
+		}
+		suite.Cases = append(suite.Cases, tc)
+	}
+	suite.Tests = len(suite.Cases)
+
+	report := junitTestSuites{
+		Name:     "gt tester batch",
+		Tests:    suite.Tests,
+		Failures: suite.Failures,
+		Errors:   suite.Errors,
+		Skipped:  suite.Skipped,
+		Time:     suite.Time,
+		Suites:   []junitTestSuite{suite},
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(report); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// saveJUnitReport writes the batch's JUnit report next to its manifest.
+func (r *Runner) saveJUnitReport(result *BatchResult) error {
+	f, err := os.Create(filepath.Join(result.OutputDir, JUnitFileName))
+	if err != nil {
+		return err
+	}
+	if err := WriteJUnit(f, result); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// failureMessage summarizes why a scenario failed.
+func failureMessage(sr ScenarioResult) string {
+	if sr.Error != "" {
+		return sr.Error
+	}
+	if sr.SuccessCriteriaTotal > 0 {
+		return fmt.Sprintf("%d/%d success criteria met", sr.SuccessCriteriaMet, sr.SuccessCriteriaTotal)
+	}
+	return "scenario failed"
+}
+
+// junitClassName groups a scenario by its directory, dotted the way CI
+// test views expect ("scenarios/registration/signup.yaml" becomes
+// "scenarios.registration").
+func junitClassName(sr ScenarioResult) string {
+	dir := filepath.ToSlash(filepath.Dir(sr.Path))
+	dir = strings.Trim(strings.TrimPrefix(dir, "./"), "/")
+	if dir == "" || dir == "." {
+		return sr.Scenario
+	}
+	return strings.ReplaceAll(dir, "/", ".")
+}
+
+// junitSeconds formats a duration as JUnit's decimal seconds.
+func junitSeconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
@@ -0,0 +1,123 @@
+package batch
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteJUnit(t *testing.T) {
+	result := &BatchResult{
+		ID:            "3f9a1c2e",
+		Config:        Config{Environment: "staging", BuildSHA: "abc123"},
+		StartedAt:     time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC),
+		TotalDuration: 90 * time.Second,
+		Results: []ScenarioResult{
+			{Scenario: "signup", Path: "scenarios/registration/signup.yaml", Status: StatusPassed, Duration: 1500 * time.Millisecond},
+			{Scenario: "checkout", Path: "scenarios/checkout.yaml", Status: StatusFailed, Duration: 2 * time.Second,
+				SuccessCriteriaMet: 1, SuccessCriteriaTotal: 3},
+			{Scenario: "login", Path: "login.yaml", Status: StatusError, Error: "browser crashed"},
+			{Scenario: "search", Path: "scenarios/search.yaml", Status: StatusSkipped, SkipReason: "quarantined: flaky"},
+			{Scenario: "profile", Path: "scenarios/profile.yaml", Status: StatusAborted},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteJUnit(&buf, result); err != nil {
+		t.Fatalf("WriteJUnit() error = %v", err)
+	}
+
+	var report junitTestSuites
+	if err := xml.Unmarshal(buf.Bytes(), &report); err != nil {
+		t.Fatalf("report is not valid XML: %v\n%s", err, buf.String())
+	}
+	if report.Tests != 5 || report.Failures != 1 || report.Errors != 2 || report.Skipped != 1 {
+		t.Errorf("totals = %d tests, %d failures, %d errors, %d skipped; want 5, 1, 2, 1",
+			report.Tests, report.Failures, report.Errors, report.Skipped)
+	}
+	if len(report.Suites) != 1 {
+		t.Fatalf("got %d suites, want 1", len(report.Suites))
+	}
+	suite := report.Suites[0]
+	if suite.Name != "batch-3f9a1c2e" || suite.Time != "90.000" {
+		t.Errorf("suite = %q, time %q", suite.Name, suite.Time)
+	}
+
+	cases := suite.Cases
+	if cases[0].ClassName != "scenarios.registration" || cases[0].Time != "1.500" {
+		t.Errorf("passed case = %+v", cases[0])
+	}
+	if cases[0].Failure != nil || cases[0].Error != nil || cases[0].Skipped != nil {
+		t.Errorf("passed case has an outcome element: %+v", cases[0])
+	}
+	if cases[1].Failure == nil || cases[1].Failure.Message != "1/3 success criteria met" {
+		t.Errorf("failed case failure = %+v", cases[1].Failure)
+	}
+	if cases[2].Error == nil || cases[2].Error.Message != "browser crashed" || cases[2].Error.Type != "browser_crash" {
+		t.Errorf("errored case error = %+v", cases[2].Error)
+	}
+	if cases[2].ClassName != "login" {
+		t.Errorf("top-level scenario classname = %q, want the scenario name", cases[2].ClassName)
+	}
+	if cases[3].Skipped == nil || cases[3].Skipped.Message != "quarantined: flaky" {
+		t.Errorf("skipped case = %+v", cases[3].Skipped)
+	}
+	if cases[4].Skipped != nil || cases[4].Error == nil || cases[4].Error.Type != "aborted" || cases[4].Error.Message != "aborted" {
+		t.Errorf("aborted case = %+v", cases[4])
+	}
+	if !strings.Contains(buf.String(), `<error message="aborted" type="aborted">`) {
+		t.Errorf("aborted run should be an error element:\n%s", buf.String())
+	}
+}
+
+func TestWriteJUnit_ABModelsInName(t *testing.T) {
+	result := &BatchResult{
+		Config: Config{ABModels: []string{"haiku", "sonnet"}},
+		Results: []ScenarioResult{
+			{Scenario: "signup", Status: StatusPassed, Model: "haiku"},
+			{Scenario: "signup", Status: StatusPassed, Model: "sonnet"},
+		},
+	}
+	var buf bytes.Buffer
+	if err := WriteJUnit(&buf, result); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), `name="signup [haiku]"`) || !strings.Contains(buf.String(), `name="signup [sonnet]"`) {
+		t.Errorf("A/B runs should be told apart by model:\n%s", buf.String())
+	}
+}
+
+func TestRunWritesJUnitReport(t *testing.T) {
+	tmpDir := t.TempDir()
+	os.WriteFile(filepath.Join(tmpDir, "test.yaml"), []byte("scenario: test\n"), 0644)
+
+	config := DefaultConfig()
+	config.OutputDir = tmpDir
+	config.Pattern = filepath.Join(tmpDir, "*.yaml")
+	config.SkipPreflight = true
+	config.Format = FormatJUnit
+
+	runner, err := NewRunner(config)
+	if err != nil {
+		t.Fatalf("failed to create runner: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	result, err := runner.Run(ctx)
+	if err != nil {
+		t.Fatalf("batch run failed: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(result.OutputDir, JUnitFileName))
+	if err != nil {
+		t.Fatalf("JUnit report not written: %v", err)
+	}
+	if !strings.Contains(string(data), `<testcase name="test"`) {
+		t.Errorf("report missing the scenario:\n%s", data)
+	}
+}
@@ -0,0 +1,178 @@
+package batch
+
+import (
+	"sort"
+	"time"
+)
+
+const (
+	// PersonaPassRateGap is how far a persona's pass rate must trail the
+	// best persona's for the scenario to be flagged as affecting it.
+	PersonaPassRateGap = 0.25
+
+	// PersonaObservationGap is how many more observations per run than the
+	// quietest persona a persona must average (and at least double) to be
+	// flagged.
+	PersonaObservationGap = 1.0
+
+	// MinPersonaRuns is the fewest runs a persona needs before it can be
+	// flagged, so a single bad run doesn't single it out.
+	MinPersonaRuns = 2
+)
+
+// PersonaComparison compares how each scenario fared under the personas
+// it ran as across recent batches, typically scheduled batches with
+// RotatePersonas cycling each scenario's persona pool.
+type PersonaComparison struct {
+	// BatchIDs are the compared batches, newest first.
+	BatchIDs []string `json:"batch_ids"`
+
+	// Since and Until bound the compared batches' start times.
+	Since time.Time `json:"since"`
+	Until time.Time `json:"until"`
+
+	// Scenarios are the scenarios that ran as more than one persona, by
+	// name.
+	Scenarios []PersonaScenario `json:"scenarios"`
+
+	// Affected counts the scenarios with at least one affected persona.
+	Affected int `json:"affected"`
+}
+
+// PersonaScenario is one scenario's results by persona.
+type PersonaScenario struct {
+	Scenario string `json:"scenario"`
+
+	// Personas are the per-persona totals, by persona name.
+	Personas []PersonaStats `json:"personas"`
+
+	// Affected lists the personas that do markedly worse than the others:
+	// a lower pass rate (PersonaPassRateGap) or more observations
+	// (PersonaObservationGap). These point at UX issues that only affect
+	// some kinds of user.
+	Affected []string `json:"affected,omitempty"`
+}
+
+// PersonaStats totals one persona's runs of a scenario.
+type PersonaStats struct {
+	Persona string `json:"persona"`
+
+	// Runs counts completed runs (passed, failed or errored).
+	Runs   int `json:"runs"`
+	Passed int `json:"passed"`
+
+	// PassRate is Passed/Runs (0-1).
+	PassRate float64 `json:"pass_rate"`
+
+	// Observations counts observations by severity.
+	Observations map[string]int `json:"observations"`
+
+	// TotalObservations is the sum of Observations.
+	TotalObservations int `json:"total_observations"`
+
+	// AvgObservations is TotalObservations/Runs.
+	AvgObservations float64 `json:"avg_observations"`
+
+	// LastRun is the start of the newest batch the persona ran in.
+	LastRun time.Time `json:"last_run"`
+}
+
+// ComparePersonas totals the completed runs in batches by scenario and
+// persona, and flags the personas each scenario treats markedly worse.
+// Runs without a recorded persona are ignored.
+func ComparePersonas(batches []*BatchResult) *PersonaComparison {
+	cmp := &PersonaComparison{}
+	byScenario := make(map[string]map[string]*PersonaStats)
+
+	for _, b := range batches {
+		cmp.BatchIDs = append(cmp.BatchIDs, b.ID)
+		if cmp.Since.IsZero() || b.StartedAt.Before(cmp.Since) {
+			cmp.Since = b.StartedAt
+		}
+		if b.StartedAt.After(cmp.Until) {
+			cmp.Until = b.StartedAt
+		}
+
+		for _, sr := range b.Results {
+			if sr.Persona == "" || !ran(sr.Status) {
+				continue
+			}
+			personas := byScenario[sr.Scenario]
+			if personas == nil {
+				personas = make(map[string]*PersonaStats)
+				byScenario[sr.Scenario] = personas
+			}
+			stats := personas[sr.Persona]
+			if stats == nil {
+				stats = &PersonaStats{Persona: sr.Persona, Observations: make(map[string]int)}
+				personas[sr.Persona] = stats
+			}
+
+			stats.Runs++
+			if sr.Status == StatusPassed {
+				stats.Passed++
+			}
+			for sev, n := range sr.Observations {
+				stats.Observations[sev] += n
+				stats.TotalObservations += n
+			}
+			if b.StartedAt.After(stats.LastRun) {
+				stats.LastRun = b.StartedAt
+			}
+		}
+	}
+
+	for name, personas := range byScenario {
+		if len(personas) < 2 {
+			continue
+		}
+		ps := PersonaScenario{Scenario: name}
+		for _, stats := range personas {
+			stats.PassRate = float64(stats.Passed) / float64(stats.Runs)
+			stats.AvgObservations = float64(stats.TotalObservations) / float64(stats.Runs)
+			ps.Personas = append(ps.Personas, *stats)
+		}
+		sort.Slice(ps.Personas, func(i, j int) bool {
+			return ps.Personas[i].Persona < ps.Personas[j].Persona
+		})
+		ps.Affected = affectedPersonas(ps.Personas)
+		if len(ps.Affected) > 0 {
+			cmp.Affected++
+		}
+		cmp.Scenarios = append(cmp.Scenarios, ps)
+	}
+	sort.Slice(cmp.Scenarios, func(i, j int) bool {
+		return cmp.Scenarios[i].Scenario < cmp.Scenarios[j].Scenario
+	})
+
+	return cmp
+}
+
+// affectedPersonas returns the personas (with enough runs) whose pass rate
+// or observation rate is markedly worse than the best persona's.
+func affectedPersonas(stats []PersonaStats) []string {
+	bestPassRate, fewestObservations := 0.0, -1.0
+	for _, s := range stats {
+		if s.Runs < MinPersonaRuns {
+			continue
+		}
+		bestPassRate = max(bestPassRate, s.PassRate)
+		if fewestObservations < 0 || s.AvgObservations < fewestObservations {
+			fewestObservations = s.AvgObservations
+		}
+	}
+
+	var affected []string
+	for _, s := range stats {
+		if s.Runs < MinPersonaRuns {
+			continue
+		}
+		worsePassRate := bestPassRate-s.PassRate >= PersonaPassRateGap
+		moreObservations := s.AvgObservations-fewestObservations >= PersonaObservationGap &&
+			s.AvgObservations >= 2*fewestObservations
+		if worsePassRate || moreObservations {
+			affected = append(affected, s.Persona)
+		}
+	}
+	return affected
+}
@@ -0,0 +1,101 @@
+package batch
+
+import "sort"
+
+// RetryReport shows how much a batch's retries changed its outcome: which
+// failures they rescued, and what the pass rate would have been without
+// them. A high rescue rate means retries are masking real instability.
+type RetryReport struct {
+	// Retried is the number of scenario runs retried at least once.
+	Retried int `json:"retried"`
+
+	// Rescued is the number of retried runs that went on to pass.
+	Rescued int `json:"rescued"`
+
+	// StillFailing is the number of retried runs that failed anyway.
+	StillFailing int `json:"still_failing"`
+
+	// ByErrorType counts rescued runs by the error type of their first
+	// failed attempt.
+	ByErrorType map[string]int `json:"by_error_type,omitempty"`
+
+	// Scenarios lists the rescued runs, most retries first.
+	Scenarios []RescuedScenario `json:"scenarios,omitempty"`
+
+	// PassRate is the batch's pass rate (passed over passed, failed and
+	// errored runs).
+	PassRate float64 `json:"pass_rate"`
+
+	// PassRateWithoutRetries is the pass rate had every rescued run
+	// counted as failed on its first attempt.
+	PassRateWithoutRetries float64 `json:"pass_rate_without_retries"`
+}
+
+// RescuedScenario is a run that passed only after retrying.
+type RescuedScenario struct {
+	Scenario string `json:"scenario"`
+
+	// Model is set for A/B batches, which run each scenario once per model.
+	Model string `json:"model,omitempty"`
+
+	Retries int `json:"retries"`
+
+	// ErrorType is the error type of the first failed attempt.
+	ErrorType string `json:"error_type"`
+}
+
+// Masked is the share of runs that passed only because they were retried.
+func (r *RetryReport) Masked() float64 {
+	return r.PassRate - r.PassRateWithoutRetries
+}
+
+// BuildRetryReport summarizes the retries in a batch's results. It returns
+// nil when no run was retried.
+func BuildRetryReport(results []ScenarioResult, ab bool) *RetryReport {
+	report := &RetryReport{}
+	passed, total := 0, 0
+	for _, sr := range results {
+		switch sr.Status {
+		case StatusPassed:
+			passed++
+			total++
+		case StatusFailed, StatusError:
+			total++
+		}
+		if sr.RetryCount == 0 {
+			continue
+		}
+		report.Retried++
+		switch sr.Status {
+		case StatusPassed:
+			report.Rescued++
+			errType := "unknown"
+			if len(sr.RetryErrors) > 0 && sr.RetryErrors[0] != "" {
+				errType = categorizeError(sr.RetryErrors[0])
+			}
+			if report.ByErrorType == nil {
+				report.ByErrorType = make(map[string]int)
+			}
+			report.ByErrorType[errType]++
+			rescued := RescuedScenario{Scenario: sr.Scenario, Retries: sr.RetryCount, ErrorType: errType}
+			if ab {
+				rescued.Model = sr.Model
+			}
+			report.Scenarios = append(report.Scenarios, rescued)
+		case StatusFailed, StatusError:
+			report.StillFailing++
+		}
+	}
+	if report.Retried == 0 {
+		return nil
+	}
+
+	sort.SliceStable(report.Scenarios, func(i, j int) bool {
+		return report.Scenarios[i].Retries > report.Scenarios[j].Retries
+	})
+	if total > 0 {
+		report.PassRate = float64(passed) / float64(total)
+		report.PassRateWithoutRetries = float64(passed-report.Rescued) / float64(total)
+	}
+	return report
+}
@@ -0,0 +1,61 @@
+package batch
+
+import "testing"
+
+func TestBuildRetryReport(t *testing.T) {
+	results := []ScenarioResult{
+		{Scenario: "signup", Status: StatusPassed},
+		{Scenario: "checkout", Status: StatusPassed, RetryCount: 2, RetryErrors: []string{"timeout waiting for page", "timeout waiting for page"}},
+		{Scenario: "login", Status: StatusPassed, RetryCount: 1, RetryErrors: []string{"browser crashed"}},
+		{Scenario: "search", Status: StatusPassed, RetryCount: 1},
+		{Scenario: "profile", Status: StatusFailed, RetryCount: 2, RetryErrors: []string{"timeout"}},
+		{Scenario: "cart", Status: StatusSkipped},
+	}
+
+	rr := BuildRetryReport(results, false)
+	if rr == nil {
+		t.Fatal("BuildRetryReport() = nil, want a report")
+	}
+	if rr.Retried != 4 || rr.Rescued != 3 || rr.StillFailing != 1 {
+		t.Errorf("retried/rescued/still failing = %d/%d/%d, want 4/3/1", rr.Retried, rr.Rescued, rr.StillFailing)
+	}
+	want := map[string]int{"timeout": 1, "browser_crash": 1, "unknown": 1}
+	for typ, n := range want {
+		if rr.ByErrorType[typ] != n {
+			t.Errorf("ByErrorType[%s] = %d, want %d (got %v)", typ, rr.ByErrorType[typ], n, rr.ByErrorType)
+		}
+	}
+	if len(rr.Scenarios) != 3 || rr.Scenarios[0].Scenario != "checkout" || rr.Scenarios[0].Retries != 2 {
+		t.Errorf("Scenarios = %+v, want checkout (most retries) first", rr.Scenarios)
+	}
+
+	// 4 of 5 decided runs passed; without retries only signup would have
+	if rr.PassRate != 0.8 || rr.PassRateWithoutRetries != 0.2 {
+		t.Errorf("pass rate = %v with, %v without; want 0.8, 0.2", rr.PassRate, rr.PassRateWithoutRetries)
+	}
+	if masked := rr.Masked(); masked < 0.599 || masked > 0.601 {
+		t.Errorf("Masked() = %v, want 0.6", masked)
+	}
+}
+
+func TestBuildRetryReport_NoRetries(t *testing.T) {
+	results := []ScenarioResult{
+		{Scenario: "signup", Status: StatusPassed},
+		{Scenario: "checkout", Status: StatusFailed},
+	}
+	if rr := BuildRetryReport(results, false); rr != nil {
+		t.Errorf("BuildRetryReport() = %+v, want nil without retries", rr)
+	}
+}
+
+func TestBuildRetryReport_ABModel(t *testing.T) {
+	results := []ScenarioResult{
+		{Scenario: "signup", Model: "haiku", Status: StatusPassed, RetryCount: 1},
+	}
+	if rr := BuildRetryReport(results, true); rr.Scenarios[0].Model != "haiku" {
+		t.Errorf("A/B rescued scenario model = %q, want haiku", rr.Scenarios[0].Model)
+	}
+	if rr := BuildRetryReport(results, false); rr.Scenarios[0].Model != "" {
+		t.Errorf("non-A/B rescued scenario model = %q, want empty", rr.Scenarios[0].Model)
+	}
+}
@@ -0,0 +1,138 @@
+package batch
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/tester"
+)
+
+// PersonaRotationFileName is the persona rotation state stored in the
+// results directory.
+const PersonaRotationFileName = ".persona-rotation.json"
+
+// PersonaRotation tracks, per scenario, which persona in the scenario's
+// persona_pool runs next. Each batch run with RotatePersonas picks the
+// current persona and advances past it once the scenario has run, so
+// scheduled batches cycle through the pool.
+type PersonaRotation struct {
+	path string
+	next map[string]int
+}
+
+// LoadPersonaRotation loads the rotation state from a results directory.
+func LoadPersonaRotation(outputDir string) (*PersonaRotation, error) {
+	rotation := &PersonaRotation{
+		path: filepath.Join(outputDir, PersonaRotationFileName),
+		next: make(map[string]int),
+	}
+
+	data, err := os.ReadFile(rotation.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return rotation, nil
+		}
+		return nil, fmt.Errorf("failed to read persona rotation: %w", err)
+	}
+	if err := json.Unmarshal(data, &rotation.next); err != nil {
+		return nil, fmt.Errorf("failed to parse persona rotation: %w", err)
+	}
+
+	return rotation, nil
+}
+
+// Pick returns the persona a scenario runs as this batch. A pool that has
+// changed size since the last batch wraps around rather than restarting.
+func (p *PersonaRotation) Pick(scenario string, pool []string) string {
+	if len(pool) == 0 {
+		return ""
+	}
+	return pool[p.next[scenario]%len(pool)]
+}
+
+// Advance moves a scenario on to the next persona in its pool.
+func (p *PersonaRotation) Advance(scenario string) {
+	p.next[scenario]++
+}
+
+// Save writes the rotation state.
+func (p *PersonaRotation) Save() error {
+	data, err := json.MarshalIndent(p.next, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(p.path), 0755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(p.path, data, 0644)
+}
+
+// pickPersonas chooses this batch's persona for each scenario with a
+// persona pool, so every run of a scenario (all A/B models included) uses
+// the same one.
+func (r *Runner) pickPersonas(scenarios []string) error {
+	r.personas = nil
+	r.rotation = nil
+	if !r.config.RotatePersonas {
+		return nil
+	}
+
+	rotation, err := LoadPersonaRotation(r.baseDir)
+	if err != nil {
+		return err
+	}
+	r.rotation = rotation
+	r.personas = make(map[string]string)
+	for _, s := range scenarios {
+		scenario, err := tester.ParseScenarioFile(s)
+		if err != nil || len(scenario.PersonaPool) == 0 {
+			continue
+		}
+		r.personas[s] = rotation.Pick(scenarioName(s), scenario.PersonaPool)
+	}
+	return nil
+}
+
+// advancePersonas moves each rotated scenario that ran on to its next
+// persona. Scenarios that didn't run (aborted, skipped) keep theirs for
+// the next batch.
+func (r *Runner) advancePersonas(results []ScenarioResult) error {
+	if r.rotation == nil || len(r.personas) == 0 {
+		return nil
+	}
+
+	advanced := make(map[string]bool)
+	for _, sr := range results {
+		if _, rotated := r.personas[sr.Path]; !rotated || advanced[sr.Path] || !ran(sr.Status) {
+			continue
+		}
+		advanced[sr.Path] = true
+		r.rotation.Advance(scenarioName(sr.Path))
+	}
+	if len(advanced) == 0 {
+		return nil
+	}
+	return r.rotation.Save()
+}
+
+// scenarioPersona returns the persona a scenario runs as: its rotation
+// pick, or the scenario's own persona.
+func (r *Runner) scenarioPersona(scenarioPath string, scenario *tester.ScenarioConfig) string {
+	if persona, ok := r.personas[scenarioPath]; ok {
+		return persona
+	}
+	if scenario != nil {
+		return scenario.Persona
+	}
+	return ""
+}
+
+// scenarioName is a scenario's name: its file name without extension.
+func scenarioName(scenarioPath string) string {
+	return strings.TrimSuffix(filepath.Base(scenarioPath), filepath.Ext(scenarioPath))
+}
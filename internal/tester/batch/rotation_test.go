@@ -0,0 +1,125 @@
+package batch
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const rotationScenario = `
+scenario: %s
+persona: sarah
+%s
+goal: Buy a gift card
+success_criteria:
+  - Order confirmed
+environment:
+  url: https://staging.example.com
+`
+
+func TestRotatePersonas(t *testing.T) {
+	tmpDir := t.TempDir()
+	for name, pool := range map[string]string{"checkout": "persona_pool: [sarah, miguel, jo]", "login": ""} {
+		content := fmt.Sprintf(rotationScenario, name, pool)
+		if err := os.WriteFile(filepath.Join(tmpDir, name+".yaml"), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	config := DefaultConfig()
+	config.OutputDir = tmpDir
+	config.Pattern = filepath.Join(tmpDir, "*.yaml")
+	config.SkipPreflight = true
+	config.RotatePersonas = true
+
+	var got []string
+	for i := 0; i < 4; i++ {
+		runner, err := NewRunner(config)
+		if err != nil {
+			t.Fatalf("failed to create runner: %v", err)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		result, err := runner.Run(ctx)
+		cancel()
+		if err != nil {
+			t.Fatalf("batch run failed: %v", err)
+		}
+		for _, sr := range result.Results {
+			switch sr.Scenario {
+			case "checkout":
+				got = append(got, sr.Persona)
+			case "login":
+				if sr.Persona != "sarah" {
+					t.Errorf("login ran as %q, want its own persona", sr.Persona)
+				}
+			}
+		}
+	}
+
+	want := []string{"sarah", "miguel", "jo", "sarah"}
+	if len(got) != len(want) {
+		t.Fatalf("checkout personas = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("checkout personas = %v, want %v", got, want)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, PersonaRotationFileName)); err != nil {
+		t.Errorf("rotation state not saved: %v", err)
+	}
+}
+
+func TestPersonaRotationPickWraps(t *testing.T) {
+	rotation, err := LoadPersonaRotation(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 5; i++ {
+		rotation.Advance("checkout")
+	}
+	if got := rotation.Pick("checkout", []string{"sarah", "miguel"}); got != "miguel" {
+		t.Errorf("Pick after 5 advances = %q, want miguel", got)
+	}
+	if got := rotation.Pick("checkout", nil); got != "" {
+		t.Errorf("Pick with no pool = %q, want empty", got)
+	}
+}
+
+func TestComparePersonas(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 10, d, 2, 0, 0, 0, time.UTC) }
+	run := func(persona string, status RunStatus, observations int) ScenarioResult {
+		return ScenarioResult{Scenario: "checkout", Persona: persona, Status: status, Observations: map[string]int{"P2": observations}}
+	}
+	batches := []*BatchResult{
+		{ID: "b4", StartedAt: day(4), Results: []ScenarioResult{run("miguel", StatusFailed, 3), {Scenario: "login", Persona: "sarah", Status: StatusPassed}}},
+		{ID: "b3", StartedAt: day(3), Results: []ScenarioResult{run("sarah", StatusPassed, 0)}},
+		{ID: "b2", StartedAt: day(2), Results: []ScenarioResult{run("miguel", StatusPassed, 2), run("jo", StatusPassed, 1)}},
+		{ID: "b1", StartedAt: day(1), Results: []ScenarioResult{run("sarah", StatusPassed, 1), run("miguel", StatusAborted, 0)}},
+	}
+
+	cmp := ComparePersonas(batches)
+	if !cmp.Since.Equal(day(1)) || !cmp.Until.Equal(day(4)) {
+		t.Errorf("window = %v to %v", cmp.Since, cmp.Until)
+	}
+	// login only ever ran as one persona
+	if len(cmp.Scenarios) != 1 || cmp.Scenarios[0].Scenario != "checkout" {
+		t.Fatalf("Scenarios = %+v, want only checkout", cmp.Scenarios)
+	}
+
+	checkout := cmp.Scenarios[0]
+	if len(checkout.Personas) != 3 || checkout.Personas[1].Persona != "miguel" {
+		t.Fatalf("Personas = %+v, want jo, miguel, sarah", checkout.Personas)
+	}
+	miguel := checkout.Personas[1]
+	if miguel.Runs != 2 || miguel.Passed != 1 || miguel.AvgObservations != 2.5 || !miguel.LastRun.Equal(day(4)) {
+		t.Errorf("miguel = %+v", miguel)
+	}
+	// jo has too few runs to be judged
+	if len(checkout.Affected) != 1 || checkout.Affected[0] != "miguel" || cmp.Affected != 1 {
+		t.Errorf("Affected = %v (%d scenarios), want [miguel]", checkout.Affected, cmp.Affected)
+	}
+}
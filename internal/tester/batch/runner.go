@@ -12,6 +12,8 @@ import (
 	"sync"
 	"time"
 
+	"github.com/steveyegge/gastown/internal/tester"
+	"github.com/steveyegge/gastown/internal/tester/artifacts"
 	"github.com/steveyegge/gastown/internal/tester/flake"
 )
 
@@ -34,6 +36,39 @@ type Runner struct {
 
 	// quarantineActions collects actions taken during the batch.
 	quarantineActions []flake.QuarantineAction
+
+	// source supplies scenarios (nil means derive from config).
+	source ScenarioSource
+
+	// refs maps scenario paths to their source entries (set during Run).
+	refs map[string]ScenarioRef
+
+	// refModels lists the models each scenario's source entries ask for, in
+	// order (set during Run). A source lists a scenario more than once only
+	// to run it under several models, as when resuming an A/B batch.
+	refModels map[string][]string
+
+	// uploader stores run artifacts remotely (nil if upload is disabled).
+	uploader artifacts.Uploader
+
+	// warmUpProbe checks a scenario's target before it runs (WarmUp).
+	warmUpProbe WarmUpProbe
+
+	// warmUpDelay is the minimum wait before re-probing a deferred scenario.
+	warmUpDelay time.Duration
+
+	// signer signs the batch manifest and run results (nil if disabled).
+	signer *tester.Signer
+
+	// heartbeat maintains the batch's liveness file (set during Run).
+	heartbeat *heartbeatWriter
+
+	// rotation is the persona rotation state (set during Run with
+	// RotatePersonas).
+	rotation *PersonaRotation
+
+	// personas maps scenario paths to their rotated persona for this batch.
+	personas map[string]string
 }
 
 // NewRunner creates a new batch runner.
@@ -43,24 +78,71 @@ func NewRunner(config Config) (*Runner, error) {
 		return nil, fmt.Errorf("failed to create quarantine store: %w", err)
 	}
 
-	// Initialize flake detector with default config
-	flakeConfig := flake.DefaultConfig()
+	// Initialize flake detector (defaults unless the results dir has a config)
+	flakeConfig, err := flake.LoadConfig(filepath.Join(config.OutputDir, flake.ConfigFileName))
+	if err != nil {
+		return nil, err
+	}
 	detector, err := flake.NewDetector(
-		filepath.Join(config.OutputDir, ".flake-data.json"),
+		filepath.Join(config.OutputDir, flake.DataFileName),
 		flakeConfig,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create flake detector: %w", err)
 	}
 
+	var uploader artifacts.Uploader
+	if config.Upload != "" {
+		uploader, err = artifacts.NewUploader(config.Upload)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var signer *tester.Signer
+	if config.Sign != "" {
+		signer, err = tester.NewSigner(config.Sign, config.SigningKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &Runner{
 		config:          config,
 		quarantineStore: store,
 		flakeDetector:   detector,
 		baseDir:         config.OutputDir,
+		uploader:        uploader,
+		warmUpProbe:     HTTPWarmUpProbe,
+		warmUpDelay:     DefaultWarmUpDelay,
+		signer:          signer,
 	}, nil
 }
 
+// SetUploader overrides the artifact uploader derived from the config.
+func (r *Runner) SetUploader(uploader artifacts.Uploader) {
+	r.uploader = uploader
+}
+
+// SetBeadStore sets where the flake detector files tracking beads for
+// quarantined and flagged scenarios (flake config file_beads).
+func (r *Runner) SetBeadStore(store flake.BeadStore) {
+	r.flakeDetector.SetBeadStore(store)
+}
+
+// SetSource overrides the scenario source derived from the config.
+func (r *Runner) SetSource(source ScenarioSource) {
+	r.source = source
+}
+
+// Source returns the scenario source used for the batch.
+func (r *Runner) Source() ScenarioSource {
+	if r.source != nil {
+		return r.source
+	}
+	return NewScenarioSource(r.config)
+}
+
 // Run executes the batch and returns the results.
 func (r *Runner) Run(ctx context.Context) (*BatchResult, error) {
 	r.batchID = generateBatchID()
@@ -85,6 +167,14 @@ func (r *Runner) Run(ctx context.Context) (*BatchResult, error) {
 	// Filter scenarios
 	filtered := r.filterScenarios(scenarios)
 
+	// Narrow to scenarios affected by changed files
+	if r.config.OnlyChanged != "" {
+		filtered, result.Changes, err = r.selectChanged(filtered)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Separate quarantined from runnable
 	// Check both legacy store and new flake detector
 	var runnable []string
@@ -105,18 +195,24 @@ func (r *Runner) Run(ctx context.Context) (*BatchResult, error) {
 				Status:      StatusSkipped,
 				Quarantined: true,
 				SkipReason:  skipReason,
+				Flake:       r.flakeMetrics(name),
 			})
 		} else {
 			runnable = append(runnable, s)
 		}
 	}
 
-	result.ScenariosRun = len(runnable)
+	runs, _ := r.expandRuns(runnable)
+	result.ScenariosRun = len(runs)
+	if err := r.pickPersonas(runnable); err != nil {
+		return nil, err
+	}
 	result.ScenariosSkipped = len(skipped)
 	result.Results = append(result.Results, skipped...)
 
-	// Run preflight if not skipped
-	if !r.config.SkipPreflight {
+	// Run preflight if not skipped (and there is something to run when
+	// selecting by changes, so an unaffected diff stays cheap)
+	if !r.config.SkipPreflight && (result.Changes == nil || len(runnable) > 0) {
 		preflight := r.runPreflight()
 		if !preflight.Passed {
 			return nil, fmt.Errorf("preflight checks failed")
@@ -127,22 +223,53 @@ func (r *Runner) Run(ctx context.Context) (*BatchResult, error) {
 	batchDir := r.createBatchDir(result.ID)
 	result.OutputDir = batchDir
 
+	// Keep a heartbeat until the manifest is saved, so supervisors can spot
+	// a hung or dead batch
+	r.heartbeat = r.startHeartbeat(result)
+	defer func() {
+		r.heartbeat.close()
+		r.heartbeat = nil
+	}()
+
 	// Run scenarios
 	results := r.runScenarios(ctx, runnable)
 	result.Results = append(result.Results, results...)
+	if err := r.advancePersonas(results); err != nil {
+		fmt.Printf("Warning: failed to save persona rotation: %v\n", err)
+	}
 
 	// Calculate summary
 	r.calculateSummary(result)
+	if len(r.config.ABModels) > 0 {
+		result.AB = CompareModels(result.Results, r.config.ABModels)
+	}
+	result.QuarantineImpact = r.quarantineImpact(result, time.Now())
 
-	// Complete the result
+	// Complete the result. An interrupted batch still gets a (partial)
+	// manifest so finished scenarios aren't lost and the rest can be resumed.
 	now := time.Now()
-	result.CompletedAt = &now
 	result.TotalDuration = now.Sub(result.StartedAt)
+	if result.Summary.Aborted > 0 {
+		result.Interrupted = true
+	} else {
+		result.CompletedAt = &now
+	}
 
 	// Save batch manifest
 	if err := r.saveBatchManifest(result); err != nil {
 		return result, fmt.Errorf("failed to save manifest: %w", err)
 	}
+	if r.config.Format == FormatJUnit {
+		if err := r.saveJUnitReport(result); err != nil {
+			return result, fmt.Errorf("failed to save JUnit report: %w", err)
+		}
+	}
+	if err := r.signResults(result); err != nil {
+		return result, fmt.Errorf("failed to sign results: %w", err)
+	}
+	if result.Interrupted {
+		return result, nil
+	}
 
 	// Compare to baseline if requested
 	if r.config.CompareTo != "" {
@@ -155,28 +282,39 @@ func (r *Runner) Run(ctx context.Context) (*BatchResult, error) {
 		}
 	}
 
+	// Compare to recent batches if requested
+	if r.config.CompareWindow > 0 {
+		window, err := r.LoadWindow(result.ID, r.config.CompareWindow)
+		if err != nil {
+			fmt.Printf("Warning: failed to load recent batches: %v\n", err)
+		} else if len(window) > 0 {
+			result.Trend = CompareTrend(result, window, r.config.CompareWindow)
+		}
+	}
+
 	return result, nil
 }
 
-// findScenarios finds all scenario files matching the pattern.
+// findScenarios resolves scenario files from the batch's source. A
+// scenario listed more than once is returned once, with the model of each
+// listing kept for expandRuns.
 func (r *Runner) findScenarios() ([]string, error) {
-	matches, err := filepath.Glob(r.config.Pattern)
+	refs, err := r.Source().Scenarios()
 	if err != nil {
 		return nil, err
 	}
 
-	// Filter to only .yaml and .yml files
-	var scenarios []string
-	for _, m := range matches {
-		ext := strings.ToLower(filepath.Ext(m))
-		if ext == ".yaml" || ext == ".yml" {
-			scenarios = append(scenarios, m)
+	r.refs = make(map[string]ScenarioRef, len(refs))
+	r.refModels = make(map[string][]string, len(refs))
+	scenarios := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		if _, ok := r.refs[ref.Path]; !ok {
+			r.refs[ref.Path] = ref
+			scenarios = append(scenarios, ref.Path)
 		}
+		r.refModels[ref.Path] = append(r.refModels[ref.Path], ref.Model)
 	}
 
-	// Sort for consistent ordering
-	sort.Strings(scenarios)
-
 	return scenarios, nil
 }
 
@@ -225,6 +363,9 @@ func (r *Runner) extractTags(scenarioPath string) []string {
 		}
 	}
 
+	// Tags declared by the source (e.g., manifest entries)
+	tags = append(tags, r.refs[scenarioPath].Tags...)
+
 	return tags
 }
 
@@ -265,8 +406,10 @@ func (r *Runner) runPreflight() *PreflightResult {
 	return result
 }
 
-// runScenarios runs all scenarios with the configured parallelism.
-func (r *Runner) runScenarios(ctx context.Context, scenarios []string) []ScenarioResult {
+// runScenarios runs all scenarios with the configured parallelism, once
+// per A/B model if the batch compares models.
+func (r *Runner) runScenarios(ctx context.Context, paths []string) []ScenarioResult {
+	scenarios, models := r.expandRuns(paths)
 	if len(scenarios) == 0 {
 		return nil
 	}
@@ -280,39 +423,81 @@ func (r *Runner) runScenarios(ctx context.Context, scenarios []string) []Scenari
 		parallel = len(scenarios)
 	}
 
-	// Use a channel to distribute work
+	// Use a channel to distribute work. Scenarios deferred by the warm-up
+	// probe go back on the queue, so it is closed once every scenario has a
+	// result rather than up front.
 	work := make(chan int, len(scenarios))
 	for i := range scenarios {
 		work <- i
 	}
-	close(work)
+	deferrals := make([]int, len(scenarios))
+	deferredAt := make([]time.Time, len(scenarios))
+	r.heartbeat.setRuns(scenarios, models, r.refs)
 
 	// Run workers
 	var wg sync.WaitGroup
 	var mu sync.Mutex
 	stopFlag := false
+	remaining := len(scenarios)
+
+	done := func(idx int, result ScenarioResult) {
+		results[idx] = result
+		r.heartbeat.end(idx)
+		mu.Lock()
+		remaining--
+		if remaining == 0 {
+			close(work)
+		}
+		mu.Unlock()
+	}
 
 	for w := 0; w < parallel; w++ {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
 			for idx := range work {
+				// Don't start new scenarios once the batch is interrupted
+				if ctx.Err() != nil {
+					done(idx, r.abortedResult(scenarios[idx], models[idx]))
+					continue
+				}
+
 				mu.Lock()
 				if stopFlag {
 					mu.Unlock()
 					// Mark remaining as skipped
-					results[idx] = ScenarioResult{
+					done(idx, ScenarioResult{
 						Scenario:   filepath.Base(scenarios[idx]),
 						Path:       scenarios[idx],
 						Status:     StatusSkipped,
+						Model:      models[idx],
 						SkipReason: "batch stopped on failure",
-					}
+					})
 					continue
 				}
 				mu.Unlock()
 
-				result := r.runSingleScenario(ctx, scenarios[idx])
-				results[idx] = result
+				if r.config.WarmUp {
+					if err := r.warmUp(ctx, scenarios[idx], deferredAt[idx]); err != nil {
+						switch {
+						case ctx.Err() != nil:
+							done(idx, r.abortedResult(scenarios[idx], models[idx]))
+						case deferrals[idx] < r.maxWarmUpDeferrals():
+							deferrals[idx]++
+							deferredAt[idx] = time.Now()
+							fmt.Printf("Deferring %s: target unavailable (%v)\n", filepath.Base(scenarios[idx]), err)
+							work <- idx
+						default:
+							done(idx, r.unavailableResult(scenarios[idx], models[idx], deferrals[idx], err))
+						}
+						continue
+					}
+				}
+
+				r.heartbeat.begin(idx)
+				result := r.runSingleScenario(ctx, scenarios[idx], models[idx])
+				result.WarmUpDeferrals = deferrals[idx]
+				done(idx, result)
 
 				if r.config.StopOnFail && (result.Status == StatusFailed || result.Status == StatusError) {
 					mu.Lock()
@@ -327,8 +512,59 @@ func (r *Runner) runScenarios(ctx context.Context, scenarios []string) []Scenari
 	return results
 }
 
-// runSingleScenario runs a single scenario.
-func (r *Runner) runSingleScenario(ctx context.Context, scenarioPath string) ScenarioResult {
+// expandRuns lists the runs for scenarios with the model of each: one run
+// per source listing of a scenario with its own model, or one per A/B
+// model.
+func (r *Runner) expandRuns(scenarios []string) (paths, models []string) {
+	for _, s := range scenarios {
+		if len(r.config.ABModels) == 0 {
+			for _, model := range r.scenarioModels(s) {
+				paths = append(paths, s)
+				models = append(models, model)
+			}
+			continue
+		}
+		for _, model := range r.config.ABModels {
+			paths = append(paths, s)
+			models = append(models, model)
+		}
+	}
+	return paths, models
+}
+
+// scenarioModels returns the models a scenario runs with: each source
+// listing's override, or the batch model.
+func (r *Runner) scenarioModels(scenarioPath string) []string {
+	listed := r.refModels[scenarioPath]
+	if len(listed) == 0 {
+		return []string{r.config.Model}
+	}
+	models := make([]string, len(listed))
+	for i, model := range listed {
+		if model == "" {
+			model = r.config.Model
+		}
+		models[i] = model
+	}
+	return models
+}
+
+// abortedResult is the result for a scenario cut short by an interrupt.
+func (r *Runner) abortedResult(scenarioPath, model string) ScenarioResult {
+	ref := r.refs[scenarioPath]
+	return ScenarioResult{
+		Scenario:    strings.TrimSuffix(filepath.Base(scenarioPath), filepath.Ext(scenarioPath)),
+		Path:        scenarioPath,
+		Status:      StatusAborted,
+		Model:       model,
+		Environment: ref.Environment,
+		MaxAttempts: ref.MaxAttempts,
+		SkipReason:  "batch interrupted",
+	}
+}
+
+// runSingleScenario runs a single scenario with model.
+func (r *Runner) runSingleScenario(ctx context.Context, scenarioPath, model string) ScenarioResult {
 	start := time.Now()
 	name := strings.TrimSuffix(filepath.Base(scenarioPath), filepath.Ext(scenarioPath))
 
@@ -336,18 +572,55 @@ func (r *Runner) runSingleScenario(ctx context.Context, scenarioPath string) Sce
 		Scenario:     name,
 		Path:         scenarioPath,
 		Status:       StatusRunning,
+		Model:        model,
 		Observations: make(map[string]int),
 	}
+	if ref, ok := r.refs[scenarioPath]; ok {
+		result.Environment = ref.Environment
+		result.MaxAttempts = ref.MaxAttempts
+	}
+	scenario, _ := tester.ParseScenarioFile(scenarioPath)
+	if scenario != nil {
+		result.Network = scenario.Network.Describe()
+	}
+	result.Persona = r.scenarioPersona(scenarioPath, scenario)
 
-	// Check for context cancellation
+	// Check for context cancellation. Aborted runs are not recorded with the
+	// flake detector: an interrupt says nothing about the scenario.
 	select {
 	case <-ctx.Done():
-		result.Status = StatusSkipped
-		result.SkipReason = "context cancelled"
+		aborted := r.abortedResult(scenarioPath, model)
+		aborted.Duration = time.Since(start)
+		return aborted
+	default:
+	}
+
+	// The run's artifact directory
+	dateDir := time.Now().Format("2006-01-02")
+	runID := newRunID()
+	result.ArtifactDir = filepath.Join(r.baseDir, dateDir, name, fmt.Sprintf("run-%s", runID))
+
+	// Each run gets its own browser profile, so parallel runs against the
+	// same app don't share cookies or localStorage. The run's .mcp.json
+	// starts the browser in it.
+	var fixture string
+	if scenario != nil {
+		fixture = scenario.Environment.ProfileFixture
+	}
+	profile, err := tester.NewBrowserProfile(fixture)
+	if err == nil {
+		defer func() {
+			if err := profile.Remove(); err != nil {
+				fmt.Printf("Warning: failed to remove browser profile for %s: %v\n", name, err)
+			}
+		}()
+		err = writeRunMCPConfig(result.ArtifactDir, profile)
+	}
+	if err != nil {
+		result.Status = StatusError
+		result.Error = err.Error()
 		result.Duration = time.Since(start)
-		// Record skip as a run outcome (but don't count against flake rate)
 		return result
-	default:
 	}
 
 	// Simulate running the scenario
@@ -357,17 +630,112 @@ func (r *Runner) runSingleScenario(ctx context.Context, scenarioPath string) Sce
 	result.SuccessCriteriaMet = 3
 	result.SuccessCriteriaTotal = 3
 
-	// Create artifact directory
-	dateDir := time.Now().Format("2006-01-02")
-	runID := generateRunID()
-	result.ArtifactDir = filepath.Join(r.baseDir, dateDir, name, fmt.Sprintf("run-%s", runID))
+	if r.config.A11y {
+		r.mergeA11yFindings(&result)
+	}
 
-	// Record the run outcome with the flake detector
-	r.recordRunOutcome(name, result)
+	// Attempts that failed and were retried, for the retry report
+	if attempts, err := tester.LoadRetryLog(result.ArtifactDir); err != nil {
+		fmt.Printf("Warning: failed to read retry log for %s: %v\n", name, err)
+	} else {
+		for _, a := range attempts {
+			result.RetryCount++
+			result.RetryErrors = append(result.RetryErrors, a.Error)
+		}
+	}
+
+	// A matched abort_if condition (maintenance page, rate limiting) says
+	// nothing about the scenario
+	if signal, err := tester.LoadAbortSignal(result.ArtifactDir); err != nil {
+		fmt.Printf("Warning: failed to read abort signal for %s: %v\n", name, err)
+	} else if signal != nil {
+		result.Status = StatusAborted
+		result.SkipReason = signal.Reason()
+	}
+
+	// Observations over the scenario's severity budget fail a pass
+	if scenario != nil && result.Status == StatusPassed {
+		if exceeded := scenario.CheckSeverityBudget(result.Observations); len(exceeded) > 0 {
+			result.Status = StatusFailed
+			result.BudgetExceeded = exceeded
+			result.Error = "severity budget exceeded: " + strings.Join(exceeded, ", ")
+		}
+	}
+
+	// Sign after the upload, which rewrites the run's manifest with the
+	// remote URLs
+	r.uploadArtifacts(ctx, &result)
+	if r.signer != nil && result.ArtifactDir != "" {
+		if _, err := r.signer.SignResults(result.ArtifactDir); err != nil {
+			fmt.Printf("Warning: failed to sign results for %s: %v\n", name, err)
+		}
+	}
+
+	// Record the run outcome with the flake detector. A/B runs differ by
+	// model on purpose, so they would read as flakiness.
+	if len(r.config.ABModels) == 0 {
+		r.recordRunOutcome(name, result)
+	}
+	result.Flake = r.flakeMetrics(name)
 
 	return result
 }
 
+// writeRunMCPConfig writes the run's .mcp.json, pointing Playwright MCP at
+// the run's browser profile and recording into the run directory.
+func writeRunMCPConfig(runDir string, profile *tester.BrowserProfile) error {
+	if err := os.MkdirAll(runDir, 0755); err != nil {
+		return fmt.Errorf("creating run directory: %w", err)
+	}
+	pw := *tester.DefaultConfig().Playwright
+	pw.Profile = profile
+	_, err := tester.EnsureMCPConfig(runDir, runDir, &pw)
+	return err
+}
+
+// uploadArtifacts uploads a scenario's recorded artifacts when upload is
+// enabled and rewrites its manifest to point at the remote copies. Runs
+// without an artifact manifest are skipped. Upload failures are logged but
+// don't fail the scenario; the local artifacts are kept in that case.
+func (r *Runner) uploadArtifacts(ctx context.Context, result *ScenarioResult) {
+	if r.uploader == nil || result.ArtifactDir == "" {
+		return
+	}
+	if _, err := os.Stat(filepath.Join(result.ArtifactDir, "manifest.json")); err != nil {
+		return
+	}
+
+	mgr, err := artifacts.NewManager(r.baseDir)
+	if err != nil {
+		fmt.Printf("Warning: failed to upload artifacts for %s: %v\n", result.Scenario, err)
+		return
+	}
+	url, err := mgr.UploadRun(ctx, result.ArtifactDir, r.uploader, !r.config.KeepLocalArtifacts)
+	if err != nil {
+		fmt.Printf("Warning: failed to upload artifacts for %s: %v\n", result.Scenario, err)
+		return
+	}
+	result.ArtifactURL = url
+}
+
+// mergeA11yFindings counts the run's axe-core violations into its
+// observations. Unreadable scans are reported but don't fail the scenario.
+func (r *Runner) mergeA11yFindings(result *ScenarioResult) {
+	findings, err := tester.LoadA11yFindings(result.ArtifactDir)
+	if err != nil {
+		fmt.Printf("Warning: failed to read accessibility scans for %s: %v\n", result.Scenario, err)
+		return
+	}
+	for _, f := range findings {
+		result.Observations[string(f.Severity)]++
+		result.A11yViolations++
+		if result.A11yRules == nil {
+			result.A11yRules = make(map[string]int)
+		}
+		result.A11yRules[f.Rule]++
+	}
+}
+
 // recordRunOutcome records a scenario run with the flake detector.
 func (r *Runner) recordRunOutcome(scenario string, result ScenarioResult) {
 	// Convert batch status to flake outcome
@@ -387,6 +755,9 @@ func (r *Runner) recordRunOutcome(scenario string, result ScenarioResult) {
 		}
 	case StatusSkipped:
 		outcome = flake.OutcomeSkip
+	case StatusAborted:
+		// Aborted runs never count toward the flake rate
+		return
 	default:
 		outcome = flake.OutcomeError
 	}
@@ -399,14 +770,23 @@ func (r *Runner) recordRunOutcome(scenario string, result ScenarioResult) {
 		BatchID:             r.batchID,
 		ErrorType:           categorizeError(result.Error),
 		InfrastructureError: isInfraError,
+		Tags:                r.extractTags(result.Path),
+		BuildSHA:            r.config.BuildSHA,
+		AppVersion:          r.config.AppVersion,
 	}
+	if outcome == flake.OutcomeFail || outcome == flake.OutcomeError {
+		record.Excerpt = failureExcerpt(result.Error)
+	}
+
+	// Scenarios may carry their own flake policy; unparseable files use
+	// the batch config
+	r.flakeDetector.SetPolicy(scenario, scenarioFlakePolicy(result.Path))
 
 	// Record and collect any quarantine actions
 	actions, err := r.flakeDetector.RecordRun(scenario, record)
 	if err != nil {
 		// Log but don't fail the run
 		fmt.Printf("Warning: failed to record run for %s: %v\n", scenario, err)
-		return
 	}
 
 	// Collect actions for summary
@@ -415,6 +795,42 @@ func (r *Runner) recordRunOutcome(scenario string, result ScenarioResult) {
 	}
 }
 
+// maxExcerpt is the longest failure excerpt kept in flake history.
+const maxExcerpt = 500
+
+// failureExcerpt trims a failure message for flake history.
+func failureExcerpt(msg string) string {
+	msg = strings.TrimSpace(msg)
+	if len(msg) <= maxExcerpt {
+		return msg
+	}
+	return strings.TrimSpace(msg[:maxExcerpt]) + "..."
+}
+
+// flakeMetrics snapshots a scenario's flake metrics for the manifest. It
+// returns nil if the scenario has no run history.
+func (r *Runner) flakeMetrics(scenario string) *flake.FlakeMetrics {
+	m := r.flakeDetector.GetMetrics(scenario)
+	if m == nil || m.WindowRuns == 0 {
+		return nil
+	}
+	return m
+}
+
+// scenarioFlakePolicy returns the flake_policy declared in a scenario file,
+// or nil if it has none.
+func scenarioFlakePolicy(path string) *flake.Policy {
+	sc, err := tester.ParseScenarioFile(path)
+	if err != nil || sc.FlakePolicy == nil {
+		return nil
+	}
+	return &flake.Policy{
+		WindowSize:     sc.FlakePolicy.Window,
+		FlakeThreshold: sc.FlakePolicy.Threshold,
+		AutoQuarantine: sc.FlakePolicy.AutoQuarantine,
+	}
+}
+
 // isInfrastructureError checks if an error is infrastructure-related.
 func isInfrastructureError(errMsg string) bool {
 	infraPatterns := []string{
@@ -426,6 +842,7 @@ func isInfrastructureError(errMsg string) bool {
 		"playwright",
 		"chromium",
 		"failed to launch",
+		tester.LimitErrorPrefix,
 	}
 	lower := strings.ToLower(errMsg)
 	for _, pattern := range infraPatterns {
@@ -443,6 +860,8 @@ func categorizeError(errMsg string) string {
 	}
 	lower := strings.ToLower(errMsg)
 	switch {
+	case strings.Contains(lower, tester.LimitErrorPrefix):
+		return "resource_limit"
 	case strings.Contains(lower, "timeout"):
 		return "timeout"
 	case strings.Contains(lower, "browser") || strings.Contains(lower, "chromium"):
@@ -466,21 +885,38 @@ func (r *Runner) calculateSummary(result *BatchResult) {
 			result.Summary.Failed++
 		case StatusError:
 			result.Summary.Errors++
+			if sr.Error != "" && isInfrastructureError(sr.Error) {
+				result.Summary.InfraErrors++
+			}
 		case StatusSkipped:
 			result.Summary.Skipped++
+		case StatusAborted:
+			result.Summary.Aborted++
 		}
 
 		result.Summary.TotalRetries += sr.RetryCount
+		result.Summary.WarmUpDeferrals += sr.WarmUpDeferrals
+
+		result.Summary.A11yViolations += sr.A11yViolations
+		for rule, count := range sr.A11yRules {
+			if result.Summary.A11yRules == nil {
+				result.Summary.A11yRules = make(map[string]int)
+			}
+			result.Summary.A11yRules[rule] += count
+		}
 
 		for severity, count := range sr.Observations {
 			result.Summary.TotalObservations[severity] += count
 		}
 	}
 
+	result.Summary.Retries = BuildRetryReport(result.Results, len(r.config.ABModels) > 0)
+
 	// Calculate flake rate (failures + errors / total run)
 	total := result.Summary.Passed + result.Summary.Failed + result.Summary.Errors
 	if total > 0 {
 		result.Summary.FlakeRate = float64(result.Summary.Failed+result.Summary.Errors) / float64(total)
+		result.Summary.InfraErrorRate = float64(result.Summary.InfraErrors) / float64(total)
 	}
 
 	// Process quarantine actions taken during this batch
@@ -493,6 +929,12 @@ func (r *Runner) calculateSummary(result *BatchResult) {
 		case "flag":
 			result.Summary.FlakyScenarios = append(result.Summary.FlakyScenarios, action.Scenario)
 		}
+		if action.Runbook != "" && action.Action != "unquarantine" {
+			if result.Summary.Runbooks == nil {
+				result.Summary.Runbooks = make(map[string]string)
+			}
+			result.Summary.Runbooks[action.Scenario] = action.Runbook
+		}
 	}
 
 	// Identify quarantine candidates (scenarios that failed but weren't auto-quarantined)
@@ -533,6 +975,16 @@ func (r *Runner) saveBatchManifest(result *BatchResult) error {
 	return os.WriteFile(manifestPath, data, 0644)
 }
 
+// signResults signs the batch manifest, if signing is enabled. Each run's
+// results are signed as it finishes (see runSingleScenario).
+func (r *Runner) signResults(result *BatchResult) error {
+	if r.signer == nil {
+		return nil
+	}
+	_, err := r.signer.SignResults(result.OutputDir)
+	return err
+}
+
 // generateBatchID generates a unique batch identifier.
 func generateBatchID() string {
 	b := make([]byte, 4)
@@ -540,6 +992,10 @@ func generateBatchID() string {
 	return fmt.Sprintf("%02x%02x%02x%02x", b[0], b[1], b[2], b[3])
 }
 
+// newRunID generates a scenario run ID; tests replace it to know where a
+// run's artifacts will be.
+var newRunID = generateRunID
+
 // generateRunID generates a unique run identifier.
 func generateRunID() string {
 	b := make([]byte, 4)
@@ -601,6 +1057,14 @@ func (s *QuarantineStore) IsQuarantined(scenarioPath string) bool {
 	return ok
 }
 
+// Get returns a scenario's quarantine entry, if it is quarantined.
+func (s *QuarantineStore) Get(scenario string) (QuarantineEntry, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	entry, ok := s.quarantined[scenario]
+	return entry, ok
+}
+
 // Quarantine adds a scenario to quarantine.
 func (s *QuarantineStore) Quarantine(scenario, reason string, flakeRate float64) error {
 	s.mu.Lock()
@@ -676,37 +1140,94 @@ func (s *QuarantineStore) save() error {
 }
 
 // LoadBaseline loads a previous batch result to use as a comparison baseline.
-// The batchID can be a full batch ID (e.g., "a1b2c3d4") or a path to the manifest.
+// The batchID can be a full batch ID (e.g., "a1b2c3d4"), a path to the manifest,
+// or BaselineRef ("baseline") to use the pinned baseline for the environment.
 func (r *Runner) LoadBaseline(batchID string) (*BatchResult, error) {
+	if batchID == BaselineRef {
+		store, err := LoadBaselineStore(r.baseDir)
+		if err != nil {
+			return nil, err
+		}
+		pin, ok := store.Get(r.config.Environment)
+		if !ok {
+			return nil, fmt.Errorf("no baseline pinned for environment %q (use 'gt tester baseline set')", r.config.Environment)
+		}
+		batchID = pin.BatchID
+	}
+
+	path, err := FindBatchManifest(r.baseDir, batchID)
+	if err != nil {
+		return nil, err
+	}
+	return loadManifestFile(path)
+}
+
+// FindBatchManifest locates the manifest for a batch in an output directory.
+// The batchID can be a full batch ID (e.g., "a1b2c3d4") or a path to the manifest.
+func FindBatchManifest(baseDir, batchID string) (string, error) {
 	// First, try to interpret as a direct path to manifest
 	if strings.HasSuffix(batchID, "manifest.json") {
-		return loadManifestFile(batchID)
+		return batchID, nil
 	}
 
 	// Search for the batch in the output directory
 	// Batch manifests are stored at: <output>/<date>/batch-<id>/manifest.json
-	pattern := filepath.Join(r.baseDir, "*", fmt.Sprintf("batch-%s", batchID), "manifest.json")
+	pattern := filepath.Join(baseDir, "*", fmt.Sprintf("batch-%s", batchID), "manifest.json")
 	matches, err := filepath.Glob(pattern)
 	if err != nil {
-		return nil, fmt.Errorf("failed to search for baseline: %w", err)
+		return "", fmt.Errorf("failed to search for baseline: %w", err)
 	}
 
 	if len(matches) == 0 {
 		// Try without the batch- prefix (in case user passed full path component)
-		pattern = filepath.Join(r.baseDir, "*", batchID, "manifest.json")
+		pattern = filepath.Join(baseDir, "*", batchID, "manifest.json")
 		matches, err = filepath.Glob(pattern)
 		if err != nil {
-			return nil, fmt.Errorf("failed to search for baseline: %w", err)
+			return "", fmt.Errorf("failed to search for baseline: %w", err)
 		}
 	}
 
 	if len(matches) == 0 {
-		return nil, fmt.Errorf("baseline batch %q not found in %s", batchID, r.baseDir)
+		return "", fmt.Errorf("baseline batch %q not found in %s", batchID, baseDir)
 	}
 
 	// Use the most recent match if multiple are found
 	sort.Strings(matches)
-	return loadManifestFile(matches[len(matches)-1])
+	return matches[len(matches)-1], nil
+}
+
+// ListBatchManifests returns the manifest paths of every batch in an output
+// directory, oldest first.
+func ListBatchManifests(baseDir string) ([]string, error) {
+	matches, err := filepath.Glob(filepath.Join(baseDir, "*", "batch-*", "manifest.json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to search for batches: %w", err)
+	}
+	// Paths are <output>/<date>/batch-<id>/manifest.json, so lexical order is
+	// date order; within a day, order by modification time.
+	modTimes := make(map[string]time.Time, len(matches))
+	for _, m := range matches {
+		if info, err := os.Stat(m); err == nil {
+			modTimes[m] = info.ModTime()
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		di, dj := filepath.Dir(filepath.Dir(matches[i])), filepath.Dir(filepath.Dir(matches[j]))
+		if di != dj {
+			return di < dj
+		}
+		return modTimes[matches[i]].Before(modTimes[matches[j]])
+	})
+	return matches, nil
+}
+
+// LoadBatchResult loads a batch result by ID or manifest path.
+func LoadBatchResult(baseDir, batchID string) (*BatchResult, error) {
+	path, err := FindBatchManifest(baseDir, batchID)
+	if err != nil {
+		return nil, err
+	}
+	return loadManifestFile(path)
 }
 
 // loadManifestFile loads a batch result from a manifest file.
@@ -2,10 +2,15 @@ package batch
 
 import (
 	"context"
+	"encoding/json"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/steveyegge/gastown/internal/tester"
+	"github.com/steveyegge/gastown/internal/tester/artifacts"
 )
 
 func TestDefaultConfig(t *testing.T) {
@@ -476,3 +481,465 @@ func TestCountObservations(t *testing.T) {
 		}
 	}
 }
+
+func TestBaselinePinning(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	// Write a batch manifest to pin
+	completed := time.Now()
+	good := &BatchResult{
+		ID:           "goodbeef",
+		Config:       Config{Environment: "staging"},
+		CompletedAt:  &completed,
+		ScenariosRun: 1,
+		Results:      []ScenarioResult{{Scenario: "login", Status: StatusPassed}},
+	}
+	dir := filepath.Join(tmpDir, "2026-01-14", "batch-goodbeef")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := json.Marshal(good)
+	if err := os.WriteFile(filepath.Join(dir, "manifest.json"), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	store, err := LoadBaselineStore(tmpDir)
+	if err != nil {
+		t.Fatalf("LoadBaselineStore failed: %v", err)
+	}
+	if err := store.Set(BaselinePin{Environment: "staging", BatchID: "goodbeef"}); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+
+	// --compare-to baseline resolves the pin for the runner's environment
+	runner, err := NewRunner(Config{OutputDir: tmpDir, Environment: "staging"})
+	if err != nil {
+		t.Fatalf("NewRunner failed: %v", err)
+	}
+	baseline, err := runner.LoadBaseline(BaselineRef)
+	if err != nil {
+		t.Fatalf("LoadBaseline(baseline) failed: %v", err)
+	}
+	if baseline.ID != "goodbeef" {
+		t.Errorf("Expected pinned baseline goodbeef, got %s", baseline.ID)
+	}
+
+	prod, _ := NewRunner(Config{OutputDir: tmpDir, Environment: "production"})
+	if _, err := prod.LoadBaseline(BaselineRef); err == nil {
+		t.Error("Expected error for environment without a pin")
+	}
+
+	// Pins persist and track the replaced batch
+	store, _ = LoadBaselineStore(tmpDir)
+	if err := store.Set(BaselinePin{Environment: "staging", BatchID: "newer123"}); err != nil {
+		t.Fatal(err)
+	}
+	reloaded, _ := LoadBaselineStore(tmpDir)
+	pin, ok := reloaded.Get("staging")
+	if !ok || pin.BatchID != "newer123" || pin.Previous != "goodbeef" {
+		t.Errorf("Unexpected pin after replace: %+v", pin)
+	}
+}
+
+func TestCheckPromotion(t *testing.T) {
+	completed := time.Now()
+	baseline := &BatchResult{
+		ID:      "base",
+		Results: []ScenarioResult{{Scenario: "login", Status: StatusPassed}},
+	}
+
+	passing := &BatchResult{
+		ID:           "cand",
+		CompletedAt:  &completed,
+		ScenariosRun: 1,
+		Results:      []ScenarioResult{{Scenario: "login", Status: StatusPassed}},
+	}
+	if failures := CheckPromotion(passing, baseline); len(failures) != 0 {
+		t.Errorf("Expected passing batch to be promotable, got %v", failures)
+	}
+
+	failing := &BatchResult{
+		ID:           "bad",
+		CompletedAt:  &completed,
+		ScenariosRun: 1,
+		Results:      []ScenarioResult{{Scenario: "login", Status: StatusFailed}},
+		Summary:      BatchSummary{Failed: 1},
+	}
+	failures := CheckPromotion(failing, baseline)
+	if len(failures) != 2 {
+		t.Errorf("Expected failure and regression gates, got %v", failures)
+	}
+
+	if failures := CheckPromotion(&BatchResult{ID: "empty"}, nil); len(failures) != 2 {
+		t.Errorf("Expected incomplete and empty gates, got %v", failures)
+	}
+}
+
+func TestRunInterruptedBatch(t *testing.T) {
+	tmpDir := t.TempDir()
+	for _, name := range []string{"a.yaml", "b.yaml"} {
+		os.WriteFile(filepath.Join(tmpDir, name), []byte("scenario: test\n"), 0644)
+	}
+
+	config := DefaultConfig()
+	config.OutputDir = tmpDir
+	config.Pattern = filepath.Join(tmpDir, "*.yaml")
+	config.SkipPreflight = true
+	config.Model = "haiku"
+
+	runner, err := NewRunner(config)
+	if err != nil {
+		t.Fatalf("failed to create runner: %v", err)
+	}
+
+	// Cancelled before any scenario starts, as if Ctrl-C hit immediately
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	result, err := runner.Run(ctx)
+	if err != nil {
+		t.Fatalf("interrupted batch should still return a result: %v", err)
+	}
+	if !result.Interrupted || result.CompletedAt != nil {
+		t.Errorf("expected interrupted batch without completion time, got interrupted=%v completed=%v",
+			result.Interrupted, result.CompletedAt)
+	}
+	if result.Summary.Aborted != 2 || result.Summary.Skipped != 0 {
+		t.Errorf("expected 2 aborted and 0 skipped, got %d aborted, %d skipped",
+			result.Summary.Aborted, result.Summary.Skipped)
+	}
+
+	// The partial manifest is on disk and can be resumed
+	saved, err := LoadBatchResult(tmpDir, result.ID)
+	if err != nil {
+		t.Fatalf("loading partial manifest: %v", err)
+	}
+	if !saved.Interrupted {
+		t.Error("expected manifest to record interrupted: true")
+	}
+
+	resumeConfig, err := ResumeConfig(saved)
+	if err != nil {
+		t.Fatalf("ResumeConfig: %v", err)
+	}
+	if resumeConfig.ResumeOf != result.ID {
+		t.Errorf("ResumeOf = %q, want %q", resumeConfig.ResumeOf, result.ID)
+	}
+
+	resumer, err := NewRunner(resumeConfig)
+	if err != nil {
+		t.Fatalf("failed to create runner: %v", err)
+	}
+	resumer.SetSource(&ResumeSource{Batch: saved})
+
+	resumed, err := resumer.Run(context.Background())
+	if err != nil {
+		t.Fatalf("resumed batch failed: %v", err)
+	}
+	if resumed.Interrupted || resumed.ScenariosRun != 2 || resumed.Summary.Passed != 2 {
+		t.Errorf("expected resumed batch to run both scenarios, got run=%d passed=%d interrupted=%v",
+			resumed.ScenariosRun, resumed.Summary.Passed, resumed.Interrupted)
+	}
+	for _, r := range resumed.Results {
+		if r.Model != "haiku" {
+			t.Errorf("resumed %s with model %q, want haiku", r.Scenario, r.Model)
+		}
+	}
+
+	if _, err := ResumeConfig(resumed); err == nil {
+		t.Error("expected error resuming a completed batch")
+	}
+}
+
+func TestResumeABBatch(t *testing.T) {
+	tmpDir := t.TempDir()
+	a := filepath.Join(tmpDir, "a.yaml")
+	b := filepath.Join(tmpDir, "b.yaml")
+	for _, path := range []string{a, b} {
+		os.WriteFile(path, []byte("scenario: test\n"), 0644)
+	}
+
+	// a finished under haiku before the interrupt; the rest were aborted
+	saved := &BatchResult{
+		ID:          "ab123",
+		Interrupted: true,
+		Config:      Config{OutputDir: tmpDir, SkipPreflight: true, Model: "haiku", ABModels: []string{"haiku", "sonnet"}},
+		Results: []ScenarioResult{
+			{Scenario: "a", Path: a, Model: "haiku", Status: StatusPassed},
+			{Scenario: "a", Path: a, Model: "sonnet", Status: StatusAborted},
+			{Scenario: "b", Path: b, Model: "haiku", Status: StatusAborted},
+			{Scenario: "b", Path: b, Model: "sonnet", Status: StatusAborted},
+		},
+	}
+	config, err := ResumeConfig(saved)
+	if err != nil {
+		t.Fatalf("ResumeConfig: %v", err)
+	}
+	resumer, err := NewRunner(config)
+	if err != nil {
+		t.Fatalf("failed to create runner: %v", err)
+	}
+	resumer.SetSource(&ResumeSource{Batch: saved})
+
+	resumed, err := resumer.Run(context.Background())
+	if err != nil {
+		t.Fatalf("resumed batch failed: %v", err)
+	}
+	var runs []string
+	for _, r := range resumed.Results {
+		runs = append(runs, r.Scenario+"/"+r.Model)
+	}
+	if got := strings.Join(runs, " "); got != "a/sonnet b/haiku b/sonnet" || resumed.ScenariosRun != 3 {
+		t.Errorf("resumed runs = %q (%d), want exactly the aborted a/sonnet b/haiku b/sonnet", got, resumed.ScenariosRun)
+	}
+}
+
+func TestAbortedRunNotRecorded(t *testing.T) {
+	tmpDir := t.TempDir()
+	config := DefaultConfig()
+	config.OutputDir = tmpDir
+
+	runner, err := NewRunner(config)
+	if err != nil {
+		t.Fatalf("failed to create runner: %v", err)
+	}
+
+	runner.recordRunOutcome("checkout", ScenarioResult{
+		Scenario:   "checkout",
+		Path:       filepath.Join(tmpDir, "checkout.yaml"),
+		Status:     StatusAborted,
+		SkipReason: "abort_if matched: http_status 503",
+	})
+	if hist := runner.flakeDetector.GetHistory("checkout"); hist != nil {
+		t.Errorf("aborted run should not be recorded, got %d runs", hist.TotalRuns)
+	}
+
+	runner.recordRunOutcome("checkout", ScenarioResult{
+		Scenario: "checkout",
+		Path:     filepath.Join(tmpDir, "checkout.yaml"),
+		Status:   StatusFailed,
+	})
+	if hist := runner.flakeDetector.GetHistory("checkout"); hist == nil || hist.TotalRuns != 1 {
+		t.Errorf("expected failed run to be recorded, got %+v", hist)
+	}
+}
+
+func TestLimitViolationIsInfrastructureError(t *testing.T) {
+	v := &tester.LimitViolation{
+		Process: "chromium",
+		Limits:  tester.ResourceLimits{MemoryMB: 1024},
+		Detail:  "killed by signal: killed",
+	}
+	if !isInfrastructureError(v.Reason()) {
+		t.Errorf("isInfrastructureError(%q) = false, want true", v.Reason())
+	}
+	if got := categorizeError(v.Reason()); got != "resource_limit" {
+		t.Errorf("categorizeError(%q) = %q, want resource_limit", v.Reason(), got)
+	}
+}
+
+func TestFlakeMetricsInManifest(t *testing.T) {
+	tmpDir := t.TempDir()
+	os.WriteFile(filepath.Join(tmpDir, "checkout.yaml"), []byte("scenario: checkout\n"), 0644)
+
+	config := DefaultConfig()
+	config.OutputDir = tmpDir
+	config.Pattern = filepath.Join(tmpDir, "*.yaml")
+	config.SkipPreflight = true
+
+	runner, err := NewRunner(config)
+	if err != nil {
+		t.Fatalf("failed to create runner: %v", err)
+	}
+	result, err := runner.Run(context.Background())
+	if err != nil {
+		t.Fatalf("batch run failed: %v", err)
+	}
+	sr := result.ScenarioResult("checkout")
+	if sr == nil || sr.Flake == nil {
+		t.Fatalf("expected flake metrics on the run result, got %+v", sr)
+	}
+	if sr.Flake.WindowRuns != 1 || sr.Flake.WindowPasses != 1 {
+		t.Errorf("expected 1 passing run in window, got %+v", sr.Flake)
+	}
+
+	// A quarantined scenario is skipped, and its metrics explain why
+	runner, err = NewRunner(config)
+	if err != nil {
+		t.Fatalf("failed to create runner: %v", err)
+	}
+	if err := runner.flakeDetector.Quarantine("checkout", "manual"); err != nil {
+		t.Fatalf("Quarantine() error = %v", err)
+	}
+	result, err = runner.Run(context.Background())
+	if err != nil {
+		t.Fatalf("batch run failed: %v", err)
+	}
+	sr = result.ScenarioResult("checkout")
+	if sr == nil || sr.Status != StatusSkipped {
+		t.Fatalf("expected skipped result, got %+v", sr)
+	}
+	if sr.Flake == nil || sr.Flake.WindowRuns != 1 {
+		t.Errorf("expected skipped result to carry prior metrics, got %+v", sr.Flake)
+	}
+}
+
+func TestRetryErrorsRecordedByRunner(t *testing.T) {
+	tmpDir := t.TempDir()
+	os.WriteFile(filepath.Join(tmpDir, "checkout.yaml"), []byte("scenario: checkout\n"), 0644)
+
+	oldRunID := newRunID
+	newRunID = func() string { return "fixed" }
+	defer func() { newRunID = oldRunID }()
+
+	// The scenario's run retried twice before passing
+	runDir := filepath.Join(tmpDir, time.Now().Format("2006-01-02"), "checkout", "run-fixed")
+	if err := os.MkdirAll(runDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	attempts := []tester.RetryAttempt{
+		{Attempt: 1, Error: "browser_crash: chromium exited"},
+		{Attempt: 2, Error: "timeout: page did not load"},
+	}
+	if err := tester.WriteRetryLog(runDir, attempts); err != nil {
+		t.Fatalf("WriteRetryLog: %v", err)
+	}
+
+	config := DefaultConfig()
+	config.OutputDir = tmpDir
+	config.Pattern = filepath.Join(tmpDir, "*.yaml")
+	config.SkipPreflight = true
+
+	runner, err := NewRunner(config)
+	if err != nil {
+		t.Fatalf("failed to create runner: %v", err)
+	}
+	result, err := runner.Run(context.Background())
+	if err != nil {
+		t.Fatalf("batch run failed: %v", err)
+	}
+
+	sr := result.ScenarioResult("checkout")
+	if sr == nil || sr.RetryCount != 2 || len(sr.RetryErrors) != 2 {
+		t.Fatalf("expected 2 recorded retries, got %+v", sr)
+	}
+	rr := result.Summary.Retries
+	if rr == nil || rr.Rescued != 1 {
+		t.Fatalf("expected one rescued run in the retry report, got %+v", rr)
+	}
+	if rr.ByErrorType["browser_crash"] != 1 {
+		t.Errorf("ByErrorType = %v, want the first attempt's browser_crash", rr.ByErrorType)
+	}
+}
+
+// urlUploader pretends to upload files, returning example URLs.
+type urlUploader struct{}
+
+func (urlUploader) Name() string { return "fake://bucket" }
+
+func (urlUploader) Upload(_ context.Context, _, key string) (string, error) {
+	return "https://example.com/" + key, nil
+}
+
+func TestSignedRunVerifiesAfterUpload(t *testing.T) {
+	tmpDir := t.TempDir()
+	os.WriteFile(filepath.Join(tmpDir, "checkout.yaml"), []byte("scenario: checkout\n"), 0644)
+
+	oldRunID := newRunID
+	newRunID = func() string { return "fixed" }
+	defer func() { newRunID = oldRunID }()
+
+	// The run recorded a video, so the upload rewrites its manifest
+	mgr, err := artifacts.NewManager(tmpDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	run, err := mgr.InitRun("checkout", "fixed", artifacts.DefaultRecordingConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.WriteFile(mgr.VideoPath(run.RunDir), []byte("video"), 0644)
+	if err := mgr.RecordVideo(run); err != nil {
+		t.Fatal(err)
+	}
+	if err := mgr.FinalizeRun(run); err != nil {
+		t.Fatal(err)
+	}
+
+	config := DefaultConfig()
+	config.OutputDir = tmpDir
+	config.Pattern = filepath.Join(tmpDir, "*.yaml")
+	config.SkipPreflight = true
+	config.Sign = tester.SignHMAC
+	config.SigningKey = filepath.Join(tmpDir, "signing.key")
+
+	runner, err := NewRunner(config)
+	if err != nil {
+		t.Fatalf("failed to create runner: %v", err)
+	}
+	runner.SetUploader(urlUploader{})
+	result, err := runner.Run(context.Background())
+	if err != nil {
+		t.Fatalf("batch run failed: %v", err)
+	}
+	if sr := result.ScenarioResult("checkout"); sr == nil || sr.ArtifactURL == "" {
+		t.Fatalf("expected an uploaded run, got %+v", sr)
+	}
+
+	results, err := tester.VerifyResults(run.RunDir, tester.VerifyKeys{HMACKey: config.SigningKey})
+	if err != nil {
+		t.Fatalf("VerifyResults: %v", err)
+	}
+	if len(results) != 1 || results[0].Status != tester.VerifyOK {
+		t.Errorf("uploaded run manifest verification = %+v, want ok", results)
+	}
+}
+
+func TestRunMCPConfigUsesProfile(t *testing.T) {
+	tmpDir := t.TempDir()
+	os.WriteFile(filepath.Join(tmpDir, "alice.json"), []byte(`{"cookies":[]}`), 0644)
+	os.WriteFile(filepath.Join(tmpDir, "checkout.yaml"), []byte("scenario: checkout\n"), 0644)
+	os.WriteFile(filepath.Join(tmpDir, "account.yaml"), []byte(`scenario: account
+persona: alice
+goal: View the account page
+success_criteria: [Account page shown]
+environment:
+  url: https://example.com
+  profile_fixture: alice.json
+`), 0644)
+
+	oldRunID := newRunID
+	newRunID = func() string { return "fixed" }
+	defer func() { newRunID = oldRunID }()
+
+	config := DefaultConfig()
+	config.OutputDir = tmpDir
+	config.Pattern = filepath.Join(tmpDir, "*.yaml")
+	config.SkipPreflight = true
+	runner, err := NewRunner(config)
+	if err != nil {
+		t.Fatalf("failed to create runner: %v", err)
+	}
+	if _, err := runner.Run(context.Background()); err != nil {
+		t.Fatalf("batch run failed: %v", err)
+	}
+
+	mcpArgs := func(scenario string) string {
+		t.Helper()
+		data, err := os.ReadFile(filepath.Join(tmpDir, time.Now().Format("2006-01-02"), scenario, "run-fixed", ".mcp.json"))
+		if err != nil {
+			t.Fatalf("%s: no .mcp.json: %v", scenario, err)
+		}
+		var mcp tester.MCPConfig
+		if err := json.Unmarshal(data, &mcp); err != nil {
+			t.Fatal(err)
+		}
+		return strings.Join(mcp.MCPServers["playwright"].Args, " ")
+	}
+	if args := mcpArgs("checkout"); !strings.Contains(args, "--user-data-dir "+os.TempDir()) {
+		t.Errorf("checkout args = %q, want its own --user-data-dir", args)
+	}
+	if args := mcpArgs("account"); !strings.Contains(args, "--storage-state ") {
+		t.Errorf("account args = %q, want --storage-state from the fixture", args)
+	}
+}
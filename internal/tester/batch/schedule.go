@@ -0,0 +1,228 @@
+package batch
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// ScheduleFileName is the schedule file stored in the results directory.
+const ScheduleFileName = ".schedules.json"
+
+// maxScheduleHistory is how many runs are kept per schedule.
+const maxScheduleHistory = 20
+
+// Schedule runs a batch on a cron schedule.
+type Schedule struct {
+	// ID identifies the schedule; batches it starts record it as ScheduleID.
+	ID string `json:"id"`
+
+	// Cron is the five-field cron expression, evaluated in local time.
+	Cron string `json:"cron"`
+
+	// Config is the batch configuration to run.
+	Config Config `json:"config"`
+
+	// Notify is where completion notices go: a mail address (e.g. "mayor/")
+	// or an http(s) webhook URL. Empty disables notification.
+	Notify string `json:"notify,omitempty"`
+
+	// Paused schedules are skipped by the scheduler.
+	Paused bool `json:"paused,omitempty"`
+
+	// CreatedAt is when the schedule was added.
+	CreatedAt time.Time `json:"created_at"`
+
+	// CreatedBy identifies who added the schedule.
+	CreatedBy string `json:"created_by,omitempty"`
+
+	// NextRun is the next time the schedule is due.
+	NextRun time.Time `json:"next_run"`
+
+	// Runs is the recent run history, newest last.
+	Runs []ScheduleRun `json:"runs,omitempty"`
+}
+
+// ScheduleRun records one batch started by a schedule.
+type ScheduleRun struct {
+	BatchID   string    `json:"batch_id,omitempty"`
+	StartedAt time.Time `json:"started_at"`
+	Duration  string    `json:"duration,omitempty"`
+	Passed    int       `json:"passed"`
+	Failed    int       `json:"failed"`
+	Errors    int       `json:"errors"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// LastRun returns the most recent run, if any.
+func (s *Schedule) LastRun() (ScheduleRun, bool) {
+	if len(s.Runs) == 0 {
+		return ScheduleRun{}, false
+	}
+	return s.Runs[len(s.Runs)-1], true
+}
+
+// ScheduleStore manages batch schedules in a results directory.
+type ScheduleStore struct {
+	path      string
+	schedules map[string]*Schedule
+}
+
+// LoadScheduleStore loads the schedules from a results directory.
+func LoadScheduleStore(outputDir string) (*ScheduleStore, error) {
+	store := &ScheduleStore{
+		path:      filepath.Join(outputDir, ScheduleFileName),
+		schedules: make(map[string]*Schedule),
+	}
+
+	data, err := os.ReadFile(store.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return store, nil
+		}
+		return nil, fmt.Errorf("failed to read schedules: %w", err)
+	}
+
+	var schedules []*Schedule
+	if err := json.Unmarshal(data, &schedules); err != nil {
+		return nil, fmt.Errorf("failed to parse schedules: %w", err)
+	}
+	for _, s := range schedules {
+		store.schedules[s.ID] = s
+	}
+
+	return store, nil
+}
+
+// Add validates and saves a new schedule, assigning its ID and first run.
+func (s *ScheduleStore) Add(sched *Schedule, now time.Time) error {
+	cron, err := ParseCron(sched.Cron)
+	if err != nil {
+		return err
+	}
+	next := cron.Next(now)
+	if next.IsZero() {
+		return fmt.Errorf("cron expression %q never matches", sched.Cron)
+	}
+
+	if sched.ID == "" {
+		sched.ID = "sched-" + generateRunID()
+	}
+	if _, exists := s.schedules[sched.ID]; exists {
+		return fmt.Errorf("schedule %s already exists", sched.ID)
+	}
+	sched.CreatedAt = now
+	sched.NextRun = next
+	s.schedules[sched.ID] = sched
+	return s.save()
+}
+
+// Get returns a schedule by ID.
+func (s *ScheduleStore) Get(id string) (*Schedule, bool) {
+	sched, ok := s.schedules[id]
+	return sched, ok
+}
+
+// List returns all schedules ordered by next run.
+func (s *ScheduleStore) List() []*Schedule {
+	schedules := make([]*Schedule, 0, len(s.schedules))
+	for _, sched := range s.schedules {
+		schedules = append(schedules, sched)
+	}
+	sort.Slice(schedules, func(i, j int) bool {
+		if !schedules[i].NextRun.Equal(schedules[j].NextRun) {
+			return schedules[i].NextRun.Before(schedules[j].NextRun)
+		}
+		return schedules[i].ID < schedules[j].ID
+	})
+	return schedules
+}
+
+// Remove deletes a schedule.
+func (s *ScheduleStore) Remove(id string) error {
+	if _, ok := s.schedules[id]; !ok {
+		return fmt.Errorf("schedule %s not found", id)
+	}
+	delete(s.schedules, id)
+	return s.save()
+}
+
+// SetPaused pauses or resumes a schedule. Resuming recomputes the next run
+// so missed runs are not replayed.
+func (s *ScheduleStore) SetPaused(id string, paused bool, now time.Time) error {
+	sched, ok := s.schedules[id]
+	if !ok {
+		return fmt.Errorf("schedule %s not found", id)
+	}
+	sched.Paused = paused
+	if !paused {
+		if cron, err := ParseCron(sched.Cron); err == nil {
+			sched.NextRun = cron.Next(now)
+		}
+	}
+	return s.save()
+}
+
+// Due returns the unpaused schedules whose next run is at or before now.
+func (s *ScheduleStore) Due(now time.Time) []*Schedule {
+	var due []*Schedule
+	for _, sched := range s.List() {
+		if !sched.Paused && !sched.NextRun.IsZero() && !sched.NextRun.After(now) {
+			due = append(due, sched)
+		}
+	}
+	return due
+}
+
+// RecordRun appends a run to a schedule's history and advances its next
+// run past now. Runs missed while the scheduler was down are skipped
+// rather than run back to back.
+func (s *ScheduleStore) RecordRun(id string, run ScheduleRun, now time.Time) error {
+	sched, ok := s.schedules[id]
+	if !ok {
+		return fmt.Errorf("schedule %s not found", id)
+	}
+	sched.Runs = append(sched.Runs, run)
+	if len(sched.Runs) > maxScheduleHistory {
+		sched.Runs = sched.Runs[len(sched.Runs)-maxScheduleHistory:]
+	}
+	if cron, err := ParseCron(sched.Cron); err == nil {
+		sched.NextRun = cron.Next(now)
+	}
+	return s.save()
+}
+
+func (s *ScheduleStore) save() error {
+	data, err := json.MarshalIndent(s.List(), "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(s.path, data, 0644)
+}
+
+// NewScheduleRun summarizes a batch result for a schedule's history.
+func NewScheduleRun(started time.Time, result *BatchResult, err error) ScheduleRun {
+	run := ScheduleRun{StartedAt: started}
+	if err != nil {
+		run.Error = err.Error()
+	}
+	if result != nil {
+		run.BatchID = result.ID
+		run.Duration = result.TotalDuration.Round(time.Second).String()
+		run.Passed = result.Summary.Passed
+		run.Failed = result.Summary.Failed
+		run.Errors = result.Summary.Errors
+		if result.Interrupted && run.Error == "" {
+			run.Error = fmt.Sprintf("interrupted, %d scenarios aborted", result.Summary.Aborted)
+		}
+	}
+	return run
+}
@@ -0,0 +1,137 @@
+package batch
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCron_Invalid(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"a * * * *",
+	} {
+		if _, err := ParseCron(expr); err == nil {
+			t.Errorf("ParseCron(%q) succeeded, want error", expr)
+		}
+	}
+}
+
+func TestCronSchedule_Next(t *testing.T) {
+	// Wednesday 2026-01-07 10:30 UTC
+	base := time.Date(2026, 1, 7, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{"0 2 * * *", time.Date(2026, 1, 8, 2, 0, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2026, 1, 7, 10, 45, 0, 0, time.UTC)},
+		{"30 10 * * *", time.Date(2026, 1, 8, 10, 30, 0, 0, time.UTC)}, // strictly after
+		{"0 9-17/4 * * *", time.Date(2026, 1, 7, 13, 0, 0, 0, time.UTC)},
+		{"0 0 * * 1", time.Date(2026, 1, 12, 0, 0, 0, 0, time.UTC)}, // next Monday
+		{"0 0 * * 7", time.Date(2026, 1, 11, 0, 0, 0, 0, time.UTC)}, // 7 is Sunday
+		{"0 0 1 3 *", time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1,15 * *", time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2026, 1, 7, 11, 0, 0, 0, time.UTC)},
+		// Both day fields restricted: either may match (Friday the 9th first)
+		{"0 0 20 * 5", time.Date(2026, 1, 9, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		c, err := ParseCron(tt.expr)
+		if err != nil {
+			t.Fatalf("ParseCron(%q): %v", tt.expr, err)
+		}
+		if got := c.Next(base); !got.Equal(tt.want) {
+			t.Errorf("Next(%q) = %s, want %s", tt.expr, got, tt.want)
+		}
+	}
+}
+
+func TestCronSchedule_NeverMatches(t *testing.T) {
+	c, err := ParseCron("0 0 30 2 *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := c.Next(time.Now()); !got.IsZero() {
+		t.Errorf("Next = %s, want zero for Feb 30", got)
+	}
+}
+
+func TestScheduleStore(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2026, 1, 7, 1, 0, 0, 0, time.Local)
+
+	store, err := LoadScheduleStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sched := &Schedule{Cron: "0 2 * * *", Config: Config{Pattern: "scenarios/*.yaml", Environment: "staging"}}
+	if err := store.Add(sched, now); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if sched.ID == "" {
+		t.Fatal("expected generated schedule ID")
+	}
+	if want := time.Date(2026, 1, 7, 2, 0, 0, 0, time.Local); !sched.NextRun.Equal(want) {
+		t.Errorf("NextRun = %s, want %s", sched.NextRun, want)
+	}
+	if err := store.Add(&Schedule{Cron: "bogus"}, now); err == nil {
+		t.Error("expected error for invalid cron")
+	}
+
+	if due := store.Due(now); len(due) != 0 {
+		t.Errorf("expected nothing due at 01:00, got %d", len(due))
+	}
+
+	// Scheduler was down for two days: only one run is due, then it advances
+	late := now.Add(49 * time.Hour)
+	reloaded, err := LoadScheduleStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	due := reloaded.Due(late)
+	if len(due) != 1 || due[0].ID != sched.ID {
+		t.Fatalf("Due = %v, want [%s]", due, sched.ID)
+	}
+
+	result := &BatchResult{ID: "abcd1234", TotalDuration: 90 * time.Second}
+	result.Summary.Passed = 3
+	result.Summary.Failed = 1
+	if err := reloaded.RecordRun(sched.ID, NewScheduleRun(late, result, nil), late); err != nil {
+		t.Fatalf("RecordRun: %v", err)
+	}
+
+	got, _ := reloaded.Get(sched.ID)
+	last, ok := got.LastRun()
+	if !ok || last.BatchID != "abcd1234" || last.Passed != 3 || last.Failed != 1 || last.Duration != "1m30s" {
+		t.Errorf("LastRun = %+v", last)
+	}
+	if !got.NextRun.After(late) {
+		t.Errorf("NextRun %s not advanced past %s", got.NextRun, late)
+	}
+	if len(reloaded.Due(late)) != 0 {
+		t.Error("expected nothing due after recording run")
+	}
+
+	// Paused schedules are never due
+	if err := reloaded.SetPaused(sched.ID, true, late); err != nil {
+		t.Fatal(err)
+	}
+	if len(reloaded.Due(late.Add(72*time.Hour))) != 0 {
+		t.Error("paused schedule should not be due")
+	}
+
+	if err := reloaded.Remove(sched.ID); err != nil {
+		t.Fatal(err)
+	}
+	if len(reloaded.List()) != 0 {
+		t.Error("expected no schedules after remove")
+	}
+}
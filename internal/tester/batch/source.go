@@ -0,0 +1,421 @@
+package batch
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/tester"
+	"gopkg.in/yaml.v3"
+)
+
+// ScenarioSource supplies the scenarios for a batch run.
+// Implementations resolve scenarios to local files so the runner can execute
+// them the same way regardless of where the suite is defined.
+type ScenarioSource interface {
+	// Name describes the source for display (pattern, manifest path, or URL).
+	Name() string
+
+	// Scenarios returns the scenarios to run, in a stable order.
+	Scenarios() ([]ScenarioRef, error)
+}
+
+// ScenarioRef is a scenario file resolved by a source, plus any per-scenario
+// overrides the source declared for it.
+type ScenarioRef struct {
+	// Path is the local path to the scenario file.
+	Path string `json:"path" yaml:"path"`
+
+	// Model overrides the batch model for this scenario (optional).
+	Model string `json:"model,omitempty" yaml:"model,omitempty"`
+
+	// Tags are added to the tags derived from the scenario path.
+	Tags []string `json:"tags,omitempty" yaml:"tags,omitempty"`
+
+	// Environment overrides the batch environment for this scenario (optional).
+	Environment string `json:"environment,omitempty" yaml:"environment,omitempty"`
+
+	// MaxAttempts overrides the scenario's retry attempts (optional).
+	MaxAttempts int `json:"max_attempts,omitempty" yaml:"max_attempts,omitempty"`
+}
+
+// SuiteDefinition is the manifest format shared by file and HTTP sources.
+//
+// Example:
+//
+//	name: parent-portal
+//	include: [auth]
+//	defaults:
+//	  model: sonnet
+//	  environment: staging
+//	  retry:
+//	    max_attempts: 2
+//	  tags: [smoke]
+//	scenarios:
+//	  - path: registration/signup.yaml
+//	    model: haiku
+//	    tags: [critical-path]
+//	  - glob: checkout/*.yaml
+type SuiteDefinition struct {
+	// Name is the suite name.
+	Name string `json:"name" yaml:"name"`
+
+	// Include lists other suites, by name or path, whose scenarios are part
+	// of this one. Included suites inherit these defaults where their own
+	// are unset (local suites only).
+	Include []string `json:"include,omitempty" yaml:"include,omitempty"`
+
+	// Defaults apply to every entry that does not override them.
+	Defaults SuiteEntry `json:"defaults,omitempty" yaml:"defaults,omitempty"`
+
+	// Scenarios lists the scenarios in the suite.
+	Scenarios []SuiteEntry `json:"scenarios" yaml:"scenarios"`
+}
+
+// SuiteEntry is a single scenario (or glob of scenarios) in a suite.
+type SuiteEntry struct {
+	// Path is the scenario file, relative to the suite location.
+	Path string `json:"path,omitempty" yaml:"path,omitempty"`
+
+	// Glob matches several scenario files (manifest files only).
+	Glob string `json:"glob,omitempty" yaml:"glob,omitempty"`
+
+	// Model overrides the batch model for matching scenarios.
+	Model string `json:"model,omitempty" yaml:"model,omitempty"`
+
+	// Tags are attached to matching scenarios.
+	Tags []string `json:"tags,omitempty" yaml:"tags,omitempty"`
+
+	// Environment overrides the batch environment for matching scenarios.
+	Environment string `json:"environment,omitempty" yaml:"environment,omitempty"`
+
+	// Retry overrides the retry settings of matching scenarios.
+	Retry *tester.ScenarioRetry `json:"retry,omitempty" yaml:"retry,omitempty"`
+}
+
+// NewScenarioSource picks the source described by the config.
+// A suite URL takes precedence over a manifest, which takes precedence over
+// a named suite, which takes precedence over the glob pattern.
+func NewScenarioSource(config Config) ScenarioSource {
+	switch {
+	case config.SuiteURL != "":
+		return &HTTPSource{
+			URL:      config.SuiteURL,
+			CacheDir: filepath.Join(config.OutputDir, ".suite-cache"),
+		}
+	case config.Manifest != "":
+		return &ManifestSource{Path: config.Manifest}
+	case config.Suite != "":
+		return &SuiteSource{Suite: config.Suite, Dir: config.SuiteDir}
+	default:
+		return &GlobSource{Pattern: config.Pattern}
+	}
+}
+
+// GlobSource finds scenario files matching a glob pattern.
+type GlobSource struct {
+	Pattern string
+}
+
+// Name returns the glob pattern.
+func (s *GlobSource) Name() string {
+	return s.Pattern
+}
+
+// Scenarios returns all .yaml/.yml files matching the pattern, sorted.
+func (s *GlobSource) Scenarios() ([]ScenarioRef, error) {
+	paths, err := globScenarioFiles(s.Pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	refs := make([]ScenarioRef, 0, len(paths))
+	for _, p := range paths {
+		refs = append(refs, ScenarioRef{Path: p})
+	}
+	return refs, nil
+}
+
+// ManifestSource reads scenarios from a local suite definition file.
+// Relative paths, globs and included suite paths resolve against the
+// manifest's directory; suites included by name are looked up under it.
+type ManifestSource struct {
+	Path string
+}
+
+// Name returns the manifest path.
+func (s *ManifestSource) Name() string {
+	return s.Path
+}
+
+// Scenarios returns the scenarios listed in the manifest and the suites
+// it includes.
+func (s *ManifestSource) Scenarios() ([]ScenarioRef, error) {
+	loader := &suiteLoader{dir: filepath.Dir(s.Path)}
+	refs, err := loader.load(s.Path, SuiteEntry{})
+	if err != nil {
+		return nil, err
+	}
+	return dedupeRefs(refs), nil
+}
+
+// suiteScenarios resolves a local suite's own entries, with defaults
+// applied, against its directory.
+func suiteScenarios(suite *SuiteDefinition, defaults SuiteEntry, baseDir string) ([]ScenarioRef, error) {
+	var refs []ScenarioRef
+	for i, entry := range suite.Scenarios {
+		entry = entry.inherit(defaults)
+		switch {
+		case entry.Path != "":
+			p := entry.Path
+			if !filepath.IsAbs(p) {
+				p = filepath.Join(baseDir, p)
+			}
+			if _, err := os.Stat(p); err != nil {
+				return nil, fmt.Errorf("scenario %d: %w", i+1, err)
+			}
+			refs = append(refs, entry.ref(p))
+		case entry.Glob != "":
+			pattern := entry.Glob
+			if !filepath.IsAbs(pattern) {
+				pattern = filepath.Join(baseDir, pattern)
+			}
+			paths, err := globScenarioFiles(pattern)
+			if err != nil {
+				return nil, fmt.Errorf("scenario %d: %w", i+1, err)
+			}
+			for _, p := range paths {
+				refs = append(refs, entry.ref(p))
+			}
+		default:
+			return nil, fmt.Errorf("scenario %d: path or glob is required", i+1)
+		}
+	}
+	return refs, nil
+}
+
+// ResumeSource re-runs the scenarios an interrupted batch did not finish.
+type ResumeSource struct {
+	Batch *BatchResult
+}
+
+// Name describes the batch being resumed.
+func (s *ResumeSource) Name() string {
+	return fmt.Sprintf("resume of batch %s", s.Batch.ID)
+}
+
+// Scenarios returns the batch's aborted runs with the model and source
+// overrides they were going to run with. A scenario aborted under several
+// models (an A/B batch) is listed once per model, so each aborted run, and
+// only those, runs again.
+func (s *ResumeSource) Scenarios() ([]ScenarioRef, error) {
+	var refs []ScenarioRef
+	for _, r := range s.Batch.Results {
+		if r.Status == StatusAborted {
+			refs = append(refs, ScenarioRef{Path: r.Path, Model: r.Model, Environment: r.Environment, MaxAttempts: r.MaxAttempts})
+		}
+	}
+	return refs, nil
+}
+
+// ResumeConfig returns the config for resuming an interrupted batch. Tag and
+// change selection are dropped: the aborted scenarios were already selected.
+// An A/B batch resumes each aborted (scenario, model) run under its own
+// model, without a model comparison.
+func ResumeConfig(prev *BatchResult) (Config, error) {
+	if !prev.Interrupted {
+		return Config{}, fmt.Errorf("batch %s was not interrupted", prev.ID)
+	}
+	config := prev.Config
+	config.ResumeOf = prev.ID
+	config.ABModels = nil
+	config.FilterTags = nil
+	config.ExcludeTags = nil
+	config.OnlyChanged = ""
+	config.ChangeMap = ""
+	return config, nil
+}
+
+// HTTPSource fetches a suite definition from an HTTP endpoint and downloads
+// its scenarios into a local cache, so one suite can be shared by many towns.
+// Scenario paths resolve relative to the suite URL; globs are not supported.
+type HTTPSource struct {
+	// URL is the suite definition URL (YAML or JSON).
+	URL string
+
+	// CacheDir is where downloaded scenario files are stored.
+	CacheDir string
+
+	// Client is the HTTP client to use (defaults to a 30s timeout client).
+	Client *http.Client
+}
+
+// Name returns the suite URL.
+func (s *HTTPSource) Name() string {
+	return s.URL
+}
+
+// Scenarios fetches the suite and returns the cached scenario files.
+func (s *HTTPSource) Scenarios() ([]ScenarioRef, error) {
+	base, err := url.Parse(s.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid suite URL: %w", err)
+	}
+
+	data, err := s.fetch(base.String())
+	if err != nil {
+		return nil, fmt.Errorf("fetching suite: %w", err)
+	}
+
+	suite, err := parseSuiteDefinition(data)
+	if err != nil {
+		return nil, fmt.Errorf("parsing suite %s: %w", s.URL, err)
+	}
+
+	if len(suite.Include) > 0 {
+		return nil, fmt.Errorf("suite %s: include is not supported for remote suites", s.URL)
+	}
+
+	suiteDir := filepath.Join(s.CacheDir, cacheKey(base))
+	var refs []ScenarioRef
+	for i, entry := range suite.Scenarios {
+		entry = entry.inherit(suite.Defaults)
+		if entry.Glob != "" {
+			return nil, fmt.Errorf("scenario %d: glob is not supported for remote suites", i+1)
+		}
+		if entry.Path == "" {
+			return nil, fmt.Errorf("scenario %d: path is required", i+1)
+		}
+
+		ref, err := base.Parse(entry.Path)
+		if err != nil {
+			return nil, fmt.Errorf("scenario %d: invalid path %q: %w", i+1, entry.Path, err)
+		}
+		content, err := s.fetch(ref.String())
+		if err != nil {
+			return nil, fmt.Errorf("scenario %d: %w", i+1, err)
+		}
+
+		// Keep the remote path layout so directory-derived tags still work
+		local := filepath.Join(suiteDir, filepath.FromSlash(strings.TrimPrefix(path.Clean("/"+ref.Path), "/")))
+		if err := os.MkdirAll(filepath.Dir(local), 0755); err != nil {
+			return nil, fmt.Errorf("creating suite cache: %w", err)
+		}
+		if err := os.WriteFile(local, content, 0644); err != nil {
+			return nil, fmt.Errorf("caching scenario: %w", err)
+		}
+		refs = append(refs, entry.ref(local))
+	}
+
+	return dedupeRefs(refs), nil
+}
+
+// fetch GETs rawURL and returns the body.
+func (s *HTTPSource) fetch(rawURL string) ([]byte, error) {
+	client := s.Client
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+
+	resp, err := client.Get(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s: %s", rawURL, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// cacheKey returns a filesystem-safe directory name for a suite URL.
+func cacheKey(u *url.URL) string {
+	key := u.Host + "_" + strings.TrimSuffix(path.Base(u.Path), path.Ext(u.Path))
+	return strings.NewReplacer(":", "_", "/", "_").Replace(key)
+}
+
+// parseSuiteDefinition parses a YAML (or JSON) suite definition.
+func parseSuiteDefinition(data []byte) (*SuiteDefinition, error) {
+	var suite SuiteDefinition
+	if err := yaml.Unmarshal(data, &suite); err != nil {
+		return nil, err
+	}
+	if len(suite.Scenarios) == 0 && len(suite.Include) == 0 {
+		return nil, fmt.Errorf("suite has no scenarios")
+	}
+	return &suite, nil
+}
+
+// inherit fills unset fields of e from defaults d. Default tags are merged
+// with e's tags. It applies suite defaults to entries, and an including
+// suite's defaults to an included suite's.
+func (e SuiteEntry) inherit(d SuiteEntry) SuiteEntry {
+	if e.Model == "" {
+		e.Model = d.Model
+	}
+	if e.Environment == "" {
+		e.Environment = d.Environment
+	}
+	if e.Retry == nil {
+		e.Retry = d.Retry
+	}
+	if len(d.Tags) > 0 {
+		e.Tags = append(append([]string{}, d.Tags...), e.Tags...)
+	}
+	return e
+}
+
+// ref builds a ScenarioRef for a resolved path.
+func (e SuiteEntry) ref(p string) ScenarioRef {
+	ref := ScenarioRef{Path: p, Model: e.Model, Tags: e.Tags, Environment: e.Environment}
+	if e.Retry != nil {
+		ref.MaxAttempts = e.Retry.MaxAttempts
+	}
+	return ref
+}
+
+// dedupeRefs drops repeated paths, keeping the first occurrence.
+func dedupeRefs(refs []ScenarioRef) []ScenarioRef {
+	seen := make(map[string]bool, len(refs))
+	var out []ScenarioRef
+	for _, r := range refs {
+		if seen[r.Path] {
+			continue
+		}
+		seen[r.Path] = true
+		out = append(out, r)
+	}
+	return out
+}
+
+// globScenarioFiles returns .yaml/.yml files matching pattern, sorted.
+// Suite files are not scenarios and are left out.
+func globScenarioFiles(pattern string) ([]string, error) {
+	matches, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	// Filter to only .yaml and .yml files, other than suite files and
+	// include fragments
+	var scenarios []string
+	for _, m := range matches {
+		ext := strings.ToLower(filepath.Ext(m))
+		if (ext == ".yaml" || ext == ".yml") && !isSuiteFile(m) && !tester.IsScenarioFragment(m) {
+			scenarios = append(scenarios, m)
+		}
+	}
+
+	// Sort for consistent ordering
+	sort.Strings(scenarios)
+
+	return scenarios, nil
+}
@@ -0,0 +1,166 @@
+package batch
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestNewScenarioSource(t *testing.T) {
+	config := DefaultConfig()
+	config.Pattern = "*.yaml"
+	if _, ok := NewScenarioSource(config).(*GlobSource); !ok {
+		t.Error("expected GlobSource for pattern")
+	}
+
+	config.Manifest = "suite.yaml"
+	if _, ok := NewScenarioSource(config).(*ManifestSource); !ok {
+		t.Error("expected ManifestSource when manifest is set")
+	}
+
+	config.SuiteURL = "http://example.com/suite.yaml"
+	if _, ok := NewScenarioSource(config).(*HTTPSource); !ok {
+		t.Error("expected HTTPSource when suite URL is set")
+	}
+}
+
+func TestManifestSource(t *testing.T) {
+	tmpDir := t.TempDir()
+	for _, s := range []string{"login.yaml", "checkout/cart.yaml", "checkout/pay.yaml", "checkout/notes.txt"} {
+		path := filepath.Join(tmpDir, s)
+		os.MkdirAll(filepath.Dir(path), 0755)
+		os.WriteFile(path, []byte("test: true"), 0644)
+	}
+
+	manifest := filepath.Join(tmpDir, "suite.yaml")
+	os.WriteFile(manifest, []byte(`name: nightly
+defaults:
+  model: sonnet
+scenarios:
+  - path: login.yaml
+    model: haiku
+    tags: [critical-path]
+  - glob: checkout/*.yaml
+  - path: login.yaml
+`), 0644)
+
+	refs, err := (&ManifestSource{Path: manifest}).Scenarios()
+	if err != nil {
+		t.Fatalf("Scenarios failed: %v", err)
+	}
+
+	if len(refs) != 3 {
+		t.Fatalf("expected 3 scenarios, got %d: %+v", len(refs), refs)
+	}
+	if refs[0].Path != filepath.Join(tmpDir, "login.yaml") || refs[0].Model != "haiku" {
+		t.Errorf("unexpected first ref: %+v", refs[0])
+	}
+	if !hasAnyTag(refs[0].Tags, []string{"critical-path"}) {
+		t.Errorf("expected critical-path tag, got %v", refs[0].Tags)
+	}
+	if refs[1].Model != "sonnet" {
+		t.Errorf("expected default model sonnet, got %q", refs[1].Model)
+	}
+}
+
+func TestManifestSourceMissingScenario(t *testing.T) {
+	tmpDir := t.TempDir()
+	manifest := filepath.Join(tmpDir, "suite.yaml")
+	os.WriteFile(manifest, []byte("scenarios:\n  - path: missing.yaml\n"), 0644)
+
+	if _, err := (&ManifestSource{Path: manifest}).Scenarios(); err == nil {
+		t.Error("expected error for missing scenario file")
+	}
+}
+
+func TestHTTPSource(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/suites/smoke.yaml", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("name: smoke\nscenarios:\n  - path: ../scenarios/login/basic.yaml\n    tags: [smoke]\n"))
+	})
+	mux.HandleFunc("/scenarios/login/basic.yaml", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("scenario: basic\n"))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	cacheDir := t.TempDir()
+	source := &HTTPSource{URL: srv.URL + "/suites/smoke.yaml", CacheDir: cacheDir}
+
+	refs, err := source.Scenarios()
+	if err != nil {
+		t.Fatalf("Scenarios failed: %v", err)
+	}
+	if len(refs) != 1 {
+		t.Fatalf("expected 1 scenario, got %d", len(refs))
+	}
+
+	data, err := os.ReadFile(refs[0].Path)
+	if err != nil {
+		t.Fatalf("cached scenario not readable: %v", err)
+	}
+	if string(data) != "scenario: basic\n" {
+		t.Errorf("unexpected cached content: %q", data)
+	}
+	if filepath.Base(filepath.Dir(refs[0].Path)) != "login" {
+		t.Errorf("expected remote directory layout preserved, got %s", refs[0].Path)
+	}
+}
+
+func TestHTTPSourceNotFound(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	defer srv.Close()
+
+	source := &HTTPSource{URL: srv.URL + "/suite.yaml", CacheDir: t.TempDir()}
+	if _, err := source.Scenarios(); err == nil {
+		t.Error("expected error for missing suite")
+	}
+}
+
+func TestRunWithManifestOverrides(t *testing.T) {
+	tmpDir := t.TempDir()
+	os.WriteFile(filepath.Join(tmpDir, "a.yaml"), []byte("scenario: a\n"), 0644)
+	os.WriteFile(filepath.Join(tmpDir, "b.yaml"), []byte("scenario: b\n"), 0644)
+
+	manifest := filepath.Join(tmpDir, "suite.yaml")
+	os.WriteFile(manifest, []byte(`scenarios:
+  - path: a.yaml
+    model: haiku
+    tags: [smoke]
+  - path: b.yaml
+`), 0644)
+
+	config := DefaultConfig()
+	config.OutputDir = tmpDir
+	config.Manifest = manifest
+	config.Model = "sonnet"
+	config.FilterTags = []string{"smoke"}
+	config.SkipPreflight = true
+
+	runner, err := NewRunner(config)
+	if err != nil {
+		t.Fatalf("failed to create runner: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	result, err := runner.Run(ctx)
+	if err != nil {
+		t.Fatalf("batch run failed: %v", err)
+	}
+
+	if result.ScenariosFound != 2 {
+		t.Errorf("expected 2 scenarios found, got %d", result.ScenariosFound)
+	}
+	if result.ScenariosRun != 1 {
+		t.Fatalf("expected 1 scenario run (tag filter), got %d", result.ScenariosRun)
+	}
+	if result.Results[0].Model != "haiku" {
+		t.Errorf("expected model override haiku, got %q", result.Results[0].Model)
+	}
+}
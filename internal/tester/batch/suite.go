@@ -0,0 +1,204 @@
+package batch
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// SuiteFileName is the conventional name of a suite file. Files named
+// <name>.suite.yaml are suites too.
+const SuiteFileName = "suite.yaml"
+
+// SuiteSource resolves a named suite (gt tester batch --suite smoke). Suites
+// are found by searching Dir for suite files and matching their name field
+// (or, without one, the name implied by the file: the directory holding a
+// suite.yaml, or the prefix of <name>.suite.yaml). Suite may also be the
+// path to a suite file.
+type SuiteSource struct {
+	// Suite is the suite name or file path.
+	Suite string
+
+	// Dir is searched for suite files (default: the working directory).
+	Dir string
+}
+
+// Name describes the suite.
+func (s *SuiteSource) Name() string {
+	return "suite " + s.Suite
+}
+
+// Scenarios returns the scenarios of the suite and every suite it
+// includes, with defaults inherited down the include chain.
+func (s *SuiteSource) Scenarios() ([]ScenarioRef, error) {
+	dir := s.Dir
+	if dir == "" {
+		dir = "."
+	}
+	loader := &suiteLoader{dir: dir}
+
+	path := s.Suite
+	if info, err := os.Stat(path); err != nil || info.IsDir() {
+		if path, err = loader.find(s.Suite); err != nil {
+			return nil, err
+		}
+	}
+
+	refs, err := loader.load(path, SuiteEntry{})
+	if err != nil {
+		return nil, err
+	}
+	return dedupeRefs(refs), nil
+}
+
+// suiteLoader resolves local suite files and the suites they include.
+type suiteLoader struct {
+	// dir is searched for suites included by name.
+	dir string
+
+	// index maps suite names to files, built on the first name lookup.
+	index map[string]string
+
+	// stack holds the suites being resolved, to detect include cycles.
+	stack []string
+}
+
+// load resolves the suite at path. inherited are the defaults of the suites
+// including it, which fill in whatever its own defaults leave unset.
+func (l *suiteLoader) load(path string, inherited SuiteEntry) ([]ScenarioRef, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		abs = path
+	}
+	for _, p := range l.stack {
+		if p == abs {
+			return nil, fmt.Errorf("suite include cycle: %s -> %s", strings.Join(l.stack, " -> "), abs)
+		}
+	}
+	l.stack = append(l.stack, abs)
+	defer func() { l.stack = l.stack[:len(l.stack)-1] }()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading suite: %w", err)
+	}
+	suite, err := parseSuiteDefinition(data)
+	if err != nil {
+		return nil, fmt.Errorf("parsing suite %s: %w", path, err)
+	}
+
+	baseDir := filepath.Dir(path)
+	defaults := suite.Defaults.inherit(inherited)
+
+	var refs []ScenarioRef
+	for _, include := range suite.Include {
+		incPath, err := l.resolveInclude(include, baseDir)
+		if err != nil {
+			return nil, fmt.Errorf("suite %s: %w", path, err)
+		}
+		incRefs, err := l.load(incPath, defaults)
+		if err != nil {
+			return nil, err
+		}
+		refs = append(refs, incRefs...)
+	}
+
+	own, err := suiteScenarios(suite, defaults, baseDir)
+	if err != nil {
+		return nil, fmt.Errorf("suite %s: %w", path, err)
+	}
+	return append(refs, own...), nil
+}
+
+// resolveInclude returns the file for an include: a path relative to the
+// including suite, or a suite name.
+func (l *suiteLoader) resolveInclude(include, baseDir string) (string, error) {
+	if isSuiteFile(include) || strings.ContainsRune(include, filepath.Separator) || strings.Contains(include, "/") {
+		p := include
+		if !filepath.IsAbs(p) {
+			p = filepath.Join(baseDir, p)
+		}
+		if _, err := os.Stat(p); err != nil {
+			return "", fmt.Errorf("include %q: %w", include, err)
+		}
+		return p, nil
+	}
+	return l.find(include)
+}
+
+// find returns the suite file for a suite name.
+func (l *suiteLoader) find(name string) (string, error) {
+	if l.index == nil {
+		index, err := indexSuites(l.dir)
+		if err != nil {
+			return "", err
+		}
+		l.index = index
+	}
+	path, ok := l.index[name]
+	if !ok {
+		return "", fmt.Errorf("suite %q not found under %s", name, l.dir)
+	}
+	return path, nil
+}
+
+// indexSuites finds the suite files under dir, keyed by suite name. Hidden
+// directories (including result caches) and node_modules are skipped.
+func indexSuites(dir string) (map[string]string, error) {
+	index := make(map[string]string)
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			name := d.Name()
+			if path != dir && (strings.HasPrefix(name, ".") || name == "node_modules") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !isSuiteFile(d.Name()) {
+			return nil
+		}
+
+		name := suiteNameFromPath(path)
+		if data, err := os.ReadFile(path); err == nil {
+			if suite, err := parseSuiteDefinition(data); err == nil && suite.Name != "" {
+				name = suite.Name
+			}
+		}
+		if prev, ok := index[name]; ok {
+			return fmt.Errorf("suite %q is defined by both %s and %s", name, prev, path)
+		}
+		index[name] = path
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("finding suites: %w", err)
+	}
+	return index, nil
+}
+
+// isSuiteFile reports whether a file name is a suite file: suite.yaml or
+// <name>.suite.yaml (or .yml).
+func isSuiteFile(name string) bool {
+	base := filepath.Base(name)
+	for _, ext := range []string{".yaml", ".yml"} {
+		if base == "suite"+ext || strings.HasSuffix(base, ".suite"+ext) {
+			return true
+		}
+	}
+	return false
+}
+
+// suiteNameFromPath is the name of a suite file without a name field.
+func suiteNameFromPath(path string) string {
+	base := filepath.Base(path)
+	base = strings.TrimSuffix(strings.TrimSuffix(base, ".yaml"), ".yml")
+	if base == "suite" {
+		return filepath.Base(filepath.Dir(path))
+	}
+	return strings.TrimSuffix(base, ".suite")
+}
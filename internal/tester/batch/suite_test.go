@@ -0,0 +1,99 @@
+package batch
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeSuiteFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestSuiteSource(t *testing.T) {
+	dir := t.TempDir()
+	writeSuiteFiles(t, dir, map[string]string{
+		"auth/login.yaml":  "test: true",
+		"auth/logout.yaml": "test: true",
+		"auth/suite.yaml": `defaults:
+  model: haiku
+  tags: [auth]
+scenarios:
+  - glob: "*.yaml"
+`,
+		"checkout/cart.yaml": "test: true",
+		"suites/smoke.suite.yaml": `name: smoke
+include: [auth]
+defaults:
+  model: sonnet
+  environment: production
+  retry:
+    max_attempts: 1
+  tags: [smoke]
+scenarios:
+  - path: ../checkout/cart.yaml
+    environment: staging
+`,
+		// Hidden directories are not searched
+		".suite-cache/suite.yaml": "name: smoke\nscenarios:\n  - path: x.yaml\n",
+	})
+
+	refs, err := (&SuiteSource{Suite: "smoke", Dir: dir}).Scenarios()
+	if err != nil {
+		t.Fatalf("Scenarios: %v", err)
+	}
+	if len(refs) != 3 {
+		t.Fatalf("expected 3 scenarios (suite.yaml is not one), got %d: %+v", len(refs), refs)
+	}
+
+	byName := make(map[string]ScenarioRef)
+	for _, ref := range refs {
+		byName[filepath.Base(ref.Path)] = ref
+	}
+
+	// The included suite keeps its own model and inherits the rest
+	login := byName["login.yaml"]
+	if login.Model != "haiku" || login.Environment != "production" || login.MaxAttempts != 1 {
+		t.Errorf("login = %+v, want haiku/production/1", login)
+	}
+	if strings.Join(login.Tags, ",") != "smoke,auth" {
+		t.Errorf("login tags = %v, want [smoke auth]", login.Tags)
+	}
+
+	cart := byName["cart.yaml"]
+	if cart.Model != "sonnet" || cart.Environment != "staging" {
+		t.Errorf("cart = %+v, want sonnet/staging", cart)
+	}
+
+	// A suite file path works too; the directory names a suite.yaml
+	refs, err = (&SuiteSource{Suite: filepath.Join(dir, "auth", "suite.yaml")}).Scenarios()
+	if err != nil || len(refs) != 2 {
+		t.Errorf("suite by path: %d refs, %v", len(refs), err)
+	}
+
+	if _, err := (&SuiteSource{Suite: "nightly", Dir: dir}).Scenarios(); err == nil {
+		t.Error("expected an error for an unknown suite")
+	}
+}
+
+func TestSuiteSourceIncludeCycle(t *testing.T) {
+	dir := t.TempDir()
+	writeSuiteFiles(t, dir, map[string]string{
+		"a.suite.yaml": "include: [b]\n",
+		"b.suite.yaml": "include: [a.suite.yaml]\n",
+	})
+	_, err := (&SuiteSource{Suite: "a", Dir: dir}).Scenarios()
+	if err == nil || !strings.Contains(err.Error(), "cycle") {
+		t.Errorf("expected an include cycle error, got %v", err)
+	}
+}
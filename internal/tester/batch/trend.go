@@ -0,0 +1,183 @@
+package batch
+
+import (
+	"fmt"
+	"sort"
+)
+
+// TrendComparison compares a batch against a window of recent batches in
+// the same environment. Judging each scenario by the window's majority
+// instead of a single baseline keeps one flaky run from showing up as a
+// regression or a fix.
+type TrendComparison struct {
+	// Window is the number of previous batches requested.
+	Window int `json:"window"`
+
+	// BatchIDs are the batches compared against, newest first. There may be
+	// fewer than Window if the history is short.
+	BatchIDs []string `json:"batch_ids"`
+
+	// PassRate is this batch's pass rate (0-1).
+	PassRate float64 `json:"pass_rate"`
+
+	// WindowPassRate is the mean pass rate of the window batches.
+	WindowPassRate float64 `json:"window_pass_rate"`
+
+	// PassRateDelta is PassRate - WindowPassRate.
+	PassRateDelta float64 `json:"pass_rate_delta"`
+
+	// NewFailures are scenarios failing in a majority of recent runs (this
+	// batch and the window) whose first recent run passed.
+	NewFailures []TrendItem `json:"new_failures,omitempty"`
+
+	// Recurring are scenarios failing now that failed in a majority of
+	// recent runs from the start of the window.
+	Recurring []TrendItem `json:"recurring,omitempty"`
+
+	// Fixed are scenarios passing now that failed in a majority of the
+	// window's runs.
+	Fixed []TrendItem `json:"fixed,omitempty"`
+
+	// Intermittent are scenarios failing now that passed in most recent
+	// runs. They are likely noise and are not treated as regressions.
+	Intermittent []TrendItem `json:"intermittent,omitempty"`
+}
+
+// TrendItem is one scenario's history across the recent runs.
+type TrendItem struct {
+	// Scenario is the scenario name.
+	Scenario string `json:"scenario"`
+
+	// Runs is how many recent runs (this batch included) ran the scenario.
+	Runs int `json:"runs"`
+
+	// Failures is how many of those runs failed or errored.
+	Failures int `json:"failures"`
+
+	// WindowPassRate is the scenario's pass rate over the window alone
+	// (0-1), or -1 if the window never ran it.
+	WindowPassRate float64 `json:"window_pass_rate"`
+}
+
+// Description summarizes the item, e.g. "failed 3/5 recent runs".
+func (t TrendItem) Description() string {
+	return fmt.Sprintf("failed %d/%d recent runs", t.Failures, t.Runs)
+}
+
+// LoadWindow loads the last n completed batches in the runner's environment,
+// newest first, excluding the batch with excludeID.
+func (r *Runner) LoadWindow(excludeID string, n int) ([]*BatchResult, error) {
+	return loadRecentBatches(r.baseDir, r.config.Environment, excludeID, n)
+}
+
+// LoadRecentBatches loads the last n completed batches in an output
+// directory for environment, newest first.
+func LoadRecentBatches(baseDir, environment string, n int) ([]*BatchResult, error) {
+	return loadRecentBatches(baseDir, environment, "", n)
+}
+
+func loadRecentBatches(baseDir, environment, excludeID string, n int) ([]*BatchResult, error) {
+	paths, err := ListBatchManifests(baseDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var window []*BatchResult
+	for i := len(paths) - 1; i >= 0 && len(window) < n; i-- {
+		prev, err := loadManifestFile(paths[i])
+		if err != nil {
+			continue // skip unreadable manifests rather than failing the window
+		}
+		if prev.ID == excludeID || prev.CompletedAt == nil || prev.Config.Environment != environment {
+			continue
+		}
+		window = append(window, prev)
+	}
+	return window, nil
+}
+
+// CompareTrend compares current against window (newest first).
+func CompareTrend(current *BatchResult, window []*BatchResult, n int) *TrendComparison {
+	trend := &TrendComparison{
+		Window:   n,
+		PassRate: passRate(current),
+	}
+
+	// history holds each scenario's outcomes oldest first (true = failing)
+	history := make(map[string][]bool)
+	for i := len(window) - 1; i >= 0; i-- {
+		prev := window[i]
+		trend.BatchIDs = append([]string{prev.ID}, trend.BatchIDs...)
+		trend.WindowPassRate += passRate(prev)
+		for _, sr := range prev.Results {
+			if ran(sr.Status) {
+				history[sr.Scenario] = append(history[sr.Scenario], isFailing(sr.Status))
+			}
+		}
+	}
+	if len(window) > 0 {
+		trend.WindowPassRate /= float64(len(window))
+	}
+	trend.PassRateDelta = trend.PassRate - trend.WindowPassRate
+
+	for _, sr := range current.Results {
+		if !ran(sr.Status) {
+			continue
+		}
+		prior := history[sr.Scenario]
+		failing := isFailing(sr.Status)
+		recent := append(append([]bool{}, prior...), failing)
+
+		item := TrendItem{Scenario: sr.Scenario, Runs: len(recent), WindowPassRate: -1}
+		for _, f := range recent {
+			if f {
+				item.Failures++
+			}
+		}
+		priorFailures := item.Failures
+		if failing {
+			priorFailures--
+		}
+		if len(prior) > 0 {
+			item.WindowPassRate = 1 - float64(priorFailures)/float64(len(prior))
+		}
+
+		switch {
+		case failing && item.Failures*2 > item.Runs && !recent[0]:
+			trend.NewFailures = append(trend.NewFailures, item)
+		case failing && item.Failures*2 > item.Runs:
+			trend.Recurring = append(trend.Recurring, item)
+		case failing:
+			trend.Intermittent = append(trend.Intermittent, item)
+		case len(prior) > 0 && priorFailures*2 > len(prior):
+			trend.Fixed = append(trend.Fixed, item)
+		}
+	}
+
+	for _, items := range [][]TrendItem{trend.NewFailures, trend.Recurring, trend.Fixed, trend.Intermittent} {
+		sort.Slice(items, func(i, j int) bool {
+			return items[i].Scenario < items[j].Scenario
+		})
+	}
+	return trend
+}
+
+// passRate is the share of run scenarios that passed (0 if none ran).
+func passRate(result *BatchResult) float64 {
+	s := result.Summary
+	total := s.Passed + s.Failed + s.Errors
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Passed) / float64(total)
+}
+
+// ran reports whether a status is the outcome of an actual run.
+func ran(status RunStatus) bool {
+	return status == StatusPassed || status == StatusFailed || status == StatusError
+}
+
+// isFailing reports whether a status counts as a failure.
+func isFailing(status RunStatus) bool {
+	return status == StatusFailed || status == StatusError
+}
@@ -0,0 +1,116 @@
+package batch
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeTrendBatch writes a batch manifest with the given scenario outcomes.
+func writeTrendBatch(t *testing.T, baseDir, date, id, env string, complete bool, statuses map[string]RunStatus) *BatchResult {
+	t.Helper()
+	result := &BatchResult{ID: id, Config: Config{Environment: env}}
+	if complete {
+		now := time.Now()
+		result.CompletedAt = &now
+	}
+	for scenario, status := range statuses {
+		result.Results = append(result.Results, ScenarioResult{Scenario: scenario, Status: status})
+		switch status {
+		case StatusPassed:
+			result.Summary.Passed++
+		case StatusFailed:
+			result.Summary.Failed++
+		}
+	}
+
+	dir := filepath.Join(baseDir, date, "batch-"+id)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := json.Marshal(result)
+	if err := os.WriteFile(filepath.Join(dir, "manifest.json"), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	return result
+}
+
+func TestCompareTrend(t *testing.T) {
+	tmpDir := t.TempDir()
+	p, f := StatusPassed, StatusFailed
+
+	// Window history, oldest first: checkout starts failing mid-window,
+	// search always fails, login always passes, profile was failing
+	history := [][5]RunStatus{
+		// checkout, search, login, profile, home
+		{p, f, p, f, p},
+		{p, f, p, f, p},
+		{f, f, p, f, p},
+		{f, f, p, p, p},
+	}
+	names := []string{"checkout", "search", "login", "profile", "home"}
+	for i, row := range history {
+		statuses := make(map[string]RunStatus)
+		for j, status := range row {
+			statuses[names[j]] = status
+		}
+		date := time.Date(2026, 3, 1+i, 0, 0, 0, 0, time.UTC).Format("2006-01-02")
+		writeTrendBatch(t, tmpDir, date, "win"+string(rune('a'+i)), "staging", true, statuses)
+	}
+	// Neither other environments nor interrupted batches count
+	writeTrendBatch(t, tmpDir, "2026-03-05", "prod0001", "production", true, map[string]RunStatus{"checkout": p})
+	writeTrendBatch(t, tmpDir, "2026-03-05", "partial1", "staging", false, map[string]RunStatus{"checkout": p})
+
+	current := writeTrendBatch(t, tmpDir, "2026-03-06", "current1", "staging", true, map[string]RunStatus{
+		"checkout": f, "search": f, "login": f, "profile": p, "home": p,
+	})
+
+	runner, err := NewRunner(Config{OutputDir: tmpDir, Environment: "staging"})
+	if err != nil {
+		t.Fatalf("NewRunner failed: %v", err)
+	}
+	window, err := runner.LoadWindow(current.ID, 5)
+	if err != nil {
+		t.Fatalf("LoadWindow failed: %v", err)
+	}
+	if len(window) != 4 || window[0].ID != "wind" || window[3].ID != "wina" {
+		var ids []string
+		for _, w := range window {
+			ids = append(ids, w.ID)
+		}
+		t.Fatalf("Unexpected window %v", ids)
+	}
+
+	trend := CompareTrend(current, window, 5)
+
+	check := func(name string, items []TrendItem, want ...string) {
+		t.Helper()
+		if len(items) != len(want) {
+			t.Errorf("%s = %+v, want %v", name, items, want)
+			return
+		}
+		for i, w := range want {
+			if items[i].Scenario != w {
+				t.Errorf("%s[%d] = %s, want %s", name, i, items[i].Scenario, w)
+			}
+		}
+	}
+	check("NewFailures", trend.NewFailures, "checkout")
+	check("Recurring", trend.Recurring, "search")
+	check("Intermittent", trend.Intermittent, "login")
+	check("Fixed", trend.Fixed, "profile")
+
+	if item := trend.NewFailures[0]; item.Runs != 5 || item.Failures != 3 || item.WindowPassRate != 0.5 {
+		t.Errorf("Unexpected checkout item: %+v", item)
+	}
+
+	// Current passes 2/5; the window averages (3+3+2+3)/20 passes per run
+	if trend.PassRate != 0.4 || trend.WindowPassRate != 0.55 {
+		t.Errorf("PassRate = %v, WindowPassRate = %v", trend.PassRate, trend.WindowPassRate)
+	}
+	if d := trend.PassRateDelta; d > -0.149 || d < -0.151 {
+		t.Errorf("PassRateDelta = %v, want -0.15", d)
+	}
+}
@@ -0,0 +1,112 @@
+package batch
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// TriageAction is the decision made for a new issue during triage.
+type TriageAction string
+
+const (
+	// TriageFiled means a bead was filed for the issue.
+	TriageFiled TriageAction = "filed"
+
+	// TriageQuarantined means the scenario was quarantined.
+	TriageQuarantined TriageAction = "quarantined"
+
+	// TriageKnownFlake means the failure is a known flake and needs no action.
+	TriageKnownFlake TriageAction = "known_flake"
+
+	// TriageDismissed means the issue was looked at and dismissed.
+	TriageDismissed TriageAction = "dismissed"
+)
+
+// TriageDecision records how a new issue from a batch comparison was handled.
+type TriageDecision struct {
+	// Scenario and Description identify the comparison item.
+	Scenario    string `json:"scenario"`
+	Description string `json:"description"`
+
+	// Action is what was decided.
+	Action TriageAction `json:"action"`
+
+	// BeadID is the bead filed for the issue (TriageFiled).
+	BeadID string `json:"bead_id,omitempty"`
+
+	// Note is free-form context (e.g., why it was dismissed).
+	Note string `json:"note,omitempty"`
+
+	// DecidedAt is when the decision was made.
+	DecidedAt time.Time `json:"decided_at"`
+
+	// DecidedBy is who made the decision.
+	DecidedBy string `json:"decided_by,omitempty"`
+}
+
+// DecisionFor returns the decision recorded for a comparison item, or nil.
+func (r *BatchResult) DecisionFor(item ComparisonItem) *TriageDecision {
+	for i := range r.Triage {
+		d := &r.Triage[i]
+		if d.Scenario == item.Scenario && d.Description == item.Description {
+			return d
+		}
+	}
+	return nil
+}
+
+// RecordTriage records a decision, replacing any earlier decision for the
+// same item.
+func (r *BatchResult) RecordTriage(d TriageDecision) {
+	if d.DecidedAt.IsZero() {
+		d.DecidedAt = time.Now()
+	}
+	for i := range r.Triage {
+		if r.Triage[i].Scenario == d.Scenario && r.Triage[i].Description == d.Description {
+			r.Triage[i] = d
+			return
+		}
+	}
+	r.Triage = append(r.Triage, d)
+}
+
+// UntriagedIssues returns the comparison's new issues that have no triage
+// decision yet, in comparison order.
+func (r *BatchResult) UntriagedIssues() []ComparisonItem {
+	if r.Comparison == nil {
+		return nil
+	}
+	var items []ComparisonItem
+	for _, item := range r.Comparison.NewIssues {
+		if r.DecisionFor(item) == nil {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// ScenarioResult returns the result for a scenario in this batch, or nil.
+func (r *BatchResult) ScenarioResult(scenario string) *ScenarioResult {
+	for i := range r.Results {
+		if r.Results[i].Scenario == scenario {
+			return &r.Results[i]
+		}
+	}
+	return nil
+}
+
+// SaveBatchResult writes a batch result back to its manifest in an output
+// directory.
+func SaveBatchResult(baseDir string, result *BatchResult) error {
+	path, err := FindBatchManifest(baseDir, result.ID)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding manifest: %w", err)
+	}
+	return os.WriteFile(path, data, 0644)
+}
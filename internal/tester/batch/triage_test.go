@@ -0,0 +1,72 @@
+package batch
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRecordTriage(t *testing.T) {
+	crash := ComparisonItem{Scenario: "checkout", Description: "App crashed", Severity: "P0"}
+	slow := ComparisonItem{Scenario: "login", Description: "Slow load", Severity: "P2"}
+	result := &BatchResult{
+		ID:         "b1",
+		Comparison: &Comparison{NewIssues: []ComparisonItem{crash, slow}},
+	}
+
+	if got := result.UntriagedIssues(); len(got) != 2 {
+		t.Fatalf("UntriagedIssues() = %d items, want 2", len(got))
+	}
+
+	result.RecordTriage(TriageDecision{Scenario: "checkout", Description: "App crashed", Action: TriageKnownFlake})
+	d := result.DecisionFor(crash)
+	if d == nil || d.Action != TriageKnownFlake || d.DecidedAt.IsZero() {
+		t.Fatalf("DecisionFor(crash) = %+v", d)
+	}
+	if result.DecisionFor(slow) != nil {
+		t.Error("expected no decision for untriaged item")
+	}
+
+	// A later decision replaces the earlier one
+	result.RecordTriage(TriageDecision{Scenario: "checkout", Description: "App crashed", Action: TriageFiled, BeadID: "gt-123"})
+	if len(result.Triage) != 1 || result.Triage[0].BeadID != "gt-123" {
+		t.Errorf("Triage = %+v, want single filed decision", result.Triage)
+	}
+
+	got := result.UntriagedIssues()
+	if len(got) != 1 || got[0] != slow {
+		t.Errorf("UntriagedIssues() = %+v, want [slow]", got)
+	}
+}
+
+func TestSaveBatchResultRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	batchDir := filepath.Join(dir, "2026-01-01", "batch-b1")
+	if err := os.MkdirAll(batchDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(batchDir, "manifest.json"), []byte(`{"id":"b1"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := LoadBatchResult(dir, "b1")
+	if err != nil {
+		t.Fatalf("LoadBatchResult failed: %v", err)
+	}
+	result.RecordTriage(TriageDecision{Scenario: "checkout", Description: "App crashed", Action: TriageDismissed, Note: "expected"})
+	if err := SaveBatchResult(dir, result); err != nil {
+		t.Fatalf("SaveBatchResult failed: %v", err)
+	}
+
+	loaded, err := LoadBatchResult(dir, "b1")
+	if err != nil {
+		t.Fatalf("reload failed: %v", err)
+	}
+	if len(loaded.Triage) != 1 || loaded.Triage[0].Note != "expected" {
+		t.Errorf("Triage after reload = %+v", loaded.Triage)
+	}
+
+	if err := SaveBatchResult(dir, &BatchResult{ID: "missing"}); err == nil {
+		t.Error("expected error saving a batch with no manifest")
+	}
+}
@@ -5,6 +5,8 @@ package batch
 
 import (
 	"time"
+
+	"github.com/steveyegge/gastown/internal/tester/flake"
 )
 
 // RunStatus represents the outcome of a single scenario run.
@@ -31,6 +33,11 @@ const (
 
 	// StatusRetrying means the scenario is retrying after an error.
 	StatusRetrying RunStatus = "retrying"
+
+	// StatusAborted means the batch was interrupted before the scenario
+	// finished, or the run hit one of the scenario's abort_if conditions.
+	// Aborted runs never count toward the flake rate.
+	StatusAborted RunStatus = "aborted"
 )
 
 // Config defines the configuration for a batch run.
@@ -38,6 +45,18 @@ type Config struct {
 	// Pattern is the glob pattern for scenario files.
 	Pattern string `json:"pattern" yaml:"pattern"`
 
+	// Manifest is a suite definition file listing scenarios (overrides Pattern).
+	Manifest string `json:"manifest,omitempty" yaml:"manifest,omitempty"`
+
+	// SuiteURL is an HTTP endpoint serving a suite definition (overrides Manifest).
+	SuiteURL string `json:"suite_url,omitempty" yaml:"suite_url,omitempty"`
+
+	// Suite is a named suite (or suite file) to run (overrides Pattern).
+	Suite string `json:"suite,omitempty" yaml:"suite,omitempty"`
+
+	// SuiteDir is searched for suite files. Default: the working directory.
+	SuiteDir string `json:"suite_dir,omitempty" yaml:"suite_dir,omitempty"`
+
 	// Parallel is the number of scenarios to run simultaneously.
 	Parallel int `json:"parallel" yaml:"parallel"`
 
@@ -50,6 +69,15 @@ type Config struct {
 	// Model overrides the model for all scenarios.
 	Model string `json:"model,omitempty" yaml:"model,omitempty"`
 
+	// ABModels runs every scenario once under each of these two models
+	// (overriding Model and source overrides) and compares the results.
+	ABModels []string `json:"ab_models,omitempty" yaml:"ab_models,omitempty"`
+
+	// RotatePersonas runs each scenario that has a persona_pool with the
+	// next persona in its pool, advancing one persona per batch (see
+	// PersonaRotation).
+	RotatePersonas bool `json:"rotate_personas,omitempty" yaml:"rotate_personas,omitempty"`
+
 	// Environment is the target environment.
 	Environment string `json:"environment" yaml:"environment"`
 
@@ -65,11 +93,77 @@ type Config struct {
 	// CompareTo is the previous batch run to compare against.
 	CompareTo string `json:"compare_to,omitempty" yaml:"compare_to,omitempty"`
 
+	// CompareWindow compares the batch against this many previous completed
+	// batches in the same environment. 0 disables the trend comparison.
+	CompareWindow int `json:"compare_window,omitempty" yaml:"compare_window,omitempty"`
+
 	// SkipPreflight skips the preflight checks.
 	SkipPreflight bool `json:"skip_preflight" yaml:"skip_preflight"`
 
 	// OutputDir is the output directory for results.
 	OutputDir string `json:"output_dir" yaml:"output_dir"`
+
+	// Upload is an object storage destination (s3://bucket/prefix or
+	// gs://bucket/prefix) for run artifacts. Empty disables upload.
+	Upload string `json:"upload,omitempty" yaml:"upload,omitempty"`
+
+	// KeepLocalArtifacts keeps artifact files on disk after upload.
+	KeepLocalArtifacts bool `json:"keep_local_artifacts,omitempty" yaml:"keep_local_artifacts,omitempty"`
+
+	// OnlyChanged runs only scenarios affected by the files changed in this
+	// git revision range ("main...HEAD"), or between this revision and the
+	// working tree ("HEAD"). Empty runs every scenario.
+	OnlyChanged string `json:"only_changed,omitempty" yaml:"only_changed,omitempty"`
+
+	// ChangeMap is the change mapping file for OnlyChanged.
+	// Default: ChangeMapFileName in the working directory.
+	ChangeMap string `json:"change_map,omitempty" yaml:"change_map,omitempty"`
+
+	// Journeys is the journey map used to report which journeys skipped
+	// quarantined scenarios cover. Default: JourneysFileName in the working
+	// directory, if it exists.
+	Journeys string `json:"journeys,omitempty" yaml:"journeys,omitempty"`
+
+	// ScheduleID is the schedule that started this batch, if any.
+	ScheduleID string `json:"schedule_id,omitempty" yaml:"schedule_id,omitempty"`
+
+	// A11y runs axe-core accessibility scans at key steps and merges the
+	// violations into each scenario's observations.
+	A11y bool `json:"a11y,omitempty" yaml:"a11y,omitempty"`
+
+	// ResumeOf is the interrupted batch this run resumes, if any.
+	ResumeOf string `json:"resume_of,omitempty" yaml:"resume_of,omitempty"`
+
+	// BuildSHA is the commit of the app build under test, recorded on each
+	// run so flakiness can be traced to the build it started with.
+	BuildSHA string `json:"build_sha,omitempty" yaml:"build_sha,omitempty"`
+
+	// AppVersion is the version of the app build under test.
+	AppVersion string `json:"app_version,omitempty" yaml:"app_version,omitempty"`
+
+	// WarmUp probes each scenario's target URL before running it and defers
+	// the scenario to later in the batch while the app is unavailable.
+	WarmUp bool `json:"warm_up,omitempty" yaml:"warm_up,omitempty"`
+
+	// WarmUpDeferrals is how many times a scenario may be deferred before
+	// it is skipped as unavailable. Default: DefaultWarmUpDeferrals.
+	WarmUpDeferrals int `json:"warm_up_deferrals,omitempty" yaml:"warm_up_deferrals,omitempty"`
+
+	// Sign signs the batch manifest and each run's results with this
+	// method (tester.SignHMAC or tester.SignMinisign). Empty disables it.
+	Sign string `json:"sign,omitempty" yaml:"sign,omitempty"`
+
+	// SigningKey is the HMAC key file, or the minisign secret key.
+	SigningKey string `json:"signing_key,omitempty" yaml:"signing_key,omitempty"`
+
+	// NoRetriesReport compares the batch's pass rate with what it would
+	// have been without retries in the printed summary and summary mail.
+	NoRetriesReport bool `json:"no_retries_report,omitempty" yaml:"no_retries_report,omitempty"`
+
+	// Format is FormatJUnit to also write a JUnit XML report (JUnitFileName)
+	// next to the manifest, for CI test summary views. Empty writes only
+	// the manifest.
+	Format string `json:"format,omitempty" yaml:"format,omitempty"`
 }
 
 // DefaultConfig returns the default batch configuration.
@@ -98,6 +192,23 @@ type ScenarioResult struct {
 	// Duration is how long the scenario took.
 	Duration time.Duration `json:"duration"`
 
+	// Model is the model the scenario ran with (batch or source override).
+	Model string `json:"model,omitempty"`
+
+	// Persona is the persona the scenario ran as: the scenario's own, or
+	// its rotation pick (RotatePersonas).
+	Persona string `json:"persona,omitempty"`
+
+	// Environment is the environment the scenario ran against, when its
+	// source overrides the batch environment.
+	Environment string `json:"environment,omitempty"`
+
+	// MaxAttempts is the retry attempts its source set for the scenario, if any.
+	MaxAttempts int `json:"max_attempts,omitempty"`
+
+	// Network describes the scenario's emulated network profile, if any.
+	Network string `json:"network,omitempty"`
+
 	// Observations is the count of observations by severity.
 	Observations map[string]int `json:"observations"`
 
@@ -110,17 +221,45 @@ type ScenarioResult struct {
 	// RetryCount is how many retries were needed.
 	RetryCount int `json:"retry_count"`
 
+	// RetryErrors are the errors of the attempts that were retried, in
+	// order. They explain what a retry rescued the run from.
+	RetryErrors []string `json:"retry_errors,omitempty"`
+
+	// WarmUpDeferrals is how many times the scenario was deferred because
+	// its target failed the warm-up probe.
+	WarmUpDeferrals int `json:"warm_up_deferrals,omitempty"`
+
+	// A11yViolations is the number of accessibility violations (--a11y runs).
+	// They are also counted in Observations by severity.
+	A11yViolations int `json:"a11y_violations,omitempty"`
+
+	// A11yRules counts accessibility violations by axe rule ID.
+	A11yRules map[string]int `json:"a11y_rules,omitempty"`
+
+	// BudgetExceeded lists the scenario's severity budgets the run's
+	// observations exceeded, turning a pass into a failure.
+	BudgetExceeded []string `json:"budget_exceeded,omitempty"`
+
 	// Error contains the error message if failed.
 	Error string `json:"error,omitempty"`
 
 	// ArtifactDir is the directory containing artifacts.
 	ArtifactDir string `json:"artifact_dir,omitempty"`
 
+	// ArtifactURL is the uploaded run manifest URL (if artifacts were uploaded).
+	ArtifactURL string `json:"artifact_url,omitempty"`
+
 	// Quarantined indicates if this scenario is quarantined.
 	Quarantined bool `json:"quarantined"`
 
 	// SkipReason explains why the scenario was skipped.
 	SkipReason string `json:"skip_reason,omitempty"`
+
+	// Flake is the scenario's flake metrics as of this batch, including
+	// this run. It lets the manifest alone explain a quarantine skip and
+	// drive reports without the flake detector's store. Nil if the scenario
+	// has no run history.
+	Flake *flake.FlakeMetrics `json:"flake,omitempty"`
 }
 
 // BatchResult holds the aggregated results of a batch run.
@@ -134,9 +273,14 @@ type BatchResult struct {
 	// StartedAt is when the batch started.
 	StartedAt time.Time `json:"started_at"`
 
-	// CompletedAt is when the batch completed.
+	// CompletedAt is when the batch completed (nil if it was interrupted).
 	CompletedAt *time.Time `json:"completed_at,omitempty"`
 
+	// Interrupted is true if the batch was cancelled before every scenario
+	// ran. The manifest is partial; aborted scenarios can be re-run with
+	// 'gt tester batch --resume <id>'.
+	Interrupted bool `json:"interrupted,omitempty"`
+
 	// TotalDuration is the total elapsed time.
 	TotalDuration time.Duration `json:"total_duration"`
 
@@ -163,6 +307,25 @@ type BatchResult struct {
 
 	// Comparison holds the comparison to a baseline batch (if --compare-to was used).
 	Comparison *Comparison `json:"comparison,omitempty"`
+
+	// Trend holds the comparison to recent batches (if --compare-window
+	// was used).
+	Trend *TrendComparison `json:"trend,omitempty"`
+
+	// Changes records the changed files and affected scenarios (if
+	// --only-changed was used).
+	Changes *ChangeSelection `json:"changes,omitempty"`
+
+	// Triage records decisions made on the comparison's new issues with
+	// 'gt tester triage'.
+	Triage []TriageDecision `json:"triage,omitempty"`
+
+	// AB compares the models of an A/B batch (if --ab was used).
+	AB *ModelComparison `json:"ab,omitempty"`
+
+	// QuarantineImpact describes the quarantined scenarios skipped by a batch
+	// that passed only because they were skipped.
+	QuarantineImpact *QuarantineImpact `json:"quarantine_impact,omitempty"`
 }
 
 // BatchSummary holds aggregated statistics for a batch run.
@@ -179,15 +342,37 @@ type BatchSummary struct {
 	// Skipped is the count of skipped scenarios.
 	Skipped int `json:"skipped"`
 
+	// Aborted is the count of scenarios cut short by an interrupt.
+	Aborted int `json:"aborted,omitempty"`
+
 	// TotalObservations is the total observation count by severity.
 	TotalObservations map[string]int `json:"total_observations"`
 
 	// TotalRetries is the sum of all retries.
 	TotalRetries int `json:"total_retries"`
 
+	// Retries reports the failures retries rescued (nil if nothing was
+	// retried).
+	Retries *RetryReport `json:"retries,omitempty"`
+
+	// WarmUpDeferrals is the sum of all warm-up deferrals.
+	WarmUpDeferrals int `json:"warm_up_deferrals,omitempty"`
+
+	// A11yViolations is the total accessibility violation count (--a11y runs).
+	A11yViolations int `json:"a11y_violations,omitempty"`
+
+	// A11yRules counts accessibility violations by axe rule ID across the batch.
+	A11yRules map[string]int `json:"a11y_rules,omitempty"`
+
 	// FlakeRate is the calculated flake rate for this batch.
 	FlakeRate float64 `json:"flake_rate"`
 
+	// InfraErrors is the count of errored scenarios attributed to infrastructure.
+	InfraErrors int `json:"infra_errors,omitempty"`
+
+	// InfraErrorRate is the share of scenario runs that hit infrastructure errors.
+	InfraErrorRate float64 `json:"infra_error_rate,omitempty"`
+
 	// NewQuarantineCandidates are scenarios that might need quarantining.
 	NewQuarantineCandidates []string `json:"new_quarantine_candidates,omitempty"`
 
@@ -199,6 +384,9 @@ type BatchSummary struct {
 
 	// FlakyScenarios are scenarios detected as flaky (but not yet quarantined).
 	FlakyScenarios []string `json:"flaky_scenarios,omitempty"`
+
+	// Runbooks maps auto-quarantined and flagged scenarios to their triage runbook URL.
+	Runbooks map[string]string `json:"runbooks,omitempty"`
 }
 
 // PreflightResult holds the result of preflight checks.
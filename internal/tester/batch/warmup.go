@@ -0,0 +1,106 @@
+package batch
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/tester"
+)
+
+const (
+	// DefaultWarmUpDeferrals is how many times a scenario is deferred for an
+	// unavailable target before it is skipped.
+	DefaultWarmUpDeferrals = 2
+
+	// DefaultWarmUpDelay is the minimum wait before re-probing a deferred
+	// scenario's target.
+	DefaultWarmUpDelay = 15 * time.Second
+
+	// warmUpTimeout bounds a single warm-up probe.
+	warmUpTimeout = 5 * time.Second
+)
+
+// WarmUpProbe checks whether a scenario's target URL is ready to test. It
+// returns an error if the app is unavailable.
+type WarmUpProbe func(ctx context.Context, url string) error
+
+// HTTPWarmUpProbe requests url and treats connection errors and 5xx
+// responses (e.g. a 502 from a restarting app) as unavailable. Any other
+// response means the app is up, even if the page itself errors.
+func HTTPWarmUpProbe(ctx context.Context, url string) error {
+	ctx, cancel := context.WithTimeout(ctx, warmUpTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("invalid target URL: %w", err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	if resp.StatusCode >= 500 {
+		return fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// SetWarmUpProbe replaces the probe used when WarmUp is enabled.
+func (r *Runner) SetWarmUpProbe(probe WarmUpProbe) {
+	r.warmUpProbe = probe
+}
+
+// maxWarmUpDeferrals returns the configured deferral limit.
+func (r *Runner) maxWarmUpDeferrals() int {
+	if r.config.WarmUpDeferrals > 0 {
+		return r.config.WarmUpDeferrals
+	}
+	return DefaultWarmUpDeferrals
+}
+
+// warmUp probes a scenario's target URL. Scenarios without a URL are not
+// probed. If the scenario was deferred, it first waits out the rest of the
+// warm-up delay so a restarting app has time to come back.
+func (r *Runner) warmUp(ctx context.Context, scenarioPath string, deferredAt time.Time) error {
+	sc, err := tester.ParseScenarioFile(scenarioPath)
+	if err != nil || sc.Environment.URL == "" {
+		// Let the run itself report unparseable scenarios
+		return nil
+	}
+
+	if !deferredAt.IsZero() {
+		if wait := time.Until(deferredAt.Add(r.warmUpDelay)); wait > 0 {
+			timer := time.NewTimer(wait)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return ctx.Err()
+			case <-timer.C:
+			}
+		}
+	}
+
+	return r.warmUpProbe(ctx, sc.Environment.URL)
+}
+
+// unavailableResult is the result for a scenario whose target stayed
+// unavailable through every deferral. It is skipped rather than failed so
+// infrastructure outages don't count against the scenario's flake data.
+func (r *Runner) unavailableResult(scenarioPath, model string, deferrals int, err error) ScenarioResult {
+	return ScenarioResult{
+		Scenario:        strings.TrimSuffix(filepath.Base(scenarioPath), filepath.Ext(scenarioPath)),
+		Path:            scenarioPath,
+		Status:          StatusSkipped,
+		Model:           model,
+		WarmUpDeferrals: deferrals,
+		SkipReason:      fmt.Sprintf("target unavailable after %d warm-up deferrals: %v", deferrals, err),
+	}
+}
@@ -0,0 +1,115 @@
+package batch
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const warmUpScenario = `
+scenario: %s
+persona: sarah
+goal: Sign in
+success_criteria:
+  - Dashboard shown
+environment:
+  url: https://staging.example.com
+`
+
+func newWarmUpRunner(t *testing.T, names ...string) *Runner {
+	t.Helper()
+	tmpDir := t.TempDir()
+	for _, name := range names {
+		content := strings.Replace(warmUpScenario, "%s", name, 1)
+		if err := os.WriteFile(filepath.Join(tmpDir, name+".yaml"), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	config := DefaultConfig()
+	config.OutputDir = tmpDir
+	config.Pattern = filepath.Join(tmpDir, "*.yaml")
+	config.SkipPreflight = true
+	config.WarmUp = true
+
+	runner, err := NewRunner(config)
+	if err != nil {
+		t.Fatalf("failed to create runner: %v", err)
+	}
+	runner.warmUpDelay = 0
+	return runner
+}
+
+func TestWarmUpDefersUnavailableScenario(t *testing.T) {
+	runner := newWarmUpRunner(t, "login")
+	probes := 0
+	runner.SetWarmUpProbe(func(ctx context.Context, url string) error {
+		probes++
+		if probes == 1 {
+			return errors.New("HTTP 502")
+		}
+		return nil
+	})
+
+	result, err := runner.Run(context.Background())
+	if err != nil {
+		t.Fatalf("batch run failed: %v", err)
+	}
+	if probes != 2 {
+		t.Errorf("expected 2 probes, got %d", probes)
+	}
+	if result.Summary.Passed != 1 {
+		t.Errorf("expected 1 passed, got %d", result.Summary.Passed)
+	}
+	if got := result.Results[0].WarmUpDeferrals; got != 1 {
+		t.Errorf("expected 1 deferral, got %d", got)
+	}
+	if result.Summary.WarmUpDeferrals != 1 {
+		t.Errorf("expected summary deferrals 1, got %d", result.Summary.WarmUpDeferrals)
+	}
+}
+
+func TestWarmUpSkipsPersistentlyUnavailableScenario(t *testing.T) {
+	runner := newWarmUpRunner(t, "login", "signup")
+	runner.config.Parallel = 2
+	runner.SetWarmUpProbe(func(ctx context.Context, url string) error {
+		return errors.New("connection refused")
+	})
+
+	result, err := runner.Run(context.Background())
+	if err != nil {
+		t.Fatalf("batch run failed: %v", err)
+	}
+	if result.Summary.Skipped != 2 {
+		t.Fatalf("expected 2 skipped, got %d", result.Summary.Skipped)
+	}
+	for _, sr := range result.Results {
+		if sr.WarmUpDeferrals != DefaultWarmUpDeferrals {
+			t.Errorf("%s: expected %d deferrals, got %d", sr.Scenario, DefaultWarmUpDeferrals, sr.WarmUpDeferrals)
+		}
+		if !strings.Contains(sr.SkipReason, "target unavailable") {
+			t.Errorf("%s: unexpected skip reason %q", sr.Scenario, sr.SkipReason)
+		}
+	}
+}
+
+func TestHTTPWarmUpProbe(t *testing.T) {
+	status := http.StatusBadGateway
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	if err := HTTPWarmUpProbe(context.Background(), server.URL); err == nil {
+		t.Error("expected 502 to be unavailable")
+	}
+	status = http.StatusNotFound
+	if err := HTTPWarmUpProbe(context.Background(), server.URL); err != nil {
+		t.Errorf("expected 404 to count as up, got %v", err)
+	}
+}
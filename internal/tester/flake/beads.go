@@ -0,0 +1,227 @@
+package flake
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+)
+
+// TrackingLabel marks the beads filed for flaky scenarios.
+const TrackingLabel = "gt:flaky-test"
+
+// maxBeadFailures is how many recent failures a tracking bead lists.
+const maxBeadFailures = 5
+
+// BeadStore is the subset of the beads client used to file tracking beads.
+// *beads.Beads satisfies it.
+type BeadStore interface {
+	List(opts beads.ListOptions) ([]*beads.Issue, error)
+	Show(id string) (*beads.Issue, error)
+	Create(opts beads.CreateOptions) (*beads.Issue, error)
+	Update(id string, opts beads.UpdateOptions) error
+	CloseWithReason(reason string, ids ...string) error
+}
+
+// SetBeadStore sets where tracking beads are filed when Config.FileBeads
+// is on.
+func (d *Detector) SetBeadStore(store BeadStore) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.beadStore = store
+}
+
+// TrackingBead returns the ID of a scenario's tracking bead, or "" if none
+// has been filed.
+func (d *Detector) TrackingBead(scenario string) string {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	if hist, ok := d.history[scenario]; ok {
+		return hist.TrackingBead
+	}
+	return ""
+}
+
+// fileBeads files or updates a scenario's tracking bead for each quarantine
+// or flag action, and closes it when the scenario is auto-unquarantined.
+// Each scenario has a single bead, reopened if it flakes again.
+func (d *Detector) fileBeads(actions []QuarantineAction) error {
+	d.mu.RLock()
+	store := d.beadStore
+	d.mu.RUnlock()
+	if !d.config.FileBeads || store == nil {
+		return nil
+	}
+
+	var errs []string
+	for _, action := range actions {
+		var err error
+		switch action.Action {
+		case "quarantine", "flag":
+			err = d.fileBead(store, action)
+		case "unquarantine":
+			err = d.closeBead(store, action)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", action.Scenario, err))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("filing tracking beads: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// fileBead creates the scenario's tracking bead, or refreshes (and if need
+// be reopens) the one already filed. A scenario with no bead in its local
+// history adopts an open tracking bead with its title, e.g. one filed from
+// another clone, rather than filing a duplicate.
+func (d *Detector) fileBead(store BeadStore, action QuarantineAction) error {
+	d.mu.RLock()
+	id := ""
+	if hist, ok := d.history[action.Scenario]; ok {
+		id = hist.TrackingBead
+	}
+	description := d.beadDescription(action)
+	d.mu.RUnlock()
+
+	if id != "" {
+		issue, err := store.Show(id)
+		switch {
+		case err == nil:
+			opts := beads.UpdateOptions{Description: &description}
+			if issue.Status == "closed" {
+				open := "open"
+				opts.Status = &open
+			}
+			return store.Update(id, opts)
+		case errors.Is(err, beads.ErrNotFound):
+			// Deleted by hand; file a new one
+		default:
+			return err
+		}
+	}
+
+	title := "[tester] flaky: " + action.Scenario
+	existing, err := store.List(beads.ListOptions{Status: "open", Label: TrackingLabel, Priority: -1})
+	if err != nil {
+		return err
+	}
+	for _, issue := range existing {
+		if issue.Title == title && issue.ID != id {
+			if err := store.Update(issue.ID, beads.UpdateOptions{Description: &description}); err != nil {
+				return err
+			}
+			return d.setTrackingBead(action.Scenario, issue.ID)
+		}
+	}
+
+	issue, err := store.Create(beads.CreateOptions{
+		Title:       title,
+		Type:        "bug",
+		Priority:    2,
+		Description: description,
+	})
+	if err != nil {
+		return err
+	}
+	if err := store.Update(issue.ID, beads.UpdateOptions{AddLabels: []string{TrackingLabel}}); err != nil {
+		return err
+	}
+	return d.setTrackingBead(action.Scenario, issue.ID)
+}
+
+// setTrackingBead records a scenario's tracking bead in its history.
+func (d *Detector) setTrackingBead(scenario, id string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if hist, ok := d.history[scenario]; ok {
+		hist.TrackingBead = id
+	}
+	return d.save()
+}
+
+// closeBead closes the scenario's tracking bead, keeping its ID so a
+// relapse reopens the same bead.
+func (d *Detector) closeBead(store BeadStore, action QuarantineAction) error {
+	id := d.TrackingBead(action.Scenario)
+	if id == "" {
+		return nil
+	}
+	err := store.CloseWithReason(action.Reason, id)
+	if errors.Is(err, beads.ErrNotFound) {
+		return nil
+	}
+	return err
+}
+
+// beadDescription renders a tracking bead's body: why the scenario was
+// flagged, its metrics, and its most recent failures.
+// Caller must hold at least a read lock.
+func (d *Detector) beadDescription(action QuarantineAction) string {
+	var b strings.Builder
+
+	state := "flagged as flaky"
+	if action.Action == "quarantine" {
+		state = "quarantined"
+	}
+	fmt.Fprintf(&b, "Scenario %s was %s on %s.\n\n", action.Scenario, state, action.Timestamp.Format("2006-01-02 15:04"))
+	fmt.Fprintf(&b, "Reason: %s\n", action.Reason)
+	if action.Runbook != "" {
+		fmt.Fprintf(&b, "Runbook: %s\n", action.Runbook)
+	}
+
+	if m := action.Metrics; m != nil {
+		b.WriteString("\n## Metrics\n\n")
+		failed := m.WindowFailures + m.WindowErrors
+		if m.ScoredRuns < m.WindowRuns {
+			failed = m.WindowFailures
+		}
+		if m.Window != "" {
+			fmt.Fprintf(&b, "- Window: %s\n", m.Window)
+		}
+		fmt.Fprintf(&b, "- Flake rate: %.0f%% (%d/%d failed)\n", m.FlakeRate*100, failed, m.ScoredRuns)
+		fmt.Fprintf(&b, "- Success rate: %.0f%%\n", m.SuccessRate*100)
+		fmt.Fprintf(&b, "- Infra error rate: %.0f%%\n", m.InfraErrorRate*100)
+		fmt.Fprintf(&b, "- Consecutive failures: %d\n", m.ConsecutiveFailures)
+		fmt.Fprintf(&b, "- Average retries: %.1f\n", m.AverageRetries)
+		fmt.Fprintf(&b, "- Average duration: %s\n", m.AverageDuration.Round(time.Second))
+	}
+
+	hist, ok := d.history[action.Scenario]
+	if !ok {
+		return b.String()
+	}
+	var failures []RunRecord
+	for _, run := range hist.Runs {
+		if run.Outcome == OutcomeFail || run.Outcome == OutcomeError {
+			failures = append(failures, run)
+			if len(failures) == maxBeadFailures {
+				break
+			}
+		}
+	}
+	if len(failures) == 0 {
+		return b.String()
+	}
+
+	b.WriteString("\n## Recent failures\n\n")
+	for _, run := range failures {
+		fmt.Fprintf(&b, "- %s %s", run.Timestamp.Format("2006-01-02 15:04"), run.Outcome)
+		if run.BatchID != "" {
+			fmt.Fprintf(&b, " (%s)", run.BatchID)
+		}
+		if run.ErrorType != "" {
+			fmt.Fprintf(&b, " [%s]", run.ErrorType)
+		}
+		b.WriteString("\n")
+		if run.Excerpt != "" {
+			for _, line := range strings.Split(run.Excerpt, "\n") {
+				fmt.Fprintf(&b, "    %s\n", line)
+			}
+		}
+	}
+	return b.String()
+}
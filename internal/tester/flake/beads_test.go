@@ -0,0 +1,249 @@
+package flake
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+)
+
+// fakeBeadStore is an in-memory BeadStore.
+type fakeBeadStore struct {
+	issues  map[string]*beads.Issue
+	labels  map[string][]string
+	creates int
+}
+
+func newFakeBeadStore() *fakeBeadStore {
+	return &fakeBeadStore{issues: map[string]*beads.Issue{}, labels: map[string][]string{}}
+}
+
+func (f *fakeBeadStore) List(opts beads.ListOptions) ([]*beads.Issue, error) {
+	var issues []*beads.Issue
+	for id, issue := range f.issues {
+		if opts.Status != "" && opts.Status != "all" && issue.Status != opts.Status {
+			continue
+		}
+		if opts.Label != "" && !containsString(f.labels[id], opts.Label) {
+			continue
+		}
+		issues = append(issues, issue)
+	}
+	return issues, nil
+}
+
+func (f *fakeBeadStore) Show(id string) (*beads.Issue, error) {
+	issue, ok := f.issues[id]
+	if !ok {
+		return nil, beads.ErrNotFound
+	}
+	return issue, nil
+}
+
+func (f *fakeBeadStore) Create(opts beads.CreateOptions) (*beads.Issue, error) {
+	f.creates++
+	issue := &beads.Issue{
+		ID:          fmt.Sprintf("gt-%d", f.creates),
+		Title:       opts.Title,
+		Description: opts.Description,
+		Status:      "open",
+	}
+	f.issues[issue.ID] = issue
+	return issue, nil
+}
+
+func (f *fakeBeadStore) Update(id string, opts beads.UpdateOptions) error {
+	issue, ok := f.issues[id]
+	if !ok {
+		return beads.ErrNotFound
+	}
+	if opts.Description != nil {
+		issue.Description = *opts.Description
+	}
+	if opts.Status != nil {
+		issue.Status = *opts.Status
+	}
+	f.labels[id] = append(f.labels[id], opts.AddLabels...)
+	return nil
+}
+
+func (f *fakeBeadStore) CloseWithReason(reason string, ids ...string) error {
+	for _, id := range ids {
+		issue, ok := f.issues[id]
+		if !ok {
+			return beads.ErrNotFound
+		}
+		issue.Status = "closed"
+	}
+	return nil
+}
+
+func beadTestDetector(store BeadStore) *Detector {
+	config := DefaultConfig()
+	config.MinRuns = 3
+	config.AutoUnquarantine = true
+	config.FileBeads = true
+	d := newMemoryDetector(config)
+	d.SetBeadStore(store)
+	return d
+}
+
+func recordOutcomes(t *testing.T, d *Detector, scenario string, outcomes ...RunOutcome) []QuarantineAction {
+	t.Helper()
+	var actions []QuarantineAction
+	for _, outcome := range outcomes {
+		record := RunRecord{Timestamp: time.Now(), Outcome: outcome, BatchID: "batch-1"}
+		if outcome == OutcomeFail {
+			record.Excerpt = "element #submit not found"
+		}
+		a, err := d.RecordRun(scenario, record)
+		if err != nil {
+			t.Fatalf("RecordRun: %v", err)
+		}
+		actions = append(actions, a...)
+	}
+	return actions
+}
+
+func TestFileBeadsOnQuarantine(t *testing.T) {
+	store := newFakeBeadStore()
+	d := beadTestDetector(store)
+
+	recordOutcomes(t, d, "checkout", OutcomeFail, OutcomeFail, OutcomeFail)
+
+	id := d.TrackingBead("checkout")
+	if id == "" {
+		t.Fatal("expected a tracking bead after quarantine")
+	}
+	issue := store.issues[id]
+	if issue.Title != "[tester] flaky: checkout" {
+		t.Errorf("Title = %q", issue.Title)
+	}
+	for _, want := range []string{"quarantined", "Flake rate: 100%", "## Recent failures", "element #submit not found"} {
+		if !strings.Contains(issue.Description, want) {
+			t.Errorf("description missing %q:\n%s", want, issue.Description)
+		}
+	}
+	if labels := store.labels[id]; len(labels) != 1 || labels[0] != TrackingLabel {
+		t.Errorf("labels = %v, want [%s]", labels, TrackingLabel)
+	}
+}
+
+func TestFileBeadsDedupesAndCloses(t *testing.T) {
+	store := newFakeBeadStore()
+	d := beadTestDetector(store)
+
+	recordOutcomes(t, d, "checkout", OutcomeFail, OutcomeFail, OutcomeFail)
+	id := d.TrackingBead("checkout")
+
+	// Recover: enough passes to clear the 90% bar auto-unquarantines
+	actions := recordOutcomes(t, d, "checkout", repeatOutcome(OutcomePass, 10)...)
+	if !hasAction(actions, "unquarantine") {
+		t.Fatal("expected an auto-unquarantine")
+	}
+	if store.issues[id].Status != "closed" {
+		t.Errorf("bead status after unquarantine = %s, want closed", store.issues[id].Status)
+	}
+
+	// Relapse reopens the same bead rather than filing another
+	recordOutcomes(t, d, "checkout", repeatOutcome(OutcomeFail, 10)...)
+	if store.creates != 1 {
+		t.Errorf("creates = %d, want 1", store.creates)
+	}
+	if got := d.TrackingBead("checkout"); got != id {
+		t.Errorf("TrackingBead = %s, want %s", got, id)
+	}
+	if store.issues[id].Status != "open" {
+		t.Errorf("bead status after relapse = %s, want open", store.issues[id].Status)
+	}
+}
+
+func TestFileBeadsFlagUpdatesOneBead(t *testing.T) {
+	store := newFakeBeadStore()
+	d := beadTestDetector(store)
+	d.config.AutoQuarantine = false
+
+	// Flags fire on every flaky run; they all land on one bead
+	recordOutcomes(t, d, "search", OutcomeFail, OutcomeFail, OutcomeFail, OutcomeFail, OutcomePass)
+	if store.creates != 1 {
+		t.Errorf("creates = %d, want 1", store.creates)
+	}
+	issue := store.issues[d.TrackingBead("search")]
+	if !strings.Contains(issue.Description, "flagged as flaky") {
+		t.Errorf("description should describe a flag:\n%s", issue.Description)
+	}
+}
+
+func TestFileBeadsRecreatesDeletedBead(t *testing.T) {
+	store := newFakeBeadStore()
+	d := beadTestDetector(store)
+	d.config.AutoQuarantine = false
+
+	recordOutcomes(t, d, "search", OutcomeFail, OutcomeFail, OutcomeFail)
+	delete(store.issues, d.TrackingBead("search"))
+
+	recordOutcomes(t, d, "search", OutcomeFail)
+	if store.creates != 2 {
+		t.Errorf("creates = %d, want 2", store.creates)
+	}
+	if _, ok := store.issues[d.TrackingBead("search")]; !ok {
+		t.Error("tracking bead should point at the new bead")
+	}
+}
+
+func TestFileBeadsAdoptsOpenBead(t *testing.T) {
+	store := newFakeBeadStore()
+	other := beadTestDetector(store)
+	recordOutcomes(t, other, "checkout", OutcomeFail, OutcomeFail, OutcomeFail)
+	id := other.TrackingBead("checkout")
+
+	// A detector with its own history (e.g. another clone) finds the open
+	// bead by label and title instead of filing a duplicate
+	d := beadTestDetector(store)
+	recordOutcomes(t, d, "checkout", OutcomeFail, OutcomeFail, OutcomeFail)
+	if store.creates != 1 {
+		t.Errorf("creates = %d, want 1", store.creates)
+	}
+	if got := d.TrackingBead("checkout"); got != id {
+		t.Errorf("TrackingBead = %s, want %s", got, id)
+	}
+}
+
+func TestFileBeadsDisabled(t *testing.T) {
+	store := newFakeBeadStore()
+	d := beadTestDetector(store)
+	d.config.FileBeads = false
+
+	recordOutcomes(t, d, "checkout", OutcomeFail, OutcomeFail, OutcomeFail)
+	if store.creates != 0 {
+		t.Errorf("creates = %d, want 0 with file_beads off", store.creates)
+	}
+}
+
+func repeatOutcome(outcome RunOutcome, n int) []RunOutcome {
+	outcomes := make([]RunOutcome, n)
+	for i := range outcomes {
+		outcomes[i] = outcome
+	}
+	return outcomes
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func hasAction(actions []QuarantineAction, kind string) bool {
+	for _, a := range actions {
+		if a.Action == kind {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,172 @@
+package flake
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// UnknownBuild labels runs recorded without build metadata.
+const UnknownBuild = "unknown"
+
+// BuildLabel identifies the app build a run was against, e.g.
+// "1.4.2 (abc1234)", or UnknownBuild.
+func (r RunRecord) BuildLabel() string {
+	sha := r.BuildSHA
+	if len(sha) > 7 {
+		sha = sha[:7]
+	}
+	switch {
+	case r.AppVersion != "" && sha != "":
+		return fmt.Sprintf("%s (%s)", r.AppVersion, sha)
+	case r.AppVersion != "":
+		return r.AppVersion
+	case sha != "":
+		return sha
+	default:
+		return UnknownBuild
+	}
+}
+
+// MatchesBuild reports whether the run was against build, given as an app
+// version or a (possibly abbreviated) commit SHA.
+func (r RunRecord) MatchesBuild(build string) bool {
+	if build == "" {
+		return false
+	}
+	if r.AppVersion == build {
+		return true
+	}
+	return r.BuildSHA != "" && len(build) >= 4 && strings.HasPrefix(r.BuildSHA, build)
+}
+
+// BuildStats tallies a scenario's runs against one build (or range of builds).
+type BuildStats struct {
+	Build       string    `json:"build"`
+	FirstRun    time.Time `json:"first_run,omitempty"`
+	Runs        int       `json:"runs"`
+	Passes      int       `json:"passes"`
+	Failures    int       `json:"failures"`
+	InfraErrors int       `json:"infra_errors"`
+
+	// FailureRate is scored like FlakeMetrics.FlakeRate.
+	FailureRate float64 `json:"failure_rate"`
+}
+
+// BuildOnset compares a scenario's failure rate on a build with its rate
+// before that build was first tested.
+type BuildOnset struct {
+	Scenario string     `json:"scenario"`
+	Build    string     `json:"build"`
+	Before   BuildStats `json:"before"`
+	On       BuildStats `json:"on"`
+
+	// Started is true when the scenario was below the flake threshold
+	// before the build and at or above it on the build.
+	Started bool `json:"started"`
+}
+
+// BuildTrend groups a scenario's recorded runs by app build, oldest build
+// first. Only the runs still in history are counted.
+func (d *Detector) BuildTrend(scenario string) []BuildStats {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	hist, ok := d.history[scenario]
+	if !ok {
+		return nil
+	}
+	config := d.configFor(scenario)
+
+	// Runs are most recent first; walk oldest first so builds come out in
+	// the order they were first tested.
+	var order []string
+	byBuild := make(map[string][]RunRecord)
+	for i := len(hist.Runs) - 1; i >= 0; i-- {
+		run := hist.Runs[i]
+		label := run.BuildLabel()
+		if _, seen := byBuild[label]; !seen {
+			order = append(order, label)
+		}
+		byBuild[label] = append(byBuild[label], run)
+	}
+
+	stats := make([]BuildStats, 0, len(order))
+	for _, label := range order {
+		stats = append(stats, tallyRuns(label, byBuild[label], config))
+	}
+	return stats
+}
+
+// BuildOnset reports whether a scenario's flakiness started with build,
+// comparing its runs against build with the runs recorded before build was
+// first tested. Returns an error if no recorded run matches build.
+func (d *Detector) BuildOnset(scenario, build string) (*BuildOnset, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	hist, ok := d.history[scenario]
+	if !ok {
+		return nil, fmt.Errorf("no history for %s", scenario)
+	}
+	config := d.configFor(scenario)
+
+	// Runs are most recent first, so the first run against build is the
+	// last match and everything after it is earlier
+	var on []RunRecord
+	first := -1
+	for i, run := range hist.Runs {
+		if run.MatchesBuild(build) {
+			on = append(on, run)
+			first = i
+		}
+	}
+	if first < 0 {
+		return nil, fmt.Errorf("%s has no recorded runs against build %s", scenario, build)
+	}
+
+	o := &BuildOnset{
+		Scenario: scenario,
+		Build:    build,
+		On:       tallyRuns(build, on, config),
+		Before:   tallyRuns("before "+build, hist.Runs[first+1:], config),
+	}
+	if o.Before.Runs > 0 {
+		o.Started = o.Before.FailureRate < config.FlakeThreshold &&
+			o.On.FailureRate >= config.FlakeThreshold
+	}
+	return o, nil
+}
+
+// tallyRuns counts outcomes the way calculateMetricsUnlocked does, without
+// the window.
+func tallyRuns(label string, runs []RunRecord, config Config) BuildStats {
+	s := BuildStats{Build: label}
+	for _, run := range runs {
+		if s.FirstRun.IsZero() || run.Timestamp.Before(s.FirstRun) {
+			s.FirstRun = run.Timestamp
+		}
+		s.Runs++
+		switch run.Outcome {
+		case OutcomePass:
+			s.Passes++
+		case OutcomeFail:
+			s.Failures++
+		case OutcomeError:
+			if run.InfrastructureError {
+				s.InfraErrors++
+			} else {
+				s.Failures++
+			}
+		}
+	}
+
+	failures, scored := s.Failures+s.InfraErrors, s.Runs
+	if config.ExcludeInfraErrors {
+		failures, scored = s.Failures, s.Runs-s.InfraErrors
+	}
+	if scored > 0 {
+		s.FailureRate = float64(failures) / float64(scored)
+	}
+	return s
+}
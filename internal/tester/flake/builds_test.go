@@ -0,0 +1,86 @@
+package flake
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestBuildLabelAndMatch(t *testing.T) {
+	r := RunRecord{BuildSHA: "abc1234def5678", AppVersion: "1.4.2"}
+	if got := r.BuildLabel(); got != "1.4.2 (abc1234)" {
+		t.Errorf("BuildLabel() = %q", got)
+	}
+	if (RunRecord{}).BuildLabel() != UnknownBuild {
+		t.Error("expected unknown label without build metadata")
+	}
+
+	for build, want := range map[string]bool{
+		"1.4.2":          true,
+		"abc1234":        true,
+		"abc1234def5678": true,
+		"abc":            false, // too short to be a SHA prefix
+		"1.4":            false,
+		"":               false,
+	} {
+		if got := r.MatchesBuild(build); got != want {
+			t.Errorf("MatchesBuild(%q) = %v, want %v", build, got, want)
+		}
+	}
+}
+
+func TestBuildTrendAndOnset(t *testing.T) {
+	config := DefaultConfig()
+	config.AutoQuarantine = false
+	detector, err := NewDetector(filepath.Join(t.TempDir(), "flake.json"), config)
+	if err != nil {
+		t.Fatalf("NewDetector failed: %v", err)
+	}
+
+	start := time.Now().Add(-time.Hour)
+	record := func(i int, version string, outcome RunOutcome) {
+		_, err := detector.RecordRun("checkout", RunRecord{
+			Timestamp:  start.Add(time.Duration(i) * time.Minute),
+			Outcome:    outcome,
+			AppVersion: version,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Stable on 1.0 and 1.1, flaky from 1.2
+	for i, o := range []RunOutcome{OutcomePass, OutcomePass, OutcomePass, OutcomeFail} {
+		record(i, "1.0", o)
+	}
+	for i := 0; i < 3; i++ {
+		record(10+i, "1.1", OutcomePass)
+	}
+	for i, o := range []RunOutcome{OutcomeFail, OutcomePass, OutcomeFail, OutcomeFail} {
+		record(20+i, "1.2", o)
+	}
+
+	trend := detector.BuildTrend("checkout")
+	if len(trend) != 3 || trend[0].Build != "1.0" || trend[2].Build != "1.2" {
+		t.Fatalf("BuildTrend() = %+v", trend)
+	}
+	if trend[0].FailureRate != 0.25 || trend[2].FailureRate != 0.75 {
+		t.Errorf("failure rates = %v, %v", trend[0].FailureRate, trend[2].FailureRate)
+	}
+
+	onset, err := detector.BuildOnset("checkout", "1.2")
+	if err != nil {
+		t.Fatalf("BuildOnset failed: %v", err)
+	}
+	if !onset.Started || onset.Before.Runs != 7 || onset.On.Runs != 4 {
+		t.Errorf("BuildOnset(1.2) = %+v, want started with 7 before / 4 on", onset)
+	}
+
+	onset, _ = detector.BuildOnset("checkout", "1.1")
+	if onset.Started {
+		t.Error("flakiness should not be attributed to 1.1, which always passed")
+	}
+
+	if _, err := detector.BuildOnset("checkout", "2.0"); err == nil {
+		t.Error("expected error for a build with no runs")
+	}
+}
@@ -5,12 +5,16 @@ package flake
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"sort"
+	"strings"
 	"sync"
 	"time"
+
+	"github.com/steveyegge/gastown/internal/util"
 )
 
 // Config defines the configuration for flake detection.
@@ -19,6 +23,12 @@ type Config struct {
 	// Default: 10
 	WindowSize int `json:"window_size" yaml:"window_size"`
 
+	// WindowDuration, if set, scores the runs within this long of now
+	// (e.g. "7d") instead of the last WindowSize runs, so scenarios that
+	// run at different frequencies are judged over the same period.
+	// Default: unset (count-based window)
+	WindowDuration Duration `json:"window_duration,omitempty" yaml:"window_duration,omitempty"`
+
 	// FlakeThreshold is the failure rate above which a test is considered flaky.
 	// Range: 0.0 to 1.0. Default: 0.3 (30% failures)
 	FlakeThreshold float64 `json:"flake_threshold" yaml:"flake_threshold"`
@@ -42,6 +52,59 @@ type Config struct {
 	// ConsecutiveFailuresThreshold is the number of consecutive failures before quarantine.
 	// If set > 0, this overrides flake rate detection. Default: 0 (disabled)
 	ConsecutiveFailuresThreshold int `json:"consecutive_failures_threshold" yaml:"consecutive_failures_threshold"`
+
+	// ExcludeInfraErrors keeps infrastructure errors out of quarantine
+	// decisions: they are still recorded and reported as an infra error
+	// rate, but don't count toward flake rate, min runs or failure streaks.
+	// Default: false
+	ExcludeInfraErrors bool `json:"exclude_infra_errors" yaml:"exclude_infra_errors"`
+
+	// Runbooks maps scenarios (by tag or name prefix) to triage runbook URLs
+	// attached to quarantine actions.
+	Runbooks []RunbookRule `json:"runbooks,omitempty" yaml:"runbooks,omitempty"`
+
+	// DefaultRunbook is the runbook URL template used when no rule matches.
+	DefaultRunbook string `json:"default_runbook,omitempty" yaml:"default_runbook,omitempty"`
+
+	// WebhookURL receives a JSON POST for each auto-quarantine or flag action.
+	WebhookURL string `json:"webhook_url,omitempty" yaml:"webhook_url,omitempty"`
+
+	// FileBeads files a tracking bead for each quarantined or flagged
+	// scenario, with its metrics and recent failures, and closes it when
+	// the scenario is auto-unquarantined. Default: false
+	FileBeads bool `json:"file_beads,omitempty" yaml:"file_beads,omitempty"`
+}
+
+// Policy overrides Config for a single scenario. Zero fields keep the
+// detector's config.
+type Policy struct {
+	// WindowSize overrides Config.WindowSize. It also switches a
+	// time-based config back to a count-based window for the scenario.
+	WindowSize int `json:"window_size,omitempty"`
+
+	// FlakeThreshold overrides Config.FlakeThreshold.
+	FlakeThreshold float64 `json:"flake_threshold,omitempty"`
+
+	// AutoQuarantine overrides Config.AutoQuarantine.
+	AutoQuarantine *bool `json:"auto_quarantine,omitempty"`
+}
+
+// apply returns c with the policy's overrides.
+func (p *Policy) apply(c Config) Config {
+	if p == nil {
+		return c
+	}
+	if p.WindowSize > 0 {
+		c.WindowSize = p.WindowSize
+		c.WindowDuration = 0
+	}
+	if p.FlakeThreshold > 0 {
+		c.FlakeThreshold = p.FlakeThreshold
+	}
+	if p.AutoQuarantine != nil {
+		c.AutoQuarantine = *p.AutoQuarantine
+	}
+	return c
 }
 
 // DefaultConfig returns the default flake detection configuration.
@@ -89,6 +152,18 @@ type RunRecord struct {
 
 	// InfrastructureError indicates if this was an infra vs test failure.
 	InfrastructureError bool `json:"infrastructure_error,omitempty"`
+
+	// Tags are the scenario's tags at run time (used for runbook matching).
+	Tags []string `json:"tags,omitempty"`
+
+	// BuildSHA is the commit of the app build the run was against.
+	BuildSHA string `json:"build_sha,omitempty"`
+
+	// AppVersion is the version of the app build the run was against.
+	AppVersion string `json:"app_version,omitempty"`
+
+	// Excerpt is the start of the failure message, for failed runs.
+	Excerpt string `json:"excerpt,omitempty"`
 }
 
 // ScenarioHistory tracks the run history for a single scenario.
@@ -122,6 +197,16 @@ type ScenarioHistory struct {
 
 	// ConsecutivePasses is the current consecutive pass count.
 	ConsecutivePasses int `json:"consecutive_passes"`
+
+	// Policy is the scenario's own flake policy, as of its last run.
+	Policy *Policy `json:"policy,omitempty"`
+
+	// TrackingBead is the bead filed for the scenario when it was
+	// quarantined or flagged (Config.FileBeads).
+	TrackingBead string `json:"tracking_bead,omitempty"`
+
+	// Reviews are the quarantine reviews of the scenario, oldest first.
+	Reviews []ReviewRecord `json:"reviews,omitempty"`
 }
 
 // FlakeMetrics contains calculated flake metrics for a scenario.
@@ -135,6 +220,13 @@ type FlakeMetrics struct {
 	// SuccessRate is the success rate over the window (0.0 to 1.0).
 	SuccessRate float64 `json:"success_rate"`
 
+	// WindowMode is how the window was chosen: WindowModeRuns (the last
+	// N runs) or WindowModeTime (the runs within a duration).
+	WindowMode string `json:"window_mode"`
+
+	// Window describes the window, e.g. "last 10 runs" or "last 7d".
+	Window string `json:"window"`
+
 	// WindowRuns is the number of runs in the window.
 	WindowRuns int `json:"window_runs"`
 
@@ -147,6 +239,13 @@ type FlakeMetrics struct {
 	// WindowErrors is the number of errors in the window.
 	WindowErrors int `json:"window_errors"`
 
+	// ScoredRuns is the number of window runs counted toward FlakeRate and
+	// SuccessRate. It excludes infra errors when ExcludeInfraErrors is set.
+	ScoredRuns int `json:"scored_runs"`
+
+	// InfraErrorRate is the infrastructure error rate over the window (0.0 to 1.0).
+	InfraErrorRate float64 `json:"infra_error_rate"`
+
 	// IsFlaky indicates if the test is considered flaky.
 	IsFlaky bool `json:"is_flaky"`
 
@@ -194,6 +293,30 @@ type QuarantineEntry struct {
 
 	// Notes contains any manual notes about the quarantine.
 	Notes string `json:"notes,omitempty"`
+
+	// Runbook is the triage runbook URL for this scenario.
+	Runbook string `json:"runbook,omitempty"`
+
+	// Owner is who is responsible for fixing the scenario.
+	Owner string `json:"owner,omitempty"`
+
+	// ExpiresAt is when the quarantine lapses. Expired entries no longer
+	// cause the scenario to be skipped.
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+
+	// ReviewedBy is who reviewed the entry (gt tester quarantine review).
+	ReviewedBy string `json:"reviewed_by,omitempty"`
+
+	// ReviewedAt is when the entry was reviewed.
+	ReviewedAt *time.Time `json:"reviewed_at,omitempty"`
+
+	// ReviewDecision is ReviewApproved or ReviewRejected.
+	ReviewDecision string `json:"review_decision,omitempty"`
+}
+
+// Expired reports whether the quarantine has lapsed as of now.
+func (e *QuarantineEntry) Expired(now time.Time) bool {
+	return e.ExpiresAt != nil && !now.Before(*e.ExpiresAt)
 }
 
 // QuarantineAction represents an action taken by the detector.
@@ -210,10 +333,17 @@ type QuarantineAction struct {
 	// Metrics contains the metrics that triggered the action.
 	Metrics *FlakeMetrics `json:"metrics,omitempty"`
 
+	// Runbook is the triage runbook URL for the scenario (if configured).
+	Runbook string `json:"runbook,omitempty"`
+
 	// Timestamp is when the action was taken.
 	Timestamp time.Time `json:"timestamp"`
 }
 
+// DataFileName is the detector's history and quarantine store in a results
+// directory.
+const DataFileName = ".flake-data.json"
+
 // Detector tracks test run history and detects flaky tests.
 type Detector struct {
 	config      Config
@@ -225,16 +355,40 @@ type Detector struct {
 	// quarantine maps scenario name to quarantine entry.
 	quarantine map[string]*QuarantineEntry
 
+	// policies holds per-scenario overrides set for the next recorded run.
+	policies map[string]*Policy
+
+	// beadStore receives tracking beads (nil disables filing).
+	beadStore BeadStore
+
+	// now is the clock time-based windows are measured against.
+	now func() time.Time
+
 	mu sync.RWMutex
 }
 
 // NewDetector creates a new flake detector.
 func NewDetector(storagePath string, config Config) (*Detector, error) {
+	d := newMemoryDetector(config)
+	d.storagePath = storagePath
+
+	// Load existing data
+	if err := d.load(); err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to load flake data: %w", err)
+	}
+
+	return d, nil
+}
+
+// newMemoryDetector creates a detector with no storage, applying defaults
+// for zero config values.
+func newMemoryDetector(config Config) *Detector {
 	d := &Detector{
-		config:      config,
-		storagePath: storagePath,
-		history:     make(map[string]*ScenarioHistory),
-		quarantine:  make(map[string]*QuarantineEntry),
+		config:     config,
+		history:    make(map[string]*ScenarioHistory),
+		quarantine: make(map[string]*QuarantineEntry),
+		policies:   make(map[string]*Policy),
+		now:        time.Now,
 	}
 
 	// Apply defaults for zero values
@@ -250,17 +404,64 @@ func NewDetector(storagePath string, config Config) (*Detector, error) {
 	if d.config.UnquarantineThreshold <= 0 {
 		d.config.UnquarantineThreshold = 0.9
 	}
+	return d
+}
 
-	// Load existing data
-	if err := d.load(); err != nil && !os.IsNotExist(err) {
-		return nil, fmt.Errorf("failed to load flake data: %w", err)
-	}
+// SetPolicy sets a scenario's flake policy. It is saved with the
+// scenario's history on its next recorded run and applies to that run's
+// quarantine decision. A nil policy reverts the scenario to the detector
+// config.
+func (d *Detector) SetPolicy(scenario string, policy *Policy) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.policies[scenario] = policy
+}
 
-	return d, nil
+// configFor returns the config in effect for a scenario.
+// Caller must hold at least a read lock.
+func (d *Detector) configFor(scenario string) Config {
+	if hist, ok := d.history[scenario]; ok {
+		return hist.Policy.apply(d.config)
+	}
+	return d.config
 }
 
 // RecordRun records a test run outcome and returns any quarantine actions.
+// Quarantine and flag actions are posted to the configured webhook and
+// filed as tracking beads; a failure of either is returned as an error
+// alongside the actions.
 func (d *Detector) RecordRun(scenario string, record RunRecord) ([]QuarantineAction, error) {
+	actions, err := d.recordRun(scenario, record)
+	if err != nil {
+		return actions, err
+	}
+	notifyErr := d.notify(actions)
+	beadErr := d.fileBeads(actions)
+	return actions, errors.Join(notifyErr, beadErr)
+}
+
+// notify posts quarantine and flag actions to the webhook, if configured.
+func (d *Detector) notify(actions []QuarantineAction) error {
+	if d.config.WebhookURL == "" {
+		return nil
+	}
+	var errs []string
+	for _, action := range actions {
+		if action.Action != "quarantine" && action.Action != "flag" {
+			continue
+		}
+		if err := sendWebhook(d.config.WebhookURL, action); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// recordRun updates history and quarantine state under the lock.
+func (d *Detector) recordRun(scenario string, record RunRecord) ([]QuarantineAction, error) {
 	d.mu.Lock()
 	defer d.mu.Unlock()
 
@@ -275,6 +476,11 @@ func (d *Detector) RecordRun(scenario string, record RunRecord) ([]QuarantineAct
 		d.history[scenario] = hist
 	}
 
+	if policy, ok := d.policies[scenario]; ok {
+		hist.Policy = policy
+	}
+	config := d.configFor(scenario)
+
 	// Prepend the new record (most recent first)
 	hist.Runs = append([]RunRecord{record}, hist.Runs...)
 	hist.LastRun = record.Timestamp
@@ -293,6 +499,10 @@ func (d *Detector) RecordRun(scenario string, record RunRecord) ([]QuarantineAct
 	case OutcomeError:
 		if record.InfrastructureError {
 			hist.TotalErrors++
+			if config.ExcludeInfraErrors {
+				// Grid instability says nothing about the scenario
+				break
+			}
 		} else {
 			hist.TotalFailures++
 		}
@@ -300,16 +510,26 @@ func (d *Detector) RecordRun(scenario string, record RunRecord) ([]QuarantineAct
 		hist.ConsecutivePasses = 0
 	}
 
-	// Trim history to window size * 2 (keep some buffer)
-	maxHistory := d.config.WindowSize * 2
-	if len(hist.Runs) > maxHistory {
-		hist.Runs = hist.Runs[:maxHistory]
-	}
+	// Trim history to twice the window (keep some buffer)
+	hist.Runs = config.retainedRuns(hist.Runs, d.now())
 
 	// Calculate metrics and determine actions
 	metrics := d.calculateMetrics(scenario)
 	actions := d.determineActions(scenario, metrics)
 
+	// Attach the triage runbook to new quarantines and flags
+	if runbook := d.config.RunbookFor(scenario, record.Tags); runbook != "" {
+		for i := range actions {
+			if actions[i].Action == "unquarantine" {
+				continue
+			}
+			actions[i].Runbook = runbook
+			if entry, ok := d.quarantine[scenario]; ok && actions[i].Action == "quarantine" {
+				entry.Runbook = runbook
+			}
+		}
+	}
+
 	// Save updated state
 	if err := d.save(); err != nil {
 		return actions, fmt.Errorf("failed to save flake data: %w", err)
@@ -364,12 +584,13 @@ func (d *Detector) GetFlakyScenarios() []*FlakeMetrics {
 	return flaky
 }
 
-// IsQuarantined checks if a scenario is quarantined.
+// IsQuarantined checks if a scenario is quarantined. Expired entries
+// don't count.
 func (d *Detector) IsQuarantined(scenario string) bool {
 	d.mu.RLock()
 	defer d.mu.RUnlock()
-	_, ok := d.quarantine[scenario]
-	return ok
+	entry, ok := d.quarantine[scenario]
+	return ok && !entry.Expired(time.Now())
 }
 
 // GetQuarantineEntry returns the quarantine entry for a scenario.
@@ -407,31 +628,7 @@ func (d *Detector) Quarantine(scenario, reason string) error {
 	d.mu.Lock()
 	defer d.mu.Unlock()
 
-	var flakeRate float64
-	if hist, ok := d.history[scenario]; ok {
-		metrics := d.calculateMetricsUnlocked(scenario)
-		flakeRate = metrics.FlakeRate
-		now := time.Now()
-		d.quarantine[scenario] = &QuarantineEntry{
-			Scenario:        scenario,
-			QuarantinedAt:   now,
-			Reason:          reason,
-			FlakeRate:       flakeRate,
-			AutoQuarantined: false,
-			ReviewRequired:  false,
-			LastRunAt:       &hist.LastRun,
-		}
-	} else {
-		d.quarantine[scenario] = &QuarantineEntry{
-			Scenario:        scenario,
-			QuarantinedAt:   time.Now(),
-			Reason:          reason,
-			FlakeRate:       0,
-			AutoQuarantined: false,
-			ReviewRequired:  false,
-		}
-	}
-
+	d.quarantine[scenario] = d.newManualEntry(scenario, reason, time.Now())
 	return d.save()
 }
 
@@ -456,6 +653,7 @@ func (d *Detector) GetHistory(scenario string) *ScenarioHistory {
 		for i, r := range hist.Runs {
 			copy.Runs[i] = r
 		}
+		copy.Reviews = append([]ReviewRecord(nil), hist.Reviews...)
 		return &copy
 	}
 	return nil
@@ -478,8 +676,14 @@ func (d *Detector) calculateMetrics(scenario string) *FlakeMetrics {
 
 // calculateMetricsUnlocked calculates metrics without locking.
 func (d *Detector) calculateMetricsUnlocked(scenario string) *FlakeMetrics {
+	config := d.configFor(scenario)
 	metrics := &FlakeMetrics{
-		Scenario: scenario,
+		Scenario:   scenario,
+		WindowMode: WindowModeRuns,
+		Window:     config.WindowLabel(),
+	}
+	if config.timeWindow() {
+		metrics.WindowMode = WindowModeTime
 	}
 
 	hist, ok := d.history[scenario]
@@ -487,17 +691,10 @@ func (d *Detector) calculateMetricsUnlocked(scenario string) *FlakeMetrics {
 		return metrics
 	}
 
-	// Calculate window metrics
-	windowEnd := d.config.WindowSize
-	if windowEnd > len(hist.Runs) {
-		windowEnd = len(hist.Runs)
-	}
-
 	var totalDuration time.Duration
 	var totalRetries int
 
-	for i := 0; i < windowEnd; i++ {
-		run := hist.Runs[i]
+	for _, run := range config.windowRuns(hist.Runs, d.now()) {
 		metrics.WindowRuns++
 		totalDuration += run.Duration
 		totalRetries += run.RetryCount
@@ -517,10 +714,19 @@ func (d *Detector) calculateMetricsUnlocked(scenario string) *FlakeMetrics {
 	}
 
 	// Calculate rates
+	failures := metrics.WindowFailures + metrics.WindowErrors
+	metrics.ScoredRuns = metrics.WindowRuns
+	if config.ExcludeInfraErrors {
+		failures = metrics.WindowFailures
+		metrics.ScoredRuns -= metrics.WindowErrors
+	}
+	if metrics.ScoredRuns > 0 {
+		// Flake rate = (failures + errors) / total, without infra errors if excluded
+		metrics.FlakeRate = float64(failures) / float64(metrics.ScoredRuns)
+		metrics.SuccessRate = float64(metrics.WindowPasses) / float64(metrics.ScoredRuns)
+	}
 	if metrics.WindowRuns > 0 {
-		// Flake rate = (failures + errors) / total
-		metrics.FlakeRate = float64(metrics.WindowFailures+metrics.WindowErrors) / float64(metrics.WindowRuns)
-		metrics.SuccessRate = float64(metrics.WindowPasses) / float64(metrics.WindowRuns)
+		metrics.InfraErrorRate = float64(metrics.WindowErrors) / float64(metrics.WindowRuns)
 		metrics.AverageRetries = float64(totalRetries) / float64(metrics.WindowRuns)
 		metrics.AverageDuration = totalDuration / time.Duration(metrics.WindowRuns)
 	}
@@ -535,20 +741,20 @@ func (d *Detector) calculateMetricsUnlocked(scenario string) *FlakeMetrics {
 	}
 
 	// Determine flaky status
-	if metrics.WindowRuns >= d.config.MinRuns {
-		metrics.IsFlaky = metrics.FlakeRate >= d.config.FlakeThreshold
+	if metrics.ScoredRuns >= config.MinRuns {
+		metrics.IsFlaky = metrics.FlakeRate >= config.FlakeThreshold
 
 		// Also check consecutive failures threshold
-		if d.config.ConsecutiveFailuresThreshold > 0 {
-			if metrics.ConsecutiveFailures >= d.config.ConsecutiveFailuresThreshold {
+		if config.ConsecutiveFailuresThreshold > 0 {
+			if metrics.ConsecutiveFailures >= config.ConsecutiveFailuresThreshold {
 				metrics.IsFlaky = true
 			}
 		}
 	}
 
 	// Determine stable status
-	if metrics.WindowRuns >= d.config.MinRuns {
-		metrics.IsStable = metrics.SuccessRate >= d.config.UnquarantineThreshold
+	if metrics.ScoredRuns >= config.MinRuns {
+		metrics.IsStable = metrics.SuccessRate >= config.UnquarantineThreshold
 	}
 
 	return metrics
@@ -558,16 +764,18 @@ func (d *Detector) calculateMetricsUnlocked(scenario string) *FlakeMetrics {
 // Caller must hold the write lock.
 func (d *Detector) determineActions(scenario string, metrics *FlakeMetrics) []QuarantineAction {
 	var actions []QuarantineAction
-	now := time.Now()
+	now := d.now()
+	config := d.configFor(scenario)
 
-	_, isQuarantined := d.quarantine[scenario]
+	entry, isQuarantined := d.quarantine[scenario]
+	isQuarantined = isQuarantined && !entry.Expired(now)
 
 	// Check for auto-quarantine
-	if !isQuarantined && d.config.AutoQuarantine && metrics.IsFlaky {
-		reason := fmt.Sprintf("Auto-quarantined: %.0f%% failure rate over %d runs",
-			metrics.FlakeRate*100, metrics.WindowRuns)
+	if !isQuarantined && config.AutoQuarantine && metrics.IsFlaky {
+		reason := fmt.Sprintf("Auto-quarantined: %.0f%% failure rate over %d runs%s",
+			metrics.FlakeRate*100, metrics.ScoredRuns, metrics.timeWindowSuffix())
 
-		if d.config.ConsecutiveFailuresThreshold > 0 && metrics.ConsecutiveFailures >= d.config.ConsecutiveFailuresThreshold {
+		if config.ConsecutiveFailuresThreshold > 0 && metrics.ConsecutiveFailures >= config.ConsecutiveFailuresThreshold {
 			reason = fmt.Sprintf("Auto-quarantined: %d consecutive failures",
 				metrics.ConsecutiveFailures)
 		}
@@ -591,11 +799,10 @@ func (d *Detector) determineActions(scenario string, metrics *FlakeMetrics) []Qu
 	}
 
 	// Check for auto-unquarantine
-	if isQuarantined && d.config.AutoUnquarantine && metrics.IsStable {
-		entry := d.quarantine[scenario]
+	if isQuarantined && config.AutoUnquarantine && metrics.IsStable {
 		if entry.AutoQuarantined {
-			reason := fmt.Sprintf("Auto-unquarantined: %.0f%% success rate over %d runs",
-				metrics.SuccessRate*100, metrics.WindowRuns)
+			reason := fmt.Sprintf("Auto-unquarantined: %.0f%% success rate over %d runs%s",
+				metrics.SuccessRate*100, metrics.ScoredRuns, metrics.timeWindowSuffix())
 
 			delete(d.quarantine, scenario)
 
@@ -610,7 +817,7 @@ func (d *Detector) determineActions(scenario string, metrics *FlakeMetrics) []Qu
 	}
 
 	// Flag for review if flaky but not auto-quarantining
-	if !d.config.AutoQuarantine && metrics.IsFlaky && !isQuarantined {
+	if !config.AutoQuarantine && metrics.IsFlaky && !isQuarantined {
 		actions = append(actions, QuarantineAction{
 			Action:    "flag",
 			Scenario:  scenario,
@@ -658,8 +865,12 @@ func (d *Detector) load() error {
 	return nil
 }
 
-// save saves the detector state to disk.
+// save saves the detector state to disk. In-memory detectors (no storage
+// path) have nothing to save.
 func (d *Detector) save() error {
+	if d.storagePath == "" {
+		return nil
+	}
 	storage := storageData{
 		Version:    1,
 		Config:     d.config,
@@ -678,5 +889,5 @@ func (d *Detector) save() error {
 		return fmt.Errorf("failed to create directory: %w", err)
 	}
 
-	return os.WriteFile(d.storagePath, data, 0644)
+	return util.AtomicWriteFile(d.storagePath, data, 0644)
 }
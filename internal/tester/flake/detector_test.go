@@ -147,6 +147,66 @@ func TestAutoQuarantine(t *testing.T) {
 	}
 }
 
+func TestScenarioPolicy(t *testing.T) {
+	storagePath := filepath.Join(t.TempDir(), "flake.json")
+
+	config := DefaultConfig()
+	config.WindowSize = 5
+	config.MinRuns = 3
+
+	detector, err := NewDetector(storagePath, config)
+	if err != nil {
+		t.Fatalf("NewDetector failed: %v", err)
+	}
+
+	// 2 failures in 4 runs: flaky under the global 0.3 threshold, but not
+	// under the scenario's 0.6, and never quarantined automatically
+	noQuarantine := false
+	detector.SetPolicy("exploratory", &Policy{WindowSize: 20, FlakeThreshold: 0.6, AutoQuarantine: &noQuarantine})
+	for _, outcome := range []RunOutcome{OutcomeFail, OutcomePass, OutcomeFail, OutcomePass} {
+		for _, scenario := range []string{"exploratory", "checkout"} {
+			if _, err := detector.RecordRun(scenario, RunRecord{Timestamp: time.Now(), Outcome: outcome}); err != nil {
+				t.Fatalf("RecordRun failed: %v", err)
+			}
+		}
+	}
+
+	if !detector.IsQuarantined("checkout") {
+		t.Error("expected checkout to be quarantined under the global policy")
+	}
+	if detector.GetMetrics("exploratory").IsFlaky {
+		t.Error("expected exploratory to be under its own threshold")
+	}
+
+	// Crossing the scenario threshold flags instead of quarantining
+	actions, err := detector.RecordRun("exploratory", RunRecord{Timestamp: time.Now(), Outcome: OutcomeFail})
+	if err != nil {
+		t.Fatalf("RecordRun failed: %v", err)
+	}
+	actions, err = detector.RecordRun("exploratory", RunRecord{Timestamp: time.Now(), Outcome: OutcomeFail})
+	if err != nil {
+		t.Fatalf("RecordRun failed: %v", err)
+	}
+	if len(actions) != 1 || actions[0].Action != "flag" {
+		t.Errorf("expected a flag action, got %+v", actions)
+	}
+	if detector.IsQuarantined("exploratory") {
+		t.Error("expected exploratory not to be auto-quarantined")
+	}
+
+	// The policy is persisted with the scenario's history
+	reloaded, err := NewDetector(storagePath, config)
+	if err != nil {
+		t.Fatalf("NewDetector failed: %v", err)
+	}
+	if hist := reloaded.GetHistory("exploratory"); hist == nil || hist.Policy == nil || hist.Policy.WindowSize != 20 {
+		t.Errorf("expected persisted policy, got %+v", hist)
+	}
+	if m := reloaded.GetMetrics("exploratory"); m.WindowRuns != 6 {
+		t.Errorf("WindowRuns = %d, want 6 (scenario window 20)", m.WindowRuns)
+	}
+}
+
 func TestManualQuarantine(t *testing.T) {
 	tmpDir := t.TempDir()
 	storagePath := filepath.Join(tmpDir, "flake.json")
@@ -525,6 +585,65 @@ func TestMetricsCalculation(t *testing.T) {
 	}
 }
 
+func TestExcludeInfraErrors(t *testing.T) {
+	tmpDir := t.TempDir()
+	storagePath := filepath.Join(tmpDir, "flake.json")
+
+	config := DefaultConfig()
+	config.ExcludeInfraErrors = true
+	config.ConsecutiveFailuresThreshold = 2
+	detector, err := NewDetector(storagePath, config)
+	if err != nil {
+		t.Fatalf("NewDetector failed: %v", err)
+	}
+
+	// Unstable grid: mostly infra errors around a healthy scenario
+	outcomes := []RunOutcome{OutcomePass, OutcomeError, OutcomeError, OutcomePass, OutcomeError, OutcomePass}
+	for _, outcome := range outcomes {
+		actions, err := detector.RecordRun("grid-victim", RunRecord{
+			Timestamp:           time.Now(),
+			Outcome:             outcome,
+			InfrastructureError: outcome == OutcomeError,
+		})
+		if err != nil {
+			t.Fatalf("RecordRun failed: %v", err)
+		}
+		if len(actions) != 0 {
+			t.Fatalf("Expected no actions for infra errors, got %+v", actions)
+		}
+	}
+
+	if detector.IsQuarantined("grid-victim") {
+		t.Error("Infra errors should not quarantine a healthy scenario")
+	}
+
+	metrics := detector.GetMetrics("grid-victim")
+	if metrics.WindowErrors != 3 || metrics.ScoredRuns != 3 {
+		t.Errorf("Expected 3 errors and 3 scored runs, got %d and %d", metrics.WindowErrors, metrics.ScoredRuns)
+	}
+	if metrics.FlakeRate != 0 || metrics.SuccessRate != 1 {
+		t.Errorf("Expected FlakeRate=0 SuccessRate=1, got %.2f and %.2f", metrics.FlakeRate, metrics.SuccessRate)
+	}
+	if metrics.InfraErrorRate != 0.5 {
+		t.Errorf("Expected InfraErrorRate=0.5, got %.2f", metrics.InfraErrorRate)
+	}
+
+	// Errors are still tracked
+	history := detector.GetHistory("grid-victim")
+	if history.TotalErrors != 3 || history.ConsecutiveFailures != 0 {
+		t.Errorf("Expected TotalErrors=3 ConsecutiveFailures=0, got %d and %d",
+			history.TotalErrors, history.ConsecutiveFailures)
+	}
+
+	// Product failures still quarantine
+	for i := 0; i < 2; i++ {
+		_, _ = detector.RecordRun("grid-victim", RunRecord{Timestamp: time.Now(), Outcome: OutcomeFail})
+	}
+	if !detector.IsQuarantined("grid-victim") {
+		t.Error("Expected product failures to quarantine the scenario")
+	}
+}
+
 func TestDefaultConfig(t *testing.T) {
 	config := DefaultConfig()
 
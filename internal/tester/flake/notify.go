@@ -0,0 +1,102 @@
+package flake
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/util"
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigFileName is the flake configuration file stored next to the flake
+// data in a results directory.
+const ConfigFileName = ".flake-config.yaml"
+
+// RunbookRule maps scenarios to a triage runbook. A rule matches when the
+// scenario has Tag or its name starts with ScenarioPrefix; rules are checked
+// in order and the first match wins.
+type RunbookRule struct {
+	// Tag matches scenarios carrying this tag.
+	Tag string `json:"tag,omitempty" yaml:"tag,omitempty"`
+
+	// ScenarioPrefix matches scenarios whose name starts with this prefix.
+	ScenarioPrefix string `json:"scenario_prefix,omitempty" yaml:"scenario_prefix,omitempty"`
+
+	// URL is the runbook URL template. {scenario} and {tag} are replaced
+	// with the scenario name and the matched tag.
+	URL string `json:"url" yaml:"url"`
+}
+
+// LoadConfig loads flake configuration from a YAML or JSON file, filling
+// unset fields from DefaultConfig. A missing file yields the defaults.
+func LoadConfig(path string) (Config, error) {
+	config := DefaultConfig()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return config, nil
+		}
+		return config, fmt.Errorf("failed to read flake config: %w", err)
+	}
+
+	// YAML is a superset of JSON, so one decoder handles both
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return config, fmt.Errorf("failed to parse flake config %s: %w", path, err)
+	}
+	return config, nil
+}
+
+// RunbookFor returns the runbook URL for a scenario with the given tags,
+// or DefaultRunbook if no rule matches.
+func (c Config) RunbookFor(scenario string, tags []string) string {
+	for _, rule := range c.Runbooks {
+		if rule.URL == "" {
+			continue
+		}
+		if rule.Tag != "" {
+			for _, t := range tags {
+				if strings.EqualFold(t, rule.Tag) {
+					return expandRunbook(rule.URL, scenario, t)
+				}
+			}
+		}
+		if rule.ScenarioPrefix != "" && strings.HasPrefix(scenario, rule.ScenarioPrefix) {
+			return expandRunbook(rule.URL, scenario, "")
+		}
+	}
+	if c.DefaultRunbook != "" {
+		return expandRunbook(c.DefaultRunbook, scenario, "")
+	}
+	return ""
+}
+
+func expandRunbook(tmpl, scenario, tag string) string {
+	return strings.NewReplacer("{scenario}", scenario, "{tag}", tag).Replace(tmpl)
+}
+
+// webhookPayload is the body posted to the quarantine webhook. Text makes
+// the payload render directly in Slack-compatible incoming webhooks.
+type webhookPayload struct {
+	Text   string           `json:"text"`
+	Event  string           `json:"event"`
+	Action QuarantineAction `json:"action"`
+}
+
+// sendWebhook posts a quarantine action to the configured webhook.
+func sendWebhook(url string, action QuarantineAction) error {
+	text := fmt.Sprintf("Test %s: %s - %s", action.Action, action.Scenario, action.Reason)
+	if action.Runbook != "" {
+		text += "\nRunbook: " + action.Runbook
+	}
+
+	if err := util.PostWebhook(url, webhookPayload{
+		Text:   text,
+		Event:  action.Action,
+		Action: action,
+	}); err != nil {
+		return fmt.Errorf("quarantine webhook: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package flake
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRunbookFor(t *testing.T) {
+	config := DefaultConfig()
+	config.Runbooks = []RunbookRule{
+		{Tag: "checkout", URL: "https://wiki/checkout#{scenario}"},
+		{ScenarioPrefix: "registration-", URL: "https://wiki/registration"},
+	}
+	config.DefaultRunbook = "https://wiki/flaky?s={scenario}"
+
+	tests := []struct {
+		scenario string
+		tags     []string
+		want     string
+	}{
+		{"cart-total", []string{"Checkout"}, "https://wiki/checkout#cart-total"},
+		{"registration-flow", nil, "https://wiki/registration"},
+		{"login", []string{"auth"}, "https://wiki/flaky?s=login"},
+	}
+
+	for _, tt := range tests {
+		if got := config.RunbookFor(tt.scenario, tt.tags); got != tt.want {
+			t.Errorf("RunbookFor(%q, %v) = %q, want %q", tt.scenario, tt.tags, got, tt.want)
+		}
+	}
+
+	if got := DefaultConfig().RunbookFor("login", nil); got != "" {
+		t.Errorf("expected no runbook without rules, got %q", got)
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	// Missing file yields defaults
+	config, err := LoadConfig(filepath.Join(tmpDir, ConfigFileName))
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if config.WindowSize != DefaultConfig().WindowSize {
+		t.Errorf("Expected default WindowSize, got %d", config.WindowSize)
+	}
+
+	path := filepath.Join(tmpDir, ConfigFileName)
+	data := "min_runs: 5\nwebhook_url: https://hooks.example.com/x\nrunbooks:\n  - tag: checkout\n    url: https://wiki/checkout\n"
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	config, err = LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if config.MinRuns != 5 || config.WebhookURL != "https://hooks.example.com/x" || len(config.Runbooks) != 1 {
+		t.Errorf("Unexpected config: %+v", config)
+	}
+	if !config.AutoQuarantine {
+		t.Error("Expected AutoQuarantine default to be kept")
+	}
+}
+
+func TestAutoQuarantineRunbookAndWebhook(t *testing.T) {
+	var mu sync.Mutex
+	var received []webhookPayload
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var p webhookPayload
+		if err := json.NewDecoder(r.Body).Decode(&p); err != nil {
+			t.Errorf("decoding webhook: %v", err)
+		}
+		mu.Lock()
+		received = append(received, p)
+		mu.Unlock()
+	}))
+	defer srv.Close()
+
+	config := DefaultConfig()
+	config.MinRuns = 3
+	config.WebhookURL = srv.URL
+	config.Runbooks = []RunbookRule{{Tag: "checkout", URL: "https://wiki/checkout#{scenario}"}}
+
+	detector, err := NewDetector(filepath.Join(t.TempDir(), "flake.json"), config)
+	if err != nil {
+		t.Fatalf("NewDetector failed: %v", err)
+	}
+
+	var actions []QuarantineAction
+	for i := 0; i < 3; i++ {
+		actions, err = detector.RecordRun("cart-total", RunRecord{
+			Timestamp: time.Now(),
+			Outcome:   OutcomeFail,
+			Tags:      []string{"checkout"},
+		})
+		if err != nil {
+			t.Fatalf("RecordRun failed: %v", err)
+		}
+	}
+
+	if len(actions) != 1 || actions[0].Runbook != "https://wiki/checkout#cart-total" {
+		t.Fatalf("Expected quarantine action with runbook, got %+v", actions)
+	}
+	if entry := detector.GetQuarantineEntry("cart-total"); entry == nil || entry.Runbook != actions[0].Runbook {
+		t.Errorf("Expected quarantine entry to carry runbook, got %+v", entry)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(received) != 1 {
+		t.Fatalf("Expected 1 webhook call, got %d", len(received))
+	}
+	if received[0].Event != "quarantine" || received[0].Action.Runbook == "" {
+		t.Errorf("Unexpected webhook payload: %+v", received[0])
+	}
+}
@@ -0,0 +1,268 @@
+package flake
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Quarantine change actions reported by ApplyQuarantine.
+const (
+	ChangeAdd    = "add"
+	ChangeUpdate = "update"
+	ChangeRemove = "remove"
+)
+
+// QuarantineFile declares quarantine state so it can live in version
+// control and be reviewed:
+//
+//	quarantine:
+//	  - scenario: checkout-flow
+//	    reason: Payment sandbox times out (PAY-123)
+//	    owner: alice
+//	    expires: 2026-11-01
+//	remove:
+//	  - registration-flow
+type QuarantineFile struct {
+	// Quarantine lists the scenarios that should be quarantined.
+	Quarantine []QuarantineSpec `json:"quarantine" yaml:"quarantine"`
+
+	// Remove lists scenarios to take out of quarantine.
+	Remove []string `json:"remove,omitempty" yaml:"remove,omitempty"`
+}
+
+// QuarantineSpec declares one quarantine entry.
+type QuarantineSpec struct {
+	// Scenario is the scenario name.
+	Scenario string `json:"scenario" yaml:"scenario"`
+
+	// Reason is why the scenario is quarantined (required).
+	Reason string `json:"reason" yaml:"reason"`
+
+	// Owner is who is responsible for fixing the scenario.
+	Owner string `json:"owner,omitempty" yaml:"owner,omitempty"`
+
+	// Expires is when the quarantine lapses, as YYYY-MM-DD (midnight UTC)
+	// or RFC 3339. Empty means it never expires.
+	Expires string `json:"expires,omitempty" yaml:"expires,omitempty"`
+}
+
+// QuarantineChange describes one change made by ApplyQuarantine.
+type QuarantineChange struct {
+	// Action is ChangeAdd, ChangeUpdate or ChangeRemove.
+	Action string `json:"action"`
+
+	// Scenario is the affected scenario.
+	Scenario string `json:"scenario"`
+
+	// Fields describes changed fields for updates, e.g.
+	// `owner: "" → "alice"`.
+	Fields []string `json:"fields,omitempty"`
+
+	// Entry is the entry after the change (before it, for removals).
+	Entry *QuarantineEntry `json:"entry"`
+}
+
+// LoadQuarantineFile reads and validates a quarantine file (YAML or JSON).
+func LoadQuarantineFile(path string) (*QuarantineFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read quarantine file: %w", err)
+	}
+
+	var file QuarantineFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse quarantine file %s: %w", path, err)
+	}
+	if err := file.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid quarantine file %s: %w", path, err)
+	}
+	return &file, nil
+}
+
+// Validate checks that every entry names a scenario and reason, expiry
+// dates parse, and no scenario is listed twice.
+func (f *QuarantineFile) Validate() error {
+	seen := make(map[string]bool)
+	for i, spec := range f.Quarantine {
+		if spec.Scenario == "" {
+			return fmt.Errorf("quarantine[%d]: scenario is required", i)
+		}
+		if spec.Reason == "" {
+			return fmt.Errorf("quarantine[%d] (%s): reason is required", i, spec.Scenario)
+		}
+		if _, err := spec.expiresAt(); err != nil {
+			return fmt.Errorf("quarantine[%d] (%s): %w", i, spec.Scenario, err)
+		}
+		if seen[spec.Scenario] {
+			return fmt.Errorf("scenario %q is listed more than once", spec.Scenario)
+		}
+		seen[spec.Scenario] = true
+	}
+	for i, scenario := range f.Remove {
+		if scenario == "" {
+			return fmt.Errorf("remove[%d]: scenario is required", i)
+		}
+		if seen[scenario] {
+			return fmt.Errorf("scenario %q is listed more than once", scenario)
+		}
+		seen[scenario] = true
+	}
+	return nil
+}
+
+// expiresAt parses Expires, returning nil if it is unset.
+func (s QuarantineSpec) expiresAt() (*time.Time, error) {
+	return ParseExpiry(s.Expires)
+}
+
+// ParseExpiry parses a quarantine expiry given as YYYY-MM-DD (midnight UTC)
+// or RFC 3339, returning nil if it is empty.
+func ParseExpiry(s string) (*time.Time, error) {
+	if s == "" {
+		return nil, nil
+	}
+	for _, layout := range []string{"2006-01-02", time.RFC3339} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return &t, nil
+		}
+	}
+	return nil, fmt.Errorf("invalid expires %q (want YYYY-MM-DD or RFC 3339)", s)
+}
+
+// ApplyQuarantine brings quarantine state in line with file in a single
+// save: listed scenarios are added or updated, removals are dropped, and
+// with prune, manual entries not listed in the file are dropped too.
+// Auto-quarantined entries are only pruned if explicitly removed; listing
+// one adopts it as a manual entry. With dryRun, the changes are computed
+// but not saved. Changes are returned sorted by scenario.
+func (d *Detector) ApplyQuarantine(file *QuarantineFile, prune, dryRun bool) ([]QuarantineChange, error) {
+	if err := file.Validate(); err != nil {
+		return nil, err
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	now := time.Now()
+	next := make(map[string]*QuarantineEntry, len(d.quarantine))
+	for scenario, entry := range d.quarantine {
+		next[scenario] = entry
+	}
+
+	var changes []QuarantineChange
+	listed := make(map[string]bool)
+	for _, spec := range file.Quarantine {
+		listed[spec.Scenario] = true
+		expiresAt, _ := spec.expiresAt()
+
+		old, ok := d.quarantine[spec.Scenario]
+		if !ok {
+			entry := d.newManualEntry(spec.Scenario, spec.Reason, now)
+			entry.Owner = spec.Owner
+			entry.ExpiresAt = expiresAt
+			next[spec.Scenario] = entry
+			changes = append(changes, QuarantineChange{Action: ChangeAdd, Scenario: spec.Scenario, Entry: entry})
+			continue
+		}
+
+		entry := *old
+		entry.Reason = spec.Reason
+		entry.Owner = spec.Owner
+		entry.ExpiresAt = expiresAt
+		entry.AutoQuarantined = false
+		entry.ReviewRequired = false
+		if fields := diffEntries(old, &entry); len(fields) > 0 {
+			next[spec.Scenario] = &entry
+			changes = append(changes, QuarantineChange{Action: ChangeUpdate, Scenario: spec.Scenario, Fields: fields, Entry: &entry})
+		}
+	}
+
+	remove := make(map[string]bool)
+	for _, scenario := range file.Remove {
+		remove[scenario] = true
+	}
+	if prune {
+		for scenario, entry := range d.quarantine {
+			if !listed[scenario] && !entry.AutoQuarantined {
+				remove[scenario] = true
+			}
+		}
+	}
+	for scenario := range remove {
+		if entry, ok := d.quarantine[scenario]; ok {
+			delete(next, scenario)
+			changes = append(changes, QuarantineChange{Action: ChangeRemove, Scenario: scenario, Entry: entry})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Scenario < changes[j].Scenario
+	})
+
+	if dryRun || len(changes) == 0 {
+		return changes, nil
+	}
+
+	prev := d.quarantine
+	d.quarantine = next
+	if err := d.save(); err != nil {
+		d.quarantine = prev
+		return nil, fmt.Errorf("failed to save flake data: %w", err)
+	}
+	return changes, nil
+}
+
+// newManualEntry builds a manual quarantine entry, filling flake rate, last
+// run and runbook from the scenario's history.
+// Caller must hold at least a read lock.
+func (d *Detector) newManualEntry(scenario, reason string, now time.Time) *QuarantineEntry {
+	entry := &QuarantineEntry{
+		Scenario:      scenario,
+		QuarantinedAt: now,
+		Reason:        reason,
+	}
+	if hist, ok := d.history[scenario]; ok {
+		lastRun := hist.LastRun
+		entry.FlakeRate = d.calculateMetricsUnlocked(scenario).FlakeRate
+		entry.LastRunAt = &lastRun
+		if len(hist.Runs) > 0 {
+			entry.Runbook = d.config.RunbookFor(scenario, hist.Runs[0].Tags)
+		}
+	} else {
+		entry.Runbook = d.config.RunbookFor(scenario, nil)
+	}
+	return entry
+}
+
+// diffEntries describes the declarative fields that differ between two
+// entries.
+func diffEntries(old, new *QuarantineEntry) []string {
+	var fields []string
+	field := func(name, from, to string) {
+		if from != to {
+			fields = append(fields, fmt.Sprintf("%s: %q → %q", name, from, to))
+		}
+	}
+	field("reason", old.Reason, new.Reason)
+	field("owner", old.Owner, new.Owner)
+	field("expires", formatExpiry(old.ExpiresAt), formatExpiry(new.ExpiresAt))
+	if old.AutoQuarantined && !new.AutoQuarantined {
+		fields = append(fields, "auto-quarantined → manual")
+	}
+	return fields
+}
+
+// formatExpiry formats an expiry time for display, or "" if unset.
+func formatExpiry(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	if t.Equal(t.Truncate(24 * time.Hour)) {
+		return t.Format("2006-01-02")
+	}
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,134 @@
+package flake
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestApplyQuarantine(t *testing.T) {
+	tmpDir := t.TempDir()
+	storagePath := filepath.Join(tmpDir, "flake.json")
+
+	detector, err := NewDetector(storagePath, DefaultConfig())
+	if err != nil {
+		t.Fatalf("NewDetector failed: %v", err)
+	}
+	for _, s := range []string{"checkout-flow", "search", "old-manual"} {
+		if err := detector.Quarantine(s, "manual"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Simulate an auto-quarantine, which --prune must leave alone
+	detector.quarantine["auto-flaky"] = &QuarantineEntry{Scenario: "auto-flaky", Reason: "Auto-quarantined", AutoQuarantined: true}
+
+	path := filepath.Join(tmpDir, "quarantine.yaml")
+	content := `quarantine:
+  - scenario: registration-flow
+    reason: Login button flaky
+    owner: alice
+    expires: 2099-01-31
+  - scenario: checkout-flow
+    reason: manual
+    owner: bob
+  - scenario: search
+    reason: manual
+remove:
+  - never-quarantined
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	file, err := LoadQuarantineFile(path)
+	if err != nil {
+		t.Fatalf("LoadQuarantineFile failed: %v", err)
+	}
+
+	// A dry run reports changes without saving
+	changes, err := detector.ApplyQuarantine(file, true, true)
+	if err != nil {
+		t.Fatalf("ApplyQuarantine failed: %v", err)
+	}
+	if len(changes) != 3 {
+		t.Fatalf("Expected 3 changes, got %+v", changes)
+	}
+	if detector.IsQuarantined("registration-flow") {
+		t.Error("Dry run should not quarantine")
+	}
+
+	changes, err = detector.ApplyQuarantine(file, true, false)
+	if err != nil {
+		t.Fatalf("ApplyQuarantine failed: %v", err)
+	}
+	want := []struct{ action, scenario string }{
+		{ChangeUpdate, "checkout-flow"},
+		{ChangeRemove, "old-manual"},
+		{ChangeAdd, "registration-flow"},
+	}
+	for i, w := range want {
+		if changes[i].Action != w.action || changes[i].Scenario != w.scenario {
+			t.Errorf("changes[%d] = %s %s, want %s %s", i, changes[i].Action, changes[i].Scenario, w.action, w.scenario)
+		}
+	}
+	if fields := changes[0].Fields; len(fields) != 1 || fields[0] != `owner: "" → "bob"` {
+		t.Errorf("Unexpected update fields: %v", fields)
+	}
+
+	// Changes are persisted
+	reloaded, err := NewDetector(storagePath, DefaultConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	entry := reloaded.GetQuarantineEntry("registration-flow")
+	if entry == nil || entry.Owner != "alice" || entry.ExpiresAt == nil || entry.ExpiresAt.Format("2006-01-02") != "2099-01-31" {
+		t.Errorf("Unexpected entry: %+v", entry)
+	}
+	if reloaded.IsQuarantined("old-manual") || !reloaded.IsQuarantined("auto-flaky") {
+		t.Error("Prune should remove unlisted manual entries only")
+	}
+
+	// Re-applying is a no-op
+	changes, err = reloaded.ApplyQuarantine(file, true, false)
+	if err != nil || len(changes) != 0 {
+		t.Errorf("Expected no changes, got %+v (%v)", changes, err)
+	}
+}
+
+func TestQuarantineFileValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		file QuarantineFile
+	}{
+		{"missing reason", QuarantineFile{Quarantine: []QuarantineSpec{{Scenario: "a"}}}},
+		{"bad expiry", QuarantineFile{Quarantine: []QuarantineSpec{{Scenario: "a", Reason: "r", Expires: "next week"}}}},
+		{"duplicate", QuarantineFile{Quarantine: []QuarantineSpec{{Scenario: "a", Reason: "r"}}, Remove: []string{"a"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.file.Validate(); err == nil {
+				t.Error("Expected validation error")
+			}
+		})
+	}
+}
+
+func TestExpiredQuarantine(t *testing.T) {
+	detector, err := NewDetector(filepath.Join(t.TempDir(), "flake.json"), DefaultConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	file := &QuarantineFile{Quarantine: []QuarantineSpec{
+		{Scenario: "lapsed", Reason: "r", Expires: time.Now().Add(-time.Hour).Format(time.RFC3339)},
+	}}
+	if _, err := detector.ApplyQuarantine(file, false, false); err != nil {
+		t.Fatal(err)
+	}
+	if detector.IsQuarantined("lapsed") {
+		t.Error("Expired quarantine should not count")
+	}
+	if detector.GetQuarantineEntry("lapsed") == nil {
+		t.Error("Expired entry should still be listed")
+	}
+}
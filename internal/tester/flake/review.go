@@ -0,0 +1,151 @@
+package flake
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Review decisions recorded on reviewed quarantine entries.
+const (
+	ReviewApproved = "approved"
+	ReviewRejected = "rejected"
+)
+
+// QuarantineReview is an operator's decision on a quarantine entry that
+// awaits review.
+type QuarantineReview struct {
+	// Reviewer is who made the decision (required).
+	Reviewer string
+
+	// Approve keeps the scenario quarantined; otherwise it is unquarantined.
+	Approve bool
+
+	// Owner is who is responsible for fixing the scenario (required to
+	// approve).
+	Owner string
+
+	// ExpiresAt is when the approved quarantine lapses (required to approve).
+	ExpiresAt *time.Time
+
+	// Notes are added to the entry's notes, if set.
+	Notes string
+}
+
+// ReviewRecord is a quarantine review kept in the scenario's history, so
+// the decision outlives the entry: a rejected quarantine is removed.
+type ReviewRecord struct {
+	// Reviewer is who made the decision.
+	Reviewer string `json:"reviewer"`
+
+	// Decision is ReviewApproved or ReviewRejected.
+	Decision string `json:"decision"`
+
+	// ReviewedAt is when the decision was made.
+	ReviewedAt time.Time `json:"reviewed_at"`
+
+	// Reason is the quarantine reason that was reviewed.
+	Reason string `json:"reason,omitempty"`
+
+	// Notes are the review's notes, if any.
+	Notes string `json:"notes,omitempty"`
+}
+
+// PendingReview returns the quarantine entries awaiting review, oldest
+// first.
+func (d *Detector) PendingReview() []*QuarantineEntry {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	var entries []*QuarantineEntry
+	for _, entry := range d.quarantine {
+		if entry.ReviewRequired {
+			copy := *entry
+			entries = append(entries, &copy)
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].QuarantinedAt.Before(entries[j].QuarantinedAt)
+	})
+	return entries
+}
+
+// ReviewQuarantine records a review of a scenario's quarantine entry, which
+// must be awaiting review. Approving keeps the scenario quarantined under
+// the review's owner and expiry; rejecting unquarantines it. Either way the
+// reviewer, time and decision are recorded on the returned entry and in the
+// scenario's history.
+func (d *Detector) ReviewQuarantine(scenario string, review QuarantineReview) (*QuarantineEntry, error) {
+	if review.Reviewer == "" {
+		return nil, fmt.Errorf("reviewer is required")
+	}
+	now := time.Now()
+	if review.Approve {
+		if review.Owner == "" {
+			return nil, fmt.Errorf("an owner is required to approve a quarantine")
+		}
+		if review.ExpiresAt == nil {
+			return nil, fmt.Errorf("an expiry is required to approve a quarantine")
+		}
+		if !review.ExpiresAt.After(now) {
+			return nil, fmt.Errorf("expiry %s is in the past", formatExpiry(review.ExpiresAt))
+		}
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	old, ok := d.quarantine[scenario]
+	if !ok {
+		return nil, fmt.Errorf("scenario %q is not quarantined", scenario)
+	}
+	if !old.ReviewRequired {
+		return nil, fmt.Errorf("scenario %q is not awaiting review", scenario)
+	}
+
+	entry := *old
+	entry.ReviewRequired = false
+	entry.ReviewedBy = review.Reviewer
+	entry.ReviewedAt = &now
+	if review.Notes != "" {
+		if entry.Notes != "" {
+			entry.Notes += "\n"
+		}
+		entry.Notes += review.Notes
+	}
+
+	if review.Approve {
+		entry.ReviewDecision = ReviewApproved
+		entry.Owner = review.Owner
+		entry.ExpiresAt = review.ExpiresAt
+		d.quarantine[scenario] = &entry
+	} else {
+		entry.ReviewDecision = ReviewRejected
+		delete(d.quarantine, scenario)
+	}
+
+	hist, ok := d.history[scenario]
+	if !ok {
+		hist = &ScenarioHistory{Scenario: scenario, Runs: []RunRecord{}}
+		d.history[scenario] = hist
+	}
+	reviews := hist.Reviews
+	hist.Reviews = append(hist.Reviews, ReviewRecord{
+		Reviewer:   review.Reviewer,
+		Decision:   entry.ReviewDecision,
+		ReviewedAt: now,
+		Reason:     entry.Reason,
+		Notes:      review.Notes,
+	})
+
+	if err := d.save(); err != nil {
+		d.quarantine[scenario] = old
+		if ok {
+			hist.Reviews = reviews
+		} else {
+			delete(d.history, scenario)
+		}
+		return nil, fmt.Errorf("failed to save flake data: %w", err)
+	}
+	return &entry, nil
+}
@@ -0,0 +1,102 @@
+package flake
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestReviewQuarantine(t *testing.T) {
+	storagePath := filepath.Join(t.TempDir(), "flake.json")
+	detector, err := NewDetector(storagePath, DefaultConfig())
+	if err != nil {
+		t.Fatalf("NewDetector failed: %v", err)
+	}
+	now := time.Now()
+	for i, s := range []string{"checkout-flow", "search"} {
+		detector.quarantine[s] = &QuarantineEntry{
+			Scenario:        s,
+			QuarantinedAt:   now.Add(time.Duration(i) * time.Minute),
+			Reason:          "Auto-quarantined",
+			AutoQuarantined: true,
+			ReviewRequired:  true,
+		}
+	}
+	if err := detector.Quarantine("manual", "Investigating"); err != nil {
+		t.Fatal(err)
+	}
+
+	pending := detector.PendingReview()
+	if len(pending) != 2 || pending[0].Scenario != "checkout-flow" || pending[1].Scenario != "search" {
+		t.Fatalf("PendingReview = %+v, want checkout-flow, search", pending)
+	}
+
+	expires := now.Add(14 * 24 * time.Hour)
+	past := now.Add(-time.Hour)
+	invalid := []struct {
+		name     string
+		scenario string
+		review   QuarantineReview
+	}{
+		{"no reviewer", "search", QuarantineReview{Approve: true, Owner: "alice", ExpiresAt: &expires}},
+		{"no owner", "search", QuarantineReview{Reviewer: "bob", Approve: true, ExpiresAt: &expires}},
+		{"no expiry", "search", QuarantineReview{Reviewer: "bob", Approve: true, Owner: "alice"}},
+		{"past expiry", "search", QuarantineReview{Reviewer: "bob", Approve: true, Owner: "alice", ExpiresAt: &past}},
+		{"not quarantined", "unknown", QuarantineReview{Reviewer: "bob"}},
+		{"not awaiting review", "manual", QuarantineReview{Reviewer: "bob"}},
+	}
+	for _, tt := range invalid {
+		if _, err := detector.ReviewQuarantine(tt.scenario, tt.review); err == nil {
+			t.Errorf("%s: expected an error", tt.name)
+		}
+	}
+
+	entry, err := detector.ReviewQuarantine("checkout-flow", QuarantineReview{
+		Reviewer: "bob", Approve: true, Owner: "alice", ExpiresAt: &expires, Notes: "PAY-123",
+	})
+	if err != nil {
+		t.Fatalf("approve: %v", err)
+	}
+	if entry.ReviewDecision != ReviewApproved || entry.ReviewedBy != "bob" || entry.ReviewedAt == nil {
+		t.Errorf("approved entry review = %q by %q at %v", entry.ReviewDecision, entry.ReviewedBy, entry.ReviewedAt)
+	}
+	if entry.Owner != "alice" || entry.Notes != "PAY-123" || entry.ReviewRequired {
+		t.Errorf("approved entry = %+v", entry)
+	}
+	if !detector.IsQuarantined("checkout-flow") {
+		t.Error("approved scenario should stay quarantined")
+	}
+
+	entry, err = detector.ReviewQuarantine("search", QuarantineReview{Reviewer: "bob"})
+	if err != nil {
+		t.Fatalf("reject: %v", err)
+	}
+	if entry.ReviewDecision != ReviewRejected || entry.ReviewedBy != "bob" {
+		t.Errorf("rejected entry review = %q by %q", entry.ReviewDecision, entry.ReviewedBy)
+	}
+	if detector.IsQuarantined("search") {
+		t.Error("rejected scenario should be unquarantined")
+	}
+	if len(detector.PendingReview()) != 0 {
+		t.Error("expected no entries awaiting review")
+	}
+
+	// The review is persisted
+	reloaded, err := NewDetector(storagePath, DefaultConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := reloaded.GetQuarantineEntry("checkout-flow")
+	if got == nil || got.ReviewedBy != "bob" || got.ReviewDecision != ReviewApproved || got.ExpiresAt == nil {
+		t.Errorf("reloaded entry = %+v", got)
+	}
+
+	// The rejection is kept in the history, though the entry is gone
+	hist := reloaded.GetHistory("search")
+	if hist == nil || len(hist.Reviews) != 1 {
+		t.Fatalf("search history = %+v, want the rejection", hist)
+	}
+	if r := hist.Reviews[0]; r.Reviewer != "bob" || r.Decision != ReviewRejected || r.ReviewedAt.IsZero() || r.Reason != "Auto-quarantined" {
+		t.Errorf("rejection record = %+v", r)
+	}
+}
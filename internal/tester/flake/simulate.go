@@ -0,0 +1,99 @@
+package flake
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// SimulationResult reports what a flake config would have done had it been
+// in effect for the recorded run history.
+type SimulationResult struct {
+	// Label describes the config, e.g. "window=10 threshold=0.30 min_runs=3".
+	Label string `json:"label"`
+
+	// Config is the simulated config.
+	Config Config `json:"config"`
+
+	// Quarantines is how many auto-quarantines would have fired.
+	Quarantines int `json:"quarantines"`
+
+	// Unquarantines is how many auto-unquarantines would have fired.
+	Unquarantines int `json:"unquarantines"`
+
+	// Scenarios are the scenarios that would have been quarantined at least once.
+	Scenarios []string `json:"scenarios,omitempty"`
+
+	// QuarantinedAtEnd are the scenarios that would be quarantined now.
+	QuarantinedAtEnd []string `json:"quarantined_at_end,omitempty"`
+}
+
+// SimulationLabel describes the tunable settings of a config.
+func SimulationLabel(c Config) string {
+	window := fmt.Sprint(c.WindowSize)
+	if c.timeWindow() {
+		window = c.WindowDuration.String()
+	}
+	label := fmt.Sprintf("window=%s threshold=%.2f min_runs=%d", window, c.FlakeThreshold, c.MinRuns)
+	if c.ConsecutiveFailuresThreshold > 0 {
+		label += fmt.Sprintf(" consecutive=%d", c.ConsecutiveFailuresThreshold)
+	}
+	return label
+}
+
+// Simulate replays every scenario's recorded runs, oldest first, through
+// each config and reports the quarantine decisions each would have made.
+// Auto-quarantine is always on in the simulation; manual quarantines and
+// per-scenario policies are ignored so every scenario is judged by the
+// config under test. Time-based windows are measured from each replayed
+// run's timestamp. Only retained history is replayed (twice the window of
+// the config the runs were recorded under).
+func (d *Detector) Simulate(configs []Config) []SimulationResult {
+	d.mu.RLock()
+	history := make(map[string][]RunRecord, len(d.history))
+	for scenario, hist := range d.history {
+		history[scenario] = append([]RunRecord(nil), hist.Runs...)
+	}
+	d.mu.RUnlock()
+
+	scenarios := make([]string, 0, len(history))
+	for scenario := range history {
+		scenarios = append(scenarios, scenario)
+	}
+	sort.Strings(scenarios)
+
+	results := make([]SimulationResult, 0, len(configs))
+	for _, config := range configs {
+		config.AutoQuarantine = true
+		config.WebhookURL = ""
+		sim := newMemoryDetector(config)
+		result := SimulationResult{Label: SimulationLabel(sim.config), Config: sim.config}
+
+		for _, scenario := range scenarios {
+			quarantined := false
+			runs := history[scenario]
+			for i := len(runs) - 1; i >= 0; i-- {
+				at := runs[i].Timestamp
+				sim.now = func() time.Time { return at }
+				actions, _ := sim.recordRun(scenario, runs[i]) // in-memory: save can't fail
+				for _, action := range actions {
+					switch action.Action {
+					case "quarantine":
+						result.Quarantines++
+						quarantined = true
+					case "unquarantine":
+						result.Unquarantines++
+					}
+				}
+			}
+			if quarantined {
+				result.Scenarios = append(result.Scenarios, scenario)
+			}
+			if sim.IsQuarantined(scenario) {
+				result.QuarantinedAtEnd = append(result.QuarantinedAtEnd, scenario)
+			}
+		}
+		results = append(results, result)
+	}
+	return results
+}
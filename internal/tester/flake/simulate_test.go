@@ -0,0 +1,56 @@
+package flake
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSimulate(t *testing.T) {
+	config := DefaultConfig()
+	config.AutoQuarantine = false
+	detector, err := NewDetector(filepath.Join(t.TempDir(), "flake.json"), config)
+	if err != nil {
+		t.Fatalf("NewDetector failed: %v", err)
+	}
+
+	// 3 failures in 10 runs, never more than 40% of the runs so far
+	pattern := []RunOutcome{OutcomePass, OutcomeFail, OutcomePass, OutcomePass, OutcomeFail,
+		OutcomePass, OutcomePass, OutcomePass, OutcomeFail, OutcomePass}
+	start := time.Now().Add(-time.Hour)
+	for i, outcome := range pattern {
+		at := start.Add(time.Duration(i) * time.Minute)
+		if _, err := detector.RecordRun("flaky", RunRecord{Timestamp: at, Outcome: outcome}); err != nil {
+			t.Fatalf("RecordRun failed: %v", err)
+		}
+		if _, err := detector.RecordRun("stable", RunRecord{Timestamp: at, Outcome: OutcomePass}); err != nil {
+			t.Fatalf("RecordRun failed: %v", err)
+		}
+	}
+
+	strict, lenient := config, config
+	strict.FlakeThreshold = 0.2
+	lenient.FlakeThreshold = 0.5
+	results := detector.Simulate([]Config{strict, lenient})
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 results, got %d", len(results))
+	}
+
+	if results[0].Label != "window=10 threshold=0.20 min_runs=3" {
+		t.Errorf("Unexpected label %q", results[0].Label)
+	}
+	if results[0].Quarantines != 1 || len(results[0].Scenarios) != 1 || results[0].Scenarios[0] != "flaky" {
+		t.Errorf("Strict config: got %d quarantines of %v, want 1 of [flaky]", results[0].Quarantines, results[0].Scenarios)
+	}
+	if len(results[0].QuarantinedAtEnd) != 1 {
+		t.Errorf("Strict config: expected flaky quarantined at end, got %v", results[0].QuarantinedAtEnd)
+	}
+	if results[1].Quarantines != 0 {
+		t.Errorf("Lenient config: expected no quarantines, got %d (%v)", results[1].Quarantines, results[1].Scenarios)
+	}
+
+	// Simulation must not touch the real detector
+	if detector.IsQuarantined("flaky") {
+		t.Error("Simulate should not quarantine scenarios in the detector")
+	}
+}
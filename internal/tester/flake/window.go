@@ -0,0 +1,154 @@
+package flake
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Window modes reported in FlakeMetrics.WindowMode.
+const (
+	// WindowModeRuns scores the last Config.WindowSize runs.
+	WindowModeRuns = "runs"
+
+	// WindowModeTime scores the runs within Config.WindowDuration.
+	WindowModeTime = "time"
+)
+
+// Duration is a time.Duration written as a string in flake config, e.g.
+// "7d", "2w" or "36h". Days and weeks are accepted on top of the units
+// time.ParseDuration knows.
+type Duration time.Duration
+
+// ParseDuration parses a duration with optional d (day) and w (week) units.
+func ParseDuration(s string) (Duration, error) {
+	s = strings.TrimSpace(s)
+	for suffix, unit := range map[string]time.Duration{"d": 24 * time.Hour, "w": 7 * 24 * time.Hour} {
+		if n, ok := strings.CutSuffix(s, suffix); ok {
+			v, err := strconv.ParseFloat(n, 64)
+			if err != nil || v < 0 {
+				return 0, fmt.Errorf("invalid duration %q", s)
+			}
+			return Duration(v * float64(unit)), nil
+		}
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+	return Duration(d), nil
+}
+
+// String formats whole days as "7d" and anything else like time.Duration.
+func (d Duration) String() string {
+	day := 24 * time.Hour
+	if td := time.Duration(d); td > 0 && td%day == 0 {
+		return fmt.Sprintf("%dd", td/day)
+	}
+	return time.Duration(d).String()
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (d *Duration) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+	parsed, err := ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// MarshalYAML implements yaml.Marshaler.
+func (d Duration) MarshalYAML() (interface{}, error) {
+	return d.String(), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	parsed, err := ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// timeWindow reports whether the config scores runs by age rather than
+// by count.
+func (c Config) timeWindow() bool {
+	return c.WindowDuration > 0
+}
+
+// WindowLabel describes the config's window, e.g. "last 10 runs" or
+// "last 7d".
+func (c Config) WindowLabel() string {
+	if c.timeWindow() {
+		return "last " + c.WindowDuration.String()
+	}
+	return fmt.Sprintf("last %d runs", c.WindowSize)
+}
+
+// windowRuns returns the leading runs (most recent first) that fall in
+// the config's window as of now.
+func (c Config) windowRuns(runs []RunRecord, now time.Time) []RunRecord {
+	if !c.timeWindow() {
+		if len(runs) > c.WindowSize {
+			return runs[:c.WindowSize]
+		}
+		return runs
+	}
+	return runsSince(runs, now.Add(-time.Duration(c.WindowDuration)))
+}
+
+// maxTimeWindowRuns caps the history kept in time-window mode, so a
+// scenario run many times a day doesn't grow its history without bound.
+const maxTimeWindowRuns = 1000
+
+// retainedRuns trims history to twice the window, keeping a buffer for
+// simulating larger windows. Time windows also keep at most
+// maxTimeWindowRuns.
+func (c Config) retainedRuns(runs []RunRecord, now time.Time) []RunRecord {
+	max := c.WindowSize * 2
+	if c.timeWindow() {
+		runs = runsSince(runs, now.Add(-2*time.Duration(c.WindowDuration)))
+		max = maxTimeWindowRuns
+	}
+	if len(runs) > max {
+		return runs[:max]
+	}
+	return runs
+}
+
+// runsSince returns the leading runs at or after cutoff.
+func runsSince(runs []RunRecord, cutoff time.Time) []RunRecord {
+	for i, run := range runs {
+		if run.Timestamp.Before(cutoff) {
+			return runs[:i]
+		}
+	}
+	return runs
+}
+
+// timeWindowSuffix qualifies a run count with a time-based window, e.g.
+// " in the last 7d". Count-based windows need no qualifier.
+func (m *FlakeMetrics) timeWindowSuffix() string {
+	if m.WindowMode != WindowModeTime {
+		return ""
+	}
+	return " in the " + m.Window
+}
@@ -0,0 +1,158 @@
+package flake
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		in   string
+		want time.Duration
+		str  string
+	}{
+		{"7d", 7 * 24 * time.Hour, "7d"},
+		{"2w", 14 * 24 * time.Hour, "14d"},
+		{"36h", 36 * time.Hour, "36h0m0s"},
+		{"1.5d", 36 * time.Hour, "36h0m0s"},
+	}
+	for _, tt := range tests {
+		got, err := ParseDuration(tt.in)
+		if err != nil {
+			t.Errorf("ParseDuration(%q) failed: %v", tt.in, err)
+			continue
+		}
+		if time.Duration(got) != tt.want || got.String() != tt.str {
+			t.Errorf("ParseDuration(%q) = %v (%s), want %v (%s)", tt.in, time.Duration(got), got, tt.want, tt.str)
+		}
+	}
+	for _, bad := range []string{"", "seven days", "-1d", "d"} {
+		if _, err := ParseDuration(bad); err == nil {
+			t.Errorf("ParseDuration(%q) should fail", bad)
+		}
+	}
+}
+
+func TestTimeWindowMetrics(t *testing.T) {
+	config := DefaultConfig()
+	config.WindowSize = 3
+	config.WindowDuration = Duration(7 * 24 * time.Hour)
+	config.AutoQuarantine = false
+	detector := newMemoryDetector(config)
+	now := time.Now()
+	detector.now = func() time.Time { return now }
+
+	// Two old failures fall outside the 7 day window; the four recent runs,
+	// more than WindowSize, all count
+	record := func(age time.Duration, outcome RunOutcome) {
+		t.Helper()
+		if _, err := detector.RecordRun("nightly", RunRecord{Timestamp: now.Add(-age), Outcome: outcome}); err != nil {
+			t.Fatalf("RecordRun failed: %v", err)
+		}
+	}
+	record(10*24*time.Hour, OutcomeFail)
+	record(9*24*time.Hour, OutcomeFail)
+	record(6*24*time.Hour, OutcomePass)
+	record(4*24*time.Hour, OutcomeFail)
+	record(2*24*time.Hour, OutcomePass)
+	record(time.Hour, OutcomePass)
+
+	m := detector.GetMetrics("nightly")
+	if m.WindowMode != WindowModeTime || m.Window != "last 7d" {
+		t.Errorf("window = %q (%s), want time mode over last 7d", m.WindowMode, m.Window)
+	}
+	if m.WindowRuns != 4 || m.WindowFailures != 1 {
+		t.Errorf("WindowRuns=%d WindowFailures=%d, want 4 and 1", m.WindowRuns, m.WindowFailures)
+	}
+	if m.FlakeRate != 0.25 {
+		t.Errorf("FlakeRate = %v, want 0.25", m.FlakeRate)
+	}
+
+	// History is kept for twice the window
+	if hist := detector.GetHistory("nightly"); len(hist.Runs) != 6 {
+		t.Errorf("retained %d runs, want 6", len(hist.Runs))
+	}
+	now = now.Add(6 * 24 * time.Hour)
+	record(0, OutcomePass)
+	if hist := detector.GetHistory("nightly"); len(hist.Runs) != 5 {
+		t.Errorf("retained %d runs after 6 days, want 5 (14 day buffer)", len(hist.Runs))
+	}
+
+	// A scenario policy window size switches back to counting runs
+	detector.SetPolicy("nightly", &Policy{WindowSize: 2})
+	record(0, OutcomeFail)
+	m = detector.GetMetrics("nightly")
+	if m.WindowMode != WindowModeRuns || m.Window != "last 2 runs" || m.WindowRuns != 2 {
+		t.Errorf("policy window: mode %q (%s) with %d runs, want last 2 runs", m.WindowMode, m.Window, m.WindowRuns)
+	}
+}
+
+func TestTimeWindowRetentionCap(t *testing.T) {
+	config := DefaultConfig()
+	config.WindowDuration = Duration(7 * 24 * time.Hour)
+	now := time.Now()
+
+	runs := make([]RunRecord, maxTimeWindowRuns+50)
+	for i := range runs {
+		runs[i] = RunRecord{Timestamp: now.Add(-time.Duration(i) * time.Second), Outcome: OutcomePass}
+	}
+	if got := config.retainedRuns(runs, now); len(got) != maxTimeWindowRuns {
+		t.Errorf("retained %d runs, want %d", len(got), maxTimeWindowRuns)
+	}
+}
+
+func TestTimeWindowQuarantineReason(t *testing.T) {
+	config := DefaultConfig()
+	config.WindowDuration = Duration(24 * time.Hour)
+	detector := newMemoryDetector(config)
+
+	var actions []QuarantineAction
+	for i := 0; i < 3; i++ {
+		var err error
+		actions, err = detector.RecordRun("checkout", RunRecord{Timestamp: time.Now(), Outcome: OutcomeFail})
+		if err != nil {
+			t.Fatalf("RecordRun failed: %v", err)
+		}
+	}
+	if len(actions) != 1 || actions[0].Action != "quarantine" {
+		t.Fatalf("Expected a quarantine, got %+v", actions)
+	}
+	if !strings.HasSuffix(actions[0].Reason, "over 3 runs in the last 1d") {
+		t.Errorf("Reason = %q, want the time window", actions[0].Reason)
+	}
+}
+
+func TestWindowDurationConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ConfigFileName)
+	if err := os.WriteFile(path, []byte("window_duration: 7d\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	config, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if time.Duration(config.WindowDuration) != 7*24*time.Hour || config.WindowLabel() != "last 7d" {
+		t.Errorf("WindowDuration = %v (%s)", time.Duration(config.WindowDuration), config.WindowLabel())
+	}
+
+	// The detector stores its config as JSON
+	data, err := json.Marshal(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"window_duration":"7d"`) {
+		t.Errorf("JSON = %s, want window_duration \"7d\"", data)
+	}
+	var decoded Config
+	if err := json.Unmarshal(data, &decoded); err != nil || decoded.WindowDuration != config.WindowDuration {
+		t.Errorf("JSON round trip = %v, %v", time.Duration(decoded.WindowDuration), err)
+	}
+
+	if SimulationLabel(config) != "window=7d threshold=0.30 min_runs=3" {
+		t.Errorf("SimulationLabel = %q", SimulationLabel(config))
+	}
+}
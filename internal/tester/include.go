@@ -0,0 +1,295 @@
+package tester
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Scenarios share config through include: a scenario (or fragment) lists
+// fragment files, relative to its own directory:
+//
+//	include:
+//	  - fragments/staging-env.fragment.yaml
+//	  - fragments/personas.fragment.yaml
+//
+// Fragments are partial scenarios. They are merged in order, each
+// fragment's own includes first, and the including file is merged last:
+// mappings merge key by key, anything else is replaced. Anchors defined
+// in an included file can be aliased by every file merged after it, so a
+// fragment can hold named blocks (&checkout_waits) for scenarios to pick
+// with *checkout_waits or <<: *checkout_waits.
+//
+// Name fragments <name>.fragment.yaml so scenario globs skip them.
+
+// includeKey is the top-level key listing a file's includes.
+const includeKey = "include"
+
+// IsScenarioFragment reports whether a file name is an include fragment:
+// <name>.fragment.yaml (or .yml).
+func IsScenarioFragment(name string) bool {
+	base := filepath.Base(name)
+	return strings.HasSuffix(base, ".fragment.yaml") || strings.HasSuffix(base, ".fragment.yml")
+}
+
+// includeFile is one file in a scenario's include graph.
+type includeFile struct {
+	path string // "" for scenario bytes without a file
+	data []byte
+}
+
+// resolveIncludes returns the files to merge for a scenario: its
+// includes, depth first and each once, then the scenario itself. Include
+// paths resolve against the including file's directory (dir for the
+// scenario). Cycles are an error.
+func resolveIncludes(data []byte, path, dir string) ([]includeFile, error) {
+	r := &includeResolver{seen: make(map[string]bool)}
+	if path != "" {
+		if abs, err := filepath.Abs(path); err == nil {
+			r.stack = []string{abs}
+			r.seen[abs] = true
+		}
+	}
+	if err := r.visit(data, dir); err != nil {
+		return nil, err
+	}
+	return append(r.files, includeFile{path: path, data: data}), nil
+}
+
+type includeResolver struct {
+	files []includeFile
+	seen  map[string]bool
+	stack []string // absolute paths of the files being resolved
+}
+
+// visit resolves the includes of a file in dir.
+func (r *includeResolver) visit(data []byte, dir string) error {
+	includes, err := includeList(data)
+	if err != nil {
+		return err
+	}
+	for _, inc := range includes {
+		abs, err := filepath.Abs(filepath.Join(dir, inc))
+		if err != nil {
+			return fmt.Errorf("include %q: %w", inc, err)
+		}
+		for i, p := range r.stack {
+			if p == abs {
+				return fmt.Errorf("include cycle: %s", includeChain(append(r.stack[i:], abs)))
+			}
+		}
+		if r.seen[abs] {
+			continue
+		}
+		r.seen[abs] = true
+
+		fragment, err := os.ReadFile(abs) //nolint:gosec // G304: include of a trusted scenario
+		if err != nil {
+			return fmt.Errorf("include %q: %w", inc, err)
+		}
+		r.stack = append(r.stack, abs)
+		if err := r.visit(fragment, filepath.Dir(abs)); err != nil {
+			return err
+		}
+		r.stack = r.stack[:len(r.stack)-1]
+		r.files = append(r.files, includeFile{path: abs, data: fragment})
+	}
+	return nil
+}
+
+// includeChain renders a cycle, e.g. "a.yaml -> b.fragment.yaml -> a.yaml".
+func includeChain(paths []string) string {
+	names := make([]string, len(paths))
+	for i, p := range paths {
+		names[i] = filepath.Base(p)
+	}
+	return strings.Join(names, " -> ")
+}
+
+// includeList reads a file's top-level include: list, a path or a list of
+// paths. It is read from the include block alone because the rest of the
+// file may alias anchors that only its includes define.
+func includeList(data []byte) ([]string, error) {
+	var block []string
+	lines := strings.Split(string(data), "\n")
+	for i, line := range lines {
+		if !strings.HasPrefix(line, includeKey+":") {
+			continue
+		}
+		block = append(block, line)
+		for _, next := range lines[i+1:] {
+			trimmed := strings.TrimSpace(next)
+			if trimmed != "" && !strings.HasPrefix(trimmed, "#") &&
+				!strings.HasPrefix(next, " ") && !strings.HasPrefix(next, "\t") && !strings.HasPrefix(next, "-") {
+				break
+			}
+			block = append(block, next)
+		}
+		break
+	}
+	if block == nil {
+		return nil, nil
+	}
+
+	var parsed map[string]yaml.Node
+	if err := yaml.Unmarshal([]byte(strings.Join(block, "\n")), &parsed); err != nil {
+		return nil, fmt.Errorf("parsing include: %w", err)
+	}
+	node := parsed[includeKey]
+	switch node.Kind {
+	case yaml.ScalarNode:
+		if node.Value == "" {
+			return nil, nil
+		}
+		return []string{node.Value}, nil
+	case yaml.SequenceNode:
+		var paths []string
+		if err := node.Decode(&paths); err != nil {
+			return nil, fmt.Errorf("include must be a path or a list of paths")
+		}
+		return paths, nil
+	default:
+		return nil, fmt.Errorf("include must be a path or a list of paths")
+	}
+}
+
+// includeDocument parses the files of an include graph as one YAML stream,
+// so later files can alias earlier files' anchors, and merges their
+// top-level mappings in order.
+func includeDocument(files []includeFile) (*yaml.Node, error) {
+	var stream bytes.Buffer
+	starts := make([]int, len(files)) // stream line before each file's first line
+	line := 0
+	for i, f := range files {
+		content := bytes.TrimPrefix(f.data, []byte("\ufeff"))
+		if !bytes.HasPrefix(content, []byte("---")) {
+			stream.WriteString("---\n")
+			line++
+		}
+		starts[i] = line
+		stream.Write(content)
+		if !bytes.HasSuffix(content, []byte("\n")) {
+			stream.WriteString("\n")
+		}
+		line += bytes.Count(content, []byte("\n"))
+		if !bytes.HasSuffix(content, []byte("\n")) {
+			line++
+		}
+	}
+
+	dec := yaml.NewDecoder(&stream)
+	merged := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	for i, f := range files {
+		var root yaml.Node
+		if err := dec.Decode(&root); err != nil {
+			if errors.Is(err, io.EOF) {
+				err = fmt.Errorf("no YAML document")
+			}
+			return nil, includeError(files, starts, i, err)
+		}
+		doc := &root
+		if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+			doc = root.Content[0]
+		}
+		switch {
+		case doc.Kind == yaml.MappingNode:
+			merged = mergeNodes(merged, withoutKey(doc, includeKey))
+		case doc.Kind == yaml.ScalarNode && doc.Tag == "!!null":
+			// Empty file or comments only
+		default:
+			return nil, fileError(f.path, fmt.Errorf("parsing YAML: scenario must be a mapping"))
+		}
+	}
+	var extra yaml.Node
+	if err := dec.Decode(&extra); !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("parsing YAML: a scenario or fragment file must hold a single YAML document")
+	}
+	return merged, nil
+}
+
+// yamlLine matches the line number in a yaml error.
+var yamlLine = regexp.MustCompile(`line (\d+)`)
+
+// includeError reports a stream parse error against the file and line it
+// came from. i is the file being decoded when the error occurred.
+func includeError(files []includeFile, starts []int, i int, err error) error {
+	msg := err.Error()
+	m := yamlLine.FindStringSubmatchIndex(msg)
+	if m == nil {
+		return fileError(files[i].path, fmt.Errorf("parsing YAML: %w", err))
+	}
+	n, _ := strconv.Atoi(msg[m[2]:m[3]])
+	for j := len(files) - 1; j >= 0; j-- {
+		if n > starts[j] {
+			msg = msg[:m[2]] + strconv.Itoa(n-starts[j]) + msg[m[3]:]
+			return fileError(files[j].path, fmt.Errorf("parsing YAML: %s", msg))
+		}
+	}
+	return fileError(files[i].path, fmt.Errorf("parsing YAML: %w", err))
+}
+
+// fileError prefixes err with the path of the file it came from, if the
+// file has one.
+func fileError(path string, err error) error {
+	if path == "" {
+		return err
+	}
+	return fmt.Errorf("%s: %w", path, err)
+}
+
+// mergeNodes merges over onto base: mappings key by key, recursively;
+// anything else is replaced by over. Neither input is modified, since
+// nodes may be anchors shared with other files.
+func mergeNodes(base, over *yaml.Node) *yaml.Node {
+	b, o := resolveAlias(base), resolveAlias(over)
+	if b.Kind != yaml.MappingNode || o.Kind != yaml.MappingNode {
+		return over
+	}
+	out := &yaml.Node{Kind: yaml.MappingNode, Tag: b.Tag, Style: b.Style, Line: b.Line, Column: b.Column}
+	out.Content = append(out.Content, b.Content...)
+	for i := 0; i+1 < len(o.Content); i += 2 {
+		key, value := o.Content[i], o.Content[i+1]
+		if j := mappingIndex(out, key.Value); j >= 0 {
+			out.Content[j+1] = mergeNodes(out.Content[j+1], value)
+			continue
+		}
+		out.Content = append(out.Content, key, value)
+	}
+	return out
+}
+
+func resolveAlias(n *yaml.Node) *yaml.Node {
+	for n.Kind == yaml.AliasNode && n.Alias != nil {
+		n = n.Alias
+	}
+	return n
+}
+
+// mappingIndex returns the index of key's key node in a mapping, or -1.
+func mappingIndex(m *yaml.Node, key string) int {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// withoutKey returns a mapping without key.
+func withoutKey(m *yaml.Node, key string) *yaml.Node {
+	i := mappingIndex(m, key)
+	if i < 0 {
+		return m
+	}
+	out := *m
+	out.Content = append(append([]*yaml.Node{}, m.Content[:i]...), m.Content[i+2:]...)
+	return &out
+}
@@ -0,0 +1,180 @@
+package tester
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeScenarioFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestParseScenarioFile_Include(t *testing.T) {
+	dir := t.TempDir()
+	writeScenarioFiles(t, dir, map[string]string{
+		"fragments/env.fragment.yaml": `
+environment:
+  url: https://staging.example.com
+  viewport:
+    width: 1280
+    height: 720
+test_data:
+  email_inbox: mailhog
+tags: [staging]
+`,
+		"fragments/common.fragment.yaml": `
+include: env.fragment.yaml
+persona: sarah
+success_criteria:
+  - Lands on the dashboard
+wait_strategies:
+  network_idle: true
+  min_load_time: 500
+`,
+		"checkout.yaml": `
+include:
+  - fragments/common.fragment.yaml
+  - fragments/env.fragment.yaml
+scenario: checkout
+goal: Buy something
+environment:
+  viewport:
+    width: 390
+tags: [checkout]
+`,
+	})
+
+	s, err := ParseScenarioFile(filepath.Join(dir, "checkout.yaml"))
+	if err != nil {
+		t.Fatalf("ParseScenarioFile failed: %v", err)
+	}
+
+	if s.Scenario != "checkout" || s.Persona != "sarah" || s.Goal != "Buy something" {
+		t.Errorf("got scenario=%q persona=%q goal=%q", s.Scenario, s.Persona, s.Goal)
+	}
+	// Mappings merge key by key; the scenario wins
+	if s.Environment.URL != "https://staging.example.com" {
+		t.Errorf("Environment.URL = %q", s.Environment.URL)
+	}
+	if v := s.Environment.Viewport; v == nil || v.Width != 390 || v.Height != 720 {
+		t.Errorf("Viewport = %+v, want 390x720", v)
+	}
+	if s.WaitStrategies == nil || !s.WaitStrategies.NetworkIdle || s.WaitStrategies.MinLoadTime != 500 {
+		t.Errorf("WaitStrategies = %+v", s.WaitStrategies)
+	}
+	if s.TestData == nil || s.TestData.EmailInbox != "mailhog" {
+		t.Errorf("TestData = %+v", s.TestData)
+	}
+	// Lists are replaced, not appended
+	if len(s.Tags) != 1 || s.Tags[0] != "checkout" {
+		t.Errorf("Tags = %v, want [checkout]", s.Tags)
+	}
+
+	// env is included twice but merged once, before common
+	if len(s.Includes) != 2 || filepath.Base(s.Includes[0]) != "env.fragment.yaml" ||
+		filepath.Base(s.Includes[1]) != "common.fragment.yaml" {
+		t.Errorf("Includes = %v", s.Includes)
+	}
+}
+
+func TestParseScenarioFile_IncludeAnchors(t *testing.T) {
+	dir := t.TempDir()
+	writeScenarioFiles(t, dir, map[string]string{
+		"blocks.fragment.yaml": `
+personas:
+  sarah: &sarah sarah
+waits:
+  slow: &slow_waits
+    network_idle: true
+    min_load_time: 2000
+`,
+		"slow.yaml": `
+include: blocks.fragment.yaml
+scenario: slow_dashboard
+persona: *sarah
+goal: Load the dashboard
+success_criteria: [Dashboard renders]
+environment:
+  url: https://example.com
+wait_strategies:
+  <<: *slow_waits
+  animation_complete: true
+`,
+	})
+
+	s, err := ParseScenarioFile(filepath.Join(dir, "slow.yaml"))
+	if err != nil {
+		t.Fatalf("ParseScenarioFile failed: %v", err)
+	}
+	if s.Persona != "sarah" {
+		t.Errorf("Persona = %q, want sarah", s.Persona)
+	}
+	ws := s.WaitStrategies
+	if ws == nil || !ws.NetworkIdle || !ws.AnimationComplete || ws.MinLoadTime != 2000 {
+		t.Errorf("WaitStrategies = %+v", ws)
+	}
+}
+
+func TestParseScenarioFile_IncludeCycle(t *testing.T) {
+	dir := t.TempDir()
+	writeScenarioFiles(t, dir, map[string]string{
+		"a.fragment.yaml": "include: b.fragment.yaml\n",
+		"b.fragment.yaml": "include: [a.fragment.yaml]\n",
+		"self.yaml":       "include: self.yaml\nscenario: self\n",
+		"loop.yaml":       "include: a.fragment.yaml\nscenario: loop\n",
+	})
+
+	_, err := ParseScenarioFile(filepath.Join(dir, "loop.yaml"))
+	if err == nil || !strings.Contains(err.Error(), "include cycle: a.fragment.yaml -> b.fragment.yaml -> a.fragment.yaml") {
+		t.Errorf("expected cycle error, got %v", err)
+	}
+	_, err = ParseScenarioFile(filepath.Join(dir, "self.yaml"))
+	if err == nil || !strings.Contains(err.Error(), "include cycle: self.yaml -> self.yaml") {
+		t.Errorf("expected self-include cycle error, got %v", err)
+	}
+}
+
+func TestParseScenarioFile_IncludeErrors(t *testing.T) {
+	dir := t.TempDir()
+	writeScenarioFiles(t, dir, map[string]string{
+		"bad.fragment.yaml": "environment:\n  url: [unclosed\n",
+		"broken.yaml":       "include: bad.fragment.yaml\nscenario: broken\n",
+		"missing.yaml":      "include: nope.fragment.yaml\nscenario: missing\n",
+		"map.yaml":          "include:\n  file: x.yaml\nscenario: map\n",
+	})
+
+	_, err := ParseScenarioFile(filepath.Join(dir, "broken.yaml"))
+	if err == nil || !strings.Contains(err.Error(), "bad.fragment.yaml: parsing YAML") {
+		t.Errorf("expected error naming the fragment, got %v", err)
+	}
+	if _, err := ParseScenarioFile(filepath.Join(dir, "missing.yaml")); err == nil || !strings.Contains(err.Error(), "nope.fragment.yaml") {
+		t.Errorf("expected missing include error, got %v", err)
+	}
+	if _, err := ParseScenarioFile(filepath.Join(dir, "map.yaml")); err == nil || !strings.Contains(err.Error(), "list of paths") {
+		t.Errorf("expected include type error, got %v", err)
+	}
+}
+
+func TestIsScenarioFragment(t *testing.T) {
+	for name, want := range map[string]bool{
+		"fragments/env.fragment.yaml": true,
+		"common.fragment.yml":         true,
+		"checkout.yaml":               false,
+		"fragment.yaml":               false,
+	} {
+		if got := IsScenarioFragment(name); got != want {
+			t.Errorf("IsScenarioFragment(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
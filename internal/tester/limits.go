@@ -0,0 +1,204 @@
+package tester
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// LimitsFile is the file in the run directory recording a resource limit
+// violation by the run's agent or browser processes.
+const LimitsFile = "limits.json"
+
+// LimitErrorPrefix starts the error of a run stopped by a resource limit,
+// so batch results classify it as an infrastructure error.
+const LimitErrorPrefix = "resource limit exceeded"
+
+// Enforcement mechanisms for ResourceLimits.
+const (
+	// EnforceCgroup runs the process in a transient systemd scope with
+	// MemoryMax and CPUWeight set.
+	EnforceCgroup = "cgroup"
+
+	// EnforceRlimit caps the process's address space with prlimit. CPU
+	// shares are not enforced.
+	EnforceRlimit = "rlimit"
+)
+
+// ResourceLimits caps the memory and CPU share of the processes a scenario
+// run spawns (the agent and its browser), so one runaway scenario can't
+// starve parallel workers. Zero values mean no limit. Nothing applies them
+// yet: scenario runs don't spawn agent processes, so there is no launch to
+// wrap with Command.
+type ResourceLimits struct {
+	// MemoryMB is the memory ceiling in megabytes.
+	MemoryMB int `json:"memory_mb,omitempty" yaml:"memory_mb,omitempty"`
+
+	// CPUShares is the relative CPU weight, as cgroup v1 cpu.shares
+	// (default 1024; 512 gets half the CPU of an unlimited process under
+	// contention).
+	CPUShares int `json:"cpu_shares,omitempty" yaml:"cpu_shares,omitempty"`
+}
+
+// IsZero reports whether no limit is set.
+func (l ResourceLimits) IsZero() bool {
+	return l.MemoryMB <= 0 && l.CPUShares <= 0
+}
+
+// String describes the limits, e.g. "memory 2048MB, cpu_shares 512".
+func (l ResourceLimits) String() string {
+	var parts []string
+	if l.MemoryMB > 0 {
+		parts = append(parts, fmt.Sprintf("memory %dMB", l.MemoryMB))
+	}
+	if l.CPUShares > 0 {
+		parts = append(parts, fmt.Sprintf("cpu_shares %d", l.CPUShares))
+	}
+	if len(parts) == 0 {
+		return "none"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// ParseMemoryMB parses a memory size such as "2g", "512m" or "1024"
+// (megabytes) into megabytes.
+func ParseMemoryMB(size string) (int, error) {
+	s := strings.ToLower(strings.TrimSpace(size))
+	mult := 1
+	s = strings.TrimSuffix(s, "b")
+	if strings.HasSuffix(s, "g") {
+		mult = 1024
+	}
+	s = strings.TrimSuffix(strings.TrimSuffix(s, "g"), "m")
+	n, err := strconv.Atoi(s)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid memory size %q (want e.g. 512m or 2g)", size)
+	}
+	return n * mult, nil
+}
+
+// cpuWeight converts cgroup v1 cpu.shares to a cgroup v2 CPUWeight, as
+// systemd does.
+func cpuWeight(shares int) int {
+	w := 1 + ((shares-2)*9999)/262142
+	return min(max(w, 1), 10000)
+}
+
+// Command returns a command running name under the limits, and how they
+// are enforced: EnforceCgroup when systemd-run can create a scope (as root,
+// or with a user session), else EnforceRlimit for the memory limit when
+// prlimit is available, else "" (unlimited).
+func (l ResourceLimits) Command(ctx context.Context, name string, args ...string) (*exec.Cmd, string) {
+	if l.IsZero() {
+		return exec.CommandContext(ctx, name, args...), ""
+	}
+
+	if path, ok := systemdRun(); ok {
+		wrapped := []string{"--scope", "--quiet", "--collect"}
+		if os.Getuid() != 0 {
+			wrapped = append([]string{"--user"}, wrapped...)
+		}
+		if l.MemoryMB > 0 {
+			wrapped = append(wrapped, "-p", fmt.Sprintf("MemoryMax=%dM", l.MemoryMB), "-p", "MemorySwapMax=0")
+		}
+		if l.CPUShares > 0 {
+			wrapped = append(wrapped, "-p", fmt.Sprintf("CPUWeight=%d", cpuWeight(l.CPUShares)))
+		}
+		wrapped = append(wrapped, "--", name)
+		return exec.CommandContext(ctx, path, append(wrapped, args...)...), EnforceCgroup //nolint:gosec // G204: wraps the caller's command
+	}
+
+	if path, err := exec.LookPath("prlimit"); err == nil && l.MemoryMB > 0 {
+		wrapped := []string{fmt.Sprintf("--as=%d", int64(l.MemoryMB)<<20), "--", name}
+		return exec.CommandContext(ctx, path, append(wrapped, args...)...), EnforceRlimit //nolint:gosec // G204: wraps the caller's command
+	}
+
+	return exec.CommandContext(ctx, name, args...), ""
+}
+
+// systemdRun returns the systemd-run binary if it can create a scope for
+// this user: root uses the system manager, anyone else needs the user
+// manager of a login session.
+func systemdRun() (string, bool) {
+	path, err := exec.LookPath("systemd-run")
+	if err != nil {
+		return "", false
+	}
+	if os.Getuid() != 0 && os.Getenv("XDG_RUNTIME_DIR") == "" {
+		return "", false
+	}
+	return path, true
+}
+
+// LimitViolation records a process stopped by a resource limit.
+type LimitViolation struct {
+	// Process is the process that was stopped, e.g. "agent" or "chromium".
+	Process string `json:"process"`
+
+	// Limits are the limits in effect.
+	Limits ResourceLimits `json:"limits"`
+
+	// Detail describes how the process ended, e.g. "killed by signal: killed".
+	Detail string `json:"detail"`
+}
+
+// Reason describes the violation for results, e.g. "resource limit
+// exceeded: chromium over memory 2048MB (killed by signal: killed)".
+func (v *LimitViolation) Reason() string {
+	return fmt.Sprintf("%s: %s over %s (%s)", LimitErrorPrefix, v.Process, v.Limits, v.Detail)
+}
+
+// CheckLimitViolation inspects the error from running a limited process.
+// A process killed by SIGKILL under a memory limit is attributed to the
+// limit, as that is how the OOM killer ends it; under an address space
+// rlimit, allocation failures usually end in SIGABRT or SIGSEGV instead.
+// Returns nil for other failures.
+func CheckLimitViolation(err error, limits ResourceLimits, enforcement, process string) *LimitViolation {
+	var exitErr *exec.ExitError
+	if limits.MemoryMB <= 0 || !errors.As(err, &exitErr) {
+		return nil
+	}
+	status, ok := exitErr.Sys().(syscall.WaitStatus)
+	if !ok || !status.Signaled() {
+		return nil
+	}
+	switch sig := status.Signal(); {
+	case sig == syscall.SIGKILL,
+		enforcement == EnforceRlimit && (sig == syscall.SIGABRT || sig == syscall.SIGSEGV):
+		return &LimitViolation{Process: process, Limits: limits, Detail: "killed by signal: " + sig.String()}
+	}
+	return nil
+}
+
+// WriteLimitViolation records a violation in the run directory.
+func WriteLimitViolation(dir string, v *LimitViolation) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, LimitsFile), data, 0644)
+}
+
+// LoadLimitViolation reads the run's resource limit violation. It returns
+// nil if the run stayed within its limits.
+func LoadLimitViolation(dir string) (*LimitViolation, error) {
+	data, err := os.ReadFile(filepath.Join(dir, LimitsFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var v LimitViolation
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", LimitsFile, err)
+	}
+	return &v, nil
+}
@@ -0,0 +1,114 @@
+package tester
+
+import (
+	"os/exec"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestParseMemoryMB(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    int
+		wantErr bool
+	}{
+		{"512", 512, false},
+		{"512m", 512, false},
+		{"512MB", 512, false},
+		{"2g", 2048, false},
+		{" 2GB ", 2048, false},
+		{"", 0, true},
+		{"0", 0, true},
+		{"-1g", 0, true},
+		{"lots", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseMemoryMB(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseMemoryMB(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseMemoryMB(%q) = %d, want %d", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestResourceLimitsString(t *testing.T) {
+	if !(ResourceLimits{}).IsZero() {
+		t.Error("zero limits should be IsZero")
+	}
+	if got := (ResourceLimits{}).String(); got != "none" {
+		t.Errorf("String() = %q, want none", got)
+	}
+	l := ResourceLimits{MemoryMB: 2048, CPUShares: 512}
+	if got := l.String(); got != "memory 2048MB, cpu_shares 512" {
+		t.Errorf("String() = %q", got)
+	}
+}
+
+func TestCPUWeight(t *testing.T) {
+	tests := map[int]int{1024: 39, 2: 1, 1: 1, 262144: 10000, 1 << 20: 10000}
+	for shares, want := range tests {
+		if got := cpuWeight(shares); got != want {
+			t.Errorf("cpuWeight(%d) = %d, want %d", shares, got, want)
+		}
+	}
+}
+
+func TestLimitViolationRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+
+	v, err := LoadLimitViolation(dir)
+	if err != nil || v != nil {
+		t.Fatalf("LoadLimitViolation() on empty dir = %v, %v; want nil, nil", v, err)
+	}
+
+	want := &LimitViolation{
+		Process: "chromium",
+		Limits:  ResourceLimits{MemoryMB: 1024},
+		Detail:  "killed by signal: killed",
+	}
+	if err := WriteLimitViolation(dir, want); err != nil {
+		t.Fatalf("WriteLimitViolation() error = %v", err)
+	}
+	got, err := LoadLimitViolation(dir)
+	if err != nil {
+		t.Fatalf("LoadLimitViolation() error = %v", err)
+	}
+	if *got != *want {
+		t.Errorf("LoadLimitViolation() = %+v, want %+v", got, want)
+	}
+	if !strings.HasPrefix(got.Reason(), LimitErrorPrefix+": chromium over memory 1024MB") {
+		t.Errorf("Reason() = %q", got.Reason())
+	}
+}
+
+func TestCheckLimitViolation(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("requires POSIX signals")
+	}
+	limits := ResourceLimits{MemoryMB: 256}
+
+	killed := exec.Command("sh", "-c", "kill -9 $$").Run()
+	if v := CheckLimitViolation(killed, limits, EnforceCgroup, "agent"); v == nil {
+		t.Error("SIGKILL under a memory limit should be a violation")
+	}
+	if v := CheckLimitViolation(killed, ResourceLimits{CPUShares: 512}, EnforceCgroup, "agent"); v != nil {
+		t.Error("SIGKILL without a memory limit should not be a violation")
+	}
+
+	aborted := exec.Command("sh", "-c", "kill -ABRT $$").Run()
+	if v := CheckLimitViolation(aborted, limits, EnforceRlimit, "agent"); v == nil {
+		t.Error("SIGABRT under an rlimit should be a violation")
+	}
+	if v := CheckLimitViolation(aborted, limits, EnforceCgroup, "agent"); v != nil {
+		t.Error("SIGABRT under a cgroup should not be a violation")
+	}
+
+	failed := exec.Command("sh", "-c", "exit 1").Run()
+	if v := CheckLimitViolation(failed, limits, EnforceCgroup, "agent"); v != nil {
+		t.Error("a plain exit failure should not be a violation")
+	}
+}
@@ -54,6 +54,10 @@ func PlaywrightMCPConfigWithRecording(outputDir string, cfg *PlaywrightConfig) M
 		if cfg.Timeout > 0 {
 			config.Env["PLAYWRIGHT_TIMEOUT"] = fmt.Sprintf("%d", cfg.Timeout)
 		}
+
+		if cfg.Profile != nil {
+			config.Args = append(config.Args, cfg.Profile.Args()...)
+		}
 	}
 
 	return config
@@ -218,7 +222,7 @@ func ValidateConfidence(c string) bool {
 // ValidateObservationType checks if an observation type is valid.
 func ValidateObservationType(t string) bool {
 	switch ObservationType(t) {
-	case ObservationConfusion, ObservationFriction, ObservationError, ObservationSuccess, ObservationSuggestion:
+	case ObservationConfusion, ObservationFriction, ObservationError, ObservationSuccess, ObservationSuggestion, ObservationAccessibility:
 		return true
 	default:
 		return false
@@ -0,0 +1,177 @@
+package tester
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Network profiles for a scenario's network block.
+const (
+	NetworkSlow3G           = "slow-3g"
+	NetworkFast3G           = "fast-3g"
+	NetworkOfflineIntervals = "offline-intervals"
+	NetworkCustom           = "custom"
+)
+
+// Default offline-intervals timing: 5s offline every 30s.
+const (
+	DefaultOfflineEvery = 30 * time.Second
+	DefaultOfflineFor   = 5 * time.Second
+)
+
+// ScenarioNetwork emulates degraded network conditions for a scenario, so
+// UX observations can be gathered on slow or flaky connections.
+type ScenarioNetwork struct {
+	// Profile is slow-3g, fast-3g, offline-intervals, or custom.
+	Profile string `yaml:"profile"`
+
+	// LatencyMs is the added round-trip latency (custom profile).
+	LatencyMs int `yaml:"latency_ms,omitempty"`
+
+	// DownloadKbps and UploadKbps cap throughput in kilobits per second
+	// (custom profile). Zero leaves the direction unthrottled.
+	DownloadKbps int `yaml:"download_kbps,omitempty"`
+	UploadKbps   int `yaml:"upload_kbps,omitempty"`
+
+	// OfflineEvery and OfflineFor time the outages of the
+	// offline-intervals profile. Default: 5s offline every 30s.
+	OfflineEvery YAMLDuration `yaml:"offline_every,omitempty"`
+	OfflineFor   YAMLDuration `yaml:"offline_for,omitempty"`
+}
+
+// NetworkConditions are the resolved emulation settings for a profile.
+type NetworkConditions struct {
+	LatencyMs    int `json:"latency_ms"`
+	DownloadKbps int `json:"download_kbps,omitempty"`
+	UploadKbps   int `json:"upload_kbps,omitempty"`
+
+	// OfflineEveryMs and OfflineForMs are zero unless the connection drops
+	// periodically.
+	OfflineEveryMs int64 `json:"offline_every_ms,omitempty"`
+	OfflineForMs   int64 `json:"offline_for_ms,omitempty"`
+}
+
+// networkPresets are the throttled profiles, matching Chrome DevTools.
+var networkPresets = map[string]NetworkConditions{
+	NetworkSlow3G: {LatencyMs: 2000, DownloadKbps: 400, UploadKbps: 400},
+	NetworkFast3G: {LatencyMs: 563, DownloadKbps: 1440, UploadKbps: 675},
+}
+
+// Conditions resolves the profile to emulation settings.
+func (n *ScenarioNetwork) Conditions() NetworkConditions {
+	switch n.Profile {
+	case NetworkCustom:
+		return NetworkConditions{LatencyMs: n.LatencyMs, DownloadKbps: n.DownloadKbps, UploadKbps: n.UploadKbps}
+	case NetworkOfflineIntervals:
+		every, off := n.OfflineEvery.Duration(), n.OfflineFor.Duration()
+		if every == 0 {
+			every = DefaultOfflineEvery
+		}
+		if off == 0 {
+			off = DefaultOfflineFor
+		}
+		return NetworkConditions{OfflineEveryMs: every.Milliseconds(), OfflineForMs: off.Milliseconds()}
+	}
+	return networkPresets[n.Profile]
+}
+
+// Describe summarizes the profile for results and reports, e.g.
+// "slow-3g (2000ms, 400/400 kbps)" or "offline-intervals (5s every 30s)".
+func (n *ScenarioNetwork) Describe() string {
+	if n == nil {
+		return ""
+	}
+	c := n.Conditions()
+	if c.OfflineEveryMs > 0 {
+		return fmt.Sprintf("%s (%s every %s)", n.Profile,
+			time.Duration(c.OfflineForMs)*time.Millisecond, time.Duration(c.OfflineEveryMs)*time.Millisecond)
+	}
+	kbps := func(v int) string {
+		if v == 0 {
+			return "unlimited"
+		}
+		return fmt.Sprint(v)
+	}
+	return fmt.Sprintf("%s (%dms, %s/%s kbps)", n.Profile, c.LatencyMs, kbps(c.DownloadKbps), kbps(c.UploadKbps))
+}
+
+// validate checks the network block.
+func (n *ScenarioNetwork) validate() error {
+	switch n.Profile {
+	case NetworkSlow3G, NetworkFast3G, NetworkOfflineIntervals, NetworkCustom:
+	case "":
+		return fmt.Errorf("network.profile is required")
+	default:
+		return fmt.Errorf("network.profile must be one of: slow-3g, fast-3g, offline-intervals, custom")
+	}
+
+	throttled := n.LatencyMs != 0 || n.DownloadKbps != 0 || n.UploadKbps != 0
+	if n.LatencyMs < 0 || n.DownloadKbps < 0 || n.UploadKbps < 0 {
+		return fmt.Errorf("network.latency_ms, download_kbps and upload_kbps cannot be negative")
+	}
+	if n.Profile == NetworkCustom && !throttled {
+		return fmt.Errorf("network profile custom needs at least one of latency_ms, download_kbps, upload_kbps")
+	}
+	if n.Profile != NetworkCustom && throttled {
+		return fmt.Errorf("network.latency_ms, download_kbps and upload_kbps only apply to the custom profile")
+	}
+
+	intervals := n.OfflineEvery != 0 || n.OfflineFor != 0
+	if n.Profile != NetworkOfflineIntervals && intervals {
+		return fmt.Errorf("network.offline_every and offline_for only apply to the offline-intervals profile")
+	}
+	if n.OfflineEvery < 0 || n.OfflineFor < 0 {
+		return fmt.Errorf("network.offline_every and offline_for cannot be negative")
+	}
+	if c := n.Conditions(); c.OfflineEveryMs > 0 && c.OfflineForMs >= c.OfflineEveryMs {
+		return fmt.Errorf("network.offline_for must be shorter than offline_every")
+	}
+	return nil
+}
+
+// NetworkEmulationScript returns a Playwright function that applies the
+// profile to a page: throttling through the Chrome DevTools Protocol, and
+// periodic outages through context.setOffline. The agent runs it with
+// browser_run_code before navigating; the probe script runs it directly.
+func NetworkEmulationScript(n *ScenarioNetwork) string {
+	conditions, _ := json.Marshal(n.Conditions())
+	return fmt.Sprintf(`async (page) => {
+  const c = %s;
+  const kbps = (v) => v ? v * 125 : -1;
+  if (c.latency_ms || c.download_kbps || c.upload_kbps) {
+    const cdp = await page.context().newCDPSession(page);
+    await cdp.send('Network.enable');
+    await cdp.send('Network.emulateNetworkConditions', { offline: false,
+      latency: c.latency_ms, downloadThroughput: kbps(c.download_kbps), uploadThroughput: kbps(c.upload_kbps) });
+  }
+  if (c.offline_every_ms) {
+    const ctx = page.context();
+    setInterval(() => {
+      ctx.setOffline(true).catch(() => {});
+      setTimeout(() => ctx.setOffline(false).catch(() => {}), c.offline_for_ms);
+    }, c.offline_every_ms);
+  }
+}`, conditions)
+}
+
+// NetworkInstructions is appended to the tester CLAUDE.md for scenarios
+// with a network profile.
+func NetworkInstructions(n *ScenarioNetwork) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, `
+## Network Conditions
+
+This test runs on a degraded connection: %s. Before your first navigation,
+run this script with browser_run_code to apply it:
+
+`, n.Describe())
+	b.WriteString("```js\n" + NetworkEmulationScript(n) + "\n```\n")
+	b.WriteString(`
+Slowness and dropped connections are the point of this test, not
+infrastructure errors. Record how the app copes (loading states, error
+messages, lost input, retries) as observations, and keep going.
+`)
+	return b.String()
+}
@@ -4,26 +4,67 @@ import (
 	"fmt"
 	"net/url"
 	"os"
+	"path/filepath"
+	"sort"
 	"strings"
 
 	"gopkg.in/yaml.v3"
 )
 
-// ParseScenarioFile reads and parses a scenario YAML file.
+// ParseScenarioFile reads and parses a scenario YAML file. Its includes
+// resolve against the file's directory.
 func ParseScenarioFile(path string) (*ScenarioConfig, error) {
 	data, err := os.ReadFile(path) //nolint:gosec // G304: path is from trusted scenario directory
 	if err != nil {
 		return nil, fmt.Errorf("reading scenario file: %w", err)
 	}
-	return ParseScenario(data)
+	return parseScenario(data, path, filepath.Dir(path))
 }
 
-// ParseScenario parses scenario YAML content from bytes.
+// ParseScenario parses scenario YAML content from bytes. Its includes
+// resolve against the working directory.
 func ParseScenario(data []byte) (*ScenarioConfig, error) {
+	return parseScenario(data, "", ".")
+}
+
+// parseScenario parses scenario YAML read from path (if any), merging in
+// the fragments it includes from dir.
+func parseScenario(data []byte, path, dir string) (*ScenarioConfig, error) {
+	files, err := resolveIncludes(data, path, dir)
+	if err != nil {
+		return nil, err
+	}
+	var doc *yaml.Node
+	if len(files) == 1 {
+		doc, err = scenarioDocument(data)
+	} else {
+		doc, err = includeDocument(files)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Check the schema version before decoding: deprecated fields may not
+	// decode into the current types at all.
+	warning, err := checkScenarioVersion(doc)
+	if err != nil {
+		return nil, fmt.Errorf("scenario validation failed: %w", err)
+	}
+
 	var s ScenarioConfig
-	if err := yaml.Unmarshal(data, &s); err != nil {
+	if err := doc.Decode(&s); err != nil {
 		return nil, fmt.Errorf("parsing YAML: %w", err)
 	}
+	for _, f := range files[:len(files)-1] {
+		s.Includes = append(s.Includes, f.path)
+	}
+	if warning != "" {
+		s.Warnings = append(s.Warnings, warning)
+	}
+
+	if f := s.Environment.ProfileFixture; f != "" && !filepath.IsAbs(f) {
+		s.Environment.ProfileFixture = filepath.Join(dir, f)
+	}
 
 	// Apply defaults
 	s.applyDefaults()
@@ -37,6 +78,11 @@ func ParseScenario(data []byte) (*ScenarioConfig, error) {
 
 // applyDefaults sets default values for optional fields.
 func (s *ScenarioConfig) applyDefaults() {
+	// Unversioned scenarios without deprecated fields are version 1
+	if s.Version == 0 {
+		s.Version = CurrentScenarioVersion
+	}
+
 	// Default timeout
 	if s.Timeout == 0 {
 		s.Timeout = DefaultScenarioTimeout()
@@ -106,6 +152,16 @@ func (s *ScenarioConfig) Validate() error {
 		errs = append(errs, "persona field is required")
 	}
 
+	seenPersonas := make(map[string]bool, len(s.PersonaPool))
+	for i, p := range s.PersonaPool {
+		if strings.TrimSpace(p) == "" {
+			errs = append(errs, fmt.Sprintf("persona_pool[%d] is empty", i))
+		} else if seenPersonas[p] {
+			errs = append(errs, fmt.Sprintf("persona_pool lists %q twice", p))
+		}
+		seenPersonas[p] = true
+	}
+
 	if s.Goal == "" {
 		errs = append(errs, "goal field is required")
 	}
@@ -147,6 +203,44 @@ func (s *ScenarioConfig) Validate() error {
 		}
 	}
 
+	// Flake policy validation
+	if s.FlakePolicy != nil {
+		if s.FlakePolicy.Window < 0 {
+			errs = append(errs, "flake_policy.window cannot be negative")
+		}
+		if s.FlakePolicy.Threshold < 0 || s.FlakePolicy.Threshold > 1 {
+			errs = append(errs, "flake_policy.threshold must be between 0 and 1")
+		}
+	}
+
+	// Abort conditions validation
+	if s.AbortIf != nil {
+		if err := s.validateAbortIf(); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+
+	// Severity budget validation
+	sevs := make([]string, 0, len(s.SeverityBudget))
+	for sev := range s.SeverityBudget {
+		sevs = append(sevs, sev)
+	}
+	sort.Strings(sevs)
+	for _, sev := range sevs {
+		if budget := s.SeverityBudget[sev]; !ValidateSeverity(sev) {
+			errs = append(errs, fmt.Sprintf("severity_budget has invalid severity %q (use P0, P1, P2, P3)", sev))
+		} else if budget < 0 {
+			errs = append(errs, fmt.Sprintf("severity_budget.%s cannot be negative", sev))
+		}
+	}
+
+	// Network emulation validation
+	if s.Network != nil {
+		if err := s.Network.validate(); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+
 	if len(errs) > 0 {
 		return fmt.Errorf("scenario validation failed:\n  - %s", strings.Join(errs, "\n  - "))
 	}
@@ -320,6 +414,31 @@ func (s *ScenarioConfig) validateRecording() error {
 	return nil
 }
 
+func (s *ScenarioConfig) validateAbortIf() error {
+	a := s.AbortIf
+
+	if a.IsEmpty() {
+		return fmt.Errorf("abort_if must set at least one of selectors, http_status, text")
+	}
+	for _, sel := range a.Selectors {
+		if strings.TrimSpace(sel) == "" {
+			return fmt.Errorf("abort_if.selectors cannot contain empty selectors")
+		}
+	}
+	for _, code := range a.HTTPStatus {
+		if code < 100 || code > 599 {
+			return fmt.Errorf("abort_if.http_status contains invalid status code: %d", code)
+		}
+	}
+	for _, text := range a.Text {
+		if strings.TrimSpace(text) == "" {
+			return fmt.Errorf("abort_if.text cannot contain empty strings")
+		}
+	}
+
+	return nil
+}
+
 // IsRetryable returns true if the given error type should trigger a retry.
 func (s *ScenarioConfig) IsRetryable(errorType string) bool {
 	if s.Retry == nil {
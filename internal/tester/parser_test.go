@@ -351,6 +351,158 @@ test_data:
 	}
 }
 
+func TestParseScenario_FlakePolicy(t *testing.T) {
+	yaml := `
+scenario: explore_settings
+persona: sarah
+goal: test
+success_criteria:
+  - ok
+environment:
+  url: https://example.com
+flake_policy:
+  window: 20
+  threshold: 0.5
+  auto_quarantine: false
+`
+	s, err := ParseScenario([]byte(yaml))
+	if err != nil {
+		t.Fatalf("ParseScenario failed: %v", err)
+	}
+	p := s.FlakePolicy
+	if p == nil || p.Window != 20 || p.Threshold != 0.5 || p.AutoQuarantine == nil || *p.AutoQuarantine {
+		t.Errorf("FlakePolicy = %+v", p)
+	}
+
+	_, err = ParseScenario([]byte(strings.Replace(yaml, "threshold: 0.5", "threshold: 50", 1)))
+	if err == nil || !strings.Contains(err.Error(), "flake_policy.threshold") {
+		t.Errorf("Error = %v, want flake_policy.threshold error", err)
+	}
+}
+
+func TestParseScenario_AbortIf(t *testing.T) {
+	yaml := `
+scenario: checkout
+persona: sarah
+goal: test
+success_criteria:
+  - ok
+environment:
+  url: https://example.com
+abort_if:
+  selectors: [".maintenance-banner"]
+  http_status: [503]
+  text: ["rate limited"]
+`
+	s, err := ParseScenario([]byte(yaml))
+	if err != nil {
+		t.Fatalf("ParseScenario failed: %v", err)
+	}
+	a := s.AbortIf
+	if a.IsEmpty() || a.Selectors[0] != ".maintenance-banner" || a.HTTPStatus[0] != 503 || a.Text[0] != "rate limited" {
+		t.Errorf("AbortIf = %+v", a)
+	}
+
+	_, err = ParseScenario([]byte(strings.Replace(yaml, "[503]", "[5030]", 1)))
+	if err == nil || !strings.Contains(err.Error(), "abort_if.http_status") {
+		t.Errorf("Error = %v, want abort_if.http_status error", err)
+	}
+}
+
+func TestParseScenario_Network(t *testing.T) {
+	base := `
+scenario: checkout
+persona: sarah
+goal: test
+success_criteria:
+  - ok
+environment:
+  url: https://example.com
+`
+	tests := []struct {
+		network  string
+		describe string
+		wantErr  string
+	}{
+		{"network: {profile: slow-3g}", "slow-3g (2000ms, 400/400 kbps)", ""},
+		{"network: {profile: custom, latency_ms: 300, download_kbps: 1000}", "custom (300ms, 1000/unlimited kbps)", ""},
+		{"network: {profile: offline-intervals}", "offline-intervals (5s every 30s)", ""},
+		{"network: {profile: offline-intervals, offline_every: 1m, offline_for: 10s}", "offline-intervals (10s every 1m0s)", ""},
+		{"network: {profile: dialup}", "", "network.profile must be one of"},
+		{"network: {profile: custom}", "", "custom needs at least one of"},
+		{"network: {profile: slow-3g, latency_ms: 100}", "", "only apply to the custom profile"},
+		{"network: {profile: offline-intervals, offline_every: 5s, offline_for: 5s}", "", "offline_for must be shorter"},
+	}
+	for _, tt := range tests {
+		s, err := ParseScenario([]byte(base + tt.network))
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("%s: error = %v, want %q", tt.network, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", tt.network, err)
+			continue
+		}
+		if got := s.Network.Describe(); got != tt.describe {
+			t.Errorf("%s: Describe() = %q, want %q", tt.network, got, tt.describe)
+		}
+	}
+}
+
+func TestParseScenario_SeverityBudget(t *testing.T) {
+	yaml := `
+scenario: checkout
+persona: sarah
+goal: test
+success_criteria:
+  - ok
+environment:
+  url: https://example.com
+severity_budget:
+  P0: 0
+  P1: 2
+`
+	s, err := ParseScenario([]byte(yaml))
+	if err != nil {
+		t.Fatalf("ParseScenario failed: %v", err)
+	}
+	if got := s.CheckSeverityBudget(map[string]int{"P1": 2, "P2": 9}); len(got) != 0 {
+		t.Errorf("within budget, got %v", got)
+	}
+	got := s.CheckSeverityBudget(map[string]int{"P0": 1, "P1": 3})
+	if len(got) != 2 || got[0] != "1 P0 observations (budget 0)" || got[1] != "3 P1 observations (budget 2)" {
+		t.Errorf("CheckSeverityBudget() = %v", got)
+	}
+
+	_, err = ParseScenario([]byte(strings.Replace(yaml, "P1: 2", "P5: 2", 1)))
+	if err == nil || !strings.Contains(err.Error(), `invalid severity "P5"`) {
+		t.Errorf("Error = %v, want invalid severity error", err)
+	}
+	_, err = ParseScenario([]byte(strings.Replace(yaml, "P1: 2", "P1: -1", 1)))
+	if err == nil || !strings.Contains(err.Error(), "severity_budget.P1 cannot be negative") {
+		t.Errorf("Error = %v, want negative budget error", err)
+	}
+}
+
+func TestNetworkEmulationScript(t *testing.T) {
+	script := NetworkEmulationScript(&ScenarioNetwork{Profile: NetworkSlow3G})
+	for _, want := range []string{`"latency_ms":2000`, "Network.emulateNetworkConditions", "setOffline"} {
+		if !strings.Contains(script, want) {
+			t.Errorf("script missing %q:\n%s", want, script)
+		}
+	}
+
+	rendered, err := RenderTesterTemplate(&TesterTemplateData{Network: &ScenarioNetwork{Profile: NetworkOfflineIntervals}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(rendered, "## Network Conditions") || !strings.Contains(rendered, "browser_run_code") {
+		t.Error("template missing network instructions")
+	}
+}
+
 func TestParseScenario_InvalidWaitStrategies(t *testing.T) {
 	yaml := `
 scenario: test
@@ -395,6 +547,30 @@ environment:
 	}
 }
 
+func TestParseScenario_PersonaPool(t *testing.T) {
+	base := `
+scenario: test
+persona: sarah
+goal: test
+success_criteria:
+  - ok
+environment:
+  url: https://example.com
+`
+	s, err := ParseScenario([]byte(base + "persona_pool: [sarah, miguel]\n"))
+	if err != nil {
+		t.Fatalf("ParseScenario failed: %v", err)
+	}
+	if len(s.PersonaPool) != 2 || s.PersonaPool[1] != "miguel" {
+		t.Errorf("PersonaPool = %v, want [sarah miguel]", s.PersonaPool)
+	}
+
+	_, err = ParseScenario([]byte(base + "persona_pool: [sarah, sarah]\n"))
+	if err == nil || !strings.Contains(err.Error(), "twice") {
+		t.Errorf("Error = %v, want a duplicate persona error", err)
+	}
+}
+
 func TestScenarioConfig_IsRetryable(t *testing.T) {
 	s := &ScenarioConfig{
 		Retry: &ScenarioRetry{
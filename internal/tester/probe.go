@@ -0,0 +1,286 @@
+package tester
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Wait strategy names reported by a probe.
+const (
+	StrategyNetworkIdle       = "network_idle"
+	StrategyAnimationComplete = "animation_complete"
+	StrategySelector          = "custom_selector"
+	StrategyMinLoadTime       = "min_load_time"
+)
+
+// DefaultProbeTimeout is how long a probe waits for each strategy.
+const DefaultProbeTimeout = 30 * time.Second
+
+// StrategyProbe is the outcome of waiting for one wait strategy.
+type StrategyProbe struct {
+	// Strategy is the wait strategy name (see Strategy* constants).
+	Strategy string `json:"strategy"`
+
+	// Target is the selector for custom_selector probes.
+	Target string `json:"target,omitempty"`
+
+	// Resolved is true if the strategy was satisfied within the timeout.
+	Resolved bool `json:"resolved"`
+
+	// DurationMs is the time from navigation start until the strategy
+	// resolved (or gave up).
+	DurationMs int64 `json:"duration_ms"`
+
+	// Error explains why the strategy did not resolve.
+	Error string `json:"error,omitempty"`
+}
+
+// ProbeResult reports how a scenario's wait strategies behave against the
+// live target, so authors can tune wait_strategies before full runs.
+type ProbeResult struct {
+	Scenario string `json:"scenario"`
+	URL      string `json:"url"`
+
+	// Status is the HTTP status of the navigation response.
+	Status int `json:"status"`
+
+	// LoadMs is the time until the page's load event.
+	LoadMs int64 `json:"load_ms"`
+
+	// TimeoutMs is the per-strategy timeout the probe used.
+	TimeoutMs int64 `json:"timeout_ms"`
+
+	// Network describes the emulated network profile, if any.
+	Network string `json:"network,omitempty"`
+
+	Strategies []StrategyProbe `json:"strategies"`
+
+	// Advice lists tuning suggestions derived from the timings.
+	Advice []string `json:"advice,omitempty"`
+}
+
+// probeRequest is passed to the probe script as JSON.
+type probeRequest struct {
+	URL               string   `json:"url"`
+	TimeoutMs         int64    `json:"timeout_ms"`
+	Width             int      `json:"width,omitempty"`
+	Height            int      `json:"height,omitempty"`
+	Device            string   `json:"device,omitempty"`
+	NetworkIdle       bool     `json:"network_idle"`
+	AnimationComplete bool     `json:"animation_complete"`
+	Selectors         []string `json:"selectors,omitempty"`
+	MinLoadTimeMs     int      `json:"min_load_time_ms"`
+
+	// NetworkScript is the scenario's NetworkEmulationScript, applied
+	// before navigating.
+	NetworkScript string `json:"network_script,omitempty"`
+}
+
+// probeScript navigates with Playwright and times each wait strategy
+// concurrently from navigation start. It prints a ProbeResult as JSON.
+const probeScript = `
+const { chromium, devices } = require('playwright');
+const req = JSON.parse(process.env.GT_PROBE_REQUEST);
+
+(async () => {
+  const browser = await chromium.launch();
+  const opts = req.device && devices[req.device] ? { ...devices[req.device] }
+    : (req.width ? { viewport: { width: req.width, height: req.height } } : {});
+  const page = await (await browser.newContext(opts)).newPage();
+  const out = { url: req.url, timeout_ms: req.timeout_ms, strategies: [] };
+  if (req.network_script) {
+    await eval('(' + req.network_script + ')')(page);
+  }
+
+  const start = Date.now();
+  const since = () => Date.now() - start;
+  const loaded = page.waitForEvent('load', { timeout: req.timeout_ms })
+    .then(() => { out.load_ms = since(); }, () => {});
+  const time = (strategy, target, wait) => wait().then(
+    () => out.strategies.push({ strategy, target, resolved: true, duration_ms: since() }),
+    (e) => out.strategies.push({ strategy, target, resolved: false, duration_ms: since(),
+      error: String(e && e.message || e).split('\n')[0] }));
+
+  const resp = await page.goto(req.url, { waitUntil: 'commit', timeout: req.timeout_ms });
+  out.status = resp ? resp.status() : 0;
+
+  const waits = [loaded];
+  if (req.network_idle) {
+    waits.push(time('network_idle', undefined, () => page.waitForLoadState('networkidle', { timeout: req.timeout_ms })));
+  }
+  if (req.animation_complete) {
+    waits.push(time('animation_complete', undefined, () => page.waitForFunction(
+      () => document.readyState === 'complete' &&
+        document.getAnimations().every(a => a.playState !== 'running'),
+      null, { timeout: req.timeout_ms, polling: 100 })));
+  }
+  for (const sel of req.selectors || []) {
+    waits.push(time('custom_selector', sel, () => page.waitForSelector(sel, { timeout: req.timeout_ms })));
+  }
+  await Promise.all(waits);
+
+  await browser.close();
+  console.log(JSON.stringify(out));
+})().catch((e) => { console.error(String(e && e.message || e)); process.exit(1); });
+`
+
+// ProbeWaitStrategies loads the scenario's target URL in a headless
+// browser and measures how long each configured wait strategy takes to
+// resolve. It requires Node.js with the playwright package resolvable from
+// the working directory.
+func ProbeWaitStrategies(ctx context.Context, s *ScenarioConfig, timeout time.Duration) (*ProbeResult, error) {
+	if timeout <= 0 {
+		timeout = DefaultProbeTimeout
+	}
+	req := newProbeRequest(s, timeout)
+	reqJSON, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	dir, err := os.MkdirTemp("", "gt-probe-*")
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	scriptPath := filepath.Join(dir, "probe.js")
+	if err := os.WriteFile(scriptPath, []byte(probeScript), 0600); err != nil {
+		return nil, err
+	}
+
+	// Allow for navigation plus the slowest strategy, and browser startup
+	ctx, cancel := context.WithTimeout(ctx, 2*timeout+30*time.Second)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "node", scriptPath)
+	cmd.Env = append(os.Environ(), "GT_PROBE_REQUEST="+string(reqJSON))
+	if wd, err := os.Getwd(); err == nil {
+		// Resolve playwright from the project, not the temp dir
+		cmd.Env = append(cmd.Env, "NODE_PATH="+filepath.Join(wd, "node_modules"))
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			msg = err.Error()
+		}
+		return nil, fmt.Errorf("probe failed: %s", msg)
+	}
+
+	return parseProbeOutput(s, req, stdout.Bytes())
+}
+
+func newProbeRequest(s *ScenarioConfig, timeout time.Duration) probeRequest {
+	req := probeRequest{
+		URL:       s.Environment.URL,
+		TimeoutMs: timeout.Milliseconds(),
+		Device:    s.Environment.Device,
+	}
+	if v := s.Environment.Viewport; v != nil {
+		req.Width, req.Height = v.Width, v.Height
+	}
+	if ws := s.WaitStrategies; ws != nil {
+		req.NetworkIdle = ws.NetworkIdle
+		req.AnimationComplete = ws.AnimationComplete
+		req.Selectors = ws.CustomSelectors
+		req.MinLoadTimeMs = ws.MinLoadTime
+	}
+	if s.Network != nil {
+		req.NetworkScript = NetworkEmulationScript(s.Network)
+	}
+	return req
+}
+
+// parseProbeOutput decodes the probe script output, orders strategies as
+// configured, adds the min_load_time entry, and derives advice.
+func parseProbeOutput(s *ScenarioConfig, req probeRequest, data []byte) (*ProbeResult, error) {
+	var result ProbeResult
+	if err := json.Unmarshal(bytes.TrimSpace(data), &result); err != nil {
+		return nil, fmt.Errorf("parsing probe output: %w", err)
+	}
+	result.Scenario = s.Scenario
+	result.URL = req.URL
+	result.TimeoutMs = req.TimeoutMs
+	result.Network = s.Network.Describe()
+
+	// The script records strategies as they resolve; report them in
+	// configuration order instead.
+	order := func(p StrategyProbe) int {
+		switch p.Strategy {
+		case StrategyNetworkIdle:
+			return -2
+		case StrategyAnimationComplete:
+			return -1
+		}
+		for i, sel := range req.Selectors {
+			if sel == p.Target {
+				return i
+			}
+		}
+		return len(req.Selectors)
+	}
+	sorted := make([]StrategyProbe, 0, len(result.Strategies)+1)
+	for rank := -2; rank <= len(req.Selectors); rank++ {
+		for _, p := range result.Strategies {
+			if order(p) == rank {
+				sorted = append(sorted, p)
+			}
+		}
+	}
+	if req.MinLoadTimeMs > 0 {
+		// A fixed delay always resolves; it is reported for comparison
+		sorted = append(sorted, StrategyProbe{
+			Strategy:   StrategyMinLoadTime,
+			Resolved:   true,
+			DurationMs: int64(req.MinLoadTimeMs),
+		})
+	}
+	result.Strategies = sorted
+	result.Advice = probeAdvice(&result)
+
+	return &result, nil
+}
+
+// probeAdvice suggests wait_strategies changes based on probe timings.
+func probeAdvice(r *ProbeResult) []string {
+	var advice []string
+
+	var slowest int64
+	var minLoad *StrategyProbe
+	for i, p := range r.Strategies {
+		switch {
+		case p.Strategy == StrategyMinLoadTime:
+			minLoad = &r.Strategies[i]
+		case !p.Resolved:
+			name := p.Strategy
+			if p.Target != "" {
+				name = fmt.Sprintf("%s %q", p.Strategy, p.Target)
+			}
+			advice = append(advice, fmt.Sprintf("%s did not resolve within %dms; fix or remove it", name, r.TimeoutMs))
+		case p.DurationMs > slowest:
+			slowest = p.DurationMs
+		}
+	}
+
+	if len(r.Strategies) == 0 {
+		advice = append(advice, fmt.Sprintf("no wait strategies configured; page load took %dms", r.LoadMs))
+	}
+	if minLoad != nil && slowest > 0 && minLoad.DurationMs <= slowest {
+		advice = append(advice, fmt.Sprintf("min_load_time (%dms) elapses before the other strategies resolve (%dms) and adds nothing", minLoad.DurationMs, slowest))
+	}
+	if minLoad != nil && slowest > 0 && minLoad.DurationMs > 2*slowest {
+		advice = append(advice, fmt.Sprintf("min_load_time (%dms) is well above the slowest strategy (%dms); consider lowering it", minLoad.DurationMs, slowest))
+	}
+
+	return advice
+}
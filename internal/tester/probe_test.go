@@ -0,0 +1,99 @@
+package tester
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func probeScenario(ws *ScenarioWaitStrategies) *ScenarioConfig {
+	return &ScenarioConfig{
+		Scenario: "signup",
+		Environment: ScenarioEnvironment{
+			URL:      "https://staging.example.com",
+			Viewport: &ScenarioViewport{Width: 390, Height: 844},
+		},
+		WaitStrategies: ws,
+	}
+}
+
+func TestNewProbeRequest(t *testing.T) {
+	s := probeScenario(&ScenarioWaitStrategies{
+		NetworkIdle:     true,
+		CustomSelectors: []string{"#app"},
+		MinLoadTime:     500,
+	})
+
+	req := newProbeRequest(s, 10*time.Second)
+	if req.URL != "https://staging.example.com" || req.TimeoutMs != 10000 {
+		t.Errorf("unexpected request: %+v", req)
+	}
+	if req.Width != 390 || req.Height != 844 {
+		t.Errorf("viewport = %dx%d, want 390x844", req.Width, req.Height)
+	}
+	if !req.NetworkIdle || req.AnimationComplete || len(req.Selectors) != 1 || req.MinLoadTimeMs != 500 {
+		t.Errorf("wait strategies not copied: %+v", req)
+	}
+}
+
+func TestParseProbeOutput(t *testing.T) {
+	s := probeScenario(&ScenarioWaitStrategies{
+		NetworkIdle:     true,
+		CustomSelectors: []string{"#app", "[data-ready]"},
+		MinLoadTime:     200,
+	})
+	req := newProbeRequest(s, 5*time.Second)
+
+	// Strategies arrive in resolution order
+	out := `{"status":200,"load_ms":300,"strategies":[
+		{"strategy":"custom_selector","target":"#app","resolved":true,"duration_ms":400},
+		{"strategy":"network_idle","resolved":true,"duration_ms":900},
+		{"strategy":"custom_selector","target":"[data-ready]","resolved":false,"duration_ms":5000,"error":"Timeout 5000ms exceeded."}
+	]}`
+
+	result, err := parseProbeOutput(s, req, []byte(out))
+	if err != nil {
+		t.Fatalf("parseProbeOutput: %v", err)
+	}
+	if result.Scenario != "signup" || result.Status != 200 || result.LoadMs != 300 {
+		t.Errorf("unexpected result header: %+v", result)
+	}
+
+	var got []string
+	for _, p := range result.Strategies {
+		got = append(got, p.Strategy+":"+p.Target)
+	}
+	want := "network_idle:,custom_selector:#app,custom_selector:[data-ready],min_load_time:"
+	if strings.Join(got, ",") != want {
+		t.Errorf("strategy order = %v, want %s", got, want)
+	}
+
+	advice := strings.Join(result.Advice, "\n")
+	if !strings.Contains(advice, `"[data-ready]" did not resolve`) {
+		t.Errorf("expected advice about unresolved selector, got %q", advice)
+	}
+	if !strings.Contains(advice, "min_load_time (200ms) elapses before") {
+		t.Errorf("expected advice about redundant min_load_time, got %q", advice)
+	}
+}
+
+func TestProbeAdvice_MinLoadTimeTooHigh(t *testing.T) {
+	r := &ProbeResult{
+		TimeoutMs: 30000,
+		Strategies: []StrategyProbe{
+			{Strategy: StrategyNetworkIdle, Resolved: true, DurationMs: 800},
+			{Strategy: StrategyMinLoadTime, Resolved: true, DurationMs: 3000},
+		},
+	}
+	advice := probeAdvice(r)
+	if len(advice) != 1 || !strings.Contains(advice[0], "consider lowering it") {
+		t.Errorf("advice = %v", advice)
+	}
+}
+
+func TestParseProbeOutput_Invalid(t *testing.T) {
+	s := probeScenario(nil)
+	if _, err := parseProbeOutput(s, newProbeRequest(s, 0), []byte("not json")); err == nil {
+		t.Error("expected error for invalid output")
+	}
+}
@@ -0,0 +1,121 @@
+package tester
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// storageStateFile is the copy of a storage state fixture inside a run's
+// profile, so the run can't modify the fixture.
+const storageStateFile = "storage-state.json"
+
+// BrowserProfile is a run's own browser profile. Each run gets a fresh
+// user-data-dir, so cookies and localStorage never bleed between runs,
+// including parallel runs against the same app. Remove wipes it once the
+// run ends.
+type BrowserProfile struct {
+	// Dir is the profile's user-data-dir.
+	Dir string
+
+	// Fixture is the fixture the profile was seeded from, if any.
+	Fixture string
+
+	// StorageState is the seeded Playwright storage state (cookies and
+	// localStorage), for fixtures given as a JSON file. Empty otherwise.
+	StorageState string
+}
+
+// NewBrowserProfile creates a run's profile in a new temporary directory,
+// outside the artifacts so it is never uploaded. A fixture seeds it, e.g.
+// with a logged-in session: a directory is copied in as the user-data-dir,
+// a file is used as a Playwright storage state (the output of
+// storageState() or 'playwright codegen --save-storage').
+func NewBrowserProfile(fixture string) (*BrowserProfile, error) {
+	var info fs.FileInfo
+	if fixture != "" {
+		var err error
+		if info, err = os.Stat(fixture); err != nil {
+			return nil, fmt.Errorf("reading profile fixture: %w", err)
+		}
+	}
+
+	dir, err := os.MkdirTemp("", "gt-tester-profile-")
+	if err != nil {
+		return nil, fmt.Errorf("creating browser profile: %w", err)
+	}
+	p := &BrowserProfile{Dir: dir, Fixture: fixture}
+	if fixture == "" {
+		return p, nil
+	}
+
+	if info.IsDir() {
+		err = copyProfile(fixture, dir)
+	} else {
+		p.StorageState = filepath.Join(dir, storageStateFile)
+		err = copyProfileFile(fixture, p.StorageState)
+	}
+	if err != nil {
+		_ = p.Remove()
+		return nil, fmt.Errorf("seeding browser profile from %s: %w", fixture, err)
+	}
+	return p, nil
+}
+
+// Args returns the Playwright MCP arguments that run the browser in this
+// profile.
+func (p *BrowserProfile) Args() []string {
+	if p.StorageState != "" {
+		// Storage state seeds an isolated (in-memory) context
+		return []string{"--isolated", "--storage-state", p.StorageState}
+	}
+	return []string{"--user-data-dir", p.Dir}
+}
+
+// Remove wipes the profile.
+func (p *BrowserProfile) Remove() error {
+	return os.RemoveAll(p.Dir)
+}
+
+// copyProfile copies a profile directory. Only directories and regular
+// files are copied: a browser's lock files and sockets (SingletonLock and
+// friends) belong to the browser that wrote them.
+func copyProfile(src, dst string) error {
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		switch {
+		case d.IsDir():
+			return os.MkdirAll(target, 0700)
+		case d.Type().IsRegular():
+			return copyProfileFile(path, target)
+		default:
+			return nil
+		}
+	})
+}
+
+func copyProfileFile(src, dst string) error {
+	in, err := os.Open(src) //nolint:gosec // G304: fixture path is from the scenario
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600) //nolint:gosec // G304: dst is inside the new profile
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		_ = out.Close()
+		return err
+	}
+	return out.Close()
+}
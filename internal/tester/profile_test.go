@@ -0,0 +1,109 @@
+package tester
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestNewBrowserProfile_Fresh(t *testing.T) {
+	a, err := NewBrowserProfile("")
+	if err != nil {
+		t.Fatalf("NewBrowserProfile() error = %v", err)
+	}
+	b, err := NewBrowserProfile("")
+	if err != nil {
+		t.Fatalf("NewBrowserProfile() error = %v", err)
+	}
+	if a.Dir == b.Dir {
+		t.Fatalf("runs share profile %s", a.Dir)
+	}
+	if got := a.Args(); !slices.Equal(got, []string{"--user-data-dir", a.Dir}) {
+		t.Errorf("Args() = %v", got)
+	}
+
+	for _, p := range []*BrowserProfile{a, b} {
+		if err := p.Remove(); err != nil {
+			t.Fatalf("Remove() error = %v", err)
+		}
+		if _, err := os.Stat(p.Dir); !os.IsNotExist(err) {
+			t.Errorf("profile %s not wiped", p.Dir)
+		}
+	}
+}
+
+func TestNewBrowserProfile_Fixtures(t *testing.T) {
+	fixtures := t.TempDir()
+
+	// A user-data-dir fixture is copied, minus the browser's lock files
+	userDataDir := filepath.Join(fixtures, "logged-in")
+	if err := os.MkdirAll(filepath.Join(userDataDir, "Default"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(userDataDir, "Default", "Cookies"), []byte("session"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink("host-1234", filepath.Join(userDataDir, "SingletonLock")); err != nil {
+		t.Fatal(err)
+	}
+	p, err := NewBrowserProfile(userDataDir)
+	if err != nil {
+		t.Fatalf("NewBrowserProfile() error = %v", err)
+	}
+	defer func() { _ = p.Remove() }()
+	if data, err := os.ReadFile(filepath.Join(p.Dir, "Default", "Cookies")); err != nil || string(data) != "session" {
+		t.Errorf("cookies not copied: %q, %v", data, err)
+	}
+	if _, err := os.Lstat(filepath.Join(p.Dir, "SingletonLock")); !os.IsNotExist(err) {
+		t.Error("lock file copied into the profile")
+	}
+
+	// A storage state fixture is copied and loaded into an isolated context
+	state := filepath.Join(fixtures, "alice.json")
+	if err := os.WriteFile(state, []byte(`{"cookies":[],"origins":[]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	p, err = NewBrowserProfile(state)
+	if err != nil {
+		t.Fatalf("NewBrowserProfile() error = %v", err)
+	}
+	defer func() { _ = p.Remove() }()
+	if got := p.Args(); !slices.Equal(got, []string{"--isolated", "--storage-state", p.StorageState}) || p.StorageState == state {
+		t.Errorf("Args() = %v, want an isolated copy of %s", got, state)
+	}
+
+	mcp := PlaywrightMCPConfigWithRecording("", &PlaywrightConfig{Profile: p})
+	if !slices.Contains(mcp.Args, "--storage-state") {
+		t.Errorf("MCP args = %v, want the profile's", mcp.Args)
+	}
+
+	if _, err := NewBrowserProfile(filepath.Join(fixtures, "missing.json")); err == nil {
+		t.Error("expected error for a missing fixture")
+	}
+}
+
+func TestParseScenarioFile_ProfileFixture(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "checkout.yaml")
+	content := `
+scenario: checkout
+persona: sarah
+goal: Buy a gift card
+success_criteria:
+  - Order confirmed
+environment:
+  url: https://staging.example.com
+  profile_fixture: fixtures/alice.json
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	s, err := ParseScenarioFile(path)
+	if err != nil {
+		t.Fatalf("ParseScenarioFile() error = %v", err)
+	}
+	if want := filepath.Join(dir, "fixtures", "alice.json"); s.Environment.ProfileFixture != want {
+		t.Errorf("ProfileFixture = %s, want %s", s.Environment.ProfileFixture, want)
+	}
+}
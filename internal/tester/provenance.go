@@ -0,0 +1,111 @@
+package tester
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"time"
+)
+
+// Provenance records everything needed to re-run a scenario exactly as a
+// result was produced: the scenario as it was, the settings resolved from
+// it and the command line, and the tool that ran it.
+type Provenance struct {
+	// ScenarioFile is the absolute path the scenario was loaded from.
+	ScenarioFile string `json:"scenario_file"`
+
+	// ScenarioHash is the SHA-256 of the scenario file, "sha256:<hex>".
+	ScenarioHash string `json:"scenario_hash"`
+
+	// ScenarioContent is the scenario file as it was run, so a rerun does
+	// not depend on the file being unchanged.
+	ScenarioContent string `json:"scenario_content"`
+
+	// Settings are the run settings after applying scenario values,
+	// defaults and command line overrides.
+	Settings RunSettings `json:"settings"`
+
+	// Overrides are the command line flags that were set explicitly.
+	Overrides map[string]string `json:"overrides,omitempty"`
+
+	// GastownVersion is the gt version that produced the result.
+	GastownVersion string `json:"gastown_version"`
+
+	// Platform is the OS and architecture the run happened on.
+	Platform string `json:"platform"`
+
+	// RerunOf is the run directory this run reproduces, if any.
+	RerunOf string `json:"rerun_of,omitempty"`
+
+	// RecordedAt is when the provenance was captured.
+	RecordedAt time.Time `json:"recorded_at"`
+}
+
+// RunSettings are the resolved settings a scenario run used.
+type RunSettings struct {
+	// Model is the agent model.
+	Model string `json:"model"`
+
+	// Environment is the target environment profile (staging, production).
+	Environment string `json:"environment"`
+
+	// MaxAttempts is the number of attempts allowed for infrastructure errors.
+	MaxAttempts int `json:"max_attempts"`
+
+	// TimeoutSeconds is the run timeout.
+	TimeoutSeconds int `json:"timeout_seconds"`
+
+	// Headed shows the browser window.
+	Headed bool `json:"headed"`
+
+	// Video records a video of the run.
+	Video bool `json:"video"`
+
+	// Trace records a Playwright trace.
+	Trace bool `json:"trace"`
+
+	// A11y runs axe-core accessibility scans.
+	A11y bool `json:"a11y"`
+}
+
+// NewProvenance reads the scenario file and records it with the resolved
+// settings.
+func NewProvenance(scenarioPath string, settings RunSettings, overrides map[string]string, version string) (*Provenance, error) {
+	data, err := os.ReadFile(scenarioPath)
+	if err != nil {
+		return nil, fmt.Errorf("reading scenario: %w", err)
+	}
+	abs, err := filepath.Abs(scenarioPath)
+	if err != nil {
+		abs = scenarioPath
+	}
+	return &Provenance{
+		ScenarioFile:    abs,
+		ScenarioHash:    HashScenario(data),
+		ScenarioContent: string(data),
+		Settings:        settings,
+		Overrides:       overrides,
+		GastownVersion:  version,
+		Platform:        runtime.GOOS + "/" + runtime.GOARCH,
+		RecordedAt:      time.Now().UTC(),
+	}, nil
+}
+
+// HashScenario returns the content hash recorded for a scenario file.
+func HashScenario(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// ScenarioChanged reports whether the scenario file on disk no longer
+// matches the recorded content. A missing file counts as changed.
+func (p *Provenance) ScenarioChanged() bool {
+	data, err := os.ReadFile(p.ScenarioFile)
+	if err != nil {
+		return true
+	}
+	return HashScenario(data) != p.ScenarioHash
+}
@@ -0,0 +1,58 @@
+package tester
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNewProvenance(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "signup.yaml")
+	content := "scenario: signup\npersona: sarah\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	settings := RunSettings{Model: "sonnet", Environment: "staging", MaxAttempts: 3, TimeoutSeconds: 600, Video: true}
+	p, err := NewProvenance(path, settings, map[string]string{"model": "sonnet"}, "0.4.0 (dev)")
+	if err != nil {
+		t.Fatalf("NewProvenance: %v", err)
+	}
+
+	if p.ScenarioContent != content {
+		t.Errorf("ScenarioContent = %q", p.ScenarioContent)
+	}
+	if !strings.HasPrefix(p.ScenarioHash, "sha256:") || p.ScenarioHash != HashScenario([]byte(content)) {
+		t.Errorf("ScenarioHash = %q", p.ScenarioHash)
+	}
+	if !filepath.IsAbs(p.ScenarioFile) {
+		t.Errorf("ScenarioFile should be absolute, got %q", p.ScenarioFile)
+	}
+	if p.Settings != settings || p.Overrides["model"] != "sonnet" || p.GastownVersion != "0.4.0 (dev)" {
+		t.Errorf("unexpected provenance: %+v", p)
+	}
+	if p.ScenarioChanged() {
+		t.Error("scenario should be unchanged")
+	}
+
+	if err := os.WriteFile(path, []byte(content+"goal: sign up\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if !p.ScenarioChanged() {
+		t.Error("edited scenario should be reported as changed")
+	}
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	if !p.ScenarioChanged() {
+		t.Error("missing scenario should be reported as changed")
+	}
+}
+
+func TestNewProvenance_MissingFile(t *testing.T) {
+	if _, err := NewProvenance(filepath.Join(t.TempDir(), "missing.yaml"), RunSettings{}, nil, ""); err == nil {
+		t.Error("expected an error for a missing scenario")
+	}
+}
@@ -0,0 +1,47 @@
+package tester
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// RetriesFile is the file in the run directory listing the attempts of a
+// run that failed and were retried.
+const RetriesFile = "retries.json"
+
+// RetryAttempt is one failed attempt of a scenario run that was retried.
+type RetryAttempt struct {
+	// Attempt is the 1-based attempt number.
+	Attempt int `json:"attempt"`
+
+	// Error is the error that ended the attempt.
+	Error string `json:"error"`
+}
+
+// WriteRetryLog records a run's retried attempts in the run directory.
+func WriteRetryLog(dir string, attempts []RetryAttempt) error {
+	data, err := json.MarshalIndent(attempts, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, RetriesFile), data, 0644)
+}
+
+// LoadRetryLog reads the run's retried attempts. It returns nil if the run
+// was not retried.
+func LoadRetryLog(dir string) ([]RetryAttempt, error) {
+	data, err := os.ReadFile(filepath.Join(dir, RetriesFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var attempts []RetryAttempt
+	if err := json.Unmarshal(data, &attempts); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", RetriesFile, err)
+	}
+	return attempts, nil
+}
@@ -5,7 +5,10 @@
 // in types.go.
 package tester
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
 
 // ScenarioConfig represents a parsed scenario YAML file.
 // Scenarios define what an AI persona should accomplish and how to verify success.
@@ -13,13 +16,20 @@ type ScenarioConfig struct {
 	// Scenario is the unique identifier/name for this test scenario.
 	Scenario string `yaml:"scenario"`
 
-	// Version enables breaking change detection and migration tooling.
+	// Version is the scenario schema version (see CurrentScenarioVersion).
+	// Unversioned scenarios are treated as version 1 unless they use
+	// deprecated fields, which 'gt tester migrate-scenarios' rewrites.
 	Version int `yaml:"version"`
 
 	// Persona is the AI persona that will execute this scenario.
 	// Examples: "sarah" (tech-comfortable parent), "miguel" (overwhelmed dad)
 	Persona string `yaml:"persona"`
 
+	// PersonaPool lists personas a batch run with --rotate-personas cycles
+	// through for this scenario, one per batch, to find UX issues that
+	// only affect some kinds of user. Persona is used outside rotation.
+	PersonaPool []string `yaml:"persona_pool,omitempty"`
+
 	// Goal describes what the persona should accomplish in user story format.
 	// This is the primary instruction given to the AI agent.
 	Goal string `yaml:"goal"`
@@ -49,6 +59,32 @@ type ScenarioConfig struct {
 
 	// Tags allow categorizing and filtering scenarios.
 	Tags []string `yaml:"tags,omitempty"`
+
+	// FlakePolicy overrides the batch flake detection policy for this
+	// scenario, e.g. for known-noisy exploratory scenarios.
+	FlakePolicy *ScenarioFlakePolicy `yaml:"flake_policy,omitempty"`
+
+	// AbortIf lists environment conditions (maintenance pages, rate
+	// limiting) that abort the run immediately. Aborted runs never count
+	// toward the flake rate.
+	AbortIf *ScenarioAbortIf `yaml:"abort_if,omitempty"`
+
+	// SeverityBudget caps observations per severity (e.g. P0: 0, P1: 2).
+	// A run that would pass fails when any count exceeds its budget, so
+	// new friction can gate CI even when the success criteria are met.
+	SeverityBudget map[string]int `yaml:"severity_budget,omitempty"`
+
+	// Network emulates a degraded connection (slow-3g, offline-intervals,
+	// custom latency/bandwidth) for the run.
+	Network *ScenarioNetwork `yaml:"network,omitempty"`
+
+	// Warnings lists non-fatal problems found while parsing, such as a
+	// version newer than CurrentScenarioVersion.
+	Warnings []string `yaml:"-"`
+
+	// Includes are the absolute paths of the fragments merged into the
+	// scenario through include:, in merge order.
+	Includes []string `yaml:"-"`
 }
 
 // ScenarioEnvironment configures the target application for testing.
@@ -63,6 +99,12 @@ type ScenarioEnvironment struct {
 	// Device simulates a specific device (overrides viewport).
 	// Examples: "iPhone 12", "Pixel 5", "iPad Pro"
 	Device string `yaml:"device,omitempty"`
+
+	// ProfileFixture seeds each run's fresh browser profile, e.g. with a
+	// logged-in session: a Playwright storage state JSON file, or a
+	// user-data-dir to copy. Relative paths resolve against the scenario
+	// file's directory.
+	ProfileFixture string `yaml:"profile_fixture,omitempty"`
 }
 
 // ScenarioViewport defines browser viewport dimensions for YAML parsing.
@@ -176,6 +218,55 @@ type ScenarioRetry struct {
 	Backoff string `yaml:"backoff,omitempty"`
 }
 
+// ScenarioFlakePolicy overrides flake detection settings for one scenario.
+// Unset fields fall back to the batch flake config.
+type ScenarioFlakePolicy struct {
+	// Window is the number of recent runs considered.
+	Window int `yaml:"window,omitempty"`
+
+	// Threshold is the failure rate (0.0-1.0) at which the scenario is flaky.
+	Threshold float64 `yaml:"threshold,omitempty"`
+
+	// AutoQuarantine controls whether the scenario is quarantined when flaky
+	// (otherwise it is only flagged).
+	AutoQuarantine *bool `yaml:"auto_quarantine,omitempty"`
+}
+
+// ScenarioAbortIf defines conditions that abort a run as soon as the agent
+// sees any of them.
+type ScenarioAbortIf struct {
+	// Selectors abort the run when an element matching one is present.
+	// Example: [".maintenance-banner"]
+	Selectors []string `yaml:"selectors,omitempty"`
+
+	// HTTPStatus aborts the run when a page navigation returns one of these
+	// status codes. Example: [503]
+	HTTPStatus []int `yaml:"http_status,omitempty"`
+
+	// Text aborts the run when the page shows one of these strings
+	// (case-insensitive). Example: ["rate limited"]
+	Text []string `yaml:"text,omitempty"`
+}
+
+// IsEmpty reports whether no abort conditions are set.
+func (a *ScenarioAbortIf) IsEmpty() bool {
+	return a == nil || (len(a.Selectors) == 0 && len(a.HTTPStatus) == 0 && len(a.Text) == 0)
+}
+
+// CheckSeverityBudget compares observation counts by severity against the
+// scenario's severity_budget. It returns one description per exceeded
+// budget, in severity order, e.g. "3 P1 observations (budget 2)".
+func (s *ScenarioConfig) CheckSeverityBudget(counts map[string]int) []string {
+	var exceeded []string
+	for _, sev := range []Severity{SeverityP0, SeverityP1, SeverityP2, SeverityP3} {
+		budget, ok := s.SeverityBudget[string(sev)]
+		if ok && counts[string(sev)] > budget {
+			exceeded = append(exceeded, fmt.Sprintf("%d %s observations (budget %d)", counts[string(sev)], sev, budget))
+		}
+	}
+	return exceeded
+}
+
 // YAMLDuration is a wrapper for time.Duration that supports YAML unmarshaling.
 type YAMLDuration time.Duration
 
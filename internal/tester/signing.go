@@ -0,0 +1,379 @@
+package tester
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Signing methods.
+const (
+	// SignHMAC signs with HMAC-SHA256 under a shared workspace key.
+	SignHMAC = "hmac"
+
+	// SignMinisign signs with a minisign key pair, using the minisign CLI.
+	SignMinisign = "minisign"
+)
+
+// SignatureSuffix is appended to a signed file's name for its signature
+// record. minisign signatures additionally go in MinisignSuffix.
+const (
+	SignatureSuffix = ".sig"
+	MinisignSuffix  = ".minisig"
+)
+
+// SigningKeyEnvVar names the signing key file when no key is given on the
+// command line: the HMAC key, or the minisign secret key when signing.
+const SigningKeyEnvVar = "GT_TESTER_SIGNING_KEY"
+
+// SignedResultFiles are the result files signed in a run or batch
+// directory.
+var SignedResultFiles = []string{"manifest.json", "observations.json"}
+
+// Verification statuses.
+const (
+	VerifyOK       = "ok"
+	VerifyTampered = "tampered"
+	VerifyUnsigned = "unsigned"
+	VerifyError    = "error"
+)
+
+// Signature is the record written next to a signed file. It pins the
+// file's name, run and digest at signing time; the HMAC or minisign
+// signature covers them, so a signature can't be moved to another file or
+// run.
+type Signature struct {
+	// Method is SignHMAC or SignMinisign.
+	Method string `json:"method"`
+
+	// File is the signed file's base name.
+	File string `json:"file"`
+
+	// Run identifies the directory holding the file (see signedRun).
+	Run string `json:"run"`
+
+	// Digest is the SHA-256 of the file, "sha256:<hex>".
+	Digest string `json:"digest"`
+
+	// KeyID identifies the HMAC key without revealing it.
+	KeyID string `json:"key_id,omitempty"`
+
+	// MAC is the hex HMAC-SHA256 of File, Run, Digest and SignedAt under
+	// the key.
+	MAC string `json:"mac,omitempty"`
+
+	SignedAt time.Time `json:"signed_at"`
+}
+
+// Signer signs result files.
+type Signer struct {
+	// Method is SignHMAC or SignMinisign.
+	Method string
+
+	// KeyPath is the HMAC key file, or the minisign secret key.
+	KeyPath string
+}
+
+// DefaultSigningKeyPath returns the workspace HMAC key for a town.
+func DefaultSigningKeyPath(townRoot string) string {
+	return filepath.Join(townRoot, ".runtime", "tester-signing.key")
+}
+
+// NewSigner returns a signer for method. An HMAC key that doesn't exist
+// yet is generated, so a workspace gets its key on first use.
+func NewSigner(method, keyPath string) (*Signer, error) {
+	if keyPath == "" {
+		return nil, fmt.Errorf("a signing key is required (--signing-key or %s)", SigningKeyEnvVar)
+	}
+	switch method {
+	case SignHMAC:
+		if _, err := os.Stat(keyPath); os.IsNotExist(err) {
+			if err := GenerateHMACKey(keyPath); err != nil {
+				return nil, err
+			}
+		}
+	case SignMinisign:
+		if _, err := exec.LookPath("minisign"); err != nil {
+			return nil, fmt.Errorf("minisign signing requires the minisign CLI: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unknown signing method %q (want %s or %s)", method, SignHMAC, SignMinisign)
+	}
+	return &Signer{Method: method, KeyPath: keyPath}, nil
+}
+
+// GenerateHMACKey writes a new random HMAC key to path, readable only by
+// its owner.
+func GenerateHMACKey(path string) error {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return fmt.Errorf("generating signing key: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("creating signing key directory: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(hex.EncodeToString(key)+"\n"), 0600); err != nil {
+		return fmt.Errorf("writing signing key: %w", err)
+	}
+	return nil
+}
+
+// loadHMACKey reads a hex-encoded HMAC key file.
+func loadHMACKey(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading signing key: %w", err)
+	}
+	key, err := hex.DecodeString(strings.TrimSpace(string(data)))
+	if err != nil || len(key) == 0 {
+		return nil, fmt.Errorf("signing key %s is not a hex key", path)
+	}
+	return key, nil
+}
+
+// hmacKeyID returns a short fingerprint of an HMAC key.
+func hmacKeyID(key []byte) string {
+	sum := sha256.Sum256(key)
+	return hex.EncodeToString(sum[:8])
+}
+
+func fileDigest(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:]), nil
+}
+
+// signedRun identifies the run or batch directory holding path by its last
+// three path elements (e.g. "2026-03-30/checkout/run-001"), which survive
+// moving the results root but differ between runs.
+func signedRun(path string) string {
+	dir, err := filepath.Abs(filepath.Dir(path))
+	if err != nil {
+		dir = filepath.Dir(path)
+	}
+	parts := strings.Split(filepath.ToSlash(dir), "/")
+	if len(parts) > 3 {
+		parts = parts[len(parts)-3:]
+	}
+	return strings.Join(parts, "/")
+}
+
+// signedContent is what a signature vouches for besides the file's bytes.
+func signedContent(sig *Signature) string {
+	return fmt.Sprintf("file:%s run:%s digest:%s signed_at:%s",
+		sig.File, sig.Run, sig.Digest, sig.SignedAt.Format(time.RFC3339Nano))
+}
+
+func computeMAC(key []byte, sig *Signature) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(signedContent(sig)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// SignFile signs path, writing its signature record to path+SignatureSuffix
+// (and, for minisign, the minisign signature to path+MinisignSuffix).
+func (s *Signer) SignFile(path string) (*Signature, error) {
+	digest, err := fileDigest(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	sig := &Signature{
+		Method:   s.Method,
+		File:     filepath.Base(path),
+		Run:      signedRun(path),
+		Digest:   digest,
+		SignedAt: time.Now().UTC(),
+	}
+
+	switch s.Method {
+	case SignHMAC:
+		key, err := loadHMACKey(s.KeyPath)
+		if err != nil {
+			return nil, err
+		}
+		sig.KeyID = hmacKeyID(key)
+		sig.MAC = computeMAC(key, sig)
+	case SignMinisign:
+		// The trusted comment is signed too, binding the file and run
+		cmd := exec.Command("minisign", "-S", "-s", s.KeyPath, "-m", path, "-x", path+MinisignSuffix, "-t", signedContent(sig)) //nolint:gosec // G204: fixed CLI, args are file paths
+		if out, err := cmd.CombinedOutput(); err != nil {
+			return nil, fmt.Errorf("minisign: %s", strings.TrimSpace(string(out)))
+		}
+	default:
+		return nil, fmt.Errorf("unknown signing method %q", s.Method)
+	}
+
+	data, err := json.MarshalIndent(sig, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(path+SignatureSuffix, data, 0644); err != nil {
+		return nil, fmt.Errorf("writing signature: %w", err)
+	}
+	return sig, nil
+}
+
+// SignResults signs the SignedResultFiles present in dir and returns the
+// paths signed.
+func (s *Signer) SignResults(dir string) ([]string, error) {
+	var signed []string
+	for _, name := range SignedResultFiles {
+		path := filepath.Join(dir, name)
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+		if _, err := s.SignFile(path); err != nil {
+			return signed, err
+		}
+		signed = append(signed, path)
+	}
+	return signed, nil
+}
+
+// VerifyKeys are the keys signatures are checked against. A method whose
+// key is missing can't be verified.
+type VerifyKeys struct {
+	// HMACKey is the HMAC key file.
+	HMACKey string
+
+	// MinisignPublicKey is the minisign public key file.
+	MinisignPublicKey string
+}
+
+// VerifyResult is the verification outcome for one result file.
+type VerifyResult struct {
+	File   string `json:"file"`
+	Method string `json:"method,omitempty"`
+
+	// Status is VerifyOK, VerifyTampered, VerifyUnsigned or VerifyError.
+	Status string `json:"status"`
+
+	// Detail explains a status other than VerifyOK.
+	Detail string `json:"detail,omitempty"`
+
+	SignedAt *time.Time `json:"signed_at,omitempty"`
+}
+
+// VerifyFile checks path against its signature record.
+func VerifyFile(path string, keys VerifyKeys) VerifyResult {
+	result := VerifyResult{File: path}
+
+	data, err := os.ReadFile(path + SignatureSuffix)
+	if os.IsNotExist(err) {
+		result.Status = VerifyUnsigned
+		result.Detail = "no signature"
+		return result
+	}
+	if err != nil {
+		return verifyError(result, err.Error())
+	}
+	var sig Signature
+	if err := json.Unmarshal(data, &sig); err != nil {
+		return verifyError(result, "invalid signature record: "+err.Error())
+	}
+	result.Method = sig.Method
+	result.SignedAt = &sig.SignedAt
+
+	digest, err := fileDigest(path)
+	if err != nil {
+		return verifyError(result, err.Error())
+	}
+	if digest != sig.Digest {
+		result.Status = VerifyTampered
+		result.Detail = "content changed since signing"
+		return result
+	}
+	if sig.File != filepath.Base(path) || sig.Run != signedRun(path) {
+		result.Status = VerifyTampered
+		result.Detail = fmt.Sprintf("signature is for %s in %s", sig.File, sig.Run)
+		return result
+	}
+
+	switch sig.Method {
+	case SignHMAC:
+		if keys.HMACKey == "" {
+			return verifyError(result, "no HMAC key to verify with")
+		}
+		key, err := loadHMACKey(keys.HMACKey)
+		if err != nil {
+			return verifyError(result, err.Error())
+		}
+		if sig.KeyID != "" && sig.KeyID != hmacKeyID(key) {
+			return verifyError(result, fmt.Sprintf("signed with a different key (%s)", sig.KeyID))
+		}
+		if !hmac.Equal([]byte(computeMAC(key, &sig)), []byte(sig.MAC)) {
+			result.Status = VerifyTampered
+			result.Detail = "signature does not match"
+			return result
+		}
+	case SignMinisign:
+		if keys.MinisignPublicKey == "" {
+			return verifyError(result, "no minisign public key to verify with")
+		}
+		// -Q prints only the verified trusted comment
+		cmd := exec.Command("minisign", "-V", "-Q", "-p", keys.MinisignPublicKey, "-m", path, "-x", path+MinisignSuffix) //nolint:gosec // G204: fixed CLI, args are file paths
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			if _, ok := err.(*exec.ExitError); !ok {
+				return verifyError(result, "minisign: "+err.Error())
+			}
+			result.Status = VerifyTampered
+			result.Detail = "minisign: " + strings.TrimSpace(string(out))
+			return result
+		}
+		if strings.TrimSpace(string(out)) != signedContent(&sig) {
+			result.Status = VerifyTampered
+			result.Detail = "signature record does not match the minisign trusted comment"
+			return result
+		}
+	default:
+		return verifyError(result, fmt.Sprintf("unknown signing method %q", sig.Method))
+	}
+
+	result.Status = VerifyOK
+	return result
+}
+
+func verifyError(result VerifyResult, detail string) VerifyResult {
+	result.Status = VerifyError
+	result.Detail = detail
+	return result
+}
+
+// VerifyResults verifies path: a single file, or every SignedResultFiles
+// file under a run, batch or results directory.
+func VerifyResults(path string, keys VerifyKeys) ([]VerifyResult, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return []VerifyResult{VerifyFile(strings.TrimSuffix(path, SignatureSuffix), keys)}, nil
+	}
+
+	var results []VerifyResult
+	err = filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		for _, name := range SignedResultFiles {
+			if d.Name() == name {
+				results = append(results, VerifyFile(p, keys))
+			}
+		}
+		return nil
+	})
+	return results, err
+}
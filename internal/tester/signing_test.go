@@ -0,0 +1,174 @@
+package tester
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSignAndVerifyHMAC(t *testing.T) {
+	dir := t.TempDir()
+	keyPath := filepath.Join(dir, "keys", "signing.key")
+	runDir := filepath.Join(dir, "run-001")
+	if err := os.MkdirAll(runDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	obsPath := filepath.Join(runDir, "observations.json")
+	if err := os.WriteFile(obsPath, []byte(`{"observations": []}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// The key is generated on first use
+	signer, err := NewSigner(SignHMAC, keyPath)
+	if err != nil {
+		t.Fatalf("NewSigner: %v", err)
+	}
+	if info, err := os.Stat(keyPath); err != nil || info.Mode().Perm() != 0600 {
+		t.Fatalf("expected a private key file, got %v, %v", info, err)
+	}
+	signed, err := signer.SignResults(runDir)
+	if err != nil {
+		t.Fatalf("SignResults: %v", err)
+	}
+	if len(signed) != 1 || signed[0] != obsPath {
+		t.Fatalf("signed = %v, want [%s]", signed, obsPath)
+	}
+
+	keys := VerifyKeys{HMACKey: keyPath}
+	if r := VerifyFile(obsPath, keys); r.Status != VerifyOK {
+		t.Fatalf("VerifyFile = %+v, want ok", r)
+	}
+
+	// A different key can't vouch for the signature
+	otherKey := filepath.Join(dir, "other.key")
+	if err := GenerateHMACKey(otherKey); err != nil {
+		t.Fatal(err)
+	}
+	if r := VerifyFile(obsPath, VerifyKeys{HMACKey: otherKey}); r.Status != VerifyError {
+		t.Errorf("VerifyFile with another key = %+v, want error", r)
+	}
+
+	// Unsigned files are reported
+	if err := os.WriteFile(filepath.Join(runDir, "manifest.json"), []byte(`{}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	results, err := VerifyResults(dir, keys)
+	if err != nil {
+		t.Fatalf("VerifyResults: %v", err)
+	}
+	statuses := map[string]string{}
+	for _, r := range results {
+		statuses[filepath.Base(r.File)] = r.Status
+	}
+	if statuses["observations.json"] != VerifyOK || statuses["manifest.json"] != VerifyUnsigned {
+		t.Errorf("statuses = %v", statuses)
+	}
+
+	// Any edit is detected
+	if err := os.WriteFile(obsPath, []byte(`{"observations": [{"severity": "P3"}]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if r := VerifyFile(obsPath, keys); r.Status != VerifyTampered {
+		t.Errorf("VerifyFile after edit = %+v, want tampered", r)
+	}
+}
+
+func TestVerifyFile_ForgedDigest(t *testing.T) {
+	dir := t.TempDir()
+	keyPath := filepath.Join(dir, "signing.key")
+	path := filepath.Join(dir, "manifest.json")
+	if err := os.WriteFile(path, []byte(`{"passed": 1}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	signer, err := NewSigner(SignHMAC, keyPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := signer.SignFile(path); err != nil {
+		t.Fatal(err)
+	}
+
+	// Editing the file and re-signing without the key leaves a stale MAC
+	if err := os.WriteFile(path, []byte(`{"passed": 2}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	forger := &Signer{Method: SignHMAC, KeyPath: filepath.Join(dir, "forged.key")}
+	if err := GenerateHMACKey(forger.KeyPath); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := forger.SignFile(path); err != nil {
+		t.Fatal(err)
+	}
+	if r := VerifyFile(path, VerifyKeys{HMACKey: keyPath}); r.Status == VerifyOK {
+		t.Errorf("forged signature verified: %+v", r)
+	}
+}
+
+func TestVerifyFile_MovedSignature(t *testing.T) {
+	dir := t.TempDir()
+	keyPath := filepath.Join(dir, "signing.key")
+	signer, err := NewSigner(SignHMAC, keyPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys := VerifyKeys{HMACKey: keyPath}
+	content := []byte(`{"observations": []}`)
+
+	runA := filepath.Join(dir, "2026-03-30", "checkout", "run-001")
+	runB := filepath.Join(dir, "2026-03-31", "checkout", "run-001")
+	for _, d := range []string{runA, runB} {
+		if err := os.MkdirAll(d, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	obsA := filepath.Join(runA, "observations.json")
+	if err := os.WriteFile(obsA, content, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := signer.SignFile(obsA); err != nil {
+		t.Fatal(err)
+	}
+	sigData, err := os.ReadFile(obsA + SignatureSuffix)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The same bytes and signature copied into another run
+	obsB := filepath.Join(runB, "observations.json")
+	os.WriteFile(obsB, content, 0644)
+	os.WriteFile(obsB+SignatureSuffix, sigData, 0644)
+	if r := VerifyFile(obsB, keys); r.Status != VerifyTampered {
+		t.Errorf("signature moved to another run = %+v, want tampered", r)
+	}
+
+	// ... or onto another file in the same run
+	manifest := filepath.Join(runA, "manifest.json")
+	os.WriteFile(manifest, content, 0644)
+	os.WriteFile(manifest+SignatureSuffix, sigData, 0644)
+	if r := VerifyFile(manifest, keys); r.Status != VerifyTampered {
+		t.Errorf("signature moved to another file = %+v, want tampered", r)
+	}
+
+	// Backdating the signature breaks the MAC
+	var sig Signature
+	if err := json.Unmarshal(sigData, &sig); err != nil {
+		t.Fatal(err)
+	}
+	sig.SignedAt = sig.SignedAt.Add(-24 * time.Hour)
+	backdated, _ := json.Marshal(sig)
+	os.WriteFile(obsA+SignatureSuffix, backdated, 0644)
+	if r := VerifyFile(obsA, keys); r.Status != VerifyTampered {
+		t.Errorf("backdated signature = %+v, want tampered", r)
+	}
+}
+
+func TestNewSigner_Errors(t *testing.T) {
+	if _, err := NewSigner(SignHMAC, ""); err == nil {
+		t.Error("expected an error without a key")
+	}
+	if _, err := NewSigner("gpg", "key"); err == nil {
+		t.Error("expected an error for an unknown method")
+	}
+}
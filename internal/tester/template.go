@@ -33,6 +33,18 @@ type TesterTemplateData struct {
 
 	// SuccessCriteria lists the success criteria as a formatted string.
 	SuccessCriteria string
+
+	// A11yOutputDir enables the accessibility audit instructions, telling
+	// the agent to save axe-core scans under this run directory.
+	A11yOutputDir string
+
+	// AbortIf enables the abort condition instructions, telling the agent
+	// to write abort.json under AbortOutputDir if one matches.
+	AbortIf        *ScenarioAbortIf
+	AbortOutputDir string
+
+	// Network enables the network emulation instructions.
+	Network *ScenarioNetwork
 }
 
 // RenderTesterTemplate renders the tester CLAUDE.md template with the given data.
@@ -44,6 +56,25 @@ func RenderTesterTemplate(data *TesterTemplateData) (string, error) {
 // RenderTesterTemplateFromDir renders the template from a specific directory.
 // If templateDir is empty, it uses the inline fallback template.
 func RenderTesterTemplateFromDir(templateDir string, data *TesterTemplateData) (string, error) {
+	rendered, err := renderBaseTemplate(templateDir, data)
+	if err != nil {
+		return "", err
+	}
+	if !data.AbortIf.IsEmpty() && data.AbortOutputDir != "" {
+		rendered += AbortInstructions(data.AbortIf, data.AbortOutputDir)
+	}
+	if data.Network != nil {
+		rendered += NetworkInstructions(data.Network)
+	}
+	if data.A11yOutputDir != "" {
+		rendered += A11yInstructions(data.A11yOutputDir)
+	}
+	return rendered, nil
+}
+
+// renderBaseTemplate renders templates/tester-CLAUDE.md from templateDir,
+// falling back to the inline template.
+func renderBaseTemplate(templateDir string, data *TesterTemplateData) (string, error) {
 	if templateDir != "" {
 		templatePath := filepath.Join(templateDir, "templates", "tester-CLAUDE.md")
 		content, err := os.ReadFile(templatePath)
@@ -42,6 +42,10 @@ type PlaywrightConfig struct {
 	// Timeout is the default timeout for operations (milliseconds).
 	// Default: 30000 (30 seconds)
 	Timeout int `json:"timeout,omitempty"`
+
+	// Profile is the run's browser profile. Nil shares Playwright's
+	// default profile between runs.
+	Profile *BrowserProfile `json:"-"`
 }
 
 // Viewport defines browser window dimensions.
@@ -161,7 +165,7 @@ type IsolationConfig struct {
 // Observation represents a UX observation from the test.
 type Observation struct {
 	// Type is the observation category.
-	// Values: confusion, friction, error, success, suggestion
+	// Values: confusion, friction, error, success, suggestion, accessibility
 	Type string `json:"type"`
 
 	// Severity is the priority level: P0, P1, P2, P3.
@@ -182,6 +186,12 @@ type Observation struct {
 	// Screenshot is the filename of the associated screenshot.
 	Screenshot string `json:"screenshot,omitempty"`
 
+	// Rule is the axe-core rule ID for accessibility observations.
+	Rule string `json:"rule,omitempty"`
+
+	// WCAG lists WCAG success criteria for accessibility observations.
+	WCAG []string `json:"wcag,omitempty"`
+
 	// Validated indicates human validation status (null if pending).
 	Validated *bool `json:"validated,omitempty"`
 
@@ -200,9 +210,15 @@ type TestResult struct {
 	// Completed indicates if the test finished without errors.
 	Completed bool `json:"completed"`
 
-	// Status is the overall result: passed, failed, error, blocked.
+	// Status is the overall result: passed, failed, error, blocked, aborted.
 	Status string `json:"status"`
 
+	// AbortReason explains which abort_if condition stopped an aborted run.
+	AbortReason string `json:"abort_reason,omitempty"`
+
+	// Network describes the emulated network profile, if the scenario set one.
+	Network string `json:"network,omitempty"`
+
 	// DurationSeconds is the test duration.
 	DurationSeconds int `json:"duration_seconds"`
 
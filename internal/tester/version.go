@@ -0,0 +1,345 @@
+package tester
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// CurrentScenarioVersion is the scenario schema version this build
+// understands and writes.
+//
+// Version history:
+//
+//	0  Unversioned draft format: user_story, target, steps, integer
+//	   timeout (seconds), recording.screenshots as a map.
+//	1  Flat persona/goal, environment.url, duration timeout.
+const CurrentScenarioVersion = 1
+
+// scenarioMigration upgrades a scenario document from one version to the next.
+type scenarioMigration struct {
+	from  int
+	apply func(doc *yaml.Node) []string // returns descriptions of changes
+}
+
+// scenarioMigrations are applied in order until the document is current.
+var scenarioMigrations = []scenarioMigration{
+	{from: 0, apply: migrateScenarioV0},
+}
+
+// ScenarioVersion returns the version declared by scenario YAML, or 0 if
+// none is declared.
+func ScenarioVersion(data []byte) (int, error) {
+	doc, err := scenarioDocument(data)
+	if err != nil {
+		return 0, err
+	}
+	return documentVersion(doc)
+}
+
+// DeprecatedScenarioFields lists fields in scenario YAML that
+// 'gt tester migrate-scenarios' would rewrite.
+func DeprecatedScenarioFields(data []byte) ([]string, error) {
+	doc, err := scenarioDocument(data)
+	if err != nil {
+		return nil, err
+	}
+	return deprecatedFields(doc), nil
+}
+
+// MigrateScenario upgrades scenario YAML to CurrentScenarioVersion,
+// rewriting deprecated fields. It returns the new YAML and a description of
+// each change; no changes means the scenario is already current, including
+// unversioned scenarios that use no deprecated fields, which are returned
+// untouched. Scenarios from a newer version than this build supports are an
+// error.
+func MigrateScenario(data []byte) ([]byte, []string, error) {
+	doc, err := scenarioDocument(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	version, err := documentVersion(doc)
+	if err != nil {
+		return nil, nil, err
+	}
+	if version > CurrentScenarioVersion {
+		return nil, nil, fmt.Errorf("scenario version %d is newer than supported version %d (upgrade gt)", version, CurrentScenarioVersion)
+	}
+	if version == CurrentScenarioVersion {
+		return data, nil, nil
+	}
+
+	var changes []string
+	for _, m := range scenarioMigrations {
+		if m.from < version {
+			continue
+		}
+		changes = append(changes, m.apply(doc)...)
+	}
+	if len(changes) == 0 {
+		// Unversioned but already in the current format: nothing to rewrite
+		return data, nil, nil
+	}
+	changes = append(changes, fmt.Sprintf("version: %d -> %d", version, CurrentScenarioVersion))
+	setVersion(doc, CurrentScenarioVersion)
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{doc}}); err != nil {
+		return nil, nil, fmt.Errorf("encoding scenario: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, nil, fmt.Errorf("encoding scenario: %w", err)
+	}
+	return buf.Bytes(), changes, nil
+}
+
+// MigrateScenarioFile upgrades a scenario file the way MigrateScenario
+// does. Files that include fragments are never rewritten: rewriting would
+// need the fragments' anchors and would inline what they share, so they
+// are only checked, with their includes merged as parseScenario does, and
+// an error names the deprecated fields to upgrade by hand.
+func MigrateScenarioFile(path string) ([]byte, []string, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // G304: path is a user-specified scenario file
+	if err != nil {
+		return nil, nil, err
+	}
+	includes, err := includeList(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(includes) == 0 {
+		return MigrateScenario(data)
+	}
+
+	files, err := resolveIncludes(data, path, filepath.Dir(path))
+	if err != nil {
+		return nil, nil, err
+	}
+	doc, err := includeDocument(files)
+	if err != nil {
+		return nil, nil, err
+	}
+	version, err := documentVersion(doc)
+	if err != nil {
+		return nil, nil, err
+	}
+	if version > CurrentScenarioVersion {
+		return nil, nil, fmt.Errorf("scenario version %d is newer than supported version %d (upgrade gt)", version, CurrentScenarioVersion)
+	}
+	if version == 0 {
+		if fields := deprecatedFields(doc); len(fields) > 0 {
+			return nil, nil, fmt.Errorf("uses include, so it can't be rewritten; upgrade %s by hand in it or its fragments",
+				strings.Join(fields, ", "))
+		}
+	}
+	return data, nil, nil
+}
+
+// checkScenarioVersion rejects unversioned scenarios that still use
+// deprecated fields and returns a warning for versions newer than this
+// build supports.
+func checkScenarioVersion(doc *yaml.Node) (string, error) {
+	version, err := documentVersion(doc)
+	if err != nil {
+		return "", err
+	}
+	switch {
+	case version < 0:
+		return "", fmt.Errorf("version cannot be negative")
+	case version == 0:
+		if fields := deprecatedFields(doc); len(fields) > 0 {
+			return "", fmt.Errorf("scenario uses deprecated fields (%s); run 'gt tester migrate-scenarios' to upgrade it",
+				strings.Join(fields, ", "))
+		}
+	case version > CurrentScenarioVersion:
+		return fmt.Sprintf("scenario version %d is newer than supported version %d; unknown fields are ignored",
+			version, CurrentScenarioVersion), nil
+	}
+	return "", nil
+}
+
+// scenarioDocument parses YAML and returns its top-level mapping.
+func scenarioDocument(data []byte) (*yaml.Node, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("parsing YAML: %w", err)
+	}
+	if root.Kind != yaml.DocumentNode || len(root.Content) == 0 {
+		return &yaml.Node{Kind: yaml.MappingNode}, nil
+	}
+	doc := root.Content[0]
+	if doc.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("parsing YAML: scenario must be a mapping")
+	}
+	return doc, nil
+}
+
+func documentVersion(doc *yaml.Node) (int, error) {
+	v := mappingValue(doc, "version")
+	if v == nil || v.Value == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(v.Value)
+	if err != nil {
+		return 0, fmt.Errorf("version must be an integer, got %q", v.Value)
+	}
+	return n, nil
+}
+
+func deprecatedFields(doc *yaml.Node) []string {
+	var fields []string
+	for _, key := range []string{"user_story", "target", "steps"} {
+		if mappingValue(doc, key) != nil {
+			fields = append(fields, key)
+		}
+	}
+	if t := mappingValue(doc, "timeout"); t != nil && t.Tag == "!!int" {
+		fields = append(fields, "timeout (seconds)")
+	}
+	if rec := mappingValue(doc, "recording"); rec != nil {
+		if s := mappingValue(rec, "screenshots"); s != nil && s.Kind == yaml.MappingNode {
+			fields = append(fields, "recording.screenshots (map)")
+		}
+	}
+	return fields
+}
+
+// migrateScenarioV0 upgrades the unversioned draft format.
+func migrateScenarioV0(doc *yaml.Node) []string {
+	var changes []string
+
+	if story := mappingValue(doc, "user_story"); story != nil {
+		var fields []*yaml.Node
+		if p := mappingValue(story, "persona"); p != nil && mappingValue(doc, "persona") == nil {
+			fields = append(fields, scalarNode("persona"), scalarNode(p.Value))
+		}
+		if g := mappingValue(story, "goal"); g != nil && mappingValue(doc, "goal") == nil {
+			goal := g.Value
+			if c := mappingValue(story, "context"); c != nil && c.Value != "" {
+				goal = strings.TrimRight(goal, "\n") + "\n\n" + c.Value
+			}
+			fields = append(fields, scalarNode("goal"), scalarNode(goal))
+		}
+		replaceMappingKey(doc, "user_story", fields...)
+		changes = append(changes, "user_story -> persona, goal")
+	}
+
+	if target := mappingValue(doc, "target"); target != nil {
+		var fields []*yaml.Node
+		if u := mappingValue(target, "url"); u != nil {
+			if env := mappingValue(doc, "environment"); env != nil {
+				if mappingValue(env, "url") == nil {
+					setMappingValue(env, "url", scalarNode(u.Value))
+				}
+			} else {
+				env = &yaml.Node{Kind: yaml.MappingNode, Content: []*yaml.Node{scalarNode("url"), scalarNode(u.Value)}}
+				fields = append(fields, scalarNode("environment"), env)
+			}
+			changes = append(changes, "target.url -> environment.url")
+		} else {
+			changes = append(changes, "target removed (set environment.url)")
+		}
+		replaceMappingKey(doc, "target", fields...)
+	}
+
+	if steps := mappingValue(doc, "steps"); steps != nil {
+		if steps.Kind == yaml.SequenceNode && len(steps.Content) > 0 {
+			var b strings.Builder
+			if g := mappingValue(doc, "goal"); g != nil {
+				b.WriteString(strings.TrimRight(g.Value, "\n"))
+				b.WriteString("\n\n")
+			}
+			b.WriteString("Suggested steps:\n")
+			for _, s := range steps.Content {
+				b.WriteString("- " + s.Value + "\n")
+			}
+			setMappingValue(doc, "goal", scalarNode(b.String()))
+		}
+		replaceMappingKey(doc, "steps")
+		changes = append(changes, "steps -> goal")
+	}
+
+	if t := mappingValue(doc, "timeout"); t != nil && t.Tag == "!!int" {
+		old := t.Value
+		*t = *scalarNode(old + "s")
+		changes = append(changes, fmt.Sprintf("timeout: %s -> %ss", old, old))
+	}
+
+	if rec := mappingValue(doc, "recording"); rec != nil {
+		if s := mappingValue(rec, "screenshots"); s != nil && s.Kind == yaml.MappingNode {
+			*s = yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: "true"}
+			changes = append(changes, "recording.screenshots map -> true")
+		}
+	}
+
+	return changes
+}
+
+func setVersion(doc *yaml.Node, version int) {
+	v := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: strconv.Itoa(version)}
+	if mappingValue(doc, "version") != nil {
+		setMappingValue(doc, "version", v)
+		return
+	}
+	// Keep version next to the scenario name, where the examples put it
+	at := 0
+	for i := 0; i+1 < len(doc.Content); i += 2 {
+		if doc.Content[i].Value == "scenario" {
+			at = i + 2
+			break
+		}
+	}
+	key := scalarNode("version")
+	doc.Content = append(doc.Content[:at], append([]*yaml.Node{key, v}, doc.Content[at:]...)...)
+}
+
+func scalarNode(value string) *yaml.Node {
+	n := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+	if strings.Contains(value, "\n") {
+		n.Style = yaml.LiteralStyle
+	}
+	return n
+}
+
+// mappingValue returns the value for key in a mapping node, or nil.
+func mappingValue(m *yaml.Node, key string) *yaml.Node {
+	if m == nil || m.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			return m.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// setMappingValue sets key in a mapping node, appending it if absent.
+func setMappingValue(m *yaml.Node, key string, value *yaml.Node) {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			m.Content[i+1] = value
+			return
+		}
+	}
+	m.Content = append(m.Content, scalarNode(key), value)
+}
+
+// replaceMappingKey removes key from a mapping node, putting the given
+// key/value nodes in its place.
+func replaceMappingKey(m *yaml.Node, key string, with ...*yaml.Node) {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			rest := append(append([]*yaml.Node{}, with...), m.Content[i+2:]...)
+			m.Content = append(m.Content[:i], rest...)
+			return
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package tester
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const legacyScenario = `# Draft-format scenario
+scenario: sarah_registers
+description: "First-time parent registration"
+user_story:
+  name: new_parent_registration
+  persona: sarah
+  goal: Register for ScreenCoach
+  context: Found ScreenCoach through school newsletter.
+target:
+  app: parent-portal
+  url: https://staging.example.com
+steps:
+  - Find and click registration
+  - Complete signup form
+success_criteria:
+  - Account created successfully
+recording:
+  video: true
+  screenshots:
+    on_failure: true
+timeout: 600
+`
+
+func TestParseScenario_RejectsDeprecatedFields(t *testing.T) {
+	_, err := ParseScenario([]byte(legacyScenario))
+	if err == nil {
+		t.Fatal("expected error for unversioned scenario with deprecated fields")
+	}
+	for _, want := range []string{"user_story", "target", "migrate-scenarios"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q should mention %q", err, want)
+		}
+	}
+}
+
+func TestParseScenario_Versions(t *testing.T) {
+	base := `
+scenario: login
+persona: sarah
+goal: Log in
+success_criteria: [Dashboard visible]
+environment:
+  url: https://staging.example.com
+`
+	s, err := ParseScenario([]byte(base))
+	if err != nil {
+		t.Fatalf("ParseScenario failed: %v", err)
+	}
+	if s.Version != CurrentScenarioVersion || len(s.Warnings) != 0 {
+		t.Errorf("unversioned scenario: Version = %d, Warnings = %v", s.Version, s.Warnings)
+	}
+
+	s, err = ParseScenario([]byte("version: 99\n" + base))
+	if err != nil {
+		t.Fatalf("ParseScenario with future version failed: %v", err)
+	}
+	if len(s.Warnings) != 1 || !strings.Contains(s.Warnings[0], "newer than supported") {
+		t.Errorf("Warnings = %v, want future version warning", s.Warnings)
+	}
+
+	if _, err := ParseScenario([]byte("version: -1\n" + base)); err == nil {
+		t.Error("expected error for negative version")
+	}
+}
+
+func TestMigrateScenario(t *testing.T) {
+	out, changes, err := MigrateScenario([]byte(legacyScenario))
+	if err != nil {
+		t.Fatalf("MigrateScenario failed: %v", err)
+	}
+	if len(changes) != 6 {
+		t.Errorf("changes = %v, want 6", changes)
+	}
+
+	s, err := ParseScenario(out)
+	if err != nil {
+		t.Fatalf("migrated scenario doesn't parse: %v\n%s", err, out)
+	}
+	if s.Version != CurrentScenarioVersion || s.Persona != "sarah" || s.Environment.URL != "https://staging.example.com" {
+		t.Errorf("unexpected migrated scenario: %+v", s)
+	}
+	for _, want := range []string{"Register for ScreenCoach", "school newsletter", "- Complete signup form"} {
+		if !strings.Contains(s.Goal, want) {
+			t.Errorf("Goal %q missing %q", s.Goal, want)
+		}
+	}
+	if s.Timeout.Duration().Seconds() != 600 || !*s.Recording.Screenshots {
+		t.Errorf("Timeout = %v, Screenshots = %v", s.Timeout.Duration(), *s.Recording.Screenshots)
+	}
+	if !strings.HasPrefix(string(out), "# Draft-format scenario\nscenario: sarah_registers\nversion: 1\n") {
+		t.Errorf("version should follow scenario, comments kept:\n%s", out)
+	}
+
+	// Current scenarios are left alone; future ones are refused
+	if _, changes, err := MigrateScenario(out); err != nil || changes != nil {
+		t.Errorf("re-migrating: changes = %v, err = %v", changes, err)
+	}
+	plain := []byte("scenario: x\npersona: sarah\ngoal: Sign up\n")
+	if same, changes, err := MigrateScenario(plain); err != nil || changes != nil || !bytes.Equal(same, plain) {
+		t.Errorf("unversioned current-format scenario rewritten: changes = %v, err = %v\n%s", changes, err, same)
+	}
+	if _, _, err := MigrateScenario([]byte("scenario: x\nversion: 99\n")); err == nil {
+		t.Error("expected error migrating a future version")
+	}
+}
+
+func TestMigrateScenarioFile_Include(t *testing.T) {
+	dir := t.TempDir()
+	writeScenarioFiles(t, dir, map[string]string{
+		"blocks.fragment.yaml": "personas:\n  sarah: &sarah sarah\n",
+		"current.yaml": `include: blocks.fragment.yaml
+scenario: current
+version: 1
+persona: *sarah
+goal: Load the dashboard
+success_criteria: [Dashboard renders]
+environment:
+  url: https://example.com
+`,
+		"legacy.yaml": `include: blocks.fragment.yaml
+scenario: legacy
+user_story:
+  persona: *sarah
+  goal: Load the dashboard
+timeout: 600
+`,
+	})
+
+	// Anchors from the fragment resolve; a current file is left alone
+	path := filepath.Join(dir, "current.yaml")
+	data, _ := os.ReadFile(path)
+	out, changes, err := MigrateScenarioFile(path)
+	if err != nil || changes != nil || string(out) != string(data) {
+		t.Errorf("current scenario: changes = %v, err = %v", changes, err)
+	}
+
+	// A legacy file that includes fragments is reported, not rewritten
+	_, _, err = MigrateScenarioFile(filepath.Join(dir, "legacy.yaml"))
+	if err == nil || !strings.Contains(err.Error(), "user_story") || !strings.Contains(err.Error(), "by hand") {
+		t.Errorf("legacy scenario with include: err = %v", err)
+	}
+}
@@ -2,7 +2,10 @@ package inbox
 
 import (
 	"fmt"
+	"os"
 	"os/exec"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/steveyegge/gastown/internal/beads"
@@ -96,18 +99,13 @@ func archiveMessage(msgID, address, workDir string) error {
 	return nil
 }
 
-// sendReply sends a reply to a message.
-func sendReply(original *Message, body, address, workDir string) error {
+// sendReply sends a reply to a message. With all set, everyone else the
+// original went to is CC'd.
+func sendReply(original *Message, body, address, workDir string, all bool) error {
 	router := mail.NewRouter(workDir)
 
 	// Convert inbox.Message to mail.Message for reply
-	mailOriginal := &mail.Message{
-		ID:       original.ID,
-		From:     original.From,
-		Subject:  original.Subject,
-		Body:     original.Body,
-		ThreadID: original.ThreadID,
-	}
+	mailOriginal := original.toMail()
 
 	// Create reply
 	reply := mail.NewReplyMessage(
@@ -118,6 +116,10 @@ func sendReply(original *Message, body, address, workDir string) error {
 		mailOriginal,
 	)
 
+	if all {
+		_, reply.CC = mailOriginal.ReplyAllRecipients(address)
+	}
+
 	if err := router.Send(reply); err != nil {
 		return fmt.Errorf("sending reply: %w", err)
 	}
@@ -125,6 +127,41 @@ func sendReply(original *Message, body, address, workDir string) error {
 	return nil
 }
 
+// sendMessage sends a new message from address. An empty subject is taken
+// from the body's first line.
+func sendMessage(to, subject, body, address, workDir string) error {
+	if subject == "" {
+		subject = truncateString(strings.TrimSpace(strings.SplitN(strings.TrimSpace(body), "\n", 2)[0]), 60)
+	}
+	router := mail.NewRouter(workDir)
+	if err := router.Send(mail.NewMessage(address, to, subject, body)); err != nil {
+		return fmt.Errorf("sending message: %w", err)
+	}
+	return nil
+}
+
+// toMail converts the fields of an inbox.Message needed for replies back to
+// a mail.Message.
+func (m *Message) toMail() *mail.Message {
+	return &mail.Message{
+		ID:         m.ID,
+		From:       m.From,
+		To:         m.To,
+		Recipients: m.Recipients,
+		CC:         m.CC,
+		Subject:    m.Subject,
+		Body:       m.Body,
+		Timestamp:  m.Timestamp,
+		ThreadID:   m.ThreadID,
+	}
+}
+
+// replyAllCC returns who a reply-all from address would CC.
+func replyAllCC(original *Message, address string) []string {
+	_, cc := original.toMail().ReplyAllRecipients(address)
+	return cc
+}
+
 // loadThreadMessages loads all messages in a thread.
 func loadThreadMessages(threadID, address, workDir string) ([]*mail.Message, error) {
 	router := mail.NewRouter(workDir)
@@ -149,6 +186,9 @@ func convertToInboxMessage(mm *mail.Message) Message {
 		Subject:    mm.Subject,
 		Body:       mm.Body,
 		From:       mm.From,
+		To:         mm.To,
+		Recipients: mm.Recipients,
+		CC:         mm.CC,
 		Timestamp:  mm.Timestamp,
 		Read:       mm.Read,
 		ThreadID:   mm.ThreadID,
@@ -287,3 +327,98 @@ func archiveOld(address, workDir string) error {
 
 	return nil
 }
+
+// createBeadFromMessage creates a task bead pre-filled from a message and
+// returns its ID. Beads the message references are linked as related (not
+// blocking) and listed in the description; linking is best-effort since a stale reference shouldn't lose the bead.
+func createBeadFromMessage(msg *Message, address, workDir string) (string, error) {
+	b := beads.New(workDir)
+	issue, err := b.Create(beads.CreateOptions{
+		Title:       beadTitleFromMessage(msg),
+		Type:        "task",
+		Priority:    2,
+		Description: beadDescriptionFromMessage(msg),
+		Actor:       address,
+	})
+	if err != nil {
+		return "", fmt.Errorf("creating bead: %w", err)
+	}
+
+	for _, ref := range msg.References {
+		_ = b.AddRelated(issue.ID, ref)
+	}
+
+	return issue.ID, nil
+}
+
+// beadTitleFromMessage derives a bead title from a message subject,
+// dropping reply/forward prefixes.
+func beadTitleFromMessage(msg *Message) string {
+	title := strings.TrimSpace(msg.Subject)
+	for {
+		lower := strings.ToLower(title)
+		switch {
+		case strings.HasPrefix(lower, "re:"), strings.HasPrefix(lower, "fw:"):
+			title = strings.TrimSpace(title[3:])
+		case strings.HasPrefix(lower, "fwd:"):
+			title = strings.TrimSpace(title[4:])
+		default:
+			if title == "" {
+				return "Follow up on message from " + msg.From
+			}
+			return title
+		}
+	}
+}
+
+// beadDescriptionFromMessage builds a bead description that quotes the
+// message body and lists the beads it references.
+func beadDescriptionFromMessage(msg *Message) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "From %s", msg.From)
+	if !msg.Timestamp.IsZero() {
+		fmt.Fprintf(&sb, " on %s", msg.Timestamp.Format("2006-01-02 15:04"))
+	}
+	fmt.Fprintf(&sb, " (mail %s):\n\n", msg.ID)
+
+	for _, line := range strings.Split(strings.TrimRight(msg.Body, "\n"), "\n") {
+		if line == "" {
+			sb.WriteString(">\n")
+		} else {
+			sb.WriteString("> " + line + "\n")
+		}
+	}
+
+	if len(msg.References) > 0 {
+		sb.WriteString("\nReferences:\n")
+		for _, ref := range msg.References {
+			sb.WriteString("- " + ref + "\n")
+		}
+	}
+
+	return sb.String()
+}
+
+// exportMarkdown renders msgs as markdown (see mail.RenderMarkdown) to a
+// file in dir, named after the thread or the single message, and returns
+// its path.
+func exportMarkdown(msgs []Message, dir string) (string, error) {
+	mailMsgs := make([]*mail.Message, len(msgs))
+	for i := range msgs {
+		mailMsgs[i] = msgs[i].toMail()
+	}
+	md, err := mail.RenderMarkdown(mailMsgs, time.Now())
+	if err != nil {
+		return "", err
+	}
+
+	name := msgs[0].ID
+	if len(msgs) > 1 && msgs[0].ThreadID != "" {
+		name = msgs[0].ThreadID
+	}
+	path := filepath.Join(dir, "mail-"+strings.ReplaceAll(name, "/", "-")+".md")
+	if err := os.WriteFile(path, []byte(md), 0644); err != nil {
+		return "", fmt.Errorf("writing %s: %w", path, err)
+	}
+	return path, nil
+}
@@ -0,0 +1,79 @@
+package inbox
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBeadTitleFromMessage(t *testing.T) {
+	tests := []struct {
+		subject string
+		want    string
+	}{
+		{"Login broken on staging", "Login broken on staging"},
+		{"Re: Login broken", "Login broken"},
+		{"RE: Fwd: re: Login broken", "Login broken"},
+		{"  ", "Follow up on message from gastown/witness"},
+	}
+	for _, tt := range tests {
+		msg := &Message{Subject: tt.subject, From: "gastown/witness"}
+		if got := beadTitleFromMessage(msg); got != tt.want {
+			t.Errorf("beadTitleFromMessage(%q) = %q, want %q", tt.subject, got, tt.want)
+		}
+	}
+}
+
+func TestBeadDescriptionFromMessage(t *testing.T) {
+	msg := &Message{
+		ID:         "msg-123",
+		From:       "mayor/",
+		Timestamp:  time.Date(2026, 1, 7, 9, 30, 0, 0, time.UTC),
+		Body:       "Checkout fails.\n\nSee gt-abc for context.\n",
+		References: []string{"gt-abc"},
+	}
+
+	got := beadDescriptionFromMessage(msg)
+	for _, want := range []string{
+		"From mayor/ on 2026-01-07 09:30 (mail msg-123):\n\n",
+		"> Checkout fails.\n>\n> See gt-abc for context.\n",
+		"References:\n- gt-abc\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("description missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestExportMarkdown(t *testing.T) {
+	dir := t.TempDir()
+	thread := []Message{
+		{ID: "hq-1", From: "mayor/", To: "gastown/witness", Subject: "Refinery stuck", Body: "Check the queue.", ThreadID: "thread-abc", Timestamp: time.Now().Add(-time.Hour)},
+		{ID: "hq-2", From: "gastown/witness", To: "mayor/", Subject: "Re: Refinery stuck", Body: "Restarted it.", ThreadID: "thread-abc", Timestamp: time.Now()},
+	}
+
+	path, err := exportMarkdown(thread, dir)
+	if err != nil {
+		t.Fatalf("exportMarkdown: %v", err)
+	}
+	if filepath.Base(path) != "mail-thread-abc.md" {
+		t.Errorf("thread exported to %s, want mail-thread-abc.md", path)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(data), "---\nsubject: Refinery stuck\n") || !strings.Contains(string(data), "Restarted it.") {
+		t.Errorf("unexpected export:\n%s", data)
+	}
+
+	path, err = exportMarkdown(thread[1:], dir)
+	if err != nil {
+		t.Fatalf("exportMarkdown: %v", err)
+	}
+	if filepath.Base(path) != "mail-hq-2.md" {
+		t.Errorf("message exported to %s, want mail-hq-2.md", path)
+	}
+}
@@ -26,6 +26,7 @@ func loadMessages(address, workDir string, ls *LearningSystem) ([]Message, []str
 	messages := make([]Message, 0, len(mailMessages))
 	for _, mm := range mailMessages {
 		msg := convertMailMessage(mm, ls)
+		msg.Auth = router.Authenticate(mm)
 		messages = append(messages, msg)
 	}
 
@@ -47,6 +48,9 @@ func convertMailMessage(mm *mail.Message, ls *LearningSystem) Message {
 		Subject:    mm.Subject,
 		Body:       mm.Body,
 		From:       mm.From,
+		To:         mm.To,
+		Recipients: mm.Recipients,
+		CC:         mm.CC,
 		Timestamp:  mm.Timestamp,
 		Read:       mm.Read,
 		ThreadID:   mm.ThreadID,
@@ -16,6 +16,7 @@ type KeyMap struct {
 	Approve     key.Binding
 	Reject      key.Binding
 	Reply       key.Binding
+	ReplyAll    key.Binding
 	Reload      key.Binding
 	Archive     key.Binding
 	ArchiveInfo key.Binding // Phase 5: Archive all INFO messages
@@ -24,11 +25,17 @@ type KeyMap struct {
 	Expand      key.Binding // Phase 3: Expand bead references
 	Hook        key.Binding // Phase 3: Hook/claim bead
 	Learn       key.Binding // Phase 6: Learn message type
+	Summarize   key.Binding // Summarize message or thread via agent
+	CreateBead  key.Binding // Create a bead pre-filled from the message
+	Export      key.Binding // Export the message or thread as markdown
+	Open        key.Binding // Expand or collapse a thread message
+	ViewDiff    key.Binding // Show a proposal and its patch full screen
 
 	// General
 	NextPage key.Binding // Phase 5: Next page of messages
 	PrevPage key.Binding // Phase 5: Previous page of messages
 	Tab      key.Binding
+	Palette  key.Binding // Command palette: every action by name
 	Help     key.Binding
 	Quit     key.Binding
 }
@@ -72,6 +79,10 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("R"),
 			key.WithHelp("R", "reply"),
 		),
+		ReplyAll: key.NewBinding(
+			key.WithKeys("W"),
+			key.WithHelp("W", "reply all"),
+		),
 		Reload: key.NewBinding(
 			key.WithKeys("r"),
 			key.WithHelp("r", "reload"),
@@ -112,10 +123,34 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("L"),
 			key.WithHelp("L", "learn type"),
 		),
+		Summarize: key.NewBinding(
+			key.WithKeys("S"),
+			key.WithHelp("S", "summarize"),
+		),
+		CreateBead: key.NewBinding(
+			key.WithKeys("B"),
+			key.WithHelp("B", "create bead"),
+		),
+		Export: key.NewBinding(
+			key.WithKeys("E"),
+			key.WithHelp("E", "export markdown"),
+		),
+		Open: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "expand message"),
+		),
+		ViewDiff: key.NewBinding(
+			key.WithKeys("v"),
+			key.WithHelp("v", "view proposal [P]"),
+		),
 		Tab: key.NewBinding(
 			key.WithKeys("tab"),
 			key.WithHelp("tab", "switch pane"),
 		),
+		Palette: key.NewBinding(
+			key.WithKeys(":"),
+			key.WithHelp(":", "commands"),
+		),
 		Help: key.NewBinding(
 			key.WithKeys("?"),
 			key.WithHelp("?", "help"),
@@ -129,17 +164,17 @@ func DefaultKeyMap() KeyMap {
 
 // ShortHelp returns keybindings to show in the mini help view.
 func (k KeyMap) ShortHelp() []key.Binding {
-	return []key.Binding{k.Up, k.Down, k.Archive, k.Quit, k.Help}
+	return []key.Binding{k.Up, k.Down, k.Archive, k.Palette, k.Quit, k.Help}
 }
 
 // FullHelp returns keybindings for the expanded help view.
 func (k KeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
 		{k.Up, k.Down, k.PageUp, k.PageDown},
-		{k.Top, k.Bottom, k.NextPage, k.PrevPage, k.Tab},
-		{k.Approve, k.Reject, k.Reply, k.Reload, k.Archive},
+		{k.Top, k.Bottom, k.NextPage, k.PrevPage, k.Tab, k.Open},
+		{k.Approve, k.Reject, k.ViewDiff, k.Reply, k.ReplyAll, k.Reload, k.Archive},
 		{k.ArchiveInfo, k.MarkAllRead, k.ArchiveOld},
-		{k.Expand, k.Hook, k.Learn},
-		{k.Help, k.Quit},
+		{k.Expand, k.Hook, k.Learn, k.Summarize, k.CreateBead, k.Export},
+		{k.Palette, k.Help, k.Quit},
 	}
 }
\ No newline at end of file
@@ -6,6 +6,8 @@ import (
 	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
@@ -23,6 +25,10 @@ const (
 	ModeExpand
 	// ModeLearn shows the type selection for learning.
 	ModeLearn
+	// ModePalette shows the command palette.
+	ModePalette
+	// ModeDiff shows a proposal's patch full screen.
+	ModeDiff
 )
 
 // ExpandedBead holds information about an expanded bead reference.
@@ -69,9 +75,21 @@ type Model struct {
 	mode       ViewMode
 	replyInput textarea.Model
 	replyingTo *Message // Message being replied to
+	replyAll   bool     // Reply to all recipients, not just the sender
+
+	// New message (palette compose); reply mode without replyingTo
+	composeTo      string
+	composeSubject string
+
+	// Command palette (:)
+	paletteInput  textinput.Model
+	paletteCursor int
 
 	// Phase 2: Thread view
 	threadMessages []Message // Messages in current thread
+	threadCursor   int       // Selected message in the thread
+	threadExpanded map[string]bool
+	threadViewport viewport.Model
 
 	// Phase 2: Status message (for confirmations)
 	statusMsg string
@@ -84,12 +102,36 @@ type Model struct {
 	lastFetch time.Time
 	newCount  int // New messages since last view
 
+	// Per-type notification levels and quiet hours
+	notifyPrefs NotifyPrefs
+
+	// Auto-refresh (0 disables; r still reloads)
+	refreshInterval time.Duration
+	lastRefresh     time.Time // Last successful fetch, shown in the header
+
+	// Time display (relative/absolute, zone, clock, locale date layout)
+	timeFormat TimeFormat
+
+	// Color theme (dark/light/high-contrast, or attributes only)
+	theme Theme
+
 	// Phase 5: Pagination
 	page int
 
 	// Phase 6: Learning System
 	learning    *LearningSystem
 	learnCursor int
+
+	// Agent summaries, keyed by message ID or threadSummaryKey
+	summarizer  *Summarizer
+	summaries   map[string][]string
+	summarizing string // key of the summary being generated
+
+	// Proposal previews: statuses of beads referenced by proposals, and
+	// the proposal shown in the diff view
+	beadStatus   map[string]ExpandedBead
+	diffMessage  *Message
+	diffViewport viewport.Model
 }
 
 // New creates a new inbox TUI model.
@@ -100,7 +142,11 @@ func New(address, workDir string) Model {
 	ti.SetWidth(60)
 	ti.SetHeight(5)
 
-	return Model{
+	pi := textinput.New()
+	pi.Prompt = ":"
+	pi.Placeholder = "command"
+
+	m := Model{
 		address:    address,
 		workDir:    workDir,
 		keys:       DefaultKeyMap(),
@@ -110,7 +156,20 @@ func New(address, workDir string) Model {
 		mode:       ModeList,
 		replyInput: ti,
 		learning:   NewLearningSystem(workDir),
+		summarizer: NewSummarizer(workDir),
+		summaries:  make(map[string][]string),
+		beadStatus: make(map[string]ExpandedBead),
+
+		paletteInput:   pi,
+		threadViewport: viewport.New(0, 0),
+		diffViewport:   viewport.New(0, 0),
+
+		refreshInterval: loadRefreshInterval(workDir),
+		timeFormat:      loadTimeFormat(workDir),
+		notifyPrefs:     loadNotifyPrefs(workDir),
 	}
+	m.SetTheme(loadTheme(workDir))
+	return m
 }
 
 // Init initializes the model and starts fetching messages.
@@ -121,9 +180,13 @@ func (m Model) Init() tea.Cmd {
 // tickMsg is sent periodically to trigger a refresh.
 type tickMsg time.Time
 
-// tick creates a command that sends a tickMsg every 30 seconds.
+// tick creates a command that sends a tickMsg after the refresh interval,
+// or nil when auto-refresh is disabled.
 func (m Model) tick() tea.Cmd {
-	return tea.Tick(30*time.Second, func(t time.Time) tea.Msg {
+	if m.refreshInterval <= 0 {
+		return nil
+	}
+	return tea.Tick(m.refreshInterval, func(t time.Time) tea.Msg {
 		return tickMsg(t)
 	})
 }
@@ -160,6 +223,25 @@ type beadsLoadedMsg struct {
 	err   error
 }
 
+// summaryLoadedMsg is the result of summarizing a message or thread.
+type summaryLoadedMsg struct {
+	key     string
+	bullets []string
+	err     error
+}
+
+// beadCreatedMsg is the result of creating a bead from a message.
+type beadCreatedMsg struct {
+	id  string
+	err error
+}
+
+// exportedMsg is the result of exporting a message or thread to markdown.
+type exportedMsg struct {
+	path string
+	err  error
+}
+
 // Update handles messages and updates the model state.
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
@@ -169,6 +251,12 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.help.Width = msg.Width
 		// Update textarea width for reply mode
 		m.replyInput.SetWidth(m.width - 4)
+		if m.mode == ModeThread {
+			m.syncThreadViewport()
+		}
+		if m.mode == ModeDiff {
+			m.syncDiffViewport()
+		}
 		return m, nil
 
 	case fetchMessagesMsg:
@@ -191,24 +279,36 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 
 			newCount := 0
+			now := m.timeFormat.in(time.Now())
 			for _, msg := range msg.messages {
-				if !knownIDs[msg.ID] {
+				if knownIDs[msg.ID] {
+					continue
+				}
+				level := m.notifyPrefs.Level(msg.Type, now)
+				if level >= NotifyBadge {
 					newCount++
-					// Check for ALERT
-					if msg.Type == TypeAlert {
-						notifyCmds = append(notifyCmds, notifyAlert(msg.Subject))
-					}
+				}
+				if cmd := notifyMessage(msg, level); cmd != nil {
+					notifyCmds = append(notifyCmds, cmd)
 				}
 			}
 			m.newCount += newCount
 		}
 
 		m.messages = msg.messages
+		if m.err == nil {
+			m.lastRefresh = time.Now()
+		}
 		if m.lastFetch.IsZero() {
 			m.lastFetch = time.Now()
 		}
 
 		cmds := append(archiveCmds, notifyCmds...)
+		if m.err == nil {
+			if cmd := m.loadProposalBeads(m.messages); cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+		}
 		if len(cmds) > 0 {
 			return m, tea.Batch(cmds...)
 		}
@@ -237,8 +337,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.statusMsg = "Failed to load thread: " + msg.err.Error()
 			return m, nil
 		}
-		m.threadMessages = msg.messages
-		m.mode = ModeThread
+		m.openThread(msg.messages)
 		return m, nil
 
 	case beadsLoadedMsg:
@@ -250,6 +349,45 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.mode = ModeExpand
 		return m, nil
 
+	case proposalBeadsMsg:
+		// Statuses are best-effort; the preview shows them as unknown
+		if msg.err == nil {
+			for _, bead := range msg.beads {
+				m.beadStatus[bead.ID] = bead
+			}
+		}
+		return m, nil
+
+	case summaryLoadedMsg:
+		if m.summarizing == msg.key {
+			m.summarizing = ""
+		}
+		if msg.err != nil {
+			m.statusMsg = "Summary failed: " + msg.err.Error()
+			return m, nil
+		}
+		m.summaries[msg.key] = msg.bullets
+		if m.mode == ModeThread {
+			m.syncThreadViewport()
+		}
+		return m, nil
+
+	case beadCreatedMsg:
+		if msg.err != nil {
+			m.statusMsg = "Create bead failed: " + msg.err.Error()
+			return m, nil
+		}
+		m.statusMsg = "Created bead " + msg.id
+		return m, nil
+
+	case exportedMsg:
+		if msg.err != nil {
+			m.statusMsg = "Export failed: " + msg.err.Error()
+			return m, nil
+		}
+		m.statusMsg = "Exported to " + msg.path
+		return m, nil
+
 	case tea.KeyMsg:
 		// Clear status message and new count on any key press
 		m.statusMsg = ""
@@ -265,6 +403,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m.updateExpandMode(msg)
 		case ModeLearn:
 			return m.updateLearnMode(msg)
+		case ModePalette:
+			return m.updatePaletteMode(msg)
+		case ModeDiff:
+			return m.updateDiffMode(msg)
 		default:
 			return m.updateListMode(msg)
 		}
@@ -283,6 +425,10 @@ func (m Model) updateListMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.showHelp = !m.showHelp
 		return m, nil
 
+	case key.Matches(msg, m.keys.Palette):
+		// : - command palette
+		return m.openPalette()
+
 	case key.Matches(msg, m.keys.Up):
 		if m.cursor > 0 {
 			m.cursor--
@@ -336,11 +482,12 @@ func (m Model) updateListMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
-	case key.Matches(msg, m.keys.Reply):
-		// R - enter reply mode
+	case key.Matches(msg, m.keys.Reply), key.Matches(msg, m.keys.ReplyAll):
+		// R - enter reply mode, W - reply to all
 		if sel := m.SelectedMessage(); sel != nil {
 			m.mode = ModeReply
 			m.replyingTo = sel
+			m.replyAll = key.Matches(msg, m.keys.ReplyAll)
 			m.replyInput.Reset()
 			m.replyInput.Focus()
 			return m, nil
@@ -394,6 +541,13 @@ func (m Model) updateListMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
+	case key.Matches(msg, m.keys.ViewDiff):
+		// v - view a proposal's patch full screen
+		if sel := m.SelectedMessage(); sel != nil && sel.Type == TypeProposal {
+			m.openDiff(sel)
+		}
+		return m, nil
+
 	case key.Matches(msg, m.keys.Expand):
 		// e - expand bead references
 		if sel := m.SelectedMessage(); sel != nil && len(sel.References) > 0 {
@@ -401,6 +555,27 @@ func (m Model) updateListMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
+	case key.Matches(msg, m.keys.Summarize):
+		// S - summarize selected message
+		if sel := m.SelectedMessage(); sel != nil {
+			return m.startSummary(sel.ID, []Message{*sel})
+		}
+		return m, nil
+
+	case key.Matches(msg, m.keys.CreateBead):
+		// B - create bead from selected message
+		if sel := m.SelectedMessage(); sel != nil {
+			return m, m.doCreateBead(sel)
+		}
+		return m, nil
+
+	case key.Matches(msg, m.keys.Export):
+		// E - export selected message as markdown
+		if sel := m.SelectedMessage(); sel != nil {
+			return m, m.doExport([]Message{*sel})
+		}
+		return m, nil
+
 	case key.Matches(msg, m.keys.Learn):
 		// L - enter learning mode
 		if sel := m.SelectedMessage(); sel != nil {
@@ -506,19 +681,29 @@ func (m Model) updateReplyMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		// Cancel reply
 		m.mode = ModeList
 		m.replyingTo = nil
+		m.composeTo = ""
 		m.replyInput.Blur()
 		return m, nil
 
 	case tea.KeyCtrlD:
 		// Send reply (Ctrl+D as alternative to Enter since Enter adds newlines)
-		if m.replyingTo != nil && m.replyInput.Value() != "" {
-			cmd := m.doReply(m.replyingTo, m.replyInput.Value())
-			m.mode = ModeList
-			m.replyingTo = nil
-			m.replyInput.Blur()
-			return m, cmd
+		if m.replyInput.Value() == "" {
+			return m, nil
 		}
-		return m, nil
+		var cmd tea.Cmd
+		switch {
+		case m.replyingTo != nil:
+			cmd = m.doReply(m.replyingTo, m.replyInput.Value(), m.replyAll)
+		case m.composeTo != "":
+			cmd = m.doCompose(m.composeTo, m.composeSubject, m.replyInput.Value())
+		default:
+			return m, nil
+		}
+		m.mode = ModeList
+		m.replyingTo = nil
+		m.composeTo = ""
+		m.replyInput.Blur()
+		return m, cmd
 	}
 
 	// Pass to textarea
@@ -527,34 +712,24 @@ func (m Model) updateReplyMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
-// updateThreadMode handles key input in thread mode.
-func (m Model) updateThreadMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch {
-	case key.Matches(msg, m.keys.Quit), msg.Type == tea.KeyEsc:
-		// Exit thread view back to list
-		m.mode = ModeList
-		m.threadMessages = nil
-		return m, nil
+// threadSummaryKey returns the summaries key for a whole thread.
+func threadSummaryKey(threadID string) string {
+	return "thread:" + threadID
+}
 
-	case key.Matches(msg, m.keys.Reply):
-		// R - reply to thread (reply to original message)
-		if len(m.threadMessages) > 0 {
-			// Reply to the first message in thread (the original)
-			original := m.threadMessages[0]
-			m.mode = ModeReply
-			m.replyingTo = &original
-			m.replyInput.Reset()
-			m.replyInput.Focus()
-		}
+// startSummary begins summarizing msgs under key, unless a summary is
+// already shown or in progress.
+func (m Model) startSummary(key string, msgs []Message) (tea.Model, tea.Cmd) {
+	if _, ok := m.summaries[key]; ok || m.summarizing == key {
 		return m, nil
-
-	case key.Matches(msg, m.keys.Reload):
-		// r - reload messages
-		m.loading = true
-		return m, m.fetchMessages
 	}
-
-	return m, nil
+	if bullets, ok := m.summarizer.Cached(msgs); ok {
+		m.summaries[key] = bullets
+		return m, nil
+	}
+	m.summarizing = key
+	m.statusMsg = "Summarizing..."
+	return m, m.doSummarize(key, msgs)
 }
 
 // View renders the model to a string.
@@ -648,9 +823,9 @@ func (m Model) doArchiveOld() tea.Cmd {
 }
 
 // doReply creates a command to send a reply.
-func (m Model) doReply(msg *Message, body string) tea.Cmd {
+func (m Model) doReply(msg *Message, body string, all bool) tea.Cmd {
 	return func() tea.Msg {
-		err := sendReply(msg, body, m.address, m.workDir)
+		err := sendReply(msg, body, m.address, m.workDir, all)
 		return actionResultMsg{
 			action:  "Reply sent",
 			success: err == nil,
@@ -659,6 +834,18 @@ func (m Model) doReply(msg *Message, body string) tea.Cmd {
 	}
 }
 
+// doCompose creates a command to send a new message.
+func (m Model) doCompose(to, subject, body string) tea.Cmd {
+	return func() tea.Msg {
+		err := sendMessage(to, subject, body, m.address, m.workDir)
+		return actionResultMsg{
+			action:  "Message sent",
+			success: err == nil,
+			err:     err,
+		}
+	}
+}
+
 // loadThread creates a command to load thread messages.
 func (m Model) loadThread(threadID string) tea.Cmd {
 	return func() tea.Msg {
@@ -686,6 +873,32 @@ func (m Model) loadBeads(beadIDs []string) tea.Cmd {
 	}
 }
 
+// doSummarize creates a command to summarize messages with the agent.
+func (m Model) doSummarize(key string, msgs []Message) tea.Cmd {
+	summarizer := m.summarizer
+	return func() tea.Msg {
+		bullets, err := summarizer.Summarize(msgs)
+		return summaryLoadedMsg{key: key, bullets: bullets, err: err}
+	}
+}
+
+// doCreateBead creates a command to create a bead from a message.
+func (m Model) doCreateBead(msg *Message) tea.Cmd {
+	sel := *msg
+	return func() tea.Msg {
+		id, err := createBeadFromMessage(&sel, m.address, m.workDir)
+		return beadCreatedMsg{id: id, err: err}
+	}
+}
+
+// doExport creates a command to export messages as markdown.
+func (m Model) doExport(msgs []Message) tea.Cmd {
+	return func() tea.Msg {
+		path, err := exportMarkdown(msgs, ".")
+		return exportedMsg{path: path, err: err}
+	}
+}
+
 // doHook creates a command to hook a bead.
 func (m Model) doHook(beadID string) tea.Cmd {
 	return func() tea.Msg {
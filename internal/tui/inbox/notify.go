@@ -1,16 +1,189 @@
 package inbox
 
 import (
+	"encoding/json"
+	"fmt"
+	"os"
 	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/steveyegge/gastown/internal/workspace"
 )
 
-// notifyAlert creates a command that sends a desktop notification.
-func notifyAlert(subject string) tea.Cmd {
+// NotifyLevel is how loudly the inbox announces a new message.
+// Each level includes the ones before it.
+type NotifyLevel int
+
+const (
+	// NotifyNone ignores the message beyond listing it.
+	NotifyNone NotifyLevel = iota
+	// NotifyBadge counts the message in the header's NEW badge.
+	NotifyBadge
+	// NotifyDesktop also sends a desktop notification.
+	NotifyDesktop
+	// NotifySound also rings the terminal bell.
+	NotifySound
+)
+
+// ParseNotifyLevel parses "none", "badge", "desktop" or "sound".
+func ParseNotifyLevel(s string) (NotifyLevel, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "none", "off":
+		return NotifyNone, nil
+	case "badge":
+		return NotifyBadge, nil
+	case "desktop":
+		return NotifyDesktop, nil
+	case "sound":
+		return NotifySound, nil
+	}
+	return NotifyNone, fmt.Errorf("invalid notification level %q (want none, badge, desktop or sound)", s)
+}
+
+// NotifyPrefs decides how each message type is announced when it arrives.
+type NotifyPrefs struct {
+	// Levels maps message types to their notification level; types not
+	// listed get NotifyBadge.
+	Levels map[MessageType]NotifyLevel
+
+	// QuietStart and QuietEnd bound quiet hours as offsets from midnight.
+	// During quiet hours desktop and sound notifications drop to the badge.
+	// Equal bounds disable quiet hours; a start after the end spans midnight.
+	QuietStart time.Duration
+	QuietEnd   time.Duration
+}
+
+// DefaultNotifyPrefs notifies the desktop for alerts and badges the rest.
+func DefaultNotifyPrefs() NotifyPrefs {
+	return NotifyPrefs{Levels: map[MessageType]NotifyLevel{
+		TypeProposal: NotifyBadge,
+		TypeQuestion: NotifyBadge,
+		TypeAlert:    NotifyDesktop,
+		TypeInfo:     NotifyBadge,
+	}}
+}
+
+// Level returns the notification level for a message of type t arriving
+// at now (in the display time zone).
+func (p NotifyPrefs) Level(t MessageType, now time.Time) NotifyLevel {
+	level, ok := p.Levels[t]
+	if !ok {
+		level = NotifyBadge
+	}
+	if level > NotifyBadge && p.inQuietHours(now) {
+		level = NotifyBadge
+	}
+	return level
+}
+
+// inQuietHours reports whether now's time of day falls in quiet hours.
+func (p NotifyPrefs) inQuietHours(now time.Time) bool {
+	if p.QuietStart == p.QuietEnd {
+		return false
+	}
+	tod := time.Duration(now.Hour())*time.Hour + time.Duration(now.Minute())*time.Minute
+	if p.QuietStart < p.QuietEnd {
+		return tod >= p.QuietStart && tod < p.QuietEnd
+	}
+	return tod >= p.QuietStart || tod < p.QuietEnd
+}
+
+// ParseQuietHours parses a range like "22:00-07:00" into offsets from
+// midnight.
+func ParseQuietHours(s string) (start, end time.Duration, err error) {
+	from, to, ok := strings.Cut(strings.TrimSpace(s), "-")
+	if !ok {
+		return 0, 0, fmt.Errorf("invalid quiet hours %q (want HH:MM-HH:MM)", s)
+	}
+	if start, err = parseClock(from); err != nil {
+		return 0, 0, fmt.Errorf("invalid quiet hours %q: %w", s, err)
+	}
+	if end, err = parseClock(to); err != nil {
+		return 0, 0, fmt.Errorf("invalid quiet hours %q: %w", s, err)
+	}
+	return start, end, nil
+}
+
+// parseClock parses "HH:MM" into an offset from midnight.
+func parseClock(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(s))
+	if err != nil {
+		return 0, fmt.Errorf("bad time %q", s)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// notifyConfig is the "notify" section of <town>/config/inbox.json.
+type notifyConfig struct {
+	Proposal   string `json:"proposal"`
+	Question   string `json:"question"`
+	Alert      string `json:"alert"`
+	Info       string `json:"info"`
+	QuietHours string `json:"quiet_hours"`
+}
+
+// loadNotifyPrefs reads the "notify" section of <town>/config/inbox.json,
+// falling back to DefaultNotifyPrefs for unset or invalid fields.
+func loadNotifyPrefs(workDir string) NotifyPrefs {
+	townRoot, _ := workspace.FindFromCwd()
+	if townRoot == "" {
+		townRoot = workDir
+	}
+
+	var file struct {
+		Notify notifyConfig `json:"notify"`
+	}
+	if data, err := os.ReadFile(filepath.Join(townRoot, "config", "inbox.json")); err == nil {
+		_ = json.Unmarshal(data, &file)
+	}
+
+	prefs := DefaultNotifyPrefs()
+	for t, s := range map[MessageType]string{
+		TypeProposal: file.Notify.Proposal,
+		TypeQuestion: file.Notify.Question,
+		TypeAlert:    file.Notify.Alert,
+		TypeInfo:     file.Notify.Info,
+	} {
+		if level, err := ParseNotifyLevel(s); err == nil {
+			prefs.Levels[t] = level
+		}
+	}
+	if start, end, err := ParseQuietHours(file.Notify.QuietHours); err == nil {
+		prefs.QuietStart, prefs.QuietEnd = start, end
+	}
+	return prefs
+}
+
+// SetNotifyPrefs overrides the configured notification preferences.
+func (m *Model) SetNotifyPrefs(p NotifyPrefs) {
+	m.notifyPrefs = p
+}
+
+// notifyMessage creates a command that announces a new message at the
+// given level. Badge counting is done by the caller.
+func notifyMessage(msg Message, level NotifyLevel) tea.Cmd {
+	if level < NotifyDesktop {
+		return nil
+	}
 	return func() tea.Msg {
+		urgency, title := "normal", "GT Message"
+		switch msg.Type {
+		case TypeAlert:
+			urgency, title = "critical", "GT Alert"
+		case TypeProposal:
+			title = "GT Proposal"
+		case TypeQuestion:
+			title = "GT Question"
+		}
 		// Ignore errors, it's just a notification
-		_ = exec.Command("notify-send", "-u", "critical", "GT Alert", subject).Run()
+		_ = exec.Command("notify-send", "-u", urgency, title, msg.Subject).Run()
+		if level >= NotifySound {
+			// The TUI owns stdout; the bell on stderr reaches the same terminal
+			_, _ = fmt.Fprint(os.Stderr, "\a")
+		}
 		return nil
 	}
-}
\ No newline at end of file
+}
@@ -0,0 +1,111 @@
+package inbox
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestParseNotifyLevel(t *testing.T) {
+	tests := map[string]NotifyLevel{
+		"none":    NotifyNone,
+		"badge":   NotifyBadge,
+		"Desktop": NotifyDesktop,
+		" sound ": NotifySound,
+	}
+	for in, want := range tests {
+		got, err := ParseNotifyLevel(in)
+		if err != nil {
+			t.Errorf("ParseNotifyLevel(%q): %v", in, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("ParseNotifyLevel(%q) = %v, want %v", in, got, want)
+		}
+	}
+	if _, err := ParseNotifyLevel("loud"); err == nil {
+		t.Error("expected error for unknown level")
+	}
+}
+
+func TestParseQuietHours(t *testing.T) {
+	start, end, err := ParseQuietHours("22:00-07:30")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if start != 22*time.Hour || end != 7*time.Hour+30*time.Minute {
+		t.Errorf("got %s-%s", start, end)
+	}
+	for _, bad := range []string{"", "22:00", "25:00-07:00", "22:00-7pm"} {
+		if _, _, err := ParseQuietHours(bad); err == nil {
+			t.Errorf("ParseQuietHours(%q): expected error", bad)
+		}
+	}
+}
+
+func TestNotifyPrefs_Level(t *testing.T) {
+	prefs := DefaultNotifyPrefs()
+	prefs.Levels[TypeInfo] = NotifyNone
+	prefs.Levels[TypeProposal] = NotifySound
+	prefs.QuietStart, prefs.QuietEnd = 22*time.Hour, 7*time.Hour
+
+	at := func(hour, min int) time.Time {
+		return time.Date(2026, 3, 7, hour, min, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		name string
+		typ  MessageType
+		now  time.Time
+		want NotifyLevel
+	}{
+		{"alert by day", TypeAlert, at(12, 0), NotifyDesktop},
+		{"proposal by day", TypeProposal, at(12, 0), NotifySound},
+		{"info muted", TypeInfo, at(12, 0), NotifyNone},
+		{"question default", TypeQuestion, at(12, 0), NotifyBadge},
+		{"alert before midnight", TypeAlert, at(23, 0), NotifyBadge},
+		{"proposal after midnight", TypeProposal, at(6, 59), NotifyBadge},
+		{"quiet hours end", TypeProposal, at(7, 0), NotifySound},
+		{"info stays muted", TypeInfo, at(23, 0), NotifyNone},
+	}
+	for _, tt := range tests {
+		if got := prefs.Level(tt.typ, tt.now); got != tt.want {
+			t.Errorf("%s: Level = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	// Same-day window
+	prefs.QuietStart, prefs.QuietEnd = 12*time.Hour, 13*time.Hour
+	if got := prefs.Level(TypeAlert, at(12, 30)); got != NotifyBadge {
+		t.Errorf("inside same-day window: Level = %v, want badge", got)
+	}
+	if got := prefs.Level(TypeAlert, at(13, 30)); got != NotifyDesktop {
+		t.Errorf("outside same-day window: Level = %v, want desktop", got)
+	}
+}
+
+func TestLoadNotifyPrefs(t *testing.T) {
+	townRoot := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(townRoot, "config"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	config := `{"notify": {"alert": "sound", "info": "none", "question": "bogus", "quiet_hours": "23:00-06:00"}}`
+	if err := os.WriteFile(filepath.Join(townRoot, "config", "inbox.json"), []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(townRoot)
+
+	prefs := loadNotifyPrefs(townRoot)
+	if prefs.Levels[TypeAlert] != NotifySound {
+		t.Errorf("alert = %v, want sound", prefs.Levels[TypeAlert])
+	}
+	if prefs.Levels[TypeInfo] != NotifyNone {
+		t.Errorf("info = %v, want none", prefs.Levels[TypeInfo])
+	}
+	if prefs.Levels[TypeQuestion] != NotifyBadge {
+		t.Errorf("invalid question level should fall back to badge, got %v", prefs.Levels[TypeQuestion])
+	}
+	if prefs.QuietStart != 23*time.Hour || prefs.QuietEnd != 6*time.Hour {
+		t.Errorf("quiet hours = %s-%s", prefs.QuietStart, prefs.QuietEnd)
+	}
+}
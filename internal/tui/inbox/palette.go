@@ -0,0 +1,278 @@
+package inbox
+
+import (
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// paletteCommand is an inbox action that can be run by name from the
+// command palette (:).
+type paletteCommand struct {
+	Name string
+	Desc string
+
+	// Arg names the command's argument, e.g. "<address>"; empty if it
+	// takes none. Optional arguments are bracketed: "[bead-id]".
+	Arg string
+
+	// binding is the key that runs the same action, for commands that
+	// have one. The palette shows it and presses it to run the command.
+	binding func(KeyMap) key.Binding
+}
+
+// argRequired reports whether the command can't run without its argument.
+func (c paletteCommand) argRequired() bool {
+	return strings.HasPrefix(c.Arg, "<")
+}
+
+// paletteCommands lists every command in the palette, in display order.
+var paletteCommands = []paletteCommand{
+	{Name: "compose", Desc: "Write a new message", Arg: "<address> [subject]"},
+	{Name: "reply", Desc: "Reply to the sender", binding: func(k KeyMap) key.Binding { return k.Reply }},
+	{Name: "reply-all", Desc: "Reply to all recipients", binding: func(k KeyMap) key.Binding { return k.ReplyAll }},
+	{Name: "archive", Desc: "Archive the selected message", binding: func(k KeyMap) key.Binding { return k.Archive }},
+	{Name: "archive-info", Desc: "Archive all INFO messages", binding: func(k KeyMap) key.Binding { return k.ArchiveInfo }},
+	{Name: "archive-old", Desc: "Archive messages older than a day", binding: func(k KeyMap) key.Binding { return k.ArchiveOld }},
+	{Name: "mark-all-read", Desc: "Mark every message read", binding: func(k KeyMap) key.Binding { return k.MarkAllRead }},
+	{Name: "approve", Desc: "Approve the selected proposal", binding: func(k KeyMap) key.Binding { return k.Approve }},
+	{Name: "reject", Desc: "Reject the selected proposal", binding: func(k KeyMap) key.Binding { return k.Reject }},
+	{Name: "view-proposal", Desc: "Show the proposal and its patch full screen", binding: func(k KeyMap) key.Binding { return k.ViewDiff }},
+	{Name: "open-bead", Desc: "Show a bead, or the beads the message references", Arg: "[bead-id]"},
+	{Name: "create-bead", Desc: "Create a bead from the message", binding: func(k KeyMap) key.Binding { return k.CreateBead }},
+	{Name: "export", Desc: "Export the message or thread as markdown", binding: func(k KeyMap) key.Binding { return k.Export }},
+	{Name: "thread", Desc: "Show the message's thread", binding: func(k KeyMap) key.Binding { return k.Tab }},
+	{Name: "summarize", Desc: "Summarize the message", binding: func(k KeyMap) key.Binding { return k.Summarize }},
+	{Name: "learn", Desc: "Correct the message's type", binding: func(k KeyMap) key.Binding { return k.Learn }},
+	{Name: "switch-account", Desc: "View another address's inbox", Arg: "<address>"},
+	{Name: "reload", Desc: "Refetch messages", binding: func(k KeyMap) key.Binding { return k.Reload }},
+	{Name: "next-page", Desc: "Next page of messages", binding: func(k KeyMap) key.Binding { return k.NextPage }},
+	{Name: "prev-page", Desc: "Previous page of messages", binding: func(k KeyMap) key.Binding { return k.PrevPage }},
+	{Name: "help", Desc: "Toggle key help", binding: func(k KeyMap) key.Binding { return k.Help }},
+	{Name: "quit", Desc: "Quit the inbox", binding: func(k KeyMap) key.Binding { return k.Quit }},
+}
+
+// parsePaletteInput splits palette input into the command query and its
+// argument: "compose mayor/ Status" is ("compose", "mayor/ Status").
+func parsePaletteInput(input string) (query, arg string) {
+	input = strings.TrimLeft(input, " ")
+	if i := strings.IndexByte(input, ' '); i >= 0 {
+		return input[:i], strings.TrimSpace(input[i+1:])
+	}
+	return input, ""
+}
+
+// filterPalette returns the commands matching query, best match first.
+// An exact name sorts first, then prefix matches, then fuzzy (subsequence)
+// matches by how tightly the query's letters cluster. An empty query
+// matches every command in display order.
+func filterPalette(query string) []paletteCommand {
+	query = strings.ToLower(query)
+	type scored struct {
+		cmd   paletteCommand
+		score int
+		order int
+	}
+	var matches []scored
+	for i, c := range paletteCommands {
+		if score, ok := fuzzyScore(query, c.Name); ok {
+			matches = append(matches, scored{c, score, i})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].score != matches[j].score {
+			return matches[i].score < matches[j].score
+		}
+		return matches[i].order < matches[j].order
+	})
+
+	cmds := make([]paletteCommand, len(matches))
+	for i, m := range matches {
+		cmds[i] = m.cmd
+	}
+	return cmds
+}
+
+// fuzzyScore reports whether query's characters appear in order in name,
+// and a score where lower is better. Characters that start a word of the
+// name (after "-") cost nothing to skip to, so "mar" matches mark-all-read
+// ahead of archive-old.
+func fuzzyScore(query, name string) (int, bool) {
+	switch {
+	case query == name:
+		return -2, true
+	case strings.HasPrefix(name, query):
+		return -1, true
+	}
+
+	score := 0
+	qi := 0
+	last := -1
+	for ni := 0; ni < len(name) && qi < len(query); ni++ {
+		if name[ni] != query[qi] {
+			continue
+		}
+		if last >= 0 && ni > last+1 && !wordStart(name, ni) {
+			score += ni - last - 1
+		}
+		if last < 0 && ni > 0 && !wordStart(name, ni) {
+			score += ni
+		}
+		last = ni
+		qi++
+	}
+	if qi < len(query) {
+		return 0, false
+	}
+	return score, true
+}
+
+// wordStart reports whether name[i] begins a word of a dashed name.
+func wordStart(name string, i int) bool {
+	return i == 0 || !unicode.IsLetter(rune(name[i-1]))
+}
+
+// openPalette switches to the command palette.
+func (m Model) openPalette() (tea.Model, tea.Cmd) {
+	m.mode = ModePalette
+	m.paletteCursor = 0
+	m.paletteInput.Reset()
+	return m, m.paletteInput.Focus()
+}
+
+// closePalette returns from the command palette to the list.
+func (m *Model) closePalette() {
+	m.mode = ModeList
+	m.paletteInput.Blur()
+}
+
+// paletteMatches returns the commands matching the palette's input.
+func (m Model) paletteMatches() []paletteCommand {
+	query, _ := parsePaletteInput(m.paletteInput.Value())
+	return filterPalette(query)
+}
+
+// updatePaletteMode handles key input in the command palette.
+func (m Model) updatePaletteMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	matches := m.paletteMatches()
+
+	switch msg.Type {
+	case tea.KeyEsc, tea.KeyCtrlC:
+		m.closePalette()
+		return m, nil
+
+	case tea.KeyUp, tea.KeyCtrlP:
+		if m.paletteCursor > 0 {
+			m.paletteCursor--
+		}
+		return m, nil
+
+	case tea.KeyDown, tea.KeyCtrlN:
+		if m.paletteCursor < len(matches)-1 {
+			m.paletteCursor++
+		}
+		return m, nil
+
+	case tea.KeyTab:
+		// Complete the selected command's name, ready for its argument
+		if m.paletteCursor < len(matches) {
+			_, arg := parsePaletteInput(m.paletteInput.Value())
+			m.paletteInput.SetValue(matches[m.paletteCursor].Name + " " + arg)
+			m.paletteInput.CursorEnd()
+			m.paletteCursor = 0
+		}
+		return m, nil
+
+	case tea.KeyEnter:
+		if m.paletteCursor >= len(matches) {
+			return m, nil
+		}
+		c := matches[m.paletteCursor]
+		_, arg := parsePaletteInput(m.paletteInput.Value())
+		if arg == "" && c.argRequired() {
+			// Ask for the argument before running
+			m.paletteInput.SetValue(c.Name + " ")
+			m.paletteInput.CursorEnd()
+			m.paletteCursor = 0
+			m.statusMsg = c.Name + " needs " + c.Arg
+			return m, nil
+		}
+		m.closePalette()
+		return m.runPaletteCommand(c, arg)
+	}
+
+	before := m.paletteInput.Value()
+	var cmd tea.Cmd
+	m.paletteInput, cmd = m.paletteInput.Update(msg)
+	if m.paletteInput.Value() != before {
+		m.paletteCursor = 0
+	}
+	return m, cmd
+}
+
+// runPaletteCommand runs c with arg. Commands with a key binding behave
+// exactly as if the key had been pressed in the list.
+func (m Model) runPaletteCommand(c paletteCommand, arg string) (tea.Model, tea.Cmd) {
+	if c.binding != nil {
+		return m.updateListMode(bindingKeyMsg(c.binding(m.keys)))
+	}
+
+	switch c.Name {
+	case "compose":
+		to, subject := parsePaletteInput(arg)
+		m.mode = ModeReply
+		m.replyingTo = nil
+		m.composeTo = to
+		m.composeSubject = subject
+		m.replyInput.Reset()
+		return m, m.replyInput.Focus()
+
+	case "open-bead":
+		if arg != "" {
+			return m, m.loadBeads(strings.Fields(arg))
+		}
+		return m.updateListMode(bindingKeyMsg(m.keys.Expand))
+
+	case "switch-account":
+		if arg == m.address {
+			return m, nil
+		}
+		m.address = arg
+		m.messages = nil
+		m.cursor = 0
+		m.page = 0
+		m.newCount = 0
+		m.lastFetch = time.Time{} // the new inbox's messages aren't "new"
+		m.loading = true
+		m.statusMsg = "Switched to " + arg
+		return m, m.fetchMessages
+	}
+	return m, nil
+}
+
+// bindingKeyMsg returns the key press for a binding's first key.
+func bindingKeyMsg(b key.Binding) tea.KeyMsg {
+	k := b.Keys()[0]
+	for t, name := range keyNames {
+		if name == k {
+			return tea.KeyMsg{Type: t}
+		}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+}
+
+// keyNames maps the named keys used in DefaultKeyMap to their types.
+var keyNames = map[tea.KeyType]string{
+	tea.KeyTab:    "tab",
+	tea.KeyEsc:    "esc",
+	tea.KeyUp:     "up",
+	tea.KeyDown:   "down",
+	tea.KeyHome:   "home",
+	tea.KeyEnd:    "end",
+	tea.KeyPgUp:   "pgup",
+	tea.KeyPgDown: "pgdown",
+}
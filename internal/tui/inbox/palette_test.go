@@ -0,0 +1,98 @@
+package inbox
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestFilterPalette(t *testing.T) {
+	tests := []struct {
+		query string
+		first string
+	}{
+		{"compose", "compose"},
+		{"arch", "archive"},
+		{"mar", "mark-all-read"},
+		{"ao", "archive-old"},
+		{"sa", "switch-account"},
+		{"ob", "open-bead"},
+	}
+	for _, tt := range tests {
+		got := filterPalette(tt.query)
+		if len(got) == 0 || got[0].Name != tt.first {
+			names := make([]string, len(got))
+			for i, c := range got {
+				names[i] = c.Name
+			}
+			t.Errorf("filterPalette(%q) = %v, want %s first", tt.query, names, tt.first)
+		}
+	}
+
+	if got := filterPalette(""); len(got) != len(paletteCommands) {
+		t.Errorf("empty query matched %d of %d commands", len(got), len(paletteCommands))
+	}
+	if got := filterPalette("zzz"); len(got) != 0 {
+		t.Errorf("filterPalette(zzz) = %v, want none", got)
+	}
+}
+
+func TestParsePaletteInput(t *testing.T) {
+	query, arg := parsePaletteInput("compose mayor/  Weekly status ")
+	if query != "compose" || arg != "mayor/  Weekly status" {
+		t.Errorf("parsePaletteInput() = %q, %q", query, arg)
+	}
+	if query, arg := parsePaletteInput("mar"); query != "mar" || arg != "" {
+		t.Errorf("parsePaletteInput(mar) = %q, %q", query, arg)
+	}
+}
+
+// typePalette opens the palette on m, types input and presses enter.
+func typePalette(t *testing.T, m Model, input string) (Model, tea.Cmd) {
+	t.Helper()
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(":")})
+	m = updated.(Model)
+	if m.mode != ModePalette {
+		t.Fatalf(": opened mode %v, want palette", m.mode)
+	}
+	for _, r := range input {
+		updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		m = updated.(Model)
+	}
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	return updated.(Model), cmd
+}
+
+func TestPalette_RunsCommands(t *testing.T) {
+	m := New("mayor/", t.TempDir())
+
+	// A command with a key binding behaves like the key
+	m, _ = typePalette(t, m, "help")
+	if m.mode != ModeList || !m.showHelp {
+		t.Errorf("help: mode %v, showHelp %v", m.mode, m.showHelp)
+	}
+
+	// A required argument is asked for before running
+	m, cmd := typePalette(t, m, "swi")
+	if m.mode != ModePalette || cmd != nil || m.paletteInput.Value() != "switch-account " {
+		t.Fatalf("switch-account without address: mode %v, input %q", m.mode, m.paletteInput.Value())
+	}
+	for _, r := range "gastown/witness" {
+		updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		m = updated.(Model)
+	}
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if m.address != "gastown/witness" || m.mode != ModeList || cmd == nil || !m.loading {
+		t.Errorf("switch-account: address %q, mode %v, loading %v", m.address, m.mode, m.loading)
+	}
+
+	m, _ = typePalette(t, m, "compose mayor/ Weekly status")
+	if m.mode != ModeReply || m.composeTo != "mayor/" || m.composeSubject != "Weekly status" || m.replyingTo != nil {
+		t.Errorf("compose: mode %v, to %q, subject %q", m.mode, m.composeTo, m.composeSubject)
+	}
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m = updated.(Model); m.mode != ModeList || m.composeTo != "" {
+		t.Errorf("esc should cancel compose: mode %v, to %q", m.mode, m.composeTo)
+	}
+}
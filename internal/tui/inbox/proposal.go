@@ -0,0 +1,443 @@
+package inbox
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/alecthomas/chroma/v2"
+	"github.com/alecthomas/chroma/v2/lexers"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// diffHeaderLines and diffFooterLines frame the diff viewport.
+const (
+	diffHeaderLines = 3
+	diffFooterLines = 2
+)
+
+// proposalPreview is a PROPOSAL message split for the structured preview:
+// the proposal text on top and, if the body carries a patch, the unified
+// diff below it.
+type proposalPreview struct {
+	// summary is the body without the patch.
+	summary string
+
+	// patch holds the unified diff lines, without any ``` fence.
+	patch []string
+
+	// files are the files the patch touches, in order.
+	files []string
+
+	added, deleted int
+}
+
+// hunkHeaderRe matches a unified diff hunk header, capturing the old and
+// new line counts (each defaults to 1 when omitted).
+var hunkHeaderRe = regexp.MustCompile(`^@@ -\d+(?:,(\d+))? \+\d+(?:,(\d+))? @@`)
+
+// patchHeaderPrefixes start the extended header lines git writes between
+// "diff --git" and the first hunk.
+var patchHeaderPrefixes = []string{
+	"diff ", "index ", "new file mode", "deleted file mode", "old mode", "new mode",
+	"similarity index", "dissimilarity index", "rename from", "rename to",
+	"copy from", "copy to", "Binary files",
+}
+
+// parseProposal splits a message body into its text and any patch. A patch
+// is a ```diff (or ```patch) fenced block, or an unfenced unified diff
+// starting with "diff --git" or a "---"/"+++" pair. Hunk line counts decide
+// where an unfenced patch ends, so text after it stays in the summary.
+func parseProposal(body string) proposalPreview {
+	var p proposalPreview
+	var summary []string
+
+	lines := strings.Split(body, "\n")
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		switch {
+		case isDiffFence(line):
+			end := i + 1
+			for end < len(lines) && strings.TrimSpace(lines[end]) != "```" {
+				end++
+			}
+			p.scanPatch(lines[i+1:end], true)
+			i = end
+		case startsPatch(lines, i):
+			i += p.scanPatch(lines[i:], false) - 1
+		default:
+			summary = append(summary, line)
+		}
+	}
+
+	p.summary = strings.TrimSpace(strings.Join(summary, "\n"))
+	return p
+}
+
+// hasPatch reports whether the proposal carries a patch.
+func (p proposalPreview) hasPatch() bool {
+	return len(p.patch) > 0
+}
+
+// isDiffFence reports whether line opens a fenced diff block.
+func isDiffFence(line string) bool {
+	line = strings.TrimSpace(line)
+	return line == "```diff" || line == "```patch"
+}
+
+// startsPatch reports whether an unfenced unified diff starts at lines[i].
+func startsPatch(lines []string, i int) bool {
+	if strings.HasPrefix(lines[i], "diff --git ") {
+		return true
+	}
+	return strings.HasPrefix(lines[i], "--- ") && i+1 < len(lines) && strings.HasPrefix(lines[i+1], "+++ ")
+}
+
+// scanPatch adds the patch at the start of lines to p, returning how many
+// lines it took. With all, every line is taken (the block was fenced);
+// otherwise the patch ends at the first line that isn't part of it.
+func (p *proposalPreview) scanPatch(lines []string, all bool) int {
+	var oldLeft, newLeft int
+	var oldFile string
+	for i, line := range lines {
+		switch {
+		case oldLeft > 0 || newLeft > 0:
+			switch {
+			case strings.HasPrefix(line, "+"):
+				newLeft--
+				p.added++
+			case strings.HasPrefix(line, "-"):
+				oldLeft--
+				p.deleted++
+			case strings.HasPrefix(line, `\`):
+				// "\ No newline at end of file"
+			default:
+				oldLeft--
+				newLeft--
+			}
+		case strings.HasPrefix(line, "@@"):
+			oldLeft, newLeft = parseHunkHeader(line)
+		case strings.HasPrefix(line, "--- "):
+			oldFile = patchFileName(line)
+		case strings.HasPrefix(line, "+++ "):
+			file := patchFileName(line)
+			if file == "/dev/null" {
+				file = oldFile
+			}
+			p.files = append(p.files, file)
+		case isPatchHeader(line), all:
+		default:
+			return i
+		}
+		p.patch = append(p.patch, line)
+	}
+	return len(lines)
+}
+
+// parseHunkHeader returns the old and new line counts of a hunk header.
+func parseHunkHeader(line string) (oldLines, newLines int) {
+	m := hunkHeaderRe.FindStringSubmatch(line)
+	if m == nil {
+		return 0, 0
+	}
+	count := func(s string) int {
+		if s == "" {
+			return 1
+		}
+		n, _ := strconv.Atoi(s)
+		return n
+	}
+	return count(m[1]), count(m[2])
+}
+
+// patchFileName extracts the path from a "---" or "+++" line, dropping the
+// a/ or b/ prefix and any trailing timestamp.
+func patchFileName(line string) string {
+	name := line[4:]
+	if i := strings.IndexByte(name, '\t'); i >= 0 {
+		name = name[:i]
+	}
+	if strings.HasPrefix(name, "a/") || strings.HasPrefix(name, "b/") {
+		name = name[2:]
+	}
+	return name
+}
+
+func isPatchHeader(line string) bool {
+	for _, prefix := range patchHeaderPrefixes {
+		if strings.HasPrefix(line, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// statLine summarizes the patch, e.g. "2 files, +10 -3".
+func (p proposalPreview) statLine() string {
+	files := "1 file"
+	if len(p.files) != 1 {
+		files = fmt.Sprintf("%d files", len(p.files))
+	}
+	return fmt.Sprintf("%s, %s %s", files,
+		diffAddStyle.Render(fmt.Sprintf("+%d", p.added)),
+		diffDelStyle.Render(fmt.Sprintf("-%d", p.deleted)))
+}
+
+// renderPatch renders diff lines for display, truncated to width: file
+// headers bold, hunk headers colored, and added, removed and context lines
+// syntax-highlighted for the language of the file they belong to.
+func renderPatch(lines []string, width int) []string {
+	var lexer chroma.Lexer
+	var oldLeft, newLeft int
+	rendered := make([]string, 0, len(lines))
+	for _, raw := range lines {
+		line := truncateString(strings.ReplaceAll(raw, "\t", "    "), width)
+		switch {
+		case oldLeft > 0 || newLeft > 0:
+			switch {
+			case strings.HasPrefix(line, "+"):
+				newLeft--
+				rendered = append(rendered, diffAddStyle.Render("+")+highlightCode(line[1:], lexer))
+			case strings.HasPrefix(line, "-"):
+				oldLeft--
+				rendered = append(rendered, diffDelStyle.Render("-")+highlightCode(line[1:], lexer))
+			case strings.HasPrefix(line, `\`):
+				rendered = append(rendered, dimStyle.Render(line))
+			default:
+				oldLeft--
+				newLeft--
+				rendered = append(rendered, highlightCode(line, lexer))
+			}
+		case strings.HasPrefix(line, "@@"):
+			oldLeft, newLeft = parseHunkHeader(raw)
+			rendered = append(rendered, diffHunkStyle.Render(line))
+		case strings.HasPrefix(raw, "+++ "):
+			lexer = lexers.Match(patchFileName(raw))
+			rendered = append(rendered, diffFileStyle.Render(line))
+		case strings.HasPrefix(raw, "--- ") || isPatchHeader(raw):
+			rendered = append(rendered, diffFileStyle.Render(line))
+		case strings.HasPrefix(line, "+"):
+			// Hand-written patches don't always keep hunk counts right
+			rendered = append(rendered, diffAddStyle.Render(line))
+		case strings.HasPrefix(line, "-"):
+			rendered = append(rendered, diffDelStyle.Render(line))
+		default:
+			rendered = append(rendered, line)
+		}
+	}
+	return rendered
+}
+
+// highlightCode syntax-highlights one line of code with lexer. Lines are
+// highlighted independently, so constructs spanning lines (block comments,
+// raw strings) are only partly colored.
+func highlightCode(code string, lexer chroma.Lexer) string {
+	if lexer == nil || strings.TrimSpace(code) == "" {
+		return code
+	}
+	it, err := lexer.Tokenise(nil, code)
+	if err != nil {
+		return code
+	}
+	var b strings.Builder
+	for _, tok := range it.Tokens() {
+		value := strings.TrimSuffix(tok.Value, "\n")
+		switch {
+		case tok.Type.InCategory(chroma.Keyword):
+			b.WriteString(syntaxKeywordStyle.Render(value))
+		case tok.Type.InSubCategory(chroma.LiteralString):
+			b.WriteString(syntaxStringStyle.Render(value))
+		case tok.Type.InSubCategory(chroma.LiteralNumber):
+			b.WriteString(syntaxNumberStyle.Render(value))
+		case tok.Type.InCategory(chroma.Comment):
+			b.WriteString(syntaxCommentStyle.Render(value))
+		default:
+			b.WriteString(value)
+		}
+	}
+	return b.String()
+}
+
+// renderProposalLines renders the structured preview of a proposal: its
+// text, the statuses of the beads it references, and its patch.
+func (m Model) renderProposalLines(msg *Message, p proposalPreview, width int) []string {
+	var lines []string
+	for _, line := range wrapText(p.summary, width) {
+		lines = append(lines, highlightBeadRefs(line, msg.References))
+	}
+
+	if len(msg.References) > 0 {
+		lines = append(lines, "", previewLabelStyle.Render("Beads:"))
+		for _, id := range msg.References {
+			lines = append(lines, m.renderBeadStatusLine(id, width))
+		}
+	}
+
+	if p.hasPatch() {
+		lines = append(lines, "", previewLabelStyle.Render("Patch: ")+p.statLine())
+		lines = append(lines, renderPatch(p.patch, width)...)
+	}
+	return lines
+}
+
+// renderBeadStatusLine renders a referenced bead with its status and title,
+// as far as they have been loaded.
+func (m Model) renderBeadStatusLine(id string, width int) string {
+	bead, ok := m.beadStatus[id]
+	if !ok {
+		return titleStyle.Render(id) + " " + dimStyle.Render("[...]")
+	}
+	status := bead.Status
+	if status == "" {
+		status = "unknown"
+	}
+	statusStyle := dimStyle
+	switch status {
+	case "open", "in_progress":
+		statusStyle = alertBadgeStyle
+	case "closed":
+		statusStyle = infoBadgeStyle
+	}
+	title := truncateString(bead.Title, width-len(id)-len(status)-4)
+	return fmt.Sprintf("%s %s %s", titleStyle.Render(id), statusStyle.Render("["+status+"]"), title)
+}
+
+// proposalBeadsMsg carries the statuses of beads referenced by proposals.
+type proposalBeadsMsg struct {
+	beads []ExpandedBead
+	err   error
+}
+
+// loadProposalBeads loads the beads referenced by the proposals in msgs,
+// or returns nil if there are none.
+func (m Model) loadProposalBeads(msgs []Message) tea.Cmd {
+	seen := make(map[string]bool)
+	var ids []string
+	for _, msg := range msgs {
+		if msg.Type != TypeProposal {
+			continue
+		}
+		for _, id := range msg.References {
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	workDir := m.workDir
+	return func() tea.Msg {
+		beads, err := fetchBeadDetails(ids, workDir)
+		return proposalBeadsMsg{beads: beads, err: err}
+	}
+}
+
+// openDiff shows the selected proposal's patch in the diff view.
+func (m *Model) openDiff(msg *Message) {
+	m.diffMessage = msg
+	m.mode = ModeDiff
+	m.syncDiffViewport()
+	m.diffViewport.GotoTop()
+}
+
+// closeDiff leaves the diff view for the list.
+func (m *Model) closeDiff() {
+	m.mode = ModeList
+	m.diffMessage = nil
+	m.diffViewport.SetYOffset(0)
+}
+
+// syncDiffViewport sizes the diff viewport to the window and renders the
+// proposal into it.
+func (m *Model) syncDiffViewport() {
+	m.diffViewport.Width = m.width - 2
+	m.diffViewport.Height = max(m.height-diffHeaderLines-diffFooterLines, 1)
+	if m.diffMessage == nil {
+		return
+	}
+	p := parseProposal(m.diffMessage.Body)
+	m.diffViewport.SetContent(strings.Join(m.renderProposalLines(m.diffMessage, p, m.width-2), "\n"))
+}
+
+// updateDiffMode handles key input in the diff view. Proposals can be
+// approved or rejected without leaving it.
+func (m Model) updateDiffMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Quit), msg.Type == tea.KeyEsc:
+		m.closeDiff()
+		return m, nil
+
+	case key.Matches(msg, m.keys.Approve):
+		sel := m.diffMessage
+		m.closeDiff()
+		return m, m.doApprove(sel)
+
+	case key.Matches(msg, m.keys.Reject):
+		sel := m.diffMessage
+		m.closeDiff()
+		return m, m.doReject(sel)
+
+	case key.Matches(msg, m.keys.Up):
+		m.diffViewport.LineUp(1)
+		return m, nil
+
+	case key.Matches(msg, m.keys.Down):
+		m.diffViewport.LineDown(1)
+		return m, nil
+
+	case key.Matches(msg, m.keys.PageUp):
+		m.diffViewport.HalfViewUp()
+		return m, nil
+
+	case key.Matches(msg, m.keys.PageDown):
+		m.diffViewport.HalfViewDown()
+		return m, nil
+
+	case key.Matches(msg, m.keys.Top):
+		m.diffViewport.GotoTop()
+		return m, nil
+
+	case key.Matches(msg, m.keys.Bottom):
+		m.diffViewport.GotoBottom()
+		return m, nil
+	}
+
+	return m, nil
+}
+
+// renderDiffView renders the full-screen proposal and diff view.
+func (m Model) renderDiffView() string {
+	var b strings.Builder
+
+	b.WriteString(titleStyle.Render("PROPOSAL"))
+	if m.diffMessage != nil {
+		b.WriteString("  ")
+		b.WriteString(truncateString(m.diffMessage.Subject, m.width-12))
+	}
+	b.WriteString("\n")
+	b.WriteString(dimStyle.Render(strings.Repeat("─", m.width-2)))
+	b.WriteString("\n\n")
+
+	// Render fresh content so bead statuses loaded since opening show up
+	vp := m.diffViewport
+	if m.diffMessage != nil {
+		p := parseProposal(m.diffMessage.Body)
+		vp.SetContent(strings.Join(m.renderProposalLines(m.diffMessage, p, m.width-2), "\n"))
+	}
+	b.WriteString(vp.View())
+	b.WriteString("\n")
+
+	b.WriteString(dimStyle.Render(strings.Repeat("─", m.width-2)))
+	b.WriteString("\n")
+	hints := "↑/↓ scroll | pgup/pgdn page | g/G top/bottom | y approve | n reject | Esc back"
+	if vp.TotalLineCount() > vp.Height {
+		hints += fmt.Sprintf(" | %3.f%%", vp.ScrollPercent()*100)
+	}
+	b.WriteString(helpStyle.Render(hints))
+
+	return b.String()
+}
@@ -0,0 +1,66 @@
+package inbox
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseProposal_Fenced(t *testing.T) {
+	body := "Rename the helper.\n\n```diff\n" +
+		"--- a/util.go\n+++ b/util.go\n@@ -1,2 +1,2 @@\n package util\n-func old() {}\n+func renamed() {}\n" +
+		"```\n\nThanks!"
+	p := parseProposal(body)
+
+	if !p.hasPatch() {
+		t.Fatal("expected a patch")
+	}
+	if len(p.patch) != 6 {
+		t.Errorf("patch has %d lines, want 6: %q", len(p.patch), p.patch)
+	}
+	if len(p.files) != 1 || p.files[0] != "util.go" {
+		t.Errorf("files = %v, want [util.go]", p.files)
+	}
+	if p.added != 1 || p.deleted != 1 {
+		t.Errorf("added/deleted = %d/%d, want 1/1", p.added, p.deleted)
+	}
+	if strings.Contains(p.summary, "```") || !strings.Contains(p.summary, "Thanks!") {
+		t.Errorf("summary = %q", p.summary)
+	}
+}
+
+func TestParseProposal_Unfenced(t *testing.T) {
+	body := "Fix the typo.\n" +
+		"diff --git a/README.md b/README.md\nindex 123..456 100644\n--- a/README.md\n+++ b/README.md\n" +
+		"@@ -1 +1,2 @@\n-Helo\n+Hello\n+World\n" +
+		"Let me know if this works."
+	p := parseProposal(body)
+
+	if len(p.files) != 1 || p.files[0] != "README.md" {
+		t.Errorf("files = %v, want [README.md]", p.files)
+	}
+	if p.added != 2 || p.deleted != 1 {
+		t.Errorf("added/deleted = %d/%d, want 2/1", p.added, p.deleted)
+	}
+	// Text after the last hunk stays in the summary
+	if !strings.Contains(p.summary, "Let me know") {
+		t.Errorf("summary = %q, want trailing text", p.summary)
+	}
+	if strings.Contains(p.summary, "diff --git") {
+		t.Errorf("summary should not contain the patch: %q", p.summary)
+	}
+}
+
+func TestParseProposal_NoPatch(t *testing.T) {
+	p := parseProposal("Should we bump the timeout?\n--- signed, mayor")
+	if p.hasPatch() {
+		t.Errorf("unexpected patch: %q", p.patch)
+	}
+}
+
+func TestRenderPatch(t *testing.T) {
+	p := parseProposal("```diff\n--- a/x.go\n+++ b/x.go\n@@ -1 +1 @@\n-a := 1\n+a := 2\n```")
+	lines := renderPatch(p.patch, 80)
+	if len(lines) != len(p.patch) {
+		t.Errorf("rendered %d lines, want %d", len(lines), len(p.patch))
+	}
+}
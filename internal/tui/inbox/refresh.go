@@ -0,0 +1,79 @@
+package inbox
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// DefaultRefreshInterval is how often the inbox refetches messages.
+const DefaultRefreshInterval = 30 * time.Second
+
+// minRefreshInterval keeps a typo like "1ms" from hammering beads.
+const minRefreshInterval = time.Second
+
+// ParseRefreshInterval parses a refresh interval such as "30s" or "2m".
+// "off", "never" and "0" disable auto-refresh (returned as 0).
+func ParseRefreshInterval(s string) (time.Duration, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "off", "never", "0":
+		return 0, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid refresh interval %q (want a duration like 30s, or off)", s)
+	}
+	if d < minRefreshInterval {
+		return 0, fmt.Errorf("refresh interval %s is below the %s minimum (use off to disable)", d, minRefreshInterval)
+	}
+	return d, nil
+}
+
+// loadRefreshInterval reads "refresh_interval" from <town>/config/inbox.json,
+// falling back to DefaultRefreshInterval if unset or invalid.
+func loadRefreshInterval(workDir string) time.Duration {
+	townRoot, _ := workspace.FindFromCwd()
+	if townRoot == "" {
+		townRoot = workDir
+	}
+
+	var file struct {
+		RefreshInterval string `json:"refresh_interval"`
+	}
+	data, err := os.ReadFile(filepath.Join(townRoot, "config", "inbox.json"))
+	if err != nil {
+		return DefaultRefreshInterval
+	}
+	if err := json.Unmarshal(data, &file); err != nil || file.RefreshInterval == "" {
+		return DefaultRefreshInterval
+	}
+	d, err := ParseRefreshInterval(file.RefreshInterval)
+	if err != nil {
+		return DefaultRefreshInterval
+	}
+	return d
+}
+
+// SetRefreshInterval overrides the configured auto-refresh interval.
+// 0 disables auto-refresh; r still reloads manually.
+func (m *Model) SetRefreshInterval(d time.Duration) {
+	m.refreshInterval = d
+}
+
+// refreshStatus describes when messages were last fetched and whether
+// auto-refresh is on, for the header.
+func (m Model) refreshStatus() string {
+	if m.lastRefresh.IsZero() {
+		return ""
+	}
+	status := "refreshed " + m.timeFormat.Clock(m.lastRefresh)
+	if m.refreshInterval <= 0 {
+		status += " (auto off, r to refresh)"
+	}
+	return status
+}
@@ -0,0 +1,60 @@
+package inbox
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseRefreshInterval(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"30s", 30 * time.Second, false},
+		{"2m", 2 * time.Minute, false},
+		{"off", 0, false},
+		{"Never", 0, false},
+		{"0", 0, false},
+		{"500ms", 0, true},
+		{"soon", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseRefreshInterval(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseRefreshInterval(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseRefreshInterval(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestRefreshDisabled(t *testing.T) {
+	m := Model{}
+	m.SetRefreshInterval(0)
+	if m.tick() != nil {
+		t.Error("expected no tick when auto-refresh is off")
+	}
+
+	// Failed fetches don't count as a refresh
+	updated, _ := m.Update(fetchMessagesMsg{err: errors.New("bd timeout")})
+	if status := updated.(Model).refreshStatus(); status != "" {
+		t.Errorf("refreshStatus after failed fetch = %q, want empty", status)
+	}
+
+	updated, _ = m.Update(fetchMessagesMsg{})
+	status := updated.(Model).refreshStatus()
+	if !strings.HasPrefix(status, "refreshed ") || !strings.Contains(status, "auto off") {
+		t.Errorf("refreshStatus = %q", status)
+	}
+
+	m.SetRefreshInterval(DefaultRefreshInterval)
+	if m.tick() == nil {
+		t.Error("expected tick when auto-refresh is on")
+	}
+}
@@ -6,114 +6,77 @@ import (
 	"github.com/charmbracelet/lipgloss"
 )
 
-// Color definitions matching the spec
-var (
-	// Message type colors
-	colorProposal = lipgloss.Color("11") // Yellow
-	colorQuestion = lipgloss.Color("14") // Cyan
-	colorAlert    = lipgloss.Color("9")  // Red
-	colorInfo     = lipgloss.Color("8")  // Gray
-
-	// UI colors
-	colorSelected  = lipgloss.Color("236") // Selection background
-	colorBorder    = lipgloss.Color("240") // Border color
-	colorTitle     = lipgloss.Color("12")  // Title color (blue)
-	colorDim       = lipgloss.Color("8")   // Dimmed text
-	colorNormal    = lipgloss.Color("15")  // Normal text (white)
-	colorUnread    = lipgloss.Color("15")  // Unread indicator
-	colorRead      = lipgloss.Color("8")   // Read indicator (dimmed)
-	colorHeader    = lipgloss.Color("15")  // Header text
-	colorHeaderDim = lipgloss.Color("8")   // Dimmed header parts
-
-	// Aging colors (Phase 4)
-	colorAgeFresh  = lipgloss.Color("15") // < 1h: Bright white
-	colorAgeRecent = lipgloss.Color("252") // 1h-24h: Near white
-	colorAgeOld    = lipgloss.Color("245") // 1d-3d: Gray
-	colorAgeStale  = lipgloss.Color("240") // > 3d: Dark gray
-)
-
-// Styles for the inbox TUI
+// Styles for the inbox TUI, set from the theme by applyTheme.
 var (
 	// Title style for "GT INBOX" header
-	titleStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(colorTitle)
+	titleStyle lipgloss.Style
 
 	// Aging styles (Phase 4)
-	ageFreshStyle = lipgloss.NewStyle().Foreground(colorAgeFresh)
-	ageRecentStyle = lipgloss.NewStyle().Foreground(colorAgeRecent)
-	ageOldStyle    = lipgloss.NewStyle().Foreground(colorAgeOld)
-	ageStaleStyle  = lipgloss.NewStyle().Foreground(colorAgeStale)
+	ageFreshStyle  lipgloss.Style
+	ageRecentStyle lipgloss.Style
+	ageOldStyle    lipgloss.Style
+	ageStaleStyle  lipgloss.Style
 
 	// Selected item style
-	selectedStyle = lipgloss.NewStyle().
-			Background(colorSelected).
-			Foreground(colorNormal)
+	selectedStyle lipgloss.Style
 
 	// Normal message row styles
-	messageStyle = lipgloss.NewStyle().
-			Foreground(colorNormal)
-
-	dimStyle = lipgloss.NewStyle().
-			Foreground(colorDim)
+	messageStyle lipgloss.Style
+	dimStyle     lipgloss.Style
 
 	// Badge styles by type
-	proposalBadgeStyle = lipgloss.NewStyle().
-				Foreground(colorProposal)
-
-	questionBadgeStyle = lipgloss.NewStyle().
-				Foreground(colorQuestion)
-
-	alertBadgeStyle = lipgloss.NewStyle().
-			Foreground(colorAlert).
-			Bold(true)
-
-	infoBadgeStyle = lipgloss.NewStyle().
-			Foreground(colorInfo)
+	proposalBadgeStyle lipgloss.Style
+	questionBadgeStyle lipgloss.Style
+	alertBadgeStyle    lipgloss.Style
+	infoBadgeStyle     lipgloss.Style
 
 	// Preview pane styles
-	previewHeaderStyle = lipgloss.NewStyle().
-				Bold(true).
-				Foreground(colorHeader)
-
-	previewLabelStyle = lipgloss.NewStyle().
-				Foreground(colorHeaderDim)
-
-	previewBodyStyle = lipgloss.NewStyle().
-				Foreground(colorNormal)
+	previewHeaderStyle lipgloss.Style
+	previewLabelStyle  lipgloss.Style
+	previewBodyStyle   lipgloss.Style
 
 	// Border styles
-	borderStyle = lipgloss.NewStyle().
-			Border(lipgloss.NormalBorder()).
-			BorderForeground(colorBorder)
+	borderStyle lipgloss.Style
 
 	// Help footer style
-	helpStyle = lipgloss.NewStyle().
-			Foreground(colorDim)
+	helpStyle lipgloss.Style
 
 	// Error style
-	errorStyle = lipgloss.NewStyle().
-			Foreground(colorAlert)
+	errorStyle lipgloss.Style
 
 	// Separator line for INFO section
-	separatorStyle = lipgloss.NewStyle().
-			Foreground(colorDim)
+	separatorStyle lipgloss.Style
 
 	// Unread indicator styles
-	unreadStyle = lipgloss.NewStyle().
-			Foreground(colorUnread).
-			Bold(true)
+	unreadStyle lipgloss.Style
+	readStyle   lipgloss.Style
 
-	readStyle = lipgloss.NewStyle().
-			Foreground(colorRead)
+	// Unauthenticated message flag
+	unauthStyle lipgloss.Style
 
 	// Priority styles
-	priorityUrgentStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("9")).Bold(true)
-	priorityHighStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("11"))
-	priorityNormalStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("12"))
-	priorityLowStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+	priorityUrgentStyle lipgloss.Style
+	priorityHighStyle   lipgloss.Style
+	priorityNormalStyle lipgloss.Style
+	priorityLowStyle    lipgloss.Style
+
+	// Diff styles (proposal patches)
+	diffAddStyle  lipgloss.Style
+	diffDelStyle  lipgloss.Style
+	diffHunkStyle lipgloss.Style
+	diffFileStyle lipgloss.Style
+
+	// Syntax highlighting styles for patch code
+	syntaxKeywordStyle lipgloss.Style
+	syntaxStringStyle  lipgloss.Style
+	syntaxNumberStyle  lipgloss.Style
+	syntaxCommentStyle lipgloss.Style
 )
 
+func init() {
+	applyTheme(DarkTheme())
+}
+
 // BadgeStyle returns the appropriate style for a message type badge.
 func BadgeStyle(t MessageType) lipgloss.Style {
 	switch t {
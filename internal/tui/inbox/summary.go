@@ -0,0 +1,226 @@
+package inbox
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// summaryBullets is how many bullets a summary is condensed to.
+const summaryBullets = 3
+
+// summaryTimeout bounds a single summarizer agent invocation.
+const summaryTimeout = 90 * time.Second
+
+// SummarizerConfig configures the agent used by the S (summarize) action.
+// It is read from the "summarizer" section of <town>/config/inbox.json:
+//
+//	{"summarizer": {"agent": "gemini"}}
+//	{"summarizer": {"command": "my-llm --stdin"}}
+type SummarizerConfig struct {
+	// Agent is the agent preset to run non-interactively (default: claude).
+	Agent string `json:"agent,omitempty"`
+
+	// Command is a shell command that reads the prompt on stdin and prints
+	// the summary. Takes precedence over Agent.
+	Command string `json:"command,omitempty"`
+}
+
+// Summarizer condenses messages and threads into short bullet summaries
+// using a configured agent. Summaries are cached per message on disk so
+// each long message only costs one agent call.
+type Summarizer struct {
+	cfg      SummarizerConfig
+	cacheDir string
+}
+
+// NewSummarizer loads the summarizer config for the workspace.
+func NewSummarizer(workDir string) *Summarizer {
+	townRoot, _ := workspace.FindFromCwd()
+	if townRoot == "" {
+		townRoot = workDir
+	}
+
+	s := &Summarizer{
+		cacheDir: filepath.Join(townRoot, constants.DirRuntime, "inbox-summaries"),
+	}
+
+	var file struct {
+		Summarizer SummarizerConfig `json:"summarizer"`
+	}
+	if data, err := os.ReadFile(filepath.Join(townRoot, "config", "inbox.json")); err == nil {
+		_ = json.Unmarshal(data, &file)
+	}
+	s.cfg = file.Summarizer
+	return s
+}
+
+// summaryKey identifies the content being summarized. The hash covers the
+// message bodies so a thread that grows gets a fresh summary.
+func summaryKey(msgs []Message) string {
+	h := sha256.New()
+	for _, m := range msgs {
+		h.Write([]byte(m.ID))
+		h.Write([]byte{0})
+		h.Write([]byte(m.Body))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil)[:12])
+}
+
+// Cached returns a previously generated summary for msgs, if any.
+func (s *Summarizer) Cached(msgs []Message) ([]string, bool) {
+	data, err := os.ReadFile(filepath.Join(s.cacheDir, summaryKey(msgs)+".json"))
+	if err != nil {
+		return nil, false
+	}
+	var bullets []string
+	if err := json.Unmarshal(data, &bullets); err != nil || len(bullets) == 0 {
+		return nil, false
+	}
+	return bullets, true
+}
+
+// Summarize returns a bullet summary of msgs, calling the agent on a cache miss.
+func (s *Summarizer) Summarize(msgs []Message) ([]string, error) {
+	if len(msgs) == 0 {
+		return nil, fmt.Errorf("nothing to summarize")
+	}
+	if bullets, ok := s.Cached(msgs); ok {
+		return bullets, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), summaryTimeout)
+	defer cancel()
+
+	cmd, err := s.command(ctx, buildSummaryPrompt(msgs))
+	if err != nil {
+		return nil, err
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("summarizer failed: %s", firstLine(msg))
+		}
+		return nil, fmt.Errorf("summarizer failed: %w", err)
+	}
+
+	bullets := parseSummaryBullets(stdout.String())
+	if len(bullets) == 0 {
+		return nil, fmt.Errorf("summarizer returned no summary")
+	}
+
+	if err := os.MkdirAll(s.cacheDir, 0755); err == nil {
+		if data, err := json.Marshal(bullets); err == nil {
+			_ = os.WriteFile(filepath.Join(s.cacheDir, summaryKey(msgs)+".json"), data, 0644)
+		}
+	}
+	return bullets, nil
+}
+
+// command builds the summarizer invocation for prompt.
+func (s *Summarizer) command(ctx context.Context, prompt string) (*exec.Cmd, error) {
+	if s.cfg.Command != "" {
+		// Command comes from the town's own config, like other hook commands.
+		cmd := exec.CommandContext(ctx, "sh", "-c", s.cfg.Command) //nolint:gosec // G204: command is from trusted town config
+		cmd.Stdin = strings.NewReader(prompt)
+		return cmd, nil
+	}
+
+	args, err := summarizerArgs(s.cfg.Agent, prompt)
+	if err != nil {
+		return nil, err
+	}
+	return exec.CommandContext(ctx, args[0], args[1:]...), nil //nolint:gosec // G204: binary is from agent preset registry
+}
+
+// summarizerArgs returns the argv for running agent non-interactively.
+func summarizerArgs(agent, prompt string) ([]string, error) {
+	if agent == "" {
+		agent = string(config.DefaultAgentPreset())
+	}
+	preset := config.GetAgentPresetByName(agent)
+	if preset == nil {
+		return nil, fmt.Errorf("unknown summarizer agent %q", agent)
+	}
+
+	args := []string{preset.Command}
+	ni := preset.NonInteractive
+	switch {
+	case preset.Name == config.AgentClaude:
+		args = append(args, "--print", prompt)
+	case ni == nil:
+		return nil, fmt.Errorf("agent %q has no non-interactive mode; set summarizer.command in config/inbox.json", agent)
+	case ni.Subcommand != "":
+		args = append(args, ni.Subcommand, prompt)
+	case ni.PromptFlag != "":
+		args = append(args, ni.PromptFlag, prompt)
+	default:
+		args = append(args, prompt)
+	}
+	return args, nil
+}
+
+// buildSummaryPrompt builds the agent prompt for one message or a thread.
+func buildSummaryPrompt(msgs []Message) string {
+	var b strings.Builder
+	if len(msgs) == 1 {
+		b.WriteString("Summarize the following message")
+	} else {
+		b.WriteString(fmt.Sprintf("Summarize the following thread of %d messages", len(msgs)))
+	}
+	b.WriteString(fmt.Sprintf(" in exactly %d short bullet points, each starting with \"- \". ", summaryBullets))
+	b.WriteString("Lead with what failed or what decision is needed. Output only the bullets.\n\n")
+
+	for _, m := range msgs {
+		b.WriteString(fmt.Sprintf("From: %s\nSubject: %s\n\n%s\n\n---\n\n", m.From, m.Subject, m.Body))
+	}
+	return b.String()
+}
+
+// parseSummaryBullets extracts up to summaryBullets bullet lines from agent
+// output. If the agent ignored the bullet format, the first non-empty lines
+// are used instead.
+func parseSummaryBullets(out string) []string {
+	var bullets, plain []string
+	for _, line := range strings.Split(out, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "```") {
+			continue
+		}
+		trimmed := strings.TrimLeft(line, "-*•· ")
+		if trimmed != line && trimmed != "" {
+			bullets = append(bullets, trimmed)
+		} else {
+			plain = append(plain, line)
+		}
+	}
+	if len(bullets) == 0 {
+		bullets = plain
+	}
+	if len(bullets) > summaryBullets {
+		bullets = bullets[:summaryBullets]
+	}
+	return bullets
+}
+
+func firstLine(s string) string {
+	if idx := strings.IndexByte(s, '\n'); idx >= 0 {
+		return s[:idx]
+	}
+	return s
+}
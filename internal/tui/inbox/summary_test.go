@@ -0,0 +1,86 @@
+package inbox
+
+import (
+	"testing"
+)
+
+func TestParseSummaryBullets(t *testing.T) {
+	tests := []struct {
+		name string
+		out  string
+		want []string
+	}{
+		{"dash bullets", "- one\n- two\n- three\n", []string{"one", "two", "three"}},
+		{"caps at three", "* a\n* b\n* c\n* d", []string{"a", "b", "c"}},
+		{"skips preamble", "Here is the summary:\n\n• x\n• y", []string{"x", "y"}},
+		{"plain fallback", "first line\nsecond line", []string{"first line", "second line"}},
+		{"code fences", "```\n- a\n```", []string{"a"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseSummaryBullets(tt.out)
+			if len(got) != len(tt.want) {
+				t.Fatalf("parseSummaryBullets() = %q, want %q", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("bullet %d = %q, want %q", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSummarizerArgs(t *testing.T) {
+	args, err := summarizerArgs("", "prompt")
+	if err != nil {
+		t.Fatalf("default agent: %v", err)
+	}
+	if args[0] != "claude" || args[1] != "--print" || args[2] != "prompt" {
+		t.Errorf("default agent args = %q", args)
+	}
+
+	args, err = summarizerArgs("gemini", "prompt")
+	if err != nil {
+		t.Fatalf("gemini: %v", err)
+	}
+	if args[0] != "gemini" || args[len(args)-1] != "prompt" {
+		t.Errorf("gemini args = %q", args)
+	}
+
+	if _, err := summarizerArgs("no-such-agent", "prompt"); err == nil {
+		t.Error("expected error for unknown agent")
+	}
+}
+
+func TestSummarizer_CachesSummary(t *testing.T) {
+	s := &Summarizer{
+		cfg:      SummarizerConfig{Command: `cat >/dev/null; printf -- '- a\n- b\n- c\n- d\n'`},
+		cacheDir: t.TempDir(),
+	}
+	msgs := []Message{{ID: "msg-1", From: "refinery", Subject: "Merge failed", Body: "long body"}}
+
+	if _, ok := s.Cached(msgs); ok {
+		t.Fatal("expected no cached summary before summarizing")
+	}
+
+	bullets, err := s.Summarize(msgs)
+	if err != nil {
+		t.Fatalf("Summarize: %v", err)
+	}
+	if len(bullets) != summaryBullets {
+		t.Errorf("expected %d bullets, got %q", summaryBullets, bullets)
+	}
+
+	cached, ok := s.Cached(msgs)
+	if !ok || len(cached) != len(bullets) {
+		t.Errorf("expected cached summary, got %q (ok=%v)", cached, ok)
+	}
+
+	// A changed body is a different summary
+	msgs[0].Body = "edited body"
+	if _, ok := s.Cached(msgs); ok {
+		t.Error("expected cache miss after body changed")
+	}
+}
@@ -0,0 +1,392 @@
+package inbox
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// Theme is the inbox's color palette. Colors are 256-color ANSI codes.
+type Theme struct {
+	// Name is the theme's name in config and --theme.
+	Name string
+
+	// NoColor renders with text attributes only (bold, faint, reverse),
+	// for NO_COLOR and --no-color. The colors are ignored.
+	NoColor bool
+
+	// Message type badges
+	Proposal lipgloss.Color
+	Question lipgloss.Color
+	Alert    lipgloss.Color
+	Info     lipgloss.Color
+
+	// UI
+	Selected     lipgloss.Color // Selection background
+	SelectedText lipgloss.Color // Selection foreground
+	Border       lipgloss.Color
+	Title        lipgloss.Color
+	Dim          lipgloss.Color
+	Normal       lipgloss.Color
+	Unread       lipgloss.Color
+	Read         lipgloss.Color
+	Header       lipgloss.Color
+	HeaderDim    lipgloss.Color
+
+	// Message ages: < 1h, 1h-24h, 1d-3d, > 3d
+	AgeFresh  lipgloss.Color
+	AgeRecent lipgloss.Color
+	AgeOld    lipgloss.Color
+	AgeStale  lipgloss.Color
+
+	// Priorities
+	PriorityUrgent lipgloss.Color
+	PriorityHigh   lipgloss.Color
+	PriorityNormal lipgloss.Color
+	PriorityLow    lipgloss.Color
+
+	// Proposal patches
+	DiffAdd       lipgloss.Color
+	DiffDel       lipgloss.Color
+	DiffHunk      lipgloss.Color
+	SyntaxKeyword lipgloss.Color
+	SyntaxString  lipgloss.Color
+	SyntaxNumber  lipgloss.Color
+}
+
+// DarkTheme is the default theme, for dark terminal backgrounds.
+func DarkTheme() Theme {
+	return Theme{
+		Name:           "dark",
+		Proposal:       "11", // Yellow
+		Question:       "14", // Cyan
+		Alert:          "9",  // Red
+		Info:           "8",  // Gray
+		Selected:       "236",
+		SelectedText:   "15",
+		Border:         "240",
+		Title:          "12", // Blue
+		Dim:            "8",
+		Normal:         "15", // White
+		Unread:         "15",
+		Read:           "8",
+		Header:         "15",
+		HeaderDim:      "8",
+		AgeFresh:       "15",  // Bright white
+		AgeRecent:      "252", // Near white
+		AgeOld:         "245", // Gray
+		AgeStale:       "240", // Dark gray
+		PriorityUrgent: "9",
+		PriorityHigh:   "11",
+		PriorityNormal: "12",
+		PriorityLow:    "8",
+		DiffAdd:        "10",
+		DiffDel:        "9",
+		DiffHunk:       "14",
+		SyntaxKeyword:  "13",
+		SyntaxString:   "11",
+		SyntaxNumber:   "12",
+	}
+}
+
+// LightTheme is for light terminal backgrounds: dark text, and darker
+// shades of the dark theme's hues.
+func LightTheme() Theme {
+	return Theme{
+		Name:           "light",
+		Proposal:       "136", // Dark yellow
+		Question:       "30",  // Teal
+		Alert:          "160", // Red
+		Info:           "244", // Gray
+		Selected:       "254",
+		SelectedText:   "232",
+		Border:         "250",
+		Title:          "25", // Blue
+		Dim:            "244",
+		Normal:         "235", // Near black
+		Unread:         "232",
+		Read:           "244",
+		Header:         "232",
+		HeaderDim:      "243",
+		AgeFresh:       "232", // Black
+		AgeRecent:      "237",
+		AgeOld:         "243",
+		AgeStale:       "248", // Light gray
+		PriorityUrgent: "160",
+		PriorityHigh:   "136",
+		PriorityNormal: "25",
+		PriorityLow:    "244",
+		DiffAdd:        "28",
+		DiffDel:        "160",
+		DiffHunk:       "30",
+		SyntaxKeyword:  "90",
+		SyntaxString:   "136",
+		SyntaxNumber:   "25",
+	}
+}
+
+// HighContrastTheme uses only bright colors on black, with an inverted
+// selection, for low-vision use and washed-out displays.
+func HighContrastTheme() Theme {
+	return Theme{
+		Name:           "high-contrast",
+		Proposal:       "226", // Bright yellow
+		Question:       "51",  // Bright cyan
+		Alert:          "196", // Bright red
+		Info:           "15",
+		Selected:       "15",
+		SelectedText:   "0",
+		Border:         "15",
+		Title:          "51",
+		Dim:            "250",
+		Normal:         "15",
+		Unread:         "15",
+		Read:           "250",
+		Header:         "15",
+		HeaderDim:      "250",
+		AgeFresh:       "15",
+		AgeRecent:      "15",
+		AgeOld:         "250",
+		AgeStale:       "247",
+		PriorityUrgent: "196",
+		PriorityHigh:   "226",
+		PriorityNormal: "51",
+		PriorityLow:    "250",
+		DiffAdd:        "46",
+		DiffDel:        "196",
+		DiffHunk:       "51",
+		SyntaxKeyword:  "201",
+		SyntaxString:   "226",
+		SyntaxNumber:   "51",
+	}
+}
+
+// ThemeNames lists the built-in themes.
+var ThemeNames = []string{"dark", "light", "high-contrast"}
+
+// ParseTheme returns the built-in theme with the given name.
+func ParseTheme(name string) (Theme, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "dark", "":
+		return DarkTheme(), nil
+	case "light":
+		return LightTheme(), nil
+	case "high-contrast", "highcontrast", "contrast":
+		return HighContrastTheme(), nil
+	}
+	return Theme{}, fmt.Errorf("invalid theme %q (want %s)", name, strings.Join(ThemeNames, ", "))
+}
+
+// WithoutColor returns the theme rendered with text attributes only.
+func (t Theme) WithoutColor() Theme {
+	t.NoColor = true
+	return t
+}
+
+// noColorEnv reports whether the environment turns color off: NO_COLOR
+// (https://no-color.org/) with any value, or CLICOLOR=0.
+func noColorEnv() bool {
+	if _, exists := os.LookupEnv("NO_COLOR"); exists {
+		return true
+	}
+	return os.Getenv("CLICOLOR") == "0"
+}
+
+// loadTheme reads "theme" and "no_color" from <town>/config/inbox.json,
+// falling back to the dark theme for an unset or unknown theme. NO_COLOR
+// or CLICOLOR=0 in the environment turns color off whatever the config
+// says.
+func loadTheme(workDir string) Theme {
+	townRoot, _ := workspace.FindFromCwd()
+	if townRoot == "" {
+		townRoot = workDir
+	}
+
+	var file struct {
+		Theme   string `json:"theme"`
+		NoColor bool   `json:"no_color"`
+	}
+	if data, err := os.ReadFile(filepath.Join(townRoot, "config", "inbox.json")); err == nil {
+		_ = json.Unmarshal(data, &file)
+	}
+
+	theme, err := ParseTheme(file.Theme)
+	if err != nil {
+		theme = DarkTheme()
+	}
+	if file.NoColor || noColorEnv() {
+		theme = theme.WithoutColor()
+	}
+	return theme
+}
+
+// SetTheme overrides the configured theme.
+func (m *Model) SetTheme(t Theme) {
+	m.theme = t
+	applyTheme(t)
+	m.applyComponentTheme(t)
+}
+
+// applyComponentTheme styles the help, reply and palette components.
+// Their defaults carry colors of their own, so without color they get
+// attribute-only styles; with color they go back to the defaults.
+func (m *Model) applyComponentTheme(t Theme) {
+	if !t.NoColor {
+		m.help.Styles = help.New().Styles
+		m.replyInput.FocusedStyle, m.replyInput.BlurredStyle = textarea.DefaultStyles()
+		defaults := textinput.New()
+		m.paletteInput.PlaceholderStyle = defaults.PlaceholderStyle
+		m.paletteInput.CompletionStyle = defaults.CompletionStyle
+		return
+	}
+
+	plain := lipgloss.NewStyle()
+	faint := plain.Faint(true)
+
+	m.help.Styles = help.Styles{
+		Ellipsis:       faint,
+		ShortKey:       plain,
+		ShortDesc:      faint,
+		ShortSeparator: faint,
+		FullKey:        plain,
+		FullDesc:       faint,
+		FullSeparator:  faint,
+	}
+
+	reply := textarea.Style{
+		Base:             plain,
+		CursorLine:       plain,
+		CursorLineNumber: plain,
+		EndOfBuffer:      faint,
+		LineNumber:       faint,
+		Placeholder:      faint,
+		Prompt:           plain,
+		Text:             plain,
+	}
+	m.replyInput.FocusedStyle = reply
+	m.replyInput.BlurredStyle = reply
+
+	m.paletteInput.PlaceholderStyle = faint
+	m.paletteInput.CompletionStyle = faint
+}
+
+// Theme returns the model's theme, for callers that override part of it.
+func (m *Model) Theme() Theme {
+	return m.theme
+}
+
+// applyTheme rebuilds the inbox styles from a theme. Without color, the
+// styles keep what sets messages apart (selection, badge and age
+// emphasis) with bold, faint, underline and reverse video. The terminal's
+// color profile is left alone so those attributes still render.
+func applyTheme(t Theme) {
+	if t.NoColor {
+		applyNoColorStyles()
+		return
+	}
+
+	titleStyle = lipgloss.NewStyle().Bold(true).Foreground(t.Title)
+
+	ageFreshStyle = lipgloss.NewStyle().Foreground(t.AgeFresh)
+	ageRecentStyle = lipgloss.NewStyle().Foreground(t.AgeRecent)
+	ageOldStyle = lipgloss.NewStyle().Foreground(t.AgeOld)
+	ageStaleStyle = lipgloss.NewStyle().Foreground(t.AgeStale)
+
+	selectedStyle = lipgloss.NewStyle().Background(t.Selected).Foreground(t.SelectedText)
+	messageStyle = lipgloss.NewStyle().Foreground(t.Normal)
+	dimStyle = lipgloss.NewStyle().Foreground(t.Dim)
+
+	proposalBadgeStyle = lipgloss.NewStyle().Foreground(t.Proposal)
+	questionBadgeStyle = lipgloss.NewStyle().Foreground(t.Question)
+	alertBadgeStyle = lipgloss.NewStyle().Foreground(t.Alert).Bold(true)
+	infoBadgeStyle = lipgloss.NewStyle().Foreground(t.Info)
+
+	previewHeaderStyle = lipgloss.NewStyle().Bold(true).Foreground(t.Header)
+	previewLabelStyle = lipgloss.NewStyle().Foreground(t.HeaderDim)
+	previewBodyStyle = lipgloss.NewStyle().Foreground(t.Normal)
+
+	borderStyle = lipgloss.NewStyle().Border(lipgloss.NormalBorder()).BorderForeground(t.Border)
+	helpStyle = lipgloss.NewStyle().Foreground(t.Dim)
+	errorStyle = lipgloss.NewStyle().Foreground(t.Alert)
+	separatorStyle = lipgloss.NewStyle().Foreground(t.Dim)
+
+	unreadStyle = lipgloss.NewStyle().Foreground(t.Unread).Bold(true)
+	readStyle = lipgloss.NewStyle().Foreground(t.Read)
+	unauthStyle = lipgloss.NewStyle().Foreground(t.Alert).Bold(true)
+
+	priorityUrgentStyle = lipgloss.NewStyle().Foreground(t.PriorityUrgent).Bold(true)
+	priorityHighStyle = lipgloss.NewStyle().Foreground(t.PriorityHigh)
+	priorityNormalStyle = lipgloss.NewStyle().Foreground(t.PriorityNormal)
+	priorityLowStyle = lipgloss.NewStyle().Foreground(t.PriorityLow)
+
+	diffAddStyle = lipgloss.NewStyle().Foreground(t.DiffAdd)
+	diffDelStyle = lipgloss.NewStyle().Foreground(t.DiffDel)
+	diffHunkStyle = lipgloss.NewStyle().Foreground(t.DiffHunk)
+	diffFileStyle = lipgloss.NewStyle().Bold(true).Foreground(t.Header)
+
+	syntaxKeywordStyle = lipgloss.NewStyle().Foreground(t.SyntaxKeyword)
+	syntaxStringStyle = lipgloss.NewStyle().Foreground(t.SyntaxString)
+	syntaxNumberStyle = lipgloss.NewStyle().Foreground(t.SyntaxNumber)
+	syntaxCommentStyle = lipgloss.NewStyle().Foreground(t.Dim).Italic(true)
+}
+
+// applyNoColorStyles sets the attribute-only styles.
+func applyNoColorStyles() {
+	plain := lipgloss.NewStyle()
+	bold := plain.Bold(true)
+	faint := plain.Faint(true)
+
+	titleStyle = bold
+
+	// Newer messages stand out, older ones recede
+	ageFreshStyle = bold
+	ageRecentStyle = plain
+	ageOldStyle = faint
+	ageStaleStyle = faint
+
+	selectedStyle = plain.Reverse(true)
+	messageStyle = plain
+	dimStyle = faint
+
+	// Badges carry their type letter; emphasis follows urgency
+	proposalBadgeStyle = bold
+	questionBadgeStyle = plain.Underline(true)
+	alertBadgeStyle = bold.Reverse(true)
+	infoBadgeStyle = faint
+
+	previewHeaderStyle = bold
+	previewLabelStyle = faint
+	previewBodyStyle = plain
+
+	borderStyle = plain.Border(lipgloss.NormalBorder())
+	helpStyle = faint
+	errorStyle = bold
+	separatorStyle = faint
+
+	unreadStyle = bold
+	readStyle = faint
+	unauthStyle = bold.Reverse(true)
+
+	priorityUrgentStyle = bold.Reverse(true)
+	priorityHighStyle = bold
+	priorityNormalStyle = plain
+	priorityLowStyle = faint
+
+	diffAddStyle = plain
+	diffDelStyle = plain
+	diffHunkStyle = faint
+	diffFileStyle = bold
+
+	syntaxKeywordStyle = plain
+	syntaxStringStyle = plain
+	syntaxNumberStyle = plain
+	syntaxCommentStyle = faint.Italic(true)
+}
@@ -0,0 +1,150 @@
+package inbox
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+func TestParseTheme(t *testing.T) {
+	for name, want := range map[string]string{
+		"":              "dark",
+		"dark":          "dark",
+		"Light":         "light",
+		"high-contrast": "high-contrast",
+		"contrast":      "high-contrast",
+	} {
+		got, err := ParseTheme(name)
+		if err != nil || got.Name != want {
+			t.Errorf("ParseTheme(%q) = %q, %v; want %q", name, got.Name, err, want)
+		}
+	}
+	if _, err := ParseTheme("solarized"); err == nil {
+		t.Error("expected error for unknown theme")
+	}
+}
+
+func TestApplyThemeNoColor(t *testing.T) {
+	profile := lipgloss.ColorProfile()
+	t.Cleanup(func() {
+		lipgloss.SetColorProfile(profile)
+		applyTheme(DarkTheme())
+	})
+
+	lipgloss.SetColorProfile(termenv.ANSI256)
+	applyTheme(LightTheme().WithoutColor())
+	if lipgloss.ColorProfile() != termenv.ANSI256 {
+		t.Errorf("color profile = %v, want the terminal's profile kept", lipgloss.ColorProfile())
+	}
+
+	now := time.Now()
+	styles := map[string]lipgloss.Style{
+		"proposal badge": BadgeStyle(TypeProposal),
+		"question badge": BadgeStyle(TypeQuestion),
+		"alert badge":    BadgeStyle(TypeAlert),
+		"info badge":     BadgeStyle(TypeInfo),
+		"fresh age":      AgeStyle(now),
+		"recent age":     AgeStyle(now.Add(-5 * time.Hour)),
+		"old age":        AgeStyle(now.Add(-48 * time.Hour)),
+		"stale age":      AgeStyle(now.Add(-5 * 24 * time.Hour)),
+		"selected":       selectedStyle,
+	}
+	for name, style := range styles {
+		if _, ok := style.GetForeground().(lipgloss.NoColor); !ok {
+			t.Errorf("%s: foreground = %v, want none", name, style.GetForeground())
+		}
+		if _, ok := style.GetBackground().(lipgloss.NoColor); !ok {
+			t.Errorf("%s: background = %v, want none", name, style.GetBackground())
+		}
+	}
+
+	// Attributes stand in for the colors, and still reach the terminal
+	selected := selectedStyle.Render("Subject")
+	if !strings.Contains(selected, "\x1b[7m") {
+		t.Errorf("selected row = %q, want reverse video", selected)
+	}
+	if strings.Contains(selected, "38;5;") || strings.Contains(selected, "48;5;") {
+		t.Errorf("selected row = %q, want no color", selected)
+	}
+	if !BadgeStyle(TypeAlert).GetBold() || !BadgeStyle(TypeInfo).GetFaint() {
+		t.Error("alert badges should be bold and info badges faint")
+	}
+	if !AgeStyle(now).GetBold() || !AgeStyle(now.Add(-5*24*time.Hour)).GetFaint() {
+		t.Error("fresh messages should be bold and stale ones faint")
+	}
+
+	// Switching back restores the colors
+	applyTheme(DarkTheme())
+	if BadgeStyle(TypeAlert).GetForeground() != DarkTheme().Alert {
+		t.Errorf("alert badge = %v after dark theme, want %v", BadgeStyle(TypeAlert).GetForeground(), DarkTheme().Alert)
+	}
+}
+
+func TestSetThemeNoColorComponents(t *testing.T) {
+	profile := lipgloss.ColorProfile()
+	t.Cleanup(func() {
+		lipgloss.SetColorProfile(profile)
+		applyTheme(DarkTheme())
+	})
+	lipgloss.SetColorProfile(termenv.ANSI256)
+
+	m := New("mayor/", t.TempDir())
+	m.SetTheme(DarkTheme().WithoutColor())
+	m.help.Width = 200
+	helpView := m.help.View(m.keys)
+	if strings.Contains(helpView, "38;5;") || strings.Contains(helpView, "38;2;") {
+		t.Errorf("help = %q, want no color", helpView)
+	}
+	if !strings.Contains(helpView, "\x1b[2m") {
+		t.Errorf("help = %q, want faint descriptions", helpView)
+	}
+
+	m.SetTheme(DarkTheme())
+	if _, ok := m.help.Styles.ShortKey.GetForeground().(lipgloss.NoColor); ok {
+		t.Error("help keys should get their color back with the dark theme")
+	}
+}
+
+func TestLoadTheme(t *testing.T) {
+	townRoot := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(townRoot, "config"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeConfig := func(config string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(townRoot, "config", "inbox.json"), []byte(config), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Chdir(townRoot)
+	t.Setenv("NO_COLOR", "")
+	os.Unsetenv("NO_COLOR")
+	t.Setenv("CLICOLOR", "")
+
+	writeConfig(`{"theme": "light"}`)
+	if theme := loadTheme(townRoot); theme.Name != "light" || theme.NoColor {
+		t.Errorf("theme = %q (no color %v), want light with color", theme.Name, theme.NoColor)
+	}
+
+	writeConfig(`{"theme": "bogus"}`)
+	if theme := loadTheme(townRoot); theme.Name != "dark" {
+		t.Errorf("unknown theme should fall back to dark, got %q", theme.Name)
+	}
+
+	writeConfig(`{"theme": "high-contrast", "no_color": true}`)
+	if theme := loadTheme(townRoot); theme.Name != "high-contrast" || !theme.NoColor {
+		t.Errorf("theme = %q (no color %v), want high-contrast without color", theme.Name, theme.NoColor)
+	}
+
+	// NO_COLOR wins over the config, whatever its value
+	writeConfig(`{"theme": "light"}`)
+	t.Setenv("NO_COLOR", "")
+	if theme := loadTheme(townRoot); !theme.NoColor {
+		t.Error("NO_COLOR should turn color off")
+	}
+}
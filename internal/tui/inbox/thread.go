@@ -0,0 +1,208 @@
+package inbox
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// collapsedBodyLines is how many body lines a thread message shows until
+// it is expanded.
+const collapsedBodyLines = 3
+
+// threadHeaderLines and threadFooterLines frame the thread viewport.
+const (
+	threadHeaderLines = 3
+	threadFooterLines = 2
+)
+
+// openThread shows msgs in the thread view, selecting the newest message.
+func (m *Model) openThread(msgs []Message) {
+	m.threadMessages = msgs
+	m.threadExpanded = make(map[string]bool)
+	m.threadCursor = len(msgs) - 1
+	m.mode = ModeThread
+	m.syncThreadViewport()
+	m.threadViewport.GotoBottom()
+}
+
+// closeThread leaves the thread view for the list.
+func (m *Model) closeThread() {
+	m.mode = ModeList
+	m.threadMessages = nil
+	m.threadExpanded = nil
+	m.threadCursor = 0
+	m.threadViewport.SetYOffset(0)
+}
+
+// syncThreadViewport sizes the thread viewport to the window and renders
+// the thread into it.
+func (m *Model) syncThreadViewport() {
+	m.threadViewport.Width = m.width - 2
+	m.threadViewport.Height = max(m.height-threadHeaderLines-threadFooterLines, 1)
+	content, _ := m.threadContent()
+	m.threadViewport.SetContent(content)
+}
+
+// threadContent renders the thread's summary and messages (oldest first)
+// for the viewport. starts holds the first line of each message.
+func (m Model) threadContent() (string, []int) {
+	var lines []string
+	starts := make([]int, len(m.threadMessages))
+
+	// Whole-thread summary (S action)
+	if len(m.threadMessages) > 0 {
+		summary := m.renderSummaryLines(threadSummaryKey(m.threadMessages[0].ThreadID), m.width-2)
+		lines = append(lines, summary...)
+		if len(summary) > 0 {
+			lines = append(lines, "")
+		}
+	}
+
+	for i, msg := range m.threadMessages {
+		starts[i] = len(lines)
+
+		// Message header: From and timestamp, with the cursor
+		marker := "  "
+		if i == m.threadCursor {
+			marker = selectedStyle.Render("▸") + " "
+		}
+		header := fmt.Sprintf("%s  %s", msg.From, dimStyle.Render(m.timeFormat.Detail(msg.Timestamp)))
+		lines = append(lines, marker+previewLabelStyle.Render(header))
+
+		// Message body, collapsed to a few lines until expanded
+		bodyLines := wrapText(msg.Body, m.width-6)
+		shown := bodyLines
+		if !m.threadExpanded[msg.ID] && len(bodyLines) > collapsedBodyLines {
+			shown = bodyLines[:collapsedBodyLines]
+		}
+		for _, line := range shown {
+			lines = append(lines, "    "+line)
+		}
+		if hidden := len(bodyLines) - len(shown); hidden > 0 {
+			lines = append(lines, dimStyle.Render(fmt.Sprintf("    ... %d more lines (enter to expand)", hidden)))
+		}
+
+		// Separator between messages
+		if i < len(m.threadMessages)-1 {
+			lines = append(lines, "")
+		}
+	}
+	return strings.Join(lines, "\n"), starts
+}
+
+// moveThreadCursor selects the message delta away from the current one
+// and scrolls it into view.
+func (m *Model) moveThreadCursor(delta int) {
+	m.threadCursor = min(max(m.threadCursor+delta, 0), len(m.threadMessages)-1)
+	m.syncThreadViewport()
+	m.scrollToThreadCursor()
+}
+
+// scrollToThreadCursor scrolls the viewport so the selected message is
+// visible, showing as much of it as fits.
+func (m *Model) scrollToThreadCursor() {
+	_, starts := m.threadContent()
+	if m.threadCursor < 0 || m.threadCursor >= len(starts) {
+		return
+	}
+	start := starts[m.threadCursor]
+	end := m.threadViewport.TotalLineCount()
+	if m.threadCursor+1 < len(starts) {
+		end = starts[m.threadCursor+1]
+	}
+
+	vp := &m.threadViewport
+	switch {
+	case start < vp.YOffset:
+		vp.SetYOffset(start)
+	case end > vp.YOffset+vp.Height:
+		vp.SetYOffset(min(start, end-vp.Height))
+	}
+}
+
+// updateThreadMode handles key input in thread mode.
+func (m Model) updateThreadMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Quit), msg.Type == tea.KeyEsc:
+		// Exit thread view back to list
+		m.closeThread()
+		return m, nil
+
+	case key.Matches(msg, m.keys.Up):
+		m.moveThreadCursor(-1)
+		return m, nil
+
+	case key.Matches(msg, m.keys.Down):
+		m.moveThreadCursor(1)
+		return m, nil
+
+	case key.Matches(msg, m.keys.Open):
+		// enter - expand or collapse the selected message
+		if m.threadCursor >= 0 && m.threadCursor < len(m.threadMessages) {
+			id := m.threadMessages[m.threadCursor].ID
+			m.threadExpanded[id] = !m.threadExpanded[id]
+			m.syncThreadViewport()
+			m.scrollToThreadCursor()
+		}
+		return m, nil
+
+	case key.Matches(msg, m.keys.PageUp):
+		m.threadViewport.HalfViewUp()
+		return m, nil
+
+	case key.Matches(msg, m.keys.PageDown):
+		m.threadViewport.HalfViewDown()
+		return m, nil
+
+	case key.Matches(msg, m.keys.Top):
+		// g - jump to the oldest message
+		m.threadCursor = 0
+		m.syncThreadViewport()
+		m.threadViewport.GotoTop()
+		return m, nil
+
+	case key.Matches(msg, m.keys.Bottom):
+		// G - jump to the newest message
+		m.threadCursor = len(m.threadMessages) - 1
+		m.syncThreadViewport()
+		m.threadViewport.GotoBottom()
+		return m, nil
+
+	case key.Matches(msg, m.keys.Reply), key.Matches(msg, m.keys.ReplyAll):
+		// R - reply to thread (reply to original message), W - reply to all
+		if len(m.threadMessages) > 0 {
+			// Reply to the first message in thread (the original)
+			original := m.threadMessages[0]
+			m.mode = ModeReply
+			m.replyingTo = &original
+			m.replyAll = key.Matches(msg, m.keys.ReplyAll)
+			m.replyInput.Reset()
+			m.replyInput.Focus()
+		}
+		return m, nil
+
+	case key.Matches(msg, m.keys.Summarize):
+		// S - summarize the whole thread
+		if len(m.threadMessages) > 0 {
+			return m.startSummary(threadSummaryKey(m.threadMessages[0].ThreadID), m.threadMessages)
+		}
+		return m, nil
+
+	case key.Matches(msg, m.keys.Export):
+		// E - export the whole thread as markdown
+		if len(m.threadMessages) > 0 {
+			return m, m.doExport(m.threadMessages)
+		}
+		return m, nil
+
+	case key.Matches(msg, m.keys.Reload):
+		// r - reload messages
+		m.loading = true
+		return m, m.fetchMessages
+	}
+
+	return m, nil
+}
@@ -0,0 +1,75 @@
+package inbox
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// threadModel returns a model showing a thread of n messages with ten-line
+// bodies in a 20-line window.
+func threadModel(t *testing.T, n int) Model {
+	t.Helper()
+	m := New("mayor/", t.TempDir())
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 80, Height: 20})
+	m = updated.(Model)
+
+	var msgs []Message
+	for i := 0; i < n; i++ {
+		var body []string
+		for j := 0; j < 10; j++ {
+			body = append(body, fmt.Sprintf("msg%d line%d", i, j))
+		}
+		msgs = append(msgs, Message{ID: fmt.Sprintf("m%d", i), From: "gastown/refinery", ThreadID: "t1", Body: strings.Join(body, "\n")})
+	}
+	updated, _ = m.Update(threadLoadedMsg{messages: msgs})
+	return updated.(Model)
+}
+
+func pressKey(m Model, k tea.KeyMsg) Model {
+	updated, _ := m.Update(k)
+	return updated.(Model)
+}
+
+func TestThreadView_ExpandAndScroll(t *testing.T) {
+	m := threadModel(t, 5)
+	if m.mode != ModeThread || m.threadCursor != 4 || !m.threadViewport.AtBottom() {
+		t.Fatalf("thread opened with cursor %d, at bottom %v", m.threadCursor, m.threadViewport.AtBottom())
+	}
+
+	// Bodies are collapsed until expanded with enter
+	if view := m.View(); strings.Contains(view, "msg4 line9") || !strings.Contains(view, "7 more lines") {
+		t.Errorf("collapsed view:\n%s", view)
+	}
+	m = pressKey(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if !m.threadExpanded["m4"] || !strings.Contains(m.View(), "msg4 line9") {
+		t.Errorf("enter did not expand the message:\n%s", m.View())
+	}
+
+	// Jump to the oldest message; moving down keeps the selection visible
+	m = pressKey(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("g")})
+	if m.threadCursor != 0 || !m.threadViewport.AtTop() || !strings.Contains(m.View(), "msg0 line0") {
+		t.Errorf("g: cursor %d, offset %d", m.threadCursor, m.threadViewport.YOffset)
+	}
+	for i := 0; i < 3; i++ {
+		m = pressKey(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	}
+	if m.threadCursor != 3 || !strings.Contains(m.View(), "msg3 line0") {
+		t.Errorf("j: cursor %d, view:\n%s", m.threadCursor, m.View())
+	}
+
+	m = pressKey(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("G")})
+	if m.threadCursor != 4 || !strings.Contains(m.View(), "msg4 line9") {
+		t.Errorf("G: cursor %d", m.threadCursor)
+	}
+	if lines := strings.Count(m.View(), "\n") + 1; lines != 20 {
+		t.Errorf("view is %d lines, want the window height 20", lines)
+	}
+
+	m = pressKey(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.mode != ModeList || m.threadMessages != nil {
+		t.Errorf("esc: mode %v", m.mode)
+	}
+}
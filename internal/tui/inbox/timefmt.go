@@ -0,0 +1,255 @@
+package inbox
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// Time display modes.
+const (
+	// TimeRelative shows message ages such as "3h" (default).
+	TimeRelative = "relative"
+
+	// TimeAbsolute shows the time a message was sent in the display
+	// time zone: the clock time for today's messages, the date otherwise.
+	TimeAbsolute = "absolute"
+)
+
+// isoDateLayout is the date layout used when no locale is set.
+const isoDateLayout = "2006-01-02"
+
+// localeDateLayouts maps locales (BCP 47 tags or bare languages) to their
+// customary numeric date layout. Lookups try the full tag, then the language.
+var localeDateLayouts = map[string]string{
+	"en-US": "Jan 2, 2006",
+	"en":    "2 Jan 2006",
+	"de":    "02.01.2006",
+	"fr":    "02/01/2006",
+	"es":    "02/01/2006",
+	"it":    "02/01/2006",
+	"pt":    "02/01/2006",
+	"nl":    "02-01-2006",
+	"sv":    isoDateLayout,
+	"pl":    "02.01.2006",
+	"ru":    "02.01.2006",
+	"ja":    "2006/01/02",
+	"zh":    "2006/01/02",
+	"ko":    "2006. 01. 02.",
+}
+
+// TimeFormat controls how message times are displayed. The zero value
+// shows relative ages with 24-hour, ISO-dated timestamps in the local zone.
+type TimeFormat struct {
+	// Mode is TimeRelative or TimeAbsolute.
+	Mode string
+
+	// Location is the display time zone (nil means local).
+	Location *time.Location
+
+	// Clock12 uses a 12-hour clock ("3:04 PM") instead of 24-hour.
+	Clock12 bool
+
+	// DateLayout is the Go date layout (empty means ISO).
+	DateLayout string
+}
+
+// timeConfig is the "time" section of <town>/config/inbox.json.
+type timeConfig struct {
+	Display  string `json:"display"`  // relative or absolute
+	Timezone string `json:"timezone"` // IANA name, "UTC" or "local"
+	Clock    string `json:"clock"`    // 12h or 24h
+	Locale   string `json:"locale"`   // e.g. en-US, de-DE; default from $LC_TIME/$LANG
+}
+
+// ParseTimeMode validates a time display mode.
+func ParseTimeMode(s string) (string, error) {
+	switch mode := strings.ToLower(strings.TrimSpace(s)); mode {
+	case TimeRelative, TimeAbsolute:
+		return mode, nil
+	}
+	return "", fmt.Errorf("invalid time display %q (want relative or absolute)", s)
+}
+
+// ParseTimezone loads a display time zone: an IANA name such as
+// "Europe/Berlin", "UTC", or "local".
+func ParseTimezone(s string) (*time.Location, error) {
+	if s == "" || strings.EqualFold(s, "local") {
+		return time.Local, nil
+	}
+	loc, err := time.LoadLocation(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone %q (want an IANA name like Europe/Berlin, UTC, or local)", s)
+	}
+	return loc, nil
+}
+
+// NewTimeFormat builds a time format for a locale such as "en-US",
+// "de_DE.UTF-8" or "" (ISO dates). The locale sets the date layout and
+// whether the clock defaults to 12-hour.
+func NewTimeFormat(locale string) TimeFormat {
+	tag := normalizeLocale(locale)
+	f := TimeFormat{Mode: TimeRelative, Location: time.Local}
+	if layout, ok := localeDateLayouts[tag]; ok {
+		f.DateLayout = layout
+	} else if lang, _, _ := strings.Cut(tag, "-"); localeDateLayouts[lang] != "" {
+		f.DateLayout = localeDateLayouts[lang]
+	}
+	f.Clock12 = tag == "en-US"
+	return f
+}
+
+// normalizeLocale turns POSIX locale names like "de_DE.UTF-8" into
+// BCP 47 tags like "de-DE". "C" and "POSIX" yield "".
+func normalizeLocale(locale string) string {
+	locale, _, _ = strings.Cut(locale, ".")
+	locale, _, _ = strings.Cut(locale, "@")
+	if locale == "C" || locale == "POSIX" {
+		return ""
+	}
+	lang, region, _ := strings.Cut(strings.ReplaceAll(locale, "_", "-"), "-")
+	if region == "" {
+		return strings.ToLower(lang)
+	}
+	return strings.ToLower(lang) + "-" + strings.ToUpper(region)
+}
+
+// envLocale returns the locale for time formatting from the environment.
+func envLocale() string {
+	for _, key := range []string{"LC_ALL", "LC_TIME", "LANG"} {
+		if v := os.Getenv(key); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// loadTimeFormat reads the "time" section of <town>/config/inbox.json,
+// falling back to relative ages in the environment's locale for unset or
+// invalid fields.
+func loadTimeFormat(workDir string) TimeFormat {
+	townRoot, _ := workspace.FindFromCwd()
+	if townRoot == "" {
+		townRoot = workDir
+	}
+
+	var file struct {
+		Time timeConfig `json:"time"`
+	}
+	if data, err := os.ReadFile(filepath.Join(townRoot, "config", "inbox.json")); err == nil {
+		_ = json.Unmarshal(data, &file)
+	}
+
+	locale := file.Time.Locale
+	if locale == "" {
+		locale = envLocale()
+	}
+	f := NewTimeFormat(locale)
+	if mode, err := ParseTimeMode(file.Time.Display); err == nil {
+		f.Mode = mode
+	}
+	if loc, err := ParseTimezone(file.Time.Timezone); err == nil {
+		f.Location = loc
+	}
+	switch strings.ToLower(file.Time.Clock) {
+	case "12h":
+		f.Clock12 = true
+	case "24h":
+		f.Clock12 = false
+	}
+	return f
+}
+
+// SetTimeFormat overrides the configured time format.
+func (m *Model) SetTimeFormat(f TimeFormat) {
+	m.timeFormat = f
+}
+
+// TimeFormat returns the model's time format, for callers that override
+// part of it.
+func (m *Model) TimeFormat() TimeFormat {
+	return m.timeFormat
+}
+
+// in converts t to the display time zone.
+func (f TimeFormat) in(t time.Time) time.Time {
+	if f.Location == nil {
+		return t.Local()
+	}
+	return t.In(f.Location)
+}
+
+// clockLayout returns the layout for clock times.
+func (f TimeFormat) clockLayout(seconds bool) string {
+	switch {
+	case f.Clock12 && seconds:
+		return "3:04:05 PM"
+	case f.Clock12:
+		return "3:04 PM"
+	case seconds:
+		return "15:04:05"
+	default:
+		return "15:04"
+	}
+}
+
+// dateLayout returns the layout for dates.
+func (f TimeFormat) dateLayout() string {
+	if f.DateLayout == "" {
+		return isoDateLayout
+	}
+	return f.DateLayout
+}
+
+// Age renders t for the message list: an age like "3h" in relative mode,
+// or the clock time (today) or date (earlier) in absolute mode.
+func (f TimeFormat) Age(t time.Time) string {
+	if f.Mode != TimeAbsolute {
+		return relativeAge(time.Since(t))
+	}
+	local := f.in(t)
+	now := f.in(time.Now())
+	if local.YearDay() == now.YearDay() && local.Year() == now.Year() {
+		return local.Format(f.clockLayout(false))
+	}
+	return local.Format(f.dateLayout())
+}
+
+// AgeWidth returns the column width that fits any Age.
+func (f TimeFormat) AgeWidth() int {
+	if f.Mode != TimeAbsolute {
+		return 4
+	}
+	// A late-December evening is the widest date and clock time
+	ref := time.Date(2006, 12, 28, 22, 44, 0, 0, time.UTC)
+	width := len(ref.Format(f.dateLayout()))
+	if w := len(ref.Format(f.clockLayout(false))); w > width {
+		width = w
+	}
+	return width
+}
+
+// Stamp renders t in full, with the date, clock time and zone, e.g.
+// "2026-10-16 14:05 CEST".
+func (f TimeFormat) Stamp(t time.Time) string {
+	return f.in(t).Format(f.dateLayout() + " " + f.clockLayout(false) + " MST")
+}
+
+// Detail renders t for message headers: the full Stamp, followed by the
+// age in relative mode, e.g. "2026-10-16 14:05 CEST (3h ago)".
+func (f TimeFormat) Detail(t time.Time) string {
+	if f.Mode == TimeAbsolute {
+		return f.Stamp(t)
+	}
+	return fmt.Sprintf("%s (%s ago)", f.Stamp(t), relativeAge(time.Since(t)))
+}
+
+// Clock renders the time of day of t with seconds, e.g. "14:05:09".
+func (f TimeFormat) Clock(t time.Time) string {
+	return f.in(t).Format(f.clockLayout(true))
+}
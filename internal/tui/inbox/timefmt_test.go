@@ -0,0 +1,97 @@
+package inbox
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewTimeFormat_Locale(t *testing.T) {
+	sent := time.Date(2026, 3, 7, 15, 4, 0, 0, time.UTC)
+	tests := []struct {
+		locale  string
+		want    string
+		clock12 bool
+	}{
+		{"", "2026-03-07", false},
+		{"C", "2026-03-07", false},
+		{"en_US.UTF-8", "Mar 7, 2026", true},
+		{"en-GB", "7 Mar 2026", false},
+		{"de_DE.UTF-8", "07.03.2026", false},
+		{"fr", "07/03/2026", false},
+		{"ja_JP", "2026/03/07", false},
+		{"xx-YY", "2026-03-07", false},
+	}
+
+	for _, tt := range tests {
+		f := NewTimeFormat(tt.locale)
+		if got := sent.Format(f.dateLayout()); got != tt.want {
+			t.Errorf("NewTimeFormat(%q) date = %q, want %q", tt.locale, got, tt.want)
+		}
+		if f.Clock12 != tt.clock12 {
+			t.Errorf("NewTimeFormat(%q).Clock12 = %v, want %v", tt.locale, f.Clock12, tt.clock12)
+		}
+	}
+}
+
+func TestTimeFormat_Absolute(t *testing.T) {
+	berlin, err := ParseTimezone("Europe/Berlin")
+	if err != nil {
+		t.Skipf("tzdata unavailable: %v", err)
+	}
+	f := TimeFormat{Mode: TimeAbsolute, Location: berlin}
+
+	sent := time.Date(2026, 3, 7, 23, 30, 0, 0, time.UTC) // 00:30 on the 8th in Berlin
+	if got := f.Stamp(sent); got != "2026-03-08 00:30 CET" {
+		t.Errorf("Stamp = %q", got)
+	}
+	if got := f.Age(sent); got != "2026-03-08" {
+		t.Errorf("Age (earlier day) = %q", got)
+	}
+	if got := f.Detail(sent); strings.Contains(got, "ago") {
+		t.Errorf("Detail in absolute mode should not show an age: %q", got)
+	}
+
+	now := time.Now()
+	if got, want := f.Age(now), now.In(berlin).Format("15:04"); got != want {
+		t.Errorf("Age (today) = %q, want %q", got, want)
+	}
+
+	f.Clock12 = true
+	if got := f.Clock(sent); got != "12:30:00 AM" {
+		t.Errorf("Clock (12h) = %q", got)
+	}
+	f.DateLayout = "Jan 2, 2006"
+	if w := f.AgeWidth(); w != len("Dec 28, 2006") {
+		t.Errorf("AgeWidth = %d", w)
+	}
+}
+
+func TestTimeFormat_RelativeDefault(t *testing.T) {
+	var f TimeFormat
+	sent := time.Now().Add(-3 * time.Hour)
+	if got := f.Age(sent); got != "3h" {
+		t.Errorf("Age = %q, want 3h", got)
+	}
+	if f.AgeWidth() != 4 {
+		t.Errorf("AgeWidth = %d, want 4", f.AgeWidth())
+	}
+	if got := f.Detail(sent); !strings.HasSuffix(got, " (3h ago)") {
+		t.Errorf("Detail = %q", got)
+	}
+}
+
+func TestParseTimeOptions(t *testing.T) {
+	if mode, err := ParseTimeMode("Absolute"); err != nil || mode != TimeAbsolute {
+		t.Errorf("ParseTimeMode(Absolute) = %q, %v", mode, err)
+	}
+	if _, err := ParseTimeMode("fuzzy"); err == nil {
+		t.Error("expected error for invalid time display")
+	}
+	if loc, err := ParseTimezone("UTC"); err != nil || loc != time.UTC {
+		t.Errorf("ParseTimezone(UTC) = %v, %v", loc, err)
+	}
+	if _, err := ParseTimezone("Mars/Olympus"); err == nil {
+		t.Error("expected error for unknown timezone")
+	}
+}
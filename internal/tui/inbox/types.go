@@ -4,6 +4,8 @@ package inbox
 import (
 	"fmt"
 	"time"
+
+	"github.com/steveyegge/gastown/internal/mail"
 )
 
 // MessageType indicates the purpose of an inbox message.
@@ -61,6 +63,15 @@ type Message struct {
 	// From is the sender address.
 	From string
 
+	// To is the recipient address of this copy.
+	To string
+
+	// Recipients lists all primary recipients of a multi-recipient send.
+	Recipients []string
+
+	// CC lists the CC'd addresses.
+	CC []string
+
 	// Timestamp is when the message was sent.
 	Timestamp time.Time
 
@@ -75,11 +86,19 @@ type Message struct {
 
 	// References are bead IDs referenced in the message body.
 	References []string
+
+	// Auth is the result of checking the message's signature against its
+	// sender's rig key.
+	Auth mail.AuthStatus
 }
 
 // Age returns the age of the message as a human-readable string.
 func (m *Message) Age() string {
-	d := time.Since(m.Timestamp)
+	return relativeAge(time.Since(m.Timestamp))
+}
+
+// relativeAge formats an age compactly, e.g. "<1m", "5m", "3h", "2d".
+func relativeAge(d time.Duration) string {
 	if d < time.Minute {
 		return "<1m"
 	}
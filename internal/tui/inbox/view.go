@@ -6,6 +6,7 @@ import (
 	"unicode/utf8"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/steveyegge/gastown/internal/mail"
 )
 
 // renderView renders the entire inbox view.
@@ -24,6 +25,10 @@ func (m Model) renderView() string {
 		return m.renderExpandView()
 	case ModeLearn:
 		return m.renderLearnView()
+	case ModePalette:
+		return m.renderPaletteView()
+	case ModeDiff:
+		return m.renderDiffView()
 	default:
 		return m.renderListView()
 	}
@@ -78,6 +83,60 @@ func (m Model) renderLearnView() string {
 	return b.String()
 }
 
+// renderPaletteView renders the command palette: the input and the
+// commands matching it, with their keys.
+func (m Model) renderPaletteView() string {
+	var b strings.Builder
+
+	b.WriteString(titleStyle.Render("COMMANDS"))
+	b.WriteString("\n\n")
+	b.WriteString(m.paletteInput.View())
+	b.WriteString("\n\n")
+
+	matches := m.paletteMatches()
+	if len(matches) == 0 {
+		b.WriteString(dimStyle.Render("  No matching command"))
+		b.WriteString("\n")
+	}
+	nameWidth := 0
+	for _, c := range paletteCommands {
+		if w := len(c.Name) + len(c.Arg) + 1; w > nameWidth {
+			nameWidth = w
+		}
+	}
+	maxLines := m.height - 10
+	for i, c := range matches {
+		if i >= maxLines {
+			break
+		}
+		cursor := "  "
+		if i == m.paletteCursor {
+			cursor = "▸ "
+		}
+		keyHint := "   "
+		if c.binding != nil {
+			keyHint = padRight(c.binding(m.keys).Help().Key, 3)
+		}
+		line := fmt.Sprintf("%s%s %s %s", cursor, keyHint, padRight(strings.TrimSpace(c.Name+" "+c.Arg), nameWidth), dimStyle.Render(c.Desc))
+		if i == m.paletteCursor {
+			line = selectedStyle.Render(line)
+		}
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(dimStyle.Render(strings.Repeat("─", m.width-2)))
+	b.WriteString("\n")
+	if m.statusMsg != "" {
+		b.WriteString(titleStyle.Render(m.statusMsg))
+	} else {
+		b.WriteString(helpStyle.Render("Enter run | Tab complete | ↑↓ select | Esc cancel"))
+	}
+
+	return b.String()
+}
+
 // renderListView renders the standard list + preview view.
 func (m Model) renderListView() string {
 	var b strings.Builder
@@ -132,7 +191,7 @@ func (m Model) renderHeader() string {
 	title := titleStyle.Render("GT INBOX")
 	statsStr := fmt.Sprintf("%d unread", unread)
 	if oldestUnread != nil {
-		statsStr += fmt.Sprintf(" (oldest: %s)", oldestUnread.Age())
+		statsStr += fmt.Sprintf(" (oldest: %s)", m.timeFormat.Age(oldestUnread.Timestamp))
 	}
 	statsStr += fmt.Sprintf(" | %d messages", len(m.messages))
 
@@ -142,6 +201,10 @@ func (m Model) renderHeader() string {
 		statsStr += fmt.Sprintf(" [Page %d/%d]", m.page+1, totalPages)
 	}
 
+	if refreshed := m.refreshStatus(); refreshed != "" {
+		statsStr += " | " + refreshed
+	}
+
 	stats := dimStyle.Render(statsStr)
 
 	// Phase 4: New messages notification
@@ -278,12 +341,15 @@ func (m Model) renderMessageLine(msg *Message, width int, selected bool) string
 	indicator := "  "
 	if selected {
 		indicator = "▸ "
+	} else if msg.Auth.Flagged() {
+		indicator = unauthStyle.Render("! ")
 	} else if !msg.Read {
 		indicator = unreadStyle.Render("○ ")
 	}
 
 	// Age
-	age := msg.Age()
+	age := m.timeFormat.Age(msg.Timestamp)
+	ageWidth := m.timeFormat.AgeWidth()
 
 	// Badge with color (unless selected, then use selected colors)
 	badge := msg.Type.Badge()
@@ -298,8 +364,8 @@ func (m Model) renderMessageLine(msg *Message, width int, selected bool) string
 	}
 
 	// Calculate available space for subject
-	// indicator(2) + subject + "  " + age(4) + "  " + badge(3) + reply
-	fixedWidth := 2 + 2 + 4 + 2 + 3 + len(replyIndicator)
+	// indicator(2) + subject + "  " + age + "  " + badge(3) + reply
+	fixedWidth := 2 + 2 + ageWidth + 2 + 3 + len(replyIndicator)
 	subjectWidth := width - fixedWidth
 	if subjectWidth < 10 {
 		subjectWidth = 10
@@ -314,7 +380,21 @@ func (m Model) renderMessageLine(msg *Message, width int, selected bool) string
 		subject = AgeStyle(msg.Timestamp).Render(subject)
 	}
 
-	return fmt.Sprintf("%s%s  %4s  %s%s", indicator, subject, age, badge, replyIndicator)
+	return fmt.Sprintf("%s%s  %*s  %s%s", indicator, subject, ageWidth, age, badge, replyIndicator)
+}
+
+// renderAuthFlag describes a message's signature check for the preview.
+// Mail from rigs that don't sign gets no flag.
+func renderAuthFlag(status mail.AuthStatus) string {
+	switch status {
+	case mail.AuthVerified:
+		return dimStyle.Render("✓ verified")
+	case mail.AuthUnsigned:
+		return unauthStyle.Render("⚠ UNSIGNED - sender not verified")
+	case mail.AuthInvalid:
+		return unauthStyle.Render("⚠ INVALID SIGNATURE - may be forged")
+	}
+	return ""
 }
 
 // renderDivider renders the vertical divider between list and preview.
@@ -359,10 +439,35 @@ func (m Model) renderPreview(width, height int) string {
 
 	// From line
 	fromLine := fmt.Sprintf(" %s %s", previewLabelStyle.Render("From:"), msg.From)
+	if auth := renderAuthFlag(msg.Auth); auth != "" {
+		fromLine += "  " + auth
+	}
 	b.WriteString(truncateString(fromLine, width))
 	b.WriteString("\n")
 	linesWritten++
 
+	// Date line
+	if !msg.Timestamp.IsZero() {
+		dateLine := fmt.Sprintf(" %s %s", previewLabelStyle.Render("Date:"), m.timeFormat.Detail(msg.Timestamp))
+		b.WriteString(truncateString(dateLine, width))
+		b.WriteString("\n")
+		linesWritten++
+	}
+
+	// To and CC lines, when the message went to more than us
+	if len(msg.Recipients) > 1 {
+		toLine := fmt.Sprintf(" %s %s", previewLabelStyle.Render("To:"), strings.Join(msg.Recipients, ", "))
+		b.WriteString(truncateString(toLine, width))
+		b.WriteString("\n")
+		linesWritten++
+	}
+	if len(msg.CC) > 0 {
+		ccLine := fmt.Sprintf(" %s %s", previewLabelStyle.Render("CC:"), strings.Join(msg.CC, ", "))
+		b.WriteString(truncateString(ccLine, width))
+		b.WriteString("\n")
+		linesWritten++
+	}
+
 	// Bead references line (Phase 3)
 	if len(msg.References) > 0 {
 		refsLine := fmt.Sprintf(" %s %s",
@@ -378,15 +483,31 @@ func (m Model) renderPreview(width, height int) string {
 	b.WriteString("\n")
 	linesWritten++
 
-	// Body content (wrap lines, highlight bead references)
-	bodyLines := wrapText(msg.Body, width-2)
+	// Agent summary (S action)
+	for _, line := range m.renderSummaryLines(msg.ID, width-2) {
+		b.WriteString(" " + line)
+		b.WriteString("\n")
+		linesWritten++
+	}
+
+	// Body content (wrap lines, highlight bead references). Proposals that
+	// reference beads or carry a patch get the structured preview.
+	var bodyLines []string
+	if msg.Type == TypeProposal {
+		if p := parseProposal(msg.Body); p.hasPatch() || len(msg.References) > 0 {
+			bodyLines = m.renderProposalLines(msg, p, width-2)
+		}
+	}
+	if bodyLines == nil {
+		for _, line := range wrapText(msg.Body, width-2) {
+			bodyLines = append(bodyLines, highlightBeadRefs(line, msg.References))
+		}
+	}
 	for _, line := range bodyLines {
 		if linesWritten >= height-2 { // Reserve space for bottom actions
 			break
 		}
-		// Highlight bead references in the line
-	highlightedLine := highlightBeadRefs(line, msg.References)
-		b.WriteString(" " + highlightedLine)
+		b.WriteString(" " + line)
 		b.WriteString("\n")
 		linesWritten++
 	}
@@ -413,7 +534,7 @@ func (m Model) getQuickActionsHint(msg *Message) string {
 	var base string
 	switch msg.Type {
 	case TypeProposal:
-		base = "[y] Approve  [n] Reject  [R] Reply  [r] Reload  [L] Learn"
+		base = "[y] Approve  [n] Reject  [v] View  [R] Reply  [r] Reload  [L] Learn"
 	case TypeQuestion:
 		base = "[R] Reply  [a] Archive  [r] Reload  [L] Learn"
 	case TypeAlert:
@@ -424,6 +545,12 @@ func (m Model) getQuickActionsHint(msg *Message) string {
 		base = "[r] Reload  [L] Learn"
 	}
 
+	if len(msg.CC) > 0 || len(msg.Recipients) > 1 {
+		base = strings.Replace(base, "[R] Reply", "[R] Reply  [W] All", 1)
+	}
+
+	base += "  [S] Summarize  [B] Bead"
+
 	// Add expand hint if message has bead references
 	if len(msg.References) > 0 {
 		if base != "" {
@@ -435,6 +562,31 @@ func (m Model) getQuickActionsHint(msg *Message) string {
 	return base
 }
 
+// renderSummaryLines renders the agent summary for key, a progress line
+// while it is being generated, or nothing.
+func (m Model) renderSummaryLines(key string, width int) []string {
+	if m.summarizing == key {
+		return []string{dimStyle.Render("Summarizing...")}
+	}
+	bullets, ok := m.summaries[key]
+	if !ok {
+		return nil
+	}
+
+	lines := []string{previewLabelStyle.Render("Summary:")}
+	for _, bullet := range bullets {
+		for i, wrapped := range wrapText(bullet, width-2) {
+			prefix := "• "
+			if i > 0 {
+				prefix = "  "
+			}
+			lines = append(lines, prefix+wrapped)
+		}
+	}
+	lines = append(lines, dimStyle.Render(strings.Repeat("─", width)))
+	return lines
+}
+
 // renderFooter renders the help footer.
 func (m Model) renderFooter() string {
 	// Show status message if present
@@ -445,7 +597,7 @@ func (m Model) renderFooter() string {
 	if m.showHelp {
 		return m.help.View(m.keys)
 	}
-	return helpStyle.Render("↑↓ nav | q quit | ? help")
+	return helpStyle.Render("↑↓ nav | : commands | q quit | ? help")
 }
 
 // renderReplyView renders the reply composition view.
@@ -453,14 +605,36 @@ func (m Model) renderReplyView() string {
 	var b strings.Builder
 
 	// Header
-	b.WriteString(titleStyle.Render("REPLY"))
-	b.WriteString("\n\n")
+	if m.replyingTo == nil && m.composeTo != "" {
+		b.WriteString(titleStyle.Render("NEW MESSAGE"))
+		b.WriteString("\n\n")
+		b.WriteString(previewLabelStyle.Render("To: "))
+		b.WriteString(m.composeTo)
+		b.WriteString("\n")
+		b.WriteString(previewLabelStyle.Render("Subject: "))
+		if m.composeSubject != "" {
+			b.WriteString(m.composeSubject)
+		} else {
+			b.WriteString(dimStyle.Render("(first line of the message)"))
+		}
+		b.WriteString("\n")
+	} else {
+		b.WriteString(titleStyle.Render("REPLY"))
+		b.WriteString("\n\n")
+	}
 
 	// Show what we're replying to
 	if m.replyingTo != nil {
 		b.WriteString(previewLabelStyle.Render("To: "))
 		b.WriteString(m.replyingTo.From)
 		b.WriteString("\n")
+		if m.replyAll {
+			if cc := replyAllCC(m.replyingTo, m.address); len(cc) > 0 {
+				b.WriteString(previewLabelStyle.Render("CC: "))
+				b.WriteString(strings.Join(cc, ", "))
+				b.WriteString("\n")
+			}
+		}
 		b.WriteString(previewLabelStyle.Render("Re: "))
 		b.WriteString(m.replyingTo.Subject)
 		b.WriteString("\n")
@@ -496,58 +670,22 @@ func (m Model) renderThreadView() string {
 	b.WriteString(dimStyle.Render(strings.Repeat("─", m.width-2)))
 	b.WriteString("\n\n")
 
-	// Thread messages (oldest first)
-	contentHeight := m.height - 6
-	linesUsed := 0
-
-	for i, msg := range m.threadMessages {
-		if linesUsed >= contentHeight-3 {
-			b.WriteString(dimStyle.Render(fmt.Sprintf("... and %d more messages", len(m.threadMessages)-i)))
-			b.WriteString("\n")
-			break
-		}
-
-		// Message header: From and timestamp
-		msgHeader := fmt.Sprintf("%s  %s", msg.From, dimStyle.Render(msg.Age()))
-		b.WriteString(previewLabelStyle.Render(msgHeader))
-		b.WriteString("\n")
-		linesUsed++
-
-		// Message body (truncate if needed)
-	bodyLines := wrapText(msg.Body, m.width-4)
-		maxBodyLines := 3
-		for j, line := range bodyLines {
-			if j >= maxBodyLines || linesUsed >= contentHeight-3 {
-				if len(bodyLines) > maxBodyLines {
-					b.WriteString(dimStyle.Render("  ..."))
-					b.WriteString("\n")
-					linesUsed++
-				}
-				break
-			}
-			b.WriteString("  ")
-			b.WriteString(line)
-			b.WriteString("\n")
-			linesUsed++
-		}
-
-		// Separator between messages
-		if i < len(m.threadMessages)-1 {
-			b.WriteString("\n")
-			linesUsed++
-		}
-	}
-
-	// Pad remaining
-	for linesUsed < contentHeight {
-		b.WriteString("\n")
-		linesUsed++
-	}
+	// Thread messages (oldest first), scrolled by the viewport. Render
+	// fresh content so relative times stay current.
+	vp := m.threadViewport
+	content, _ := m.threadContent()
+	vp.SetContent(content)
+	b.WriteString(vp.View())
+	b.WriteString("\n")
 
 	// Footer
 	b.WriteString(dimStyle.Render(strings.Repeat("─", m.width-2)))
 	b.WriteString("\n")
-	b.WriteString(helpStyle.Render("R reply | Esc back"))
+	hints := "↑/↓ select | enter expand | pgup/pgdn scroll | g/G oldest/newest | R reply | S summarize | E export | Esc back"
+	if vp.TotalLineCount() > vp.Height {
+		hints += fmt.Sprintf(" | %3.f%%", vp.ScrollPercent()*100)
+	}
+	b.WriteString(helpStyle.Render(hints))
 
 	return b.String()
 }
@@ -0,0 +1,35 @@
+package util
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPostWebhook(t *testing.T) {
+	var got map[string]string
+	status := http.StatusOK
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+			t.Errorf("Content-Type = %q, want application/json", ct)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decoding body: %v", err)
+		}
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	if err := PostWebhook(srv.URL, map[string]string{"text": "hello"}); err != nil {
+		t.Fatalf("PostWebhook: %v", err)
+	}
+	if got["text"] != "hello" {
+		t.Errorf("payload = %v, want text=hello", got)
+	}
+
+	status = http.StatusInternalServerError
+	if err := PostWebhook(srv.URL, map[string]string{}); err == nil {
+		t.Error("expected an error for HTTP 500")
+	}
+}
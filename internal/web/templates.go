@@ -3,8 +3,10 @@ package web
 
 import (
 	"embed"
+	"fmt"
 	"html/template"
 	"io/fs"
+	"time"
 
 	"github.com/steveyegge/gastown/internal/activity"
 )
@@ -68,6 +70,9 @@ func LoadTemplates() (*template.Template, error) {
 		"statusClass":     statusClass,
 		"workStatusClass": workStatusClass,
 		"progressPercent": progressPercent,
+		"sparkline":       sparkline,
+		"percent":         percent,
+		"sinceNow":        sinceNow,
 	}
 
 	// Get the templates subdirectory
@@ -136,3 +141,21 @@ func progressPercent(completed, total int) int {
 	}
 	return (completed * 100) / total
 }
+
+// sinceNow formats how long ago t was, coarsely (e.g., "3m", "2h").
+func sinceNow(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	d := time.Since(t)
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	}
+}
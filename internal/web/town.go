@@ -0,0 +1,222 @@
+package web
+
+import (
+	"encoding/json"
+	"html/template"
+	"net/http"
+	"time"
+)
+
+// TownStatus is the read-only snapshot served by gt serve.
+type TownStatus struct {
+	GeneratedAt time.Time             `json:"generated_at"`
+	MergeQueues []RigQueue            `json:"merge_queues"`
+	Batches     []BatchRun            `json:"batches"` // Newest first
+	Quarantine  []QuarantinedScenario `json:"quarantine"`
+	Inboxes     []InboxCount          `json:"inboxes"`
+	Sessions    []AgentSession        `json:"sessions"`
+
+	// Errors maps a section name to why it couldn't be fetched. Sections
+	// fail independently so one broken source doesn't blank the board.
+	Errors map[string]string `json:"errors,omitempty"`
+}
+
+// RigQueue is a rig's merge queue.
+type RigQueue struct {
+	Rig     string       `json:"rig"`
+	Ready   []QueueEntry `json:"ready"`
+	Blocked []QueueEntry `json:"blocked"`
+}
+
+// QueueEntry is one merge request in a rig's queue.
+type QueueEntry struct {
+	ID        string    `json:"id"`
+	Title     string    `json:"title"`
+	Branch    string    `json:"branch"`
+	Worker    string    `json:"worker,omitempty"`
+	Priority  int       `json:"priority"`
+	BlockedBy string    `json:"blocked_by,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// BatchRun summarizes one tester batch.
+type BatchRun struct {
+	ID          string        `json:"id"`
+	Environment string        `json:"environment,omitempty"`
+	StartedAt   time.Time     `json:"started_at"`
+	Duration    time.Duration `json:"duration"`
+	Passed      int           `json:"passed"`
+	Failed      int           `json:"failed"`
+	Errors      int           `json:"errors"`
+	Skipped     int           `json:"skipped"`
+	Interrupted bool          `json:"interrupted,omitempty"`
+
+	// PassRate is passed / (passed + failed + errors), 0..1.
+	PassRate float64 `json:"pass_rate"`
+}
+
+// QuarantinedScenario is a tester scenario held out of batches.
+type QuarantinedScenario struct {
+	Scenario      string    `json:"scenario"`
+	Reason        string    `json:"reason"`
+	FlakeRate     float64   `json:"flake_rate"`
+	Auto          bool      `json:"auto"`
+	QuarantinedAt time.Time `json:"quarantined_at"`
+}
+
+// InboxCount is an agent's mail count.
+type InboxCount struct {
+	Address string `json:"address"`
+	Unread  int    `json:"unread"`
+	Total   int    `json:"total"`
+}
+
+// AgentSession is a running agent tmux session.
+type AgentSession struct {
+	Session      string    `json:"session"`
+	Role         string    `json:"role"`
+	Rig          string    `json:"rig,omitempty"`
+	Name         string    `json:"name,omitempty"`
+	LastActivity time.Time `json:"last_activity"`
+}
+
+// TownFetcher defines the interface for fetching town dashboard data.
+type TownFetcher interface {
+	FetchMergeQueues() ([]RigQueue, error)
+	FetchBatches() ([]BatchRun, error)
+	FetchQuarantine() ([]QuarantinedScenario, error)
+	FetchInboxes() ([]InboxCount, error)
+	FetchSessions() ([]AgentSession, error)
+}
+
+// TownHandler serves the town dashboard: HTML at / and JSON under /api/.
+type TownHandler struct {
+	fetcher  TownFetcher
+	template *template.Template
+	mux      *http.ServeMux
+}
+
+// NewTownHandler creates a new town dashboard handler with the given fetcher.
+func NewTownHandler(fetcher TownFetcher) (*TownHandler, error) {
+	tmpl, err := LoadTemplates()
+	if err != nil {
+		return nil, err
+	}
+
+	h := &TownHandler{
+		fetcher:  fetcher,
+		template: tmpl,
+		mux:      http.NewServeMux(),
+	}
+	h.mux.HandleFunc("/", h.serveHTML)
+	h.mux.HandleFunc("/api/status", h.serveStatus)
+	h.mux.HandleFunc("/api/mq", serveSection(fetcher.FetchMergeQueues))
+	h.mux.HandleFunc("/api/batches", serveSection(fetcher.FetchBatches))
+	h.mux.HandleFunc("/api/quarantine", serveSection(fetcher.FetchQuarantine))
+	h.mux.HandleFunc("/api/inbox", serveSection(fetcher.FetchInboxes))
+	h.mux.HandleFunc("/api/sessions", serveSection(fetcher.FetchSessions))
+	return h, nil
+}
+
+// ServeHTTP implements http.Handler. The dashboard is read-only, so
+// anything but GET/HEAD is rejected.
+func (h *TownHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	h.mux.ServeHTTP(w, r)
+}
+
+// Status fetches every section, recording per-section failures in Errors.
+func (h *TownHandler) Status() *TownStatus {
+	s := &TownStatus{GeneratedAt: time.Now()}
+	fail := func(section string, err error) {
+		if err == nil {
+			return
+		}
+		if s.Errors == nil {
+			s.Errors = make(map[string]string)
+		}
+		s.Errors[section] = err.Error()
+	}
+
+	var err error
+	s.MergeQueues, err = h.fetcher.FetchMergeQueues()
+	fail("merge_queues", err)
+	s.Batches, err = h.fetcher.FetchBatches()
+	fail("batches", err)
+	s.Quarantine, err = h.fetcher.FetchQuarantine()
+	fail("quarantine", err)
+	s.Inboxes, err = h.fetcher.FetchInboxes()
+	fail("inboxes", err)
+	s.Sessions, err = h.fetcher.FetchSessions()
+	fail("sessions", err)
+	return s
+}
+
+func (h *TownHandler) serveHTML(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+
+	if err := h.template.ExecuteTemplate(w, "town.html", h.Status()); err != nil {
+		http.Error(w, "Failed to render template", http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *TownHandler) serveStatus(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, h.Status())
+}
+
+// serveSection returns a handler serving one fetcher section as JSON.
+func serveSection[T any](fetch func() ([]T, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		rows, err := fetch()
+		if err != nil {
+			writeJSON(w, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+			return
+		}
+		if rows == nil {
+			rows = []T{}
+		}
+		writeJSON(w, http.StatusOK, rows)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(v)
+}
+
+// sparkBlocks are the levels used by sparkline, lowest first.
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// sparkline renders batch pass rates as Unicode blocks, oldest first.
+// Batches are given newest first, as in TownStatus.
+func sparkline(batches []BatchRun) string {
+	out := make([]rune, 0, len(batches))
+	for i := len(batches) - 1; i >= 0; i-- {
+		level := int(batches[i].PassRate * float64(len(sparkBlocks)-1))
+		if level < 0 {
+			level = 0
+		} else if level >= len(sparkBlocks) {
+			level = len(sparkBlocks) - 1
+		}
+		out = append(out, sparkBlocks[level])
+	}
+	return string(out)
+}
+
+// percent formats a 0..1 rate as a whole percentage.
+func percent(rate float64) int {
+	return int(rate*100 + 0.5)
+}
@@ -0,0 +1,256 @@
+package web
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/mail"
+	"github.com/steveyegge/gastown/internal/refinery"
+	"github.com/steveyegge/gastown/internal/rig"
+	"github.com/steveyegge/gastown/internal/session"
+	"github.com/steveyegge/gastown/internal/tester/batch"
+	"github.com/steveyegge/gastown/internal/tester/flake"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// townBatchLimit is how many recent batches the dashboard shows.
+const townBatchLimit = 20
+
+// LiveTownFetcher fetches town dashboard data from beads, tmux, mail and
+// tester results.
+type LiveTownFetcher struct {
+	townRoot   string
+	resultsDir string
+}
+
+// NewLiveTownFetcher creates a fetcher for the current workspace. resultsDir
+// is the tester output directory (as for gt tester batch --output).
+func NewLiveTownFetcher(resultsDir string) (*LiveTownFetcher, error) {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return nil, fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	return &LiveTownFetcher{
+		townRoot:   townRoot,
+		resultsDir: resultsDir,
+	}, nil
+}
+
+// FetchMergeQueues lists ready and blocked MRs for each rig with a refinery.
+func (f *LiveTownFetcher) FetchMergeQueues() ([]RigQueue, error) {
+	rigs, err := f.discoverRigs()
+	if err != nil {
+		return nil, err
+	}
+
+	var queues []RigQueue
+	for _, r := range rigs {
+		if !r.HasRefinery {
+			continue
+		}
+		e := refinery.NewEngineer(r)
+		if err := e.LoadConfig(); err != nil {
+			return nil, fmt.Errorf("loading %s refinery config: %w", r.Name, err)
+		}
+		ready, err := e.ListReadyMRs()
+		if err != nil {
+			return nil, fmt.Errorf("listing %s merge queue: %w", r.Name, err)
+		}
+		blocked, err := e.ListBlockedMRs()
+		if err != nil {
+			return nil, fmt.Errorf("listing %s blocked MRs: %w", r.Name, err)
+		}
+
+		q := RigQueue{Rig: r.Name}
+		for _, mr := range ready {
+			q.Ready = append(q.Ready, queueEntry(mr))
+		}
+		for _, mr := range blocked {
+			q.Blocked = append(q.Blocked, queueEntry(mr))
+		}
+		queues = append(queues, q)
+	}
+	return queues, nil
+}
+
+func queueEntry(mr *refinery.MRInfo) QueueEntry {
+	return QueueEntry{
+		ID:        mr.ID,
+		Title:     mr.Title,
+		Branch:    mr.Branch,
+		Worker:    mr.Worker,
+		Priority:  mr.Priority,
+		BlockedBy: mr.BlockedBy,
+		CreatedAt: mr.CreatedAt,
+	}
+}
+
+// FetchBatches loads the most recent tester batch manifests, newest first.
+func (f *LiveTownFetcher) FetchBatches() ([]BatchRun, error) {
+	manifests, err := batch.ListBatchManifests(f.resultsDir)
+	if err != nil {
+		return nil, err
+	}
+	if len(manifests) > townBatchLimit {
+		manifests = manifests[len(manifests)-townBatchLimit:]
+	}
+
+	runs := make([]BatchRun, 0, len(manifests))
+	for i := len(manifests) - 1; i >= 0; i-- {
+		result, err := batch.LoadBatchResult(f.resultsDir, manifests[i])
+		if err != nil {
+			continue // skip unreadable manifests
+		}
+		runs = append(runs, batchRun(result))
+	}
+	return runs, nil
+}
+
+func batchRun(result *batch.BatchResult) BatchRun {
+	s := result.Summary
+	run := BatchRun{
+		ID:          result.ID,
+		Environment: result.Config.Environment,
+		StartedAt:   result.StartedAt,
+		Duration:    result.TotalDuration,
+		Passed:      s.Passed,
+		Failed:      s.Failed,
+		Errors:      s.Errors,
+		Skipped:     s.Skipped,
+		Interrupted: result.Interrupted,
+	}
+	if ran := s.Passed + s.Failed + s.Errors; ran > 0 {
+		run.PassRate = float64(s.Passed) / float64(ran)
+	}
+	return run
+}
+
+// FetchQuarantine lists quarantined scenarios from the flake detector.
+func (f *LiveTownFetcher) FetchQuarantine() ([]QuarantinedScenario, error) {
+	cfg, err := flake.LoadConfig(filepath.Join(f.resultsDir, flake.ConfigFileName))
+	if err != nil {
+		return nil, err
+	}
+	detector, err := flake.NewDetector(filepath.Join(f.resultsDir, flake.DataFileName), cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []QuarantinedScenario
+	for _, e := range detector.ListQuarantined() {
+		rows = append(rows, QuarantinedScenario{
+			Scenario:      e.Scenario,
+			Reason:        e.Reason,
+			FlakeRate:     e.FlakeRate,
+			Auto:          e.AutoQuarantined,
+			QuarantinedAt: e.QuarantinedAt,
+		})
+	}
+	return rows, nil
+}
+
+// FetchInboxes counts mail for the overseer, town agents and every agent
+// with a running session. Inboxes that can't be read are skipped.
+func (f *LiveTownFetcher) FetchInboxes() ([]InboxCount, error) {
+	addresses := []string{"overseer", "mayor/", "deacon/"}
+	sessions, _ := f.FetchSessions()
+	for _, s := range sessions {
+		if addr := inboxAddress(s); addr != "" && addr != "mayor/" && addr != "deacon/" {
+			addresses = append(addresses, addr)
+		}
+	}
+
+	router := mail.NewRouter(f.townRoot)
+	var counts []InboxCount
+	for _, addr := range addresses {
+		mailbox, err := router.GetMailbox(addr)
+		if err != nil {
+			continue
+		}
+		total, unread, err := mailbox.Count()
+		if err != nil {
+			continue
+		}
+		counts = append(counts, InboxCount{Address: addr, Unread: unread, Total: total})
+	}
+	return counts, nil
+}
+
+// inboxAddress returns the mail address for an agent session, in the form
+// gt status uses.
+func inboxAddress(s AgentSession) string {
+	switch session.Role(s.Role) {
+	case session.RoleMayor:
+		return "mayor/"
+	case session.RoleDeacon:
+		return "deacon/"
+	case session.RoleWitness, session.RoleRefinery:
+		return s.Rig + "/" + s.Role
+	case session.RoleCrew:
+		return s.Rig + "/crew/" + s.Name
+	case session.RolePolecat:
+		return s.Rig + "/" + s.Name
+	default:
+		return ""
+	}
+}
+
+// FetchSessions lists running agent tmux sessions, most recently active first.
+func (f *LiveTownFetcher) FetchSessions() ([]AgentSession, error) {
+	cmd := exec.Command("tmux", "list-sessions", "-F", "#{session_name}|#{session_activity}")
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+	if err := cmd.Run(); err != nil {
+		// tmux not running or no sessions
+		return nil, nil
+	}
+
+	var sessions []AgentSession
+	for _, line := range strings.Split(strings.TrimSpace(stdout.String()), "\n") {
+		name, activityStr, ok := strings.Cut(line, "|")
+		if !ok {
+			continue
+		}
+		id, err := session.ParseSessionName(name)
+		if err != nil {
+			continue // not an agent session
+		}
+		s := AgentSession{
+			Session: name,
+			Role:    string(id.Role),
+			Rig:     id.Rig,
+			Name:    id.Name,
+		}
+		if unix, ok := parseActivityTimestamp(activityStr); ok {
+			s.LastActivity = time.Unix(unix, 0)
+		}
+		sessions = append(sessions, s)
+	}
+
+	sort.SliceStable(sessions, func(i, j int) bool {
+		return sessions[i].LastActivity.After(sessions[j].LastActivity)
+	})
+	return sessions, nil
+}
+
+func (f *LiveTownFetcher) discoverRigs() ([]*rig.Rig, error) {
+	rigsConfig, err := config.LoadRigsConfig(constants.MayorRigsPath(f.townRoot))
+	if err != nil {
+		rigsConfig = &config.RigsConfig{Rigs: make(map[string]config.RigEntry)}
+	}
+	mgr := rig.NewManager(f.townRoot, rigsConfig, git.NewGit(f.townRoot))
+	rigs, err := mgr.DiscoverRigs()
+	if err != nil {
+		return nil, fmt.Errorf("discovering rigs: %w", err)
+	}
+	return rigs, nil
+}
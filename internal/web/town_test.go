@@ -0,0 +1,182 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/tester/batch"
+)
+
+// MockTownFetcher is a mock implementation for testing.
+type MockTownFetcher struct {
+	Queues     []RigQueue
+	Batches    []BatchRun
+	Quarantine []QuarantinedScenario
+	Inboxes    []InboxCount
+	Sessions   []AgentSession
+	MQError    error
+}
+
+func (m *MockTownFetcher) FetchMergeQueues() ([]RigQueue, error) {
+	return m.Queues, m.MQError
+}
+
+func (m *MockTownFetcher) FetchBatches() ([]BatchRun, error) {
+	return m.Batches, nil
+}
+
+func (m *MockTownFetcher) FetchQuarantine() ([]QuarantinedScenario, error) {
+	return m.Quarantine, nil
+}
+
+func (m *MockTownFetcher) FetchInboxes() ([]InboxCount, error) {
+	return m.Inboxes, nil
+}
+
+func (m *MockTownFetcher) FetchSessions() ([]AgentSession, error) {
+	return m.Sessions, nil
+}
+
+func newTestTownHandler(t *testing.T, mock *MockTownFetcher) *TownHandler {
+	t.Helper()
+	handler, err := NewTownHandler(mock)
+	if err != nil {
+		t.Fatalf("NewTownHandler() error = %v", err)
+	}
+	return handler
+}
+
+func TestTownHandler_RendersHTML(t *testing.T) {
+	handler := newTestTownHandler(t, &MockTownFetcher{
+		Queues: []RigQueue{{
+			Rig:   "gastown",
+			Ready: []QueueEntry{{ID: "gt-mr1", Branch: "polecat/nux", CreatedAt: time.Now()}},
+		}},
+		Batches: []BatchRun{
+			{ID: "newest", PassRate: 1, Passed: 4},
+			{ID: "oldest", PassRate: 0, Failed: 4},
+		},
+		Quarantine: []QuarantinedScenario{{Scenario: "login-flow", FlakeRate: 0.4}},
+		Inboxes:    []InboxCount{{Address: "overseer", Unread: 3, Total: 5}},
+		Sessions:   []AgentSession{{Session: "gt-gastown-witness", Role: "witness", Rig: "gastown"}},
+	})
+
+	req := httptest.NewRequest("GET", "/", nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", w.Code)
+	}
+	body := w.Body.String()
+	for _, want := range []string{"gt-mr1", "polecat/nux", "▁█", "login-flow", "40%", "overseer", "gt-gastown-witness", `http-equiv="refresh"`} {
+		if !strings.Contains(body, want) {
+			t.Errorf("HTML missing %q", want)
+		}
+	}
+}
+
+func TestTownHandler_JSON(t *testing.T) {
+	handler := newTestTownHandler(t, &MockTownFetcher{
+		Inboxes: []InboxCount{{Address: "mayor/", Unread: 1, Total: 2}},
+		MQError: errFetchFailed,
+	})
+
+	// A failing section is reported without blanking the rest
+	req := httptest.NewRequest("GET", "/api/status", nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("/api/status = %d, want 200", w.Code)
+	}
+	var status TownStatus
+	if err := json.Unmarshal(w.Body.Bytes(), &status); err != nil {
+		t.Fatalf("decoding status: %v", err)
+	}
+	if status.Errors["merge_queues"] != errFetchFailed.Error() {
+		t.Errorf("Errors = %v", status.Errors)
+	}
+	if len(status.Inboxes) != 1 || status.Inboxes[0].Unread != 1 {
+		t.Errorf("Inboxes = %+v", status.Inboxes)
+	}
+
+	// Section endpoints return empty arrays, not null
+	req = httptest.NewRequest("GET", "/api/sessions", nil)
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	if got := strings.TrimSpace(w.Body.String()); got != "[]" {
+		t.Errorf("/api/sessions = %q, want []", got)
+	}
+
+	req = httptest.NewRequest("GET", "/api/mq", nil)
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("/api/mq with failing fetcher = %d, want 500", w.Code)
+	}
+}
+
+func TestTownHandler_ReadOnly(t *testing.T) {
+	handler := newTestTownHandler(t, &MockTownFetcher{})
+
+	req := httptest.NewRequest("POST", "/api/status", nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("POST = %d, want 405", w.Code)
+	}
+
+	req = httptest.NewRequest("GET", "/nope", nil)
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("GET /nope = %d, want 404", w.Code)
+	}
+}
+
+func TestLiveTownFetcher_FetchBatches(t *testing.T) {
+	resultsDir := t.TempDir()
+	write := func(day, id string, passed, failed int) {
+		dir := filepath.Join(resultsDir, day, "batch-"+id)
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		data, _ := json.Marshal(batch.BatchResult{
+			ID:      id,
+			Summary: batch.BatchSummary{Passed: passed, Failed: failed},
+		})
+		if err := os.WriteFile(filepath.Join(dir, "manifest.json"), data, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("2026-01-14", "older", 1, 3)
+	write("2026-01-15", "newer", 4, 0)
+
+	f := &LiveTownFetcher{resultsDir: resultsDir}
+	runs, err := f.FetchBatches()
+	if err != nil {
+		t.Fatalf("FetchBatches() error = %v", err)
+	}
+	if len(runs) != 2 || runs[0].ID != "newer" || runs[1].ID != "older" {
+		t.Fatalf("FetchBatches() = %+v, want newer then older", runs)
+	}
+	if runs[0].PassRate != 1 || runs[1].PassRate != 0.25 {
+		t.Errorf("pass rates = %v, %v", runs[0].PassRate, runs[1].PassRate)
+	}
+}
+
+func TestSparkline(t *testing.T) {
+	got := sparkline([]BatchRun{{PassRate: 1}, {PassRate: 0.5}, {PassRate: 0}})
+	if got != "▁▄█" {
+		t.Errorf("sparkline = %q, want ▁▄█", got)
+	}
+	if sparkline(nil) != "" {
+		t.Error("sparkline(nil) should be empty")
+	}
+}
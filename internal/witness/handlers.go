@@ -343,7 +343,7 @@ Please fix the issue and resubmit with 'gt done'.`,
 		),
 	}
 
-	if err := router.Send(notification); err != nil {
+	if err := router.SendOrQueue(notification); err != nil {
 		result.Error = fmt.Errorf("sending failure notification: %w", err)
 		return result
 	}
@@ -0,0 +1,132 @@
+// Package flake lets other Go tools use gt's flake detector: record test
+// outcomes and query quarantine state in a gt results directory without
+// shelling out to 'gt tester quarantine'.
+//
+//	d, err := flake.Open("test-results")
+//	if err != nil {
+//		return err
+//	}
+//	if d.IsQuarantined("checkout-flow") {
+//		return nil // skip it
+//	}
+//	actions, err := d.Record("checkout-flow", flake.RunRecord{Outcome: flake.OutcomeFail})
+//
+// A Detector holds the store in memory and rewrites it on each change, so
+// don't record into a results directory a gt tester batch is writing to.
+package flake
+
+import (
+	"path/filepath"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/tester/flake"
+)
+
+// Types shared with the detector. See the gt tester docs for field details.
+type (
+	Config           = flake.Config
+	Policy           = flake.Policy
+	RunOutcome       = flake.RunOutcome
+	RunRecord        = flake.RunRecord
+	Metrics          = flake.FlakeMetrics
+	History          = flake.ScenarioHistory
+	QuarantineEntry  = flake.QuarantineEntry
+	QuarantineAction = flake.QuarantineAction
+)
+
+// Run outcomes.
+const (
+	OutcomePass  = flake.OutcomePass
+	OutcomeFail  = flake.OutcomeFail
+	OutcomeError = flake.OutcomeError // Infrastructure error
+	OutcomeSkip  = flake.OutcomeSkip
+)
+
+// DefaultConfig returns the default flake detection configuration.
+func DefaultConfig() Config {
+	return flake.DefaultConfig()
+}
+
+// Detector records run outcomes and tracks flake rates and quarantine.
+type Detector struct {
+	d *flake.Detector
+}
+
+// Open opens the flake detector of a gt results directory (the --output of
+// gt tester batch, "test-results" by default), with the directory's flake
+// config. The store is created on the first change if it doesn't exist.
+func Open(resultsDir string) (*Detector, error) {
+	config, err := flake.LoadConfig(filepath.Join(resultsDir, flake.ConfigFileName))
+	if err != nil {
+		return nil, err
+	}
+	return New(filepath.Join(resultsDir, flake.DataFileName), config)
+}
+
+// New opens a detector storing its state at storagePath, with config in
+// place of a results directory's flake config.
+func New(storagePath string, config Config) (*Detector, error) {
+	d, err := flake.NewDetector(storagePath, config)
+	if err != nil {
+		return nil, err
+	}
+	return &Detector{d: d}, nil
+}
+
+// Record records a run of scenario and returns the quarantine actions it
+// triggered. A zero Timestamp is set to now. Quarantine and flag actions
+// are also posted to the configured webhook; a webhook failure is returned
+// as an error alongside the actions.
+func (d *Detector) Record(scenario string, record RunRecord) ([]QuarantineAction, error) {
+	if record.Timestamp.IsZero() {
+		record.Timestamp = time.Now()
+	}
+	return d.d.RecordRun(scenario, record)
+}
+
+// SetPolicy overrides the flake config for scenario from its next recorded
+// run. A nil policy reverts to the detector config.
+func (d *Detector) SetPolicy(scenario string, policy *Policy) {
+	d.d.SetPolicy(scenario, policy)
+}
+
+// IsQuarantined reports whether scenario is quarantined. Expired entries
+// don't count.
+func (d *Detector) IsQuarantined(scenario string) bool {
+	return d.d.IsQuarantined(scenario)
+}
+
+// QuarantineEntry returns scenario's quarantine entry, or nil.
+func (d *Detector) QuarantineEntry(scenario string) *QuarantineEntry {
+	return d.d.GetQuarantineEntry(scenario)
+}
+
+// Quarantined returns all quarantine entries, most recent first.
+func (d *Detector) Quarantined() []*QuarantineEntry {
+	return d.d.ListQuarantined()
+}
+
+// Quarantine manually quarantines scenario.
+func (d *Detector) Quarantine(scenario, reason string) error {
+	return d.d.Quarantine(scenario, reason)
+}
+
+// Unquarantine takes scenario out of quarantine.
+func (d *Detector) Unquarantine(scenario string) error {
+	return d.d.Unquarantine(scenario)
+}
+
+// Metrics returns scenario's flake metrics (zero if it has no runs).
+func (d *Detector) Metrics(scenario string) *Metrics {
+	return d.d.GetMetrics(scenario)
+}
+
+// Flaky returns the metrics of every scenario over its flake threshold.
+func (d *Detector) Flaky() []*Metrics {
+	return d.d.GetFlakyScenarios()
+}
+
+// History returns scenario's run history, or nil if it has no runs.
+func (d *Detector) History(scenario string) *History {
+	return d.d.GetHistory(scenario)
+}
@@ -0,0 +1,47 @@
+package flake
+
+import (
+	"testing"
+)
+
+func TestDetector_RecordAndQuarantine(t *testing.T) {
+	dir := t.TempDir()
+	d, err := Open(dir)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+
+	// The default config quarantines after 3 runs over a 30% failure rate
+	var actions []QuarantineAction
+	for _, outcome := range []RunOutcome{OutcomeFail, OutcomePass, OutcomeFail} {
+		actions, err = d.Record("checkout-flow", RunRecord{Outcome: outcome})
+		if err != nil {
+			t.Fatalf("Record: %v", err)
+		}
+	}
+	if len(actions) != 1 || actions[0].Action != "quarantine" {
+		t.Errorf("actions = %+v, want a quarantine", actions)
+	}
+	if h := d.History("checkout-flow"); h == nil || len(h.Runs) != 3 || h.Runs[0].Timestamp.IsZero() {
+		t.Errorf("history = %+v, want 3 timestamped runs", h)
+	}
+
+	// State is persisted for gt tester quarantine and other tools
+	reopened, err := Open(dir)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	if !reopened.IsQuarantined("checkout-flow") || len(reopened.Quarantined()) != 1 {
+		t.Error("quarantine not persisted")
+	}
+	if m := reopened.Metrics("checkout-flow"); m.WindowRuns != 3 {
+		t.Errorf("WindowRuns = %d, want 3", m.WindowRuns)
+	}
+
+	if err := reopened.Unquarantine("checkout-flow"); err != nil {
+		t.Fatal(err)
+	}
+	if reopened.IsQuarantined("checkout-flow") || reopened.QuarantineEntry("checkout-flow") != nil {
+		t.Error("still quarantined after Unquarantine")
+	}
+}
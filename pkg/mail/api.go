@@ -0,0 +1,117 @@
+package mail
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// API operations.
+const (
+	OpList = "list"
+	OpRead = "read"
+	OpSend = "send"
+	OpAck  = "ack"
+)
+
+// Request is one line of input to Serve:
+//
+//	{"id":"1","op":"list","unread":true}
+//	{"id":"2","op":"read","message_id":"hq-abc123"}
+//	{"id":"3","op":"ack","message_id":"hq-abc123"}
+//	{"id":"4","op":"send","message":{"to":"mayor/","subject":"Done","body":"gt-42 merged"}}
+type Request struct {
+	// ID is echoed in the response, to match responses to requests.
+	ID string `json:"id,omitempty"`
+
+	// Op is OpList, OpRead, OpSend or OpAck.
+	Op string `json:"op"`
+
+	// MessageID is the message to read or ack.
+	MessageID string `json:"message_id,omitempty"`
+
+	// Unread lists only unread messages.
+	Unread bool `json:"unread,omitempty"`
+
+	// Message is the message to send (see Client.Send).
+	Message *Message `json:"message,omitempty"`
+}
+
+// Response is one line of Serve's output, written for each request in
+// order. Failed requests have OK false and an Error; the stream goes on.
+type Response struct {
+	ID    string `json:"id,omitempty"`
+	OK    bool   `json:"ok"`
+	Error string `json:"error,omitempty"`
+
+	// Messages is the inbox, for list (absent when empty).
+	Messages []*Message `json:"messages,omitempty"`
+
+	// Message is the message read, or the message as sent.
+	Message *Message `json:"message,omitempty"`
+}
+
+// Serve answers JSON-lines requests from r with JSON-lines responses on w
+// until r is exhausted. Blank lines are skipped. It returns an error only
+// if reading or writing fails.
+func Serve(c *Client, r io.Reader, w io.Writer) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	enc := json.NewEncoder(w)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var req Request
+		var resp Response
+		if err := json.Unmarshal([]byte(line), &req); err != nil {
+			resp = Response{Error: fmt.Sprintf("invalid request: %v", err)}
+		} else {
+			resp = c.Handle(req)
+		}
+		if err := enc.Encode(resp); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// Handle answers a single request.
+func (c *Client) Handle(req Request) Response {
+	resp := Response{ID: req.ID}
+	var err error
+
+	switch req.Op {
+	case OpList:
+		resp.Messages, err = c.List(req.Unread)
+	case OpRead:
+		if req.MessageID == "" {
+			err = fmt.Errorf("message_id is required")
+			break
+		}
+		resp.Message, err = c.Read(req.MessageID)
+	case OpAck:
+		if req.MessageID == "" {
+			err = fmt.Errorf("message_id is required")
+			break
+		}
+		err = c.Ack(req.MessageID)
+	case OpSend:
+		err = c.Send(req.Message)
+		resp.Message = req.Message
+	default:
+		err = fmt.Errorf("unknown op %q (want %s, %s, %s or %s)", req.Op, OpList, OpRead, OpSend, OpAck)
+	}
+
+	if err != nil {
+		resp.Error = err.Error()
+		resp.Message = nil
+		return resp
+	}
+	resp.OK = true
+	return resp
+}
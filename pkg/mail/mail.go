@@ -0,0 +1,126 @@
+// Package mail lets bots, editor plugins and other Go programs use gt's
+// agent mail: list an inbox, read, acknowledge and send messages in a Gas
+// Town workspace, without shelling out to 'gt mail' or depending on how
+// mail is stored.
+//
+//	c, err := mail.Open(townRoot, "mayor/")
+//	if err != nil {
+//		return err
+//	}
+//	unread, err := c.List(true)
+//	for _, msg := range unread {
+//		handle(msg)
+//		_ = c.Ack(msg.ID)
+//	}
+//	err = c.Send(&mail.Message{To: "gastown/Toast", Subject: "Ping", Body: "Still there?"})
+//
+// Programs in other languages can use the same operations as JSON lines
+// over stdio with 'gt mail api' (see Serve).
+package mail
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/mail"
+)
+
+// Types shared with gt's mail router. See the gt mail docs for field
+// details.
+type (
+	Message     = mail.Message
+	Priority    = mail.Priority
+	MessageType = mail.MessageType
+)
+
+// Message priorities.
+const (
+	PriorityLow    = mail.PriorityLow
+	PriorityNormal = mail.PriorityNormal
+	PriorityHigh   = mail.PriorityHigh
+	PriorityUrgent = mail.PriorityUrgent
+)
+
+// Message types.
+const (
+	TypeTask         = mail.TypeTask
+	TypeScavenge     = mail.TypeScavenge
+	TypeNotification = mail.TypeNotification
+	TypeReply        = mail.TypeReply
+)
+
+// ErrMessageNotFound is returned for a message ID not in the inbox.
+var ErrMessageNotFound = mail.ErrMessageNotFound
+
+// Client reads one address's inbox and sends mail from that address.
+type Client struct {
+	address string
+	mailbox *mail.Mailbox
+	send    func(*Message) error
+}
+
+// Open opens the mail of address (e.g. "mayor/" or "gastown/Toast") in the
+// Gas Town workspace at townRoot.
+func Open(townRoot, address string) (*Client, error) {
+	if address == "" {
+		return nil, fmt.Errorf("address is required")
+	}
+	router := mail.NewRouterWithTownRoot(townRoot, townRoot)
+	mailbox, err := router.GetMailbox(address)
+	if err != nil {
+		return nil, fmt.Errorf("opening mailbox for %s: %w", address, err)
+	}
+	return &Client{address: address, mailbox: mailbox, send: router.Send}, nil
+}
+
+// Address returns the address the client reads and sends as.
+func (c *Client) Address() string {
+	return c.address
+}
+
+// List returns the messages in the inbox, or only the unread ones.
+func (c *Client) List(unreadOnly bool) ([]*Message, error) {
+	if unreadOnly {
+		return c.mailbox.ListUnread()
+	}
+	return c.mailbox.List()
+}
+
+// Read returns a message from the inbox. Like 'gt mail read', reading
+// doesn't mark the message read; Ack does.
+func (c *Client) Read(id string) (*Message, error) {
+	return c.mailbox.Get(id)
+}
+
+// Ack marks a message read, keeping it in the inbox (as 'gt mail ack').
+func (c *Client) Ack(id string) error {
+	return c.mailbox.MarkReadOnly(id)
+}
+
+// Send sends msg from the client's address. To is required; an empty
+// priority or type is normal priority notification (reply if ReplyTo is
+// set), and a reply joins the thread of the message it replies to.
+func (c *Client) Send(msg *Message) error {
+	if msg == nil || (msg.To == "" && msg.Queue == "" && msg.Channel == "") {
+		return fmt.Errorf("recipient (to) is required")
+	}
+
+	msg.From = c.address
+	msg.Priority = mail.ParsePriority(string(msg.Priority))
+	if msg.Type == "" && msg.ReplyTo != "" {
+		msg.Type = TypeReply
+	}
+	msg.Type = mail.ParseMessageType(string(msg.Type))
+	if msg.Timestamp.IsZero() {
+		msg.Timestamp = time.Now()
+	}
+	if msg.ThreadID == "" && msg.ReplyTo != "" {
+		if original, err := c.mailbox.Get(msg.ReplyTo); err == nil {
+			msg.ThreadID = original.ThreadID
+		}
+	}
+	if msg.ThreadID == "" {
+		msg.ThreadID = mail.NewThreadID()
+	}
+	return c.send(msg)
+}
@@ -0,0 +1,88 @@
+package mail
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/mail"
+)
+
+func TestServe(t *testing.T) {
+	mailbox := mail.NewMailbox(t.TempDir())
+	original := mail.NewMessage("gastown/Toast", "mayor/", "Merged", "gt-42 is in")
+	if err := mailbox.Append(original); err != nil {
+		t.Fatal(err)
+	}
+	var sent []*Message
+	c := &Client{address: "mayor/", mailbox: mailbox, send: func(msg *Message) error {
+		sent = append(sent, msg)
+		return nil
+	}}
+
+	in := strings.Join([]string{
+		`{"id":"1","op":"list","unread":true}`,
+		`{"id":"2","op":"read","message_id":"` + original.ID + `"}`,
+		``,
+		`{"id":"3","op":"ack","message_id":"` + original.ID + `"}`,
+		`{"id":"4","op":"list","unread":true}`,
+		`{"id":"5","op":"send","message":{"to":"gastown/Toast","subject":"Thanks","reply_to":"` + original.ID + `"}}`,
+		`{"id":"6","op":"read","message_id":"hq-missing"}`,
+		`{"id":"7","op":"fly"}`,
+		`not json`,
+	}, "\n")
+	var out bytes.Buffer
+	if err := Serve(c, strings.NewReader(in), &out); err != nil {
+		t.Fatalf("Serve: %v", err)
+	}
+
+	var responses []Response
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		var resp Response
+		if err := json.Unmarshal([]byte(line), &resp); err != nil {
+			t.Fatalf("response %q: %v", line, err)
+		}
+		responses = append(responses, resp)
+	}
+	if len(responses) != 8 {
+		t.Fatalf("got %d responses, want 8:\n%s", len(responses), out.String())
+	}
+
+	if r := responses[0]; !r.OK || r.ID != "1" || len(r.Messages) != 1 {
+		t.Errorf("list = %+v, want the one unread message", r)
+	}
+	if r := responses[1]; !r.OK || r.Message == nil || r.Message.Subject != "Merged" {
+		t.Errorf("read = %+v", r)
+	}
+	if r := responses[2]; !r.OK {
+		t.Errorf("ack = %+v", r)
+	}
+	if r := responses[3]; !r.OK || len(r.Messages) != 0 {
+		t.Errorf("list after ack = %+v, want no unread messages", r)
+	}
+
+	if r := responses[4]; !r.OK || len(sent) != 1 {
+		t.Fatalf("send = %+v (%d sent)", r, len(sent))
+	}
+	if msg := sent[0]; msg.From != "mayor/" || msg.Type != TypeReply || msg.Priority != PriorityNormal ||
+		msg.ThreadID != original.ThreadID || msg.Timestamp.IsZero() {
+		t.Errorf("sent message = %+v, want a normal reply from mayor/ in the original thread", msg)
+	}
+
+	for i, want := range map[int]string{5: "not found", 6: `unknown op "fly"`, 7: "invalid request"} {
+		if r := responses[i]; r.OK || !strings.Contains(r.Error, want) {
+			t.Errorf("response %d = %+v, want error containing %q", i, r, want)
+		}
+	}
+}
+
+func TestSendRequiresRecipient(t *testing.T) {
+	c := &Client{address: "mayor/", mailbox: mail.NewMailbox(t.TempDir()), send: func(*Message) error { return nil }}
+	if resp := c.Handle(Request{Op: OpSend, Message: &Message{Subject: "No one"}}); resp.OK {
+		t.Error("send without a recipient succeeded")
+	}
+	if resp := c.Handle(Request{Op: OpSend}); resp.OK {
+		t.Error("send without a message succeeded")
+	}
+}